@@ -5,6 +5,8 @@ import (
 
 	pb "go.viam.com/api/provisioning/v1"
 	"go.viam.com/utils/rpc"
+
+	rpb "go.viam.com/rdk/proto/rdk/provisioning/v1"
 )
 
 // ProvisioningInfo holds provisioning info.
@@ -42,11 +44,15 @@ type CloudConfig struct {
 
 // ProvisioningClient is a gRPC client for method calls to the Provisioning API.
 type ProvisioningClient struct {
-	client pb.ProvisioningServiceClient
+	client     pb.ProvisioningServiceClient
+	modeClient rpb.ProvisioningModeServiceClient
 }
 
 func newProvisioningClient(conn rpc.ClientConn) *ProvisioningClient {
-	return &ProvisioningClient{client: pb.NewProvisioningServiceClient(conn)}
+	return &ProvisioningClient{
+		client:     pb.NewProvisioningServiceClient(conn),
+		modeClient: rpb.NewProvisioningModeServiceClient(conn),
+	}
 }
 
 // GetSmartMachineStatus gets the status of the smart machine including networking.
@@ -76,6 +82,20 @@ func (c *ProvisioningClient) SetSmartMachineCredentials(ctx context.Context, clo
 	return err
 }
 
+// EnterProvisioning puts the smart machine back into provisioning mode, in which it runs a hotspot to be set up through.
+// It returns once provisioning mode has been asked for, before the hotspot is up. Only machines that set themselves
+// up, such as with go.viam.com/rdk/web/provisioning, support it.
+func (c *ProvisioningClient) EnterProvisioning(ctx context.Context) error {
+	_, err := c.modeClient.EnterProvisioning(ctx, &rpb.EnterProvisioningRequest{})
+	return err
+}
+
+// ExitProvisioning takes the smart machine out of provisioning mode with whatever it has been set up with.
+func (c *ProvisioningClient) ExitProvisioning(ctx context.Context) error {
+	_, err := c.client.ExitProvisioning(ctx, &pb.ExitProvisioningRequest{})
+	return err
+}
+
 // GetNetworkList gets the list of networks that are visible to the smart machine.
 func (c *ProvisioningClient) GetNetworkList(ctx context.Context) ([]*NetworkInfo, error) {
 	resp, err := c.client.GetNetworkList(ctx, &pb.GetNetworkListRequest{})
@@ -8,6 +8,7 @@ import (
 	"go.viam.com/test"
 	"google.golang.org/grpc"
 
+	rpb "go.viam.com/rdk/proto/rdk/provisioning/v1"
 	"go.viam.com/rdk/testutils/inject"
 )
 
@@ -68,7 +69,8 @@ func createProvisioningGrpcClient() *inject.ProvisioningServiceClient {
 
 func TestProvisioningClient(t *testing.T) {
 	grpcClient := createProvisioningGrpcClient()
-	client := ProvisioningClient{client: grpcClient}
+	modeClient := &inject.ProvisioningModeServiceClient{}
+	client := ProvisioningClient{client: grpcClient, modeClient: modeClient}
 
 	t.Run("GetSmartMachineStatus", func(t *testing.T) {
 		pbResponse := pb.GetSmartMachineStatusResponse{
@@ -116,6 +118,32 @@ func TestProvisioningClient(t *testing.T) {
 		test.That(t, err, test.ShouldBeNil)
 	})
 
+	t.Run("EnterProvisioning", func(t *testing.T) {
+		var called bool
+		modeClient.EnterProvisioningFunc = func(
+			ctx context.Context, in *rpb.EnterProvisioningRequest, opts ...grpc.CallOption,
+		) (*rpb.EnterProvisioningResponse, error) {
+			called = true
+			return &rpb.EnterProvisioningResponse{}, nil
+		}
+		err := client.EnterProvisioning(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, called, test.ShouldBeTrue)
+	})
+
+	t.Run("ExitProvisioning", func(t *testing.T) {
+		var called bool
+		grpcClient.ExitProvisioningFunc = func(
+			ctx context.Context, in *pb.ExitProvisioningRequest, opts ...grpc.CallOption,
+		) (*pb.ExitProvisioningResponse, error) {
+			called = true
+			return &pb.ExitProvisioningResponse{}, nil
+		}
+		err := client.ExitProvisioning(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, called, test.ShouldBeTrue)
+	})
+
 	t.Run("GetNetworkList", func(t *testing.T) {
 		expectedNetworks := []*NetworkInfo{&networkInfo}
 		grpcClient.GetNetworkListFunc = func(
@@ -3218,6 +3218,21 @@ This won't work unless you have an existing installation of our GitHub app on yo
 			UsageText: createUsageText("version", nil, false, false),
 			Action:    createCommandWithT[emptyArgs](VersionAction),
 		},
+		{
+			Name:  "lint-config",
+			Usage: "check a local machine config file for likely mistakes",
+			UsageText: createUsageText(
+				"lint-config", []string{generalFlagPath}, false, false,
+			),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     generalFlagPath,
+					Required: true,
+					Usage:    "path to the machine config file",
+				},
+			},
+			Action: createCommandWithT[lintConfigArgs](LintConfigAction),
+		},
 		{
 			Name:  "parse-ftdc",
 			Usage: "parse an ftdc file and open a REPL with extra options",
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+
+	// register the builtin models so that their attributes are converted and validated, which is
+	// how implicit dependencies and deprecations are found.
+	_ "go.viam.com/rdk/components/register"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	_ "go.viam.com/rdk/services/register"
+)
+
+type lintConfigArgs struct {
+	Path string
+}
+
+// LintConfigAction is the cli action to lint a local machine config file.
+func LintConfigAction(c *cli.Context, args lintConfigArgs) error {
+	cfg, err := config.ReadLocalConfig(c.Context, args.Path, logging.NewLogger("config"))
+	if err != nil {
+		return errors.Wrap(err, "failed to read config")
+	}
+	findings := config.Lint(cfg)
+	if len(findings) == 0 {
+		printf(c.App.Writer, "No problems found in %s", args.Path)
+		return nil
+	}
+	for _, f := range findings {
+		if f.Severity == resource.ConfigWarningSeverityWarning {
+			warningf(c.App.Writer, "%s", f.String())
+		} else {
+			infof(c.App.Writer, "%s", f.String())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/base"
+	"go.viam.com/rdk/components/motor"
+	"go.viam.com/rdk/resource"
+)
+
+func TestLintConfigAction(t *testing.T) {
+	// linting relies on the builtin models being registered in the CLI
+	for _, apiModel := range []resource.APIModel{
+		{API: base.API, Model: resource.DefaultModelFamily.WithModel("wheeled")},
+		{API: motor.API, Model: resource.DefaultModelFamily.WithModel("fake")},
+	} {
+		_, ok := resource.LookupRegistration(apiModel.API, apiModel.Model)
+		test.That(t, ok, test.ShouldBeTrue)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	test.That(t, os.WriteFile(path, []byte(`{
+		"components": [
+			{"name": "left", "api": "rdk:component:motor", "model": "fake"},
+			{"name": "right", "api": "rdk:component:motor", "model": "fake"},
+			{
+				"name": "base",
+				"api": "rdk:component:base",
+				"model": "wheeled",
+				"attributes": {"left": ["left"], "right": ["right"], "width_mm": 100, "wheel_circumference_mm": 100}
+			}
+		]
+	}`), 0o600), test.ShouldBeNil)
+
+	cCtx, _, out, _ := setup(nil, nil, nil, nil, "")
+	test.That(t, LintConfigAction(cCtx, lintConfigArgs{Path: path}), test.ShouldBeNil)
+	output := strings.Join(out.messages, "")
+	test.That(t, output, test.ShouldContainSubstring, `component "base"`)
+	// the motors are dependencies of the base through its attributes, which are only known with
+	// the wheeled base registered
+	test.That(t, output, test.ShouldNotContainSubstring, `component "left"`)
+	test.That(t, output, test.ShouldNotContainSubstring, `component "right"`)
+}
@@ -24,6 +24,7 @@ func init() {
 		RPCServiceServerConstructor: NewRPCServiceServer,
 		RPCServiceHandler:           pb.RegisterArmServiceHandlerFromEndpoint,
 		RPCServiceDesc:              &pb.ArmService_ServiceDesc,
+		StreamRPCServiceDesc:        &streamServiceDesc,
 		RPCClient:                   NewClientFromConn,
 	})
 
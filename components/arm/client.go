@@ -3,12 +3,20 @@ package arm
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"sync"
+	"time"
 
+	"github.com/pkg/errors"
 	commonpb "go.viam.com/api/common/v1"
 	pb "go.viam.com/api/component/arm/v1"
+	goutils "go.viam.com/utils"
 	"go.viam.com/utils/protoutils"
 	"go.viam.com/utils/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"go.viam.com/rdk/logging"
 	rprotoutils "go.viam.com/rdk/protoutils"
@@ -23,6 +31,7 @@ type client struct {
 	resource.TriviallyReconfigurable
 	resource.TriviallyCloseable
 	name   string
+	conn   rpc.ClientConn
 	client pb.ArmServiceClient
 	logger logging.Logger
 
@@ -42,6 +51,7 @@ func NewClientFromConn(
 	return &client{
 		Named:  name.PrependRemote(remoteName).AsNamed(),
 		name:   name.ShortName(),
+		conn:   conn,
 		client: pbClient,
 		logger: logger,
 	}, nil
@@ -155,6 +165,61 @@ func (c *client) JointPositions(ctx context.Context, extra map[string]interface{
 	return referenceframe.InputsFromJointPositions(m, resp.Positions)
 }
 
+// StreamJointState streams the state of the arm from the server. Servers that do not serve the
+// stream are polled instead.
+func (c *client) StreamJointState(ctx context.Context, opts StreamJointStateOptions) (<-chan JointState, error) {
+	req, err := jointStateRequest(c.name, opts)
+	if err != nil {
+		return nil, err
+	}
+	m, err := c.Kinematics(ctx)
+	if err != nil {
+		warnKinematicsUnsafe(ctx, c.logger, err)
+	}
+	stream, err := c.conn.NewStream(ctx, &streamServiceDesc.Streams[0], "/"+StreamServiceName+"/"+streamJointStateMethod)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(req); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	// the first state is sent right away, so an older server is found out before returning
+	resp := &structpb.Struct{}
+	if err := stream.RecvMsg(resp); err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return pollJointState(ctx, c, opts), nil
+		}
+		return nil, err
+	}
+
+	ch := make(chan JointState)
+	goutils.PanicCapturingGo(func() {
+		defer close(ch)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- jointStateFromResponse(resp, m):
+			}
+			resp = &structpb.Struct{}
+			if err := stream.RecvMsg(resp); err != nil {
+				if errors.Is(err, io.EOF) || ctx.Err() != nil {
+					return
+				}
+				select {
+				case <-ctx.Done():
+				case ch <- JointState{Time: time.Now(), Err: err}:
+				}
+				return
+			}
+		}
+	})
+	return ch, nil
+}
+
 func (c *client) Stop(ctx context.Context, extra map[string]interface{}) error {
 	ext, err := protoutils.StructToStructPb(extra)
 	if err != nil {
@@ -198,6 +263,40 @@ func (c *client) DoCommand(ctx context.Context, cmd map[string]interface{}) (map
 	return rprotoutils.DoFromResourceClient(ctx, c.client, c.name, cmd)
 }
 
+func (c *client) EnterFreeDrive(ctx context.Context, constraints FreeDriveConstraints, extra map[string]interface{}) error {
+	_, err := c.DoCommand(ctx, map[string]interface{}{EnterFreeDriveCommand: constraints.toCommand(), "extra": extra})
+	return err
+}
+
+func (c *client) ExitFreeDrive(ctx context.Context, extra map[string]interface{}) error {
+	_, err := c.DoCommand(ctx, map[string]interface{}{ExitFreeDriveCommand: true, "extra": extra})
+	return err
+}
+
+func (c *client) IsInFreeDrive(ctx context.Context) (bool, error) {
+	resp, err := c.DoCommand(ctx, map[string]interface{}{IsInFreeDriveCommand: true})
+	if err != nil {
+		return false, err
+	}
+	inFreeDrive, ok := resp[IsInFreeDriveCommand].(bool)
+	if !ok {
+		return false, fmt.Errorf("arm %q does not support free drive", c.name)
+	}
+	return inFreeDrive, nil
+}
+
+func (c *client) EngageBrakes(ctx context.Context, extra map[string]interface{}) error {
+	return resource.EngageBrakes(ctx, c, extra)
+}
+
+func (c *client) ReleaseBrakes(ctx context.Context, softStart resource.SoftStart, extra map[string]interface{}) error {
+	return resource.ReleaseBrakes(ctx, c, softStart, extra)
+}
+
+func (c *client) BrakesEngaged(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return resource.BrakesEngaged(ctx, c, extra)
+}
+
 func (c *client) IsMoving(ctx context.Context) (bool, error) {
 	resp, err := c.client.IsMoving(ctx, &pb.IsMovingRequest{Name: c.name})
 	if err != nil {
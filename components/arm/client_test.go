@@ -223,4 +223,50 @@ func TestClient(t *testing.T) {
 
 		test.That(t, conn.Close(), test.ShouldBeNil)
 	})
+
+	t.Run("stream joint state", func(t *testing.T) {
+		conn, err := viamgrpc.Dial(context.Background(), listener1.Addr().String(), logger)
+		test.That(t, err, test.ShouldBeNil)
+		defer conn.Close()
+		arm1Client, err := arm.NewClientFromConn(context.Background(), conn, "", arm.Named(testArmName), logger)
+		test.That(t, err, test.ShouldBeNil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		states, err := arm.StreamJointState(ctx, arm1Client, arm.StreamJointStateOptions{
+			Rate:  100,
+			Extra: map[string]interface{}{"foo": "StreamJointState"},
+		})
+		test.That(t, err, test.ShouldBeNil)
+		for i := 0; i < 3; i++ {
+			state := <-states
+			test.That(t, state.Err, test.ShouldBeNil)
+			test.That(t, state.Time.IsZero(), test.ShouldBeFalse)
+			test.That(t, state.JointPositions, test.ShouldHaveLength, len(jointPos1))
+			for j, input := range state.JointPositions {
+				test.That(t, input.Value, test.ShouldAlmostEqual, jointPos1[j].Value)
+			}
+			test.That(t, spatialmath.PoseAlmostEqual(state.EndPosition, pos1), test.ShouldBeTrue)
+		}
+		test.That(t, extraOptions, test.ShouldResemble, map[string]interface{}{"foo": "StreamJointState"})
+		cancel()
+		for range states {
+		}
+
+		// only the end position
+		ctx, cancel = context.WithCancel(context.Background())
+		defer cancel()
+		states, err = arm.StreamJointState(ctx, arm1Client, arm.StreamJointStateOptions{EndPosition: true})
+		test.That(t, err, test.ShouldBeNil)
+		state := <-states
+		test.That(t, state.Err, test.ShouldBeNil)
+		test.That(t, state.JointPositions, test.ShouldBeNil)
+		test.That(t, spatialmath.PoseAlmostEqual(state.EndPosition, pos1), test.ShouldBeTrue)
+
+		client, err := arm.NewClientFromConn(context.Background(), conn, "", arm.Named(missingArmName), logger)
+		test.That(t, err, test.ShouldBeNil)
+		_, err = arm.StreamJointState(ctx, client, arm.StreamJointStateOptions{})
+		test.That(t, err, test.ShouldNotBeNil)
+		_, err = arm.StreamJointState(ctx, arm1Client, arm.StreamJointStateOptions{Rate: -1})
+		test.That(t, err, test.ShouldNotBeNil)
+	})
 }
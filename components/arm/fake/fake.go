@@ -4,10 +4,13 @@ package fake
 import (
 	"context"
 	_ "embed"
+	"math"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
+	goutils "go.viam.com/utils"
 
 	"go.viam.com/rdk/components/arm"
 	"go.viam.com/rdk/logging"
@@ -25,8 +28,9 @@ var Model = resource.DefaultModelFamily.WithModel("fake")
 
 // Config is used for converting config attributes.
 type Config struct {
-	ArmModel      string `json:"arm-model,omitempty"`
-	ModelFilePath string `json:"model-path,omitempty"`
+	ArmModel      string                 `json:"arm-model,omitempty"`
+	ModelFilePath string                 `json:"model-path,omitempty"`
+	JointLimits   []arm.JointLimitConfig `json:"joint_limits,omitempty"`
 }
 
 // Known values that can be provided for the ArmModel field.
@@ -50,16 +54,27 @@ var xarm7JSON []byte
 
 // Validate ensures all parts of the config are valid.
 func (conf *Config) Validate(path string) ([]string, []string, error) {
-	var err error
+	var (
+		model referenceframe.Model
+		err   error
+	)
 	switch {
 	case conf.ArmModel != "" && conf.ModelFilePath != "":
 		err = errAttrCfgPopulation
 	case conf.ArmModel != "" && conf.ModelFilePath == "":
-		_, err = modelFromName(conf.ArmModel, "")
+		model, err = modelFromName(conf.ArmModel, "")
 	case conf.ArmModel == "" && conf.ModelFilePath != "":
-		_, err = referenceframe.KinematicModelFromFile(conf.ModelFilePath, "")
+		model, err = referenceframe.KinematicModelFromFile(conf.ModelFilePath, "")
+	default:
+		model, err = modelFromName(Model.Name, "")
 	}
-	return nil, nil, err
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := arm.ApplyJointLimits(model, conf.JointLimits); err != nil {
+		return nil, nil, resource.NewConfigValidationError(path, err)
+	}
+	return nil, nil, nil
 }
 
 func init() {
@@ -99,8 +114,11 @@ func buildModel(cfg resource.Config, newConf *Config) (referenceframe.Model, err
 		// if no arm model is specified, we return a fake arm with 1 dof and 0 spatial transformation
 		model, err = modelFromName(Model.Name, cfg.Name)
 	}
+	if err != nil {
+		return nil, err
+	}
 
-	return model, err
+	return arm.ApplyJointLimits(model, newConf.JointLimits)
 }
 
 // Arm is a fake arm that can simply read and set properties.
@@ -109,9 +127,17 @@ type Arm struct {
 	CloseCount int
 	logger     logging.Logger
 
-	mu     sync.RWMutex
-	joints []referenceframe.Input
-	model  referenceframe.Model
+	mu             sync.RWMutex
+	joints         []referenceframe.Input
+	model          referenceframe.Model
+	jointLimits    []arm.JointLimitConfig
+	freeDrive      bool
+	freeDriveTimer *time.Timer
+	// freeDriveSession counts the times free drive has been entered, so that the timer of an
+	// earlier session cannot end a later one.
+	freeDriveSession int
+	brakesEngaged    bool
+	softStart        resource.SoftStart
 }
 
 // Reconfigure atomically reconfigures this arm in place based on the new config.
@@ -137,6 +163,7 @@ func (a *Arm) Reconfigure(ctx context.Context, deps resource.Dependencies, conf
 	defer a.mu.Unlock()
 	a.joints = referenceframe.FloatsToInputs(make([]float64, dof))
 	a.model = model
+	a.jointLimits = newConf.JointLimits
 
 	return nil
 }
@@ -155,54 +182,96 @@ func (a *Arm) EndPosition(ctx context.Context, extra map[string]interface{}) (sp
 // MoveToPosition sets the position.
 func (a *Arm) MoveToPosition(ctx context.Context, pose spatialmath.Pose, extra map[string]interface{}) error {
 	a.mu.RLock()
-	defer a.mu.RUnlock()
-
 	model := a.model
-	_, err := model.Transform(a.joints)
+	current := append([]referenceframe.Input(nil), a.joints...)
+	a.mu.RUnlock()
+
+	_, err := model.Transform(current)
 	if err != nil && strings.Contains(err.Error(), referenceframe.OOBErrString) {
 		return errors.New("cannot move arm: " + err.Error())
 	} else if err != nil {
 		return err
 	}
 
-	plan, err := motionplan.GetGlobal().PlanFrameMotion(ctx, a.logger, pose, model, a.joints, nil, nil)
+	plan, err := motionplan.GetGlobal().PlanFrameMotion(ctx, a.logger, pose, model, current, nil, nil)
 	if err != nil {
 		return err
 	}
-	copy(a.joints, plan[len(plan)-1])
-	return nil
+	return a.moveTo(ctx, plan[len(plan)-1], nil)
 }
 
 // MoveToJointPositions sets the joints.
 func (a *Arm) MoveToJointPositions(ctx context.Context, joints []referenceframe.Input, extra map[string]interface{}) error {
-	if err := arm.CheckDesiredJointPositions(ctx, a, joints); err != nil {
-		return err
-	}
-	a.mu.RLock()
-	defer a.mu.RUnlock()
-	_, err := a.model.Transform(joints)
-	if err != nil {
-		return err
-	}
-	copy(a.joints, joints)
-	return nil
+	return a.MoveThroughJointPositions(ctx, [][]referenceframe.Input{joints}, nil, extra)
 }
 
 // MoveThroughJointPositions moves the fake arm through the given inputs.
 func (a *Arm) MoveThroughJointPositions(
 	ctx context.Context,
 	positions [][]referenceframe.Input,
-	_ *arm.MoveOptions,
+	opts *arm.MoveOptions,
 	_ map[string]interface{},
 ) error {
 	for _, goal := range positions {
-		if err := a.MoveToJointPositions(ctx, goal, nil); err != nil {
+		if err := arm.CheckDesiredJointPositions(ctx, a, goal); err != nil {
+			return err
+		}
+		a.mu.RLock()
+		_, err := a.model.Transform(goal)
+		a.mu.RUnlock()
+		if err != nil {
+			return err
+		}
+		if err := a.moveTo(ctx, goal, opts); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// moveTo moves the joints of the fake arm to goal, taking as long as an arm limited by opts and the
+// configured joint limits would.
+func (a *Arm) moveTo(ctx context.Context, goal []referenceframe.Input, opts *arm.MoveOptions) error {
+	if err := a.releaseBrakesToMove(ctx); err != nil {
+		return err
+	}
+	a.mu.RLock()
+	opts = arm.ScaleMoveOptions(opts, a.jointLimits)
+	var maxDelta float64
+	for i, in := range goal {
+		if i < len(a.joints) {
+			maxDelta = math.Max(maxDelta, math.Abs(in.Value-a.joints[i].Value))
+		}
+	}
+	a.mu.RUnlock()
+
+	if d := moveDuration(maxDelta, opts); d > 0 && !goutils.SelectContextOrWait(ctx, d) {
+		return ctx.Err()
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	copy(a.joints, goal)
+	return nil
+}
+
+// moveDuration returns how long moving a joint by delta radians takes when it starts and ends at rest,
+// accelerating at opts.MaxAccRads up to opts.MaxVelRads. It is zero if neither is limited.
+func moveDuration(delta float64, opts *arm.MoveOptions) time.Duration {
+	vel, acc := opts.MaxVelRads, opts.MaxAccRads
+	var secs float64
+	switch {
+	case vel > 0 && acc > 0 && delta > vel*vel/acc:
+		// accelerates to full speed, cruises, then decelerates
+		secs = delta/vel + vel/acc
+	case acc > 0:
+		// accelerates for half the way and decelerates for the rest
+		secs = 2 * math.Sqrt(delta/acc)
+	case vel > 0:
+		secs = delta / vel
+	}
+	return time.Duration(secs * float64(time.Second))
+}
+
 // JointPositions returns joints.
 func (a *Arm) JointPositions(ctx context.Context, extra map[string]interface{}) ([]referenceframe.Input, error) {
 	a.mu.RLock()
@@ -210,11 +279,104 @@ func (a *Arm) JointPositions(ctx context.Context, extra map[string]interface{})
 	return a.joints, nil
 }
 
-// Stop doesn't do anything for a fake arm.
+// Stop exits free drive and engages the brakes, and otherwise doesn't do anything for a fake arm.
 func (a *Arm) Stop(ctx context.Context, extra map[string]interface{}) error {
+	if err := a.ExitFreeDrive(ctx, extra); err != nil {
+		return err
+	}
+	return a.EngageBrakes(ctx, extra)
+}
+
+// EngageBrakes marks the fake arm's brakes as engaged. Moving the fake arm releases them again.
+func (a *Arm) EngageBrakes(ctx context.Context, extra map[string]interface{}) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.brakesEngaged = true
 	return nil
 }
 
+// ReleaseBrakes waits out the torque ramp of the soft start, if the brakes are engaged, and then
+// marks them as released, remembering the soft start for when moving releases them.
+func (a *Arm) ReleaseBrakes(ctx context.Context, softStart resource.SoftStart, extra map[string]interface{}) error {
+	a.mu.RLock()
+	engaged := a.brakesEngaged
+	a.mu.RUnlock()
+	if engaged && softStart.TorqueRampDuration > 0 && !goutils.SelectContextOrWait(ctx, softStart.TorqueRampDuration) {
+		return ctx.Err()
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.brakesEngaged = false
+	a.softStart = softStart
+	return nil
+}
+
+// releaseBrakesToMove releases the brakes with the most recent soft start if they are engaged, as
+// an arm with brakes does before it moves.
+func (a *Arm) releaseBrakesToMove(ctx context.Context) error {
+	a.mu.RLock()
+	engaged, softStart := a.brakesEngaged, a.softStart
+	a.mu.RUnlock()
+	if !engaged {
+		return nil
+	}
+	return a.ReleaseBrakes(ctx, softStart, nil)
+}
+
+// BrakesEngaged returns whether the fake arm's brakes are engaged.
+func (a *Arm) BrakesEngaged(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.brakesEngaged, nil
+}
+
+// EnterFreeDrive puts the fake arm in free drive, leaving it after constraints.MaxDuration if set.
+func (a *Arm) EnterFreeDrive(ctx context.Context, constraints arm.FreeDriveConstraints, extra map[string]interface{}) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.stopFreeDriveTimer()
+	a.freeDrive = true
+	a.freeDriveSession++
+	if constraints.MaxDuration > 0 {
+		session := a.freeDriveSession
+		a.freeDriveTimer = time.AfterFunc(constraints.MaxDuration, func() {
+			a.mu.Lock()
+			defer a.mu.Unlock()
+			// the timer may have fired while a new free drive session was being entered
+			if a.freeDriveSession != session {
+				return
+			}
+			a.stopFreeDriveTimer()
+			a.freeDrive = false
+		})
+	}
+	return nil
+}
+
+// ExitFreeDrive takes the fake arm out of free drive.
+func (a *Arm) ExitFreeDrive(ctx context.Context, extra map[string]interface{}) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.stopFreeDriveTimer()
+	a.freeDrive = false
+	return nil
+}
+
+// IsInFreeDrive returns whether the fake arm is in free drive.
+func (a *Arm) IsInFreeDrive(ctx context.Context) (bool, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.freeDrive, nil
+}
+
+// stopFreeDriveTimer must be called with mu held.
+func (a *Arm) stopFreeDriveTimer() {
+	if a.freeDriveTimer != nil {
+		a.freeDriveTimer.Stop()
+		a.freeDriveTimer = nil
+	}
+}
+
 // IsMoving is always false for a fake arm.
 func (a *Arm) IsMoving(ctx context.Context) (bool, error) {
 	return false, nil
@@ -243,6 +405,7 @@ func (a *Arm) GoToInputs(ctx context.Context, inputSteps ...[]referenceframe.Inp
 func (a *Arm) Close(ctx context.Context) error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
+	a.stopFreeDriveTimer()
 	a.CloseCount++
 	return nil
 }
@@ -4,12 +4,16 @@ import (
 	"context"
 	"math"
 	"testing"
+	"time"
 
 	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
 
+	"go.viam.com/rdk/components/arm"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/utils"
 )
 
 func TestReconfigure(t *testing.T) {
@@ -112,3 +116,126 @@ func TestJointPositions(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, sampleInputs, test.ShouldResemble, inputs)
 }
+
+func TestJointLimits(t *testing.T) {
+	ctx := context.Background()
+	minDeg, maxDeg := -10.0, 10.0
+	armConf := &Config{
+		ArmModel:    xArm6Model,
+		JointLimits: []arm.JointLimitConfig{{MinDeg: &minDeg, MaxDeg: &maxDeg}},
+	}
+	_, _, err := armConf.Validate("")
+	test.That(t, err, test.ShouldBeNil)
+
+	a, err := NewArm(ctx, nil, resource.Config{Name: "testArm", ConvertedAttributes: armConf}, logging.NewTestLogger(t))
+	test.That(t, err, test.ShouldBeNil)
+
+	model, err := a.Kinematics(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, model.DoF()[0].Min, test.ShouldAlmostEqual, utils.DegToRad(minDeg))
+	test.That(t, model.DoF()[0].Max, test.ShouldAlmostEqual, utils.DegToRad(maxDeg))
+
+	joints := make([]referenceframe.Input, len(model.DoF()))
+	joints[0] = referenceframe.Input{Value: utils.DegToRad(5)}
+	test.That(t, a.MoveToJointPositions(ctx, joints, nil), test.ShouldBeNil)
+	joints[0] = referenceframe.Input{Value: utils.DegToRad(20)}
+	err = a.MoveToJointPositions(ctx, joints, nil)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "needs to be within range")
+
+	// limits may only be tightened
+	maxDeg = 400
+	_, _, err = armConf.Validate("")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "must be within the model limits")
+
+	opts := arm.ScaleMoveOptions(&arm.MoveOptions{MaxVelRads: 1}, []arm.JointLimitConfig{
+		{MaxVelDegsPerSec: 10},
+		{MaxVelDegsPerSec: 20, MaxAccDegsPerSec2: 30},
+	})
+	test.That(t, opts.MaxVelRads, test.ShouldAlmostEqual, utils.DegToRad(10))
+	test.That(t, opts.MaxAccRads, test.ShouldAlmostEqual, utils.DegToRad(30))
+
+	t.Run("prismatic", func(t *testing.T) {
+		model, err := referenceframe.UnmarshalModelJSON([]byte(`{
+			"name": "slider",
+			"links": [{"id": "carriage", "parent": "rail"}],
+			"joints": [
+				{"id": "waist", "type": "revolute", "parent": "world", "axis": {"z": 1}, "min": -90, "max": 90},
+				{"id": "rail", "type": "prismatic", "parent": "waist", "axis": {"x": 1}, "min": 0, "max": 500}
+			]
+		}`), "slider")
+		test.That(t, err, test.ShouldBeNil)
+
+		limited, err := arm.ApplyJointLimits(model, []arm.JointLimitConfig{{MinDeg: &minDeg}, {MaxVelDegsPerSec: 10}})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, limited.DoF()[0].Min, test.ShouldAlmostEqual, utils.DegToRad(minDeg))
+		test.That(t, limited.DoF()[1], test.ShouldResemble, referenceframe.Limit{Min: 0, Max: 500})
+
+		maxDeg := 10.0
+		_, err = arm.ApplyJointLimits(model, []arm.JointLimitConfig{{}, {MaxDeg: &maxDeg}})
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "joint 1 is prismatic")
+	})
+
+	t.Run("velocity", func(t *testing.T) {
+		armConf := &Config{
+			ArmModel:    xArm6Model,
+			JointLimits: []arm.JointLimitConfig{{MaxVelDegsPerSec: 100}},
+		}
+		a, err := NewArm(ctx, nil, resource.Config{Name: "testArm", ConvertedAttributes: armConf}, logging.NewTestLogger(t))
+		test.That(t, err, test.ShouldBeNil)
+		joints := make([]referenceframe.Input, 6)
+		joints[1] = referenceframe.Input{Value: utils.DegToRad(20)}
+
+		// moving 20 degrees at 100 degrees per second takes at least 200ms
+		start := time.Now()
+		test.That(t, a.MoveToJointPositions(ctx, joints, nil), test.ShouldBeNil)
+		test.That(t, time.Since(start), test.ShouldBeGreaterThanOrEqualTo, 200*time.Millisecond)
+		current, err := a.JointPositions(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, current[1].Value, test.ShouldAlmostEqual, utils.DegToRad(20))
+
+		// slower move options are kept, faster ones are limited
+		joints[1] = referenceframe.Input{}
+		start = time.Now()
+		err = a.MoveThroughJointPositions(ctx, [][]referenceframe.Input{joints}, &arm.MoveOptions{MaxVelRads: 100}, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, time.Since(start), test.ShouldBeGreaterThanOrEqualTo, 200*time.Millisecond)
+
+		// a limited move can be cancelled before the arm gets there
+		cancelCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		joints[1] = referenceframe.Input{Value: utils.DegToRad(20)}
+		err = a.MoveToJointPositions(cancelCtx, joints, nil)
+		test.That(t, err, test.ShouldBeError, context.DeadlineExceeded)
+		current, err = a.JointPositions(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, current[1].Value, test.ShouldAlmostEqual, 0)
+	})
+}
+
+func TestFreeDrive(t *testing.T) {
+	ctx := context.Background()
+	a, err := NewArm(ctx, nil, resource.Config{Name: "testArm", ConvertedAttributes: &Config{}}, logging.NewTestLogger(t))
+	test.That(t, err, test.ShouldBeNil)
+	defer a.Close(ctx)
+	freeDriver, ok := a.(arm.FreeDriver)
+	test.That(t, ok, test.ShouldBeTrue)
+
+	test.That(t, freeDriver.EnterFreeDrive(ctx, arm.FreeDriveConstraints{}, nil), test.ShouldBeNil)
+	inFreeDrive, err := freeDriver.IsInFreeDrive(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, inFreeDrive, test.ShouldBeTrue)
+	test.That(t, a.Stop(ctx, nil), test.ShouldBeNil)
+	inFreeDrive, err = freeDriver.IsInFreeDrive(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, inFreeDrive, test.ShouldBeFalse)
+
+	test.That(t, freeDriver.EnterFreeDrive(ctx, arm.FreeDriveConstraints{MaxDuration: time.Millisecond}, nil), test.ShouldBeNil)
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		inFreeDrive, err := freeDriver.IsInFreeDrive(ctx)
+		test.That(tb, err, test.ShouldBeNil)
+		test.That(tb, inFreeDrive, test.ShouldBeFalse)
+	})
+}
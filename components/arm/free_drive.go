@@ -0,0 +1,113 @@
+package arm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.viam.com/rdk/session"
+	"go.viam.com/rdk/utils"
+)
+
+// DoCommand keys used to carry free drive requests over the arm API.
+const (
+	EnterFreeDriveCommand = "enter_free_drive"
+	ExitFreeDriveCommand  = "exit_free_drive"
+	IsInFreeDriveCommand  = "is_in_free_drive"
+)
+
+// FreeDriveConstraints limit how an arm may be moved by hand while in free drive.
+// Zero values leave the arm's own limits in place.
+type FreeDriveConstraints struct {
+	// MaxJointVelDegsPerSec is the fastest any joint may be moved. Arms should brake
+	// or exit free drive when it is exceeded.
+	MaxJointVelDegsPerSec float64
+	// MaxDuration is how long the arm may stay in free drive before exiting it on its own.
+	MaxDuration time.Duration
+}
+
+// A FreeDriver is an arm that can be put into free drive (also known as hand guiding),
+// where its joints are compliant so that it can be moved by hand.
+//
+// Stop must exit free drive. Entering free drive through the arm API safety monitors
+// the arm, so that the arm is stopped, and therefore leaves free drive, when the session
+// that entered it ends.
+type FreeDriver interface {
+	// EnterFreeDrive puts the arm into free drive subject to the given constraints.
+	EnterFreeDrive(ctx context.Context, constraints FreeDriveConstraints, extra map[string]interface{}) error
+
+	// ExitFreeDrive takes the arm out of free drive, holding it at its current position.
+	ExitFreeDrive(ctx context.Context, extra map[string]interface{}) error
+
+	// IsInFreeDrive returns whether the arm is in free drive.
+	IsInFreeDrive(ctx context.Context) (bool, error)
+}
+
+func (c FreeDriveConstraints) toCommand() map[string]interface{} {
+	return map[string]interface{}{
+		"max_joint_vel_degs_per_sec": c.MaxJointVelDegsPerSec,
+		"max_duration_sec":           c.MaxDuration.Seconds(),
+	}
+}
+
+func freeDriveConstraintsFromCommand(cmd interface{}) (FreeDriveConstraints, error) {
+	var constraints FreeDriveConstraints
+	if cmd == nil {
+		return constraints, nil
+	}
+	fields, ok := cmd.(map[string]interface{})
+	if !ok {
+		return constraints, fmt.Errorf("%s must be an object, got %T", EnterFreeDriveCommand, cmd)
+	}
+	r := utils.NewAttributeReader(fields)
+	maxVel := utils.Optional(r, "max_joint_vel_degs_per_sec", 0.)
+	maxDuration := utils.Optional(r, "max_duration_sec", 0.)
+	if err := r.Err(); err != nil {
+		return constraints, fmt.Errorf("invalid %s: %w", EnterFreeDriveCommand, err)
+	}
+	if maxVel < 0 || maxDuration < 0 {
+		return constraints, fmt.Errorf("%s limits must not be negative", EnterFreeDriveCommand)
+	}
+	constraints.MaxJointVelDegsPerSec = maxVel
+	constraints.MaxDuration = time.Duration(maxDuration * float64(time.Second))
+	return constraints, nil
+}
+
+// doFreeDriveCommand handles a free drive DoCommand on behalf of an arm that supports free drive.
+// It reports false if cmd is not a free drive command.
+func doFreeDriveCommand(
+	ctx context.Context,
+	a Arm,
+	cmd map[string]interface{},
+) (map[string]interface{}, bool, error) {
+	freeDriver, ok := a.(FreeDriver)
+	if !ok {
+		return nil, false, nil
+	}
+	if enter, ok := cmd[EnterFreeDriveCommand]; ok {
+		constraints, err := freeDriveConstraintsFromCommand(enter)
+		if err != nil {
+			return nil, true, err
+		}
+		// Monitor the arm before entering free drive so that it cannot be left
+		// compliant by a client that went away.
+		session.SafetyMonitor(ctx, a)
+		return map[string]interface{}{}, true, freeDriver.EnterFreeDrive(ctx, constraints, extraFromCommand(cmd))
+	}
+	if _, ok := cmd[ExitFreeDriveCommand]; ok {
+		return map[string]interface{}{}, true, freeDriver.ExitFreeDrive(ctx, extraFromCommand(cmd))
+	}
+	if _, ok := cmd[IsInFreeDriveCommand]; ok {
+		inFreeDrive, err := freeDriver.IsInFreeDrive(ctx)
+		if err != nil {
+			return nil, true, err
+		}
+		return map[string]interface{}{IsInFreeDriveCommand: inFreeDrive}, true, nil
+	}
+	return nil, false, nil
+}
+
+func extraFromCommand(cmd map[string]interface{}) map[string]interface{} {
+	extra, _ := cmd["extra"].(map[string]interface{})
+	return extra
+}
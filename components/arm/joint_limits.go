@@ -0,0 +1,93 @@
+package arm
+
+import (
+	"fmt"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/utils"
+)
+
+// JointLimitConfig overrides the limits of a single joint of an arm. Overrides may only be tighter
+// than the limits in the arm's kinematics. Unset fields keep the kinematics limit. Position limits are
+// in degrees, so they may only be set on revolute joints.
+type JointLimitConfig struct {
+	MinDeg            *float64 `json:"min_deg,omitempty"`
+	MaxDeg            *float64 `json:"max_deg,omitempty"`
+	MaxVelDegsPerSec  float64  `json:"max_vel_degs_per_sec,omitempty"`
+	MaxAccDegsPerSec2 float64  `json:"max_acc_degs_per_sec2,omitempty"`
+}
+
+// ValidateJointLimits checks that the joint limit overrides are consistent on their own. Whether they
+// fit within the arm's kinematics is checked by ApplyJointLimits.
+func ValidateJointLimits(limits []JointLimitConfig) error {
+	for i, l := range limits {
+		if l.MinDeg != nil && l.MaxDeg != nil && *l.MinDeg > *l.MaxDeg {
+			return fmt.Errorf("joint_limits[%d] min_deg %v is greater than max_deg %v", i, *l.MinDeg, *l.MaxDeg)
+		}
+		if l.MaxVelDegsPerSec < 0 {
+			return fmt.Errorf("joint_limits[%d] max_vel_degs_per_sec cannot be negative", i)
+		}
+		if l.MaxAccDegsPerSec2 < 0 {
+			return fmt.Errorf("joint_limits[%d] max_acc_degs_per_sec2 cannot be negative", i)
+		}
+	}
+	return nil
+}
+
+// ApplyJointLimits returns a copy of the model with the position limits of limits applied, so that
+// they are enforced both by motion planning and by CheckDesiredJointPositions.
+func ApplyJointLimits(model referenceframe.Model, limits []JointLimitConfig) (referenceframe.Model, error) {
+	if len(limits) == 0 {
+		return model, nil
+	}
+	if err := ValidateJointLimits(limits); err != nil {
+		return nil, err
+	}
+	dof := model.DoF()
+	if len(limits) > len(dof) {
+		return nil, fmt.Errorf("got joint_limits for %d joints but the arm only has %d", len(limits), len(dof))
+	}
+	jointTypes, err := referenceframe.JointTypes(model)
+	if err != nil {
+		return nil, err
+	}
+	newLimits := make([]referenceframe.Limit, len(dof))
+	copy(newLimits, dof)
+	for i, l := range limits {
+		if (l.MinDeg != nil || l.MaxDeg != nil) && jointTypes[i] != referenceframe.RevoluteJoint {
+			return nil, fmt.Errorf("joint_limits[%d] sets limits in degrees but joint %d is %s", i, i, jointTypes[i])
+		}
+		if l.MinDeg != nil {
+			newLimits[i].Min = utils.DegToRad(*l.MinDeg)
+		}
+		if l.MaxDeg != nil {
+			newLimits[i].Max = utils.DegToRad(*l.MaxDeg)
+		}
+	}
+	return referenceframe.ModelWithJointLimits(model, newLimits)
+}
+
+// ScaleMoveOptions caps the velocity and acceleration of opts to the lowest per-joint limit in limits,
+// since a single move is limited by its slowest joint. opts may be nil, in which case only the limits
+// apply.
+func ScaleMoveOptions(opts *MoveOptions, limits []JointLimitConfig) *MoveOptions {
+	scaled := MoveOptions{}
+	if opts != nil {
+		scaled = *opts
+	}
+	for _, l := range limits {
+		if l.MaxVelDegsPerSec != 0 {
+			maxVel := utils.DegToRad(l.MaxVelDegsPerSec)
+			if scaled.MaxVelRads == 0 || scaled.MaxVelRads > maxVel {
+				scaled.MaxVelRads = maxVel
+			}
+		}
+		if l.MaxAccDegsPerSec2 != 0 {
+			maxAcc := utils.DegToRad(l.MaxAccDegsPerSec2)
+			if scaled.MaxAccRads == 0 || scaled.MaxAccRads > maxAcc {
+				scaled.MaxAccRads = maxAcc
+			}
+		}
+	}
+	return &scaled
+}
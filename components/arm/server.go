@@ -7,11 +7,15 @@ import (
 
 	commonpb "go.viam.com/api/common/v1"
 	pb "go.viam.com/api/component/arm/v1"
+	vprotoutils "go.viam.com/utils/protoutils"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/protoutils"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/session"
 	"go.viam.com/rdk/spatialmath"
 )
 
@@ -204,7 +208,35 @@ func (s *serviceServer) GetKinematics(ctx context.Context, req *commonpb.GetKine
 	return referenceframe.KinematicModelToProtobuf(model), nil
 }
 
-// DoCommand receives arbitrary commands.
+// StreamJointState sends the state of an arm at the requested rate until the client goes away.
+func (s *serviceServer) StreamJointState(req *structpb.Struct, stream grpc.ServerStream) error {
+	name, opts := jointStateOptionsFromRequest(req)
+	arm, err := s.coll.Resource(name)
+	if err != nil {
+		return err
+	}
+	// safe to ignore error because conversion function below can handle nil values and warning messages are logged from client
+	//nolint:errcheck
+	m, _ := arm.Kinematics(stream.Context())
+	states, err := StreamJointState(stream.Context(), arm, opts)
+	if err != nil {
+		return err
+	}
+	for state := range states {
+		resp, err := jointStateToResponse(state, m)
+		if err != nil {
+			return err
+		}
+		if err := stream.SendMsg(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DoCommand receives arbitrary commands. Free drive and brake commands are handled here rather than by
+// the arm's DoCommand so that the arm is safety monitored by the session that put it in free drive or
+// released its brakes.
 func (s *serviceServer) DoCommand(ctx context.Context,
 	req *commonpb.DoCommandRequest,
 ) (*commonpb.DoCommandResponse, error) {
@@ -212,5 +244,20 @@ func (s *serviceServer) DoCommand(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	cmd := req.GetCommand().AsMap()
+	resp, handled, err := doFreeDriveCommand(ctx, arm, cmd)
+	if !handled {
+		resp, handled, err = resource.DoBrakeCommand(ctx, arm, cmd, session.SafetyMonitor)
+	}
+	if handled {
+		if err != nil {
+			return nil, err
+		}
+		pbRes, err := vprotoutils.StructToStructPb(resp)
+		if err != nil {
+			return nil, err
+		}
+		return &commonpb.DoCommandResponse{Result: pbRes}, nil
+	}
 	return protoutils.DoFromResourceServer(ctx, arm, req)
 }
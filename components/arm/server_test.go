@@ -12,6 +12,8 @@ import (
 	"go.viam.com/utils/protoutils"
 
 	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/arm/fake"
+	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/spatialmath"
@@ -272,3 +274,53 @@ func TestServer(t *testing.T) {
 		test.That(t, err.Error(), test.ShouldContainSubstring, errStopUnimplemented.Error())
 	})
 }
+
+func TestServerFreeDrive(t *testing.T) {
+	ctx := context.Background()
+	freeDriveArm, err := fake.NewArm(ctx, nil, resource.Config{Name: testArmName, ConvertedAttributes: &fake.Config{}},
+		logging.NewTestLogger(t))
+	test.That(t, err, test.ShouldBeNil)
+	var doCommand map[string]interface{}
+	injectArm := &inject.Arm{}
+	injectArm.DoFunc = func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+		doCommand = cmd
+		return nil, errors.New("unknown command")
+	}
+	armSvc, err := resource.NewAPIResourceCollection(arm.API, map[resource.Name]arm.Arm{
+		arm.Named(testArmName): freeDriveArm,
+		arm.Named(failArmName): injectArm,
+	})
+	test.That(t, err, test.ShouldBeNil)
+	armServer := arm.NewRPCServiceServer(armSvc).(pb.ArmServiceServer)
+
+	do := func(name string, cmd map[string]interface{}) (map[string]interface{}, error) {
+		pbCmd, err := protoutils.StructToStructPb(cmd)
+		test.That(t, err, test.ShouldBeNil)
+		resp, err := armServer.DoCommand(ctx, &commonpb.DoCommandRequest{Name: name, Command: pbCmd})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Result.AsMap(), nil
+	}
+
+	resp, err := do(testArmName, map[string]interface{}{arm.EnterFreeDriveCommand: map[string]interface{}{"max_duration_sec": 60}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp, test.ShouldBeEmpty)
+	resp, err = do(testArmName, map[string]interface{}{arm.IsInFreeDriveCommand: true})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp, test.ShouldResemble, map[string]interface{}{arm.IsInFreeDriveCommand: true})
+
+	// stopping the arm, as happens when the session that entered free drive ends, exits it
+	test.That(t, freeDriveArm.Stop(ctx, nil), test.ShouldBeNil)
+	inFreeDrive, err := freeDriveArm.(arm.FreeDriver).IsInFreeDrive(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, inFreeDrive, test.ShouldBeFalse)
+
+	_, err = do(testArmName, map[string]interface{}{arm.EnterFreeDriveCommand: map[string]interface{}{"max_duration_sec": -1}})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// arms that do not support free drive get the command themselves
+	_, err = do(failArmName, map[string]interface{}{arm.EnterFreeDriveCommand: map[string]interface{}{}})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, doCommand, test.ShouldContainKey, arm.EnterFreeDriveCommand)
+}
@@ -0,0 +1,226 @@
+package arm
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	commonpb "go.viam.com/api/common/v1"
+	pb "go.viam.com/api/component/arm/v1"
+	"go.viam.com/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// defaultStreamRate is how many states per second StreamJointState sends when no rate is given.
+const defaultStreamRate = 10
+
+// StreamServiceName is the name of the rdk-owned gRPC service that arm states are streamed on. It
+// is not part of arm.proto, so its requests and responses are structs.
+const StreamServiceName = "rdk.component.arm.v1.ArmStreamService"
+
+// streamJointStateMethod is the server streaming method of the stream service.
+const streamJointStateMethod = "StreamJointState"
+
+// jointStateStreamServer serves the stream service.
+type jointStateStreamServer interface {
+	StreamJointState(req *structpb.Struct, stream grpc.ServerStream) error
+}
+
+// streamServiceDesc describes the stream service, which the arm service server also serves.
+var streamServiceDesc = grpc.ServiceDesc{
+	ServiceName: StreamServiceName,
+	HandlerType: (*jointStateStreamServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    streamJointStateMethod,
+			Handler:       streamJointStateHandler,
+			ServerStreams: true,
+		},
+	},
+}
+
+// StreamJointStateOptions configures StreamJointState. If neither JointPositions nor
+// EndPosition is set, both are sent.
+type StreamJointStateOptions struct {
+	// Rate is how many states per second are read and sent. Defaults to 10.
+	Rate float64
+	// JointPositions is whether to send the joint positions of the arm.
+	JointPositions bool
+	// EndPosition is whether to send the end position of the arm.
+	EndPosition bool
+
+	Extra map[string]interface{}
+}
+
+// A JointState is the state of an arm sent by StreamJointState, or the error encountered
+// getting it. Only the positions that were asked for are set.
+type JointState struct {
+	Time           time.Time
+	JointPositions []referenceframe.Input
+	EndPosition    spatialmath.Pose
+	Err            error
+}
+
+// A JointStateStreamer is an arm that can stream its own joint state, such as an arm
+// client that streams it from the server rather than polling.
+type JointStateStreamer interface {
+	StreamJointState(ctx context.Context, opts StreamJointStateOptions) (<-chan JointState, error)
+}
+
+// StreamJointState sends the joint positions and end position of the arm at the given rate,
+// so that callers do not need to poll the arm themselves. Errors are sent as states rather than
+// ending the stream. The returned channel is closed once ctx is done.
+func StreamJointState(ctx context.Context, a Arm, opts StreamJointStateOptions) (<-chan JointState, error) {
+	if opts.Rate < 0 {
+		return nil, errors.New("rate cannot be negative")
+	}
+	if opts.Rate == 0 {
+		opts.Rate = defaultStreamRate
+	}
+	if !opts.JointPositions && !opts.EndPosition {
+		opts.JointPositions = true
+		opts.EndPosition = true
+	}
+	if streamer, ok := a.(JointStateStreamer); ok {
+		return streamer.StreamJointState(ctx, opts)
+	}
+	return pollJointState(ctx, a, opts), nil
+}
+
+// pollJointState reads the state of the arm at the rate of opts, which must already have
+// its defaults set.
+func pollJointState(ctx context.Context, a Arm, opts StreamJointStateOptions) <-chan JointState {
+	interval := time.Duration(float64(time.Second) / opts.Rate)
+
+	ch := make(chan JointState)
+	utils.PanicCapturingGo(func() {
+		defer close(ch)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			var state JointState
+			if opts.JointPositions {
+				state.JointPositions, state.Err = a.JointPositions(ctx, opts.Extra)
+			}
+			if state.Err == nil && opts.EndPosition {
+				state.EndPosition, state.Err = a.EndPosition(ctx, opts.Extra)
+			}
+			state.Time = time.Now()
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- state:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+	return ch
+}
+
+func streamJointStateHandler(srv interface{}, stream grpc.ServerStream) error {
+	req := &structpb.Struct{}
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+	return srv.(jointStateStreamServer).StreamJointState(req, stream)
+}
+
+// jointStateRequest is the StreamJointState request for the named arm.
+func jointStateRequest(name string, opts StreamJointStateOptions) (*structpb.Struct, error) {
+	return structpb.NewStruct(map[string]interface{}{
+		"name":            name,
+		"rate_hz":         opts.Rate,
+		"joint_positions": opts.JointPositions,
+		"end_position":    opts.EndPosition,
+		"extra":           opts.Extra,
+	})
+}
+
+// jointStateOptionsFromRequest returns the name of the arm and the options of a StreamJointState request.
+func jointStateOptionsFromRequest(req *structpb.Struct) (string, StreamJointStateOptions) {
+	fields := req.GetFields()
+	opts := StreamJointStateOptions{
+		Rate:           fields["rate_hz"].GetNumberValue(),
+		JointPositions: fields["joint_positions"].GetBoolValue(),
+		EndPosition:    fields["end_position"].GetBoolValue(),
+		Extra:          fields["extra"].GetStructValue().AsMap(),
+	}
+	return fields["name"].GetStringValue(), opts
+}
+
+// jointStateToResponse converts a state to a StreamJointState response. Joint positions are sent
+// the same way as GetJointPositions sends them, converted by the model of the arm.
+func jointStateToResponse(state JointState, m referenceframe.Model) (*structpb.Struct, error) {
+	resp := map[string]interface{}{"time": state.Time.Format(time.RFC3339Nano)}
+	if state.Err != nil {
+		resp["error"] = state.Err.Error()
+	}
+	if state.JointPositions != nil {
+		jp, err := referenceframe.JointPositionsFromInputs(m, state.JointPositions)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]interface{}, 0, len(jp.Values))
+		for _, v := range jp.Values {
+			values = append(values, v)
+		}
+		resp["joint_positions"] = values
+	}
+	if state.EndPosition != nil {
+		pose := spatialmath.PoseToProtobuf(state.EndPosition)
+		resp["end_position"] = map[string]interface{}{
+			"x": pose.X, "y": pose.Y, "z": pose.Z,
+			"o_x": pose.OX, "o_y": pose.OY, "o_z": pose.OZ, "theta": pose.Theta,
+		}
+	}
+	return structpb.NewStruct(resp)
+}
+
+// jointStateFromResponse converts a StreamJointState response back to a state.
+func jointStateFromResponse(resp *structpb.Struct, m referenceframe.Model) JointState {
+	fields := resp.GetFields()
+	var state JointState
+	var err error
+	if state.Time, err = time.Parse(time.RFC3339Nano, fields["time"].GetStringValue()); err != nil {
+		return JointState{Time: time.Now(), Err: errors.Wrap(err, "invalid joint state time")}
+	}
+	if msg := fields["error"].GetStringValue(); msg != "" {
+		state.Err = errors.New(msg)
+	}
+	if values := fields["joint_positions"].GetListValue(); values != nil {
+		jp := &pb.JointPositions{}
+		for _, v := range values.GetValues() {
+			jp.Values = append(jp.Values, v.GetNumberValue())
+		}
+		if state.JointPositions, err = referenceframe.InputsFromJointPositions(m, jp); err != nil {
+			state.Err = err
+		}
+	}
+	if pose := fields["end_position"].GetStructValue(); pose != nil {
+		p := pose.GetFields()
+		state.EndPosition = spatialmath.NewPoseFromProtobuf(&commonpb.Pose{
+			X:     p["x"].GetNumberValue(),
+			Y:     p["y"].GetNumberValue(),
+			Z:     p["z"].GetNumberValue(),
+			OX:    p["o_x"].GetNumberValue(),
+			OY:    p["o_y"].GetNumberValue(),
+			OZ:    p["o_z"].GetNumberValue(),
+			Theta: p["theta"].GetNumberValue(),
+		})
+	}
+	return state
+}
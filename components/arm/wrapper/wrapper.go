@@ -16,8 +16,9 @@ import (
 
 // Config is used for converting config attributes.
 type Config struct {
-	ModelFilePath string `json:"model-path"`
-	ArmName       string `json:"arm-name"`
+	ModelFilePath string                 `json:"model-path"`
+	ArmName       string                 `json:"arm-name"`
+	JointLimits   []arm.JointLimitConfig `json:"joint_limits,omitempty"`
 }
 
 var model = resource.DefaultModelFamily.WithModel("wrapper_arm")
@@ -28,7 +29,11 @@ func (cfg *Config) Validate(path string) ([]string, []string, error) {
 	if cfg.ArmName == "" {
 		return nil, nil, resource.NewConfigValidationFieldRequiredError(path, "arm-name")
 	}
-	if _, err := referenceframe.KinematicModelFromFile(cfg.ModelFilePath, ""); err != nil {
+	model, err := referenceframe.KinematicModelFromFile(cfg.ModelFilePath, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := arm.ApplyJointLimits(model, cfg.JointLimits); err != nil {
 		return nil, nil, err
 	}
 	deps = append(deps, cfg.ArmName)
@@ -48,9 +53,10 @@ type Arm struct {
 	logger logging.Logger
 	opMgr  *operation.SingleOperationManager
 
-	mu     sync.RWMutex
-	model  referenceframe.Model
-	actual arm.Arm
+	mu          sync.RWMutex
+	model       referenceframe.Model
+	jointLimits []arm.JointLimitConfig
+	actual      arm.Arm
 }
 
 // NewWrapperArm returns a wrapper component for another arm.
@@ -78,6 +84,10 @@ func (wrapper *Arm) Reconfigure(ctx context.Context, deps resource.Dependencies,
 	if err != nil {
 		return err
 	}
+	model, err = arm.ApplyJointLimits(model, newConf.JointLimits)
+	if err != nil {
+		return err
+	}
 
 	newArm, err := arm.FromDependencies(deps, newConf.ArmName)
 	if err != nil {
@@ -86,6 +96,7 @@ func (wrapper *Arm) Reconfigure(ctx context.Context, deps resource.Dependencies,
 
 	wrapper.mu.Lock()
 	wrapper.model = model
+	wrapper.jointLimits = newConf.JointLimits
 	wrapper.actual = newArm
 	wrapper.mu.Unlock()
 
@@ -122,27 +133,30 @@ func (wrapper *Arm) MoveToJointPositions(ctx context.Context, joints []reference
 
 	wrapper.mu.RLock()
 	defer wrapper.mu.RUnlock()
-	return wrapper.actual.MoveToJointPositions(ctx, joints, extra)
+	// MoveToJointPositions takes no options, so the joint limits are passed through MoveThroughJointPositions
+	opts := arm.ScaleMoveOptions(nil, wrapper.jointLimits)
+	return wrapper.actual.MoveThroughJointPositions(ctx, [][]referenceframe.Input{joints}, opts, extra)
 }
 
 // MoveThroughJointPositions moves the arm sequentially through the given joints.
 func (wrapper *Arm) MoveThroughJointPositions(
 	ctx context.Context,
 	positions [][]referenceframe.Input,
-	_ *arm.MoveOptions,
-	_ map[string]interface{},
+	opts *arm.MoveOptions,
+	extra map[string]interface{},
 ) error {
 	for _, goal := range positions {
 		// check that joint positions are not out of bounds
 		if err := arm.CheckDesiredJointPositions(ctx, wrapper, goal); err != nil {
 			return err
 		}
-		err := wrapper.MoveToJointPositions(ctx, goal, nil)
-		if err != nil {
-			return err
-		}
 	}
-	return nil
+	ctx, done := wrapper.opMgr.New(ctx)
+	defer done()
+
+	wrapper.mu.RLock()
+	defer wrapper.mu.RUnlock()
+	return wrapper.actual.MoveThroughJointPositions(ctx, positions, arm.ScaleMoveOptions(opts, wrapper.jointLimits), extra)
 }
 
 // JointPositions returns the set joints.
@@ -30,6 +30,8 @@ func (sb *sensorBase) MoveStraight(
 	sb.opMgr.CancelRunning(ctx)
 	ctx, done := sb.opMgr.New(ctx)
 	defer done()
+	sb.slip.setCommanded(mmPerSec*sign(float64(distanceMm)), 0)
+	defer sb.slip.clearCommanded()
 
 	// If a position movement sensor or controls are not configured, we cannot use this MoveStraight method.
 	// Instead we need to use the MoveStraight method of the base that the sensorcontrolled base wraps.
@@ -149,6 +151,8 @@ func (sb *sensorBase) MoveStraight(
 			}
 
 			// update velocity controller
+			// only the linear velocity is checked for slip, since the heading corrections are small
+			sb.slip.updateCommanded(linVelDes, 0)
 			if err := sb.updateControlConfig(ctx, linVelDes/1000.0, angVelDes); err != nil {
 				return err
 			}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/golang/geo/r3"
 	"github.com/pkg/errors"
+	goutils "go.viam.com/utils"
 
 	"go.viam.com/rdk/components/base"
 	"go.viam.com/rdk/components/movementsensor"
@@ -42,6 +43,12 @@ type Config struct {
 	Base              string              `json:"base"`
 	ControlParameters []control.PIDConfig `json:"control_parameters,omitempty"`
 	ControlFreq       float64             `json:"control_frequency_hz,omitempty"`
+	// ControlLoop replaces the built-in velocity control loop with a custom block diagram.
+	// Its first constant block sets the linear velocity and its second the angular velocity.
+	ControlLoop *control.Config `json:"control_loop,omitempty"`
+	// SlipDetection, if set, detects when the base slips or stalls. It needs a movement sensor that
+	// reports linear and angular velocity.
+	SlipDetection *SlipDetectionConfig `json:"slip_detection,omitempty"`
 }
 
 // Validate validates all parts of the sensor controlled base config.
@@ -64,6 +71,32 @@ func (cfg *Config) Validate(path string) ([]string, []string, error) {
 		}
 	}
 
+	if cfg.ControlLoop != nil {
+		if len(cfg.ControlParameters) != 0 {
+			return nil, nil, resource.NewConfigValidationError(path,
+				errors.New("only one of control_parameters and control_loop may be set"))
+		}
+		if err := cfg.ControlLoop.Validate(); err != nil {
+			return nil, nil, resource.NewConfigValidationError(path, errors.Wrap(err, "invalid control_loop"))
+		}
+		var constants int
+		for _, b := range cfg.ControlLoop.Blocks {
+			if string(b.Type) == control.BlockNameConstant {
+				constants++
+			}
+		}
+		if constants < 2 {
+			return nil, nil, resource.NewConfigValidationError(path,
+				errors.New("control_loop needs a constant block for each of linear and angular velocity"))
+		}
+	}
+
+	if cfg.SlipDetection != nil {
+		if err := cfg.SlipDetection.validate(); err != nil {
+			return nil, nil, resource.NewConfigValidationError(path, err)
+		}
+	}
+
 	return deps, nil, nil
 }
 
@@ -84,12 +117,16 @@ type sensorBase struct {
 	// headingFunc returns the current angle between (-180,180) and whether Spin is supported
 	headingFunc func(ctx context.Context) (float64, bool, error)
 
+	pidLoop           *control.PIDLoop
 	controlLoopConfig *control.Config
 	blockNames        map[string][]string
 	loop              *control.Loop
 	configPIDVals     []control.PIDConfig
 	tunedVals         *[]control.PIDConfig
 	controlFreq       float64
+
+	slip        *slipDetector
+	slipWorkers *goutils.StoppableWorkers
 }
 
 func init() {
@@ -111,6 +148,7 @@ func createSensorBase(
 		configPIDVals: []control.PIDConfig{{}, {}},
 		Named:         conf.ResourceName().AsNamed(),
 		opMgr:         operation.NewSingleOperationManager(),
+		slip:          &slipDetector{},
 	}
 
 	if err := sb.Reconfigure(ctx, deps, conf); err != nil {
@@ -131,6 +169,10 @@ func (sb *sensorBase) Reconfigure(ctx context.Context, deps resource.Dependencie
 		sb.loop.Stop()
 		sb.loop = nil
 	}
+	if sb.slipWorkers != nil {
+		sb.slipWorkers.Stop()
+		sb.slipWorkers = nil
+	}
 
 	sb.mu.Lock()
 	defer sb.mu.Unlock()
@@ -206,7 +248,37 @@ func (sb *sensorBase) Reconfigure(ctx context.Context, deps resource.Dependencie
 		return errors.Wrapf(err, "no base named (%s)", newConf.Base)
 	}
 
-	if sb.velocities != nil && len(newConf.ControlParameters) != 0 {
+	if newConf.SlipDetection != nil && sb.velocities == nil {
+		return errors.New("slip_detection needs a movement sensor that reports linear and angular velocity")
+	}
+	sb.slip.reconfigure(newConf.SlipDetection, sb.velocities)
+	if newConf.SlipDetection != nil {
+		interval := time.Duration(float64(time.Second) / sb.controlFreq)
+		sb.slipWorkers = goutils.NewBackgroundStoppableWorkers(func(ctx context.Context) {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					sb.checkSlip(ctx)
+				}
+			}
+		})
+	}
+
+	if sb.velocities != nil && newConf.ControlLoop != nil {
+		// a custom control loop declares its own PID gains in its blocks
+		sb.configPIDVals = nil
+		sb.mu.Unlock()
+		if err := sb.setupCustomControlLoop(*newConf.ControlLoop); err != nil {
+			sb.mu.Lock()
+			return err
+		}
+		sb.mu.Lock()
+	} else if sb.velocities != nil && len(newConf.ControlParameters) != 0 {
+		sb.configPIDVals = []control.PIDConfig{{}, {}}
 		// assign linear and angular PID correctly based on the given type
 		for _, pidConf := range newConf.ControlParameters {
 			switch pidConf.Type {
@@ -240,6 +312,7 @@ func (sb *sensorBase) SetPower(
 	ctx context.Context, linear, angular r3.Vector, extra map[string]interface{},
 ) error {
 	sb.opMgr.CancelRunning(ctx)
+	sb.slip.clearCommanded()
 	if sb.loop != nil {
 		sb.loop.Pause()
 	}
@@ -248,6 +321,7 @@ func (sb *sensorBase) SetPower(
 
 func (sb *sensorBase) Stop(ctx context.Context, extra map[string]interface{}) error {
 	sb.opMgr.CancelRunning(ctx)
+	sb.slip.clearCommanded()
 	if sb.loop != nil {
 		sb.loop.Pause()
 		// update pid controllers to be an at rest state
@@ -275,8 +349,7 @@ func (sb *sensorBase) DoCommand(ctx context.Context, req map[string]interface{})
 
 	sb.mu.Lock()
 	defer sb.mu.Unlock()
-	ok := req[getPID].(bool)
-	if ok {
+	if ok, _ := req[getPID].(bool); ok {
 		var respStr string
 		for _, pidConf := range *sb.tunedVals {
 			if !pidConf.NeedsAutoTuning() {
@@ -286,10 +359,75 @@ func (sb *sensorBase) DoCommand(ctx context.Context, req map[string]interface{})
 		resp[getPID] = respStr
 	}
 
+	if val, ok := req[control.AutotuneCommand]; ok {
+		if sb.pidLoop == nil {
+			return nil, errors.New("control loop not configured, cannot autotune")
+		}
+		method, err := control.TuneMethodFromCommand(val)
+		if err != nil {
+			return nil, err
+		}
+		if sb.loop != nil {
+			sb.loop.Stop()
+			sb.loop = nil
+		}
+		if err := sb.pidLoop.Autotune(ctx, method); err != nil {
+			return nil, err
+		}
+		// zero the configured values so that movement is refused until tuning is done and
+		// the tuned values are applied.
+		for i := range sb.configPIDVals {
+			sb.configPIDVals[i] = control.PIDConfig{Type: sb.configPIDVals[i].Type}
+		}
+		resp[control.AutotuneCommand] = method
+	}
+
+	if ok, _ := req[getSlipEvents].(bool); ok {
+		resp[getSlipEvents] = sb.slip.eventMaps()
+	}
+
+	if ok, _ := req[control.ControlLoopStateCommand].(bool); ok {
+		resp[control.ControlLoopStateCommand] = control.LoopStateResponse(ctx, sb.loop)
+	}
+
+	if ok, _ := req[control.TuningStatusCommand].(bool); ok && sb.pidLoop != nil {
+		resp[control.TuningStatusCommand] = map[string]interface{}{
+			"tuning": sb.pidLoop.Tuning(ctx),
+			"done":   sb.pidLoop.TuningDone(),
+		}
+	}
+
+	if ok, _ := req[control.ApplyTunedPIDCommand].(bool); ok {
+		if sb.pidLoop == nil {
+			return nil, errors.New("control loop not configured, no tuned values to apply")
+		}
+		tuned, err := sb.pidLoop.ApplyTunedValues()
+		if err != nil {
+			return nil, err
+		}
+		copy(sb.configPIDVals, tuned)
+		sb.blockNames = sb.pidLoop.BlockNames
+		controlParams := make([]map[string]interface{}, 0, len(tuned))
+		for _, pidConf := range tuned {
+			controlParams = append(controlParams, map[string]interface{}{
+				"type": pidConf.Type,
+				"p":    pidConf.P,
+				"i":    pidConf.I,
+				"d":    pidConf.D,
+			})
+		}
+		resp[control.ApplyTunedPIDCommand] = map[string]interface{}{
+			"control_parameters": controlParams,
+		}
+	}
+
 	return resp, nil
 }
 
 func (sb *sensorBase) Close(ctx context.Context) error {
+	if sb.slipWorkers != nil {
+		sb.slipWorkers.Stop()
+	}
 	if err := sb.Stop(ctx, nil); err != nil {
 		return err
 	}
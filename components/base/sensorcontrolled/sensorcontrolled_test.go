@@ -12,6 +12,7 @@ import (
 	geo "github.com/kellydunn/golang-geo"
 	"go.viam.com/test"
 	"go.viam.com/utils"
+	gotestutils "go.viam.com/utils/testutils"
 
 	"go.viam.com/rdk/components/base"
 	"go.viam.com/rdk/components/movementsensor"
@@ -549,3 +550,105 @@ func TestSensorBaseDoCommand(t *testing.T) {
 	test.That(t, resp, test.ShouldResemble, emptyMap)
 	test.That(t, b.Close(ctx), test.ShouldBeNil)
 }
+
+func TestSensorBaseSlipDetection(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	deps, _ := msDependencies(t, []string{"setvel1"})
+
+	var mu sync.Mutex
+	var measured r3.Vector
+	var stopped bool
+	ms, ok := deps[movementsensor.Named("setvel1")].(*inject.MovementSensor)
+	test.That(t, ok, test.ShouldBeTrue)
+	ms.LinearVelocityFunc = func(ctx context.Context, extra map[string]interface{}) (r3.Vector, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return measured, nil
+	}
+	injectBase, ok := deps[base.Named("test_base")].(*inject.Base)
+	test.That(t, ok, test.ShouldBeTrue)
+	injectBase.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+		mu.Lock()
+		defer mu.Unlock()
+		stopped = true
+		return nil
+	}
+
+	conf := &Config{
+		MovementSensor: []string{"setvel1"},
+		Base:           "test_base",
+		SlipDetection:  &SlipDetectionConfig{Tolerance: 1},
+	}
+	_, _, err := conf.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "tolerance")
+	conf.SlipDetection = &SlipDetectionConfig{DurationSec: 0.05, StopOnSlip: true}
+	_, _, err = conf.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+
+	b, err := createSensorBase(ctx, deps, resource.Config{Name: "test", API: base.API, ConvertedAttributes: conf}, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	// the wheels are commanded to move but the base does not
+	test.That(t, b.SetVelocity(ctx, r3.Vector{Y: 200}, r3.Vector{}, nil), test.ShouldBeNil)
+	gotestutils.WaitForAssertion(t, func(tb testing.TB) {
+		resp, err := b.DoCommand(ctx, map[string]interface{}{getSlipEvents: true})
+		test.That(tb, err, test.ShouldBeNil)
+		test.That(tb, resp[getSlipEvents], test.ShouldHaveLength, 1)
+	})
+	resp, err := b.DoCommand(ctx, map[string]interface{}{getSlipEvents: true})
+	test.That(t, err, test.ShouldBeNil)
+	event, ok := resp[getSlipEvents].([]interface{})[0].(map[string]interface{})
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, event["kind"], test.ShouldEqual, slipKindStall)
+	test.That(t, event["commanded_linear_mm_per_sec"], test.ShouldEqual, 200.)
+	test.That(t, event["stopped"], test.ShouldBeTrue)
+	mu.Lock()
+	test.That(t, stopped, test.ShouldBeTrue)
+	mu.Unlock()
+	test.That(t, b.Close(ctx), test.ShouldBeNil)
+
+	t.Run("slip", func(t *testing.T) {
+		sd := &slipDetector{}
+		sd.reconfigure(&SlipDetectionConfig{}, ms)
+		now := time.Now()
+		sd.setCommanded(200, 0)
+		setMeasured := func(mmPerSec float64) {
+			mu.Lock()
+			defer mu.Unlock()
+			measured = r3.Vector{Y: mmPerSec / 1000}
+		}
+
+		// moving a little slower than commanded is within the tolerance
+		setMeasured(150)
+		event, err := sd.check(ctx, now)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, event, test.ShouldBeNil)
+
+		// moving much slower is a slip once it has lasted long enough, and is reported once
+		setMeasured(50)
+		event, err = sd.check(ctx, now)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, event, test.ShouldBeNil)
+		event, err = sd.check(ctx, now.Add(time.Second))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, event, test.ShouldNotBeNil)
+		test.That(t, event.Kind, test.ShouldEqual, slipKindSlip)
+		test.That(t, event.MeasuredLinearMmPerSec, test.ShouldAlmostEqual, 50)
+		event, err = sd.check(ctx, now.Add(2*time.Second))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, event, test.ShouldBeNil)
+
+		// slow commands and stopped bases are not checked
+		sd.updateCommanded(10, 0)
+		event, err = sd.check(ctx, now.Add(3*time.Second))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, event, test.ShouldBeNil)
+		sd.setCommanded(200, 0)
+		sd.clearCommanded()
+		event, err = sd.check(ctx, now.Add(4*time.Second))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, event, test.ShouldBeNil)
+	})
+}
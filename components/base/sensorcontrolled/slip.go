@@ -0,0 +1,273 @@
+package sensorcontrolled
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/components/movementsensor"
+)
+
+const (
+	getSlipEvents          = "get_slip_events"
+	slipKindSlip           = "slip"
+	slipKindStall          = "stall"
+	defaultSlipTolerance   = 0.5
+	defaultSlipDurationSec = 0.5
+	// commanded velocities slower than these are not checked, since measured velocities are noisy near zero
+	minSlipLinearMmPerSec    = 20.
+	minSlipAngularDegsPerSec = 5.
+	// a base measured moving slower than this fraction of its commanded velocity has stalled
+	stallFraction = 0.1
+	maxSlipEvents = 10
+)
+
+// SlipDetectionConfig configures detecting when the base slips or stalls, by comparing the velocity
+// it was commanded to move at with the velocity its movement sensor measures.
+type SlipDetectionConfig struct {
+	// Tolerance is the fraction of the commanded velocity that the measured velocity may fall short by.
+	// It defaults to 0.5.
+	Tolerance float64 `json:"tolerance,omitempty"`
+	// DurationSec is how long the measured velocity must fall short for before the base has slipped.
+	// It defaults to 0.5.
+	DurationSec float64 `json:"duration_sec,omitempty"`
+	// StopOnSlip stops the base when it slips or stalls.
+	StopOnSlip bool `json:"stop_on_slip,omitempty"`
+}
+
+func (cfg *SlipDetectionConfig) validate() error {
+	if cfg.Tolerance < 0 || cfg.Tolerance >= 1 {
+		return errors.New("slip_detection tolerance must be at least 0 and less than 1")
+	}
+	if cfg.DurationSec < 0 {
+		return errors.New("slip_detection duration_sec cannot be negative")
+	}
+	return nil
+}
+
+// A SlipEvent is a time the base slipped or stalled. Linear velocities are in mm/s and angular
+// velocities in deg/s.
+type SlipEvent struct {
+	Time time.Time
+	// Kind is "stall" if the base was measured not moving at all, or "slip" if it moved slower than commanded.
+	Kind                       string
+	CommandedLinearMmPerSec    float64
+	MeasuredLinearMmPerSec     float64
+	CommandedAngularDegsPerSec float64
+	MeasuredAngularDegsPerSec  float64
+	// Stopped is whether the base was stopped because of the slip.
+	Stopped bool
+}
+
+func (e SlipEvent) toMap() map[string]interface{} {
+	return map[string]interface{}{
+		"time":                           e.Time.Format(time.RFC3339Nano),
+		"kind":                           e.Kind,
+		"commanded_linear_mm_per_sec":    e.CommandedLinearMmPerSec,
+		"measured_linear_mm_per_sec":     e.MeasuredLinearMmPerSec,
+		"commanded_angular_degs_per_sec": e.CommandedAngularDegsPerSec,
+		"measured_angular_degs_per_sec":  e.MeasuredAngularDegsPerSec,
+		"stopped":                        e.Stopped,
+	}
+}
+
+// slipDetector tracks the velocity the base is commanded to move at and compares it with the
+// velocity measured by a movement sensor.
+type slipDetector struct {
+	mu         sync.Mutex
+	conf       *SlipDetectionConfig // nil when slip detection is not configured
+	velocities movementsensor.MovementSensor
+
+	commanded bool
+	linear    float64
+	angular   float64
+	// generation changes every time a new command starts or the base stops, so that measurements
+	// taken across the change are thrown away
+	generation int
+	// since is when the measured velocity started falling short, and reported is whether that
+	// has been reported yet
+	since    time.Time
+	reported bool
+
+	events []SlipEvent
+}
+
+// reconfigure sets the config of the detector, with its defaults filled in, or disables it if conf is nil.
+func (sd *slipDetector) reconfigure(conf *SlipDetectionConfig, velocities movementsensor.MovementSensor) {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	sd.velocities = velocities
+	sd.conf = nil
+	if conf != nil {
+		withDefaults := *conf
+		if withDefaults.Tolerance == 0 {
+			withDefaults.Tolerance = defaultSlipTolerance
+		}
+		if withDefaults.DurationSec == 0 {
+			withDefaults.DurationSec = defaultSlipDurationSec
+		}
+		sd.conf = &withDefaults
+	}
+	sd.since = time.Time{}
+	sd.reported = false
+}
+
+// setCommanded records that the base was commanded to move at the given velocities.
+func (sd *slipDetector) setCommanded(linearMmPerSec, angularDegsPerSec float64) {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	sd.commanded = true
+	sd.linear = linearMmPerSec
+	sd.angular = angularDegsPerSec
+	sd.generation++
+	sd.since = time.Time{}
+	sd.reported = false
+}
+
+// updateCommanded changes the velocities of the current command, such as when a move slows down
+// near its goal, without starting over the time the measured velocity has fallen short for.
+func (sd *slipDetector) updateCommanded(linearMmPerSec, angularDegsPerSec float64) {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	if !sd.commanded {
+		return
+	}
+	sd.linear = linearMmPerSec
+	sd.angular = angularDegsPerSec
+}
+
+// clearCommanded records that the base is no longer moving at a known velocity.
+func (sd *slipDetector) clearCommanded() {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	sd.commanded = false
+	sd.generation++
+	sd.since = time.Time{}
+	sd.reported = false
+}
+
+// check compares the commanded velocity with the measured velocity at now, and returns an event if
+// the measured velocity has fallen short for long enough and that has not been reported yet.
+func (sd *slipDetector) check(ctx context.Context, now time.Time) (*SlipEvent, error) {
+	sd.mu.Lock()
+	if sd.conf == nil || !sd.commanded {
+		sd.mu.Unlock()
+		return nil, nil
+	}
+	conf, velocities, linear, angular, generation := *sd.conf, sd.velocities, sd.linear, sd.angular, sd.generation
+	sd.mu.Unlock()
+
+	linVel, err := velocities.LinearVelocity(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	angVel, err := velocities.AngularVelocity(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	// the movement sensor measures linear velocity in m/s
+	event := SlipEvent{
+		Time:                       now,
+		CommandedLinearMmPerSec:    linear,
+		MeasuredLinearMmPerSec:     linVel.Y * 1000,
+		CommandedAngularDegsPerSec: angular,
+		MeasuredAngularDegsPerSec:  angVel.Z,
+	}
+
+	checked, short, stalled := false, false, true
+	for _, v := range []struct{ commanded, measured, min float64 }{
+		{linear, event.MeasuredLinearMmPerSec, minSlipLinearMmPerSec},
+		{angular, event.MeasuredAngularDegsPerSec, minSlipAngularDegsPerSec},
+	} {
+		if math.Abs(v.commanded) < v.min {
+			continue
+		}
+		checked = true
+		along := v.measured * sign(v.commanded)
+		short = short || along < (1-conf.Tolerance)*math.Abs(v.commanded)
+		stalled = stalled && math.Abs(v.measured) < stallFraction*math.Abs(v.commanded)
+	}
+	event.Kind = slipKindSlip
+	if stalled {
+		event.Kind = slipKindStall
+	}
+
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	// the base may have been commanded again or stopped while the velocities were being measured
+	if sd.generation != generation {
+		return nil, nil
+	}
+	if !checked || !short {
+		sd.since = time.Time{}
+		sd.reported = false
+		return nil, nil
+	}
+	if sd.since.IsZero() {
+		sd.since = now
+	}
+	if sd.reported || now.Sub(sd.since).Seconds() < conf.DurationSec {
+		return nil, nil
+	}
+	sd.reported = true
+	return &event, nil
+}
+
+func (sd *slipDetector) stopOnSlip() bool {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	return sd.conf != nil && sd.conf.StopOnSlip
+}
+
+// record keeps an event, forgetting the oldest once there are too many.
+func (sd *slipDetector) record(event SlipEvent) {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	sd.events = append(sd.events, event)
+	if len(sd.events) > maxSlipEvents {
+		sd.events = sd.events[len(sd.events)-maxSlipEvents:]
+	}
+}
+
+// eventMaps returns the recorded events, oldest first, for a DoCommand response.
+func (sd *slipDetector) eventMaps() []interface{} {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	events := make([]interface{}, 0, len(sd.events))
+	for _, e := range sd.events {
+		events = append(events, e.toMap())
+	}
+	return events
+}
+
+// checkSlip checks whether the base has slipped, and if it has, logs it, records it and stops
+// the base if configured to.
+func (sb *sensorBase) checkSlip(ctx context.Context) {
+	event, err := sb.slip.check(ctx, time.Now())
+	if err != nil {
+		sb.logger.CDebugw(ctx, "could not check for slip", "error", err)
+		return
+	}
+	if event == nil {
+		return
+	}
+	if sb.slip.stopOnSlip() {
+		if err := sb.Stop(ctx, nil); err != nil {
+			sb.logger.CErrorw(ctx, "could not stop base after it slipped", "error", err)
+		} else {
+			event.Stopped = true
+		}
+	}
+	sb.slip.record(*event)
+	sb.logger.CWarnw(ctx, "base "+event.Kind+" detected",
+		"kind", event.Kind,
+		"commanded_linear_mm_per_sec", event.CommandedLinearMmPerSec,
+		"measured_linear_mm_per_sec", event.MeasuredLinearMmPerSec,
+		"commanded_angular_degs_per_sec", event.CommandedAngularDegsPerSec,
+		"measured_angular_degs_per_sec", event.MeasuredAngularDegsPerSec,
+		"stopped", event.Stopped,
+	)
+}
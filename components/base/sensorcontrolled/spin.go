@@ -23,6 +23,8 @@ func (sb *sensorBase) Spin(ctx context.Context, angleDeg, degsPerSec float64, ex
 	sb.opMgr.CancelRunning(ctx)
 	ctx, done := sb.opMgr.New(ctx)
 	defer done()
+	sb.slip.setCommanded(0, degsPerSec*sign(angleDeg))
+	defer sb.slip.clearCommanded()
 
 	// If an orientation movement sensor or controls are not configured, we cannot use this Spin method.
 	// Instead we need to use the Spin method of the base that the sensorBase wraps.
@@ -128,6 +130,7 @@ func (sb *sensorBase) Spin(ctx context.Context, angleDeg, degsPerSec float64, ex
 				return sb.Stop(ctx, nil)
 			}
 			angVel := calcAngVel(angErr, degsPerSec, slowDownAng)
+			sb.slip.updateCommanded(0, angVel)
 
 			if err := sb.updateControlConfig(ctx, 0, angVel); err != nil {
 				return err
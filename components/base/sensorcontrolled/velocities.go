@@ -19,6 +19,7 @@ func (sb *sensorBase) SetVelocity(
 	ctx, done := sb.opMgr.New(ctx)
 	defer done()
 
+	sb.slip.setCommanded(linear.Y, angular.Z)
 	if sb.controlLoopConfig == nil {
 		sb.logger.CWarnf(ctx, "control parameters not configured, using %v's SetVelocity method", sb.controlledBase.Name().ShortName())
 		return sb.controlledBase.SetVelocity(ctx, linear, angular, extra)
@@ -82,6 +83,28 @@ func (sb *sensorBase) setupControlLoop(linear, angular control.PIDConfig) error
 		return err
 	}
 
+	sb.pidLoop = pl
+	sb.controlLoopConfig = pl.ControlConf
+	sb.loop = pl.ControlLoop
+	sb.blockNames = pl.BlockNames
+	sb.tunedVals = pl.TunedVals
+
+	return nil
+}
+
+// setupCustomControlLoop sets up the control loop from a user declared block diagram.
+func (sb *sensorBase) setupCustomControlLoop(loopConf control.Config) error {
+	options := control.Options{
+		UseCustomConfig:      true,
+		CompleteCustomConfig: loopConf,
+		ControllableType:     "base_name",
+	}
+	pl, err := control.SetupPIDControlConfig(nil, sb.Name().ShortName(), options, sb, sb.logger)
+	if err != nil {
+		return err
+	}
+
+	sb.pidLoop = pl
 	sb.controlLoopConfig = pl.ControlConf
 	sb.loop = pl.ControlLoop
 	sb.blockNames = pl.BlockNames
@@ -14,6 +14,7 @@ import (
 	pb "go.viam.com/api/component/board/v1"
 
 	"go.viam.com/rdk/data"
+	firmwarepb "go.viam.com/rdk/proto/rdk/component/board/v1"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/robot"
 )
@@ -23,6 +24,7 @@ func init() {
 		RPCServiceServerConstructor: NewRPCServiceServer,
 		RPCServiceHandler:           pb.RegisterBoardServiceHandlerFromEndpoint,
 		RPCServiceDesc:              &pb.BoardService_ServiceDesc,
+		StreamRPCServiceDesc:        &firmwarepb.BoardFirmwareService_ServiceDesc,
 		RPCClient:                   NewClientFromConn,
 	})
 	data.RegisterCollector(data.MethodMetadata{
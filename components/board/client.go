@@ -8,6 +8,7 @@ import (
 	"time"
 
 	pb "go.viam.com/api/component/board/v1"
+	"go.viam.com/utils"
 	"go.viam.com/utils/protoutils"
 	"go.viam.com/utils/rpc"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -22,6 +23,7 @@ type client struct {
 	resource.Named
 	resource.TriviallyReconfigurable
 	resource.TriviallyCloseable
+	conn   rpc.ClientConn
 	client pb.BoardServiceClient
 	logger logging.Logger
 
@@ -46,6 +48,7 @@ func NewClientFromConn(
 	bClient := pb.NewBoardServiceClient(conn)
 	c := &client{
 		Named:     name.PrependRemote(remoteName).AsNamed(),
+		conn:      conn,
 		client:    bClient,
 		logger:    logger,
 		boardName: name.ShortName(),
@@ -90,6 +93,61 @@ func (c *client) DoCommand(ctx context.Context, cmd map[string]interface{}) (map
 	return rprotoutils.DoFromResourceClient(ctx, c.client, c.boardName, cmd)
 }
 
+// FlashFirmware flashes the image over the firmware service, which streams its progress back. Servers
+// that do not serve it are sent the image through DoCommand, and polled for progress meanwhile.
+func (c *client) FlashFirmware(
+	ctx context.Context,
+	image FirmwareImage,
+	progress func(FirmwareProgress),
+	extra map[string]interface{},
+) error {
+	if err := image.Validate(); err != nil {
+		return err
+	}
+	if served, err := c.streamFirmware(ctx, image, progress, extra); served {
+		return err
+	}
+	cmd := map[string]interface{}{FlashFirmwareCommand: image.toCommand(), "extra": extra}
+	done := make(chan error, 1)
+	utils.PanicCapturingGo(func() {
+		_, err := c.DoCommand(ctx, cmd)
+		done <- err
+	})
+	ticker := time.NewTicker(firmwareStatusInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-done:
+			return err
+		case <-ticker.C:
+			if progress == nil {
+				continue
+			}
+			status, err := c.FirmwareStatus(ctx, image.Target, nil)
+			if err != nil {
+				c.logger.CDebugw(ctx, "failed to get firmware status", "target", image.Target, "error", err)
+				continue
+			}
+			if status.Flashing {
+				progress(status.Progress)
+			}
+		}
+	}
+}
+
+func (c *client) RollbackFirmware(ctx context.Context, target string, extra map[string]interface{}) error {
+	_, err := c.DoCommand(ctx, map[string]interface{}{RollbackFirmwareCommand: target, "extra": extra})
+	return err
+}
+
+func (c *client) FirmwareStatus(ctx context.Context, target string, extra map[string]interface{}) (FirmwareStatus, error) {
+	resp, err := c.DoCommand(ctx, map[string]interface{}{FirmwareStatusCommand: target, "extra": extra})
+	if err != nil {
+		return FirmwareStatus{}, err
+	}
+	return firmwareStatusFromCommand(resp)
+}
+
 // analogClient satisfies a gRPC based board.AnalogReader. Refer to the interface
 // for descriptions of its methods.
 type analogClient struct {
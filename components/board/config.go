@@ -1,6 +1,12 @@
 package board
 
-import "go.viam.com/rdk/resource"
+import (
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/resource"
+)
 
 // SPIConfig enumerates a specific, shareable SPI bus.
 type SPIConfig struct {
@@ -33,12 +39,26 @@ func (config *I2CConfig) Validate(path string) error {
 	return nil
 }
 
+// The ways an analog reader can combine the samples it takes over average_over_ms.
+const (
+	AnalogFilterAverage = "average"
+	AnalogFilterMedian  = "median"
+)
+
 // AnalogReaderConfig describes the configuration of an analog reader on a board.
 type AnalogReaderConfig struct {
 	Name              string `json:"name"`
 	Pin               string `json:"pin"`
 	AverageOverMillis int    `json:"average_over_ms,omitempty"`
 	SamplesPerSecond  int    `json:"samples_per_sec,omitempty"`
+	// Oversample is the number of raw readings averaged into each sample, which defaults to 1.
+	Oversample int `json:"oversample,omitempty"`
+	// Filter is AnalogFilterAverage, the default, or AnalogFilterMedian, which ignores spikes.
+	Filter string `json:"filter,omitempty"`
+	// ReferenceVoltage, if set, is the voltage of a full scale reading, and ResolutionBits the
+	// number of bits in a reading. Together they set the range and step size of the readings.
+	ReferenceVoltage float32 `json:"reference_voltage,omitempty"`
+	ResolutionBits   int     `json:"resolution_bits,omitempty"`
 }
 
 // Validate ensures all parts of the config are valid.
@@ -46,6 +66,21 @@ func (config *AnalogReaderConfig) Validate(path string) error {
 	if config.Name == "" {
 		return resource.NewConfigValidationFieldRequiredError(path, "name")
 	}
+	if config.Oversample < 0 {
+		return resource.NewConfigValidationError(path, errors.New("oversample cannot be negative"))
+	}
+	switch config.Filter {
+	case "", AnalogFilterAverage, AnalogFilterMedian:
+	default:
+		return resource.NewConfigValidationError(path, errors.Errorf(
+			"filter must be %q or %q, not %q", AnalogFilterAverage, AnalogFilterMedian, config.Filter))
+	}
+	if config.ReferenceVoltage < 0 {
+		return resource.NewConfigValidationError(path, errors.New("reference_voltage cannot be negative"))
+	}
+	if config.ResolutionBits < 0 || config.ResolutionBits > 32 {
+		return resource.NewConfigValidationError(path, errors.New("resolution_bits must be between 0 and 32"))
+	}
 	return nil
 }
 
@@ -65,3 +100,46 @@ func (config *DigitalInterruptConfig) Validate(path string) error {
 	}
 	return nil
 }
+
+// STM32FirmwareConfig describes an STM32 attached to a board, whose firmware is flashed through its
+// UART bootloader.
+type STM32FirmwareConfig struct {
+	// Name is the target that firmware images for the STM32 name.
+	Name string `json:"name"`
+	// SerialPath is the path of the UART wired to the STM32's bootloader UART, like /dev/ttyS0.
+	SerialPath string `json:"serial_path"`
+	// BaudRate is one of 9600, 19200, 38400, 57600, or 115200, the default.
+	BaudRate int `json:"baud_rate,omitempty"`
+	// Boot0Pin and ResetPin are the board pins wired to the STM32's BOOT0 and NRST.
+	Boot0Pin string `json:"boot0_pin"`
+	ResetPin string `json:"reset_pin"`
+	// FlashSizeKB is the size of the STM32's flash.
+	FlashSizeKB int `json:"flash_size_kb"`
+}
+
+// STM32BaudRates are the baud rates an STM32 bootloader can be flashed at.
+var STM32BaudRates = []int{9600, 19200, 38400, 57600, 115200}
+
+// Validate ensures all parts of the config are valid.
+func (config *STM32FirmwareConfig) Validate(path string) error {
+	if config.Name == "" {
+		return resource.NewConfigValidationFieldRequiredError(path, "name")
+	}
+	if config.SerialPath == "" {
+		return resource.NewConfigValidationFieldRequiredError(path, "serial_path")
+	}
+	if config.Boot0Pin == "" {
+		return resource.NewConfigValidationFieldRequiredError(path, "boot0_pin")
+	}
+	if config.ResetPin == "" {
+		return resource.NewConfigValidationFieldRequiredError(path, "reset_pin")
+	}
+	if config.FlashSizeKB <= 0 {
+		return resource.NewConfigValidationError(path, errors.New("flash_size_kb must be positive"))
+	}
+	if config.BaudRate != 0 && !slices.Contains(STM32BaudRates, config.BaudRate) {
+		return resource.NewConfigValidationError(path,
+			errors.Errorf("baud_rate must be one of %v, not %d", STM32BaudRates, config.BaudRate))
+	}
+	return nil
+}
@@ -167,6 +167,10 @@ type Board struct {
 	CloseCount int
 
 	workers *utils.StoppableWorkers
+
+	firmwareMu sync.Mutex
+	firmware   *board.BootloaderFlasher
+	mcus       map[string]*mcu
 }
 
 // AnalogByName returns the analog pin by the given name if it exists.
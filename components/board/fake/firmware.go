@@ -0,0 +1,102 @@
+package fake
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"go.viam.com/rdk/components/board"
+)
+
+// fakeFlashSize is the size of the application flash of every fake microcontroller.
+const fakeFlashSize = 64 << 10
+
+// mcu is a fake microcontroller attached to a fake board, which is created the first time it is named.
+type mcu struct {
+	mu    sync.Mutex
+	flash []byte
+}
+
+// firmwareFlasher returns the flasher of the board's fake microcontrollers.
+func (b *Board) firmwareFlasher() *board.BootloaderFlasher {
+	b.firmwareMu.Lock()
+	defer b.firmwareMu.Unlock()
+	if b.firmware == nil {
+		b.mcus = map[string]*mcu{}
+		b.firmware = board.NewBootloaderFlasher(func(target string) (board.Bootloader, error) {
+			b.firmwareMu.Lock()
+			defer b.firmwareMu.Unlock()
+			m, ok := b.mcus[target]
+			if !ok {
+				m = &mcu{flash: bytes.Repeat([]byte{0xFF}, fakeFlashSize)}
+				b.mcus[target] = m
+			}
+			return m, nil
+		})
+	}
+	return b.firmware
+}
+
+// FlashFirmware flashes a fake microcontroller.
+func (b *Board) FlashFirmware(
+	ctx context.Context,
+	image board.FirmwareImage,
+	progress func(board.FirmwareProgress),
+	extra map[string]interface{},
+) error {
+	return b.firmwareFlasher().FlashFirmware(ctx, image, progress, extra)
+}
+
+// RollbackFirmware restores the firmware of a fake microcontroller from before its last flash.
+func (b *Board) RollbackFirmware(ctx context.Context, target string, extra map[string]interface{}) error {
+	return b.firmwareFlasher().RollbackFirmware(ctx, target, extra)
+}
+
+// FirmwareStatus returns the firmware status of a fake microcontroller.
+func (b *Board) FirmwareStatus(ctx context.Context, target string, extra map[string]interface{}) (board.FirmwareStatus, error) {
+	return b.firmwareFlasher().FirmwareStatus(ctx, target, extra)
+}
+
+// Enter does nothing for a fake microcontroller.
+func (m *mcu) Enter(ctx context.Context) error {
+	return nil
+}
+
+// Size returns the size of the fake flash.
+func (m *mcu) Size() int {
+	return fakeFlashSize
+}
+
+// Read reads the fake flash.
+func (m *mcu) Read(ctx context.Context, offset int, buf []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copy(buf, m.flash[offset:])
+	return nil
+}
+
+// Erase erases the fake flash.
+func (m *mcu) Erase(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.flash {
+		m.flash[i] = 0xFF
+	}
+	return nil
+}
+
+// Write writes the fake flash.
+func (m *mcu) Write(ctx context.Context, offset int, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copy(m.flash[offset:], data)
+	return nil
+}
+
+// Exit does nothing for a fake microcontroller.
+func (m *mcu) Exit(ctx context.Context) error {
+	return nil
+}
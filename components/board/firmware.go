@@ -0,0 +1,324 @@
+package board
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"go.viam.com/rdk/utils"
+)
+
+// DoCommand keys used to carry firmware requests over the board API.
+const (
+	FlashFirmwareCommand    = "flash_firmware"
+	RollbackFirmwareCommand = "rollback_firmware"
+	FirmwareStatusCommand   = "firmware_status"
+)
+
+const (
+	// firmwareChunkSize is how much of an image is written between progress reports.
+	firmwareChunkSize = 4096
+	// firmwareStatusInterval is how often clients poll for progress while flashing through DoCommand.
+	firmwareStatusInterval = 250 * time.Millisecond
+	// firmwareRestoreTimeout bounds restoring the previous firmware after a failed flash, which goes on
+	// even if the flash was cancelled so that the microcontroller is not left erased.
+	firmwareRestoreTimeout = 5 * time.Minute
+	// firmwareExitTimeout bounds resetting the microcontroller out of its bootloader.
+	firmwareExitTimeout = 10 * time.Second
+)
+
+// A FirmwareStage is a step in flashing firmware.
+type FirmwareStage string
+
+// The stages of flashing firmware, in order. A flash that fails after erasing rolls back before failing.
+const (
+	FirmwareStageBackingUp   FirmwareStage = "backing_up"
+	FirmwareStageErasing     FirmwareStage = "erasing"
+	FirmwareStageWriting     FirmwareStage = "writing"
+	FirmwareStageVerifying   FirmwareStage = "verifying"
+	FirmwareStageRollingBack FirmwareStage = "rolling_back"
+	FirmwareStageDone        FirmwareStage = "done"
+	FirmwareStageFailed      FirmwareStage = "failed"
+)
+
+// FirmwareImage is firmware to flash onto a microcontroller attached to a board.
+type FirmwareImage struct {
+	// Target names the microcontroller, as known to the board.
+	Target string
+	// Version is reported by FirmwareStatus once the image is flashed.
+	Version string
+	// Data is the raw image, written from the start of the microcontroller's application flash.
+	Data []byte
+	// SHA256 is the hex encoded SHA-256 of Data. If set, the image is checked against it before flashing.
+	SHA256 string
+}
+
+// Validate checks that the image names its target and matches its hash, if it has one.
+func (img FirmwareImage) Validate() error {
+	if img.Target == "" {
+		return errors.New("firmware image must name a target")
+	}
+	if len(img.Data) == 0 {
+		return errors.New("firmware image is empty")
+	}
+	if img.SHA256 != "" {
+		sum := sha256.Sum256(img.Data)
+		if !strings.EqualFold(hex.EncodeToString(sum[:]), img.SHA256) {
+			return errors.Errorf("firmware image for %q does not match its SHA-256", img.Target)
+		}
+	}
+	return nil
+}
+
+// FirmwareProgress is how far a flash or rollback has got.
+type FirmwareProgress struct {
+	Stage      FirmwareStage
+	BytesDone  int
+	BytesTotal int
+}
+
+// FirmwareStatus describes the firmware of a microcontroller attached to a board.
+type FirmwareStatus struct {
+	// Version is the version of the flashed firmware, if known.
+	Version string
+	// Flashing is whether a flash or rollback is in progress.
+	Flashing bool
+	// Progress is the progress of the current or last flash or rollback.
+	Progress FirmwareProgress
+	// Error is why the last flash or rollback failed, if it did.
+	Error string
+	// CanRollback is whether there is earlier firmware to roll back to.
+	CanRollback bool
+}
+
+// A FirmwareFlasher is a board that can update the firmware of microcontrollers attached to it. The
+// boards in this repository flash STM32s through their system memory bootloader.
+//
+// Over the network, images are flashed on the firmware service, which streams progress back. Images are
+// sent in a single message, so they are limited by the connection's maximum message size.
+type FirmwareFlasher interface {
+	// FlashFirmware flashes an image, calling progress, if not nil, as it goes. If the new image cannot
+	// be written and verified, the previous firmware is restored.
+	FlashFirmware(
+		ctx context.Context,
+		image FirmwareImage,
+		progress func(FirmwareProgress),
+		extra map[string]interface{},
+	) error
+
+	// RollbackFirmware restores the firmware of the target from before its last successful flash.
+	RollbackFirmware(ctx context.Context, target string, extra map[string]interface{}) error
+
+	// FirmwareStatus returns the firmware status of the target.
+	FirmwareStatus(ctx context.Context, target string, extra map[string]interface{}) (FirmwareStatus, error)
+}
+
+// A Bootloader is the flashing protocol of a microcontroller, such as the STM32 system memory
+// bootloader. Implementations split reads and writes into whatever sizes and alignments their protocol
+// requires.
+type Bootloader interface {
+	// Enter resets the microcontroller into its bootloader.
+	Enter(ctx context.Context) error
+	// Size returns the size in bytes of the application flash.
+	Size() int
+	// Read fills buf from the application flash, starting at offset.
+	Read(ctx context.Context, offset int, buf []byte) error
+	// Erase erases the application flash.
+	Erase(ctx context.Context) error
+	// Write writes data to the erased application flash, starting at offset.
+	Write(ctx context.Context, offset int, data []byte) error
+	// Exit resets the microcontroller into its application.
+	Exit(ctx context.Context) error
+}
+
+// FlashBootloader writes data to the microcontroller behind bl, calling progress, if not nil, as it goes.
+// The previous contents of the application flash are read first and returned, less any trailing erased
+// bytes, so that callers can later roll back to them. If data cannot be written and verified, the previous
+// contents are restored. Restoring them and leaving the bootloader are done even if ctx is cancelled, as
+// the previous contents are lost otherwise.
+func FlashBootloader(
+	ctx context.Context,
+	bl Bootloader,
+	data []byte,
+	progress func(FirmwareProgress),
+) (previous []byte, err error) {
+	if progress == nil {
+		progress = func(FirmwareProgress) {}
+	}
+	if len(data) > bl.Size() {
+		return nil, errors.Errorf("firmware image of %d bytes does not fit in %d bytes of flash", len(data), bl.Size())
+	}
+	if err := bl.Enter(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to enter bootloader")
+	}
+	defer func() {
+		exitCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), firmwareExitTimeout)
+		defer cancel()
+		err = multierr.Combine(err, bl.Exit(exitCtx))
+	}()
+
+	previous = make([]byte, bl.Size())
+	for offset := 0; offset < len(previous); offset += firmwareChunkSize {
+		progress(FirmwareProgress{Stage: FirmwareStageBackingUp, BytesDone: offset, BytesTotal: len(previous)})
+		if err := bl.Read(ctx, offset, previous[offset:min(offset+firmwareChunkSize, len(previous))]); err != nil {
+			progress(FirmwareProgress{Stage: FirmwareStageFailed})
+			return nil, errors.Wrap(err, "failed to back up firmware")
+		}
+	}
+
+	if err := writeFirmware(ctx, bl, data, FirmwareStageWriting, progress); err != nil {
+		// erased bytes are left as they are, so only the rest of the previous firmware is restored.
+		restoreCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), firmwareRestoreTimeout)
+		defer cancel()
+		rollbackErr := writeFirmware(restoreCtx, bl, trimErased(previous), FirmwareStageRollingBack, progress)
+		progress(FirmwareProgress{Stage: FirmwareStageFailed})
+		if rollbackErr != nil {
+			return nil, multierr.Combine(err, errors.Wrap(rollbackErr, "failed to roll back firmware"))
+		}
+		return nil, errors.Wrap(err, "rolled back firmware")
+	}
+	progress(FirmwareProgress{Stage: FirmwareStageDone, BytesDone: len(data), BytesTotal: len(data)})
+	return trimErased(previous), nil
+}
+
+// writeFirmware erases the flash, then writes and verifies data.
+func writeFirmware(
+	ctx context.Context,
+	bl Bootloader,
+	data []byte,
+	stage FirmwareStage,
+	progress func(FirmwareProgress),
+) error {
+	progress(FirmwareProgress{Stage: FirmwareStageErasing, BytesTotal: len(data)})
+	if err := bl.Erase(ctx); err != nil {
+		return errors.Wrap(err, "failed to erase flash")
+	}
+	for offset := 0; offset < len(data); offset += firmwareChunkSize {
+		progress(FirmwareProgress{Stage: stage, BytesDone: offset, BytesTotal: len(data)})
+		if err := bl.Write(ctx, offset, data[offset:min(offset+firmwareChunkSize, len(data))]); err != nil {
+			return errors.Wrap(err, "failed to write flash")
+		}
+	}
+	readBack := make([]byte, firmwareChunkSize)
+	for offset := 0; offset < len(data); offset += firmwareChunkSize {
+		progress(FirmwareProgress{Stage: FirmwareStageVerifying, BytesDone: offset, BytesTotal: len(data)})
+		chunk := data[offset:min(offset+firmwareChunkSize, len(data))]
+		if err := bl.Read(ctx, offset, readBack[:len(chunk)]); err != nil {
+			return errors.Wrap(err, "failed to read back flash")
+		}
+		if !bytes.Equal(chunk, readBack[:len(chunk)]) {
+			return errors.Errorf("flash does not match image at offset %d", offset)
+		}
+	}
+	return nil
+}
+
+// trimErased drops trailing erased (0xFF) bytes.
+func trimErased(flash []byte) []byte {
+	end := len(flash)
+	for end > 0 && flash[end-1] == 0xFF {
+		end--
+	}
+	return flash[:end]
+}
+
+func (img FirmwareImage) toCommand() map[string]interface{} {
+	return map[string]interface{}{
+		"target":  img.Target,
+		"version": img.Version,
+		"data":    base64.StdEncoding.EncodeToString(img.Data),
+		"sha256":  img.SHA256,
+	}
+}
+
+func firmwareImageFromCommand(cmd interface{}) (FirmwareImage, error) {
+	fields, ok := cmd.(map[string]interface{})
+	if !ok {
+		return FirmwareImage{}, errors.Errorf("%s must be an object, got %T", FlashFirmwareCommand, cmd)
+	}
+	r := utils.NewAttributeReader(fields)
+	target := utils.Required[string](r, "target")
+	version := utils.Optional(r, "version", "")
+	hash := utils.Optional(r, "sha256", "")
+	encoded := utils.Required[string](r, "data")
+	if err := r.Err(); err != nil {
+		return FirmwareImage{}, errors.Wrapf(err, "invalid %s", FlashFirmwareCommand)
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return FirmwareImage{}, errors.Wrapf(err, "%s.data must be base64 encoded", FlashFirmwareCommand)
+	}
+	img := FirmwareImage{Target: target, Version: version, Data: data, SHA256: hash}
+	return img, img.Validate()
+}
+
+func (s FirmwareStatus) toCommand() map[string]interface{} {
+	return map[string]interface{}{
+		"version":      s.Version,
+		"flashing":     s.Flashing,
+		"stage":        string(s.Progress.Stage),
+		"bytes_done":   s.Progress.BytesDone,
+		"bytes_total":  s.Progress.BytesTotal,
+		"error":        s.Error,
+		"can_rollback": s.CanRollback,
+	}
+}
+
+func firmwareStatusFromCommand(resp map[string]interface{}) (FirmwareStatus, error) {
+	fields, ok := resp[FirmwareStatusCommand].(map[string]interface{})
+	if !ok {
+		return FirmwareStatus{}, errors.New("board does not support flashing firmware")
+	}
+	r := utils.NewAttributeReader(fields)
+	status := FirmwareStatus{
+		Version:  utils.Optional(r, "version", ""),
+		Flashing: utils.Optional(r, "flashing", false),
+		Progress: FirmwareProgress{
+			Stage:      FirmwareStage(utils.Optional(r, "stage", "")),
+			BytesDone:  utils.Optional(r, "bytes_done", 0),
+			BytesTotal: utils.Optional(r, "bytes_total", 0),
+		},
+		Error:       utils.Optional(r, "error", ""),
+		CanRollback: utils.Optional(r, "can_rollback", false),
+	}
+	return status, errors.Wrapf(r.Err(), "invalid %s", FirmwareStatusCommand)
+}
+
+// doFirmwareCommand handles a firmware DoCommand on behalf of a board that can flash firmware.
+// It reports false if cmd is not a firmware command.
+func doFirmwareCommand(
+	ctx context.Context,
+	b Board,
+	cmd map[string]interface{},
+) (map[string]interface{}, bool, error) {
+	flasher, ok := b.(FirmwareFlasher)
+	if !ok {
+		return nil, false, nil
+	}
+	extra, _ := cmd["extra"].(map[string]interface{})
+	if flash, ok := cmd[FlashFirmwareCommand]; ok {
+		img, err := firmwareImageFromCommand(flash)
+		if err != nil {
+			return nil, true, err
+		}
+		return map[string]interface{}{}, true, flasher.FlashFirmware(ctx, img, nil, extra)
+	}
+	if target, ok := cmd[RollbackFirmwareCommand].(string); ok {
+		return map[string]interface{}{}, true, flasher.RollbackFirmware(ctx, target, extra)
+	}
+	if target, ok := cmd[FirmwareStatusCommand].(string); ok {
+		status, err := flasher.FirmwareStatus(ctx, target, extra)
+		if err != nil {
+			return nil, true, err
+		}
+		return map[string]interface{}{FirmwareStatusCommand: status.toCommand()}, true, nil
+	}
+	return nil, false, nil
+}
@@ -0,0 +1,128 @@
+package board
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// A BootloaderFlasher is a FirmwareFlasher for boards whose microcontrollers are flashed through a
+// Bootloader. It keeps the status of each target and the firmware to roll each back to, so boards only
+// need to find the bootloader of a target.
+type BootloaderFlasher struct {
+	bootloader func(target string) (Bootloader, error)
+
+	mu      sync.Mutex
+	targets map[string]*flashTarget
+}
+
+// flashTarget is the firmware state of one microcontroller.
+type flashTarget struct {
+	// flashMu is held for the whole of a flash or rollback.
+	flashMu sync.Mutex
+
+	// the rest are guarded by the mutex of the BootloaderFlasher.
+	version         string
+	previous        []byte
+	previousVersion string
+	status          FirmwareStatus
+}
+
+// NewBootloaderFlasher returns a BootloaderFlasher that flashes each target through the bootloader
+// returned for it.
+func NewBootloaderFlasher(bootloader func(target string) (Bootloader, error)) *BootloaderFlasher {
+	return &BootloaderFlasher{bootloader: bootloader, targets: map[string]*flashTarget{}}
+}
+
+func (f *BootloaderFlasher) target(name string) *flashTarget {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.targets[name]
+	if !ok {
+		t = &flashTarget{}
+		f.targets[name] = t
+	}
+	return t
+}
+
+// FlashFirmware flashes an image through the bootloader of its target.
+func (f *BootloaderFlasher) FlashFirmware(
+	ctx context.Context,
+	image FirmwareImage,
+	progress func(FirmwareProgress),
+	extra map[string]interface{},
+) error {
+	if err := image.Validate(); err != nil {
+		return err
+	}
+	return f.flash(ctx, image.Target, image.Data, image.Version, progress, false)
+}
+
+// RollbackFirmware restores the firmware of the target from before its last successful flash.
+func (f *BootloaderFlasher) RollbackFirmware(ctx context.Context, target string, extra map[string]interface{}) error {
+	t := f.target(target)
+	f.mu.Lock()
+	previous, previousVersion := t.previous, t.previousVersion
+	f.mu.Unlock()
+	if previous == nil {
+		return errors.Errorf("no earlier firmware to roll back %q to", target)
+	}
+	return f.flash(ctx, target, previous, previousVersion, nil, true)
+}
+
+// FirmwareStatus returns the firmware status of the target.
+func (f *BootloaderFlasher) FirmwareStatus(ctx context.Context, target string, extra map[string]interface{}) (FirmwareStatus, error) {
+	t := f.target(target)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return t.status, nil
+}
+
+func (f *BootloaderFlasher) flash(
+	ctx context.Context,
+	target string,
+	data []byte,
+	version string,
+	progress func(FirmwareProgress),
+	rollback bool,
+) error {
+	bl, err := f.bootloader(target)
+	if err != nil {
+		return err
+	}
+	t := f.target(target)
+	t.flashMu.Lock()
+	defer t.flashMu.Unlock()
+
+	f.mu.Lock()
+	t.status.Flashing = true
+	t.status.Error = ""
+	f.mu.Unlock()
+
+	previous, err := FlashBootloader(ctx, bl, data, func(p FirmwareProgress) {
+		f.mu.Lock()
+		t.status.Progress = p
+		f.mu.Unlock()
+		if progress != nil {
+			progress(p)
+		}
+	})
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t.status.Flashing = false
+	if err != nil {
+		t.status.Error = err.Error()
+		return err
+	}
+	if rollback {
+		t.previous, t.previousVersion = nil, ""
+	} else {
+		t.previous, t.previousVersion = previous, t.version
+	}
+	t.version = version
+	t.status.Version = version
+	t.status.CanRollback = t.previous != nil
+	return nil
+}
@@ -0,0 +1,132 @@
+package board
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.viam.com/utils/protoutils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	firmwarepb "go.viam.com/rdk/proto/rdk/component/board/v1"
+)
+
+// FlashFirmware flashes the image of the request onto the named board, sending its progress as it
+// goes. The stream ends once the image is flashed, or with the error that stopped it.
+func (s *serviceServer) FlashFirmware(
+	req *firmwarepb.FlashFirmwareRequest,
+	stream firmwarepb.BoardFirmwareService_FlashFirmwareServer,
+) error {
+	b, err := s.coll.Resource(req.GetName())
+	if err != nil {
+		return err
+	}
+	flasher, ok := b.(FirmwareFlasher)
+	if !ok {
+		return errors.Errorf("board %q cannot flash firmware", req.GetName())
+	}
+	img := FirmwareImage{
+		Target:  req.GetTarget(),
+		Version: req.GetVersion(),
+		Data:    req.GetData(),
+		SHA256:  req.GetSha256(),
+	}
+	if err := img.Validate(); err != nil {
+		return err
+	}
+
+	// progress is sent from the flashing goroutine, and stops being sent once FlashFirmware returns.
+	var mu sync.Mutex
+	var sendErr error
+	finished := false
+	progress := func(p FirmwareProgress) {
+		mu.Lock()
+		defer mu.Unlock()
+		if finished || sendErr != nil {
+			return
+		}
+		sendErr = stream.Send(progressToResponse(p))
+	}
+	// the first message lets the client know the service is served before flashing starts
+	progress(FirmwareProgress{Stage: FirmwareStageBackingUp, BytesTotal: len(img.Data)})
+	err = flasher.FlashFirmware(stream.Context(), img, progress, req.GetExtra().AsMap())
+
+	mu.Lock()
+	defer mu.Unlock()
+	finished = true
+	if err != nil {
+		return err
+	}
+	return sendErr
+}
+
+// flashFirmwareRequest is the FlashFirmware request for the named board.
+func flashFirmwareRequest(
+	name string,
+	image FirmwareImage,
+	extra map[string]interface{},
+) (*firmwarepb.FlashFirmwareRequest, error) {
+	ext, err := protoutils.StructToStructPb(extra)
+	if err != nil {
+		return nil, err
+	}
+	return &firmwarepb.FlashFirmwareRequest{
+		Name:    name,
+		Target:  image.Target,
+		Version: image.Version,
+		Data:    image.Data,
+		Sha256:  image.SHA256,
+		Extra:   ext,
+	}, nil
+}
+
+func progressToResponse(p FirmwareProgress) *firmwarepb.FlashFirmwareResponse {
+	return &firmwarepb.FlashFirmwareResponse{
+		Stage:      string(p.Stage),
+		BytesDone:  int64(p.BytesDone),
+		BytesTotal: int64(p.BytesTotal),
+	}
+}
+
+func progressFromResponse(resp *firmwarepb.FlashFirmwareResponse) FirmwareProgress {
+	return FirmwareProgress{
+		Stage:      FirmwareStage(resp.GetStage()),
+		BytesDone:  int(resp.GetBytesDone()),
+		BytesTotal: int(resp.GetBytesTotal()),
+	}
+}
+
+// streamFirmware flashes the image over the firmware service, calling progress as it is streamed back.
+// It reports false, without error, if the server does not serve the firmware service.
+func (c *client) streamFirmware(
+	ctx context.Context,
+	image FirmwareImage,
+	progress func(FirmwareProgress),
+	extra map[string]interface{},
+) (bool, error) {
+	req, err := flashFirmwareRequest(c.boardName, image, extra)
+	if err != nil {
+		return true, err
+	}
+	stream, err := firmwarepb.NewBoardFirmwareServiceClient(c.conn).FlashFirmware(ctx, req)
+	if err != nil {
+		return true, err
+	}
+	for first := true; ; first = false {
+		resp, err := stream.Recv()
+		if err != nil {
+			if first && status.Code(err) == codes.Unimplemented {
+				return false, nil
+			}
+			if errors.Is(err, io.EOF) {
+				return true, nil
+			}
+			return true, err
+		}
+		if progress != nil {
+			progress(progressFromResponse(resp))
+		}
+	}
+}
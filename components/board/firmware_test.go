@@ -0,0 +1,245 @@
+package board_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net"
+	"testing"
+
+	"github.com/pkg/errors"
+	commonpb "go.viam.com/api/common/v1"
+	pb "go.viam.com/api/component/board/v1"
+	"go.viam.com/test"
+	"go.viam.com/utils/protoutils"
+	"go.viam.com/utils/rpc"
+
+	"go.viam.com/rdk/components/board"
+	"go.viam.com/rdk/components/board/fake"
+	viamgrpc "go.viam.com/rdk/grpc"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+)
+
+// flakyBootloader is an in-memory bootloader whose next write past the first chunk can be made to fail
+// or to cancel the flash. Operations fail once their context is done.
+type flakyBootloader struct {
+	flash       []byte
+	failWrite   bool
+	cancelWrite context.CancelFunc
+	entered     int
+	exited      int
+}
+
+func (bl *flakyBootloader) Enter(ctx context.Context) error {
+	bl.entered++
+	return nil
+}
+
+func (bl *flakyBootloader) Size() int {
+	return len(bl.flash)
+}
+
+func (bl *flakyBootloader) Read(ctx context.Context, offset int, buf []byte) error {
+	copy(buf, bl.flash[offset:])
+	return nil
+}
+
+func (bl *flakyBootloader) Erase(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for i := range bl.flash {
+		bl.flash[i] = 0xFF
+	}
+	return nil
+}
+
+func (bl *flakyBootloader) Write(ctx context.Context, offset int, data []byte) error {
+	if bl.failWrite && offset > 0 {
+		bl.failWrite = false
+		return errors.New("write failed")
+	}
+	if bl.cancelWrite != nil && offset > 0 {
+		bl.cancelWrite()
+		bl.cancelWrite = nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	copy(bl.flash[offset:], data)
+	return nil
+}
+
+func (bl *flakyBootloader) Exit(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	bl.exited++
+	return nil
+}
+
+func TestFlashBootloader(t *testing.T) {
+	ctx := context.Background()
+	original := bytes.Repeat([]byte{1, 2, 3}, 3000)
+	bl := &flakyBootloader{flash: bytes.Repeat([]byte{0xFF}, 32<<10)}
+	copy(bl.flash, original)
+
+	image := bytes.Repeat([]byte{4, 5}, 6000)
+	var stages []board.FirmwareStage
+	previous, err := board.FlashBootloader(ctx, bl, image, func(p board.FirmwareProgress) {
+		if len(stages) == 0 || stages[len(stages)-1] != p.Stage {
+			stages = append(stages, p.Stage)
+		}
+	})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, previous, test.ShouldResemble, original)
+	test.That(t, bl.flash[:len(image)], test.ShouldResemble, image)
+	test.That(t, stages, test.ShouldResemble, []board.FirmwareStage{
+		board.FirmwareStageBackingUp,
+		board.FirmwareStageErasing,
+		board.FirmwareStageWriting,
+		board.FirmwareStageVerifying,
+		board.FirmwareStageDone,
+	})
+
+	t.Run("failed writes are rolled back", func(t *testing.T) {
+		bl.failWrite = true
+		var lastStage board.FirmwareStage
+		_, err := board.FlashBootloader(ctx, bl, bytes.Repeat([]byte{6}, 10000), func(p board.FirmwareProgress) {
+			lastStage = p.Stage
+		})
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "write failed")
+		test.That(t, lastStage, test.ShouldEqual, board.FirmwareStageFailed)
+		test.That(t, bl.flash[:len(image)], test.ShouldResemble, image)
+		test.That(t, bl.flash[len(image)], test.ShouldEqual, byte(0xFF))
+		test.That(t, bl.exited, test.ShouldEqual, bl.entered)
+	})
+
+	t.Run("cancelled flashes are rolled back", func(t *testing.T) {
+		cancelCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		bl.cancelWrite = cancel
+		_, err := board.FlashBootloader(cancelCtx, bl, bytes.Repeat([]byte{7}, 10000), nil)
+		test.That(t, errors.Is(err, context.Canceled), test.ShouldBeTrue)
+		test.That(t, err.Error(), test.ShouldNotContainSubstring, "failed to roll back")
+		test.That(t, bl.flash[:len(image)], test.ShouldResemble, image)
+		test.That(t, bl.flash[len(image)], test.ShouldEqual, byte(0xFF))
+		test.That(t, bl.exited, test.ShouldEqual, bl.entered)
+	})
+
+	t.Run("images must fit", func(t *testing.T) {
+		_, err := board.FlashBootloader(ctx, bl, make([]byte, 64<<10), nil)
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
+
+func TestServerFirmware(t *testing.T) {
+	ctx := context.Background()
+	fakeBoard, err := fake.NewBoard(ctx, resource.Config{Name: "board1", ConvertedAttributes: &fake.Config{}},
+		logging.NewTestLogger(t))
+	test.That(t, err, test.ShouldBeNil)
+	boardSvc, err := resource.NewAPIResourceCollection(board.API, map[resource.Name]board.Board{
+		board.Named("board1"): fakeBoard,
+	})
+	test.That(t, err, test.ShouldBeNil)
+	boardServer := board.NewRPCServiceServer(boardSvc).(pb.BoardServiceServer)
+
+	do := func(cmd map[string]interface{}) (map[string]interface{}, error) {
+		pbCmd, err := protoutils.StructToStructPb(cmd)
+		test.That(t, err, test.ShouldBeNil)
+		resp, err := boardServer.DoCommand(ctx, &commonpb.DoCommandRequest{Name: "board1", Command: pbCmd})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Result.AsMap(), nil
+	}
+	flash := func(data []byte, version, hash string) error {
+		_, err := do(map[string]interface{}{board.FlashFirmwareCommand: map[string]interface{}{
+			"target":  "esp32",
+			"version": version,
+			"data":    base64.StdEncoding.EncodeToString(data),
+			"sha256":  hash,
+		}})
+		return err
+	}
+	status := func() board.FirmwareStatus {
+		s, err := fakeBoard.FirmwareStatus(ctx, "esp32", nil)
+		test.That(t, err, test.ShouldBeNil)
+		return s
+	}
+
+	v1 := []byte("firmware v1")
+	sum := sha256.Sum256(v1)
+	test.That(t, flash(v1, "1.0.0", hex.EncodeToString(sum[:])), test.ShouldBeNil)
+	test.That(t, status().Version, test.ShouldEqual, "1.0.0")
+	test.That(t, status().CanRollback, test.ShouldBeTrue)
+
+	// images that do not match their hash are refused
+	test.That(t, flash([]byte("firmware v2"), "2.0.0", hex.EncodeToString(sum[:])), test.ShouldNotBeNil)
+	test.That(t, status().Version, test.ShouldEqual, "1.0.0")
+
+	test.That(t, flash([]byte("firmware v2"), "2.0.0", ""), test.ShouldBeNil)
+	resp, err := do(map[string]interface{}{board.FirmwareStatusCommand: "esp32"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp[board.FirmwareStatusCommand], test.ShouldContainKey, "version")
+
+	_, err = do(map[string]interface{}{board.RollbackFirmwareCommand: "esp32"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, status().Version, test.ShouldEqual, "1.0.0")
+	test.That(t, status().CanRollback, test.ShouldBeFalse)
+	_, err = do(map[string]interface{}{board.RollbackFirmwareCommand: "esp32"})
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestClientFirmwareStream(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	fakeBoard, err := fake.NewBoard(ctx, resource.Config{Name: testBoardName, ConvertedAttributes: &fake.Config{}}, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	rpcServer, err := rpc.NewServer(logger, rpc.WithUnauthenticated())
+	test.That(t, err, test.ShouldBeNil)
+	boardSvc, err := resource.NewAPIResourceCollection(board.API, map[resource.Name]board.Board{
+		board.Named(testBoardName): fakeBoard,
+	})
+	test.That(t, err, test.ShouldBeNil)
+	resourceAPI, ok, err := resource.LookupAPIRegistration[board.Board](board.API)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, resourceAPI.RegisterRPCService(ctx, rpcServer, boardSvc), test.ShouldBeNil)
+	go rpcServer.Serve(listener)
+	defer rpcServer.Stop()
+
+	conn, err := viamgrpc.Dial(ctx, listener.Addr().String(), logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer conn.Close()
+	client, err := board.NewClientFromConn(ctx, conn, "", board.Named(testBoardName), logger)
+	test.That(t, err, test.ShouldBeNil)
+	flasher, ok := client.(board.FirmwareFlasher)
+	test.That(t, ok, test.ShouldBeTrue)
+
+	var stages []board.FirmwareStage
+	image := board.FirmwareImage{Target: "esp32", Version: "1.0.0", Data: bytes.Repeat([]byte{7}, 10000)}
+	err = flasher.FlashFirmware(ctx, image, func(p board.FirmwareProgress) {
+		if len(stages) == 0 || stages[len(stages)-1] != p.Stage {
+			stages = append(stages, p.Stage)
+		}
+	}, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, stages, test.ShouldContain, board.FirmwareStageWriting)
+	test.That(t, stages[len(stages)-1], test.ShouldEqual, board.FirmwareStageDone)
+
+	status, err := flasher.FirmwareStatus(ctx, "esp32", nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, status.Version, test.ShouldEqual, "1.0.0")
+
+	// errors flashing end the stream
+	err = flasher.FlashFirmware(ctx, board.FirmwareImage{Target: "esp32", Data: make([]byte, 128<<10)}, nil, nil)
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -10,19 +10,22 @@ import (
 	"go.viam.com/utils"
 
 	"go.viam.com/rdk/components/board"
+	"go.viam.com/rdk/components/board/mcp3008helper"
 	"go.viam.com/rdk/components/board/pinwrappers"
 	"go.viam.com/rdk/grpc"
 )
 
 type wrappedAnalogReader struct {
-	mu         sync.RWMutex
-	chipSelect string
-	reader     *pinwrappers.AnalogSmoother
+	mu     sync.RWMutex
+	config mcp3008helper.MCP3008AnalogConfig
+	reader *pinwrappers.AnalogSmoother
 }
 
-func newWrappedAnalogReader(ctx context.Context, chipSelect string, reader *pinwrappers.AnalogSmoother) *wrappedAnalogReader {
+func newWrappedAnalogReader(
+	ctx context.Context, config mcp3008helper.MCP3008AnalogConfig, reader *pinwrappers.AnalogSmoother,
+) *wrappedAnalogReader {
 	var wrapped wrappedAnalogReader
-	wrapped.reset(ctx, chipSelect, reader)
+	wrapped.reset(ctx, config, reader)
 	return &wrapped
 }
 
@@ -39,14 +42,16 @@ func (a *wrappedAnalogReader) Close(ctx context.Context) error {
 	return a.reader.Close(ctx)
 }
 
-func (a *wrappedAnalogReader) reset(ctx context.Context, chipSelect string, reader *pinwrappers.AnalogSmoother) {
+func (a *wrappedAnalogReader) reset(
+	ctx context.Context, config mcp3008helper.MCP3008AnalogConfig, reader *pinwrappers.AnalogSmoother,
+) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	if a.reader != nil {
 		utils.UncheckedError(a.reader.Close(ctx))
 	}
 	a.reader = reader
-	a.chipSelect = chipSelect
+	a.config = config
 }
 
 func (a *wrappedAnalogReader) Write(ctx context.Context, value int, extra map[string]interface{}) error {
@@ -61,6 +61,7 @@ func NewBoard(
 		gpios:         map[string]*gpioPin{},
 		interrupts:    map[string]*digitalInterrupt{},
 	}
+	b.firmware = board.NewBootloaderFlasher(b.stm32Bootloader)
 
 	if err := b.Reconfigure(ctx, nil, conf); err != nil {
 		return nil, err
@@ -91,6 +92,7 @@ func (b *Board) Reconfigure(
 	if err := b.reconfigureInterrupts(newConf); err != nil {
 		return err
 	}
+	b.firmwareTargets = newConf.FirmwareTargets
 	return nil
 }
 
@@ -205,27 +207,23 @@ func (b *Board) reconfigureAnalogReaders(ctx context.Context, newConf *LinuxBoar
 
 		stillExists[c.Name] = struct{}{}
 		if curr, ok := b.analogReaders[c.Name]; ok {
-			if curr.chipSelect != c.ChipSelect {
+			// the smoothing is set up when the reader starts, so any change to the config restarts it
+			if curr.config != c {
 				ar := &mcp3008helper.MCP3008AnalogReader{channel, bus, c.ChipSelect}
-				curr.reset(ctx, curr.chipSelect,
-					pinwrappers.SmoothAnalogReader(ar, board.AnalogReaderConfig{
-						AverageOverMillis: c.AverageOverMillis, SamplesPerSecond: c.SamplesPerSecond,
-					}, b.logger))
+				curr.reset(ctx, c, pinwrappers.SmoothAnalogReader(ar, c.ReaderConfig(), b.logger))
 			}
 			continue
 		}
 		ar := &mcp3008helper.MCP3008AnalogReader{channel, bus, c.ChipSelect}
-		b.analogReaders[c.Name] = newWrappedAnalogReader(ctx, c.ChipSelect,
-			pinwrappers.SmoothAnalogReader(ar, board.AnalogReaderConfig{
-				AverageOverMillis: c.AverageOverMillis, SamplesPerSecond: c.SamplesPerSecond,
-			}, b.logger))
+		b.analogReaders[c.Name] = newWrappedAnalogReader(ctx, c,
+			pinwrappers.SmoothAnalogReader(ar, c.ReaderConfig(), b.logger))
 	}
 
 	for name := range b.analogReaders {
 		if _, ok := stillExists[name]; ok {
 			continue
 		}
-		b.analogReaders[name].reset(ctx, "", nil)
+		b.analogReaders[name].reset(ctx, mcp3008helper.MCP3008AnalogConfig{}, nil)
 		delete(b.analogReaders, name)
 	}
 	return nil
@@ -363,6 +361,9 @@ type Board struct {
 	gpios      map[string]*gpioPin
 	interrupts map[string]*digitalInterrupt
 
+	firmwareTargets []board.STM32FirmwareConfig
+	firmware        *board.BootloaderFlasher
+
 	workers *utils.StoppableWorkers
 }
 
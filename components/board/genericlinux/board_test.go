@@ -67,6 +67,15 @@ func TestConfigValidate(t *testing.T) {
 	_, _, err = validConfig.Validate("path")
 	test.That(t, err, test.ShouldBeNil)
 
+	validConfig.AnalogReaders = []mcp3008helper.MCP3008AnalogConfig{{Name: "bar", Filter: "mode"}}
+	_, _, err = validConfig.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "filter")
+
+	validConfig.AnalogReaders = []mcp3008helper.MCP3008AnalogConfig{{Name: "bar", Filter: "median", Oversample: 4}}
+	_, _, err = validConfig.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+
 	validConfig.DigitalInterrupts = []board.DigitalInterruptConfig{{}}
 	_, _, err = validConfig.Validate("path")
 	test.That(t, err, test.ShouldNotBeNil)
@@ -13,6 +13,7 @@ import (
 type Config struct {
 	AnalogReaders     []mcp3008helper.MCP3008AnalogConfig `json:"analogs,omitempty"`
 	DigitalInterrupts []board.DigitalInterruptConfig      `json:"digital_interrupts,omitempty"`
+	FirmwareTargets   []board.STM32FirmwareConfig         `json:"firmware_targets,omitempty"`
 }
 
 // Validate ensures all parts of the config are valid.
@@ -27,6 +28,11 @@ func (conf *Config) Validate(path string) ([]string, []string, error) {
 			return nil, nil, err
 		}
 	}
+	for idx, c := range conf.FirmwareTargets {
+		if err := c.Validate(fmt.Sprintf("%s.%s.%d", path, "firmware_targets", idx)); err != nil {
+			return nil, nil, err
+		}
+	}
 	return nil, nil, nil
 }
 
@@ -40,6 +46,7 @@ func (conf *Config) Validate(path string) ([]string, []string, error) {
 type LinuxBoardConfig struct {
 	AnalogReaders     []mcp3008helper.MCP3008AnalogConfig
 	DigitalInterrupts []board.DigitalInterruptConfig
+	FirmwareTargets   []board.STM32FirmwareConfig
 	GpioMappings      map[string]GPIOBoardMapping
 }
 
@@ -63,6 +70,7 @@ func ConstPinDefs(gpioMappings map[string]GPIOBoardMapping) ConfigConverter {
 		return &LinuxBoardConfig{
 			AnalogReaders:     newConf.AnalogReaders,
 			DigitalInterrupts: newConf.DigitalInterrupts,
+			FirmwareTargets:   newConf.FirmwareTargets,
 			GpioMappings:      gpioMappings,
 		}, nil
 	}
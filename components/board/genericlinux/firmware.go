@@ -0,0 +1,122 @@
+//go:build linux
+
+package genericlinux
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"golang.org/x/sys/unix"
+
+	"go.viam.com/rdk/components/board"
+)
+
+// stm32BaudRate is the baud rate STM32 bootloaders are flashed at unless configured otherwise.
+const stm32BaudRate = 115200
+
+// baudRates maps the supported baud rates to their termios speeds.
+var baudRates = map[int]uint32{
+	9600:   unix.B9600,
+	19200:  unix.B19200,
+	38400:  unix.B38400,
+	57600:  unix.B57600,
+	115200: unix.B115200,
+}
+
+// FlashFirmware flashes an image onto one of the STM32s configured as firmware targets.
+func (b *Board) FlashFirmware(
+	ctx context.Context,
+	image board.FirmwareImage,
+	progress func(board.FirmwareProgress),
+	extra map[string]interface{},
+) error {
+	return b.firmware.FlashFirmware(ctx, image, progress, extra)
+}
+
+// RollbackFirmware restores the firmware of the target from before its last successful flash.
+func (b *Board) RollbackFirmware(ctx context.Context, target string, extra map[string]interface{}) error {
+	return b.firmware.RollbackFirmware(ctx, target, extra)
+}
+
+// FirmwareStatus returns the firmware status of the target.
+func (b *Board) FirmwareStatus(ctx context.Context, target string, extra map[string]interface{}) (board.FirmwareStatus, error) {
+	return b.firmware.FirmwareStatus(ctx, target, extra)
+}
+
+// stm32Bootloader returns the bootloader of the configured firmware target with the given name.
+func (b *Board) stm32Bootloader(target string) (board.Bootloader, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, conf := range b.firmwareTargets {
+		if conf.Name != target {
+			continue
+		}
+		boot0, err := b.GPIOPinByName(conf.Boot0Pin)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot find BOOT0 pin of firmware target %q", target)
+		}
+		reset, err := b.GPIOPinByName(conf.ResetPin)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot find reset pin of firmware target %q", target)
+		}
+		path, baudRate := conf.SerialPath, conf.BaudRate
+		if baudRate == 0 {
+			baudRate = stm32BaudRate
+		}
+		open := func() (io.ReadWriteCloser, error) {
+			return openSerial(path, baudRate)
+		}
+		return board.NewSTM32Bootloader(open, boot0, reset, conf.FlashSizeKB*1024), nil
+	}
+	return nil, errors.Errorf("no firmware target named %q", target)
+}
+
+// openSerial opens a raw serial port with 8 data bits, even parity, and 1 stop bit, as STM32
+// bootloaders expect. The port is nonblocking, so reads from it can be given deadlines.
+func openSerial(path string, baudRate int) (*os.File, error) {
+	speed, ok := baudRates[baudRate]
+	if !ok {
+		return nil, errors.Errorf("unsupported baud rate %d", baudRate)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|unix.O_NOCTTY|unix.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, err
+	}
+	rawConn, err := f.SyscallConn()
+	if err != nil {
+		return nil, multierr.Combine(err, f.Close())
+	}
+	var termiosErr error
+	if err := rawConn.Control(func(fd uintptr) {
+		termiosErr = setSerialMode(int(fd), speed)
+	}); err != nil {
+		termiosErr = err
+	}
+	if termiosErr != nil {
+		return nil, multierr.Combine(errors.Wrapf(termiosErr, "cannot configure serial port %s", path), f.Close())
+	}
+	return f, nil
+}
+
+func setSerialMode(fd int, speed uint32) error {
+	t, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+	if err != nil {
+		return err
+	}
+	t.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	t.Oflag &^= unix.OPOST
+	t.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	t.Cflag &^= unix.CSIZE | unix.PARODD | unix.CSTOPB | unix.CRTSCTS | unix.CBAUD
+	t.Cflag |= unix.CS8 | unix.PARENB | unix.CREAD | unix.CLOCAL | speed
+	t.Ispeed = speed
+	t.Ospeed = speed
+	t.Cc[unix.VMIN] = 1
+	t.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(fd, unix.TCSETS, t); err != nil {
+		return err
+	}
+	return unix.IoctlSetInt(fd, unix.TCFLSH, unix.TCIOFLUSH)
+}
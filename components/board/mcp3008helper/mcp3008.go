@@ -10,7 +10,6 @@ import (
 	"go.viam.com/rdk/components/board"
 	"go.viam.com/rdk/components/board/genericlinux/buses"
 	"go.viam.com/rdk/grpc"
-	"go.viam.com/rdk/resource"
 )
 
 // MCP3008AnalogReader implements a board.AnalogReader using an MCP3008 ADC via SPI.
@@ -28,18 +27,37 @@ type MCP3008AnalogConfig struct {
 	ChipSelect        string `json:"chip_select"` // the CS line for the ADC chip, typically a pin number on the board
 	AverageOverMillis int    `json:"average_over_ms,omitempty"`
 	SamplesPerSecond  int    `json:"samples_per_sec,omitempty"`
+	Oversample        int    `json:"oversample,omitempty"`
+	Filter            string `json:"filter,omitempty"`
+	// ReferenceVoltage is the voltage on the ADC's VREF pin, which a full scale reading is.
+	ReferenceVoltage float32 `json:"reference_voltage,omitempty"`
 }
 
+// resolutionBits is the resolution of the MCP3008.
+const resolutionBits = 10
+
 // Validate ensures all parts of the config are valid.
 func (config *MCP3008AnalogConfig) Validate(path string) error {
-	if config.Name == "" {
-		return resource.NewConfigValidationFieldRequiredError(path, "name")
+	readerConfig := config.ReaderConfig()
+	return readerConfig.Validate(path)
+}
+
+// ReaderConfig returns the config of the analog reader that smooths the readings of the ADC.
+func (config *MCP3008AnalogConfig) ReaderConfig() board.AnalogReaderConfig {
+	return board.AnalogReaderConfig{
+		Name:              config.Name,
+		Pin:               config.Channel,
+		AverageOverMillis: config.AverageOverMillis,
+		SamplesPerSecond:  config.SamplesPerSecond,
+		Oversample:        config.Oversample,
+		Filter:            config.Filter,
+		ReferenceVoltage:  config.ReferenceVoltage,
+		ResolutionBits:    resolutionBits,
 	}
-	return nil
 }
 
 // Read returns a board.AnalogValue, but the Min, Max, and StepSize within it are never initialized
-// and will always be 0!
+// and will always be 0, unless the reader is smoothed with a reference_voltage configured!
 func (mar *MCP3008AnalogReader) Read(ctx context.Context, extra map[string]interface{}) (
 	analogVal board.AnalogValue, err error,
 ) {
@@ -5,6 +5,8 @@ package pinwrappers
 
 import (
 	"context"
+	"slices"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -24,7 +26,11 @@ type AnalogSmoother struct {
 	Raw               board.Analog
 	AverageOverMillis int
 	SamplesPerSecond  int
-	data              *utils.RollingAverage
+	Oversample        int
+	Filter            string
+	ReferenceVoltage  float32
+	ResolutionBits    int
+	data              sampleWindow
 	lastData          atomic.Pointer[board.AnalogValue]
 	lastError         atomic.Pointer[errValue]
 	logger            logging.Logger
@@ -37,12 +43,19 @@ func SmoothAnalogReader(r board.Analog, c board.AnalogReaderConfig, logger loggi
 		Raw:               r,
 		AverageOverMillis: c.AverageOverMillis,
 		SamplesPerSecond:  c.SamplesPerSecond,
+		Oversample:        c.Oversample,
+		Filter:            c.Filter,
+		ReferenceVoltage:  c.ReferenceVoltage,
+		ResolutionBits:    c.ResolutionBits,
 		logger:            logger,
 	}
 	if smoother.SamplesPerSecond <= 0 {
 		logger.Debug("Can't read nonpositive samples per second; defaulting to 1 instead")
 		smoother.SamplesPerSecond = 1
 	}
+	if smoother.Oversample <= 0 {
+		smoother.Oversample = 1
+	}
 
 	smoother.Start()
 	return smoother
@@ -68,15 +81,15 @@ func (as *AnalogSmoother) Read(ctx context.Context, extra map[string]interface{}
 	}
 
 	if as.data == nil { // We're using raw data, and not averaging
-		return *lastDataPointer, nil
+		return as.scale(*lastDataPointer), nil
 	}
 
-	analogVal := board.AnalogValue{
+	analogVal := as.scale(board.AnalogValue{
 		Min:      lastDataPointer.Min,
 		Max:      lastDataPointer.Max,
 		StepSize: lastDataPointer.StepSize,
-		Value:    as.data.Average(),
-	}
+		Value:    as.data.Value(),
+	})
 
 	lastErr := as.lastError.Load()
 	if lastErr == nil {
@@ -110,7 +123,11 @@ func (as *AnalogSmoother) Start() {
 	numSamples := (as.SamplesPerSecond * as.AverageOverMillis) / 1000
 	nanosBetween := 1e9 / as.SamplesPerSecond
 	if numSamples >= 1 {
-		as.data = utils.NewRollingAverage(numSamples)
+		if as.Filter == board.AnalogFilterMedian {
+			as.data = newRollingMedian(numSamples)
+		} else {
+			as.data = averageWindow{utils.NewRollingAverage(numSamples)}
+		}
 	} else {
 		as.logger.Debug("Too few samples to smooth over; defaulting to raw data.")
 		as.data = nil
@@ -127,7 +144,7 @@ func (as *AnalogSmoother) Start() {
 			default:
 			}
 			start := time.Now()
-			reading, err := as.Raw.Read(ctx, nil)
+			reading, err := as.readOversampled(ctx)
 			as.lastError.Store(&errValue{err != nil, err})
 			if err == nil {
 				as.lastData.Store(&reading)
@@ -163,6 +180,100 @@ func (as *AnalogSmoother) Start() {
 	})
 }
 
+// readOversampled returns the average of Oversample readings of the underlying reader.
+func (as *AnalogSmoother) readOversampled(ctx context.Context) (board.AnalogValue, error) {
+	reading, err := as.Raw.Read(ctx, nil)
+	if err != nil || as.Oversample <= 1 {
+		return reading, err
+	}
+	sum := reading.Value
+	for i := 1; i < as.Oversample; i++ {
+		next, err := as.Raw.Read(ctx, nil)
+		if err != nil {
+			return board.AnalogValue{}, err
+		}
+		sum += next.Value
+	}
+	// round to the nearest value rather than down
+	reading.Value = (sum + as.Oversample/2) / as.Oversample
+	return reading, nil
+}
+
+// scale sets the range and step size of a reading from the reference voltage, if one is
+// configured. Without a resolution, the step size reported by the underlying reader is scaled to
+// the new range.
+func (as *AnalogSmoother) scale(v board.AnalogValue) board.AnalogValue {
+	if as.ReferenceVoltage <= 0 {
+		return v
+	}
+	switch {
+	case as.ResolutionBits > 0:
+		v.StepSize = as.ReferenceVoltage / float32(uint64(1)<<as.ResolutionBits)
+	case v.Max > v.Min:
+		v.StepSize *= as.ReferenceVoltage / (v.Max - v.Min)
+	}
+	v.Min = 0
+	v.Max = as.ReferenceVoltage
+	return v
+}
+
+// A sampleWindow combines the most recent samples into one value.
+type sampleWindow interface {
+	Add(x int)
+	Value() int
+}
+
+// averageWindow is the mean of its samples.
+type averageWindow struct {
+	*utils.RollingAverage
+}
+
+func (w averageWindow) Value() int {
+	return w.Average()
+}
+
+// rollingMedian is the median of its samples, which, unlike the mean, is not thrown off by the
+// occasional noisy sample.
+type rollingMedian struct {
+	mu  sync.Mutex
+	pos int
+	// count is how many samples have been added, up to the window size, so that the window is not
+	// padded with zeros until it fills up.
+	count  int
+	data   []int
+	sorted []int
+}
+
+func newRollingMedian(windowSize int) *rollingMedian {
+	return &rollingMedian{data: make([]int, windowSize), sorted: make([]int, windowSize)}
+}
+
+func (rm *rollingMedian) Add(x int) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.data[rm.pos] = x
+	rm.pos = (rm.pos + 1) % len(rm.data)
+	if rm.count < len(rm.data) {
+		rm.count++
+	}
+}
+
+func (rm *rollingMedian) Value() int {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if rm.count == 0 {
+		return 0
+	}
+	sorted := rm.sorted[:rm.count]
+	copy(sorted, rm.data[:rm.count])
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
+
 func (as *AnalogSmoother) Write(ctx context.Context, value int, extra map[string]interface{}) error {
 	return grpc.UnimplementedError
 }
@@ -75,3 +75,98 @@ func TestAnalogSmoother1(t *testing.T) {
 
 	test.That(t, as.Close(context.Background()), test.ShouldBeNil)
 }
+
+// seqAnalog repeats a sequence of values until it has been read lim times.
+type seqAnalog struct {
+	mu     sync.Mutex
+	values []int
+	n      int
+	lim    int
+}
+
+func (t *seqAnalog) Read(ctx context.Context, extra map[string]interface{}) (board.AnalogValue, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.n >= t.lim {
+		return board.AnalogValue{}, errStopReading
+	}
+	v := t.values[t.n%len(t.values)]
+	t.n++
+	return board.AnalogValue{Value: v, Min: 0, Max: 3.3, StepSize: 0.1}, nil
+}
+
+func (t *seqAnalog) Write(ctx context.Context, value int, extra map[string]interface{}) error {
+	return grpc.UnimplementedError
+}
+
+func (t *seqAnalog) Close(ctx context.Context) error {
+	return nil
+}
+
+func TestAnalogSmootherFilter(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	spiky := []int{10, 10, 10, 10, 1000}
+
+	for filter, expected := range map[string]int{board.AnalogFilterAverage: 208, board.AnalogFilterMedian: 10} {
+		t.Run(filter, func(t *testing.T) {
+			testReader := seqAnalog{values: spiky, lim: 200}
+			as := SmoothAnalogReader(&testReader, board.AnalogReaderConfig{
+				AverageOverMillis: 10,
+				SamplesPerSecond:  10000,
+				Filter:            filter,
+			}, logger)
+			defer func() {
+				test.That(t, as.Close(context.Background()), test.ShouldBeNil)
+			}()
+
+			testutils.WaitForAssertionWithSleep(t, 10*time.Millisecond, 200, func(tb testing.TB) {
+				tb.Helper()
+				v, err := as.Read(context.Background(), nil)
+				test.That(tb, err, test.ShouldEqual, errStopReading)
+				test.That(tb, v.Value, test.ShouldEqual, expected)
+			})
+		})
+	}
+
+	rm := newRollingMedian(4)
+	test.That(t, rm.Value(), test.ShouldEqual, 0)
+	// until the window fills up, only the samples added so far count
+	rm.Add(9)
+	rm.Add(11)
+	test.That(t, rm.Value(), test.ShouldEqual, 10)
+	for _, v := range []int{1, 7, 3, 100} {
+		rm.Add(v)
+	}
+	test.That(t, rm.Value(), test.ShouldEqual, 5)
+}
+
+func TestAnalogSmootherOversampleAndScale(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	testReader := seqAnalog{values: []int{0, 101}, lim: 20}
+	as := SmoothAnalogReader(&testReader, board.AnalogReaderConfig{
+		SamplesPerSecond: 1000,
+		Oversample:       2,
+		ReferenceVoltage: 5,
+		ResolutionBits:   10,
+	}, logger)
+	defer func() {
+		test.That(t, as.Close(context.Background()), test.ShouldBeNil)
+	}()
+
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		v, err := as.Read(context.Background(), nil)
+		test.That(tb, err, test.ShouldBeNil)
+		// each reading is the rounded mean of a pair of raw readings
+		test.That(tb, v.Value, test.ShouldEqual, 51)
+		test.That(tb, v.Min, test.ShouldEqual, 0)
+		test.That(tb, v.Max, test.ShouldEqual, 5)
+		test.That(tb, v.StepSize, test.ShouldAlmostEqual, 5.0/1024)
+	})
+
+	// without a resolution, the step size of the underlying reader is scaled to the new range
+	as.ResolutionBits = 0
+	v := as.scale(board.AnalogValue{Value: 3, Min: 0, Max: 3.3, StepSize: 0.33})
+	test.That(t, v.Max, test.ShouldEqual, 5)
+	test.That(t, v.StepSize, test.ShouldAlmostEqual, 0.5, 1e-6)
+}
@@ -7,7 +7,9 @@ import (
 
 	commonpb "go.viam.com/api/common/v1"
 	pb "go.viam.com/api/component/board/v1"
+	vprotoutils "go.viam.com/utils/protoutils"
 
+	firmwarepb "go.viam.com/rdk/proto/rdk/component/board/v1"
 	"go.viam.com/rdk/protoutils"
 	"go.viam.com/rdk/resource"
 )
@@ -27,9 +29,11 @@ var (
 	}
 )
 
-// serviceServer implements the BoardService from board.proto.
+// serviceServer implements the BoardService from board.proto, and the BoardFirmwareService
+// alongside it.
 type serviceServer struct {
 	pb.UnimplementedBoardServiceServer
+	firmwarepb.UnimplementedBoardFirmwareServiceServer
 	coll resource.APIResourceCollection[Board]
 }
 
@@ -294,7 +298,8 @@ func (s *serviceServer) StreamTicks(
 	}
 }
 
-// DoCommand receives arbitrary commands.
+// DoCommand receives arbitrary commands. Firmware commands are handled here for boards that can flash
+// firmware, as the board API has no methods for them.
 func (s *serviceServer) DoCommand(ctx context.Context,
 	req *commonpb.DoCommandRequest,
 ) (*commonpb.DoCommandResponse, error) {
@@ -302,6 +307,16 @@ func (s *serviceServer) DoCommand(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	if resp, handled, err := doFirmwareCommand(ctx, b, req.GetCommand().AsMap()); handled {
+		if err != nil {
+			return nil, err
+		}
+		pbRes, err := vprotoutils.StructToStructPb(resp)
+		if err != nil {
+			return nil, err
+		}
+		return &commonpb.DoCommandResponse{Result: pbRes}, nil
+	}
 	return protoutils.DoFromResourceServer(ctx, b, req)
 }
 
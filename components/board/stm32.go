@@ -0,0 +1,299 @@
+package board
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// The STM32 system memory bootloader's UART protocol, as described in ST application note AN3155.
+const (
+	stm32Sync = 0x7F
+	stm32Ack  = 0x79
+	stm32Nack = 0x1F
+
+	stm32CmdGet           = 0x00
+	stm32CmdReadMemory    = 0x11
+	stm32CmdWriteMemory   = 0x31
+	stm32CmdErase         = 0x43
+	stm32CmdExtendedErase = 0x44
+
+	// stm32FlashBase is the address of the start of the main flash.
+	stm32FlashBase = 0x08000000
+	// stm32MaxTransfer is the most bytes a single read or write command can carry.
+	stm32MaxTransfer = 256
+)
+
+const (
+	stm32ResetPulse    = 50 * time.Millisecond
+	stm32BootDelay     = 100 * time.Millisecond
+	stm32ReplyTimeout  = time.Second
+	stm32EraseTimeout  = 30 * time.Second
+	stm32SyncAttempts  = 5
+	stm32ErasedPadding = 0xFF
+)
+
+// An STM32Bootloader flashes an STM32 through its system memory bootloader over a UART. The
+// microcontroller is reset into the bootloader by driving its BOOT0 pin high while pulsing its
+// NRST pin low, and back into its application the same way with BOOT0 low. The UART must be set
+// to 8 data bits with even parity, at up to 115200 baud.
+type STM32Bootloader struct {
+	open      func() (io.ReadWriteCloser, error)
+	boot0     GPIOPin
+	reset     GPIOPin
+	flashSize int
+
+	port     io.ReadWriteCloser
+	eraseCmd byte
+}
+
+// NewSTM32Bootloader returns a bootloader for the STM32 with the given size of flash, whose UART
+// is opened by open each time it is flashed, and whose BOOT0 and NRST are wired to the given pins.
+func NewSTM32Bootloader(open func() (io.ReadWriteCloser, error), boot0, reset GPIOPin, flashSize int) *STM32Bootloader {
+	return &STM32Bootloader{open: open, boot0: boot0, reset: reset, flashSize: flashSize}
+}
+
+// Enter resets the STM32 into its bootloader and synchronizes with it.
+func (bl *STM32Bootloader) Enter(ctx context.Context) error {
+	if err := bl.resetInto(ctx, true); err != nil {
+		return err
+	}
+	port, err := bl.open()
+	if err != nil {
+		return errors.Wrap(err, "cannot open bootloader UART")
+	}
+	bl.port = port
+	if err := bl.sync(ctx); err != nil {
+		return multierr.Combine(err, bl.closePort())
+	}
+	if err := bl.readCommands(); err != nil {
+		return multierr.Combine(err, bl.closePort())
+	}
+	return nil
+}
+
+// Size returns the size of the flash.
+func (bl *STM32Bootloader) Size() int {
+	return bl.flashSize
+}
+
+// Read fills buf from the flash, starting at offset.
+func (bl *STM32Bootloader) Read(ctx context.Context, offset int, buf []byte) error {
+	for done := 0; done < len(buf); done += stm32MaxTransfer {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		chunk := buf[done:min(done+stm32MaxTransfer, len(buf))]
+		if err := bl.command(stm32CmdReadMemory); err != nil {
+			return err
+		}
+		if err := bl.sendAddress(offset + done); err != nil {
+			return err
+		}
+		n := byte(len(chunk) - 1)
+		if err := bl.sendWithAck(stm32ReplyTimeout, n, ^n); err != nil {
+			return err
+		}
+		if err := bl.readFull(chunk, stm32ReplyTimeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Erase erases the whole flash.
+func (bl *STM32Bootloader) Erase(ctx context.Context) error {
+	if err := bl.command(bl.eraseCmd); err != nil {
+		return err
+	}
+	if bl.eraseCmd == stm32CmdExtendedErase {
+		// the special page count 0xFFFF is a mass erase
+		return bl.sendWithAck(stm32EraseTimeout, 0xFF, 0xFF, 0x00)
+	}
+	return bl.sendWithAck(stm32EraseTimeout, 0xFF, 0x00)
+}
+
+// Write writes data to the erased flash, starting at offset, which must be word aligned.
+func (bl *STM32Bootloader) Write(ctx context.Context, offset int, data []byte) error {
+	if offset%4 != 0 {
+		return errors.Errorf("STM32 flash writes must be word aligned, not at offset %d", offset)
+	}
+	for done := 0; done < len(data); done += stm32MaxTransfer {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		chunk := data[done:min(done+stm32MaxTransfer, len(data))]
+		// writes must be whole words, so short chunks are padded with erased bytes
+		for len(chunk)%4 != 0 {
+			chunk = append(chunk[:len(chunk):len(chunk)], stm32ErasedPadding)
+		}
+		if err := bl.command(stm32CmdWriteMemory); err != nil {
+			return err
+		}
+		if err := bl.sendAddress(offset + done); err != nil {
+			return err
+		}
+		msg := make([]byte, 0, len(chunk)+2)
+		msg = append(msg, byte(len(chunk)-1))
+		msg = append(msg, chunk...)
+		msg = append(msg, xorChecksum(msg))
+		if err := bl.sendWithAck(stm32ReplyTimeout, msg...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Exit resets the STM32 into its application.
+func (bl *STM32Bootloader) Exit(ctx context.Context) error {
+	return multierr.Combine(bl.closePort(), bl.resetInto(ctx, false))
+}
+
+func (bl *STM32Bootloader) closePort() error {
+	if bl.port == nil {
+		return nil
+	}
+	err := bl.port.Close()
+	bl.port = nil
+	return err
+}
+
+// resetInto resets the STM32 into its bootloader if bootloader is true, or else its application.
+func (bl *STM32Bootloader) resetInto(ctx context.Context, bootloader bool) error {
+	if err := bl.boot0.Set(ctx, bootloader, nil); err != nil {
+		return errors.Wrap(err, "cannot set BOOT0")
+	}
+	if err := bl.reset.Set(ctx, false, nil); err != nil {
+		return errors.Wrap(err, "cannot reset STM32")
+	}
+	time.Sleep(stm32ResetPulse)
+	if err := bl.reset.Set(ctx, true, nil); err != nil {
+		return errors.Wrap(err, "cannot reset STM32")
+	}
+	time.Sleep(stm32BootDelay)
+	return nil
+}
+
+// sync sends the byte the bootloader detects the baud rate from. A bootloader that already
+// synchronized answers with a NACK, which is as good.
+func (bl *STM32Bootloader) sync(ctx context.Context) error {
+	var err error
+	for attempt := 0; attempt < stm32SyncAttempts; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if _, err = bl.port.Write([]byte{stm32Sync}); err != nil {
+			return err
+		}
+		var reply [1]byte
+		if err = bl.readFull(reply[:], stm32ReplyTimeout); err == nil {
+			if reply[0] == stm32Ack || reply[0] == stm32Nack {
+				return nil
+			}
+			err = errors.Errorf("unexpected reply 0x%02x", reply[0])
+		}
+	}
+	return errors.Wrap(err, "STM32 bootloader did not respond")
+}
+
+// readCommands asks the bootloader which commands it supports, to pick how to erase.
+func (bl *STM32Bootloader) readCommands() error {
+	if err := bl.command(stm32CmdGet); err != nil {
+		return err
+	}
+	var count [1]byte
+	if err := bl.readFull(count[:], stm32ReplyTimeout); err != nil {
+		return err
+	}
+	// the bootloader version, then the commands
+	reply := make([]byte, int(count[0])+1)
+	if err := bl.readFull(reply, stm32ReplyTimeout); err != nil {
+		return err
+	}
+	if err := bl.readAck(stm32ReplyTimeout); err != nil {
+		return err
+	}
+	bl.eraseCmd = 0
+	for _, cmd := range reply[1:] {
+		if cmd == stm32CmdErase || cmd == stm32CmdExtendedErase {
+			bl.eraseCmd = cmd
+		}
+	}
+	if bl.eraseCmd == 0 {
+		return errors.New("STM32 bootloader supports no erase command")
+	}
+	return nil
+}
+
+// command sends a command with its complement and waits for it to be accepted.
+func (bl *STM32Bootloader) command(cmd byte) error {
+	if err := bl.sendWithAck(stm32ReplyTimeout, cmd, ^cmd); err != nil {
+		return errors.Wrapf(err, "STM32 bootloader refused command 0x%02x", cmd)
+	}
+	return nil
+}
+
+// sendAddress sends the flash address of offset with its checksum.
+func (bl *STM32Bootloader) sendAddress(offset int) error {
+	addr := uint32(stm32FlashBase + offset)
+	msg := []byte{byte(addr >> 24), byte(addr >> 16), byte(addr >> 8), byte(addr)}
+	return bl.sendWithAck(stm32ReplyTimeout, append(msg, xorChecksum(msg))...)
+}
+
+func (bl *STM32Bootloader) sendWithAck(timeout time.Duration, msg ...byte) error {
+	if _, err := bl.port.Write(msg); err != nil {
+		return err
+	}
+	return bl.readAck(timeout)
+}
+
+func (bl *STM32Bootloader) readAck(timeout time.Duration) error {
+	var reply [1]byte
+	if err := bl.readFull(reply[:], timeout); err != nil {
+		return err
+	}
+	switch reply[0] {
+	case stm32Ack:
+		return nil
+	case stm32Nack:
+		return errors.New("STM32 bootloader sent NACK")
+	default:
+		return errors.Errorf("unexpected reply 0x%02x from STM32 bootloader", reply[0])
+	}
+}
+
+// readFull reads len(buf) bytes, failing if they do not all arrive within timeout. The port is
+// given a read deadline if it supports one, like a serial port opened as an os.File does.
+func (bl *STM32Bootloader) readFull(buf []byte, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	if d, ok := bl.port.(interface{ SetReadDeadline(time.Time) error }); ok {
+		if err := d.SetReadDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	for read := 0; read < len(buf); {
+		n, err := bl.port.Read(buf[read:])
+		read += n
+		switch {
+		case errors.Is(err, os.ErrDeadlineExceeded):
+			return errors.New("timed out waiting for STM32 bootloader")
+		case err != nil && !errors.Is(err, io.EOF):
+			return err
+		case read < len(buf) && time.Now().After(deadline):
+			return errors.New("timed out waiting for STM32 bootloader")
+		}
+	}
+	return nil
+}
+
+func xorChecksum(data []byte) byte {
+	var sum byte
+	for _, b := range data {
+		sum ^= b
+	}
+	return sum
+}
@@ -0,0 +1,244 @@
+package board_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/board"
+	"go.viam.com/rdk/components/board/fake"
+)
+
+// stm32Device simulates the UART of an STM32 in its bootloader, replying to each command as soon as
+// it has been written in full.
+type stm32Device struct {
+	flash    []byte
+	extended bool
+	nackNext bool
+
+	in     []byte
+	out    bytes.Buffer
+	state  func() bool
+	addr   int
+	closed bool
+}
+
+func newSTM32Device(size int, extended bool) *stm32Device {
+	dev := &stm32Device{flash: bytes.Repeat([]byte{0xFF}, size), extended: extended}
+	dev.state = dev.idle
+	return dev
+}
+
+func (dev *stm32Device) Write(p []byte) (int, error) {
+	dev.in = append(dev.in, p...)
+	// each state consumes what it can and reports whether there may be more to do
+	for progressed := true; progressed; {
+		progressed = dev.state()
+	}
+	return len(p), nil
+}
+
+func (dev *stm32Device) Read(p []byte) (int, error) {
+	if dev.out.Len() == 0 {
+		return 0, io.EOF
+	}
+	return dev.out.Read(p)
+}
+
+func (dev *stm32Device) Close() error {
+	dev.closed = true
+	return nil
+}
+
+// take consumes n written bytes, if that many have been written.
+func (dev *stm32Device) take(n int) ([]byte, bool) {
+	if len(dev.in) < n {
+		return nil, false
+	}
+	msg := dev.in[:n]
+	dev.in = dev.in[n:]
+	return msg, true
+}
+
+func (dev *stm32Device) reply(ok bool) {
+	if ok && !dev.nackNext {
+		dev.out.WriteByte(0x79)
+		return
+	}
+	dev.nackNext = false
+	dev.out.WriteByte(0x1F)
+	dev.state = dev.idle
+}
+
+func (dev *stm32Device) idle() bool {
+	if len(dev.in) > 0 && dev.in[0] == 0x7F {
+		dev.take(1)
+		dev.reply(true)
+		return true
+	}
+	msg, ok := dev.take(2)
+	if !ok {
+		return false
+	}
+	cmd := msg[0]
+	if msg[1] != ^cmd {
+		dev.reply(false)
+		return true
+	}
+	eraseCmd := byte(0x43)
+	if dev.extended {
+		eraseCmd = 0x44
+	}
+	switch cmd {
+	case 0x00:
+		dev.reply(true)
+		commands := []byte{0x00, 0x01, 0x02, 0x11, 0x21, 0x31, eraseCmd}
+		dev.out.WriteByte(byte(len(commands)))
+		dev.out.WriteByte(0x31)
+		dev.out.Write(commands)
+		dev.reply(true)
+	case 0x11:
+		dev.reply(true)
+		dev.state = dev.address(dev.readLength)
+	case 0x31:
+		dev.reply(true)
+		dev.state = dev.address(dev.writeData)
+	case eraseCmd:
+		dev.reply(true)
+		dev.state = dev.erase
+	default:
+		dev.reply(false)
+	}
+	return true
+}
+
+func (dev *stm32Device) address(next func() bool) func() bool {
+	return func() bool {
+		msg, ok := dev.take(5)
+		if !ok {
+			return false
+		}
+		dev.addr = int(msg[0])<<24 | int(msg[1])<<16 | int(msg[2])<<8 | int(msg[3]) - 0x08000000
+		valid := msg[4] == msg[0]^msg[1]^msg[2]^msg[3] && dev.addr >= 0 && dev.addr < len(dev.flash)
+		dev.reply(valid)
+		if valid {
+			dev.state = next
+		}
+		return true
+	}
+}
+
+func (dev *stm32Device) readLength() bool {
+	msg, ok := dev.take(2)
+	if !ok {
+		return false
+	}
+	n := int(msg[0]) + 1
+	valid := msg[1] == ^msg[0] && dev.addr+n <= len(dev.flash)
+	dev.reply(valid)
+	if valid {
+		dev.out.Write(dev.flash[dev.addr : dev.addr+n])
+		dev.state = dev.idle
+	}
+	return true
+}
+
+func (dev *stm32Device) writeData() bool {
+	if len(dev.in) == 0 || len(dev.in) < int(dev.in[0])+3 {
+		return false
+	}
+	msg, _ := dev.take(int(dev.in[0]) + 3)
+	var sum byte
+	for _, b := range msg[:len(msg)-1] {
+		sum ^= b
+	}
+	data := msg[1 : len(msg)-1]
+	valid := sum == msg[len(msg)-1] && len(data)%4 == 0 && dev.addr+len(data) <= len(dev.flash)
+	dev.reply(valid)
+	if valid {
+		for i, b := range data {
+			// flash bits can only be cleared without erasing
+			dev.flash[dev.addr+i] &= b
+		}
+		dev.state = dev.idle
+	}
+	return true
+}
+
+func (dev *stm32Device) erase() bool {
+	var msg []byte
+	var ok bool
+	if dev.extended {
+		msg, ok = dev.take(3)
+	} else {
+		msg, ok = dev.take(2)
+	}
+	if !ok {
+		return false
+	}
+	valid := bytes.Equal(msg, []byte{0xFF, 0xFF, 0x00}[:len(msg)]) || bytes.Equal(msg, []byte{0xFF, 0x00})
+	dev.reply(valid)
+	if valid {
+		for i := range dev.flash {
+			dev.flash[i] = 0xFF
+		}
+		dev.state = dev.idle
+	}
+	return true
+}
+
+func TestSTM32Bootloader(t *testing.T) {
+	ctx := context.Background()
+	fakeBoard := &fake.Board{GPIOPins: map[string]*fake.GPIOPin{}}
+	boot0, err := fakeBoard.GPIOPinByName("boot0")
+	test.That(t, err, test.ShouldBeNil)
+	reset, err := fakeBoard.GPIOPinByName("reset")
+	test.That(t, err, test.ShouldBeNil)
+
+	for _, extended := range []bool{false, true} {
+		dev := newSTM32Device(16<<10, extended)
+		opened := 0
+		bl := board.NewSTM32Bootloader(func() (io.ReadWriteCloser, error) {
+			opened++
+			dev.closed = false
+			return dev, nil
+		}, boot0, reset, len(dev.flash))
+
+		original := bytes.Repeat([]byte{1, 2, 3, 4}, 1500)
+		copy(dev.flash, original)
+		// an image whose length is not a whole number of words
+		image := bytes.Repeat([]byte{5, 6, 7}, 3001)
+		previous, err := board.FlashBootloader(ctx, bl, image, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, previous, test.ShouldResemble, original)
+		test.That(t, dev.flash[:len(image)], test.ShouldResemble, image)
+		test.That(t, dev.flash[len(image)], test.ShouldEqual, byte(0xFF))
+		test.That(t, opened, test.ShouldEqual, 1)
+		test.That(t, dev.closed, test.ShouldBeTrue)
+
+		// the STM32 is left running its application
+		high, err := boot0.Get(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, high, test.ShouldBeFalse)
+		high, err = reset.Get(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, high, test.ShouldBeTrue)
+	}
+
+	t.Run("refused commands fail", func(t *testing.T) {
+		dev := newSTM32Device(4<<10, true)
+		bl := board.NewSTM32Bootloader(func() (io.ReadWriteCloser, error) {
+			return dev, nil
+		}, boot0, reset, len(dev.flash))
+		test.That(t, bl.Enter(ctx), test.ShouldBeNil)
+		dev.nackNext = true
+		err := bl.Write(ctx, 0, []byte{1, 2, 3, 4})
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "NACK")
+		test.That(t, bl.Write(ctx, 2, []byte{1, 2}), test.ShouldNotBeNil)
+		test.That(t, bl.Exit(ctx), test.ShouldBeNil)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"image"
 	"io"
 	"os"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -22,6 +23,7 @@ import (
 	goprotoutils "go.viam.com/utils/protoutils"
 	"go.viam.com/utils/rpc"
 	"golang.org/x/exp/slices"
+	"google.golang.org/grpc/metadata"
 
 	"go.viam.com/rdk/components/camera/rtppassthrough"
 	"go.viam.com/rdk/data"
@@ -72,6 +74,8 @@ type client struct {
 	subGenerationID  int
 	associatedSubs   map[int][]rtppassthrough.SubscriptionID
 	trackClosed      <-chan struct{}
+
+	link linkEstimator
 }
 
 // NewClientFromConn constructs a new Client from connection passed in.
@@ -178,12 +182,21 @@ func (c *client) Stream(
 func (c *client) Image(ctx context.Context, mimeType string, extra map[string]interface{}) ([]byte, ImageMetadata, error) {
 	ctx, span := trace.StartSpan(ctx, "camera::client::Image")
 	defer span.End()
+	// With format hints, the client picks the format when none is asked for and the server transcodes to it.
+	if hints, ok := imageFormatHintsFromContext(ctx); ok {
+		quality := hints.JPEGQuality
+		if mimeType == "" {
+			mimeType, quality = negotiateImageFormat(hints, c.link.estimate())
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, ImageNegotiationMetadataKey, strconv.Itoa(quality))
+	}
 	expectedType, _ := utils.CheckLazyMIMEType(mimeType)
 
 	convertedExtra, err := goprotoutils.StructToStructPb(extra)
 	if err != nil {
 		return nil, ImageMetadata{}, err
 	}
+	start := time.Now()
 	resp, err := c.client.GetImage(ctx, &pb.GetImageRequest{
 		Name:     c.name,
 		MimeType: expectedType,
@@ -192,6 +205,7 @@ func (c *client) Image(ctx context.Context, mimeType string, extra map[string]in
 	if err != nil {
 		return nil, ImageMetadata{}, err
 	}
+	c.link.record(len(resp.Image), time.Since(start))
 	if len(resp.Image) == 0 {
 		return nil, ImageMetadata{}, errors.New("received empty bytes from client GetImage")
 	}
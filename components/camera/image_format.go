@@ -0,0 +1,171 @@
+package camera
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image/jpeg"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/utils"
+)
+
+// ImageNegotiationMetadataKey is the gRPC metadata key a client sets on GetImage requests to ask the server to
+// transcode the image to the requested MIME type when the camera produces a different one. Its value is the JPEG
+// quality, from 1 to 100, to encode with, or 0 for the default.
+const ImageNegotiationMetadataKey = "viam-image-negotiation"
+
+const (
+	// Below this throughput, compressed formats are preferred over raw ones.
+	slowLinkBytesPerSec = 4 << 20
+	// Below this throughput, JPEG quality is also lowered unless the caller picked one.
+	verySlowLinkBytesPerSec = 512 << 10
+	slowLinkJPEGQuality     = 50
+	// linkEstimateWeight is how much each call moves the throughput estimate.
+	linkEstimateWeight = 0.25
+)
+
+// ImageFormatHints tell a camera client which formats a caller can decode, so that the client can pick the
+// format that best suits the link to the camera on each call to Image that does not ask for a MIME type.
+type ImageFormatHints struct {
+	// Accept lists the MIME types that can be decoded, most preferred first.
+	Accept []string
+	// JPEGQuality is the quality, from 1 to 100, to encode JPEG images with. If zero, it is lowered
+	// on slow links and otherwise left to the server.
+	JPEGQuality int
+}
+
+type ctxKeyImageFormatHints struct{}
+
+// WithImageFormatHints attaches format hints to the context for camera clients to negotiate with.
+func WithImageFormatHints(ctx context.Context, hints ImageFormatHints) context.Context {
+	return context.WithValue(ctx, ctxKeyImageFormatHints{}, hints)
+}
+
+func imageFormatHintsFromContext(ctx context.Context) (ImageFormatHints, bool) {
+	hints, ok := ctx.Value(ctxKeyImageFormatHints{}).(ImageFormatHints)
+	return hints, ok
+}
+
+// negotiateImageFormat picks the MIME type and JPEG quality to ask for given the hints and the estimated
+// throughput of the link, where zero means it is not known yet.
+func negotiateImageFormat(hints ImageFormatHints, bytesPerSec float64) (string, int) {
+	if len(hints.Accept) == 0 {
+		return "", hints.JPEGQuality
+	}
+	mimeType := hints.Accept[0]
+	if bytesPerSec > 0 && bytesPerSec < slowLinkBytesPerSec {
+		for _, accepted := range hints.Accept {
+			if accepted == utils.MimeTypeJPEG || accepted == utils.MimeTypePNG {
+				mimeType = accepted
+				break
+			}
+		}
+	}
+	quality := hints.JPEGQuality
+	if quality == 0 && mimeType == utils.MimeTypeJPEG && bytesPerSec > 0 && bytesPerSec < verySlowLinkBytesPerSec {
+		quality = slowLinkJPEGQuality
+	}
+	return mimeType, quality
+}
+
+// linkEstimator keeps a moving average of the throughput of image calls. It includes the time taken to
+// capture images, so it underestimates the link itself, which errs on the side of smaller images.
+type linkEstimator struct {
+	mu          sync.Mutex
+	bytesPerSec float64
+}
+
+func (l *linkEstimator) record(n int, took time.Duration) {
+	if n == 0 || took <= 0 {
+		return
+	}
+	sample := float64(n) / took.Seconds()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.bytesPerSec == 0 {
+		l.bytesPerSec = sample
+		return
+	}
+	l.bytesPerSec += linkEstimateWeight * (sample - l.bytesPerSec)
+}
+
+func (l *linkEstimator) estimate() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.bytesPerSec
+}
+
+// negotiatedQuality returns the JPEG quality from the incoming request's metadata, and whether the client
+// asked for negotiation at all.
+func negotiatedQuality(ctx context.Context) (int, bool, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, false, nil
+	}
+	values := md.Get(ImageNegotiationMetadataKey)
+	if len(values) == 0 {
+		return 0, false, nil
+	}
+	quality, err := strconv.Atoi(values[0])
+	if err != nil || quality < 0 || quality > 100 {
+		return 0, false, errors.Errorf("invalid %s %q, must be a JPEG quality from 0 to 100", ImageNegotiationMetadataKey, values[0])
+	}
+	return quality, true, nil
+}
+
+// transcodeKey identifies identical negotiated requests, which share one capture and transcode.
+func transcodeKey(name, mimeType string, quality int, extra map[string]interface{}) (string, error) {
+	extraJSON, err := json.Marshal(extra)
+	if err != nil {
+		return "", err
+	}
+	return name + "\x00" + mimeType + "\x00" + strconv.Itoa(quality) + "\x00" + string(extraJSON), nil
+}
+
+// transcodeImage converts an image to the requested MIME type and JPEG quality. Depth images are only
+// transcoded between depth formats, and color images between color formats; anything else is returned as is.
+func transcodeImage(ctx context.Context, data []byte, fromMIME, toMIME string, quality int) ([]byte, string, error) {
+	fromMIME, _ = utils.CheckLazyMIMEType(fromMIME)
+	toMIME, _ = utils.CheckLazyMIMEType(toMIME)
+	if toMIME == "" || (fromMIME == toMIME && (toMIME != utils.MimeTypeJPEG || quality == 0)) {
+		return data, fromMIME, nil
+	}
+	fromDepth := fromMIME == utils.MimeTypeRawDepth
+	switch toMIME {
+	case utils.MimeTypeRawDepth:
+		if !fromDepth && fromMIME != utils.MimeTypePNG {
+			return data, fromMIME, nil
+		}
+	case utils.MimeTypeJPEG, utils.MimeTypeRawRGBA:
+		if fromDepth {
+			return data, fromMIME, nil
+		}
+	case utils.MimeTypePNG:
+	default:
+		return data, fromMIME, nil
+	}
+
+	img, err := rimage.DecodeImage(ctx, data, fromMIME)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "could not decode %s image to transcode it", fromMIME)
+	}
+	if toMIME == utils.MimeTypeJPEG && quality > 0 {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), toMIME, nil
+	}
+	out, err := rimage.EncodeImage(ctx, img, toMIME)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "could not encode image as %s", toMIME)
+	}
+	return out, toMIME, nil
+}
@@ -0,0 +1,81 @@
+package camera
+
+import (
+	"context"
+	"image"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/utils"
+)
+
+func TestNegotiateImageFormat(t *testing.T) {
+	hints := ImageFormatHints{Accept: []string{utils.MimeTypeRawRGBA, utils.MimeTypeJPEG, utils.MimeTypePNG}}
+
+	// unknown and fast links get the preferred format
+	mimeType, quality := negotiateImageFormat(hints, 0)
+	test.That(t, mimeType, test.ShouldEqual, utils.MimeTypeRawRGBA)
+	test.That(t, quality, test.ShouldEqual, 0)
+	mimeType, _ = negotiateImageFormat(hints, 100<<20)
+	test.That(t, mimeType, test.ShouldEqual, utils.MimeTypeRawRGBA)
+
+	// slow links get the preferred compressed format, and very slow ones a lower quality
+	mimeType, quality = negotiateImageFormat(hints, 1<<20)
+	test.That(t, mimeType, test.ShouldEqual, utils.MimeTypeJPEG)
+	test.That(t, quality, test.ShouldEqual, 0)
+	mimeType, quality = negotiateImageFormat(hints, 100<<10)
+	test.That(t, mimeType, test.ShouldEqual, utils.MimeTypeJPEG)
+	test.That(t, quality, test.ShouldEqual, slowLinkJPEGQuality)
+	hints.JPEGQuality = 90
+	_, quality = negotiateImageFormat(hints, 100<<10)
+	test.That(t, quality, test.ShouldEqual, 90)
+
+	depthHints := ImageFormatHints{Accept: []string{utils.MimeTypeRawDepth, utils.MimeTypePNG}}
+	mimeType, _ = negotiateImageFormat(depthHints, 1<<20)
+	test.That(t, mimeType, test.ShouldEqual, utils.MimeTypePNG)
+
+	mimeType, _ = negotiateImageFormat(ImageFormatHints{}, 1<<20)
+	test.That(t, mimeType, test.ShouldEqual, "")
+}
+
+func TestLinkEstimator(t *testing.T) {
+	var l linkEstimator
+	test.That(t, l.estimate(), test.ShouldEqual, 0)
+	l.record(1000, time.Second)
+	test.That(t, l.estimate(), test.ShouldEqual, 1000)
+	l.record(5000, time.Second)
+	test.That(t, l.estimate(), test.ShouldEqual, 2000)
+	l.record(0, time.Second)
+	test.That(t, l.estimate(), test.ShouldEqual, 2000)
+}
+
+func TestTranscodeImage(t *testing.T) {
+	ctx := context.Background()
+	pngBytes, err := rimage.EncodeImage(ctx, image.NewRGBA(image.Rect(0, 0, 8, 8)), utils.MimeTypePNG)
+	test.That(t, err, test.ShouldBeNil)
+
+	out, mimeType, err := transcodeImage(ctx, pngBytes, utils.MimeTypePNG, utils.MimeTypeJPEG, 30)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, mimeType, test.ShouldEqual, utils.MimeTypeJPEG)
+	_, err = rimage.DecodeImage(ctx, out, utils.MimeTypeJPEG)
+	test.That(t, err, test.ShouldBeNil)
+
+	out, mimeType, err = transcodeImage(ctx, pngBytes, utils.MimeTypePNG, utils.WithLazyMIMEType(utils.MimeTypePNG), 30)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, mimeType, test.ShouldEqual, utils.MimeTypePNG)
+	test.That(t, out, test.ShouldResemble, pngBytes)
+
+	depthBytes, err := rimage.EncodeImage(ctx, rimage.NewEmptyDepthMap(8, 8), utils.MimeTypeRawDepth)
+	test.That(t, err, test.ShouldBeNil)
+	// depth is not turned into color
+	out, mimeType, err = transcodeImage(ctx, depthBytes, utils.MimeTypeRawDepth, utils.MimeTypeJPEG, 0)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, mimeType, test.ShouldEqual, utils.MimeTypeRawDepth)
+	test.That(t, out, test.ShouldResemble, depthBytes)
+	_, mimeType, err = transcodeImage(ctx, depthBytes, utils.MimeTypeRawDepth, utils.MimeTypePNG, 0)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, mimeType, test.ShouldEqual, utils.MimeTypePNG)
+}
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"image"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.opencensus.io/trace"
 	commonpb "go.viam.com/api/common/v1"
 	pb "go.viam.com/api/component/camera/v1"
+	"golang.org/x/sync/singleflight"
 	"google.golang.org/genproto/googleapis/api/httpbody"
 
 	"go.viam.com/rdk/logging"
@@ -21,6 +23,11 @@ import (
 	"go.viam.com/rdk/utils"
 )
 
+// negotiatedImageTimeout bounds capturing and transcoding an image shared between negotiated requests,
+// which is not cancelled by any one of them going away, when the request that starts it has no deadline
+// of its own. Requests served by a robot have the deadline of their method policy.
+const negotiatedImageTimeout = 10 * time.Second
+
 // serviceServer implements the CameraService from camera.proto.
 type serviceServer struct {
 	pb.UnimplementedCameraServiceServer
@@ -29,6 +36,9 @@ type serviceServer struct {
 	imgTypesMu sync.RWMutex
 	imgTypes   map[string]ImageType
 	logger     logging.Logger
+
+	// transcodes shares negotiated images between identical requests made at the same time.
+	transcodes singleflight.Group
 }
 
 // NewRPCServiceServer constructs an camera gRPC service server.
@@ -84,6 +94,14 @@ func (s *serviceServer) GetImage(
 	}
 	req.MimeType = utils.WithLazyMIMEType(req.MimeType)
 
+	quality, negotiate, err := negotiatedQuality(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if negotiate {
+		return s.getNegotiatedImage(ctx, cam, req, quality)
+	}
+
 	resBytes, resMetadata, err := cam.Image(ctx, req.MimeType, req.Extra.AsMap())
 	if err != nil {
 		return nil, err
@@ -95,6 +113,57 @@ func (s *serviceServer) GetImage(
 	return &pb.GetImageResponse{MimeType: actualMIME, Image: resBytes}, nil
 }
 
+// getNegotiatedImage returns an image in the requested MIME type, transcoding it if the camera produced another.
+// Identical requests made while one is in progress share its result, so that a camera viewed by many clients
+// is only captured and transcoded once.
+func (s *serviceServer) getNegotiatedImage(
+	ctx context.Context,
+	cam Camera,
+	req *pb.GetImageRequest,
+	quality int,
+) (*pb.GetImageResponse, error) {
+	extra := req.Extra.AsMap()
+	key, err := transcodeKey(req.Name, req.MimeType, quality, extra)
+	if err != nil {
+		return nil, err
+	}
+	results := s.transcodes.DoChan(key, func() (interface{}, error) {
+		// the image is shared by every caller waiting on it, so the first caller going away must not
+		// cancel it for the rest, though it keeps that caller's deadline.
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			deadline = time.Now().Add(negotiatedImageTimeout)
+		}
+		ctx, cancel := context.WithDeadline(context.WithoutCancel(ctx), deadline)
+		defer cancel()
+		resBytes, resMetadata, err := cam.Image(ctx, req.MimeType, extra)
+		if err != nil {
+			return nil, err
+		}
+		if len(resBytes) == 0 {
+			return nil, fmt.Errorf("received empty bytes from Image method of %s", req.Name)
+		}
+		out, mimeType, err := transcodeImage(ctx, resBytes, resMetadata.MimeType, req.MimeType, quality)
+		if err != nil {
+			s.logger.CDebugw(ctx, "could not transcode image, returning it as the camera produced it", "name", req.Name, "error", err)
+			out, mimeType = resBytes, resMetadata.MimeType
+		}
+		actualMIME, _ := utils.CheckLazyMIMEType(mimeType)
+		return &pb.GetImageResponse{MimeType: actualMIME, Image: out}, nil
+	})
+	var result singleflight.Result
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result = <-results:
+	}
+	if result.Err != nil {
+		return nil, result.Err
+	}
+	resp := result.Val.(*pb.GetImageResponse)
+	return &pb.GetImageResponse{MimeType: resp.MimeType, Image: resp.Image}, nil
+}
+
 // GetImages returns a list of images and metadata from a camera of the underlying robot.
 func (s *serviceServer) GetImages(
 	ctx context.Context,
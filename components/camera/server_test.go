@@ -8,12 +8,14 @@ import (
 	"image/jpeg"
 	"image/png"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	pb "go.viam.com/api/component/camera/v1"
 	"go.viam.com/test"
 	goprotoutils "go.viam.com/utils/protoutils"
+	"google.golang.org/grpc/metadata"
 
 	"go.viam.com/rdk/components/camera"
 	"go.viam.com/rdk/data"
@@ -587,3 +589,76 @@ func TestGetImageRace(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestServerNegotiatedImage(t *testing.T) {
+	cameraServer, injectCamera, _, _, err := newServer()
+	test.That(t, err, test.ShouldBeNil)
+
+	var imgBuf bytes.Buffer
+	test.That(t, png.Encode(&imgBuf, image.NewRGBA(image.Rect(0, 0, 4, 4))), test.ShouldBeNil)
+	injectCamera.ImageFunc = func(ctx context.Context, mimeType string, extra map[string]interface{}) ([]byte, camera.ImageMetadata, error) {
+		return imgBuf.Bytes(), camera.ImageMetadata{MimeType: utils.MimeTypePNG}, nil
+	}
+
+	// without negotiation, the image is returned as the camera produced it
+	resp, err := cameraServer.GetImage(context.Background(), &pb.GetImageRequest{Name: testCameraName, MimeType: utils.MimeTypeJPEG})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.MimeType, test.ShouldEqual, utils.MimeTypePNG)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(camera.ImageNegotiationMetadataKey, "40"))
+	resp, err = cameraServer.GetImage(ctx, &pb.GetImageRequest{Name: testCameraName, MimeType: utils.MimeTypeJPEG})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.MimeType, test.ShouldEqual, utils.MimeTypeJPEG)
+	_, err = jpeg.Decode(bytes.NewReader(resp.Image))
+	test.That(t, err, test.ShouldBeNil)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(camera.ImageNegotiationMetadataKey, "best"))
+	_, err = cameraServer.GetImage(ctx, &pb.GetImageRequest{Name: testCameraName, MimeType: utils.MimeTypeJPEG})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	t.Run("callers going away do not cancel a shared image", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		var captures atomic.Int32
+		injectCamera.ImageFunc = func(
+			ctx context.Context, mimeType string, extra map[string]interface{},
+		) ([]byte, camera.ImageMetadata, error) {
+			if captures.Add(1) == 1 {
+				close(started)
+			}
+			select {
+			case <-ctx.Done():
+				return nil, camera.ImageMetadata{}, ctx.Err()
+			case <-release:
+			}
+			return imgBuf.Bytes(), camera.ImageMetadata{MimeType: utils.MimeTypePNG}, nil
+		}
+		md := metadata.Pairs(camera.ImageNegotiationMetadataKey, "40")
+		req := &pb.GetImageRequest{Name: testCameraName, MimeType: utils.MimeTypeJPEG}
+
+		firstCtx, cancelFirst := context.WithCancel(metadata.NewIncomingContext(context.Background(), md))
+		firstErr := make(chan error, 1)
+		go func() {
+			_, err := cameraServer.GetImage(firstCtx, req)
+			firstErr <- err
+		}()
+		<-started
+
+		secondResp := make(chan *pb.GetImageResponse, 1)
+		secondErr := make(chan error, 1)
+		go func() {
+			resp, err := cameraServer.GetImage(metadata.NewIncomingContext(context.Background(), md), req)
+			secondResp <- resp
+			secondErr <- err
+		}()
+
+		// give the second request time to join the first
+		time.Sleep(100 * time.Millisecond)
+		cancelFirst()
+		test.That(t, <-firstErr, test.ShouldBeError, context.Canceled)
+		close(release)
+		test.That(t, <-secondErr, test.ShouldBeNil)
+		test.That(t, (<-secondResp).MimeType, test.ShouldEqual, utils.MimeTypeJPEG)
+		test.That(t, captures.Load(), test.ShouldEqual, 1)
+	})
+}
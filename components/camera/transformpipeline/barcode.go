@@ -0,0 +1,178 @@
+package transformpipeline
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"sync"
+	"time"
+
+	"github.com/fogleman/gg"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/utils"
+	visbarcode "go.viam.com/rdk/vision/barcode"
+)
+
+// the DoCommands that return the barcodes read from the last image.
+const (
+	getBarcodesCommand = "get_barcodes"
+	getReadingsCommand = "get_readings"
+)
+
+// barcodeConfig are the attributes for a barcode transform, which reads the 1D barcodes and QR
+// codes in each image.
+type barcodeConfig struct {
+	// Formats are the formats to read, out of qr_code, ean_13, ean_8, upc_a and code_128. All of
+	// them are read if it is empty.
+	Formats []string `json:"formats,omitempty"`
+	// DrawBoxes draws a box around each barcode labeled with its payload. It defaults to true.
+	DrawBoxes bool `json:"draw_boxes,omitempty"`
+	// BoxColor is the color of the boxes and labels, and defaults to green.
+	BoxColor string `json:"box_color,omitempty"`
+}
+
+type barcodeSource struct {
+	src       camera.VideoSource
+	formats   []visbarcode.Format
+	drawBoxes bool
+	boxColor  color.Color
+
+	mu       sync.Mutex
+	lastRead time.Time
+	last     []visbarcode.Barcode
+}
+
+// newBarcodeTransform creates a new transform that reads the barcodes in images.
+func newBarcodeTransform(
+	ctx context.Context, source camera.VideoSource, stream camera.ImageType, am utils.AttributeMap,
+) (camera.VideoSource, camera.ImageType, error) {
+	if stream == camera.DepthStream {
+		return nil, camera.UnspecifiedStream, errors.New("barcode transform cannot read barcodes in depth images")
+	}
+	bs, err := newBarcodeSource(source, am)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	// boxes are drawn over the image without moving its pixels, so the intrinsics still hold
+	props, err := propsFromVideoSource(ctx, source)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	cameraModel := &transform.PinholeCameraModel{
+		PinholeCameraIntrinsics: props.IntrinsicParams,
+		Distortion:              props.DistortionParams,
+	}
+	src, err := camera.NewVideoSourceFromReader(ctx, bs, cameraModel, camera.ColorStream)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	return src, camera.ColorStream, err
+}
+
+func newBarcodeSource(source camera.VideoSource, am utils.AttributeMap) (*barcodeSource, error) {
+	conf, err := resource.TransformAttributeMap[*barcodeConfig](am)
+	if err != nil {
+		return nil, err
+	}
+	if !am.Has("draw_boxes") {
+		conf.DrawBoxes = true
+	}
+	bs := &barcodeSource{src: source, drawBoxes: conf.DrawBoxes, boxColor: rimage.Green}
+	for _, f := range conf.Formats {
+		format, err := visbarcode.ParseFormat(f)
+		if err != nil {
+			return nil, err
+		}
+		bs.formats = append(bs.formats, format)
+	}
+	if conf.BoxColor != "" {
+		if bs.boxColor, err = rimage.NewColorFromHex(conf.BoxColor); err != nil {
+			return nil, errors.Wrap(err, "invalid barcode box_color")
+		}
+	}
+	return bs, nil
+}
+
+// Read reads the barcodes in the next image of the source, and draws them on it.
+func (bs *barcodeSource) Read(ctx context.Context) (image.Image, func(), error) {
+	ctx, span := trace.StartSpan(ctx, "camera::transformpipeline::barcode::Read")
+	defer span.End()
+	orig, release, err := camera.ReadImage(ctx, bs.src)
+	if err != nil {
+		return nil, nil, err
+	}
+	barcodes := visbarcode.Decode(orig, bs.formats...)
+	bs.mu.Lock()
+	bs.lastRead = time.Now()
+	bs.last = barcodes
+	bs.mu.Unlock()
+
+	if !bs.drawBoxes || len(barcodes) == 0 {
+		return orig, release, nil
+	}
+	dc := gg.NewContextForImage(orig)
+	for _, b := range barcodes {
+		box := b.BoundingBox()
+		rimage.DrawRectangleEmpty(dc, box, bs.boxColor, 2)
+		rimage.DrawString(dc, b.Payload, image.Pt(box.Min.X, box.Max.Y+2), bs.boxColor, 16)
+	}
+	return dc.Image(), release, nil
+}
+
+// DoCommand returns the barcodes read from the last image. The "get_barcodes" command returns each
+// barcode with its format, payload, corners and bounding box, and the "get_readings" command
+// returns their payloads in the shape of sensor readings. Barcodes are only read when images are
+// read, so a transform that is not being read has not read any.
+func (bs *barcodeSource) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	var lastRead interface{}
+	if !bs.lastRead.IsZero() {
+		lastRead = bs.lastRead.Format(time.RFC3339Nano)
+	}
+	_, getBarcodes := cmd[getBarcodesCommand]
+	_, getReadings := cmd[getReadingsCommand]
+	switch {
+	case getBarcodes:
+		barcodes := make([]interface{}, 0, len(bs.last))
+		for _, b := range bs.last {
+			corners := make([]interface{}, 0, len(b.Corners))
+			for _, c := range b.Corners {
+				corners = append(corners, []interface{}{c.X, c.Y})
+			}
+			box := b.BoundingBox()
+			barcodes = append(barcodes, map[string]interface{}{
+				"format":  string(b.Format),
+				"payload": b.Payload,
+				"corners": corners,
+				"box":     map[string]interface{}{"x_min": box.Min.X, "y_min": box.Min.Y, "x_max": box.Max.X, "y_max": box.Max.Y},
+			})
+		}
+		return map[string]interface{}{"time": lastRead, "barcodes": barcodes}, nil
+	case getReadings:
+		payloads := make([]interface{}, 0, len(bs.last))
+		formats := make([]interface{}, 0, len(bs.last))
+		for _, b := range bs.last {
+			payloads = append(payloads, b.Payload)
+			formats = append(formats, string(b.Format))
+		}
+		return map[string]interface{}{
+			"time":     lastRead,
+			"count":    len(bs.last),
+			"payloads": payloads,
+			"formats":  formats,
+		}, nil
+	default:
+		return nil, resource.ErrDoUnimplemented
+	}
+}
+
+func (bs *barcodeSource) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,122 @@
+package transformpipeline
+
+import (
+	"context"
+	"image"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/camera/fake"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/utils"
+)
+
+// toteImage returns the 320x240 image of a version 1 QR code of "TOTE-0042" shared by the barcode
+// tests, drawn at 4 pixels per module with its top left corner at (100, 60).
+func toteImage(t *testing.T) image.Image {
+	t.Helper()
+	img, err := rimage.ReadImageFromFile(utils.ResolveFile("vision/barcode/data/tote_qr.png"))
+	test.That(t, err, test.ShouldBeNil)
+	return img
+}
+
+// hasGreenPixel returns whether any pixel within rect is mostly green.
+func hasGreenPixel(img image.Image, rect image.Rectangle) bool {
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			if r, g, b, _ := img.At(x, y).RGBA(); g > 2*r && g > 2*b {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestBarcode(t *testing.T) {
+	ctx := context.Background()
+	source, err := camera.NewVideoSourceFromReader(ctx, &fake.StaticSource{ColorImg: toteImage(t)}, nil, camera.ColorStream)
+	test.That(t, err, test.ShouldBeNil)
+
+	bs, err := newBarcodeSource(source, utils.AttributeMap{})
+	test.That(t, err, test.ShouldBeNil)
+
+	// nothing has been read before the first image
+	resp, err := bs.DoCommand(ctx, map[string]interface{}{getBarcodesCommand: true})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["time"], test.ShouldBeNil)
+	test.That(t, resp["barcodes"], test.ShouldBeEmpty)
+
+	out, _, err := bs.Read(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, out.Bounds(), test.ShouldResemble, image.Rect(0, 0, 320, 240))
+	// the box is drawn around the code, with its payload below it
+	test.That(t, hasGreenPixel(out, image.Rect(90, 50, 110, 70)), test.ShouldBeTrue)
+	test.That(t, hasGreenPixel(out, image.Rect(100, 146, 180, 170)), test.ShouldBeTrue)
+	test.That(t, hasGreenPixel(out, image.Rect(200, 0, 320, 240)), test.ShouldBeFalse)
+
+	resp, err = bs.DoCommand(ctx, map[string]interface{}{getBarcodesCommand: true})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["time"], test.ShouldNotBeNil)
+	barcodes, ok := resp["barcodes"].([]interface{})
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, barcodes, test.ShouldHaveLength, 1)
+	read, ok := barcodes[0].(map[string]interface{})
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, read["format"], test.ShouldEqual, "qr_code")
+	test.That(t, read["payload"], test.ShouldEqual, "TOTE-0042")
+	test.That(t, read["corners"], test.ShouldHaveLength, 4)
+	box, ok := read["box"].(map[string]interface{})
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, box["x_min"], test.ShouldAlmostEqual, 100, 4)
+	test.That(t, box["y_min"], test.ShouldAlmostEqual, 60, 4)
+
+	resp, err = bs.DoCommand(ctx, map[string]interface{}{getReadingsCommand: true})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["count"], test.ShouldEqual, 1)
+	test.That(t, resp["payloads"], test.ShouldResemble, []interface{}{"TOTE-0042"})
+	test.That(t, resp["formats"], test.ShouldResemble, []interface{}{"qr_code"})
+
+	_, err = bs.DoCommand(ctx, map[string]interface{}{"other": true})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, bs.Close(ctx), test.ShouldBeNil)
+
+	t.Run("formats and boxes", func(t *testing.T) {
+		// only 1D barcodes are read, so the QR code is not
+		bs, err := newBarcodeSource(source, utils.AttributeMap{"formats": []interface{}{"ean_13"}, "draw_boxes": false})
+		test.That(t, err, test.ShouldBeNil)
+		out, _, err := bs.Read(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, hasGreenPixel(out, out.Bounds()), test.ShouldBeFalse)
+		resp, err := bs.DoCommand(ctx, map[string]interface{}{getReadingsCommand: true})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp["count"], test.ShouldEqual, 0)
+		test.That(t, bs.Close(ctx), test.ShouldBeNil)
+	})
+
+	t.Run("pipeline", func(t *testing.T) {
+		src, stream, err := newBarcodeTransform(ctx, source, camera.ColorStream, utils.AttributeMap{})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, stream, test.ShouldEqual, camera.ColorStream)
+		tp := transformPipeline{pipeline: []camera.VideoSource{src, source}}
+		_, err = tp.DoCommand(ctx, map[string]interface{}{getBarcodesCommand: true})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, src.Close(ctx), test.ShouldBeNil)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for errText, attrs := range map[string]utils.AttributeMap{
+			"format":    {"formats": []interface{}{"aztec"}},
+			"box_color": {"box_color": "green"},
+		} {
+			_, err := newBarcodeSource(source, attrs)
+			test.That(t, err, test.ShouldNotBeNil)
+			test.That(t, err.Error(), test.ShouldContainSubstring, errText)
+		}
+		_, _, err := newBarcodeTransform(ctx, source, camera.DepthStream, utils.AttributeMap{})
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
+	test.That(t, source.Close(ctx), test.ShouldBeNil)
+}
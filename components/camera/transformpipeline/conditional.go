@@ -0,0 +1,375 @@
+package transformpipeline
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/services/vision"
+	"go.viam.com/rdk/utils"
+)
+
+// getBranchCommand is the DoCommand that returns which branch a conditional transform last took.
+const getBranchCommand = "get_branch"
+
+// the branches of a conditional transform.
+const (
+	branchThen = "then"
+	branchElse = "else"
+)
+
+const defaultConditionConfidence = 0.5
+
+// conditionalConfig are the attributes for a conditional transform, which applies one of two lists
+// of transforms to each image depending on a condition, so that one pipeline can adapt to the
+// camera it is built on and to what the camera sees.
+type conditionalConfig struct {
+	If conditionConfig `json:"if"`
+	// Then are the transforms applied when the condition holds.
+	Then []Transformation `json:"then"`
+	// Else are the transforms applied when the condition does not hold. Images are passed through
+	// as they are if there are none.
+	Else []Transformation `json:"else,omitempty"`
+}
+
+// conditionConfig is a condition of a conditional transform, which holds when every part of it
+// that is given holds. The parts on the source are checked once when the pipeline is built, so
+// that a branch that cannot be built for the source, such as undistorting without intrinsics, is
+// not. The parts on the images are checked for each image.
+type conditionConfig struct {
+	// HasIntrinsics holds when whether the source has intrinsics matches it.
+	HasIntrinsics *bool `json:"has_intrinsics,omitempty"`
+	// Stream holds when the source outputs this stream, color or depth.
+	Stream string `json:"stream,omitempty"`
+	// MinBrightness and MaxBrightness hold when the mean brightness of an image, from 0 to 1, is at
+	// least or at most them.
+	MinBrightness *float64 `json:"min_brightness,omitempty"`
+	MaxBrightness *float64 `json:"max_brightness,omitempty"`
+	// ClassifierName is a vision service that holds when it classifies an image as Label with at
+	// least ConfidenceThreshold, which defaults to 0.5.
+	ClassifierName      string   `json:"classifier_name,omitempty"`
+	Label               string   `json:"label,omitempty"`
+	ConfidenceThreshold *float64 `json:"confidence_threshold,omitempty"`
+	// IntervalSec is how long whether an image holds is reused for before checking another, so that
+	// slowly changing conditions such as day and night do not run a classifier on every image.
+	IntervalSec float64 `json:"interval_sec,omitempty"`
+}
+
+func (cond *conditionConfig) validate() error {
+	if cond.HasIntrinsics == nil && cond.Stream == "" && !cond.checksImages() {
+		return errors.New("conditional transform has no condition in if")
+	}
+	if cond.Stream != "" && cond.Stream != string(camera.ColorStream) && cond.Stream != string(camera.DepthStream) {
+		return errors.Errorf("conditional stream must be %q or %q, got %q", camera.ColorStream, camera.DepthStream, cond.Stream)
+	}
+	for _, b := range []*float64{cond.MinBrightness, cond.MaxBrightness} {
+		if b != nil && (*b < 0 || *b > 1) {
+			return errors.Errorf("conditional brightness must be between 0 and 1, got %v", *b)
+		}
+	}
+	if (cond.ClassifierName == "") != (cond.Label == "") {
+		return errors.New("conditional classifier_name and label must be given together")
+	}
+	if c := cond.ConfidenceThreshold; c != nil && (*c < 0 || *c > 1) {
+		return errors.Errorf("conditional confidence_threshold must be between 0 and 1, got %v", *c)
+	}
+	if cond.IntervalSec < 0 {
+		return errors.New("conditional interval_sec cannot be negative")
+	}
+	return nil
+}
+
+// holdsForSource returns whether the parts of the condition on the source hold.
+func (cond *conditionConfig) holdsForSource(props camera.Properties, stream camera.ImageType) bool {
+	if cond.HasIntrinsics != nil && *cond.HasIntrinsics != (props.IntrinsicParams.CheckValid() == nil) {
+		return false
+	}
+	if cond.Stream != "" && camera.ImageType(cond.Stream) != stream {
+		return false
+	}
+	return true
+}
+
+// checksImages returns whether the condition has parts on the images.
+func (cond *conditionConfig) checksImages() bool {
+	return cond.MinBrightness != nil || cond.MaxBrightness != nil || cond.ClassifierName != ""
+}
+
+type conditionalSource struct {
+	src   camera.VideoSource
+	cond  conditionConfig
+	r     robot.Robot
+	frame *frameSource
+	// the transforms of each branch, and the source of the branch's output, which are nil for a
+	// branch that was not built because the source never takes it
+	thenPipeline, elsePipeline []camera.VideoSource
+	thenOut, elseOut           camera.VideoSource
+
+	// mu is held while an image goes through a branch, so that the branch reads the image that
+	// was checked
+	mu        sync.Mutex
+	checkedAt time.Time
+	held      bool
+	branch    string
+}
+
+// newConditionalTransform creates a new transform that applies one of two lists of transforms
+// depending on a condition.
+func newConditionalTransform(
+	ctx context.Context, source camera.VideoSource, stream camera.ImageType, r robot.Robot, am utils.AttributeMap,
+) (camera.VideoSource, camera.ImageType, error) {
+	conf, err := resource.TransformAttributeMap[*conditionalConfig](am)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	if err := conf.If.validate(); err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	if len(conf.Then) == 0 {
+		return nil, camera.UnspecifiedStream, errors.New("conditional transform has no then transforms")
+	}
+	props, err := propsFromVideoSource(ctx, source)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+
+	// the branches read the image being handled from the frame source, with the source's properties
+	cs := &conditionalSource{src: source, cond: conf.If, r: r, frame: &frameSource{src: source}}
+	frameSrc, err := camera.NewVideoSourceFromReader(ctx, cs.frame, &transform.PinholeCameraModel{
+		PinholeCameraIntrinsics: props.IntrinsicParams,
+		Distortion:              props.DistortionParams,
+	}, stream)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	buildBranch := func(name string, trs []Transformation) ([]camera.VideoSource, camera.VideoSource, camera.ImageType, error) {
+		pipeline, outStream, err := buildTransforms(ctx, r, frameSrc, stream, trs)
+		if err != nil {
+			return nil, nil, camera.UnspecifiedStream, errors.Wrapf(err, "could not build %s branch of conditional transform", name)
+		}
+		if len(pipeline) == 0 {
+			return nil, frameSrc, stream, nil
+		}
+		return pipeline, pipeline[len(pipeline)-1], outStream, nil
+	}
+
+	holds := conf.If.holdsForSource(props, stream)
+	outStream := stream
+	var outs []camera.VideoSource
+	if holds {
+		if cs.thenPipeline, cs.thenOut, outStream, err = buildBranch(branchThen, conf.Then); err != nil {
+			return nil, camera.UnspecifiedStream, err
+		}
+		outs = append(outs, cs.thenOut)
+	}
+	if !holds || conf.If.checksImages() {
+		var elseStream camera.ImageType
+		if cs.elsePipeline, cs.elseOut, elseStream, err = buildBranch(branchElse, conf.Else); err != nil {
+			return nil, camera.UnspecifiedStream, err
+		}
+		if holds && elseStream != outStream {
+			return nil, camera.UnspecifiedStream, errors.Errorf(
+				"branches of conditional transform must output the same stream, got %q and %q", outStream, elseStream)
+		}
+		outStream = elseStream
+		outs = append(outs, cs.elseOut)
+	}
+
+	// the output has intrinsics if every branch that can be taken agrees on them
+	cameraModel := &transform.PinholeCameraModel{}
+	for i, out := range outs {
+		outProps, err := propsFromVideoSource(ctx, out)
+		if err != nil {
+			return nil, camera.UnspecifiedStream, err
+		}
+		if i == 0 {
+			cameraModel.PinholeCameraIntrinsics = outProps.IntrinsicParams
+			cameraModel.Distortion = outProps.DistortionParams
+		} else if !sameIntrinsics(cameraModel.PinholeCameraIntrinsics, outProps.IntrinsicParams) {
+			cameraModel.PinholeCameraIntrinsics, cameraModel.Distortion = nil, nil
+		}
+	}
+	src, err := camera.NewVideoSourceFromReader(ctx, cs, cameraModel, outStream)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	return src, outStream, err
+}
+
+func sameIntrinsics(a, b *transform.PinholeCameraIntrinsics) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Read checks the condition on the next image of the source and passes it through the branch
+// the condition picks.
+func (cs *conditionalSource) Read(ctx context.Context) (image.Image, func(), error) {
+	ctx, span := trace.StartSpan(ctx, "camera::transformpipeline::conditional::Read")
+	defer span.End()
+	img, release, err := camera.ReadImage(ctx, cs.src)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	out, branch := cs.elseOut, branchElse
+	if cs.thenOut != nil {
+		holds, err := cs.holds(ctx, img)
+		if err != nil {
+			release()
+			return nil, nil, err
+		}
+		if holds {
+			out, branch = cs.thenOut, branchThen
+		}
+	}
+	cs.branch = branch
+	cs.frame.set(img)
+	defer cs.frame.set(nil)
+	res, resRelease, err := camera.ReadImage(ctx, out)
+	if err != nil {
+		release()
+		return nil, nil, errors.Wrapf(err, "could not transform image in %s branch", branch)
+	}
+	return res, func() {
+		resRelease()
+		release()
+	}, nil
+}
+
+// holds returns whether the condition holds for img, reusing the last result if it was found less
+// than interval_sec ago. The parts of the condition on the source already hold.
+func (cs *conditionalSource) holds(ctx context.Context, img image.Image) (bool, error) {
+	if cs.elseOut == nil {
+		return true, nil
+	}
+	now := time.Now()
+	if !cs.checkedAt.IsZero() && now.Sub(cs.checkedAt).Seconds() < cs.cond.IntervalSec {
+		return cs.held, nil
+	}
+	holds, err := cs.holdsForImage(ctx, img)
+	if err != nil {
+		return false, err
+	}
+	cs.checkedAt, cs.held = now, holds
+	return holds, nil
+}
+
+func (cs *conditionalSource) holdsForImage(ctx context.Context, img image.Image) (bool, error) {
+	if cs.cond.MinBrightness != nil || cs.cond.MaxBrightness != nil {
+		b := meanBrightness(img)
+		if cs.cond.MinBrightness != nil && b < *cs.cond.MinBrightness {
+			return false, nil
+		}
+		if cs.cond.MaxBrightness != nil && b > *cs.cond.MaxBrightness {
+			return false, nil
+		}
+	}
+	if cs.cond.ClassifierName == "" {
+		return true, nil
+	}
+	srv, err := vision.FromRobot(cs.r, cs.cond.ClassifierName)
+	if err != nil {
+		return false, errors.Wrap(err, "conditional transform can't find vision service")
+	}
+	classifications, err := srv.Classifications(ctx, img, 0, nil)
+	if err != nil {
+		return false, errors.Wrap(err, "could not get classifications")
+	}
+	threshold := defaultConditionConfidence
+	if cs.cond.ConfidenceThreshold != nil {
+		threshold = *cs.cond.ConfidenceThreshold
+	}
+	for _, c := range classifications {
+		if c.Label() == cs.cond.Label && c.Score() >= threshold {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// meanBrightness returns the mean brightness of img from 0 to 1, sampling every fourth pixel of
+// every fourth row.
+func meanBrightness(img image.Image) float64 {
+	bounds := img.Bounds()
+	var sum, n float64
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 4 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 4 {
+			sum += float64(color.Gray16Model.Convert(img.At(x, y)).(color.Gray16).Y)
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / n / 0xffff
+}
+
+// DoCommand returns which branch was last taken for the "get_branch" command, and passes other
+// commands to the transforms of the branches, last first, until one of them handles it.
+func (cs *conditionalSource) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	if _, ok := cmd[getBranchCommand]; ok {
+		cs.mu.Lock()
+		defer cs.mu.Unlock()
+		var branch interface{}
+		if cs.branch != "" {
+			branch = cs.branch
+		}
+		return map[string]interface{}{"branch": branch}, nil
+	}
+	for _, pipeline := range [][]camera.VideoSource{cs.thenPipeline, cs.elsePipeline} {
+		for i := len(pipeline) - 1; i >= 0; i-- {
+			resp, err := pipeline[i].DoCommand(ctx, cmd)
+			if !errors.Is(err, resource.ErrDoUnimplemented) {
+				return resp, err
+			}
+		}
+	}
+	return nil, resource.ErrDoUnimplemented
+}
+
+func (cs *conditionalSource) Close(ctx context.Context) error {
+	return nil
+}
+
+// frameSource returns the image a conditional transform is handling to the transforms of its
+// branches, or the next image of the source when it is not handling one, such as while the
+// branches are being built.
+type frameSource struct {
+	src camera.VideoSource
+
+	mu  sync.Mutex
+	img image.Image
+}
+
+func (fs *frameSource) set(img image.Image) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.img = img
+}
+
+func (fs *frameSource) Read(ctx context.Context) (image.Image, func(), error) {
+	fs.mu.Lock()
+	img := fs.img
+	fs.mu.Unlock()
+	if img == nil {
+		return camera.ReadImage(ctx, fs.src)
+	}
+	return img, func() {}, nil
+}
+
+func (fs *frameSource) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,201 @@
+package transformpipeline
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/camera/fake"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/services/vision"
+	"go.viam.com/rdk/testutils/inject"
+	"go.viam.com/rdk/utils"
+	"go.viam.com/rdk/vision/classification"
+)
+
+func uniformImage(gray uint8) image.Image {
+	img := image.NewGray(image.Rect(0, 0, 40, 30))
+	for i := range img.Pix {
+		img.Pix[i] = gray
+	}
+	return img
+}
+
+func TestConditional(t *testing.T) {
+	ctx := context.Background()
+	r := &inject.Robot{}
+	intrinsics := &transform.PinholeCameraIntrinsics{Width: 40, Height: 30, Fx: 50, Fy: 50, Ppx: 20, Ppy: 15}
+	static := &fake.StaticSource{ColorImg: uniformImage(20)}
+	newSource := func(t *testing.T, intrinsics *transform.PinholeCameraIntrinsics) camera.VideoSource {
+		t.Helper()
+		source, err := camera.NewVideoSourceFromReader(
+			ctx, static, &transform.PinholeCameraModel{PinholeCameraIntrinsics: intrinsics}, camera.ColorStream)
+		test.That(t, err, test.ShouldBeNil)
+		return source
+	}
+	resize := []interface{}{map[string]interface{}{
+		"type":       "resize",
+		"attributes": map[string]interface{}{"width_px": 10, "height_px": 20},
+	}}
+	getBranch := func(t *testing.T, src camera.VideoSource) interface{} {
+		t.Helper()
+		resp, err := src.DoCommand(ctx, map[string]interface{}{getBranchCommand: true})
+		test.That(t, err, test.ShouldBeNil)
+		return resp["branch"]
+	}
+
+	t.Run("source condition", func(t *testing.T) {
+		am := utils.AttributeMap{"if": map[string]interface{}{"has_intrinsics": true}, "then": resize}
+
+		// with intrinsics the images are resized
+		src, stream, err := newConditionalTransform(ctx, newSource(t, intrinsics), camera.ColorStream, r, am)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, stream, test.ShouldEqual, camera.ColorStream)
+		test.That(t, getBranch(t, src), test.ShouldBeNil)
+		out, _, err := camera.ReadImage(ctx, src)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, out.Bounds(), test.ShouldResemble, image.Rect(0, 0, 10, 20))
+		test.That(t, getBranch(t, src), test.ShouldEqual, branchThen)
+		test.That(t, src.Close(ctx), test.ShouldBeNil)
+
+		// without them there is no else branch, so they are passed through
+		src, _, err = newConditionalTransform(ctx, newSource(t, nil), camera.ColorStream, r, am)
+		test.That(t, err, test.ShouldBeNil)
+		out, _, err = camera.ReadImage(ctx, src)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, out.Bounds(), test.ShouldResemble, image.Rect(0, 0, 40, 30))
+		test.That(t, getBranch(t, src), test.ShouldEqual, branchElse)
+		test.That(t, src.Close(ctx), test.ShouldBeNil)
+	})
+
+	t.Run("brightness", func(t *testing.T) {
+		source := newSource(t, intrinsics)
+		am := utils.AttributeMap{
+			"if":   map[string]interface{}{"max_brightness": 0.3},
+			"then": resize,
+			"else": []interface{}{map[string]interface{}{
+				"type":       "resize",
+				"attributes": map[string]interface{}{"width_px": 20, "height_px": 10},
+			}},
+		}
+		src, _, err := newConditionalTransform(ctx, source, camera.ColorStream, r, am)
+		test.That(t, err, test.ShouldBeNil)
+		// the branches resize differently, so the output has no intrinsics
+		_, err = src.NextPointCloud(ctx)
+		test.That(t, err, test.ShouldWrap, transform.ErrNoIntrinsics)
+
+		static.ColorImg = uniformImage(20)
+		out, _, err := camera.ReadImage(ctx, src)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, out.Bounds(), test.ShouldResemble, image.Rect(0, 0, 10, 20))
+		test.That(t, getBranch(t, src), test.ShouldEqual, branchThen)
+
+		static.ColorImg = uniformImage(200)
+		out, _, err = camera.ReadImage(ctx, src)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, out.Bounds(), test.ShouldResemble, image.Rect(0, 0, 20, 10))
+		test.That(t, getBranch(t, src), test.ShouldEqual, branchElse)
+		test.That(t, src.Close(ctx), test.ShouldBeNil)
+
+		// within interval_sec the last result is reused
+		am["if"] = map[string]interface{}{"max_brightness": 0.3, "interval_sec": 60}
+		src, _, err = newConditionalTransform(ctx, source, camera.ColorStream, r, am)
+		test.That(t, err, test.ShouldBeNil)
+		static.ColorImg = uniformImage(20)
+		_, _, err = camera.ReadImage(ctx, src)
+		test.That(t, err, test.ShouldBeNil)
+		static.ColorImg = uniformImage(200)
+		out, _, err = camera.ReadImage(ctx, src)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, out.Bounds(), test.ShouldResemble, image.Rect(0, 0, 10, 20))
+		test.That(t, src.Close(ctx), test.ShouldBeNil)
+	})
+
+	t.Run("classifier", func(t *testing.T) {
+		svc := inject.NewVisionService("day_night")
+		svc.ClassificationsFunc = func(
+			ctx context.Context, img image.Image, n int, extra map[string]interface{},
+		) (classification.Classifications, error) {
+			if gray, ok := color.GrayModel.Convert(img.At(0, 0)).(color.Gray); ok && gray.Y < 100 {
+				return classification.Classifications{classification.NewClassification(0.9, "night")}, nil
+			}
+			return classification.Classifications{classification.NewClassification(0.9, "day")}, nil
+		}
+		r := &inject.Robot{}
+		r.ResourceByNameFunc = func(name resource.Name) (resource.Resource, error) {
+			if name != vision.Named("day_night") {
+				return nil, resource.NewNotFoundError(name)
+			}
+			return svc, nil
+		}
+		am := utils.AttributeMap{
+			"if":   map[string]interface{}{"classifier_name": "day_night", "label": "night"},
+			"then": resize,
+		}
+		src, _, err := newConditionalTransform(ctx, newSource(t, intrinsics), camera.ColorStream, r, am)
+		test.That(t, err, test.ShouldBeNil)
+
+		static.ColorImg = uniformImage(20)
+		out, _, err := camera.ReadImage(ctx, src)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, out.Bounds(), test.ShouldResemble, image.Rect(0, 0, 10, 20))
+		static.ColorImg = uniformImage(200)
+		out, _, err = camera.ReadImage(ctx, src)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, out.Bounds(), test.ShouldResemble, image.Rect(0, 0, 40, 30))
+		test.That(t, src.Close(ctx), test.ShouldBeNil)
+
+		// a classifier that is not on the robot fails the read
+		am["if"] = map[string]interface{}{"classifier_name": "missing", "label": "night"}
+		src, _, err = newConditionalTransform(ctx, newSource(t, intrinsics), camera.ColorStream, r, am)
+		test.That(t, err, test.ShouldBeNil)
+		_, _, err = camera.ReadImage(ctx, src)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, src.Close(ctx), test.ShouldBeNil)
+	})
+
+	t.Run("commands", func(t *testing.T) {
+		am := utils.AttributeMap{
+			"if":   map[string]interface{}{"min_brightness": 0.5},
+			"then": []interface{}{map[string]interface{}{"type": "fiducial"}},
+		}
+		src, _, err := newConditionalTransform(ctx, newSource(t, intrinsics), camera.ColorStream, r, am)
+		test.That(t, err, test.ShouldBeNil)
+		// commands go to the transforms of the branches
+		resp, err := src.DoCommand(ctx, map[string]interface{}{getMarkersCommand: true})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp["markers"], test.ShouldBeEmpty)
+		_, err = src.DoCommand(ctx, map[string]interface{}{"other": true})
+		test.That(t, err, test.ShouldBeError, resource.ErrDoUnimplemented)
+		test.That(t, src.Close(ctx), test.ShouldBeNil)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		source := newSource(t, intrinsics)
+		for errText, am := range map[string]utils.AttributeMap{
+			"no condition": {"if": map[string]interface{}{}, "then": resize},
+			"no then":      {"if": map[string]interface{}{"has_intrinsics": true}},
+			"stream":       {"if": map[string]interface{}{"stream": "infrared"}, "then": resize},
+			"brightness":   {"if": map[string]interface{}{"min_brightness": 2}, "then": resize},
+			"together":     {"if": map[string]interface{}{"classifier_name": "day_night"}, "then": resize},
+			"confidence_threshold": {
+				"if":   map[string]interface{}{"classifier_name": "day_night", "label": "night", "confidence_threshold": -1},
+				"then": resize,
+			},
+			"interval_sec": {"if": map[string]interface{}{"max_brightness": 0.5, "interval_sec": -1}, "then": resize},
+			"then branch": {
+				"if":   map[string]interface{}{"has_intrinsics": true},
+				"then": []interface{}{map[string]interface{}{"type": "unknown"}},
+			},
+		} {
+			_, _, err := newConditionalTransform(ctx, source, camera.ColorStream, r, am)
+			test.That(t, err, test.ShouldNotBeNil)
+			test.That(t, err.Error(), test.ShouldContainSubstring, errText)
+		}
+	})
+}
@@ -0,0 +1,275 @@
+package transformpipeline
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/utils"
+)
+
+// equalizeConfig are the attributes for an equalize transform, which spreads the brightness of
+// each image over the whole range so that dim or washed out images have more contrast.
+type equalizeConfig struct{}
+
+// claheConfig are the attributes for a clahe transform, which equalizes each tile of the image on
+// its own so that unevenly lit images have more contrast everywhere. The equalization of a tile is
+// limited by the clip limit so that noise in flat regions is not amplified.
+type claheConfig struct {
+	// TilesX and TilesY are how many tiles the image is split into across and down. They default to 8.
+	TilesX int `json:"tiles_x,omitempty"`
+	TilesY int `json:"tiles_y,omitempty"`
+	// ClipLimit is how many times more pixels than average any brightness of a tile may have before
+	// the rest are spread over the other brightnesses. It defaults to 2, and 0 does not limit them.
+	ClipLimit float64 `json:"clip_limit,omitempty"`
+}
+
+// gammaConfig are the attributes for a gamma transform. A gamma greater than 1 brightens the
+// shadows of an image, and one less than 1 darkens them.
+type gammaConfig struct {
+	Gamma float64 `json:"gamma"`
+}
+
+// contrastSource adjusts the contrast of the images of its source. Exactly one of its
+// adjustments is set.
+type contrastSource struct {
+	src      camera.VideoSource
+	name     string
+	equalize bool
+	clahe    *claheConfig
+	gamma    []uint8
+}
+
+// newEqualizeTransform creates a new transform that equalizes the histogram of the brightness of images.
+func newEqualizeTransform(
+	ctx context.Context, source camera.VideoSource, stream camera.ImageType, am utils.AttributeMap,
+) (camera.VideoSource, camera.ImageType, error) {
+	if _, err := resource.TransformAttributeMap[*equalizeConfig](am); err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	return newContrastTransform(ctx, source, stream, &contrastSource{name: string(transformTypeEqualize), equalize: true})
+}
+
+// newCLAHETransform creates a new transform that equalizes the histograms of tiles of images with
+// contrast limited adaptive histogram equalization.
+func newCLAHETransform(
+	ctx context.Context, source camera.VideoSource, stream camera.ImageType, am utils.AttributeMap,
+) (camera.VideoSource, camera.ImageType, error) {
+	conf, err := resource.TransformAttributeMap[*claheConfig](am)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	if !am.Has("tiles_x") {
+		conf.TilesX = 8
+	}
+	if !am.Has("tiles_y") {
+		conf.TilesY = 8
+	}
+	if !am.Has("clip_limit") {
+		conf.ClipLimit = 2
+	}
+	if conf.TilesX < 1 || conf.TilesY < 1 {
+		return nil, camera.UnspecifiedStream, errors.New("clahe tiles_x and tiles_y must be at least 1")
+	}
+	if conf.ClipLimit < 0 {
+		return nil, camera.UnspecifiedStream, errors.New("clahe clip_limit cannot be negative")
+	}
+	return newContrastTransform(ctx, source, stream, &contrastSource{name: string(transformTypeCLAHE), clahe: conf})
+}
+
+// newGammaTransform creates a new transform that gamma corrects images.
+func newGammaTransform(
+	ctx context.Context, source camera.VideoSource, stream camera.ImageType, am utils.AttributeMap,
+) (camera.VideoSource, camera.ImageType, error) {
+	conf, err := resource.TransformAttributeMap[*gammaConfig](am)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	if conf.Gamma <= 0 {
+		return nil, camera.UnspecifiedStream, errors.New("gamma must be greater than 0")
+	}
+	lut := make([]uint8, 256)
+	for v := range lut {
+		lut[v] = uint8(math.Round(255 * math.Pow(float64(v)/255, 1/conf.Gamma)))
+	}
+	return newContrastTransform(ctx, source, stream, &contrastSource{name: string(transformTypeGamma), gamma: lut})
+}
+
+func newContrastTransform(
+	ctx context.Context, source camera.VideoSource, stream camera.ImageType, reader *contrastSource,
+) (camera.VideoSource, camera.ImageType, error) {
+	if stream == camera.DepthStream {
+		return nil, camera.UnspecifiedStream, errors.Errorf("%s transform cannot adjust the contrast of depth images", reader.name)
+	}
+	// the contrast of an image does not change where its pixels are, so the intrinsics still hold
+	props, err := propsFromVideoSource(ctx, source)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	cameraModel := &transform.PinholeCameraModel{
+		PinholeCameraIntrinsics: props.IntrinsicParams,
+		Distortion:              props.DistortionParams,
+	}
+	reader.src = source
+	src, err := camera.NewVideoSourceFromReader(ctx, reader, cameraModel, camera.ColorStream)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	return src, camera.ColorStream, err
+}
+
+// Read adjusts the contrast of the next image of the source.
+func (cs *contrastSource) Read(ctx context.Context) (image.Image, func(), error) {
+	ctx, span := trace.StartSpan(ctx, "camera::transformpipeline::"+cs.name+"::Read")
+	defer span.End()
+	orig, release, err := camera.ReadImage(ctx, cs.src)
+	if err != nil {
+		return nil, nil, err
+	}
+	bounds := image.Rect(0, 0, orig.Bounds().Dx(), orig.Bounds().Dy())
+	img := image.NewNRGBA(bounds)
+	draw.Draw(img, bounds, orig, orig.Bounds().Min, draw.Src)
+
+	if cs.gamma != nil {
+		for i := 0; i < len(img.Pix); i += 4 {
+			img.Pix[i] = cs.gamma[img.Pix[i]]
+			img.Pix[i+1] = cs.gamma[img.Pix[i+1]]
+			img.Pix[i+2] = cs.gamma[img.Pix[i+2]]
+		}
+		return img, release, nil
+	}
+
+	// the brightness is equalized on its own so that the colors do not shift
+	luma := make([]uint8, len(img.Pix)/4)
+	cb := make([]uint8, len(luma))
+	cr := make([]uint8, len(luma))
+	for i := range luma {
+		luma[i], cb[i], cr[i] = color.RGBToYCbCr(img.Pix[4*i], img.Pix[4*i+1], img.Pix[4*i+2])
+	}
+	if cs.equalize {
+		lut := equalizationLUT(histogram(luma, bounds.Dx(), bounds), 0)
+		for i, v := range luma {
+			luma[i] = lut[v]
+		}
+	} else {
+		clahe(luma, bounds.Dx(), bounds.Dy(), cs.clahe)
+	}
+	for i := range luma {
+		img.Pix[4*i], img.Pix[4*i+1], img.Pix[4*i+2] = color.YCbCrToRGB(luma[i], cb[i], cr[i])
+	}
+	return img, release, nil
+}
+
+// histogram counts the brightnesses of the pixels of an image within r.
+func histogram(luma []uint8, width int, r image.Rectangle) []int {
+	hist := make([]int, 256)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for _, v := range luma[y*width+r.Min.X : y*width+r.Max.X] {
+			hist[v]++
+		}
+	}
+	return hist
+}
+
+// equalizationLUT returns the brightness each brightness maps to when equalizing a histogram. If
+// clipLimit is set, no brightness may have more than clipLimit times the average count, and the
+// counts above it are spread evenly over all the brightnesses.
+func equalizationLUT(hist []int, clipLimit float64) []uint8 {
+	total := 0
+	for _, count := range hist {
+		total += count
+	}
+	lut := make([]uint8, 256)
+	if clipLimit > 0 {
+		limit := int(math.Max(1, clipLimit*float64(total)/256))
+		excess := 0
+		for v, count := range hist {
+			if count > limit {
+				excess += count - limit
+				hist[v] = limit
+			}
+		}
+		for v := range hist {
+			hist[v] += excess / 256
+			if v < excess%256 {
+				hist[v]++
+			}
+		}
+	}
+	// without clipping, the darkest brightness in the image becomes black
+	cdfMin := 0
+	if clipLimit == 0 {
+		for _, count := range hist {
+			if count != 0 {
+				cdfMin = count
+				break
+			}
+		}
+	}
+	if total <= cdfMin {
+		for v := range lut {
+			lut[v] = uint8(v)
+		}
+		return lut
+	}
+	cdf := 0
+	for v, count := range hist {
+		cdf += count
+		lut[v] = uint8(math.Round(math.Max(0, float64(cdf-cdfMin)) * 255 / float64(total-cdfMin)))
+	}
+	return lut
+}
+
+// clahe equalizes the brightness of each tile of an image, interpolating between the equalizations
+// of the nearest tiles so that the edges of the tiles do not show.
+func clahe(luma []uint8, width, height int, conf *claheConfig) {
+	tilesX, tilesY := min(conf.TilesX, width), min(conf.TilesY, height)
+	if tilesX == 0 || tilesY == 0 {
+		return
+	}
+	luts := make([][]uint8, tilesX*tilesY)
+	for ty := 0; ty < tilesY; ty++ {
+		for tx := 0; tx < tilesX; tx++ {
+			tile := image.Rect(tx*width/tilesX, ty*height/tilesY, (tx+1)*width/tilesX, (ty+1)*height/tilesY)
+			luts[ty*tilesX+tx] = equalizationLUT(histogram(luma, width, tile), conf.ClipLimit)
+		}
+	}
+	tileW := float64(width) / float64(tilesX)
+	tileH := float64(height) / float64(tilesY)
+
+	// nearestTiles returns the tiles whose centers are either side of p, and how far p is from the first
+	nearestTiles := func(p int, size float64, tiles int) (int, int, float64) {
+		f := (float64(p)+0.5)/size - 0.5
+		t0 := int(math.Floor(f))
+		switch {
+		case t0 < 0:
+			return 0, 0, 0
+		case t0 >= tiles-1:
+			return tiles - 1, tiles - 1, 0
+		default:
+			return t0, t0 + 1, f - float64(t0)
+		}
+	}
+	for y := 0; y < height; y++ {
+		ty0, ty1, wy := nearestTiles(y, tileH, tilesY)
+		for x := 0; x < width; x++ {
+			tx0, tx1, wx := nearestTiles(x, tileW, tilesX)
+			v := luma[y*width+x]
+			top := (1-wx)*float64(luts[ty0*tilesX+tx0][v]) + wx*float64(luts[ty0*tilesX+tx1][v])
+			bottom := (1-wx)*float64(luts[ty1*tilesX+tx0][v]) + wx*float64(luts[ty1*tilesX+tx1][v])
+			luma[y*width+x] = uint8(math.Round((1-wy)*top + wy*bottom))
+		}
+	}
+}
+
+func (cs *contrastSource) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,132 @@
+package transformpipeline
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/camera/fake"
+	"go.viam.com/rdk/utils"
+)
+
+// grayStripes returns an image whose columns alternate between the given grays, using the first
+// pair on the left half and the second pair on the right half.
+func grayStripes(left1, left2, right1, right2 uint8) image.Image {
+	img := image.NewGray(image.Rect(0, 0, 40, 10))
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 40; x++ {
+			v := []uint8{left1, left2, right1, right2}[2*(x/20)+x%2]
+			img.SetGray(x, y, color.Gray{Y: v})
+		}
+	}
+	return img
+}
+
+func TestContrast(t *testing.T) {
+	ctx := context.Background()
+	newSource := func(img image.Image) camera.VideoSource {
+		source, err := camera.NewVideoSourceFromReader(ctx, &fake.StaticSource{ColorImg: img}, nil, camera.UnspecifiedStream)
+		test.That(t, err, test.ShouldBeNil)
+		return source
+	}
+	grayAt := func(img image.Image, x, y int) uint8 {
+		return color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y
+	}
+
+	t.Run("equalize", func(t *testing.T) {
+		source := newSource(grayStripes(100, 100, 150, 150))
+		src, stream, err := newEqualizeTransform(ctx, source, camera.ColorStream, utils.AttributeMap{})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, stream, test.ShouldEqual, camera.ColorStream)
+		out, _, err := camera.ReadImage(ctx, src)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, out.Bounds(), test.ShouldResemble, image.Rect(0, 0, 40, 10))
+		// the two grays are spread over the whole range
+		test.That(t, grayAt(out, 5, 5), test.ShouldEqual, 0)
+		test.That(t, grayAt(out, 30, 5), test.ShouldEqual, 255)
+		test.That(t, src.Close(ctx), test.ShouldBeNil)
+
+		// an image of one color is left as it is
+		source = newSource(grayStripes(100, 100, 100, 100))
+		src, _, err = newEqualizeTransform(ctx, source, camera.ColorStream, utils.AttributeMap{})
+		test.That(t, err, test.ShouldBeNil)
+		out, _, err = camera.ReadImage(ctx, src)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, grayAt(out, 5, 5), test.ShouldEqual, 100)
+		test.That(t, src.Close(ctx), test.ShouldBeNil)
+	})
+
+	t.Run("clahe", func(t *testing.T) {
+		// the dark half and the bright half each have little contrast
+		source := newSource(grayStripes(20, 30, 200, 210))
+
+		src, _, err := newCLAHETransform(ctx, source, camera.ColorStream, utils.AttributeMap{"tiles_x": 2, "tiles_y": 1, "clip_limit": 0})
+		test.That(t, err, test.ShouldBeNil)
+		out, _, err := camera.ReadImage(ctx, src)
+		test.That(t, err, test.ShouldBeNil)
+		// without a clip limit each tile is spread over the whole range
+		test.That(t, grayAt(out, 2, 5), test.ShouldEqual, 0)
+		test.That(t, grayAt(out, 3, 5), test.ShouldEqual, 255)
+		test.That(t, grayAt(out, 36, 5), test.ShouldEqual, 0)
+		test.That(t, grayAt(out, 37, 5), test.ShouldEqual, 255)
+		test.That(t, src.Close(ctx), test.ShouldBeNil)
+
+		// the clip limit keeps the contrast from being stretched as far
+		src, _, err = newCLAHETransform(ctx, source, camera.ColorStream, utils.AttributeMap{"tiles_x": 2, "tiles_y": 1})
+		test.That(t, err, test.ShouldBeNil)
+		out, _, err = camera.ReadImage(ctx, src)
+		test.That(t, err, test.ShouldBeNil)
+		contrast := int(grayAt(out, 3, 5)) - int(grayAt(out, 2, 5))
+		test.That(t, contrast, test.ShouldBeGreaterThan, 10)
+		test.That(t, contrast, test.ShouldBeLessThan, 255)
+		test.That(t, src.Close(ctx), test.ShouldBeNil)
+
+		// more tiles than pixels still works
+		src, _, err = newCLAHETransform(ctx, source, camera.ColorStream, utils.AttributeMap{"tiles_x": 64, "tiles_y": 64})
+		test.That(t, err, test.ShouldBeNil)
+		_, _, err = camera.ReadImage(ctx, src)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, src.Close(ctx), test.ShouldBeNil)
+	})
+
+	t.Run("gamma", func(t *testing.T) {
+		source := newSource(grayStripes(64, 64, 255, 255))
+		src, _, err := newGammaTransform(ctx, source, camera.ColorStream, utils.AttributeMap{"gamma": 2})
+		test.That(t, err, test.ShouldBeNil)
+		out, _, err := camera.ReadImage(ctx, src)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, grayAt(out, 5, 5), test.ShouldEqual, 128)
+		test.That(t, grayAt(out, 30, 5), test.ShouldEqual, 255)
+		test.That(t, src.Close(ctx), test.ShouldBeNil)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		source := newSource(grayStripes(0, 0, 0, 0))
+		for errText, build := range map[string]func() error{
+			"tiles_x": func() error {
+				_, _, err := newCLAHETransform(ctx, source, camera.ColorStream, utils.AttributeMap{"tiles_x": 0})
+				return err
+			},
+			"clip_limit": func() error {
+				_, _, err := newCLAHETransform(ctx, source, camera.ColorStream, utils.AttributeMap{"clip_limit": -1})
+				return err
+			},
+			"gamma": func() error {
+				_, _, err := newGammaTransform(ctx, source, camera.ColorStream, utils.AttributeMap{})
+				return err
+			},
+			"depth": func() error {
+				_, _, err := newEqualizeTransform(ctx, source, camera.DepthStream, utils.AttributeMap{})
+				return err
+			},
+		} {
+			err := build()
+			test.That(t, err, test.ShouldNotBeNil)
+			test.That(t, err.Error(), test.ShouldContainSubstring, errText)
+		}
+	})
+}
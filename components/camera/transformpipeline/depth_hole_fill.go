@@ -0,0 +1,144 @@
+package transformpipeline
+
+import (
+	"context"
+	"fmt"
+	"image"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/utils"
+)
+
+// the hole filling methods.
+const (
+	holeFillMethodInpaint        = "inpaint"
+	holeFillMethodJointBilateral = "joint_bilateral"
+)
+
+const (
+	defaultHoleFillRadius        = 5
+	defaultHoleFillSpatialSigma  = 2.0
+	defaultHoleFillColorSigma    = 0.1
+	defaultHoleFillMaxIterations = 10
+)
+
+// depthHoleFillConfig are the attributes for a depth hole filling transform.
+type depthHoleFillConfig struct {
+	Method          string  `json:"method,omitempty"`
+	ColorCameraName string  `json:"color_camera_name,omitempty"`
+	RadiusPx        int     `json:"radius_px,omitempty"`
+	SpatialSigma    float64 `json:"spatial_sigma,omitempty"`
+	ColorSigma      float64 `json:"color_sigma,omitempty"`
+	MaxIterations   int     `json:"max_iterations,omitempty"`
+}
+
+// depthHoleFillSource fills in the missing pixels of the depth maps from its source, which stereo and
+// time of flight sensors leave where they could not measure depth.
+type depthHoleFillSource struct {
+	src  camera.VideoSource
+	conf *depthHoleFillConfig
+	r    robot.Robot
+}
+
+func newDepthHoleFillTransform(
+	ctx context.Context,
+	source camera.VideoSource,
+	stream camera.ImageType,
+	r robot.Robot,
+	am utils.AttributeMap,
+) (camera.VideoSource, camera.ImageType, error) {
+	if stream != camera.DepthStream {
+		return nil, camera.UnspecifiedStream,
+			errors.Errorf("source has stream type %s, depth_hole_fill only supports depth stream inputs", stream)
+	}
+	conf, err := resource.TransformAttributeMap[*depthHoleFillConfig](am)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	switch conf.Method {
+	case "", holeFillMethodInpaint:
+		conf.Method = holeFillMethodInpaint
+		if conf.MaxIterations == 0 {
+			conf.MaxIterations = defaultHoleFillMaxIterations
+		}
+	case holeFillMethodJointBilateral:
+		if conf.ColorCameraName == "" {
+			return nil, camera.UnspecifiedStream, errors.New("depth_hole_fill with joint_bilateral method requires color_camera_name")
+		}
+		if conf.RadiusPx == 0 {
+			conf.RadiusPx = defaultHoleFillRadius
+		}
+		if conf.SpatialSigma == 0 {
+			conf.SpatialSigma = defaultHoleFillSpatialSigma
+		}
+		if conf.ColorSigma == 0 {
+			conf.ColorSigma = defaultHoleFillColorSigma
+		}
+	default:
+		return nil, camera.UnspecifiedStream, errors.Errorf("unknown depth_hole_fill method %q, must be %s or %s",
+			conf.Method, holeFillMethodInpaint, holeFillMethodJointBilateral)
+	}
+	if conf.RadiusPx < 0 || conf.MaxIterations < 0 {
+		return nil, camera.UnspecifiedStream, errors.New("radius_px and max_iterations cannot be negative")
+	}
+
+	props, err := propsFromVideoSource(ctx, source)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	var cameraModel transform.PinholeCameraModel
+	cameraModel.PinholeCameraIntrinsics = props.IntrinsicParams
+	if props.DistortionParams != nil {
+		cameraModel.Distortion = props.DistortionParams
+	}
+	reader := &depthHoleFillSource{source, conf, r}
+	src, err := camera.NewVideoSourceFromReader(ctx, reader, &cameraModel, camera.DepthStream)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	return src, camera.DepthStream, err
+}
+
+// Read returns the depth map from the source with its holes filled in.
+func (hs *depthHoleFillSource) Read(ctx context.Context) (image.Image, func(), error) {
+	ctx, span := trace.StartSpan(ctx, "camera::transformpipeline::depth_hole_fill::Read")
+	defer span.End()
+	orig, release, err := camera.ReadImage(ctx, hs.src)
+	if err != nil {
+		return nil, nil, err
+	}
+	dm, err := rimage.ConvertImageToDepthMap(ctx, orig)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch hs.conf.Method {
+	case holeFillMethodJointBilateral:
+		colorCam, err := camera.FromRobot(hs.r, hs.conf.ColorCameraName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("depth_hole_fill cannot find color camera: %w", err)
+		}
+		img, err := camera.DecodeImageFromCamera(ctx, "", nil, colorCam)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not get image from color camera: %w", err)
+		}
+		filled, err := rimage.JointBilateralHoleFilling(dm, rimage.ConvertImage(img),
+			hs.conf.RadiusPx, hs.conf.SpatialSigma, hs.conf.ColorSigma)
+		if err != nil {
+			return nil, nil, err
+		}
+		return filled, release, nil
+	default:
+		return rimage.InpaintDepthMap(dm, hs.conf.MaxIterations), release, nil
+	}
+}
+
+func (hs *depthHoleFillSource) Close(ctx context.Context) error {
+	return nil
+}
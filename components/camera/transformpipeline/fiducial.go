@@ -0,0 +1,241 @@
+package transformpipeline
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fogleman/gg"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
+	visbarcode "go.viam.com/rdk/vision/barcode"
+)
+
+// getMarkersCommand is the DoCommand that returns the markers found in the last image.
+const getMarkersCommand = "get_markers"
+
+// fiducialConfig are the attributes for a fiducial transform, which detects the fiducial markers
+// in each image, such as ArUco markers and AprilTags.
+type fiducialConfig struct {
+	// Family is the built in family of markers to detect, and defaults to aruco_original.
+	Family string `json:"family,omitempty"`
+	// CustomFamily is a family of markers to detect instead of a built in one, given by its codes,
+	// such as the AprilTag tag36h11 family or an OpenCV ArUco dictionary.
+	CustomFamily *markerFamilyConfig `json:"custom_family,omitempty"`
+	// MarkerSizeMM is the printed width of the markers' black borders, for finding their poses
+	// with the intrinsics of the camera.
+	MarkerSizeMM float64 `json:"marker_size_mm,omitempty"`
+	// DrawMarkers outlines each marker and labels it with its ID. It defaults to true.
+	DrawMarkers bool `json:"draw_markers,omitempty"`
+	// Color is the color of the outlines and labels, and defaults to green.
+	Color string `json:"color,omitempty"`
+}
+
+// markerFamilyConfig is a family of square markers with a black border one cell wide.
+type markerFamilyConfig struct {
+	Name string `json:"name"`
+	// Size is the number of data cells along each side of a marker.
+	Size int `json:"size"`
+	// Codes are the data cells of the markers by ID as hex strings such as "0xd5d628584", read row
+	// by row from the top left with the first cell the most significant bit and a white cell a set
+	// bit.
+	Codes []string `json:"codes"`
+	// MaxCorrection is how many data cells of a marker may be misread for it to still be detected.
+	MaxCorrection int `json:"max_correction,omitempty"`
+}
+
+type fiducialSource struct {
+	src          camera.VideoSource
+	family       *visbarcode.MarkerFamily
+	intrinsics   *transform.PinholeCameraIntrinsics // nil unless poses are found
+	markerSizeMM float64
+	drawMarkers  bool
+	color        color.Color
+
+	mu       sync.Mutex
+	lastRead time.Time
+	last     []visbarcode.Marker
+	poses    []spatialmath.Pose
+}
+
+// newFiducialTransform creates a new transform that detects fiducial markers in images.
+func newFiducialTransform(
+	ctx context.Context, source camera.VideoSource, stream camera.ImageType, am utils.AttributeMap,
+) (camera.VideoSource, camera.ImageType, error) {
+	if stream == camera.DepthStream {
+		return nil, camera.UnspecifiedStream, errors.New("fiducial transform cannot detect markers in depth images")
+	}
+	// markers are drawn over the image without moving its pixels, so the intrinsics still hold
+	props, err := propsFromVideoSource(ctx, source)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	fs, err := newFiducialSource(source, props.IntrinsicParams, am)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	cameraModel := &transform.PinholeCameraModel{
+		PinholeCameraIntrinsics: props.IntrinsicParams,
+		Distortion:              props.DistortionParams,
+	}
+	src, err := camera.NewVideoSourceFromReader(ctx, fs, cameraModel, camera.ColorStream)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	return src, camera.ColorStream, err
+}
+
+func newFiducialSource(
+	source camera.VideoSource, intrinsics *transform.PinholeCameraIntrinsics, am utils.AttributeMap,
+) (*fiducialSource, error) {
+	conf, err := resource.TransformAttributeMap[*fiducialConfig](am)
+	if err != nil {
+		return nil, err
+	}
+	if !am.Has("draw_markers") {
+		conf.DrawMarkers = true
+	}
+	fs := &fiducialSource{src: source, drawMarkers: conf.DrawMarkers, color: rimage.Green}
+	switch {
+	case conf.CustomFamily != nil && conf.Family != "":
+		return nil, errors.New("fiducial transform can have either a family or a custom_family, not both")
+	case conf.CustomFamily != nil:
+		codes := make([]uint64, 0, len(conf.CustomFamily.Codes))
+		for _, c := range conf.CustomFamily.Codes {
+			code, err := strconv.ParseUint(c, 0, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid code %q in fiducial custom_family", c)
+			}
+			codes = append(codes, code)
+		}
+		fs.family, err = visbarcode.NewMarkerFamily(
+			conf.CustomFamily.Name, conf.CustomFamily.Size, codes, conf.CustomFamily.MaxCorrection)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid fiducial custom_family")
+		}
+	default:
+		if conf.Family == "" {
+			conf.Family = visbarcode.ArucoOriginal
+		}
+		if fs.family, err = visbarcode.ParseMarkerFamily(conf.Family); err != nil {
+			return nil, err
+		}
+	}
+	if conf.MarkerSizeMM < 0 {
+		return nil, errors.New("fiducial marker_size_mm cannot be negative")
+	}
+	if conf.MarkerSizeMM > 0 {
+		if err := intrinsics.CheckValid(); err != nil {
+			return nil, errors.Wrap(err, "fiducial transform needs the intrinsics of the camera to find marker poses")
+		}
+		fs.intrinsics, fs.markerSizeMM = intrinsics, conf.MarkerSizeMM
+	}
+	if conf.Color != "" {
+		if fs.color, err = rimage.NewColorFromHex(conf.Color); err != nil {
+			return nil, errors.Wrap(err, "invalid fiducial color")
+		}
+	}
+	return fs, nil
+}
+
+// Read detects the markers in the next image of the source, and draws them on it.
+func (fs *fiducialSource) Read(ctx context.Context) (image.Image, func(), error) {
+	ctx, span := trace.StartSpan(ctx, "camera::transformpipeline::fiducial::Read")
+	defer span.End()
+	orig, release, err := camera.ReadImage(ctx, fs.src)
+	if err != nil {
+		return nil, nil, err
+	}
+	markers := visbarcode.DecodeMarkers(orig, fs.family)
+	var poses []spatialmath.Pose
+	if fs.intrinsics != nil {
+		poses = make([]spatialmath.Pose, len(markers))
+		for i, m := range markers {
+			// a marker whose corners are degenerate has no pose, but is still reported
+			if pose, err := m.Pose(fs.intrinsics, fs.markerSizeMM); err == nil {
+				poses[i] = pose
+			}
+		}
+	}
+	fs.mu.Lock()
+	fs.lastRead = time.Now()
+	fs.last = markers
+	fs.poses = poses
+	fs.mu.Unlock()
+
+	if !fs.drawMarkers || len(markers) == 0 {
+		return orig, release, nil
+	}
+	dc := gg.NewContextForImage(orig)
+	dc.SetColor(fs.color)
+	dc.SetLineWidth(2)
+	for _, m := range markers {
+		for i, c := range m.Corners {
+			next := m.Corners[(i+1)%len(m.Corners)]
+			dc.DrawLine(c.X, c.Y, next.X, next.Y)
+			dc.Stroke()
+		}
+		// mark the top left corner, which gives the marker's orientation
+		dc.DrawCircle(m.Corners[0].X, m.Corners[0].Y, 4)
+		dc.Fill()
+		center := m.Center()
+		rimage.DrawString(dc, strconv.Itoa(m.ID), image.Pt(int(center.X), int(center.Y)), fs.color, 16)
+	}
+	return dc.Image(), release, nil
+}
+
+// DoCommand returns the markers found in the last image. The "get_markers" command returns each
+// marker with its family, ID and corners, clockwise from its top left, and with its pose in the
+// camera's frame if marker_size_mm is configured. Markers are only detected when images are read,
+// so a transform that is not being read has not detected any.
+func (fs *fiducialSource) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	if _, ok := cmd[getMarkersCommand]; !ok {
+		return nil, resource.ErrDoUnimplemented
+	}
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	var lastRead interface{}
+	if !fs.lastRead.IsZero() {
+		lastRead = fs.lastRead.Format(time.RFC3339Nano)
+	}
+	markers := make([]interface{}, 0, len(fs.last))
+	for i, m := range fs.last {
+		corners := make([]interface{}, 0, len(m.Corners))
+		for _, c := range m.Corners {
+			corners = append(corners, []interface{}{c.X, c.Y})
+		}
+		marker := map[string]interface{}{
+			"family":  m.Family,
+			"id":      m.ID,
+			"corners": corners,
+		}
+		if i < len(fs.poses) && fs.poses[i] != nil {
+			p := spatialmath.PoseToProtobuf(fs.poses[i])
+			marker["pose"] = map[string]interface{}{
+				"x":     p.X,
+				"y":     p.Y,
+				"z":     p.Z,
+				"o_x":   p.OX,
+				"o_y":   p.OY,
+				"o_z":   p.OZ,
+				"theta": p.Theta,
+			}
+		}
+		markers = append(markers, marker)
+	}
+	return map[string]interface{}{"time": lastRead, "markers": markers}, nil
+}
+
+func (fs *fiducialSource) Close(ctx context.Context) error {
+	return nil
+}
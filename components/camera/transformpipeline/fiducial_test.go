@@ -0,0 +1,157 @@
+package transformpipeline
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/camera/fake"
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/utils"
+	visbarcode "go.viam.com/rdk/vision/barcode"
+)
+
+// markerImage returns a 320x240 image with the original ArUco marker of the given ID drawn at 10
+// pixels per cell, its top left corner at (100, 60).
+func markerImage(t *testing.T, id int) image.Image {
+	t.Helper()
+	f, err := visbarcode.ParseMarkerFamily(visbarcode.ArucoOriginal)
+	test.That(t, err, test.ShouldBeNil)
+	img := image.NewGray(image.Rect(0, 0, 320, 240))
+	for i := range img.Pix {
+		img.Pix[i] = 255
+	}
+	for y := 0; y < 7; y++ {
+		for x := 0; x < 7; x++ {
+			black := true
+			if x > 0 && y > 0 && x < 6 && y < 6 {
+				black = f.Codes[id]>>(24-(5*(y-1)+x-1))&1 == 0
+			}
+			if !black {
+				continue
+			}
+			for dy := 0; dy < 10; dy++ {
+				for dx := 0; dx < 10; dx++ {
+					img.SetGray(100+10*x+dx, 60+10*y+dy, color.Gray{})
+				}
+			}
+		}
+	}
+	return img
+}
+
+func TestFiducial(t *testing.T) {
+	ctx := context.Background()
+	intrinsics := &transform.PinholeCameraIntrinsics{Width: 320, Height: 240, Fx: 300, Fy: 300, Ppx: 160, Ppy: 120}
+	source, err := camera.NewVideoSourceFromReader(
+		ctx,
+		&fake.StaticSource{ColorImg: markerImage(t, 300)},
+		&transform.PinholeCameraModel{PinholeCameraIntrinsics: intrinsics},
+		camera.ColorStream,
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	fs, err := newFiducialSource(source, intrinsics, utils.AttributeMap{"marker_size_mm": 70})
+	test.That(t, err, test.ShouldBeNil)
+
+	// nothing has been detected before the first image
+	resp, err := fs.DoCommand(ctx, map[string]interface{}{getMarkersCommand: true})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["time"], test.ShouldBeNil)
+	test.That(t, resp["markers"], test.ShouldBeEmpty)
+
+	out, _, err := fs.Read(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, out.Bounds(), test.ShouldResemble, image.Rect(0, 0, 320, 240))
+	// the marker is outlined
+	test.That(t, hasGreenPixel(out, image.Rect(95, 55, 105, 65)), test.ShouldBeTrue)
+	test.That(t, hasGreenPixel(out, image.Rect(200, 0, 320, 240)), test.ShouldBeFalse)
+
+	resp, err = fs.DoCommand(ctx, map[string]interface{}{getMarkersCommand: true})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["time"], test.ShouldNotBeNil)
+	markers, ok := resp["markers"].([]interface{})
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, markers, test.ShouldHaveLength, 1)
+	marker, ok := markers[0].(map[string]interface{})
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, marker["family"], test.ShouldEqual, visbarcode.ArucoOriginal)
+	test.That(t, marker["id"], test.ShouldEqual, 300)
+	test.That(t, marker["corners"], test.ShouldHaveLength, 4)
+	// the marker is 70 pixels wide, so at a focal length of 300 pixels a 70mm marker is 300mm away
+	pose, ok := marker["pose"].(map[string]interface{})
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, pose["x"], test.ShouldAlmostEqual, -25, 2)
+	test.That(t, pose["y"], test.ShouldAlmostEqual, -25, 2)
+	test.That(t, pose["z"], test.ShouldAlmostEqual, 300, 5)
+
+	_, err = fs.DoCommand(ctx, map[string]interface{}{"other": true})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, fs.Close(ctx), test.ShouldBeNil)
+
+	t.Run("custom family", func(t *testing.T) {
+		f, err := visbarcode.ParseMarkerFamily(visbarcode.ArucoOriginal)
+		test.That(t, err, test.ShouldBeNil)
+		// a family of just the marker in the image, given its own ID
+		customFamily := map[string]interface{}{
+			"name":  "dock",
+			"size":  5,
+			"codes": []interface{}{"0x0", fmt.Sprintf("%#x", f.Codes[300])},
+		}
+		fs, err := newFiducialSource(source, nil, utils.AttributeMap{"custom_family": customFamily, "draw_markers": false})
+		test.That(t, err, test.ShouldBeNil)
+		out, _, err := fs.Read(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, hasGreenPixel(out, out.Bounds()), test.ShouldBeFalse)
+		resp, err := fs.DoCommand(ctx, map[string]interface{}{getMarkersCommand: true})
+		test.That(t, err, test.ShouldBeNil)
+		markers, ok := resp["markers"].([]interface{})
+		test.That(t, ok, test.ShouldBeTrue)
+		test.That(t, markers, test.ShouldHaveLength, 1)
+		marker, ok := markers[0].(map[string]interface{})
+		test.That(t, ok, test.ShouldBeTrue)
+		test.That(t, marker["family"], test.ShouldEqual, "dock")
+		test.That(t, marker["id"], test.ShouldEqual, 1)
+		// there are no poses without a marker size
+		test.That(t, marker["pose"], test.ShouldBeNil)
+	})
+
+	t.Run("pipeline", func(t *testing.T) {
+		src, stream, err := newFiducialTransform(ctx, source, camera.ColorStream, utils.AttributeMap{})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, stream, test.ShouldEqual, camera.ColorStream)
+		tp := transformPipeline{pipeline: []camera.VideoSource{src, source}}
+		_, err = tp.DoCommand(ctx, map[string]interface{}{getMarkersCommand: true})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, src.Close(ctx), test.ShouldBeNil)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		customFamily := func(code string) map[string]interface{} {
+			return map[string]interface{}{"name": "a", "size": 1, "codes": []interface{}{code}}
+		}
+		for errText, attrs := range map[string]utils.AttributeMap{
+			"tag99h99":       {"family": "tag99h99"},
+			"not both":       {"family": "aruco_original", "custom_family": customFamily("1")},
+			"custom_family":  {"custom_family": customFamily("zz")},
+			"marker_size_mm": {"marker_size_mm": -1},
+			"color":          {"color": "green"},
+		} {
+			_, err := newFiducialSource(source, intrinsics, attrs)
+			test.That(t, err, test.ShouldNotBeNil)
+			test.That(t, err.Error(), test.ShouldContainSubstring, errText)
+		}
+		// poses need intrinsics
+		_, err := newFiducialSource(source, nil, utils.AttributeMap{"marker_size_mm": 70})
+		test.That(t, err, test.ShouldNotBeNil)
+		_, _, err = newFiducialTransform(ctx, source, camera.DepthStream, utils.AttributeMap{})
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
+	test.That(t, source.Close(ctx), test.ShouldBeNil)
+}
@@ -0,0 +1,163 @@
+package transformpipeline
+
+import (
+	"context"
+	"image"
+	"image/color"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/utils"
+)
+
+// the images an hsv_mask transform can output.
+const (
+	hsvMaskOutputMask  = "mask"
+	hsvMaskOutputImage = "image"
+)
+
+// hsvMaskConfig are the attributes for an hsv_mask transform. Hues are in degrees, and a range whose
+// minimum is greater than its maximum wraps around 360, so that reds can be selected. Saturations and
+// values are between 0 and 1, and all the maximums default to the largest possible value.
+type hsvMaskConfig struct {
+	HueMin        float64 `json:"hue_min_degs,omitempty"`
+	HueMax        float64 `json:"hue_max_degs,omitempty"`
+	SaturationMin float64 `json:"saturation_min,omitempty"`
+	SaturationMax float64 `json:"saturation_max,omitempty"`
+	ValueMin      float64 `json:"value_min,omitempty"`
+	ValueMax      float64 `json:"value_max,omitempty"`
+	// Output is "mask", the default, for a black and white image that is white where pixels are in range,
+	// or "image" for the source image with the pixels that are out of range blacked out.
+	Output string `json:"output,omitempty"`
+	// Invert selects the pixels that are out of range instead, which makes a chroma key of a green screen.
+	Invert bool `json:"invert,omitempty"`
+	// Transparent makes the pixels that are masked out transparent rather than black in an "image" output.
+	Transparent bool `json:"transparent,omitempty"`
+}
+
+type hsvMaskSource struct {
+	src         camera.VideoSource
+	hueMin      float64
+	hueMax      float64
+	satMin      float64
+	satMax      float64
+	valMin      float64
+	valMax      float64
+	output      string
+	invert      bool
+	transparent bool
+}
+
+// newHSVMaskTransform creates a new transform that masks images by their HSV colors.
+func newHSVMaskTransform(
+	ctx context.Context, source camera.VideoSource, stream camera.ImageType, am utils.AttributeMap,
+) (camera.VideoSource, camera.ImageType, error) {
+	conf, err := resource.TransformAttributeMap[*hsvMaskConfig](am)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	if stream == camera.DepthStream {
+		return nil, camera.UnspecifiedStream, errors.New("hsv_mask transform cannot mask depth images")
+	}
+	if !am.Has("hue_max_degs") {
+		conf.HueMax = 360
+	}
+	if !am.Has("saturation_max") {
+		conf.SaturationMax = 1
+	}
+	if !am.Has("value_max") {
+		conf.ValueMax = 1
+	}
+	if conf.HueMin < 0 || conf.HueMin > 360 || conf.HueMax < 0 || conf.HueMax > 360 {
+		return nil, camera.UnspecifiedStream, errors.New("hsv_mask hues must be between 0 and 360 degrees")
+	}
+	for _, r := range [][2]float64{{conf.SaturationMin, conf.SaturationMax}, {conf.ValueMin, conf.ValueMax}} {
+		if r[0] < 0 || r[1] > 1 || r[0] > r[1] {
+			return nil, camera.UnspecifiedStream, errors.New(
+				"hsv_mask saturations and values must be between 0 and 1, with each minimum at most its maximum")
+		}
+	}
+	switch conf.Output {
+	case "":
+		conf.Output = hsvMaskOutputMask
+	case hsvMaskOutputMask, hsvMaskOutputImage:
+	default:
+		return nil, camera.UnspecifiedStream, errors.Errorf(
+			"hsv_mask output must be %q or %q, not %q", hsvMaskOutputMask, hsvMaskOutputImage, conf.Output)
+	}
+	reader := &hsvMaskSource{
+		src:         source,
+		hueMin:      conf.HueMin,
+		hueMax:      conf.HueMax,
+		satMin:      conf.SaturationMin,
+		satMax:      conf.SaturationMax,
+		valMin:      conf.ValueMin,
+		valMax:      conf.ValueMax,
+		output:      conf.Output,
+		invert:      conf.Invert,
+		transparent: conf.Transparent,
+	}
+	src, err := camera.NewVideoSourceFromReader(ctx, reader, nil, camera.ColorStream)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	return src, camera.ColorStream, err
+}
+
+// selected returns whether a color is selected by the mask.
+func (hs *hsvMaskSource) selected(c rimage.Color) bool {
+	h, s, v := c.HsvNormal()
+	var inRange bool
+	if hs.hueMin <= hs.hueMax {
+		inRange = h >= hs.hueMin && h <= hs.hueMax
+	} else {
+		inRange = h >= hs.hueMin || h <= hs.hueMax
+	}
+	inRange = inRange && s >= hs.satMin && s <= hs.satMax && v >= hs.valMin && v <= hs.valMax
+	return inRange != hs.invert
+}
+
+// Read masks the next image of the source.
+func (hs *hsvMaskSource) Read(ctx context.Context) (image.Image, func(), error) {
+	ctx, span := trace.StartSpan(ctx, "camera::transformpipeline::hsv_mask::Read")
+	defer span.End()
+	orig, release, err := camera.ReadImage(ctx, hs.src)
+	if err != nil {
+		return nil, nil, err
+	}
+	img := rimage.ConvertImage(orig)
+	bounds := image.Rect(0, 0, img.Width(), img.Height())
+	if hs.output == hsvMaskOutputMask {
+		mask := image.NewGray(bounds)
+		for y := 0; y < img.Height(); y++ {
+			for x := 0; x < img.Width(); x++ {
+				if hs.selected(img.GetXY(x, y)) {
+					mask.SetGray(x, y, color.Gray{Y: 255})
+				}
+			}
+		}
+		return mask, release, nil
+	}
+	masked := image.NewNRGBA(bounds)
+	for y := 0; y < img.Height(); y++ {
+		for x := 0; x < img.Width(); x++ {
+			c := img.GetXY(x, y)
+			switch {
+			case hs.selected(c):
+				r, g, b := c.RGB255()
+				masked.SetNRGBA(x, y, color.NRGBA{R: r, G: g, B: b, A: 255})
+			case !hs.transparent:
+				masked.SetNRGBA(x, y, color.NRGBA{A: 255})
+			}
+		}
+	}
+	return masked, release, nil
+}
+
+func (hs *hsvMaskSource) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,78 @@
+package transformpipeline
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/camera/fake"
+	"go.viam.com/rdk/utils"
+)
+
+func TestHSVMask(t *testing.T) {
+	ctx := context.Background()
+	// red on the left and green on the right
+	img := image.NewNRGBA(image.Rect(0, 0, 20, 10))
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 20; x++ {
+			if x < 10 {
+				img.SetNRGBA(x, y, color.NRGBA{R: 255, A: 255})
+			} else {
+				img.SetNRGBA(x, y, color.NRGBA{G: 255, A: 255})
+			}
+		}
+	}
+	source, err := camera.NewVideoSourceFromReader(ctx, &fake.StaticSource{ColorImg: img}, nil, camera.UnspecifiedStream)
+	test.That(t, err, test.ShouldBeNil)
+
+	am := utils.AttributeMap{"hue_min_degs": 100, "hue_max_degs": 140, "saturation_min": 0.5}
+	hs, stream, err := newHSVMaskTransform(ctx, source, camera.ColorStream, am)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, stream, test.ShouldEqual, camera.ColorStream)
+	out, _, err := camera.ReadImage(ctx, hs)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, out.Bounds(), test.ShouldResemble, img.Bounds())
+	test.That(t, color.GrayModel.Convert(out.At(2, 2)), test.ShouldResemble, color.Gray{Y: 0})
+	test.That(t, color.GrayModel.Convert(out.At(15, 2)), test.ShouldResemble, color.Gray{Y: 255})
+	test.That(t, hs.Close(ctx), test.ShouldBeNil)
+
+	// a hue range can wrap around to select reds
+	am = utils.AttributeMap{"hue_min_degs": 340, "hue_max_degs": 20}
+	hs, _, err = newHSVMaskTransform(ctx, source, camera.ColorStream, am)
+	test.That(t, err, test.ShouldBeNil)
+	out, _, err = camera.ReadImage(ctx, hs)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, color.GrayModel.Convert(out.At(2, 2)), test.ShouldResemble, color.Gray{Y: 255})
+	test.That(t, color.GrayModel.Convert(out.At(15, 2)), test.ShouldResemble, color.Gray{Y: 0})
+	test.That(t, hs.Close(ctx), test.ShouldBeNil)
+
+	// a chroma key removes the green screen
+	am = utils.AttributeMap{"hue_min_degs": 100, "hue_max_degs": 140, "output": "image", "invert": true, "transparent": true}
+	hs, _, err = newHSVMaskTransform(ctx, source, camera.ColorStream, am)
+	test.That(t, err, test.ShouldBeNil)
+	out, _, err = camera.ReadImage(ctx, hs)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, color.NRGBAModel.Convert(out.At(2, 2)), test.ShouldResemble, color.NRGBA{R: 255, A: 255})
+	test.That(t, color.NRGBAModel.Convert(out.At(15, 2)), test.ShouldResemble, color.NRGBA{})
+	test.That(t, hs.Close(ctx), test.ShouldBeNil)
+
+	t.Run("invalid", func(t *testing.T) {
+		for errText, attrs := range map[string]utils.AttributeMap{
+			"hues":   {"hue_max_degs": 400},
+			"values": {"value_min": 0.8, "value_max": 0.2},
+			"output": {"output": "overlay"},
+		} {
+			_, _, err := newHSVMaskTransform(ctx, source, camera.ColorStream, attrs)
+			test.That(t, err, test.ShouldNotBeNil)
+			test.That(t, err.Error(), test.ShouldContainSubstring, errText)
+		}
+		_, _, err := newHSVMaskTransform(ctx, source, camera.DepthStream, utils.AttributeMap{})
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
+	test.That(t, source.Close(ctx), test.ShouldBeNil)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"image"
 	"image/color"
+	"math"
 
 	"github.com/disintegration/imaging"
 	"github.com/pkg/errors"
@@ -155,12 +156,16 @@ func (rs *resizeSource) Close(ctx context.Context) error {
 	return nil
 }
 
-// cropConfig are the attributes for a crop transform.
+// cropConfig are the attributes for a crop transform. The bounds are in pixels unless Relative is set,
+// or all of them are at most 1, in which case they are fractions of the image's width and height.
+// If AspectRatio (width / height) is set, the crop window is shrunk about its center to have it.
 type cropConfig struct {
 	XMin        float64 `json:"x_min_px"`
 	YMin        float64 `json:"y_min_px"`
 	XMax        float64 `json:"x_max_px"`
 	YMax        float64 `json:"y_max_px"`
+	Relative    bool    `json:"relative,omitempty"`
+	AspectRatio float64 `json:"aspect_ratio,omitempty"`
 	ShowCropBox bool    `json:"overlay_crop_box"`
 }
 
@@ -169,6 +174,7 @@ type cropSource struct {
 	imgType     camera.ImageType
 	cropWindow  image.Rectangle
 	cropRel     []float64
+	aspectRatio float64
 	showCropBox bool
 	imgBounds   image.Rectangle
 }
@@ -190,9 +196,17 @@ func newCropTransform(
 	if conf.YMin >= conf.YMax {
 		return nil, camera.UnspecifiedStream, errors.New("cannot crop image to 0 height (y_min is >= y_max)")
 	}
+	if conf.AspectRatio < 0 {
+		return nil, camera.UnspecifiedStream, errors.New("aspect_ratio must be a positive number")
+	}
 	cropRect := image.Rectangle{}
 	cropRel := []float64{}
 	switch {
+	case conf.Relative:
+		if conf.XMax > 1.0 || conf.YMax > 1.0 {
+			return nil, camera.UnspecifiedStream, errors.New("if relative is set, all crop attributes must be between 0 and 1")
+		}
+		cropRel = []float64{conf.XMin, conf.YMin, conf.XMax, conf.YMax}
 	case conf.XMax == 1.0 && conf.YMax == 1.0 && conf.XMin == 0.0 && conf.YMin == 0.0:
 		// interpreting this to mean cropping to the upper left pixel
 		// you wouldn't use crop if you weren't gonna crop your image
@@ -208,12 +222,16 @@ func newCropTransform(
 		}
 		cropRel = []float64{conf.XMin, conf.YMin, conf.XMax, conf.YMax}
 	}
+	if len(cropRel) == 0 {
+		cropRect = lockAspectRatio(cropRect, conf.AspectRatio)
+	}
 
 	reader := &cropSource{
 		src:         source,
 		imgType:     stream,
 		cropWindow:  cropRect,
 		cropRel:     cropRel,
+		aspectRatio: conf.AspectRatio,
 		showCropBox: conf.ShowCropBox,
 	}
 	src, err := camera.NewVideoSourceFromReader(ctx, reader, nil, stream)
@@ -238,6 +256,25 @@ func (cs *cropSource) relToAbsCrop(img image.Image) image.Rectangle {
 
 	// Create cropping rectangle
 	rect := image.Rect(x1, y1, x2, y2)
+	return lockAspectRatio(rect, cs.aspectRatio)
+}
+
+// lockAspectRatio shrinks rect about its center so that its width / height is ratio.
+// A ratio of 0 leaves rect as it is.
+func lockAspectRatio(rect image.Rectangle, ratio float64) image.Rectangle {
+	if ratio <= 0 || rect.Empty() {
+		return rect
+	}
+	width, height := rect.Dx(), rect.Dy()
+	if float64(width) > ratio*float64(height) {
+		newWidth := max(int(math.Round(ratio*float64(height))), 1)
+		rect.Min.X += (width - newWidth) / 2
+		rect.Max.X = rect.Min.X + newWidth
+	} else {
+		newHeight := max(int(math.Round(float64(width)/ratio)), 1)
+		rect.Min.Y += (height - newHeight) / 2
+		rect.Max.Y = rect.Min.Y + newHeight
+	}
 	return rect
 }
 
@@ -255,6 +292,7 @@ func (cs *cropSource) Read(ctx context.Context) (image.Image, func(), error) {
 	// check to see if the image size changed, and if the relative crop needs to be redone
 	if cs.imgBounds != orig.Bounds() && len(cs.cropRel) != 0 {
 		cs.cropWindow = image.Rectangle{} // reset the crop box
+		cs.imgBounds = orig.Bounds()
 	}
 	if cs.cropWindow.Empty() && len(cs.cropRel) != 0 {
 		cs.cropWindow = cs.relToAbsCrop(orig)
@@ -131,6 +131,64 @@ func TestCrop(t *testing.T) {
 	test.That(t, out, test.ShouldHaveSameTypeAs, &image.NRGBA{})
 	test.That(t, rs.Close(context.Background()), test.ShouldBeNil)
 
+	// explicitly relative crop of the whole image
+	am = utils.AttributeMap{
+		"x_min_px": 0.0,
+		"x_max_px": 1.0,
+		"y_min_px": 0.0,
+		"y_max_px": 1.0,
+		"relative": true,
+	}
+	rs, stream, err = newCropTransform(context.Background(), source, camera.ColorStream, am)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, stream, test.ShouldEqual, camera.ColorStream)
+	out, _, err = camera.ReadImage(context.Background(), rs)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, out.Bounds().Dx(), test.ShouldEqual, 100)
+	test.That(t, out.Bounds().Dy(), test.ShouldEqual, 100)
+	test.That(t, rs.Close(context.Background()), test.ShouldBeNil)
+
+	// relative crop locked to an aspect ratio is shrunk about its center
+	am = utils.AttributeMap{
+		"x_min_px":     0.0,
+		"x_max_px":     1.0,
+		"y_min_px":     0.0,
+		"y_max_px":     1.0,
+		"relative":     true,
+		"aspect_ratio": 2.0,
+	}
+	rs, stream, err = newCropTransform(context.Background(), source, camera.ColorStream, am)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, stream, test.ShouldEqual, camera.ColorStream)
+	out, _, err = camera.ReadImage(context.Background(), rs)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, out.Bounds().Dx(), test.ShouldEqual, 100)
+	test.That(t, out.Bounds().Dy(), test.ShouldEqual, 50)
+	test.That(t, rs.Close(context.Background()), test.ShouldBeNil)
+
+	// the same relative crop follows the resolution of the camera
+	wideSource, err := camera.NewVideoSourceFromReader(
+		context.Background(), &fake.StaticSource{ColorImg: image.NewRGBA(image.Rect(0, 0, 400, 100))}, nil, camera.UnspecifiedStream)
+	test.That(t, err, test.ShouldBeNil)
+	rs, _, err = newCropTransform(context.Background(), wideSource, camera.ColorStream, am)
+	test.That(t, err, test.ShouldBeNil)
+	out, _, err = camera.ReadImage(context.Background(), rs)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, out.Bounds().Dx(), test.ShouldEqual, 200)
+	test.That(t, out.Bounds().Dy(), test.ShouldEqual, 100)
+	test.That(t, rs.Close(context.Background()), test.ShouldBeNil)
+	test.That(t, wideSource.Close(context.Background()), test.ShouldBeNil)
+
+	// pixel crop locked to an aspect ratio
+	am = utils.AttributeMap{"x_min_px": 10, "x_max_px": 50, "y_min_px": 10, "y_max_px": 20, "aspect_ratio": 1.0}
+	rs, _, err = newCropTransform(context.Background(), source, camera.ColorStream, am)
+	test.That(t, err, test.ShouldBeNil)
+	out, _, err = camera.ReadImage(context.Background(), rs)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, out.Bounds().Dx(), test.ShouldEqual, 10)
+	test.That(t, out.Bounds().Dy(), test.ShouldEqual, 10)
+	test.That(t, rs.Close(context.Background()), test.ShouldBeNil)
+
 	// relative crop but you just overlay the box
 	am = utils.AttributeMap{
 		"x_min_px":         0.2,
@@ -168,6 +226,16 @@ func TestCrop(t *testing.T) {
 	_, _, err = newCropTransform(context.Background(), source, camera.ColorStream, am)
 	test.That(t, err, test.ShouldNotBeNil)
 	test.That(t, err.Error(), test.ShouldContainSubstring, "negative number")
+	// error - relative attributes greater than 1
+	am = utils.AttributeMap{"x_min_px": 0, "x_max_px": 10, "y_min_px": 0, "y_max_px": 1, "relative": true}
+	_, _, err = newCropTransform(context.Background(), source, camera.ColorStream, am)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "between 0 and 1")
+	// error - negative aspect ratio
+	am = utils.AttributeMap{"x_min_px": 0, "x_max_px": 10, "y_min_px": 0, "y_max_px": 10, "aspect_ratio": -1}
+	_, _, err = newCropTransform(context.Background(), source, camera.ColorStream, am)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "aspect_ratio")
 
 	// close the source
 	test.That(t, source.Close(context.Background()), test.ShouldBeNil)
@@ -650,3 +718,38 @@ func BenchmarkDepthRotate(b *testing.B) {
 	test.That(b, rs.Close(context.Background()), test.ShouldBeNil)
 	test.That(b, source.Close(context.Background()), test.ShouldBeNil)
 }
+
+func TestDepthHoleFill(t *testing.T) {
+	dm := rimage.NewEmptyDepthMap(20, 10)
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 20; x++ {
+			dm.Set(x, y, 1000)
+		}
+	}
+	dm.Set(10, 5, 0)
+	dm.Set(11, 5, 0)
+	source, err := camera.NewVideoSourceFromReader(context.Background(), &fake.StaticSource{DepthImg: dm}, nil, camera.DepthStream)
+	test.That(t, err, test.ShouldBeNil)
+
+	_, _, err = newDepthHoleFillTransform(context.Background(), source, camera.ColorStream, nil, utils.AttributeMap{})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, _, err = newDepthHoleFillTransform(context.Background(), source, camera.DepthStream, nil,
+		utils.AttributeMap{"method": "joint_bilateral"})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "color_camera_name")
+	_, _, err = newDepthHoleFillTransform(context.Background(), source, camera.DepthStream, nil,
+		utils.AttributeMap{"method": "telea"})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	hs, stream, err := newDepthHoleFillTransform(context.Background(), source, camera.DepthStream, nil, utils.AttributeMap{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, stream, test.ShouldEqual, camera.DepthStream)
+	out, _, err := camera.ReadImage(context.Background(), hs)
+	test.That(t, err, test.ShouldBeNil)
+	filled, err := rimage.ConvertImageToDepthMap(context.Background(), out)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, filled.GetDepth(10, 5), test.ShouldEqual, rimage.Depth(1000))
+	test.That(t, filled.GetDepth(11, 5), test.ShouldEqual, rimage.Depth(1000))
+	test.That(t, hs.Close(context.Background()), test.ShouldBeNil)
+	test.That(t, source.Close(context.Background()), test.ShouldBeNil)
+}
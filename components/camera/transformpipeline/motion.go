@@ -0,0 +1,219 @@
+package transformpipeline
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+	"time"
+
+	"github.com/fogleman/gg"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/utils"
+)
+
+// the images a motion transform can output.
+const (
+	motionOutputMask  = "mask"
+	motionOutputImage = "image"
+)
+
+// getLastMotionCommand is the DoCommand that returns when motion was last seen.
+const getLastMotionCommand = "get_last_motion"
+
+// motionConfig are the attributes for a motion transform, which detects motion by differencing each
+// image with the one before it.
+type motionConfig struct {
+	// Threshold is how much the brightness of a pixel, from 0 to 255, must change for it to have moved.
+	// It defaults to 25.
+	Threshold int `json:"threshold,omitempty"`
+	// MinAreaPx is how many pixels a moving region must cover to count as motion, so that noise is ignored.
+	MinAreaPx int `json:"min_area_px,omitempty"`
+	// Output is "mask", the default, for a black and white image that is white where pixels moved,
+	// or "image" for the source image with a box drawn around each moving region.
+	Output string `json:"output,omitempty"`
+	// BoxColor is the color of the boxes drawn on an "image" output, and defaults to red.
+	BoxColor string `json:"box_color,omitempty"`
+}
+
+type motionSource struct {
+	src       camera.VideoSource
+	threshold int
+	minArea   int
+	output    string
+	boxColor  color.Color
+
+	mu         sync.Mutex
+	prev       *image.Gray
+	lastMotion time.Time
+	lastBoxes  []image.Rectangle
+}
+
+// newMotionTransform creates a new transform that detects motion between consecutive images.
+func newMotionTransform(
+	ctx context.Context, source camera.VideoSource, stream camera.ImageType, am utils.AttributeMap,
+) (camera.VideoSource, camera.ImageType, error) {
+	if stream == camera.DepthStream {
+		return nil, camera.UnspecifiedStream, errors.New("motion transform cannot detect motion in depth images")
+	}
+	ms, err := newMotionSource(source, am)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	src, err := camera.NewVideoSourceFromReader(ctx, ms, nil, camera.ColorStream)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	return src, camera.ColorStream, err
+}
+
+func newMotionSource(source camera.VideoSource, am utils.AttributeMap) (*motionSource, error) {
+	conf, err := resource.TransformAttributeMap[*motionConfig](am)
+	if err != nil {
+		return nil, err
+	}
+	if !am.Has("threshold") {
+		conf.Threshold = 25
+	}
+	if conf.Threshold < 0 || conf.Threshold > 255 {
+		return nil, errors.New("motion threshold must be between 0 and 255")
+	}
+	if conf.MinAreaPx < 0 {
+		return nil, errors.New("motion min_area_px cannot be negative")
+	}
+	switch conf.Output {
+	case "":
+		conf.Output = motionOutputMask
+	case motionOutputMask, motionOutputImage:
+	default:
+		return nil, errors.Errorf(
+			"motion output must be %q or %q, not %q", motionOutputMask, motionOutputImage, conf.Output)
+	}
+	ms := &motionSource{
+		src:       source,
+		threshold: conf.Threshold,
+		minArea:   conf.MinAreaPx,
+		output:    conf.Output,
+		boxColor:  rimage.Red,
+	}
+	if conf.BoxColor != "" {
+		if ms.boxColor, err = rimage.NewColorFromHex(conf.BoxColor); err != nil {
+			return nil, errors.Wrap(err, "invalid motion box_color")
+		}
+	}
+	return ms, nil
+}
+
+// Read compares the next image of the source with the one before it, and returns where it moved.
+func (ms *motionSource) Read(ctx context.Context) (image.Image, func(), error) {
+	ctx, span := trace.StartSpan(ctx, "camera::transformpipeline::motion::Read")
+	defer span.End()
+	orig, release, err := camera.ReadImage(ctx, ms.src)
+	if err != nil {
+		return nil, nil, err
+	}
+	bounds := image.Rect(0, 0, orig.Bounds().Dx(), orig.Bounds().Dy())
+	gray := image.NewGray(bounds)
+	draw.Draw(gray, bounds, orig, orig.Bounds().Min, draw.Src)
+
+	mask := image.NewGray(bounds)
+	ms.mu.Lock()
+	// the first image, and the first after the size of the images changes, have nothing to compare to
+	if ms.prev != nil && ms.prev.Bounds() == bounds {
+		for i, v := range gray.Pix {
+			diff := int(v) - int(ms.prev.Pix[i])
+			if diff > ms.threshold || -diff > ms.threshold {
+				mask.Pix[i] = 255
+			}
+		}
+	}
+	ms.prev = gray
+	boxes := motionRegions(mask, ms.minArea)
+	ms.lastBoxes = boxes
+	if len(boxes) != 0 {
+		ms.lastMotion = time.Now()
+	}
+	ms.mu.Unlock()
+
+	if ms.output == motionOutputMask {
+		return mask, release, nil
+	}
+	dc := gg.NewContextForImage(orig)
+	for _, box := range boxes {
+		rimage.DrawRectangleEmpty(dc, box, ms.boxColor, 2)
+	}
+	return dc.Image(), release, nil
+}
+
+// motionRegions returns the bounding boxes of the regions of the mask that moved and cover at least
+// minArea pixels. Pixels are in the same region if they touch, including diagonally.
+func motionRegions(mask *image.Gray, minArea int) []image.Rectangle {
+	width, height := mask.Rect.Dx(), mask.Rect.Dy()
+	seen := make([]bool, len(mask.Pix))
+	var boxes []image.Rectangle
+	var stack []int
+	for start, v := range mask.Pix {
+		if v == 0 || seen[start] {
+			continue
+		}
+		box := image.Rect(start%width, start/width, start%width+1, start/width+1)
+		area := 0
+		seen[start] = true
+		stack = append(stack[:0], start)
+		for len(stack) != 0 {
+			i := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			area++
+			x, y := i%width, i/width
+			box = box.Union(image.Rect(x, y, x+1, y+1))
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					nx, ny := x+dx, y+dy
+					if nx < 0 || ny < 0 || nx >= width || ny >= height {
+						continue
+					}
+					if j := ny*width + nx; mask.Pix[j] != 0 && !seen[j] {
+						seen[j] = true
+						stack = append(stack, j)
+					}
+				}
+			}
+		}
+		if area >= minArea {
+			boxes = append(boxes, box)
+		}
+	}
+	return boxes
+}
+
+// DoCommand returns when motion was last seen, for the "get_last_motion" command. Motion is only
+// looked for when images are read, so a transform that is not being read does not see any.
+func (ms *motionSource) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	if _, ok := cmd[getLastMotionCommand]; !ok {
+		return nil, resource.ErrDoUnimplemented
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	resp := map[string]interface{}{"last_motion": nil, "motion": len(ms.lastBoxes) != 0}
+	if !ms.lastMotion.IsZero() {
+		resp["last_motion"] = ms.lastMotion.Format(time.RFC3339Nano)
+	}
+	boxes := make([]interface{}, 0, len(ms.lastBoxes))
+	for _, box := range ms.lastBoxes {
+		boxes = append(boxes, map[string]interface{}{
+			"x_min": box.Min.X, "y_min": box.Min.Y, "x_max": box.Max.X, "y_max": box.Max.Y,
+		})
+	}
+	resp["boxes"] = boxes
+	return resp, nil
+}
+
+func (ms *motionSource) Close(ctx context.Context) error {
+	return nil
+}
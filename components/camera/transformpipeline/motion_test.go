@@ -0,0 +1,163 @@
+package transformpipeline
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"sync"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/camera/fake"
+	"go.viam.com/rdk/utils"
+)
+
+// frameSequence returns its frames in order, repeating the last one. It is read directly rather than
+// through a stream, so that each read gets the next frame.
+type frameSequence struct {
+	camera.VideoSource
+	mu     sync.Mutex
+	frames []image.Image
+}
+
+func (fs *frameSequence) Read(ctx context.Context) (image.Image, func(), error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	img := fs.frames[0]
+	if len(fs.frames) > 1 {
+		fs.frames = fs.frames[1:]
+	}
+	return img, func() {}, nil
+}
+
+func (fs *frameSequence) Close(ctx context.Context) error {
+	return nil
+}
+
+// frameWithSquare returns a black frame with a white square whose top left corner is at p.
+func frameWithSquare(p image.Point) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, 40, 30))
+	for y := 0; y < 30; y++ {
+		for x := 0; x < 40; x++ {
+			c := color.NRGBA{A: 255}
+			if (image.Point{x, y}).In(image.Rectangle{p, p.Add(image.Pt(5, 5))}) {
+				c = color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestMotion(t *testing.T) {
+	ctx := context.Background()
+	newSource := func(frames ...image.Image) camera.VideoSource {
+		return &frameSequence{frames: frames}
+	}
+
+	source := newSource(frameWithSquare(image.Pt(2, 2)), frameWithSquare(image.Pt(2, 2)), frameWithSquare(image.Pt(30, 20)))
+	ms, err := newMotionSource(source, utils.AttributeMap{})
+	test.That(t, err, test.ShouldBeNil)
+
+	// nothing has moved before the first image or while the image stays the same
+	for i := 0; i < 2; i++ {
+		out, _, err := ms.Read(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, hasDrawnPixel(out, out.Bounds()), test.ShouldBeFalse)
+	}
+	resp, err := ms.DoCommand(ctx, map[string]interface{}{getLastMotionCommand: true})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["last_motion"], test.ShouldBeNil)
+	test.That(t, resp["motion"], test.ShouldBeFalse)
+
+	// the square moving shows where it left and where it arrived
+	out, _, err := ms.Read(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, color.GrayModel.Convert(out.At(3, 3)), test.ShouldResemble, color.Gray{Y: 255})
+	test.That(t, color.GrayModel.Convert(out.At(32, 22)), test.ShouldResemble, color.Gray{Y: 255})
+	test.That(t, color.GrayModel.Convert(out.At(20, 15)), test.ShouldResemble, color.Gray{Y: 0})
+	resp, err = ms.DoCommand(ctx, map[string]interface{}{getLastMotionCommand: true})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["last_motion"], test.ShouldNotBeNil)
+	test.That(t, resp["motion"], test.ShouldBeTrue)
+	test.That(t, resp["boxes"], test.ShouldResemble, []interface{}{
+		map[string]interface{}{"x_min": 2, "y_min": 2, "x_max": 7, "y_max": 7},
+		map[string]interface{}{"x_min": 30, "y_min": 20, "x_max": 35, "y_max": 25},
+	})
+
+	// once the square stops, the last motion is remembered
+	_, _, err = ms.Read(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	resp, err = ms.DoCommand(ctx, map[string]interface{}{getLastMotionCommand: true})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["last_motion"], test.ShouldNotBeNil)
+	test.That(t, resp["motion"], test.ShouldBeFalse)
+
+	_, err = ms.DoCommand(ctx, map[string]interface{}{"other": true})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, ms.Close(ctx), test.ShouldBeNil)
+
+	t.Run("pipeline", func(t *testing.T) {
+		// the command reaches the transform through the pipeline camera
+		static := &fake.StaticSource{ColorImg: frameWithSquare(image.Pt(2, 2))}
+		source, err := camera.NewVideoSourceFromReader(ctx, static, nil, camera.ColorStream)
+		test.That(t, err, test.ShouldBeNil)
+		src, stream, err := newMotionTransform(ctx, source, camera.ColorStream, utils.AttributeMap{})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, stream, test.ShouldEqual, camera.ColorStream)
+		tp := transformPipeline{pipeline: []camera.VideoSource{src, source}}
+		resp, err := tp.DoCommand(ctx, map[string]interface{}{getLastMotionCommand: true})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp["motion"], test.ShouldBeFalse)
+		test.That(t, src.Close(ctx), test.ShouldBeNil)
+	})
+
+	t.Run("image output", func(t *testing.T) {
+		source := newSource(frameWithSquare(image.Pt(2, 2)), frameWithSquare(image.Pt(30, 20)))
+		am := utils.AttributeMap{"output": "image", "box_color": "#00ff00", "min_area_px": 10}
+		ms, err := newMotionSource(source, am)
+		test.That(t, err, test.ShouldBeNil)
+		_, _, err = ms.Read(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		out, _, err := ms.Read(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		// the boxes are drawn on the frame around where the square moved
+		r, g, b, _ := out.At(2, 4).RGBA()
+		test.That(t, r+b, test.ShouldEqual, 0)
+		test.That(t, g, test.ShouldBeGreaterThan, 0)
+		test.That(t, hasDrawnPixel(out, image.Rect(31, 21, 34, 24)), test.ShouldBeTrue)
+		test.That(t, hasDrawnPixel(out, image.Rect(12, 10, 25, 15)), test.ShouldBeFalse)
+		test.That(t, ms.Close(ctx), test.ShouldBeNil)
+	})
+
+	t.Run("small regions are ignored", func(t *testing.T) {
+		source := newSource(frameWithSquare(image.Pt(2, 2)), frameWithSquare(image.Pt(30, 20)))
+		ms, err := newMotionSource(source, utils.AttributeMap{"min_area_px": 26})
+		test.That(t, err, test.ShouldBeNil)
+		for i := 0; i < 2; i++ {
+			_, _, err = ms.Read(ctx)
+			test.That(t, err, test.ShouldBeNil)
+		}
+		resp, err := ms.DoCommand(ctx, map[string]interface{}{getLastMotionCommand: true})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp["last_motion"], test.ShouldBeNil)
+		test.That(t, ms.Close(ctx), test.ShouldBeNil)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for errText, attrs := range map[string]utils.AttributeMap{
+			"threshold":   {"threshold": 300},
+			"min_area_px": {"min_area_px": -1},
+			"output":      {"output": "boxes"},
+			"box_color":   {"box_color": "red"},
+		} {
+			_, _, err := newMotionTransform(ctx, source, camera.ColorStream, attrs)
+			test.That(t, err, test.ShouldNotBeNil)
+			test.That(t, err.Error(), test.ShouldContainSubstring, errText)
+		}
+		_, _, err := newMotionTransform(ctx, source, camera.DepthStream, utils.AttributeMap{})
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,190 @@
+package transformpipeline
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fogleman/gg"
+	"github.com/golang/freetype/truetype"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/utils"
+)
+
+// the corners of the image that an overlay can be drawn in.
+const (
+	overlayTopLeft     = "top_left"
+	overlayTopRight    = "top_right"
+	overlayBottomLeft  = "bottom_left"
+	overlayBottomRight = "bottom_right"
+)
+
+// overlayConfig are the attributes for an overlay transform. The machine name, timestamp and text are drawn
+// on their own lines, in that order.
+type overlayConfig struct {
+	Text            string  `json:"text,omitempty"`
+	ShowTimestamp   bool    `json:"show_timestamp,omitempty"`
+	TimestampFormat string  `json:"timestamp_format,omitempty"`
+	ShowMachineName bool    `json:"show_machine_name,omitempty"`
+	Position        string  `json:"position,omitempty"`
+	FontSize        float64 `json:"font_size,omitempty"`
+	Color           string  `json:"color,omitempty"`
+	BackgroundColor string  `json:"background_color,omitempty"`
+}
+
+// overlaySource draws text onto the images of its source.
+type overlaySource struct {
+	src             camera.VideoSource
+	text            string
+	showTimestamp   bool
+	timestampFormat string
+	machineName     string
+	position        string
+	face            *truetype.Options
+	textColor       color.Color
+	backgroundColor color.Color
+	now             func() time.Time
+}
+
+func newOverlayTransform(
+	ctx context.Context, source camera.VideoSource, stream camera.ImageType, r robot.Robot, am utils.AttributeMap,
+) (camera.VideoSource, camera.ImageType, error) {
+	conf, err := resource.TransformAttributeMap[*overlayConfig](am)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	if stream == camera.DepthStream {
+		return nil, camera.UnspecifiedStream, errors.New("overlay transform cannot draw on depth images")
+	}
+	if conf.Text == "" && !conf.ShowTimestamp && !conf.ShowMachineName {
+		return nil, camera.UnspecifiedStream, errors.New("overlay transform needs text, show_timestamp or show_machine_name")
+	}
+	ovs := &overlaySource{
+		src:             source,
+		text:            conf.Text,
+		showTimestamp:   conf.ShowTimestamp,
+		timestampFormat: conf.TimestampFormat,
+		position:        conf.Position,
+		face:            &truetype.Options{Size: conf.FontSize},
+		textColor:       rimage.White,
+		now:             time.Now,
+	}
+	switch ovs.position {
+	case "":
+		ovs.position = overlayTopLeft
+	case overlayTopLeft, overlayTopRight, overlayBottomLeft, overlayBottomRight:
+	default:
+		return nil, camera.UnspecifiedStream, errors.Errorf(
+			"overlay position must be one of %s, %s, %s or %s, not %q",
+			overlayTopLeft, overlayTopRight, overlayBottomLeft, overlayBottomRight, conf.Position)
+	}
+	if ovs.timestampFormat == "" {
+		ovs.timestampFormat = time.RFC3339
+	}
+	if conf.FontSize < 0 {
+		return nil, camera.UnspecifiedStream, errors.New("overlay font_size cannot be negative")
+	}
+	if ovs.face.Size == 0 {
+		ovs.face.Size = 16
+	}
+	if conf.Color != "" {
+		if ovs.textColor, err = rimage.NewColorFromHex(conf.Color); err != nil {
+			return nil, camera.UnspecifiedStream, errors.Wrap(err, "invalid overlay color")
+		}
+	}
+	if conf.BackgroundColor != "" {
+		background, err := rimage.NewColorFromHex(conf.BackgroundColor)
+		if err != nil {
+			return nil, camera.UnspecifiedStream, errors.Wrap(err, "invalid overlay background_color")
+		}
+		// the background is half transparent so that the frame still shows through it
+		r, g, b := background.RGB255()
+		ovs.backgroundColor = color.NRGBA{R: r, G: g, B: b, A: 128}
+	}
+	if conf.ShowMachineName {
+		ovs.machineName = machineName(r)
+	}
+	src, err := camera.NewVideoSourceFromReader(ctx, ovs, nil, camera.ColorStream)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	return src, camera.ColorStream, err
+}
+
+// machineName returns the name of the machine part, which is the first label of its cloud FQDN,
+// or the hostname of a machine that is not managed by the cloud.
+func machineName(r robot.Robot) string {
+	if lr, ok := r.(robot.LocalRobot); ok {
+		if cfg := lr.Config(); cfg != nil && cfg.Cloud != nil && cfg.Cloud.FQDN != "" {
+			name, _, _ := strings.Cut(cfg.Cloud.FQDN, ".")
+			return name
+		}
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	return hostname
+}
+
+// lines returns the lines of text to draw on an image read at t.
+func (ovs *overlaySource) lines(t time.Time) []string {
+	var lines []string
+	if ovs.machineName != "" {
+		lines = append(lines, ovs.machineName)
+	}
+	if ovs.showTimestamp {
+		lines = append(lines, t.Format(ovs.timestampFormat))
+	}
+	if ovs.text != "" {
+		lines = append(lines, strings.Split(ovs.text, "\n")...)
+	}
+	return lines
+}
+
+// Read draws the overlay onto the next image of the source.
+func (ovs *overlaySource) Read(ctx context.Context) (image.Image, func(), error) {
+	ctx, span := trace.StartSpan(ctx, "camera::transformpipeline::overlay::Read")
+	defer span.End()
+	img, release, err := camera.ReadImage(ctx, ovs.src)
+	if err != nil {
+		return nil, nil, err
+	}
+	text := strings.Join(ovs.lines(ovs.now()), "\n")
+
+	dc := gg.NewContextForImage(img)
+	dc.SetFontFace(truetype.NewFace(rimage.Font(), ovs.face))
+	width, height := dc.MeasureMultilineString(text, 1)
+	margin := ovs.face.Size / 2
+	x, y := margin, margin
+	align := gg.AlignLeft
+	if ovs.position == overlayTopRight || ovs.position == overlayBottomRight {
+		x = float64(dc.Width()) - margin - width
+		align = gg.AlignRight
+	}
+	if ovs.position == overlayBottomLeft || ovs.position == overlayBottomRight {
+		y = float64(dc.Height()) - margin - height
+	}
+	if ovs.backgroundColor != nil {
+		dc.SetColor(ovs.backgroundColor)
+		dc.DrawRectangle(x-margin/2, y-margin/2, width+margin, height+margin)
+		dc.Fill()
+	}
+	dc.SetColor(ovs.textColor)
+	// the width is rounded up so that measuring and drawing never disagree about where a line wraps
+	dc.DrawStringWrapped(text, x, y, 0, 0, width+1, 1, align)
+	return dc.Image(), release, nil
+}
+
+func (ovs *overlaySource) Close(ctx context.Context) error {
+	return nil
+}
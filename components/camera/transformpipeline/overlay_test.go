@@ -0,0 +1,110 @@
+package transformpipeline
+
+import (
+	"context"
+	"image"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/camera/fake"
+	"go.viam.com/rdk/utils"
+)
+
+// hasDrawnPixel returns whether any pixel of img within rect is not black.
+func hasDrawnPixel(img image.Image, rect image.Rectangle) bool {
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			if r, g, b, _ := img.At(x, y).RGBA(); r+g+b != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestOverlay(t *testing.T) {
+	ctx := context.Background()
+	black := image.NewNRGBA(image.Rect(0, 0, 200, 100))
+	for i := 3; i < len(black.Pix); i += 4 {
+		black.Pix[i] = 255
+	}
+	source, err := camera.NewVideoSourceFromReader(ctx, &fake.StaticSource{ColorImg: black}, nil, camera.UnspecifiedStream)
+	test.That(t, err, test.ShouldBeNil)
+	topLeft := image.Rect(0, 0, 100, 50)
+	bottomRight := image.Rect(100, 50, 200, 100)
+
+	am := utils.AttributeMap{"text": "hello"}
+	rs, stream, err := newOverlayTransform(ctx, source, camera.ColorStream, nil, am)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, stream, test.ShouldEqual, camera.ColorStream)
+	out, _, err := camera.ReadImage(ctx, rs)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, out.Bounds(), test.ShouldResemble, black.Bounds())
+	test.That(t, hasDrawnPixel(out, topLeft), test.ShouldBeTrue)
+	test.That(t, hasDrawnPixel(out, bottomRight), test.ShouldBeFalse)
+	test.That(t, rs.Close(ctx), test.ShouldBeNil)
+
+	am = utils.AttributeMap{"text": "hello", "position": "bottom_right", "color": "#ff0000", "font_size": 12}
+	rs, _, err = newOverlayTransform(ctx, source, camera.ColorStream, nil, am)
+	test.That(t, err, test.ShouldBeNil)
+	out, _, err = camera.ReadImage(ctx, rs)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, hasDrawnPixel(out, topLeft), test.ShouldBeFalse)
+	test.That(t, hasDrawnPixel(out, bottomRight), test.ShouldBeTrue)
+	for y := bottomRight.Min.Y; y < bottomRight.Max.Y; y++ {
+		for x := bottomRight.Min.X; x < bottomRight.Max.X; x++ {
+			_, g, b, _ := out.At(x, y).RGBA()
+			test.That(t, g+b, test.ShouldEqual, 0)
+		}
+	}
+	test.That(t, rs.Close(ctx), test.ShouldBeNil)
+
+	// the source image is not drawn on
+	test.That(t, hasDrawnPixel(black, black.Bounds()), test.ShouldBeFalse)
+
+	t.Run("lines", func(t *testing.T) {
+		ovs := &overlaySource{
+			machineName:     "my-part",
+			showTimestamp:   true,
+			timestampFormat: "2006-01-02 15:04:05",
+			text:            "line 1\nline 2",
+		}
+		lines := ovs.lines(time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC))
+		test.That(t, lines, test.ShouldResemble, []string{"my-part", "2024-03-04 05:06:07", "line 1", "line 2"})
+		test.That(t, machineName(nil), test.ShouldNotBeEmpty)
+	})
+
+	t.Run("background", func(t *testing.T) {
+		am := utils.AttributeMap{"show_timestamp": true, "background_color": "#ffffff", "color": "#000000"}
+		rs, _, err := newOverlayTransform(ctx, source, camera.ColorStream, nil, am)
+		test.That(t, err, test.ShouldBeNil)
+		out, _, err := camera.ReadImage(ctx, rs)
+		test.That(t, err, test.ShouldBeNil)
+		// the corner of the background is half white over the black image
+		r, _, _, _ := out.At(5, 5).RGBA()
+		test.That(t, int(r>>8), test.ShouldBeBetween, 100, 160)
+		test.That(t, hasDrawnPixel(out, bottomRight), test.ShouldBeFalse)
+		test.That(t, rs.Close(ctx), test.ShouldBeNil)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for errText, attrs := range map[string]utils.AttributeMap{
+			"needs text":       {},
+			"position":         {"text": "a", "position": "middle"},
+			"color":            {"text": "a", "color": "red"},
+			"font_size":        {"text": "a", "font_size": -1},
+			"background_color": {"text": "a", "background_color": "#12"},
+		} {
+			_, _, err := newOverlayTransform(ctx, source, camera.ColorStream, nil, attrs)
+			test.That(t, err, test.ShouldNotBeNil)
+			test.That(t, err.Error(), test.ShouldContainSubstring, errText)
+		}
+		_, _, err := newOverlayTransform(ctx, source, camera.DepthStream, nil, utils.AttributeMap{"text": "a"})
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
+	test.That(t, source.Close(ctx), test.ShouldBeNil)
+}
@@ -67,10 +67,11 @@ func init() {
 
 // transformConfig specifies a stream and list of transforms to apply on images/pointclouds coming from a source camera.
 type transformConfig struct {
-	CameraParameters     *transform.PinholeCameraIntrinsics `json:"intrinsic_parameters,omitempty"`
-	DistortionParameters *transform.BrownConrady            `json:"distortion_parameters,omitempty"`
-	Source               string                             `json:"source"`
-	Pipeline             []Transformation                   `json:"pipeline"`
+	CameraParameters            *transform.PinholeCameraIntrinsics `json:"intrinsic_parameters,omitempty"`
+	DistortionParameters        *transform.BrownConrady            `json:"distortion_parameters,omitempty"`
+	FisheyeDistortionParameters *transform.KannalaBrandt           `json:"fisheye_distortion_parameters,omitempty"`
+	Source                      string                             `json:"source"`
+	Pipeline                    []Transformation                   `json:"pipeline"`
 }
 
 // Validate ensures all parts of the config are valid.
@@ -89,6 +90,11 @@ func (cfg *transformConfig) Validate(path string) ([]string, []string, error) {
 		}
 	}
 
+	if cfg.DistortionParameters != nil && cfg.FisheyeDistortionParameters != nil {
+		return nil, nil, resource.NewConfigValidationError(path,
+			errors.New("cannot have both distortion_parameters and fisheye_distortion_parameters"))
+	}
+
 	deps = append(deps, cfg.Source)
 	return deps, nil, nil
 }
@@ -150,32 +156,51 @@ func newTransformPipeline(
 	} else {
 		streamType = camera.ColorStream
 	}
+	pipeline, streamType, err := buildTransforms(ctx, r, source, streamType, cfg.Pipeline)
+	if err != nil {
+		return nil, err
+	}
+	cameraModel, err := newPinholeModel(cfg.CameraParameters, cfg.DistortionParameters, cfg.FisheyeDistortionParameters)
+	if err != nil {
+		return nil, err
+	}
+	return camera.NewVideoSourceFromReader(
+		ctx,
+		transformPipeline{named, pipeline, pipeline[len(pipeline)-1], cfg.CameraParameters, logger},
+		&cameraModel,
+		streamType,
+	)
+}
+
+// buildTransforms builds each transform of trs on the one before it, the first on source, and
+// returns them with the stream the last one outputs.
+func buildTransforms(
+	ctx context.Context,
+	r robot.Robot,
+	source camera.VideoSource,
+	stream camera.ImageType,
+	trs []Transformation,
+) ([]camera.VideoSource, camera.ImageType, error) {
 	// loop through the pipeline and create the image flow
-	pipeline := make([]camera.VideoSource, 0, len(cfg.Pipeline))
+	pipeline := make([]camera.VideoSource, 0, len(trs))
 	lastSource, err := videoSourceFromCamera(ctx, source)
 	if err != nil {
-		return nil, err
+		return nil, camera.UnspecifiedStream, err
 	}
-	for _, tr := range cfg.Pipeline {
-		src, newStreamType, err := buildTransform(ctx, r, lastSource, streamType, tr)
+	for _, tr := range trs {
+		src, newStreamType, err := buildTransform(ctx, r, lastSource, stream, tr)
 		if err != nil {
-			return nil, err
+			return nil, camera.UnspecifiedStream, err
 		}
 		streamSrc, err := videoSourceFromCamera(ctx, src)
 		if err != nil {
-			return nil, err
+			return nil, camera.UnspecifiedStream, err
 		}
 		pipeline = append(pipeline, streamSrc)
 		lastSource = streamSrc
-		streamType = newStreamType
+		stream = newStreamType
 	}
-	cameraModel := camera.NewPinholeModelWithBrownConradyDistortion(cfg.CameraParameters, cfg.DistortionParameters)
-	return camera.NewVideoSourceFromReader(
-		ctx,
-		transformPipeline{named, pipeline, lastSource, cfg.CameraParameters, logger},
-		&cameraModel,
-		streamType,
-	)
+	return pipeline, stream, nil
 }
 
 type transformPipeline struct {
@@ -209,6 +234,18 @@ func (tp transformPipeline) NextPointCloud(ctx context.Context) (pointcloud.Poin
 	return nil, errors.New("function NextPointCloud not defined for last videosource in transform pipeline")
 }
 
+// DoCommand passes commands to the transforms of the pipeline, last first, until one of them
+// handles it.
+func (tp transformPipeline) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	for i := len(tp.pipeline) - 1; i >= 0; i-- {
+		resp, err := tp.pipeline[i].DoCommand(ctx, cmd)
+		if !errors.Is(err, resource.ErrDoUnimplemented) {
+			return resp, err
+		}
+	}
+	return nil, resource.ErrDoUnimplemented
+}
+
 func (tp transformPipeline) Close(ctx context.Context) error {
 	return nil
 }
@@ -3,6 +3,7 @@ package transformpipeline
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/invopop/jsonschema"
 
@@ -22,6 +23,17 @@ const (
 	transformTypeCrop            = transformType("crop")
 	transformTypeDetections      = transformType("detections")
 	transformTypeClassifications = transformType("classifications")
+	transformTypeDepthHoleFill   = transformType("depth_hole_fill")
+	transformTypeOverlay         = transformType("overlay")
+	transformTypeUndistort       = transformType("undistort")
+	transformTypeHSVMask         = transformType("hsv_mask")
+	transformTypeMotion          = transformType("motion")
+	transformTypeEqualize        = transformType("equalize")
+	transformTypeCLAHE           = transformType("clahe")
+	transformTypeGamma           = transformType("gamma")
+	transformTypeBarcode         = transformType("barcode")
+	transformTypeFiducial        = transformType("fiducial")
+	transformTypeConditional     = transformType("conditional")
 )
 
 // transformRegistration holds pertinent information regarding the available transforms.
@@ -46,7 +58,7 @@ var registeredTransformConfigs = map[transformType]*transformRegistration{
 	transformTypeCrop: {
 		string(transformTypeCrop),
 		&cropConfig{},
-		"Crop the image to the specified rectangle in pixels",
+		"Crop the image to the specified rectangle in pixels or fractions of the image size, optionally locked to an aspect ratio",
 	},
 	transformTypeDetections: {
 		string(transformTypeDetections),
@@ -58,6 +70,69 @@ var registeredTransformConfigs = map[transformType]*transformRegistration{
 		&classifierConfig{},
 		"Overlays image classifications on the image. Can use any classifier registered in the vision service.",
 	},
+	transformTypeDepthHoleFill: {
+		string(transformTypeDepthHoleFill),
+		&depthHoleFillConfig{},
+		"Fills in the holes of depth maps, either by inpainting them or by using an aligned color camera to fill them " +
+			"without crossing the edges of objects.",
+	},
+	transformTypeOverlay: {
+		string(transformTypeOverlay),
+		&overlayConfig{},
+		"Draws a timestamp, the machine's name and text onto the image, so that recorded and streamed footage describes itself.",
+	},
+	transformTypeUndistort: {
+		string(transformTypeUndistort),
+		&undistortConfig{},
+		"Uses intrinsics and a Brown-Conrady or Kannala-Brandt (fisheye) distortion model to rectify the image.",
+	},
+	transformTypeHSVMask: {
+		string(transformTypeHSVMask),
+		&hsvMaskConfig{},
+		"Selects the pixels within a range of hue, saturation and value, outputting either a black and white mask " +
+			"or the image with the other pixels removed. Useful for following lines and finding colored markers.",
+	},
+	transformTypeMotion: {
+		string(transformTypeMotion),
+		&motionConfig{},
+		"Detects motion by comparing each image with the one before it, outputting either a mask of what moved or the image " +
+			"with boxes around it. The get_last_motion command returns when motion was last seen.",
+	},
+	transformTypeEqualize: {
+		string(transformTypeEqualize),
+		&equalizeConfig{},
+		"Equalizes the histogram of the brightness of the image, so that dim or washed out images have more contrast.",
+	},
+	transformTypeCLAHE: {
+		string(transformTypeCLAHE),
+		&claheConfig{},
+		"Equalizes the brightness of each tile of the image with contrast limited adaptive histogram equalization (CLAHE), " +
+			"so that unevenly lit images have more contrast everywhere without amplifying noise.",
+	},
+	transformTypeGamma: {
+		string(transformTypeGamma),
+		&gammaConfig{},
+		"Gamma corrects the image. A gamma greater than 1 brightens the shadows, and one less than 1 darkens them.",
+	},
+	transformTypeBarcode: {
+		string(transformTypeBarcode),
+		&barcodeConfig{},
+		"Reads the QR codes and 1D barcodes in the image and draws a box around each labeled with its payload. " +
+			"The get_barcodes command returns the barcodes read from the last image, and get_readings returns their payloads.",
+	},
+	transformTypeFiducial: {
+		string(transformTypeFiducial),
+		&fiducialConfig{},
+		"Detects fiducial markers such as ArUco markers and AprilTags, outlining each and labeling it with its ID. The get_markers " +
+			"command returns the markers found in the last image, with their poses when marker_size_mm and intrinsics are given.",
+	},
+	transformTypeConditional: {
+		string(transformTypeConditional),
+		&conditionalConfig{},
+		"Applies the then transforms when a condition holds and the else transforms when it does not. Conditions on the source, " +
+			"such as whether it has intrinsics, are checked once, and conditions on the images, such as their brightness or how " +
+			"a classifier labels them, are checked for each image. The get_branch command returns which branch was last taken.",
+	},
 }
 
 // Transformation states the type of transformation and the attributes that are specific to the given type.
@@ -67,10 +142,19 @@ type Transformation struct {
 }
 
 // JSONSchema defines the schema for each of the possible transforms in the pipeline in a OneOf.
+// Transforms nested in the config of another transform, like the branches of a conditional
+// transform, are only described by their type and attributes, since reflecting them again
+// would never end.
 func (Transformation) JSONSchema() *jsonschema.Schema {
+	reflector := &jsonschema.Reflector{Mapper: func(t reflect.Type) *jsonschema.Schema {
+		if t != reflect.TypeOf(Transformation{}) {
+			return nil
+		}
+		return &jsonschema.Schema{Type: "object", Description: "A transform of the same form as those in the pipeline."}
+	}}
 	schemas := make([]*jsonschema.Schema, 0, len(registeredTransformConfigs))
 	for _, transformReg := range registeredTransformConfigs {
-		transformSchema := jsonschema.Reflect(transformReg.retType)
+		transformSchema := reflector.Reflect(transformReg.retType)
 		transformSchema.Title = transformReg.name
 		transformSchema.Type = "object"
 		transformSchema.Description = transformReg.description
@@ -102,6 +186,28 @@ func buildTransform(
 		return newDetectionsTransform(ctx, source, r, tr.Attributes)
 	case transformTypeClassifications:
 		return newClassificationsTransform(ctx, source, r, tr.Attributes)
+	case transformTypeDepthHoleFill:
+		return newDepthHoleFillTransform(ctx, source, stream, r, tr.Attributes)
+	case transformTypeOverlay:
+		return newOverlayTransform(ctx, source, stream, r, tr.Attributes)
+	case transformTypeUndistort:
+		return newUndistortTransform(ctx, source, stream, tr.Attributes)
+	case transformTypeHSVMask:
+		return newHSVMaskTransform(ctx, source, stream, tr.Attributes)
+	case transformTypeMotion:
+		return newMotionTransform(ctx, source, stream, tr.Attributes)
+	case transformTypeEqualize:
+		return newEqualizeTransform(ctx, source, stream, tr.Attributes)
+	case transformTypeCLAHE:
+		return newCLAHETransform(ctx, source, stream, tr.Attributes)
+	case transformTypeGamma:
+		return newGammaTransform(ctx, source, stream, tr.Attributes)
+	case transformTypeBarcode:
+		return newBarcodeTransform(ctx, source, stream, tr.Attributes)
+	case transformTypeFiducial:
+		return newFiducialTransform(ctx, source, stream, tr.Attributes)
+	case transformTypeConditional:
+		return newConditionalTransform(ctx, source, stream, r, tr.Attributes)
 	default:
 		return nil, camera.UnspecifiedStream, fmt.Errorf("do not  know camera transform of type %q", tr.Type)
 	}
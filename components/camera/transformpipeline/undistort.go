@@ -0,0 +1,112 @@
+package transformpipeline
+
+import (
+	"context"
+	"image"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/utils"
+)
+
+// undistortConfig are the attributes for an undistort transform. Lenses narrow enough to be modeled as a
+// pinhole camera use the Brown-Conrady distortion_parameters, and wide-angle and fisheye lenses use the
+// Kannala-Brandt fisheye_distortion_parameters.
+type undistortConfig struct {
+	CameraParams            *transform.PinholeCameraIntrinsics `json:"intrinsic_parameters"`
+	DistortionParams        *transform.BrownConrady            `json:"distortion_parameters,omitempty"`
+	FisheyeDistortionParams *transform.KannalaBrandt           `json:"fisheye_distortion_parameters,omitempty"`
+}
+
+// undistortSource will undistort the original image according to the Distortion parameters
+// within the intrinsic parameters.
+type undistortSource struct {
+	originalSource camera.VideoSource
+	stream         camera.ImageType
+	cameraParams   *transform.PinholeCameraModel
+}
+
+// newUndistortTransform creates a new undistort transform.
+func newUndistortTransform(
+	ctx context.Context, source camera.VideoSource, stream camera.ImageType, am utils.AttributeMap,
+) (camera.VideoSource, camera.ImageType, error) {
+	conf, err := resource.TransformAttributeMap[*undistortConfig](am)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	if err := conf.CameraParams.CheckValid(); err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	cameraModel, err := newPinholeModel(conf.CameraParams, conf.DistortionParams, conf.FisheyeDistortionParams)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	if cameraModel.Distortion == nil {
+		return nil, camera.UnspecifiedStream, errors.New(
+			"undistort transform needs distortion_parameters or fisheye_distortion_parameters")
+	}
+	reader := &undistortSource{source, stream, &cameraModel}
+	src, err := camera.NewVideoSourceFromReader(ctx, reader, &cameraModel, stream)
+	if err != nil {
+		return nil, camera.UnspecifiedStream, err
+	}
+	return src, stream, err
+}
+
+// newPinholeModel returns the camera model of the given intrinsics with at most one model of distortion.
+// If neither model is given, the Distortion of the camera model is left nil, to prevent
+// https://go.dev/doc/faq#nil_error.
+func newPinholeModel(
+	intrinsics *transform.PinholeCameraIntrinsics, brownConrady *transform.BrownConrady, kannalaBrandt *transform.KannalaBrandt,
+) (transform.PinholeCameraModel, error) {
+	cameraModel := transform.PinholeCameraModel{PinholeCameraIntrinsics: intrinsics}
+	switch {
+	case brownConrady != nil && kannalaBrandt != nil:
+		return cameraModel, errors.New("cannot have both distortion_parameters and fisheye_distortion_parameters")
+	case brownConrady != nil:
+		cameraModel.Distortion = brownConrady
+	case kannalaBrandt != nil:
+		cameraModel.Distortion = kannalaBrandt
+	}
+	return cameraModel, nil
+}
+
+// Read undistorts the original image according to the camera model.
+func (us *undistortSource) Read(ctx context.Context) (image.Image, func(), error) {
+	ctx, span := trace.StartSpan(ctx, "camera::transformpipeline::undistort::Read")
+	defer span.End()
+	orig, release, err := camera.ReadImage(ctx, us.originalSource)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch us.stream {
+	case camera.ColorStream, camera.UnspecifiedStream:
+		color, err := us.cameraParams.UndistortImage(rimage.ConvertImage(orig))
+		if err != nil {
+			return nil, release, err
+		}
+		return color, release, nil
+	case camera.DepthStream:
+		dm, err := rimage.ConvertImageToDepthMap(ctx, orig)
+		if err != nil {
+			return nil, release, err
+		}
+		dm, err = us.cameraParams.UndistortDepthMap(dm)
+		if err != nil {
+			return nil, release, err
+		}
+		return dm, release, nil
+	default:
+		return nil, release, camera.NewUnsupportedImageTypeError(us.stream)
+	}
+}
+
+// Close does nothing.
+func (us *undistortSource) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,73 @@
+package transformpipeline
+
+import (
+	"context"
+	"testing"
+
+	"go.viam.com/test"
+	"go.viam.com/utils/artifact"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/camera/fake"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/utils"
+)
+
+func TestUndistort(t *testing.T) {
+	ctx := context.Background()
+	img, err := rimage.NewImageFromFile(artifact.MustPath("rimage/board1_small.png"))
+	test.That(t, err, test.ShouldBeNil)
+	source, err := camera.NewVideoSourceFromReader(ctx, &fake.StaticSource{ColorImg: img}, nil, camera.UnspecifiedStream)
+	test.That(t, err, test.ShouldBeNil)
+	intrinsics := map[string]interface{}{"width_px": 128, "height_px": 72, "fx": 60, "fy": 60, "ppx": 64, "ppy": 36}
+
+	am := utils.AttributeMap{
+		"intrinsic_parameters":          intrinsics,
+		"fisheye_distortion_parameters": map[string]interface{}{"k1": 0.1, "k2": 0.01},
+	}
+	us, stream, err := newUndistortTransform(ctx, source, camera.ColorStream, am)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, stream, test.ShouldEqual, camera.ColorStream)
+	out, _, err := camera.ReadImage(ctx, us)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, out.Bounds(), test.ShouldResemble, img.Bounds())
+	// the principal point is not moved, but the corners are
+	test.That(t, rimage.ConvertImage(out).GetXY(64, 36), test.ShouldResemble, img.GetXY(64, 36))
+	test.That(t, out, test.ShouldNotResemble, img)
+
+	props, err := us.Properties(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, props.DistortionParams.ModelType(), test.ShouldEqual, transform.KannalaBrandtDistortionType)
+	test.That(t, props.DistortionParams.Parameters(), test.ShouldResemble, []float64{0.1, 0.01, 0, 0})
+	test.That(t, us.Close(ctx), test.ShouldBeNil)
+
+	am = utils.AttributeMap{
+		"intrinsic_parameters":  intrinsics,
+		"distortion_parameters": map[string]interface{}{"rk1": -0.1},
+	}
+	us, _, err = newUndistortTransform(ctx, source, camera.ColorStream, am)
+	test.That(t, err, test.ShouldBeNil)
+	props, err = us.Properties(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, props.DistortionParams.ModelType(), test.ShouldEqual, transform.BrownConradyDistortionType)
+	test.That(t, us.Close(ctx), test.ShouldBeNil)
+
+	t.Run("invalid", func(t *testing.T) {
+		for errText, attrs := range map[string]utils.AttributeMap{
+			"intrinsic":                   {"distortion_parameters": map[string]interface{}{"rk1": -0.1}},
+			"needs distortion_parameters": {"intrinsic_parameters": intrinsics},
+			"both": {
+				"intrinsic_parameters":          intrinsics,
+				"distortion_parameters":         map[string]interface{}{"rk1": -0.1},
+				"fisheye_distortion_parameters": map[string]interface{}{"k1": 0.1},
+			},
+		} {
+			_, _, err := newUndistortTransform(ctx, source, camera.ColorStream, attrs)
+			test.That(t, err, test.ShouldNotBeNil)
+			test.That(t, err.Error(), test.ShouldContainSubstring, errText)
+		}
+	})
+
+	test.That(t, source.Close(ctx), test.ShouldBeNil)
+}
@@ -0,0 +1,188 @@
+//go:build linux
+
+package videosource
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	v4l2 "github.com/blackjack/webcam"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// pixelFormatMJPEG is the V4L2 fourcc for motion JPEG, 'MJPG'.
+const pixelFormatMJPEG = v4l2.PixelFormat(0x47504A4D)
+
+// mjpegFrameTimeoutSec is how long to wait for the device to produce a frame.
+const mjpegFrameTimeoutSec = 5
+
+// mjpegMaxFailedReads is how many reads in a row may fail before the camera is considered
+// disconnected.
+const mjpegMaxFailedReads = 3
+
+// mjpegSource reads camera-native JPEG frames straight from a V4L2 device without decoding them.
+type mjpegSource struct {
+	mu        sync.Mutex
+	cam       *v4l2.Webcam
+	path      string
+	width     int
+	height    int
+	frameRate float32
+
+	failedReads atomic.Int32
+}
+
+// devicePath turns a webcam video_path, which may be a device name like "video0", into a
+// device file path.
+func devicePath(path string) string {
+	if strings.HasPrefix(path, "/") {
+		return path
+	}
+	return filepath.Join("/dev", filepath.Base(path))
+}
+
+// openMJPEGSource opens the device at path and negotiates MJPEG capture at the size and frame rate
+// of conf. A width or height of zero picks the device's largest MJPEG frame size, and no frame rate
+// leaves the device's frame rate unchanged.
+func openMJPEGSource(path string, conf *WebcamConfig) (*mjpegSource, error) {
+	if path == "" {
+		return nil, errors.New("mjpeg_passthrough requires video_path to be set")
+	}
+	cam, err := v4l2.Open(devicePath(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot open %s", devicePath(path))
+	}
+	src, err := negotiateMJPEG(cam, conf)
+	if err != nil {
+		return nil, multierr.Combine(err, cam.Close())
+	}
+	src.path = devicePath(path)
+	return src, nil
+}
+
+func negotiateMJPEG(cam *v4l2.Webcam, conf *WebcamConfig) (*mjpegSource, error) {
+	if _, ok := cam.GetSupportedFormats()[pixelFormatMJPEG]; !ok {
+		return nil, errors.New("camera does not support MJPEG capture")
+	}
+	width, height := conf.Width, conf.Height
+	if width == 0 || height == 0 {
+		for _, size := range cam.GetSupportedFrameSizes(pixelFormatMJPEG) {
+			if int(size.MaxWidth*size.MaxHeight) > width*height {
+				width, height = int(size.MaxWidth), int(size.MaxHeight)
+			}
+		}
+	}
+	format, gotWidth, gotHeight, err := cam.SetImageFormat(pixelFormatMJPEG, uint32(width), uint32(height))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot set MJPEG image format")
+	}
+	if format != pixelFormatMJPEG {
+		return nil, errors.New("camera refused MJPEG capture")
+	}
+	if width != 0 && height != 0 && (int(gotWidth) != width || int(gotHeight) != height) {
+		return nil, errors.Errorf("requested width and height (%dx%d) are not available for MJPEG capture"+
+			" (closest supported resolution is %dx%d)", width, height, gotWidth, gotHeight)
+	}
+
+	var supported [][2]float32
+	for _, rate := range cam.GetSupportedFramerates(pixelFormatMJPEG, gotWidth, gotHeight) {
+		supported = append(supported, framesPerSecond(rate))
+	}
+	frameRate, err := conf.chooseFrameRate(supported)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot choose MJPEG frame rate")
+	}
+	if frameRate > 0 {
+		if err := cam.SetFramerate(frameRate); err != nil {
+			return nil, errors.Wrap(err, "cannot set MJPEG frame rate")
+		}
+	}
+	// the driver may round the frame rate to one it supports
+	if got, err := cam.GetFramerate(); err == nil && got > 0 {
+		frameRate = got
+	}
+	if err := cam.StartStreaming(); err != nil {
+		return nil, errors.Wrap(err, "cannot start MJPEG capture")
+	}
+	return &mjpegSource{cam: cam, width: int(gotWidth), height: int(gotHeight), frameRate: frameRate}, nil
+}
+
+// framesPerSecond returns the range of frame rates, in frames per second, of a V4L2 frame interval,
+// which is in seconds per frame.
+func framesPerSecond(rate v4l2.FrameRate) [2]float32 {
+	perSecond := func(num, den uint32) float32 {
+		if num == 0 {
+			return 0
+		}
+		return float32(den) / float32(num)
+	}
+	// the longest interval is the lowest frame rate
+	return [2]float32{perSecond(rate.MaxNumerator, rate.MaxDenominator), perSecond(rate.MinNumerator, rate.MinDenominator)}
+}
+
+// Read returns the next JPEG frame from the camera. The returned bytes are owned by the caller.
+func (s *mjpegSource) Read() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cam == nil {
+		return nil, errClosed
+	}
+	frame, err := s.readFrame()
+	if err != nil {
+		s.failedReads.Add(1)
+		return nil, err
+	}
+	s.failedReads.Store(0)
+	// the frame buffer is reused by the driver on the next read
+	out := make([]byte, len(frame))
+	copy(out, frame)
+	return out, nil
+}
+
+func (s *mjpegSource) readFrame() ([]byte, error) {
+	if err := s.cam.WaitForFrame(mjpegFrameTimeoutSec); err != nil {
+		return nil, err
+	}
+	frame, err := s.cam.ReadFrame()
+	if err != nil {
+		return nil, err
+	}
+	if len(frame) == 0 {
+		return nil, errors.New("camera returned an empty frame")
+	}
+	return frame, nil
+}
+
+// Connected returns whether the device is still present and reads from it have not kept failing.
+func (s *mjpegSource) Connected() bool {
+	if _, err := os.Stat(s.path); err != nil {
+		return false
+	}
+	return s.failedReads.Load() < mjpegMaxFailedReads
+}
+
+// Size returns the negotiated frame size.
+func (s *mjpegSource) Size() (int, int) {
+	return s.width, s.height
+}
+
+// FrameRate returns the negotiated frame rate, or zero if it is not known.
+func (s *mjpegSource) FrameRate() float32 {
+	return s.frameRate
+}
+
+// Close stops capturing and releases the device.
+func (s *mjpegSource) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cam == nil {
+		return nil
+	}
+	err := multierr.Combine(s.cam.StopStreaming(), s.cam.Close())
+	s.cam = nil
+	return err
+}
@@ -0,0 +1,37 @@
+//go:build !linux
+
+package videosource
+
+import "github.com/pkg/errors"
+
+// mjpegSource would read camera-native JPEG frames from a V4L2 device, which only exists on linux.
+type mjpegSource struct{}
+
+func openMJPEGSource(path string, conf *WebcamConfig) (*mjpegSource, error) {
+	return nil, errors.New("mjpeg_passthrough is only supported on linux")
+}
+
+// Read always fails since MJPEG passthrough is unsupported.
+func (s *mjpegSource) Read() ([]byte, error) {
+	return nil, errors.New("mjpeg_passthrough is only supported on linux")
+}
+
+// Size returns a zero size since MJPEG passthrough is unsupported.
+func (s *mjpegSource) Size() (int, int) {
+	return 0, 0
+}
+
+// FrameRate returns zero since MJPEG passthrough is unsupported.
+func (s *mjpegSource) FrameRate() float32 {
+	return 0
+}
+
+// Connected always returns false since MJPEG passthrough is unsupported.
+func (s *mjpegSource) Connected() bool {
+	return false
+}
+
+// Close does nothing.
+func (s *mjpegSource) Close() error {
+	return nil
+}
@@ -28,7 +28,7 @@ func getReaderAndDriver(
 	name string,
 	constraints mediadevices.MediaStreamConstraints,
 	logger logging.Logger,
-) (video.Reader, driver.Driver, error) {
+) (video.Reader, driver.Driver, prop.Media, error) {
 	var ptr *string
 	if name == "" {
 		ptr = nil
@@ -37,13 +37,13 @@ func getReaderAndDriver(
 	}
 	d, selectedMedia, err := getUserVideoDriver(constraints, ptr, logger)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, prop.Media{}, err
 	}
 	reader, err := newReaderFromDriver(d, selectedMedia)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, prop.Media{}, err
 	}
-	return reader, d, nil
+	return reader, d, selectedMedia, nil
 }
 
 func getUserVideoDriver(
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"image"
+	"math"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -18,6 +20,7 @@ import (
 	"github.com/pion/mediadevices/pkg/io/video"
 	"github.com/pion/mediadevices/pkg/prop"
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 	goutils "go.viam.com/utils"
 
 	"go.viam.com/rdk/components/camera"
@@ -76,6 +79,18 @@ type WebcamConfig struct {
 	Width                int                                `json:"width_px,omitempty"`
 	Height               int                                `json:"height_px,omitempty"`
 	FrameRate            float32                            `json:"frame_rate,omitempty"`
+
+	// Formats lists acceptable pixel formats in order of preference. The first format the
+	// camera supports with the other constraints is used. It cannot be set with Format.
+	Formats []string `json:"formats,omitempty"`
+	// MinFrameRate and MaxFrameRate bound the frame rate when FrameRate is not required
+	// exactly. If FrameRate is also set, it is the preferred rate within the range.
+	MinFrameRate float32 `json:"min_frame_rate,omitempty"`
+	MaxFrameRate float32 `json:"max_frame_rate,omitempty"`
+	// MJPEGPassthrough captures camera-native MJPEG frames and serves them as JPEG without
+	// decoding and re-encoding them. Only supported on linux. If Formats lists others beside
+	// MJPEG, they are used without passthrough when the camera cannot capture MJPEG.
+	MJPEGPassthrough bool `json:"mjpeg_passthrough,omitempty"`
 }
 
 // Validate ensures all parts of the config are valid.
@@ -90,13 +105,106 @@ func (c WebcamConfig) Validate(path string) ([]string, []string, error) {
 			"got illegal non-positive dimension for frame rate (%.2f) field set for webcam camera",
 			c.FrameRate)
 	}
+	if c.MinFrameRate < 0 || c.MaxFrameRate < 0 {
+		return nil, nil, fmt.Errorf(
+			"got illegal negative min_frame_rate and max_frame_rate (%.2f, %.2f) fields set for webcam camera",
+			c.MinFrameRate, c.MaxFrameRate)
+	}
+	if c.MaxFrameRate > 0 && c.MinFrameRate > c.MaxFrameRate {
+		return nil, nil, fmt.Errorf("min_frame_rate (%.2f) cannot be greater than max_frame_rate (%.2f)",
+			c.MinFrameRate, c.MaxFrameRate)
+	}
+	if c.FrameRate > 0 && (c.FrameRate < c.MinFrameRate || (c.MaxFrameRate > 0 && c.FrameRate > c.MaxFrameRate)) {
+		return nil, nil, fmt.Errorf("frame_rate (%.2f) must be within min_frame_rate and max_frame_rate", c.FrameRate)
+	}
+	if c.Format != "" && len(c.Formats) != 0 {
+		return nil, nil, errors.New("only one of format and formats can be set for webcam camera")
+	}
+	if c.MJPEGPassthrough {
+		if (len(c.Formats) != 0 && !slices.Contains(c.Formats, string(frame.FormatMJPEG))) ||
+			(c.Format != "" && c.Format != string(frame.FormatMJPEG)) {
+			return nil, nil, errors.Errorf("mjpeg_passthrough requires the %s format", frame.FormatMJPEG)
+		}
+		if c.Path == "" {
+			return nil, nil, errors.New("mjpeg_passthrough requires video_path to be set")
+		}
+	}
 
 	return []string{}, nil, nil
 }
 
+// formatsToTry returns the pixel formats to negotiate in order of preference. An empty format
+// lets the camera pick any supported format.
+func (c *WebcamConfig) formatsToTry() []string {
+	if len(c.Formats) != 0 {
+		return c.Formats
+	}
+	return []string{c.Format}
+}
+
+// frameRateConstraint returns the frame rate constraint for the configured frame rate and range.
+func (c *WebcamConfig) frameRateConstraint() prop.FloatConstraint {
+	if c.MinFrameRate == 0 && c.MaxFrameRate == 0 {
+		if c.FrameRate > 0.0 {
+			return prop.FloatExact(c.FrameRate)
+		}
+		return prop.FloatRanged{Min: 0.0, Ideal: 30.0, Max: 140.0}
+	}
+	maxRate := c.MaxFrameRate
+	if maxRate == 0 {
+		maxRate = 140.0
+	}
+	ideal := c.FrameRate
+	if ideal == 0 {
+		ideal = maxRate
+	}
+	return prop.FloatRanged{Min: c.MinFrameRate, Ideal: ideal, Max: maxRate}
+}
+
+// chooseFrameRate returns the frame rate to capture at, given the ranges of frame rates the camera
+// supports as [min, max] frames per second, that is closest to the preferred rate within the
+// configured range. Zero means the camera's frame rate should be left as it is.
+func (c *WebcamConfig) chooseFrameRate(supported [][2]float32) (float32, error) {
+	lo, hi := c.MinFrameRate, c.MaxFrameRate
+	if lo == 0 && hi == 0 {
+		if c.FrameRate == 0 {
+			return 0, nil
+		}
+		lo, hi = c.FrameRate, c.FrameRate
+	}
+	if hi == 0 {
+		hi = 140.0
+	}
+	preferred := c.FrameRate
+	if preferred == 0 {
+		preferred = hi
+	}
+	if len(supported) == 0 {
+		return preferred, nil
+	}
+
+	var chosen float32
+	found := false
+	for _, rates := range supported {
+		low, high := max(rates[0], lo), min(rates[1], hi)
+		if low > high {
+			continue
+		}
+		rate := min(max(preferred, low), high)
+		if !found || math.Abs(float64(rate-preferred)) < math.Abs(float64(chosen-preferred)) {
+			chosen, found = rate, true
+		}
+	}
+	if !found {
+		return 0, errors.Errorf("camera supports no frame rate between %.2f and %.2f", lo, hi)
+	}
+	return chosen, nil
+}
+
 // makeConstraints is a helper that returns constraints to mediadevices in order to find and make a video source.
-// Constraints are specifications for the video stream such as frame format, resolution etc.
-func makeConstraints(conf *WebcamConfig, logger logging.Logger) mediadevices.MediaStreamConstraints {
+// Constraints are specifications for the video stream such as frame format, resolution etc. An empty format
+// accepts any supported format.
+func makeConstraints(conf *WebcamConfig, format string, logger logging.Logger) mediadevices.MediaStreamConstraints {
 	return mediadevices.MediaStreamConstraints{
 		Video: func(constraint *mediadevices.MediaTrackConstraints) {
 			if conf.Width > 0 {
@@ -111,13 +219,9 @@ func makeConstraints(conf *WebcamConfig, logger logging.Logger) mediadevices.Med
 				constraint.Height = prop.IntRanged{Min: minResolutionDimension, Ideal: 480, Max: 2160}
 			}
 
-			if conf.FrameRate > 0.0 {
-				constraint.FrameRate = prop.FloatExact(conf.FrameRate)
-			} else {
-				constraint.FrameRate = prop.FloatRanged{Min: 0.0, Ideal: 30.0, Max: 140.0}
-			}
+			constraint.FrameRate = conf.frameRateConstraint()
 
-			if conf.Format == "" {
+			if format == "" {
 				constraint.FrameFormat = prop.FrameFormatOneOf{
 					frame.FormatI420,
 					frame.FormatI444,
@@ -130,7 +234,7 @@ func makeConstraints(conf *WebcamConfig, logger logging.Logger) mediadevices.Med
 					frame.FormatZ16,
 				}
 			} else {
-				constraint.FrameFormat = prop.FrameFormatExact(conf.Format)
+				constraint.FrameFormat = prop.FrameFormatExact(format)
 			}
 
 			logger.Debugf("constraints: %v", constraint)
@@ -139,14 +243,41 @@ func makeConstraints(conf *WebcamConfig, logger logging.Logger) mediadevices.Med
 }
 
 // findReaderAndDriver finds a video device and returns an image reader and the driver instance,
-// as well as the path to the driver.
+// as well as the path to the driver and the negotiated media properties. Configured formats are
+// tried in order of preference.
 func findReaderAndDriver(
 	conf *WebcamConfig,
 	path string,
 	logger logging.Logger,
-) (video.Reader, driverutils.Driver, string, error) {
+) (video.Reader, driverutils.Driver, string, prop.Media, error) {
 	mediadevicescamera.Initialize()
-	constraints := makeConstraints(conf, logger)
+
+	var errs error
+	for _, format := range conf.formatsToTry() {
+		reader, driver, foundPath, media, err := findReaderAndDriverWithFormat(conf, format, path, logger)
+		if err == nil {
+			logger.Infow("negotiated webcam format",
+				"format", media.FrameFormat,
+				"width", media.Width,
+				"height", media.Height,
+				"frame_rate", media.FrameRate)
+			return reader, driver, foundPath, media, nil
+		}
+		if format != "" {
+			err = errors.Wrapf(err, "format %s", format)
+		}
+		errs = multierr.Combine(errs, err)
+	}
+	return nil, nil, "", prop.Media{}, errs
+}
+
+func findReaderAndDriverWithFormat(
+	conf *WebcamConfig,
+	format string,
+	path string,
+	logger logging.Logger,
+) (video.Reader, driverutils.Driver, string, prop.Media, error) {
+	constraints := makeConstraints(conf, format, logger)
 
 	// Handle specific path
 	if path != "" {
@@ -154,9 +285,9 @@ func findReaderAndDriver(
 		if err == nil {
 			path = resolvedPath
 		}
-		reader, driver, err := getReaderAndDriver(filepath.Base(path), constraints, logger)
+		reader, driver, media, err := getReaderAndDriver(filepath.Base(path), constraints, logger)
 		if err != nil {
-			return nil, nil, "", err
+			return nil, nil, "", prop.Media{}, err
 		}
 
 		img, release, err := reader.Read()
@@ -164,32 +295,34 @@ func findReaderAndDriver(
 			defer release()
 		}
 		if err != nil {
-			return nil, nil, "", err
+			return nil, nil, "", prop.Media{}, multierr.Combine(err, driver.Close())
 		}
 
 		if conf.Width != 0 && conf.Height != 0 {
 			if img.Bounds().Dx() != conf.Width || img.Bounds().Dy() != conf.Height {
-				return nil, nil, "", errors.Errorf("requested width and height (%dx%d) are not available for this webcam"+
-					" (closest driver found supports resolution %dx%d)",
-					conf.Width, conf.Height, img.Bounds().Dx(), img.Bounds().Dy())
+				return nil, nil, "", prop.Media{}, multierr.Combine(
+					errors.Errorf("requested width and height (%dx%d) are not available for this webcam"+
+						" (closest driver found supports resolution %dx%d)",
+						conf.Width, conf.Height, img.Bounds().Dx(), img.Bounds().Dy()),
+					driver.Close())
 			}
 		}
-		return reader, driver, path, nil
+		return reader, driver, path, media, nil
 	}
 
 	// Handle "any" path
-	reader, driver, err := getReaderAndDriver("", constraints, logger)
+	reader, driver, media, err := getReaderAndDriver("", constraints, logger)
 	if err != nil {
-		return nil, nil, "", errors.Wrap(err, "found no webcams")
+		return nil, nil, "", prop.Media{}, errors.Wrap(err, "found no webcams")
 	}
 	labels := strings.Split(driver.Info().Label, mediadevicescamera.LabelSeparator)
 	if len(labels) == 0 {
 		logger.Error("no labels parsed from driver")
-		return nil, nil, "", nil
+		return nil, nil, "", prop.Media{}, nil
 	}
 	path = labels[0] // path is always the first element
 
-	return reader, driver, path, nil
+	return reader, driver, path, media, nil
 }
 
 // webcam is a video driver wrapper camera that ensures its underlying driver stays connected.
@@ -202,6 +335,10 @@ type webcam struct {
 
 	reader video.Reader
 	driver driverutils.Driver
+	// mjpeg is set instead of reader and driver when mjpeg_passthrough is enabled.
+	mjpeg *mjpegSource
+	// negotiated holds the media properties the driver settled on.
+	negotiated prop.Media
 
 	// this is returned to us as a label in mediadevices but our config
 	// treats it as a video path.
@@ -254,11 +391,15 @@ func (c *webcam) Reconfigure(
 
 	c.cameraModel = camera.NewPinholeModelWithBrownConradyDistortion(newConf.CameraParameters, newConf.DistortionParameters)
 	driverReinitNotNeeded := c.conf.Format == newConf.Format &&
+		slices.Equal(c.conf.Formats, newConf.Formats) &&
 		c.conf.Path == newConf.Path &&
 		c.conf.Width == newConf.Width &&
-		c.conf.Height == newConf.Height
+		c.conf.Height == newConf.Height &&
+		c.conf.MinFrameRate == newConf.MinFrameRate &&
+		c.conf.MaxFrameRate == newConf.MaxFrameRate &&
+		c.conf.MJPEGPassthrough == newConf.MJPEGPassthrough
 
-	if c.driver != nil && c.reader != nil && driverReinitNotNeeded {
+	if c.isOpen() && driverReinitNotNeeded {
 		c.conf = *newConf
 		return nil
 	}
@@ -275,6 +416,9 @@ func (c *webcam) Reconfigure(
 	c.conf = *newConf
 
 	if c.conf.FrameRate == 0.0 {
+		c.conf.FrameRate = c.negotiated.FrameRate
+	}
+	if c.conf.FrameRate <= 0.0 {
 		c.conf.FrameRate = defaultFrameRate
 	}
 	c.buffer = NewWebcamBuffer(c.workers.Context())
@@ -283,10 +427,19 @@ func (c *webcam) Reconfigure(
 	return nil
 }
 
+// isOpen returns whether the camera has an open source to read frames from.
+// Assumes a lock is held.
+func (c *webcam) isOpen() bool {
+	return c.mjpeg != nil || (c.driver != nil && c.reader != nil)
+}
+
 // isCameraConnected is a helper for monitoring connectivity to the driver.
 func (c *webcam) isCameraConnected() (bool, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
+	if c.mjpeg != nil {
+		return c.mjpeg.Connected(), nil
+	}
 	if c.driver == nil {
 		return true, errors.New("no configured camera")
 	}
@@ -307,14 +460,45 @@ func (c *webcam) reconnectCamera(conf *WebcamConfig) error {
 		c.driver = nil
 		c.reader = nil
 	}
+	if c.mjpeg != nil {
+		c.logger.Debug("closing current camera")
+		if err := c.mjpeg.Close(); err != nil {
+			c.logger.Errorw("failed to close current camera", "error", err)
+		}
+		c.mjpeg = nil
+	}
 
-	reader, driver, foundLabel, err := findReaderAndDriver(conf, c.targetPath, c.logger)
+	if conf.MJPEGPassthrough {
+		src, err := openMJPEGSource(c.targetPath, conf)
+		if err == nil {
+			width, height := src.Size()
+			c.mjpeg = src
+			c.negotiated = prop.Media{Video: prop.Video{
+				Width:       width,
+				Height:      height,
+				FrameFormat: frame.FormatMJPEG,
+				FrameRate:   src.FrameRate(),
+			}}
+			c.logger.Infow("opened webcam for mjpeg passthrough", "width", width, "height", height, "frame_rate", src.FrameRate())
+			c.disconnected = false
+			c.closed = false
+			c.logger = c.logger.WithFields("camera_label", c.targetPath)
+			return nil
+		}
+		if !slices.ContainsFunc(conf.Formats, func(format string) bool { return format != string(frame.FormatMJPEG) }) {
+			return errors.Wrap(err, "failed to open camera for mjpeg passthrough")
+		}
+		c.logger.Warnw("cannot open camera for mjpeg passthrough; trying the other configured formats", "error", err)
+	}
+
+	reader, driver, foundLabel, media, err := findReaderAndDriver(conf, c.targetPath, c.logger)
 	if err != nil {
 		return errors.Wrap(err, "failed to find camera")
 	}
 
 	c.reader = reader
 	c.driver = driver
+	c.negotiated = media
 	c.disconnected = false
 	c.closed = false
 	if c.targetPath == "" {
@@ -421,7 +605,7 @@ func (c *webcam) Image(ctx context.Context, mimeType string, extra map[string]in
 	if err := c.ensureActive(); err != nil {
 		return nil, camera.ImageMetadata{}, err
 	}
-	if c.reader == nil {
+	if !c.isOpen() {
 		return nil, camera.ImageMetadata{}, errors.New("underlying reader is nil")
 	}
 	img, err := c.getLatestFrame()
@@ -432,6 +616,10 @@ func (c *webcam) Image(ctx context.Context, mimeType string, extra map[string]in
 	if mimeType == "" {
 		mimeType = utils.MimeTypeJPEG
 	}
+	if lazy, ok := img.(*rimage.LazyEncodedImage); ok && mimeType == utils.MimeTypeJPEG {
+		// passthrough frames are already jpeg encoded by the camera
+		return lazy.RawData(), camera.ImageMetadata{MimeType: mimeType}, nil
+	}
 	imgBytes, err := rimage.EncodeImage(ctx, img, mimeType)
 	if err != nil {
 		return nil, camera.ImageMetadata{}, err
@@ -451,11 +639,10 @@ func (c *webcam) NextPointCloud(ctx context.Context) (pointcloud.PointCloud, err
 		return nil, transform.NewNoIntrinsicsError("cannot do a projection to a point cloud")
 	}
 
-	img, release, err := c.reader.Read()
+	img, err := c.getLatestFrame()
 	if err != nil {
 		return nil, err
 	}
-	defer release()
 
 	dm, err := rimage.ConvertImageToDepthMap(ctx, img)
 	if err != nil {
@@ -474,7 +661,9 @@ func (c *webcam) Properties(ctx context.Context) (camera.Properties, error) {
 	}
 
 	var frameRate float32
-	if c.conf.FrameRate > 0 {
+	if c.negotiated.FrameRate > 0 {
+		frameRate = c.negotiated.FrameRate
+	} else if c.conf.FrameRate > 0 {
 		frameRate = c.conf.FrameRate
 	}
 	return camera.Properties{
@@ -533,6 +722,16 @@ func (c *webcam) startBuffer() {
 						c.buffer.release = nil
 						c.buffer.frame = nil
 					}
+					if c.mjpeg != nil {
+						data, err := c.mjpeg.Read()
+						c.buffer.err = err
+						if err != nil {
+							c.logger.Errorf("error reading frame: %v", err)
+							return // next iteration of for loop
+						}
+						c.buffer.frame = rimage.NewLazyEncodedImage(data, utils.MimeTypeJPEG)
+						return
+					}
 					img, release, err := c.reader.Read()
 					c.buffer.err = err
 					if err != nil {
@@ -570,5 +769,8 @@ func (c *webcam) Close(ctx context.Context) error {
 	}
 	c.closed = true
 
+	if c.mjpeg != nil {
+		return c.mjpeg.Close()
+	}
 	return c.driver.Close()
 }
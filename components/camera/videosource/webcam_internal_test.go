@@ -0,0 +1,41 @@
+package videosource
+
+import (
+	"testing"
+
+	"go.viam.com/test"
+)
+
+func TestChooseFrameRate(t *testing.T) {
+	// discrete rates as a UVC camera reports them, and a continuous range
+	discrete := [][2]float32{{30, 30}, {15, 15}, {5, 5}}
+	continuous := [][2]float32{{1, 60}}
+
+	for _, tc := range []struct {
+		name      string
+		conf      WebcamConfig
+		supported [][2]float32
+		expected  float32
+		err       bool
+	}{
+		{name: "unset leaves the rate alone", supported: discrete},
+		{name: "exact", conf: WebcamConfig{FrameRate: 15}, supported: discrete, expected: 15},
+		{name: "exact unsupported", conf: WebcamConfig{FrameRate: 20}, supported: discrete, err: true},
+		{name: "range prefers max", conf: WebcamConfig{MinFrameRate: 10, MaxFrameRate: 20}, supported: discrete, expected: 15},
+		{name: "range with preferred", conf: WebcamConfig{MinFrameRate: 1, MaxFrameRate: 30, FrameRate: 12}, supported: discrete, expected: 15},
+		{name: "range unsupported", conf: WebcamConfig{MinFrameRate: 40}, supported: discrete, err: true},
+		{name: "continuous", conf: WebcamConfig{MinFrameRate: 10, MaxFrameRate: 20, FrameRate: 12}, supported: continuous, expected: 12},
+		{name: "continuous clamped", conf: WebcamConfig{MinFrameRate: 90}, supported: continuous, err: true},
+		{name: "rates unknown", conf: WebcamConfig{MinFrameRate: 10, MaxFrameRate: 20}, expected: 20},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rate, err := tc.conf.chooseFrameRate(tc.supported)
+			if tc.err {
+				test.That(t, err, test.ShouldNotBeNil)
+				return
+			}
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, rate, test.ShouldEqual, tc.expected)
+		})
+	}
+}
@@ -50,4 +50,54 @@ func TestWebcamValidation(t *testing.T) {
 	test.That(t, err.Error(), test.ShouldEqual,
 		"got illegal non-positive dimension for frame rate (-100.00) field set for webcam camera")
 	test.That(t, deps, test.ShouldBeNil)
+
+	// frame rate range
+	webCfg.FrameRate = 0
+	webCfg.MinFrameRate = 15
+	webCfg.MaxFrameRate = 30
+	_, _, err = webCfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+
+	webCfg.MinFrameRate = 60
+	_, _, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual, "min_frame_rate (60.00) cannot be greater than max_frame_rate (30.00)")
+
+	webCfg.MinFrameRate = 15
+	webCfg.FrameRate = 60
+	_, _, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual, "frame_rate (60.00) must be within min_frame_rate and max_frame_rate")
+
+	// format preference list
+	webCfg.FrameRate = 0
+	webCfg.Formats = []string{"MJPEG", "YUYV"}
+	_, _, err = webCfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+
+	webCfg.Format = "YUYV"
+	_, _, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual, "only one of format and formats can be set for webcam camera")
+
+	// mjpeg passthrough
+	webCfg.Formats = nil
+	webCfg.MJPEGPassthrough = true
+	_, _, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual, "mjpeg_passthrough requires the MJPEG format")
+
+	webCfg.Format = "MJPEG"
+	_, _, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual, "mjpeg_passthrough requires video_path to be set")
+
+	webCfg.Path = "video0"
+	_, _, err = webCfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+
+	// other formats can be listed to fall back to when the camera cannot capture MJPEG
+	webCfg.Format = ""
+	webCfg.Formats = []string{"MJPEG", "YUYV"}
+	_, _, err = webCfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+
+	webCfg.Formats = []string{"YUYV"}
+	_, _, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual, "mjpeg_passthrough requires the MJPEG format")
 }
@@ -281,6 +281,12 @@ func (vs *videoSource) DoCommand(ctx context.Context, cmd map[string]interface{}
 	if res, ok := vs.videoSource.(resource.Resource); ok {
 		return res.DoCommand(ctx, cmd)
 	}
+	// readers, like the transforms of a pipeline, can handle commands of their own
+	if doer, ok := vs.actualSource.(interface {
+		DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error)
+	}); ok {
+		return doer.DoCommand(ctx, cmd)
+	}
 	return nil, resource.ErrDoUnimplemented
 }
 
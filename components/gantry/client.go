@@ -145,6 +145,18 @@ func (c *client) DoCommand(ctx context.Context, cmd map[string]interface{}) (map
 	return rprotoutils.DoFromResourceClient(ctx, c.client, c.name, cmd)
 }
 
+func (c *client) EngageBrakes(ctx context.Context, extra map[string]interface{}) error {
+	return resource.EngageBrakes(ctx, c, extra)
+}
+
+func (c *client) ReleaseBrakes(ctx context.Context, softStart resource.SoftStart, extra map[string]interface{}) error {
+	return resource.ReleaseBrakes(ctx, c, softStart, extra)
+}
+
+func (c *client) BrakesEngaged(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	return resource.BrakesEngaged(ctx, c, extra)
+}
+
 func (c *client) IsMoving(ctx context.Context) (bool, error) {
 	resp, err := c.client.IsMoving(ctx, &pb.IsMovingRequest{Name: c.name})
 	if err != nil {
@@ -3,8 +3,10 @@ package fake
 
 import (
 	"context"
+	"sync"
 
 	"github.com/golang/geo/r3"
+	goutils "go.viam.com/utils"
 
 	"go.viam.com/rdk/components/gantry"
 	"go.viam.com/rdk/logging"
@@ -32,15 +34,13 @@ func init() {
 // NewGantry returns a new fake gantry.
 func NewGantry(name resource.Name, logger logging.Logger) gantry.Gantry {
 	return &Gantry{
-		testutils.NewUnimplementedResource(name),
-		resource.TriviallyReconfigurable{},
-		resource.TriviallyCloseable{},
-		[]float64{1.2},
-		[]float64{120},
-		[]float64{5},
-		2,
-		r3.Vector{X: 1, Y: 0, Z: 0},
-		logger,
+		Named:          testutils.NewUnimplementedResource(name),
+		positionsMm:    []float64{1.2},
+		speedsMmPerSec: []float64{120},
+		lengths:        []float64{5},
+		lengthMeters:   2,
+		frame:          r3.Vector{X: 1, Y: 0, Z: 0},
+		logger:         logger,
 	}
 }
 
@@ -55,6 +55,10 @@ type Gantry struct {
 	lengthMeters   float64
 	frame          r3.Vector
 	logger         logging.Logger
+
+	mu            sync.Mutex
+	brakesEngaged bool
+	softStart     resource.SoftStart
 }
 
 // Position returns the position in meters.
@@ -69,22 +73,71 @@ func (g *Gantry) Lengths(ctx context.Context, extra map[string]interface{}) ([]f
 
 // Home runs the homing sequence of the gantry and returns true once completed.
 func (g *Gantry) Home(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	if err := g.releaseBrakesToMove(ctx); err != nil {
+		return false, err
+	}
 	g.logger.CInfo(ctx, "homing")
 	return true, nil
 }
 
 // MoveToPosition is in meters.
 func (g *Gantry) MoveToPosition(ctx context.Context, positionsMm, speedsMmPerSec []float64, extra map[string]interface{}) error {
+	if err := g.releaseBrakesToMove(ctx); err != nil {
+		return err
+	}
 	g.positionsMm = positionsMm
 	g.speedsMmPerSec = speedsMmPerSec
 	return nil
 }
 
-// Stop doesn't do anything for a fake gantry.
+// Stop engages the brakes, and otherwise doesn't do anything for a fake gantry.
 func (g *Gantry) Stop(ctx context.Context, extra map[string]interface{}) error {
+	return g.EngageBrakes(ctx, extra)
+}
+
+// EngageBrakes marks the fake gantry's brakes as engaged. Moving the fake gantry releases them again.
+func (g *Gantry) EngageBrakes(ctx context.Context, extra map[string]interface{}) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.brakesEngaged = true
+	return nil
+}
+
+// ReleaseBrakes waits out the torque ramp of the soft start, if the brakes are engaged, and then
+// marks them as released, remembering the soft start for when moving releases them.
+func (g *Gantry) ReleaseBrakes(ctx context.Context, softStart resource.SoftStart, extra map[string]interface{}) error {
+	g.mu.Lock()
+	engaged := g.brakesEngaged
+	g.mu.Unlock()
+	if engaged && softStart.TorqueRampDuration > 0 && !goutils.SelectContextOrWait(ctx, softStart.TorqueRampDuration) {
+		return ctx.Err()
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.brakesEngaged = false
+	g.softStart = softStart
 	return nil
 }
 
+// releaseBrakesToMove releases the brakes with the most recent soft start if they are engaged, as
+// a gantry with brakes does before it moves.
+func (g *Gantry) releaseBrakesToMove(ctx context.Context) error {
+	g.mu.Lock()
+	engaged, softStart := g.brakesEngaged, g.softStart
+	g.mu.Unlock()
+	if !engaged {
+		return nil
+	}
+	return g.ReleaseBrakes(ctx, softStart, nil)
+}
+
+// BrakesEngaged returns whether the fake gantry's brakes are engaged.
+func (g *Gantry) BrakesEngaged(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.brakesEngaged, nil
+}
+
 // IsMoving is always false for a fake gantry.
 func (g *Gantry) IsMoving(ctx context.Context) (bool, error) {
 	return false, nil
@@ -6,10 +6,12 @@ import (
 
 	commonpb "go.viam.com/api/common/v1"
 	pb "go.viam.com/api/component/gantry/v1"
+	vprotoutils "go.viam.com/utils/protoutils"
 
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/protoutils"
 	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/session"
 )
 
 // serviceServer implements the GantryService from gantry.proto.
@@ -119,7 +121,8 @@ func (s *serviceServer) IsMoving(ctx context.Context, req *pb.IsMovingRequest) (
 	return &pb.IsMovingResponse{IsMoving: moving}, nil
 }
 
-// DoCommand receives arbitrary commands.
+// DoCommand receives arbitrary commands. Brake commands are handled here rather than by the gantry's
+// DoCommand so that the gantry is safety monitored by the session that released its brakes.
 func (s *serviceServer) DoCommand(ctx context.Context,
 	req *commonpb.DoCommandRequest,
 ) (*commonpb.DoCommandResponse, error) {
@@ -127,5 +130,15 @@ func (s *serviceServer) DoCommand(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	if resp, handled, err := resource.DoBrakeCommand(ctx, gantry, req.GetCommand().AsMap(), session.SafetyMonitor); handled {
+		if err != nil {
+			return nil, err
+		}
+		pbRes, err := vprotoutils.StructToStructPb(resp)
+		if err != nil {
+			return nil, err
+		}
+		return &commonpb.DoCommandResponse{Result: pbRes}, nil
+	}
 	return protoutils.DoFromResourceServer(ctx, gantry, req)
 }
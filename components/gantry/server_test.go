@@ -5,11 +5,14 @@ import (
 	"testing"
 
 	"github.com/pkg/errors"
+	commonpb "go.viam.com/api/common/v1"
 	pb "go.viam.com/api/component/gantry/v1"
 	"go.viam.com/test"
 	"go.viam.com/utils/protoutils"
 
 	"go.viam.com/rdk/components/gantry"
+	"go.viam.com/rdk/components/gantry/fake"
+	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/testutils/inject"
 )
@@ -214,3 +217,69 @@ func TestServer(t *testing.T) {
 		test.That(t, err.Error(), test.ShouldContainSubstring, errStopFailed.Error())
 	})
 }
+
+func TestServerBrakes(t *testing.T) {
+	ctx := context.Background()
+	brakedGantry := fake.NewGantry(gantry.Named(testGantryName), logging.NewTestLogger(t))
+	var doCommand map[string]interface{}
+	injectGantry := &inject.Gantry{}
+	injectGantry.DoFunc = func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+		doCommand = cmd
+		return nil, errors.New("unknown command")
+	}
+	gantrySvc, err := resource.NewAPIResourceCollection(gantry.API, map[resource.Name]gantry.Gantry{
+		gantry.Named(testGantryName): brakedGantry,
+		gantry.Named(failGantryName): injectGantry,
+	})
+	test.That(t, err, test.ShouldBeNil)
+	gantryServer := gantry.NewRPCServiceServer(gantrySvc).(pb.GantryServiceServer)
+
+	do := func(name string, cmd map[string]interface{}) (map[string]interface{}, error) {
+		pbCmd, err := protoutils.StructToStructPb(cmd)
+		test.That(t, err, test.ShouldBeNil)
+		resp, err := gantryServer.DoCommand(ctx, &commonpb.DoCommandRequest{Name: name, Command: pbCmd})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Result.AsMap(), nil
+	}
+
+	resp, err := do(testGantryName, map[string]interface{}{resource.EngageBrakesCommand: true})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp, test.ShouldBeEmpty)
+	resp, err = do(testGantryName, map[string]interface{}{resource.BrakesEngagedCommand: true})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp, test.ShouldResemble, map[string]interface{}{resource.BrakesEngagedCommand: true})
+
+	_, err = do(testGantryName, map[string]interface{}{
+		resource.ReleaseBrakesCommand: map[string]interface{}{"torque_ramp_sec": 0.5, "speed_ramp_sec": 1},
+	})
+	test.That(t, err, test.ShouldBeNil)
+	engaged, err := brakedGantry.(resource.Braker).BrakesEngaged(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, engaged, test.ShouldBeFalse)
+
+	// stopping the gantry, as happens when the session that released the brakes ends, engages them
+	test.That(t, brakedGantry.Stop(ctx, nil), test.ShouldBeNil)
+	engaged, err = brakedGantry.(resource.Braker).BrakesEngaged(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, engaged, test.ShouldBeTrue)
+
+	// moving releases the brakes first
+	test.That(t, brakedGantry.MoveToPosition(ctx, []float64{2}, []float64{10}, nil), test.ShouldBeNil)
+	engaged, err = brakedGantry.(resource.Braker).BrakesEngaged(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, engaged, test.ShouldBeFalse)
+
+	_, err = do(testGantryName, map[string]interface{}{
+		resource.ReleaseBrakesCommand: map[string]interface{}{"torque_ramp_sec": -1},
+	})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = do(testGantryName, map[string]interface{}{resource.ReleaseBrakesCommand: "now"})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// gantries without brakes get the command themselves
+	_, err = do(failGantryName, map[string]interface{}{resource.ReleaseBrakesCommand: true})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, doCommand, test.ShouldContainKey, resource.ReleaseBrakesCommand)
+}
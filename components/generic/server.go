@@ -6,8 +6,8 @@ import (
 
 	commonpb "go.viam.com/api/common/v1"
 	genericpb "go.viam.com/api/component/generic/v1"
-	"go.viam.com/utils/protoutils"
 
+	"go.viam.com/rdk/protoutils"
 	"go.viam.com/rdk/resource"
 )
 
@@ -28,13 +28,5 @@ func (s *serviceServer) DoCommand(ctx context.Context, req *commonpb.DoCommandRe
 	if err != nil {
 		return nil, err
 	}
-	result, err := genericDevice.DoCommand(ctx, req.Command.AsMap())
-	if err != nil {
-		return nil, err
-	}
-	res, err := protoutils.StructToStructPb(result)
-	if err != nil {
-		return nil, err
-	}
-	return &commonpb.DoCommandResponse{Result: res}, nil
+	return protoutils.DoFromResourceServer(ctx, genericDevice, req)
 }
@@ -0,0 +1,167 @@
+// Package gimbal contains a gRPC based gimbal client.
+package gimbal
+
+import (
+	"context"
+	"sync"
+
+	commonpb "go.viam.com/api/common/v1"
+	"go.viam.com/utils/protoutils"
+	"go.viam.com/utils/rpc"
+
+	"go.viam.com/rdk/logging"
+	pb "go.viam.com/rdk/proto/rdk/component/gimbal/v1"
+	rprotoutils "go.viam.com/rdk/protoutils"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+)
+
+// client implements GimbalServiceClient.
+type client struct {
+	resource.Named
+	resource.TriviallyReconfigurable
+	resource.TriviallyCloseable
+	name   string
+	client pb.GimbalServiceClient
+	logger logging.Logger
+
+	mu    sync.Mutex
+	model referenceframe.Model
+}
+
+// NewClientFromConn constructs a new Client from connection passed in.
+func NewClientFromConn(
+	ctx context.Context,
+	conn rpc.ClientConn,
+	remoteName string,
+	name resource.Name,
+	logger logging.Logger,
+) (Gimbal, error) {
+	return &client{
+		Named:  name.PrependRemote(remoteName).AsNamed(),
+		name:   name.ShortName(),
+		client: pb.NewGimbalServiceClient(conn),
+		logger: logger,
+	}, nil
+}
+
+func (c *client) Angles(ctx context.Context, extra map[string]interface{}) (Angles, error) {
+	ext, err := protoutils.StructToStructPb(extra)
+	if err != nil {
+		return Angles{}, err
+	}
+	resp, err := c.client.GetAngles(ctx, &pb.GetAnglesRequest{Name: c.name, Extra: ext})
+	if err != nil {
+		return Angles{}, err
+	}
+	return anglesFromProto(resp.GetAngles()), nil
+}
+
+func (c *client) SetTarget(ctx context.Context, target Angles, extra map[string]interface{}) error {
+	ext, err := protoutils.StructToStructPb(extra)
+	if err != nil {
+		return err
+	}
+	_, err = c.client.SetTarget(ctx, &pb.SetTargetRequest{Name: c.name, Target: anglesToProto(target), Extra: ext})
+	return err
+}
+
+func (c *client) Target(ctx context.Context, extra map[string]interface{}) (Angles, error) {
+	ext, err := protoutils.StructToStructPb(extra)
+	if err != nil {
+		return Angles{}, err
+	}
+	resp, err := c.client.GetTarget(ctx, &pb.GetTargetRequest{Name: c.name, Extra: ext})
+	if err != nil {
+		return Angles{}, err
+	}
+	return anglesFromProto(resp.GetTarget()), nil
+}
+
+func (c *client) SetStabilization(ctx context.Context, enabled bool, extra map[string]interface{}) error {
+	ext, err := protoutils.StructToStructPb(extra)
+	if err != nil {
+		return err
+	}
+	_, err = c.client.SetStabilization(ctx, &pb.SetStabilizationRequest{Name: c.name, Enabled: enabled, Extra: ext})
+	return err
+}
+
+func (c *client) Stabilizing(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	ext, err := protoutils.StructToStructPb(extra)
+	if err != nil {
+		return false, err
+	}
+	resp, err := c.client.IsStabilizing(ctx, &pb.IsStabilizingRequest{Name: c.name, Extra: ext})
+	if err != nil {
+		return false, err
+	}
+	return resp.GetIsStabilizing(), nil
+}
+
+func (c *client) TrackDetection(ctx context.Context, label string, extra map[string]interface{}) error {
+	ext, err := protoutils.StructToStructPb(extra)
+	if err != nil {
+		return err
+	}
+	_, err = c.client.TrackDetection(ctx, &pb.TrackDetectionRequest{Name: c.name, Label: label, Extra: ext})
+	return err
+}
+
+func (c *client) Stop(ctx context.Context, extra map[string]interface{}) error {
+	ext, err := protoutils.StructToStructPb(extra)
+	if err != nil {
+		return err
+	}
+	_, err = c.client.Stop(ctx, &pb.StopRequest{Name: c.name, Extra: ext})
+	return err
+}
+
+func (c *client) IsMoving(ctx context.Context) (bool, error) {
+	resp, err := c.client.IsMoving(ctx, &pb.IsMovingRequest{Name: c.name})
+	if err != nil {
+		return false, err
+	}
+	return resp.GetIsMoving(), nil
+}
+
+func (c *client) Kinematics(ctx context.Context) (referenceframe.Model, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the model does not change while the gimbal is configured, so it is only fetched once
+	if c.model != nil {
+		return c.model, nil
+	}
+	resp, err := c.client.GetKinematics(ctx, &commonpb.GetKinematicsRequest{Name: c.name})
+	if err != nil {
+		return nil, err
+	}
+	model, err := referenceframe.KinematicModelFromProtobuf(c.name, resp)
+	if err != nil {
+		return nil, err
+	}
+	c.model = model
+	return c.model, nil
+}
+
+func (c *client) CurrentInputs(ctx context.Context) ([]referenceframe.Input, error) {
+	resp, err := c.client.GetInputs(ctx, &pb.GetInputsRequest{Name: c.name})
+	if err != nil {
+		return nil, err
+	}
+	return referenceframe.FloatsToInputs(resp.GetInputs()), nil
+}
+
+func (c *client) GoToInputs(ctx context.Context, inputSteps ...[]referenceframe.Input) error {
+	steps := make([]*pb.Inputs, 0, len(inputSteps))
+	for _, step := range inputSteps {
+		steps = append(steps, &pb.Inputs{Values: referenceframe.InputsToFloats(step)})
+	}
+	_, err := c.client.GoToInputs(ctx, &pb.GoToInputsRequest{Name: c.name, Steps: steps})
+	return err
+}
+
+func (c *client) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	return rprotoutils.DoFromResourceClient(ctx, c.client, c.name, cmd)
+}
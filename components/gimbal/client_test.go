@@ -0,0 +1,176 @@
+package gimbal_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"go.viam.com/test"
+	"go.viam.com/utils/rpc"
+
+	"go.viam.com/rdk/components/gimbal"
+	viamgrpc "go.viam.com/rdk/grpc"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/testutils"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+const (
+	testGimbalName    = "gimbal1"
+	testGimbalName2   = "gimbal2"
+	missingGimbalName = "gimbal3"
+)
+
+// panTiltJSON is the kinematics of a gimbal with yaw and pitch axes.
+const panTiltJSON = `{
+	"name": "pan_tilt",
+	"joints": [
+		{"id": "yaw", "type": "revolute", "parent": "world", "axis": {"z": 1}, "min": -90, "max": 90},
+		{"id": "pitch", "type": "revolute", "parent": "yaw", "axis": {"y": 1}, "min": -45, "max": 45}
+	]
+}`
+
+func TestClient(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	listener1, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	rpcServer, err := rpc.NewServer(logger, rpc.WithUnauthenticated())
+	test.That(t, err, test.ShouldBeNil)
+
+	model, err := referenceframe.UnmarshalModelJSON([]byte(panTiltJSON), "")
+	test.That(t, err, test.ShouldBeNil)
+
+	var extraOptions map[string]interface{}
+	var target gimbal.Angles
+	stabilizing := false
+	var tracking string
+	var steps [][]referenceframe.Input
+	injectGimbal := &inject.Gimbal{}
+	injectGimbal.AnglesFunc = func(ctx context.Context, extra map[string]interface{}) (gimbal.Angles, error) {
+		extraOptions = extra
+		return gimbal.Angles{Pitch: -10, Yaw: 5}, nil
+	}
+	injectGimbal.SetTargetFunc = func(ctx context.Context, angles gimbal.Angles, extra map[string]interface{}) error {
+		extraOptions = extra
+		target = angles
+		return nil
+	}
+	injectGimbal.TargetFunc = func(ctx context.Context, extra map[string]interface{}) (gimbal.Angles, error) {
+		return target, nil
+	}
+	injectGimbal.SetStabilizationFunc = func(ctx context.Context, enabled bool, extra map[string]interface{}) error {
+		stabilizing = enabled
+		return nil
+	}
+	injectGimbal.StabilizingFunc = func(ctx context.Context, extra map[string]interface{}) (bool, error) {
+		return stabilizing, nil
+	}
+	injectGimbal.TrackDetectionFunc = func(ctx context.Context, label string, extra map[string]interface{}) error {
+		tracking = label
+		return nil
+	}
+	injectGimbal.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+		extraOptions = extra
+		stabilizing = false
+		tracking = ""
+		return nil
+	}
+	injectGimbal.IsMovingFunc = func(ctx context.Context) (bool, error) {
+		return tracking != "", nil
+	}
+	injectGimbal.KinematicsFunc = func(ctx context.Context) (referenceframe.Model, error) {
+		return model, nil
+	}
+	injectGimbal.CurrentInputsFunc = func(ctx context.Context) ([]referenceframe.Input, error) {
+		return referenceframe.FloatsToInputs([]float64{0.1, -0.2}), nil
+	}
+	injectGimbal.GoToInputsFunc = func(ctx context.Context, inputSteps ...[]referenceframe.Input) error {
+		steps = inputSteps
+		return nil
+	}
+	injectGimbal.DoFunc = testutils.EchoFunc
+
+	gimbalSvc, err := resource.NewAPIResourceCollection(
+		gimbal.API,
+		map[resource.Name]gimbal.Gimbal{gimbal.Named(testGimbalName): injectGimbal})
+	test.That(t, err, test.ShouldBeNil)
+	resourceAPI, ok, err := resource.LookupAPIRegistration[gimbal.Gimbal](gimbal.API)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, resourceAPI.RegisterRPCService(context.Background(), rpcServer, gimbalSvc), test.ShouldBeNil)
+
+	go rpcServer.Serve(listener1)
+	defer rpcServer.Stop()
+
+	t.Run("gimbal client", func(t *testing.T) {
+		conn, err := viamgrpc.Dial(context.Background(), listener1.Addr().String(), logger)
+		test.That(t, err, test.ShouldBeNil)
+		defer conn.Close()
+		gimbal1Client, err := gimbal.NewClientFromConn(context.Background(), conn, "", gimbal.Named(testGimbalName), logger)
+		test.That(t, err, test.ShouldBeNil)
+
+		resp, err := gimbal1Client.DoCommand(context.Background(), testutils.TestCommand)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp["command"], test.ShouldEqual, testutils.TestCommand["command"])
+		test.That(t, resp["data"], test.ShouldEqual, testutils.TestCommand["data"])
+
+		extra := map[string]interface{}{"foo": "Angles"}
+		angles, err := gimbal1Client.Angles(context.Background(), extra)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, angles, test.ShouldResemble, gimbal.Angles{Pitch: -10, Yaw: 5})
+		test.That(t, extraOptions, test.ShouldResemble, extra)
+
+		extra = map[string]interface{}{"foo": "SetTarget"}
+		test.That(t, gimbal1Client.SetTarget(context.Background(), gimbal.Angles{Roll: 1, Pitch: -20, Yaw: 30}, extra),
+			test.ShouldBeNil)
+		test.That(t, extraOptions, test.ShouldResemble, extra)
+		got, err := gimbal1Client.Target(context.Background(), nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, got, test.ShouldResemble, gimbal.Angles{Roll: 1, Pitch: -20, Yaw: 30})
+
+		test.That(t, gimbal1Client.SetStabilization(context.Background(), true, nil), test.ShouldBeNil)
+		isStabilizing, err := gimbal1Client.Stabilizing(context.Background(), nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, isStabilizing, test.ShouldBeTrue)
+
+		test.That(t, gimbal1Client.TrackDetection(context.Background(), "person", nil), test.ShouldBeNil)
+		test.That(t, tracking, test.ShouldEqual, "person")
+		moving, err := gimbal1Client.IsMoving(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, moving, test.ShouldBeTrue)
+
+		extra = map[string]interface{}{"foo": "Stop"}
+		test.That(t, gimbal1Client.Stop(context.Background(), extra), test.ShouldBeNil)
+		test.That(t, extraOptions, test.ShouldResemble, extra)
+		moving, err = gimbal1Client.IsMoving(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, moving, test.ShouldBeFalse)
+
+		// the frame system of the client sees the gimbal's axes
+		m, err := gimbal1Client.Kinematics(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, m.DoF(), test.ShouldHaveLength, 2)
+		inputs, err := gimbal1Client.CurrentInputs(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, referenceframe.InputsToFloats(inputs), test.ShouldResemble, []float64{0.1, -0.2})
+		goal := referenceframe.FloatsToInputs([]float64{0.3, 0.4})
+		test.That(t, gimbal1Client.GoToInputs(context.Background(), goal), test.ShouldBeNil)
+		test.That(t, steps, test.ShouldResemble, [][]referenceframe.Input{goal})
+
+		test.That(t, gimbal1Client.Close(context.Background()), test.ShouldBeNil)
+	})
+
+	t.Run("missing gimbal", func(t *testing.T) {
+		conn, err := viamgrpc.Dial(context.Background(), listener1.Addr().String(), logger)
+		test.That(t, err, test.ShouldBeNil)
+		defer conn.Close()
+		client2, err := resourceAPI.RPCClient(context.Background(), conn, "", gimbal.Named(missingGimbalName), logger)
+		test.That(t, err, test.ShouldBeNil)
+
+		_, err = client2.Angles(context.Background(), nil)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, errGimbalNotFound.Error())
+	})
+}
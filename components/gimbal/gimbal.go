@@ -0,0 +1,107 @@
+// Package gimbal defines an actuated mount that points a camera and can keep it steady while
+// the machine carrying it moves.
+//
+// Gimbals are served on the rdk-owned GimbalService, as the API has no gimbal proto.
+package gimbal
+
+import (
+	"context"
+
+	pb "go.viam.com/rdk/proto/rdk/component/gimbal/v1"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/robot/framesystem"
+)
+
+func init() {
+	resource.RegisterAPI(API, resource.APIRegistration[Gimbal]{
+		RPCServiceServerConstructor: NewRPCServiceServer,
+		RPCServiceHandler:           pb.RegisterGimbalServiceHandlerFromEndpoint,
+		RPCServiceDesc:              &pb.GimbalService_ServiceDesc,
+		RPCClient:                   NewClientFromConn,
+	})
+}
+
+// SubtypeName is a constant that identifies the component resource API string "gimbal".
+const SubtypeName = "gimbal"
+
+// API is a variable that identifies the component resource API.
+var API = resource.APINamespaceRDK.WithComponentType(SubtypeName)
+
+// Named is a helper for getting the named Gimbal's typed resource name.
+func Named(name string) resource.Name {
+	return resource.NewName(API, name)
+}
+
+// Angles are the roll, pitch and yaw of a gimbal in degrees. They follow the right hand rule
+// about the X (forward), Y (left) and Z (up) axes of its mount, so positive pitch points the
+// camera down and positive yaw turns it left.
+type Angles struct {
+	Roll  float64 `json:"roll"`
+	Pitch float64 `json:"pitch"`
+	Yaw   float64 `json:"yaw"`
+}
+
+// A Gimbal points a camera using up to three rotational axes, and can stabilize it by
+// countering the motion of its mount.
+//
+// Its inputs in the frame system are the angles of its axes relative to its mount, in radians,
+// ordered yaw, pitch and roll and omitting axes it does not have, so that frames attached to a
+// gimbal stay posed correctly while it stabilizes.
+//
+// SetTarget example:
+//
+//	// Point the camera 20 degrees down.
+//	err := myGimbal.SetTarget(context.Background(), gimbal.Angles{Pitch: -20}, nil)
+//
+// SetStabilization example:
+//
+//	// Keep the camera level while the machine moves.
+//	err := myGimbal.SetStabilization(context.Background(), true, nil)
+//
+// TrackDetection example:
+//
+//	// Keep a detected person in the middle of the camera's view.
+//	err := myGimbal.TrackDetection(context.Background(), "person", nil)
+type Gimbal interface {
+	resource.Resource
+	resource.Actuator
+	framesystem.InputEnabled
+
+	// Angles returns the current angles of the gimbal's axes relative to its mount.
+	Angles(ctx context.Context, extra map[string]interface{}) (Angles, error)
+
+	// SetTarget sets the angles the camera should point at and stops tracking any detection.
+	// While stabilizing, roll and pitch are relative to the horizon rather than the mount, and
+	// yaw to the heading the mount had when stabilization was turned on.
+	SetTarget(ctx context.Context, target Angles, extra map[string]interface{}) error
+
+	// Target returns the angles the camera is pointed at, which follow the tracked detection
+	// while tracking.
+	Target(ctx context.Context, extra map[string]interface{}) (Angles, error)
+
+	// SetStabilization turns stabilization on or off.
+	SetStabilization(ctx context.Context, enabled bool, extra map[string]interface{}) error
+
+	// Stabilizing returns whether the gimbal is stabilizing.
+	Stabilizing(ctx context.Context, extra map[string]interface{}) (bool, error)
+
+	// TrackDetection keeps the highest scoring detection with the given label in the middle of
+	// the camera's view, until the target is set or the gimbal is stopped.
+	TrackDetection(ctx context.Context, label string, extra map[string]interface{}) error
+}
+
+// FromDependencies is a helper for getting the named gimbal from a collection of dependencies.
+func FromDependencies(deps resource.Dependencies, name string) (Gimbal, error) {
+	return resource.FromDependencies[Gimbal](deps, Named(name))
+}
+
+// FromRobot is a helper for getting the named gimbal from the given Robot.
+func FromRobot(r robot.Robot, name string) (Gimbal, error) {
+	return robot.ResourceFromRobot[Gimbal](r, Named(name))
+}
+
+// NamesFromRobot is a helper for getting all gimbal names from the given Robot.
+func NamesFromRobot(r robot.Robot) []string {
+	return robot.NamesByAPI(r, API)
+}
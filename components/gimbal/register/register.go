@@ -0,0 +1,7 @@
+// Package register registers all relevant gimbals
+package register
+
+import (
+	// for gimbals.
+	_ "go.viam.com/rdk/components/gimbal/stabilized"
+)
@@ -0,0 +1,176 @@
+// Package gimbal contains a gRPC based gimbal service server.
+package gimbal
+
+import (
+	"context"
+
+	commonpb "go.viam.com/api/common/v1"
+
+	"go.viam.com/rdk/operation"
+	pb "go.viam.com/rdk/proto/rdk/component/gimbal/v1"
+	rprotoutils "go.viam.com/rdk/protoutils"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+)
+
+// serviceServer implements the GimbalService from gimbal.proto.
+type serviceServer struct {
+	pb.UnimplementedGimbalServiceServer
+	coll resource.APIResourceCollection[Gimbal]
+}
+
+// NewRPCServiceServer constructs a gimbal gRPC service server.
+// It is intentionally untyped to prevent use outside of tests.
+func NewRPCServiceServer(coll resource.APIResourceCollection[Gimbal]) interface{} {
+	return &serviceServer{coll: coll}
+}
+
+// GetAngles returns the current angles of a gimbal of the underlying robot.
+func (s *serviceServer) GetAngles(ctx context.Context, req *pb.GetAnglesRequest) (*pb.GetAnglesResponse, error) {
+	g, err := s.coll.Resource(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	angles, err := g.Angles(ctx, req.Extra.AsMap())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetAnglesResponse{Angles: anglesToProto(angles)}, nil
+}
+
+// SetTarget sets the angles a gimbal of the underlying robot points its camera at.
+func (s *serviceServer) SetTarget(ctx context.Context, req *pb.SetTargetRequest) (*pb.SetTargetResponse, error) {
+	g, err := s.coll.Resource(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SetTargetResponse{}, g.SetTarget(ctx, anglesFromProto(req.GetTarget()), req.Extra.AsMap())
+}
+
+// GetTarget returns the angles a gimbal of the underlying robot points its camera at.
+func (s *serviceServer) GetTarget(ctx context.Context, req *pb.GetTargetRequest) (*pb.GetTargetResponse, error) {
+	g, err := s.coll.Resource(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	target, err := g.Target(ctx, req.Extra.AsMap())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetTargetResponse{Target: anglesToProto(target)}, nil
+}
+
+// SetStabilization turns stabilization of a gimbal of the underlying robot on or off.
+func (s *serviceServer) SetStabilization(
+	ctx context.Context,
+	req *pb.SetStabilizationRequest,
+) (*pb.SetStabilizationResponse, error) {
+	g, err := s.coll.Resource(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SetStabilizationResponse{}, g.SetStabilization(ctx, req.GetEnabled(), req.Extra.AsMap())
+}
+
+// IsStabilizing returns whether a gimbal of the underlying robot is stabilizing.
+func (s *serviceServer) IsStabilizing(ctx context.Context, req *pb.IsStabilizingRequest) (*pb.IsStabilizingResponse, error) {
+	g, err := s.coll.Resource(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	stabilizing, err := g.Stabilizing(ctx, req.Extra.AsMap())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.IsStabilizingResponse{IsStabilizing: stabilizing}, nil
+}
+
+// TrackDetection has a gimbal of the underlying robot track detections with a label.
+func (s *serviceServer) TrackDetection(ctx context.Context, req *pb.TrackDetectionRequest) (*pb.TrackDetectionResponse, error) {
+	g, err := s.coll.Resource(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TrackDetectionResponse{}, g.TrackDetection(ctx, req.GetLabel(), req.Extra.AsMap())
+}
+
+// GetInputs returns the frame system inputs of a gimbal of the underlying robot.
+func (s *serviceServer) GetInputs(ctx context.Context, req *pb.GetInputsRequest) (*pb.GetInputsResponse, error) {
+	g, err := s.coll.Resource(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := g.CurrentInputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetInputsResponse{Inputs: referenceframe.InputsToFloats(inputs)}, nil
+}
+
+// GoToInputs moves a gimbal of the underlying robot through the given inputs.
+func (s *serviceServer) GoToInputs(ctx context.Context, req *pb.GoToInputsRequest) (*pb.GoToInputsResponse, error) {
+	operation.CancelOtherWithLabel(ctx, req.GetName())
+	g, err := s.coll.Resource(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	steps := make([][]referenceframe.Input, 0, len(req.GetSteps()))
+	for _, step := range req.GetSteps() {
+		steps = append(steps, referenceframe.FloatsToInputs(step.GetValues()))
+	}
+	return &pb.GoToInputsResponse{}, g.GoToInputs(ctx, steps...)
+}
+
+// Stop stops a gimbal of the underlying robot.
+func (s *serviceServer) Stop(ctx context.Context, req *pb.StopRequest) (*pb.StopResponse, error) {
+	operation.CancelOtherWithLabel(ctx, req.GetName())
+	g, err := s.coll.Resource(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.StopResponse{}, g.Stop(ctx, req.Extra.AsMap())
+}
+
+// IsMoving queries if a gimbal of the underlying robot is moving.
+func (s *serviceServer) IsMoving(ctx context.Context, req *pb.IsMovingRequest) (*pb.IsMovingResponse, error) {
+	g, err := s.coll.Resource(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	moving, err := g.IsMoving(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.IsMovingResponse{IsMoving: moving}, nil
+}
+
+func (s *serviceServer) GetKinematics(ctx context.Context, req *commonpb.GetKinematicsRequest) (*commonpb.GetKinematicsResponse, error) {
+	g, err := s.coll.Resource(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	model, err := g.Kinematics(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return referenceframe.KinematicModelToProtobuf(model), nil
+}
+
+// DoCommand receives arbitrary commands.
+func (s *serviceServer) DoCommand(ctx context.Context,
+	req *commonpb.DoCommandRequest,
+) (*commonpb.DoCommandResponse, error) {
+	g, err := s.coll.Resource(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return rprotoutils.DoFromResourceServer(ctx, g, req)
+}
+
+func anglesToProto(angles Angles) *pb.Angles {
+	return &pb.Angles{Roll: angles.Roll, Pitch: angles.Pitch, Yaw: angles.Yaw}
+}
+
+func anglesFromProto(angles *pb.Angles) Angles {
+	return Angles{Roll: angles.GetRoll(), Pitch: angles.GetPitch(), Yaw: angles.GetYaw()}
+}
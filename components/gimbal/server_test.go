@@ -0,0 +1,131 @@
+package gimbal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.viam.com/test"
+	"go.viam.com/utils/protoutils"
+
+	"go.viam.com/rdk/components/gimbal"
+	pb "go.viam.com/rdk/proto/rdk/component/gimbal/v1"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+var (
+	errCantTrack      = errors.New("can't track")
+	errCantStabilize  = errors.New("can't stabilize")
+	errGimbalNotFound = errors.New("not found")
+)
+
+func newServer() (pb.GimbalServiceServer, *inject.Gimbal, *inject.Gimbal, error) {
+	injectGimbal := &inject.Gimbal{}
+	injectGimbal2 := &inject.Gimbal{}
+	gimbals := map[resource.Name]gimbal.Gimbal{
+		gimbal.Named(testGimbalName):  injectGimbal,
+		gimbal.Named(testGimbalName2): injectGimbal2,
+	}
+	gimbalSvc, err := resource.NewAPIResourceCollection(gimbal.API, gimbals)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return gimbal.NewRPCServiceServer(gimbalSvc).(pb.GimbalServiceServer), injectGimbal, injectGimbal2, nil
+}
+
+func TestServer(t *testing.T) {
+	gimbalServer, injectGimbal, injectGimbal2, err := newServer()
+	test.That(t, err, test.ShouldBeNil)
+
+	var extraOptions map[string]interface{}
+	var target gimbal.Angles
+	injectGimbal.AnglesFunc = func(ctx context.Context, extra map[string]interface{}) (gimbal.Angles, error) {
+		extraOptions = extra
+		return gimbal.Angles{Roll: 1, Pitch: 2, Yaw: 3}, nil
+	}
+	injectGimbal.SetTargetFunc = func(ctx context.Context, angles gimbal.Angles, extra map[string]interface{}) error {
+		extraOptions = extra
+		target = angles
+		return nil
+	}
+	injectGimbal.TargetFunc = func(ctx context.Context, extra map[string]interface{}) (gimbal.Angles, error) {
+		return target, nil
+	}
+	injectGimbal.CurrentInputsFunc = func(ctx context.Context) ([]referenceframe.Input, error) {
+		return referenceframe.FloatsToInputs([]float64{0.1, 0.2}), nil
+	}
+	var steps [][]referenceframe.Input
+	injectGimbal.GoToInputsFunc = func(ctx context.Context, inputSteps ...[]referenceframe.Input) error {
+		steps = inputSteps
+		return nil
+	}
+
+	injectGimbal2.SetStabilizationFunc = func(ctx context.Context, enabled bool, extra map[string]interface{}) error {
+		return errCantStabilize
+	}
+	injectGimbal2.TrackDetectionFunc = func(ctx context.Context, label string, extra map[string]interface{}) error {
+		return errCantTrack
+	}
+
+	t.Run("angles", func(t *testing.T) {
+		_, err := gimbalServer.GetAngles(context.Background(), &pb.GetAnglesRequest{Name: missingGimbalName})
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, errGimbalNotFound.Error())
+
+		extra := map[string]interface{}{"foo": "Angles"}
+		ext, err := protoutils.StructToStructPb(extra)
+		test.That(t, err, test.ShouldBeNil)
+		resp, err := gimbalServer.GetAngles(context.Background(), &pb.GetAnglesRequest{Name: testGimbalName, Extra: ext})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp.GetAngles().GetRoll(), test.ShouldEqual, 1)
+		test.That(t, resp.GetAngles().GetPitch(), test.ShouldEqual, 2)
+		test.That(t, resp.GetAngles().GetYaw(), test.ShouldEqual, 3)
+		test.That(t, extraOptions, test.ShouldResemble, extra)
+	})
+
+	t.Run("target", func(t *testing.T) {
+		extra := map[string]interface{}{"foo": "SetTarget"}
+		ext, err := protoutils.StructToStructPb(extra)
+		test.That(t, err, test.ShouldBeNil)
+		_, err = gimbalServer.SetTarget(context.Background(), &pb.SetTargetRequest{
+			Name:   testGimbalName,
+			Target: &pb.Angles{Pitch: -20},
+			Extra:  ext,
+		})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, target, test.ShouldResemble, gimbal.Angles{Pitch: -20})
+		test.That(t, extraOptions, test.ShouldResemble, extra)
+
+		resp, err := gimbalServer.GetTarget(context.Background(), &pb.GetTargetRequest{Name: testGimbalName})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp.GetTarget().GetPitch(), test.ShouldEqual, -20)
+	})
+
+	t.Run("stabilization and tracking", func(t *testing.T) {
+		_, err := gimbalServer.SetStabilization(context.Background(),
+			&pb.SetStabilizationRequest{Name: testGimbalName2, Enabled: true})
+		test.That(t, err, test.ShouldBeError, errCantStabilize)
+
+		_, err = gimbalServer.TrackDetection(context.Background(),
+			&pb.TrackDetectionRequest{Name: testGimbalName2, Label: "person"})
+		test.That(t, err, test.ShouldBeError, errCantTrack)
+	})
+
+	t.Run("inputs", func(t *testing.T) {
+		resp, err := gimbalServer.GetInputs(context.Background(), &pb.GetInputsRequest{Name: testGimbalName})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp.GetInputs(), test.ShouldResemble, []float64{0.1, 0.2})
+
+		_, err = gimbalServer.GoToInputs(context.Background(), &pb.GoToInputsRequest{
+			Name:  testGimbalName,
+			Steps: []*pb.Inputs{{Values: []float64{0.3, 0.4}}, {Values: []float64{0.5, 0.6}}},
+		})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, steps, test.ShouldResemble, [][]referenceframe.Input{
+			referenceframe.FloatsToInputs([]float64{0.3, 0.4}),
+			referenceframe.FloatsToInputs([]float64{0.5, 0.6}),
+		})
+	})
+}
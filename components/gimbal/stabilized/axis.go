@@ -0,0 +1,180 @@
+package stabilized
+
+import (
+	"context"
+	"math"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"go.viam.com/rdk/components/motor"
+	"go.viam.com/rdk/components/servo"
+	"go.viam.com/rdk/resource"
+)
+
+const (
+	defaultServoCenterDegrees = 90.
+	defaultAxisLimitDegrees   = 90.
+	defaultMotorRPM           = 60.
+)
+
+// AxisConfig configures one axis of the gimbal, driven by either a servo or a motor.
+type AxisConfig struct {
+	Servo string `json:"servo,omitempty"`
+	Motor string `json:"motor,omitempty"`
+
+	// CenterDegrees is the servo angle, or motor position in degrees, at which the axis is
+	// centered on the mount. Defaults to 90 for servos and 0 for motors.
+	CenterDegrees *float64 `json:"center_degrees,omitempty"`
+	// Reversed is set if the actuator turns the axis the opposite way to the right hand rule.
+	Reversed bool `json:"reversed,omitempty"`
+	// MinDegrees and MaxDegrees limit the axis relative to its center. They default to -90
+	// and 90.
+	MinDegrees *float64 `json:"min_degrees,omitempty"`
+	MaxDegrees *float64 `json:"max_degrees,omitempty"`
+	// MotorRPM is how fast a motor moves the axis. Defaults to 60.
+	MotorRPM float64 `json:"motor_rpm,omitempty"`
+}
+
+func (cfg *AxisConfig) validate(path string) ([]string, error) {
+	if (cfg.Servo == "") == (cfg.Motor == "") {
+		return nil, resource.NewConfigValidationError(path, errors.New("exactly one of servo and motor must be set"))
+	}
+	if lower, upper := cfg.limits(); lower > upper {
+		return nil, resource.NewConfigValidationError(path, errors.New("min_degrees must not be greater than max_degrees"))
+	}
+	if cfg.MotorRPM < 0 {
+		return nil, resource.NewConfigValidationError(path, errors.New("motor_rpm must not be negative"))
+	}
+	if cfg.Servo != "" {
+		return []string{cfg.Servo}, nil
+	}
+	return []string{cfg.Motor}, nil
+}
+
+func (cfg *AxisConfig) limits() (float64, float64) {
+	lower, upper := -defaultAxisLimitDegrees, defaultAxisLimitDegrees
+	if cfg.MinDegrees != nil {
+		lower = *cfg.MinDegrees
+	}
+	if cfg.MaxDegrees != nil {
+		upper = *cfg.MaxDegrees
+	}
+	return lower, upper
+}
+
+// An axis moves one axis of the gimbal to angles relative to its center.
+type axis struct {
+	name   string
+	servo  servo.Servo
+	motor  motor.Motor
+	center float64
+	sign   float64
+	lower  float64
+	upper  float64
+	rpm    float64
+
+	last float64 // the angle last moved to, NaN if not moved yet
+}
+
+func newAxis(name string, cfg *AxisConfig, deps resource.Dependencies) (*axis, error) {
+	a := &axis{name: name, sign: 1, rpm: defaultMotorRPM, last: math.NaN()}
+	if cfg.Reversed {
+		a.sign = -1
+	}
+	a.lower, a.upper = cfg.limits()
+	if cfg.MotorRPM > 0 {
+		a.rpm = cfg.MotorRPM
+	}
+	var err error
+	if cfg.Servo != "" {
+		a.center = defaultServoCenterDegrees
+		a.servo, err = resource.FromDependencies[servo.Servo](deps, servo.Named(cfg.Servo))
+	} else {
+		a.motor, err = motor.FromDependencies(deps, cfg.Motor)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "no actuator for %s axis", name)
+	}
+	if cfg.CenterDegrees != nil {
+		a.center = *cfg.CenterDegrees
+	}
+	return a, nil
+}
+
+// clamp limits degrees to the axis' range.
+func (a *axis) clamp(degrees float64) float64 {
+	return math.Max(a.lower, math.Min(a.upper, degrees))
+}
+
+// moveTo moves the axis to degrees from its center, clamped to its limits, unless it was
+// already moved there.
+func (a *axis) moveTo(ctx context.Context, degrees float64) error {
+	degrees = a.clamp(degrees)
+	position := a.center + a.sign*degrees
+	if a.servo != nil {
+		// servos only move in whole degrees
+		position = math.Round(position)
+		if position < 0 || position > 180 {
+			return errors.Errorf("%s axis servo cannot move to %v degrees", a.name, position)
+		}
+		if position == math.Round(a.center+a.sign*a.last) {
+			return nil
+		}
+		if err := a.servo.Move(ctx, uint32(position), nil); err != nil {
+			return err
+		}
+	} else {
+		if math.Abs(degrees-a.last) < 0.1 {
+			return nil
+		}
+		if err := a.motor.GoTo(ctx, a.rpm, position/360, nil); err != nil {
+			return err
+		}
+	}
+	a.last = degrees
+	return nil
+}
+
+// angle returns the current angle of the axis from its center.
+func (a *axis) angle(ctx context.Context) (float64, error) {
+	var position float64
+	if a.servo != nil {
+		p, err := a.servo.Position(ctx, nil)
+		if err != nil {
+			return 0, err
+		}
+		position = float64(p)
+	} else {
+		revolutions, err := a.motor.Position(ctx, nil)
+		if err != nil {
+			return 0, err
+		}
+		position = revolutions * 360
+	}
+	return a.sign * (position - a.center), nil
+}
+
+func (a *axis) stop(ctx context.Context) error {
+	a.last = math.NaN()
+	if a.servo != nil {
+		return a.servo.Stop(ctx, nil)
+	}
+	return a.motor.Stop(ctx, nil)
+}
+
+func (a *axis) isMoving(ctx context.Context) (bool, error) {
+	if a.servo != nil {
+		return a.servo.IsMoving(ctx)
+	}
+	return a.motor.IsMoving(ctx)
+}
+
+// stopAxes stops every axis, returning any errors combined.
+func stopAxes(ctx context.Context, axes []*axis) error {
+	var errs error
+	for _, a := range axes {
+		errs = multierr.Combine(errs, a.stop(ctx))
+	}
+	return errs
+}
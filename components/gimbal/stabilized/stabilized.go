@@ -0,0 +1,526 @@
+// Package stabilized implements a gimbal that drives servos or motors, stabilized against the
+// motion of its mount as measured by a movement sensor.
+package stabilized
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	goutils "go.viam.com/utils"
+
+	"go.viam.com/rdk/components/gimbal"
+	"go.viam.com/rdk/components/movementsensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/services/vision"
+	"go.viam.com/rdk/spatialmath"
+	rdkutils "go.viam.com/rdk/utils"
+)
+
+var model = resource.DefaultModelFamily.WithModel("stabilized")
+
+const (
+	defaultUpdateRateHz         = 50.
+	defaultTrackingRateHz       = 5.
+	defaultHorizontalFOVDegrees = 60.
+	defaultVerticalFOVDegrees   = 45.
+	defaultTrackingGain         = 0.5
+)
+
+// Config is used for converting stabilized gimbal config attributes.
+type Config struct {
+	Roll  *AxisConfig `json:"roll,omitempty"`
+	Pitch *AxisConfig `json:"pitch,omitempty"`
+	Yaw   *AxisConfig `json:"yaw,omitempty"`
+
+	// MovementSensor measures the orientation of the gimbal's mount. It is needed to stabilize.
+	MovementSensor string `json:"movement_sensor,omitempty"`
+	// UpdateRateHz is how often the axes are moved towards the target. Defaults to 50.
+	UpdateRateHz float64 `json:"update_rate_hz,omitempty"`
+
+	// Camera and VisionService are the camera on the gimbal and the vision service that finds
+	// the detections it tracks. Both are needed to track detections.
+	Camera        string `json:"camera,omitempty"`
+	VisionService string `json:"vision_service,omitempty"`
+	// HorizontalFOVDegrees and VerticalFOVDegrees are the camera's fields of view. They default
+	// to 60 and 45.
+	HorizontalFOVDegrees float64 `json:"horizontal_fov_degrees,omitempty"`
+	VerticalFOVDegrees   float64 `json:"vertical_fov_degrees,omitempty"`
+	// TrackingRateHz is how often detections are requested while tracking. Defaults to 5.
+	TrackingRateHz float64 `json:"tracking_rate_hz,omitempty"`
+	// TrackingGain is the fraction of the way to a detection the target is moved on each
+	// detection. Defaults to 0.5.
+	TrackingGain float64 `json:"tracking_gain,omitempty"`
+}
+
+// Validate ensures all parts of the config are valid.
+func (conf *Config) Validate(path string) ([]string, []string, error) {
+	var deps []string
+	if conf.Roll == nil && conf.Pitch == nil && conf.Yaw == nil {
+		return nil, nil, resource.NewConfigValidationError(path, errors.New("need at least one axis"))
+	}
+	for _, named := range []struct {
+		name string
+		conf *AxisConfig
+	}{{"yaw", conf.Yaw}, {"pitch", conf.Pitch}, {"roll", conf.Roll}} {
+		if named.conf == nil {
+			continue
+		}
+		axisDeps, err := named.conf.validate(fmt.Sprintf("%s.%s", path, named.name))
+		if err != nil {
+			return nil, nil, err
+		}
+		deps = append(deps, axisDeps...)
+	}
+	if conf.MovementSensor != "" {
+		deps = append(deps, conf.MovementSensor)
+	}
+	if (conf.Camera == "") != (conf.VisionService == "") {
+		return nil, nil, resource.NewConfigValidationError(path,
+			errors.New("camera and vision_service must be set together"))
+	}
+	if conf.VisionService != "" {
+		deps = append(deps, resource.NewName(vision.API, conf.VisionService).String())
+	}
+	if conf.UpdateRateHz < 0 || conf.TrackingRateHz < 0 || conf.HorizontalFOVDegrees < 0 ||
+		conf.VerticalFOVDegrees < 0 || conf.TrackingGain < 0 {
+		return nil, nil, resource.NewConfigValidationError(path,
+			errors.New("rates, fields of view and tracking_gain must not be negative"))
+	}
+	return deps, nil, nil
+}
+
+func init() {
+	resource.RegisterComponent(gimbal.API, model, resource.Registration[gimbal.Gimbal, *Config]{
+		Constructor: newStabilizedGimbal,
+	})
+}
+
+type stabilizedGimbal struct {
+	resource.Named
+	resource.AlwaysRebuild
+	logger logging.Logger
+
+	roll, pitch, yaw *axis
+	axes             []*axis // ordered yaw, pitch, roll, from the mount outwards
+	mount            movementsensor.MovementSensor
+	detector         vision.Service
+	camera           string
+	hFOV, vFOV       float64
+	trackingGain     float64
+	model            referenceframe.Model
+
+	// moveMu serializes moving the axes, which may block until they arrive.
+	moveMu sync.Mutex
+
+	mu            sync.Mutex
+	active        bool // whether the axes are moved towards the target, until stopped
+	target        gimbal.Angles
+	stabilizing   bool
+	yawReference  float64 // the mount's yaw when stabilizing started, NaN until read
+	trackingLabel string
+	lastErr       string
+
+	workers *goutils.StoppableWorkers
+}
+
+func newStabilizedGimbal(
+	ctx context.Context,
+	deps resource.Dependencies,
+	conf resource.Config,
+	logger logging.Logger,
+) (gimbal.Gimbal, error) {
+	newConf, err := resource.NativeConfig[*Config](conf)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &stabilizedGimbal{
+		Named:        conf.ResourceName().AsNamed(),
+		logger:       logger,
+		camera:       newConf.Camera,
+		hFOV:         defaultHorizontalFOVDegrees,
+		vFOV:         defaultVerticalFOVDegrees,
+		trackingGain: defaultTrackingGain,
+		yawReference: math.NaN(),
+	}
+	if newConf.HorizontalFOVDegrees > 0 {
+		g.hFOV = newConf.HorizontalFOVDegrees
+	}
+	if newConf.VerticalFOVDegrees > 0 {
+		g.vFOV = newConf.VerticalFOVDegrees
+	}
+	if newConf.TrackingGain > 0 {
+		g.trackingGain = newConf.TrackingGain
+	}
+
+	if newConf.Yaw != nil {
+		if g.yaw, err = newAxis("yaw", newConf.Yaw, deps); err != nil {
+			return nil, err
+		}
+		g.axes = append(g.axes, g.yaw)
+	}
+	if newConf.Pitch != nil {
+		if g.pitch, err = newAxis("pitch", newConf.Pitch, deps); err != nil {
+			return nil, err
+		}
+		g.axes = append(g.axes, g.pitch)
+	}
+	if newConf.Roll != nil {
+		if g.roll, err = newAxis("roll", newConf.Roll, deps); err != nil {
+			return nil, err
+		}
+		g.axes = append(g.axes, g.roll)
+	}
+
+	if newConf.MovementSensor != "" {
+		if g.mount, err = movementsensor.FromDependencies(deps, newConf.MovementSensor); err != nil {
+			return nil, err
+		}
+	}
+	if newConf.VisionService != "" {
+		if g.detector, err = vision.FromDependencies(deps, newConf.VisionService); err != nil {
+			return nil, err
+		}
+	}
+
+	if g.model, err = g.newModel(); err != nil {
+		return nil, err
+	}
+
+	updateRate := defaultUpdateRateHz
+	if newConf.UpdateRateHz > 0 {
+		updateRate = newConf.UpdateRateHz
+	}
+	trackingRate := defaultTrackingRateHz
+	if newConf.TrackingRateHz > 0 {
+		trackingRate = newConf.TrackingRateHz
+	}
+	g.workers = goutils.NewBackgroundStoppableWorkers(func(ctx context.Context) {
+		g.every(ctx, updateRate, g.update)
+	})
+	if g.detector != nil {
+		g.workers.Add(func(ctx context.Context) {
+			g.every(ctx, trackingRate, g.track)
+		})
+	}
+	return g, nil
+}
+
+// newModel returns the kinematics of the gimbal: a revolute joint for each of its axes, from the
+// mount outwards. It is built from a kinematics config so that it can be sent to clients.
+func (g *stabilizedGimbal) newModel() (referenceframe.Model, error) {
+	var joints []referenceframe.JointConfig
+	parent := referenceframe.World
+	for _, a := range g.axes {
+		var axis spatialmath.AxisConfig
+		switch a {
+		case g.yaw:
+			axis.Z = 1
+		case g.pitch:
+			axis.Y = 1
+		case g.roll:
+			axis.X = 1
+		}
+		id := g.Name().ShortName() + "_" + a.name
+		joints = append(joints, referenceframe.JointConfig{
+			ID:     id,
+			Type:   referenceframe.RevoluteJoint,
+			Parent: parent,
+			Axis:   axis,
+			Min:    a.lower,
+			Max:    a.upper,
+		})
+		parent = id
+	}
+	data, err := json.Marshal(map[string]interface{}{"name": g.Name().ShortName(), "joints": joints})
+	if err != nil {
+		return nil, err
+	}
+	return referenceframe.UnmarshalModelJSON(data, "")
+}
+
+// every calls fn rateHz times a second until ctx is done, logging its errors when they change.
+func (g *stabilizedGimbal) every(ctx context.Context, rateHz float64, fn func(context.Context) error) {
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / rateHz))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		err := fn(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		g.mu.Lock()
+		if err != nil && err.Error() != g.lastErr {
+			g.logger.CWarnw(ctx, "gimbal update failed", "error", err)
+		}
+		g.lastErr = ""
+		if err != nil {
+			g.lastErr = err.Error()
+		}
+		g.mu.Unlock()
+	}
+}
+
+// update moves the axes towards the target, countering the motion of the mount if stabilizing.
+func (g *stabilizedGimbal) update(ctx context.Context) error {
+	g.mu.Lock()
+	active, joints, stabilizing, yawReference := g.active, g.target, g.stabilizing, g.yawReference
+	g.mu.Unlock()
+	if !active {
+		return nil
+	}
+
+	if stabilizing {
+		ori, err := g.mount.Orientation(ctx, nil)
+		if err != nil {
+			return errors.Wrap(err, "cannot stabilize")
+		}
+		mount := ori.EulerAngles()
+		mountYaw := rdkutils.RadToDeg(mount.Yaw)
+		if math.IsNaN(yawReference) {
+			g.mu.Lock()
+			g.yawReference = mountYaw
+			g.mu.Unlock()
+			yawReference = mountYaw
+		}
+		joints.Roll -= rdkutils.RadToDeg(mount.Roll)
+		joints.Pitch -= rdkutils.RadToDeg(mount.Pitch)
+		joints.Yaw -= wrapDegrees(mountYaw - yawReference)
+	}
+	return g.moveAxes(ctx, joints)
+}
+
+// wrapDegrees wraps degrees into [-180, 180).
+func wrapDegrees(degrees float64) float64 {
+	return math.Mod(math.Mod(degrees+180, 360)+360, 360) - 180
+}
+
+// track moves the target towards the tracked detection, if any.
+func (g *stabilizedGimbal) track(ctx context.Context) error {
+	g.mu.Lock()
+	label := g.trackingLabel
+	g.mu.Unlock()
+	if label == "" {
+		return nil
+	}
+
+	detections, err := g.detector.DetectionsFromCamera(ctx, g.camera, nil)
+	if err != nil {
+		return errors.Wrap(err, "cannot track detection")
+	}
+	var bestBox []float64
+	bestScore := math.Inf(-1)
+	for _, d := range detections {
+		if d.Label() != label || d.Score() <= bestScore || len(d.NormalizedBoundingBox()) != 4 {
+			continue
+		}
+		bestBox, bestScore = d.NormalizedBoundingBox(), d.Score()
+	}
+	if bestBox == nil {
+		return nil
+	}
+	// offsets of the detection from the middle of the view, from -0.5 to 0.5 with y down
+	x := (bestBox[0]+bestBox[2])/2 - 0.5
+	y := (bestBox[1]+bestBox[3])/2 - 0.5
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.trackingLabel != label {
+		return nil
+	}
+	// positive yaw turns the camera left and positive pitch points it down
+	g.target.Yaw = g.clampTarget(g.yaw, g.target.Yaw-g.trackingGain*x*g.hFOV)
+	g.target.Pitch = g.clampTarget(g.pitch, g.target.Pitch+g.trackingGain*y*g.vFOV)
+	return nil
+}
+
+// clampTarget limits a tracked target angle to the range of its axis, so that tracking does not
+// wind up while a detection is out of reach.
+func (g *stabilizedGimbal) clampTarget(a *axis, degrees float64) float64 {
+	if a == nil {
+		return degrees
+	}
+	return a.clamp(degrees)
+}
+
+func (g *stabilizedGimbal) moveAxes(ctx context.Context, joints gimbal.Angles) error {
+	g.moveMu.Lock()
+	defer g.moveMu.Unlock()
+	var errs error
+	for _, a := range g.axes {
+		var degrees float64
+		switch a {
+		case g.yaw:
+			degrees = joints.Yaw
+		case g.pitch:
+			degrees = joints.Pitch
+		case g.roll:
+			degrees = joints.Roll
+		}
+		errs = multierr.Combine(errs, a.moveTo(ctx, degrees))
+	}
+	return errs
+}
+
+func (g *stabilizedGimbal) Angles(ctx context.Context, extra map[string]interface{}) (gimbal.Angles, error) {
+	var angles gimbal.Angles
+	for _, a := range g.axes {
+		degrees, err := a.angle(ctx)
+		if err != nil {
+			return gimbal.Angles{}, err
+		}
+		switch a {
+		case g.yaw:
+			angles.Yaw = degrees
+		case g.pitch:
+			angles.Pitch = degrees
+		case g.roll:
+			angles.Roll = degrees
+		}
+	}
+	return angles, nil
+}
+
+func (g *stabilizedGimbal) SetTarget(ctx context.Context, target gimbal.Angles, extra map[string]interface{}) error {
+	g.mu.Lock()
+	g.target = target
+	g.trackingLabel = ""
+	g.active = true
+	g.mu.Unlock()
+	return g.update(ctx)
+}
+
+func (g *stabilizedGimbal) Target(ctx context.Context, extra map[string]interface{}) (gimbal.Angles, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.target, nil
+}
+
+func (g *stabilizedGimbal) SetStabilization(ctx context.Context, enabled bool, extra map[string]interface{}) error {
+	if enabled && g.mount == nil {
+		return errors.New("cannot stabilize without a movement_sensor")
+	}
+	g.mu.Lock()
+	if enabled && !g.stabilizing {
+		g.yawReference = math.NaN()
+	}
+	g.stabilizing = enabled
+	g.active = true
+	g.mu.Unlock()
+	return g.update(ctx)
+}
+
+func (g *stabilizedGimbal) Stabilizing(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.stabilizing, nil
+}
+
+func (g *stabilizedGimbal) TrackDetection(ctx context.Context, label string, extra map[string]interface{}) error {
+	if g.detector == nil {
+		return errors.New("cannot track detections without a camera and vision_service")
+	}
+	if label == "" {
+		return errors.New("need a label to track")
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.trackingLabel = label
+	g.active = true
+	return nil
+}
+
+// Stop stops tracking and stabilizing and stops the axes where they are.
+func (g *stabilizedGimbal) Stop(ctx context.Context, extra map[string]interface{}) error {
+	g.mu.Lock()
+	g.active = false
+	g.stabilizing = false
+	g.trackingLabel = ""
+	g.mu.Unlock()
+
+	g.moveMu.Lock()
+	defer g.moveMu.Unlock()
+	return stopAxes(ctx, g.axes)
+}
+
+// IsMoving returns whether the gimbal is following a target or any of its axes are moving.
+func (g *stabilizedGimbal) IsMoving(ctx context.Context) (bool, error) {
+	g.mu.Lock()
+	active := g.active
+	g.mu.Unlock()
+	if active {
+		return true, nil
+	}
+	for _, a := range g.axes {
+		moving, err := a.isMoving(ctx)
+		if err != nil || moving {
+			return moving, err
+		}
+	}
+	return false, nil
+}
+
+func (g *stabilizedGimbal) Kinematics(ctx context.Context) (referenceframe.Model, error) {
+	return g.model, nil
+}
+
+// CurrentInputs returns the angles of the axes in radians, ordered yaw, pitch and roll.
+func (g *stabilizedGimbal) CurrentInputs(ctx context.Context) ([]referenceframe.Input, error) {
+	inputs := make([]float64, 0, len(g.axes))
+	for _, a := range g.axes {
+		degrees, err := a.angle(ctx)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, rdkutils.DegToRad(degrees))
+	}
+	return referenceframe.FloatsToInputs(inputs), nil
+}
+
+// GoToInputs stops tracking and stabilizing and moves the axes through the given angles.
+func (g *stabilizedGimbal) GoToInputs(ctx context.Context, inputSteps ...[]referenceframe.Input) error {
+	g.mu.Lock()
+	g.active = false
+	g.stabilizing = false
+	g.trackingLabel = ""
+	g.mu.Unlock()
+
+	for _, goal := range inputSteps {
+		if len(goal) != len(g.axes) {
+			return referenceframe.NewIncorrectDoFError(len(goal), len(g.axes))
+		}
+		var joints gimbal.Angles
+		for i, a := range g.axes {
+			degrees := rdkutils.RadToDeg(goal[i].Value)
+			switch a {
+			case g.yaw:
+				joints.Yaw = degrees
+			case g.pitch:
+				joints.Pitch = degrees
+			case g.roll:
+				joints.Roll = degrees
+			}
+		}
+		if err := g.moveAxes(ctx, joints); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *stabilizedGimbal) Close(ctx context.Context) error {
+	g.workers.Stop()
+	return g.Stop(ctx, nil)
+}
@@ -0,0 +1,234 @@
+package stabilized
+
+import (
+	"context"
+	"image"
+	"math"
+	"sync"
+	"testing"
+
+	commonpb "go.viam.com/api/common/v1"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/gimbal"
+	"go.viam.com/rdk/components/motor"
+	"go.viam.com/rdk/components/movementsensor"
+	"go.viam.com/rdk/components/servo"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/services/vision"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/testutils/inject"
+	rdkutils "go.viam.com/rdk/utils"
+	"go.viam.com/rdk/vision/objectdetection"
+)
+
+func TestValidate(t *testing.T) {
+	lower, upper := 30., -30.
+	for _, tc := range []struct {
+		name string
+		conf Config
+		deps []string
+		err  string
+	}{
+		{name: "no axes", conf: Config{}, err: "need at least one axis"},
+		{
+			name: "servo and motor",
+			conf: Config{Pitch: &AxisConfig{Servo: "s", Motor: "m"}},
+			err:  "exactly one of servo and motor",
+		},
+		{
+			name: "bad limits",
+			conf: Config{Pitch: &AxisConfig{Servo: "s", MinDegrees: &lower, MaxDegrees: &upper}},
+			err:  "min_degrees",
+		},
+		{
+			name: "camera without vision service",
+			conf: Config{Pitch: &AxisConfig{Servo: "s"}, Camera: "cam"},
+			err:  "camera and vision_service",
+		},
+		{
+			name: "valid",
+			conf: Config{
+				Pitch:          &AxisConfig{Servo: "s"},
+				Yaw:            &AxisConfig{Motor: "m"},
+				MovementSensor: "imu",
+				Camera:         "cam",
+				VisionService:  "vis",
+			},
+			deps: []string{"m", "s", "imu", vision.Named("vis").String()},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			deps, _, err := tc.conf.Validate("path")
+			if tc.err != "" {
+				test.That(t, err, test.ShouldNotBeNil)
+				test.That(t, err.Error(), test.ShouldContainSubstring, tc.err)
+				return
+			}
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, deps, test.ShouldResemble, tc.deps)
+		})
+	}
+}
+
+// actuators records where the injected servos and motor were moved to.
+type actuators struct {
+	mu     sync.Mutex
+	servos map[string]uint32
+	motor  float64
+}
+
+func (a *actuators) servo(name string) uint32 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.servos[name]
+}
+
+func (a *actuators) motorPosition() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.motor
+}
+
+func setupDependencies(mountYaw *float64, detections []objectdetection.Detection) (resource.Dependencies, *actuators) {
+	moved := &actuators{servos: map[string]uint32{"roll": 90, "pitch": 90}}
+	deps := resource.Dependencies{}
+	for _, name := range []string{"roll", "pitch"} {
+		s := inject.NewServo(name)
+		s.MoveFunc = func(ctx context.Context, angleDeg uint32, extra map[string]interface{}) error {
+			moved.mu.Lock()
+			defer moved.mu.Unlock()
+			moved.servos[name] = angleDeg
+			return nil
+		}
+		s.PositionFunc = func(ctx context.Context, extra map[string]interface{}) (uint32, error) {
+			return moved.servo(name), nil
+		}
+		s.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+			return nil
+		}
+		deps[servo.Named(name)] = s
+	}
+
+	m := inject.NewMotor("yaw")
+	m.GoToFunc = func(ctx context.Context, rpm, position float64, extra map[string]interface{}) error {
+		moved.mu.Lock()
+		defer moved.mu.Unlock()
+		moved.motor = position
+		return nil
+	}
+	m.PositionFunc = func(ctx context.Context, extra map[string]interface{}) (float64, error) {
+		return moved.motorPosition(), nil
+	}
+	m.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+		return nil
+	}
+	deps[motor.Named("yaw")] = m
+
+	imu := inject.NewMovementSensor("imu")
+	imu.OrientationFunc = func(ctx context.Context, extra map[string]interface{}) (spatialmath.Orientation, error) {
+		moved.mu.Lock()
+		defer moved.mu.Unlock()
+		return &spatialmath.EulerAngles{
+			Roll:  rdkutils.DegToRad(10),
+			Pitch: rdkutils.DegToRad(-5),
+			Yaw:   rdkutils.DegToRad(*mountYaw),
+		}, nil
+	}
+	deps[movementsensor.Named("imu")] = imu
+
+	vis := inject.NewVisionService("vis")
+	vis.DetectionsFromCameraFunc = func(
+		ctx context.Context, cameraName string, extra map[string]interface{},
+	) ([]objectdetection.Detection, error) {
+		return detections, nil
+	}
+	deps[vision.Named("vis")] = vis
+	return deps, moved
+}
+
+func TestStabilizedGimbal(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	mountYaw := 30.
+	bounds := image.Rect(0, 0, 100, 100)
+	deps, moved := setupDependencies(&mountYaw, []objectdetection.Detection{
+		objectdetection.NewDetection(bounds, image.Rect(70, 40, 90, 60), 0.9, "person"),
+		objectdetection.NewDetection(bounds, image.Rect(0, 0, 10, 10), 0.5, "person"),
+		objectdetection.NewDetection(bounds, image.Rect(0, 0, 10, 10), 0.99, "dog"),
+	})
+	conf := resource.Config{
+		Name:  "gimbal",
+		API:   gimbal.API,
+		Model: model,
+		ConvertedAttributes: &Config{
+			Roll:           &AxisConfig{Servo: "roll"},
+			Pitch:          &AxisConfig{Servo: "pitch"},
+			Yaw:            &AxisConfig{Motor: "yaw"},
+			MovementSensor: "imu",
+			Camera:         "cam",
+			VisionService:  "vis",
+			// leave tracking to the test
+			TrackingRateHz: 0.001,
+		},
+	}
+	g, err := newStabilizedGimbal(ctx, deps, conf, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, g.Close(ctx), test.ShouldBeNil)
+	}()
+
+	// without stabilization the target is relative to the mount
+	test.That(t, g.SetTarget(ctx, gimbal.Angles{Pitch: 20}, nil), test.ShouldBeNil)
+	test.That(t, moved.servo("pitch"), test.ShouldEqual, 110)
+	test.That(t, moved.servo("roll"), test.ShouldEqual, 90)
+	angles, err := g.Angles(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, angles, test.ShouldResemble, gimbal.Angles{Pitch: 20})
+
+	// stabilizing counters the mount's roll and pitch, and its yaw since stabilization started
+	test.That(t, g.SetStabilization(ctx, true, nil), test.ShouldBeNil)
+	test.That(t, moved.servo("roll"), test.ShouldEqual, 80)
+	test.That(t, moved.servo("pitch"), test.ShouldEqual, 115)
+	test.That(t, moved.motorPosition(), test.ShouldAlmostEqual, 0)
+	moved.mu.Lock()
+	mountYaw = 40
+	moved.mu.Unlock()
+	test.That(t, g.SetTarget(ctx, gimbal.Angles{Pitch: 20}, nil), test.ShouldBeNil)
+	test.That(t, moved.motorPosition(), test.ShouldAlmostEqual, -10./360)
+
+	// the frame system sees the axes' angles, from the mount outwards
+	m, err := g.Kinematics(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, m.DoF(), test.ShouldHaveLength, 3)
+	// and so does that of clients, which are sent the kinematics config
+	test.That(t, referenceframe.KinematicModelToProtobuf(m).GetFormat(), test.ShouldEqual,
+		commonpb.KinematicsFileFormat_KINEMATICS_FILE_FORMAT_SVA)
+	inputs, err := g.CurrentInputs(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, inputs[0].Value, test.ShouldAlmostEqual, rdkutils.DegToRad(-10))
+	test.That(t, inputs[1].Value, test.ShouldAlmostEqual, rdkutils.DegToRad(25))
+	test.That(t, inputs[2].Value, test.ShouldAlmostEqual, rdkutils.DegToRad(-10))
+
+	// tracking moves the target towards the best detection with the label
+	test.That(t, g.TrackDetection(ctx, "person", nil), test.ShouldBeNil)
+	test.That(t, g.(*stabilizedGimbal).track(ctx), test.ShouldBeNil)
+	target, err := g.Target(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, target.Yaw, test.ShouldAlmostEqual, -0.5*0.3*defaultHorizontalFOVDegrees)
+	test.That(t, target.Pitch, test.ShouldAlmostEqual, 20)
+
+	// stopping ends stabilization and tracking
+	test.That(t, g.Stop(ctx, nil), test.ShouldBeNil)
+	stabilizing, err := g.Stabilizing(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, stabilizing, test.ShouldBeFalse)
+
+	// inputs are joint angles, so going to them does not stabilize
+	test.That(t, g.GoToInputs(ctx, referenceframe.FloatsToInputs([]float64{0, math.Pi / 18, 0})), test.ShouldBeNil)
+	test.That(t, moved.servo("pitch"), test.ShouldEqual, 100)
+	test.That(t, moved.servo("roll"), test.ShouldEqual, 90)
+}
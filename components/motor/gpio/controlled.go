@@ -38,20 +38,52 @@ func (cm *controlledMotor) State(ctx context.Context) ([]float64, error) {
 
 // updateControlBlockPosVel updates the trap profile and the constant set point for position and velocity control.
 func (cm *controlledMotor) updateControlBlock(ctx context.Context, setPoint, maxVel float64) error {
-	// Update the Trapezoidal Velocity Profile block with the given maxVel for velocity control
-	dependsOn := []string{cm.blockNames[control.BlockNameConstant][0], cm.blockNames[control.BlockNameEndpoint][0]}
-	if err := control.UpdateTrapzBlock(ctx, cm.blockNames[control.BlockNameTrapezoidal][0], maxVel, dependsOn, cm.loop); err != nil {
+	trapzName, err := cm.blockName(control.BlockNameTrapezoidal)
+	if err != nil {
+		return err
+	}
+	constantName, err := cm.blockName(control.BlockNameConstant)
+	if err != nil {
+		return err
+	}
+
+	// Update the Trapezoidal Velocity Profile block with the given maxVel for velocity control.
+	// A custom control loop keeps the inputs it declared for the block.
+	var dependsOn []string
+	if cm.customControlLoop {
+		for _, b := range cm.controlLoopConfig.Blocks {
+			if b.Name == trapzName {
+				dependsOn = b.DependsOn
+			}
+		}
+	} else {
+		endpointName, err := cm.blockName(control.BlockNameEndpoint)
+		if err != nil {
+			return err
+		}
+		dependsOn = []string{constantName, endpointName}
+	}
+	if err := control.UpdateTrapzBlock(ctx, trapzName, maxVel, dependsOn, cm.loop); err != nil {
 		return err
 	}
 
 	// Update the Constant block with the given setPoint for position control
-	if err := control.UpdateConstantBlock(ctx, cm.blockNames[control.BlockNameConstant][0], setPoint, cm.loop); err != nil {
+	if err := control.UpdateConstantBlock(ctx, constantName, setPoint, cm.loop); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// blockName returns the name of the first block of the given type in the control loop.
+func (cm *controlledMotor) blockName(blockType string) (string, error) {
+	names := cm.blockNames[blockType]
+	if len(names) == 0 {
+		return "", errors.Errorf("control loop of %v has no %v block", cm.Name().ShortName(), blockType)
+	}
+	return names[0], nil
+}
+
 func (cm *controlledMotor) setupControlLoop(conf *Config) error {
 	// set the necessary options for an encoded motor
 	options := control.Options{
@@ -59,6 +91,22 @@ func (cm *controlledMotor) setupControlLoop(conf *Config) error {
 		LoopFrequency:             100.0,
 	}
 
+	// a custom control loop declares its own PID gains in its blocks
+	if conf.ControlLoop != nil {
+		options.UseCustomConfig = true
+		options.CompleteCustomConfig = *conf.ControlLoop
+		pl, err := control.SetupPIDControlConfig(nil, cm.Name().ShortName(), options, cm, cm.logger)
+		if err != nil {
+			return err
+		}
+		cm.pidLoop = pl
+		cm.customControlLoop = true
+		cm.controlLoopConfig = *pl.ControlConf
+		cm.blockNames = pl.BlockNames
+		cm.tunedVals = pl.TunedVals
+		return nil
+	}
+
 	// convert the motor config ControlParameters to the control.PIDConfig structure for use in setup_control.go
 	cm.configPIDVals = []control.PIDConfig{{
 		Type: "",
@@ -78,6 +126,7 @@ func (cm *controlledMotor) setupControlLoop(conf *Config) error {
 		return err
 	}
 
+	cm.pidLoop = pl
 	cm.controlLoopConfig = *pl.ControlConf
 	cm.loop = pl.ControlLoop
 	cm.blockNames = pl.BlockNames
@@ -133,7 +182,7 @@ func setupMotorWithControls(
 	}
 
 	// setup control loop
-	if conf.ControlParameters == nil {
+	if conf.ControlParameters == nil && conf.ControlLoop == nil {
 		return nil, motor.NewControlParametersUnimplementedError()
 	}
 	if err := cm.setupControlLoop(conf); err != nil {
@@ -158,6 +207,10 @@ type controlledMotor struct {
 	real motor.Motor
 	enc  encoder.Encoder
 
+	pidLoop *control.PIDLoop
+	// customControlLoop is whether the control loop was declared in the config, in which case
+	// there are no configured PID values.
+	customControlLoop bool
 	controlLoopConfig control.Config
 	blockNames        map[string][]string
 	loop              *control.Loop
@@ -398,15 +451,75 @@ func (cm *controlledMotor) DoCommand(ctx context.Context, req map[string]interfa
 
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
-	ok := req[getPID].(bool)
-	if ok {
+	if ok, _ := req[getPID].(bool); ok {
 		var respStr string
-		if !(*cm.tunedVals)[0].NeedsAutoTuning() {
+		if len(*cm.tunedVals) > 0 && !(*cm.tunedVals)[0].NeedsAutoTuning() {
 			respStr += (*cm.tunedVals)[0].String()
 		}
 		resp[getPID] = respStr
 	}
 
+	if val, ok := req[control.AutotuneCommand]; ok {
+		if cm.pidLoop == nil {
+			return nil, errors.New("control loop not configured, cannot autotune")
+		}
+		method, err := control.TuneMethodFromCommand(val)
+		if err != nil {
+			return nil, err
+		}
+		if cm.loop != nil {
+			cm.loop.Stop()
+			cm.loop = nil
+		}
+		if err := cm.pidLoop.Autotune(ctx, method); err != nil {
+			return nil, err
+		}
+		// zero the configured values so that movement is refused until tuning is done and
+		// the tuned values are applied.
+		for i := range cm.configPIDVals {
+			cm.configPIDVals[i] = control.PIDConfig{Type: cm.configPIDVals[i].Type}
+		}
+		resp[control.AutotuneCommand] = method
+	}
+
+	if ok, _ := req[control.ControlLoopStateCommand].(bool); ok {
+		resp[control.ControlLoopStateCommand] = control.LoopStateResponse(ctx, cm.loop)
+	}
+
+	if ok, _ := req[control.TuningStatusCommand].(bool); ok {
+		if cm.pidLoop == nil {
+			return nil, errors.New("control loop not configured, no tuning status")
+		}
+		resp[control.TuningStatusCommand] = map[string]interface{}{
+			"tuning": cm.pidLoop.Tuning(ctx),
+			"done":   cm.pidLoop.TuningDone(),
+		}
+	}
+
+	if ok, _ := req[control.ApplyTunedPIDCommand].(bool); ok {
+		if cm.pidLoop == nil {
+			return nil, errors.New("control loop not configured, no tuned values to apply")
+		}
+		tuned, err := cm.pidLoop.ApplyTunedValues()
+		if err != nil {
+			return nil, err
+		}
+		cm.configPIDVals = tuned
+		cm.controlLoopConfig = *cm.pidLoop.ControlConf
+		cm.blockNames = cm.pidLoop.BlockNames
+		controlParams := map[string]interface{}{}
+		if len(tuned) > 0 {
+			controlParams = map[string]interface{}{
+				"p": tuned[0].P,
+				"i": tuned[0].I,
+				"d": tuned[0].D,
+			}
+		}
+		resp[control.ApplyTunedPIDCommand] = map[string]interface{}{
+			"control_parameters": controlParams,
+		}
+	}
+
 	return resp, nil
 }
 
@@ -119,3 +119,12 @@ func TestControlledMotorCreation(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, resp, test.ShouldResemble, emptyMap)
 }
+
+func TestControlledMotorTuningWithoutLoop(t *testing.T) {
+	cm := &controlledMotor{tunedVals: &[]control.PIDConfig{{}}}
+	for _, cmd := range []string{control.TuningStatusCommand, control.ApplyTunedPIDCommand} {
+		_, err := cm.DoCommand(context.Background(), map[string]interface{}{cmd: true})
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "control loop not configured")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"go.viam.com/rdk/components/board"
 	"go.viam.com/rdk/components/encoder"
 	"go.viam.com/rdk/components/motor"
+	"go.viam.com/rdk/control"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/resource"
 )
@@ -124,6 +125,8 @@ type Config struct {
 	MaxRPM            float64         `json:"max_rpm,omitempty"`
 	TicksPerRotation  int             `json:"ticks_per_rotation,omitempty"`
 	ControlParameters *motorPIDConfig `json:"control_parameters,omitempty"`
+	// ControlLoop replaces the built-in position control loop with a custom block diagram.
+	ControlLoop *control.Config `json:"control_loop,omitempty"`
 }
 
 // Validate ensures all parts of the config are valid.
@@ -150,6 +153,18 @@ func (conf *Config) Validate(path string) ([]string, []string, error) {
 	} else if conf.MaxRPM <= 0 {
 		return nil, nil, resource.NewConfigValidationFieldRequiredError(path, "max_rpm")
 	}
+
+	if conf.ControlLoop != nil {
+		if conf.Encoder == "" {
+			return nil, nil, resource.NewConfigValidationError(path, errors.New("control_loop requires an encoder"))
+		}
+		// the motor drives its position through these blocks
+		if err := conf.ControlLoop.Validate(
+			control.BlockNameConstant, control.BlockNameTrapezoidal,
+		); err != nil {
+			return nil, nil, resource.NewConfigValidationError(path, errors.Wrap(err, "invalid control_loop"))
+		}
+	}
 	return deps, nil, nil
 }
 
@@ -218,7 +233,7 @@ func createNewMotor(
 		}
 
 		switch {
-		case motorConfig.ControlParameters == nil:
+		case motorConfig.ControlParameters == nil && motorConfig.ControlLoop == nil:
 			m, err = WrapMotorWithEncoder(ctx, e, cfg, *motorConfig, m, logger)
 			if err != nil {
 				return nil, err
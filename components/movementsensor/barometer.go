@@ -0,0 +1,160 @@
+package movementsensor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"go.viam.com/rdk/resource"
+)
+
+// CapabilityBarometer is the capability of movement sensors that implement Barometer.
+const CapabilityBarometer resource.Capability = "barometer"
+
+// DoCommand keys used to carry barometer requests over the movement sensor API, whose proto has
+// no barometer methods. Their responses hold the value under the same key.
+const (
+	PressureAltitudeCommand = "pressure_altitude"
+	VerticalSpeedCommand    = "vertical_speed"
+)
+
+// StandardSeaLevelPressure is the sea level pressure of the International Standard Atmosphere,
+// in pascals.
+const StandardSeaLevelPressure = 101325.0
+
+// ErrMethodUnimplementedBarometer returns error if the Barometer methods are unimplemented.
+var ErrMethodUnimplementedBarometer = errors.New("Barometer Unimplemented")
+
+// A Barometer is a movement sensor that measures air pressure, such as on a drone or a robot
+// that rides elevators. Pressure altitude is precise over short periods but drifts with the
+// weather, so it is best fused with an absolute altitude, such as from a GPS.
+type Barometer interface {
+	// PressureAltitude returns the altitude above sea level in meters implied by the measured
+	// air pressure.
+	PressureAltitude(ctx context.Context, extra map[string]interface{}) (float64, error)
+
+	// VerticalSpeed returns how fast the sensor is climbing in meters per second. It is negative
+	// while descending.
+	VerticalSpeed(ctx context.Context, extra map[string]interface{}) (float64, error)
+}
+
+// PressureToAltitude returns the altitude in meters at which the International Standard
+// Atmosphere has the given pressure, relative to the given pressure at sea level. Both pressures
+// must be in the same units.
+func PressureToAltitude(pressure, seaLevelPressure float64) float64 {
+	return 44330.8 * (1 - math.Pow(pressure/seaLevelPressure, 0.190263))
+}
+
+// VerticalSpeedEstimator estimates vertical speed from successive altitudes, smoothing out the
+// noise of differentiating them. It is safe for concurrent use.
+type VerticalSpeedEstimator struct {
+	// TimeConstant is how long changes in vertical speed take to be mostly reflected in the
+	// estimate. Zero makes the estimate the speed between the last two altitudes.
+	TimeConstant time.Duration
+
+	mu           sync.Mutex
+	lastAltitude float64
+	lastTime     time.Time
+	speed        float64
+}
+
+// Update adds the altitude measured at t and returns the new vertical speed estimate in meters
+// per second. Altitudes that are NaN or not newer than the last one are ignored.
+func (e *VerticalSpeedEstimator) Update(altitude float64, t time.Time) float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if math.IsNaN(altitude) {
+		return e.speed
+	}
+	if e.lastTime.IsZero() {
+		e.lastAltitude, e.lastTime = altitude, t
+		return e.speed
+	}
+	dt := t.Sub(e.lastTime)
+	if dt <= 0 {
+		return e.speed
+	}
+	speed := (altitude - e.lastAltitude) / dt.Seconds()
+	alpha := dt.Seconds() / (e.TimeConstant.Seconds() + dt.Seconds())
+	e.speed += alpha * (speed - e.speed)
+	e.lastAltitude, e.lastTime = altitude, t
+	return e.speed
+}
+
+// Speed returns the current vertical speed estimate in meters per second.
+func (e *VerticalSpeedEstimator) Speed() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.speed
+}
+
+// DoBarometerCommand handles a barometer DoCommand on behalf of a resource server. It reports false
+// if cmd is not a barometer command or ms is not a Barometer.
+func DoBarometerCommand(
+	ctx context.Context,
+	ms MovementSensor,
+	cmd map[string]interface{},
+) (map[string]interface{}, bool, error) {
+	baro, ok := ms.(Barometer)
+	if !ok {
+		return nil, false, nil
+	}
+	extra, _ := cmd["extra"].(map[string]interface{})
+	if _, ok := cmd[PressureAltitudeCommand]; ok {
+		altitude, err := baro.PressureAltitude(ctx, extra)
+		if err != nil {
+			return nil, true, err
+		}
+		return map[string]interface{}{PressureAltitudeCommand: altitude}, true, nil
+	}
+	if _, ok := cmd[VerticalSpeedCommand]; ok {
+		speed, err := baro.VerticalSpeed(ctx, extra)
+		if err != nil {
+			return nil, true, err
+		}
+		return map[string]interface{}{VerticalSpeedCommand: speed}, true, nil
+	}
+	return nil, false, nil
+}
+
+// barometerValue makes a barometer request through DoCommand, for clients of remote movement
+// sensors.
+func barometerValue(ctx context.Context, res resource.Resource, key string, extra map[string]interface{}) (float64, error) {
+	resp, err := res.DoCommand(ctx, map[string]interface{}{key: true, "extra": extra})
+	if err != nil {
+		return math.NaN(), err
+	}
+	value, ok := resp[key].(float64)
+	if !ok {
+		return math.NaN(), fmt.Errorf("%q: %w", res.Name(), ErrMethodUnimplementedBarometer)
+	}
+	return value, nil
+}
+
+// barometerReadings adds the pressure altitude and vertical speed of ms to readings, if ms is a
+// Barometer whose properties say it supports them. A barometer that fails leaves its readings out
+// rather than failing the readings of the rest of the sensor.
+func barometerReadings(ctx context.Context, ms MovementSensor, readings, extra map[string]interface{}) {
+	baro, ok := ms.(Barometer)
+	if !ok {
+		return
+	}
+	// clients satisfy Barometer whether or not the remote sensor is one
+	props, err := ms.Properties(ctx, extra)
+	if err != nil || props == nil || !props.BarometerSupported {
+		return
+	}
+	altitude, err := baro.PressureAltitude(ctx, extra)
+	if err != nil {
+		return
+	}
+	speed, err := baro.VerticalSpeed(ctx, extra)
+	if err != nil {
+		return
+	}
+	readings[PressureAltitudeCommand] = altitude
+	readings[VerticalSpeedCommand] = speed
+}
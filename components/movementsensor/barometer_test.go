@@ -0,0 +1,29 @@
+package movementsensor
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+)
+
+func TestPressureToAltitude(t *testing.T) {
+	test.That(t, PressureToAltitude(StandardSeaLevelPressure, StandardSeaLevelPressure), test.ShouldEqual, 0)
+	// the standard atmosphere has about 89.9 kPa at 1000 m
+	test.That(t, PressureToAltitude(89874.6, StandardSeaLevelPressure), test.ShouldAlmostEqual, 1000, 1)
+}
+
+func TestVerticalSpeedEstimator(t *testing.T) {
+	var e VerticalSpeedEstimator
+	start := time.Now()
+	test.That(t, e.Update(10, start), test.ShouldEqual, 0)
+	test.That(t, e.Update(12, start.Add(time.Second)), test.ShouldEqual, 2)
+	test.That(t, e.Update(math.NaN(), start.Add(2*time.Second)), test.ShouldEqual, 2)
+	test.That(t, e.Update(11, start.Add(2*time.Second)), test.ShouldEqual, -1)
+
+	smoothed := VerticalSpeedEstimator{TimeConstant: time.Second}
+	smoothed.Update(0, start)
+	test.That(t, smoothed.Update(2, start.Add(time.Second)), test.ShouldEqual, 1)
+	test.That(t, smoothed.Speed(), test.ShouldEqual, 1)
+}
@@ -2,12 +2,18 @@ package movementsensor
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/golang/geo/r3"
 	geo "github.com/kellydunn/golang-geo"
+	"github.com/pkg/errors"
 	commonpb "go.viam.com/api/common/v1"
 	pb "go.viam.com/api/component/movementsensor/v1"
+	goutils "go.viam.com/utils"
 	"go.viam.com/utils/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/structpb"
 
 	"go.viam.com/rdk/logging"
@@ -22,6 +28,7 @@ type client struct {
 	resource.TriviallyReconfigurable
 	resource.TriviallyCloseable
 	name   string
+	conn   rpc.ClientConn
 	client pb.MovementSensorServiceClient
 	logger logging.Logger
 }
@@ -38,6 +45,7 @@ func NewClientFromConn(
 	return &client{
 		Named:  name.PrependRemote(remoteName).AsNamed(),
 		name:   name.ShortName(),
+		conn:   conn,
 		client: c,
 		logger: logger,
 	}, nil
@@ -151,6 +159,57 @@ func (c *client) Readings(ctx context.Context, extra map[string]interface{}) (ma
 	return protoutils.ReadingProtoToGo(resp.Readings)
 }
 
+// StreamReadings streams the readings of the movement sensor from the server. Servers that do not
+// serve the stream are polled instead.
+func (c *client) StreamReadings(ctx context.Context, opts StreamReadingsOptions) (<-chan StreamedReading, error) {
+	req, err := readingsRequest(c.name, opts)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := c.conn.NewStream(ctx, &streamServiceDesc.Streams[0], "/"+StreamServiceName+"/"+streamReadingsMethod)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(req); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	// the first reading is sent right away, so an older server is found out before returning
+	resp := &structpb.Struct{}
+	if err := stream.RecvMsg(resp); err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return pollReadings(ctx, c, opts), nil
+		}
+		return nil, err
+	}
+
+	ch := make(chan StreamedReading)
+	goutils.PanicCapturingGo(func() {
+		defer close(ch)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- readingFromResponse(resp):
+			}
+			resp = &structpb.Struct{}
+			if err := stream.RecvMsg(resp); err != nil {
+				if errors.Is(err, io.EOF) || ctx.Err() != nil {
+					return
+				}
+				select {
+				case <-ctx.Done():
+				case ch <- StreamedReading{Time: time.Now(), Err: err}:
+				}
+				return
+			}
+		}
+	})
+	return ch, nil
+}
+
 func (c *client) Accuracy(ctx context.Context, extra map[string]interface{}) (*Accuracy, error,
 ) {
 	ext, err := structpb.NewStruct(extra)
@@ -179,7 +238,20 @@ func (c *client) Properties(ctx context.Context, extra map[string]interface{}) (
 	if err != nil {
 		return nil, err
 	}
-	return ProtoFeaturesToProperties(resp), nil
+	props := ProtoFeaturesToProperties(resp)
+	// servers that cannot report capabilities have no barometers to report
+	if supported, err := resource.HasCapability(ctx, c, CapabilityBarometer); err == nil {
+		props.BarometerSupported = supported
+	}
+	return props, nil
+}
+
+func (c *client) PressureAltitude(ctx context.Context, extra map[string]interface{}) (float64, error) {
+	return barometerValue(ctx, c, PressureAltitudeCommand, extra)
+}
+
+func (c *client) VerticalSpeed(ctx context.Context, extra map[string]interface{}) (float64, error) {
+	return barometerValue(ctx, c, VerticalSpeedCommand, extra)
 }
 
 func (c *client) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
@@ -248,4 +248,43 @@ func TestClient(t *testing.T) {
 		test.That(t, client2.Close(context.Background()), test.ShouldBeNil)
 		test.That(t, conn.Close(), test.ShouldBeNil)
 	})
+
+	t.Run("StreamReadings", func(t *testing.T) {
+		conn, err := viamgrpc.Dial(context.Background(), listener1.Addr().String(), logger)
+		test.That(t, err, test.ShouldBeNil)
+		defer conn.Close()
+		gps1Client, err := movementsensor.NewClientFromConn(context.Background(), conn, "", movementsensor.Named(testMovementSensorName), logger)
+		test.That(t, err, test.ShouldBeNil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		readings, err := movementsensor.StreamReadings(ctx, gps1Client, movementsensor.StreamReadingsOptions{Rate: 100})
+		test.That(t, err, test.ShouldBeNil)
+		for i := 0; i < 3; i++ {
+			reading := <-readings
+			test.That(t, reading.Err, test.ShouldBeNil)
+			test.That(t, reading.Time.IsZero(), test.ShouldBeFalse)
+			test.That(t, reading.Readings["position"], test.ShouldResemble, rs["position"])
+			test.That(t, reading.Readings["linear_velocity"], test.ShouldResemble, rs["linear_velocity"])
+			test.That(t, reading.Readings["orientation"], test.ShouldResemble, rs["orientation"])
+		}
+		cancel()
+		for range readings {
+		}
+
+		// errors reading the sensor are sent as readings
+		ctx, cancel = context.WithCancel(context.Background())
+		defer cancel()
+		client2, err := movementsensor.NewClientFromConn(context.Background(), conn, "", movementsensor.Named(failMovementSensorName), logger)
+		test.That(t, err, test.ShouldBeNil)
+		readings, err = movementsensor.StreamReadings(ctx, client2, movementsensor.StreamReadingsOptions{})
+		test.That(t, err, test.ShouldBeNil)
+		reading := <-readings
+		test.That(t, reading.Err, test.ShouldNotBeNil)
+		test.That(t, reading.Err.Error(), test.ShouldContainSubstring, errReadingsFailed.Error())
+
+		missing, err := movementsensor.NewClientFromConn(context.Background(), conn, "", movementsensor.Named(missingMovementSensorName), logger)
+		test.That(t, err, test.ShouldBeNil)
+		_, err = movementsensor.StreamReadings(ctx, missing, movementsensor.StreamReadingsOptions{})
+		test.That(t, err, test.ShouldNotBeNil)
+	})
 }
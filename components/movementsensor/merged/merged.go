@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"math"
 	"sync"
+	"time"
 
 	"github.com/golang/geo/r3"
 	geo "github.com/kellydunn/golang-geo"
+	"github.com/pkg/errors"
 	"go.uber.org/multierr"
 	"golang.org/x/exp/maps"
 
@@ -18,7 +20,11 @@ import (
 	"go.viam.com/rdk/spatialmath"
 )
 
-const errStrAccuracy = "_accuracy_err"
+const (
+	errStrAccuracy = "_accuracy_err"
+
+	defaultAltitudeTimeConstantSec = 10.
+)
 
 var model = resource.DefaultModelFamily.WithModel("merged")
 
@@ -30,6 +36,12 @@ type Config struct {
 	LinearVelocity     []string `json:"linear_velocity,omitempty"`
 	AngularVelocity    []string `json:"angular_velocity,omitempty"`
 	LinearAcceleration []string `json:"linear_acceleration,omitempty"`
+	Barometer          []string `json:"barometer,omitempty"`
+
+	// AltitudeTimeConstantSec is how long the fused altitude takes to mostly follow a change in
+	// the position sensor's altitude that the barometer does not see, such as from a change in
+	// the weather. Defaults to 10 seconds.
+	AltitudeTimeConstantSec float64 `json:"altitude_time_constant_sec,omitempty"`
 }
 
 // Validate validates the merged model's configuration.
@@ -41,6 +53,11 @@ func (cfg *Config) Validate(path string) ([]string, []string, error) {
 	deps = append(deps, cfg.LinearVelocity...)
 	deps = append(deps, cfg.AngularVelocity...)
 	deps = append(deps, cfg.LinearAcceleration...)
+	deps = append(deps, cfg.Barometer...)
+	if cfg.AltitudeTimeConstantSec < 0 {
+		return nil, nil, resource.NewConfigValidationError(path,
+			errors.New("altitude_time_constant_sec must not be negative"))
+	}
 	return deps, nil, nil
 }
 
@@ -56,6 +73,13 @@ type merged struct {
 	linVel  movementsensor.MovementSensor
 	angVel  movementsensor.MovementSensor
 	linAcc  movementsensor.MovementSensor
+
+	// baro is fused with pos to smooth out its altitude and to keep reporting altitude while
+	// pos has none.
+	baro                 movementsensor.Barometer
+	altitudeTimeConstant time.Duration
+	altitudeOffset       float64 // absolute altitude minus pressure altitude, NaN until known
+	lastFused            time.Time
 }
 
 func init() {
@@ -189,9 +213,72 @@ func (m *merged) Reconfigure(ctx context.Context, deps resource.Dependencies, co
 		return err
 	}
 
+	m.baro, err = m.firstBarometer(ctx, deps, newConf.Barometer)
+	if err != nil {
+		return err
+	}
+	m.altitudeTimeConstant = time.Duration(defaultAltitudeTimeConstantSec * float64(time.Second))
+	if newConf.AltitudeTimeConstantSec > 0 {
+		m.altitudeTimeConstant = time.Duration(newConf.AltitudeTimeConstantSec * float64(time.Second))
+	}
+	m.altitudeOffset = math.NaN()
+
 	return nil
 }
 
+// firstBarometer returns the first sensor named that is a barometer.
+func (m *merged) firstBarometer(
+	ctx context.Context, deps resource.Dependencies, names []string,
+) (movementsensor.Barometer, error) {
+	if len(names) == 0 || deps == nil {
+		return nil, nil
+	}
+	for _, name := range names {
+		ms, err := movementsensor.FromDependencies(deps, name)
+		if err != nil {
+			m.logger.CDebugf(ctx, "error getting sensor %v from dependencies", name)
+			continue
+		}
+		isBarometer, err := resource.HasCapability(ctx, ms, movementsensor.CapabilityBarometer)
+		if err != nil {
+			m.logger.CDebugf(ctx, "error in getting sensor %v capabilities", name)
+			continue
+		}
+		baro, ok := ms.(movementsensor.Barometer)
+		if !isBarometer || !ok {
+			continue
+		}
+		m.logger.Debugf("using sensor %v as barometer sensor", name)
+		return baro, nil
+	}
+	return nil, fmt.Errorf("barometer not supported by any sensor in list %#v", names)
+}
+
+// fuseAltitude combines the absolute altitude of the position sensor, which is right on average
+// but noisy, with the pressure altitude of the barometer, which is smooth but drifts with the
+// weather. It low-pass filters the offset between the two, so that the fused altitude follows
+// the barometer over short periods and the position sensor over long ones. While the position
+// sensor has no altitude, the barometer is used with the last offset.
+func (m *merged) fuseAltitude(absolute, pressure float64, now time.Time) float64 {
+	if math.IsNaN(pressure) {
+		return absolute
+	}
+	if !math.IsNaN(absolute) {
+		offset := absolute - pressure
+		if math.IsNaN(m.altitudeOffset) {
+			m.altitudeOffset = offset
+		} else if dt := now.Sub(m.lastFused).Seconds(); dt > 0 {
+			alpha := dt / (m.altitudeTimeConstant.Seconds() + dt)
+			m.altitudeOffset += alpha * (offset - m.altitudeOffset)
+		}
+		m.lastFused = now
+	}
+	if math.IsNaN(m.altitudeOffset) {
+		return absolute
+	}
+	return pressure + m.altitudeOffset
+}
+
 func (m *merged) Position(ctx context.Context, extra map[string]interface{}) (*geo.Point, float64, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -200,7 +287,47 @@ func (m *merged) Position(ctx context.Context, extra map[string]interface{}) (*g
 		return geo.NewPoint(math.NaN(), math.NaN()), math.NaN(),
 			movementsensor.ErrMethodUnimplementedPosition
 	}
-	return m.pos.Position(ctx, extra)
+	point, altitude, err := m.pos.Position(ctx, extra)
+	if err != nil || m.baro == nil {
+		return point, altitude, err
+	}
+	pressureAltitude, err := m.baro.PressureAltitude(ctx, extra)
+	if err != nil {
+		m.logger.CDebugw(ctx, "not fusing altitude with barometer", "error", err)
+		return point, altitude, nil
+	}
+	return point, m.fuseAltitude(altitude, pressureAltitude, time.Now()), nil
+}
+
+func (m *merged) PressureAltitude(ctx context.Context, extra map[string]interface{}) (float64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.baro == nil {
+		return math.NaN(), movementsensor.ErrMethodUnimplementedBarometer
+	}
+	return m.baro.PressureAltitude(ctx, extra)
+}
+
+func (m *merged) VerticalSpeed(ctx context.Context, extra map[string]interface{}) (float64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.baro == nil {
+		return math.NaN(), movementsensor.ErrMethodUnimplementedBarometer
+	}
+	return m.baro.VerticalSpeed(ctx, extra)
+}
+
+// Capabilities reports the barometer capability when a barometer is configured.
+func (m *merged) Capabilities() []resource.Capability {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.baro == nil {
+		return nil
+	}
+	return []resource.Capability{movementsensor.CapabilityBarometer}
 }
 
 func (m *merged) Orientation(ctx context.Context, extra map[string]interface{}) (spatialmath.Orientation, error) {
@@ -406,6 +533,7 @@ func (m *merged) Properties(ctx context.Context, extra map[string]interface{}) (
 		LinearVelocitySupported:     m.linVel != nil,
 		AngularVelocitySupported:    m.angVel != nil,
 		LinearAccelerationSupported: m.linAcc != nil,
+		BarometerSupported:          m.baro != nil,
 	}, nil
 }
 
@@ -359,3 +359,81 @@ func TestCreation(t *testing.T) {
 	// close the sensor, this test is done
 	test.That(t, ms.Close(ctx), test.ShouldBeNil)
 }
+
+type testBarometer struct {
+	*inject.MovementSensor
+	altitude float64
+	speed    float64
+	speedErr error
+}
+
+func (b *testBarometer) PressureAltitude(ctx context.Context, extra map[string]interface{}) (float64, error) {
+	return b.altitude, nil
+}
+
+func (b *testBarometer) VerticalSpeed(ctx context.Context, extra map[string]interface{}) (float64, error) {
+	return b.speed, b.speedErr
+}
+
+func (b *testBarometer) Capabilities() []resource.Capability {
+	return []resource.Capability{movementsensor.CapabilityBarometer}
+}
+
+func TestBarometerFusion(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	conf := setUpCfg(emptySensors, posSensors, emptySensors, emptySensors, emptySensors, emptySensors)
+	conf.ConvertedAttributes.(*Config).Barometer = []string{"notBaro", "goodBaro"}
+	deps := setupDependencies(t, map[string]movementsensor.Properties{
+		posSensors[0]: posProps,
+		"notBaro":     emptyProps,
+	}, false, false)
+	deps[movementsensor.Named("notBaro")].(*inject.MovementSensor).DoFunc = func(
+		ctx context.Context, cmd map[string]interface{},
+	) (map[string]interface{}, error) {
+		return resource.CapabilitiesResponse(nil), nil
+	}
+	baro := &testBarometer{MovementSensor: inject.NewMovementSensor("goodBaro"), altitude: 20, speed: 0.5}
+	deps[movementsensor.Named("goodBaro")] = baro
+
+	ms, err := newMergedModel(ctx, deps, conf, logger)
+	test.That(t, err, test.ShouldBeNil)
+	caps, err := resource.GetCapabilities(ctx, ms)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, caps, test.ShouldResemble, []resource.Capability{movementsensor.CapabilityBarometer})
+
+	// the first fused altitude is the position sensor's, after which the barometer's changes
+	// are followed
+	_, alt, err := ms.Position(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, alt, test.ShouldEqual, testalt)
+	baro.altitude = 23
+	_, alt, err = ms.Position(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, alt, test.ShouldAlmostEqual, testalt+3, 0.01)
+
+	readings, err := ms.Readings(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, readings[movementsensor.PressureAltitudeCommand], test.ShouldEqual, 23.)
+	test.That(t, readings[movementsensor.VerticalSpeedCommand], test.ShouldEqual, 0.5)
+
+	// a failing barometer does not hide the other readings
+	baro.speedErr = errors.New("barometer unplugged")
+	readings, err = ms.Readings(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, readings, test.ShouldContainKey, "position")
+	test.That(t, readings, test.ShouldNotContainKey, movementsensor.PressureAltitudeCommand)
+	baro.speedErr = nil
+
+	resp, handled, err := movementsensor.DoBarometerCommand(ctx, ms,
+		map[string]interface{}{movementsensor.VerticalSpeedCommand: true})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, handled, test.ShouldBeTrue)
+	test.That(t, resp, test.ShouldResemble, map[string]interface{}{movementsensor.VerticalSpeedCommand: 0.5})
+
+	// a barometer must be found if one is configured
+	conf.ConvertedAttributes.(*Config).Barometer = []string{"notBaro"}
+	err = ms.Reconfigure(ctx, deps, conf)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "barometer not supported")
+}
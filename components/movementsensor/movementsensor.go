@@ -25,6 +25,7 @@ func init() {
 		RPCServiceHandler:           pb.RegisterMovementSensorServiceHandlerFromEndpoint,
 		RPCServiceDesc:              &pb.MovementSensorService_ServiceDesc,
 		RPCClient:                   NewClientFromConn,
+		StreamRPCServiceDesc:        &streamServiceDesc,
 	})
 	data.RegisterCollector(data.MethodMetadata{
 		API:        API,
@@ -256,6 +257,8 @@ func DefaultAPIReadings(ctx context.Context, g MovementSensor, extra map[string]
 		readings["orientation"] = ori
 	}
 
+	barometerReadings(ctx, g, readings, extra)
+
 	return readings, nil
 }
 
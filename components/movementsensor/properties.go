@@ -15,6 +15,9 @@ type Properties struct {
 	LinearVelocitySupported     bool
 	AngularVelocitySupported    bool
 	LinearAccelerationSupported bool
+	// BarometerSupported is whether the movement sensor is a Barometer. It is not part of the
+	// movement sensor proto, so clients learn it from the capabilities of the remote sensor.
+	BarometerSupported bool
 }
 
 // ProtoFeaturesToProperties takes a GetPropertiesResponse and returns
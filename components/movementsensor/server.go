@@ -7,6 +7,9 @@ import (
 	"github.com/golang/geo/r3"
 	commonpb "go.viam.com/api/common/v1"
 	pb "go.viam.com/api/component/movementsensor/v1"
+	vprotoutils "go.viam.com/utils/protoutils"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"go.viam.com/rdk/protoutils"
 	"go.viam.com/rdk/resource"
@@ -22,6 +25,30 @@ func NewRPCServiceServer(coll resource.APIResourceCollection[MovementSensor]) in
 	return &serviceServer{coll: coll}
 }
 
+// StreamReadings sends the changing readings of a movement sensor at the requested rate until the
+// client goes away.
+func (s *serviceServer) StreamReadings(req *structpb.Struct, stream grpc.ServerStream) error {
+	name, opts := readingsOptionsFromRequest(req)
+	ms, err := s.coll.Resource(name)
+	if err != nil {
+		return err
+	}
+	readings, err := StreamReadings(stream.Context(), ms, opts)
+	if err != nil {
+		return err
+	}
+	for reading := range readings {
+		resp, err := readingToResponse(reading)
+		if err != nil {
+			return err
+		}
+		if err := stream.SendMsg(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetReadings returns the most recent readings from the given Sensor.
 func (s *serviceServer) GetReadings(
 	ctx context.Context,
@@ -188,7 +215,8 @@ func (s *serviceServer) GetAccuracy(
 	return accuracyToProtoResponse(uacc)
 }
 
-// DoCommand receives arbitrary commands.
+// DoCommand receives arbitrary commands. Barometer commands are answered by movement sensors that
+// are Barometers.
 func (s *serviceServer) DoCommand(ctx context.Context,
 	req *commonpb.DoCommandRequest,
 ) (*commonpb.DoCommandResponse, error) {
@@ -196,5 +224,15 @@ func (s *serviceServer) DoCommand(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	if resp, handled, err := DoBarometerCommand(ctx, msDevice, req.GetCommand().AsMap()); handled {
+		if err != nil {
+			return nil, err
+		}
+		pbRes, err := vprotoutils.StructToStructPb(resp)
+		if err != nil {
+			return nil, err
+		}
+		return &commonpb.DoCommandResponse{Result: pbRes}, nil
+	}
 	return protoutils.DoFromResourceServer(ctx, msDevice, req)
 }
@@ -0,0 +1,302 @@
+package movementsensor
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/golang/geo/r3"
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"go.viam.com/rdk/protoutils"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// defaultStreamRate is how many readings per second StreamReadings checks when no rate is given.
+const defaultStreamRate = 10
+
+// StreamServiceName is the name of the rdk-owned gRPC service that movement sensor readings are
+// streamed on. It is not part of movementsensor.proto, so its requests and responses are structs.
+const StreamServiceName = "rdk.component.movementsensor.v1.MovementSensorStreamService"
+
+// streamReadingsMethod is the server streaming method of the stream service.
+const streamReadingsMethod = "StreamReadings"
+
+// readingsStreamServer serves the stream service.
+type readingsStreamServer interface {
+	StreamReadings(req *structpb.Struct, stream grpc.ServerStream) error
+}
+
+// streamServiceDesc describes the stream service, which the movement sensor service server also serves.
+var streamServiceDesc = grpc.ServiceDesc{
+	ServiceName: StreamServiceName,
+	HandlerType: (*readingsStreamServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    streamReadingsMethod,
+			Handler:       streamReadingsHandler,
+			ServerStreams: true,
+		},
+	},
+}
+
+// StreamReadingsOptions configures StreamReadings. A zero threshold ignores that reading
+// when deciding whether a reading has changed. If no thresholds are set, every reading is sent.
+type StreamReadingsOptions struct {
+	// Rate is the most readings per second that are read and sent. Defaults to 10.
+	Rate float64
+	// MaxInterval, if set, sends a reading at least this often even if nothing has changed
+	// by more than its threshold.
+	MaxInterval time.Duration
+
+	// OrientationDegrees is how far the orientation must rotate, in degrees.
+	OrientationDegrees float64
+	// CompassDegrees is how far the compass heading must turn, in degrees.
+	CompassDegrees float64
+	// PositionMeters is how far the position must move, in meters.
+	PositionMeters float64
+	// LinearVelocity is how much the linear velocity must change, in m/s.
+	LinearVelocity float64
+	// AngularVelocity is how much the angular velocity must change, in deg/s.
+	AngularVelocity float64
+	// LinearAcceleration is how much the linear acceleration must change, in m/s^2.
+	LinearAcceleration float64
+
+	Extra map[string]interface{}
+}
+
+// A ReadingsStreamer is a movement sensor that can stream its own readings, such as a movement
+// sensor client that streams them from the server rather than polling.
+type ReadingsStreamer interface {
+	StreamReadings(ctx context.Context, opts StreamReadingsOptions) (<-chan StreamedReading, error)
+}
+
+func (opts StreamReadingsOptions) hasThresholds() bool {
+	return opts.OrientationDegrees > 0 || opts.CompassDegrees > 0 || opts.PositionMeters > 0 ||
+		opts.LinearVelocity > 0 || opts.AngularVelocity > 0 || opts.LinearAcceleration > 0
+}
+
+// A StreamedReading is a set of readings sent by StreamReadings, or the error encountered
+// getting them.
+type StreamedReading struct {
+	Time     time.Time
+	Readings map[string]interface{}
+	Err      error
+}
+
+// StreamReadings reads from the movement sensor at up to the given rate and sends the readings
+// that have changed by more than the configured thresholds since the last reading sent, so
+// that callers do not need to poll high rate sensors themselves. The first reading is always
+// sent, as are errors. The returned channel is closed once ctx is done.
+func StreamReadings(ctx context.Context, ms MovementSensor, opts StreamReadingsOptions) (<-chan StreamedReading, error) {
+	if opts.Rate < 0 {
+		return nil, errors.New("rate cannot be negative")
+	}
+	if opts.MaxInterval < 0 {
+		return nil, errors.New("max interval cannot be negative")
+	}
+	if opts.Rate == 0 {
+		opts.Rate = defaultStreamRate
+	}
+	if streamer, ok := ms.(ReadingsStreamer); ok {
+		return streamer.StreamReadings(ctx, opts)
+	}
+	return pollReadings(ctx, ms, opts), nil
+}
+
+// pollReadings reads from the movement sensor at the rate of opts, which must already have its
+// defaults set.
+func pollReadings(ctx context.Context, ms MovementSensor, opts StreamReadingsOptions) <-chan StreamedReading {
+	interval := time.Duration(float64(time.Second) / opts.Rate)
+
+	ch := make(chan StreamedReading)
+	utils.PanicCapturingGo(func() {
+		defer close(ch)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var last map[string]interface{}
+		var lastSent time.Time
+		for {
+			readings, err := ms.Readings(ctx, opts.Extra)
+			now := time.Now()
+			send := err != nil || lastSent.IsZero() || !opts.hasThresholds() ||
+				(opts.MaxInterval > 0 && now.Sub(lastSent) >= opts.MaxInterval) ||
+				readingsChanged(last, readings, opts)
+			if send {
+				if err == nil {
+					last = readings
+				}
+				lastSent = now
+				select {
+				case <-ctx.Done():
+					return
+				case ch <- StreamedReading{Time: now, Readings: readings, Err: err}:
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+	return ch
+}
+
+func streamReadingsHandler(srv interface{}, stream grpc.ServerStream) error {
+	req := &structpb.Struct{}
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+	return srv.(readingsStreamServer).StreamReadings(req, stream)
+}
+
+// readingsRequest is the StreamReadings request for the named movement sensor.
+func readingsRequest(name string, opts StreamReadingsOptions) (*structpb.Struct, error) {
+	return structpb.NewStruct(map[string]interface{}{
+		"name":                name,
+		"rate_hz":             opts.Rate,
+		"max_interval_sec":    opts.MaxInterval.Seconds(),
+		"orientation_degs":    opts.OrientationDegrees,
+		"compass_degs":        opts.CompassDegrees,
+		"position_meters":     opts.PositionMeters,
+		"linear_velocity":     opts.LinearVelocity,
+		"angular_velocity":    opts.AngularVelocity,
+		"linear_acceleration": opts.LinearAcceleration,
+		"extra":               opts.Extra,
+	})
+}
+
+// readingsOptionsFromRequest returns the name of the movement sensor and the options of a
+// StreamReadings request.
+func readingsOptionsFromRequest(req *structpb.Struct) (string, StreamReadingsOptions) {
+	fields := req.GetFields()
+	opts := StreamReadingsOptions{
+		Rate:               fields["rate_hz"].GetNumberValue(),
+		MaxInterval:        time.Duration(fields["max_interval_sec"].GetNumberValue() * float64(time.Second)),
+		OrientationDegrees: fields["orientation_degs"].GetNumberValue(),
+		CompassDegrees:     fields["compass_degs"].GetNumberValue(),
+		PositionMeters:     fields["position_meters"].GetNumberValue(),
+		LinearVelocity:     fields["linear_velocity"].GetNumberValue(),
+		AngularVelocity:    fields["angular_velocity"].GetNumberValue(),
+		LinearAcceleration: fields["linear_acceleration"].GetNumberValue(),
+		Extra:              fields["extra"].GetStructValue().AsMap(),
+	}
+	return fields["name"].GetStringValue(), opts
+}
+
+// readingToResponse converts a reading to a StreamReadings response. Readings are sent the same
+// way as GetReadings sends them.
+func readingToResponse(reading StreamedReading) (*structpb.Struct, error) {
+	resp := &structpb.Struct{Fields: map[string]*structpb.Value{
+		"time": structpb.NewStringValue(reading.Time.Format(time.RFC3339Nano)),
+	}}
+	if reading.Err != nil {
+		resp.Fields["error"] = structpb.NewStringValue(reading.Err.Error())
+	}
+	if reading.Readings != nil {
+		readings, err := protoutils.ReadingGoToProto(reading.Readings)
+		if err != nil {
+			return nil, err
+		}
+		resp.Fields["readings"] = structpb.NewStructValue(&structpb.Struct{Fields: readings})
+	}
+	return resp, nil
+}
+
+// readingFromResponse converts a StreamReadings response back to a reading.
+func readingFromResponse(resp *structpb.Struct) StreamedReading {
+	fields := resp.GetFields()
+	var reading StreamedReading
+	var err error
+	if reading.Time, err = time.Parse(time.RFC3339Nano, fields["time"].GetStringValue()); err != nil {
+		return StreamedReading{Time: time.Now(), Err: errors.Wrap(err, "invalid reading time")}
+	}
+	if msg := fields["error"].GetStringValue(); msg != "" {
+		reading.Err = errors.New(msg)
+	}
+	if readings := fields["readings"].GetStructValue(); readings != nil {
+		if reading.Readings, err = protoutils.ReadingProtoToGo(readings.GetFields()); err != nil {
+			reading.Err = err
+		}
+	}
+	return reading
+}
+
+// readingsChanged returns whether any reading with a threshold has changed by more than it.
+func readingsChanged(prev, curr map[string]interface{}, opts StreamReadingsOptions) bool {
+	if opts.OrientationDegrees > 0 {
+		a, okA := prev["orientation"].(spatialmath.Orientation)
+		b, okB := curr["orientation"].(spatialmath.Orientation)
+		if okA != okB || (okA && orientationDegreesBetween(a, b) > opts.OrientationDegrees) {
+			return true
+		}
+	}
+	if opts.CompassDegrees > 0 {
+		a, okA := prev["compass"].(float64)
+		b, okB := curr["compass"].(float64)
+		if okA != okB || (okA && headingDegreesBetween(a, b) > opts.CompassDegrees) {
+			return true
+		}
+	}
+	if opts.PositionMeters > 0 {
+		a, okA := prev["position"].(*geo.Point)
+		b, okB := curr["position"].(*geo.Point)
+		if okA != okB || (okA && a != nil && b != nil && 1000*a.GreatCircleDistance(b) > opts.PositionMeters) {
+			return true
+		}
+	}
+	if opts.LinearVelocity > 0 && vectorChanged(prev["linear_velocity"], curr["linear_velocity"], opts.LinearVelocity) {
+		return true
+	}
+	if opts.AngularVelocity > 0 && vectorChanged(prev["angular_velocity"], curr["angular_velocity"], opts.AngularVelocity) {
+		return true
+	}
+	if opts.LinearAcceleration > 0 &&
+		vectorChanged(prev["linear_acceleration"], curr["linear_acceleration"], opts.LinearAcceleration) {
+		return true
+	}
+	return false
+}
+
+func vectorChanged(prev, curr interface{}, threshold float64) bool {
+	toVector := func(v interface{}) (r3.Vector, bool) {
+		switch v := v.(type) {
+		case r3.Vector:
+			return v, true
+		case spatialmath.AngularVelocity:
+			return r3.Vector(v), true
+		default:
+			return r3.Vector{}, false
+		}
+	}
+	a, okA := toVector(prev)
+	b, okB := toVector(curr)
+	return okA != okB || (okA && a.Sub(b).Norm() > threshold)
+}
+
+// orientationDegreesBetween returns the angle of the rotation between two orientations in degrees.
+func orientationDegreesBetween(a, b spatialmath.Orientation) float64 {
+	theta := spatialmath.QuatToR4AA(spatialmath.OrientationBetween(a, b).Quaternion()).Theta
+	deg := math.Mod(math.Abs(theta)*180/math.Pi, 360)
+	if deg > 180 {
+		deg = 360 - deg
+	}
+	return deg
+}
+
+// headingDegreesBetween returns the smallest angle between two compass headings in degrees.
+func headingDegreesBetween(a, b float64) float64 {
+	diff := math.Mod(math.Abs(a-b), 360)
+	if diff > 180 {
+		diff = 360 - diff
+	}
+	return diff
+}
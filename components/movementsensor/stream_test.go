@@ -0,0 +1,99 @@
+package movementsensor_test
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/movementsensor"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+func TestStreamReadings(t *testing.T) {
+	// each read rotates the sensor by a further degree about Z
+	var mu sync.Mutex
+	var yawDeg float64
+	ms := inject.NewMovementSensor("imu")
+	ms.ReadingsFunc = func(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		yawDeg++
+		return map[string]interface{}{
+			"orientation":     &spatialmath.EulerAngles{Yaw: yawDeg * math.Pi / 180},
+			"linear_velocity": r3.Vector{},
+		}, nil
+	}
+
+	readN := func(ch <-chan movementsensor.StreamedReading, n int) []movementsensor.StreamedReading {
+		var out []movementsensor.StreamedReading
+		for len(out) < n {
+			select {
+			case r := <-ch:
+				test.That(t, r.Err, test.ShouldBeNil)
+				out = append(out, r)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for readings")
+			}
+		}
+		return out
+	}
+
+	// drain waits for the stream to stop so that it no longer reads from the sensor.
+	drain := func(ch <-chan movementsensor.StreamedReading) {
+		for {
+			if _, ok := <-ch; !ok {
+				return
+			}
+		}
+	}
+
+	t.Run("no thresholds", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ch, err := movementsensor.StreamReadings(ctx, ms, movementsensor.StreamReadingsOptions{Rate: 1000})
+		test.That(t, err, test.ShouldBeNil)
+		readings := readN(ch, 3)
+		test.That(t, readings[0].Time.Before(readings[2].Time), test.ShouldBeTrue)
+
+		cancel()
+		drain(ch)
+	})
+
+	t.Run("orientation threshold", func(t *testing.T) {
+		mu.Lock()
+		yawDeg = 0
+		mu.Unlock()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ch, err := movementsensor.StreamReadings(ctx, ms, movementsensor.StreamReadingsOptions{
+			Rate:               1000,
+			OrientationDegrees: 4.5,
+		})
+		test.That(t, err, test.ShouldBeNil)
+
+		// the first reading is always sent and then only every fifth degree of rotation
+		readings := readN(ch, 3)
+		yaws := make([]float64, 0, len(readings))
+		for _, r := range readings {
+			yaws = append(yaws, r.Readings["orientation"].(*spatialmath.EulerAngles).Yaw*180/math.Pi)
+		}
+		test.That(t, yaws[0], test.ShouldAlmostEqual, 1)
+		test.That(t, yaws[1], test.ShouldAlmostEqual, 6)
+		test.That(t, yaws[2], test.ShouldAlmostEqual, 11)
+
+		cancel()
+		drain(ch)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := movementsensor.StreamReadings(context.Background(), ms, movementsensor.StreamReadingsOptions{Rate: -1})
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
@@ -463,31 +463,39 @@ func (o *odometry) trackPosition() {
 func (o *odometry) DoCommand(ctx context.Context,
 	req map[string]interface{},
 ) (map[string]interface{}, error) {
+	// every command is read before any is carried out, so that a malformed request changes nothing
+	r := utils.NewAttributeReader(req)
+	compass := utils.Optional[*bool](r, useCompass, nil)
+	reset := utils.Optional[*bool](r, resetShift, nil)
+	lat := utils.Optional[*float64](r, setLat, nil)
+	long := utils.Optional[*float64](r, setLong, nil)
+	xMove := utils.Optional[*float64](r, moveX, nil)
+	yMove := utils.Optional[*float64](r, moveY, nil)
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+
 	resp := make(map[string]interface{})
 
 	o.mu.Lock()
 	defer o.mu.Unlock()
-	cmd, ok := req[useCompass].(bool)
-	if ok {
-		o.useCompass = cmd
-		resp[useCompass] = fmt.Sprintf("using orientation as compass heading set to %v", cmd)
+	if compass != nil {
+		o.useCompass = *compass
+		resp[useCompass] = fmt.Sprintf("using orientation as compass heading set to %v", *compass)
 	}
 
-	reset, ok := req[resetShift].(bool)
-	if ok {
-		o.shiftPos = reset
+	if reset != nil {
+		o.shiftPos = *reset
 		o.originCoord = geo.NewPoint(0, 0)
 		o.coord = geo.NewPoint(0, 0)
 		o.position.X = 0
 		o.position.Y = 0
 		o.orientation.Yaw = 0
 
-		resp[resetShift] = fmt.Sprintf("resetting position and setting shift to %v", reset)
+		resp[resetShift] = fmt.Sprintf("resetting position and setting shift to %v", *reset)
 	}
-	lat, okY := req[setLat].(float64)
-	long, okX := req[setLong].(float64)
-	if okY && okX {
-		o.originCoord = geo.NewPoint(lat, long)
+	if lat != nil && long != nil {
+		o.originCoord = geo.NewPoint(*lat, *long)
 		o.shiftPos = true
 		o.coordUpToDate.Store(false)
 		o.mu.Unlock()
@@ -502,22 +510,20 @@ func (o *odometry) DoCommand(ctx context.Context,
 		}
 		o.mu.Lock()
 
-		resp[setLat] = fmt.Sprintf("lat shifted to %.8f", lat)
-		resp[setLong] = fmt.Sprintf("lng shifted to %.8f", long)
-	} else if okY || okX {
+		resp[setLat] = fmt.Sprintf("lat shifted to %.8f", *lat)
+		resp[setLong] = fmt.Sprintf("lng shifted to %.8f", *long)
+	} else if lat != nil || long != nil {
 		// If only one value is given, return an error.
 		// This prevents errors when neither is given.
 		resp["bad shift"] = "need both lat and long shifts"
 	}
 
-	xMove, okX := req[moveX].(float64)
-	yMove, okY := req[moveY].(float64)
-	if okX {
-		o.position.X += xMove
+	if xMove != nil {
+		o.position.X += *xMove
 		resp[moveX] = fmt.Sprintf("x position moved to %.8f", o.position.X)
 	}
-	if okY {
-		o.position.Y += yMove
+	if yMove != nil {
+		o.position.Y += *yMove
 		resp[moveY] = fmt.Sprintf("y position shifted to %.8f", o.position.Y)
 	}
 
@@ -11,6 +11,7 @@ import (
 	_ "go.viam.com/rdk/components/encoder"
 	_ "go.viam.com/rdk/components/gantry"
 	_ "go.viam.com/rdk/components/generic"
+	_ "go.viam.com/rdk/components/gimbal"
 	_ "go.viam.com/rdk/components/gripper"
 	_ "go.viam.com/rdk/components/input"
 	_ "go.viam.com/rdk/components/motor"
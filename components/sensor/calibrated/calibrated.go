@@ -0,0 +1,230 @@
+// Package calibrated implements a sensor that corrects the readings of another resource.
+//
+// A calibrated sensor reads its source and applies a calibration to each configured reading,
+// either a scale and offset or a polynomial, so that a miscalibrated probe can be corrected in
+// config rather than in its firmware. Readings without a calibration are passed through as is.
+package calibrated
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+)
+
+// Model is the model of calibrated sensors.
+var Model = resource.DefaultModelFamily.WithModel("calibrated")
+
+// RawKey is the readings key that holds the uncalibrated values of the calibrated readings
+// when IncludeRaw is set.
+const RawKey = "_raw"
+
+// Calibration corrects a reading. A polynomial, if given, replaces the scale and offset.
+type Calibration struct {
+	// Scale multiplies the reading, and defaults to 1.
+	Scale *float64 `json:"scale,omitempty"`
+	// Offset is added to the reading after it is scaled.
+	Offset float64 `json:"offset,omitempty"`
+	// Polynomial are the coefficients of the calibrated value in powers of the reading, lowest
+	// first, so [1, 2, 3] is 1 + 2x + 3x².
+	Polynomial []float64 `json:"polynomial,omitempty"`
+}
+
+// apply returns the calibrated value of a reading.
+func (c Calibration) apply(x float64) float64 {
+	if len(c.Polynomial) != 0 {
+		// Horner's method
+		y := 0.0
+		for i := len(c.Polynomial) - 1; i >= 0; i-- {
+			y = y*x + c.Polynomial[i]
+		}
+		return y
+	}
+	scale := 1.0
+	if c.Scale != nil {
+		scale = *c.Scale
+	}
+	return x*scale + c.Offset
+}
+
+// Config is used for converting config attributes.
+type Config struct {
+	// Source is the name of the resource to calibrate.
+	Source string `json:"source"`
+	// SourceAPI is the API of the source, which defaults to the sensor API. Any resource whose
+	// API has Readings, like movement and power sensors, can be calibrated.
+	SourceAPI string `json:"source_api,omitempty"`
+	// Calibrations are keyed by the name of the reading to correct. Readings nested in maps are
+	// named by their path, like "probe.celsius".
+	Calibrations map[string]Calibration `json:"calibrations"`
+	// IncludeRaw is whether the uncalibrated values are also returned, under RawKey.
+	IncludeRaw bool `json:"include_raw,omitempty"`
+}
+
+// Validate ensures all parts of the config are valid.
+func (cfg *Config) Validate(path string) ([]string, []string, error) {
+	if cfg.Source == "" {
+		return nil, nil, resource.NewConfigValidationFieldRequiredError(path, "source")
+	}
+	if cfg.SourceAPI != "" {
+		if _, err := resource.NewAPIFromString(cfg.SourceAPI); err != nil {
+			return nil, nil, resource.NewConfigValidationError(path, err)
+		}
+	}
+	if len(cfg.Calibrations) == 0 {
+		return nil, nil, resource.NewConfigValidationFieldRequiredError(path, "calibrations")
+	}
+	for key, c := range cfg.Calibrations {
+		if key == "" || strings.HasPrefix(key, ".") || strings.HasSuffix(key, ".") {
+			return nil, nil, resource.NewConfigValidationError(path, errors.Errorf("invalid reading name %q", key))
+		}
+		if len(c.Polynomial) != 0 && (c.Scale != nil || c.Offset != 0) {
+			return nil, nil, resource.NewConfigValidationError(path,
+				errors.Errorf("calibration of %q can have a polynomial or a scale and offset, not both", key))
+		}
+	}
+	return []string{cfg.Source}, nil, nil
+}
+
+func init() {
+	resource.RegisterComponent(sensor.API, Model, resource.Registration[sensor.Sensor, *Config]{
+		Constructor: newCalibrated,
+	})
+}
+
+// sensorResource is a resource that has readings.
+type sensorResource interface {
+	resource.Resource
+	resource.Sensor
+}
+
+type calibrated struct {
+	resource.Named
+	resource.AlwaysRebuild
+	resource.TriviallyCloseable
+
+	source       sensorResource
+	calibrations map[string]Calibration
+	includeRaw   bool
+}
+
+func newCalibrated(
+	ctx context.Context,
+	deps resource.Dependencies,
+	conf resource.Config,
+	logger logging.Logger,
+) (sensor.Sensor, error) {
+	newConf, err := resource.NativeConfig[*Config](conf)
+	if err != nil {
+		return nil, err
+	}
+	api := sensor.API
+	if newConf.SourceAPI != "" {
+		if api, err = resource.NewAPIFromString(newConf.SourceAPI); err != nil {
+			return nil, err
+		}
+	}
+	res, err := deps.Lookup(resource.NewName(api, newConf.Source))
+	if err != nil {
+		return nil, err
+	}
+	source, ok := res.(sensorResource)
+	if !ok {
+		return nil, errors.Errorf("source %q does not have readings", newConf.Source)
+	}
+	return &calibrated{
+		Named:        conf.ResourceName().AsNamed(),
+		source:       source,
+		calibrations: newConf.Calibrations,
+		includeRaw:   newConf.IncludeRaw,
+	}, nil
+}
+
+// Readings returns the readings of the source with the calibrations applied. A calibrated reading
+// that the source did not return is left out, but one that is not a number is an error.
+func (c *calibrated) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	readings, err := c.source.Readings(ctx, extra)
+	if err != nil {
+		return nil, err
+	}
+	readings = copyReadings(readings)
+	raw := map[string]interface{}{}
+	for key, calibration := range c.calibrations {
+		parent, name, ok := lookupReading(readings, key)
+		if !ok {
+			continue
+		}
+		x, ok := toFloat64(parent[name])
+		if !ok {
+			return nil, errors.Errorf("reading %q is %T, which cannot be calibrated", key, parent[name])
+		}
+		raw[key] = x
+		parent[name] = calibration.apply(x)
+	}
+	if c.includeRaw {
+		readings[RawKey] = raw
+	}
+	return readings, nil
+}
+
+// DoCommand passes commands through to the source.
+func (c *calibrated) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	return c.source.DoCommand(ctx, cmd)
+}
+
+// copyReadings copies the maps of readings, so that calibrating them does not change what the
+// source may have cached.
+func copyReadings(readings map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(readings))
+	for k, v := range readings {
+		if m, ok := v.(map[string]interface{}); ok {
+			v = copyReadings(m)
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// lookupReading returns the map that holds the reading at a dotted path, and its key in it.
+func lookupReading(readings map[string]interface{}, path string) (map[string]interface{}, string, bool) {
+	parts := strings.Split(path, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := readings[part].(map[string]interface{})
+		if !ok {
+			return nil, "", false
+		}
+		readings = next
+	}
+	name := parts[len(parts)-1]
+	if _, ok := readings[name]; !ok {
+		return nil, "", false
+	}
+	return readings, name, true
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,88 @@
+package calibrated
+
+import (
+	"context"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+func TestCalibrated(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	sourceReadings := map[string]interface{}{
+		"celsius":  20.0,
+		"humidity": int64(40),
+		"probe":    map[string]interface{}{"ph": 7.5},
+		"status":   "ok",
+	}
+	source := inject.NewSensor("probe")
+	source.ReadingsFunc = func(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+		return sourceReadings, nil
+	}
+	deps := resource.Dependencies{sensor.Named("probe"): source}
+
+	scale := 1.8
+	cfg := &Config{
+		Source: "probe",
+		Calibrations: map[string]Calibration{
+			"celsius":  {Scale: &scale, Offset: 32},
+			"humidity": {Offset: -2.5},
+			"probe.ph": {Polynomial: []float64{0.5, 1, 0.01}},
+			"missing":  {Offset: 1},
+		},
+		IncludeRaw: true,
+	}
+	deps2, _, err := cfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, deps2, test.ShouldResemble, []string{"probe"})
+
+	s, err := newCalibrated(ctx, deps, resource.Config{
+		Name:                "calibrated-probe",
+		API:                 sensor.API,
+		Model:               Model,
+		ConvertedAttributes: cfg,
+	}, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	readings, err := s.Readings(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, readings["celsius"], test.ShouldAlmostEqual, 68.0)
+	test.That(t, readings["humidity"], test.ShouldAlmostEqual, 37.5)
+	test.That(t, readings["probe"].(map[string]interface{})["ph"], test.ShouldAlmostEqual, 0.5+7.5+0.01*7.5*7.5)
+	test.That(t, readings["status"], test.ShouldEqual, "ok")
+	test.That(t, readings, test.ShouldNotContainKey, "missing")
+	test.That(t, readings[RawKey], test.ShouldResemble, map[string]interface{}{
+		"celsius":  20.0,
+		"humidity": 40.0,
+		"probe.ph": 7.5,
+	})
+
+	// the source's readings are not changed
+	test.That(t, sourceReadings["celsius"], test.ShouldEqual, 20.0)
+	test.That(t, sourceReadings["probe"].(map[string]interface{})["ph"], test.ShouldEqual, 7.5)
+
+	// a calibrated reading that is not a number is an error
+	sourceReadings = map[string]interface{}{"celsius": "hot"}
+	_, err = s.Readings(ctx, nil)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "celsius")
+
+	t.Run("invalid config", func(t *testing.T) {
+		for _, cfg := range []*Config{
+			{Calibrations: map[string]Calibration{"a": {}}},
+			{Source: "probe"},
+			{Source: "probe", Calibrations: map[string]Calibration{"a.": {}}},
+			{Source: "probe", Calibrations: map[string]Calibration{"a": {Offset: 1, Polynomial: []float64{1}}}},
+		} {
+			_, _, err := cfg.Validate("path")
+			test.That(t, err, test.ShouldNotBeNil)
+		}
+	})
+}
@@ -3,12 +3,16 @@ package sensor
 
 import (
 	"context"
+	"time"
 
 	commonpb "go.viam.com/api/common/v1"
 	pb "go.viam.com/api/component/sensor/v1"
 	"go.viam.com/utils/rpc"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/structpb"
 
+	"go.viam.com/rdk/grpc"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/protoutils"
 	"go.viam.com/rdk/resource"
@@ -22,6 +26,8 @@ type client struct {
 	name   string
 	client pb.SensorServiceClient
 	logger logging.Logger
+
+	clockOffset grpc.ClockOffsetEstimator
 }
 
 // NewClientFromConn constructs a new Client from connection passed in.
@@ -46,17 +52,30 @@ func (c *client) Readings(ctx context.Context, extra map[string]interface{}) (ma
 	if err != nil {
 		return nil, err
 	}
+	var header metadata.MD
+	sent := time.Now()
 	resp, err := c.client.GetReadings(ctx, &commonpb.GetReadingsRequest{
 		Name:  c.name,
 		Extra: ext,
-	})
+	}, googlegrpc.Header(&header))
 	if err != nil {
 		return nil, err
 	}
+	received := time.Now()
 
+	if offset, ok := grpc.ClockOffsetFromHeader(header, sent, received); ok {
+		c.clockOffset.AddSample(offset)
+	}
 	return protoutils.ReadingProtoToGo(resp.Readings)
 }
 
+// ClockOffset returns how far the clock of the machine serving the sensor is ahead of ours, as
+// estimated from the times it reports on readings responses, so that times in readings can be
+// converted to the local clock.
+func (c *client) ClockOffset() (grpc.ClockOffset, bool) {
+	return c.clockOffset.Estimate()
+}
+
 func (c *client) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
 	return protoutils.DoFromResourceClient(ctx, c.client, c.name, cmd)
 }
@@ -26,7 +26,8 @@ func TestClient(t *testing.T) {
 	logger := logging.NewTestLogger(t)
 	listener1, err := net.Listen("tcp", "localhost:0")
 	test.That(t, err, test.ShouldBeNil)
-	rpcServer, err := rpc.NewServer(logger, rpc.WithUnauthenticated())
+	rpcServer, err := rpc.NewServer(logger, rpc.WithUnauthenticated(),
+		rpc.WithUnaryServerInterceptor(viamgrpc.ServerTimesUnaryServerInterceptor))
 	test.That(t, err, test.ShouldBeNil)
 
 	rs := map[string]interface{}{"a": 1.1, "b": 2.2}
@@ -92,6 +93,14 @@ func TestClient(t *testing.T) {
 		test.That(t, rs1, test.ShouldResemble, rs)
 		test.That(t, extraCap, test.ShouldResemble, map[string]interface{}{"foo": "bar"})
 
+		// the clock offset of the server is kept by the client rather than added to the readings
+		offsetProvider, ok := sensor1Client.(viamgrpc.ClockOffsetProvider)
+		test.That(t, ok, test.ShouldBeTrue)
+		offset, ok := offsetProvider.ClockOffset()
+		test.That(t, ok, test.ShouldBeTrue)
+		// both ends share a clock, so the offset is within the measurement error
+		test.That(t, offset.Offset.Abs(), test.ShouldBeLessThanOrEqualTo, offset.RoundTrip)
+
 		test.That(t, sensor1Client.Close(context.Background()), test.ShouldBeNil)
 		test.That(t, conn.Close(), test.ShouldBeNil)
 	})
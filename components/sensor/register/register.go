@@ -3,5 +3,7 @@ package register
 
 import (
 	// for Sensors.
+	_ "go.viam.com/rdk/components/sensor/calibrated"
 	_ "go.viam.com/rdk/components/sensor/fake"
+	_ "go.viam.com/rdk/components/sensor/shadow"
 )
@@ -0,0 +1,215 @@
+// Package shadow implements a sensor that mirrors the readings of a resource on another machine.
+//
+// A shadow sensor polls its source, usually a sensor on a remote, and serves the last readings
+// it got, so that a coordinator machine can read the state of a fleet without a round trip per
+// read and keep serving the last known state while a machine is unreachable. Every reading
+// includes, under ShadowKey, when it was replicated and whether it is stale.
+package shadow
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	goutils "go.viam.com/utils"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+)
+
+// Model is the model of shadow sensors.
+var Model = resource.DefaultModelFamily.WithModel("shadow")
+
+// ShadowKey is the readings key that holds the replication metadata of a shadow sensor.
+const ShadowKey = "_shadow"
+
+const defaultRefreshInterval = time.Second
+
+// Config is used for converting config attributes.
+type Config struct {
+	// Source is the name of the resource to mirror, like "machine-a:sensor1".
+	Source string `json:"source"`
+	// SourceAPI is the API of the source, which defaults to the sensor API. Any resource whose
+	// API has Readings, like movement and power sensors, can be mirrored.
+	SourceAPI string `json:"source_api,omitempty"`
+	// RefreshIntervalSec is how often the source is polled, which defaults to one second.
+	RefreshIntervalSec float64 `json:"refresh_interval_sec,omitempty"`
+	// MaxStalenessSec, if set, is how old readings can be before they are marked stale. It
+	// defaults to three refresh intervals.
+	MaxStalenessSec float64 `json:"max_staleness_sec,omitempty"`
+}
+
+// Validate ensures all parts of the config are valid.
+func (cfg *Config) Validate(path string) ([]string, []string, error) {
+	if cfg.Source == "" {
+		return nil, nil, resource.NewConfigValidationFieldRequiredError(path, "source")
+	}
+	if cfg.SourceAPI != "" {
+		if _, err := resource.NewAPIFromString(cfg.SourceAPI); err != nil {
+			return nil, nil, resource.NewConfigValidationError(path, err)
+		}
+	}
+	if cfg.RefreshIntervalSec < 0 {
+		return nil, nil, resource.NewConfigValidationError(path, errors.New("refresh_interval_sec cannot be negative"))
+	}
+	if cfg.MaxStalenessSec < 0 {
+		return nil, nil, resource.NewConfigValidationError(path, errors.New("max_staleness_sec cannot be negative"))
+	}
+	return []string{cfg.Source}, nil, nil
+}
+
+func init() {
+	resource.RegisterComponent(sensor.API, Model, resource.Registration[sensor.Sensor, *Config]{
+		Constructor: newShadow,
+	})
+}
+
+type shadow struct {
+	resource.Named
+	resource.AlwaysRebuild
+	logger logging.Logger
+
+	sourceName      resource.Name
+	source          resource.Sensor
+	refreshInterval time.Duration
+	maxStaleness    time.Duration
+	workers         *goutils.StoppableWorkers
+
+	mu        sync.Mutex
+	readings  map[string]interface{}
+	updatedAt time.Time
+	lastErr   error
+}
+
+func newShadow(
+	ctx context.Context,
+	deps resource.Dependencies,
+	conf resource.Config,
+	logger logging.Logger,
+) (sensor.Sensor, error) {
+	newConf, err := resource.NativeConfig[*Config](conf)
+	if err != nil {
+		return nil, err
+	}
+	api := sensor.API
+	if newConf.SourceAPI != "" {
+		if api, err = resource.NewAPIFromString(newConf.SourceAPI); err != nil {
+			return nil, err
+		}
+	}
+	sourceName := resource.NewName(api, newConf.Source)
+	res, err := deps.Lookup(sourceName)
+	if err != nil {
+		return nil, err
+	}
+	source, ok := res.(resource.Sensor)
+	if !ok {
+		return nil, errors.Errorf("source %q does not have readings", newConf.Source)
+	}
+
+	s := &shadow{
+		Named:           conf.ResourceName().AsNamed(),
+		logger:          logger,
+		sourceName:      sourceName,
+		source:          source,
+		refreshInterval: defaultRefreshInterval,
+	}
+	if newConf.RefreshIntervalSec > 0 {
+		s.refreshInterval = time.Duration(newConf.RefreshIntervalSec * float64(time.Second))
+	}
+	s.maxStaleness = 3 * s.refreshInterval
+	if newConf.MaxStalenessSec > 0 {
+		s.maxStaleness = time.Duration(newConf.MaxStalenessSec * float64(time.Second))
+	}
+	s.workers = goutils.NewBackgroundStoppableWorkers(s.replicate)
+	return s, nil
+}
+
+// replicate polls the source until the shadow is closed.
+func (s *shadow) replicate(ctx context.Context) {
+	ticker := time.NewTicker(s.refreshInterval)
+	defer ticker.Stop()
+	for {
+		s.refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *shadow) refresh(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, s.refreshInterval)
+	defer cancel()
+	readings, err := s.source.Readings(ctx, nil)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		if ctx.Err() == nil && s.lastErr == nil {
+			s.logger.CWarnw(ctx, "failed to replicate readings; keeping the last ones", "error", err)
+		}
+		s.lastErr = err
+		return
+	}
+	s.readings = readings
+	s.updatedAt = time.Now()
+	s.lastErr = nil
+}
+
+// Readings returns the last readings replicated from the source along with when they were
+// replicated.
+func (s *shadow) Readings(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.updatedAt.IsZero() {
+		if s.lastErr != nil {
+			return nil, errors.Wrap(s.lastErr, "no readings replicated yet")
+		}
+		return nil, errors.New("no readings replicated yet")
+	}
+
+	readings := make(map[string]interface{}, len(s.readings)+1)
+	for k, v := range s.readings {
+		readings[k] = v
+	}
+	readings[ShadowKey] = s.metadata()
+	return readings, nil
+}
+
+// metadata describes how fresh the replicated readings are. It must be called with mu held.
+func (s *shadow) metadata() map[string]interface{} {
+	age := time.Since(s.updatedAt)
+	md := map[string]interface{}{
+		"source":     s.sourceName.String(),
+		"updated_at": s.updatedAt.UTC().Format(time.RFC3339Nano),
+		"age_sec":    age.Seconds(),
+		"stale":      age > s.maxStaleness,
+	}
+	if s.lastErr != nil {
+		md["last_error"] = s.lastErr.Error()
+	}
+	return md
+}
+
+// DoCommand returns the replication metadata for {"status": true}.
+func (s *shadow) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	if _, ok := cmd["status"]; !ok {
+		return nil, resource.ErrDoUnimplemented
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.updatedAt.IsZero() {
+		return map[string]interface{}{"stale": true}, nil
+	}
+	return s.metadata(), nil
+}
+
+// Close stops replicating readings.
+func (s *shadow) Close(ctx context.Context) error {
+	s.workers.Stop()
+	return nil
+}
@@ -0,0 +1,84 @@
+package shadow
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+func TestShadow(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	var failing atomic.Bool
+	var count atomic.Int64
+	source := inject.NewSensor("machine-a:temp")
+	source.ReadingsFunc = func(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+		if failing.Load() {
+			return nil, errors.New("machine-a is unreachable")
+		}
+		return map[string]interface{}{"celsius": 21.5, "count": count.Add(1)}, nil
+	}
+	deps := resource.Dependencies{sensor.Named("machine-a:temp"): source}
+
+	conf := resource.Config{
+		Name:                "temp-shadow",
+		API:                 sensor.API,
+		Model:               Model,
+		ConvertedAttributes: &Config{Source: "machine-a:temp", RefreshIntervalSec: 0.01, MaxStalenessSec: 0.05},
+	}
+	s, err := newShadow(ctx, deps, conf, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, s.Close(ctx), test.ShouldBeNil)
+	}()
+
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		readings, err := s.Readings(ctx, nil)
+		test.That(tb, err, test.ShouldBeNil)
+		test.That(tb, readings["celsius"], test.ShouldEqual, 21.5)
+		test.That(tb, readings["count"], test.ShouldBeGreaterThan, 1)
+		md, _ := readings[ShadowKey].(map[string]interface{})
+		test.That(tb, md["source"], test.ShouldEqual, sensor.Named("machine-a:temp").String())
+		test.That(tb, md["stale"], test.ShouldBeFalse)
+	})
+
+	// the last readings are served, marked stale, while the source is unreachable
+	failing.Store(true)
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		readings, err := s.Readings(ctx, nil)
+		test.That(tb, err, test.ShouldBeNil)
+		test.That(tb, readings["celsius"], test.ShouldEqual, 21.5)
+		md, _ := readings[ShadowKey].(map[string]interface{})
+		test.That(tb, md["stale"], test.ShouldBeTrue)
+		test.That(tb, md["last_error"], test.ShouldEqual, "machine-a is unreachable")
+	})
+
+	failing.Store(false)
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		status, err := s.DoCommand(ctx, map[string]interface{}{"status": true})
+		test.That(tb, err, test.ShouldBeNil)
+		test.That(tb, status["stale"], test.ShouldBeFalse)
+		test.That(tb, status["last_error"], test.ShouldBeNil)
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		deps, _, err := (&Config{Source: "machine-a:temp"}).Validate("path")
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, deps, test.ShouldResemble, []string{"machine-a:temp"})
+
+		_, _, err = (&Config{}).Validate("path")
+		test.That(t, err, test.ShouldNotBeNil)
+		_, _, err = (&Config{Source: "temp", SourceAPI: "not-an-api"}).Validate("path")
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,226 @@
+package config
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	goutils "go.viam.com/utils"
+	"go.viam.com/utils/rpc"
+	"golang.org/x/net/proxy"
+	"google.golang.org/grpc"
+)
+
+// cloudProxySchemes are the URL schemes the cloud config's proxy can have.
+var cloudProxySchemes = []string{"http", "https", "socks5"}
+
+// proxyURL returns the cloud config's proxy, or nil if it has none.
+func (config *Cloud) proxyURL() (*url.URL, error) {
+	if config.Proxy == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(config.Proxy)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid proxy")
+	}
+	if !slices.Contains(cloudProxySchemes, u.Scheme) || u.Host == "" {
+		return nil, errors.Errorf("proxy must be a URL like http://host:port or socks5://host:port, not %q", config.Proxy)
+	}
+	if u.Scheme == "socks5" && u.User != nil {
+		return nil, errors.New("socks5 proxy credentials are not supported")
+	}
+	return u, nil
+}
+
+// TLSConfig returns the TLS config for connections to the cloud, which trusts the certificates
+// in CACertFile in addition to the system's. It is nil if there is no CACertFile.
+func (config *Cloud) TLSConfig() (*tls.Config, error) {
+	if config.CACertFile == "" {
+		return nil, nil
+	}
+	certs, err := os.ReadFile(config.CACertFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading ca_cert_file")
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(certs) {
+		return nil, errors.Errorf("no PEM certificates found in ca_cert_file %q", config.CACertFile)
+	}
+	return &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: pool}, nil
+}
+
+// DialOptions returns the options that gRPC connections to the cloud, including to signaling,
+// need to trust the cloud config's CA certificates. Their proxy is set by dialing them with a
+// context from ContextWithProxy.
+func (config *Cloud) DialOptions() ([]rpc.DialOption, error) {
+	tlsConfig, err := config.TLSConfig()
+	if err != nil || tlsConfig == nil {
+		return nil, err
+	}
+	return []rpc.DialOption{rpc.WithTLSConfig(tlsConfig)}, nil
+}
+
+// HTTPTransport returns a transport for HTTP requests to the cloud, such as package downloads,
+// that goes through the cloud config's proxy and trusts its CA certificates.
+func (config *Cloud) HTTPTransport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	proxyURL, err := config.proxyURL()
+	if err != nil {
+		return nil, err
+	}
+	if proxyURL != nil {
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	tlsConfig, err := config.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	return transport, nil
+}
+
+// ContextWithProxy returns ctx with a dialer that makes the gRPC connections dialed with it, such as
+// those to the app and to signaling, go through the cloud config's proxy. Other connections of the
+// process are not affected. ctx is returned as is if there is no proxy.
+func (config *Cloud) ContextWithProxy(ctx context.Context) (context.Context, error) {
+	proxyURL, err := config.proxyURL()
+	if err != nil || proxyURL == nil {
+		return ctx, err
+	}
+	tlsConfig, err := config.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	return rpc.ContextWithDialer(ctx, &proxyDialer{proxyURL: proxyURL, tlsConfig: tlsConfig}), nil
+}
+
+// A proxyDialer is an rpc.Dialer that dials gRPC connections through a proxy.
+type proxyDialer struct {
+	proxyURL *url.URL
+	// tlsConfig is used to connect to https proxies, and may be nil.
+	tlsConfig *tls.Config
+}
+
+func (d *proxyDialer) DialDirect(
+	ctx context.Context,
+	target string,
+	keyExtra string,
+	onClose func() error,
+	opts ...grpc.DialOption,
+) (rpc.ClientConn, bool, error) {
+	opts = append(opts, grpc.WithContextDialer(d.dial))
+	//nolint:staticcheck
+	conn, err := grpc.DialContext(ctx, target, opts...)
+	if err != nil {
+		return nil, false, err
+	}
+	return &proxiedClientConn{ClientConn: rpc.GrpcOverHTTPClientConn{ClientConn: conn}, onClose: onClose}, false, nil
+}
+
+func (d *proxyDialer) DialFunc(
+	proto string,
+	target string,
+	keyExtra string,
+	dialNew func() (rpc.ClientConn, func() error, error),
+) (rpc.ClientConn, bool, error) {
+	conn, onClose, err := dialNew()
+	if err != nil {
+		return nil, false, err
+	}
+	return &proxiedClientConn{ClientConn: conn, onClose: onClose}, false, nil
+}
+
+func (d *proxyDialer) Close() error {
+	return nil
+}
+
+// dial connects to addr through the proxy.
+func (d *proxyDialer) dial(ctx context.Context, addr string) (net.Conn, error) {
+	if d.proxyURL.Scheme == "socks5" {
+		dialer, err := proxy.SOCKS5("tcp", d.proxyURL.Host, nil, proxy.Direct)
+		if err != nil {
+			return nil, err
+		}
+		//nolint:forcetypeassert
+		return dialer.(proxy.ContextDialer).DialContext(ctx, "tcp", addr)
+	}
+
+	var netDialer net.Dialer
+	conn, err := netDialer.DialContext(ctx, "tcp", d.proxyURL.Host)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, multierr.Combine(err, conn.Close())
+		}
+	}
+	if d.proxyURL.Scheme == "https" {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if d.tlsConfig != nil {
+			tlsConfig = d.tlsConfig.Clone()
+		}
+		tlsConfig.ServerName = d.proxyURL.Hostname()
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return nil, multierr.Combine(err, conn.Close())
+		}
+		conn = tlsConn
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if user := d.proxyURL.User; user != nil {
+		password, _ := user.Password()
+		auth := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+auth)
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, multierr.Combine(err, conn.Close())
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		return nil, multierr.Combine(err, conn.Close())
+	}
+	goutils.UncheckedError(resp.Body.Close())
+	if resp.StatusCode != http.StatusOK {
+		return nil, multierr.Combine(errors.Errorf("proxy refused to connect to %s: %s", addr, resp.Status), conn.Close())
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		return nil, multierr.Combine(err, conn.Close())
+	}
+	return conn, nil
+}
+
+// A proxiedClientConn is a connection dialed by a proxyDialer, which runs onClose once it is closed.
+type proxiedClientConn struct {
+	rpc.ClientConn
+	onClose func() error
+}
+
+func (cc *proxiedClientConn) Close() error {
+	err := cc.ClientConn.Close()
+	if cc.onClose != nil {
+		err = multierr.Combine(err, cc.onClose())
+	}
+	return err
+}
@@ -0,0 +1,289 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/resource"
+)
+
+// A DisabledResource is a resource left out of the machine because its enabled condition is
+// false, or could not be evaluated.
+type DisabledResource struct {
+	Name      resource.Name
+	Condition string
+	// Error is why the condition could not be evaluated, if it could not be.
+	Error error
+}
+
+// excludeDisabledResources removes the components and services whose enabled condition is
+// false from the config, and records them in DisabledResources. Resources whose condition
+// cannot be evaluated are disabled as well, since it is safer to leave them off.
+func (c *Config) excludeDisabledResources() {
+	c.DisabledResources = nil
+	exclude := func(confs []resource.Config) []resource.Config {
+		kept := confs[:0]
+		for _, conf := range confs {
+			if conf.Enabled == "" {
+				kept = append(kept, conf)
+				continue
+			}
+			enabled, err := EvaluateCondition(conf.Enabled, c.Variables)
+			if err == nil && enabled {
+				kept = append(kept, conf)
+				continue
+			}
+			c.DisabledResources = append(c.DisabledResources, DisabledResource{
+				Name:      conf.ResourceName(),
+				Condition: conf.Enabled,
+				Error:     err,
+			})
+		}
+		return kept
+	}
+	c.Components = exclude(c.Components)
+	c.Services = exclude(c.Services)
+}
+
+// EvaluateCondition evaluates an enabled condition, like `region == "arctic" && !indoors`.
+// Conditions compare values with == and != and combine them with &&, || and !. Values are
+// quoted strings, numbers, true, false, null, variables like variables.region or just region,
+// and environment variables like environment.HOME. Unset variables are null, and a condition
+// that is null is false.
+func EvaluateCondition(condition string, variables map[string]interface{}) (bool, error) {
+	p := &conditionParser{input: condition, variables: variables}
+	value, err := p.parseOr()
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid condition %q", condition)
+	}
+	p.skipSpace()
+	if p.pos != len(p.input) {
+		return false, errors.Errorf("invalid condition %q: unexpected %q", condition, p.input[p.pos:])
+	}
+	return conditionTruth(value, condition)
+}
+
+func conditionTruth(value interface{}, condition string) (bool, error) {
+	switch v := value.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	default:
+		return false, errors.Errorf("condition %q is %v, not true or false", condition, v)
+	}
+}
+
+// conditionParser is a recursive descent parser that evaluates a condition as it parses it.
+type conditionParser struct {
+	input     string
+	pos       int
+	variables map[string]interface{}
+}
+
+func (p *conditionParser) skipSpace() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// consume skips past op if it is next in the input.
+func (p *conditionParser) consume(op string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.input[p.pos:], op) {
+		p.pos += len(op)
+		return true
+	}
+	return false
+}
+
+func (p *conditionParser) parseOr() (interface{}, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l, err := conditionTruth(left, p.input)
+		if err != nil {
+			return nil, err
+		}
+		r, err := conditionTruth(right, p.input)
+		if err != nil {
+			return nil, err
+		}
+		left = l || r
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseAnd() (interface{}, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume("&&") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l, err := conditionTruth(left, p.input)
+		if err != nil {
+			return nil, err
+		}
+		r, err := conditionTruth(right, p.input)
+		if err != nil {
+			return nil, err
+		}
+		left = l && r
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseNot() (interface{}, error) {
+	p.skipSpace()
+	if strings.HasPrefix(p.input[p.pos:], "!") && !strings.HasPrefix(p.input[p.pos:], "!=") {
+		p.pos++
+		value, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		truth, err := conditionTruth(value, p.input)
+		if err != nil {
+			return nil, err
+		}
+		return !truth, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *conditionParser) parseComparison() (interface{}, error) {
+	left, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case p.consume("=="):
+		right, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return conditionValuesEqual(left, right), nil
+	case p.consume("!="):
+		right, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return !conditionValuesEqual(left, right), nil
+	default:
+		return left, nil
+	}
+}
+
+func (p *conditionParser) parseValue() (interface{}, error) {
+	p.skipSpace()
+	if p.pos == len(p.input) {
+		return nil, errors.New("unexpected end of condition")
+	}
+	switch c := p.input[p.pos]; {
+	case c == '(':
+		p.pos++
+		value, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, errors.Errorf("missing ) at %d", p.pos)
+		}
+		return value, nil
+	case c == '"':
+		end := p.pos + 1
+		for end < len(p.input) && p.input[end] != '"' {
+			if p.input[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(p.input) {
+			return nil, errors.New("unterminated string")
+		}
+		s, err := strconv.Unquote(p.input[p.pos : end+1])
+		if err != nil {
+			return nil, err
+		}
+		p.pos = end + 1
+		return s, nil
+	case c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		end := p.pos + 1
+		for end < len(p.input) && strings.ContainsRune("0123456789.eE+-", rune(p.input[end])) {
+			end++
+		}
+		f, err := strconv.ParseFloat(p.input[p.pos:end], 64)
+		if err != nil {
+			return nil, errors.Errorf("invalid number %q", p.input[p.pos:end])
+		}
+		p.pos = end
+		return f, nil
+	case c == '_' || unicode.IsLetter(rune(c)):
+		end := p.pos
+		for end < len(p.input) {
+			r := rune(p.input[end])
+			if r != '_' && r != '-' && r != '.' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				break
+			}
+			end++
+		}
+		ident := p.input[p.pos:end]
+		p.pos = end
+		return p.lookup(ident), nil
+	default:
+		return nil, errors.Errorf("unexpected %q at %d", c, p.pos)
+	}
+}
+
+// lookup returns the value of a literal or variable.
+func (p *conditionParser) lookup(ident string) interface{} {
+	switch ident {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+	if name, ok := strings.CutPrefix(ident, "environment."); ok {
+		if value, ok := os.LookupEnv(name); ok {
+			return value
+		}
+		return nil
+	}
+	name := strings.TrimPrefix(ident, "variables.")
+	return normalizeConditionValue(p.variables[name])
+}
+
+// normalizeConditionValue converts numbers to float64 so that variables compare equal to
+// number literals regardless of how they were decoded.
+func normalizeConditionValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	default:
+		return v
+	}
+}
+
+func conditionValuesEqual(left, right interface{}) bool {
+	return reflect.DeepEqual(left, right)
+}
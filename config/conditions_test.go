@@ -0,0 +1,67 @@
+package config_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+)
+
+func TestEvaluateCondition(t *testing.T) {
+	t.Setenv("VIAM_TEST_SITE", "lab")
+	variables := map[string]interface{}{"region": "arctic", "heaters": 2.0, "indoors": false}
+
+	for _, tc := range []struct {
+		condition string
+		expected  bool
+	}{
+		{`region == "arctic"`, true},
+		{`variables.region != "arctic"`, false},
+		{`heaters == 2`, true},
+		{`!indoors && region == "arctic"`, true},
+		{`indoors || (heaters == 1 || environment.VIAM_TEST_SITE == "lab")`, true},
+		{`unset`, false},
+		{`unset == null`, true},
+		{`environment.VIAM_TEST_UNSET == "x"`, false},
+		{`true && !false`, true},
+	} {
+		t.Run(tc.condition, func(t *testing.T) {
+			enabled, err := config.EvaluateCondition(tc.condition, variables)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, enabled, test.ShouldEqual, tc.expected)
+		})
+	}
+
+	for _, condition := range []string{`region`, `region ==`, `(indoors`, `"arctic`, `region = "arctic"`} {
+		t.Run(condition, func(t *testing.T) {
+			_, err := config.EvaluateCondition(condition, variables)
+			test.That(t, err, test.ShouldNotBeNil)
+		})
+	}
+}
+
+func TestDisabledResources(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	cfg, err := config.FromReader(context.Background(), "", strings.NewReader(`{
+		"variables": {"region": "temperate"},
+		"components": [
+			{"name": "heater", "api": "rdk:component:generic", "model": "rdk:builtin:fake", "enabled": "region == \"arctic\""},
+			{"name": "fan", "api": "rdk:component:generic", "model": "rdk:builtin:fake", "enabled": "region != \"arctic\""},
+			{"name": "broken", "api": "rdk:component:generic", "model": "rdk:builtin:fake", "enabled": "region =="}
+		]
+	}`), logger, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cfg.Components, test.ShouldHaveLength, 1)
+	test.That(t, cfg.Components[0].Name, test.ShouldEqual, "fan")
+
+	test.That(t, cfg.DisabledResources, test.ShouldHaveLength, 2)
+	test.That(t, cfg.DisabledResources[0].Name.Name, test.ShouldEqual, "heater")
+	test.That(t, cfg.DisabledResources[0].Condition, test.ShouldEqual, `region == "arctic"`)
+	test.That(t, cfg.DisabledResources[0].Error, test.ShouldBeNil)
+	test.That(t, cfg.DisabledResources[1].Name.Name, test.ShouldEqual, "broken")
+	test.That(t, cfg.DisabledResources[1].Error, test.ShouldNotBeNil)
+}
@@ -3,7 +3,9 @@ package config
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -12,6 +14,7 @@ import (
 	"reflect"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,6 +23,7 @@ import (
 	"go.viam.com/utils/jwks"
 	"go.viam.com/utils/pexec"
 	"go.viam.com/utils/rpc"
+	"google.golang.org/grpc/codes"
 
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/referenceframe"
@@ -79,10 +83,46 @@ type Config struct {
 	// should be turned off. Defaults to false.
 	DisableLogDeduplication bool
 
+	// UnknownAttributes sets the policy for resource attributes that a model's native config
+	// does not declare, keyed by model (e.g. "rdk:builtin:gpio") or "*" for every model. It
+	// takes precedence over the policy the model was registered with.
+	UnknownAttributes map[string]resource.UnknownAttributesPolicy
+
+	// NameConflicts sets how short resource names that match more than one resource, such as
+	// resources of the same name on two remotes, are resolved.
+	NameConflicts resource.NameConflictResolution
+
+	// Variables are per-machine parameters that resource attributes and module settings can
+	// refer to with ${variables.<name>} placeholders, so that near-identical machines can
+	// share one config. A variable set to null must be given a value by the machine. For
+	// cloud configs, variables in the machine's local config file take precedence. Local
+	// configs can also list "local_fragments", whose resources and modules are merged into the
+	// config once the fragment's own variables, overridden by these, are substituted in them.
+	Variables map[string]interface{}
+
+	// Templates are reusable partial component configs, keyed by name, that components can
+	// reference with "template" to share an API, model and attributes. Templates are expanded
+	// when the config is read, so components are always complete once read.
+	Templates map[string]rutils.AttributeMap
+
+	// DisabledResources are the resources left out of the config because their enabled
+	// condition is false. They are set when the config is processed.
+	DisabledResources []DisabledResource
+
 	// toCache stores the JSON marshalled version of the config to be cached. It should be a copy of
 	// the config pulled from cloud with minor changes.
 	// This version is kept because the config is changed as it moves through the system.
 	toCache []byte
+
+	// unprocessed stores the JSON marshalled version of the config before it was processed, with
+	// placeholders and secret references left in, for keeping it in the config history.
+	unprocessed []byte
+
+	// fetchStatus records when a cloud config was fetched and whether it came from the cache.
+	fetchStatus FetchStatus
+
+	// signed is the signed form of a cloud config, which is cached alongside it.
+	signed *signedConfig
 }
 
 // MaintenanceConfig specifies a sensor that the machine will check to determine if the machine should reconfigure.
@@ -111,6 +151,11 @@ type configData struct {
 	PackagePath             string                        `json:"package_path,omitempty"`
 	DisableLogDeduplication bool                          `json:"disable_log_deduplication"`
 	Jobs                    []JobConfig                   `json:"jobs,omitempty"`
+
+	UnknownAttributes map[string]resource.UnknownAttributesPolicy `json:"unknown_attributes,omitempty"`
+	NameConflicts     *resource.NameConflictResolution            `json:"name_conflicts,omitempty"`
+	Variables         map[string]interface{}                      `json:"variables,omitempty"`
+	Templates         map[string]rutils.AttributeMap              `json:"templates,omitempty"`
 }
 
 // AppValidationStatus refers to the.
@@ -142,6 +187,23 @@ func (c *Config) Ensure(fromCloud bool, logger logging.Logger) error {
 		return err
 	}
 
+	for model, policy := range c.UnknownAttributes {
+		if err := policy.Validate(); err != nil {
+			return resource.NewConfigValidationError(fmt.Sprintf("unknown_attributes.%s", model), err)
+		}
+	}
+
+	if err := c.NameConflicts.Validate("name_conflicts"); err != nil {
+		return err
+	}
+
+	for name := range c.Variables {
+		if !variableNameRegexp.MatchString(name) {
+			return resource.NewConfigValidationError(fmt.Sprintf("variables.%s", name),
+				errors.New("variable names may only contain letters, numbers, underscores and hyphens"))
+		}
+	}
+
 	// Validate jobs, modules, remotes, packages, and processes, and log errors for lack of
 	// uniqueness within each category.
 	seenJobs := make(map[string]struct{})
@@ -259,7 +321,13 @@ func (c *Config) SetToCache(cfg *Config) error {
 	return nil
 }
 
-// StoreToCache caches the toCache.
+// FetchStatus returns when the config was fetched from the cloud and whether it was read
+// from the local cache. It is the zero value for configs that did not come from the cloud.
+func (c *Config) FetchStatus() FetchStatus {
+	return c.fetchStatus
+}
+
+// StoreToCache caches the toCache along with when it was fetched.
 func (c *Config) StoreToCache() error {
 	if c.toCache == nil {
 		return errors.New("no unprocessed config to cache")
@@ -269,12 +337,31 @@ func (c *Config) StoreToCache() error {
 	}
 	reader := bytes.NewReader(c.toCache)
 	path := getCloudCacheFilePath(c.Cloud.ID)
-	return artifact.AtomicStore(path, reader, c.Cloud.ID)
+	if err := artifact.AtomicStore(path, reader, c.Cloud.ID); err != nil {
+		return err
+	}
+	if c.fetchStatus.FetchedAt.IsZero() {
+		return nil
+	}
+	md, err := json.Marshal(cacheMetadata{FetchedAt: c.fetchStatus.FetchedAt, Signed: c.signed})
+	if err != nil {
+		return err
+	}
+	return artifact.AtomicStore(getCloudCacheMetadataFilePath(c.Cloud.ID), bytes.NewReader(md), c.Cloud.ID)
 }
 
 // UnmarshalJSON unmarshals JSON into the config and adjusts some
 // names if they are not fully filled in.
 func (c *Config) UnmarshalJSON(data []byte) error {
+	// fragments are merged first so that their components can be based on templates.
+	data, err := expandFragments(data)
+	if err != nil {
+		return err
+	}
+	data, err = expandComponentTemplates(data)
+	if err != nil {
+		return err
+	}
 	var conf configData
 	if err := json.Unmarshal(data, &conf); err != nil {
 		return err
@@ -306,6 +393,12 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 	c.PackagePath = conf.PackagePath
 	c.DisableLogDeduplication = conf.DisableLogDeduplication
 	c.Jobs = conf.Jobs
+	c.UnknownAttributes = conf.UnknownAttributes
+	if conf.NameConflicts != nil {
+		c.NameConflicts = *conf.NameConflicts
+	}
+	c.Variables = conf.Variables
+	c.Templates = conf.Templates
 
 	return nil
 }
@@ -322,6 +415,11 @@ func (c Config) MarshalJSON() ([]byte, error) {
 		c.Remotes[idx].adjustPartialNames()
 	}
 
+	var nameConflicts *resource.NameConflictResolution
+	if c.NameConflicts.Policy != "" || len(c.NameConflicts.PreferredRemotes) != 0 {
+		nameConflicts = &c.NameConflicts
+	}
+
 	return json.Marshal(configData{
 		Cloud:                   c.Cloud,
 		Modules:                 c.Modules,
@@ -340,6 +438,10 @@ func (c Config) MarshalJSON() ([]byte, error) {
 		PackagePath:             c.PackagePath,
 		DisableLogDeduplication: c.DisableLogDeduplication,
 		Jobs:                    c.Jobs,
+		UnknownAttributes:       c.UnknownAttributes,
+		NameConflicts:           nameConflicts,
+		Variables:               c.Variables,
+		Templates:               c.Templates,
 	})
 }
 
@@ -375,8 +477,19 @@ type Remote struct {
 	ReconnectInterval         time.Duration
 	AssociatedResourceConfigs []resource.AssociatedResourceConfig
 
+	// LowBandwidth tunes the connection to the remote for constrained links, such as to a micro-RDK on
+	// LTE-M: it is checked and refreshed less often, and the APIs of its resources are only fetched again
+	// when the resources change. It and the intervals below can only be set in local configs, as the
+	// RemoteConfig proto has no fields for them.
+	LowBandwidth bool
+	// RefreshInterval is how often the resources of the remote are refreshed.
+	RefreshInterval time.Duration
+	// HeartbeatInterval is how often session heartbeats are sent to the remote. It is capped at half of
+	// the remote's heartbeat window so that sessions do not expire.
+	HeartbeatInterval time.Duration
+
 	// Secret is a helper for a robot location secret.
-	Secret string
+	Secret string `config:"secret"`
 
 	alreadyValidated bool
 	cachedErr        error
@@ -393,17 +506,23 @@ type remoteData struct {
 	ConnectionCheckInterval   string                              `json:"connection_check_interval,omitempty"`
 	ReconnectInterval         string                              `json:"reconnect_interval,omitempty"`
 	AssociatedResourceConfigs []resource.AssociatedResourceConfig `json:"service_configs"`
+	LowBandwidth              bool                                `json:"low_bandwidth,omitempty"`
+	RefreshInterval           string                              `json:"refresh_interval,omitempty"`
+	HeartbeatInterval         string                              `json:"heartbeat_interval,omitempty"`
 
 	// Secret is a helper for a robot location secret.
 	Secret string `json:"secret"`
 }
 
-// Equals checks if the two configs are deeply equal to each other.
+// Equals checks if the two configs are deeply equal to each other. Associated resource configs
+// are ignored, as changing them does not require reconnecting to the remote.
 func (conf Remote) Equals(other Remote) bool {
 	conf.alreadyValidated = false
 	conf.cachedErr = nil
+	conf.AssociatedResourceConfigs = nil
 	other.alreadyValidated = false
 	other.cachedErr = nil
+	other.AssociatedResourceConfigs = nil
 	//nolint:govet
 	return reflect.DeepEqual(conf, other)
 }
@@ -422,6 +541,7 @@ func (conf *Remote) UnmarshalJSON(data []byte) error {
 		ManagedBy:                 temp.ManagedBy,
 		Insecure:                  temp.Insecure,
 		AssociatedResourceConfigs: temp.AssociatedResourceConfigs,
+		LowBandwidth:              temp.LowBandwidth,
 		Secret:                    temp.Secret,
 	}
 	if temp.ConnectionCheckInterval != "" {
@@ -438,6 +558,20 @@ func (conf *Remote) UnmarshalJSON(data []byte) error {
 		}
 		conf.ReconnectInterval = dur
 	}
+	if temp.RefreshInterval != "" {
+		dur, err := time.ParseDuration(temp.RefreshInterval)
+		if err != nil {
+			return err
+		}
+		conf.RefreshInterval = dur
+	}
+	if temp.HeartbeatInterval != "" {
+		dur, err := time.ParseDuration(temp.HeartbeatInterval)
+		if err != nil {
+			return err
+		}
+		conf.HeartbeatInterval = dur
+	}
 	return nil
 }
 
@@ -451,6 +585,7 @@ func (conf Remote) MarshalJSON() ([]byte, error) {
 		ManagedBy:                 conf.ManagedBy,
 		Insecure:                  conf.Insecure,
 		AssociatedResourceConfigs: conf.AssociatedResourceConfigs,
+		LowBandwidth:              conf.LowBandwidth,
 		Secret:                    conf.Secret,
 	}
 	if conf.ConnectionCheckInterval != 0 {
@@ -459,6 +594,12 @@ func (conf Remote) MarshalJSON() ([]byte, error) {
 	if conf.ReconnectInterval != 0 {
 		temp.ReconnectInterval = conf.ReconnectInterval.String()
 	}
+	if conf.RefreshInterval != 0 {
+		temp.RefreshInterval = conf.RefreshInterval.String()
+	}
+	if conf.HeartbeatInterval != 0 {
+		temp.HeartbeatInterval = conf.HeartbeatInterval.String()
+	}
 	return json.Marshal(temp)
 }
 
@@ -533,8 +674,8 @@ func (conf *Remote) validate(path string) error {
 // URL is constructed as $Path?id=ID and secret is put in a http header.
 type Cloud struct {
 	ID                string
-	Secret            string
-	LocationSecret    string // Deprecated: Use LocationSecrets
+	Secret            string `config:"secret"`
+	LocationSecret    string `config:"secret"` // Deprecated: Use LocationSecrets
 	LocationSecrets   []LocationSecret
 	LocationID        string
 	PrimaryOrgID      string
@@ -548,10 +689,29 @@ type Cloud struct {
 	LogPath           string
 	AppAddress        string
 	RefreshInterval   time.Duration
-
-	// cached by us and fetched from a non-config endpoint.
-	TLSCertificate string
-	TLSPrivateKey  string
+	// ConfigQuietPeriod, if set, delays applying a changed config until no further
+	// changes have been seen for this long, so that rapid edits are applied at once. Configs
+	// identical to the last one applied are then not applied again.
+	ConfigQuietPeriod time.Duration
+	// MaxCachedConfigAge, if set, is how old a cached config can be before it is
+	// considered stale when the cloud cannot be reached.
+	MaxCachedConfigAge time.Duration
+	// StaleConfigBehavior controls what happens when the cached config is stale.
+	StaleConfigBehavior StaleConfigBehavior
+	// ConfigSigningKeys, if set, are the base64 encoded ed25519 public keys that configs from the
+	// cloud must be signed by. They are only read from the local config.
+	ConfigSigningKeys []string
+	// Proxy, if set, is the http, https or socks5 proxy URL that connections to the cloud go
+	// through, for networks that only allow egress through one. It is only read from the local config.
+	Proxy string
+	// CACertFile, if set, is a file of PEM certificates to trust for connections to the cloud in
+	// addition to the system's, for networks whose proxies intercept TLS.
+	CACertFile string
+
+	// cached by us and fetched from a non-config endpoint. The certificate is not really a
+	// secret but is annoying to diff.
+	TLSCertificate string `config:"secret"`
+	TLSPrivateKey  string `config:"secret"`
 }
 
 // Note: keep this in sync with Cloud.
@@ -574,6 +734,13 @@ type cloudData struct {
 	Path              string           `json:"path,omitempty"`
 	LogPath           string           `json:"log_path,omitempty"`
 	RefreshInterval   string           `json:"refresh_interval,omitempty"`
+	ConfigQuietPeriod string           `json:"config_quiet_period,omitempty"`
+
+	MaxCachedConfigAge  string              `json:"max_cached_config_age,omitempty"`
+	StaleConfigBehavior StaleConfigBehavior `json:"stale_config_behavior,omitempty"`
+	ConfigSigningKeys   []string            `json:"config_signing_keys,omitempty"`
+	Proxy               string              `json:"proxy,omitempty"`
+	CACertFile          string              `json:"ca_cert_file,omitempty"`
 
 	// cached by us and fetched from a non-config endpoint.
 	TLSCertificate string `json:"tls_certificate"`
@@ -604,6 +771,11 @@ func (config *Cloud) UnmarshalJSON(data []byte) error {
 		AppAddress:        temp.AppAddress,
 		TLSCertificate:    temp.TLSCertificate,
 		TLSPrivateKey:     temp.TLSPrivateKey,
+
+		StaleConfigBehavior: temp.StaleConfigBehavior,
+		ConfigSigningKeys:   temp.ConfigSigningKeys,
+		Proxy:               temp.Proxy,
+		CACertFile:          temp.CACertFile,
 	}
 	if temp.RefreshInterval != "" {
 		dur, err := time.ParseDuration(temp.RefreshInterval)
@@ -612,6 +784,20 @@ func (config *Cloud) UnmarshalJSON(data []byte) error {
 		}
 		config.RefreshInterval = dur
 	}
+	if temp.ConfigQuietPeriod != "" {
+		dur, err := time.ParseDuration(temp.ConfigQuietPeriod)
+		if err != nil {
+			return err
+		}
+		config.ConfigQuietPeriod = dur
+	}
+	if temp.MaxCachedConfigAge != "" {
+		dur, err := time.ParseDuration(temp.MaxCachedConfigAge)
+		if err != nil {
+			return err
+		}
+		config.MaxCachedConfigAge = dur
+	}
 	return nil
 }
 
@@ -635,10 +821,21 @@ func (config Cloud) MarshalJSON() ([]byte, error) {
 		AppAddress:        config.AppAddress,
 		TLSCertificate:    config.TLSCertificate,
 		TLSPrivateKey:     config.TLSPrivateKey,
+
+		StaleConfigBehavior: config.StaleConfigBehavior,
+		ConfigSigningKeys:   config.ConfigSigningKeys,
+		Proxy:               config.Proxy,
+		CACertFile:          config.CACertFile,
 	}
 	if config.RefreshInterval != 0 {
 		temp.RefreshInterval = config.RefreshInterval.String()
 	}
+	if config.ConfigQuietPeriod != 0 {
+		temp.ConfigQuietPeriod = config.ConfigQuietPeriod.String()
+	}
+	if config.MaxCachedConfigAge != 0 {
+		temp.MaxCachedConfigAge = config.MaxCachedConfigAge.String()
+	}
 	return json.Marshal(temp)
 }
 
@@ -660,9 +857,43 @@ func (config *Cloud) Validate(path string, fromCloud bool) error {
 	if config.RefreshInterval == 0 {
 		config.RefreshInterval = 10 * time.Second
 	}
+	if config.ConfigQuietPeriod < 0 {
+		return resource.NewConfigValidationError(path, errors.New("config_quiet_period cannot be negative"))
+	}
+	if config.MaxCachedConfigAge < 0 {
+		return resource.NewConfigValidationError(path, errors.New("max_cached_config_age cannot be negative"))
+	}
+	switch config.StaleConfigBehavior {
+	case "", StaleConfigWarn, StaleConfigRefuse:
+	default:
+		return resource.NewConfigValidationError(path,
+			errors.Errorf("stale_config_behavior must be %q or %q", StaleConfigWarn, StaleConfigRefuse))
+	}
+	if _, err := parseConfigSigningKeys(config.ConfigSigningKeys); err != nil {
+		return resource.NewConfigValidationError(path, err)
+	}
+	if _, err := config.proxyURL(); err != nil {
+		return resource.NewConfigValidationError(path, err)
+	}
+	if _, err := config.TLSConfig(); err != nil {
+		return resource.NewConfigValidationError(path, err)
+	}
 	return nil
 }
 
+// StaleConfigBehavior controls what happens when the robot falls back to a cached cloud
+// config that is older than the max_cached_config_age.
+type StaleConfigBehavior string
+
+// The supported StaleConfigBehavior values.
+const (
+	// StaleConfigWarn uses the stale config and logs a warning. This is the default.
+	StaleConfigWarn StaleConfigBehavior = "warn"
+	// StaleConfigRefuse refuses to use the stale config, so a robot that cannot reach the
+	// cloud will not start with it.
+	StaleConfigRefuse StaleConfigBehavior = "refuse"
+)
+
 // ValidateTLS ensures TLS fields are valid.
 func (config *Cloud) ValidateTLS(path string) error {
 	if config.TLSCertificate == "" {
@@ -678,7 +909,7 @@ func (config *Cloud) ValidateTLS(path string) error {
 type LocationSecret struct {
 	ID string `json:"id"`
 	// Payload of the secret
-	Secret string `json:"secret"`
+	Secret string `json:"secret" config:"secret"`
 }
 
 // NetworkConfig describes networking settings for the web server.
@@ -722,6 +953,9 @@ type NetworkConfigData struct {
 
 	// TrafficTunnelEndpoints are the allowed ports and options for tunneling.
 	TrafficTunnelEndpoints []TrafficTunnelEndpoint `json:"traffic_tunnel_endpoints"`
+
+	// MethodPolicies set the default deadlines and retry policies of the methods of resource APIs.
+	MethodPolicies []MethodPolicyConfig `json:"method_policies,omitempty"`
 }
 
 // MarshalJSON marshals out this config.
@@ -752,6 +986,18 @@ func (nc *NetworkConfig) Validate(path string) error {
 	if (nc.TLSCertFile == "") != (nc.TLSKeyFile == "") {
 		return resource.NewConfigValidationError(path, errors.New("must provide both tls_cert_file and tls_key_file"))
 	}
+	seenPolicies := map[string]struct{}{}
+	for idx, policy := range nc.MethodPolicies {
+		if err := policy.Validate(fmt.Sprintf("%s.method_policies.%d", path, idx)); err != nil {
+			return err
+		}
+		key := policy.API + "/" + policy.Method
+		if _, ok := seenPolicies[key]; ok {
+			return resource.NewConfigValidationError(path,
+				errors.Errorf("more than one method policy for api %q and method %q", policy.API, policy.Method))
+		}
+		seenPolicies[key] = struct{}{}
+	}
 
 	return nc.Sessions.Validate(path + ".sessions")
 }
@@ -856,6 +1102,111 @@ func (tte *TrafficTunnelEndpoint) MarshalJSON() ([]byte, error) {
 	return json.Marshal(temp)
 }
 
+// MethodPolicyConfig sets the default deadline and retry policy of the methods of a resource API.
+// Servers apply the deadline to requests that do not set their own, and advertise the policy to
+// clients, which apply it to their later requests.
+type MethodPolicyConfig struct {
+	// API is the resource API, like "rdk:component:camera".
+	API string
+	// Method is the gRPC method of the API, like "GetImage". If it is empty, the policy is for all
+	// of the API's methods that do not have a policy of their own.
+	Method string
+	// Timeout is the deadline of requests that do not set their own.
+	Timeout time.Duration
+	// MaxAttempts is how many times clients try a request that fails with one of RetryCodes,
+	// including the first time. Requests are not retried if it is 0 or 1.
+	MaxAttempts uint
+	// RetryBackoff is how long clients wait between attempts.
+	RetryBackoff time.Duration
+	// RetryCodes are the gRPC status codes that are retried, like "UNAVAILABLE". They default to
+	// UNAVAILABLE and RESOURCE_EXHAUSTED.
+	RetryCodes []string
+}
+
+// Note: keep this in sync with MethodPolicyConfig.
+type methodPolicyConfigData struct {
+	API          string   `json:"api"`
+	Method       string   `json:"method,omitempty"`
+	Timeout      string   `json:"timeout,omitempty"`
+	MaxAttempts  uint     `json:"max_attempts,omitempty"`
+	RetryBackoff string   `json:"retry_backoff,omitempty"`
+	RetryCodes   []string `json:"retry_codes,omitempty"`
+}
+
+// UnmarshalJSON unmarshals JSON data into this method policy.
+func (mp *MethodPolicyConfig) UnmarshalJSON(data []byte) error {
+	var temp methodPolicyConfigData
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	mp.API = temp.API
+	mp.Method = temp.Method
+	mp.MaxAttempts = temp.MaxAttempts
+	mp.RetryCodes = temp.RetryCodes
+	if temp.Timeout != "" {
+		dur, err := time.ParseDuration(temp.Timeout)
+		if err != nil {
+			return err
+		}
+		mp.Timeout = dur
+	}
+	if temp.RetryBackoff != "" {
+		dur, err := time.ParseDuration(temp.RetryBackoff)
+		if err != nil {
+			return err
+		}
+		mp.RetryBackoff = dur
+	}
+	return nil
+}
+
+// MarshalJSON marshals out this method policy.
+func (mp MethodPolicyConfig) MarshalJSON() ([]byte, error) {
+	temp := methodPolicyConfigData{
+		API:         mp.API,
+		Method:      mp.Method,
+		MaxAttempts: mp.MaxAttempts,
+		RetryCodes:  mp.RetryCodes,
+	}
+	if mp.Timeout != 0 {
+		temp.Timeout = mp.Timeout.String()
+	}
+	if mp.RetryBackoff != 0 {
+		temp.RetryBackoff = mp.RetryBackoff.String()
+	}
+	return json.Marshal(temp)
+}
+
+// Validate ensures all parts of the config are valid.
+func (mp *MethodPolicyConfig) Validate(path string) error {
+	if mp.API == "" {
+		return resource.NewConfigValidationFieldRequiredError(path, "api")
+	}
+	if _, err := resource.NewAPIFromString(mp.API); err != nil {
+		return resource.NewConfigValidationError(path, err)
+	}
+	if mp.Timeout < 0 || mp.RetryBackoff < 0 {
+		return resource.NewConfigValidationError(path, errors.New("timeout and retry_backoff cannot be negative"))
+	}
+	if _, err := mp.ParseRetryCodes(); err != nil {
+		return resource.NewConfigValidationError(path, err)
+	}
+	return nil
+}
+
+// ParseRetryCodes returns the gRPC status codes of RetryCodes.
+func (mp *MethodPolicyConfig) ParseRetryCodes() ([]codes.Code, error) {
+	parsed := make([]codes.Code, 0, len(mp.RetryCodes))
+	for _, name := range mp.RetryCodes {
+		var code codes.Code
+		if err := code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(name)))); err != nil {
+			return nil, errors.Errorf("invalid retry code %q", name)
+		}
+		parsed = append(parsed, code)
+	}
+	return parsed, nil
+}
+
 // AuthConfig describes authentication and authorization settings for the web server.
 type AuthConfig struct {
 	Handlers           []AuthHandlerConfig `json:"handlers,omitempty"`
@@ -943,7 +1294,7 @@ func (c *ExternalAuthConfig) Validate(path string) error {
 // AuthHandlerConfig describes the configuration for a particular auth handler.
 type AuthHandlerConfig struct {
 	Type   rpc.CredentialsType `json:"type"`
-	Config rutils.AttributeMap `json:"config"`
+	Config rutils.AttributeMap `json:"config" config:"secret"`
 }
 
 // Validate ensures all parts of the config are valid. If it exists, updates ExternalAuthConfig's ValidatedKeySet once validated.
@@ -1198,10 +1549,52 @@ type Revision struct {
 	LastUpdated time.Time
 }
 
+// Checksum returns a hex encoded SHA-256 checksum of the config's JSON content, which is the
+// same for configs with the same content.
+func (c *Config) Checksum() (string, error) {
+	md, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(md)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// FetchStatus describes when a cloud config was fetched from the cloud and whether it
+// was read from the local cache because the cloud could not be reached.
+type FetchStatus struct {
+	// FromCache is true if the config was read from the local cache.
+	FromCache bool
+	// FetchedAt is when the config was fetched from the cloud. It is zero if unknown.
+	FetchedAt time.Time
+	// MaxAge is the max_cached_config_age the config was read with, if any.
+	MaxAge time.Duration
+}
+
+// Age returns how long before now the config was fetched from the cloud, or zero if unknown.
+func (s FetchStatus) Age(now time.Time) time.Duration {
+	if s.FetchedAt.IsZero() {
+		return 0
+	}
+	return now.Sub(s.FetchedAt)
+}
+
+// Stale returns whether the config came from the cache and is older than its max age.
+// A cached config with an unknown fetch time is considered stale when a max age is set.
+func (s FetchStatus) Stale(now time.Time) bool {
+	if !s.FromCache || s.MaxAge <= 0 {
+		return false
+	}
+	return s.FetchedAt.IsZero() || s.Age(now) > s.MaxAge
+}
+
 // UpdateLoggerRegistryFromConfig will update the passed in registry with all log patterns
 // in `cfg.LogConfig` and each resource's `LogConfiguration` field if present. It will
-// also turn on or off log deduplication on the registry as necessary.
+// also turn on or off log deduplication on the registry as necessary, and mask the secrets
+// resolved for `cfg` in all logs in place of those of the previous config.
 func UpdateLoggerRegistryFromConfig(registry *logging.Registry, cfg *Config, logger logging.Logger) {
+	registry.SetRedactedValues(cfg.ResolvedSecrets())
+
 	var combinedLogCfg []logging.LoggerPatternConfig
 	if cfg.LogConfig != nil {
 		combinedLogCfg = append(combinedLogCfg, cfg.LogConfig...)
@@ -10,6 +10,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	"go.viam.com/utils/jwks"
 	"go.viam.com/utils/pexec"
 	"go.viam.com/utils/rpc"
+	"google.golang.org/grpc/codes"
 
 	"go.viam.com/rdk/components/arm"
 	"go.viam.com/rdk/components/base"
@@ -132,6 +134,9 @@ func TestConfig3(t *testing.T) {
 	test.That(t, cfg.Remotes, test.ShouldHaveLength, 1)
 	test.That(t, cfg.Remotes[0].ConnectionCheckInterval, test.ShouldEqual, 12*time.Second)
 	test.That(t, cfg.Remotes[0].ReconnectInterval, test.ShouldEqual, 3*time.Second)
+	test.That(t, cfg.Remotes[0].LowBandwidth, test.ShouldBeTrue)
+	test.That(t, cfg.Remotes[0].RefreshInterval, test.ShouldEqual, time.Minute)
+	test.That(t, cfg.Remotes[0].HeartbeatInterval, test.ShouldEqual, 30*time.Second)
 	test.That(t, cfg.Remotes[0].AssociatedResourceConfigs, test.ShouldHaveLength, 2)
 	test.That(t, cfg.Remotes[0].AssociatedResourceConfigs[0], test.ShouldResemble, resource.AssociatedResourceConfig{
 		API: resource.APINamespaceRDK.WithServiceType("data_manager"),
@@ -1321,6 +1326,37 @@ func TestConfigRobotRevision(t *testing.T) {
 	test.That(t, cfg.Revision, test.ShouldEqual, "rev1")
 }
 
+func TestConfigUnknownAttributes(t *testing.T) {
+	logger, logs := logging.NewObservedTestLogger(t)
+	cfgWithPolicy := func(policies string) string {
+		return fmt.Sprintf(`{
+			"components": [
+				{"name": "m", "type": "motor", "model": "fake", "attributes": {"max_rpm_": 100, "encoder": "e", "speed": 1}}
+			],
+			"unknown_attributes": %s
+		}`, policies)
+	}
+	read := func(policies string) error {
+		_, err := config.FromReader(context.Background(), "", strings.NewReader(cfgWithPolicy(policies)), logger, nil)
+		return err
+	}
+
+	test.That(t, read(`{}`), test.ShouldBeNil)
+	test.That(t, logs.FilterMessageSnippet("unknown attributes").Len(), test.ShouldEqual, 0)
+
+	err := read(`{"*": "error"}`)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "max_rpm_ (did you mean max_rpm?), speed")
+
+	// a per-model policy takes precedence over the one for every model
+	test.That(t, read(`{"*": "error", "rdk:builtin:fake": "warn"}`), test.ShouldBeNil)
+	test.That(t, logs.FilterMessageSnippet("unknown attributes").Len(), test.ShouldEqual, 1)
+
+	err = read(`{"*": "strict"}`)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "unknown attributes policy must be one of")
+}
+
 func TestConfigJSONMarshalRoundtrip(t *testing.T) {
 	type testcase struct {
 		name     string
@@ -1541,3 +1577,42 @@ func TestConfigJSONMarshalRoundtrip(t *testing.T) {
 		})
 	}
 }
+
+func TestMethodPolicyConfig(t *testing.T) {
+	var nc config.NetworkConfig
+	err := json.Unmarshal([]byte(`{"method_policies": [
+		{"api": "rdk:component:camera", "method": "GetImage", "timeout": "2s"},
+		{"api": "rdk:component:arm", "timeout": "5m", "max_attempts": 3, "retry_backoff": "100ms", "retry_codes": ["unavailable"]}
+	]}`), &nc)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, nc.Validate("network"), test.ShouldBeNil)
+	test.That(t, nc.MethodPolicies, test.ShouldResemble, []config.MethodPolicyConfig{
+		{API: "rdk:component:camera", Method: "GetImage", Timeout: 2 * time.Second},
+		{
+			API: "rdk:component:arm", Timeout: 5 * time.Minute,
+			MaxAttempts: 3, RetryBackoff: 100 * time.Millisecond, RetryCodes: []string{"unavailable"},
+		},
+	})
+	retryCodes, err := nc.MethodPolicies[1].ParseRetryCodes()
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, retryCodes, test.ShouldResemble, []codes.Code{codes.Unavailable})
+
+	md, err := json.Marshal(nc.MethodPolicies[1])
+	test.That(t, err, test.ShouldBeNil)
+	var roundTripped config.MethodPolicyConfig
+	test.That(t, json.Unmarshal(md, &roundTripped), test.ShouldBeNil)
+	test.That(t, roundTripped, test.ShouldResemble, nc.MethodPolicies[1])
+
+	for errText, policies := range map[string][]config.MethodPolicyConfig{
+		"api":                  {{Method: "GetImage"}},
+		"invalid retry code":   {{API: "rdk:component:camera", RetryCodes: []string{"NOPE"}}},
+		"cannot be negative":   {{API: "rdk:component:camera", Timeout: -time.Second}},
+		"more than one":        {{API: "rdk:component:camera"}, {API: "rdk:component:camera"}},
+		"not a valid api name": {{API: "rdk:component:camera:"}},
+	} {
+		nc.MethodPolicies = policies
+		err := nc.Validate("network")
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, errText)
+	}
+}
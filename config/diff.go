@@ -26,6 +26,8 @@ type Diff struct {
 	JobsEqual           bool
 	PrettyDiff          string
 	UnmodifiedResources []resource.Config
+	// Stats summarizes the changes.
+	Stats DiffStats
 }
 
 // ModifiedConfigDiff is the modificative different between two configs.
@@ -37,6 +39,23 @@ type ModifiedConfigDiff struct {
 	Packages   []PackageConfig
 	Modules    []Module
 	Jobs       []JobConfig
+
+	// AssociatedResourceConfigs lists the resources and remotes whose associated resource
+	// configs (e.g. data capture settings) changed. The owning resource is not considered
+	// modified by these changes, but the resources of each changed associated API are.
+	AssociatedResourceConfigs []AssociatedResourceConfigsDiff
+}
+
+// AssociatedResourceConfigsDiff is a change in the associated resource configs of a single
+// resource or remote.
+type AssociatedResourceConfigsDiff struct {
+	// Resource is the resource whose associated configs changed. It is empty if Remote is set.
+	Resource resource.Name
+	// Remote is the name of the remote whose associated configs changed, if any.
+	Remote string
+	// APIs are the associated APIs whose configs changed, sorted by name.
+	APIs        []resource.API
+	Left, Right []resource.AssociatedResourceConfig
 }
 
 // NewRevision returns the revision from the new config if available.
@@ -47,12 +66,30 @@ func (diff Diff) NewRevision() string {
 	return ""
 }
 
+// DiffOptions configures DiffConfigsWithOptions.
+type DiffOptions struct {
+	// RevealSensitiveConfigDiffs computes the PrettyDiff, with secrets masked.
+	RevealSensitiveConfigDiffs bool
+	// SortPrettyDiff sorts the resources, remotes, processes, packages, modules and jobs of
+	// both configs before computing the PrettyDiff, so that it only depends on what is in the
+	// configs and not on the order they are listed in.
+	SortPrettyDiff bool
+}
+
 // DiffConfigs returns the difference between the two given configs
 // from left to right.
-func DiffConfigs(left, right Config, revealSensitiveConfigDiffs bool) (_ *Diff, err error) {
+func DiffConfigs(left, right Config, revealSensitiveConfigDiffs bool) (*Diff, error) {
+	return DiffConfigsWithOptions(left, right, DiffOptions{RevealSensitiveConfigDiffs: revealSensitiveConfigDiffs})
+}
+
+// DiffConfigsWithOptions returns the difference between the two given configs
+// from left to right. The Added, Modified and Removed configs list their
+// resources sorted by API and then name, and everything else sorted by name,
+// so that diffing the same configs always produces the same diff.
+func DiffConfigsWithOptions(left, right Config, opts DiffOptions) (_ *Diff, err error) {
 	var PrettyDiff string
-	if revealSensitiveConfigDiffs {
-		PrettyDiff, err = prettyDiff(left, right)
+	if opts.RevealSensitiveConfigDiffs {
+		PrettyDiff, err = prettyDiff(left, right, opts.SortPrettyDiff)
 		if err != nil {
 			return nil, err
 		}
@@ -86,6 +123,7 @@ func DiffConfigs(left, right Config, revealSensitiveConfigDiffs bool) (_ *Diff,
 
 	different = packagesDifferent || different
 	different = diffModules(left.Modules, right.Modules, &diff) || different
+	different = diffAssociatedResourceConfigs(&left, &right, &diff) || different
 
 	diff.ResourcesEqual = !different
 
@@ -98,10 +136,163 @@ func DiffConfigs(left, right Config, revealSensitiveConfigDiffs bool) (_ *Diff,
 	logDifferent := diffLogCfg(&left, &right)
 	diff.LogEqual = !logDifferent
 
+	sortConfigLists(diff.Added)
+	sortConfigLists(diff.Removed)
+	diff.Modified.sort()
+	sortResourceConfigs(diff.UnmodifiedResources)
+	diff.Stats = newDiffStats(&diff)
+
 	return &diff, nil
 }
 
-func prettyDiff(left, right Config) (string, error) {
+// sortResourceConfigs sorts resource configs by API and then name.
+func sortResourceConfigs(confs []resource.Config) {
+	sort.SliceStable(confs, func(i, j int) bool {
+		if confs[i].API != confs[j].API {
+			return confs[i].API.String() < confs[j].API.String()
+		}
+		return confs[i].Name < confs[j].Name
+	})
+}
+
+// sortConfigLists sorts the resources of a config by API and then name, and its remotes,
+// processes, packages, modules and jobs by name.
+func sortConfigLists(conf *Config) {
+	sortResourceConfigs(conf.Components)
+	sortResourceConfigs(conf.Services)
+	sort.SliceStable(conf.Remotes, func(i, j int) bool { return conf.Remotes[i].Name < conf.Remotes[j].Name })
+	sort.SliceStable(conf.Processes, func(i, j int) bool { return conf.Processes[i].ID < conf.Processes[j].ID })
+	sort.SliceStable(conf.Packages, func(i, j int) bool { return conf.Packages[i].Name < conf.Packages[j].Name })
+	sort.SliceStable(conf.Modules, func(i, j int) bool { return conf.Modules[i].Name < conf.Modules[j].Name })
+	sort.SliceStable(conf.Jobs, func(i, j int) bool { return conf.Jobs[i].Name < conf.Jobs[j].Name })
+}
+
+// sort sorts the modified configs the same way as sortConfigLists, and the associated
+// resource config changes by resource and then remote.
+func (m *ModifiedConfigDiff) sort() {
+	sortConfigLists(&Config{
+		Remotes:    m.Remotes,
+		Components: m.Components,
+		Processes:  m.Processes,
+		Services:   m.Services,
+		Packages:   m.Packages,
+		Modules:    m.Modules,
+		Jobs:       m.Jobs,
+	})
+	sort.SliceStable(m.AssociatedResourceConfigs, func(i, j int) bool {
+		a, b := m.AssociatedResourceConfigs[i], m.AssociatedResourceConfigs[j]
+		if (a.Remote == "") != (b.Remote == "") {
+			return a.Remote == ""
+		}
+		if a.Resource != b.Resource {
+			return a.Resource.String() < b.Resource.String()
+		}
+		return a.Remote < b.Remote
+	})
+}
+
+// diffAssociatedResourceConfigs records changes to the associated resource configs of resources and
+// remotes present in both configs, and marks the resources of every changed associated API as
+// modified so they pick up the change.
+func diffAssociatedResourceConfigs(left, right *Config, diff *Diff) bool {
+	changedAPIs := map[resource.API]struct{}{}
+	record := func(assocDiff AssociatedResourceConfigsDiff) {
+		assocDiff.APIs = changedAssociatedAPIs(assocDiff.Left, assocDiff.Right)
+		if len(assocDiff.APIs) == 0 {
+			return
+		}
+		for _, api := range assocDiff.APIs {
+			changedAPIs[api] = struct{}{}
+		}
+		diff.Modified.AssociatedResourceConfigs = append(diff.Modified.AssociatedResourceConfigs, assocDiff)
+	}
+
+	leftResources := map[resource.Name][]resource.AssociatedResourceConfig{}
+	for _, conf := range append(append([]resource.Config{}, left.Components...), left.Services...) {
+		leftResources[conf.ResourceName()] = conf.AssociatedResourceConfigs
+	}
+	for _, conf := range append(append([]resource.Config{}, right.Components...), right.Services...) {
+		leftAssoc, ok := leftResources[conf.ResourceName()]
+		if !ok {
+			continue
+		}
+		record(AssociatedResourceConfigsDiff{
+			Resource: conf.ResourceName(),
+			Left:     leftAssoc,
+			Right:    conf.AssociatedResourceConfigs,
+		})
+	}
+
+	leftRemotes := map[string][]resource.AssociatedResourceConfig{}
+	for _, rem := range left.Remotes {
+		leftRemotes[rem.Name] = rem.AssociatedResourceConfigs
+	}
+	for _, rem := range right.Remotes {
+		leftAssoc, ok := leftRemotes[rem.Name]
+		if !ok {
+			continue
+		}
+		record(AssociatedResourceConfigsDiff{
+			Remote: rem.Name,
+			Left:   leftAssoc,
+			Right:  rem.AssociatedResourceConfigs,
+		})
+	}
+
+	if len(changedAPIs) == 0 {
+		return false
+	}
+
+	alreadyChanged := map[resource.Name]struct{}{}
+	for _, conf := range diff.Added.Services {
+		alreadyChanged[conf.ResourceName()] = struct{}{}
+	}
+	for _, conf := range diff.Modified.Services {
+		alreadyChanged[conf.ResourceName()] = struct{}{}
+	}
+	for _, conf := range right.Services {
+		if _, ok := changedAPIs[conf.API]; !ok {
+			continue
+		}
+		if _, ok := alreadyChanged[conf.ResourceName()]; ok {
+			continue
+		}
+		diff.Modified.Services = append(diff.Modified.Services, conf)
+	}
+	return true
+}
+
+// changedAssociatedAPIs returns the APIs whose associated configs differ between left and right.
+func changedAssociatedAPIs(left, right []resource.AssociatedResourceConfig) []resource.API {
+	byAPI := func(confs []resource.AssociatedResourceConfig) map[resource.API][]resource.AssociatedResourceConfig {
+		m := map[resource.API][]resource.AssociatedResourceConfig{}
+		for _, conf := range confs {
+			// converted attributes are derived from the attributes and may hold linked state.
+			stripped := resource.AssociatedResourceConfig{API: conf.API, RemoteName: conf.RemoteName}
+			if len(conf.Attributes) != 0 {
+				stripped.Attributes = conf.Attributes
+			}
+			m[conf.API] = append(m[conf.API], stripped)
+		}
+		return m
+	}
+	leftByAPI, rightByAPI := byAPI(left), byAPI(right)
+
+	var changed []resource.API
+	for api, confs := range rightByAPI {
+		if !reflect.DeepEqual(leftByAPI[api], confs) {
+			changed = append(changed, api)
+		}
+		delete(leftByAPI, api)
+	}
+	for api := range leftByAPI {
+		changed = append(changed, api)
+	}
+	sort.Slice(changed, func(i, j int) bool { return changed[i].String() < changed[j].String() })
+	return changed
+}
+
+func prettyDiff(left, right Config, sortLists bool) (string, error) {
 	leftMd, err := json.Marshal(left)
 	if err != nil {
 		return "", err
@@ -117,54 +308,22 @@ func prettyDiff(left, right Config) (string, error) {
 	if err := json.Unmarshal(rightMd, &rightClone); err != nil {
 		return "", err
 	}
+	// resolved secret_ref values can be anywhere in resource attributes. The clones keep the order
+	// of resources, so their secrets are masked by the paths recorded on the originals.
+	maskClonedSecrets(&leftClone, &left)
+	maskClonedSecrets(&rightClone, &right)
 	left = leftClone
 	right = rightClone
-
-	mask := "******"
-	sanitizeConfig := func(conf *Config) {
-		// Note(erd): keep in mind this will destroy the actual pretty diffing of these which
-		// is fine because we aren't considering pretty diff changes to these fields at this level
-		// of the stack.
-		if conf.Cloud != nil {
-			if conf.Cloud.Secret != "" {
-				conf.Cloud.Secret = mask
-			}
-			if conf.Cloud.LocationSecret != "" {
-				conf.Cloud.LocationSecret = mask
-			}
-			for i := range conf.Cloud.LocationSecrets {
-				if conf.Cloud.LocationSecrets[i].Secret != "" {
-					conf.Cloud.LocationSecrets[i].Secret = mask
-				}
-			}
-			// Not really a secret but annoying to diff
-			if conf.Cloud.TLSCertificate != "" {
-				conf.Cloud.TLSCertificate = mask
-			}
-			if conf.Cloud.TLSPrivateKey != "" {
-				conf.Cloud.TLSPrivateKey = mask
-			}
-		}
-		for _, hdlr := range conf.Auth.Handlers {
-			for key := range hdlr.Config {
-				hdlr.Config[key] = mask
-			}
-		}
-		for i := range conf.Remotes {
-			rem := &conf.Remotes[i]
-			if rem.Secret != "" {
-				rem.Secret = mask
-			}
-			if rem.Auth.Credentials != nil {
-				rem.Auth.Credentials.Payload = mask
-			}
-			if rem.Auth.SignalingCreds != nil {
-				rem.Auth.SignalingCreds.Payload = mask
-			}
-		}
+	if sortLists {
+		sortConfigLists(&left)
+		sortConfigLists(&right)
 	}
-	sanitizeConfig(&left)
-	sanitizeConfig(&right)
+
+	// Note(erd): keep in mind this will destroy the actual pretty diffing of sensitive fields
+	// which is fine because we aren't considering pretty diff changes to these fields at this
+	// level of the stack.
+	maskSensitive(&left)
+	maskSensitive(&right)
 
 	leftMd, err = json.MarshalIndent(left, "", " ")
 	if err != nil {
@@ -187,6 +346,18 @@ func prettyDiff(left, right Config) (string, error) {
 	return dmp.DiffPrettyText(filteredDiffs), nil
 }
 
+// maskClonedSecrets masks the resolved secrets of the resources of clone, a JSON copy of orig.
+func maskClonedSecrets(clone, orig *Config) {
+	for _, confs := range [][2][]resource.Config{{clone.Components, orig.Components}, {clone.Services, orig.Services}} {
+		if len(confs[0]) != len(confs[1]) {
+			continue
+		}
+		for i, conf := range confs[0] {
+			maskSecretAttributes(conf.Attributes, confs[1][i].SecretAttributePaths)
+		}
+	}
+}
+
 // String returns a pretty version of the diff.
 func (diff *Diff) String() string {
 	return diff.PrettyDiff
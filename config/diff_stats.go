@@ -0,0 +1,88 @@
+package config
+
+import "go.viam.com/rdk/resource"
+
+// DiffCounts counts the added, removed and modified items of one kind in a Diff.
+type DiffCounts struct {
+	Added    int
+	Removed  int
+	Modified int
+}
+
+// Total returns the number of changed items.
+func (c DiffCounts) Total() int {
+	return c.Added + c.Removed + c.Modified
+}
+
+// DiffStats summarizes a Diff so that orchestration can judge how disruptive applying it is,
+// such as to decide whether to apply it now or during a maintenance window.
+type DiffStats struct {
+	Components DiffCounts
+	Services   DiffCounts
+	Remotes    DiffCounts
+	Processes  DiffCounts
+	Packages   DiffCounts
+	Modules    DiffCounts
+	Jobs       DiffCounts
+
+	// TotalResources is the number of components and services in the new config.
+	TotalResources int
+	// ReconfigureCost is a unitless estimate of how much work applying the diff is, which is
+	// only meaningful relative to the cost of other diffs. See the reconfigureCost constants.
+	ReconfigureCost int
+}
+
+// Changes returns the number of changed resources, remotes, processes, packages, modules
+// and jobs.
+func (s DiffStats) Changes() int {
+	return s.Components.Total() + s.Services.Total() + s.Remotes.Total() + s.Processes.Total() +
+		s.Packages.Total() + s.Modules.Total() + s.Jobs.Total()
+}
+
+// The estimated cost of changing one of each kind of item. Changing a module restarts it along
+// with all of its resources, and changing a package may download it, so those cost the most.
+const (
+	reconfigureCostResource = 1
+	reconfigureCostJob      = 1
+	reconfigureCostProcess  = 2
+	reconfigureCostRemote   = 3
+	reconfigureCostPackage  = 5
+	reconfigureCostModule   = 10
+	// reconfigureCostNetwork is the cost of restarting the web server for a network change.
+	reconfigureCostNetwork = 10
+)
+
+func newDiffStats(diff *Diff) DiffStats {
+	stats := DiffStats{
+		Components: DiffCounts{len(diff.Added.Components), len(diff.Removed.Components), len(diff.Modified.Components)},
+		Services:   DiffCounts{len(diff.Added.Services), len(diff.Removed.Services), len(diff.Modified.Services)},
+		Remotes:    DiffCounts{len(diff.Added.Remotes), len(diff.Removed.Remotes), len(diff.Modified.Remotes)},
+		Processes:  DiffCounts{len(diff.Added.Processes), len(diff.Removed.Processes), len(diff.Modified.Processes)},
+		Packages:   DiffCounts{len(diff.Added.Packages), len(diff.Removed.Packages), len(diff.Modified.Packages)},
+		Modules:    DiffCounts{len(diff.Added.Modules), len(diff.Removed.Modules), len(diff.Modified.Modules)},
+		Jobs:       DiffCounts{len(diff.Added.Jobs), len(diff.Removed.Jobs), len(diff.Modified.Jobs)},
+	}
+	if diff.Right != nil {
+		stats.TotalResources = len(diff.Right.Components) + len(diff.Right.Services)
+	}
+
+	stats.ReconfigureCost = reconfigureCostResource*(stats.Components.Total()+stats.Services.Total()) +
+		reconfigureCostJob*stats.Jobs.Total() +
+		reconfigureCostProcess*stats.Processes.Total() +
+		reconfigureCostRemote*stats.Remotes.Total() +
+		reconfigureCostPackage*stats.Packages.Total() +
+		reconfigureCostModule*stats.Modules.Total()
+	// the resources of each associated API whose configs changed are reconfigured as well, once
+	// no matter how many resources changed their associated configs for that API.
+	changedAPIs := map[resource.API]struct{}{}
+	for _, assoc := range diff.Modified.AssociatedResourceConfigs {
+		for _, api := range assoc.APIs {
+			changedAPIs[api] = struct{}{}
+		}
+	}
+	stats.ReconfigureCost += reconfigureCostResource * len(changedAPIs)
+	if !diff.NetworkEqual {
+		stats.ReconfigureCost += reconfigureCostNetwork
+	}
+	return stats
+}
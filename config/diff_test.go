@@ -8,6 +8,7 @@ import (
 
 	"go.viam.com/test"
 	"go.viam.com/utils/pexec"
+	"go.viam.com/utils/rpc"
 
 	"go.viam.com/rdk/components/arm"
 	"go.viam.com/rdk/components/base"
@@ -461,6 +462,47 @@ func TestDiffConfigHeterogenousTypes(t *testing.T) {
 	}
 }
 
+func TestDiffOrdering(t *testing.T) {
+	left := config.Config{
+		Components: []resource.Config{{Name: "base1", API: base.API, Model: fakeModel}},
+	}
+	right := config.Config{
+		Components: []resource.Config{
+			{Name: "board1", API: board.API, Model: fakeModel},
+			{Name: "base2", API: base.API, Model: fakeModel},
+			{Name: "arm2", API: arm.API, Model: fakeModel},
+			{Name: "arm1", API: arm.API, Model: fakeModel},
+		},
+		Remotes: []config.Remote{{Name: "remote2"}, {Name: "remote1"}},
+	}
+
+	diff, err := config.DiffConfigs(left, right, false)
+	test.That(t, err, test.ShouldBeNil)
+	var names []string
+	for _, conf := range diff.Added.Components {
+		names = append(names, conf.Name)
+	}
+	test.That(t, names, test.ShouldResemble, []string{"arm1", "arm2", "base2", "board1"})
+	test.That(t, diff.Added.Remotes[0].Name, test.ShouldEqual, "remote1")
+	test.That(t, diff.Removed.Components[0].Name, test.ShouldEqual, "base1")
+
+	// the right config itself is not reordered
+	test.That(t, right.Components[0].Name, test.ShouldEqual, "board1")
+
+	reordered := right
+	reordered.Components = []resource.Config{right.Components[2], right.Components[0], right.Components[3], right.Components[1]}
+	reordered.Remotes = []config.Remote{right.Remotes[1], right.Remotes[0]}
+
+	opts := config.DiffOptions{RevealSensitiveConfigDiffs: true, SortPrettyDiff: true}
+	diff1, err := config.DiffConfigsWithOptions(left, right, opts)
+	test.That(t, err, test.ShouldBeNil)
+	diff2, err := config.DiffConfigsWithOptions(left, reordered, opts)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, diff1.PrettyDiff, test.ShouldNotBeEmpty)
+	test.That(t, diff2.PrettyDiff, test.ShouldEqual, diff1.PrettyDiff)
+	test.That(t, diff2.Added, test.ShouldResemble, diff1.Added)
+}
+
 func TestDiffNetworkingCfg(t *testing.T) {
 	network1 := config.NetworkConfig{NetworkConfigData: config.NetworkConfigData{FQDN: "abc"}}
 	network2 := config.NetworkConfig{NetworkConfigData: config.NetworkConfigData{FQDN: "xyz"}}
@@ -735,6 +777,57 @@ func modifiedConfigDiffValidate(c *config.ModifiedConfigDiff) error {
 	return nil
 }
 
+func TestDiffAssociatedResourceConfigs(t *testing.T) {
+	dataManagerAPI := resource.APINamespaceRDK.WithServiceType("data_manager")
+	captureConfig := func(freq float64) []resource.AssociatedResourceConfig {
+		return []resource.AssociatedResourceConfig{{
+			API: dataManagerAPI,
+			Attributes: utils.AttributeMap{
+				"capture_methods": []interface{}{map[string]interface{}{"method": "Position", "capture_frequency_hz": freq}},
+			},
+		}}
+	}
+	newConfig := func(armCapture, remoteCapture []resource.AssociatedResourceConfig) config.Config {
+		return config.Config{
+			Components: []resource.Config{
+				{Name: "arm1", API: arm.API, Model: fakeModel, AssociatedResourceConfigs: armCapture},
+				{Name: "base1", API: base.API, Model: fakeModel},
+			},
+			Services: []resource.Config{
+				{Name: "dm", API: dataManagerAPI, Model: resource.DefaultServiceModel},
+			},
+			Remotes: []config.Remote{{Name: "rem", Address: "addr", AssociatedResourceConfigs: remoteCapture}},
+		}
+	}
+
+	diff, err := config.DiffConfigs(newConfig(captureConfig(1), nil), newConfig(captureConfig(1), nil), false)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, diff.ResourcesEqual, test.ShouldBeTrue)
+	test.That(t, diff.Modified.AssociatedResourceConfigs, test.ShouldBeEmpty)
+
+	diff, err = config.DiffConfigs(newConfig(captureConfig(1), nil), newConfig(captureConfig(2), captureConfig(1)), false)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, diff.ResourcesEqual, test.ShouldBeFalse)
+	test.That(t, diff.Modified.Components, test.ShouldBeEmpty)
+	test.That(t, diff.Modified.Remotes, test.ShouldBeEmpty)
+	test.That(t, diff.Modified.AssociatedResourceConfigs, test.ShouldHaveLength, 2)
+	armDiff := diff.Modified.AssociatedResourceConfigs[0]
+	test.That(t, armDiff.Resource, test.ShouldResemble, arm.Named("arm1"))
+	test.That(t, armDiff.APIs, test.ShouldResemble, []resource.API{dataManagerAPI})
+	test.That(t, armDiff.Right, test.ShouldResemble, captureConfig(2))
+	test.That(t, diff.Modified.AssociatedResourceConfigs[1].Remote, test.ShouldEqual, "rem")
+
+	// the data manager picks up the new capture settings
+	test.That(t, diff.Modified.Services, test.ShouldHaveLength, 1)
+	test.That(t, diff.Modified.Services[0].Name, test.ShouldEqual, "dm")
+
+	// removing every associated config is also a change
+	diff, err = config.DiffConfigs(newConfig(captureConfig(1), nil), newConfig(nil, nil), false)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, diff.Modified.AssociatedResourceConfigs, test.ShouldHaveLength, 1)
+	test.That(t, diff.Modified.Services, test.ShouldHaveLength, 1)
+}
+
 func TestDiffRevision(t *testing.T) {
 	type testcase struct {
 		name         string
@@ -1048,3 +1141,117 @@ func TestDiffJobCfg(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffMasksSensitiveFields(t *testing.T) {
+	type secretConfig struct {
+		resource.TriviallyValidateConfig
+		Host   string `json:"host"`
+		APIKey string `json:"api_key" config:"secret"`
+	}
+	secretModel := resource.NewModel("acme", "sensitive", "arm")
+	resource.RegisterComponent(arm.API, secretModel, resource.Registration[arm.Arm, *secretConfig]{
+		Constructor: func(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (arm.Arm, error) {
+			return nil, nil
+		},
+	})
+	defer resource.Deregister(arm.API, secretModel)
+	config.RegisterSensitiveAttributes(base.API, extModel, "token")
+
+	left := config.Config{}
+	right := config.Config{
+		Components: []resource.Config{
+			{
+				Name:       "arm1",
+				API:        arm.API,
+				Model:      secretModel,
+				Attributes: utils.AttributeMap{"host": "arm.local", "api_key": "arm-key"},
+			},
+			{
+				Name:       "base1",
+				API:        base.API,
+				Model:      extModel,
+				Attributes: utils.AttributeMap{"token": "base-token"},
+			},
+		},
+		Remotes: []config.Remote{{
+			Name:    "remote1",
+			Address: "localhost:8080",
+			Auth:    config.RemoteAuth{Credentials: &rpc.Credentials{Type: rpc.CredentialsTypeAPIKey, Payload: "remote-key"}},
+		}},
+		Cloud: &config.Cloud{ID: "robot1", Secret: "cloud-secret", TLSPrivateKey: "private-key"},
+	}
+
+	diff, err := config.DiffConfigs(left, right, true)
+	test.That(t, err, test.ShouldBeNil)
+	for _, secret := range []string{"arm-key", "base-token", "remote-key", "cloud-secret", "private-key"} {
+		test.That(t, diff.PrettyDiff, test.ShouldNotContainSubstring, secret)
+	}
+	test.That(t, diff.PrettyDiff, test.ShouldContainSubstring, "arm.local")
+	test.That(t, diff.PrettyDiff, test.ShouldContainSubstring, string(rpc.CredentialsTypeAPIKey))
+
+	// the configs themselves are not masked
+	test.That(t, right.Components[0].Attributes["api_key"], test.ShouldEqual, "arm-key")
+	test.That(t, right.Remotes[0].Auth.Credentials.Payload, test.ShouldEqual, "remote-key")
+}
+
+func TestDiffStats(t *testing.T) {
+	left := config.Config{
+		Components: []resource.Config{{Name: "comp1", API: arm.API}, {Name: "comp2", API: arm.API}},
+		Services:   []resource.Config{{Name: "serv1"}},
+		Modules:    []config.Module{{Name: "mod1", ExePath: "/bin/mod1"}},
+	}
+	right := config.Config{
+		Components: []resource.Config{
+			{Name: "comp1", API: arm.API, Attributes: utils.AttributeMap{"value": 1}},
+			{Name: "comp3", API: arm.API},
+			{Name: "comp4", API: arm.API},
+		},
+		Services: []resource.Config{{Name: "serv1"}},
+		Modules:  []config.Module{{Name: "mod1", ExePath: "/bin/mod1-v2"}},
+	}
+	diff, err := config.DiffConfigs(left, right, false)
+	test.That(t, err, test.ShouldBeNil)
+
+	stats := diff.Stats
+	test.That(t, stats.Components, test.ShouldResemble, config.DiffCounts{Added: 2, Removed: 1, Modified: 1})
+	test.That(t, stats.Services, test.ShouldResemble, config.DiffCounts{})
+	test.That(t, stats.Modules, test.ShouldResemble, config.DiffCounts{Modified: 1})
+	test.That(t, stats.TotalResources, test.ShouldEqual, 4)
+	test.That(t, stats.Changes(), test.ShouldEqual, 5)
+	// the module change outweighs the resource changes
+	test.That(t, stats.ReconfigureCost, test.ShouldBeGreaterThan, 2*stats.Components.Total())
+
+	diff, err = config.DiffConfigs(right, right, false)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, diff.Stats.Changes(), test.ShouldEqual, 0)
+	test.That(t, diff.Stats.ReconfigureCost, test.ShouldEqual, 0)
+}
+
+func TestDiffStatsAssociatedResourceConfigs(t *testing.T) {
+	dataManagerAPI := resource.APINamespaceRDK.WithServiceType("data_manager")
+	newConfig := func(freq float64) config.Config {
+		capture := []resource.AssociatedResourceConfig{{
+			API:        dataManagerAPI,
+			Attributes: utils.AttributeMap{"capture_frequency_hz": freq},
+		}}
+		return config.Config{
+			Components: []resource.Config{
+				{Name: "arm1", API: arm.API, Model: fakeModel, AssociatedResourceConfigs: capture},
+				{Name: "arm2", API: arm.API, Model: fakeModel, AssociatedResourceConfigs: capture},
+			},
+			Services: []resource.Config{
+				{Name: "dm", API: dataManagerAPI, Model: resource.DefaultServiceModel},
+			},
+		}
+	}
+	diff, err := config.DiffConfigs(newConfig(1), newConfig(2), false)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, diff.Modified.AssociatedResourceConfigs, test.ShouldHaveLength, 2)
+
+	// both arms change the configs of the same data manager, which is only charged once on top
+	// of being modified.
+	stats := diff.Stats
+	test.That(t, stats.Components, test.ShouldResemble, config.DiffCounts{})
+	test.That(t, stats.Services, test.ShouldResemble, config.DiffCounts{Modified: 1})
+	test.That(t, stats.ReconfigureCost, test.ShouldEqual, 2)
+}
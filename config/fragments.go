@@ -0,0 +1,177 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+// localFragmentsKey is the key of the fragments defined inline in a config. It is distinct from
+// "fragments", which app uses for the IDs of the fragments a machine's config is built from.
+const localFragmentsKey = "local_fragments"
+
+// fragmentItemKeys are the keys of a fragment whose items are merged into the config.
+var fragmentItemKeys = []string{"components", "services", "modules"}
+
+// expandFragments returns the config JSON with the components, services and modules of every
+// fragment in "local_fragments" merged into it. The ${variables.<name>} placeholders of a fragment are
+// substituted before it is merged, with the fragment's own variables overridden by those of the
+// config, so that machines that share a fragment only need to set the variables that differ.
+// Items are matched by name: those the config defines itself take precedence over those of
+// fragments, and those of earlier fragments over those of later ones.
+func expandFragments(data []byte) ([]byte, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		// leave reporting the error to the full unmarshal
+		return data, nil //nolint:nilerr
+	}
+	if _, ok := raw[localFragmentsKey]; !ok {
+		return data, nil
+	}
+
+	var fragments []map[string]interface{}
+	if err := json.Unmarshal(raw[localFragmentsKey], &fragments); err != nil {
+		return nil, errors.Wrapf(err, "error parsing %s", localFragmentsKey)
+	}
+	var variables map[string]interface{}
+	if vars, ok := raw["variables"]; ok {
+		if err := json.Unmarshal(vars, &variables); err != nil {
+			return data, nil //nolint:nilerr
+		}
+	}
+
+	items := map[string][]interface{}{}
+	seen := map[string]map[string]struct{}{}
+	for _, key := range fragmentItemKeys {
+		seen[key] = map[string]struct{}{}
+		if list, ok := raw[key]; ok {
+			var parsed []interface{}
+			if err := json.Unmarshal(list, &parsed); err != nil {
+				return data, nil //nolint:nilerr
+			}
+			items[key] = parsed
+		}
+		for _, item := range items[key] {
+			if name, ok := itemName(item); ok {
+				seen[key][name] = struct{}{}
+			}
+		}
+	}
+
+	for idx, fragment := range fragments {
+		path := fmt.Sprintf("%s.%d", localFragmentsKey, idx)
+		fragmentVariables, err := parseFragmentVariables(path, fragment)
+		if err != nil {
+			return nil, err
+		}
+		visitor := &placeholderReplacementVisitor{variables: mergeVariables(fragmentVariables, variables)}
+		for key := range fragment {
+			if key != "name" && key != "variables" && !slices.Contains(fragmentItemKeys, key) {
+				return nil, errors.Errorf("%s: fragments cannot set %q", path, key)
+			}
+		}
+		for _, key := range fragmentItemKeys {
+			if fragment[key] == nil {
+				continue
+			}
+			list, ok := fragment[key].([]interface{})
+			if !ok {
+				return nil, errors.Errorf("%s.%s must be a list", path, key)
+			}
+			for _, item := range list {
+				name, ok := itemName(item)
+				if ok {
+					if _, exists := seen[key][name]; exists {
+						continue
+					}
+					seen[key][name] = struct{}{}
+				}
+				items[key] = append(items[key], visitor.replaceVariables(item))
+			}
+		}
+	}
+
+	delete(raw, localFragmentsKey)
+	for _, key := range fragmentItemKeys {
+		if items[key] == nil {
+			continue
+		}
+		list, err := json.Marshal(items[key])
+		if err != nil {
+			return nil, err
+		}
+		raw[key] = list
+	}
+	return json.Marshal(raw)
+}
+
+// parseFragmentVariables returns the variables block of a fragment.
+func parseFragmentVariables(path string, fragment map[string]interface{}) (map[string]interface{}, error) {
+	if fragment["variables"] == nil {
+		return nil, nil
+	}
+	variables, ok := fragment["variables"].(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("%s.variables must be an object", path)
+	}
+	for name := range variables {
+		if !variableNameRegexp.MatchString(name) {
+			return nil, errors.Errorf("%s.variables.%s: variable names may only contain letters, numbers, underscores and hyphens",
+				path, name)
+		}
+	}
+	return variables, nil
+}
+
+func itemName(item interface{}) (string, bool) {
+	obj, ok := item.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	name, ok := obj["name"].(string)
+	return name, ok
+}
+
+// replaceVariables substitutes the variable placeholders in the strings of a JSON value, leaving
+// any other placeholders, and those of variables without a value, to be replaced or reported
+// once the config is processed.
+func (v *placeholderReplacementVisitor) replaceVariables(value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		// a string that is exactly one variable placeholder takes on the variable's value as is.
+		if matches := placeholderRegexp.FindStringSubmatchIndex(value); matches != nil &&
+			matches[0] == 0 && matches[1] == len(value) {
+			key := value[matches[2]:matches[3]]
+			if variablePlaceholderRegexp.MatchString(key) {
+				if replaced, err := v.lookupVariable(key); err == nil {
+					return replaced
+				}
+			}
+		}
+		return placeholderRegexp.ReplaceAllStringFunc(value, func(placeholder string) string {
+			key := placeholderRegexp.FindStringSubmatch(placeholder)[placeholderRegexp.SubexpIndex("placeholder_key")]
+			if !variablePlaceholderRegexp.MatchString(key) {
+				return placeholder
+			}
+			replaced, err := v.replaceVariablePlaceholder(key)
+			if err != nil {
+				return placeholder
+			}
+			return replaced
+		})
+	case []interface{}:
+		for idx := range value {
+			value[idx] = v.replaceVariables(value[idx])
+		}
+		return value
+	case map[string]interface{}:
+		for key := range value {
+			value[key] = v.replaceVariables(value[key])
+		}
+		return value
+	default:
+		return value
+	}
+}
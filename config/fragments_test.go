@@ -0,0 +1,101 @@
+package config_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/utils"
+)
+
+func TestFragments(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+
+	cfgText := `{
+		"variables": {"wheel_diameter_mm": 120, "serial": "rover-7"},
+		"local_fragments": [
+			{
+				"name": "rover",
+				"variables": {"wheel_diameter_mm": 100, "camera_index": 0},
+				"components": [
+					{
+						"name": "base1",
+						"api": "rdk:component:base",
+						"model": "rdk:builtin:fake",
+						"attributes": {
+							"wheel_diameter_mm": "${variables.wheel_diameter_mm}",
+							"camera_index": "${variables.camera_index}",
+							"label": "${variables.serial}-base"
+						}
+					},
+					{"name": "arm1", "api": "rdk:component:arm", "model": "rdk:builtin:fake"}
+				]
+			},
+			{
+				"name": "sensors",
+				"variables": {"camera_index": 2},
+				"components": [
+					{
+						"name": "sensor1",
+						"api": "rdk:component:sensor",
+						"model": "rdk:builtin:fake",
+						"attributes": {"camera_index": "${variables.camera_index}"}
+					}
+				]
+			}
+		],
+		"components": [
+			{"name": "arm1", "api": "rdk:component:arm", "model": "rdk:builtin:fake", "attributes": {"local": true}}
+		]
+	}`
+	cfg, err := config.FromReader(ctx, "", strings.NewReader(cfgText), logger, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cfg.Components, test.ShouldHaveLength, 3)
+
+	// the config's own components take precedence over those of fragments
+	test.That(t, cfg.Components[0].Name, test.ShouldEqual, "arm1")
+	test.That(t, cfg.Components[0].Attributes, test.ShouldResemble, utils.AttributeMap{"local": true})
+
+	// variables set by the config override those of the fragment
+	test.That(t, cfg.Components[1].Name, test.ShouldEqual, "base1")
+	test.That(t, cfg.Components[1].Attributes, test.ShouldResemble, utils.AttributeMap{
+		"wheel_diameter_mm": 120.0,
+		"camera_index":      0.0,
+		"label":             "rover-7-base",
+	})
+
+	// each fragment is substituted with its own variables
+	test.That(t, cfg.Components[2].Name, test.ShouldEqual, "sensor1")
+	test.That(t, cfg.Components[2].Attributes, test.ShouldResemble, utils.AttributeMap{"camera_index": 2.0})
+	test.That(t, cfg.Variables, test.ShouldResemble, map[string]interface{}{"wheel_diameter_mm": 120.0, "serial": "rover-7"})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := config.FromReader(ctx, "", strings.NewReader(`{
+			"local_fragments": [{"name": "rover", "remotes": []}]
+		}`), logger, nil)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, `fragments cannot set "remotes"`)
+
+		_, err = config.FromReader(ctx, "", strings.NewReader(`{
+			"local_fragments": [{"name": "rover", "variables": {"bad name": 1}}]
+		}`), logger, nil)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "variable names")
+	})
+
+	t.Run("fragment ids", func(t *testing.T) {
+		// app lists the IDs of a machine's fragments under "fragments"; they are not inline fragments.
+		cfg, err := config.FromReader(ctx, "", strings.NewReader(`{
+			"fragments": ["3a7c5b2e-9f41-4d6a-8e0b-12c4f5a6d7e8"],
+			"components": [{"name": "arm1", "api": "rdk:component:arm", "model": "rdk:builtin:fake"}]
+		}`), logger, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, cfg.Components, test.ShouldHaveLength, 1)
+		test.That(t, cfg.Components[0].Name, test.ShouldEqual, "arm1")
+	})
+}
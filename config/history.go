@@ -0,0 +1,212 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.viam.com/utils/artifact"
+
+	"go.viam.com/rdk/logging"
+	rutils "go.viam.com/rdk/utils"
+)
+
+// DefaultHistorySize is the number of applied configs kept in a config history by default.
+const DefaultHistorySize = 10
+
+const historyFileExt = ".json"
+
+// DefaultHistoryDirectory returns the directory used to store the config history of the
+// machine part with the given id.
+func DefaultHistoryDirectory(id string) string {
+	return filepath.Join(rutils.ViamDotDir, "config_history", id)
+}
+
+// HistoryEntry describes a config that was previously applied to the robot.
+type HistoryEntry struct {
+	// Revision identifies the config. Configs from the cloud use their cloud revision, local
+	// configs use a hash of their contents.
+	Revision  string    `json:"revision"`
+	AppliedAt time.Time `json:"applied_at"`
+	FromCloud bool      `json:"from_cloud"`
+}
+
+type historyRecord struct {
+	HistoryEntry
+	Config json.RawMessage `json:"config"`
+	// Variables are the values of the variables that a config from the cloud was applied with,
+	// which include those from the local config file that the stored config does not.
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// History is a ring of the most recently applied configs, persisted to disk so that a
+// machine can be rolled back to a known good config after a bad config push.
+type History struct {
+	mu      sync.Mutex
+	dir     string
+	size    int
+	entries []HistoryEntry
+	files   []string
+}
+
+// NewHistory returns a History which keeps up to size configs in dir, loading any
+// configs already stored there.
+func NewHistory(dir string, size int) (*History, error) {
+	if size <= 0 {
+		return nil, errors.Errorf("config history size must be positive, got %d", size)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	h := &History{dir: dir, size: size}
+
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range dirEntries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), historyFileExt) {
+			continue
+		}
+		files = append(files, e.Name())
+	}
+	// file names are zero padded timestamps so they sort oldest first.
+	sort.Strings(files)
+	for _, f := range files {
+		record, err := h.readRecord(f)
+		if err != nil {
+			// skip anything we cannot parse rather than refusing to start.
+			continue
+		}
+		h.entries = append(h.entries, record.HistoryEntry)
+		h.files = append(h.files, f)
+	}
+	if err := h.trim(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Record stores cfg as the most recently applied config. Recording a config with the same
+// revision as the latest entry is a no-op. The config is stored as it was before being processed,
+// so that secrets it references are resolved again when it is loaded rather than stored.
+func (h *History) Record(cfg *Config) error {
+	data := cfg.toCache
+	if data == nil {
+		data = cfg.unprocessed
+	}
+	if data == nil {
+		// the config was never processed, so there is nothing resolved in it
+		var err error
+		if data, err = json.Marshal(cfg); err != nil {
+			return errors.Wrap(err, "cannot marshal config for history")
+		}
+	}
+	entry := HistoryEntry{
+		Revision:  cfg.Revision,
+		AppliedAt: time.Now(),
+		FromCloud: cfg.Cloud != nil,
+	}
+	if entry.Revision == "" {
+		sum := sha256.Sum256(data)
+		entry.Revision = "local-" + hex.EncodeToString(sum[:])[:12]
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if n := len(h.entries); n != 0 && h.entries[n-1].Revision == entry.Revision {
+		return nil
+	}
+
+	record := historyRecord{HistoryEntry: entry, Config: data}
+	if entry.FromCloud {
+		record.Variables = cfg.Variables
+	}
+	recordData, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%020d%s", entry.AppliedAt.UnixNano(), historyFileExt)
+	if err := artifact.AtomicStore(filepath.Join(h.dir, name), bytes.NewReader(recordData), name); err != nil {
+		return err
+	}
+	h.entries = append(h.entries, entry)
+	h.files = append(h.files, name)
+	return h.trim()
+}
+
+// Entries returns the stored configs, oldest first.
+func (h *History) Entries() []HistoryEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]HistoryEntry(nil), h.entries...)
+}
+
+// Load reads and processes the most recent config stored with the given revision.
+func (h *History) Load(revision string, logger logging.Logger) (*Config, error) {
+	h.mu.Lock()
+	var file string
+	for i := len(h.entries) - 1; i >= 0; i-- {
+		if h.entries[i].Revision == revision {
+			file = h.files[i]
+			break
+		}
+	}
+	h.mu.Unlock()
+	if file == "" {
+		return nil, errors.Errorf("config revision %q not found in history", revision)
+	}
+
+	record, err := h.readRecord(file)
+	if err != nil {
+		return nil, err
+	}
+	var unprocessedConfig Config
+	if err := json.Unmarshal(record.Config, &unprocessedConfig); err != nil {
+		return nil, errors.Wrapf(err, "cannot parse config revision %q", revision)
+	}
+	unprocessedConfig.Variables = mergeVariables(unprocessedConfig.Variables, record.Variables)
+	cfg, err := processConfig(&unprocessedConfig, record.FromCloud, logger)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot process config revision %q", revision)
+	}
+	cfg.Revision = record.Revision
+	return cfg, nil
+}
+
+func (h *History) readRecord(name string) (*historyRecord, error) {
+	//nolint:gosec
+	data, err := os.ReadFile(filepath.Join(h.dir, name))
+	if err != nil {
+		return nil, err
+	}
+	var record historyRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, errors.Wrapf(err, "cannot parse config history file %s", name)
+	}
+	return &record, nil
+}
+
+// trim removes the oldest entries beyond the history size. Must be called with mu held
+// or before the history is shared.
+func (h *History) trim() error {
+	var err error
+	for len(h.entries) > h.size {
+		if rmErr := os.Remove(filepath.Join(h.dir, h.files[0])); rmErr != nil && !os.IsNotExist(rmErr) {
+			err = rmErr
+		}
+		h.entries = h.entries[1:]
+		h.files = h.files[1:]
+	}
+	return err
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/utils"
+)
+
+func TestHistory(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	dir := t.TempDir()
+
+	h, err := NewHistory(dir, 2)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, h.Entries(), test.ShouldBeEmpty)
+
+	confWithMotor := func(name string) *Config {
+		return &Config{
+			Components: []resource.Config{{
+				Name:  name,
+				API:   resource.APINamespaceRDK.WithComponentType("motor"),
+				Model: resource.DefaultModelFamily.WithModel("fake"),
+			}},
+		}
+	}
+
+	cfg1 := confWithMotor("m1")
+	cfg1.Revision = "rev1"
+	test.That(t, h.Record(cfg1), test.ShouldBeNil)
+	// recording the same revision again is a no-op
+	test.That(t, h.Record(cfg1), test.ShouldBeNil)
+	test.That(t, h.Entries(), test.ShouldHaveLength, 1)
+
+	// local configs without a revision get one from their contents
+	cfg2 := confWithMotor("m2")
+	test.That(t, h.Record(cfg2), test.ShouldBeNil)
+	entries := h.Entries()
+	test.That(t, entries, test.ShouldHaveLength, 2)
+	test.That(t, entries[0].Revision, test.ShouldEqual, "rev1")
+	test.That(t, entries[1].Revision, test.ShouldStartWith, "local-")
+	localRev := entries[1].Revision
+
+	loaded, err := h.Load("rev1", logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, loaded.Revision, test.ShouldEqual, "rev1")
+	test.That(t, loaded.Components, test.ShouldHaveLength, 1)
+	test.That(t, loaded.Components[0].Name, test.ShouldEqual, "m1")
+
+	_, err = h.Load("not-there", logger)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "not found")
+
+	// the oldest config falls off the end of the ring
+	cfg3 := confWithMotor("m3")
+	cfg3.Revision = "rev3"
+	test.That(t, h.Record(cfg3), test.ShouldBeNil)
+	entries = h.Entries()
+	test.That(t, entries, test.ShouldHaveLength, 2)
+	test.That(t, entries[0].Revision, test.ShouldEqual, localRev)
+	test.That(t, entries[1].Revision, test.ShouldEqual, "rev3")
+	files, err := os.ReadDir(dir)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, files, test.ShouldHaveLength, 2)
+
+	// history survives a restart
+	h, err = NewHistory(dir, 2)
+	test.That(t, err, test.ShouldBeNil)
+	reloaded := h.Entries()
+	test.That(t, reloaded, test.ShouldHaveLength, 2)
+	test.That(t, reloaded[0].Revision, test.ShouldEqual, localRev)
+	test.That(t, reloaded[1].Revision, test.ShouldEqual, "rev3")
+	loaded, err = h.Load(localRev, logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, loaded.Components[0].Name, test.ShouldEqual, "m2")
+}
+
+func TestHistoryKeepsSecretsUnresolved(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	t.Setenv("TEST_HISTORY_SECRET", "history-secret-value")
+	dir := t.TempDir()
+	h, err := NewHistory(dir, 2)
+	test.That(t, err, test.ShouldBeNil)
+
+	cfg, err := processConfig(&Config{
+		Revision: "rev1",
+		Components: []resource.Config{{
+			Name:       "m1",
+			API:        resource.APINamespaceRDK.WithComponentType("motor"),
+			Model:      resource.DefaultModelFamily.WithModel("fake"),
+			Attributes: utils.AttributeMap{"token": map[string]interface{}{"secret_ref": "env:TEST_HISTORY_SECRET"}},
+		}},
+	}, false, logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cfg.Components[0].Attributes["token"], test.ShouldEqual, "history-secret-value")
+	test.That(t, h.Record(cfg), test.ShouldBeNil)
+
+	files, err := os.ReadDir(dir)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, files, test.ShouldHaveLength, 1)
+	data, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, string(data), test.ShouldNotContainSubstring, "history-secret-value")
+	test.That(t, string(data), test.ShouldContainSubstring, "env:TEST_HISTORY_SECRET")
+
+	// the secret is resolved again when the config is loaded
+	loaded, err := h.Load("rev1", logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, loaded.Components[0].Attributes["token"], test.ShouldEqual, "history-secret-value")
+}
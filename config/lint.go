@@ -0,0 +1,228 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.viam.com/rdk/resource"
+)
+
+// A LintFinding is a likely mistake found in a config by a LintRule. Unlike validation
+// errors, findings do not stop the config from being used.
+type LintFinding struct {
+	// Rule is the name of the rule that made the finding.
+	Rule     string
+	Severity resource.ConfigWarningSeverity
+	// Path is the location of the finding in the config, like "components.2".
+	Path    string
+	Message string
+}
+
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%s: %s (%s, %s)", f.Path, f.Message, f.Rule, f.Severity)
+}
+
+// A LintRule checks a config for one kind of likely mistake.
+type LintRule struct {
+	Name        string
+	Description string
+	Check       func(cfg *Config) []LintFinding
+}
+
+var (
+	lintRulesMu sync.RWMutex
+	lintRules   = map[string]LintRule{}
+)
+
+// RegisterLintRule registers a rule that Lint runs on every config. It panics if a rule with
+// the same name is already registered.
+func RegisterLintRule(rule LintRule) {
+	lintRulesMu.Lock()
+	defer lintRulesMu.Unlock()
+	if _, ok := lintRules[rule.Name]; ok {
+		panic(fmt.Sprintf("lint rule %q already registered", rule.Name))
+	}
+	if rule.Check == nil {
+		panic(fmt.Sprintf("lint rule %q has no check", rule.Name))
+	}
+	lintRules[rule.Name] = rule
+}
+
+// DeregisterLintRule removes a previously registered rule.
+func DeregisterLintRule(name string) {
+	lintRulesMu.Lock()
+	defer lintRulesMu.Unlock()
+	delete(lintRules, name)
+}
+
+// RegisteredLintRules returns the registered rules sorted by name.
+func RegisteredLintRules() []LintRule {
+	lintRulesMu.RLock()
+	defer lintRulesMu.RUnlock()
+	rules := make([]LintRule, 0, len(lintRules))
+	for _, rule := range lintRules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return rules
+}
+
+// Lint runs every registered rule on the config and returns their findings sorted by path
+// and then rule. Each finding's Rule is set to the name of the rule that made it.
+func Lint(cfg *Config) []LintFinding {
+	var findings []LintFinding
+	for _, rule := range RegisteredLintRules() {
+		for _, f := range rule.Check(cfg) {
+			f.Rule = rule.Name
+			findings = append(findings, f)
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Path != findings[j].Path {
+			return findings[i].Path < findings[j].Path
+		}
+		return findings[i].Rule < findings[j].Rule
+	})
+	return findings
+}
+
+func init() {
+	RegisterLintRule(LintRule{
+		Name:        "unused-remote",
+		Description: "remotes that no resource depends on",
+		Check:       lintUnusedRemotes,
+	})
+	RegisterLintRule(LintRule{
+		Name:        "duplicate-address",
+		Description: "remotes that share an address",
+		Check:       lintDuplicateAddresses,
+	})
+	RegisterLintRule(LintRule{
+		Name:        "unconsumed-component",
+		Description: "components that no other resource depends on or uses as a frame parent",
+		Check:       lintUnconsumedComponents,
+	})
+	RegisterLintRule(LintRule{
+		Name:        "deprecated-model",
+		Description: "resources whose model is registered as deprecated",
+		Check:       lintDeprecatedModels,
+	})
+}
+
+// dependencyRefs returns every dependency and frame parent referenced by the config's
+// resources, as written.
+func dependencyRefs(cfg *Config) []string {
+	var refs []string
+	for _, confs := range [][]resource.Config{cfg.Components, cfg.Services} {
+		for _, conf := range confs {
+			refs = append(refs, conf.DependsOn...)
+			refs = append(refs, conf.ImplicitDependsOn...)
+			refs = append(refs, conf.ImplicitOptionalDependsOn...)
+			if conf.Frame != nil && conf.Frame.Parent != "" {
+				refs = append(refs, conf.Frame.Parent)
+			}
+		}
+	}
+	return refs
+}
+
+// refRemoteAndName splits a dependency reference, which is either a full resource name or
+// a name that may be prefixed by its remote, into its remote and short name.
+func refRemoteAndName(ref string) (string, string) {
+	if name, err := resource.NewFromString(ref); err == nil {
+		return name.Remote, name.Name
+	}
+	if idx := strings.LastIndex(ref, ":"); idx >= 0 {
+		return ref[:idx], ref[idx+1:]
+	}
+	return "", ref
+}
+
+func lintUnusedRemotes(cfg *Config) []LintFinding {
+	used := map[string]bool{}
+	for _, ref := range dependencyRefs(cfg) {
+		remote, _ := refRemoteAndName(ref)
+		// remotes of remotes are referenced by their first hop
+		if idx := strings.Index(remote, ":"); idx >= 0 {
+			remote = remote[:idx]
+		}
+		used[remote] = true
+	}
+	var findings []LintFinding
+	for idx, remote := range cfg.Remotes {
+		if used[remote.Name] || remote.Frame != nil {
+			continue
+		}
+		findings = append(findings, LintFinding{
+			Severity: resource.ConfigWarningSeverityInfo,
+			Path:     fmt.Sprintf("%s.%d", "remotes", idx),
+			Message:  fmt.Sprintf("no resource depends on remote %q", remote.Name),
+		})
+	}
+	return findings
+}
+
+func lintDuplicateAddresses(cfg *Config) []LintFinding {
+	firstByAddress := map[string]int{}
+	var findings []LintFinding
+	for idx, remote := range cfg.Remotes {
+		if remote.Address == "" {
+			continue
+		}
+		if first, ok := firstByAddress[remote.Address]; ok {
+			findings = append(findings, LintFinding{
+				Severity: resource.ConfigWarningSeverityWarning,
+				Path:     fmt.Sprintf("%s.%d", "remotes", idx),
+				Message: fmt.Sprintf("remote %q has the same address %q as remote %q",
+					remote.Name, remote.Address, cfg.Remotes[first].Name),
+			})
+			continue
+		}
+		firstByAddress[remote.Address] = idx
+	}
+	return findings
+}
+
+func lintUnconsumedComponents(cfg *Config) []LintFinding {
+	consumed := map[string]bool{}
+	for _, ref := range dependencyRefs(cfg) {
+		if remote, name := refRemoteAndName(ref); remote == "" {
+			consumed[name] = true
+		}
+	}
+	var findings []LintFinding
+	for idx, conf := range cfg.Components {
+		if consumed[conf.Name] {
+			continue
+		}
+		findings = append(findings, LintFinding{
+			Severity: resource.ConfigWarningSeverityInfo,
+			Path:     fmt.Sprintf("%s.%d", "components", idx),
+			Message:  fmt.Sprintf("no other resource depends on component %q; it is only usable by clients", conf.Name),
+		})
+	}
+	return findings
+}
+
+func lintDeprecatedModels(cfg *Config) []LintFinding {
+	var findings []LintFinding
+	for _, section := range []struct {
+		name  string
+		confs []resource.Config
+	}{{"components", cfg.Components}, {"services", cfg.Services}} {
+		for idx, conf := range section.confs {
+			reg, ok := resource.LookupRegistration(conf.API, conf.Model)
+			if !ok || reg.Deprecated == "" {
+				continue
+			}
+			findings = append(findings, LintFinding{
+				Severity: resource.ConfigWarningSeverityWarning,
+				Path:     fmt.Sprintf("%s.%d", section.name, idx),
+				Message:  fmt.Sprintf("model %s is deprecated: %s", conf.Model, reg.Deprecated),
+			})
+		}
+	}
+	return findings
+}
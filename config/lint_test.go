@@ -0,0 +1,79 @@
+package config_test
+
+import (
+	"context"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/base"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+)
+
+func TestLint(t *testing.T) {
+	deprecatedModel := resource.NewModel("acme", "lint", "old-arm")
+	resource.RegisterComponent(arm.API, deprecatedModel, resource.Registration[arm.Arm, resource.NoNativeConfig]{
+		Constructor: func(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (arm.Arm, error) {
+			return nil, nil
+		},
+		Deprecated: "use acme:lint:arm instead",
+	})
+	defer resource.Deregister(arm.API, deprecatedModel)
+
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{Name: "arm1", API: arm.API, Model: deprecatedModel},
+			{Name: "base1", API: base.API, Model: fakeModel, DependsOn: []string{"remote1:sensor1"}},
+			{
+				Name:  "base2",
+				API:   base.API,
+				Model: fakeModel,
+				Frame: &referenceframe.LinkConfig{Parent: "arm1"},
+			},
+		},
+		Services: []resource.Config{
+			{Name: "nav", API: resource.APINamespaceRDK.WithServiceType("navigation"), Model: fakeModel, DependsOn: []string{"base1"}},
+		},
+		Remotes: []config.Remote{
+			{Name: "remote1", Address: "localhost:8081"},
+			{Name: "remote2", Address: "localhost:8082"},
+			{Name: "remote3", Address: "localhost:8081"},
+		},
+	}
+
+	type finding struct{ rule, path string }
+	var got []finding
+	for _, f := range config.Lint(cfg) {
+		test.That(t, f.Message, test.ShouldNotBeEmpty)
+		got = append(got, finding{f.Rule, f.Path})
+	}
+	test.That(t, got, test.ShouldResemble, []finding{
+		{"deprecated-model", "components.0"},
+		{"unconsumed-component", "components.2"},
+		{"unused-remote", "remotes.1"},
+		{"duplicate-address", "remotes.2"},
+		{"unused-remote", "remotes.2"},
+	})
+
+	t.Run("custom rules", func(t *testing.T) {
+		config.RegisterLintRule(config.LintRule{
+			Name: "no-services",
+			Check: func(cfg *config.Config) []config.LintFinding {
+				if len(cfg.Services) == 0 {
+					return nil
+				}
+				return []config.LintFinding{{Severity: resource.ConfigWarningSeverityWarning, Path: "services", Message: "no services"}}
+			},
+		})
+		defer config.DeregisterLintRule("no-services")
+
+		findings := config.Lint(cfg)
+		test.That(t, findings[len(findings)-1].Rule, test.ShouldEqual, "no-services")
+		test.That(t, findings[len(findings)-1].Severity, test.ShouldEqual, resource.ConfigWarningSeverityWarning)
+		test.That(t, func() { config.RegisterLintRule(config.LintRule{Name: "no-services"}) }, test.ShouldPanic)
+	})
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"reflect"
@@ -28,6 +29,14 @@ var packagePlaceholderRegexp = regexp.MustCompile(`^packages(\.(?P<type>[^\.]+))
 // This is compatible with IEEE Std 1003.1-2018 (see basedefs/V1_chap08.html).
 var environmentPlaceholderRegexp = regexp.MustCompile(`^environment\.(?P<name>[\w:/-]+)$`)
 
+// variablePlaceholderRegexp matches on placeholders for the config's variables
+// Example string satisfying the regex:
+// variables.wheel_diameter_mm.
+var variablePlaceholderRegexp = regexp.MustCompile(`^variables\.(?P<name>[\w-]+)$`)
+
+// variableNameRegexp matches valid names in the config's variables block.
+var variableNameRegexp = regexp.MustCompile(`^[\w-]+$`)
+
 // ContainsPlaceholder returns true if the passed string contains a placeholder.
 func ContainsPlaceholder(s string) bool {
 	return placeholderRegexp.MatchString(s)
@@ -88,6 +97,8 @@ func walkTypedAttributes[T any](visitor *placeholderReplacementVisitor, attribut
 type placeholderReplacementVisitor struct {
 	// Map of packageName -> packageConfig
 	packages map[string]PackageConfig
+	// Map of variable name -> value
+	variables map[string]interface{}
 	// Accumulation of all that occurred during traversal
 	AllErrors error
 }
@@ -102,6 +113,7 @@ func newPlaceholderReplacementVisitor(cfg *Config) *placeholderReplacementVisito
 
 	return &placeholderReplacementVisitor{
 		packages:  packages,
+		variables: cfg.Variables,
 		AllErrors: nil,
 	}
 }
@@ -126,6 +138,22 @@ func (v *placeholderReplacementVisitor) Visit(data interface{}) (interface{}, er
 		return data, nil
 	}
 
+	// A string that is exactly one variable placeholder takes on the variable's value as is,
+	// so that numbers, booleans, lists and objects keep their type.
+	if matches := placeholderRegexp.FindStringSubmatchIndex(s); matches != nil && matches[0] == 0 && matches[1] == len(s) {
+		key := s[matches[2]:matches[3]]
+		if variablePlaceholderRegexp.MatchString(key) {
+			value, err := v.lookupVariable(key)
+			if err != nil {
+				v.AllErrors = multierr.Append(v.AllErrors, err)
+				return data, nil
+			}
+			if _, isString := value.(string); !isString || t.Kind() != reflect.Ptr {
+				return value, nil
+			}
+		}
+	}
+
 	withReplacedRefs, err := v.replacePlaceholders(s)
 	v.AllErrors = multierr.Append(v.AllErrors, err)
 
@@ -161,6 +189,8 @@ func (v *placeholderReplacementVisitor) replacePlaceholders(s string) (string, e
 			replacementResult, err = v.replacePackagePlaceholder(string(placeholderKey))
 		case environmentPlaceholderRegexp.Match(placeholderKey):
 			replacementResult, err = v.replaceEnvironmentPlaceholder(string(placeholderKey))
+		case variablePlaceholderRegexp.Match(placeholderKey):
+			replacementResult, err = v.replaceVariablePlaceholder(string(placeholderKey))
 		default:
 			err = errors.Errorf("invalid placeholder %q", string(placeholder))
 		}
@@ -212,3 +242,57 @@ func (v *placeholderReplacementVisitor) replaceEnvironmentPlaceholder(toReplace
 	}
 	return value, nil
 }
+
+func (v *placeholderReplacementVisitor) lookupVariable(toReplace string) (interface{}, error) {
+	matches := variablePlaceholderRegexp.FindStringSubmatch(toReplace)
+	if matches == nil {
+		return nil, errors.Errorf("failed to find substring matches for %q", toReplace)
+	}
+	variableName := matches[variablePlaceholderRegexp.SubexpIndex("name")]
+	value, present := v.variables[variableName]
+	if !present {
+		return nil, errors.Errorf("no variable named %q for placeholder %q", variableName, toReplace)
+	}
+	if value == nil {
+		return nil, errors.Errorf("variable %q for placeholder %q has no value set for this machine", variableName, toReplace)
+	}
+	return value, nil
+}
+
+func (v *placeholderReplacementVisitor) replaceVariablePlaceholder(toReplace string) (string, error) {
+	value, err := v.lookupVariable(toReplace)
+	if err != nil {
+		return toReplace, err
+	}
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case float64, bool, int:
+		return fmt.Sprint(value), nil
+	default:
+		// lists and objects are embedded as JSON
+		md, err := json.Marshal(value)
+		if err != nil {
+			return toReplace, errors.Wrapf(err, "cannot embed variable for placeholder %q", toReplace)
+		}
+		return string(md), nil
+	}
+}
+
+// mergeVariables returns the variables with values from overrides taking precedence. Neither
+// map is modified.
+func mergeVariables(variables, overrides map[string]interface{}) map[string]interface{} {
+	if len(overrides) == 0 {
+		return variables
+	}
+	merged := make(map[string]interface{}, len(variables)+len(overrides))
+	for name, value := range variables {
+		merged[name] = value
+	}
+	for name, value := range overrides {
+		if value != nil {
+			merged[name] = value
+		}
+	}
+	return merged
+}
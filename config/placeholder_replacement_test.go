@@ -204,4 +204,62 @@ func TestPlaceholderReplacement(t *testing.T) {
 		err = cfg.ReplacePlaceholders()
 		test.That(t, fmt.Sprint(err), test.ShouldContainSubstring, "VIAM_UNDEFINED_TEST_VAR")
 	})
+	t.Run("variable placeholder replacement", func(t *testing.T) {
+		cfg := &config.Config{
+			Components: []resource.Config{
+				{
+					Name: "m",
+					Attributes: utils.AttributeMap{
+						"wheel_diameter_mm": "${variables.wheel_diameter_mm}",
+						"serial":            "${variables.serial}",
+						"label":             "robot-${variables.serial}-${variables.camera_index}",
+						"pins":              "${variables.pins}",
+						"nested": map[string]interface{}{
+							"camera_index": "${variables.camera_index}",
+						},
+					},
+				},
+			},
+			Modules: []config.Module{
+				{
+					Environment: map[string]string{
+						"SERIAL": "${variables.serial}",
+					},
+				},
+			},
+			Variables: map[string]interface{}{
+				"wheel_diameter_mm": 152.5,
+				"serial":            "A-17",
+				"camera_index":      float64(2),
+				"pins":              []interface{}{"11", "13"},
+			},
+		}
+		err := cfg.ReplacePlaceholders()
+		test.That(t, err, test.ShouldBeNil)
+		attrMap := cfg.Components[0].Attributes
+		test.That(t, attrMap["wheel_diameter_mm"], test.ShouldEqual, 152.5)
+		test.That(t, attrMap["serial"], test.ShouldEqual, "A-17")
+		test.That(t, attrMap["label"], test.ShouldEqual, "robot-A-17-2")
+		test.That(t, attrMap["pins"], test.ShouldResemble, []interface{}{"11", "13"})
+		test.That(t, attrMap["nested"], test.ShouldResemble, map[string]interface{}{"camera_index": float64(2)})
+		test.That(t, cfg.Modules[0].Environment["SERIAL"], test.ShouldEqual, "A-17")
+
+		// test failures
+		cfg = &config.Config{
+			Components: []resource.Config{
+				{
+					Attributes: utils.AttributeMap{
+						"a": "${variables.undeclared}",
+						"b": "${variables.required}",
+					},
+				},
+			},
+			Variables: map[string]interface{}{"required": nil},
+		}
+		err = cfg.ReplacePlaceholders()
+		test.That(t, fmt.Sprint(err), test.ShouldContainSubstring, `no variable named "undeclared"`)
+		test.That(t, fmt.Sprint(err), test.ShouldContainSubstring, `variable "required"`)
+		test.That(t, cfg.Components[0].Attributes["a"], test.ShouldEqual, "${variables.undeclared}")
+		test.That(t, cfg.Components[0].Attributes["b"], test.ShouldEqual, "${variables.required}")
+	})
 }
@@ -562,6 +562,8 @@ func validateRemote(t *testing.T, actual, expected Remote) {
 	test.That(t, actual.Insecure, test.ShouldEqual, expected.Insecure)
 	test.That(t, actual.ReconnectInterval, test.ShouldEqual, expected.ReconnectInterval)
 	test.That(t, actual.ConnectionCheckInterval, test.ShouldEqual, expected.ConnectionCheckInterval)
+	test.That(t, actual.RefreshInterval, test.ShouldEqual, expected.RefreshInterval)
+	test.That(t, actual.HeartbeatInterval, test.ShouldEqual, expected.HeartbeatInterval)
 	test.That(t, actual.Auth, test.ShouldResemble, expected.Auth)
 	f1, err := actual.Frame.ParseConfig()
 	test.That(t, err, test.ShouldBeNil)
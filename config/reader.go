@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"sort"
+	"time"
 
 	"github.com/a8m/envsubst"
 	"github.com/pkg/errors"
@@ -19,6 +20,8 @@ import (
 	"go.viam.com/utils"
 	"go.viam.com/utils/rpc"
 	"golang.org/x/sys/cpu"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/resource"
@@ -88,6 +91,32 @@ func getCloudCacheFilePath(id string) string {
 	return filepath.Join(rutils.ViamDotDir, fmt.Sprintf("cached_cloud_config_%s.json", id))
 }
 
+func getCloudCacheMetadataFilePath(id string) string {
+	return filepath.Join(rutils.ViamDotDir, fmt.Sprintf("cached_cloud_config_%s.meta.json", id))
+}
+
+// cacheMetadata is stored next to the cached config since the cached config itself is kept
+// as close as possible to what the cloud returned.
+type cacheMetadata struct {
+	FetchedAt time.Time     `json:"fetched_at"`
+	Signed    *signedConfig `json:"signed,omitempty"`
+}
+
+// readCacheFetchedAt returns when the cached config was fetched from the cloud. Caches
+// written before the fetch time was recorded fall back to the cache file's modification time.
+func readCacheFetchedAt(id string) time.Time {
+	if data, err := os.ReadFile(getCloudCacheMetadataFilePath(id)); err == nil {
+		var md cacheMetadata
+		if err := json.Unmarshal(data, &md); err == nil && !md.FetchedAt.IsZero() {
+			return md.FetchedAt
+		}
+	}
+	if fInfo, err := os.Stat(getCloudCacheFilePath(id)); err == nil {
+		return fInfo.ModTime()
+	}
+	return time.Time{}
+}
+
 func readFromCache(id string) (*Config, error) {
 	r, err := os.Open(getCloudCacheFilePath(id))
 	if err != nil {
@@ -114,6 +143,9 @@ func clearCache(id string) {
 	utils.UncheckedErrorFunc(func() error {
 		return os.Remove(getCloudCacheFilePath(id))
 	})
+	utils.UncheckedErrorFunc(func() error {
+		return os.Remove(getCloudCacheMetadataFilePath(id))
+	})
 }
 
 func readCertificateDataFromCloudGRPC(ctx context.Context,
@@ -187,9 +219,20 @@ func readFromCloud(
 	if err != nil {
 		return nil, err
 	}
+	fetchStatus := FetchStatus{FromCache: cached, FetchedAt: time.Now(), MaxAge: cloudCfg.MaxCachedConfigAge}
+	if cached {
+		fetchStatus.FetchedAt = readCacheFetchedAt(cloudCfg.ID)
+		if err := checkCachedConfigAge(fetchStatus, cloudCfg.StaleConfigBehavior, logger); err != nil {
+			return nil, err
+		}
+	}
 
-	// process the config
+	// process the config. Machine specific values for variables come from the local config file,
+	// and are only merged in for processing so that they are not written to the cache.
+	cloudVariables := unprocessedConfig.Variables
+	unprocessedConfig.Variables = mergeVariables(cloudVariables, originalCfg.Variables)
 	cfg, err := processConfigFromCloud(unprocessedConfig, logger)
+	unprocessedConfig.Variables = cloudVariables
 	if err != nil {
 		// If we cannot process the config from the cache we should clear it.
 		if cached {
@@ -278,9 +321,31 @@ func readFromCloud(
 	if err := cfg.SetToCache(unprocessedConfig); err != nil {
 		logger.Errorw("failed to set toCache on config", "error", err)
 	}
+	cfg.fetchStatus = fetchStatus
+	cfg.signed = unprocessedConfig.signed
 	return cfg, nil
 }
 
+// checkCachedConfigAge warns about or refuses a cached config older than its max age.
+func checkCachedConfigAge(status FetchStatus, behavior StaleConfigBehavior, logger logging.Logger) error {
+	now := time.Now()
+	if !status.Stale(now) {
+		return nil
+	}
+	fetchedAt := "unknown"
+	if !status.FetchedAt.IsZero() {
+		// Use logging.DefaultTimeFormatStr since this time will be logged.
+		fetchedAt = status.FetchedAt.Format(logging.DefaultTimeFormatStr)
+	}
+	if behavior == StaleConfigRefuse {
+		return errors.Errorf("cached config fetched at %s is older than max_cached_config_age (%s); refusing to use it",
+			fetchedAt, status.MaxAge)
+	}
+	logger.Warnw("cached config is older than max_cached_config_age",
+		"fetched_at", fetchedAt, "age", status.Age(now).Round(time.Second), "max_cached_config_age", status.MaxAge)
+	return nil
+}
+
 type tlsConfig struct {
 	certificate string
 	privateKey  string
@@ -459,6 +524,38 @@ func additionalModuleEnvVars(cloud *Cloud, auth AuthConfig) map[string]string {
 	return env
 }
 
+// checkUnknownAttributes applies the unknown attributes policy for the resource's model to any
+// attributes its native config does not declare. The robot config's policy for the model takes
+// precedence over the one for all models, which takes precedence over the registered one.
+func (c *Config) checkUnknownAttributes(
+	resName resource.Name,
+	conf resource.Config,
+	reg resource.Registration[resource.Resource, resource.ConfigValidator],
+	logger logging.Logger,
+) error {
+	policy, ok := c.UnknownAttributes[conf.Model.String()]
+	if !ok || policy == "" {
+		policy, ok = c.UnknownAttributes["*"]
+	}
+	if !ok || policy == "" {
+		policy = reg.UnknownAttributes
+	}
+	if policy == "" || policy == resource.UnknownAttributesIgnore {
+		return nil
+	}
+	unknown := reg.FindUnknownAttributes(conf.Attributes)
+	if len(unknown) == 0 {
+		return nil
+	}
+	if policy == resource.UnknownAttributesError {
+		return errors.Errorf("unknown attributes for (%s, %s): %s",
+			resName, conf.Model, resource.FormatUnknownAttributes(unknown))
+	}
+	logger.Warnw("Resource config has unknown attributes that will be ignored",
+		"resource", resName, "model", conf.Model, "attributes", resource.FormatUnknownAttributes(unknown))
+	return nil
+}
+
 // processConfig processes the config passed in. The config can be either JSON or gRPC derived.
 // If any part of this function errors, the function will exit and no part of the new config will be returned
 // until it is corrected.
@@ -482,12 +579,34 @@ func processConfig(unprocessedConfig *Config, fromCloud bool, logger logging.Log
 	// be instantiated later in the flow.
 	cfg.ConfigFilePath = unprocessedConfig.ConfigFilePath
 
+	// keep the config as it was given, so that resolved secrets never end up in the config history.
+	cfg.unprocessed, err = json.Marshal(unprocessedConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling unprocessed config")
+	}
+
 	// replacement can happen in resource attributes and in the module config. look at config/placeholder_replace.go
 	// for available substitution types.
 	if err := cfg.ReplacePlaceholders(); err != nil {
 		logger.Errorw("error during placeholder replacement", "err", err)
 	}
 
+	// secret references are resolved after placeholders so that a secret_ref can itself use a placeholder.
+	// look at config/secrets.go for the available secrets providers.
+	if err := cfg.ResolveSecrets(context.Background()); err != nil {
+		logger.Errorw("error resolving secret references", "err", err)
+	}
+
+	// resources are enabled or disabled once variables are final, and before default services
+	// are added so that a disabled default service is replaced by the builtin one.
+	cfg.excludeDisabledResources()
+	for _, disabled := range cfg.DisabledResources {
+		if disabled.Error != nil {
+			logger.Errorw("error evaluating enabled condition; leaving resource disabled",
+				"resource", disabled.Name, "condition", disabled.Condition, "error", disabled.Error)
+		}
+	}
+
 	// See if default service already exists in the config and add them in if not. This code allows for default services to be
 	// defined under a name other than "builtin".
 	defaultServices := resource.DefaultServices()
@@ -536,6 +655,9 @@ func processConfig(unprocessedConfig *Config, fromCloud bool, logger logging.Log
 				// until it is corrected.
 				return errors.Wrapf(err, "error converting attributes for (%s, %s)", resName.API, copied.Model)
 			}
+			if err := cfg.checkUnknownAttributes(resName, copied, reg, logger); err != nil {
+				return err
+			}
 			confs[idx].ConvertedAttributes = converted
 		}
 		return nil
@@ -653,6 +775,10 @@ func getFromCloudOrCache(
 	if err != nil {
 		if shouldReadFromCache && errorShouldCheckCache {
 			cachedConfig, cacheErr := readFromCache(cloudCfg.ID)
+			if cacheErr == nil && len(cloudCfg.ConfigSigningKeys) > 0 {
+				// the signed config is the source of truth, since the cache could have been tampered with
+				cachedConfig, cacheErr = readSignedFromCache(cloudCfg, cachedConfig, logger)
+			}
 			if cacheErr != nil {
 				if os.IsNotExist(cacheErr) {
 					// Return original http error if failed to load from cache.
@@ -666,9 +792,9 @@ func getFromCloudOrCache(
 			}
 
 			lastUpdated := "unknown"
-			if fInfo, err := os.Stat(getCloudCacheFilePath(cloudCfg.ID)); err == nil {
+			if fetchedAt := readCacheFetchedAt(cloudCfg.ID); !fetchedAt.IsZero() {
 				// Use logging.DefaultTimeFormatStr since this time will be logged.
-				lastUpdated = fInfo.ModTime().Format(logging.DefaultTimeFormatStr)
+				lastUpdated = fetchedAt.Format(logging.DefaultTimeFormatStr)
 			}
 			logger.Warnw("unable to get cloud config; using cached version", "config last updated", lastUpdated, "error", err)
 			cached = true
@@ -691,16 +817,29 @@ func getFromCloudGRPC(ctx context.Context, cloudCfg *Cloud, logger logging.Logge
 	}
 
 	service := apppb.NewRobotServiceClient(conn)
-	res, err := service.Config(ctx, &apppb.ConfigRequest{Id: cloudCfg.ID, AgentInfo: agentInfo})
+	var header metadata.MD
+	codec := &configResponseCodec{}
+	res, err := service.Config(ctx, &apppb.ConfigRequest{Id: cloudCfg.ID, AgentInfo: agentInfo},
+		grpc.Header(&header), grpc.ForceCodec(codec))
 	if err != nil {
 		// Check cache?
 		return nil, shouldCheckCacheOnFailure, errors.WithMessage(err, "error getting config from config endpoint")
 	}
+	var signed *signedConfig
+	if len(cloudCfg.ConfigSigningKeys) > 0 {
+		// a config that fails verification may have been tampered with in transit, so fall
+		// back to the cache, which is verified as well
+		signed, err = verifyConfigSignature(codec.raw, header.Get(ConfigSignatureHeader), cloudCfg.ConfigSigningKeys)
+		if err != nil {
+			return nil, shouldCheckCacheOnFailure, err
+		}
+	}
 	cfg, err := FromProto(res.Config, logger)
 	if err != nil {
 		// Check cache?
 		return nil, shouldCheckCacheOnFailure, errors.WithMessage(err, "error converting config from proto")
 	}
+	cfg.signed = signed
 
 	return cfg, false, nil
 }
@@ -726,6 +865,15 @@ func CreateNewGRPCClient(ctx context.Context, cloudCfg *Cloud, logger logging.Lo
 	if u.Scheme == "http" {
 		dialOpts = append(dialOpts, rpc.WithInsecure())
 	}
+	networkOpts, err := cloudCfg.DialOptions()
+	if err != nil {
+		return nil, err
+	}
+	dialOpts = append(dialOpts, networkOpts...)
+	ctx, err = cloudCfg.ContextWithProxy(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	return rpc.DialDirectGRPC(ctx, u.Host, logger.Sublogger("networking"), dialOpts...)
 }
@@ -752,6 +900,15 @@ func CreateNewGRPCClientWithAPIKey(ctx context.Context, cloudCfg *Cloud,
 	if u.Scheme == "http" {
 		dialOpts = append(dialOpts, rpc.WithInsecure())
 	}
+	networkOpts, err := cloudCfg.DialOptions()
+	if err != nil {
+		return nil, err
+	}
+	dialOpts = append(dialOpts, networkOpts...)
+	ctx, err = cloudCfg.ContextWithProxy(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	return rpc.DialDirectGRPC(ctx, u.Host, logger.Sublogger("networking"), dialOpts...)
 }
@@ -2,6 +2,8 @@ package config
 
 import (
 	"context"
+	"crypto/ed25519"
+	"encoding/base64"
 	"fmt"
 	"io/fs"
 	"os"
@@ -14,6 +16,8 @@ import (
 	pb "go.viam.com/api/app/v1"
 	"go.viam.com/test"
 	"go.viam.com/utils/rpc"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
 
 	"go.viam.com/rdk/config/testutils"
 	"go.viam.com/rdk/grpc"
@@ -190,6 +194,7 @@ func TestStoreToCache(t *testing.T) {
 	cfg, err := FromReader(ctx, "", strings.NewReader(`{}`), logger, nil)
 
 	test.That(t, err, test.ShouldBeNil)
+	cfg.unprocessed = nil
 
 	cloud := &Cloud{
 		ManagedBy:        "acme",
@@ -224,7 +229,10 @@ func TestStoreToCache(t *testing.T) {
 	// read config from cloud, confirm consistency
 	cloudCfg, err := readFromCloud(ctx, cfg, nil, true, false, logger, appConn)
 	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cloudCfg.FetchStatus().FromCache, test.ShouldBeTrue)
 	cloudCfg.toCache = nil
+	cloudCfg.unprocessed = nil
+	cloudCfg.fetchStatus = FetchStatus{}
 	test.That(t, cloudCfg, test.ShouldResemble, cfg)
 
 	// Modify our config
@@ -234,7 +242,10 @@ func TestStoreToCache(t *testing.T) {
 	// read config from cloud again, confirm that the cached config differs from cfg
 	cloudCfg2, err := readFromCloud(ctx, cfg, nil, true, false, logger, appConn)
 	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cloudCfg2.FetchStatus().FromCache, test.ShouldBeTrue)
 	cloudCfg2.toCache = nil
+	cloudCfg2.unprocessed = nil
+	cloudCfg2.fetchStatus = FetchStatus{}
 	test.That(t, cloudCfg2, test.ShouldNotResemble, cfgToCache)
 
 	// store the updated config to the cloud
@@ -247,10 +258,116 @@ func TestStoreToCache(t *testing.T) {
 	// read updated cloud config, confirm that it now matches our updated cfg
 	cloudCfg3, err := readFromCloud(ctx, cfg, nil, true, false, logger, appConn)
 	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cloudCfg3.FetchStatus().FromCache, test.ShouldBeTrue)
 	cloudCfg3.toCache = nil
+	cloudCfg3.unprocessed = nil
+	cloudCfg3.fetchStatus = FetchStatus{}
 	test.That(t, cloudCfg3, test.ShouldResemble, cfg)
 }
 
+func TestConfigSignature(t *testing.T) {
+	const (
+		robotPartID = "forSigningTest"
+		secret      = testutils.FakeCredentialPayLoad
+	)
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+
+	pubKey, privKey, err := ed25519.GenerateKey(nil)
+	test.That(t, err, test.ShouldBeNil)
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	test.That(t, err, test.ShouldBeNil)
+
+	cloudConfProto, err := CloudConfigToProto(&Cloud{
+		ID:               robotPartID,
+		Secret:           secret,
+		FQDN:             "fqdn",
+		LocalFQDN:        "localFqdn",
+		SignalingAddress: "abc",
+		LocationSecrets:  []LocationSecret{},
+	})
+	test.That(t, err, test.ShouldBeNil)
+	protoConfig := &pb.RobotConfig{Cloud: cloudConfProto}
+	serialized, err := proto.Marshal(protoConfig)
+	test.That(t, err, test.ShouldBeNil)
+	sign := func(key ed25519.PrivateKey) string {
+		return base64.StdEncoding.EncodeToString(ed25519.Sign(key, serialized))
+	}
+
+	fakeServer, cleanup := testutils.NewFakeCloudServer(t, ctx, logger)
+	defer cleanup()
+	fakeServer.StoreDeviceConfig(robotPartID, protoConfig, &pb.CertificateResponse{TlsCertificate: "cert", TlsPrivateKey: "key"})
+
+	appAddress := fmt.Sprintf("http://%s", fakeServer.Addr().String())
+	appConn, err := grpc.NewAppConn(ctx, appAddress, secret, robotPartID, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer appConn.Close()
+	cfgText := fmt.Sprintf(`{"cloud":{"id":%q,"app_address":%q,"secret":%q,"config_signing_keys":[%q]}}`,
+		robotPartID, appAddress, secret, base64.StdEncoding.EncodeToString(pubKey))
+
+	clearCache(robotPartID)
+	defer clearCache(robotPartID)
+
+	t.Run("unsigned", func(t *testing.T) {
+		_, err := FromReader(ctx, "", strings.NewReader(cfgText), logger, appConn)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, ConfigSignatureHeader)
+	})
+
+	t.Run("signed by another key", func(t *testing.T) {
+		fakeServer.StoreDeviceConfigSignature(robotPartID, sign(otherKey))
+		_, err := FromReader(ctx, "", strings.NewReader(cfgText), logger, appConn)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "does not match")
+	})
+
+	t.Run("signed", func(t *testing.T) {
+		fakeServer.StoreDeviceConfigSignature(robotPartID, sign(privKey))
+		cfg, err := FromReader(ctx, "", strings.NewReader(cfgText), logger, appConn)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, cfg.Cloud.FQDN, test.ShouldEqual, "fqdn")
+		test.That(t, cfg.StoreToCache(), test.ShouldBeNil)
+
+		// the signed cache is used when offline
+		fakeServer.FailOnConfigAndCertsWith(context.DeadlineExceeded)
+		defer fakeServer.FailOnConfigAndCertsWith(nil)
+		cfg, err = FromReader(ctx, "", strings.NewReader(cfgText), logger, appConn)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, cfg.FetchStatus().FromCache, test.ShouldBeTrue)
+		test.That(t, cfg.Cloud.FQDN, test.ShouldEqual, "fqdn")
+		test.That(t, cfg.Cloud.TLSCertificate, test.ShouldEqual, "cert")
+
+		// but not if its signature is gone
+		test.That(t, os.Remove(getCloudCacheMetadataFilePath(robotPartID)), test.ShouldBeNil)
+		_, err = FromReader(ctx, "", strings.NewReader(cfgText), logger, appConn)
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
+	t.Run("delivered config", func(t *testing.T) {
+		response := protowire.AppendTag(nil, 1, protowire.BytesType)
+		response = protowire.AppendBytes(response, serialized)
+		config, err := deliveredConfig(response)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, config, test.ShouldResemble, serialized)
+
+		// the signature is over the config as delivered, so one split across several fields,
+		// which decodes to the same config, is rejected
+		split := protowire.AppendTag(response, 1, protowire.BytesType)
+		split = protowire.AppendBytes(split, nil)
+		_, err = deliveredConfig(split)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "more than one piece")
+
+		_, err = deliveredConfig(nil)
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		cloud := &Cloud{ID: robotPartID, Secret: secret, ConfigSigningKeys: []string{"c2hvcnQ="}}
+		test.That(t, cloud.Validate("cloud", false), test.ShouldNotBeNil)
+	})
+}
+
 func TestCacheInvalidation(t *testing.T) {
 	id := uuid.New().String()
 	// store invalid config in cache
@@ -267,6 +384,46 @@ func TestCacheInvalidation(t *testing.T) {
 	test.That(t, os.IsNotExist(err), test.ShouldBeTrue)
 }
 
+func TestCachedConfigAge(t *testing.T) {
+	logger, logs := logging.NewObservedTestLogger(t)
+	now := time.Now()
+
+	id := uuid.New().String()
+	cfg := &Config{Cloud: &Cloud{ID: id}, fetchStatus: FetchStatus{FetchedAt: now.Add(-time.Hour)}}
+	test.That(t, cfg.SetToCache(&Config{Cloud: &Cloud{ID: id}}), test.ShouldBeNil)
+	test.That(t, cfg.StoreToCache(), test.ShouldBeNil)
+	defer clearCache(id)
+
+	// the fetch time is kept next to the cache
+	fetchedAt := readCacheFetchedAt(id)
+	test.That(t, fetchedAt.Equal(cfg.fetchStatus.FetchedAt), test.ShouldBeTrue)
+
+	status := FetchStatus{FromCache: true, FetchedAt: fetchedAt}
+	test.That(t, status.Stale(now), test.ShouldBeFalse)
+	test.That(t, status.Age(now), test.ShouldEqual, time.Hour)
+
+	status.MaxAge = 2 * time.Hour
+	test.That(t, status.Stale(now), test.ShouldBeFalse)
+	test.That(t, checkCachedConfigAge(status, StaleConfigRefuse, logger), test.ShouldBeNil)
+
+	status.MaxAge = time.Minute
+	test.That(t, status.Stale(now), test.ShouldBeTrue)
+	test.That(t, checkCachedConfigAge(status, "", logger), test.ShouldBeNil)
+	test.That(t, logs.FilterMessageSnippet("older than max_cached_config_age").Len(), test.ShouldEqual, 1)
+
+	err := checkCachedConfigAge(status, StaleConfigRefuse, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "refusing to use it")
+
+	// configs fetched from the cloud are never stale
+	status.FromCache = false
+	test.That(t, status.Stale(now), test.ShouldBeFalse)
+
+	// clearing the cache also clears the fetch time
+	clearCache(id)
+	test.That(t, readCacheFetchedAt(id).IsZero(), test.ShouldBeTrue)
+}
+
 func TestShouldCheckForCert(t *testing.T) {
 	cloud1 := Cloud{
 		ManagedBy:        "acme",
@@ -311,6 +468,9 @@ func TestProcessConfig(t *testing.T) {
 
 	cfg, err := processConfig(&unprocessedConfig, true, logger)
 	test.That(t, err, test.ShouldBeNil)
+	// the config is kept as it was given for the config history
+	test.That(t, cfg.unprocessed, test.ShouldNotBeEmpty)
+	cfg.unprocessed = nil
 	test.That(t, *cfg, test.ShouldResemble, unprocessedConfig)
 }
 
@@ -0,0 +1,293 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	goutils "go.viam.com/utils"
+
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/utils"
+)
+
+// SecretRefKey is the attribute key used to reference a secret instead of pasting it into the config.
+// An attribute value of {"secret_ref": "<provider>:<ref>"} is replaced with the resolved secret when
+// the config is processed, for example {"secret_ref": "env:API_TOKEN"} or
+// {"secret_ref": "vault:secret/data/robot#api_token"}.
+const SecretRefKey = "secret_ref"
+
+const secretResolveTimeout = 10 * time.Second
+
+// A SecretsProvider resolves references to secrets stored outside of the config.
+type SecretsProvider interface {
+	// Resolve returns the secret referred to by ref, the part of a secret_ref after the
+	// provider name and colon.
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// SecretsProviderFunc is a SecretsProvider implemented by a function.
+type SecretsProviderFunc func(ctx context.Context, ref string) (string, error)
+
+// Resolve calls f.
+func (f SecretsProviderFunc) Resolve(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+var (
+	secretsProvidersMu sync.RWMutex
+	secretsProviders   = map[string]SecretsProvider{
+		"env":   SecretsProviderFunc(resolveEnvSecret),
+		"file":  SecretsProviderFunc(resolveFileSecret),
+		"vault": SecretsProviderFunc(resolveVaultSecret),
+	}
+)
+
+// RegisterSecretsProvider makes a SecretsProvider available under the given name, replacing any
+// provider already registered under it. This is how providers such as a cloud KMS are plugged in.
+func RegisterSecretsProvider(name string, provider SecretsProvider) {
+	secretsProvidersMu.Lock()
+	defer secretsProvidersMu.Unlock()
+	secretsProviders[name] = provider
+}
+
+// ResolveSecret resolves a secret reference of the form "<provider>:<ref>".
+func ResolveSecret(ctx context.Context, secretRef string) (string, error) {
+	name, ref, ok := strings.Cut(secretRef, ":")
+	if !ok || ref == "" {
+		return "", errors.Errorf("invalid %s %q, expected <provider>:<reference>", SecretRefKey, secretRef)
+	}
+	secretsProvidersMu.RLock()
+	provider, ok := secretsProviders[name]
+	secretsProvidersMu.RUnlock()
+	if !ok {
+		return "", errors.Errorf("unknown secrets provider %q in %s %q", name, SecretRefKey, secretRef)
+	}
+	value, err := provider.Resolve(ctx, ref)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to resolve %s %q", SecretRefKey, secretRef)
+	}
+	return value, nil
+}
+
+// resolveSecretRefs replaces every {"secret_ref": ...} value in attributes with the secret it refers
+// to, and returns the paths of the attributes that were replaced.
+func resolveSecretRefs(ctx context.Context, attributes utils.AttributeMap) (utils.AttributeMap, [][]string, error) {
+	if attributes == nil {
+		return nil, nil, nil
+	}
+	var paths [][]string
+	resolved, err := resolveSecretRefsIn(ctx, map[string]interface{}(attributes), nil, &paths)
+	if err != nil {
+		return attributes, nil, err
+	}
+	//nolint:forcetypeassert
+	return utils.AttributeMap(resolved.(map[string]interface{})), paths, nil
+}
+
+func resolveSecretRefsIn(ctx context.Context, data interface{}, path []string, paths *[][]string) (interface{}, error) {
+	switch v := data.(type) {
+	case utils.AttributeMap:
+		return resolveSecretRefsIn(ctx, map[string]interface{}(v), path, paths)
+	case map[string]interface{}:
+		if ref, ok := v[SecretRefKey].(string); ok && len(v) == 1 {
+			*paths = append(*paths, slices.Clone(path))
+			return ResolveSecret(ctx, ref)
+		}
+		var errs error
+		out := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			resolved, err := resolveSecretRefsIn(ctx, val, append(path, key), paths)
+			errs = multierr.Append(errs, err)
+			out[key] = resolved
+		}
+		return out, errs
+	case []interface{}:
+		var errs error
+		out := make([]interface{}, 0, len(v))
+		for i, val := range v {
+			resolved, err := resolveSecretRefsIn(ctx, val, append(path, strconv.Itoa(i)), paths)
+			errs = multierr.Append(errs, err)
+			out = append(out, resolved)
+		}
+		return out, errs
+	default:
+		return data, nil
+	}
+}
+
+// ResolveSecrets resolves every secret_ref in component and service attributes. The paths of the
+// resolved attributes are kept in the SecretAttributePaths of each resource config so that they are
+// masked wherever this config is shown.
+func (c *Config) ResolveSecrets(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, secretResolveTimeout)
+	defer cancel()
+
+	var allErrs, err error
+	for _, confs := range [][]resource.Config{c.Components, c.Services} {
+		for i := range confs {
+			confs[i].Attributes, confs[i].SecretAttributePaths, err = resolveSecretRefs(ctx, confs[i].Attributes)
+			allErrs = multierr.Append(allErrs, err)
+		}
+	}
+	return allErrs
+}
+
+// ResolvedSecrets returns the values of every attribute of the config that was resolved from a
+// secret_ref, for masking them where they cannot be masked by path, like in logs.
+func (c *Config) ResolvedSecrets() []string {
+	var secrets []string
+	for _, confs := range [][]resource.Config{c.Components, c.Services} {
+		for _, conf := range confs {
+			for _, path := range conf.SecretAttributePaths {
+				if secret, ok := attributeAtPath(map[string]interface{}(conf.Attributes), path).(string); ok && secret != "" {
+					secrets = append(secrets, secret)
+				}
+			}
+		}
+	}
+	return secrets
+}
+
+// attributeAtPath returns the attribute at path within data, or nil if there is none.
+func attributeAtPath(data interface{}, path []string) interface{} {
+	for _, elem := range path {
+		switch v := data.(type) {
+		case utils.AttributeMap:
+			data = v[elem]
+		case map[string]interface{}:
+			data = v[elem]
+		case []interface{}:
+			idx, err := strconv.Atoi(elem)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return nil
+			}
+			data = v[idx]
+		default:
+			return nil
+		}
+	}
+	return data
+}
+
+// maskSecretAttributes masks, in place, the attributes of attrs at each of the given paths.
+func maskSecretAttributes(attrs map[string]interface{}, paths [][]string) {
+	for _, path := range paths {
+		if len(path) == 0 {
+			continue
+		}
+		switch parent := attributeAtPath(attrs, path[:len(path)-1]).(type) {
+		case utils.AttributeMap:
+			if _, ok := parent[path[len(path)-1]]; ok {
+				parent[path[len(path)-1]] = sensitiveMask
+			}
+		case map[string]interface{}:
+			if _, ok := parent[path[len(path)-1]]; ok {
+				parent[path[len(path)-1]] = sensitiveMask
+			}
+		case []interface{}:
+			if idx, err := strconv.Atoi(path[len(path)-1]); err == nil && idx >= 0 && idx < len(parent) {
+				parent[idx] = sensitiveMask
+			}
+		default:
+		}
+	}
+}
+
+// resolveEnvSecret resolves "env:NAME" to the value of the environment variable NAME.
+func resolveEnvSecret(_ context.Context, ref string) (string, error) {
+	value, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", errors.Errorf("no environment variable named %q", ref)
+	}
+	return value, nil
+}
+
+// resolveFileSecret resolves "file:/path" to the trimmed contents of the file, or "file:/path#key" to
+// the string value of key in the JSON object stored in the file.
+func resolveFileSecret(_ context.Context, ref string) (string, error) {
+	path, key, hasKey := strings.Cut(ref, "#")
+	//nolint:gosec
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if !hasKey {
+		return strings.TrimSpace(string(data)), nil
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return "", errors.Wrapf(err, "secret file %s is not a JSON object", path)
+	}
+	return secretFromMap(values, key)
+}
+
+// resolveVaultSecret resolves "vault:path#key" by reading path from the HashiCorp Vault server at
+// VAULT_ADDR with the token in VAULT_TOKEN. Both KV version 1 and 2 secrets engines are supported.
+func resolveVaultSecret(ctx context.Context, ref string) (string, error) {
+	path, key, ok := strings.Cut(ref, "#")
+	if !ok || key == "" {
+		return "", errors.New("vault secret references must be of the form vault:<path>#<key>")
+	}
+	addr := os.Getenv("VAULT_ADDR")
+	if addr == "" {
+		return "", errors.New("VAULT_ADDR must be set to resolve vault secrets")
+	}
+	token := os.Getenv("VAULT_TOKEN")
+	if token == "" {
+		return "", errors.New("VAULT_TOKEN must be set to resolve vault secrets")
+	}
+
+	url := fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(addr, "/"), strings.TrimPrefix(path, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	//nolint:bodyclose
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		goutils.UncheckedError(resp.Body.Close())
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("vault returned status %d for %s", resp.StatusCode, path)
+	}
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", errors.Wrap(err, "cannot parse vault response")
+	}
+	// KV version 2 nests the secret under another data key.
+	if nested, ok := body.Data["data"].(map[string]interface{}); ok {
+		if _, hasKey := body.Data[key]; !hasKey {
+			return secretFromMap(nested, key)
+		}
+	}
+	return secretFromMap(body.Data, key)
+}
+
+func secretFromMap(values map[string]interface{}, key string) (string, error) {
+	value, ok := values[key]
+	if !ok {
+		return "", errors.Errorf("no secret named %q", key)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", errors.Errorf("secret %q is a %T, not a string", key, value)
+	}
+	return s, nil
+}
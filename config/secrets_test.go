@@ -0,0 +1,132 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/utils"
+)
+
+func TestResolveSecrets(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("TEST_SECRET_TOKEN", "env-token-value")
+
+	dir := t.TempDir()
+	plainPath := filepath.Join(dir, "plain")
+	test.That(t, os.WriteFile(plainPath, []byte("file-value\n"), 0o600), test.ShouldBeNil)
+	jsonPath := filepath.Join(dir, "secrets.json")
+	test.That(t, os.WriteFile(jsonPath, []byte(`{"api_key": "json-value", "port": 5}`), 0o600), test.ShouldBeNil)
+
+	config.RegisterSecretsProvider("test", config.SecretsProviderFunc(func(ctx context.Context, ref string) (string, error) {
+		return "custom-" + ref, nil
+	}))
+
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{
+				Name: "c",
+				Attributes: utils.AttributeMap{
+					"token": map[string]interface{}{"secret_ref": "env:TEST_SECRET_TOKEN"},
+					"nested": map[string]interface{}{
+						"plain": map[string]interface{}{"secret_ref": "file:" + plainPath},
+						"list":  []interface{}{"unchanged", map[string]interface{}{"secret_ref": "file:" + jsonPath + "#api_key"}},
+					},
+					"not_a_ref": map[string]interface{}{"secret_ref": "env:TEST_SECRET_TOKEN", "other": 1},
+				},
+			},
+		},
+		Services: []resource.Config{
+			{
+				Name:       "s",
+				Attributes: utils.AttributeMap{"key": map[string]interface{}{"secret_ref": "test:abc"}},
+			},
+		},
+	}
+	test.That(t, cfg.ResolveSecrets(ctx), test.ShouldBeNil)
+
+	attrs := cfg.Components[0].Attributes
+	test.That(t, attrs["token"], test.ShouldEqual, "env-token-value")
+	nested := attrs["nested"].(map[string]interface{})
+	test.That(t, nested["plain"], test.ShouldEqual, "file-value")
+	test.That(t, nested["list"], test.ShouldResemble, []interface{}{"unchanged", "json-value"})
+	test.That(t, attrs["not_a_ref"], test.ShouldResemble,
+		map[string]interface{}{"secret_ref": "env:TEST_SECRET_TOKEN", "other": 1})
+	test.That(t, cfg.Services[0].Attributes["key"], test.ShouldEqual, "custom-abc")
+
+	// where secrets were resolved is kept with the config, to mask them wherever it is shown
+	paths := cfg.Components[0].SecretAttributePaths
+	test.That(t, paths, test.ShouldHaveLength, 3)
+	test.That(t, paths, test.ShouldContain, []string{"token"})
+	test.That(t, paths, test.ShouldContain, []string{"nested", "plain"})
+	test.That(t, paths, test.ShouldContain, []string{"nested", "list", "1"})
+	test.That(t, cfg.Services[0].SecretAttributePaths, test.ShouldResemble, [][]string{{"key"}})
+	secrets := cfg.ResolvedSecrets()
+	test.That(t, secrets, test.ShouldHaveLength, 4)
+	for _, secret := range []string{"env-token-value", "file-value", "json-value", "custom-abc"} {
+		test.That(t, secrets, test.ShouldContain, secret)
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, ref := range []string{
+			"nocolon",
+			"unknown:ref",
+			"env:TEST_SECRET_NOT_SET",
+			"file:" + filepath.Join(dir, "missing"),
+			"file:" + jsonPath + "#missing",
+			"file:" + jsonPath + "#port",
+			"vault:secret/data/robot",
+		} {
+			_, err := config.ResolveSecret(ctx, ref)
+			test.That(t, err, test.ShouldNotBeNil)
+		}
+
+		cfg := &config.Config{
+			Components: []resource.Config{
+				{
+					Name:       "c",
+					Attributes: utils.AttributeMap{"token": map[string]interface{}{"secret_ref": "unknown:ref"}},
+				},
+			},
+		}
+		err := cfg.ResolveSecrets(ctx)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, `unknown secrets provider "unknown"`)
+		// attributes are left untouched on failure
+		test.That(t, cfg.Components[0].Attributes["token"], test.ShouldResemble,
+			map[string]interface{}{"secret_ref": "unknown:ref"})
+		test.That(t, cfg.Components[0].SecretAttributePaths, test.ShouldBeNil)
+	})
+
+	t.Run("masked in diffs", func(t *testing.T) {
+		newConfig := func() *config.Config {
+			return &config.Config{
+				Components: []resource.Config{
+					{
+						Name:       "c",
+						API:        arm.API,
+						Model:      fakeModel,
+						Attributes: utils.AttributeMap{"token": map[string]interface{}{"secret_ref": "env:TEST_SECRET_TOKEN"}},
+					},
+				},
+			}
+		}
+		left := newConfig()
+		test.That(t, left.ResolveSecrets(ctx), test.ShouldBeNil)
+		t.Setenv("TEST_SECRET_TOKEN", "rotated-token-value")
+		right := newConfig()
+		test.That(t, right.ResolveSecrets(ctx), test.ShouldBeNil)
+
+		diff, err := config.DiffConfigs(*left, *right, true)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, diff.ResourcesEqual, test.ShouldBeFalse)
+		test.That(t, diff.PrettyDiff, test.ShouldNotContainSubstring, "env-token-value")
+		test.That(t, diff.PrettyDiff, test.ShouldNotContainSubstring, "rotated-token-value")
+	})
+}
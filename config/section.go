@@ -0,0 +1,57 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// A Section is a part of the config that can be reloaded on its own, without diffing and
+// reconfiguring the robot's resources.
+type Section string
+
+const (
+	// SectionNetwork is the network and auth config, including the cloud TLS certificate.
+	SectionNetwork Section = "network"
+	// SectionLog is the `log` pattern config and log deduplication setting. Resource and
+	// module log levels are part of their own configs and are not included.
+	SectionLog Section = "log"
+)
+
+// Sections lists every section that can be reloaded on its own.
+var Sections = []Section{SectionNetwork, SectionLog}
+
+// ParseSection returns the section with the given name.
+func ParseSection(name string) (Section, error) {
+	for _, s := range Sections {
+		if string(s) == strings.ToLower(strings.TrimSpace(name)) {
+			return s, nil
+		}
+	}
+	return "", errors.Errorf("unknown config section %q; expected one of %v", name, Sections)
+}
+
+// ApplySection returns a copy of curr with only the given section taken from next. Everything
+// else, notably the resources, is left as it is in curr so that applying the result does not
+// change any running components.
+func ApplySection(curr, next *Config, section Section) (*Config, error) {
+	out := *curr
+	switch section {
+	case SectionNetwork:
+		out.Network = next.Network
+		out.Auth = next.Auth
+		out.EnableWebProfile = next.EnableWebProfile
+		if curr.Cloud != nil && next.Cloud != nil {
+			cloud := *curr.Cloud
+			cloud.TLSCertificate = next.Cloud.TLSCertificate
+			cloud.TLSPrivateKey = next.Cloud.TLSPrivateKey
+			out.Cloud = &cloud
+		}
+	case SectionLog:
+		out.LogConfig = next.LogConfig
+		out.DisableLogDeduplication = next.DisableLogDeduplication
+	default:
+		return nil, errors.Errorf("unknown config section %q", section)
+	}
+	return &out, nil
+}
@@ -0,0 +1,65 @@
+package config_test
+
+import (
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+)
+
+func TestApplySection(t *testing.T) {
+	curr := &config.Config{
+		Components: []resource.Config{{Name: "arm1", API: arm.API, Model: fakeModel}},
+		Cloud:      &config.Cloud{ID: "robot", TLSCertificate: "old cert", TLSPrivateKey: "old key"},
+		LogConfig:  []logging.LoggerPatternConfig{{Pattern: "rdk.*", Level: "info"}},
+	}
+	curr.Network.BindAddress = "localhost:8080"
+
+	next := &config.Config{
+		Components: []resource.Config{{Name: "arm2", API: arm.API, Model: fakeModel}},
+		Cloud:      &config.Cloud{ID: "other", TLSCertificate: "new cert", TLSPrivateKey: "new key"},
+		LogConfig:  []logging.LoggerPatternConfig{{Pattern: "rdk.*", Level: "debug"}},
+
+		DisableLogDeduplication: true,
+	}
+	next.Network.BindAddress = "localhost:9090"
+
+	t.Run("network", func(t *testing.T) {
+		out, err := config.ApplySection(curr, next, config.SectionNetwork)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, out.Network.BindAddress, test.ShouldEqual, "localhost:9090")
+		test.That(t, out.Cloud.TLSCertificate, test.ShouldEqual, "new cert")
+		test.That(t, out.Cloud.TLSPrivateKey, test.ShouldEqual, "new key")
+		test.That(t, out.Cloud.ID, test.ShouldEqual, "robot")
+		test.That(t, out.LogConfig, test.ShouldResemble, curr.LogConfig)
+		test.That(t, out.Components, test.ShouldResemble, curr.Components)
+
+		// the current config is left untouched
+		test.That(t, curr.Network.BindAddress, test.ShouldEqual, "localhost:8080")
+		test.That(t, curr.Cloud.TLSCertificate, test.ShouldEqual, "old cert")
+	})
+
+	t.Run("log", func(t *testing.T) {
+		out, err := config.ApplySection(curr, next, config.SectionLog)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, out.LogConfig, test.ShouldResemble, next.LogConfig)
+		test.That(t, out.DisableLogDeduplication, test.ShouldBeTrue)
+		test.That(t, out.Network.BindAddress, test.ShouldEqual, "localhost:8080")
+		test.That(t, out.Components, test.ShouldResemble, curr.Components)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := config.ApplySection(curr, next, config.Section("components"))
+		test.That(t, err, test.ShouldNotBeNil)
+
+		_, err = config.ParseSection("components")
+		test.That(t, err, test.ShouldNotBeNil)
+		section, err := config.ParseSection(" Network ")
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, section, test.ShouldEqual, config.SectionNetwork)
+	})
+}
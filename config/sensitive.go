@@ -0,0 +1,237 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"go.viam.com/utils/rpc"
+
+	"go.viam.com/rdk/resource"
+	rutils "go.viam.com/rdk/utils"
+)
+
+// SensitiveTag is the struct tag that marks a config field as sensitive, like
+//
+//	APIKey string `json:"api_key" config:"secret"`
+//
+// Sensitive fields are masked wherever configs are printed, such as in config diffs. Masking a
+// string replaces it if it is set, masking a map replaces all of its values, and masking a struct
+// or slice masks everything in it. Fields of native resource configs tagged this way have the
+// attribute of the same JSON name masked.
+const SensitiveTag = "config"
+
+const (
+	sensitiveTagValue = "secret"
+	sensitiveMask     = "******"
+)
+
+var (
+	sensitiveFieldsMu sync.RWMutex
+	// sensitiveFields holds fields of types that cannot be tagged, like those of other packages.
+	sensitiveFields = map[reflect.Type]map[string]bool{
+		reflect.TypeOf(rpc.Credentials{}): {"Payload": true},
+	}
+
+	sensitiveAttributesMu sync.RWMutex
+	// sensitiveAttributes holds attributes registered as sensitive, keyed by API and model.
+	sensitiveAttributes = map[resource.APIModel]map[string]bool{}
+)
+
+// RegisterSensitiveField marks the named fields of the struct type t as sensitive, for types
+// whose fields cannot be tagged with SensitiveTag.
+func RegisterSensitiveField(t reflect.Type, fieldNames ...string) {
+	sensitiveFieldsMu.Lock()
+	defer sensitiveFieldsMu.Unlock()
+	if sensitiveFields[t] == nil {
+		sensitiveFields[t] = map[string]bool{}
+	}
+	for _, name := range fieldNames {
+		sensitiveFields[t][name] = true
+	}
+}
+
+// RegisterSensitiveAttributes marks the named top-level attributes of resources of the given API
+// and model as sensitive. This is needed for models whose native config type is not registered in
+// this process, such as those of modules; otherwise tagging the native config is enough.
+func RegisterSensitiveAttributes(api resource.API, model resource.Model, keys ...string) {
+	sensitiveAttributesMu.Lock()
+	defer sensitiveAttributesMu.Unlock()
+	apiModel := resource.APIModel{API: api, Model: model}
+	if sensitiveAttributes[apiModel] == nil {
+		sensitiveAttributes[apiModel] = map[string]bool{}
+	}
+	for _, key := range keys {
+		sensitiveAttributes[apiModel][key] = true
+	}
+}
+
+// maskSensitive masks, in place, every sensitive field of conf and every sensitive attribute of
+// its resources.
+func maskSensitive(conf *Config) {
+	maskSensitiveValue(reflect.ValueOf(conf).Elem(), false)
+	for _, confs := range [][]resource.Config{conf.Components, conf.Services} {
+		for _, resConf := range confs {
+			for key := range sensitiveAttributeKeys(resConf.API, resConf.Model) {
+				if _, ok := resConf.Attributes[key]; ok {
+					resConf.Attributes[key] = sensitiveMask
+				}
+			}
+		}
+	}
+}
+
+// maskSensitiveValue masks the sensitive parts of v, which must be settable, or all of it if
+// sensitive is true.
+func maskSensitiveValue(v reflect.Value, sensitive bool) {
+	switch v.Kind() {
+	case reflect.String:
+		if sensitive && v.Len() != 0 {
+			v.SetString(sensitiveMask)
+		}
+	case reflect.Pointer:
+		if !v.IsNil() {
+			maskSensitiveValue(v.Elem(), sensitive)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			maskSensitiveValue(v.Index(i), sensitive)
+		}
+	case reflect.Map:
+		if !sensitive || v.IsNil() || v.Type().Elem().Kind() == reflect.Struct {
+			return
+		}
+		mask := reflect.ValueOf(sensitiveMask)
+		if !mask.Type().AssignableTo(v.Type().Elem()) {
+			return
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			v.SetMapIndex(iter.Key(), mask)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		sensitiveFieldsMu.RLock()
+		registered := sensitiveFields[t]
+		sensitiveFieldsMu.RUnlock()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			maskSensitiveValue(v.Field(i), sensitive || registered[field.Name] || isSensitiveField(field))
+		}
+	default:
+	}
+}
+
+func isSensitiveField(field reflect.StructField) bool {
+	return field.Tag.Get(SensitiveTag) == sensitiveTagValue
+}
+
+// sensitiveAttributeKeys returns the sensitive top-level attributes of resources of the given API
+// and model, from both RegisterSensitiveAttributes and the tags of its native config type.
+func sensitiveAttributeKeys(api resource.API, model resource.Model) map[string]bool {
+	keys := map[string]bool{}
+	sensitiveAttributesMu.RLock()
+	for key := range sensitiveAttributes[resource.APIModel{API: api, Model: model}] {
+		keys[key] = true
+	}
+	sensitiveAttributesMu.RUnlock()
+
+	reg, ok := resource.LookupRegistration(api, model)
+	if !ok || reg.ConfigReflectType() == nil {
+		return keys
+	}
+	t := reg.ConfigReflectType()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return keys
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !isSensitiveField(field) {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" {
+			name = field.Name
+		}
+		if name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+}
+
+// sensitiveKeyNames are the parts of attribute names that mark them as sensitive when a model's
+// sensitive attributes are not known, such as those of modules that registered none.
+var sensitiveKeyNames = []string{"password", "passwd", "secret", "token", "credential", "apikey"}
+
+// isSensitiveKeyName returns whether an attribute named key looks like it holds a secret.
+func isSensitiveKeyName(key string) bool {
+	key = strings.ToLower(key)
+	if key == "key" || strings.HasSuffix(key, "_key") {
+		return true
+	}
+	for _, name := range sensitiveKeyNames {
+		if strings.Contains(key, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// SanitizedAttributes returns a copy of the attributes of conf with its sensitive attributes and any
+// resolved secrets masked, for showing the config of a resource outside of the machine. Attributes of
+// models whose native config type is not registered in this process, like those of modules, are also
+// masked at any depth if their names look sensitive, unless the model registered its sensitive
+// attributes.
+func SanitizedAttributes(conf resource.Config) rutils.AttributeMap {
+	if conf.Attributes == nil {
+		return nil
+	}
+	keys := sensitiveAttributeKeys(conf.API, conf.Model)
+	byName := len(keys) == 0
+	if reg, ok := resource.LookupRegistration(conf.API, conf.Model); ok && reg.ConfigReflectType() != nil {
+		byName = false
+	}
+	attrs := make(rutils.AttributeMap, len(conf.Attributes))
+	for key, value := range conf.Attributes {
+		if keys[key] {
+			attrs[key] = sensitiveMask
+			continue
+		}
+		attrs[key] = sanitizedValue(key, value, byName)
+	}
+	maskSecretAttributes(attrs, conf.SecretAttributePaths)
+	return attrs
+}
+
+// sanitizedValue returns a copy of the attribute value named key, with attributes with sensitive
+// names masked if byName is true.
+func sanitizedValue(key string, value interface{}, byName bool) interface{} {
+	if byName && isSensitiveKeyName(key) && value != nil {
+		return sensitiveMask
+	}
+	switch v := value.(type) {
+	case rutils.AttributeMap:
+		return sanitizedValue(key, map[string]interface{}(v), byName)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			out[k] = sanitizedValue(k, val, byName)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, 0, len(v))
+		for _, val := range v {
+			out = append(out, sanitizedValue(key, val, byName))
+		}
+		return out
+	default:
+		return value
+	}
+}
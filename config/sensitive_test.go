@@ -0,0 +1,104 @@
+package config_test
+
+import (
+	"context"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/base"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/utils"
+)
+
+func TestSanitizedAttributes(t *testing.T) {
+	type secretConfig struct {
+		resource.TriviallyValidateConfig
+		Host   string `json:"host"`
+		APIKey string `json:"api_key" config:"secret"`
+	}
+	secretModel := resource.NewModel("acme", "sanitized", "arm")
+	resource.RegisterComponent(arm.API, secretModel, resource.Registration[arm.Arm, *secretConfig]{
+		Constructor: func(ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger) (arm.Arm, error) {
+			return nil, nil
+		},
+	})
+	defer resource.Deregister(arm.API, secretModel)
+	registeredModel := resource.NewModel("acme", "sanitized", "registered")
+	config.RegisterSensitiveAttributes(base.API, registeredModel, "token")
+
+	config.RegisterSecretsProvider("sanitized", config.SecretsProviderFunc(func(ctx context.Context, ref string) (string, error) {
+		return "resolved-" + ref, nil
+	}))
+
+	t.Run("tagged attributes and resolved secrets", func(t *testing.T) {
+		cfg := &config.Config{Components: []resource.Config{
+			{
+				Name:  "a",
+				API:   arm.API,
+				Model: secretModel,
+				Attributes: utils.AttributeMap{
+					"host":    "arm.local",
+					"api_key": "arm-key",
+					"nested": map[string]interface{}{
+						"url":   map[string]interface{}{"secret_ref": "sanitized:url"},
+						"token": "kept",
+						"list":  []interface{}{"resolved-url", map[string]interface{}{"secret_ref": "sanitized:url"}},
+					},
+				},
+			},
+		}}
+		test.That(t, cfg.ResolveSecrets(context.Background()), test.ShouldBeNil)
+		conf := cfg.Components[0]
+		test.That(t, config.SanitizedAttributes(conf), test.ShouldResemble, utils.AttributeMap{
+			"host":    "arm.local",
+			"api_key": "******",
+			// the native config type is known, so other attributes are only masked if they were resolved
+			// from secret references, not where the same text merely appears
+			"nested": map[string]interface{}{
+				"url":   "******",
+				"token": "kept",
+				"list":  []interface{}{"resolved-url", "******"},
+			},
+		})
+		// the config itself is not masked
+		test.That(t, conf.Attributes["api_key"], test.ShouldEqual, "arm-key")
+		test.That(t, conf.Attributes["nested"].(map[string]interface{})["url"], test.ShouldEqual, "resolved-url")
+	})
+
+	t.Run("registered attributes", func(t *testing.T) {
+		conf := resource.Config{
+			API:        base.API,
+			Model:      registeredModel,
+			Attributes: utils.AttributeMap{"token": "base-token", "password": "kept"},
+		}
+		test.That(t, config.SanitizedAttributes(conf), test.ShouldResemble,
+			utils.AttributeMap{"token": "******", "password": "kept"})
+	})
+
+	t.Run("unknown models", func(t *testing.T) {
+		conf := resource.Config{
+			API:   base.API,
+			Model: resource.NewModel("acme", "sanitized", "module"),
+			Attributes: utils.AttributeMap{
+				"host":     "base.local",
+				"password": "base-password",
+				"auth": map[string]interface{}{
+					"api_key": "base-key",
+					"hosts":   []interface{}{map[string]interface{}{"access_token": "base-token", "port": 80}},
+				},
+			},
+		}
+		test.That(t, config.SanitizedAttributes(conf), test.ShouldResemble, utils.AttributeMap{
+			"host":     "base.local",
+			"password": "******",
+			"auth": map[string]interface{}{
+				"api_key": "******",
+				"hosts":   []interface{}{map[string]interface{}{"access_token": "******", "port": 80}},
+			},
+		})
+	})
+}
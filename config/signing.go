@@ -0,0 +1,181 @@
+package config
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+	apppb "go.viam.com/api/app/v1"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"go.viam.com/rdk/logging"
+)
+
+// ConfigSignatureHeader is the gRPC response header holding the base64 encoded ed25519 signature
+// of a cloud config. The signature is detached and made over the serialized config exactly as it
+// is delivered in the config field of the ConfigResponse, so the config is never re-encoded
+// before it is verified.
+const ConfigSignatureHeader = "config-signature"
+
+// signedConfig is a cloud config in the form its signature was made over.
+type signedConfig struct {
+	Config    []byte `json:"config"`
+	Signature []byte `json:"signature"`
+}
+
+// configResponseCodec is the proto codec, but keeps the bytes of the last message it decoded so
+// that the signature of a config can be checked against exactly what was delivered.
+type configResponseCodec struct {
+	raw []byte
+}
+
+func (c *configResponseCodec) Marshal(v any) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, errors.Errorf("cannot marshal %T, which is not a proto message", v)
+	}
+	return proto.Marshal(msg)
+}
+
+func (c *configResponseCodec) Unmarshal(data []byte, v any) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return errors.Errorf("cannot unmarshal into %T, which is not a proto message", v)
+	}
+	c.raw = bytes.Clone(data)
+	return proto.Unmarshal(data, msg)
+}
+
+// Name is that of the default codec, so that the content type of the call is unchanged.
+func (c *configResponseCodec) Name() string {
+	return "proto"
+}
+
+// deliveredConfig returns the serialized config field of a serialized ConfigResponse. A message
+// field may legally be split across several occurrences that are merged on decoding, but a signed
+// config must be delivered in one piece.
+func deliveredConfig(response []byte) ([]byte, error) {
+	configField := (&apppb.ConfigResponse{}).ProtoReflect().Descriptor().Fields().ByName("config").Number()
+	var config []byte
+	found := false
+	for len(response) > 0 {
+		num, typ, n := protowire.ConsumeTag(response)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		response = response[n:]
+		if num == configField && typ == protowire.BytesType {
+			if found {
+				return nil, errors.New("config from the cloud is delivered in more than one piece")
+			}
+			value, n := protowire.ConsumeBytes(response)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			config, found = value, true
+			response = response[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, response)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		response = response[n:]
+	}
+	if !found {
+		return nil, errors.New("config from the cloud is empty")
+	}
+	return config, nil
+}
+
+// parseConfigSigningKeys decodes base64 encoded ed25519 public keys.
+func parseConfigSigningKeys(encoded []string) ([]ed25519.PublicKey, error) {
+	keys := make([]ed25519.PublicKey, 0, len(encoded))
+	for idx, enc := range encoded {
+		key, err := base64.StdEncoding.DecodeString(enc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "config_signing_keys.%d is not base64 encoded", idx)
+		}
+		if len(key) != ed25519.PublicKeySize {
+			return nil, errors.Errorf("config_signing_keys.%d must be a %d byte ed25519 public key but is %d bytes",
+				idx, ed25519.PublicKeySize, len(key))
+		}
+		keys = append(keys, ed25519.PublicKey(key))
+	}
+	return keys, nil
+}
+
+// verify checks that the config was signed by one of the keys.
+func (s *signedConfig) verify(encodedKeys []string) error {
+	keys, err := parseConfigSigningKeys(encodedKeys)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if ed25519.Verify(key, s.Config, s.Signature) {
+			return nil
+		}
+	}
+	return errors.New("config signature does not match any of the config signing keys")
+}
+
+// verifyConfigSignature verifies the signature of a config fetched from the cloud, given the
+// serialized ConfigResponse it was delivered in, which must have exactly one value in the signature
+// header.
+func verifyConfigSignature(response []byte, signatureHeader []string, keys []string) (*signedConfig, error) {
+	if len(signatureHeader) != 1 {
+		return nil, errors.Errorf("expected config from the cloud to have one %s header but it has %d",
+			ConfigSignatureHeader, len(signatureHeader))
+	}
+	sig, err := base64.StdEncoding.DecodeString(signatureHeader[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "config signature is not base64 encoded")
+	}
+	config, err := deliveredConfig(response)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading the signed config")
+	}
+	signed := &signedConfig{Config: config, Signature: sig}
+	if err := signed.verify(keys); err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
+
+// readSignedFromCache reads the signed form of the cached config, verifies it and converts it
+// to a config. The TLS certificate, which is not part of the signed config, is taken from the
+// cached config.
+func readSignedFromCache(cloudCfg *Cloud, cached *Config, logger logging.Logger) (*Config, error) {
+	data, err := os.ReadFile(getCloudCacheMetadataFilePath(cloudCfg.ID))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read the signature of the cached config")
+	}
+	var md cacheMetadata
+	if err := json.Unmarshal(data, &md); err != nil {
+		return nil, errors.Wrap(err, "cannot parse the cached config metadata as json")
+	}
+	if md.Signed == nil {
+		return nil, errors.New("cached config is not signed")
+	}
+	if err := md.Signed.verify(cloudCfg.ConfigSigningKeys); err != nil {
+		return nil, errors.Wrap(err, "cached config failed signature verification")
+	}
+	var cfgProto apppb.RobotConfig
+	if err := proto.Unmarshal(md.Signed.Config, &cfgProto); err != nil {
+		return nil, errors.Wrap(err, "cannot parse the signed cached config")
+	}
+	cfg, err := FromProto(&cfgProto, logger)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error converting signed cached config from proto")
+	}
+	if cfg.Cloud != nil && cached.Cloud != nil {
+		cfg.Cloud.TLSCertificate = cached.Cloud.TLSCertificate
+		cfg.Cloud.TLSPrivateKey = cached.Cloud.TLSPrivateKey
+	}
+	cfg.signed = md.Signed
+	return cfg, nil
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// TemplateKey is the component config key that names the template the component is based on.
+const TemplateKey = "template"
+
+// expandComponentTemplates returns the config JSON with every component that references a
+// template replaced by the template merged with the component's own config. Objects, like
+// attributes, are merged key by key with the component's values taking precedence; anything
+// else in the component, including lists, replaces what is in the template.
+func expandComponentTemplates(data []byte) ([]byte, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		// leave reporting the error to the full unmarshal
+		return data, nil //nolint:nilerr
+	}
+	if _, ok := raw["templates"]; !ok {
+		return data, nil
+	}
+
+	var templates map[string]map[string]interface{}
+	if err := json.Unmarshal(raw["templates"], &templates); err != nil {
+		return nil, errors.Wrap(err, "error parsing templates")
+	}
+	for name, template := range templates {
+		if _, ok := template[TemplateKey]; ok {
+			return nil, errors.Errorf("templates.%s: templates cannot be based on other templates", name)
+		}
+		if _, ok := template["name"]; ok {
+			return nil, errors.Errorf("templates.%s: templates cannot set a name", name)
+		}
+	}
+
+	var components []map[string]interface{}
+	if comps, ok := raw["components"]; ok {
+		if err := json.Unmarshal(comps, &components); err != nil {
+			return data, nil //nolint:nilerr
+		}
+	}
+	for idx, comp := range components {
+		ref, ok := comp[TemplateKey]
+		if !ok {
+			continue
+		}
+		name, ok := ref.(string)
+		if !ok {
+			return nil, errors.Errorf("components.%d.%s must be a string", idx, TemplateKey)
+		}
+		template, ok := templates[name]
+		if !ok {
+			return nil, errors.Errorf("components.%d: unknown template %q", idx, name)
+		}
+		delete(comp, TemplateKey)
+		components[idx] = mergeTemplate(template, comp)
+	}
+
+	comps, err := json.Marshal(components)
+	if err != nil {
+		return nil, err
+	}
+	raw["components"] = comps
+	return json.Marshal(raw)
+}
+
+// mergeTemplate returns template with overrides merged into it. Neither is modified.
+func mergeTemplate(template, overrides map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(template)+len(overrides))
+	for key, value := range template {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		templateObj, ok1 := merged[key].(map[string]interface{})
+		overrideObj, ok2 := value.(map[string]interface{})
+		if ok1 && ok2 {
+			merged[key] = mergeTemplate(templateObj, overrideObj)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
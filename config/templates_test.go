@@ -0,0 +1,72 @@
+package config_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/utils"
+)
+
+func TestComponentTemplates(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+
+	cfgText := `{
+		"templates": {
+			"sonar": {
+				"api": "rdk:component:sensor",
+				"model": "acme:sensor:sonar",
+				"depends_on": ["board1"],
+				"attributes": {"bus": "i2c1", "range": {"min": 0.1, "max": 4}}
+			}
+		},
+		"components": [
+			{"name": "sonar1", "template": "sonar", "attributes": {"address": "0x10"}},
+			{"name": "sonar2", "template": "sonar", "depends_on": [], "attributes": {"address": "0x11", "range": {"max": 2}}}
+		]
+	}`
+	cfg, err := config.FromReader(ctx, "", strings.NewReader(cfgText), logger, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cfg.Components, test.ShouldHaveLength, 2)
+
+	sonar1 := cfg.Components[0]
+	test.That(t, sonar1.Name, test.ShouldEqual, "sonar1")
+	test.That(t, sonar1.API, test.ShouldResemble, sensor.API)
+	test.That(t, sonar1.Model.String(), test.ShouldEqual, "acme:sensor:sonar")
+	test.That(t, sonar1.DependsOn, test.ShouldResemble, []string{"board1"})
+	test.That(t, sonar1.Attributes, test.ShouldResemble, utils.AttributeMap{
+		"bus":     "i2c1",
+		"address": "0x10",
+		"range":   map[string]interface{}{"min": 0.1, "max": 4.0},
+	})
+
+	sonar2 := cfg.Components[1]
+	test.That(t, sonar2.DependsOn, test.ShouldBeEmpty)
+	test.That(t, sonar2.Attributes, test.ShouldResemble, utils.AttributeMap{
+		"bus":     "i2c1",
+		"address": "0x11",
+		"range":   map[string]interface{}{"min": 0.1, "max": 2.0},
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := config.FromReader(ctx, "", strings.NewReader(`{
+			"templates": {"sonar": {"api": "rdk:component:sensor"}},
+			"components": [{"name": "sonar1", "template": "lidar"}]
+		}`), logger, nil)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, `unknown template "lidar"`)
+
+		_, err = config.FromReader(ctx, "", strings.NewReader(`{
+			"templates": {"sonar": {"template": "other"}},
+			"components": []
+		}`), logger, nil)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "cannot be based on other templates")
+	})
+}
@@ -13,7 +13,9 @@ import (
 	"go.viam.com/test"
 	"go.viam.com/utils"
 	"go.viam.com/utils/rpc"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"go.viam.com/rdk/logging"
@@ -39,8 +41,9 @@ type FakeCloudServer struct {
 }
 
 type configAndCerts struct {
-	cfg   *pb.RobotConfig
-	certs *pb.CertificateResponse
+	cfg       *pb.RobotConfig
+	certs     *pb.CertificateResponse
+	signature string
 }
 
 // NewFakeCloudServer creates and starts a new grpc server for the Viam Cloud.
@@ -149,6 +152,17 @@ func (s *FakeCloudServer) StoreDeviceConfig(id string, cfg *pb.RobotConfig, cert
 	s.deviceConfigs[id] = &configAndCerts{cfg: cfg, certs: cert}
 }
 
+// StoreDeviceConfigSignature stores the signature sent in the config-signature header along
+// with the device's config.
+func (s *FakeCloudServer) StoreDeviceConfigSignature(id, signature string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d, ok := s.deviceConfigs[id]; ok {
+		d.signature = signature
+	}
+}
+
 // Config impl.
 func (s *FakeCloudServer) Config(ctx context.Context, req *pb.ConfigRequest) (*pb.ConfigResponse, error) {
 	s.mu.Lock()
@@ -162,6 +176,11 @@ func (s *FakeCloudServer) Config(ctx context.Context, req *pb.ConfigRequest) (*p
 	if !ok {
 		return nil, status.Error(codes.NotFound, "config for device not found")
 	}
+	if d.signature != "" {
+		if err := grpc.SetHeader(ctx, metadata.Pairs("config-signature", d.signature)); err != nil {
+			return nil, err
+		}
+	}
 
 	return &pb.ConfigResponse{Config: d.cfg}, nil
 }
@@ -3,6 +3,9 @@ package config
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"os"
 	"time"
 
@@ -73,6 +76,7 @@ func newCloudWatcher(ctx context.Context, config *Config, logger logging.Logger,
 	nextCheckForNewCert := time.Now().Add(checkForNewCertInterval)
 
 	var prevCfg *Config
+	debouncer := &configDebouncer{quietPeriod: config.Cloud.ConfigQuietPeriod}
 	utils.ManagedGo(func() {
 		firstRead := true
 		for {
@@ -82,6 +86,7 @@ func newCloudWatcher(ctx context.Context, config *Config, logger logging.Logger,
 				interval /= 5
 				firstRead = false
 			}
+			interval = debouncer.wait(interval, time.Now())
 			if !utils.SelectContextOrWait(cancelCtx, interval) {
 				return
 			}
@@ -101,6 +106,11 @@ func newCloudWatcher(ctx context.Context, config *Config, logger logging.Logger,
 				nextCheckForNewCert = time.Now().Add(checkForNewCertInterval)
 			}
 
+			newConfig = debouncer.offer(newConfig, time.Now())
+			if newConfig == nil {
+				continue
+			}
+
 			alignModuleLogLevels(newConfig)
 			UpdateCloudConfigDebug(newConfig.Debug)
 
@@ -120,6 +130,94 @@ func newCloudWatcher(ctx context.Context, config *Config, logger logging.Logger,
 	}
 }
 
+// A configDebouncer coalesces configs that change in quick succession so that only the
+// latest one is delivered once changes have stopped for the quiet period. Configs identical
+// to the last one delivered are dropped, since applying them again would do nothing.
+type configDebouncer struct {
+	quietPeriod time.Duration
+
+	seen       bool
+	lastKey    string
+	lastChange time.Time
+	pending    bool
+	// appliedHash is the configHash of the last config delivered.
+	appliedHash string
+}
+
+// configKey identifies the contents of a config, preferring its revision if it has one.
+func configKey(cfg *Config) string {
+	if cfg.Revision != "" {
+		return cfg.Revision
+	}
+	return configHash(cfg)
+}
+
+// configHash is a hash of all of the contents of a config, including those that change
+// without a new revision, such as the TLS certificate.
+func configHash(cfg *Config) string {
+	md, err := json.Marshal(cfg)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(md)
+	return hex.EncodeToString(sum[:])
+}
+
+// offer records a config fetched at now and returns the config to deliver, or nil if
+// delivery should wait for changes to settle or the config was already delivered.
+func (d *configDebouncer) offer(cfg *Config, now time.Time) *Config {
+	if d.quietPeriod <= 0 {
+		return cfg
+	}
+	key := configKey(cfg)
+	if !d.seen {
+		// the first config is delivered right away since it is usually what the robot
+		// started with.
+		d.seen = true
+		d.lastKey = key
+		return d.deliver(cfg)
+	}
+	if key != d.lastKey {
+		d.lastKey = key
+		d.lastChange = now
+		d.pending = true
+		return nil
+	}
+	if d.pending {
+		if now.Sub(d.lastChange) < d.quietPeriod {
+			return nil
+		}
+		d.pending = false
+	}
+	return d.deliver(cfg)
+}
+
+// deliver returns cfg unless it is identical to the last config delivered.
+func (d *configDebouncer) deliver(cfg *Config) *Config {
+	hash := configHash(cfg)
+	if hash != "" && hash == d.appliedHash {
+		return nil
+	}
+	d.appliedHash = hash
+	return cfg
+}
+
+// wait returns how long to wait before fetching again, shortening interval so that a
+// pending config is fetched again as soon as its quiet period is over.
+func (d *configDebouncer) wait(interval time.Duration, now time.Time) time.Duration {
+	if !d.pending {
+		return interval
+	}
+	remaining := d.lastChange.Add(d.quietPeriod).Sub(now)
+	if remaining < 0 {
+		remaining = 0
+	}
+	if remaining < interval {
+		return remaining
+	}
+	return interval
+}
+
 func (w *cloudWatcher) Config() <-chan *Config {
 	return w.configCh
 }
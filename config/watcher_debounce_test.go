@@ -0,0 +1,65 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+)
+
+func TestConfigDebouncer(t *testing.T) {
+	start := time.Now()
+	interval := 10 * time.Second
+
+	t.Run("disabled", func(t *testing.T) {
+		d := &configDebouncer{}
+		for _, rev := range []string{"a", "b", "c"} {
+			cfg := &Config{Revision: rev}
+			test.That(t, d.offer(cfg, start), test.ShouldEqual, cfg)
+		}
+		test.That(t, d.wait(interval, start), test.ShouldEqual, interval)
+	})
+
+	t.Run("coalesces rapid changes", func(t *testing.T) {
+		d := &configDebouncer{quietPeriod: 3 * time.Second}
+		first := &Config{Revision: "a"}
+		test.That(t, d.offer(first, start), test.ShouldEqual, first)
+		test.That(t, d.offer(&Config{Revision: "a"}, start.Add(time.Second)), test.ShouldBeNil)
+
+		// two edits in quick succession are held back
+		test.That(t, d.offer(&Config{Revision: "b"}, start.Add(2*time.Second)), test.ShouldBeNil)
+		test.That(t, d.wait(interval, start.Add(2*time.Second)), test.ShouldEqual, 3*time.Second)
+		test.That(t, d.offer(&Config{Revision: "c"}, start.Add(4*time.Second)), test.ShouldBeNil)
+		test.That(t, d.offer(&Config{Revision: "c"}, start.Add(6*time.Second)), test.ShouldBeNil)
+		test.That(t, d.wait(interval, start.Add(6*time.Second)), test.ShouldEqual, time.Second)
+
+		// the latest config is delivered once changes settle
+		latest := &Config{Revision: "c"}
+		test.That(t, d.offer(latest, start.Add(7*time.Second)), test.ShouldEqual, latest)
+		test.That(t, d.wait(interval, start.Add(7*time.Second)), test.ShouldEqual, interval)
+		test.That(t, d.offer(&Config{Revision: "c"}, start.Add(8*time.Second)), test.ShouldBeNil)
+	})
+
+	t.Run("drops configs identical to the last delivered", func(t *testing.T) {
+		d := &configDebouncer{quietPeriod: time.Second}
+		first := &Config{Revision: "a"}
+		test.That(t, d.offer(first, start), test.ShouldEqual, first)
+
+		// a change that is reverted within the quiet period settles on what was delivered
+		test.That(t, d.offer(&Config{Revision: "b"}, start.Add(time.Second)), test.ShouldBeNil)
+		test.That(t, d.offer(&Config{Revision: "a"}, start.Add(2*time.Second)), test.ShouldBeNil)
+		test.That(t, d.offer(&Config{Revision: "a"}, start.Add(4*time.Second)), test.ShouldBeNil)
+
+		// a new certificate is delivered even though the revision did not change
+		renewed := &Config{Revision: "a", Cloud: &Cloud{TLSCertificate: "renewed"}}
+		test.That(t, d.offer(renewed, start.Add(5*time.Second)), test.ShouldEqual, renewed)
+	})
+
+	t.Run("configs without revisions", func(t *testing.T) {
+		d := &configDebouncer{quietPeriod: time.Second}
+		test.That(t, d.offer(&Config{Debug: false}, start), test.ShouldNotBeNil)
+		test.That(t, d.offer(&Config{Debug: true}, start), test.ShouldBeNil)
+		test.That(t, d.offer(&Config{Debug: true}, start.Add(time.Second)), test.ShouldNotBeNil)
+		test.That(t, d.offer(&Config{Debug: true}, start.Add(2*time.Second)), test.ShouldBeNil)
+	})
+}
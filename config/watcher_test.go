@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	pb "go.viam.com/api/app/v1"
 	"go.viam.com/test"
@@ -92,7 +93,7 @@ func TestNewWatcherFile(t *testing.T) {
 	test.That(t, confToWrite.Ensure(false, logger), test.ShouldBeNil)
 
 	newConf := <-watcher.Config()
-	test.That(t, newConf, test.ShouldResemble, &confToWrite)
+	test.That(t, cmp.Diff(&confToWrite, newConf, ignoreReadState...), test.ShouldBeEmpty)
 
 	confToWrite = config.Config{
 		ConfigFilePath: temp.Name(),
@@ -123,7 +124,7 @@ func TestNewWatcherFile(t *testing.T) {
 	test.That(t, confToWrite.Ensure(false, logger), test.ShouldBeNil)
 
 	newConf = <-watcher.Config()
-	test.That(t, newConf, test.ShouldResemble, &confToWrite)
+	test.That(t, cmp.Diff(&confToWrite, newConf, ignoreReadState...), test.ShouldBeEmpty)
 
 	go func() {
 		f, err := os.OpenFile(temp.Name(), os.O_RDWR|os.O_CREATE, 0o755)
@@ -173,7 +174,7 @@ func TestNewWatcherFile(t *testing.T) {
 	test.That(t, confToWrite.Ensure(false, logger), test.ShouldBeNil)
 
 	newConf = <-watcher.Config()
-	test.That(t, newConf, test.ShouldResemble, &confToWrite)
+	test.That(t, cmp.Diff(&confToWrite, newConf, ignoreReadState...), test.ShouldBeEmpty)
 
 	test.That(t, watcher.Close(), test.ShouldBeNil)
 }
@@ -286,7 +287,7 @@ func TestNewWatcherCloud(t *testing.T) {
 	confToExpect.SetToCache(unprocessedFromCfg(confToExpect))
 
 	newConf := <-watcher.Config()
-	test.That(t, newConf, test.ShouldResemble, &confToExpect)
+	test.That(t, cmp.Diff(&confToExpect, newConf, ignoreReadState...), test.ShouldBeEmpty)
 
 	confToReturn = config.Config{
 		Cloud: newCloudConf(),
@@ -324,7 +325,7 @@ func TestNewWatcherCloud(t *testing.T) {
 	confToExpect.SetToCache(unprocessedFromCfg(confToExpect))
 
 	newConf = <-watcher.Config()
-	test.That(t, newConf, test.ShouldResemble, &confToExpect)
+	test.That(t, cmp.Diff(&confToExpect, newConf, ignoreReadState...), test.ShouldBeEmpty)
 
 	// fake server will start returning 5xx on requests.
 	// no new configs should be emitted to channel until the fake server starts returning again
@@ -339,7 +340,7 @@ func TestNewWatcherCloud(t *testing.T) {
 	fakeServer.FailOnConfigAndCerts(false)
 
 	newConf = <-watcher.Config()
-	test.That(t, newConf, test.ShouldResemble, &confToExpect)
+	test.That(t, cmp.Diff(&confToExpect, newConf, ignoreReadState...), test.ShouldBeEmpty)
 
 	confToReturn = config.Config{
 		Cloud: newCloudConf(),
@@ -376,7 +377,7 @@ func TestNewWatcherCloud(t *testing.T) {
 	confToExpect.SetToCache(unprocessedFromCfg(confToExpect))
 
 	newConf = <-watcher.Config()
-	test.That(t, newConf, test.ShouldResemble, &confToExpect)
+	test.That(t, cmp.Diff(&confToExpect, newConf, ignoreReadState...), test.ShouldBeEmpty)
 
 	test.That(t, watcher.Close(), test.ShouldBeNil)
 }
@@ -0,0 +1,175 @@
+package control
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// DoCommand keys used by components with a built-in PID control loop to drive
+// auto-tuning at runtime.
+const (
+	// AutotuneCommand starts auto-tuning the control loop. The value may be the name of
+	// the tune method to use or true to use the default method.
+	AutotuneCommand = "autotune"
+	// TuningStatusCommand reports whether tuning is in progress along with any tuned values.
+	TuningStatusCommand = "tuning_status"
+	// ApplyTunedPIDCommand confirms the tuned PID values, applying them to the running
+	// control loop and returning them in a form that can be copied into the config.
+	ApplyTunedPIDCommand = "apply_tuned_pid"
+)
+
+const defaultTuneMethod = tuneMethodZiegerNicholsPI
+
+var supportedTuneMethods = []tuneCalcMethod{
+	tuneMethodZiegerNicholsPI,
+	tuneMethodZiegerNicholsPID,
+	tuneMethodZiegerNicholsPD,
+	tuneMethodZiegerNicholsSomeOvershoot,
+	tuneMethodZiegerNicholsNoOvershoot,
+	tuneMethodCohenCoonsPI,
+	tuneMethodCohenCoonsPID,
+	tuneMethodTyreusLuybenPI,
+	tuneMethodTyreusLuybenPID,
+}
+
+// TuneMethods returns the names of all supported auto-tuning methods.
+func TuneMethods() []string {
+	methods := make([]string, 0, len(supportedTuneMethods))
+	for _, m := range supportedTuneMethods {
+		methods = append(methods, string(m))
+	}
+	return methods
+}
+
+// ValidateTuneMethod returns an error if the given auto-tuning method is not supported.
+func ValidateTuneMethod(method string) error {
+	for _, m := range supportedTuneMethods {
+		if string(m) == method {
+			return nil
+		}
+	}
+	return errors.Errorf("unsupported tune method %q, must be one of %v", method, TuneMethods())
+}
+
+// TuneMethodFromCommand parses the value of an AutotuneCommand request.
+func TuneMethodFromCommand(val interface{}) (string, error) {
+	switch v := val.(type) {
+	case bool:
+		if !v {
+			return "", errors.Errorf("%s must be true or the name of a tune method", AutotuneCommand)
+		}
+		return string(defaultTuneMethod), nil
+	case string:
+		if err := ValidateTuneMethod(v); err != nil {
+			return "", err
+		}
+		return v, nil
+	default:
+		return "", errors.Errorf("%s must be true or the name of a tune method, got %T", AutotuneCommand, val)
+	}
+}
+
+func (p *PIDLoop) tuneMethod() string {
+	if p.Options.TuneMethod == "" {
+		return string(defaultTuneMethod)
+	}
+	return p.Options.TuneMethod
+}
+
+// Tuning returns whether the control loop is currently being auto-tuned.
+func (p *PIDLoop) Tuning(ctx context.Context) bool {
+	return p.ControlLoop != nil && p.ControlLoop.GetTuning(ctx)
+}
+
+// TuningDone returns whether every PID block that needed tuning has been tuned.
+func (p *PIDLoop) TuningDone() bool {
+	for i := range p.PIDVals {
+		if p.PIDVals[i].NeedsAutoTuning() && (*p.TunedVals)[i].NeedsAutoTuning() {
+			return false
+		}
+	}
+	return true
+}
+
+// Autotune re-runs auto-tuning on every PID block of the control loop with the given
+// method, discarding the current PID values. Tuning runs in the background and its
+// results can be confirmed with ApplyTunedValues once TuningDone returns true.
+func (p *PIDLoop) Autotune(ctx context.Context, method string) error {
+	if method == "" {
+		method = string(defaultTuneMethod)
+	}
+	if err := ValidateTuneMethod(method); err != nil {
+		return err
+	}
+	if p.Tuning(ctx) {
+		return TuningInProgressErr(p.componentName)
+	}
+	if p.ControlLoop != nil {
+		p.ControlLoop.Stop()
+		p.ControlLoop = nil
+	}
+	p.activeBackgroundWorkers.Wait()
+
+	p.Options.TuneMethod = method
+	p.Options.NeedsAutoTuning = true
+	if p.Options.UseCustomConfig {
+		for _, b := range p.ControlConf.Blocks {
+			if b.Type != blockPID {
+				continue
+			}
+			pidSets, ok := b.Attribute["PIDSets"].([]*PIDConfig)
+			if !ok {
+				return errors.Errorf("pid block %s does not have a PID configured", b.Name)
+			}
+			zeroed := make([]*PIDConfig, 0, len(pidSets))
+			for _, pidSet := range pidSets {
+				zeroed = append(zeroed, &PIDConfig{Type: pidSet.Type})
+			}
+			b.Attribute["PIDSets"] = zeroed
+			b.Attribute["tune_method"] = method
+		}
+	} else {
+		zeroed := make([]PIDConfig, 0, len(p.PIDVals))
+		for _, pidVal := range p.PIDVals {
+			zeroed = append(zeroed, PIDConfig{Type: pidVal.Type})
+		}
+		p.PIDVals = zeroed
+		p.createControlLoopConfig(p.PIDVals, p.componentName)
+	}
+	for i := range *p.TunedVals {
+		(*p.TunedVals)[i] = PIDConfig{}
+	}
+
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+	return p.TunePIDLoop(cancelCtx, cancelFunc)
+}
+
+// ApplyTunedValues replaces the PID values of the control loop with the tuned values
+// and returns them so they can be persisted to the component config. The control loop
+// must be restarted for the new values to take effect.
+func (p *PIDLoop) ApplyTunedValues() ([]PIDConfig, error) {
+	if p.ControlLoop != nil && p.ControlLoop.GetTuning(context.Background()) {
+		return nil, TuningInProgressErr(p.componentName)
+	}
+	if !p.TuningDone() {
+		return nil, TuningInProgressErr(p.componentName)
+	}
+	if p.Options.UseCustomConfig {
+		return nil, fmt.Errorf("tuned values for %v use a custom control config and must be copied into the config manually",
+			p.componentName)
+	}
+
+	applied := make([]PIDConfig, len(p.PIDVals))
+	for i := range p.PIDVals {
+		applied[i] = p.PIDVals[i]
+		if p.PIDVals[i].NeedsAutoTuning() {
+			applied[i] = (*p.TunedVals)[i]
+		}
+	}
+	p.PIDVals = applied
+	p.Options.NeedsAutoTuning = false
+	p.createControlLoopConfig(p.PIDVals, p.componentName)
+	return applied, nil
+}
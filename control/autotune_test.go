@@ -0,0 +1,46 @@
+package control
+
+import (
+	"testing"
+
+	"go.viam.com/test"
+)
+
+func TestTuneMethodFromCommand(t *testing.T) {
+	method, err := TuneMethodFromCommand(true)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, method, test.ShouldEqual, "ziegerNicholsPI")
+
+	method, err = TuneMethodFromCommand("cohenCoonsPID")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, method, test.ShouldEqual, "cohenCoonsPID")
+
+	_, err = TuneMethodFromCommand(false)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	_, err = TuneMethodFromCommand("notAMethod")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "unsupported tune method")
+
+	_, err = TuneMethodFromCommand(1.0)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestApplyTunedValues(t *testing.T) {
+	pl := &PIDLoop{
+		PIDVals:     []PIDConfig{{Type: "linear_velocity"}, {Type: "angular_velocity", P: 1, I: 2}},
+		TunedVals:   &[]PIDConfig{{}, {}},
+		ControlConf: &Config{},
+	}
+	_, err := pl.ApplyTunedValues()
+	test.That(t, err, test.ShouldNotBeNil)
+
+	(*pl.TunedVals)[0] = PIDConfig{Type: "linear_velocity", P: 0.5, I: 0.25}
+	applied, err := pl.ApplyTunedValues()
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, applied, test.ShouldResemble, []PIDConfig{
+		{Type: "linear_velocity", P: 0.5, I: 0.25},
+		{Type: "angular_velocity", P: 1, I: 2},
+	})
+	test.That(t, pl.PIDVals, test.ShouldResemble, applied)
+}
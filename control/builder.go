@@ -0,0 +1,309 @@
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	rdkutils "go.viam.com/rdk/utils"
+)
+
+// ControlLoopStateCommand is the DoCommand key used by components with a built-in control
+// loop to report the state of every block in the loop.
+const ControlLoopStateCommand = "control_loop_state"
+
+var supportedBlockTypes = []controlBlockType{
+	blockConstant,
+	blockTrapezoidalVelocityProfile,
+	blockPID,
+	blockGain,
+	blockDerivative,
+	blockSum,
+	blockEncoderToRPM,
+	blockEndpoint,
+	blockFilter,
+	blockSaturation,
+}
+
+// attributes which are ints in block configs but decode as float64 from JSON.
+var intAttributes = []string{"filter_size", "kernel_size", "order", "ticks_per_revolution"}
+
+// ConfigBuilder declaratively assembles a control loop Config block by block. Blocks are
+// connected by name through their dependencies, and the assembled Config is validated by
+// Build.
+type ConfigBuilder struct {
+	cfg Config
+}
+
+// NewConfigBuilder returns a ConfigBuilder for a loop running at the given frequency in Hz.
+func NewConfigBuilder(frequency float64) *ConfigBuilder {
+	return &ConfigBuilder{cfg: Config{Frequency: frequency}}
+}
+
+// Block adds an arbitrary block to the loop.
+func (b *ConfigBuilder) Block(cfg BlockConfig) *ConfigBuilder {
+	b.cfg.Blocks = append(b.cfg.Blocks, cfg)
+	return b
+}
+
+// Constant adds a block which always outputs value.
+func (b *ConfigBuilder) Constant(name string, value float64) *ConfigBuilder {
+	return b.Block(CreateConstantBlock(context.Background(), name, value))
+}
+
+// Sum adds a block which sums its inputs, signs holds one '+' or '-' per input.
+func (b *ConfigBuilder) Sum(name, signs string, dependsOn ...string) *ConfigBuilder {
+	return b.Block(BlockConfig{
+		Name:      name,
+		Type:      blockSum,
+		Attribute: rdkutils.AttributeMap{"sum_string": signs},
+		DependsOn: dependsOn,
+	})
+}
+
+// Gain adds a block which multiplies its input by gain.
+func (b *ConfigBuilder) Gain(name string, gain float64, dependsOn ...string) *ConfigBuilder {
+	return b.Block(BlockConfig{
+		Name:      name,
+		Type:      blockGain,
+		Attribute: rdkutils.AttributeMap{"gain": gain},
+		DependsOn: dependsOn,
+	})
+}
+
+// PID adds a PID block with one set of gains per input whose output is limited to
+// [limitLo, limitUp]. Gains that are all zero are auto-tuned.
+func (b *ConfigBuilder) PID(name string, pids []PIDConfig, limitLo, limitUp float64, dependsOn ...string) *ConfigBuilder {
+	pidSets := make([]*PIDConfig, 0, len(pids))
+	for _, pid := range pids {
+		pid := pid
+		pidSets = append(pidSets, &pid)
+	}
+	return b.Block(BlockConfig{
+		Name: name,
+		Type: blockPID,
+		Attribute: rdkutils.AttributeMap{
+			"PIDSets":        pidSets,
+			"int_sat_lim_lo": limitLo,
+			"int_sat_lim_up": limitUp,
+			"limit_lo":       limitLo,
+			"limit_up":       limitUp,
+		},
+		DependsOn: dependsOn,
+	})
+}
+
+// Saturation adds a block which clamps its input to [lower, upper].
+func (b *ConfigBuilder) Saturation(name string, lower, upper float64, dependsOn ...string) *ConfigBuilder {
+	return b.Block(BlockConfig{
+		Name: name,
+		Type: blockSaturation,
+		Attribute: rdkutils.AttributeMap{
+			"lower_limit": lower,
+			"upper_limit": upper,
+		},
+		DependsOn: dependsOn,
+	})
+}
+
+// Derivative adds a block which differentiates its input using the given finite
+// difference type, for example "backward1st1".
+func (b *ConfigBuilder) Derivative(name, deriveType string, dependsOn ...string) *ConfigBuilder {
+	return b.Block(BlockConfig{
+		Name:      name,
+		Type:      blockDerivative,
+		Attribute: rdkutils.AttributeMap{"derive_type": deriveType},
+		DependsOn: dependsOn,
+	})
+}
+
+// MovingAverageFilter adds a block which averages the last size values of its input.
+func (b *ConfigBuilder) MovingAverageFilter(name string, size int, dependsOn ...string) *ConfigBuilder {
+	return b.Block(BlockConfig{
+		Name: name,
+		Type: blockFilter,
+		Attribute: rdkutils.AttributeMap{
+			"type":        string(filterFIRMovingAverage),
+			"filter_size": size,
+		},
+		DependsOn: dependsOn,
+	})
+}
+
+// Endpoint adds the block which drives the controlled component. controllableType is
+// either "motor_name" or "base_name".
+func (b *ConfigBuilder) Endpoint(name, controllableType, componentName string, dependsOn ...string) *ConfigBuilder {
+	return b.Block(BlockConfig{
+		Name:      name,
+		Type:      blockEndpoint,
+		Attribute: rdkutils.AttributeMap{controllableType: componentName},
+		DependsOn: dependsOn,
+	})
+}
+
+// Build validates and returns the assembled Config.
+func (b *ConfigBuilder) Build() (Config, error) {
+	if err := b.cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return b.cfg, nil
+}
+
+// Validate checks that the loop frequency is in range, that every block has a unique
+// name and a supported type, that every dependency refers to a block in the loop, and
+// that the loop has an endpoint. Any requiredBlockTypes must also be present.
+func (c Config) Validate(requiredBlockTypes ...string) error {
+	if c.Frequency <= 0 || c.Frequency > 200 {
+		return errors.New("loop frequency must be greater than 0 and at most 200Hz")
+	}
+	if _, err := c.normalized(); err != nil {
+		return err
+	}
+
+	var errs error
+	names := make(map[string]struct{}, len(c.Blocks))
+	types := make(map[controlBlockType]struct{}, len(c.Blocks))
+	for _, b := range c.Blocks {
+		if b.Name == "" {
+			errs = multierr.Combine(errs, errors.New("control blocks must have a name"))
+			continue
+		}
+		if _, ok := names[b.Name]; ok {
+			errs = multierr.Combine(errs, errors.Errorf("duplicate control block name %q", b.Name))
+		}
+		names[b.Name] = struct{}{}
+		types[b.Type] = struct{}{}
+		supported := false
+		for _, t := range supportedBlockTypes {
+			if b.Type == t {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			errs = multierr.Combine(errs, errors.Errorf("control block %q has unsupported type %q", b.Name, b.Type))
+		}
+	}
+	for _, b := range c.Blocks {
+		for _, dep := range b.DependsOn {
+			if _, ok := names[dep]; !ok {
+				errs = multierr.Combine(errs, errors.Errorf("control block %q depends on %q but it does not exist", b.Name, dep))
+			}
+		}
+	}
+	if _, ok := types[blockEndpoint]; !ok {
+		errs = multierr.Combine(errs, errors.New("control loop must have an endpoint block"))
+	}
+	for _, t := range requiredBlockTypes {
+		if _, ok := types[controlBlockType(t)]; !ok {
+			errs = multierr.Combine(errs, errors.Errorf("control loop must have a %s block", t))
+		}
+	}
+	return errs
+}
+
+// normalized returns a copy of the config with block attributes converted to the types
+// the blocks expect, so that configs decoded from JSON can be used to build a loop.
+func (c Config) normalized() (Config, error) {
+	out := Config{Frequency: c.Frequency}
+	if c.Blocks != nil {
+		out.Blocks = make([]BlockConfig, 0, len(c.Blocks))
+	}
+	for _, b := range c.Blocks {
+		if b.Attribute == nil {
+			out.Blocks = append(out.Blocks, b)
+			continue
+		}
+		attrs := make(rdkutils.AttributeMap, len(b.Attribute))
+		for k, v := range b.Attribute {
+			attrs[k] = v
+		}
+		for _, k := range intAttributes {
+			if v, ok := attrs[k].(float64); ok {
+				attrs[k] = int(v)
+			}
+		}
+		if v, ok := attrs["PIDSets"]; ok {
+			if _, ok := v.([]*PIDConfig); !ok {
+				data, err := json.Marshal(v)
+				if err != nil {
+					return Config{}, errors.Wrapf(err, "invalid PIDSets for control block %q", b.Name)
+				}
+				var pidSets []*PIDConfig
+				if err := json.Unmarshal(data, &pidSets); err != nil {
+					return Config{}, errors.Wrapf(err, "invalid PIDSets for control block %q", b.Name)
+				}
+				attrs["PIDSets"] = pidSets
+			}
+		}
+		b.Attribute = attrs
+		out.Blocks = append(out.Blocks, b)
+	}
+	return out, nil
+}
+
+// BlockState is a snapshot of a block in a running control loop.
+type BlockState struct {
+	Name      string
+	Type      string
+	DependsOn []string
+	Output    []float64
+}
+
+// BlockStates returns the most recent output of every block in the loop, sorted by name.
+func (l *Loop) BlockStates(ctx context.Context) []BlockState {
+	states := make([]BlockState, 0, len(l.blocks))
+	for name, b := range l.blocks {
+		cfg := b.blk.Config(ctx)
+		var output []float64
+		for _, s := range b.blk.Output(ctx) {
+			if s == nil {
+				continue
+			}
+			for i := 0; i < s.dimension; i++ {
+				output = append(output, s.GetSignalValueAt(i))
+			}
+		}
+		states = append(states, BlockState{
+			Name:      name,
+			Type:      string(cfg.Type),
+			DependsOn: cfg.DependsOn,
+			Output:    output,
+		})
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Name < states[j].Name })
+	return states
+}
+
+// LoopStateResponse returns the state of the loop in a form suitable for a DoCommand
+// response. A nil loop is reported as not running with no blocks.
+func LoopStateResponse(ctx context.Context, loop *Loop) map[string]interface{} {
+	blocks := []interface{}{}
+	if loop == nil {
+		return map[string]interface{}{"running": false, "blocks": blocks}
+	}
+	for _, state := range loop.BlockStates(ctx) {
+		dependsOn := make([]interface{}, 0, len(state.DependsOn))
+		for _, dep := range state.DependsOn {
+			dependsOn = append(dependsOn, dep)
+		}
+		output := make([]interface{}, 0, len(state.Output))
+		for _, v := range state.Output {
+			output = append(output, v)
+		}
+		blocks = append(blocks, map[string]interface{}{
+			"name":       state.Name,
+			"type":       state.Type,
+			"depends_on": dependsOn,
+			"output":     output,
+		})
+	}
+	return map[string]interface{}{
+		"running":   loop.Running(),
+		"frequency": loop.cfg.Frequency,
+		"blocks":    blocks,
+	}
+}
@@ -0,0 +1,112 @@
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/utils"
+)
+
+func TestConfigBuilder(t *testing.T) {
+	cfg, err := NewConfigBuilder(50).
+		Constant("set_point", 10).
+		Sum("sum", "+-", "set_point", "endpoint").
+		PID("PID", []PIDConfig{{P: 1, I: 0.5}}, -255, 255, "sum").
+		Saturation("clamp", -100, 100, "PID").
+		Gain("gain", 0.5, "clamp").
+		Endpoint("endpoint", "motor_name", "m", "gain").
+		Build()
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cfg.Blocks, test.ShouldHaveLength, 6)
+	test.That(t, cfg.Validate(BlockNameConstant), test.ShouldBeNil)
+	test.That(t, cfg.Validate(BlockNameTrapezoidal).Error(), test.ShouldContainSubstring,
+		"must have a trapezoidalVelocityProfile block")
+
+	_, err = NewConfigBuilder(50).
+		Constant("set_point", 10).
+		Gain("gain", 0.5, "missing").
+		Gain("gain", 0.5, "set_point").
+		Build()
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `"gain" depends on "missing"`)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `duplicate control block name "gain"`)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "must have an endpoint block")
+
+	_, err = NewConfigBuilder(0).Endpoint("endpoint", "motor_name", "m").Build()
+	test.That(t, err, test.ShouldNotBeNil)
+
+	_, err = NewConfigBuilder(50).
+		Block(BlockConfig{Name: "b", Type: "notABlock"}).
+		Endpoint("endpoint", "motor_name", "m", "b").
+		Build()
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "unsupported type")
+}
+
+func TestConfigFromJSON(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	data := `{
+		"frequency": 20,
+		"blocks": [
+			{"name": "set_point", "type": "constant", "attributes": {"constant_val": 3.0}},
+			{"name": "avg", "type": "filter", "attributes": {"type": "filterFIRMovingAverage", "filter_size": 3},
+				"depends_on": ["set_point"]},
+			{"name": "PID", "type": "PID", "attributes": {"PIDSets": [{"p": 1, "i": 2, "d": 0}]},
+				"depends_on": ["avg"]},
+			{"name": "endpoint", "type": "endpoint", "attributes": {"motor_name": "m"}, "depends_on": ["PID"]}
+		]
+	}`
+	var cfg Config
+	test.That(t, json.Unmarshal([]byte(data), &cfg), test.ShouldBeNil)
+	test.That(t, cfg.Validate(), test.ShouldBeNil)
+
+	loop, err := NewLoop(logger, cfg, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, loop.GetPIDVals(0), test.ShouldResemble, PIDConfig{P: 1, I: 2})
+
+	states := loop.BlockStates(context.Background())
+	test.That(t, states, test.ShouldHaveLength, 4)
+	test.That(t, states[0].Name, test.ShouldEqual, "PID")
+	test.That(t, states[0].DependsOn, test.ShouldResemble, []string{"avg"})
+	test.That(t, states[1].Name, test.ShouldEqual, "avg")
+	test.That(t, states[1].Type, test.ShouldEqual, "filter")
+
+	resp := LoopStateResponse(context.Background(), loop)
+	test.That(t, resp["running"], test.ShouldBeFalse)
+	test.That(t, resp["blocks"], test.ShouldHaveLength, 4)
+	test.That(t, LoopStateResponse(context.Background(), nil)["running"], test.ShouldBeFalse)
+	test.That(t, loop.startBenchmark(1), test.ShouldBeNil)
+	loop.activeBackgroundWorkers.Wait()
+}
+
+func TestSaturationNext(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+	cfg := BlockConfig{
+		Name: "clamp",
+		Type: "saturation",
+		Attribute: utils.AttributeMap{
+			"lower_limit": -1.0,
+			"upper_limit": 2.0,
+		},
+		DependsOn: []string{"A"},
+	}
+	b, err := newSaturation(cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	in := []*Signal{makeSignal("A", blockConstant)}
+	for _, tc := range []struct{ in, out float64 }{{5, 2}, {-3, -1}, {0.5, 0.5}} {
+		in[0].SetSignalValueAt(0, tc.in)
+		out, ok := b.Next(ctx, in, time.Millisecond)
+		test.That(t, ok, test.ShouldBeTrue)
+		test.That(t, out[0].GetSignalValueAt(0), test.ShouldEqual, tc.out)
+	}
+
+	cfg.Attribute["lower_limit"] = 3.0
+	test.That(t, b.UpdateConfig(ctx, cfg), test.ShouldNotBeNil)
+}
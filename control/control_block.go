@@ -22,6 +22,7 @@ const (
 	blockEncoderToRPM               controlBlockType = "encoderToRpm"
 	blockEndpoint                   controlBlockType = "endpoint"
 	blockFilter                     controlBlockType = "filter"
+	blockSaturation                 controlBlockType = "saturation"
 )
 
 // BlockConfig configuration of a given block.
@@ -95,6 +96,12 @@ func (l *Loop) createBlock(cfg BlockConfig, logger logging.Logger) (Block, error
 			return nil, err
 		}
 		return b, nil
+	case blockSaturation:
+		b, err := newSaturation(cfg, logger)
+		if err != nil {
+			return nil, err
+		}
+		return b, nil
 	case blockConstant:
 		b, err := newConstant(cfg, logger)
 		if err != nil {
@@ -49,6 +49,11 @@ func NewLoop(logger logging.Logger, cfg Config, m Controllable) (*Loop, error) {
 }
 
 func createLoop(logger logging.Logger, cfg Config, m Controllable) (*Loop, error) {
+	// configs declared in JSON need their attributes converted before blocks can use them
+	cfg, err := cfg.normalized()
+	if err != nil {
+		return nil, err
+	}
 	cancelCtx, cancel := context.WithCancel(context.Background())
 	l := Loop{
 		logger:    logger,
@@ -0,0 +1,88 @@
+package control
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/logging"
+)
+
+type saturation struct {
+	mu         sync.Mutex
+	cfg        BlockConfig
+	y          []*Signal
+	lowerLimit float64
+	upperLimit float64
+	logger     logging.Logger
+}
+
+func newSaturation(config BlockConfig, logger logging.Logger) (Block, error) {
+	s := &saturation{cfg: config, logger: logger}
+	if err := s.reset(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (b *saturation) Next(ctx context.Context, x []*Signal, dt time.Duration) ([]*Signal, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(x) != 1 {
+		return b.y, false
+	}
+	v := x[0].GetSignalValueAt(0)
+	switch {
+	case v > b.upperLimit:
+		v = b.upperLimit
+	case v < b.lowerLimit:
+		v = b.lowerLimit
+	}
+	b.y[0].SetSignalValueAt(0, v)
+	return b.y, true
+}
+
+func (b *saturation) reset() error {
+	lower, ok := b.cfg.Attribute["lower_limit"].(float64)
+	if !ok {
+		return errors.Errorf("saturation block %s doesn't have a lower_limit field", b.cfg.Name)
+	}
+	upper, ok := b.cfg.Attribute["upper_limit"].(float64)
+	if !ok {
+		return errors.Errorf("saturation block %s doesn't have an upper_limit field", b.cfg.Name)
+	}
+	if lower > upper {
+		return errors.Errorf("saturation block %s lower_limit %v is greater than upper_limit %v", b.cfg.Name, lower, upper)
+	}
+	if len(b.cfg.DependsOn) != 1 {
+		return errors.Errorf("invalid number of inputs for saturation block %s expected 1 got %d", b.cfg.Name, len(b.cfg.DependsOn))
+	}
+	b.lowerLimit = lower
+	b.upperLimit = upper
+	b.y = make([]*Signal, 1)
+	b.y[0] = makeSignal(b.cfg.Name, b.cfg.Type)
+	return nil
+}
+
+func (b *saturation) Reset(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.reset()
+}
+
+func (b *saturation) UpdateConfig(ctx context.Context, config BlockConfig) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.cfg = config
+	return b.reset()
+}
+
+func (b *saturation) Output(ctx context.Context) []*Signal {
+	return b.y
+}
+
+func (b *saturation) Config(ctx context.Context) BlockConfig {
+	return b.cfg
+}
@@ -42,6 +42,7 @@ type PIDLoop struct {
 	ControlLoop             *Loop
 	Options                 Options
 	Controllable            Controllable
+	componentName           string
 	logger                  logging.Logger
 	activeBackgroundWorkers sync.WaitGroup
 }
@@ -94,6 +95,10 @@ type Options struct {
 	// ControllableType is the type of component the control loop will be set up for,
 	// currently a base or motor
 	ControllableType string
+
+	// TuneMethod is the method used to calculate PID gains when auto-tuning. Defaults
+	// to ziegerNicholsPI.
+	TuneMethod string
 }
 
 // SetupPIDControlConfig creates a control config.
@@ -105,23 +110,29 @@ func SetupPIDControlConfig(
 	logger logging.Logger,
 ) (*PIDLoop, error) {
 	pidLoop := &PIDLoop{
-		Controllable: c,
-		PIDVals:      pidVals,
-		TunedVals:    &[]PIDConfig{{}, {}},
-		logger:       logger,
-		Options:      options,
-		ControlConf:  &Config{},
-		ControlLoop:  nil,
+		Controllable:  c,
+		PIDVals:       pidVals,
+		TunedVals:     &[]PIDConfig{{}, {}},
+		componentName: componentName,
+		logger:        logger,
+		Options:       options,
+		ControlConf:   &Config{},
+		ControlLoop:   nil,
 	}
 
 	// set controlConf as either an optional custom config, or as the default control config
 	if options.UseCustomConfig {
-		*pidLoop.ControlConf = options.CompleteCustomConfig
-		for i, b := range options.CompleteCustomConfig.Blocks {
+		customConf, err := options.CompleteCustomConfig.normalized()
+		if err != nil {
+			return nil, err
+		}
+		*pidLoop.ControlConf = customConf
+		for i, b := range customConf.Blocks {
 			if b.Type == blockSum {
 				sumIndex = i
 			}
 		}
+		pidLoop.assignBlockNames()
 	} else {
 		pidLoop.createControlLoopConfig(pidVals, componentName)
 	}
@@ -251,7 +262,11 @@ func (p *PIDLoop) createControlLoopConfig(pidVals []PIDConfig, componentName str
 		p.addSensorFeedbackVelocityControl(pidVals[1])
 	}
 
-	// assign block names
+	p.assignBlockNames()
+}
+
+// assignBlockNames groups the names of the blocks in the control config by block type.
+func (p *PIDLoop) assignBlockNames() {
 	p.BlockNames = make(map[string][]string, len(p.ControlConf.Blocks))
 	for _, b := range p.ControlConf.Blocks {
 		p.BlockNames[string(b.Type)] = append(p.BlockNames[string(b.Type)], b.Name)
@@ -290,7 +305,7 @@ func (p *PIDLoop) basicControlConfig(endpointName string, pidVals PIDConfig, con
 					"PIDSets":        []*PIDConfig{&pidVals},
 					"limit_lo":       -255.0,
 					"limit_up":       255.0,
-					"tune_method":    "ziegerNicholsPI",
+					"tune_method":    p.tuneMethod(),
 					"tune_ssr_value": 2.0,
 					"tune_step_pct":  0.35,
 				},
@@ -394,7 +409,7 @@ func (p *PIDLoop) addSensorFeedbackVelocityControl(angularPIDVals PIDConfig) {
 			"int_sat_lim_up": 255.0,
 			"limit_lo":       -255.0,
 			"limit_up":       255.0,
-			"tune_method":    "ziegerNicholsPI",
+			"tune_method":    p.tuneMethod(),
 			"tune_ssr_value": 2.0,
 			"tune_step_pct":  0.35,
 		},
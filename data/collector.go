@@ -82,6 +82,10 @@ type collector struct {
 	target           CaptureBufferedWriter
 	lastLoggedErrors map[string]int64
 	dataType         CaptureType
+
+	// consecutiveFailures is only accessed by the capture goroutine.
+	consecutiveFailures int
+	onCaptureFailure    func(consecutiveFailures int, err error)
 }
 
 // Close closes the channels backing the Collector. It should always be called before disposing of a Collector to avoid
@@ -213,19 +217,20 @@ func (c *collector) getAndPushNextReading() {
 			c.logger.Debug("capture filtered out by modular resource")
 			return
 		}
-		c.captureErrors <- errors.Wrap(err, "error while capturing data")
+		c.captureFailed(errors.Wrap(err, "error while capturing data"))
 		return
 	}
 
 	if err := c.validateReadingType(result.Type); err != nil {
-		c.captureErrors <- errors.Wrap(err, "capture result invalid type")
+		c.captureFailed(errors.Wrap(err, "capture result invalid type"))
 		return
 	}
 
 	if err := result.Validate(); err != nil {
-		c.captureErrors <- errors.Wrap(err, "capture result failed validation")
+		c.captureFailed(errors.Wrap(err, "capture result failed validation"))
 		return
 	}
+	c.consecutiveFailures = 0
 
 	select {
 	// If c.captureResults is full, c.captureResults <- a can block indefinitely.
@@ -236,6 +241,14 @@ func (c *collector) getAndPushNextReading() {
 	}
 }
 
+func (c *collector) captureFailed(err error) {
+	c.consecutiveFailures++
+	if c.onCaptureFailure != nil {
+		c.onCaptureFailure(c.consecutiveFailures, err)
+	}
+	c.captureErrors <- err
+}
+
 // NewCollector returns a new Collector with the passed capturer and configuration options. It calls capturer at the
 // specified Interval, and appends the resulting reading to target.
 func NewCollector(captureFunc CaptureFunc, params CollectorParams) (Collector, error) {
@@ -267,6 +280,7 @@ func NewCollector(captureFunc CaptureFunc, params CollectorParams) (Collector, e
 		target:           params.Target,
 		clock:            c,
 		lastLoggedErrors: make(map[string]int64, 0),
+		onCaptureFailure: params.OnCaptureFailure,
 	}, nil
 }
 
@@ -31,6 +31,9 @@ type CollectorParams struct {
 	MongoCollection *mongo.Collection
 	QueueSize       int
 	Target          CaptureBufferedWriter
+	// OnCaptureFailure, if set, is called every time a capture fails with how many captures in
+	// a row have failed.
+	OnCaptureFailure func(consecutiveFailures int, err error)
 }
 
 // Validate validates that p contains all required parameters.
@@ -118,6 +118,8 @@ type FTDC struct {
 	maxNumFiles         int
 	// ftdcDir controls where FTDC data files will be written.
 	ftdcDir string
+	// namePrefix is prepended to the names of all `Add`ed statsers.
+	namePrefix string
 
 	uploader *uploader
 	logger   logging.Logger
@@ -178,6 +180,7 @@ func (ftdc *FTDC) Add(name string, statser Statser) {
 	ftdc.mu.Lock()
 	defer ftdc.mu.Unlock()
 
+	name = ftdc.namePrefix + name
 	for _, statser := range ftdc.statsers {
 		if statser.name == name {
 			ftdc.logger.Warnw("Trying to add conflicting ftdc section", "name", name)
@@ -199,6 +202,7 @@ func (ftdc *FTDC) Remove(name string) {
 	ftdc.mu.Lock()
 	defer ftdc.mu.Unlock()
 
+	name = ftdc.namePrefix + name
 	for idx, statser := range ftdc.statsers {
 		if statser.name == name {
 			ftdc.logger.Debugw("Removed statser", "name", name, "type", fmt.Sprintf("%T", statser.statser))
@@ -210,6 +214,14 @@ func (ftdc *FTDC) Remove(name string) {
 	ftdc.logger.Warnw("Did not find statser to remove", "name", name)
 }
 
+// SetNamePrefix prepends `prefix` to the section names of statsers `Add`ed and `Remove`d after
+// this call. Such that the metrics of several robots in one process can be told apart.
+func (ftdc *FTDC) SetNamePrefix(prefix string) {
+	ftdc.mu.Lock()
+	defer ftdc.mu.Unlock()
+	ftdc.namePrefix = prefix
+}
+
 // Start spins off the background goroutine for collecting + writing FTDC data. It's normal for tests
 // to _not_ call `Start`. Tests can simulate the same functionality by calling `constructDatum` and `writeDatum`.
 func (ftdc *FTDC) Start() {
@@ -170,6 +170,26 @@ func TestMapStatser(t *testing.T) {
 	test.That(t, datums[1].asDatum().Data["mapStatser"], test.ShouldResemble, map[string]float32{"B": 42})
 }
 
+func TestNamePrefix(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+
+	ftdc := NewWithWriter(bytes.NewBuffer(nil), logger.Sublogger("ftdc"))
+	ftdc.Add("unprefixed", &foo{x: 1, y: 2})
+	ftdc.SetNamePrefix("ns1.")
+	ftdc.Add("foo", &foo{x: 3, y: 4})
+
+	datum := ftdc.constructDatum()
+	test.That(t, len(datum.Data), test.ShouldEqual, 2)
+	test.That(t, datum.Data["unprefixed"], test.ShouldNotBeNil)
+	test.That(t, datum.Data["ns1.foo"], test.ShouldNotBeNil)
+
+	// `Remove` is given the same name as `Add`.
+	ftdc.Remove("foo")
+	datum = ftdc.constructDatum()
+	test.That(t, len(datum.Data), test.ShouldEqual, 1)
+	test.That(t, datum.Data["unprefixed"], test.ShouldNotBeNil)
+}
+
 type nestedStatser struct {
 	x int
 	z int
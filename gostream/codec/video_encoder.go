@@ -21,6 +21,14 @@ type VideoEncoder interface {
 	Close() error
 }
 
+// A PooledVideoEncoder is a VideoEncoder that can encode into a reused buffer instead of
+// allocating a new one per frame. The caller must call release once it no longer references
+// the encoded bytes.
+type PooledVideoEncoder interface {
+	VideoEncoder
+	EncodePooled(ctx context.Context, img image.Image) (data []byte, release func(), err error)
+}
+
 // A VideoEncoderFactory produces VideoEncoders and provides information about the underlying encoder itself.
 type VideoEncoderFactory interface {
 	New(height, width, keyFrameInterval int, logger logging.Logger) (VideoEncoder, error)
@@ -12,6 +12,7 @@ import (
 
 	ourcodec "go.viam.com/rdk/gostream/codec"
 	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/rimage"
 )
 
 type encoder struct {
@@ -69,6 +70,23 @@ func (v *encoder) Encode(_ context.Context, img image.Image) ([]byte, error) {
 	return dataCopy, err
 }
 
+// EncodePooled asks the codec to process the given image and copies the result into a
+// pooled buffer that is returned to the pool by the returned release func.
+func (v *encoder) EncodePooled(_ context.Context, img image.Image) ([]byte, func(), error) {
+	v.img = img
+	data, release, err := v.codec.Read()
+	// the codec owns data until release, so it must be copied out before the next frame
+	dataCopy := rimage.GetBuffer(len(data))
+	copy(dataCopy, data)
+	release()
+	v.img = nil
+	if err != nil {
+		rimage.PutBuffer(dataCopy)
+		return nil, nil, err
+	}
+	return dataCopy, func() { rimage.PutBuffer(dataCopy) }, nil
+}
+
 // Close closes the encoder.
 func (v *encoder) Close() error {
 	return v.codec.Close()
@@ -104,7 +104,7 @@ func NewStream(config StreamConfig, logger logging.Logger) (Stream, error) {
 
 		videoTrackLocal: trackLocal,
 		inputImageChan:  make(chan MediaReleasePair[image.Image]),
-		outputVideoChan: make(chan []byte),
+		outputVideoChan: make(chan MediaReleasePair[[]byte]),
 
 		audioTrackLocal: audioTrackLocal,
 		inputAudioChan:  make(chan MediaReleasePair[wave.Audio]),
@@ -127,7 +127,7 @@ type basicStream struct {
 
 	videoTrackLocal *trackLocalStaticSample
 	inputImageChan  chan MediaReleasePair[image.Image]
-	outputVideoChan chan []byte
+	outputVideoChan chan MediaReleasePair[[]byte]
 	videoEncoder    codec.VideoEncoder
 
 	audioTrackLocal *trackLocalStaticSample
@@ -194,7 +194,7 @@ func (bs *basicStream) Stop() {
 	}
 
 	// reset
-	bs.outputVideoChan = make(chan []byte)
+	bs.outputVideoChan = make(chan MediaReleasePair[[]byte])
 	bs.outputAudioChan = make(chan []byte)
 	ctx, cancelFunc := context.WithCancel(context.Background())
 	bs.shutdownCtx = ctx
@@ -265,14 +265,20 @@ func (bs *basicStream) processInputFrames() {
 		}
 		var initErr bool
 		func() {
-			if framePair.Release != nil {
-				defer framePair.Release()
-			}
+			defer func() {
+				if framePair.Release != nil {
+					framePair.Release()
+				}
+			}()
 
 			var encodedFrame []byte
+			var releaseEncoded func()
 
 			if frame, ok := framePair.Media.(*rimage.LazyEncodedImage); ok && frame.MIMEType() == utils2.MimeTypeH264 {
-				encodedFrame = frame.RawData() // nothing to do; already encoded
+				// nothing to do; already encoded. The encoded frame is the input frame's data,
+				// so the input frame is released once the output goroutine has written it.
+				encodedFrame = frame.RawData()
+				releaseEncoded, framePair.Release = framePair.Release, nil
 			} else {
 				var bounds image.Rectangle
 				var boundsError any
@@ -313,19 +319,30 @@ func (bs *basicStream) processInputFrames() {
 
 				// thread-safe because the size is static
 				var err error
-				encodedFrame, err = bs.videoEncoder.Encode(bs.shutdownCtx, framePair.Media)
+				if pooled, ok := bs.videoEncoder.(codec.PooledVideoEncoder); ok {
+					encodedFrame, releaseEncoded, err = pooled.EncodePooled(bs.shutdownCtx, framePair.Media)
+				} else {
+					encodedFrame, err = bs.videoEncoder.Encode(bs.shutdownCtx, framePair.Media)
+				}
 				if err != nil {
 					bs.logger.Error(err)
 					return
 				}
 			}
 
-			if encodedFrame != nil {
-				select {
-				case <-bs.shutdownCtx.Done():
-					return
-				case bs.outputVideoChan <- encodedFrame:
+			if encodedFrame == nil {
+				if releaseEncoded != nil {
+					releaseEncoded()
 				}
+				return
+			}
+			select {
+			case <-bs.shutdownCtx.Done():
+				if releaseEncoded != nil {
+					releaseEncoded()
+				}
+				return
+			case bs.outputVideoChan <- MediaReleasePair[[]byte]{encodedFrame, releaseEncoded}:
 			}
 		}()
 		if initErr {
@@ -396,13 +413,19 @@ func (bs *basicStream) processOutputFrames() {
 	for outputFrame := range bs.outputVideoChan {
 		select {
 		case <-bs.shutdownCtx.Done():
+			if outputFrame.Release != nil {
+				outputFrame.Release()
+			}
 			return
 		default:
 		}
 		now := time.Now()
-		if err := bs.videoTrackLocal.WriteData(outputFrame); err != nil {
+		if err := bs.videoTrackLocal.WriteData(outputFrame.Media); err != nil {
 			bs.logger.Errorw("error writing frame", "error", err)
 		}
+		if outputFrame.Release != nil {
+			outputFrame.Release()
+		}
 		framesSent++
 		if Debug {
 			bs.logger.Debugw("wrote sample", "frames_sent", framesSent, "write_time", time.Since(now))
@@ -64,3 +64,22 @@ func (r *fakeReader) Close(_ context.Context) error {
 func newFakeReader() gostream.MediaReader[image.Image] {
 	return &fakeReader{}
 }
+
+func TestResizeVideoSource(t *testing.T) {
+	src := gostream.NewVideoSource(newFakeReader(), prop.Video{})
+	resizer := gostream.NewResizeVideoSource(src, 4, 2)
+	defer func() {
+		test.That(t, resizer.Close(context.Background()), test.ShouldBeNil)
+	}()
+
+	for i := 0; i < 3; i++ {
+		img, release, err := gostream.ReadImage(context.Background(), resizer)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, img.Bounds(), test.ShouldResemble, image.Rect(0, 0, 4, 2))
+		// the single source pixel is scaled to every pixel
+		r, g, b, a := img.At(3, 1).RGBA()
+		test.That(t, []uint32{r, g, b, a}, test.ShouldResemble, []uint32{0, 0, 0, 0})
+		test.That(t, release, test.ShouldNotBeNil)
+		release()
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"image"
 
-	"github.com/disintegration/imaging"
 	"github.com/pion/mediadevices/pkg/prop"
 	"go.uber.org/multierr"
+	"golang.org/x/image/draw"
+
+	"go.viam.com/rdk/rimage"
 )
 
 type resizeVideoSource struct {
@@ -29,7 +31,8 @@ func NewResizeVideoSource(src VideoSource, width, height int) VideoSource {
 	})
 }
 
-// Read returns a resized image to Width x Height dimensions.
+// Read returns a resized image to Width x Height dimensions. The resized image is backed by
+// a pooled buffer that is reused once the returned release func is called.
 func (rvs resizeVideoSource) Read(ctx context.Context) (image.Image, func(), error) {
 	img, release, err := rvs.stream.Next(ctx)
 	if err != nil {
@@ -39,7 +42,9 @@ func (rvs resizeVideoSource) Read(ctx context.Context) (image.Image, func(), err
 		defer release()
 	}
 
-	return imaging.Resize(img, rvs.width, rvs.height, imaging.NearestNeighbor), func() {}, nil
+	resized := rimage.GetRGBA(image.Rect(0, 0, rvs.width, rvs.height))
+	draw.NearestNeighbor.Scale(resized, resized.Bounds(), img, img.Bounds(), draw.Src, nil)
+	return resized, func() { rimage.PutRGBA(resized) }, nil
 }
 
 // Close closes the underlying source.
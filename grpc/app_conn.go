@@ -25,8 +25,13 @@ type AppConn struct {
 // is returned. If it times out, an `AppConn` object with a nil underlying client connection will return. Serialized attempts at
 // establishing a connection to App will continue to occur, however, in a background Goroutine. These attempts will continue until a
 // connection is made. If `cloud` is nil, an `AppConn` with a nil underlying connection will return, and the background dialer will not
-// start.
-func NewAppConn(ctx context.Context, appAddress, secret, id string, logger logging.Logger) (rpc.ClientConn, error) {
+// start. extraOpts are added to every dial, such as to trust a custom CA.
+func NewAppConn(
+	ctx context.Context,
+	appAddress, secret, id string,
+	logger logging.Logger,
+	extraOpts ...rpc.DialOption,
+) (rpc.ClientConn, error) {
 	appConn := &AppConn{ReconfigurableClientConn: &ReconfigurableClientConn{Logger: logger.Sublogger("app_conn")}}
 
 	grpcURL, err := url.Parse(appAddress)
@@ -39,6 +44,7 @@ func NewAppConn(ctx context.Context, appAddress, secret, id string, logger loggi
 	if grpcURL.Scheme == "http" {
 		dialOpts = append(dialOpts, rpc.WithInsecure())
 	}
+	dialOpts = append(dialOpts, extraOpts...)
 
 	ctxWithTimeout, ctxWithTimeoutCancel := contextutils.GetTimeoutCtx(ctx, true, id)
 	defer ctxWithTimeoutCancel()
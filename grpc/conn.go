@@ -9,6 +9,7 @@ import (
 	"go.viam.com/utils/rpc"
 	"golang.org/x/exp/maps"
 	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 
 	"go.viam.com/rdk/logging"
 )
@@ -101,6 +102,32 @@ func (c *ReconfigurableClientConn) PeerConn() *webrtc.PeerConnection {
 	return c.conn.PeerConn()
 }
 
+// Connected returns whether the underlying client connection is connected, going by the
+// connectivity state of a gRPC connection or the connection state of a WebRTC peer connection.
+// It is false until the first connection is established and after Close.
+func (c *ReconfigurableClientConn) Connected() bool {
+	c.connMu.Lock()
+	conn := c.conn
+	c.connMu.Unlock()
+	if conn == nil {
+		return false
+	}
+	if pc := conn.PeerConn(); pc != nil {
+		return pc.ConnectionState() == webrtc.PeerConnectionStateConnected
+	}
+	if stater, ok := conn.(interface{ GetState() connectivity.State }); ok {
+		// an idle connection has no transport open but reconnects as soon as it is used
+		switch stater.GetState() {
+		case connectivity.Ready, connectivity.Idle:
+			return true
+		case connectivity.Connecting, connectivity.TransientFailure, connectivity.Shutdown:
+			return false
+		}
+	}
+	// the connection cannot report its state, so assume it is usable until it is replaced
+	return true
+}
+
 // Close attempts to close the underlying client connection if there is one.
 func (c *ReconfigurableClientConn) Close() error {
 	c.connMu.Lock()
@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// IntrospectionServiceName is the name of the gRPC service that describes a machine as one JSON
+// document, for fleet inventory tooling. Its messages are well-known types, so it can be called
+// by generic gRPC tools without any generated code.
+const IntrospectionServiceName = "rdk.robot.v1.IntrospectionService"
+
+// IntrospectionMethod is the full name of the method of the introspection service, which takes
+// an empty message and returns the document as a string value.
+const IntrospectionMethod = "/" + IntrospectionServiceName + "/GetIntrospection"
+
+// An IntrospectionServer serves the introspection service.
+type IntrospectionServer interface {
+	GetIntrospection(ctx context.Context, req *emptypb.Empty) (*wrapperspb.StringValue, error)
+}
+
+// IntrospectionServiceDesc describes the introspection service for registering an
+// IntrospectionServer.
+var IntrospectionServiceDesc = grpc.ServiceDesc{
+	ServiceName: IntrospectionServiceName,
+	HandlerType: (*IntrospectionServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetIntrospection",
+			Handler:    getIntrospectionHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func getIntrospectionHandler(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IntrospectionServer).GetIntrospection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IntrospectionMethod,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IntrospectionServer).GetIntrospection(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GetIntrospection calls the introspection service of the machine at the other end of conn and
+// returns its JSON document.
+func GetIntrospection(ctx context.Context, conn grpc.ClientConnInterface, opts ...grpc.CallOption) ([]byte, error) {
+	resp := &wrapperspb.StringValue{}
+	if err := conn.Invoke(ctx, IntrospectionMethod, &emptypb.Empty{}, resp, opts...); err != nil {
+		return nil, err
+	}
+	return []byte(resp.GetValue()), nil
+}
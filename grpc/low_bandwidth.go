@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/fxamacker/cbor/v2"
+	"google.golang.org/grpc"
+
+	lbpb "go.viam.com/rdk/proto/rdk/lowbandwidth/v1"
+)
+
+// A ResourceNamesDelta is how the resource names of a machine changed since the delta with the
+// token the client sent, so that unchanged names are not sent again. It is sent by the low
+// bandwidth service as CBOR.
+type ResourceNamesDelta struct {
+	// Token identifies the current names, for the client to send with its next request.
+	Token string `cbor:"token"`
+	// Full is whether Added holds every name, as when the client's token was not known.
+	Full    bool     `cbor:"full,omitempty"`
+	Added   []string `cbor:"added,omitempty"`
+	Removed []string `cbor:"removed,omitempty"`
+}
+
+// A CompactMachineStatus is the status of a machine as sent by the low bandwidth service. Only
+// what a remote's parent needs to know is sent, and states are sent as short strings.
+type CompactMachineStatus struct {
+	ConfigRevision string                  `cbor:"revision"`
+	State          string                  `cbor:"state"`
+	Resources      []CompactResourceStatus `cbor:"resources"`
+}
+
+// A CompactResourceStatus is the status of one resource of a CompactMachineStatus.
+type CompactResourceStatus struct {
+	Name     string `cbor:"name"`
+	State    string `cbor:"state"`
+	Revision string `cbor:"revision,omitempty"`
+	Error    string `cbor:"error,omitempty"`
+}
+
+// GetResourceNamesDelta calls the low bandwidth service of the machine at the other end of conn
+// for how its resource names changed since the delta with the given token. An empty token asks for
+// every name.
+func GetResourceNamesDelta(
+	ctx context.Context, conn grpc.ClientConnInterface, since string, opts ...grpc.CallOption,
+) (ResourceNamesDelta, error) {
+	resp, err := lbpb.NewLowBandwidthServiceClient(conn).GetResourceNamesDelta(
+		ctx, &lbpb.GetResourceNamesDeltaRequest{Since: since}, opts...)
+	if err != nil {
+		return ResourceNamesDelta{}, err
+	}
+	var delta ResourceNamesDelta
+	if err := cbor.Unmarshal(resp.GetDelta(), &delta); err != nil {
+		return ResourceNamesDelta{}, err
+	}
+	return delta, nil
+}
+
+// GetCompactMachineStatus calls the low bandwidth service of the machine at the other end of conn
+// for its status.
+func GetCompactMachineStatus(
+	ctx context.Context, conn grpc.ClientConnInterface, opts ...grpc.CallOption,
+) (CompactMachineStatus, error) {
+	resp, err := lbpb.NewLowBandwidthServiceClient(conn).GetCompactMachineStatus(
+		ctx, &lbpb.GetCompactMachineStatusRequest{}, opts...)
+	if err != nil {
+		return CompactMachineStatus{}, err
+	}
+	var status CompactMachineStatus
+	if err := cbor.Unmarshal(resp.GetStatus(), &status); err != nil {
+		return CompactMachineStatus{}, err
+	}
+	return status, nil
+}
@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// MaxMetadataValuesSize is the most bytes of values EncodeMetadataValues puts under one key, which
+// keeps headers well within the limits of gRPC servers and the proxies between them and clients.
+const MaxMetadataValuesSize = 8 << 10
+
+// EncodeMetadataValues returns a header with each of values JSON encoded under key, for responses
+// whose messages have no field for them. Values that do not fit in MaxMetadataValuesSize are left
+// out, and how many were is returned. The header is nil if there is nothing to send.
+func EncodeMetadataValues[T any](key string, values []T) (metadata.MD, int) {
+	encoded := make([]string, 0, len(values))
+	size := 0
+	for _, v := range values {
+		md, err := json.Marshal(v)
+		if err != nil || size+len(md) > MaxMetadataValuesSize {
+			continue
+		}
+		size += len(md)
+		encoded = append(encoded, string(md))
+	}
+	dropped := len(values) - len(encoded)
+	if len(encoded) == 0 {
+		return nil, dropped
+	}
+	return metadata.MD{key: encoded}, dropped
+}
+
+// DecodeMetadataValues returns the values that EncodeMetadataValues put in a header under key,
+// skipping any that cannot be decoded.
+func DecodeMetadataValues[T any](md metadata.MD, key string) []T {
+	var values []T
+	for _, value := range md.Get(key) {
+		var v T
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			continue
+		}
+		values = append(values, v)
+	}
+	return values
+}
@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"strings"
+	"testing"
+
+	"go.viam.com/test"
+	"google.golang.org/grpc/metadata"
+)
+
+type testMetadataValue struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestMetadataValues(t *testing.T) {
+	md, dropped := EncodeMetadataValues[testMetadataValue]("test-values", nil)
+	test.That(t, md, test.ShouldBeNil)
+	test.That(t, dropped, test.ShouldEqual, 0)
+
+	values := []testMetadataValue{{Name: "arm1", Count: 1}, {Name: "arm2", Count: 2}}
+	md, dropped = EncodeMetadataValues("test-values", values)
+	test.That(t, dropped, test.ShouldEqual, 0)
+	test.That(t, DecodeMetadataValues[testMetadataValue](md, "test-values"), test.ShouldResemble, values)
+
+	// values that cannot be decoded are skipped
+	md.Append("test-values", "not json")
+	test.That(t, DecodeMetadataValues[testMetadataValue](md, "test-values"), test.ShouldResemble, values)
+	test.That(t, DecodeMetadataValues[testMetadataValue](metadata.MD{}, "test-values"), test.ShouldBeNil)
+
+	// values past the size bound are left out
+	long := testMetadataValue{Name: strings.Repeat("a", MaxMetadataValuesSize/3)}
+	md, dropped = EncodeMetadataValues("test-values", []testMetadataValue{long, long, long, values[0]})
+	test.That(t, dropped, test.ShouldEqual, 1)
+	test.That(t, DecodeMetadataValues[testMetadataValue](md, "test-values"), test.ShouldResemble,
+		[]testMetadataValue{long, long, values[0]})
+}
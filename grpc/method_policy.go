@@ -0,0 +1,238 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// MethodPolicyMetadataKey is the response header in which a server advertises the policy of a
+// method to clients, as JSON.
+const MethodPolicyMetadataKey = "rdk-method-policy"
+
+// A MethodPolicy is the default deadline and retry policy of a gRPC method.
+type MethodPolicy struct {
+	// Timeout is the deadline of requests that do not set their own.
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// MaxAttempts is how many times clients try a request that fails with one of RetryCodes,
+	// including the first time.
+	MaxAttempts uint `json:"max_attempts,omitempty"`
+	// RetryBackoff is how long clients wait between attempts.
+	RetryBackoff time.Duration `json:"retry_backoff,omitempty"`
+	// RetryCodes are the status codes that are retried. If empty, the defaults of
+	// grpc_retry are.
+	RetryCodes []codes.Code `json:"retry_codes,omitempty"`
+}
+
+// callOptions returns the grpc_retry options that retry a call according to the policy.
+func (p MethodPolicy) callOptions() []grpc.CallOption {
+	if p.MaxAttempts <= 1 {
+		return nil
+	}
+	opts := []grpc.CallOption{
+		grpc_retry.WithMax(p.MaxAttempts),
+		grpc_retry.WithBackoff(grpc_retry.BackoffLinear(p.RetryBackoff)),
+	}
+	if len(p.RetryCodes) != 0 {
+		opts = append(opts, grpc_retry.WithCodes(p.RetryCodes...))
+	}
+	return opts
+}
+
+// MethodPolicies are the policies of the methods a server serves, keyed by full method name, like
+// "/viam.component.camera.v1.CameraService/GetImage", or by service name, like
+// "/viam.component.camera.v1.CameraService/", for all of the methods of a service that do not
+// have their own. They can be replaced while the server runs.
+type MethodPolicies struct {
+	policies atomic.Pointer[map[string]MethodPolicy]
+}
+
+// Set replaces all of the policies.
+func (mp *MethodPolicies) Set(policies map[string]MethodPolicy) {
+	mp.policies.Store(&policies)
+}
+
+// Lookup returns the policy of a method.
+func (mp *MethodPolicies) Lookup(fullMethod string) (MethodPolicy, bool) {
+	policies := mp.policies.Load()
+	if policies == nil {
+		return MethodPolicy{}, false
+	}
+	if policy, ok := (*policies)[fullMethod]; ok {
+		return policy, true
+	}
+	if idx := strings.LastIndex(fullMethod, "/"); idx > 0 {
+		policy, ok := (*policies)[fullMethod[:idx+1]]
+		return policy, ok
+	}
+	return MethodPolicy{}, false
+}
+
+// UnaryServerInterceptor applies the deadline of a method's policy to requests that do not set
+// their own, or DefaultMethodTimeout for methods without one, and advertises the policy in the
+// MethodPolicyMetadataKey header. It takes the place of EnsureTimeoutUnaryServerInterceptor.
+func (mp *MethodPolicies) UnaryServerInterceptor(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (interface{}, error) {
+	policy, ok := mp.Lookup(info.FullMethod)
+	timeout := DefaultMethodTimeout
+	if ok && policy.Timeout > 0 {
+		timeout = policy.Timeout
+	}
+	if _, deadlineSet := ctx.Deadline(); !deadlineSet {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if ok {
+		if encoded, err := json.Marshal(policy); err == nil {
+			// the header is only advisory, so a failure to send it is not the request's
+			_ = grpc.SetHeader(ctx, metadata.Pairs(MethodPolicyMetadataKey, string(encoded)))
+		}
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor applies the deadline of a method's policy to streams that do not set
+// their own, and advertises the policy in the MethodPolicyMetadataKey header. Streams of methods
+// without a policy are left without a deadline, since they may be meant to last.
+func (mp *MethodPolicies) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream,
+	info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+) error {
+	policy, ok := mp.Lookup(info.FullMethod)
+	if !ok {
+		return handler(srv, ss)
+	}
+	if encoded, err := json.Marshal(policy); err == nil {
+		_ = ss.SetHeader(metadata.Pairs(MethodPolicyMetadataKey, string(encoded)))
+	}
+	ctx := ss.Context()
+	if _, deadlineSet := ctx.Deadline(); deadlineSet || policy.Timeout <= 0 {
+		return handler(srv, ss)
+	}
+	ctx, cancel := context.WithTimeout(ctx, policy.Timeout)
+	defer cancel()
+	wrapped := grpc_middleware.WrapServerStream(ss)
+	wrapped.WrappedContext = ctx
+	return handler(srv, wrapped)
+}
+
+// MethodPolicyLearner applies the policies that a server advertises to the later requests of a
+// client. It must come before the grpc_retry interceptor, which does the retrying.
+type MethodPolicyLearner struct {
+	mu       sync.Mutex
+	policies map[string]MethodPolicy
+}
+
+// Lookup returns the last policy advertised for a method.
+func (l *MethodPolicyLearner) Lookup(method string) (MethodPolicy, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	policy, ok := l.policies[method]
+	return policy, ok
+}
+
+func (l *MethodPolicyLearner) learn(method string, header metadata.MD) {
+	values := header.Get(MethodPolicyMetadataKey)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(values) != 1 {
+		// the server no longer has a policy for the method
+		delete(l.policies, method)
+		return
+	}
+	var policy MethodPolicy
+	if err := json.Unmarshal([]byte(values[0]), &policy); err != nil {
+		return
+	}
+	if l.policies == nil {
+		l.policies = map[string]MethodPolicy{}
+	}
+	l.policies[method] = policy
+}
+
+// UnaryClientInterceptor applies the known policy of a method to a request, and learns the policy
+// the server advertises in its response.
+func (l *MethodPolicyLearner) UnaryClientInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	if policy, ok := l.Lookup(method); ok {
+		if _, deadlineSet := ctx.Deadline(); !deadlineSet && policy.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, policy.Timeout)
+			defer cancel()
+		}
+		// options given by the caller come last so that they win
+		opts = append(policy.callOptions(), opts...)
+	}
+	var header metadata.MD
+	err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header))...)
+	if err == nil {
+		l.learn(method, header)
+	}
+	return err
+}
+
+// StreamClientInterceptor applies the deadline of the known policy of a method to a stream, and
+// learns the policy the server advertises once the stream has its headers. Streams are not
+// retried.
+func (l *MethodPolicyLearner) StreamClientInterceptor(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	cancel := context.CancelFunc(func() {})
+	if policy, ok := l.Lookup(method); ok && policy.Timeout > 0 {
+		if _, deadlineSet := ctx.Deadline(); !deadlineSet {
+			ctx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		}
+	}
+	cs, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &policyClientStream{ClientStream: cs, learner: l, method: method, cancel: cancel}, nil
+}
+
+// policyClientStream learns the policy of its method from the headers of the stream, and
+// releases the deadline it was given once the stream ends.
+type policyClientStream struct {
+	grpc.ClientStream
+	learner *MethodPolicyLearner
+	method  string
+	cancel  context.CancelFunc
+	learned bool
+}
+
+func (s *policyClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.cancel()
+		return err
+	}
+	if !s.learned {
+		s.learned = true
+		if header, err := s.ClientStream.Header(); err == nil {
+			s.learner.learn(s.method, header)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+const cameraService = "/viam.component.camera.v1.CameraService/"
+
+func TestMethodPolicies(t *testing.T) {
+	var mp MethodPolicies
+	_, ok := mp.Lookup(cameraService + "GetImage")
+	test.That(t, ok, test.ShouldBeFalse)
+
+	mp.Set(map[string]MethodPolicy{
+		cameraService + "GetImage": {Timeout: 2 * time.Second},
+		cameraService:              {Timeout: time.Minute},
+	})
+	policy, ok := mp.Lookup(cameraService + "GetImage")
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, policy.Timeout, test.ShouldEqual, 2*time.Second)
+	policy, ok = mp.Lookup(cameraService + "GetProperties")
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, policy.Timeout, test.ShouldEqual, time.Minute)
+	_, ok = mp.Lookup("/viam.component.arm.v1.ArmService/MoveToPosition")
+	test.That(t, ok, test.ShouldBeFalse)
+
+	deadlineIn := func(ctx context.Context) time.Duration {
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			return 0
+		}
+		return time.Until(deadline)
+	}
+	var handled time.Duration
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handled = deadlineIn(ctx)
+		return nil, nil
+	}
+
+	// requests without a deadline get the policy's
+	_, err := mp.UnaryServerInterceptor(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: cameraService + "GetImage"}, handler)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, handled, test.ShouldBeBetweenOrEqual, time.Second, 2*time.Second)
+
+	// but requests with their own deadline keep it
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	_, err = mp.UnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: cameraService + "GetImage"}, handler)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, handled, test.ShouldBeGreaterThan, time.Minute)
+
+	// methods without a policy get the default deadline
+	_, err = mp.UnaryServerInterceptor(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/viam.component.arm.v1.ArmService/MoveToPosition"}, handler)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, handled, test.ShouldBeBetweenOrEqual, DefaultMethodTimeout-time.Minute, DefaultMethodTimeout)
+
+	// streams get the deadline of their policy too, but none without one
+	streamHandler := func(srv interface{}, stream grpc.ServerStream) error {
+		handled = deadlineIn(stream.Context())
+		return nil
+	}
+	stream := &policyServerStream{ctx: context.Background()}
+	err = mp.StreamServerInterceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: cameraService + "GetImage"}, streamHandler)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, handled, test.ShouldBeBetweenOrEqual, time.Second, 2*time.Second)
+	test.That(t, stream.header.Get(MethodPolicyMetadataKey), test.ShouldHaveLength, 1)
+
+	stream = &policyServerStream{ctx: context.Background()}
+	err = mp.StreamServerInterceptor(nil, stream,
+		&grpc.StreamServerInfo{FullMethod: "/rdk.component.arm.v1.ArmStreamService/StreamJointState"}, streamHandler)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, handled, test.ShouldEqual, 0)
+	test.That(t, stream.header, test.ShouldBeEmpty)
+}
+
+type policyServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *policyServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *policyServerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestMethodPolicyLearner(t *testing.T) {
+	var l MethodPolicyLearner
+	advertised := MethodPolicy{
+		Timeout:      2 * time.Second,
+		MaxAttempts:  3,
+		RetryBackoff: 10 * time.Millisecond,
+		RetryCodes:   []codes.Code{codes.Unavailable},
+	}
+	var header metadata.MD
+	var lastOpts []grpc.CallOption
+	var hadDeadline bool
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		_, hadDeadline = ctx.Deadline()
+		lastOpts = opts
+		for _, opt := range opts {
+			if h, ok := opt.(grpc.HeaderCallOption); ok {
+				*h.HeaderAddr = header
+			}
+		}
+		return nil
+	}
+
+	header = metadata.Pairs(MethodPolicyMetadataKey,
+		`{"timeout":2000000000,"max_attempts":3,"retry_backoff":10000000,"retry_codes":[14]}`)
+	err := l.UnaryClientInterceptor(context.Background(), cameraService+"GetImage", nil, nil, nil, invoker)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, hadDeadline, test.ShouldBeFalse)
+	policy, ok := l.Lookup(cameraService + "GetImage")
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, policy, test.ShouldResemble, advertised)
+
+	// later requests get the deadline and the retry options
+	err = l.UnaryClientInterceptor(context.Background(), cameraService+"GetImage", nil, nil, nil, invoker)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, hadDeadline, test.ShouldBeTrue)
+	test.That(t, len(lastOpts), test.ShouldEqual, len(advertised.callOptions())+1)
+
+	// a failed request does not forget the policy, but a response without one does
+	failing := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return errors.New("unavailable")
+	}
+	err = l.UnaryClientInterceptor(context.Background(), cameraService+"GetImage", nil, nil, nil, failing)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, ok = l.Lookup(cameraService + "GetImage")
+	test.That(t, ok, test.ShouldBeTrue)
+
+	header = metadata.MD{}
+	err = l.UnaryClientInterceptor(context.Background(), cameraService+"GetImage", nil, nil, nil, invoker)
+	test.That(t, err, test.ShouldBeNil)
+	_, ok = l.Lookup(cameraService + "GetImage")
+	test.That(t, ok, test.ShouldBeFalse)
+}
+
+func TestMethodPolicyLearnerStreams(t *testing.T) {
+	var l MethodPolicyLearner
+	header := metadata.Pairs(MethodPolicyMetadataKey, `{"timeout":2000000000}`)
+	var streamCtx context.Context
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+		method string, opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		streamCtx = ctx
+		return &policyClientStreamStub{header: header}, nil
+	}
+
+	cs, err := l.StreamClientInterceptor(context.Background(), nil, nil, cameraService+"StreamImages", streamer)
+	test.That(t, err, test.ShouldBeNil)
+	_, hadDeadline := streamCtx.Deadline()
+	test.That(t, hadDeadline, test.ShouldBeFalse)
+	test.That(t, cs.RecvMsg(nil), test.ShouldBeNil)
+	policy, ok := l.Lookup(cameraService + "StreamImages")
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, policy.Timeout, test.ShouldEqual, 2*time.Second)
+
+	// later streams get the deadline, which is released when they end
+	cs, err = l.StreamClientInterceptor(context.Background(), nil, nil, cameraService+"StreamImages", streamer)
+	test.That(t, err, test.ShouldBeNil)
+	_, hadDeadline = streamCtx.Deadline()
+	test.That(t, hadDeadline, test.ShouldBeTrue)
+	cs.(*policyClientStream).ClientStream.(*policyClientStreamStub).done = true
+	test.That(t, cs.RecvMsg(nil), test.ShouldNotBeNil)
+	test.That(t, streamCtx.Err(), test.ShouldNotBeNil)
+}
+
+type policyClientStreamStub struct {
+	grpc.ClientStream
+	header metadata.MD
+	done   bool
+}
+
+func (s *policyClientStreamStub) Header() (metadata.MD, error) {
+	return s.header, nil
+}
+
+func (s *policyClientStreamStub) RecvMsg(m interface{}) error {
+	if s.done {
+		return errors.New("done")
+	}
+	return nil
+}
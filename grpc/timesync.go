@@ -0,0 +1,143 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ServerTimesMetadataKey is the response header in which a server reports, in unix nanoseconds,
+// when it received a request and when it sent the response, as "received,sent". Together with
+// when the client sent the request and received the response, they give an NTP style estimate
+// of the offset between the clocks of the client and the server.
+const ServerTimesMetadataKey = "rdk-server-times"
+
+// maxClockOffsetSamples is how many of the most recent samples a ClockOffsetEstimator picks its
+// estimate from.
+const maxClockOffsetSamples = 8
+
+// ServerTimesUnaryServerInterceptor reports when requests were received and responded to in the
+// ServerTimesMetadataKey header.
+func ServerTimesUnaryServerInterceptor(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (interface{}, error) {
+	received := time.Now()
+	resp, err := handler(ctx, req)
+	times := fmt.Sprintf("%d,%d", received.UnixNano(), time.Now().UnixNano())
+	// the header may have already been sent by the handler, in which case there is no estimate
+	_ = grpc.SetHeader(ctx, metadata.Pairs(ServerTimesMetadataKey, times))
+	return resp, err
+}
+
+// A ClockOffset is how far the clock of another machine is ahead of the local clock.
+type ClockOffset struct {
+	// Offset is added to a local time to get the time on the other machine.
+	Offset time.Duration
+	// RoundTrip is the network round trip of the measurement. The offset is accurate to within
+	// half of it.
+	RoundTrip time.Duration
+	// MeasuredAt is the local time the offset was measured.
+	MeasuredAt time.Time
+}
+
+// ToLocal converts a time read from the other machine's clock to the local clock.
+func (o ClockOffset) ToLocal(remote time.Time) time.Time {
+	return remote.Add(-o.Offset)
+}
+
+// A ClockOffsetProvider is implemented by clients of other machines, like remote robots and their
+// sensors, to tell how far the clock of the other machine is ahead of the local one, so that times
+// in what they return can be corrected.
+type ClockOffsetProvider interface {
+	// ClockOffset returns the current estimate of the clock offset, or false if there is none yet.
+	ClockOffset() (ClockOffset, bool)
+}
+
+// ClockOffsetFromHeader estimates the clock offset of a server from its ServerTimesMetadataKey
+// header, given when the request was sent and the response received. It returns false if the
+// server did not report its times.
+func ClockOffsetFromHeader(header metadata.MD, sent, received time.Time) (ClockOffset, bool) {
+	values := header.Get(ServerTimesMetadataKey)
+	if len(values) != 1 {
+		return ClockOffset{}, false
+	}
+	serverReceivedStr, serverSentStr, ok := strings.Cut(values[0], ",")
+	if !ok {
+		return ClockOffset{}, false
+	}
+	serverReceived, err := strconv.ParseInt(serverReceivedStr, 10, 64)
+	if err != nil {
+		return ClockOffset{}, false
+	}
+	serverSent, err := strconv.ParseInt(serverSentStr, 10, 64)
+	if err != nil {
+		return ClockOffset{}, false
+	}
+	t0, t3 := sent.UnixNano(), received.UnixNano()
+	return ClockOffset{
+		Offset:     time.Duration(((serverReceived - t0) + (serverSent - t3)) / 2),
+		RoundTrip:  time.Duration((t3 - t0) - (serverSent - serverReceived)),
+		MeasuredAt: received,
+	}, true
+}
+
+// A ClockOffsetEstimator estimates the clock offset of a server from the times reported on
+// responses to unary calls. Since network delays make individual samples noisy, the estimate
+// is the sample with the shortest round trip among the most recent ones.
+type ClockOffsetEstimator struct {
+	mu      sync.Mutex
+	samples []ClockOffset
+}
+
+// UnaryClientInterceptor records a clock offset sample from every response that has one.
+func (e *ClockOffsetEstimator) UnaryClientInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	var header metadata.MD
+	sent := time.Now()
+	err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header))...)
+	if err == nil {
+		if sample, ok := ClockOffsetFromHeader(header, sent, time.Now()); ok {
+			e.AddSample(sample)
+		}
+	}
+	return err
+}
+
+// AddSample records a clock offset sample, forgetting the oldest one if there are too many.
+func (e *ClockOffsetEstimator) AddSample(sample ClockOffset) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.samples = append(e.samples, sample)
+	if len(e.samples) > maxClockOffsetSamples {
+		e.samples = e.samples[len(e.samples)-maxClockOffsetSamples:]
+	}
+}
+
+// Estimate returns the current estimate of the clock offset, or false if there have not been
+// any samples yet.
+func (e *ClockOffsetEstimator) Estimate() (ClockOffset, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.samples) == 0 {
+		return ClockOffset{}, false
+	}
+	best := e.samples[0]
+	for _, sample := range e.samples[1:] {
+		if sample.RoundTrip < best.RoundTrip {
+			best = sample
+		}
+	}
+	return best, true
+}
@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestClockOffsetFromHeader(t *testing.T) {
+	sent := time.Unix(1000, 0)
+	// the server's clock is 5s ahead, each way takes 10ms and handling takes 2ms
+	serverReceived := sent.Add(5*time.Second + 10*time.Millisecond)
+	serverSent := serverReceived.Add(2 * time.Millisecond)
+	received := sent.Add(22 * time.Millisecond)
+
+	header := metadata.Pairs(ServerTimesMetadataKey,
+		fmt.Sprintf("%d,%d", serverReceived.UnixNano(), serverSent.UnixNano()))
+	offset, ok := ClockOffsetFromHeader(header, sent, received)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, offset.Offset, test.ShouldEqual, 5*time.Second)
+	test.That(t, offset.RoundTrip, test.ShouldEqual, 20*time.Millisecond)
+	test.That(t, offset.MeasuredAt, test.ShouldEqual, received)
+	test.That(t, offset.ToLocal(serverSent), test.ShouldEqual, sent.Add(12*time.Millisecond))
+
+	_, ok = ClockOffsetFromHeader(metadata.MD{}, sent, received)
+	test.That(t, ok, test.ShouldBeFalse)
+	_, ok = ClockOffsetFromHeader(metadata.Pairs(ServerTimesMetadataKey, "garbage"), sent, received)
+	test.That(t, ok, test.ShouldBeFalse)
+}
+
+func TestClockOffsetEstimator(t *testing.T) {
+	var e ClockOffsetEstimator
+	_, ok := e.Estimate()
+	test.That(t, ok, test.ShouldBeFalse)
+
+	e.AddSample(ClockOffset{Offset: time.Second, RoundTrip: 50 * time.Millisecond})
+	e.AddSample(ClockOffset{Offset: 2 * time.Second, RoundTrip: 10 * time.Millisecond})
+	e.AddSample(ClockOffset{Offset: 3 * time.Second, RoundTrip: 30 * time.Millisecond})
+	offset, ok := e.Estimate()
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, offset.Offset, test.ShouldEqual, 2*time.Second)
+
+	// the best sample is forgotten once there are enough newer ones
+	for i := 0; i < maxClockOffsetSamples; i++ {
+		e.AddSample(ClockOffset{Offset: 4 * time.Second, RoundTrip: 40 * time.Millisecond})
+	}
+	offset, ok = e.Estimate()
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, offset.Offset, test.ShouldEqual, 4*time.Second)
+}
@@ -263,6 +263,7 @@ func (imp *impl) shouldLog(logLevel Level) bool {
 }
 
 func (imp *impl) Write(entry *LogEntry) {
+	imp.registry.redact(entry)
 	if imp.registry.DeduplicateLogs.Load() && !imp.neverDeduplicate {
 		hashkeyedEntry := entry.HashKey()
 
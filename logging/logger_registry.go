@@ -18,6 +18,9 @@ type Registry struct {
 	// DeduplicateLogs controls whether to deduplicate logs. Slightly odd to store this on
 	// the registry but preferable to having a global atomic.
 	DeduplicateLogs atomic.Bool
+
+	// redactor masks values set by SetRedactedValues in every log written.
+	redactor atomic.Pointer[redactor]
 }
 
 func newRegistry() *Registry {
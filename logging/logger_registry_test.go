@@ -1,6 +1,7 @@
 package logging
 
 import (
+	"errors"
 	"testing"
 
 	"go.viam.com/test"
@@ -142,3 +143,31 @@ func TestLoggerLevelReset(t *testing.T) {
 	test.That(t, ok, test.ShouldBeTrue)
 	test.That(t, logger.GetLevel().String(), test.ShouldEqual, "Info")
 }
+
+func TestRedactedValues(t *testing.T) {
+	logger, logs, registry := NewObservedTestLoggerWithRegistry(t, "redact")
+	registry.SetRedactedValues([]string{"hunter2-secret", "abc", ""})
+
+	logger.Infow("connecting with hunter2-secret",
+		"token", "hunter2-secret",
+		"error", errors.New("bad token hunter2-secret"),
+		"other", "hunter2-secrets and abc",
+		"count", 3)
+	entry := logs.All()[0]
+	test.That(t, entry.Message, test.ShouldEqual, "connecting with ******")
+	fields := entry.ContextMap()
+	test.That(t, fields["token"], test.ShouldEqual, "******")
+	test.That(t, fields["error"], test.ShouldEqual, "bad token ******")
+	// values inside longer words and values too short to be safely masked are left alone
+	test.That(t, fields["other"], test.ShouldEqual, "hunter2-secrets and abc")
+	test.That(t, fields["count"], test.ShouldEqual, int64(3))
+
+	// the values of the next config replace those of the last
+	registry.SetRedactedValues([]string{"rotated-secret"})
+	logger.Info("hunter2-secret rotated-secret")
+	test.That(t, logs.All()[1].Message, test.ShouldEqual, "hunter2-secret ******")
+
+	registry.SetRedactedValues(nil)
+	logger.Info("rotated-secret")
+	test.That(t, logs.All()[2].Message, test.ShouldEqual, "rotated-secret")
+}
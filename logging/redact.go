@@ -0,0 +1,107 @@
+package logging
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// minRedactedLength is the length of the shortest value that is redacted. Shorter values would
+// match too much unrelated text.
+const minRedactedLength = 4
+
+const redactedMask = "******"
+
+// redactor masks a set of values, like resolved secrets, in log entries.
+type redactor struct {
+	// values are sorted longest first so that a value containing another is masked whole.
+	values []string
+}
+
+// SetRedactedValues replaces the values, like resolved secrets of the current config, that are
+// masked wherever they appear in the messages and string fields of logs written by loggers of the
+// registry. Values shorter than four bytes are not masked.
+func (lr *Registry) SetRedactedValues(values []string) {
+	kept := make([]string, 0, len(values))
+	for _, value := range values {
+		if len(value) >= minRedactedLength && !slices.Contains(kept, value) {
+			kept = append(kept, value)
+		}
+	}
+	if len(kept) == 0 {
+		lr.redactor.Store(nil)
+		return
+	}
+	slices.SortFunc(kept, func(a, b string) int { return len(b) - len(a) })
+	lr.redactor.Store(&redactor{values: kept})
+}
+
+// redact masks the redacted values of the registry in entry, in place.
+func (lr *Registry) redact(entry *LogEntry) {
+	r := lr.redactor.Load()
+	if r == nil {
+		return
+	}
+	entry.Message = r.redactString(entry.Message)
+	for i, field := range entry.Fields {
+		switch field.Type {
+		case zapcore.StringType:
+			entry.Fields[i].String = r.redactString(field.String)
+		case zapcore.ErrorType:
+			if err, ok := field.Interface.(error); ok && err != nil {
+				if msg := r.redactString(err.Error()); msg != err.Error() {
+					entry.Fields[i] = zap.String(field.Key, msg)
+				}
+			}
+		default:
+		}
+	}
+}
+
+// redactString masks every occurrence of a redacted value in s that is not part of a longer word,
+// so that a value which happens to appear inside other text is left alone.
+func (r *redactor) redactString(s string) string {
+	for _, value := range r.values {
+		if !strings.Contains(s, value) {
+			continue
+		}
+		var sb strings.Builder
+		rest := s
+		for {
+			idx := strings.Index(rest, value)
+			if idx < 0 {
+				break
+			}
+			end := idx + len(value)
+			if isWordBoundary(rest[:idx], false) && isWordBoundary(rest[end:], true) {
+				sb.WriteString(rest[:idx])
+				sb.WriteString(redactedMask)
+			} else {
+				sb.WriteString(rest[:end])
+			}
+			rest = rest[end:]
+		}
+		sb.WriteString(rest)
+		s = sb.String()
+	}
+	return s
+}
+
+// isWordBoundary returns whether the text beside a match, before it or after it, does not continue
+// the word the match is in.
+func isWordBoundary(beside string, after bool) bool {
+	var c rune
+	if after {
+		c, _ = utf8.DecodeRuneInString(beside)
+	} else {
+		c, _ = utf8.DecodeLastRuneInString(beside)
+	}
+	if c == utf8.RuneError {
+		return true
+	}
+	return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+}
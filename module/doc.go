@@ -25,6 +25,8 @@ When resources or components are attempting to load that are not built in, their
 and sent to the module. The entire config is sent as part of this, as are dependencies. Dependencies are passed by name only through GRPC,
 and the module library on the module side automatically creates grpc clients for each resource, before calling the component/service
 constructor. In this way, fully usable dependencies are provided, just as they would be during built-in resource creation.
+The parent's frame system service is always among them, and Module.FrameSystemView fetches the parent's frame system, along with
+the current inputs of its components, so that modules can do pose math consistent with the machine's configuration locally.
 
 Back on the parent side, once the AddResource() call completes, the modmanager then establishes an rpc client for the resource,
 and returns that to the resource manager, which inserts it into the resource graph. For built-in protocols (arm, motor, base, etc.) this
@@ -0,0 +1,117 @@
+package module
+
+import (
+	"context"
+	"maps"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/robot/framesystem"
+	"go.viam.com/rdk/spatialmath"
+)
+
+var errNoParent = errors.New("module is not connected to a parent machine")
+
+// FrameSystemService returns the frame system service of the parent machine, the same one
+// resources of the module receive in their dependencies.
+func (m *Module) FrameSystemService() (framesystem.Service, error) {
+	if m.parent == nil {
+		return nil, errNoParent
+	}
+	return NewFrameSystemClient(m.parent), nil
+}
+
+// FrameSystemView fetches the frame system of the parent machine, augmented with the given
+// supplemental transforms, along with the current inputs of its components, so that pose math
+// can be done in the module without a round trip to the parent for every pose.
+func (m *Module) FrameSystemView(
+	ctx context.Context,
+	supplementalTransforms []*referenceframe.LinkInFrame,
+) (*FrameSystemView, error) {
+	fsService, err := m.FrameSystemService()
+	if err != nil {
+		return nil, err
+	}
+	fs, err := framesystem.NewFromService(ctx, fsService, supplementalTransforms)
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := fsService.CurrentInputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewFrameSystemView(fs, inputs), nil
+}
+
+// A FrameSystemView is a machine's frame system along with the inputs of its components at the
+// time it was fetched. It does not change as the components move; fetch a new one to see them.
+type FrameSystemView struct {
+	FrameSystem *referenceframe.FrameSystem
+	// Inputs has inputs for every frame of FrameSystem, with zeros for those the machine did
+	// not report.
+	Inputs referenceframe.FrameSystemInputs
+}
+
+// NewFrameSystemView returns a view of the frame system with the given inputs.
+func NewFrameSystemView(fs *referenceframe.FrameSystem, inputs referenceframe.FrameSystemInputs) *FrameSystemView {
+	return &FrameSystemView{FrameSystem: fs, Inputs: overlayInputs(referenceframe.NewZeroInputs(fs), inputs)}
+}
+
+// Pose returns the pose of the origin of frame in the dst frame.
+func (v *FrameSystemView) Pose(frame, dst string) (*referenceframe.PoseInFrame, error) {
+	return v.PoseAt(frame, dst, nil)
+}
+
+// PoseAt returns the pose of the origin of frame in the dst frame if the frames in inputs were
+// at those inputs, and the rest at their current ones. It can be used to check where candidate
+// positions of a component would put it before moving it.
+func (v *FrameSystemView) PoseAt(frame, dst string, inputs referenceframe.FrameSystemInputs) (*referenceframe.PoseInFrame, error) {
+	tf, err := v.FrameSystem.Transform(
+		v.inputsAt(inputs),
+		referenceframe.NewPoseInFrame(frame, spatialmath.NewZeroPose()),
+		dst,
+	)
+	if err != nil {
+		return nil, err
+	}
+	pose, ok := tf.(*referenceframe.PoseInFrame)
+	if !ok {
+		return nil, errors.Errorf("expected a pose from transforming %q to %q, got %T", frame, dst, tf)
+	}
+	return pose, nil
+}
+
+// Interpolate returns steps evenly spaced inputs on the way from the current inputs to the given
+// ones, ending with them. Frames missing from to stay at their current inputs.
+func (v *FrameSystemView) Interpolate(to referenceframe.FrameSystemInputs, steps int) ([]referenceframe.FrameSystemInputs, error) {
+	if steps <= 0 {
+		return nil, errors.New("steps must be positive")
+	}
+	target := v.inputsAt(to)
+	waypoints := make([]referenceframe.FrameSystemInputs, 0, steps)
+	for i := 1; i <= steps; i++ {
+		waypoint, err := referenceframe.InterpolateFS(v.FrameSystem, v.Inputs, target, float64(i)/float64(steps))
+		if err != nil {
+			return nil, err
+		}
+		waypoints = append(waypoints, waypoint)
+	}
+	return waypoints, nil
+}
+
+// inputsAt returns the current inputs with those given replacing them.
+func (v *FrameSystemView) inputsAt(inputs referenceframe.FrameSystemInputs) referenceframe.FrameSystemInputs {
+	return overlayInputs(maps.Clone(v.Inputs), inputs)
+}
+
+// overlayInputs sets the inputs of base's frames that are in inputs, ignoring frames base does
+// not have, and returns base.
+func overlayInputs(base, inputs referenceframe.FrameSystemInputs) referenceframe.FrameSystemInputs {
+	for name, in := range inputs {
+		if _, ok := base[name]; ok {
+			base[name] = in
+		}
+	}
+	return base
+}
@@ -0,0 +1,56 @@
+package module_test
+
+import (
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/module"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+)
+
+func TestFrameSystemView(t *testing.T) {
+	fs := referenceframe.NewEmptyFrameSystem("test")
+	gantry, err := referenceframe.NewTranslationalFrame("gantry", r3.Vector{X: 1}, referenceframe.Limit{Min: 0, Max: 1000})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(gantry, fs.World()), test.ShouldBeNil)
+	tool, err := referenceframe.NewStaticFrame("tool", spatialmath.NewPoseFromPoint(r3.Vector{Z: 10}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(tool, gantry), test.ShouldBeNil)
+
+	view := module.NewFrameSystemView(fs, referenceframe.FrameSystemInputs{
+		"gantry":  referenceframe.FloatsToInputs([]float64{100}),
+		"missing": referenceframe.FloatsToInputs([]float64{1}),
+	})
+	test.That(t, view.Inputs, test.ShouldNotContainKey, "missing")
+
+	pose, err := view.Pose("tool", referenceframe.World)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, pose.Parent(), test.ShouldEqual, referenceframe.World)
+	test.That(t, spatialmath.R3VectorAlmostEqual(pose.Pose().Point(), r3.Vector{X: 100, Z: 10}, 1e-6), test.ShouldBeTrue)
+
+	// candidate inputs do not change the view
+	pose, err = view.PoseAt("tool", referenceframe.World, referenceframe.FrameSystemInputs{
+		"gantry": referenceframe.FloatsToInputs([]float64{300}),
+	})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatialmath.R3VectorAlmostEqual(pose.Pose().Point(), r3.Vector{X: 300, Z: 10}, 1e-6), test.ShouldBeTrue)
+	test.That(t, view.Inputs["gantry"], test.ShouldResemble, referenceframe.FloatsToInputs([]float64{100}))
+
+	_, err = view.Pose("nope", referenceframe.World)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	waypoints, err := view.Interpolate(referenceframe.FrameSystemInputs{
+		"gantry": referenceframe.FloatsToInputs([]float64{300}),
+	}, 4)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, waypoints, test.ShouldHaveLength, 4)
+	for i, expected := range []float64{150, 200, 250, 300} {
+		test.That(t, referenceframe.InputsToFloats(waypoints[i]["gantry"])[0], test.ShouldAlmostEqual, expected)
+	}
+
+	_, err = view.Interpolate(nil, 0)
+	test.That(t, err, test.ShouldNotBeNil)
+}
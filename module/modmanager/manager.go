@@ -15,7 +15,9 @@ import (
 	pb "go.viam.com/api/module/v1"
 	"go.viam.com/utils"
 	"go.viam.com/utils/pexec"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"go.viam.com/rdk/config"
@@ -661,16 +663,25 @@ func (mgr *Manager) RemoveResource(ctx context.Context, name resource.Name) erro
 // ValidateConfig determines whether the given config is valid and returns its implicit
 // required and optional dependencies.
 func (mgr *Manager) ValidateConfig(ctx context.Context, conf resource.Config) ([]string, []string, error) {
+	requiredDeps, optionalDeps, _, err := mgr.validateConfig(ctx, conf)
+	return requiredDeps, optionalDeps, err
+}
+
+// validateConfig is ValidateConfig that also returns the non-fatal problems the module found.
+func (mgr *Manager) validateConfig(
+	ctx context.Context,
+	conf resource.Config,
+) ([]string, []string, []resource.ConfigWarning, error) {
 	mod, ok := mgr.getModule(conf)
 	if !ok {
-		return nil, nil,
+		return nil, nil, nil,
 			errors.Errorf("no module registered to serve resource api %s and model %s",
 				conf.API, conf.Model)
 	}
 
 	confProto, err := config.ComponentConfigToProto(&conf)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Override context with new timeout.
@@ -678,16 +689,17 @@ func (mgr *Manager) ValidateConfig(ctx context.Context, conf resource.Config) ([
 	ctx, cancel = context.WithTimeout(ctx, validateConfigTimeout)
 	defer cancel()
 
-	resp, err := mod.client.ValidateConfig(ctx, &pb.ValidateConfigRequest{Config: confProto})
+	var hdr metadata.MD
+	resp, err := mod.client.ValidateConfig(ctx, &pb.ValidateConfigRequest{Config: confProto}, grpc.Header(&hdr))
 	// Swallow "Unimplemented" gRPC errors from modules that lack ValidateConfig
 	// receiving logic.
 	if err != nil && status.Code(err) == codes.Unimplemented {
-		return nil, nil, nil
+		return nil, nil, nil, nil
 	}
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	return resp.Dependencies, resp.OptionalDependencies, nil
+	return resp.Dependencies, resp.OptionalDependencies, modlib.ConfigWarningsFromMetadata(hdr), nil
 }
 
 // ResolveImplicitDependenciesInConfig mutates the passed in diff to add modular implicit dependencies to added
@@ -699,7 +711,7 @@ func (mgr *Manager) ResolveImplicitDependenciesInConfig(ctx context.Context, con
 	validateModularResources := func(confs []resource.Config) {
 		for i, c := range confs {
 			if mgr.Provides(c) {
-				implicitRequiredDeps, implicitOptionalDeps, err := mgr.ValidateConfig(ctx, c)
+				implicitRequiredDeps, implicitOptionalDeps, warnings, err := mgr.validateConfig(ctx, c)
 				if err != nil {
 					mgr.logger.CErrorw(ctx, "Modular config validation error found in resource: "+c.Name, "error", err)
 					continue
@@ -708,6 +720,7 @@ func (mgr *Manager) ResolveImplicitDependenciesInConfig(ctx context.Context, con
 				// Modify resource config to add its implicit required and optional dependencies.
 				confs[i].ImplicitDependsOn = implicitRequiredDeps
 				confs[i].ImplicitOptionalDependsOn = implicitOptionalDeps
+				confs[i].ImplicitWarnings = warnings
 			}
 		}
 	}
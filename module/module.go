@@ -27,6 +27,7 @@ import (
 	"go.viam.com/utils/rpc"
 	"golang.org/x/exp/maps"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	reflectpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 
 	"go.viam.com/rdk/components/camera/rtppassthrough"
@@ -63,6 +64,11 @@ const (
 
 	// NoModuleParentEnvVar indicates whether there is a parent for a module being started.
 	NoModuleParentEnvVar = "VIAM_NO_MODULE_PARENT"
+
+	// ConfigWarningsMetadataKey is the key of the header in ValidateConfig responses that
+	// carries the resource.ConfigWarnings found validating the config, see
+	// rgrpc.EncodeMetadataValues.
+	ConfigWarningsMetadataKey = "viam-config-warnings"
 )
 
 // errMaxSupportedWebRTCTrackLimit is the error returned when the MaxSupportedWebRTCTRacks limit is reached.
@@ -361,6 +367,26 @@ func (m *Module) GetParentResource(ctx context.Context, name resource.Name) (res
 	return m.parent.ResourceByName(name)
 }
 
+// ApplyResourceChanges adds, removes and updates components and services of the parent robot in a
+// single reconfigure, for modules that manage resources dynamically. The changes are kept across
+// later configs of the parent until the module is removed from them. Either every change is applied
+// or, if any of them is invalid, none are and an error is returned. The returned map holds why each
+// added or updated resource that failed to build or reconfigure did so.
+func (m *Module) ApplyResourceChanges(
+	ctx context.Context,
+	adds []resource.Config,
+	removes []resource.Name,
+	updates []resource.Config,
+) (map[resource.Name]error, error) {
+	m.mu.Lock()
+	parent := m.parent
+	m.mu.Unlock()
+	if parent == nil {
+		return nil, errors.New("module is not connected to its parent")
+	}
+	return parent.ApplyResourceChanges(ctx, adds, removes, updates)
+}
+
 func (m *Module) connectParent(ctx context.Context) error {
 	// If parent connection has already been made, do not make another one. Some
 	// tests send two ReadyRequests sequentially, and if an rdk were to retry
@@ -533,6 +559,13 @@ func (f *frameSystemClient) CurrentInputs(ctx context.Context) (referenceframe.F
 	return f.robotClient.CurrentInputs(ctx)
 }
 
+func (f *frameSystemClient) GetWorldGeometries(
+	ctx context.Context,
+	supplementalTransforms []*referenceframe.LinkInFrame,
+) (map[string]*referenceframe.GeometriesInFrame, error) {
+	return f.robotClient.GetWorldGeometries(ctx, supplementalTransforms)
+}
+
 // AddResource receives the component/service configuration from the parent.
 func (m *Module) AddResource(ctx context.Context, req *pb.AddResourceRequest) (*pb.AddResourceResponse, error) {
 	select {
@@ -684,6 +717,7 @@ func (m *Module) ReconfigureResource(ctx context.Context, req *pb.ReconfigureRes
 	}
 
 	m.logger.Debugw("rebuilding", "name", conf.ResourceName().String())
+	resource.ForgetCapabilities(res)
 	if err := res.Close(ctx); err != nil {
 		m.logger.Error(err)
 	}
@@ -730,6 +764,15 @@ func (m *Module) ValidateConfig(ctx context.Context,
 		if err != nil {
 			return nil, errors.Wrapf(err, "error validating resource")
 		}
+		if warner, ok := c.ConvertedAttributes.(resource.ConfigWarner); ok {
+			md, dropped := rgrpc.EncodeMetadataValues(ConfigWarningsMetadataKey, warner.Warnings(c.Name))
+			if dropped > 0 {
+				m.logger.CDebugw(ctx, "config warnings header is full, leaving out some warnings", "dropped", dropped)
+			}
+			if md != nil {
+				utils.UncheckedError(grpc.SetHeader(ctx, md))
+			}
+		}
 		resp := &pb.ValidateConfigResponse{
 			Dependencies:         implicitRequiredDeps,
 			OptionalDependencies: implicitOptionalDeps,
@@ -742,6 +785,12 @@ func (m *Module) ValidateConfig(ctx context.Context,
 	return &pb.ValidateConfigResponse{}, nil
 }
 
+// ConfigWarningsFromMetadata returns the config warnings in the header of a ValidateConfig
+// response, skipping any that cannot be decoded.
+func ConfigWarningsFromMetadata(md metadata.MD) []resource.ConfigWarning {
+	return rgrpc.DecodeMetadataValues[resource.ConfigWarning](md, ConfigWarningsMetadataKey)
+}
+
 // RemoveResource receives the request for resource removal.
 func (m *Module) RemoveResource(ctx context.Context, req *pb.RemoveResourceRequest) (*pb.RemoveResourceResponse, error) {
 	slowWatcher, slowWatcherCancel := utils.SlowGoroutineWatcher(
@@ -767,6 +816,7 @@ func (m *Module) RemoveResource(ctx context.Context, req *pb.RemoveResourceReque
 		return nil, err
 	}
 
+	resource.ForgetCapabilities(res)
 	if err := res.Close(ctx); err != nil {
 		m.logger.Error(err)
 	}
@@ -2,6 +2,7 @@ package module_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -23,6 +24,7 @@ import (
 	"go.viam.com/utils/rpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/structpb"
 
 	"go.viam.com/rdk/components/arm"
@@ -1013,3 +1015,16 @@ func TestFrameSystemFromDependencies(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, th.constructCount, test.ShouldEqual, 1)
 }
+
+func TestConfigWarningsFromMetadata(t *testing.T) {
+	warning := resource.NewDeprecatedFieldWarning("gizmo1", "old_speed", "speed")
+	encoded, err := json.Marshal(warning)
+	test.That(t, err, test.ShouldBeNil)
+
+	md := metadata.Pairs(
+		module.ConfigWarningsMetadataKey, string(encoded),
+		module.ConfigWarningsMetadataKey, "not json",
+	)
+	test.That(t, module.ConfigWarningsFromMetadata(md), test.ShouldResemble, []resource.ConfigWarning{warning})
+	test.That(t, module.ConfigWarningsFromMetadata(metadata.MD{}), test.ShouldBeNil)
+}
@@ -0,0 +1,229 @@
+package armplanning
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/motionplan"
+	"go.viam.com/rdk/referenceframe"
+)
+
+// AnytimePlan is one of the progressively cheaper plans found by an AnytimePlanner.
+type AnytimePlan struct {
+	// Segments holds a plan to each goal of the request, in order, each starting where the previous one ends.
+	Segments []motionplan.Plan
+	// Costs holds the cost of each segment under the request's scoring metric.
+	Costs []float64
+}
+
+// Cost returns the summed cost of the segments from the given one onwards.
+func (p AnytimePlan) Cost(from int) float64 {
+	var cost float64
+	for _, c := range p.Costs[from:] {
+		cost += c
+	}
+	return cost
+}
+
+// Trajectory joins the trajectories of all segments into one.
+func (p AnytimePlan) Trajectory() motionplan.Trajectory {
+	traj := motionplan.Trajectory{}
+	for i, segment := range p.Segments {
+		steps := segment.Trajectory()
+		if i > 0 && len(steps) > 0 {
+			// The first step of each segment is the last step of the prior one.
+			steps = steps[1:]
+		}
+		traj = append(traj, steps...)
+	}
+	return traj
+}
+
+// An AnytimePlanner finds a good enough plan as quickly as it can, and then keeps looking for cheaper
+// plans until its deadline. Each goal of the request is planned as its own segment so that a caller can
+// begin executing the first segments while the later ones are still being improved; segments that have
+// been committed to are kept by every later plan.
+type AnytimePlanner struct {
+	logger   logging.Logger
+	request  *PlanRequest
+	deadline time.Time
+	plans    chan AnytimePlan
+	workers  *utils.StoppableWorkers
+
+	mu        sync.Mutex
+	best      AnytimePlan
+	committed int
+}
+
+// PlanMotionAnytime plans a motion from a provided plan request, returning once a first plan is found.
+// Cheaper plans are then searched for in the background until the deadline passes, the context is
+// cancelled, or the planner is closed.
+func PlanMotionAnytime(
+	ctx context.Context,
+	logger logging.Logger,
+	request *PlanRequest,
+	deadline time.Time,
+) (*AnytimePlanner, error) {
+	if err := request.validatePlanRequest(); err != nil {
+		return nil, err
+	}
+	ap := &AnytimePlanner{
+		logger:   logger,
+		request:  request,
+		deadline: deadline,
+		plans:    make(chan AnytimePlan, 1),
+	}
+	first, err := ap.planSegments(ctx, AnytimePlan{}, 0, request.PlannerOptions.RandomSeed)
+	if err != nil {
+		return nil, err
+	}
+	ap.best = first
+	ap.plans <- first
+	ap.workers = utils.NewBackgroundStoppableWorkers(func(workerCtx context.Context) {
+		ap.improve(ctx, workerCtx)
+	})
+	return ap, nil
+}
+
+// Plans streams each plan found, starting with the first one. Only the latest plan is kept if the
+// receiver falls behind. The channel is closed once the planner stops looking for cheaper plans.
+func (ap *AnytimePlanner) Plans() <-chan AnytimePlan {
+	return ap.plans
+}
+
+// Best returns the cheapest plan found so far.
+func (ap *AnytimePlanner) Best() AnytimePlan {
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+	return ap.best
+}
+
+// Commit marks the first segments of the plan as about to be executed, so that every later plan keeps
+// them unchanged, and returns the cheapest plan found so far.
+func (ap *AnytimePlanner) Commit(segments int) AnytimePlan {
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+	if segments > ap.committed {
+		ap.committed = min(segments, len(ap.request.Goals))
+	}
+	return ap.best
+}
+
+// Close stops looking for cheaper plans.
+func (ap *AnytimePlanner) Close() {
+	ap.workers.Stop()
+}
+
+func (ap *AnytimePlanner) improve(ctx, workerCtx context.Context) {
+	defer close(ap.plans)
+	ctx, cancel := context.WithDeadline(ctx, ap.deadline)
+	defer cancel()
+	ctx, cancelWorker := utils.MergeContext(ctx, workerCtx)
+	defer cancelWorker()
+
+	for attempt := 1; ctx.Err() == nil; attempt++ {
+		ap.mu.Lock()
+		from, best := ap.committed, ap.best
+		ap.mu.Unlock()
+		if from >= len(ap.request.Goals) {
+			return
+		}
+
+		candidate, err := ap.planSegments(ctx, best, from, ap.request.PlannerOptions.RandomSeed+attempt)
+		if err != nil {
+			if ctx.Err() == nil {
+				ap.logger.CDebugw(ctx, "anytime planning attempt failed", "attempt", attempt, "error", err)
+			}
+			continue
+		}
+
+		ap.mu.Lock()
+		// Segments committed to while planning may already be executing, so a candidate that changes
+		// them cannot be used.
+		if ap.committed != from || candidate.Cost(from) >= ap.best.Cost(from) {
+			ap.mu.Unlock()
+			continue
+		}
+		ap.best = candidate
+		ap.mu.Unlock()
+		ap.logger.CDebugw(ctx, "found cheaper plan", "attempt", attempt, "cost", candidate.Cost(0))
+
+		// This is the only sender, so after draining a stale plan there is always room for this one.
+		select {
+		case <-ap.plans:
+		default:
+		}
+		ap.plans <- candidate
+	}
+}
+
+// planSegments plans the segments to each goal from the given one onwards, keeping the segments of
+// prefix that come before it.
+func (ap *AnytimePlanner) planSegments(
+	ctx context.Context,
+	prefix AnytimePlan,
+	from, randomSeed int,
+) (AnytimePlan, error) {
+	plan := AnytimePlan{
+		Segments: slices.Clone(prefix.Segments[:from]),
+		Costs:    slices.Clone(prefix.Costs[:from]),
+	}
+	start := ap.request.StartState
+	if from > 0 {
+		start = &PlanState{configuration: endConfiguration(start.configuration, plan.Segments)}
+	}
+	for _, goal := range ap.request.Goals[from:] {
+		opts := *ap.request.PlannerOptions
+		opts.RandomSeed = randomSeed
+		segmentRequest := &PlanRequest{
+			FrameSystem:     ap.request.FrameSystem,
+			Goals:           []*PlanState{goal},
+			StartState:      start,
+			WorldState:      ap.request.WorldState,
+			BoundingRegions: ap.request.BoundingRegions,
+			Constraints:     ap.request.Constraints,
+			PlannerOptions:  &opts,
+		}
+		if err := segmentRequest.validatePlanRequest(); err != nil {
+			return AnytimePlan{}, err
+		}
+		pm, err := newPlanManager(ap.logger, segmentRequest)
+		if err != nil {
+			return AnytimePlan{}, err
+		}
+		segment, err := pm.planMultiWaypoint(ctx, nil)
+		if err != nil {
+			return AnytimePlan{}, err
+		}
+		plan.Segments = append(plan.Segments, segment)
+		plan.Costs = append(plan.Costs, segment.Trajectory().EvaluateCost(pm.scoringFunction))
+		start = &PlanState{configuration: endConfiguration(start.configuration, plan.Segments)}
+	}
+	return plan, nil
+}
+
+// endConfiguration returns the configuration of the frame system after the last of the segments,
+// filling in frames that do not move from start.
+func endConfiguration(
+	start referenceframe.FrameSystemInputs,
+	segments []motionplan.Plan,
+) referenceframe.FrameSystemInputs {
+	end := referenceframe.FrameSystemInputs{}
+	for name, inputs := range start {
+		end[name] = inputs
+	}
+	if len(segments) == 0 {
+		return end
+	}
+	if traj := segments[len(segments)-1].Trajectory(); len(traj) > 0 {
+		for name, inputs := range traj[len(traj)-1] {
+			end[name] = inputs
+		}
+	}
+	return end
+}
@@ -0,0 +1,57 @@
+package armplanning
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/logging"
+	frame "go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/utils"
+)
+
+func TestPlanMotionAnytime(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	ur5e, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/fake/kinematics/ur5e.json"), "ur")
+	test.That(t, err, test.ShouldBeNil)
+	fs := frame.NewEmptyFrameSystem("test")
+	test.That(t, fs.AddFrame(ur5e, fs.World()), test.ShouldBeNil)
+
+	goal1 := frame.FloatsToInputs([]float64{0.5, 0, 0, 0, 0, 0})
+	goal2 := frame.FloatsToInputs([]float64{0.5, -0.5, 0.5, 0, 0, 0})
+	planner, err := PlanMotionAnytime(ctx, logger, &PlanRequest{
+		FrameSystem: fs,
+		Goals: []*PlanState{
+			{configuration: frame.FrameSystemInputs{"ur": goal1}},
+			{configuration: frame.FrameSystemInputs{"ur": goal2}},
+		},
+		StartState:     &PlanState{configuration: frame.NewZeroInputs(fs)},
+		PlannerOptions: NewBasicPlannerOptions(),
+	}, time.Now().Add(time.Second))
+	test.That(t, err, test.ShouldBeNil)
+	defer planner.Close()
+
+	first := <-planner.Plans()
+	test.That(t, first.Segments, test.ShouldHaveLength, 2)
+	test.That(t, first.Costs, test.ShouldHaveLength, 2)
+
+	// the second segment starts where the first one ends
+	traj1, traj2 := first.Segments[0].Trajectory(), first.Segments[1].Trajectory()
+	test.That(t, traj1[len(traj1)-1]["ur"], test.ShouldResemble, goal1)
+	test.That(t, traj2[0]["ur"], test.ShouldResemble, goal1)
+	test.That(t, traj2[len(traj2)-1]["ur"], test.ShouldResemble, goal2)
+	test.That(t, first.Trajectory(), test.ShouldHaveLength, len(traj1)+len(traj2)-1)
+
+	// once the first segment is committed to, later plans keep it
+	committed := planner.Commit(1)
+	for range planner.Plans() {
+		// wait for the deadline
+	}
+	best := planner.Best()
+	test.That(t, best.Segments[0], test.ShouldEqual, committed.Segments[0])
+	test.That(t, best.Cost(1), test.ShouldBeLessThanOrEqualTo, committed.Cost(1))
+}
@@ -155,6 +155,10 @@ type PlannerOptions struct {
 	// Number of seconds before terminating planner
 	Timeout float64 `json:"timeout"`
 
+	// If positive, each goal is planned as its own segment and cheaper plans are looked for for this
+	// many seconds after the first plan is found, while the first segments are already being executed.
+	AnytimeTimeout float64 `json:"anytime_timeout"`
+
 	// Number of times to try to smooth the path
 	SmoothIter int `json:"smooth_iter"`
 
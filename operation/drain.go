@@ -0,0 +1,105 @@
+package operation
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DrainingMetadataKey is set to "true" in the headers of the responses a server sends while its
+// operations are draining for shutdown, so that clients know to move elsewhere or wait.
+const DrainingMetadataKey = "viam-draining"
+
+// ErrDraining is returned for new operations once a manager starts draining. Its Unavailable
+// code tells clients that the call can be retried, once the server is back or elsewhere.
+var ErrDraining = status.Error(codes.Unavailable, "machine is shutting down and is not accepting new operations")
+
+// drainExemptMethods may still be called while draining, so that clients can follow the
+// shutdown, keep their sessions alive until it is done, and stop what is still moving.
+var drainExemptMethods = [...]string{
+	"/viam.robot.v1.RobotService/GetMachineStatus",
+	"/viam.robot.v1.RobotService/SendSessionHeartbeat",
+	"/viam.robot.v1.RobotService/StopAll",
+}
+
+// motionMethodPrefixes are the methods that move actuators. Draining waits for operations in
+// them so that a machine is not torn down in the middle of a motion.
+var motionMethodPrefixes = [...]string{
+	"/viam.service.motion.v1.MotionService/",
+	"/viam.service.navigation.v1.NavigationService/",
+	"/viam.component.arm.v1.ArmService/Move",
+	"/viam.component.base.v1.BaseService/Move",
+	"/viam.component.base.v1.BaseService/Spin",
+	"/viam.component.gantry.v1.GantryService/Move",
+	"/viam.component.gripper.v1.GripperService/",
+	"/viam.component.motor.v1.MotorService/Go",
+	"/viam.component.servo.v1.ServoService/Move",
+}
+
+// drainPollInterval is how often running operations are checked while waiting for them.
+const drainPollInterval = 50 * time.Millisecond
+
+// IsMotion returns whether the operation moves an actuator.
+func (o *Operation) IsMotion() bool {
+	for _, prefix := range motionMethodPrefixes {
+		if strings.HasPrefix(o.Method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// StartDraining makes the manager's server interceptors refuse new operations with ErrDraining,
+// other than those that report on or stop the machine. Operations already running are not
+// affected.
+func (m *Manager) StartDraining() {
+	m.draining.Store(true)
+}
+
+// Draining returns whether StartDraining has been called.
+func (m *Manager) Draining() bool {
+	return m.draining.Load()
+}
+
+// WaitForMotion waits until no motion operations are running, or ctx is done, and returns
+// the motion operations that are still running.
+func (m *Manager) WaitForMotion(ctx context.Context) []*Operation {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for {
+		var running []*Operation
+		for _, op := range m.All() {
+			if op.IsMotion() {
+				running = append(running, op)
+			}
+		}
+		if len(running) == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return running
+		case <-ticker.C:
+		}
+	}
+}
+
+func drainExempt(method string) bool {
+	if strings.HasSuffix(method, "/Stop") {
+		return true
+	}
+	for _, exempt := range drainExemptMethods {
+		if method == exempt {
+			return true
+		}
+	}
+	for _, prefix := range methodPrefixesToFilter {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package operation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+	"google.golang.org/grpc"
+
+	"go.viam.com/rdk/logging"
+)
+
+func TestDraining(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	m := NewManager(logger)
+
+	const moveMethod = "/viam.component.arm.v1.ArmService/MoveToPosition"
+	moveCtx, moveDone := m.Create(context.Background(), moveMethod, nil)
+	_, readDone := m.Create(context.Background(), "/viam.component.arm.v1.ArmService/GetEndPosition", nil)
+	defer readDone()
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	call := func(method string) (interface{}, error) {
+		return m.UnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	}
+
+	resp, err := call(moveMethod)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp, test.ShouldEqual, "ok")
+
+	m.StartDraining()
+	test.That(t, m.Draining(), test.ShouldBeTrue)
+
+	// new operations are refused, other than those that report on or stop the machine
+	_, err = call(moveMethod)
+	test.That(t, err, test.ShouldEqual, ErrDraining)
+	for _, method := range []string{
+		"/viam.robot.v1.RobotService/GetMachineStatus",
+		"/viam.robot.v1.RobotService/SendSessionHeartbeat",
+		"/viam.component.arm.v1.ArmService/Stop",
+	} {
+		resp, err := call(method)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp, test.ShouldEqual, "ok")
+	}
+
+	// only motion operations are waited for
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	running := m.WaitForMotion(ctx)
+	test.That(t, running, test.ShouldHaveLength, 1)
+	test.That(t, running[0].Method, test.ShouldEqual, moveMethod)
+	running[0].Cancel()
+	test.That(t, moveCtx.Err(), test.ShouldNotBeNil)
+
+	moveDone()
+	test.That(t, m.WaitForMotion(context.Background()), test.ShouldBeEmpty)
+}
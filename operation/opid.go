@@ -5,6 +5,7 @@ import (
 	"context"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -78,9 +79,10 @@ func NewManager(logger logging.Logger) *Manager {
 
 // Manager holds Operations.
 type Manager struct {
-	ops    map[string]*Operation
-	lock   sync.Mutex
-	logger logging.Logger
+	ops      map[string]*Operation
+	lock     sync.Mutex
+	logger   logging.Logger
+	draining atomic.Bool
 }
 
 func (m *Manager) remove(id uuid.UUID) {
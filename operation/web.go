@@ -53,6 +53,12 @@ func (m *Manager) UnaryServerInterceptor(
 	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler,
 ) (interface{}, error) {
+	if m.Draining() {
+		if !drainExempt(info.FullMethod) {
+			return nil, ErrDraining
+		}
+		utils.UncheckedError(grpc.SetHeader(ctx, metadata.Pairs(DrainingMetadataKey, "true")))
+	}
 	ctx, done := m.CreateFromIncomingContext(ctx, info.FullMethod)
 	defer done()
 	if op := Get(ctx); op != nil && op.ID.String() != "" {
@@ -79,6 +85,12 @@ func (m *Manager) StreamServerInterceptor(
 	info *grpc.StreamServerInfo,
 	handler grpc.StreamHandler,
 ) error {
+	if m.Draining() {
+		if !drainExempt(info.FullMethod) {
+			return ErrDraining
+		}
+		utils.UncheckedError(ss.SetHeader(metadata.Pairs(DrainingMetadataKey, "true")))
+	}
 	ctx, done := m.CreateFromIncomingContext(ss.Context(), info.FullMethod)
 	defer done()
 	if op := Get(ctx); op != nil && op.ID.String() != "" {
@@ -0,0 +1,395 @@
+package pointcloud
+
+import (
+	"bufio"
+	"encoding/binary"
+	"image/color"
+	"io"
+	"math/bits"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+// The serialized form of an octree written by WriteLevelsOfDetail is a header, the octree's label,
+// and then its nodes in breadth first order, one level at a time, so that a reader can show a
+// coarse cloud as soon as the first levels arrive and refine it as the rest do. Each internal node
+// records which of its children follow in the next level, along with a point that summarizes the
+// points under it for readers that stop at its level.
+var octreeMagic = [4]byte{'V', 'O', 'C', 'T'}
+
+const octreeFormatVersion = 1
+
+const (
+	octreeRecordInternal = uint8(iota)
+	octreeRecordLeaf
+	octreeRecordEmpty
+)
+
+const (
+	octreeRecordHasColor = uint8(1 << iota)
+	octreeRecordHasValue
+)
+
+type octreeHeader struct {
+	Magic               [4]byte
+	Version             uint8
+	CenterX             float64
+	CenterY             float64
+	CenterZ             float64
+	SideLength          float64
+	ConfidenceThreshold int32
+	// Partial is 1 if only some levels or regions of the octree were written.
+	Partial     uint8
+	LabelLength uint16
+}
+
+type octreeNodeRecord struct {
+	Kind uint8
+	// Mask has a bit set for each child of an internal node that is written in the next level. It
+	// is zero for internal nodes whose children were not written at all.
+	Mask      uint8
+	Flags     uint8
+	R, G, B   uint8
+	Intensity uint16
+	Value     int32
+	X, Y, Z   float64
+}
+
+func newOctreeNodeRecord(kind uint8, p r3.Vector, d Data) octreeNodeRecord {
+	rec := octreeNodeRecord{Kind: kind, X: p.X, Y: p.Y, Z: p.Z}
+	if d == nil {
+		return rec
+	}
+	if d.HasColor() {
+		rec.Flags |= octreeRecordHasColor
+		rec.R, rec.G, rec.B = d.RGB255()
+	}
+	if d.HasValue() {
+		rec.Flags |= octreeRecordHasValue
+		rec.Value = int32(d.Value())
+	}
+	rec.Intensity = d.Intensity()
+	return rec
+}
+
+func (rec octreeNodeRecord) pointAndData() PointAndData {
+	d := NewBasicData()
+	if rec.Flags&octreeRecordHasColor != 0 {
+		d.SetColor(color.NRGBA{R: rec.R, G: rec.G, B: rec.B, A: 255})
+	}
+	if rec.Flags&octreeRecordHasValue != 0 {
+		d.SetValue(int(rec.Value))
+	}
+	d.SetIntensity(rec.Intensity)
+	return PointAndData{P: r3.Vector{X: rec.X, Y: rec.Y, Z: rec.Z}, D: d}
+}
+
+// LevelOfDetail returns a coarse version of the octree with a point for every occupied node at the
+// given depth, where depth 0 is the whole octree. Nodes with more than one point under them are
+// represented by the centroid of those points, with their average color and highest value. Points
+// in nodes above the depth are kept as they are. If within is not nil, only the parts of the
+// octree that collide with it are returned, so that a client can refine the region it is
+// looking at.
+func (octree *BasicOctree) LevelOfDetail(depth int, within spatialmath.Geometry) (PointCloud, error) {
+	if depth < 0 {
+		return nil, errors.New("level of detail depth must not be negative")
+	}
+	pc := NewBasicPointCloud(0)
+	if err := octree.levelOfDetail(depth, within, pc); err != nil {
+		return nil, err
+	}
+	return pc, nil
+}
+
+func (octree *BasicOctree) levelOfDetail(depth int, within spatialmath.Geometry, pc PointCloud) error {
+	if include, err := octree.intersects(within); err != nil || !include {
+		return err
+	}
+	switch octree.node.nodeType {
+	case internalNode:
+		if depth == 0 {
+			p, d := octree.summarize()
+			return pc.Set(p, d)
+		}
+		for _, child := range octree.node.children {
+			if err := child.levelOfDetail(depth-1, within, pc); err != nil {
+				return err
+			}
+		}
+	case leafNodeFilled:
+		return pc.Set(octree.node.point.P, octree.node.point.D)
+	case leafNodeEmpty:
+	}
+	return nil
+}
+
+// intersects returns whether any of the octree's points could collide with within, which
+// everything does if it is nil.
+func (octree *BasicOctree) intersects(within spatialmath.Geometry) (bool, error) {
+	switch octree.node.nodeType {
+	case internalNode:
+		if within == nil {
+			return true, nil
+		}
+		ocbox, err := spatialmath.NewBox(
+			spatialmath.NewPoseFromPoint(octree.center),
+			r3.Vector{X: octree.sideLength, Y: octree.sideLength, Z: octree.sideLength},
+			"",
+		)
+		if err != nil {
+			return false, err
+		}
+		return within.CollidesWith(ocbox, floatEpsilon)
+	case leafNodeFilled:
+		if within == nil {
+			return true, nil
+		}
+		return within.CollidesWith(spatialmath.NewPoint(octree.node.point.P, ""), floatEpsilon)
+	case leafNodeEmpty:
+	}
+	return false, nil
+}
+
+// summarize returns a single point that represents all of the points in the octree.
+func (octree *BasicOctree) summarize() (r3.Vector, Data) {
+	var sum r3.Vector
+	var r, g, b, colored, intensity int
+	value, hasValue := 0, false
+	octree.Iterate(0, 0, func(p r3.Vector, d Data) bool {
+		sum = sum.Add(p)
+		if d == nil {
+			return true
+		}
+		if d.HasColor() {
+			pr, pg, pb := d.RGB255()
+			r, g, b = r+int(pr), g+int(pg), b+int(pb)
+			colored++
+		}
+		if d.HasValue() && (!hasValue || d.Value() > value) {
+			value, hasValue = d.Value(), true
+		}
+		intensity += int(d.Intensity())
+		return true
+	})
+	if octree.size == 0 {
+		return octree.center, NewBasicData()
+	}
+
+	d := NewBasicData()
+	if colored > 0 {
+		d.SetColor(color.NRGBA{R: uint8(r / colored), G: uint8(g / colored), B: uint8(b / colored), A: 255})
+	}
+	if hasValue {
+		d.SetValue(value)
+	}
+	d.SetIntensity(uint16(intensity / octree.size))
+	return sum.Mul(1 / float64(octree.size)), d
+}
+
+// WriteLevelsOfDetail serializes the octree to w one level at a time, coarsest first, flushing
+// after each level so that readers on slow links can show the cloud while the rest of it arrives.
+// Levels deeper than maxDepth are not written, unless maxDepth is negative, and if within is not
+// nil, only the parts of the octree that collide with it are. Use ReadLevelOfDetail or
+// ReadBasicOctree to read it.
+func (octree *BasicOctree) WriteLevelsOfDetail(w io.Writer, maxDepth int, within spatialmath.Geometry) error {
+	if octree.sideLength == octreeMagicSideLength {
+		return errors.New("cannot write an octree that has not been finalized")
+	}
+	bw := bufio.NewWriter(w)
+	header := octreeHeader{
+		Magic:               octreeMagic,
+		Version:             octreeFormatVersion,
+		CenterX:             octree.center.X,
+		CenterY:             octree.center.Y,
+		CenterZ:             octree.center.Z,
+		SideLength:          octree.sideLength,
+		ConfidenceThreshold: int32(octree.confidenceThreshold),
+		LabelLength:         uint16(len(octree.label)),
+	}
+	if maxDepth >= 0 || within != nil {
+		header.Partial = 1
+	}
+	if int(header.LabelLength) != len(octree.label) {
+		return errors.Errorf("octree label is longer than %d bytes", header.LabelLength)
+	}
+	if err := binary.Write(bw, binary.LittleEndian, header); err != nil {
+		return err
+	}
+	if _, err := bw.WriteString(octree.label); err != nil {
+		return err
+	}
+
+	include, err := octree.intersects(within)
+	if err != nil {
+		return err
+	}
+	if !include {
+		if err := binary.Write(bw, binary.LittleEndian, octreeNodeRecord{Kind: octreeRecordEmpty}); err != nil {
+			return err
+		}
+		return bw.Flush()
+	}
+
+	level := []*BasicOctree{octree}
+	for depth := 0; len(level) > 0; depth++ {
+		var next []*BasicOctree
+		for _, node := range level {
+			var rec octreeNodeRecord
+			switch node.node.nodeType {
+			case internalNode:
+				p, d := node.summarize()
+				rec = newOctreeNodeRecord(octreeRecordInternal, p, d)
+				if maxDepth >= 0 && depth >= maxDepth {
+					break
+				}
+				for i, child := range node.node.children {
+					include, err := child.intersects(within)
+					if err != nil {
+						return err
+					}
+					if include {
+						rec.Mask |= 1 << i
+						next = append(next, child)
+					}
+				}
+			case leafNodeFilled:
+				rec = newOctreeNodeRecord(octreeRecordLeaf, node.node.point.P, node.node.point.D)
+			case leafNodeEmpty:
+				rec = octreeNodeRecord{Kind: octreeRecordEmpty}
+			}
+			if err := binary.Write(bw, binary.LittleEndian, rec); err != nil {
+				return err
+			}
+		}
+		if err := bw.Flush(); err != nil {
+			return err
+		}
+		level = next
+	}
+	return nil
+}
+
+// octreeLevels is what was read of an octree written by WriteLevelsOfDetail.
+type octreeLevels struct {
+	header octreeHeader
+	label  string
+	// leaves are the points of the levels that were read.
+	leaves []PointAndData
+	// frontier summarizes the internal nodes of the deepest level that was read.
+	frontier []PointAndData
+	// truncated is whether some of the octree was not written or not read.
+	truncated bool
+}
+
+func (levels *octreeLevels) points() []PointAndData {
+	return append(levels.leaves, levels.frontier...)
+}
+
+// readOctreeLevels reads the levels of an octree from r up to maxDepth, or all of them if it is
+// negative. If r ends partway through a level, the levels before it are returned.
+func readOctreeLevels(r io.Reader, maxDepth int) (*octreeLevels, error) {
+	br := bufio.NewReader(r)
+	levels := &octreeLevels{}
+	if err := binary.Read(br, binary.LittleEndian, &levels.header); err != nil {
+		return nil, errors.Wrap(err, "error reading octree header")
+	}
+	if levels.header.Magic != octreeMagic {
+		return nil, errors.New("not a serialized octree")
+	}
+	if levels.header.Version != octreeFormatVersion {
+		return nil, errors.Errorf("unsupported octree format version %d", levels.header.Version)
+	}
+	label := make([]byte, levels.header.LabelLength)
+	if _, err := io.ReadFull(br, label); err != nil {
+		return nil, errors.Wrap(err, "error reading octree label")
+	}
+	levels.label = string(label)
+	levels.truncated = levels.header.Partial != 0
+
+	nodes := 1
+	for depth := 0; nodes > 0; depth++ {
+		if maxDepth >= 0 && depth > maxDepth {
+			levels.truncated = true
+			break
+		}
+		var leaves, internal []PointAndData
+		next := 0
+		for i := 0; i < nodes; i++ {
+			var rec octreeNodeRecord
+			if err := binary.Read(br, binary.LittleEndian, &rec); err != nil {
+				if depth > 0 && (errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)) {
+					levels.truncated = true
+					return levels, nil
+				}
+				return nil, errors.Wrap(err, "error reading octree node")
+			}
+			switch rec.Kind {
+			case octreeRecordInternal:
+				internal = append(internal, rec.pointAndData())
+				next += bits.OnesCount8(rec.Mask)
+				if rec.Mask == 0 {
+					levels.truncated = true
+				}
+			case octreeRecordLeaf:
+				leaves = append(leaves, rec.pointAndData())
+			case octreeRecordEmpty:
+			default:
+				return nil, errors.Errorf("unknown octree node kind %d", rec.Kind)
+			}
+		}
+		levels.leaves = append(levels.leaves, leaves...)
+		levels.frontier = internal
+		nodes = next
+	}
+	return levels, nil
+}
+
+// ReadLevelOfDetail reads an octree written by WriteLevelsOfDetail down to the given depth, and
+// returns the points that represent it at that depth, like BasicOctree.LevelOfDetail does. If r
+// ends early, such as because a connection dropped, the deepest level that was read in full is
+// returned instead.
+func ReadLevelOfDetail(r io.Reader, depth int) (PointCloud, error) {
+	if depth < 0 {
+		return nil, errors.New("level of detail depth must not be negative")
+	}
+	levels, err := readOctreeLevels(r, depth)
+	if err != nil {
+		return nil, err
+	}
+	points := levels.points()
+	pc := NewBasicPointCloud(len(points))
+	for _, pt := range points {
+		if err := pc.Set(pt.P, pt.D); err != nil {
+			return nil, err
+		}
+	}
+	return pc, nil
+}
+
+// ReadBasicOctree reads an octree written in full by WriteLevelsOfDetail, with no maximum depth
+// or region to write.
+func ReadBasicOctree(r io.Reader) (*BasicOctree, error) {
+	levels, err := readOctreeLevels(r, -1)
+	if err != nil {
+		return nil, err
+	}
+	if levels.truncated {
+		return nil, errors.New("octree was not written in full; use ReadLevelOfDetail to read it")
+	}
+	header := levels.header
+	octree := newBasicOctree(
+		r3.Vector{X: header.CenterX, Y: header.CenterY, Z: header.CenterZ},
+		header.SideLength,
+		int(header.ConfidenceThreshold),
+	)
+	octree.SetLabel(levels.label)
+	for _, pt := range levels.leaves {
+		if err := octree.Set(pt.P, pt.D); err != nil {
+			return nil, err
+		}
+	}
+	return octree, nil
+}
@@ -1,6 +1,7 @@
 package pointcloud
 
 import (
+	"bytes"
 	"math"
 	"path/filepath"
 	"testing"
@@ -864,3 +865,95 @@ func TestBasicOctreePointsWithinRadius(t *testing.T) {
 		test.That(t, found, test.ShouldBeTrue)
 	}
 }
+
+func TestBasicOctreeLevelOfDetail(t *testing.T) {
+	octree, err := createExampleOctree()
+	test.That(t, err, test.ShouldBeNil)
+	octree.SetLabel("example")
+
+	t.Run("coarse levels summarize the points under them", func(t *testing.T) {
+		_, err := octree.LevelOfDetail(-1, nil)
+		test.That(t, err, test.ShouldNotBeNil)
+
+		pc, err := octree.LevelOfDetail(0, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, pc.Size(), test.ShouldEqual, 1)
+		pc.Iterate(0, 0, func(p r3.Vector, d Data) bool {
+			test.That(t, d.Value(), test.ShouldEqual, 60)
+			return true
+		})
+
+		coarse := 1
+		for depth := 1; depth < 8; depth++ {
+			pc, err := octree.LevelOfDetail(depth, nil)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, pc.Size(), test.ShouldBeGreaterThanOrEqualTo, coarse)
+			test.That(t, pc.Size(), test.ShouldBeLessThanOrEqualTo, octree.Size())
+			coarse = pc.Size()
+		}
+		test.That(t, coarse, test.ShouldEqual, octree.Size())
+	})
+
+	t.Run("only the region asked for is returned", func(t *testing.T) {
+		within, err := spatialmath.NewBox(
+			spatialmath.NewPoseFromPoint(r3.Vector{X: -.5, Y: -.5, Z: 0}),
+			r3.Vector{X: .5, Y: .5, Z: .5},
+			"",
+		)
+		test.That(t, err, test.ShouldBeNil)
+		pc, err := octree.LevelOfDetail(10, within)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, pc.Size(), test.ShouldEqual, 1)
+		d, ok := pc.At(-.55, -.55, 0)
+		test.That(t, ok, test.ShouldBeTrue)
+		test.That(t, d.Value(), test.ShouldEqual, 55)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		var buf bytes.Buffer
+		test.That(t, octree.WriteLevelsOfDetail(&buf, -1, nil), test.ShouldBeNil)
+		data := buf.Bytes()
+
+		read, err := ReadBasicOctree(bytes.NewReader(data))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, read.AlmostEqual(octree), test.ShouldBeTrue)
+		test.That(t, read.Label(), test.ShouldEqual, "example")
+		test.That(t, read.MaxVal(), test.ShouldEqual, octree.MaxVal())
+
+		for depth := 0; depth < 4; depth++ {
+			expected, err := octree.LevelOfDetail(depth, nil)
+			test.That(t, err, test.ShouldBeNil)
+			pc, err := ReadLevelOfDetail(bytes.NewReader(data), depth)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, pc.Size(), test.ShouldEqual, expected.Size())
+			expected.Iterate(0, 0, func(p r3.Vector, d Data) bool {
+				_, ok := pc.At(p.X, p.Y, p.Z)
+				test.That(t, ok, test.ShouldBeTrue)
+				return true
+			})
+		}
+
+		// a stream that was cut off partway through still yields the levels before it
+		pc, err := ReadLevelOfDetail(bytes.NewReader(data[:len(data)-1]), 100)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, pc.Size(), test.ShouldBeGreaterThan, 0)
+		_, err = ReadBasicOctree(bytes.NewReader(data[:len(data)-1]))
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
+	t.Run("partial writes", func(t *testing.T) {
+		var buf bytes.Buffer
+		test.That(t, octree.WriteLevelsOfDetail(&buf, 1, nil), test.ShouldBeNil)
+		_, err := ReadBasicOctree(bytes.NewReader(buf.Bytes()))
+		test.That(t, err, test.ShouldNotBeNil)
+
+		expected, err := octree.LevelOfDetail(1, nil)
+		test.That(t, err, test.ShouldBeNil)
+		pc, err := ReadLevelOfDetail(bytes.NewReader(buf.Bytes()), 5)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, pc.Size(), test.ShouldEqual, expected.Size())
+
+		_, err = ReadBasicOctree(bytes.NewReader([]byte("not an octree at all")))
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
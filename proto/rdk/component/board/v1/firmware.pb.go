@@ -0,0 +1,248 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rdk/component/board/v1/firmware.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FlashFirmwareRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the board.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The microcontroller to flash, as known to the board.
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// The version reported once the image is flashed.
+	Version string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	// The raw image.
+	Data []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	// The hex encoded SHA-256 of data, which is checked before flashing if set.
+	Sha256 string `protobuf:"bytes,5,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	// Additional arguments to the method.
+	Extra         *structpb.Struct `protobuf:"bytes,99,opt,name=extra,proto3" json:"extra,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlashFirmwareRequest) Reset() {
+	*x = FlashFirmwareRequest{}
+	mi := &file_rdk_component_board_v1_firmware_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlashFirmwareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlashFirmwareRequest) ProtoMessage() {}
+
+func (x *FlashFirmwareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_board_v1_firmware_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlashFirmwareRequest.ProtoReflect.Descriptor instead.
+func (*FlashFirmwareRequest) Descriptor() ([]byte, []int) {
+	return file_rdk_component_board_v1_firmware_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FlashFirmwareRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FlashFirmwareRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *FlashFirmwareRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *FlashFirmwareRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *FlashFirmwareRequest) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *FlashFirmwareRequest) GetExtra() *structpb.Struct {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+type FlashFirmwareResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The stage of flashing, such as "writing" or "verifying".
+	Stage         string `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	BytesDone     int64  `protobuf:"varint,2,opt,name=bytes_done,json=bytesDone,proto3" json:"bytes_done,omitempty"`
+	BytesTotal    int64  `protobuf:"varint,3,opt,name=bytes_total,json=bytesTotal,proto3" json:"bytes_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlashFirmwareResponse) Reset() {
+	*x = FlashFirmwareResponse{}
+	mi := &file_rdk_component_board_v1_firmware_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlashFirmwareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlashFirmwareResponse) ProtoMessage() {}
+
+func (x *FlashFirmwareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_board_v1_firmware_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlashFirmwareResponse.ProtoReflect.Descriptor instead.
+func (*FlashFirmwareResponse) Descriptor() ([]byte, []int) {
+	return file_rdk_component_board_v1_firmware_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FlashFirmwareResponse) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *FlashFirmwareResponse) GetBytesDone() int64 {
+	if x != nil {
+		return x.BytesDone
+	}
+	return 0
+}
+
+func (x *FlashFirmwareResponse) GetBytesTotal() int64 {
+	if x != nil {
+		return x.BytesTotal
+	}
+	return 0
+}
+
+var File_rdk_component_board_v1_firmware_proto protoreflect.FileDescriptor
+
+const file_rdk_component_board_v1_firmware_proto_rawDesc = "" +
+	"\n" +
+	"%rdk/component/board/v1/firmware.proto\x12\x16rdk.component.board.v1\x1a\x1cgoogle/protobuf/struct.proto\"\xb7\x01\n" +
+	"\x14FlashFirmwareRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\x12\x16\n" +
+	"\x06sha256\x18\x05 \x01(\tR\x06sha256\x12-\n" +
+	"\x05extra\x18c \x01(\v2\x17.google.protobuf.StructR\x05extra\"m\n" +
+	"\x15FlashFirmwareResponse\x12\x14\n" +
+	"\x05stage\x18\x01 \x01(\tR\x05stage\x12\x1d\n" +
+	"\n" +
+	"bytes_done\x18\x02 \x01(\x03R\tbytesDone\x12\x1f\n" +
+	"\vbytes_total\x18\x03 \x01(\x03R\n" +
+	"bytesTotal2\x86\x01\n" +
+	"\x14BoardFirmwareService\x12n\n" +
+	"\rFlashFirmware\x12,.rdk.component.board.v1.FlashFirmwareRequest\x1a-.rdk.component.board.v1.FlashFirmwareResponse0\x01B.Z,go.viam.com/rdk/proto/rdk/component/board/v1b\x06proto3"
+
+var (
+	file_rdk_component_board_v1_firmware_proto_rawDescOnce sync.Once
+	file_rdk_component_board_v1_firmware_proto_rawDescData []byte
+)
+
+func file_rdk_component_board_v1_firmware_proto_rawDescGZIP() []byte {
+	file_rdk_component_board_v1_firmware_proto_rawDescOnce.Do(func() {
+		file_rdk_component_board_v1_firmware_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rdk_component_board_v1_firmware_proto_rawDesc), len(file_rdk_component_board_v1_firmware_proto_rawDesc)))
+	})
+	return file_rdk_component_board_v1_firmware_proto_rawDescData
+}
+
+var file_rdk_component_board_v1_firmware_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rdk_component_board_v1_firmware_proto_goTypes = []any{
+	(*FlashFirmwareRequest)(nil),  // 0: rdk.component.board.v1.FlashFirmwareRequest
+	(*FlashFirmwareResponse)(nil), // 1: rdk.component.board.v1.FlashFirmwareResponse
+	(*structpb.Struct)(nil),       // 2: google.protobuf.Struct
+}
+var file_rdk_component_board_v1_firmware_proto_depIdxs = []int32{
+	2, // 0: rdk.component.board.v1.FlashFirmwareRequest.extra:type_name -> google.protobuf.Struct
+	0, // 1: rdk.component.board.v1.BoardFirmwareService.FlashFirmware:input_type -> rdk.component.board.v1.FlashFirmwareRequest
+	1, // 2: rdk.component.board.v1.BoardFirmwareService.FlashFirmware:output_type -> rdk.component.board.v1.FlashFirmwareResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rdk_component_board_v1_firmware_proto_init() }
+func file_rdk_component_board_v1_firmware_proto_init() {
+	if File_rdk_component_board_v1_firmware_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rdk_component_board_v1_firmware_proto_rawDesc), len(file_rdk_component_board_v1_firmware_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rdk_component_board_v1_firmware_proto_goTypes,
+		DependencyIndexes: file_rdk_component_board_v1_firmware_proto_depIdxs,
+		MessageInfos:      file_rdk_component_board_v1_firmware_proto_msgTypes,
+	}.Build()
+	File_rdk_component_board_v1_firmware_proto = out.File
+	file_rdk_component_board_v1_firmware_proto_goTypes = nil
+	file_rdk_component_board_v1_firmware_proto_depIdxs = nil
+}
@@ -0,0 +1,134 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: rdk/component/board/v1/firmware.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BoardFirmwareService_FlashFirmware_FullMethodName = "/rdk.component.board.v1.BoardFirmwareService/FlashFirmware"
+)
+
+// BoardFirmwareServiceClient is the client API for BoardFirmwareService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BoardFirmwareService flashes firmware onto the microcontrollers attached to boards, streaming
+// progress back as it happens. It is served alongside the board API, which has no methods for it.
+type BoardFirmwareServiceClient interface {
+	// FlashFirmware flashes an image onto a target of the named board. The stream ends once the
+	// image is flashed, or with the error that stopped it.
+	FlashFirmware(ctx context.Context, in *FlashFirmwareRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FlashFirmwareResponse], error)
+}
+
+type boardFirmwareServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBoardFirmwareServiceClient(cc grpc.ClientConnInterface) BoardFirmwareServiceClient {
+	return &boardFirmwareServiceClient{cc}
+}
+
+func (c *boardFirmwareServiceClient) FlashFirmware(ctx context.Context, in *FlashFirmwareRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FlashFirmwareResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BoardFirmwareService_ServiceDesc.Streams[0], BoardFirmwareService_FlashFirmware_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FlashFirmwareRequest, FlashFirmwareResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BoardFirmwareService_FlashFirmwareClient = grpc.ServerStreamingClient[FlashFirmwareResponse]
+
+// BoardFirmwareServiceServer is the server API for BoardFirmwareService service.
+// All implementations must embed UnimplementedBoardFirmwareServiceServer
+// for forward compatibility.
+//
+// BoardFirmwareService flashes firmware onto the microcontrollers attached to boards, streaming
+// progress back as it happens. It is served alongside the board API, which has no methods for it.
+type BoardFirmwareServiceServer interface {
+	// FlashFirmware flashes an image onto a target of the named board. The stream ends once the
+	// image is flashed, or with the error that stopped it.
+	FlashFirmware(*FlashFirmwareRequest, grpc.ServerStreamingServer[FlashFirmwareResponse]) error
+	mustEmbedUnimplementedBoardFirmwareServiceServer()
+}
+
+// UnimplementedBoardFirmwareServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBoardFirmwareServiceServer struct{}
+
+func (UnimplementedBoardFirmwareServiceServer) FlashFirmware(*FlashFirmwareRequest, grpc.ServerStreamingServer[FlashFirmwareResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method FlashFirmware not implemented")
+}
+func (UnimplementedBoardFirmwareServiceServer) mustEmbedUnimplementedBoardFirmwareServiceServer() {}
+func (UnimplementedBoardFirmwareServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafeBoardFirmwareServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BoardFirmwareServiceServer will
+// result in compilation errors.
+type UnsafeBoardFirmwareServiceServer interface {
+	mustEmbedUnimplementedBoardFirmwareServiceServer()
+}
+
+func RegisterBoardFirmwareServiceServer(s grpc.ServiceRegistrar, srv BoardFirmwareServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBoardFirmwareServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BoardFirmwareService_ServiceDesc, srv)
+}
+
+func _BoardFirmwareService_FlashFirmware_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FlashFirmwareRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BoardFirmwareServiceServer).FlashFirmware(m, &grpc.GenericServerStream[FlashFirmwareRequest, FlashFirmwareResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BoardFirmwareService_FlashFirmwareServer = grpc.ServerStreamingServer[FlashFirmwareResponse]
+
+// BoardFirmwareService_ServiceDesc is the grpc.ServiceDesc for BoardFirmwareService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BoardFirmwareService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rdk.component.board.v1.BoardFirmwareService",
+	HandlerType: (*BoardFirmwareServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FlashFirmware",
+			Handler:       _BoardFirmwareService_FlashFirmware_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rdk/component/board/v1/firmware.proto",
+}
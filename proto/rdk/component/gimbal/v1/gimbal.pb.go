@@ -0,0 +1,1257 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rdk/component/gimbal/v1/gimbal.proto
+
+package v1
+
+import (
+	v1 "go.viam.com/api/common/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Angles are the roll, pitch and yaw of a gimbal in degrees.
+type Angles struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roll          float64                `protobuf:"fixed64,1,opt,name=roll,proto3" json:"roll,omitempty"`
+	Pitch         float64                `protobuf:"fixed64,2,opt,name=pitch,proto3" json:"pitch,omitempty"`
+	Yaw           float64                `protobuf:"fixed64,3,opt,name=yaw,proto3" json:"yaw,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Angles) Reset() {
+	*x = Angles{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Angles) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Angles) ProtoMessage() {}
+
+func (x *Angles) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Angles.ProtoReflect.Descriptor instead.
+func (*Angles) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Angles) GetRoll() float64 {
+	if x != nil {
+		return x.Roll
+	}
+	return 0
+}
+
+func (x *Angles) GetPitch() float64 {
+	if x != nil {
+		return x.Pitch
+	}
+	return 0
+}
+
+func (x *Angles) GetYaw() float64 {
+	if x != nil {
+		return x.Yaw
+	}
+	return 0
+}
+
+type GetAnglesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the gimbal.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Additional arguments to the method.
+	Extra         *structpb.Struct `protobuf:"bytes,99,opt,name=extra,proto3" json:"extra,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAnglesRequest) Reset() {
+	*x = GetAnglesRequest{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAnglesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnglesRequest) ProtoMessage() {}
+
+func (x *GetAnglesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnglesRequest.ProtoReflect.Descriptor instead.
+func (*GetAnglesRequest) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetAnglesRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetAnglesRequest) GetExtra() *structpb.Struct {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+type GetAnglesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Angles        *Angles                `protobuf:"bytes,1,opt,name=angles,proto3" json:"angles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAnglesResponse) Reset() {
+	*x = GetAnglesResponse{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAnglesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnglesResponse) ProtoMessage() {}
+
+func (x *GetAnglesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnglesResponse.ProtoReflect.Descriptor instead.
+func (*GetAnglesResponse) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetAnglesResponse) GetAngles() *Angles {
+	if x != nil {
+		return x.Angles
+	}
+	return nil
+}
+
+type SetTargetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the gimbal.
+	Name   string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Target *Angles `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// Additional arguments to the method.
+	Extra         *structpb.Struct `protobuf:"bytes,99,opt,name=extra,proto3" json:"extra,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTargetRequest) Reset() {
+	*x = SetTargetRequest{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTargetRequest) ProtoMessage() {}
+
+func (x *SetTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTargetRequest.ProtoReflect.Descriptor instead.
+func (*SetTargetRequest) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetTargetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetTargetRequest) GetTarget() *Angles {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *SetTargetRequest) GetExtra() *structpb.Struct {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+type SetTargetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTargetResponse) Reset() {
+	*x = SetTargetResponse{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTargetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTargetResponse) ProtoMessage() {}
+
+func (x *SetTargetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTargetResponse.ProtoReflect.Descriptor instead.
+func (*SetTargetResponse) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{4}
+}
+
+type GetTargetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the gimbal.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Additional arguments to the method.
+	Extra         *structpb.Struct `protobuf:"bytes,99,opt,name=extra,proto3" json:"extra,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTargetRequest) Reset() {
+	*x = GetTargetRequest{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTargetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTargetRequest) ProtoMessage() {}
+
+func (x *GetTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTargetRequest.ProtoReflect.Descriptor instead.
+func (*GetTargetRequest) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetTargetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetTargetRequest) GetExtra() *structpb.Struct {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+type GetTargetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        *Angles                `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTargetResponse) Reset() {
+	*x = GetTargetResponse{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTargetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTargetResponse) ProtoMessage() {}
+
+func (x *GetTargetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTargetResponse.ProtoReflect.Descriptor instead.
+func (*GetTargetResponse) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetTargetResponse) GetTarget() *Angles {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+type SetStabilizationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the gimbal.
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled bool   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Additional arguments to the method.
+	Extra         *structpb.Struct `protobuf:"bytes,99,opt,name=extra,proto3" json:"extra,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetStabilizationRequest) Reset() {
+	*x = SetStabilizationRequest{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetStabilizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStabilizationRequest) ProtoMessage() {}
+
+func (x *SetStabilizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStabilizationRequest.ProtoReflect.Descriptor instead.
+func (*SetStabilizationRequest) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SetStabilizationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetStabilizationRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SetStabilizationRequest) GetExtra() *structpb.Struct {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+type SetStabilizationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetStabilizationResponse) Reset() {
+	*x = SetStabilizationResponse{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetStabilizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStabilizationResponse) ProtoMessage() {}
+
+func (x *SetStabilizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStabilizationResponse.ProtoReflect.Descriptor instead.
+func (*SetStabilizationResponse) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{8}
+}
+
+type IsStabilizingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the gimbal.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Additional arguments to the method.
+	Extra         *structpb.Struct `protobuf:"bytes,99,opt,name=extra,proto3" json:"extra,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsStabilizingRequest) Reset() {
+	*x = IsStabilizingRequest{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsStabilizingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsStabilizingRequest) ProtoMessage() {}
+
+func (x *IsStabilizingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsStabilizingRequest.ProtoReflect.Descriptor instead.
+func (*IsStabilizingRequest) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *IsStabilizingRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *IsStabilizingRequest) GetExtra() *structpb.Struct {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+type IsStabilizingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsStabilizing bool                   `protobuf:"varint,1,opt,name=is_stabilizing,json=isStabilizing,proto3" json:"is_stabilizing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsStabilizingResponse) Reset() {
+	*x = IsStabilizingResponse{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsStabilizingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsStabilizingResponse) ProtoMessage() {}
+
+func (x *IsStabilizingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsStabilizingResponse.ProtoReflect.Descriptor instead.
+func (*IsStabilizingResponse) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *IsStabilizingResponse) GetIsStabilizing() bool {
+	if x != nil {
+		return x.IsStabilizing
+	}
+	return false
+}
+
+type TrackDetectionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the gimbal.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The label of the detections to track.
+	Label string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	// Additional arguments to the method.
+	Extra         *structpb.Struct `protobuf:"bytes,99,opt,name=extra,proto3" json:"extra,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrackDetectionRequest) Reset() {
+	*x = TrackDetectionRequest{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackDetectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackDetectionRequest) ProtoMessage() {}
+
+func (x *TrackDetectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackDetectionRequest.ProtoReflect.Descriptor instead.
+func (*TrackDetectionRequest) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TrackDetectionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TrackDetectionRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *TrackDetectionRequest) GetExtra() *structpb.Struct {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+type TrackDetectionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrackDetectionResponse) Reset() {
+	*x = TrackDetectionResponse{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrackDetectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackDetectionResponse) ProtoMessage() {}
+
+func (x *TrackDetectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackDetectionResponse.ProtoReflect.Descriptor instead.
+func (*TrackDetectionResponse) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{12}
+}
+
+type GetInputsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the gimbal.
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInputsRequest) Reset() {
+	*x = GetInputsRequest{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInputsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInputsRequest) ProtoMessage() {}
+
+func (x *GetInputsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInputsRequest.ProtoReflect.Descriptor instead.
+func (*GetInputsRequest) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetInputsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetInputsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inputs        []float64              `protobuf:"fixed64,1,rep,packed,name=inputs,proto3" json:"inputs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInputsResponse) Reset() {
+	*x = GetInputsResponse{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInputsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInputsResponse) ProtoMessage() {}
+
+func (x *GetInputsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInputsResponse.ProtoReflect.Descriptor instead.
+func (*GetInputsResponse) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetInputsResponse) GetInputs() []float64 {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+// Inputs are the angles of a gimbal's axes in radians.
+type Inputs struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []float64              `protobuf:"fixed64,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Inputs) Reset() {
+	*x = Inputs{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Inputs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Inputs) ProtoMessage() {}
+
+func (x *Inputs) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Inputs.ProtoReflect.Descriptor instead.
+func (*Inputs) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Inputs) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type GoToInputsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the gimbal.
+	Name          string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Steps         []*Inputs `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GoToInputsRequest) Reset() {
+	*x = GoToInputsRequest{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GoToInputsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoToInputsRequest) ProtoMessage() {}
+
+func (x *GoToInputsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoToInputsRequest.ProtoReflect.Descriptor instead.
+func (*GoToInputsRequest) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GoToInputsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GoToInputsRequest) GetSteps() []*Inputs {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+type GoToInputsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GoToInputsResponse) Reset() {
+	*x = GoToInputsResponse{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GoToInputsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoToInputsResponse) ProtoMessage() {}
+
+func (x *GoToInputsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoToInputsResponse.ProtoReflect.Descriptor instead.
+func (*GoToInputsResponse) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{17}
+}
+
+type StopRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the gimbal.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Additional arguments to the method.
+	Extra         *structpb.Struct `protobuf:"bytes,99,opt,name=extra,proto3" json:"extra,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopRequest) Reset() {
+	*x = StopRequest{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopRequest) ProtoMessage() {}
+
+func (x *StopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopRequest.ProtoReflect.Descriptor instead.
+func (*StopRequest) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StopRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StopRequest) GetExtra() *structpb.Struct {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+type StopResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopResponse) Reset() {
+	*x = StopResponse{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopResponse) ProtoMessage() {}
+
+func (x *StopResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopResponse.ProtoReflect.Descriptor instead.
+func (*StopResponse) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{19}
+}
+
+type IsMovingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the gimbal.
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsMovingRequest) Reset() {
+	*x = IsMovingRequest{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsMovingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsMovingRequest) ProtoMessage() {}
+
+func (x *IsMovingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsMovingRequest.ProtoReflect.Descriptor instead.
+func (*IsMovingRequest) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *IsMovingRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type IsMovingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsMoving      bool                   `protobuf:"varint,1,opt,name=is_moving,json=isMoving,proto3" json:"is_moving,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsMovingResponse) Reset() {
+	*x = IsMovingResponse{}
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsMovingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsMovingResponse) ProtoMessage() {}
+
+func (x *IsMovingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_component_gimbal_v1_gimbal_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsMovingResponse.ProtoReflect.Descriptor instead.
+func (*IsMovingResponse) Descriptor() ([]byte, []int) {
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *IsMovingResponse) GetIsMoving() bool {
+	if x != nil {
+		return x.IsMoving
+	}
+	return false
+}
+
+var File_rdk_component_gimbal_v1_gimbal_proto protoreflect.FileDescriptor
+
+const file_rdk_component_gimbal_v1_gimbal_proto_rawDesc = "" +
+	"\n" +
+	"$rdk/component/gimbal/v1/gimbal.proto\x12\x17rdk.component.gimbal.v1\x1a\x16common/v1/common.proto\x1a\x1cgoogle/protobuf/struct.proto\"D\n" +
+	"\x06Angles\x12\x12\n" +
+	"\x04roll\x18\x01 \x01(\x01R\x04roll\x12\x14\n" +
+	"\x05pitch\x18\x02 \x01(\x01R\x05pitch\x12\x10\n" +
+	"\x03yaw\x18\x03 \x01(\x01R\x03yaw\"U\n" +
+	"\x10GetAnglesRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12-\n" +
+	"\x05extra\x18c \x01(\v2\x17.google.protobuf.StructR\x05extra\"L\n" +
+	"\x11GetAnglesResponse\x127\n" +
+	"\x06angles\x18\x01 \x01(\v2\x1f.rdk.component.gimbal.v1.AnglesR\x06angles\"\x8e\x01\n" +
+	"\x10SetTargetRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x127\n" +
+	"\x06target\x18\x02 \x01(\v2\x1f.rdk.component.gimbal.v1.AnglesR\x06target\x12-\n" +
+	"\x05extra\x18c \x01(\v2\x17.google.protobuf.StructR\x05extra\"\x13\n" +
+	"\x11SetTargetResponse\"U\n" +
+	"\x10GetTargetRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12-\n" +
+	"\x05extra\x18c \x01(\v2\x17.google.protobuf.StructR\x05extra\"L\n" +
+	"\x11GetTargetResponse\x127\n" +
+	"\x06target\x18\x01 \x01(\v2\x1f.rdk.component.gimbal.v1.AnglesR\x06target\"v\n" +
+	"\x17SetStabilizationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x12-\n" +
+	"\x05extra\x18c \x01(\v2\x17.google.protobuf.StructR\x05extra\"\x1a\n" +
+	"\x18SetStabilizationResponse\"Y\n" +
+	"\x14IsStabilizingRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12-\n" +
+	"\x05extra\x18c \x01(\v2\x17.google.protobuf.StructR\x05extra\">\n" +
+	"\x15IsStabilizingResponse\x12%\n" +
+	"\x0eis_stabilizing\x18\x01 \x01(\bR\risStabilizing\"p\n" +
+	"\x15TrackDetectionRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12-\n" +
+	"\x05extra\x18c \x01(\v2\x17.google.protobuf.StructR\x05extra\"\x18\n" +
+	"\x16TrackDetectionResponse\"&\n" +
+	"\x10GetInputsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"+\n" +
+	"\x11GetInputsResponse\x12\x16\n" +
+	"\x06inputs\x18\x01 \x03(\x01R\x06inputs\" \n" +
+	"\x06Inputs\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x01R\x06values\"^\n" +
+	"\x11GoToInputsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x125\n" +
+	"\x05steps\x18\x02 \x03(\v2\x1f.rdk.component.gimbal.v1.InputsR\x05steps\"\x14\n" +
+	"\x12GoToInputsResponse\"P\n" +
+	"\vStopRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12-\n" +
+	"\x05extra\x18c \x01(\v2\x17.google.protobuf.StructR\x05extra\"\x0e\n" +
+	"\fStopResponse\"%\n" +
+	"\x0fIsMovingRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"/\n" +
+	"\x10IsMovingResponse\x12\x1b\n" +
+	"\tis_moving\x18\x01 \x01(\bR\bisMoving2\xc8\t\n" +
+	"\rGimbalService\x12b\n" +
+	"\tGetAngles\x12).rdk.component.gimbal.v1.GetAnglesRequest\x1a*.rdk.component.gimbal.v1.GetAnglesResponse\x12b\n" +
+	"\tSetTarget\x12).rdk.component.gimbal.v1.SetTargetRequest\x1a*.rdk.component.gimbal.v1.SetTargetResponse\x12b\n" +
+	"\tGetTarget\x12).rdk.component.gimbal.v1.GetTargetRequest\x1a*.rdk.component.gimbal.v1.GetTargetResponse\x12w\n" +
+	"\x10SetStabilization\x120.rdk.component.gimbal.v1.SetStabilizationRequest\x1a1.rdk.component.gimbal.v1.SetStabilizationResponse\x12n\n" +
+	"\rIsStabilizing\x12-.rdk.component.gimbal.v1.IsStabilizingRequest\x1a..rdk.component.gimbal.v1.IsStabilizingResponse\x12q\n" +
+	"\x0eTrackDetection\x12..rdk.component.gimbal.v1.TrackDetectionRequest\x1a/.rdk.component.gimbal.v1.TrackDetectionResponse\x12b\n" +
+	"\tGetInputs\x12).rdk.component.gimbal.v1.GetInputsRequest\x1a*.rdk.component.gimbal.v1.GetInputsResponse\x12e\n" +
+	"\n" +
+	"GoToInputs\x12*.rdk.component.gimbal.v1.GoToInputsRequest\x1a+.rdk.component.gimbal.v1.GoToInputsResponse\x12S\n" +
+	"\x04Stop\x12$.rdk.component.gimbal.v1.StopRequest\x1a%.rdk.component.gimbal.v1.StopResponse\x12_\n" +
+	"\bIsMoving\x12(.rdk.component.gimbal.v1.IsMovingRequest\x1a).rdk.component.gimbal.v1.IsMovingResponse\x12\\\n" +
+	"\rGetKinematics\x12$.viam.common.v1.GetKinematicsRequest\x1a%.viam.common.v1.GetKinematicsResponse\x12P\n" +
+	"\tDoCommand\x12 .viam.common.v1.DoCommandRequest\x1a!.viam.common.v1.DoCommandResponseB/Z-go.viam.com/rdk/proto/rdk/component/gimbal/v1b\x06proto3"
+
+var (
+	file_rdk_component_gimbal_v1_gimbal_proto_rawDescOnce sync.Once
+	file_rdk_component_gimbal_v1_gimbal_proto_rawDescData []byte
+)
+
+func file_rdk_component_gimbal_v1_gimbal_proto_rawDescGZIP() []byte {
+	file_rdk_component_gimbal_v1_gimbal_proto_rawDescOnce.Do(func() {
+		file_rdk_component_gimbal_v1_gimbal_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rdk_component_gimbal_v1_gimbal_proto_rawDesc), len(file_rdk_component_gimbal_v1_gimbal_proto_rawDesc)))
+	})
+	return file_rdk_component_gimbal_v1_gimbal_proto_rawDescData
+}
+
+var file_rdk_component_gimbal_v1_gimbal_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_rdk_component_gimbal_v1_gimbal_proto_goTypes = []any{
+	(*Angles)(nil),                   // 0: rdk.component.gimbal.v1.Angles
+	(*GetAnglesRequest)(nil),         // 1: rdk.component.gimbal.v1.GetAnglesRequest
+	(*GetAnglesResponse)(nil),        // 2: rdk.component.gimbal.v1.GetAnglesResponse
+	(*SetTargetRequest)(nil),         // 3: rdk.component.gimbal.v1.SetTargetRequest
+	(*SetTargetResponse)(nil),        // 4: rdk.component.gimbal.v1.SetTargetResponse
+	(*GetTargetRequest)(nil),         // 5: rdk.component.gimbal.v1.GetTargetRequest
+	(*GetTargetResponse)(nil),        // 6: rdk.component.gimbal.v1.GetTargetResponse
+	(*SetStabilizationRequest)(nil),  // 7: rdk.component.gimbal.v1.SetStabilizationRequest
+	(*SetStabilizationResponse)(nil), // 8: rdk.component.gimbal.v1.SetStabilizationResponse
+	(*IsStabilizingRequest)(nil),     // 9: rdk.component.gimbal.v1.IsStabilizingRequest
+	(*IsStabilizingResponse)(nil),    // 10: rdk.component.gimbal.v1.IsStabilizingResponse
+	(*TrackDetectionRequest)(nil),    // 11: rdk.component.gimbal.v1.TrackDetectionRequest
+	(*TrackDetectionResponse)(nil),   // 12: rdk.component.gimbal.v1.TrackDetectionResponse
+	(*GetInputsRequest)(nil),         // 13: rdk.component.gimbal.v1.GetInputsRequest
+	(*GetInputsResponse)(nil),        // 14: rdk.component.gimbal.v1.GetInputsResponse
+	(*Inputs)(nil),                   // 15: rdk.component.gimbal.v1.Inputs
+	(*GoToInputsRequest)(nil),        // 16: rdk.component.gimbal.v1.GoToInputsRequest
+	(*GoToInputsResponse)(nil),       // 17: rdk.component.gimbal.v1.GoToInputsResponse
+	(*StopRequest)(nil),              // 18: rdk.component.gimbal.v1.StopRequest
+	(*StopResponse)(nil),             // 19: rdk.component.gimbal.v1.StopResponse
+	(*IsMovingRequest)(nil),          // 20: rdk.component.gimbal.v1.IsMovingRequest
+	(*IsMovingResponse)(nil),         // 21: rdk.component.gimbal.v1.IsMovingResponse
+	(*structpb.Struct)(nil),          // 22: google.protobuf.Struct
+	(*v1.GetKinematicsRequest)(nil),  // 23: viam.common.v1.GetKinematicsRequest
+	(*v1.DoCommandRequest)(nil),      // 24: viam.common.v1.DoCommandRequest
+	(*v1.GetKinematicsResponse)(nil), // 25: viam.common.v1.GetKinematicsResponse
+	(*v1.DoCommandResponse)(nil),     // 26: viam.common.v1.DoCommandResponse
+}
+var file_rdk_component_gimbal_v1_gimbal_proto_depIdxs = []int32{
+	22, // 0: rdk.component.gimbal.v1.GetAnglesRequest.extra:type_name -> google.protobuf.Struct
+	0,  // 1: rdk.component.gimbal.v1.GetAnglesResponse.angles:type_name -> rdk.component.gimbal.v1.Angles
+	0,  // 2: rdk.component.gimbal.v1.SetTargetRequest.target:type_name -> rdk.component.gimbal.v1.Angles
+	22, // 3: rdk.component.gimbal.v1.SetTargetRequest.extra:type_name -> google.protobuf.Struct
+	22, // 4: rdk.component.gimbal.v1.GetTargetRequest.extra:type_name -> google.protobuf.Struct
+	0,  // 5: rdk.component.gimbal.v1.GetTargetResponse.target:type_name -> rdk.component.gimbal.v1.Angles
+	22, // 6: rdk.component.gimbal.v1.SetStabilizationRequest.extra:type_name -> google.protobuf.Struct
+	22, // 7: rdk.component.gimbal.v1.IsStabilizingRequest.extra:type_name -> google.protobuf.Struct
+	22, // 8: rdk.component.gimbal.v1.TrackDetectionRequest.extra:type_name -> google.protobuf.Struct
+	15, // 9: rdk.component.gimbal.v1.GoToInputsRequest.steps:type_name -> rdk.component.gimbal.v1.Inputs
+	22, // 10: rdk.component.gimbal.v1.StopRequest.extra:type_name -> google.protobuf.Struct
+	1,  // 11: rdk.component.gimbal.v1.GimbalService.GetAngles:input_type -> rdk.component.gimbal.v1.GetAnglesRequest
+	3,  // 12: rdk.component.gimbal.v1.GimbalService.SetTarget:input_type -> rdk.component.gimbal.v1.SetTargetRequest
+	5,  // 13: rdk.component.gimbal.v1.GimbalService.GetTarget:input_type -> rdk.component.gimbal.v1.GetTargetRequest
+	7,  // 14: rdk.component.gimbal.v1.GimbalService.SetStabilization:input_type -> rdk.component.gimbal.v1.SetStabilizationRequest
+	9,  // 15: rdk.component.gimbal.v1.GimbalService.IsStabilizing:input_type -> rdk.component.gimbal.v1.IsStabilizingRequest
+	11, // 16: rdk.component.gimbal.v1.GimbalService.TrackDetection:input_type -> rdk.component.gimbal.v1.TrackDetectionRequest
+	13, // 17: rdk.component.gimbal.v1.GimbalService.GetInputs:input_type -> rdk.component.gimbal.v1.GetInputsRequest
+	16, // 18: rdk.component.gimbal.v1.GimbalService.GoToInputs:input_type -> rdk.component.gimbal.v1.GoToInputsRequest
+	18, // 19: rdk.component.gimbal.v1.GimbalService.Stop:input_type -> rdk.component.gimbal.v1.StopRequest
+	20, // 20: rdk.component.gimbal.v1.GimbalService.IsMoving:input_type -> rdk.component.gimbal.v1.IsMovingRequest
+	23, // 21: rdk.component.gimbal.v1.GimbalService.GetKinematics:input_type -> viam.common.v1.GetKinematicsRequest
+	24, // 22: rdk.component.gimbal.v1.GimbalService.DoCommand:input_type -> viam.common.v1.DoCommandRequest
+	2,  // 23: rdk.component.gimbal.v1.GimbalService.GetAngles:output_type -> rdk.component.gimbal.v1.GetAnglesResponse
+	4,  // 24: rdk.component.gimbal.v1.GimbalService.SetTarget:output_type -> rdk.component.gimbal.v1.SetTargetResponse
+	6,  // 25: rdk.component.gimbal.v1.GimbalService.GetTarget:output_type -> rdk.component.gimbal.v1.GetTargetResponse
+	8,  // 26: rdk.component.gimbal.v1.GimbalService.SetStabilization:output_type -> rdk.component.gimbal.v1.SetStabilizationResponse
+	10, // 27: rdk.component.gimbal.v1.GimbalService.IsStabilizing:output_type -> rdk.component.gimbal.v1.IsStabilizingResponse
+	12, // 28: rdk.component.gimbal.v1.GimbalService.TrackDetection:output_type -> rdk.component.gimbal.v1.TrackDetectionResponse
+	14, // 29: rdk.component.gimbal.v1.GimbalService.GetInputs:output_type -> rdk.component.gimbal.v1.GetInputsResponse
+	17, // 30: rdk.component.gimbal.v1.GimbalService.GoToInputs:output_type -> rdk.component.gimbal.v1.GoToInputsResponse
+	19, // 31: rdk.component.gimbal.v1.GimbalService.Stop:output_type -> rdk.component.gimbal.v1.StopResponse
+	21, // 32: rdk.component.gimbal.v1.GimbalService.IsMoving:output_type -> rdk.component.gimbal.v1.IsMovingResponse
+	25, // 33: rdk.component.gimbal.v1.GimbalService.GetKinematics:output_type -> viam.common.v1.GetKinematicsResponse
+	26, // 34: rdk.component.gimbal.v1.GimbalService.DoCommand:output_type -> viam.common.v1.DoCommandResponse
+	23, // [23:35] is the sub-list for method output_type
+	11, // [11:23] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_rdk_component_gimbal_v1_gimbal_proto_init() }
+func file_rdk_component_gimbal_v1_gimbal_proto_init() {
+	if File_rdk_component_gimbal_v1_gimbal_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rdk_component_gimbal_v1_gimbal_proto_rawDesc), len(file_rdk_component_gimbal_v1_gimbal_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rdk_component_gimbal_v1_gimbal_proto_goTypes,
+		DependencyIndexes: file_rdk_component_gimbal_v1_gimbal_proto_depIdxs,
+		MessageInfos:      file_rdk_component_gimbal_v1_gimbal_proto_msgTypes,
+	}.Build()
+	File_rdk_component_gimbal_v1_gimbal_proto = out.File
+	file_rdk_component_gimbal_v1_gimbal_proto_goTypes = nil
+	file_rdk_component_gimbal_v1_gimbal_proto_depIdxs = nil
+}
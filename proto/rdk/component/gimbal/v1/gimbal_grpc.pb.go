@@ -0,0 +1,572 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: rdk/component/gimbal/v1/gimbal.proto
+
+package v1
+
+import (
+	context "context"
+	v1 "go.viam.com/api/common/v1"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GimbalService_GetAngles_FullMethodName        = "/rdk.component.gimbal.v1.GimbalService/GetAngles"
+	GimbalService_SetTarget_FullMethodName        = "/rdk.component.gimbal.v1.GimbalService/SetTarget"
+	GimbalService_GetTarget_FullMethodName        = "/rdk.component.gimbal.v1.GimbalService/GetTarget"
+	GimbalService_SetStabilization_FullMethodName = "/rdk.component.gimbal.v1.GimbalService/SetStabilization"
+	GimbalService_IsStabilizing_FullMethodName    = "/rdk.component.gimbal.v1.GimbalService/IsStabilizing"
+	GimbalService_TrackDetection_FullMethodName   = "/rdk.component.gimbal.v1.GimbalService/TrackDetection"
+	GimbalService_GetInputs_FullMethodName        = "/rdk.component.gimbal.v1.GimbalService/GetInputs"
+	GimbalService_GoToInputs_FullMethodName       = "/rdk.component.gimbal.v1.GimbalService/GoToInputs"
+	GimbalService_Stop_FullMethodName             = "/rdk.component.gimbal.v1.GimbalService/Stop"
+	GimbalService_IsMoving_FullMethodName         = "/rdk.component.gimbal.v1.GimbalService/IsMoving"
+	GimbalService_GetKinematics_FullMethodName    = "/rdk.component.gimbal.v1.GimbalService/GetKinematics"
+	GimbalService_DoCommand_FullMethodName        = "/rdk.component.gimbal.v1.GimbalService/DoCommand"
+)
+
+// GimbalServiceClient is the client API for GimbalService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GimbalService points cameras using the gimbals of a machine.
+type GimbalServiceClient interface {
+	// GetAngles returns the current angles of the gimbal's axes relative to its mount.
+	GetAngles(ctx context.Context, in *GetAnglesRequest, opts ...grpc.CallOption) (*GetAnglesResponse, error)
+	// SetTarget sets the angles the camera should point at and stops tracking any detection.
+	SetTarget(ctx context.Context, in *SetTargetRequest, opts ...grpc.CallOption) (*SetTargetResponse, error)
+	// GetTarget returns the angles the camera is pointed at.
+	GetTarget(ctx context.Context, in *GetTargetRequest, opts ...grpc.CallOption) (*GetTargetResponse, error)
+	// SetStabilization turns stabilization on or off.
+	SetStabilization(ctx context.Context, in *SetStabilizationRequest, opts ...grpc.CallOption) (*SetStabilizationResponse, error)
+	// IsStabilizing returns whether the gimbal is stabilizing.
+	IsStabilizing(ctx context.Context, in *IsStabilizingRequest, opts ...grpc.CallOption) (*IsStabilizingResponse, error)
+	// TrackDetection keeps the highest scoring detection with the given label in the middle of the
+	// camera's view.
+	TrackDetection(ctx context.Context, in *TrackDetectionRequest, opts ...grpc.CallOption) (*TrackDetectionResponse, error)
+	// GetInputs returns the angles of the gimbal's axes in radians, as its inputs in the frame
+	// system.
+	GetInputs(ctx context.Context, in *GetInputsRequest, opts ...grpc.CallOption) (*GetInputsResponse, error)
+	// GoToInputs moves the gimbal's axes through each of the given inputs in turn.
+	GoToInputs(ctx context.Context, in *GoToInputsRequest, opts ...grpc.CallOption) (*GoToInputsResponse, error)
+	// Stop stops tracking and stabilizing and stops the axes where they are.
+	Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error)
+	// IsMoving returns whether the gimbal is moving.
+	IsMoving(ctx context.Context, in *IsMovingRequest, opts ...grpc.CallOption) (*IsMovingResponse, error)
+	// GetKinematics returns the kinematics file for the gimbal.
+	GetKinematics(ctx context.Context, in *v1.GetKinematicsRequest, opts ...grpc.CallOption) (*v1.GetKinematicsResponse, error)
+	// DoCommand sends and receives arbitrary commands.
+	DoCommand(ctx context.Context, in *v1.DoCommandRequest, opts ...grpc.CallOption) (*v1.DoCommandResponse, error)
+}
+
+type gimbalServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGimbalServiceClient(cc grpc.ClientConnInterface) GimbalServiceClient {
+	return &gimbalServiceClient{cc}
+}
+
+func (c *gimbalServiceClient) GetAngles(ctx context.Context, in *GetAnglesRequest, opts ...grpc.CallOption) (*GetAnglesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAnglesResponse)
+	err := c.cc.Invoke(ctx, GimbalService_GetAngles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gimbalServiceClient) SetTarget(ctx context.Context, in *SetTargetRequest, opts ...grpc.CallOption) (*SetTargetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetTargetResponse)
+	err := c.cc.Invoke(ctx, GimbalService_SetTarget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gimbalServiceClient) GetTarget(ctx context.Context, in *GetTargetRequest, opts ...grpc.CallOption) (*GetTargetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTargetResponse)
+	err := c.cc.Invoke(ctx, GimbalService_GetTarget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gimbalServiceClient) SetStabilization(ctx context.Context, in *SetStabilizationRequest, opts ...grpc.CallOption) (*SetStabilizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetStabilizationResponse)
+	err := c.cc.Invoke(ctx, GimbalService_SetStabilization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gimbalServiceClient) IsStabilizing(ctx context.Context, in *IsStabilizingRequest, opts ...grpc.CallOption) (*IsStabilizingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IsStabilizingResponse)
+	err := c.cc.Invoke(ctx, GimbalService_IsStabilizing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gimbalServiceClient) TrackDetection(ctx context.Context, in *TrackDetectionRequest, opts ...grpc.CallOption) (*TrackDetectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrackDetectionResponse)
+	err := c.cc.Invoke(ctx, GimbalService_TrackDetection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gimbalServiceClient) GetInputs(ctx context.Context, in *GetInputsRequest, opts ...grpc.CallOption) (*GetInputsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInputsResponse)
+	err := c.cc.Invoke(ctx, GimbalService_GetInputs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gimbalServiceClient) GoToInputs(ctx context.Context, in *GoToInputsRequest, opts ...grpc.CallOption) (*GoToInputsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GoToInputsResponse)
+	err := c.cc.Invoke(ctx, GimbalService_GoToInputs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gimbalServiceClient) Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StopResponse)
+	err := c.cc.Invoke(ctx, GimbalService_Stop_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gimbalServiceClient) IsMoving(ctx context.Context, in *IsMovingRequest, opts ...grpc.CallOption) (*IsMovingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IsMovingResponse)
+	err := c.cc.Invoke(ctx, GimbalService_IsMoving_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gimbalServiceClient) GetKinematics(ctx context.Context, in *v1.GetKinematicsRequest, opts ...grpc.CallOption) (*v1.GetKinematicsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(v1.GetKinematicsResponse)
+	err := c.cc.Invoke(ctx, GimbalService_GetKinematics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gimbalServiceClient) DoCommand(ctx context.Context, in *v1.DoCommandRequest, opts ...grpc.CallOption) (*v1.DoCommandResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(v1.DoCommandResponse)
+	err := c.cc.Invoke(ctx, GimbalService_DoCommand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GimbalServiceServer is the server API for GimbalService service.
+// All implementations must embed UnimplementedGimbalServiceServer
+// for forward compatibility.
+//
+// GimbalService points cameras using the gimbals of a machine.
+type GimbalServiceServer interface {
+	// GetAngles returns the current angles of the gimbal's axes relative to its mount.
+	GetAngles(context.Context, *GetAnglesRequest) (*GetAnglesResponse, error)
+	// SetTarget sets the angles the camera should point at and stops tracking any detection.
+	SetTarget(context.Context, *SetTargetRequest) (*SetTargetResponse, error)
+	// GetTarget returns the angles the camera is pointed at.
+	GetTarget(context.Context, *GetTargetRequest) (*GetTargetResponse, error)
+	// SetStabilization turns stabilization on or off.
+	SetStabilization(context.Context, *SetStabilizationRequest) (*SetStabilizationResponse, error)
+	// IsStabilizing returns whether the gimbal is stabilizing.
+	IsStabilizing(context.Context, *IsStabilizingRequest) (*IsStabilizingResponse, error)
+	// TrackDetection keeps the highest scoring detection with the given label in the middle of the
+	// camera's view.
+	TrackDetection(context.Context, *TrackDetectionRequest) (*TrackDetectionResponse, error)
+	// GetInputs returns the angles of the gimbal's axes in radians, as its inputs in the frame
+	// system.
+	GetInputs(context.Context, *GetInputsRequest) (*GetInputsResponse, error)
+	// GoToInputs moves the gimbal's axes through each of the given inputs in turn.
+	GoToInputs(context.Context, *GoToInputsRequest) (*GoToInputsResponse, error)
+	// Stop stops tracking and stabilizing and stops the axes where they are.
+	Stop(context.Context, *StopRequest) (*StopResponse, error)
+	// IsMoving returns whether the gimbal is moving.
+	IsMoving(context.Context, *IsMovingRequest) (*IsMovingResponse, error)
+	// GetKinematics returns the kinematics file for the gimbal.
+	GetKinematics(context.Context, *v1.GetKinematicsRequest) (*v1.GetKinematicsResponse, error)
+	// DoCommand sends and receives arbitrary commands.
+	DoCommand(context.Context, *v1.DoCommandRequest) (*v1.DoCommandResponse, error)
+	mustEmbedUnimplementedGimbalServiceServer()
+}
+
+// UnimplementedGimbalServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGimbalServiceServer struct{}
+
+func (UnimplementedGimbalServiceServer) GetAngles(context.Context, *GetAnglesRequest) (*GetAnglesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAngles not implemented")
+}
+func (UnimplementedGimbalServiceServer) SetTarget(context.Context, *SetTargetRequest) (*SetTargetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTarget not implemented")
+}
+func (UnimplementedGimbalServiceServer) GetTarget(context.Context, *GetTargetRequest) (*GetTargetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTarget not implemented")
+}
+func (UnimplementedGimbalServiceServer) SetStabilization(context.Context, *SetStabilizationRequest) (*SetStabilizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetStabilization not implemented")
+}
+func (UnimplementedGimbalServiceServer) IsStabilizing(context.Context, *IsStabilizingRequest) (*IsStabilizingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsStabilizing not implemented")
+}
+func (UnimplementedGimbalServiceServer) TrackDetection(context.Context, *TrackDetectionRequest) (*TrackDetectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TrackDetection not implemented")
+}
+func (UnimplementedGimbalServiceServer) GetInputs(context.Context, *GetInputsRequest) (*GetInputsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInputs not implemented")
+}
+func (UnimplementedGimbalServiceServer) GoToInputs(context.Context, *GoToInputsRequest) (*GoToInputsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GoToInputs not implemented")
+}
+func (UnimplementedGimbalServiceServer) Stop(context.Context, *StopRequest) (*StopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
+}
+func (UnimplementedGimbalServiceServer) IsMoving(context.Context, *IsMovingRequest) (*IsMovingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsMoving not implemented")
+}
+func (UnimplementedGimbalServiceServer) GetKinematics(context.Context, *v1.GetKinematicsRequest) (*v1.GetKinematicsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKinematics not implemented")
+}
+func (UnimplementedGimbalServiceServer) DoCommand(context.Context, *v1.DoCommandRequest) (*v1.DoCommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DoCommand not implemented")
+}
+func (UnimplementedGimbalServiceServer) mustEmbedUnimplementedGimbalServiceServer() {}
+func (UnimplementedGimbalServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeGimbalServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GimbalServiceServer will
+// result in compilation errors.
+type UnsafeGimbalServiceServer interface {
+	mustEmbedUnimplementedGimbalServiceServer()
+}
+
+func RegisterGimbalServiceServer(s grpc.ServiceRegistrar, srv GimbalServiceServer) {
+	// If the following call pancis, it indicates UnimplementedGimbalServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GimbalService_ServiceDesc, srv)
+}
+
+func _GimbalService_GetAngles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAnglesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GimbalServiceServer).GetAngles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GimbalService_GetAngles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GimbalServiceServer).GetAngles(ctx, req.(*GetAnglesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GimbalService_SetTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GimbalServiceServer).SetTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GimbalService_SetTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GimbalServiceServer).SetTarget(ctx, req.(*SetTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GimbalService_GetTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GimbalServiceServer).GetTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GimbalService_GetTarget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GimbalServiceServer).GetTarget(ctx, req.(*GetTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GimbalService_SetStabilization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStabilizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GimbalServiceServer).SetStabilization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GimbalService_SetStabilization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GimbalServiceServer).SetStabilization(ctx, req.(*SetStabilizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GimbalService_IsStabilizing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsStabilizingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GimbalServiceServer).IsStabilizing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GimbalService_IsStabilizing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GimbalServiceServer).IsStabilizing(ctx, req.(*IsStabilizingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GimbalService_TrackDetection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrackDetectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GimbalServiceServer).TrackDetection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GimbalService_TrackDetection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GimbalServiceServer).TrackDetection(ctx, req.(*TrackDetectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GimbalService_GetInputs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInputsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GimbalServiceServer).GetInputs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GimbalService_GetInputs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GimbalServiceServer).GetInputs(ctx, req.(*GetInputsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GimbalService_GoToInputs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GoToInputsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GimbalServiceServer).GoToInputs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GimbalService_GoToInputs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GimbalServiceServer).GoToInputs(ctx, req.(*GoToInputsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GimbalService_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GimbalServiceServer).Stop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GimbalService_Stop_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GimbalServiceServer).Stop(ctx, req.(*StopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GimbalService_IsMoving_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsMovingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GimbalServiceServer).IsMoving(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GimbalService_IsMoving_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GimbalServiceServer).IsMoving(ctx, req.(*IsMovingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GimbalService_GetKinematics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1.GetKinematicsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GimbalServiceServer).GetKinematics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GimbalService_GetKinematics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GimbalServiceServer).GetKinematics(ctx, req.(*v1.GetKinematicsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GimbalService_DoCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(v1.DoCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GimbalServiceServer).DoCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GimbalService_DoCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GimbalServiceServer).DoCommand(ctx, req.(*v1.DoCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GimbalService_ServiceDesc is the grpc.ServiceDesc for GimbalService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GimbalService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rdk.component.gimbal.v1.GimbalService",
+	HandlerType: (*GimbalServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAngles",
+			Handler:    _GimbalService_GetAngles_Handler,
+		},
+		{
+			MethodName: "SetTarget",
+			Handler:    _GimbalService_SetTarget_Handler,
+		},
+		{
+			MethodName: "GetTarget",
+			Handler:    _GimbalService_GetTarget_Handler,
+		},
+		{
+			MethodName: "SetStabilization",
+			Handler:    _GimbalService_SetStabilization_Handler,
+		},
+		{
+			MethodName: "IsStabilizing",
+			Handler:    _GimbalService_IsStabilizing_Handler,
+		},
+		{
+			MethodName: "TrackDetection",
+			Handler:    _GimbalService_TrackDetection_Handler,
+		},
+		{
+			MethodName: "GetInputs",
+			Handler:    _GimbalService_GetInputs_Handler,
+		},
+		{
+			MethodName: "GoToInputs",
+			Handler:    _GimbalService_GoToInputs_Handler,
+		},
+		{
+			MethodName: "Stop",
+			Handler:    _GimbalService_Stop_Handler,
+		},
+		{
+			MethodName: "IsMoving",
+			Handler:    _GimbalService_IsMoving_Handler,
+		},
+		{
+			MethodName: "GetKinematics",
+			Handler:    _GimbalService_GetKinematics_Handler,
+		},
+		{
+			MethodName: "DoCommand",
+			Handler:    _GimbalService_DoCommand_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rdk/component/gimbal/v1/gimbal.proto",
+}
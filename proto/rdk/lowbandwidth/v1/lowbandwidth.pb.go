@@ -0,0 +1,264 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rdk/lowbandwidth/v1/lowbandwidth.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetResourceNamesDeltaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The token of the last delta the client received, or empty for every name.
+	Since         string `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResourceNamesDeltaRequest) Reset() {
+	*x = GetResourceNamesDeltaRequest{}
+	mi := &file_rdk_lowbandwidth_v1_lowbandwidth_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResourceNamesDeltaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResourceNamesDeltaRequest) ProtoMessage() {}
+
+func (x *GetResourceNamesDeltaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_lowbandwidth_v1_lowbandwidth_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResourceNamesDeltaRequest.ProtoReflect.Descriptor instead.
+func (*GetResourceNamesDeltaRequest) Descriptor() ([]byte, []int) {
+	return file_rdk_lowbandwidth_v1_lowbandwidth_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetResourceNamesDeltaRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+type GetResourceNamesDeltaResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A CBOR encoded ResourceNamesDelta.
+	Delta         []byte `protobuf:"bytes,1,opt,name=delta,proto3" json:"delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResourceNamesDeltaResponse) Reset() {
+	*x = GetResourceNamesDeltaResponse{}
+	mi := &file_rdk_lowbandwidth_v1_lowbandwidth_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResourceNamesDeltaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResourceNamesDeltaResponse) ProtoMessage() {}
+
+func (x *GetResourceNamesDeltaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_lowbandwidth_v1_lowbandwidth_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResourceNamesDeltaResponse.ProtoReflect.Descriptor instead.
+func (*GetResourceNamesDeltaResponse) Descriptor() ([]byte, []int) {
+	return file_rdk_lowbandwidth_v1_lowbandwidth_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetResourceNamesDeltaResponse) GetDelta() []byte {
+	if x != nil {
+		return x.Delta
+	}
+	return nil
+}
+
+type GetCompactMachineStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCompactMachineStatusRequest) Reset() {
+	*x = GetCompactMachineStatusRequest{}
+	mi := &file_rdk_lowbandwidth_v1_lowbandwidth_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCompactMachineStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCompactMachineStatusRequest) ProtoMessage() {}
+
+func (x *GetCompactMachineStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_lowbandwidth_v1_lowbandwidth_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCompactMachineStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetCompactMachineStatusRequest) Descriptor() ([]byte, []int) {
+	return file_rdk_lowbandwidth_v1_lowbandwidth_proto_rawDescGZIP(), []int{2}
+}
+
+type GetCompactMachineStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A CBOR encoded CompactMachineStatus.
+	Status        []byte `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCompactMachineStatusResponse) Reset() {
+	*x = GetCompactMachineStatusResponse{}
+	mi := &file_rdk_lowbandwidth_v1_lowbandwidth_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCompactMachineStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCompactMachineStatusResponse) ProtoMessage() {}
+
+func (x *GetCompactMachineStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_lowbandwidth_v1_lowbandwidth_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCompactMachineStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetCompactMachineStatusResponse) Descriptor() ([]byte, []int) {
+	return file_rdk_lowbandwidth_v1_lowbandwidth_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCompactMachineStatusResponse) GetStatus() []byte {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+var File_rdk_lowbandwidth_v1_lowbandwidth_proto protoreflect.FileDescriptor
+
+const file_rdk_lowbandwidth_v1_lowbandwidth_proto_rawDesc = "" +
+	"\n" +
+	"&rdk/lowbandwidth/v1/lowbandwidth.proto\x12\x13rdk.lowbandwidth.v1\"4\n" +
+	"\x1cGetResourceNamesDeltaRequest\x12\x14\n" +
+	"\x05since\x18\x01 \x01(\tR\x05since\"5\n" +
+	"\x1dGetResourceNamesDeltaResponse\x12\x14\n" +
+	"\x05delta\x18\x01 \x01(\fR\x05delta\" \n" +
+	"\x1eGetCompactMachineStatusRequest\"9\n" +
+	"\x1fGetCompactMachineStatusResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\fR\x06status2\x9c\x02\n" +
+	"\x13LowBandwidthService\x12~\n" +
+	"\x15GetResourceNamesDelta\x121.rdk.lowbandwidth.v1.GetResourceNamesDeltaRequest\x1a2.rdk.lowbandwidth.v1.GetResourceNamesDeltaResponse\x12\x84\x01\n" +
+	"\x17GetCompactMachineStatus\x123.rdk.lowbandwidth.v1.GetCompactMachineStatusRequest\x1a4.rdk.lowbandwidth.v1.GetCompactMachineStatusResponseB+Z)go.viam.com/rdk/proto/rdk/lowbandwidth/v1b\x06proto3"
+
+var (
+	file_rdk_lowbandwidth_v1_lowbandwidth_proto_rawDescOnce sync.Once
+	file_rdk_lowbandwidth_v1_lowbandwidth_proto_rawDescData []byte
+)
+
+func file_rdk_lowbandwidth_v1_lowbandwidth_proto_rawDescGZIP() []byte {
+	file_rdk_lowbandwidth_v1_lowbandwidth_proto_rawDescOnce.Do(func() {
+		file_rdk_lowbandwidth_v1_lowbandwidth_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rdk_lowbandwidth_v1_lowbandwidth_proto_rawDesc), len(file_rdk_lowbandwidth_v1_lowbandwidth_proto_rawDesc)))
+	})
+	return file_rdk_lowbandwidth_v1_lowbandwidth_proto_rawDescData
+}
+
+var file_rdk_lowbandwidth_v1_lowbandwidth_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_rdk_lowbandwidth_v1_lowbandwidth_proto_goTypes = []any{
+	(*GetResourceNamesDeltaRequest)(nil),    // 0: rdk.lowbandwidth.v1.GetResourceNamesDeltaRequest
+	(*GetResourceNamesDeltaResponse)(nil),   // 1: rdk.lowbandwidth.v1.GetResourceNamesDeltaResponse
+	(*GetCompactMachineStatusRequest)(nil),  // 2: rdk.lowbandwidth.v1.GetCompactMachineStatusRequest
+	(*GetCompactMachineStatusResponse)(nil), // 3: rdk.lowbandwidth.v1.GetCompactMachineStatusResponse
+}
+var file_rdk_lowbandwidth_v1_lowbandwidth_proto_depIdxs = []int32{
+	0, // 0: rdk.lowbandwidth.v1.LowBandwidthService.GetResourceNamesDelta:input_type -> rdk.lowbandwidth.v1.GetResourceNamesDeltaRequest
+	2, // 1: rdk.lowbandwidth.v1.LowBandwidthService.GetCompactMachineStatus:input_type -> rdk.lowbandwidth.v1.GetCompactMachineStatusRequest
+	1, // 2: rdk.lowbandwidth.v1.LowBandwidthService.GetResourceNamesDelta:output_type -> rdk.lowbandwidth.v1.GetResourceNamesDeltaResponse
+	3, // 3: rdk.lowbandwidth.v1.LowBandwidthService.GetCompactMachineStatus:output_type -> rdk.lowbandwidth.v1.GetCompactMachineStatusResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rdk_lowbandwidth_v1_lowbandwidth_proto_init() }
+func file_rdk_lowbandwidth_v1_lowbandwidth_proto_init() {
+	if File_rdk_lowbandwidth_v1_lowbandwidth_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rdk_lowbandwidth_v1_lowbandwidth_proto_rawDesc), len(file_rdk_lowbandwidth_v1_lowbandwidth_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rdk_lowbandwidth_v1_lowbandwidth_proto_goTypes,
+		DependencyIndexes: file_rdk_lowbandwidth_v1_lowbandwidth_proto_depIdxs,
+		MessageInfos:      file_rdk_lowbandwidth_v1_lowbandwidth_proto_msgTypes,
+	}.Build()
+	File_rdk_lowbandwidth_v1_lowbandwidth_proto = out.File
+	file_rdk_lowbandwidth_v1_lowbandwidth_proto_goTypes = nil
+	file_rdk_lowbandwidth_v1_lowbandwidth_proto_depIdxs = nil
+}
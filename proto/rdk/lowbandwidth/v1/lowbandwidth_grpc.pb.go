@@ -0,0 +1,175 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: rdk/lowbandwidth/v1/lowbandwidth.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	LowBandwidthService_GetResourceNamesDelta_FullMethodName   = "/rdk.lowbandwidth.v1.LowBandwidthService/GetResourceNamesDelta"
+	LowBandwidthService_GetCompactMachineStatus_FullMethodName = "/rdk.lowbandwidth.v1.LowBandwidthService/GetCompactMachineStatus"
+)
+
+// LowBandwidthServiceClient is the client API for LowBandwidthService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// LowBandwidthService is used by clients on constrained links, such as remotes on LTE-M, in place
+// of the robot service to learn how a machine has changed. Its responses carry CBOR rather than
+// protobuf messages with every field set.
+type LowBandwidthServiceClient interface {
+	// GetResourceNamesDelta returns how the resource names of the machine changed since the names
+	// the client last received, so that unchanged names are not sent again.
+	GetResourceNamesDelta(ctx context.Context, in *GetResourceNamesDeltaRequest, opts ...grpc.CallOption) (*GetResourceNamesDeltaResponse, error)
+	// GetCompactMachineStatus returns only what a remote's parent needs to know of the status of
+	// the machine.
+	GetCompactMachineStatus(ctx context.Context, in *GetCompactMachineStatusRequest, opts ...grpc.CallOption) (*GetCompactMachineStatusResponse, error)
+}
+
+type lowBandwidthServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLowBandwidthServiceClient(cc grpc.ClientConnInterface) LowBandwidthServiceClient {
+	return &lowBandwidthServiceClient{cc}
+}
+
+func (c *lowBandwidthServiceClient) GetResourceNamesDelta(ctx context.Context, in *GetResourceNamesDeltaRequest, opts ...grpc.CallOption) (*GetResourceNamesDeltaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetResourceNamesDeltaResponse)
+	err := c.cc.Invoke(ctx, LowBandwidthService_GetResourceNamesDelta_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lowBandwidthServiceClient) GetCompactMachineStatus(ctx context.Context, in *GetCompactMachineStatusRequest, opts ...grpc.CallOption) (*GetCompactMachineStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCompactMachineStatusResponse)
+	err := c.cc.Invoke(ctx, LowBandwidthService_GetCompactMachineStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LowBandwidthServiceServer is the server API for LowBandwidthService service.
+// All implementations must embed UnimplementedLowBandwidthServiceServer
+// for forward compatibility.
+//
+// LowBandwidthService is used by clients on constrained links, such as remotes on LTE-M, in place
+// of the robot service to learn how a machine has changed. Its responses carry CBOR rather than
+// protobuf messages with every field set.
+type LowBandwidthServiceServer interface {
+	// GetResourceNamesDelta returns how the resource names of the machine changed since the names
+	// the client last received, so that unchanged names are not sent again.
+	GetResourceNamesDelta(context.Context, *GetResourceNamesDeltaRequest) (*GetResourceNamesDeltaResponse, error)
+	// GetCompactMachineStatus returns only what a remote's parent needs to know of the status of
+	// the machine.
+	GetCompactMachineStatus(context.Context, *GetCompactMachineStatusRequest) (*GetCompactMachineStatusResponse, error)
+	mustEmbedUnimplementedLowBandwidthServiceServer()
+}
+
+// UnimplementedLowBandwidthServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLowBandwidthServiceServer struct{}
+
+func (UnimplementedLowBandwidthServiceServer) GetResourceNamesDelta(context.Context, *GetResourceNamesDeltaRequest) (*GetResourceNamesDeltaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResourceNamesDelta not implemented")
+}
+func (UnimplementedLowBandwidthServiceServer) GetCompactMachineStatus(context.Context, *GetCompactMachineStatusRequest) (*GetCompactMachineStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCompactMachineStatus not implemented")
+}
+func (UnimplementedLowBandwidthServiceServer) mustEmbedUnimplementedLowBandwidthServiceServer() {}
+func (UnimplementedLowBandwidthServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeLowBandwidthServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LowBandwidthServiceServer will
+// result in compilation errors.
+type UnsafeLowBandwidthServiceServer interface {
+	mustEmbedUnimplementedLowBandwidthServiceServer()
+}
+
+func RegisterLowBandwidthServiceServer(s grpc.ServiceRegistrar, srv LowBandwidthServiceServer) {
+	// If the following call pancis, it indicates UnimplementedLowBandwidthServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LowBandwidthService_ServiceDesc, srv)
+}
+
+func _LowBandwidthService_GetResourceNamesDelta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResourceNamesDeltaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LowBandwidthServiceServer).GetResourceNamesDelta(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LowBandwidthService_GetResourceNamesDelta_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LowBandwidthServiceServer).GetResourceNamesDelta(ctx, req.(*GetResourceNamesDeltaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LowBandwidthService_GetCompactMachineStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCompactMachineStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LowBandwidthServiceServer).GetCompactMachineStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LowBandwidthService_GetCompactMachineStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LowBandwidthServiceServer).GetCompactMachineStatus(ctx, req.(*GetCompactMachineStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LowBandwidthService_ServiceDesc is the grpc.ServiceDesc for LowBandwidthService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LowBandwidthService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rdk.lowbandwidth.v1.LowBandwidthService",
+	HandlerType: (*LowBandwidthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetResourceNamesDelta",
+			Handler:    _LowBandwidthService_GetResourceNamesDelta_Handler,
+		},
+		{
+			MethodName: "GetCompactMachineStatus",
+			Handler:    _LowBandwidthService_GetCompactMachineStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rdk/lowbandwidth/v1/lowbandwidth.proto",
+}
@@ -0,0 +1,155 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rdk/provisioning/v1/provisioning.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EnterProvisioningRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnterProvisioningRequest) Reset() {
+	*x = EnterProvisioningRequest{}
+	mi := &file_rdk_provisioning_v1_provisioning_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnterProvisioningRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnterProvisioningRequest) ProtoMessage() {}
+
+func (x *EnterProvisioningRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_provisioning_v1_provisioning_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnterProvisioningRequest.ProtoReflect.Descriptor instead.
+func (*EnterProvisioningRequest) Descriptor() ([]byte, []int) {
+	return file_rdk_provisioning_v1_provisioning_proto_rawDescGZIP(), []int{0}
+}
+
+type EnterProvisioningResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnterProvisioningResponse) Reset() {
+	*x = EnterProvisioningResponse{}
+	mi := &file_rdk_provisioning_v1_provisioning_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnterProvisioningResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnterProvisioningResponse) ProtoMessage() {}
+
+func (x *EnterProvisioningResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_provisioning_v1_provisioning_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnterProvisioningResponse.ProtoReflect.Descriptor instead.
+func (*EnterProvisioningResponse) Descriptor() ([]byte, []int) {
+	return file_rdk_provisioning_v1_provisioning_proto_rawDescGZIP(), []int{1}
+}
+
+var File_rdk_provisioning_v1_provisioning_proto protoreflect.FileDescriptor
+
+const file_rdk_provisioning_v1_provisioning_proto_rawDesc = "" +
+	"\n" +
+	"&rdk/provisioning/v1/provisioning.proto\x12\x13rdk.provisioning.v1\"\x1a\n" +
+	"\x18EnterProvisioningRequest\"\x1b\n" +
+	"\x19EnterProvisioningResponse2\x8d\x01\n" +
+	"\x17ProvisioningModeService\x12r\n" +
+	"\x11EnterProvisioning\x12-.rdk.provisioning.v1.EnterProvisioningRequest\x1a..rdk.provisioning.v1.EnterProvisioningResponseB+Z)go.viam.com/rdk/proto/rdk/provisioning/v1b\x06proto3"
+
+var (
+	file_rdk_provisioning_v1_provisioning_proto_rawDescOnce sync.Once
+	file_rdk_provisioning_v1_provisioning_proto_rawDescData []byte
+)
+
+func file_rdk_provisioning_v1_provisioning_proto_rawDescGZIP() []byte {
+	file_rdk_provisioning_v1_provisioning_proto_rawDescOnce.Do(func() {
+		file_rdk_provisioning_v1_provisioning_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rdk_provisioning_v1_provisioning_proto_rawDesc), len(file_rdk_provisioning_v1_provisioning_proto_rawDesc)))
+	})
+	return file_rdk_provisioning_v1_provisioning_proto_rawDescData
+}
+
+var file_rdk_provisioning_v1_provisioning_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rdk_provisioning_v1_provisioning_proto_goTypes = []any{
+	(*EnterProvisioningRequest)(nil),  // 0: rdk.provisioning.v1.EnterProvisioningRequest
+	(*EnterProvisioningResponse)(nil), // 1: rdk.provisioning.v1.EnterProvisioningResponse
+}
+var file_rdk_provisioning_v1_provisioning_proto_depIdxs = []int32{
+	0, // 0: rdk.provisioning.v1.ProvisioningModeService.EnterProvisioning:input_type -> rdk.provisioning.v1.EnterProvisioningRequest
+	1, // 1: rdk.provisioning.v1.ProvisioningModeService.EnterProvisioning:output_type -> rdk.provisioning.v1.EnterProvisioningResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rdk_provisioning_v1_provisioning_proto_init() }
+func file_rdk_provisioning_v1_provisioning_proto_init() {
+	if File_rdk_provisioning_v1_provisioning_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rdk_provisioning_v1_provisioning_proto_rawDesc), len(file_rdk_provisioning_v1_provisioning_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rdk_provisioning_v1_provisioning_proto_goTypes,
+		DependencyIndexes: file_rdk_provisioning_v1_provisioning_proto_depIdxs,
+		MessageInfos:      file_rdk_provisioning_v1_provisioning_proto_msgTypes,
+	}.Build()
+	File_rdk_provisioning_v1_provisioning_proto = out.File
+	file_rdk_provisioning_v1_provisioning_proto_goTypes = nil
+	file_rdk_provisioning_v1_provisioning_proto_depIdxs = nil
+}
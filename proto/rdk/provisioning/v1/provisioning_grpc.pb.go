@@ -0,0 +1,134 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: rdk/provisioning/v1/provisioning.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ProvisioningModeService_EnterProvisioning_FullMethodName = "/rdk.provisioning.v1.ProvisioningModeService/EnterProvisioning"
+)
+
+// ProvisioningModeServiceClient is the client API for ProvisioningModeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ProvisioningModeService is served alongside the provisioning API by machines that set
+// themselves up, for the calls the provisioning API has no counterpart for.
+type ProvisioningModeServiceClient interface {
+	// EnterProvisioning puts a running machine back into provisioning mode, in which it runs a
+	// hotspot to be set up through. It returns once provisioning mode has been asked for, before
+	// the hotspot is up.
+	EnterProvisioning(ctx context.Context, in *EnterProvisioningRequest, opts ...grpc.CallOption) (*EnterProvisioningResponse, error)
+}
+
+type provisioningModeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProvisioningModeServiceClient(cc grpc.ClientConnInterface) ProvisioningModeServiceClient {
+	return &provisioningModeServiceClient{cc}
+}
+
+func (c *provisioningModeServiceClient) EnterProvisioning(ctx context.Context, in *EnterProvisioningRequest, opts ...grpc.CallOption) (*EnterProvisioningResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnterProvisioningResponse)
+	err := c.cc.Invoke(ctx, ProvisioningModeService_EnterProvisioning_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProvisioningModeServiceServer is the server API for ProvisioningModeService service.
+// All implementations must embed UnimplementedProvisioningModeServiceServer
+// for forward compatibility.
+//
+// ProvisioningModeService is served alongside the provisioning API by machines that set
+// themselves up, for the calls the provisioning API has no counterpart for.
+type ProvisioningModeServiceServer interface {
+	// EnterProvisioning puts a running machine back into provisioning mode, in which it runs a
+	// hotspot to be set up through. It returns once provisioning mode has been asked for, before
+	// the hotspot is up.
+	EnterProvisioning(context.Context, *EnterProvisioningRequest) (*EnterProvisioningResponse, error)
+	mustEmbedUnimplementedProvisioningModeServiceServer()
+}
+
+// UnimplementedProvisioningModeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedProvisioningModeServiceServer struct{}
+
+func (UnimplementedProvisioningModeServiceServer) EnterProvisioning(context.Context, *EnterProvisioningRequest) (*EnterProvisioningResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnterProvisioning not implemented")
+}
+func (UnimplementedProvisioningModeServiceServer) mustEmbedUnimplementedProvisioningModeServiceServer() {
+}
+func (UnimplementedProvisioningModeServiceServer) testEmbeddedByValue() {}
+
+// UnsafeProvisioningModeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProvisioningModeServiceServer will
+// result in compilation errors.
+type UnsafeProvisioningModeServiceServer interface {
+	mustEmbedUnimplementedProvisioningModeServiceServer()
+}
+
+func RegisterProvisioningModeServiceServer(s grpc.ServiceRegistrar, srv ProvisioningModeServiceServer) {
+	// If the following call pancis, it indicates UnimplementedProvisioningModeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ProvisioningModeService_ServiceDesc, srv)
+}
+
+func _ProvisioningModeService_EnterProvisioning_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnterProvisioningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisioningModeServiceServer).EnterProvisioning(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProvisioningModeService_EnterProvisioning_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisioningModeServiceServer).EnterProvisioning(ctx, req.(*EnterProvisioningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ProvisioningModeService_ServiceDesc is the grpc.ServiceDesc for ProvisioningModeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProvisioningModeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rdk.provisioning.v1.ProvisioningModeService",
+	HandlerType: (*ProvisioningModeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "EnterProvisioning",
+			Handler:    _ProvisioningModeService_EnterProvisioning_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rdk/provisioning/v1/provisioning.proto",
+}
@@ -0,0 +1,259 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rdk/resourcechanges/v1/resourcechanges.proto
+
+package v1
+
+import (
+	v1 "go.viam.com/api/app/v1"
+	v11 "go.viam.com/api/common/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ApplyResourceChangesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The components and services to add. Services are sent as component configs, as they are to
+	// modules.
+	Adds          []*v1.ComponentConfig `protobuf:"bytes,1,rep,name=adds,proto3" json:"adds,omitempty"`
+	Removes       []*v11.ResourceName   `protobuf:"bytes,2,rep,name=removes,proto3" json:"removes,omitempty"`
+	Updates       []*v1.ComponentConfig `protobuf:"bytes,3,rep,name=updates,proto3" json:"updates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyResourceChangesRequest) Reset() {
+	*x = ApplyResourceChangesRequest{}
+	mi := &file_rdk_resourcechanges_v1_resourcechanges_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyResourceChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyResourceChangesRequest) ProtoMessage() {}
+
+func (x *ApplyResourceChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_resourcechanges_v1_resourcechanges_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyResourceChangesRequest.ProtoReflect.Descriptor instead.
+func (*ApplyResourceChangesRequest) Descriptor() ([]byte, []int) {
+	return file_rdk_resourcechanges_v1_resourcechanges_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ApplyResourceChangesRequest) GetAdds() []*v1.ComponentConfig {
+	if x != nil {
+		return x.Adds
+	}
+	return nil
+}
+
+func (x *ApplyResourceChangesRequest) GetRemoves() []*v11.ResourceName {
+	if x != nil {
+		return x.Removes
+	}
+	return nil
+}
+
+func (x *ApplyResourceChangesRequest) GetUpdates() []*v1.ComponentConfig {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
+
+type ApplyResourceChangesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The added and updated resources that could not be built or reconfigured.
+	Errors        []*ResourceError `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyResourceChangesResponse) Reset() {
+	*x = ApplyResourceChangesResponse{}
+	mi := &file_rdk_resourcechanges_v1_resourcechanges_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyResourceChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyResourceChangesResponse) ProtoMessage() {}
+
+func (x *ApplyResourceChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_resourcechanges_v1_resourcechanges_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyResourceChangesResponse.ProtoReflect.Descriptor instead.
+func (*ApplyResourceChangesResponse) Descriptor() ([]byte, []int) {
+	return file_rdk_resourcechanges_v1_resourcechanges_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ApplyResourceChangesResponse) GetErrors() []*ResourceError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ResourceError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          *v11.ResourceName      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceError) Reset() {
+	*x = ResourceError{}
+	mi := &file_rdk_resourcechanges_v1_resourcechanges_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceError) ProtoMessage() {}
+
+func (x *ResourceError) ProtoReflect() protoreflect.Message {
+	mi := &file_rdk_resourcechanges_v1_resourcechanges_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceError.ProtoReflect.Descriptor instead.
+func (*ResourceError) Descriptor() ([]byte, []int) {
+	return file_rdk_resourcechanges_v1_resourcechanges_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ResourceError) GetName() *v11.ResourceName {
+	if x != nil {
+		return x.Name
+	}
+	return nil
+}
+
+func (x *ResourceError) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_rdk_resourcechanges_v1_resourcechanges_proto protoreflect.FileDescriptor
+
+const file_rdk_resourcechanges_v1_resourcechanges_proto_rawDesc = "" +
+	"\n" +
+	",rdk/resourcechanges/v1/resourcechanges.proto\x12\x16rdk.resourcechanges.v1\x1a\x12app/v1/robot.proto\x1a\x16common/v1/common.proto\"\xbf\x01\n" +
+	"\x1bApplyResourceChangesRequest\x120\n" +
+	"\x04adds\x18\x01 \x03(\v2\x1c.viam.app.v1.ComponentConfigR\x04adds\x126\n" +
+	"\aremoves\x18\x02 \x03(\v2\x1c.viam.common.v1.ResourceNameR\aremoves\x126\n" +
+	"\aupdates\x18\x03 \x03(\v2\x1c.viam.app.v1.ComponentConfigR\aupdates\"]\n" +
+	"\x1cApplyResourceChangesResponse\x12=\n" +
+	"\x06errors\x18\x01 \x03(\v2%.rdk.resourcechanges.v1.ResourceErrorR\x06errors\"W\n" +
+	"\rResourceError\x120\n" +
+	"\x04name\x18\x01 \x01(\v2\x1c.viam.common.v1.ResourceNameR\x04name\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error2\x9c\x01\n" +
+	"\x16ResourceChangesService\x12\x81\x01\n" +
+	"\x14ApplyResourceChanges\x123.rdk.resourcechanges.v1.ApplyResourceChangesRequest\x1a4.rdk.resourcechanges.v1.ApplyResourceChangesResponseB.Z,go.viam.com/rdk/proto/rdk/resourcechanges/v1b\x06proto3"
+
+var (
+	file_rdk_resourcechanges_v1_resourcechanges_proto_rawDescOnce sync.Once
+	file_rdk_resourcechanges_v1_resourcechanges_proto_rawDescData []byte
+)
+
+func file_rdk_resourcechanges_v1_resourcechanges_proto_rawDescGZIP() []byte {
+	file_rdk_resourcechanges_v1_resourcechanges_proto_rawDescOnce.Do(func() {
+		file_rdk_resourcechanges_v1_resourcechanges_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rdk_resourcechanges_v1_resourcechanges_proto_rawDesc), len(file_rdk_resourcechanges_v1_resourcechanges_proto_rawDesc)))
+	})
+	return file_rdk_resourcechanges_v1_resourcechanges_proto_rawDescData
+}
+
+var file_rdk_resourcechanges_v1_resourcechanges_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_rdk_resourcechanges_v1_resourcechanges_proto_goTypes = []any{
+	(*ApplyResourceChangesRequest)(nil),  // 0: rdk.resourcechanges.v1.ApplyResourceChangesRequest
+	(*ApplyResourceChangesResponse)(nil), // 1: rdk.resourcechanges.v1.ApplyResourceChangesResponse
+	(*ResourceError)(nil),                // 2: rdk.resourcechanges.v1.ResourceError
+	(*v1.ComponentConfig)(nil),           // 3: viam.app.v1.ComponentConfig
+	(*v11.ResourceName)(nil),             // 4: viam.common.v1.ResourceName
+}
+var file_rdk_resourcechanges_v1_resourcechanges_proto_depIdxs = []int32{
+	3, // 0: rdk.resourcechanges.v1.ApplyResourceChangesRequest.adds:type_name -> viam.app.v1.ComponentConfig
+	4, // 1: rdk.resourcechanges.v1.ApplyResourceChangesRequest.removes:type_name -> viam.common.v1.ResourceName
+	3, // 2: rdk.resourcechanges.v1.ApplyResourceChangesRequest.updates:type_name -> viam.app.v1.ComponentConfig
+	2, // 3: rdk.resourcechanges.v1.ApplyResourceChangesResponse.errors:type_name -> rdk.resourcechanges.v1.ResourceError
+	4, // 4: rdk.resourcechanges.v1.ResourceError.name:type_name -> viam.common.v1.ResourceName
+	0, // 5: rdk.resourcechanges.v1.ResourceChangesService.ApplyResourceChanges:input_type -> rdk.resourcechanges.v1.ApplyResourceChangesRequest
+	1, // 6: rdk.resourcechanges.v1.ResourceChangesService.ApplyResourceChanges:output_type -> rdk.resourcechanges.v1.ApplyResourceChangesResponse
+	6, // [6:7] is the sub-list for method output_type
+	5, // [5:6] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_rdk_resourcechanges_v1_resourcechanges_proto_init() }
+func file_rdk_resourcechanges_v1_resourcechanges_proto_init() {
+	if File_rdk_resourcechanges_v1_resourcechanges_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rdk_resourcechanges_v1_resourcechanges_proto_rawDesc), len(file_rdk_resourcechanges_v1_resourcechanges_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rdk_resourcechanges_v1_resourcechanges_proto_goTypes,
+		DependencyIndexes: file_rdk_resourcechanges_v1_resourcechanges_proto_depIdxs,
+		MessageInfos:      file_rdk_resourcechanges_v1_resourcechanges_proto_msgTypes,
+	}.Build()
+	File_rdk_resourcechanges_v1_resourcechanges_proto = out.File
+	file_rdk_resourcechanges_v1_resourcechanges_proto_goTypes = nil
+	file_rdk_resourcechanges_v1_resourcechanges_proto_depIdxs = nil
+}
@@ -0,0 +1,134 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: rdk/resourcechanges/v1/resourcechanges.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ResourceChangesService_ApplyResourceChanges_FullMethodName = "/rdk.resourcechanges.v1.ResourceChangesService/ApplyResourceChanges"
+)
+
+// ResourceChangesServiceClient is the client API for ResourceChangesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ResourceChangesService applies a batch of resource changes to a machine in one reconfigure,
+// such as for resources a module manages dynamically. It is served to modules by their parent as
+// well as to other clients.
+type ResourceChangesServiceClient interface {
+	// ApplyResourceChanges adds, removes and updates components and services of the machine. Either
+	// every change is applied or, if any of them is invalid, none are.
+	ApplyResourceChanges(ctx context.Context, in *ApplyResourceChangesRequest, opts ...grpc.CallOption) (*ApplyResourceChangesResponse, error)
+}
+
+type resourceChangesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewResourceChangesServiceClient(cc grpc.ClientConnInterface) ResourceChangesServiceClient {
+	return &resourceChangesServiceClient{cc}
+}
+
+func (c *resourceChangesServiceClient) ApplyResourceChanges(ctx context.Context, in *ApplyResourceChangesRequest, opts ...grpc.CallOption) (*ApplyResourceChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyResourceChangesResponse)
+	err := c.cc.Invoke(ctx, ResourceChangesService_ApplyResourceChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ResourceChangesServiceServer is the server API for ResourceChangesService service.
+// All implementations must embed UnimplementedResourceChangesServiceServer
+// for forward compatibility.
+//
+// ResourceChangesService applies a batch of resource changes to a machine in one reconfigure,
+// such as for resources a module manages dynamically. It is served to modules by their parent as
+// well as to other clients.
+type ResourceChangesServiceServer interface {
+	// ApplyResourceChanges adds, removes and updates components and services of the machine. Either
+	// every change is applied or, if any of them is invalid, none are.
+	ApplyResourceChanges(context.Context, *ApplyResourceChangesRequest) (*ApplyResourceChangesResponse, error)
+	mustEmbedUnimplementedResourceChangesServiceServer()
+}
+
+// UnimplementedResourceChangesServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedResourceChangesServiceServer struct{}
+
+func (UnimplementedResourceChangesServiceServer) ApplyResourceChanges(context.Context, *ApplyResourceChangesRequest) (*ApplyResourceChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyResourceChanges not implemented")
+}
+func (UnimplementedResourceChangesServiceServer) mustEmbedUnimplementedResourceChangesServiceServer() {
+}
+func (UnimplementedResourceChangesServiceServer) testEmbeddedByValue() {}
+
+// UnsafeResourceChangesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ResourceChangesServiceServer will
+// result in compilation errors.
+type UnsafeResourceChangesServiceServer interface {
+	mustEmbedUnimplementedResourceChangesServiceServer()
+}
+
+func RegisterResourceChangesServiceServer(s grpc.ServiceRegistrar, srv ResourceChangesServiceServer) {
+	// If the following call pancis, it indicates UnimplementedResourceChangesServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ResourceChangesService_ServiceDesc, srv)
+}
+
+func _ResourceChangesService_ApplyResourceChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyResourceChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceChangesServiceServer).ApplyResourceChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceChangesService_ApplyResourceChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceChangesServiceServer).ApplyResourceChanges(ctx, req.(*ApplyResourceChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ResourceChangesService_ServiceDesc is the grpc.ServiceDesc for ResourceChangesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ResourceChangesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rdk.resourcechanges.v1.ResourceChangesService",
+	HandlerType: (*ResourceChangesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ApplyResourceChanges",
+			Handler:    _ResourceChangesService_ApplyResourceChanges_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rdk/resourcechanges/v1/resourcechanges.proto",
+}
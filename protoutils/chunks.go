@@ -0,0 +1,321 @@
+package protoutils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.viam.com/utils/protoutils"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// DefaultChunkSize is a chunk size for ChunkMap that leaves plenty of room under the
+// maximum gRPC message size for the rest of a message.
+const DefaultChunkSize = 1 << 20
+
+// EstimateStructSize returns the size in bytes of m once converted to a structpb.Struct and
+// encoded, without building the structpb.Struct. Values are converted the same way as by
+// StructToStructPb from go.viam.com/utils/protoutils.
+func EstimateStructSize(m map[string]interface{}) (int, error) {
+	var size int
+	for k, v := range m {
+		vSize, err := EstimateValueSize(v)
+		if err != nil {
+			return 0, errors.Wrapf(err, "key %q", k)
+		}
+		size += mapEntrySize(k, vSize)
+	}
+	return size, nil
+}
+
+// EstimateValueSize returns the size in bytes of v once converted to a structpb.Value and
+// encoded, without building the structpb.Value.
+func EstimateValueSize(v interface{}) (int, error) {
+	// every kind of structpb.Value is a single field with a one byte tag.
+	switch x := v.(type) {
+	case nil, bool:
+		return 2, nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+		return 1 + protowire.SizeFixed64(), nil
+	case string:
+		return 1 + protowire.SizeBytes(len(x)), nil
+	case []byte:
+		return 1 + protowire.SizeBytes(base64.StdEncoding.EncodedLen(len(x))), nil
+	case map[string]interface{}:
+		size, err := EstimateStructSize(x)
+		if err != nil {
+			return 0, err
+		}
+		return 1 + protowire.SizeBytes(size), nil
+	case []interface{}:
+		var size int
+		for i, elem := range x {
+			elemSize, err := EstimateValueSize(elem)
+			if err != nil {
+				return 0, errors.Wrapf(err, "index %d", i)
+			}
+			size += 1 + protowire.SizeBytes(elemSize)
+		}
+		return 1 + protowire.SizeBytes(size), nil
+	case *structpb.Value:
+		return proto.Size(x), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return 2, nil
+		}
+		return EstimateValueSize(rv.Elem().Interface())
+	case reflect.Bool:
+		return 2, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return 1 + protowire.SizeFixed64(), nil
+	case reflect.String:
+		return 1 + protowire.SizeBytes(rv.Len()), nil
+	case reflect.Slice, reflect.Array:
+		var size int
+		for i := 0; i < rv.Len(); i++ {
+			elemSize, err := EstimateValueSize(rv.Index(i).Interface())
+			if err != nil {
+				return 0, errors.Wrapf(err, "index %d", i)
+			}
+			size += 1 + protowire.SizeBytes(elemSize)
+		}
+		return 1 + protowire.SizeBytes(size), nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return 0, errors.Errorf("unsupported map key type %s", rv.Type().Key())
+		}
+		var size int
+		iter := rv.MapRange()
+		for iter.Next() {
+			vSize, err := EstimateValueSize(iter.Value().Interface())
+			if err != nil {
+				return 0, errors.Wrapf(err, "key %q", iter.Key().String())
+			}
+			size += mapEntrySize(iter.Key().String(), vSize)
+		}
+		return 1 + protowire.SizeBytes(size), nil
+	case reflect.Struct:
+		m, err := protoutils.InterfaceToMap(v)
+		if err != nil {
+			return 0, err
+		}
+		return EstimateValueSize(m)
+	default:
+		return 0, errors.Errorf("unsupported type %T", v)
+	}
+}
+
+// mapEntrySize returns the encoded size of an entry of a structpb.Struct's fields.
+func mapEntrySize(key string, valueSize int) int {
+	entrySize := 1 + protowire.SizeBytes(len(key)) + 1 + protowire.SizeBytes(valueSize)
+	return 1 + protowire.SizeBytes(entrySize)
+}
+
+// ChunkMap splits m into structpb.Structs of about maxBytes each and calls fn with them in
+// order, building each one only once the previous one has been handled, so that a payload too
+// large for one gRPC message, such as a large DoCommand result, can be sent as several without
+// ever holding it all as protobuf. MergeChunk reassembles the chunks.
+//
+// Maps that do not fit in a chunk are split by key and lists are split into runs of elements,
+// so a single value other than a map or list, or a single element of a list, must fit in
+// maxBytes on its own. Chunks are packed using EstimateStructSize, which does not account for
+// the overhead of keys shared by the pieces of a chunk, so leave some headroom in maxBytes.
+func ChunkMap(m map[string]interface{}, maxBytes int, fn func(*structpb.Struct) error) error {
+	if maxBytes <= 0 {
+		return errors.New("chunk size must be positive")
+	}
+	var pieces []chunkPiece
+	for _, k := range sortedKeys(m) {
+		var err error
+		pieces, err = appendChunkPieces(pieces, []string{k}, m[k], maxBytes)
+		if err != nil {
+			return err
+		}
+	}
+
+	chunk := map[string]interface{}{}
+	var chunkSize int
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		pb, err := protoutils.StructToStructPb(chunk)
+		if err != nil {
+			return err
+		}
+		chunk = map[string]interface{}{}
+		chunkSize = 0
+		return fn(pb)
+	}
+	for _, piece := range pieces {
+		if chunkSize+piece.size > maxBytes {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		insertChunkPiece(chunk, piece)
+		chunkSize += piece.size
+	}
+	return flush()
+}
+
+// MergeChunk merges a chunk made by ChunkMap into m, creating m if it is nil, and returns m.
+// Chunks must be merged in the order ChunkMap made them.
+func MergeChunk(m map[string]interface{}, chunk *structpb.Struct) map[string]interface{} {
+	if m == nil {
+		m = map[string]interface{}{}
+	}
+	for k, v := range chunk.GetFields() {
+		m[k] = mergeChunkValue(m[k], v.AsInterface())
+	}
+	return m
+}
+
+func mergeChunkValue(existing, v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		if existingMap, ok := existing.(map[string]interface{}); ok {
+			for k, elem := range x {
+				existingMap[k] = mergeChunkValue(existingMap[k], elem)
+			}
+			return existingMap
+		}
+	case []interface{}:
+		if existingList, ok := existing.([]interface{}); ok {
+			return append(existingList, x...)
+		}
+	}
+	return v
+}
+
+// A chunkPiece is a value, or a run of elements of a list, to be put at a path of keys in a
+// chunk.
+type chunkPiece struct {
+	path   []string
+	value  interface{}
+	isList bool
+	size   int
+}
+
+func appendChunkPieces(pieces []chunkPiece, path []string, v interface{}, maxBytes int) ([]chunkPiece, error) {
+	vSize, err := EstimateValueSize(v)
+	if err != nil {
+		return nil, errors.Wrapf(err, "at %s", strings.Join(path, "."))
+	}
+	if size := wrappedSize(path, vSize); size <= maxBytes {
+		return append(pieces, chunkPiece{path: path, value: v, size: size}), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Map:
+		iter := rv.MapRange()
+		keys := make([]string, 0, rv.Len())
+		for iter.Next() {
+			keys = append(keys, iter.Key().String())
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			pieces, err = appendChunkPieces(pieces, append(path[:len(path):len(path)], k),
+				rv.MapIndex(reflect.ValueOf(k).Convert(rv.Type().Key())).Interface(), maxBytes)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return pieces, nil
+	case reflect.Slice, reflect.Array:
+		var run []interface{}
+		var runSize int
+		for i := 0; i < rv.Len(); i++ {
+			elem := rv.Index(i).Interface()
+			elemSize, err := EstimateValueSize(elem)
+			if err != nil {
+				return nil, err
+			}
+			elemSize = 1 + protowire.SizeBytes(elemSize)
+			if wrappedSize(path, 1+protowire.SizeBytes(elemSize)) > maxBytes {
+				return nil, errors.Errorf("element %d of %s is larger than the chunk size of %d bytes",
+					i, strings.Join(path, "."), maxBytes)
+			}
+			if len(run) > 0 && wrappedSize(path, 1+protowire.SizeBytes(runSize+elemSize)) > maxBytes {
+				pieces = append(pieces, chunkPiece{
+					path: path, value: run, isList: true, size: wrappedSize(path, 1+protowire.SizeBytes(runSize)),
+				})
+				run, runSize = nil, 0
+			}
+			run = append(run, elem)
+			runSize += elemSize
+		}
+		if len(run) > 0 {
+			pieces = append(pieces, chunkPiece{
+				path: path, value: run, isList: true, size: wrappedSize(path, 1+protowire.SizeBytes(runSize)),
+			})
+		}
+		return pieces, nil
+	case reflect.Struct:
+		m, err := protoutils.InterfaceToMap(rv.Interface())
+		if err != nil {
+			return nil, err
+		}
+		return appendChunkPieces(pieces, path, m, maxBytes)
+	default:
+		return nil, errors.Errorf("%s is %d bytes, which is larger than the chunk size of %d bytes",
+			strings.Join(path, "."), vSize, maxBytes)
+	}
+}
+
+// wrappedSize returns the encoded size of a structpb.Struct holding only a value of the
+// given size at path.
+func wrappedSize(path []string, valueSize int) int {
+	for i := len(path) - 1; i > 0; i-- {
+		valueSize = 1 + protowire.SizeBytes(mapEntrySize(path[i], valueSize))
+	}
+	return mapEntrySize(path[0], valueSize)
+}
+
+func insertChunkPiece(chunk map[string]interface{}, piece chunkPiece) {
+	m := chunk
+	for _, k := range piece.path[:len(piece.path)-1] {
+		next, ok := m[k].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			m[k] = next
+		}
+		m = next
+	}
+	last := piece.path[len(piece.path)-1]
+	if existing, ok := m[last].([]interface{}); ok && piece.isList {
+		m[last] = append(existing, piece.value.([]interface{})...)
+		return
+	}
+	m[last] = piece.value
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// errMessageTooLarge is returned in place of building a structpb.Struct that could not be sent.
+func errMessageTooLarge(what string, size, maxSize int) error {
+	return errors.Errorf("%s of about %d bytes is larger than the maximum message size of %d bytes; "+
+		"split it up, such as with protoutils.ChunkMap", what, size, maxSize)
+}
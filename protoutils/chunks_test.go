@@ -0,0 +1,119 @@
+package protoutils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"go.viam.com/test"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestEstimateSize(t *testing.T) {
+	m := map[string]interface{}{
+		"null":   nil,
+		"bool":   true,
+		"int":    12,
+		"float":  1.5,
+		"string": strings.Repeat("x", 300),
+		"list":   []interface{}{1.0, "two", false, map[string]interface{}{"three": 3.0}},
+		"nested": map[string]interface{}{"a": map[string]interface{}{"b": []interface{}{}}},
+		"":       "",
+	}
+	pb, err := structpb.NewStruct(m)
+	test.That(t, err, test.ShouldBeNil)
+	size, err := EstimateStructSize(m)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, size, test.ShouldEqual, proto.Size(pb))
+
+	for _, v := range m {
+		pbV, err := structpb.NewValue(v)
+		test.That(t, err, test.ShouldBeNil)
+		size, err := EstimateValueSize(v)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, size, test.ShouldEqual, proto.Size(pbV))
+	}
+
+	// typed slices and maps are sized as the lists and structs they are converted to.
+	size, err = EstimateValueSize([]float64{1, 2, 3})
+	test.That(t, err, test.ShouldBeNil)
+	pbV, err := structpb.NewValue([]interface{}{1.0, 2.0, 3.0})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, size, test.ShouldEqual, proto.Size(pbV))
+
+	_, err = EstimateValueSize(map[int]string{1: "one"})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "unsupported map key type")
+}
+
+func TestChunkMap(t *testing.T) {
+	points := make([]float64, 5000)
+	for i := range points {
+		points[i] = float64(i)
+	}
+	labels := map[string]interface{}{}
+	for i := 0; i < 200; i++ {
+		labels[fmt.Sprintf("label_%d", i)] = strings.Repeat("l", 50)
+	}
+	m := map[string]interface{}{
+		"points": points,
+		"labels": labels,
+		"small":  "value",
+	}
+	const maxBytes = 4096
+
+	var merged map[string]interface{}
+	var chunks int
+	err := ChunkMap(m, maxBytes, func(chunk *structpb.Struct) error {
+		chunks++
+		test.That(t, proto.Size(chunk), test.ShouldBeLessThanOrEqualTo, maxBytes)
+		merged = MergeChunk(merged, chunk)
+		return nil
+	})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, chunks, test.ShouldBeGreaterThan, 1)
+
+	expected, err := structpb.NewStruct(map[string]interface{}{
+		"points": func() []interface{} {
+			out := make([]interface{}, 0, len(points))
+			for _, p := range points {
+				out = append(out, p)
+			}
+			return out
+		}(),
+		"labels": labels,
+		"small":  "value",
+	})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, merged, test.ShouldResemble, expected.AsMap())
+
+	t.Run("fits in one chunk", func(t *testing.T) {
+		var chunks int
+		err := ChunkMap(map[string]interface{}{"small": "value"}, maxBytes, func(chunk *structpb.Struct) error {
+			chunks++
+			test.That(t, chunk.AsMap(), test.ShouldResemble, map[string]interface{}{"small": "value"})
+			return nil
+		})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, chunks, test.ShouldEqual, 1)
+	})
+
+	t.Run("value too large", func(t *testing.T) {
+		err := ChunkMap(map[string]interface{}{"big": strings.Repeat("x", maxBytes)}, maxBytes,
+			func(chunk *structpb.Struct) error { return nil })
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "larger than the chunk size")
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		errStop := fmt.Errorf("stop")
+		var chunks int
+		err := ChunkMap(m, maxBytes, func(chunk *structpb.Struct) error {
+			chunks++
+			return errStop
+		})
+		test.That(t, err, test.ShouldEqual, errStop)
+		test.That(t, chunks, test.ShouldEqual, 1)
+	})
+}
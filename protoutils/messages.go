@@ -10,6 +10,7 @@ import (
 	commonpb "go.viam.com/api/common/v1"
 	robotpb "go.viam.com/api/robot/v1"
 	"go.viam.com/utils/protoutils"
+	"go.viam.com/utils/rpc"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -180,6 +181,9 @@ type ClientDoCommander interface {
 func DoFromResourceClient(ctx context.Context, svc ClientDoCommander, name string,
 	cmd map[string]interface{},
 ) (map[string]interface{}, error) {
+	if err := checkMessageSize("DoCommand command", cmd); err != nil {
+		return nil, err
+	}
 	command, err := protoutils.StructToStructPb(cmd)
 	if err != nil {
 		return nil, err
@@ -200,13 +204,38 @@ func DoFromResourceServer(
 	res resource.Resource,
 	req *commonpb.DoCommandRequest,
 ) (*commonpb.DoCommandResponse, error) {
-	resp, err := res.DoCommand(ctx, req.Command.AsMap())
+	cmd := req.Command.AsMap()
+	// capabilities are answered from the resource's registration when known here, and are
+	// otherwise passed on, such as to a remote that knows them.
+	if resource.IsGetCapabilitiesCommand(cmd) {
+		if caps, ok := resource.CapabilitiesOf(res); ok {
+			pbRes, err := protoutils.StructToStructPb(resource.CapabilitiesResponse(caps))
+			if err != nil {
+				return nil, err
+			}
+			return &commonpb.DoCommandResponse{Result: pbRes}, nil
+		}
+	}
+	resp, err := res.DoCommand(ctx, cmd)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkMessageSize("DoCommand result", resp); err != nil {
+		return nil, err
+	}
 	pbRes, err := protoutils.StructToStructPb(resp)
 	if err != nil {
 		return nil, err
 	}
 	return &commonpb.DoCommandResponse{Result: pbRes}, nil
 }
+
+// checkMessageSize fails if m would be too large to send over gRPC, before any time or memory
+// is spent converting it. If its size cannot be estimated, conversion is left to report why.
+func checkMessageSize(what string, m map[string]interface{}) error {
+	size, err := EstimateStructSize(m)
+	if err != nil || size <= rpc.MaxMessageSize {
+		return nil
+	}
+	return errMessageTooLarge(what, size, rpc.MaxMessageSize)
+}
@@ -699,6 +699,37 @@ func framesAlmostEqual(frame1, frame2 Frame, epsilon float64) (bool, error) {
 			return false, nil
 		default:
 		}
+	case *mimicFrame:
+		f2 := frame2.(*mimicFrame)
+		switch {
+		case f1.joint != f2.joint:
+			return false, nil
+		case math.Abs(f1.multiplier-f2.multiplier) > epsilon || math.Abs(f1.offset-f2.offset) > epsilon:
+			return false, nil
+		default:
+			return framesAlmostEqual(f1.Frame, f2.Frame, epsilon)
+		}
+	case *scissorFrame:
+		f2 := frame2.(*scissorFrame)
+		switch {
+		case !spatial.R3VectorAlmostEqual(f1.axis, f2.axis, epsilon):
+			return false, nil
+		case f1.stages != f2.stages || math.Abs(f1.armLength-f2.armLength) > epsilon:
+			return false, nil
+		default:
+		}
+	case *deltaFrame:
+		f2 := frame2.(*deltaFrame)
+		for _, d := range []float64{
+			f1.baseRadius - f2.baseRadius,
+			f1.effectorRadius - f2.effectorRadius,
+			f1.upperArmLength - f2.upperArmLength,
+			f1.forearmLength - f2.forearmLength,
+		} {
+			if math.Abs(d) > epsilon {
+				return false, nil
+			}
+		}
 	case *tailGeometryStaticFrame:
 		f2 := frame2.(*tailGeometryStaticFrame)
 		switch {
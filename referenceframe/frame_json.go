@@ -17,6 +17,9 @@ const (
 	ContinuousJoint = "continuous"
 	PrismaticJoint  = "prismatic"
 	RevoluteJoint   = "revolute"
+	// ScissorJoint and DeltaJoint are closed kinematic chains, whose inputs drive linkages that move the next frame.
+	ScissorJoint = "scissor"
+	DeltaJoint   = "delta"
 )
 
 // LinkConfig is a StaticFrame that also has a specified parent.
@@ -37,6 +40,33 @@ type JointConfig struct {
 	Max      float64                 `json:"max"`                // in mm or degs
 	Min      float64                 `json:"min"`                // in mm or degs
 	Geometry *spatial.GeometryConfig `json:"geometry,omitempty"` // only valid for prismatic/translational joints
+	Mimic    *MimicConfig            `json:"mimic,omitempty"`
+	Scissor  *ScissorConfig          `json:"scissor,omitempty"` // only valid for scissor joints
+	Delta    *DeltaConfig            `json:"delta,omitempty"`   // only valid for delta joints
+}
+
+// MimicConfig makes a joint follow another joint of its model instead of having a degree of freedom of its own.
+// Its position is Multiplier times the position of Joint plus Offset, in the units of each joint (mm or degs).
+type MimicConfig struct {
+	Joint      string   `json:"joint"`
+	Multiplier *float64 `json:"multiplier,omitempty"` // defaults to 1
+	Offset     float64  `json:"offset,omitempty"`
+}
+
+// ScissorConfig is a scissor lift of Stages crossed pairs of arms, ArmLength mm between their end pivots, which
+// extends along the joint's axis. The joint's limits are of the angle of the arms from the base.
+type ScissorConfig struct {
+	Stages    int     `json:"stages"`
+	ArmLength float64 `json:"arm_length"`
+}
+
+// DeltaConfig is a delta robot with three arms 120 degrees apart around the z axis, the first along x, whose effector
+// hangs below the base. The joint's limits are of each arm's angle below the base.
+type DeltaConfig struct {
+	BaseRadius     float64 `json:"base_radius"`     // in mm, from the center of the base to the arm joints
+	EffectorRadius float64 `json:"effector_radius"` // in mm, from the center of the effector to the forearm joints
+	UpperArmLength float64 `json:"upper_arm_length"`
+	ForearmLength  float64 `json:"forearm_length"`
 }
 
 // DHParamConfig is a revolute and static frame combined in a set of Denavit Hartenberg parameters.
@@ -127,16 +157,39 @@ func (cfg *LinkConfig) Pose() (spatial.Pose, error) {
 
 // ToFrame converts a JointConfig into a joint frame.
 func (cfg *JointConfig) ToFrame() (Frame, error) {
+	var frame Frame
+	var err error
 	switch cfg.Type {
 	case RevoluteJoint:
-		return NewRotationalFrame(cfg.ID, cfg.Axis.ParseConfig(),
+		frame, err = NewRotationalFrame(cfg.ID, cfg.Axis.ParseConfig(),
 			Limit{Min: utils.DegToRad(cfg.Min), Max: utils.DegToRad(cfg.Max)})
 	case PrismaticJoint:
-		return NewTranslationalFrame(cfg.ID, r3.Vector(cfg.Axis),
+		frame, err = NewTranslationalFrame(cfg.ID, r3.Vector(cfg.Axis),
 			Limit{Min: cfg.Min, Max: cfg.Max})
+	case ScissorJoint:
+		if cfg.Scissor == nil {
+			return nil, fmt.Errorf("scissor joint %s has no scissor config", cfg.ID)
+		}
+		frame, err = NewScissorFrame(cfg.ID, r3.Vector(cfg.Axis), cfg.Scissor.Stages, cfg.Scissor.ArmLength,
+			Limit{Min: utils.DegToRad(cfg.Min), Max: utils.DegToRad(cfg.Max)})
+	case DeltaJoint:
+		if cfg.Delta == nil {
+			return nil, fmt.Errorf("delta joint %s has no delta config", cfg.ID)
+		}
+		frame, err = NewDeltaFrame(cfg.ID, cfg.Delta.BaseRadius, cfg.Delta.EffectorRadius,
+			cfg.Delta.UpperArmLength, cfg.Delta.ForearmLength,
+			Limit{Min: utils.DegToRad(cfg.Min), Max: utils.DegToRad(cfg.Max)})
 	default:
 		return nil, NewUnsupportedJointTypeError(cfg.Type)
 	}
+	if err != nil || cfg.Mimic == nil {
+		return frame, err
+	}
+	multiplier := 1.
+	if cfg.Mimic.Multiplier != nil {
+		multiplier = *cfg.Mimic.Multiplier
+	}
+	return NewMimicFrame(frame, cfg.Mimic.Joint, multiplier, cfg.Mimic.Offset)
 }
 
 // ToDHFrames converts a DHParamConfig into a joint frame and a link frame.
@@ -0,0 +1,367 @@
+package referenceframe
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+	pb "go.viam.com/api/component/arm/v1"
+
+	spatial "go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
+)
+
+// a mimicFrame is a joint that follows another joint of its model rather than having a degree of freedom of its own,
+// such as the second finger of a gripper driven by one motor, or a joint kept in step with another by a parallelogram
+// linkage. Its position is multiplier times the position of the joint it mimics plus offset, both positions as they
+// are given in joint positions (degrees or mm).
+type mimicFrame struct {
+	Frame
+	joint      string
+	multiplier float64
+	offset     float64
+}
+
+// NewMimicFrame creates a frame that moves frame to multiplier times the position of the joint named joint in the
+// same model plus offset, in degrees or mm. The frame has no degrees of freedom of its own, so it can only be
+// transformed as part of a model that also contains the joint it mimics.
+func NewMimicFrame(frame Frame, joint string, multiplier, offset float64) (Frame, error) {
+	if len(frame.DoF()) != 1 {
+		return nil, fmt.Errorf("only joints with 1 degree of freedom can mimic another, %s has %d", frame.Name(), len(frame.DoF()))
+	}
+	if joint == frame.Name() {
+		return nil, fmt.Errorf("joint %s cannot mimic itself", joint)
+	}
+	return &mimicFrame{Frame: frame, joint: joint, multiplier: multiplier, offset: offset}, nil
+}
+
+// DoF returns no degrees of freedom, as a mimic joint is moved by the joint it mimics.
+func (mf *mimicFrame) DoF() []Limit {
+	return []Limit{}
+}
+
+// Transform returns an error, as the position of a mimic joint depends on the joint it mimics.
+func (mf *mimicFrame) Transform(input []Input) (spatial.Pose, error) {
+	return nil, fmt.Errorf("mimic joint %s can only be transformed by the model containing %s", mf.Name(), mf.joint)
+}
+
+// Interpolate returns no inputs, as a mimic joint has none of its own.
+func (mf *mimicFrame) Interpolate(from, to []Input, by float64) ([]Input, error) {
+	if len(from) != 0 || len(to) != 0 {
+		return nil, NewIncorrectDoFError(max(len(from), len(to)), 0)
+	}
+	return []Input{}, nil
+}
+
+// Geometries returns no geometries, as where they are depends on the joint it mimics.
+func (mf *mimicFrame) Geometries(input []Input) (*GeometriesInFrame, error) {
+	return NewGeometriesInFrame(mf.Name(), nil), nil
+}
+
+// InputFromProtobuf returns no inputs, as a mimic joint has none of its own.
+func (mf *mimicFrame) InputFromProtobuf(jp *pb.JointPositions) []Input {
+	return []Input{}
+}
+
+// ProtobufFromInput returns no joint positions, as a mimic joint has none of its own.
+func (mf *mimicFrame) ProtobufFromInput(input []Input) *pb.JointPositions {
+	return &pb.JointPositions{}
+}
+
+// follow returns the input of the mimic joint given the input of the joint it mimics.
+func (mf *mimicFrame) follow(leader Frame, input Input) Input {
+	pos := mf.multiplier*leader.ProtobufFromInput([]Input{input}).Values[0] + mf.offset
+	return mf.Frame.InputFromProtobuf(&pb.JointPositions{Values: []float64{pos}})[0]
+}
+
+func (mf mimicFrame) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(mf.Frame)
+	if err != nil {
+		return nil, err
+	}
+	var cfg JointConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	multiplier := mf.multiplier
+	cfg.Mimic = &MimicConfig{Joint: mf.joint, Multiplier: &multiplier, Offset: mf.offset}
+	return json.Marshal(cfg)
+}
+
+func (mf *mimicFrame) UnmarshalJSON(data []byte) error {
+	var cfg JointConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return err
+	}
+	if cfg.Mimic == nil {
+		return errors.Errorf("joint %s does not mimic another joint", cfg.ID)
+	}
+	frame, err := cfg.ToFrame()
+	if err != nil {
+		return err
+	}
+	parsed, ok := frame.(*mimicFrame)
+	if !ok {
+		return errors.Errorf("could not parse mimic joint %s", cfg.ID)
+	}
+	*mf = *parsed
+	return nil
+}
+
+// mimickedJoint returns the frame and input of the joint that mf mimics in a model's ordered transforms.
+func mimickedJoint(mf *mimicFrame, transforms []Frame, inputs []Input) (Frame, Input, error) {
+	posIdx := 0
+	for _, transform := range transforms {
+		dof := len(transform.DoF())
+		if transform.Name() == mf.joint && dof == 1 {
+			return transform, inputs[posIdx], nil
+		}
+		posIdx += dof
+	}
+	return nil, Input{}, fmt.Errorf("joint %s mimics %s, which is not a joint with 1 degree of freedom in its model", mf.Name(), mf.joint)
+}
+
+// checkMimics checks that every mimic joint of a model's ordered transforms mimics a joint of the model.
+func checkMimics(transforms []Frame) error {
+	inputs := make([]Input, 0, len(transforms))
+	for _, transform := range transforms {
+		inputs = append(inputs, make([]Input, len(transform.DoF()))...)
+	}
+	for _, transform := range transforms {
+		if mf, ok := transform.(*mimicFrame); ok {
+			if _, _, err := mimickedJoint(mf, transforms, inputs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// a scissorFrame is a scissor lift, a stack of crossed pairs of arms that extends along its axis as the angle between
+// its arms and the base opens, which is its input in radians. The fixed pivots of the stages are above one another,
+// so the lift moves straight along its axis.
+type scissorFrame struct {
+	*baseFrame
+	axis      r3.Vector
+	stages    int
+	armLength float64
+}
+
+// NewScissorFrame creates a scissor lift of the given number of stages whose arms are armLength mm between their end
+// pivots, which extends along axis. Its input is the angle of the arms from the base in radians.
+func NewScissorFrame(name string, axis r3.Vector, stages int, armLength float64, limit Limit) (Frame, error) {
+	if spatial.R3VectorAlmostEqual(r3.Vector{}, axis, 1e-8) {
+		return nil, errors.New("cannot use zero vector as scissor lift axis")
+	}
+	if stages < 1 {
+		return nil, fmt.Errorf("scissor lift %s needs at least 1 stage, got %d", name, stages)
+	}
+	if armLength <= 0 {
+		return nil, fmt.Errorf("scissor lift %s arm length must be positive, got %v", name, armLength)
+	}
+	return &scissorFrame{
+		baseFrame: &baseFrame{name: name, limits: []Limit{limit}},
+		axis:      axis.Normalize(),
+		stages:    stages,
+		armLength: armLength,
+	}, nil
+}
+
+// Transform returns the pose of the top of the lift, which each stage raises by the height of its arms.
+func (sf *scissorFrame) Transform(input []Input) (spatial.Pose, error) {
+	err := sf.validInputs(input)
+	// We allow out-of-bounds calculations, but will return a non-nil error
+	if err != nil && !strings.Contains(err.Error(), OOBErrString) {
+		return nil, err
+	}
+	height := float64(sf.stages) * sf.armLength * math.Sin(input[0].Value)
+	return spatial.NewPoseFromPoint(sf.axis.Mul(height)), err
+}
+
+// InputFromProtobuf converts pb.JointPosition to inputs.
+func (sf *scissorFrame) InputFromProtobuf(jp *pb.JointPositions) []Input {
+	n := make([]Input, len(jp.Values))
+	for idx, d := range jp.Values {
+		n[idx] = Input{utils.DegToRad(d)}
+	}
+	return n
+}
+
+// ProtobufFromInput converts inputs to pb.JointPosition.
+func (sf *scissorFrame) ProtobufFromInput(input []Input) *pb.JointPositions {
+	n := make([]float64, len(input))
+	for idx, a := range input {
+		n[idx] = utils.RadToDeg(a.Value)
+	}
+	return &pb.JointPositions{Values: n}
+}
+
+// Geometries returns no geometries, as the links of the model carry them.
+func (sf *scissorFrame) Geometries(input []Input) (*GeometriesInFrame, error) {
+	return NewGeometriesInFrame(sf.Name(), nil), nil
+}
+
+func (sf scissorFrame) MarshalJSON() ([]byte, error) {
+	return json.Marshal(JointConfig{
+		ID:      sf.name,
+		Type:    ScissorJoint,
+		Axis:    spatial.AxisConfig{sf.axis.X, sf.axis.Y, sf.axis.Z},
+		Max:     utils.RadToDeg(sf.limits[0].Max),
+		Min:     utils.RadToDeg(sf.limits[0].Min),
+		Scissor: &ScissorConfig{Stages: sf.stages, ArmLength: sf.armLength},
+	})
+}
+
+func (sf *scissorFrame) UnmarshalJSON(data []byte) error {
+	var cfg JointConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return err
+	}
+	if cfg.Scissor == nil {
+		return errors.Errorf("scissor joint %s has no scissor config", cfg.ID)
+	}
+	sf.baseFrame = &baseFrame{name: cfg.ID, limits: []Limit{{Min: utils.DegToRad(cfg.Min), Max: utils.DegToRad(cfg.Max)}}}
+	sf.axis = r3.Vector(cfg.Axis).Normalize()
+	sf.stages, sf.armLength = cfg.Scissor.Stages, cfg.Scissor.ArmLength
+	return nil
+}
+
+// a deltaFrame is a delta robot, three arms on revolute joints 120 degrees apart around the z axis of its base, each
+// joined to the effector by a parallelogram forearm, which keeps the effector parallel to the base. Its inputs are
+// the angles of the arms below the plane of the base in radians, with the first arm along the x axis, and the
+// effector hangs below the base, along -z.
+type deltaFrame struct {
+	*baseFrame
+	baseRadius     float64
+	effectorRadius float64
+	upperArmLength float64
+	forearmLength  float64
+}
+
+// NewDeltaFrame creates a delta robot whose arm joints are baseRadius mm from the center of its base, with upper
+// arms upperArmLength mm long and forearms forearmLength mm long, joined to the effector effectorRadius mm from its
+// center. Each arm has the given limit.
+func NewDeltaFrame(name string, baseRadius, effectorRadius, upperArmLength, forearmLength float64, limit Limit) (Frame, error) {
+	if baseRadius <= 0 || effectorRadius < 0 || upperArmLength <= 0 || forearmLength <= 0 {
+		return nil, fmt.Errorf("delta robot %s must have positive radii and arm lengths", name)
+	}
+	return &deltaFrame{
+		baseFrame:      &baseFrame{name: name, limits: []Limit{limit, limit, limit}},
+		baseRadius:     baseRadius,
+		effectorRadius: effectorRadius,
+		upperArmLength: upperArmLength,
+		forearmLength:  forearmLength,
+	}, nil
+}
+
+// Transform returns the pose of the effector, found where the three spheres of points each forearm can reach from
+// the end of its upper arm meet, below the base.
+func (df *deltaFrame) Transform(input []Input) (spatial.Pose, error) {
+	err := df.validInputs(input)
+	// We allow out-of-bounds calculations, but will return a non-nil error
+	if err != nil && !strings.Contains(err.Error(), OOBErrString) {
+		return nil, err
+	}
+	// the effector's joints are moved to its center, so that all three forearms reach the same point
+	var centers [3]r3.Vector
+	for i := range centers {
+		angle := float64(i) * 2 * math.Pi / 3
+		radial := r3.Vector{X: math.Cos(angle), Y: math.Sin(angle)}
+		reach := df.baseRadius - df.effectorRadius + df.upperArmLength*math.Cos(input[i].Value)
+		centers[i] = radial.Mul(reach).Add(r3.Vector{Z: -df.upperArmLength * math.Sin(input[i].Value)})
+	}
+	effector, ok := trilaterate(centers, df.forearmLength)
+	if !ok {
+		return nil, fmt.Errorf("delta robot %s arms cannot reach each other with inputs %v", df.name, input)
+	}
+	return spatial.NewPoseFromPoint(effector), err
+}
+
+// trilaterate returns the lower of the points that are radius from each center, if there is one.
+func trilaterate(centers [3]r3.Vector, radius float64) (r3.Vector, bool) {
+	toSecond, toThird := centers[1].Sub(centers[0]), centers[2].Sub(centers[0])
+	d := toSecond.Norm()
+	if d < 1e-9 {
+		return r3.Vector{}, false
+	}
+	ex := toSecond.Mul(1 / d)
+	i := ex.Dot(toThird)
+	ey := toThird.Sub(ex.Mul(i))
+	j := ey.Norm()
+	if j < 1e-9 {
+		return r3.Vector{}, false
+	}
+	ey = ey.Mul(1 / j)
+	ez := ex.Cross(ey)
+
+	// the spheres have the same radius, which simplifies where their circles of intersection lie
+	x := d / 2
+	y := (i*i+j*j)/(2*j) - i*x/j
+	zz := radius*radius - x*x - y*y
+	if zz < 0 {
+		return r3.Vector{}, false
+	}
+	z := math.Sqrt(zz)
+	p := centers[0].Add(ex.Mul(x)).Add(ey.Mul(y))
+	above, below := p.Add(ez.Mul(z)), p.Sub(ez.Mul(z))
+	if above.Z < below.Z {
+		return above, true
+	}
+	return below, true
+}
+
+// InputFromProtobuf converts pb.JointPosition to inputs.
+func (df *deltaFrame) InputFromProtobuf(jp *pb.JointPositions) []Input {
+	n := make([]Input, len(jp.Values))
+	for idx, d := range jp.Values {
+		n[idx] = Input{utils.DegToRad(d)}
+	}
+	return n
+}
+
+// ProtobufFromInput converts inputs to pb.JointPosition.
+func (df *deltaFrame) ProtobufFromInput(input []Input) *pb.JointPositions {
+	n := make([]float64, len(input))
+	for idx, a := range input {
+		n[idx] = utils.RadToDeg(a.Value)
+	}
+	return &pb.JointPositions{Values: n}
+}
+
+// Geometries returns no geometries, as the links of the model carry them.
+func (df *deltaFrame) Geometries(input []Input) (*GeometriesInFrame, error) {
+	return NewGeometriesInFrame(df.Name(), nil), nil
+}
+
+func (df deltaFrame) MarshalJSON() ([]byte, error) {
+	return json.Marshal(JointConfig{
+		ID:   df.name,
+		Type: DeltaJoint,
+		Max:  utils.RadToDeg(df.limits[0].Max),
+		Min:  utils.RadToDeg(df.limits[0].Min),
+		Delta: &DeltaConfig{
+			BaseRadius:     df.baseRadius,
+			EffectorRadius: df.effectorRadius,
+			UpperArmLength: df.upperArmLength,
+			ForearmLength:  df.forearmLength,
+		},
+	})
+}
+
+func (df *deltaFrame) UnmarshalJSON(data []byte) error {
+	var cfg JointConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return err
+	}
+	if cfg.Delta == nil {
+		return errors.Errorf("delta joint %s has no delta config", cfg.ID)
+	}
+	limit := Limit{Min: utils.DegToRad(cfg.Min), Max: utils.DegToRad(cfg.Max)}
+	df.baseFrame = &baseFrame{name: cfg.ID, limits: []Limit{limit, limit, limit}}
+	df.baseRadius, df.effectorRadius = cfg.Delta.BaseRadius, cfg.Delta.EffectorRadius
+	df.upperArmLength, df.forearmLength = cfg.Delta.UpperArmLength, cfg.Delta.ForearmLength
+	return nil
+}
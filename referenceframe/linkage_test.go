@@ -0,0 +1,164 @@
+package referenceframe
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	spatial "go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
+)
+
+func TestMimicJoints(t *testing.T) {
+	m, err := ParseModelJSONFile(utils.ResolveFile("referenceframe/testfiles/scissor_lift.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	// the lift and the shoulder, which the wrist mimics
+	test.That(t, m.DoF(), test.ShouldHaveLength, 2)
+
+	// the wrist turns back as the shoulder turns, keeping the tool pointing along x
+	inputs := []Input{{utils.DegToRad(30)}, {math.Pi / 2}}
+	pose, err := m.Transform(inputs)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatial.R3VectorAlmostEqual(pose.Point(), r3.Vector{X: 100, Y: 300, Z: 3*400*0.5 + 50}, 1e-6), test.ShouldBeTrue)
+	test.That(t, spatial.OrientationAlmostEqual(pose.Orientation(), spatial.NewZeroOrientation()), test.ShouldBeTrue)
+
+	pieces, err := m.ModelPieceFrames(inputs)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, pieces, test.ShouldContainKey, "wrist")
+	geometries, err := m.Geometries(inputs)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, geometries.Geometries(), test.ShouldHaveLength, 1)
+
+	interp, err := m.Interpolate([]Input{{0.1}, {0}}, inputs, 0.5)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, interp, test.ShouldHaveLength, 2)
+	jp := m.ProtobufFromInput(inputs)
+	test.That(t, jp.Values, test.ShouldHaveLength, 2)
+	test.That(t, m.InputFromProtobuf(jp), test.ShouldHaveLength, 2)
+
+	t.Run("serialization", func(t *testing.T) {
+		sm, ok := m.(*SimpleModel)
+		test.That(t, ok, test.ShouldBeTrue)
+		wrist := sm.OrdTransforms[len(sm.OrdTransforms)-2]
+		_, err := wrist.Transform([]Input{})
+		test.That(t, err, test.ShouldNotBeNil)
+
+		data, err := frameToJSON(wrist)
+		test.That(t, err, test.ShouldBeNil)
+		wrist2, err := jsonToFrame(json.RawMessage(data))
+		test.That(t, err, test.ShouldBeNil)
+		equal, err := framesAlmostEqual(wrist, wrist2, defaultFloatPrecision)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, equal, test.ShouldBeTrue)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		shoulder, err := NewRotationalFrame("shoulder", spatial.R4AA{RZ: 1}, Limit{Min: -math.Pi, Max: math.Pi})
+		test.That(t, err, test.ShouldBeNil)
+		_, err = NewMimicFrame(shoulder, "shoulder", 1, 0)
+		test.That(t, err, test.ShouldNotBeNil)
+		pose, err := NewPoseFrame("pose", nil)
+		test.That(t, err, test.ShouldBeNil)
+		_, err = NewMimicFrame(pose, "shoulder", 1, 0)
+		test.That(t, err, test.ShouldNotBeNil)
+
+		// a mimic joint needs the joint it mimics in its model
+		wrist, err := NewRotationalFrame("wrist", spatial.R4AA{RZ: 1}, Limit{Min: -math.Pi, Max: math.Pi})
+		test.That(t, err, test.ShouldBeNil)
+		mimic, err := NewMimicFrame(wrist, "elbow", 1, 0)
+		test.That(t, err, test.ShouldBeNil)
+		model := NewSimpleModel("bad")
+		model.OrdTransforms = []Frame{shoulder, mimic}
+		_, err = model.Transform([]Input{{0}})
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, checkMimics(model.OrdTransforms), test.ShouldNotBeNil)
+		// and cannot mimic another mimic joint
+		mimicMimic, err := NewMimicFrame(shoulder, "wrist", 1, 0)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, checkMimics([]Frame{mimicMimic, mimic}), test.ShouldNotBeNil)
+	})
+}
+
+func TestScissorFrame(t *testing.T) {
+	limit := Limit{Min: utils.DegToRad(5), Max: utils.DegToRad(60)}
+	lift, err := NewScissorFrame("lift", r3.Vector{Z: 2}, 4, 250, limit)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, lift.DoF(), test.ShouldResemble, []Limit{limit})
+
+	pose, err := lift.Transform([]Input{{utils.DegToRad(45)}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatial.R3VectorAlmostEqual(pose.Point(), r3.Vector{Z: 4 * 250 * math.Sqrt2 / 2}, 1e-6), test.ShouldBeTrue)
+	// out of bounds inputs still have a pose
+	pose, err = lift.Transform([]Input{{utils.DegToRad(90)}})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, OOBErrString)
+	test.That(t, pose.Point().Z, test.ShouldAlmostEqual, 1000)
+	test.That(t, lift.ProtobufFromInput([]Input{{math.Pi / 4}}).Values[0], test.ShouldAlmostEqual, 45)
+
+	data, err := frameToJSON(lift)
+	test.That(t, err, test.ShouldBeNil)
+	lift2, err := jsonToFrame(json.RawMessage(data))
+	test.That(t, err, test.ShouldBeNil)
+	equal, err := framesAlmostEqual(lift, lift2, defaultFloatPrecision)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, equal, test.ShouldBeTrue)
+
+	_, err = NewScissorFrame("lift", r3.Vector{}, 4, 250, limit)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = NewScissorFrame("lift", r3.Vector{Z: 1}, 0, 250, limit)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = NewScissorFrame("lift", r3.Vector{Z: 1}, 4, 0, limit)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestDeltaFrame(t *testing.T) {
+	m, err := ParseModelJSONFile(utils.ResolveFile("referenceframe/testfiles/delta.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, m.DoF(), test.ShouldHaveLength, 3)
+
+	// with the arms level the effector hangs straight below the base
+	pose, err := m.Transform(make([]Input, 3))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatial.R3VectorAlmostEqual(pose.Point(), r3.Vector{Z: -math.Sqrt(400*400-270*270) - 20}, 1e-6), test.ShouldBeTrue)
+
+	// each forearm joins the end of its upper arm to the effector
+	sm, ok := m.(*SimpleModel)
+	test.That(t, ok, test.ShouldBeTrue)
+	arms := sm.OrdTransforms[1]
+	inputs := FloatsToInputs([]float64{0.2, 0.7, -0.3})
+	pose, err = arms.Transform(inputs)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatial.OrientationAlmostEqual(pose.Orientation(), spatial.NewZeroOrientation()), test.ShouldBeTrue)
+	for i, in := range inputs {
+		angle := float64(i) * 2 * math.Pi / 3
+		radial := r3.Vector{X: math.Cos(angle), Y: math.Sin(angle)}
+		elbow := radial.Mul(100 + 200*math.Cos(in.Value)).Add(r3.Vector{Z: -200 * math.Sin(in.Value)})
+		joint := pose.Point().Add(radial.Mul(30))
+		test.That(t, joint.Sub(elbow).Norm(), test.ShouldAlmostEqual, 400)
+	}
+
+	data, err := frameToJSON(arms)
+	test.That(t, err, test.ShouldBeNil)
+	arms2, err := jsonToFrame(json.RawMessage(data))
+	test.That(t, err, test.ShouldBeNil)
+	equal, err := framesAlmostEqual(arms, arms2, defaultFloatPrecision)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, equal, test.ShouldBeTrue)
+
+	// forearms too short to meet have no pose
+	short, err := NewDeltaFrame("short", 100, 30, 200, 100, Limit{Min: -math.Pi, Max: math.Pi})
+	test.That(t, err, test.ShouldBeNil)
+	pose, err = short.Transform(make([]Input, 3))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, pose, test.ShouldBeNil)
+	model := NewSimpleModel("short")
+	model.OrdTransforms = []Frame{short}
+	_, err = model.Transform(make([]Input, 3))
+	test.That(t, err, test.ShouldNotBeNil)
+
+	_, err = NewDeltaFrame("bad", 0, 30, 200, 400, Limit{})
+	test.That(t, err, test.ShouldNotBeNil)
+}
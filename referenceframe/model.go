@@ -324,15 +324,25 @@ func (m *SimpleModel) inputsToFrames(inputs []Input, collectAll bool) ([]*static
 		input := inputs[posIdx:dof]
 		posIdx = dof
 
-		pose, errNew := transform.Transform(input)
+		// a mimic joint is moved by the joint it mimics, wherever that is in the model
+		frame := transform
+		if mf, ok := transform.(*mimicFrame); ok {
+			leader, leaderInput, err := mimickedJoint(mf, m.OrdTransforms, inputs)
+			if err != nil {
+				return nil, err
+			}
+			frame, input = mf.Frame, []Input{mf.follow(leader, leaderInput)}
+		}
+
+		pose, errNew := frame.Transform(input)
 		// Fail if inputs are incorrect and pose is nil, but allow querying out-of-bounds positions
-		if pose == nil || (err != nil && !strings.Contains(err.Error(), OOBErrString)) {
-			return nil, err
+		if pose == nil || (errNew != nil && !strings.Contains(errNew.Error(), OOBErrString)) {
+			return nil, errNew
 		}
 		multierr.AppendInto(&err, errNew)
 		if collectAll {
 			var geometry spatialmath.Geometry
-			gf, err := transform.Geometries(input)
+			gf, err := frame.Geometries(input)
 			if err != nil {
 				return nil, err
 			}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/pkg/errors"
 	"golang.org/x/exp/maps"
+
+	"go.viam.com/rdk/utils"
 )
 
 // ErrNoModelInformation is used when there is no model information.
@@ -121,6 +123,9 @@ func (cfg *ModelConfigJSON) ParseConfig(modelName string) (Model, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := checkMimics(model.OrdTransforms); err != nil {
+		return nil, err
+	}
 
 	return model, nil
 }
@@ -186,3 +191,90 @@ func sortTransforms(transforms map[string]Frame, parents map[string]string) ([]F
 
 	return orderedTransforms, nil
 }
+
+// JointTypes returns the type of each joint of a model built from a kinematics config, such as
+// RevoluteJoint or PrismaticJoint, in the order of the model's DoF.
+func JointTypes(model Model) ([]string, error) {
+	sm, ok := model.(*SimpleModel)
+	if !ok || sm.modelConfig == nil {
+		return nil, errors.New("joint types are only known for models built from a kinematics config")
+	}
+	types := make([]string, 0, len(model.DoF()))
+	for _, transform := range sm.OrdTransforms {
+		switch transform.(type) {
+		case *rotationalFrame:
+			types = append(types, RevoluteJoint)
+		case *translationalFrame:
+			types = append(types, PrismaticJoint)
+		default:
+			if len(transform.DoF()) != 0 {
+				return nil, fmt.Errorf("frame %s is not a revolute or prismatic joint", transform.Name())
+			}
+		}
+	}
+	return types, nil
+}
+
+// ModelWithJointLimits returns a copy of the model with its joint limits replaced by limits, given in the
+// order of the model's DoF in the model's units (radians or mm). Each new limit must lie within the
+// corresponding limit of the model, so limits can only ever be tightened.
+func ModelWithJointLimits(model Model, limits []Limit) (Model, error) {
+	sm, ok := model.(*SimpleModel)
+	if !ok || sm.modelConfig == nil {
+		return nil, errors.New("joint limits can only be overridden on models built from a kinematics config")
+	}
+	dof := model.DoF()
+	if len(limits) != len(dof) {
+		return nil, fmt.Errorf("got %d joint limits for a model with %d degrees of freedom", len(limits), len(dof))
+	}
+
+	byName := make(map[string]Limit, len(limits))
+	i := 0
+	for _, transform := range sm.OrdTransforms {
+		switch len(transform.DoF()) {
+		case 0:
+			continue
+		case 1:
+		default:
+			return nil, fmt.Errorf("cannot override the limits of multi-dof frame %s", transform.Name())
+		}
+		newLimit, oldLimit := limits[i], dof[i]
+		if newLimit.Min > newLimit.Max {
+			return nil, fmt.Errorf("joint %d min %v is greater than max %v", i, newLimit.Min, newLimit.Max)
+		}
+		if newLimit.Min < oldLimit.Min || newLimit.Max > oldLimit.Max {
+			return nil, fmt.Errorf("joint %d limits [%v, %v] must be within the model limits [%v, %v]",
+				i, newLimit.Min, newLimit.Max, oldLimit.Min, oldLimit.Max)
+		}
+		byName[transform.Name()] = newLimit
+		i++
+	}
+
+	cfg := *sm.modelConfig
+	cfg.Joints = append([]JointConfig(nil), sm.modelConfig.Joints...)
+	for idx, joint := range cfg.Joints {
+		l, ok := byName[joint.ID]
+		if !ok {
+			continue
+		}
+		if joint.Type == RevoluteJoint {
+			l = Limit{Min: utils.RadToDeg(l.Min), Max: utils.RadToDeg(l.Max)}
+		}
+		cfg.Joints[idx].Min, cfg.Joints[idx].Max = l.Min, l.Max
+	}
+	cfg.DHParams = append([]DHParamConfig(nil), sm.modelConfig.DHParams...)
+	for idx, dh := range cfg.DHParams {
+		if l, ok := byName[dh.ID+"_j"]; ok {
+			cfg.DHParams[idx].Min, cfg.DHParams[idx].Max = utils.RadToDeg(l.Min), utils.RadToDeg(l.Max)
+		}
+	}
+
+	// regenerate the original file so that the kinematics served to clients carry the new limits
+	cfg.OriginalFile = nil
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cfg.OriginalFile = &ModelFile{Bytes: data, Extension: "json"}
+	return cfg.ParseConfig(sm.Name())
+}
@@ -20,6 +20,8 @@ func TestParseJSONFile(t *testing.T) {
 		"referenceframe/testfiles/ur5eDH.json",
 		"components/arm/fake/kinematics/ur5e.json",
 		"components/arm/fake/kinematics/dofbot.json",
+		"referenceframe/testfiles/scissor_lift.json",
+		"referenceframe/testfiles/delta.json",
 	}
 
 	badFiles := []string{
@@ -69,3 +71,54 @@ func TestParseJSONFile(t *testing.T) {
 		})
 	}
 }
+
+func TestModelWithJointLimits(t *testing.T) {
+	for _, f := range []string{
+		"components/arm/fake/kinematics/xarm6.json",
+		"referenceframe/testfiles/ur5eDH.json",
+	} {
+		t.Run(f, func(t *testing.T) {
+			model, err := ParseModelJSONFile(utils.ResolveFile(f), "arm")
+			test.That(t, err, test.ShouldBeNil)
+			dof := model.DoF()
+
+			limits := make([]Limit, len(dof))
+			copy(limits, dof)
+			limits[0] = Limit{Min: -1, Max: 1}
+
+			limited, err := ModelWithJointLimits(model, limits)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, limited.Name(), test.ShouldEqual, "arm")
+			test.That(t, limited.DoF()[0].Min, test.ShouldAlmostEqual, -1)
+			test.That(t, limited.DoF()[0].Max, test.ShouldAlmostEqual, 1)
+			for i := 1; i < len(dof); i++ {
+				test.That(t, limited.DoF()[i].Min, test.ShouldAlmostEqual, dof[i].Min)
+				test.That(t, limited.DoF()[i].Max, test.ShouldAlmostEqual, dof[i].Max)
+			}
+
+			// the original model is untouched
+			test.That(t, model.DoF()[0], test.ShouldResemble, dof[0])
+
+			// inputs outside the new limits are out of bounds
+			inputs := make([]Input, len(dof))
+			inputs[0] = Input{Value: 1.5}
+			_, err = model.Transform(inputs)
+			test.That(t, err, test.ShouldBeNil)
+			_, err = limited.Transform(inputs)
+			test.That(t, err, test.ShouldNotBeNil)
+
+			// the served kinematics carry the new limits
+			served, err := UnmarshalModelJSON(limited.ModelConfig().OriginalFile.Bytes, "arm")
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, served.DoF()[0].Max, test.ShouldAlmostEqual, 1)
+
+			limits[0] = Limit{Min: dof[0].Min - 1, Max: 0}
+			_, err = ModelWithJointLimits(model, limits)
+			test.That(t, err, test.ShouldNotBeNil)
+			test.That(t, err.Error(), test.ShouldContainSubstring, "must be within the model limits")
+
+			_, err = ModelWithJointLimits(model, limits[1:])
+			test.That(t, err, test.ShouldNotBeNil)
+		})
+	}
+}
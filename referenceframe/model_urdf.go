@@ -38,6 +38,7 @@ type jointXML struct {
 	Origin  *pose    `xml:"origin,omitempty"`
 	Axis    *axis    `xml:"axis,omitempty"`
 	Limit   *limit   `xml:"limit,omitempty"`
+	Mimic   *mimic   `xml:"mimic,omitempty"`
 }
 
 // NewModelFromWorldState creates a ModelConfigURDF struct which can be marshalled into xml and will be a
@@ -113,7 +114,12 @@ func UnmarshalModelXML(xmlData []byte, modelName string) (*ModelConfigJSON, erro
 		links[linkElem.Name] = link
 	}
 
-	// Read the joints next
+	// Read the joints next, noting their types first so that mimic joints can be converted to the units of the joints
+	// they mimic
+	jointTypes := make(map[string]string, len(urdf.Joints))
+	for _, jointElem := range urdf.Joints {
+		jointTypes[jointElem.Name] = jointElem.Type
+	}
 	joints := make([]JointConfig, 0)
 	for _, jointElem := range urdf.Joints {
 		switch jointElem.Type {
@@ -140,6 +146,13 @@ func UnmarshalModelXML(xmlData []byte, modelName string) (*ModelConfigJSON, erro
 			default:
 				return nil, err
 			}
+			if jointElem.Mimic != nil {
+				leaderType, ok := jointTypes[jointElem.Mimic.Joint]
+				if !ok {
+					return nil, NewFrameNotInListOfTransformsError(jointElem.Mimic.Joint)
+				}
+				thisJoint.Mimic = jointElem.Mimic.toConfig(jointElem.Type, leaderType)
+			}
 			joints = append(joints, thisJoint)
 
 			// Generate child link translation and orientation data, which is held by this joint per the URDF design
@@ -1,7 +1,9 @@
 package referenceframe
 
 import (
+	"bytes"
 	"encoding/xml"
+	"math"
 	"testing"
 
 	"github.com/golang/geo/r3"
@@ -34,6 +36,53 @@ func TestParseURDFFile(t *testing.T) {
 	test.That(t, u.Name(), test.ShouldEqual, "foo")
 }
 
+func TestParseURDFMimic(t *testing.T) {
+	// a gripper whose finger slides 1cm for each radian its drive turns
+	gripper := []byte(`<robot name="gripper">
+  <link name="base"/>
+  <link name="drive_link"/>
+  <link name="finger_link"/>
+  <joint name="drive" type="revolute">
+    <parent link="base"/>
+    <child link="drive_link"/>
+    <origin xyz="0 0 0" rpy="0 0 0"/>
+    <axis xyz="0 0 1"/>
+    <limit lower="0" upper="3.14"/>
+  </joint>
+  <joint name="finger" type="prismatic">
+    <parent link="drive_link"/>
+    <child link="finger_link"/>
+    <origin xyz="0 0 0" rpy="0 0 0"/>
+    <axis xyz="0 0 1"/>
+    <limit lower="0" upper="0.04"/>
+    <mimic joint="drive" multiplier="0.01" offset="0.002"/>
+  </joint>
+</robot>`)
+	cfg, err := UnmarshalModelXML(gripper, "")
+	test.That(t, err, test.ShouldBeNil)
+	var finger JointConfig
+	for _, j := range cfg.Joints {
+		if j.ID == "finger" {
+			finger = j
+		}
+	}
+	test.That(t, finger.Mimic, test.ShouldNotBeNil)
+	test.That(t, finger.Mimic.Joint, test.ShouldEqual, "drive")
+	// converted to mm per degree and mm
+	test.That(t, *finger.Mimic.Multiplier, test.ShouldAlmostEqual, 10/utils.RadToDeg(1))
+	test.That(t, finger.Mimic.Offset, test.ShouldAlmostEqual, 2)
+
+	model, err := cfg.ParseConfig("")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, model.DoF(), test.ShouldHaveLength, 1)
+	pose, err := model.Transform([]Input{{math.Pi / 2}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, pose.Point().Z, test.ShouldAlmostEqual, 10*math.Pi/2+2)
+
+	_, err = UnmarshalModelXML(bytes.ReplaceAll(gripper, []byte(`joint="drive"`), []byte(`joint="missing"`)), "")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
 func TestWorldStateConversion(t *testing.T) {
 	foo, err := spatialmath.NewSphere(spatialmath.NewZeroPose(), 10, "foo")
 	test.That(t, err, test.ShouldBeNil)
@@ -23,6 +23,15 @@ func init() {
 	if err := RegisterFrameImplementer((*tailGeometryStaticFrame)(nil), "tail_geometry_static"); err != nil {
 		panic(err)
 	}
+	if err := RegisterFrameImplementer((*mimicFrame)(nil), "mimic"); err != nil {
+		panic(err)
+	}
+	if err := RegisterFrameImplementer((*scissorFrame)(nil), "scissor"); err != nil {
+		panic(err)
+	}
+	if err := RegisterFrameImplementer((*deltaFrame)(nil), "delta"); err != nil {
+		panic(err)
+	}
 }
 
 // RegisterFrameImplementer allows outside packages to register their implementations of the Frame
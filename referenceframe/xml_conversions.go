@@ -83,6 +83,31 @@ type limit struct {
 	Upper   float64  `xml:"upper,attr"` // translation limits are in meters, revolute limits are in radians
 }
 
+// mimic is a struct which details the XML used in a URDF mimic element, which makes a joint follow another joint.
+type mimic struct {
+	XMLName    xml.Name `xml:"mimic"`
+	Joint      string   `xml:"joint,attr"`
+	Multiplier *float64 `xml:"multiplier,attr,omitempty"` // defaults to 1
+	Offset     float64  `xml:"offset,attr,omitempty"`     // in meters or radians
+}
+
+// toConfig converts the mimic element of a joint of type jointType, which mimics a joint of type leaderType, from URDF
+// units (meters or radians) to the units of a MimicConfig (mm or degrees).
+func (m *mimic) toConfig(jointType, leaderType string) *MimicConfig {
+	scale := func(jointType string) float64 {
+		if jointType == PrismaticJoint {
+			return utils.MetersToMM(1)
+		}
+		return utils.RadToDeg(1)
+	}
+	multiplier := 1.
+	if m.Multiplier != nil {
+		multiplier = *m.Multiplier
+	}
+	multiplier *= scale(jointType) / scale(leaderType)
+	return &MimicConfig{Joint: m.Joint, Multiplier: &multiplier, Offset: m.Offset * scale(jointType)}
+}
+
 type axis struct {
 	XMLName xml.Name `xml:"axis"`
 	XYZ     string   `xml:"xyz,attr"` // "x y z" format, in meters
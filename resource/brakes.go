@@ -0,0 +1,149 @@
+package resource
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/utils"
+)
+
+// CapabilityBrakes is the capability of actuators that implement Braker.
+const CapabilityBrakes Capability = "brakes"
+
+// DoCommand keys used to carry brake requests over the APIs of actuators, such as arms and gantries,
+// whose protos have no brake methods.
+const (
+	EngageBrakesCommand  = "engage_brakes"
+	ReleaseBrakesCommand = "release_brakes"
+	BrakesEngagedCommand = "brakes_engaged"
+)
+
+// SoftStart controls how a Braker hands a gravity-loaded axis over from its brakes to its motors, so
+// that the axis neither sags when the brakes let go nor jerks when it starts moving. Zero values leave
+// the actuator's own defaults in place.
+type SoftStart struct {
+	// TorqueRampDuration is how long holding torque is ramped up for before the brakes are released.
+	TorqueRampDuration time.Duration
+	// SpeedRampDuration is how long speed limits are ramped up from zero for once the brakes are released.
+	SpeedRampDuration time.Duration
+}
+
+// A Braker is an actuator with brakes that hold its axes in place while unpowered, such as on a
+// vertical gantry or a heavy arm.
+//
+// Stop must engage the brakes. Releasing the brakes through a component API safety monitors the
+// actuator, so that it is stopped, and its brakes therefore engaged, when the session that released
+// them ends. Motion requests made while the brakes are engaged either release them first, using the
+// most recent soft start, or fail.
+type Braker interface {
+	// EngageBrakes stops the actuator and engages its brakes.
+	EngageBrakes(ctx context.Context, extra map[string]interface{}) error
+
+	// ReleaseBrakes hands the actuator's axes over to its motors with the given soft start and
+	// then releases its brakes.
+	ReleaseBrakes(ctx context.Context, softStart SoftStart, extra map[string]interface{}) error
+
+	// BrakesEngaged returns whether the actuator's brakes are engaged.
+	BrakesEngaged(ctx context.Context, extra map[string]interface{}) (bool, error)
+}
+
+func (s SoftStart) toCommand() map[string]interface{} {
+	return map[string]interface{}{
+		"torque_ramp_sec": s.TorqueRampDuration.Seconds(),
+		"speed_ramp_sec":  s.SpeedRampDuration.Seconds(),
+	}
+}
+
+func softStartFromCommand(cmd interface{}) (SoftStart, error) {
+	var softStart SoftStart
+	if cmd == nil {
+		return softStart, nil
+	}
+	fields, ok := cmd.(map[string]interface{})
+	if !ok {
+		return softStart, errors.Errorf("%s must be an object, got %T", ReleaseBrakesCommand, cmd)
+	}
+	r := utils.NewAttributeReader(fields)
+	torqueRamp := utils.Optional(r, "torque_ramp_sec", 0.)
+	speedRamp := utils.Optional(r, "speed_ramp_sec", 0.)
+	if err := r.Err(); err != nil {
+		return softStart, errors.Wrapf(err, "invalid %s", ReleaseBrakesCommand)
+	}
+	if torqueRamp < 0 || speedRamp < 0 {
+		return softStart, errors.Errorf("%s ramps must not be negative", ReleaseBrakesCommand)
+	}
+	softStart.TorqueRampDuration = time.Duration(torqueRamp * float64(time.Second))
+	softStart.SpeedRampDuration = time.Duration(speedRamp * float64(time.Second))
+	return softStart, nil
+}
+
+// DoBrakeCommand handles a brake DoCommand on behalf of a resource server. It reports false if cmd is not
+// a brake command or res is not a Braker. monitor is called with res before the brakes are released, and
+// should safety monitor it for the calling session.
+func DoBrakeCommand(
+	ctx context.Context,
+	res Resource,
+	cmd map[string]interface{},
+	monitor func(context.Context, Resource),
+) (map[string]interface{}, bool, error) {
+	braker, ok := res.(Braker)
+	if !ok {
+		return nil, false, nil
+	}
+	if _, ok := cmd[EngageBrakesCommand]; ok {
+		return map[string]interface{}{}, true, braker.EngageBrakes(ctx, brakeExtraFromCommand(cmd))
+	}
+	if release, ok := cmd[ReleaseBrakesCommand]; ok {
+		softStart, err := softStartFromCommand(release)
+		if err != nil {
+			return nil, true, err
+		}
+		// Monitor the actuator before releasing its brakes so that they cannot be left released
+		// by a client that went away.
+		if monitor != nil {
+			monitor(ctx, res)
+		}
+		return map[string]interface{}{}, true, braker.ReleaseBrakes(ctx, softStart, brakeExtraFromCommand(cmd))
+	}
+	if _, ok := cmd[BrakesEngagedCommand]; ok {
+		engaged, err := braker.BrakesEngaged(ctx, brakeExtraFromCommand(cmd))
+		if err != nil {
+			return nil, true, err
+		}
+		return map[string]interface{}{BrakesEngagedCommand: engaged}, true, nil
+	}
+	return nil, false, nil
+}
+
+func brakeExtraFromCommand(cmd map[string]interface{}) map[string]interface{} {
+	extra, _ := cmd["extra"].(map[string]interface{})
+	return extra
+}
+
+// EngageBrakes engages the brakes of a resource through DoCommand, for clients of remote resources.
+func EngageBrakes(ctx context.Context, res Resource, extra map[string]interface{}) error {
+	_, err := res.DoCommand(ctx, map[string]interface{}{EngageBrakesCommand: true, "extra": extra})
+	return err
+}
+
+// ReleaseBrakes releases the brakes of a resource through DoCommand, for clients of remote resources.
+func ReleaseBrakes(ctx context.Context, res Resource, softStart SoftStart, extra map[string]interface{}) error {
+	_, err := res.DoCommand(ctx, map[string]interface{}{ReleaseBrakesCommand: softStart.toCommand(), "extra": extra})
+	return err
+}
+
+// BrakesEngaged returns whether the brakes of a resource are engaged through DoCommand, for clients of
+// remote resources.
+func BrakesEngaged(ctx context.Context, res Resource, extra map[string]interface{}) (bool, error) {
+	resp, err := res.DoCommand(ctx, map[string]interface{}{BrakesEngagedCommand: true, "extra": extra})
+	if err != nil {
+		return false, err
+	}
+	engaged, ok := resp[BrakesEngagedCommand].(bool)
+	if !ok {
+		return false, errors.Errorf("%q does not have brakes", res.Name())
+	}
+	return engaged, nil
+}
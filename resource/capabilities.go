@@ -0,0 +1,126 @@
+package resource
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// A Capability is an optional feature that a model supports, such as returning point clouds or
+// accepting torque commands. Models declare their capabilities in their Registration so that
+// clients can adapt to them without probing for unimplemented errors.
+type Capability string
+
+// Common capabilities. Models may declare capabilities beyond these.
+const (
+	CapabilityPointClouds      Capability = "point_clouds"
+	CapabilityTorqueControl    Capability = "torque_control"
+	CapabilityRegionOfInterest Capability = "region_of_interest"
+)
+
+// GetCapabilitiesCommand is the DoCommand key that returns a resource's capabilities, under
+// the "capabilities" key, instead of being passed to the resource. It is handled by the
+// resource servers of every API.
+const GetCapabilitiesCommand = "get_capabilities"
+
+// A CapabilityReporter is a resource that reports its own capabilities, which take precedence
+// over the capabilities declared in its registration.
+type CapabilityReporter interface {
+	Capabilities() []Capability
+}
+
+var (
+	resourceCapabilitiesMu sync.RWMutex
+	// resourceCapabilities holds the registered capabilities of resources built from
+	// registrations in this process, which excludes clients for remote resources.
+	resourceCapabilities = map[Resource][]Capability{}
+)
+
+// recordCapabilities records the capabilities of a resource built from a registration.
+func recordCapabilities(res Resource, caps []Capability) {
+	if res == nil || !reflect.TypeOf(res).Comparable() {
+		return
+	}
+	resourceCapabilitiesMu.Lock()
+	defer resourceCapabilitiesMu.Unlock()
+	resourceCapabilities[res] = append([]Capability{}, caps...)
+}
+
+// ForgetCapabilities forgets the capabilities recorded for a resource once it is closed.
+func ForgetCapabilities(res Resource) {
+	if res == nil || !reflect.TypeOf(res).Comparable() {
+		return
+	}
+	resourceCapabilitiesMu.Lock()
+	defer resourceCapabilitiesMu.Unlock()
+	delete(resourceCapabilities, res)
+}
+
+// CapabilitiesOf returns the capabilities of a local resource: those it reports itself if it
+// is a CapabilityReporter, and otherwise those declared by the registration it was built from.
+// It returns false if the resource's capabilities are not known locally, such as for clients
+// of remote resources.
+func CapabilitiesOf(res Resource) ([]Capability, bool) {
+	if reporter, ok := res.(CapabilityReporter); ok {
+		return reporter.Capabilities(), true
+	}
+	if res == nil || !reflect.TypeOf(res).Comparable() {
+		return nil, false
+	}
+	resourceCapabilitiesMu.RLock()
+	defer resourceCapabilitiesMu.RUnlock()
+	caps, ok := resourceCapabilities[res]
+	return slices.Clone(caps), ok
+}
+
+// IsGetCapabilitiesCommand returns whether a DoCommand command asks for capabilities.
+func IsGetCapabilitiesCommand(cmd map[string]interface{}) bool {
+	_, ok := cmd[GetCapabilitiesCommand]
+	return ok && len(cmd) == 1
+}
+
+// CapabilitiesResponse returns the DoCommand response to a GetCapabilitiesCommand.
+func CapabilitiesResponse(caps []Capability) map[string]interface{} {
+	list := make([]interface{}, 0, len(caps))
+	for _, c := range caps {
+		list = append(list, string(c))
+	}
+	return map[string]interface{}{"capabilities": list}
+}
+
+// GetCapabilities returns the capabilities of a resource, which may be local or a client
+// for a remote resource, in which case they are requested with GetCapabilitiesCommand.
+func GetCapabilities(ctx context.Context, res Resource) ([]Capability, error) {
+	if caps, ok := CapabilitiesOf(res); ok {
+		return caps, nil
+	}
+	resp, err := res.DoCommand(ctx, map[string]interface{}{GetCapabilitiesCommand: true})
+	if err != nil {
+		return nil, err
+	}
+	list, ok := resp["capabilities"].([]interface{})
+	if !ok {
+		return nil, errors.Errorf("expected capabilities list in response but got %T", resp["capabilities"])
+	}
+	caps := make([]Capability, 0, len(list))
+	for _, c := range list {
+		s, ok := c.(string)
+		if !ok {
+			return nil, errors.Errorf("expected capability string but got %T", c)
+		}
+		caps = append(caps, Capability(s))
+	}
+	return caps, nil
+}
+
+// HasCapability returns whether the resource has the given capability.
+func HasCapability(ctx context.Context, res Resource, capability Capability) (bool, error) {
+	caps, err := GetCapabilities(ctx, res)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(caps, capability), nil
+}
@@ -0,0 +1,71 @@
+package resource_test
+
+import (
+	"context"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/generic"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+func TestCapabilities(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+
+	model := resource.NewModel("acme", "capabilities", "camera")
+	resource.RegisterComponent(generic.API, model, resource.Registration[resource.Resource, resource.NoNativeConfig]{
+		Constructor: func(
+			ctx context.Context,
+			deps resource.Dependencies,
+			conf resource.Config,
+			logger logging.Logger,
+		) (resource.Resource, error) {
+			return inject.NewGenericComponent(conf.Name), nil
+		},
+		Capabilities: []resource.Capability{resource.CapabilityPointClouds},
+	})
+	defer resource.Deregister(generic.API, model)
+
+	reg, ok := resource.LookupRegistration(generic.API, model)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, reg.Capabilities, test.ShouldResemble, []resource.Capability{resource.CapabilityPointClouds})
+
+	res, err := reg.Constructor(ctx, nil, resource.Config{Name: "cam1", API: generic.API, Model: model}, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	caps, ok := resource.CapabilitiesOf(res)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, caps, test.ShouldResemble, []resource.Capability{resource.CapabilityPointClouds})
+
+	has, err := resource.HasCapability(ctx, res, resource.CapabilityPointClouds)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, has, test.ShouldBeTrue)
+	has, err = resource.HasCapability(ctx, res, resource.CapabilityTorqueControl)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, has, test.ShouldBeFalse)
+
+	resource.ForgetCapabilities(res)
+	_, ok = resource.CapabilitiesOf(res)
+	test.That(t, ok, test.ShouldBeFalse)
+
+	t.Run("remote", func(t *testing.T) {
+		remote := inject.NewGenericComponent("remote1")
+		remote.DoFunc = func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+			test.That(t, resource.IsGetCapabilitiesCommand(cmd), test.ShouldBeTrue)
+			return resource.CapabilitiesResponse([]resource.Capability{resource.CapabilityTorqueControl}), nil
+		}
+		caps, err := resource.GetCapabilities(ctx, remote)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, caps, test.ShouldResemble, []resource.Capability{resource.CapabilityTorqueControl})
+
+		remote.DoFunc = func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+			return map[string]interface{}{}, nil
+		}
+		_, err = resource.GetCapabilities(ctx, remote)
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
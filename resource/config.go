@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/pkg/errors"
@@ -24,15 +26,51 @@ type Config struct {
 	LogConfiguration *LogConfig
 	Attributes       utils.AttributeMap
 
+	// ReconfigurePriority orders resources that do not depend on each other. Within a
+	// dependency level, resources with a higher priority are fully (re)configured before
+	// any resource with a lower priority is started. Dependencies always take precedence.
+	ReconfigurePriority int
+
+	// ConfigurationTimeout, if set, is how long the resource may take to be built or
+	// reconfigured before it is given up on and marked unhealthy, in place of the machine
+	// wide resource configuration timeout.
+	ConfigurationTimeout time.Duration
+
+	// RefreshDependentsOnRecovery is whether the resources that depend on this one are
+	// reconfigured when it recovers from being unhealthy, so that they do not keep using
+	// state from before it failed.
+	RefreshDependentsOnRecovery bool
+
+	// LazyStart is whether building the resource is deferred until it is first used, either by
+	// a client, by a resource that depends on it, or by being started explicitly, so that
+	// power-hungry peripherals are not spun up on boot.
+	LazyStart bool
+
+	// Enabled, if set, is a condition on the machine's variables and environment, like
+	// `region == "arctic"`. The resource is left out of the machine when it is false.
+	Enabled string
+
+	// Labels are arbitrary key value pairs, like zone=front or safety=critical, that group
+	// resources regardless of their API. Resources can be listed by a LabelSelector on them.
+	Labels map[string]string
+
 	AssociatedResourceConfigs []AssociatedResourceConfig
 	AssociatedAttributes      map[Name]AssociatedConfig
 	ConvertedAttributes       ConfigValidator
 	ImplicitDependsOn         []string
 	ImplicitOptionalDependsOn []string
+	// ImplicitWarnings are non-fatal problems found validating the config elsewhere, such as
+	// by the module serving the resource.
+	ImplicitWarnings []ConfigWarning
+	// SecretAttributePaths are the paths, as map keys and list indices, of the attributes that
+	// were resolved from secret references, so that they can be masked wherever the config is
+	// shown.
+	SecretAttributePaths [][]string
 
 	alreadyValidated           bool
 	cachedImplicitDeps         []string
 	cachedOptionalImplicitDeps []string
+	cachedWarnings             []ConfigWarning
 	cachedErr                  error
 }
 
@@ -43,27 +81,39 @@ type LogConfig struct {
 
 // NOTE: This data must be maintained with what is in Config.
 type typeSpecificConfigData struct {
-	Name                      string                     `json:"name"`
-	Namespace                 string                     `json:"namespace"`
-	Subtype                   string                     `json:"type"`
-	Model                     Model                      `json:"model"`
-	Frame                     *referenceframe.LinkConfig `json:"frame,omitempty"`
-	DependsOn                 []string                   `json:"depends_on,omitempty"`
-	LogConfiguration          *LogConfig                 `json:"log_configuration"`
-	AssociatedResourceConfigs []AssociatedResourceConfig `json:"service_configs,omitempty"`
-	Attributes                utils.AttributeMap         `json:"attributes,omitempty"`
+	Name                        string                     `json:"name"`
+	Namespace                   string                     `json:"namespace"`
+	Subtype                     string                     `json:"type"`
+	Model                       Model                      `json:"model"`
+	Frame                       *referenceframe.LinkConfig `json:"frame,omitempty"`
+	DependsOn                   []string                   `json:"depends_on,omitempty"`
+	LogConfiguration            *LogConfig                 `json:"log_configuration"`
+	ReconfigurePriority         int                        `json:"reconfigure_priority,omitempty"`
+	ConfigurationTimeout        string                     `json:"configuration_timeout,omitempty"`
+	RefreshDependentsOnRecovery bool                       `json:"refresh_dependents_on_recovery,omitempty"`
+	LazyStart                   bool                       `json:"lazy_start,omitempty"`
+	Enabled                     string                     `json:"enabled,omitempty"`
+	Labels                      map[string]string          `json:"labels,omitempty"`
+	AssociatedResourceConfigs   []AssociatedResourceConfig `json:"service_configs,omitempty"`
+	Attributes                  utils.AttributeMap         `json:"attributes,omitempty"`
 }
 
 // NOTE: This data must be maintained with what is in Config.
 type configData struct {
-	Name                      string                     `json:"name"`
-	API                       API                        `json:"api"`
-	Model                     Model                      `json:"model"`
-	Frame                     *referenceframe.LinkConfig `json:"frame,omitempty"`
-	DependsOn                 []string                   `json:"depends_on,omitempty"`
-	LogConfiguration          *LogConfig                 `json:"log_configuration"`
-	AssociatedResourceConfigs []AssociatedResourceConfig `json:"service_configs,omitempty"`
-	Attributes                utils.AttributeMap         `json:"attributes,omitempty"`
+	Name                        string                     `json:"name"`
+	API                         API                        `json:"api"`
+	Model                       Model                      `json:"model"`
+	Frame                       *referenceframe.LinkConfig `json:"frame,omitempty"`
+	DependsOn                   []string                   `json:"depends_on,omitempty"`
+	LogConfiguration            *LogConfig                 `json:"log_configuration"`
+	ReconfigurePriority         int                        `json:"reconfigure_priority,omitempty"`
+	ConfigurationTimeout        string                     `json:"configuration_timeout,omitempty"`
+	RefreshDependentsOnRecovery bool                       `json:"refresh_dependents_on_recovery,omitempty"`
+	LazyStart                   bool                       `json:"lazy_start,omitempty"`
+	Enabled                     string                     `json:"enabled,omitempty"`
+	Labels                      map[string]string          `json:"labels,omitempty"`
+	AssociatedResourceConfigs   []AssociatedResourceConfig `json:"service_configs,omitempty"`
+	Attributes                  utils.AttributeMap         `json:"attributes,omitempty"`
 }
 
 // UnmarshalJSON unmarshals JSON into the config.
@@ -83,6 +133,16 @@ func (conf *Config) UnmarshalJSON(data []byte) error {
 		conf.Frame = confData.Frame
 		conf.DependsOn = confData.DependsOn
 		conf.LogConfiguration = confData.LogConfiguration
+		conf.ReconfigurePriority = confData.ReconfigurePriority
+		timeout, err := parseConfigurationTimeout(confData.ConfigurationTimeout)
+		if err != nil {
+			return err
+		}
+		conf.ConfigurationTimeout = timeout
+		conf.RefreshDependentsOnRecovery = confData.RefreshDependentsOnRecovery
+		conf.LazyStart = confData.LazyStart
+		conf.Enabled = confData.Enabled
+		conf.Labels = confData.Labels
 		conf.AssociatedResourceConfigs = confData.AssociatedResourceConfigs
 		conf.Attributes = confData.Attributes
 		return nil
@@ -99,22 +159,59 @@ func (conf *Config) UnmarshalJSON(data []byte) error {
 	conf.Frame = typeSpecificConf.Frame
 	conf.DependsOn = typeSpecificConf.DependsOn
 	conf.LogConfiguration = typeSpecificConf.LogConfiguration
+	conf.ReconfigurePriority = typeSpecificConf.ReconfigurePriority
+	timeout, err := parseConfigurationTimeout(typeSpecificConf.ConfigurationTimeout)
+	if err != nil {
+		return err
+	}
+	conf.ConfigurationTimeout = timeout
+	conf.RefreshDependentsOnRecovery = typeSpecificConf.RefreshDependentsOnRecovery
+	conf.LazyStart = typeSpecificConf.LazyStart
+	conf.Enabled = typeSpecificConf.Enabled
+	conf.Labels = typeSpecificConf.Labels
 	conf.AssociatedResourceConfigs = typeSpecificConf.AssociatedResourceConfigs
 	conf.Attributes = typeSpecificConf.Attributes
 	return nil
 }
 
+func parseConfigurationTimeout(timeout string) (time.Duration, error) {
+	if timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to parse configuration_timeout")
+	}
+	if d < 0 {
+		return 0, errors.Errorf("configuration_timeout must not be negative, got %v", d)
+	}
+	return d, nil
+}
+
+func configurationTimeoutString(timeout time.Duration) string {
+	if timeout == 0 {
+		return ""
+	}
+	return timeout.String()
+}
+
 // MarshalJSON marshals JSON from the config.
 func (conf Config) MarshalJSON() ([]byte, error) {
 	return json.Marshal(configData{
-		Name:                      conf.Name,
-		API:                       conf.API,
-		Model:                     conf.Model,
-		Frame:                     conf.Frame,
-		DependsOn:                 conf.DependsOn,
-		LogConfiguration:          conf.LogConfiguration,
-		AssociatedResourceConfigs: conf.AssociatedResourceConfigs,
-		Attributes:                conf.Attributes,
+		Name:                        conf.Name,
+		API:                         conf.API,
+		Model:                       conf.Model,
+		Frame:                       conf.Frame,
+		DependsOn:                   conf.DependsOn,
+		LogConfiguration:            conf.LogConfiguration,
+		ReconfigurePriority:         conf.ReconfigurePriority,
+		ConfigurationTimeout:        configurationTimeoutString(conf.ConfigurationTimeout),
+		RefreshDependentsOnRecovery: conf.RefreshDependentsOnRecovery,
+		LazyStart:                   conf.LazyStart,
+		Enabled:                     conf.Enabled,
+		Labels:                      conf.Labels,
+		AssociatedResourceConfigs:   conf.AssociatedResourceConfigs,
+		Attributes:                  conf.Attributes,
 	})
 }
 
@@ -204,7 +301,9 @@ func (conf Config) Equals(other Config) bool {
 	conf.alreadyValidated = false
 	conf.ImplicitDependsOn = nil
 	conf.ImplicitOptionalDependsOn = nil
+	conf.ImplicitWarnings = nil
 	conf.cachedImplicitDeps = nil
+	conf.cachedWarnings = nil
 	conf.cachedErr = nil
 	conf.ConvertedAttributes = nil
 	conf.AssociatedResourceConfigs = nil
@@ -213,7 +312,9 @@ func (conf Config) Equals(other Config) bool {
 	other.alreadyValidated = false
 	other.ImplicitDependsOn = nil
 	other.ImplicitOptionalDependsOn = nil
+	other.ImplicitWarnings = nil
 	other.cachedImplicitDeps = nil
+	other.cachedWarnings = nil
 	other.cachedErr = nil
 	other.ConvertedAttributes = nil
 	other.AssociatedResourceConfigs = nil
@@ -264,10 +365,27 @@ func (conf *Config) Validate(path, defaultAPIType string) ([]string, []string, e
 		return conf.cachedImplicitDeps, conf.cachedOptionalImplicitDeps, conf.cachedErr
 	}
 	conf.cachedImplicitDeps, conf.cachedOptionalImplicitDeps, conf.cachedErr = conf.validate(path, defaultAPIType)
+	conf.cachedWarnings = nil
+	if warner, ok := conf.ConvertedAttributes.(ConfigWarner); ok && conf.cachedErr == nil {
+		conf.cachedWarnings = warner.Warnings(path)
+	}
 	conf.alreadyValidated = true
 	return conf.cachedImplicitDeps, conf.cachedOptionalImplicitDeps, conf.cachedErr
 }
 
+// Warnings returns the non-fatal problems found the last time the config was validated,
+// followed by its ImplicitWarnings. It returns nil if the config has not been validated or
+// failed validation.
+func (conf *Config) Warnings() []ConfigWarning {
+	if !conf.alreadyValidated || conf.cachedErr != nil {
+		return nil
+	}
+	if len(conf.ImplicitWarnings) == 0 {
+		return conf.cachedWarnings
+	}
+	return append(slices.Clip(conf.cachedWarnings), conf.ImplicitWarnings...)
+}
+
 // AdjustPartialNames assumes this config comes from a place where the resource
 // name, API names, Model names, and associated config type names are partially
 // stored (JSON/Proto/Database) and will fix them up to the builtin values they
@@ -324,6 +442,9 @@ func (conf *Config) validate(path, defaultAPIType string) ([]string, []string, e
 	if err := conf.API.Validate(); err != nil {
 		return nil, nil, err
 	}
+	if err := ValidateLabels(conf.Labels); err != nil {
+		return nil, nil, NewConfigValidationError(path, err)
+	}
 	if conf.ConvertedAttributes != nil {
 		var err error
 		requiredDeps, optionalDeps, err = conf.ConvertedAttributes.Validate(path)
@@ -340,6 +461,65 @@ type ConfigValidator interface {
 	Validate(path string) (requiredDependencies, optionalDependencies []string, err error)
 }
 
+// A ConfigWarner is a ConfigValidator that can also report problems with a configuration
+// that do not prevent the resource from being built, such as deprecated fields or suspicious
+// values. Warnings is only called after Validate succeeds.
+type ConfigWarner interface {
+	ConfigValidator
+	Warnings(path string) []ConfigWarning
+}
+
+// ConfigWarningSeverity describes how much attention a ConfigWarning needs. Problems that
+// should prevent a resource from being built are returned as errors from Validate instead.
+type ConfigWarningSeverity string
+
+// The supported ConfigWarningSeverity values.
+const (
+	// ConfigWarningSeverityInfo is for notes that likely need no action.
+	ConfigWarningSeverityInfo ConfigWarningSeverity = "info"
+	// ConfigWarningSeverityWarning is for problems that should be fixed, such as deprecated
+	// fields or values that are allowed but likely wrong.
+	ConfigWarningSeverityWarning ConfigWarningSeverity = "warning"
+)
+
+// A ConfigWarning is a non-fatal problem with a resource configuration.
+type ConfigWarning struct {
+	// Path is the location of the config being validated, e.g. "components.0".
+	Path string
+	// Field is the attribute the warning is about, if any.
+	Field    string
+	Severity ConfigWarningSeverity
+	Message  string
+}
+
+// NewConfigWarning returns a ConfigWarning with warning severity about the given field.
+func NewConfigWarning(path, field, message string) ConfigWarning {
+	return ConfigWarning{Path: path, Field: field, Severity: ConfigWarningSeverityWarning, Message: message}
+}
+
+// NewDeprecatedFieldWarning returns a ConfigWarning about a deprecated field, naming the
+// field that should be used instead if there is one.
+func NewDeprecatedFieldWarning(path, field, replacement string) ConfigWarning {
+	msg := fmt.Sprintf("%q is deprecated", field)
+	if replacement != "" {
+		msg += fmt.Sprintf("; use %q instead", replacement)
+	}
+	return NewConfigWarning(path, field, msg)
+}
+
+func (w ConfigWarning) String() string {
+	var prefix string
+	switch {
+	case w.Path != "" && w.Field != "":
+		prefix = w.Path + "." + w.Field + ": "
+	case w.Path != "":
+		prefix = w.Path + ": "
+	case w.Field != "":
+		prefix = w.Field + ": "
+	}
+	return fmt.Sprintf("%s%s (%s)", prefix, w.Message, w.Severity)
+}
+
 // TransformAttributeMap uses an attribute map to transform attributes to the prescribed format.
 func TransformAttributeMap[T any](attributes utils.AttributeMap) (T, error) {
 	var out T
@@ -1,7 +1,9 @@
 package resource_test
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"go.viam.com/test"
 
@@ -27,6 +29,19 @@ func (convAttr *FakeConvertedAttributes) Validate(path string) ([]string, []stri
 	return nil, nil, nil
 }
 
+// fakeWarnerAttributes is a helper for testing validation warnings.
+type fakeWarnerAttributes struct {
+	FakeConvertedAttributes
+	OldThing string
+}
+
+func (convAttr *fakeWarnerAttributes) Warnings(path string) []resource.ConfigWarning {
+	if convAttr.OldThing == "" {
+		return nil
+	}
+	return []resource.ConfigWarning{resource.NewDeprecatedFieldWarning(path, "OldThing", "Thing")}
+}
+
 var (
 	acmeAPINamespace  = resource.APINamespace("acme")
 	fakeModel         = resource.DefaultModelFamily.WithModel("fake")
@@ -267,6 +282,50 @@ func TestComponentValidate(t *testing.T) {
 	})
 }
 
+func TestConfigWarnings(t *testing.T) {
+	attrs := &fakeWarnerAttributes{OldThing: "foo"}
+	conf := resource.Config{
+		Name:                "foo",
+		API:                 base.API,
+		Model:               fakeModel,
+		ConvertedAttributes: attrs,
+	}
+	test.That(t, conf.Warnings(), test.ShouldBeNil)
+
+	// a failed validation has no warnings
+	_, _, err := conf.Validate("path", resource.APITypeComponentName)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, conf.Warnings(), test.ShouldBeNil)
+
+	conf = resource.Config{
+		Name:                "foo",
+		API:                 base.API,
+		Model:               fakeModel,
+		ConvertedAttributes: &fakeWarnerAttributes{FakeConvertedAttributes{Thing: "bar"}, "foo"},
+	}
+	_, _, err = conf.Validate("path", resource.APITypeComponentName)
+	test.That(t, err, test.ShouldBeNil)
+	warnings := conf.Warnings()
+	test.That(t, warnings, test.ShouldHaveLength, 1)
+	test.That(t, warnings[0].Severity, test.ShouldEqual, resource.ConfigWarningSeverityWarning)
+	test.That(t, warnings[0].String(), test.ShouldEqual, `path.OldThing: "OldThing" is deprecated; use "Thing" instead (warning)`)
+
+	// warnings do not affect equality
+	other := conf
+	other.ConvertedAttributes = nil
+	test.That(t, conf.Equals(other), test.ShouldBeTrue)
+
+	// graph nodes report the warnings of their config
+	node := resource.NewUnconfiguredGraphNode(conf, nil)
+	test.That(t, node.Status().Warnings, test.ShouldResemble, warnings)
+
+	// warnings found elsewhere, such as by a module, follow the config's own
+	moduleWarning := resource.NewConfigWarning("path", "speed", "suspiciously fast")
+	conf.ImplicitWarnings = []resource.ConfigWarning{moduleWarning}
+	test.That(t, conf.Warnings(), test.ShouldResemble, append(warnings, moduleWarning))
+	test.That(t, conf.Equals(other), test.ShouldBeTrue)
+}
+
 func TestComponentResourceName(t *testing.T) {
 	for _, tc := range []struct {
 		Name          string
@@ -663,3 +722,24 @@ func TestEqual(t *testing.T) {
 		})
 	})
 }
+
+func TestConfigurationTimeoutJSON(t *testing.T) {
+	var conf resource.Config
+	err := json.Unmarshal([]byte(`{"name":"foo","api":"rdk:component:arm","model":"fake","configuration_timeout":"90s"}`), &conf)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, conf.ConfigurationTimeout, test.ShouldEqual, 90*time.Second)
+
+	data, err := json.Marshal(conf)
+	test.That(t, err, test.ShouldBeNil)
+	var roundTripped resource.Config
+	test.That(t, json.Unmarshal(data, &roundTripped), test.ShouldBeNil)
+	test.That(t, roundTripped.ConfigurationTimeout, test.ShouldEqual, 90*time.Second)
+
+	err = json.Unmarshal([]byte(`{"name":"foo","type":"arm","model":"fake","configuration_timeout":"-1s"}`), &conf)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "must not be negative")
+
+	err = json.Unmarshal([]byte(`{"name":"foo","type":"arm","model":"fake","configuration_timeout":"soon"}`), &conf)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "configuration_timeout")
+}
@@ -2,6 +2,7 @@ package resource
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -68,6 +69,36 @@ func (e *mustRebuildError) Error() string {
 	return fmt.Sprintf("cannot reconfigure %q; must rebuild", e.name)
 }
 
+// NewPanicError is used when building or reconfiguring a resource panicked with the given
+// value, and stack is where it did.
+func NewPanicError(recovered interface{}, stack []byte) error {
+	return &panicError{recovered: recovered, stack: stack}
+}
+
+// IsPanicError returns whether the given error is from a panic.
+func IsPanicError(err error) bool {
+	var errArt *panicError
+	return errors.As(err, &errArt)
+}
+
+// PanicStack returns the stack of the panic the error is from, if it is from one.
+func PanicStack(err error) []byte {
+	var errArt *panicError
+	if !errors.As(err, &errArt) {
+		return nil
+	}
+	return errArt.stack
+}
+
+type panicError struct {
+	recovered interface{}
+	stack     []byte
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprint(e.recovered)
+}
+
 // DependencyNotFoundError is used when a resource is not found in a dependencies.
 func DependencyNotFoundError(name Name) error {
 	// This error represents a logical configuration error. No need to include a stack trace.
@@ -85,3 +116,36 @@ func TypeError[T Resource](actual Resource) error {
 	// This error represents a coding error. Include a stack trace for diagnostics.
 	return errors.Errorf("expected implementation of %s but it was a %T", utils.TypeStr[T](), actual)
 }
+
+// A DependencyCycleError is returned when a dependency would close a cycle in the resource graph.
+type DependencyCycleError struct {
+	// Cycle lists the resources around the cycle, each depending on the next, starting and ending
+	// with the resource whose new dependency closes it.
+	Cycle []Name
+	// Fields holds the config field that created each dependency in Cycle, like "depends_on",
+	// or "" where it is not known.
+	Fields []string
+}
+
+func (e *DependencyCycleError) Error() string {
+	if len(e.Cycle) < 2 {
+		return "circular dependency"
+	}
+	names := make([]string, 0, len(e.Cycle))
+	for _, name := range e.Cycle {
+		names = append(names, name.ShortName())
+	}
+	msg := fmt.Sprintf("circular dependency - %q already depends on %q: %s",
+		e.Cycle[1].Name, e.Cycle[0].Name, strings.Join(names, " -> "))
+
+	var edges []string
+	for i, field := range e.Fields {
+		if field != "" && i+1 < len(names) {
+			edges = append(edges, fmt.Sprintf("%s depends on %s through %s", names[i], names[i+1], field))
+		}
+	}
+	if len(edges) > 0 {
+		msg += " (" + strings.Join(edges, "; ") + ")"
+	}
+	return msg
+}
@@ -0,0 +1,91 @@
+package resource
+
+import (
+	"context"
+	"sync"
+)
+
+// resourceEventBufferSize is how many events a subscriber may fall behind by before
+// events are dropped for it.
+const resourceEventBufferSize = 64
+
+// A ResourceEvent is a change in the lifecycle state of a resource graph node.
+type ResourceEvent struct {
+	NodeStatus
+
+	// Removed is whether the node was removed from the graph, in which case NodeStatus
+	// is its last status.
+	Removed bool
+}
+
+// resourceEvents fans resource events out to subscribers.
+type resourceEvents struct {
+	mu          sync.Mutex
+	subscribers map[chan ResourceEvent]struct{}
+}
+
+// listener returns a function for the node with the given name to report transitions with.
+func (e *resourceEvents) listener(name Name) func(NodeStatus) {
+	return func(status NodeStatus) {
+		status.Name = name
+		e.publish(ResourceEvent{NodeStatus: status})
+	}
+}
+
+func (e *resourceEvents) publish(event ResourceEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for sub := range e.subscribers {
+		select {
+		case sub <- event:
+		default:
+			// the subscriber fell behind; it can catch up through the graph's Status
+		}
+	}
+}
+
+func (e *resourceEvents) subscribe(ctx context.Context) <-chan ResourceEvent {
+	sub := make(chan ResourceEvent, resourceEventBufferSize)
+	e.mu.Lock()
+	e.subscribers[sub] = struct{}{}
+	e.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		delete(e.subscribers, sub)
+		close(sub)
+	}()
+	return sub
+}
+
+// SubscribeEvents returns a channel of events for every lifecycle state transition of the
+// graph's nodes, and for every node removed from the graph, until ctx is done, at which
+// point the channel is closed. Events are dropped for subscribers that fall behind, so
+// subscribers that need a complete picture should reconcile against Status.
+func (g *Graph) SubscribeEvents(ctx context.Context) <-chan ResourceEvent {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.events == nil {
+		// Events are only wired up once subscribed to, so that graphs used as scratch space
+		// never take over the listeners of nodes they share with a subscribed graph.
+		g.events = &resourceEvents{subscribers: map[chan ResourceEvent]struct{}{}}
+		for name, node := range g.nodes {
+			node.setTransitionListener(g.events.listener(name))
+		}
+	}
+	return g.events.subscribe(ctx)
+}
+
+// publishRemoval reports that a node was removed from the graph.
+// This method is NOT threadsafe: A client must hold [Graph.mu] while calling this method.
+func (g *Graph) publishRemoval(name Name, node *GraphNode) {
+	if g.events == nil {
+		return
+	}
+	node.setTransitionListener(nil)
+	status := node.Status()
+	status.Name = name
+	g.events.publish(ResourceEvent{NodeStatus: status, Removed: true})
+}
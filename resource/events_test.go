@@ -0,0 +1,70 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/logging"
+)
+
+func nextEvent(t *testing.T, events <-chan ResourceEvent) ResourceEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for resource event")
+		return ResourceEvent{}
+	}
+}
+
+func TestGraphSubscribeEvents(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	g := NewGraph(logger)
+
+	nameA := NewName(apiA, "a")
+	nameB := NewName(apiA, "b")
+	nodeA := NewUnconfiguredGraphNode(Config{}, nil)
+	test.That(t, g.AddNode(nameA, nodeA), test.ShouldBeNil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := g.SubscribeEvents(ctx)
+
+	// nodes added before and after subscribing both report transitions
+	nodeA.SwapResource(&someResource{Named: nameA.AsNamed()}, DefaultModelFamily.WithModel("foo"), nil)
+	event := nextEvent(t, events)
+	test.That(t, event.Name, test.ShouldResemble, nameA)
+	test.That(t, event.State, test.ShouldEqual, NodeStateReady)
+	test.That(t, event.Removed, test.ShouldBeFalse)
+
+	nodeB := NewUninitializedNode()
+	test.That(t, g.AddNode(nameB, nodeB), test.ShouldBeNil)
+	nodeB.LogAndSetLastError(errors.New("failed to build"))
+	event = nextEvent(t, events)
+	test.That(t, event.Name, test.ShouldResemble, nameB)
+	test.That(t, event.State, test.ShouldEqual, NodeStateUnhealthy)
+	test.That(t, event.Error, test.ShouldBeError, "failed to build")
+
+	// scratch graphs do not take over the listeners of the nodes they share
+	scratch := NewGraph(logger)
+	test.That(t, scratch.CopyNodeAndChildren(nameA, g), test.ShouldBeNil)
+	toRemove := NewGraph(logger)
+	test.That(t, toRemove.AddNode(nameA, nodeA), test.ShouldBeNil)
+	g.MarkForRemoval(toRemove)
+	event = nextEvent(t, events)
+	test.That(t, event.Name, test.ShouldResemble, nameA)
+	test.That(t, event.State, test.ShouldEqual, NodeStateRemoving)
+
+	test.That(t, g.RemoveMarked(), test.ShouldHaveLength, 1)
+	event = nextEvent(t, events)
+	test.That(t, event.Name, test.ShouldResemble, nameA)
+	test.That(t, event.Removed, test.ShouldBeTrue)
+
+	cancel()
+	_, ok := <-events
+	test.That(t, ok, test.ShouldBeFalse)
+}
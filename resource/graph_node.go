@@ -3,6 +3,7 @@ package resource
 import (
 	"context"
 	"errors"
+	"maps"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -39,6 +40,16 @@ const (
 	NodeStateUnhealthy
 )
 
+// NodeStateFromString returns the state whose String is name, or NodeStateUnknown if there is none.
+func NodeStateFromString(name string) NodeState {
+	for s := NodeStateUnknown; s <= NodeStateUnhealthy; s++ {
+		if s.String() == name {
+			return s
+		}
+	}
+	return NodeStateUnknown
+}
+
 // A GraphNode contains the current state of a resource.
 // Based on these states, the underlying Resource may or may not be available.
 // Additionally, the node can be informed that the resource either needs to be
@@ -56,6 +67,8 @@ type GraphNode struct {
 	// incremented by this GraphNode's SwapResource method. It is only referenced
 	// in tests.
 	updatedAt int64
+	// generation is how many times the resource has been built or reconfigured.
+	generation uint64
 
 	current      Resource
 	currentModel Model
@@ -67,6 +80,9 @@ type GraphNode struct {
 	lastErr                   error
 	unresolvedDependencies    []string
 	needsDependencyResolution bool
+	// unresolvedReasons explains why each unresolved dependency could not be resolved the
+	// last time the graph tried to.
+	unresolvedReasons map[string]string
 
 	logger logging.Logger
 
@@ -85,11 +101,37 @@ type GraphNode struct {
 	// unreachable is an informational field that indicates if a resource on a remote
 	// machine is disconnected.
 	unreachable bool
+
+	// healthErr is the error from the most recent health check of the resource, if it is a
+	// HealthChecker. healthCheckInFlight is whether a check of it has not returned yet.
+	healthErr           error
+	healthCheckInFlight bool
+
+	// failed is whether the node has been unhealthy since it was last ready. recovered is
+	// whether it has since become ready again, and has not yet been reported by
+	// Graph.TakeRecovered.
+	failed    bool
+	recovered bool
+
+	// onTransition, if set, is called with the node's status after every state transition.
+	onTransition func(NodeStatus)
+
+	// configureStats times the attempts to build and reconfigure the resource.
+	configureStats ConfigureStats
+	// consecutiveCrashes is how many times in a row building or reconfiguring the resource has
+	// panicked, and lastCrash is when it last did, to back off from retrying it.
+	consecutiveCrashes int
+	lastCrash          time.Time
+
+	// startRequested is whether the resource has been asked to start, if its config defers
+	// building it until then.
+	startRequested bool
 }
 
 var (
 	errNotInitalized  = errors.New("resource not initialized yet")
 	errPendingRemoval = errors.New("resource is pending removal")
+	errNotStarted     = errors.New("resource has lazy_start set and has not been started yet")
 )
 
 // NewUninitializedNode returns a node that is brand new and not yet initialized.
@@ -118,6 +160,15 @@ func NewConfiguredGraphNode(config Config, res Resource, resModel Model) *GraphN
 	return node
 }
 
+// Generation returns how many times the resource within this GraphNode has been successfully
+// built or reconfigured. It only increases, so a change in it tells callers that cached state
+// about the resource may be stale.
+func (w *GraphNode) Generation() uint64 {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.generation
+}
+
 // UpdatedAt returns the value of the logical clock when SwapResource was last
 // called on this GraphNode (the resource was last updated). It's only used
 // for tests.
@@ -133,6 +184,12 @@ func (w *GraphNode) setGraphLogicalClock(clock *atomic.Int64) {
 	w.graphLogicalClock = clock
 }
 
+func (w *GraphNode) setTransitionListener(onTransition func(NodeStatus)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onTransition = onTransition
+}
+
 // LastReconfigured returns a pointer to the time at which the resource within
 // this GraphNode was constructed or last reconfigured. It returns nil if the
 // GraphNode is unconfigured.
@@ -154,6 +211,9 @@ func (w *GraphNode) Resource() (Resource, error) {
 		return nil, w.lastErr
 	}
 	if w.current == nil {
+		if w.deferred() {
+			return nil, errNotStarted
+		}
 		return nil, errNotInitalized
 	}
 	return w.current, nil
@@ -234,7 +294,7 @@ func (w *GraphNode) UnsetResource() {
 // to be a working resource and as such we unmark it for removal
 // and indicate it no longer needs reconfiguration. SwapResource also
 // increments the graphLogicalClock and sets updatedAt for this GraphNode
-// to the new value.
+// to the new value, and bumps its generation.
 //
 // The `ftdc` input may be nil (e.g: testing). If present, this will also updates FTDC to
 // communicate that the `Stats` method may return different values. As we'll now be calling `Stats`
@@ -246,15 +306,18 @@ func (w *GraphNode) SwapResource(newRes Resource, newModel Model, ftdc *ftdc.FTD
 	w.currentModel = newModel
 	w.revision = w.pendingRevision
 	w.lastErr = nil
+	w.healthErr = nil
 	w.transitionTo(NodeStateReady)
 
 	// these should already be set
 	w.unresolvedDependencies = nil
 	w.needsDependencyResolution = false
+	w.unresolvedReasons = nil
 
 	if w.graphLogicalClock != nil {
 		w.updatedAt = w.graphLogicalClock.Add(1)
 	}
+	w.generation++
 	now := time.Now()
 	w.lastReconfigured = &now
 
@@ -307,6 +370,13 @@ func (w *GraphNode) Config() Config {
 	return w.config
 }
 
+// Labels returns a copy of the labels in the config this resource is using.
+func (w *GraphNode) Labels() map[string]string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return maps.Clone(w.config.Labels)
+}
+
 // NeedsReconfigure returns whether or not this node needs reconfiguration
 // performed on its underlying resource.
 func (w *GraphNode) NeedsReconfigure() bool {
@@ -318,6 +388,30 @@ func (w *GraphNode) NeedsReconfigure() bool {
 	return w.state == NodeStateConfiguring || w.state == NodeStateUnhealthy
 }
 
+// Deferred returns whether building the resource is deferred until it is asked to start, as it
+// has lazy_start set and has never been built.
+func (w *GraphNode) Deferred() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.deferred()
+}
+
+func (w *GraphNode) deferred() bool {
+	return w.config.LazyStart && !w.startRequested && w.current == nil
+}
+
+// RequestStart asks for the resource to be built the next time the graph is configured, if it is
+// deferred, and returns whether it was.
+func (w *GraphNode) RequestStart() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.deferred() {
+		return false
+	}
+	w.startRequested = true
+	return true
+}
+
 // hasUnresolvedDependencies returns whether or not this node has any
 // dependencies to be resolved (even if they are empty).
 func (w *GraphNode) hasUnresolvedDependencies() bool {
@@ -342,6 +436,7 @@ func (w *GraphNode) setNeedsReconfigure(newConfig Config, mustReconfigure bool,
 	w.config = newConfig
 	w.transitionTo(NodeStateConfiguring)
 	w.unresolvedDependencies = dependencies
+	w.unresolvedReasons = nil
 }
 
 // UpdatePendingRevision sets the next revision to be applied once the node is in a
@@ -376,6 +471,11 @@ func (w *GraphNode) markReachability(reachable bool) {
 // this unmarks it.
 func (w *GraphNode) SetNewConfig(newConfig Config, dependencies []string) {
 	w.setNeedsReconfigure(newConfig, true, dependencies)
+
+	// a resource that crashed is tried again right away with a new config, which may fix it
+	w.mu.Lock()
+	w.consecutiveCrashes = 0
+	w.mu.Unlock()
 }
 
 // SetNeedsUpdate is used to inform the node that it should
@@ -407,6 +507,14 @@ func (w *GraphNode) setUnresolvedDependencies(names ...string) {
 	w.needsDependencyResolution = true
 }
 
+// setUnresolvedDependencyReasons records why each unresolved dependency could not be
+// resolved.
+func (w *GraphNode) setUnresolvedDependencyReasons(reasons map[string]string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.unresolvedReasons = reasons
+}
+
 // setDependenciesResolved sets that all unresolved dependencies have been
 // resolved and linked/unlinked from the calling resource graph.
 func (w *GraphNode) setDependenciesResolved() {
@@ -428,6 +536,30 @@ func (w *GraphNode) UnresolvedDependencies() []string {
 	return unresolvedDependencies
 }
 
+// UnresolvedDependencyReasons returns why each dependency that is yet to be resolved could
+// not be, such as it not being found or closing a dependency cycle. It returns nil if all
+// dependencies are resolved.
+func (w *GraphNode) UnresolvedDependencyReasons() map[string]string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.unresolvedDependencyReasons()
+}
+
+func (w *GraphNode) unresolvedDependencyReasons() map[string]string {
+	if len(w.unresolvedDependencies) == 0 {
+		return nil
+	}
+	reasons := make(map[string]string, len(w.unresolvedDependencies))
+	for _, dep := range w.unresolvedDependencies {
+		reason, ok := w.unresolvedReasons[dep]
+		if !ok {
+			reason = "not resolved yet"
+		}
+		reasons[dep] = reason
+	}
+	return reasons
+}
+
 // Close closes the underlying resource of this node.
 func (w *GraphNode) Close(ctx context.Context) error {
 	w.mu.Lock()
@@ -441,6 +573,7 @@ func (w *GraphNode) Close(ctx context.Context) error {
 	// Unlock before calling Close() on underlying resource, since Close() behavior can be unpredictable
 	// and usage of the graph node should not block on the underlying resource being closed.
 	w.mu.Unlock()
+	ForgetCapabilities(current)
 	// TODO(RSDK-7928): we might want to make this transition a node to an "unconfigured"
 	// or "removing" state.
 	return current.Close(ctx)
@@ -458,6 +591,7 @@ func (w *GraphNode) replace(other *GraphNode) error {
 
 	other.mu.Lock()
 	w.updatedAt = other.updatedAt
+	w.generation = other.generation
 	if other.graphLogicalClock != nil {
 		w.graphLogicalClock = other.graphLogicalClock
 	}
@@ -468,12 +602,15 @@ func (w *GraphNode) replace(other *GraphNode) error {
 	w.lastErr = other.lastErr
 	w.unresolvedDependencies = other.unresolvedDependencies
 	w.needsDependencyResolution = other.needsDependencyResolution
+	w.unresolvedReasons = other.unresolvedReasons
+	w.startRequested = other.startRequested
 
 	w.state = other.state
 	w.transitionedAt = other.transitionedAt
 
 	// other is now owned by the graph/node and is invalidated
 	other.updatedAt = 0
+	other.generation = 0
 	other.graphLogicalClock = nil
 	other.lastReconfigured = nil
 	other.current = nil
@@ -482,6 +619,8 @@ func (w *GraphNode) replace(other *GraphNode) error {
 	other.lastErr = nil
 	other.unresolvedDependencies = nil
 	other.needsDependencyResolution = false
+	other.unresolvedReasons = nil
+	other.startRequested = false
 
 	other.state = NodeStateUnknown
 	other.transitionedAt = time.Time{}
@@ -559,8 +698,21 @@ func (w *GraphNode) transitionTo(state NodeState) {
 		w.logger.Warnw("unexpected resource state transition", "from", w.state.String(), "to", state.String())
 	}
 
+	switch state {
+	case NodeStateUnhealthy:
+		w.failed = true
+	case NodeStateReady:
+		if w.failed {
+			w.failed = false
+			w.recovered = true
+		}
+	default:
+	}
 	w.state = state
 	w.transitionedAt = time.Now()
+	if w.onTransition != nil {
+		w.onTransition(w.status())
+	}
 }
 
 // Status returns the current [NodeStatus].
@@ -585,18 +737,124 @@ func (w *GraphNode) status() NodeStatus {
 		err = nil
 	}
 
+	state := w.state
+	if state == NodeStateReady && w.healthErr != nil {
+		// the resource was built, but has since stopped working
+		state = NodeStateUnhealthy
+		err = w.healthErr
+	}
+
 	// TODO (RSDK-9550): Node should have the correct notion of its name
 	return NodeStatus{
-		State:       w.state,
+		State:       state,
 		LastUpdated: w.transitionedAt,
 		Revision:    w.revision,
+		Generation:  w.generation,
 		Error:       err,
+		Warnings:    w.config.Warnings(),
+
+		UnresolvedDependencies: w.unresolvedDependencyReasons(),
+		Configure:              w.configureStats,
 	}
 }
 
+// RecordConfigure records an attempt to build the resource, if built is true, or to reconfigure
+// it, that took the given duration and failed with err if it is not nil.
+func (w *GraphNode) RecordConfigure(built bool, duration time.Duration, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if built {
+		w.configureStats.Builds.record(duration, err)
+	} else {
+		w.configureStats.Reconfigures.record(duration, err)
+	}
+	switch {
+	case IsPanicError(err):
+		w.consecutiveCrashes++
+		w.lastCrash = time.Now()
+	case err == nil:
+		w.consecutiveCrashes = 0
+	}
+}
+
+// crashBackoffBase and crashBackoffMax bound how long a resource that panicked while being
+// built or reconfigured is left alone before it is retried, which doubles with every crash in a row.
+const (
+	crashBackoffBase = 5 * time.Second
+	crashBackoffMax  = 5 * time.Minute
+)
+
+// CrashBackoff returns how much longer to wait before retrying to build or reconfigure the
+// resource, because doing so panicked the last time. It is zero if the resource can be retried.
+func (w *GraphNode) CrashBackoff() time.Duration {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.consecutiveCrashes == 0 {
+		return 0
+	}
+	backoff := crashBackoffMax
+	if shift := w.consecutiveCrashes - 1; shift < 16 {
+		backoff = min(crashBackoffBase<<shift, crashBackoffMax)
+	}
+	return max(backoff-time.Since(w.lastCrash), 0)
+}
+
+// ConfigureStats returns how many times the resource has been built and reconfigured and how
+// long that took.
+func (w *GraphNode) ConfigureStats() ConfigureStats {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.configureStats
+}
+
+// ConfigureStats describes the attempts to build and reconfigure a resource, to find the
+// resources responsible for slow startups and reconfigures.
+type ConfigureStats struct {
+	Builds       ConfigureAttempts `json:"builds"`
+	Reconfigures ConfigureAttempts `json:"reconfigures"`
+}
+
+// Crashes returns how many attempts to build or reconfigure the resource panicked.
+func (s ConfigureStats) Crashes() int {
+	return s.Builds.Crashes + s.Reconfigures.Crashes
+}
+
+// ConfigureAttempts counts attempts to build or reconfigure a resource, and how long they took.
+// An attempt that times out is only recorded once it returns, if it ever does. Crashes counts the
+// failures that were panics, which are recovered from so that the rest of the machine keeps running.
+type ConfigureAttempts struct {
+	Attempts      int           `json:"attempts"`
+	Failures      int           `json:"failures"`
+	Crashes       int           `json:"crashes"`
+	LastDuration  time.Duration `json:"last_duration"`
+	MaxDuration   time.Duration `json:"max_duration"`
+	TotalDuration time.Duration `json:"total_duration"`
+}
+
+func (a *ConfigureAttempts) record(duration time.Duration, err error) {
+	a.Attempts++
+	if err != nil {
+		a.Failures++
+	}
+	if IsPanicError(err) {
+		a.Crashes++
+	}
+	a.LastDuration = duration
+	a.MaxDuration = max(a.MaxDuration, duration)
+	a.TotalDuration += duration
+}
+
 type graphNodeStats struct {
 	State    int
 	ResStats any
+
+	BuildAttempts       int
+	BuildFailures       int
+	LastBuildSecs       float64
+	ReconfigureAttempts int
+	ReconfigureFailures int
+	LastReconfigureSecs float64
+	Crashes             int
 }
 
 // Stats satisfies the FTDC Statser interface.
@@ -621,6 +879,15 @@ func (w *GraphNode) Stats() any {
 		ret.ResStats = statser.Stats()
 	}
 
+	configureStats := w.ConfigureStats()
+	ret.BuildAttempts = configureStats.Builds.Attempts
+	ret.BuildFailures = configureStats.Builds.Failures
+	ret.LastBuildSecs = configureStats.Builds.LastDuration.Seconds()
+	ret.ReconfigureAttempts = configureStats.Reconfigures.Attempts
+	ret.ReconfigureFailures = configureStats.Reconfigures.Failures
+	ret.LastReconfigureSecs = configureStats.Reconfigures.LastDuration.Seconds()
+	ret.Crashes = configureStats.Crashes()
+
 	return ret
 }
 
@@ -630,8 +897,23 @@ type NodeStatus struct {
 	State       NodeState
 	LastUpdated time.Time
 	Revision    string
+	// Generation is how many times the resource has been successfully built or reconfigured.
+	// Clients can compare it to one seen before to detect that the resource was rebuilt, or
+	// reconfigured, underneath them.
+	Generation uint64
 
 	// Error contains any errors on the resource if it currently unhealthy.
 	// This field will be nil if the resource is not in the [NodeStateUnhealthy] state.
 	Error error
+
+	// Warnings contains non-fatal problems found when validating the resource's config.
+	Warnings []ConfigWarning
+
+	// UnresolvedDependencies maps each dependency of the resource that could not be resolved
+	// to why, such as it not being found or closing a dependency cycle.
+	UnresolvedDependencies map[string]string
+
+	// Configure describes how many times the resource has been built and reconfigured, and how
+	// long that took.
+	Configure ConfigureStats
 }
@@ -363,3 +363,100 @@ func TestTransitionToBlocking(t *testing.T) {
 	// Node should stay still be in state removing
 	test.That(t, node.MarkedForRemoval(), test.ShouldBeTrue)
 }
+
+func TestRecordConfigure(t *testing.T) {
+	node := withTestLogger(t, resource.NewUninitializedNode())
+	test.That(t, node.ConfigureStats(), test.ShouldResemble, resource.ConfigureStats{})
+
+	node.RecordConfigure(true, 3*time.Second, errors.New("camera not found"))
+	node.RecordConfigure(true, 2*time.Second, nil)
+	node.RecordConfigure(false, 10*time.Millisecond, nil)
+
+	stats := node.ConfigureStats()
+	test.That(t, stats.Builds, test.ShouldResemble, resource.ConfigureAttempts{
+		Attempts:      2,
+		Failures:      1,
+		LastDuration:  2 * time.Second,
+		MaxDuration:   3 * time.Second,
+		TotalDuration: 5 * time.Second,
+	})
+	test.That(t, stats.Reconfigures.Attempts, test.ShouldEqual, 1)
+	test.That(t, stats.Reconfigures.Failures, test.ShouldEqual, 0)
+	test.That(t, stats.Reconfigures.LastDuration, test.ShouldEqual, 10*time.Millisecond)
+	test.That(t, node.Status().Configure, test.ShouldResemble, stats)
+}
+
+func TestCrashBackoff(t *testing.T) {
+	node := withTestLogger(t, resource.NewUnconfiguredGraphNode(resource.Config{}, nil))
+	test.That(t, node.CrashBackoff(), test.ShouldEqual, 0)
+
+	crash := errors.Wrap(resource.NewPanicError("boom", nil), "panic creating resource")
+	test.That(t, resource.IsPanicError(crash), test.ShouldBeTrue)
+	test.That(t, crash.Error(), test.ShouldEqual, "panic creating resource: boom")
+
+	node.RecordConfigure(true, time.Millisecond, crash)
+	test.That(t, node.ConfigureStats().Builds.Crashes, test.ShouldEqual, 1)
+	backoff := node.CrashBackoff()
+	test.That(t, backoff, test.ShouldBeGreaterThan, 0)
+
+	// every crash in a row doubles the backoff, while other failures leave it as is
+	node.RecordConfigure(true, time.Millisecond, crash)
+	node.RecordConfigure(true, time.Millisecond, errors.New("camera not found"))
+	test.That(t, node.CrashBackoff(), test.ShouldBeGreaterThan, backoff)
+	test.That(t, node.ConfigureStats().Builds, test.ShouldResemble, resource.ConfigureAttempts{
+		Attempts:      3,
+		Failures:      3,
+		Crashes:       2,
+		LastDuration:  time.Millisecond,
+		MaxDuration:   time.Millisecond,
+		TotalDuration: 3 * time.Millisecond,
+	})
+
+	// succeeding resets the backoff but not the count
+	node.RecordConfigure(false, time.Millisecond, nil)
+	test.That(t, node.CrashBackoff(), test.ShouldEqual, 0)
+	test.That(t, node.Status().Configure.Crashes(), test.ShouldEqual, 2)
+
+	// so does a new config
+	node.RecordConfigure(false, time.Millisecond, crash)
+	test.That(t, node.CrashBackoff(), test.ShouldBeGreaterThan, 0)
+	node.SetNewConfig(resource.Config{}, nil)
+	test.That(t, node.CrashBackoff(), test.ShouldEqual, 0)
+	test.That(t, node.Status().Configure.Crashes(), test.ShouldEqual, 3)
+}
+
+func TestGeneration(t *testing.T) {
+	node := withTestLogger(t, resource.NewUninitializedNode())
+	test.That(t, node.Generation(), test.ShouldEqual, 0)
+
+	ourRes := &someResource{Resource: testutils.NewUnimplementedResource(generic.Named("foo"))}
+	node.SwapResource(ourRes, resource.DefaultModelFamily.WithModel("bar"), nil)
+	test.That(t, node.Generation(), test.ShouldEqual, 1)
+
+	// failing to reconfigure leaves the generation as is
+	node.SetNeedsUpdate()
+	node.LogAndSetLastError(errors.New("whoops"))
+	test.That(t, node.Generation(), test.ShouldEqual, 1)
+
+	node.SwapResource(ourRes, resource.DefaultModelFamily.WithModel("bar"), nil)
+	test.That(t, node.Generation(), test.ShouldEqual, 2)
+	test.That(t, node.Status().Generation, test.ShouldEqual, 2)
+}
+
+func TestLazyStartNode(t *testing.T) {
+	node := withTestLogger(t, resource.NewUnconfiguredGraphNode(resource.Config{LazyStart: true}, nil))
+	test.That(t, node.Deferred(), test.ShouldBeTrue)
+	_, err := node.Resource()
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "lazy_start")
+
+	test.That(t, node.RequestStart(), test.ShouldBeTrue)
+	test.That(t, node.Deferred(), test.ShouldBeFalse)
+	test.That(t, node.NeedsReconfigure(), test.ShouldBeTrue)
+	test.That(t, node.RequestStart(), test.ShouldBeFalse)
+
+	// resources without lazy_start are never deferred
+	node = withTestLogger(t, resource.NewUnconfiguredGraphNode(resource.Config{}, nil))
+	test.That(t, node.Deferred(), test.ShouldBeFalse)
+	test.That(t, node.RequestStart(), test.ShouldBeFalse)
+}
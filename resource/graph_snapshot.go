@@ -0,0 +1,126 @@
+package resource
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"time"
+)
+
+// A GraphSnapshot is a copy of a resource graph, taken at one point in time, that shares no
+// state with the graph. It can be inspected and serialized as JSON without racing the graph
+// being reconfigured.
+type GraphSnapshot struct {
+	// LogicalClock is the graph's logical clock when the snapshot was taken. It increases every
+	// time a resource in the graph is built or reconfigured.
+	LogicalClock int64 `json:"logical_clock"`
+	// Nodes are sorted by name.
+	Nodes []NodeSnapshot `json:"nodes"`
+	// Edges are sorted by dependent, then by dependency.
+	Edges []EdgeSnapshot `json:"edges"`
+}
+
+// A NodeSnapshot is a copy of a resource graph node.
+type NodeSnapshot struct {
+	Name   string `json:"name"`
+	API    string `json:"api"`
+	Remote string `json:"remote,omitempty"`
+	// Model is empty until the resource has been built.
+	Model       string    `json:"model,omitempty"`
+	State       string    `json:"state"`
+	LastUpdated time.Time `json:"last_updated"`
+	// LastReconfigured is when the resource was last built or reconfigured, if ever.
+	LastReconfigured *time.Time `json:"last_reconfigured,omitempty"`
+	Revision         string     `json:"revision,omitempty"`
+	// Generation is how many times the resource has been successfully built or reconfigured.
+	Generation uint64 `json:"generation"`
+	// Error is why the resource is unhealthy, if it is.
+	Error string `json:"error,omitempty"`
+	// Unreachable is whether the resource is on a remote that cannot be reached.
+	Unreachable bool              `json:"unreachable,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	// UnresolvedDependencies maps each dependency that could not be resolved to why.
+	UnresolvedDependencies map[string]string `json:"unresolved_dependencies,omitempty"`
+	// Configure describes the attempts to build and reconfigure the resource.
+	Configure ConfigureStats `json:"configure"`
+}
+
+// An EdgeSnapshot is a dependency between two resource graph nodes.
+type EdgeSnapshot struct {
+	// Dependent is the name of the resource that depends on Dependency.
+	Dependent  string `json:"dependent"`
+	Dependency string `json:"dependency"`
+}
+
+// Snapshot returns a copy of the graph's nodes, with their states, last errors and when they
+// were last reconfigured, and of the dependencies between them.
+func (g *Graph) Snapshot() GraphSnapshot {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	snapshot := GraphSnapshot{
+		LogicalClock: g.logicalClock.Load(),
+		Nodes:        make([]NodeSnapshot, 0, len(g.nodes)),
+		Edges:        []EdgeSnapshot{},
+	}
+	for _, nameNode := range nodesSortedByName(g.nodes) {
+		snapshot.Nodes = append(snapshot.Nodes, nameNode.Node.snapshot(nameNode.Name))
+	}
+	for dependent, dependencies := range g.parents {
+		for dependency := range dependencies {
+			snapshot.Edges = append(snapshot.Edges, EdgeSnapshot{
+				Dependent:  dependent.String(),
+				Dependency: dependency.String(),
+			})
+		}
+	}
+	slices.SortFunc(snapshot.Edges, func(left, right EdgeSnapshot) int {
+		return cmp.Or(
+			cmp.Compare(left.Dependent, right.Dependent),
+			cmp.Compare(left.Dependency, right.Dependency),
+		)
+	})
+	return snapshot
+}
+
+// Node returns the snapshot of the node with the given name.
+func (s GraphSnapshot) Node(name Name) (NodeSnapshot, bool) {
+	i, found := slices.BinarySearchFunc(s.Nodes, name.String(), func(node NodeSnapshot, name string) int {
+		return cmp.Compare(node.Name, name)
+	})
+	if !found {
+		return NodeSnapshot{}, false
+	}
+	return s.Nodes[i], true
+}
+
+func (w *GraphNode) snapshot(name Name) NodeSnapshot {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	status := w.status()
+	snapshot := NodeSnapshot{
+		Name:                   name.String(),
+		API:                    name.API.String(),
+		Remote:                 name.Remote,
+		State:                  status.State.String(),
+		LastUpdated:            status.LastUpdated,
+		Revision:               status.Revision,
+		Generation:             status.Generation,
+		Unreachable:            w.unreachable,
+		Labels:                 maps.Clone(w.config.Labels),
+		UnresolvedDependencies: status.UnresolvedDependencies,
+		Configure:              status.Configure,
+	}
+	if w.currentModel != (Model{}) {
+		snapshot.Model = w.currentModel.String()
+	}
+	if w.lastReconfigured != nil {
+		lastReconfigured := *w.lastReconfigured
+		snapshot.LastReconfigured = &lastReconfigured
+	}
+	if status.Error != nil {
+		snapshot.Error = status.Error.Error()
+	}
+	return snapshot
+}
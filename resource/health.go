@@ -0,0 +1,121 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// A HealthChecker is a resource that can report whether it still works after being built,
+// such as whether the device it drives is still connected. Resources that are not
+// HealthCheckers are considered healthy for as long as they are ready.
+type HealthChecker interface {
+	// Healthy returns an error describing why the resource is not working, or nil if it is.
+	Healthy(ctx context.Context) error
+}
+
+// CheckHealth checks every ready resource in the graph that is a HealthChecker, giving each
+// up to timeout, and records the results on their nodes. A resource that fails its check
+// stays available, but its node's status reports it as unhealthy until it passes a check or
+// is rebuilt. Resources whose previous check has not returned yet are skipped.
+func (g *Graph) CheckHealth(ctx context.Context, timeout time.Duration) {
+	g.mu.Lock()
+	nodes := make([]*GraphNode, 0, len(g.nodes))
+	for _, node := range g.nodes {
+		nodes = append(nodes, node)
+	}
+	g.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, node := range nodes {
+		checker, builtAt, ok := node.startHealthCheck()
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			node.checkHealth(ctx, checker, builtAt, timeout)
+		}()
+	}
+	wg.Wait()
+}
+
+// startHealthCheck returns the node's resource if it is ready, is a HealthChecker and is not
+// already being checked, along with when it was built, and marks it as being checked.
+func (w *GraphNode) startHealthCheck() (HealthChecker, *time.Time, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.state != NodeStateReady || w.current == nil || w.healthCheckInFlight {
+		return nil, nil, false
+	}
+	checker, ok := w.current.(HealthChecker)
+	if !ok {
+		return nil, nil, false
+	}
+	w.healthCheckInFlight = true
+	return checker, w.lastReconfigured, true
+}
+
+func (w *GraphNode) checkHealth(ctx context.Context, checker HealthChecker, builtAt *time.Time, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result := make(chan error, 1)
+	go func() {
+		var err error
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("health check panicked: %v", r)
+			}
+			w.mu.Lock()
+			w.healthCheckInFlight = false
+			w.mu.Unlock()
+			result <- err
+		}()
+		err = checker.Healthy(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-result:
+	case <-ctx.Done():
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			// the checks were cancelled, which says nothing about the resource
+			return
+		}
+		// a resource that cannot answer in time is not working
+		err = fmt.Errorf("health check timed out after %v", timeout)
+	}
+	w.setHealth(builtAt, err)
+}
+
+// setHealth records the result of a health check of the resource built at builtAt, unless
+// the resource has since been rebuilt.
+func (w *GraphNode) setHealth(builtAt *time.Time, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.lastReconfigured != builtAt || w.state != NodeStateReady {
+		return
+	}
+	changed := (w.healthErr == nil) != (err == nil)
+	w.healthErr = err
+	if !changed {
+		return
+	}
+	if err == nil {
+		w.recovered = true
+	}
+	if w.logger != nil {
+		if err != nil {
+			w.logger.Warnw("resource failed health check", "error", err)
+		} else {
+			w.logger.Infow("resource passed health check")
+		}
+	}
+	if w.onTransition != nil {
+		w.onTransition(w.status())
+	}
+}
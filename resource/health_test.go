@@ -0,0 +1,114 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/logging"
+)
+
+type healthCheckedResource struct {
+	someResource
+
+	mu      sync.Mutex
+	err     error
+	checked int
+	block   chan struct{}
+}
+
+func (r *healthCheckedResource) Healthy(ctx context.Context) error {
+	r.mu.Lock()
+	r.checked++
+	err, block := r.err, r.block
+	r.mu.Unlock()
+	if block != nil {
+		<-block
+	}
+	return err
+}
+
+func (r *healthCheckedResource) checks() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.checked
+}
+
+func (r *healthCheckedResource) setHealth(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.err = err
+}
+
+func TestGraphCheckHealth(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := logging.NewTestLogger(t)
+	g := NewGraph(logger)
+	model := DefaultModelFamily.WithModel("foo")
+
+	checkedName := NewName(apiA, "checked")
+	checked := &healthCheckedResource{someResource: someResource{Named: checkedName.AsNamed()}}
+	checkedNode := NewUninitializedNode()
+	checkedNode.SwapResource(checked, model, nil)
+	test.That(t, g.AddNode(checkedName, checkedNode), test.ShouldBeNil)
+
+	uncheckedName := NewName(apiA, "unchecked")
+	uncheckedNode := NewUninitializedNode()
+	uncheckedNode.SwapResource(&someResource{Named: uncheckedName.AsNamed()}, model, nil)
+	test.That(t, g.AddNode(uncheckedName, uncheckedNode), test.ShouldBeNil)
+
+	g.CheckHealth(ctx, time.Second)
+	test.That(t, checked.checks(), test.ShouldEqual, 1)
+	test.That(t, checkedNode.Status().State, test.ShouldEqual, NodeStateReady)
+
+	events := g.SubscribeEvents(ctx)
+
+	// a failed check is reported in the node's status, but leaves the resource available
+	checked.setHealth(errors.New("device unplugged"))
+	g.CheckHealth(ctx, time.Second)
+	status := checkedNode.Status()
+	test.That(t, status.State, test.ShouldEqual, NodeStateUnhealthy)
+	test.That(t, status.Error, test.ShouldBeError, "device unplugged")
+	res, err := checkedNode.Resource()
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, res, test.ShouldEqual, checked)
+	test.That(t, uncheckedNode.Status().State, test.ShouldEqual, NodeStateReady)
+
+	event := nextEvent(t, events)
+	test.That(t, event.Name, test.ShouldResemble, checkedName)
+	test.That(t, event.State, test.ShouldEqual, NodeStateUnhealthy)
+
+	// passing a check again recovers the node
+	checked.setHealth(nil)
+	g.CheckHealth(ctx, time.Second)
+	test.That(t, checkedNode.Status().State, test.ShouldEqual, NodeStateReady)
+	test.That(t, nextEvent(t, events).State, test.ShouldEqual, NodeStateReady)
+	test.That(t, g.TakeRecovered(), test.ShouldResemble, []Name{checkedName})
+	test.That(t, g.TakeRecovered(), test.ShouldBeEmpty)
+
+	// a check that does not return in time fails, and the resource is not checked again
+	// until it returns
+	block := make(chan struct{})
+	checked.mu.Lock()
+	checked.block = block
+	checked.mu.Unlock()
+	g.CheckHealth(ctx, 10*time.Millisecond)
+	status = checkedNode.Status()
+	test.That(t, status.State, test.ShouldEqual, NodeStateUnhealthy)
+	test.That(t, status.Error.Error(), test.ShouldContainSubstring, "timed out")
+	g.CheckHealth(ctx, 10*time.Millisecond)
+	test.That(t, checked.checks(), test.ShouldEqual, 4)
+	checked.mu.Lock()
+	checked.block = nil
+	checked.mu.Unlock()
+	close(block)
+
+	// rebuilding the resource clears its health
+	checkedNode.SwapResource(checked, model, nil)
+	test.That(t, checkedNode.Status().State, test.ShouldEqual, NodeStateReady)
+}
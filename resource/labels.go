@@ -0,0 +1,143 @@
+package resource
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// label keys and values start and end with a letter or digit, and may contain '.', '_', '/' and
+// '-' in between. Values may also be empty.
+var labelPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+
+// ValidateLabels checks that the keys and values of labels can be used in a LabelSelector.
+func ValidateLabels(labels map[string]string) error {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !labelPattern.MatchString(key) {
+			return errors.Errorf("invalid label key %q: must start and end with a letter or digit and contain only letters, "+
+				"digits, '.', '_', '/' and '-'", key)
+		}
+		if value := labels[key]; value != "" && !labelPattern.MatchString(value) {
+			return errors.Errorf("invalid value %q for label %q: must be empty or start and end with a letter or digit and "+
+				"contain only letters, digits, '.', '_', '/' and '-'", value, key)
+		}
+	}
+	return nil
+}
+
+type labelOperator int
+
+const (
+	labelEquals labelOperator = iota
+	labelNotEquals
+	labelExists
+	labelNotExists
+)
+
+type labelRequirement struct {
+	key   string
+	op    labelOperator
+	value string
+}
+
+func (req labelRequirement) matches(labels map[string]string) bool {
+	value, ok := labels[req.key]
+	switch req.op {
+	case labelEquals:
+		return ok && value == req.value
+	case labelNotEquals:
+		return !ok || value != req.value
+	case labelExists:
+		return ok
+	case labelNotExists:
+		return !ok
+	default:
+		return false
+	}
+}
+
+func (req labelRequirement) String() string {
+	switch req.op {
+	case labelEquals:
+		return req.key + "=" + req.value
+	case labelNotEquals:
+		return req.key + "!=" + req.value
+	case labelNotExists:
+		return "!" + req.key
+	default:
+		return req.key
+	}
+}
+
+// A LabelSelector selects resources by the labels in their configs. All of its requirements must
+// hold for a resource to be selected, so the empty selector selects every resource.
+type LabelSelector struct {
+	requirements []labelRequirement
+}
+
+// ParseLabelSelector parses a comma separated list of requirements on labels. Each requirement is
+// one of:
+//
+//	key=value   the label is set to value ("key==value" is also accepted)
+//	key!=value  the label is not set to value, or is not set at all
+//	key         the label is set
+//	!key        the label is not set
+//
+// For example, "zone=front,safety=critical" selects resources in the front zone that are critical
+// to safety, whatever their API.
+func ParseLabelSelector(selector string) (LabelSelector, error) {
+	var sel LabelSelector
+	if strings.TrimSpace(selector) == "" {
+		return sel, nil
+	}
+	for _, part := range strings.Split(selector, ",") {
+		part = strings.TrimSpace(part)
+		var req labelRequirement
+		switch {
+		case strings.Contains(part, "!="):
+			key, value, _ := strings.Cut(part, "!=")
+			req = labelRequirement{key: strings.TrimSpace(key), op: labelNotEquals, value: strings.TrimSpace(value)}
+		case strings.Contains(part, "=="):
+			key, value, _ := strings.Cut(part, "==")
+			req = labelRequirement{key: strings.TrimSpace(key), op: labelEquals, value: strings.TrimSpace(value)}
+		case strings.Contains(part, "="):
+			key, value, _ := strings.Cut(part, "=")
+			req = labelRequirement{key: strings.TrimSpace(key), op: labelEquals, value: strings.TrimSpace(value)}
+		case strings.HasPrefix(part, "!"):
+			req = labelRequirement{key: strings.TrimSpace(part[1:]), op: labelNotExists}
+		default:
+			req = labelRequirement{key: part, op: labelExists}
+		}
+		if err := ValidateLabels(map[string]string{req.key: req.value}); err != nil {
+			return LabelSelector{}, errors.Wrapf(err, "invalid label selector requirement %q", part)
+		}
+		sel.requirements = append(sel.requirements, req)
+	}
+	return sel, nil
+}
+
+// Matches returns whether the given labels satisfy every requirement of the selector.
+func (sel LabelSelector) Matches(labels map[string]string) bool {
+	for _, req := range sel.requirements {
+		if !req.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the selector in the form accepted by ParseLabelSelector.
+func (sel LabelSelector) String() string {
+	parts := make([]string, 0, len(sel.requirements))
+	for _, req := range sel.requirements {
+		parts = append(parts, req.String())
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,58 @@
+package resource
+
+import (
+	"testing"
+
+	"go.viam.com/test"
+)
+
+func TestValidateLabels(t *testing.T) {
+	test.That(t, ValidateLabels(nil), test.ShouldBeNil)
+	test.That(t, ValidateLabels(map[string]string{"zone": "front", "viam.com/owner": "team-a", "spare": ""}), test.ShouldBeNil)
+
+	err := ValidateLabels(map[string]string{"": "front"})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "invalid label key")
+
+	err = ValidateLabels(map[string]string{"zone": "front,back"})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `invalid value "front,back"`)
+}
+
+func TestLabelSelector(t *testing.T) {
+	labels := map[string]string{"zone": "front", "safety": "critical"}
+
+	for _, tc := range []struct {
+		selector string
+		matches  bool
+	}{
+		{"", true},
+		{"zone=front", true},
+		{"zone==front", true},
+		{"zone = front , safety=critical", true},
+		{"zone=back", false},
+		{"zone!=back", true},
+		{"zone!=front", false},
+		{"owner!=me", true},
+		{"safety", true},
+		{"owner", false},
+		{"!owner", true},
+		{"!zone", false},
+		{"zone=front,owner", false},
+	} {
+		t.Run(tc.selector, func(t *testing.T) {
+			sel, err := ParseLabelSelector(tc.selector)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, sel.Matches(labels), test.ShouldEqual, tc.matches)
+		})
+	}
+
+	sel, err := ParseLabelSelector(" zone == front,!owner, safety!=low")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, sel.String(), test.ShouldEqual, "zone=front,!owner,safety!=low")
+
+	for _, selector := range []string{"zone=front,", "=front", "zone=front!", "!", "zone=(front)"} {
+		_, err := ParseLabelSelector(selector)
+		test.That(t, err, test.ShouldNotBeNil)
+	}
+}
@@ -0,0 +1,133 @@
+package resource
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// NameConflictPolicy controls which resource a short name refers to when it matches more than
+// one resource, such as when two remotes have resources of the same name.
+type NameConflictPolicy string
+
+// The supported NameConflictPolicy values. An empty policy is treated as NameConflictPolicyError.
+const (
+	// NameConflictPolicyError refuses to resolve short names that match more than one resource.
+	NameConflictPolicyError NameConflictPolicy = "error"
+	// NameConflictPolicyPreferLocal resolves short names to the resource on the machine itself,
+	// if one of the matches is, rather than one on a remote.
+	NameConflictPolicyPreferLocal NameConflictPolicy = "prefer_local"
+	// NameConflictPolicyPreferNamedRemote resolves short names to the resource on the first of
+	// the preferred remotes that has one.
+	NameConflictPolicyPreferNamedRemote NameConflictPolicy = "prefer_named_remote"
+	// NameConflictPolicyRequireQualified only resolves short names to resources on the machine
+	// itself. Resources on remotes must be referred to with their remote, as in "remote:name".
+	NameConflictPolicyRequireQualified NameConflictPolicy = "require_qualified"
+)
+
+// Validate returns an error if the policy is not a supported value.
+func (p NameConflictPolicy) Validate() error {
+	switch p {
+	case "", NameConflictPolicyError, NameConflictPolicyPreferLocal,
+		NameConflictPolicyPreferNamedRemote, NameConflictPolicyRequireQualified:
+		return nil
+	default:
+		return errors.Errorf("name conflict policy must be one of %q, %q, %q or %q, got %q",
+			NameConflictPolicyError, NameConflictPolicyPreferLocal,
+			NameConflictPolicyPreferNamedRemote, NameConflictPolicyRequireQualified, p)
+	}
+}
+
+// NameConflictResolution configures how short names that match more than one resource are
+// resolved.
+type NameConflictResolution struct {
+	Policy NameConflictPolicy `json:"policy,omitempty"`
+	// PreferredRemotes are the remotes to take resources from under
+	// NameConflictPolicyPreferNamedRemote, most preferred first.
+	PreferredRemotes []string `json:"preferred_remotes,omitempty"`
+}
+
+// Validate ensures the resolution has a supported policy, and remotes to prefer if its policy
+// needs them.
+func (r NameConflictResolution) Validate(path string) error {
+	if err := r.Policy.Validate(); err != nil {
+		return NewConfigValidationError(path, err)
+	}
+	if r.Policy == NameConflictPolicyPreferNamedRemote && len(r.PreferredRemotes) == 0 {
+		return NewConfigValidationFieldRequiredError(path, "preferred_remotes")
+	}
+	return nil
+}
+
+// Resolve returns which of the resources matching the given short name it refers to. matches
+// must not be empty.
+func (r NameConflictResolution) Resolve(shortName string, matches []Name) (Name, error) {
+	if r.Policy == NameConflictPolicyRequireQualified && !strings.Contains(shortName, ":") {
+		local := localNames(matches)
+		if len(local) == 0 {
+			return Name{}, &NameConflictError{Name: shortName, Matches: matches, Policy: r.Policy}
+		}
+		matches = local
+	}
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+
+	var preferred []Name
+	switch r.Policy {
+	case NameConflictPolicyPreferLocal:
+		preferred = localNames(matches)
+	case NameConflictPolicyPreferNamedRemote:
+		for _, remote := range r.PreferredRemotes {
+			for _, match := range matches {
+				if match.Remote == remote {
+					preferred = append(preferred, match)
+				}
+			}
+			if len(preferred) != 0 {
+				break
+			}
+		}
+	case "", NameConflictPolicyError, NameConflictPolicyRequireQualified:
+	}
+	if len(preferred) == 1 {
+		return preferred[0], nil
+	}
+	return Name{}, &NameConflictError{Name: shortName, Matches: matches, Policy: r.Policy}
+}
+
+func localNames(names []Name) []Name {
+	var local []Name
+	for _, name := range names {
+		if name.Remote == "" {
+			local = append(local, name)
+		}
+	}
+	return local
+}
+
+// A NameConflictError is returned when a short name cannot be resolved to one resource under a
+// NameConflictPolicy.
+type NameConflictError struct {
+	Name    string
+	Matches []Name
+	Policy  NameConflictPolicy
+}
+
+func (e *NameConflictError) Error() string {
+	matches := NamesToStrings(e.Matches)
+	slices.Sort(matches)
+	if e.Policy == NameConflictPolicyRequireQualified && len(localNames(e.Matches)) == 0 {
+		return fmt.Sprintf(
+			"resource name %q only matches resources on remotes %v, which must be referred to with their remote name",
+			e.Name, matches)
+	}
+	policy := e.Policy
+	if policy == "" {
+		policy = NameConflictPolicyError
+	}
+	return fmt.Sprintf("more than one resource with name %q exists under the %q name conflict policy: %v",
+		e.Name, policy, matches)
+}
@@ -0,0 +1,97 @@
+package resource
+
+import (
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/logging"
+)
+
+func TestNameConflictResolution(t *testing.T) {
+	api := APINamespaceRDK.WithComponentType("motor")
+	local := NewName(api, "m")
+	onRem1 := NewName(api, "rem1:m")
+	onRem2 := NewName(api, "rem2:m")
+	all := []Name{onRem1, local, onRem2}
+
+	for _, tc := range []struct {
+		name       string
+		resolution NameConflictResolution
+		shortName  string
+		matches    []Name
+		expected   Name
+		expErr     string
+	}{
+		{"single match", NameConflictResolution{}, "m", []Name{onRem1}, onRem1, ""},
+		{
+			"conflict errors by default", NameConflictResolution{}, "m", all, Name{},
+			`more than one resource with name "m" exists under the "error" name conflict policy: ` +
+				"[rdk:component:motor/m rdk:component:motor/rem1:m rdk:component:motor/rem2:m]",
+		},
+		{"prefer local", NameConflictResolution{Policy: NameConflictPolicyPreferLocal}, "m", all, local, ""},
+		{
+			"prefer local without a local match",
+			NameConflictResolution{Policy: NameConflictPolicyPreferLocal}, "m", []Name{onRem1, onRem2}, Name{},
+			`under the "prefer_local" name conflict policy`,
+		},
+		{
+			"prefer named remote",
+			NameConflictResolution{Policy: NameConflictPolicyPreferNamedRemote, PreferredRemotes: []string{"rem3", "rem2", "rem1"}},
+			"m", all, onRem2, "",
+		},
+		{
+			"prefer named remote without a match on them",
+			NameConflictResolution{Policy: NameConflictPolicyPreferNamedRemote, PreferredRemotes: []string{"rem3"}},
+			"m", all, Name{}, "more than one resource",
+		},
+		{"require qualified", NameConflictResolution{Policy: NameConflictPolicyRequireQualified}, "m", all, local, ""},
+		{
+			"require qualified with only remote matches",
+			NameConflictResolution{Policy: NameConflictPolicyRequireQualified}, "m", []Name{onRem1}, Name{},
+			`resource name "m" only matches resources on remotes [rdk:component:motor/rem1:m]`,
+		},
+		{
+			"require qualified with a qualified name",
+			NameConflictResolution{Policy: NameConflictPolicyRequireQualified}, "rem1:m", []Name{onRem1}, onRem1, "",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resolved, err := tc.resolution.Resolve(tc.shortName, tc.matches)
+			if tc.expErr != "" {
+				test.That(t, err, test.ShouldNotBeNil)
+				test.That(t, err.Error(), test.ShouldContainSubstring, tc.expErr)
+				return
+			}
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, resolved, test.ShouldResemble, tc.expected)
+		})
+	}
+
+	test.That(t, NameConflictResolution{}.Validate("name_conflicts"), test.ShouldBeNil)
+	test.That(t, NameConflictResolution{Policy: "first"}.Validate("name_conflicts"), test.ShouldNotBeNil)
+	err := NameConflictResolution{Policy: NameConflictPolicyPreferNamedRemote}.Validate("name_conflicts")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "preferred_remotes")
+}
+
+func TestResourceGraphNameConflictResolution(t *testing.T) {
+	api := APINamespaceRDK.WithComponentType("motor")
+	logger := logging.NewTestLogger(t)
+	g := NewGraph(logger)
+	dependent := NewName(api, "dependent")
+	node := NewUnconfiguredGraphNode(Config{}, []string{"m"})
+	test.That(t, g.AddNode(dependent, node), test.ShouldBeNil)
+	local := NewName(api, "m")
+	onRemote := NewName(api, "rem1:m")
+	test.That(t, g.AddNode(local, NewUninitializedNode()), test.ShouldBeNil)
+	test.That(t, g.AddNode(onRemote, NewUninitializedNode()), test.ShouldBeNil)
+
+	err := g.ResolveDependencies(logger)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, node.UnresolvedDependencyReasons()["m"], test.ShouldContainSubstring, onRemote.String())
+
+	g.SetNameConflictResolution(NameConflictResolution{Policy: NameConflictPolicyPreferLocal})
+	test.That(t, g.ResolveDependencies(logger), test.ShouldBeNil)
+	test.That(t, g.GetAllParentsOf(dependent), test.ShouldResemble, []Name{local})
+}
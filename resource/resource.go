@@ -20,6 +20,7 @@ import (
 	"context"
 	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/jhump/protoreflect/desc"
@@ -127,6 +128,48 @@ func (d Dependencies) Lookup(name Name) (Resource, error) {
 	return res, nil
 }
 
+// An OptionalDependencyWatcher is a resource with weak or optional dependencies that is told when
+// they appear, are rebuilt or go away, instead of being reconfigured with all of its dependencies
+// each time. Its constructor and Reconfigure are still passed the weak and optional dependencies
+// available at the time they are called.
+type OptionalDependencyWatcher interface {
+	// OptionalDependenciesChanged is called with the weak or optional dependencies that appeared
+	// or were rebuilt since they were last passed to the resource, and the names of those that
+	// went away.
+	OptionalDependenciesChanged(ctx context.Context, added Dependencies, removed []Name) error
+}
+
+// DiffDependencies returns the dependencies in newDeps that are missing from oldDeps or are
+// different resources there, and the sorted names of those in oldDeps that are missing from
+// newDeps.
+func DiffDependencies(oldDeps, newDeps Dependencies) (Dependencies, []Name) {
+	added := Dependencies{}
+	for name, res := range newDeps {
+		if oldRes, ok := oldDeps[name]; !ok || !sameResource(oldRes, res) {
+			added[name] = res
+		}
+	}
+	var removed []Name
+	for name := range oldDeps {
+		if _, ok := newDeps[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	slices.SortFunc(removed, func(a, b Name) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return added, removed
+}
+
+// sameResource returns whether a and b are the same resource, assuming they are not if they
+// cannot be compared.
+func sameResource(a, b Resource) bool {
+	if a == nil || b == nil || reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
+
 // An RPCAPI provides RPC information about a particular API.
 type RPCAPI struct {
 	API          API
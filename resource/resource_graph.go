@@ -1,6 +1,7 @@
 package resource
 
 import (
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -31,6 +32,10 @@ type Graph struct {
 	logicalClock *atomic.Int64
 	logger       logging.Logger
 	ftdc         *ftdc.FTDC
+	// events is set once the graph is subscribed to. It is not shared with clones.
+	events *resourceEvents
+	// nameConflicts resolves short names that match more than one node.
+	nameConflicts NameConflictResolution
 }
 
 // NewGraph creates a new resource graph.
@@ -136,9 +141,25 @@ func (g *Graph) clone() *Graph {
 		parents:                 copyNodeMap(g.parents),
 		transitiveClosureMatrix: copyTransitiveClosureMatrix(g.transitiveClosureMatrix),
 		logicalClock:            g.logicalClock,
+		nameConflicts:           g.nameConflicts,
 	}
 }
 
+// SetNameConflictResolution sets how short names that match more than one node are resolved.
+func (g *Graph) SetNameConflictResolution(resolution NameConflictResolution) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.nameConflicts = resolution
+}
+
+// ResolveNameConflict returns which of the nodes matching the given short name it refers to,
+// according to the graph's name conflict resolution. matches must not be empty.
+func (g *Graph) ResolveNameConflict(shortName string, matches []Name) (Name, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.nameConflicts.Resolve(shortName, matches)
+}
+
 func addResToSet(rd resourceDependencies, key, node Name) {
 	// check if a graphNodes exists for a key, otherwise create one
 	nodes, ok := rd[key]
@@ -236,6 +257,19 @@ func (g *Graph) FindNodesByAPI(api API) []Name {
 	return ret
 }
 
+// FindNodesByLabels finds the nodes whose config labels match the given selector.
+func (g *Graph) FindNodesByLabels(selector LabelSelector) []Name {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var ret []Name
+	for k, v := range g.nodes {
+		if v != nil && selector.Matches(v.Labels()) {
+			ret = append(ret, k)
+		}
+	}
+	return ret
+}
+
 // FindNodesByShortName returns all resources matching the given short name.
 func (g *Graph) FindNodesByShortName(name string) []Name {
 	hasRemote := strings.Contains(name, ":")
@@ -284,9 +318,18 @@ func (g *Graph) addNode(node Name, nodeVal *GraphNode) error {
 		if !val.IsUninitialized() {
 			return errors.Errorf("initialized node already exists with name %q; must swap instead", node)
 		}
-		return val.replace(nodeVal)
+		if err := val.replace(nodeVal); err != nil {
+			return err
+		}
+		if g.events != nil {
+			g.events.listener(node)(val.Status())
+		}
+		return nil
 	}
 	nodeVal.setGraphLogicalClock(g.logicalClock)
+	if g.events != nil {
+		nodeVal.setTransitionListener(g.events.listener(node))
+	}
 	if g.ftdc != nil {
 		g.ftdc.Add(node.String(), nodeVal)
 	}
@@ -330,7 +373,7 @@ func (g *Graph) addChild(child, parent Name) error {
 			return err
 		}
 	} else if g.transitiveClosureMatrix[parent][child] != 0 {
-		return errors.Errorf("circular dependency - %q already depends on %q", parent.Name, child.Name)
+		return g.cycleError(child, parent)
 	}
 	if _, ok := g.parents[child][parent]; ok {
 		return nil
@@ -342,6 +385,77 @@ func (g *Graph) addChild(child, parent Name) error {
 	return nil
 }
 
+// cycleError explains the cycle that making child depend on parent would close, given that parent
+// already depends on child.
+func (g *Graph) cycleError(child, parent Name) error {
+	cycle := append([]Name{child}, g.dependencyPath(parent, child)...)
+	fields := make([]string, 0, len(cycle)-1)
+	for i := 0; i+1 < len(cycle); i++ {
+		fields = append(fields, g.dependencyField(cycle[i], cycle[i+1]))
+	}
+	return &DependencyCycleError{Cycle: cycle, Fields: fields}
+}
+
+// dependencyPath returns a shortest path of dependencies from one node to another, including
+// both, or nil if from does not depend on to.
+func (g *Graph) dependencyPath(from, to Name) []Name {
+	previous := map[Name]Name{from: from}
+	queue := []Name{from}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node == to {
+			path := []Name{to}
+			for path[0] != from {
+				path = append([]Name{previous[path[0]]}, path...)
+			}
+			return path
+		}
+		// visit dependencies in order, so the same graph always explains a cycle the same way
+		deps := make([]Name, 0, len(g.parents[node]))
+		for dep := range g.parents[node] {
+			deps = append(deps, dep)
+		}
+		sort.Slice(deps, func(i, j int) bool { return deps[i].String() < deps[j].String() })
+		for _, dep := range deps {
+			if _, ok := previous[dep]; !ok {
+				previous[dep] = node
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return nil
+}
+
+// dependencyField returns the field of node's config that makes it depend on dep, or "" if it
+// is not known.
+func (g *Graph) dependencyField(node, dep Name) string {
+	if dep.API.Type.Name == "remote" {
+		return "remote"
+	}
+	gNode, ok := g.nodes[node]
+	if !ok || gNode == nil {
+		return ""
+	}
+	conf := gNode.Config()
+	names := func(deps []string) bool {
+		for _, d := range deps {
+			if d == dep.String() || d == dep.ShortName() || d == dep.Name {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case names(conf.DependsOn):
+		return "depends_on"
+	case names(conf.ImplicitDependsOn):
+		return "attributes"
+	default:
+		return ""
+	}
+}
+
 func (g *Graph) removeChild(child, parent Name) {
 	// Link nodes
 	removeResFromSet(g.children, parent, child)
@@ -385,6 +499,9 @@ func (g *Graph) remove(node Name) {
 	delete(g.transitiveClosureMatrix, node)
 	delete(g.parents, node)
 	delete(g.children, node)
+	if removed, ok := g.nodes[node]; ok {
+		g.publishRemoval(node, removed)
+	}
 	delete(g.nodes, node)
 	if g.ftdc != nil {
 		g.ftdc.Remove(node.String())
@@ -581,9 +698,11 @@ func (g *Graph) ResolveDependencies(logger logging.Logger) error {
 		}
 
 		remainingDeps := make([]string, 0, len(unresolvedDeps))
+		reasons := make(map[string]string)
 		for _, dep := range unresolvedDeps {
 			tryResolve := func() (Name, bool) {
 				if dep == nodeName.String() {
+					reasons[dep] = "resource cannot depend on itself"
 					allErrs = multierr.Combine(errors.Errorf("node cannot depend on itself: %q", nodeName))
 					logger.Errorw("node cannot depend on itself", "name", nodeName)
 					return Name{}, false
@@ -595,36 +714,42 @@ func (g *Graph) ResolveDependencies(logger logging.Logger) error {
 				// if a name is later added that conflicts, it will not
 				// necessarily be caught unless the resource config changes.
 				nodeNames := g.FindNodesByShortName(dep)
-				switch len(nodeNames) {
-				case 0:
-				case 1:
-					if nodeNames[0].String() == nodeName.String() {
-						allErrs = multierr.Combine(errors.Errorf("node cannot depend on itself: %q", nodeName))
-						logger.Errorw("node cannot depend on itself", "name", nodeName)
-						return Name{}, false
-					}
-					logger.Debugw(
-						"dependency resolved for resource",
-						"name", nodeName,
-						"dependency", nodeNames[0],
-					)
-					return nodeNames[0], true
-				default:
+				if len(nodeNames) == 0 {
+					reasons[dep] = "not found in machine config or connected remotes"
+					return Name{}, false
+				}
+				resolvedName, err := g.nameConflicts.Resolve(dep, nodeNames)
+				if err != nil {
+					reasons[dep] = err.Error()
 					allErrs = multierr.Combine(
 						allErrs,
-						errors.Errorf("conflicting names for resource %q: %v", nodeName, NamesToStrings(nodeNames)))
+						errors.Errorf("conflicting names for resource %q: %v", nodeName, err))
 					logger.Errorw(
 						"cannot resolve dependency for resource due to multiple matching names",
 						"name", nodeName,
 						"conflicts", NamesToStrings(nodeNames),
+						"policy", g.nameConflicts.Policy,
 					)
+					return Name{}, false
+				}
+				if resolvedName.String() == nodeName.String() {
+					reasons[dep] = "resource cannot depend on itself"
+					allErrs = multierr.Combine(errors.Errorf("node cannot depend on itself: %q", nodeName))
+					logger.Errorw("node cannot depend on itself", "name", nodeName)
+					return Name{}, false
 				}
-				return Name{}, false
+				logger.Debugw(
+					"dependency resolved for resource",
+					"name", nodeName,
+					"dependency", resolvedName,
+				)
+				return resolvedName, true
 			}
 
 			resolvedName, resolved := tryResolve()
 			if resolved {
 				if err := g.addChild(nodeName, resolvedName); err != nil {
+					reasons[dep] = err.Error()
 					allErrs = multierr.Combine(allErrs, err)
 					logger.Errorw(
 						"error adding dependency for resource as a child to parent",
@@ -640,6 +765,7 @@ func (g *Graph) ResolveDependencies(logger logging.Logger) error {
 			}
 		}
 		node.setUnresolvedDependencies(remainingDeps...)
+		node.setUnresolvedDependencyReasons(reasons)
 		if len(remainingDeps) == 0 {
 			node.setDependenciesResolved()
 		}
@@ -696,6 +822,24 @@ func (g *Graph) MarkReachability(node Name, reachable bool) error {
 	return nil
 }
 
+// TakeRecovered returns the names of the nodes that have recovered from being unhealthy,
+// whether by being rebuilt, reconfigured, or passing a health check, since it was last
+// called.
+func (g *Graph) TakeRecovered() []Name {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var recovered []Name
+	for name, node := range g.nodes {
+		node.mu.Lock()
+		if node.recovered {
+			recovered = append(recovered, name)
+			node.recovered = false
+		}
+		node.mu.Unlock()
+	}
+	return recovered
+}
+
 // Status returns a slice of all graph node statuses.
 func (g *Graph) Status() []NodeStatus {
 	g.mu.Lock()
@@ -1,6 +1,8 @@
 package resource
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -307,7 +309,12 @@ func TestResourceGraphDepTree(t *testing.T) {
 	}
 	err := g.AddChild(NewName(apiA, "A"),
 		NewName(apiA, "F"))
-	test.That(t, err.Error(), test.ShouldEqual, "circular dependency - \"F\" already depends on \"A\"")
+	test.That(t, err.Error(), test.ShouldEqual, "circular dependency - \"F\" already depends on \"A\": A -> F -> E -> B -> A")
+	var cycleErr *DependencyCycleError
+	test.That(t, errors.As(err, &cycleErr), test.ShouldBeTrue)
+	test.That(t, cycleErr.Cycle, test.ShouldResemble, []Name{
+		NewName(apiA, "A"), NewName(apiA, "F"), NewName(apiA, "E"), NewName(apiA, "B"), NewName(apiA, "A"),
+	})
 	test.That(t, g.AddChild(NewName(apiA, "D"),
 		NewName(apiA, "F")), test.ShouldBeNil)
 }
@@ -1141,6 +1148,154 @@ func TestResourceGraphResolveDependencies(t *testing.T) {
 	test.That(t, node6.hasUnresolvedDependencies(), test.ShouldBeFalse)
 }
 
+func TestResourceGraphDependencyDiagnostics(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	g := NewGraph(logger)
+
+	api := APINamespaceRDK.WithComponentType("aapi")
+	nodes := map[string]*GraphNode{
+		"a": NewUnconfiguredGraphNode(Config{Name: "a", DependsOn: []string{"b"}}, []string{"b"}),
+		"b": NewUnconfiguredGraphNode(Config{Name: "b", ImplicitDependsOn: []string{"c"}}, []string{"c"}),
+		"c": NewUnconfiguredGraphNode(Config{Name: "c", DependsOn: []string{"a"}}, []string{"a"}),
+		"d": NewUnconfiguredGraphNode(Config{Name: "d", DependsOn: []string{"missing"}}, []string{"missing"}),
+	}
+	for name, node := range nodes {
+		test.That(t, g.AddNode(NewName(api, name), node), test.ShouldBeNil)
+	}
+
+	err := g.ResolveDependencies(logger)
+	test.That(t, err, test.ShouldNotBeNil)
+	var cycleErr *DependencyCycleError
+	test.That(t, errors.As(err, &cycleErr), test.ShouldBeTrue)
+	test.That(t, cycleErr.Cycle, test.ShouldHaveLength, 4)
+	test.That(t, cycleErr.Cycle[0], test.ShouldResemble, cycleErr.Cycle[3])
+	test.That(t, cycleErr.Fields, test.ShouldHaveLength, 3)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "b depends on c through attributes")
+	test.That(t, err.Error(), test.ShouldContainSubstring, "through depends_on")
+
+	// the dependency that would have closed the cycle is left unresolved, with the cycle as why
+	var cyclic int
+	for name, node := range nodes {
+		if name == "d" {
+			continue
+		}
+		for _, reason := range node.UnresolvedDependencyReasons() {
+			test.That(t, reason, test.ShouldEqual, cycleErr.Error())
+			cyclic++
+		}
+	}
+	test.That(t, cyclic, test.ShouldEqual, 1)
+
+	reasons := nodes["d"].UnresolvedDependencyReasons()
+	test.That(t, reasons, test.ShouldResemble, map[string]string{"missing": "not found in machine config or connected remotes"})
+	test.That(t, nodes["d"].Status().UnresolvedDependencies, test.ShouldResemble, reasons)
+
+	// a new config clears the reasons until dependencies are resolved again
+	nodes["d"].SetNewConfig(Config{Name: "d", DependsOn: []string{"other"}}, []string{"other"})
+	test.That(t, nodes["d"].UnresolvedDependencyReasons(), test.ShouldResemble, map[string]string{"other": "not resolved yet"})
+}
+
+func TestResourceGraphExportJSON(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	g := NewGraph(logger)
+
+	nameA := NewName(apiA, "a")
+	nameB := NewName(apiA, "b")
+	nameC := NewName(apiA, "rem1:c")
+	model := DefaultModelFamily.WithModel("foo")
+	test.That(t, g.AddNode(nameA, NewConfiguredGraphNode(Config{}, &someResource{Named: nameA.AsNamed()}, model)), test.ShouldBeNil)
+	test.That(t, g.AddNode(nameB, NewUnconfiguredGraphNode(Config{}, []string{"a", "missing"})), test.ShouldBeNil)
+	nodeC := NewUninitializedNode()
+	nodeC.LogAndSetLastError(errors.New("remote is offline"))
+	test.That(t, g.AddNode(nameC, nodeC), test.ShouldBeNil)
+	test.That(t, g.AddChild(nameB, nameA), test.ShouldBeNil)
+	test.That(t, g.AddChild(nameB, nameC), test.ShouldBeNil)
+
+	out, err := g.ExportJSON()
+	test.That(t, err, test.ShouldBeNil)
+	again, err := g.ExportJSON()
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, again, test.ShouldEqual, out)
+
+	var exported ExportedGraph
+	test.That(t, json.Unmarshal([]byte(out), &exported), test.ShouldBeNil)
+	test.That(t, exported.Nodes, test.ShouldHaveLength, 3)
+
+	a, b, c := exported.Nodes[0], exported.Nodes[1], exported.Nodes[2]
+	test.That(t, a.Name, test.ShouldEqual, nameA.String())
+	test.That(t, a.Model, test.ShouldEqual, model.String())
+	test.That(t, a.State, test.ShouldEqual, NodeStateReady.String())
+	test.That(t, a.DependsOn, test.ShouldBeEmpty)
+
+	test.That(t, b.Name, test.ShouldEqual, nameB.String())
+	test.That(t, b.State, test.ShouldEqual, NodeStateConfiguring.String())
+	test.That(t, b.DependsOn, test.ShouldResemble, []string{nameA.String(), nameC.String()})
+	test.That(t, b.UnresolvedDependencies, test.ShouldResemble, []string{"a", "missing"})
+
+	test.That(t, c.Name, test.ShouldEqual, nameC.String())
+	test.That(t, c.Remote, test.ShouldEqual, "rem1")
+	test.That(t, c.State, test.ShouldEqual, NodeStateUnhealthy.String())
+	test.That(t, c.Error, test.ShouldEqual, "remote is offline")
+}
+
+func TestResourceGraphSnapshot(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	g := NewGraph(logger)
+
+	nameA := NewName(apiA, "a")
+	nameB := NewName(apiA, "b")
+	model := DefaultModelFamily.WithModel("foo")
+	nodeA := NewConfiguredGraphNode(Config{Labels: map[string]string{"zone": "front"}}, &someResource{Named: nameA.AsNamed()}, model)
+	test.That(t, g.AddNode(nameA, nodeA), test.ShouldBeNil)
+	nodeB := NewUnconfiguredGraphNode(Config{}, []string{"a", "missing"})
+	test.That(t, g.AddNode(nameB, nodeB), test.ShouldBeNil)
+	test.That(t, g.AddChild(nameB, nameA), test.ShouldBeNil)
+
+	snapshot := g.Snapshot()
+	test.That(t, snapshot.LogicalClock, test.ShouldEqual, g.CurrLogicalClockValue())
+	test.That(t, snapshot.Nodes, test.ShouldHaveLength, 2)
+	test.That(t, snapshot.Edges, test.ShouldResemble, []EdgeSnapshot{{Dependent: nameB.String(), Dependency: nameA.String()}})
+
+	a, ok := snapshot.Node(nameA)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, a.Model, test.ShouldEqual, model.String())
+	test.That(t, a.State, test.ShouldEqual, NodeStateReady.String())
+	test.That(t, a.LastReconfigured, test.ShouldNotBeNil)
+	test.That(t, *a.LastReconfigured, test.ShouldEqual, *nodeA.LastReconfigured())
+	test.That(t, a.Labels, test.ShouldResemble, map[string]string{"zone": "front"})
+
+	b, ok := snapshot.Node(nameB)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, b.State, test.ShouldEqual, NodeStateConfiguring.String())
+	test.That(t, b.LastReconfigured, test.ShouldBeNil)
+	test.That(t, b.UnresolvedDependencies, test.ShouldContainKey, "missing")
+	_, ok = snapshot.Node(NewName(apiA, "c"))
+	test.That(t, ok, test.ShouldBeFalse)
+
+	// later changes to the graph do not show up in the snapshot
+	a.Labels["zone"] = "back"
+	nodeB.LogAndSetLastError(errors.New("failed to build"))
+	test.That(t, g.AddNode(NewName(apiA, "c"), NewUninitializedNode()), test.ShouldBeNil)
+	test.That(t, snapshot.Nodes, test.ShouldHaveLength, 2)
+	b, _ = snapshot.Node(nameB)
+	test.That(t, b.Error, test.ShouldBeEmpty)
+
+	again := g.Snapshot()
+	test.That(t, again.Nodes, test.ShouldHaveLength, 3)
+	a, _ = again.Node(nameA)
+	test.That(t, a.Labels, test.ShouldResemble, map[string]string{"zone": "front"})
+	b, _ = again.Node(nameB)
+	test.That(t, b.State, test.ShouldEqual, NodeStateUnhealthy.String())
+	test.That(t, b.Error, test.ShouldEqual, "failed to build")
+
+	out, err := json.Marshal(again)
+	test.That(t, err, test.ShouldBeNil)
+	var unmarshaled GraphSnapshot
+	test.That(t, json.Unmarshal(out, &unmarshaled), test.ShouldBeNil)
+	test.That(t, unmarshaled.Nodes, test.ShouldHaveLength, 3)
+	test.That(t, unmarshaled.Edges, test.ShouldResemble, again.Edges)
+}
+
 type someResource struct {
 	Named
 	TriviallyReconfigurable
@@ -127,6 +127,18 @@ type Registration[ResourceT Resource, ConfigT any] struct {
 	// NOTE: This is currently an experimental feature and subject to change.
 	WeakDependencies []Matcher
 
+	// UnknownAttributes is the default policy for attributes that the native config does not
+	// declare. It can be overridden per model by the robot config.
+	UnknownAttributes UnknownAttributesPolicy
+
+	// Deprecated, if set, marks the model as deprecated and explains what to use instead.
+	// Configs using the model are flagged by config linting.
+	Deprecated string
+
+	// Capabilities are the optional features the model supports, which clients can look up with
+	// GetCapabilities instead of probing for them.
+	Capabilities []Capability
+
 	// configType can be used to dynamically inspect the resource config type.
 	configType reflect.Type
 
@@ -147,6 +159,11 @@ type APIRegistration[ResourceT Resource] struct {
 	ReflectRPCServiceDesc       *desc.ServiceDescriptor
 	RPCClient                   CreateRPCClient[ResourceT]
 
+	// StreamRPCServiceDesc, if set, is an rdk-owned service that the server constructed by
+	// RPCServiceServerConstructor also serves, for streaming methods that are not part of the
+	// API's proto.
+	StreamRPCServiceDesc *grpc.ServiceDesc
+
 	// MaxInstance sets a limit on the number of this api allowed on a robot.
 	// If MaxInstance is not set then it will default to 0 and there will be no limit.
 	MaxInstance int
@@ -165,12 +182,14 @@ func (rs APIRegistration[ResourceT]) RegisterRPCService(
 	if rs.RPCServiceServerConstructor == nil {
 		return nil
 	}
-	return rpcServer.RegisterServiceServer(
-		ctx,
-		rs.RPCServiceDesc,
-		rs.RPCServiceServerConstructor(apiColl),
-		rs.RPCServiceHandler,
-	)
+	server := rs.RPCServiceServerConstructor(apiColl)
+	if err := rpcServer.RegisterServiceServer(ctx, rs.RPCServiceDesc, server, rs.RPCServiceHandler); err != nil {
+		return err
+	}
+	if rs.StreamRPCServiceDesc == nil {
+		return nil
+	}
+	return rpcServer.RegisterServiceServer(ctx, rs.StreamRPCServiceDesc, server)
 }
 
 // AssociatedConfig defines the contract for a config that is associated with another config.
@@ -295,10 +314,13 @@ func makeGenericResourceRegistration[ResourceT Resource, ConfigT ConfigValidator
 ) Registration[Resource, ConfigValidator] {
 	reg := Registration[Resource, ConfigValidator]{
 		// NOTE: any fields added to Registration must be copied/adapted here.
-		WeakDependencies: typed.WeakDependencies,
-		isDefault:        typed.isDefault,
-		api:              typed.api,
-		configType:       typed.configType,
+		WeakDependencies:  typed.WeakDependencies,
+		UnknownAttributes: typed.UnknownAttributes,
+		Deprecated:        typed.Deprecated,
+		Capabilities:      typed.Capabilities,
+		isDefault:         typed.isDefault,
+		api:               typed.api,
+		configType:        typed.configType,
 	}
 	if typed.Constructor != nil {
 		reg.Constructor = func(
@@ -307,7 +329,12 @@ func makeGenericResourceRegistration[ResourceT Resource, ConfigT ConfigValidator
 			conf Config,
 			logger logging.Logger,
 		) (Resource, error) {
-			return typed.Constructor(ctx, deps, conf, logger)
+			res, err := typed.Constructor(ctx, deps, conf, logger)
+			if err != nil {
+				return nil, err
+			}
+			recordCapabilities(res, typed.Capabilities)
+			return res, nil
 		}
 	}
 	if typed.DeprecatedRobotConstructor != nil {
@@ -317,7 +344,12 @@ func makeGenericResourceRegistration[ResourceT Resource, ConfigT ConfigValidator
 			conf Config,
 			logger logging.Logger,
 		) (Resource, error) {
-			return typed.DeprecatedRobotConstructor(ctx, r, conf, logger)
+			res, err := typed.DeprecatedRobotConstructor(ctx, r, conf, logger)
+			if err != nil {
+				return nil, err
+			}
+			recordCapabilities(res, typed.Capabilities)
+			return res, nil
 		}
 	}
 	if typed.AttributeMapConverter != nil {
@@ -476,6 +508,7 @@ func makeGenericAPIRegistration[ResourceT Resource](
 		RPCServiceDesc:        typed.RPCServiceDesc,
 		RPCServiceHandler:     typed.RPCServiceHandler,
 		ReflectRPCServiceDesc: typed.ReflectRPCServiceDesc,
+		StreamRPCServiceDesc:  typed.StreamRPCServiceDesc,
 		MaxInstance:           typed.MaxInstance,
 		typedVersion:          typed,
 		MakeEmptyCollection: func() APIResourceCollection[Resource] {
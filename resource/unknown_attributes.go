@@ -0,0 +1,164 @@
+package resource
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/utils"
+)
+
+// UnknownAttributesPolicy controls what happens when a resource config has attributes that its
+// model's native config does not know about, which usually means the attribute name has a typo.
+type UnknownAttributesPolicy string
+
+// The supported UnknownAttributesPolicy values. An empty policy defers to the next policy that
+// applies and is otherwise treated as UnknownAttributesIgnore.
+const (
+	UnknownAttributesIgnore UnknownAttributesPolicy = "ignore"
+	UnknownAttributesWarn   UnknownAttributesPolicy = "warn"
+	UnknownAttributesError  UnknownAttributesPolicy = "error"
+)
+
+// Validate returns an error if the policy is not a supported value.
+func (p UnknownAttributesPolicy) Validate() error {
+	switch p {
+	case "", UnknownAttributesIgnore, UnknownAttributesWarn, UnknownAttributesError:
+		return nil
+	default:
+		return errors.Errorf("unknown attributes policy must be one of %q, %q or %q, got %q",
+			UnknownAttributesIgnore, UnknownAttributesWarn, UnknownAttributesError, p)
+	}
+}
+
+// An UnknownAttribute is an attribute not recognized by a model's native config, along with the
+// closest recognized attribute name if there is one that is likely to be meant instead.
+type UnknownAttribute struct {
+	Name       string
+	Suggestion string
+}
+
+func (a UnknownAttribute) String() string {
+	if a.Suggestion == "" {
+		return a.Name
+	}
+	return a.Name + " (did you mean " + a.Suggestion + "?)"
+}
+
+// FormatUnknownAttributes returns a readable, comma separated list of unknown attributes.
+func FormatUnknownAttributes(unknown []UnknownAttribute) string {
+	names := make([]string, 0, len(unknown))
+	for _, a := range unknown {
+		names = append(names, a.String())
+	}
+	return strings.Join(names, ", ")
+}
+
+// FindUnknownAttributes returns the attributes that the registered native config type does not
+// declare, sorted by name. Models without a native config struct, and native configs with a
+// catch-all Attributes map, accept any attribute and never report unknown ones.
+func (r Registration[ResourceT, ConfigT]) FindUnknownAttributes(attributes utils.AttributeMap) []UnknownAttribute {
+	return unknownAttributes(r.configType, attributes)
+}
+
+func unknownAttributes(configType reflect.Type, attributes utils.AttributeMap) []UnknownAttribute {
+	if configType == nil || len(attributes) == 0 || attributes.Has("attributes") {
+		return nil
+	}
+	for configType.Kind() == reflect.Ptr {
+		configType = configType.Elem()
+	}
+	if configType.Kind() != reflect.Struct {
+		return nil
+	}
+	if f, ok := configType.FieldByName("Attributes"); ok && f.Type.Kind() == reflect.Map {
+		return nil
+	}
+
+	known := map[string]struct{}{}
+	collectAttributeNames(configType, known)
+
+	var unknown []UnknownAttribute
+	for name := range attributes {
+		if _, ok := known[strings.ToLower(name)]; ok {
+			continue
+		}
+		unknown = append(unknown, UnknownAttribute{Name: name, Suggestion: closestAttributeName(name, known)})
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].Name < unknown[j].Name })
+	return unknown
+}
+
+// collectAttributeNames adds the lowercased attribute name of every field of t the way
+// TransformAttributeMap decodes them.
+func collectAttributeNames(t reflect.Type, names map[string]struct{}) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		fieldType := f.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if f.Anonymous && fieldType.Kind() == reflect.Struct {
+			// embedded structs decode from their own key unless squashed, but configs are
+			// commonly written either way so accept both.
+			collectAttributeNames(fieldType, names)
+			if strings.Contains(opts, "squash") {
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[strings.ToLower(name)] = struct{}{}
+	}
+}
+
+// closestAttributeName returns the known name closest to name if it is close enough to be a typo.
+func closestAttributeName(name string, known map[string]struct{}) string {
+	name = strings.ToLower(name)
+	maxDist := 2
+	if len(name) <= 4 {
+		maxDist = 1
+	}
+	best, bestDist := "", maxDist+1
+	for candidate := range known {
+		if d := editDistance(name, candidate); d < bestDist || (d == bestDist && candidate < best) {
+			best, bestDist = candidate, d
+		}
+	}
+	if bestDist > maxDist {
+		return ""
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
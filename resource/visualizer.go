@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"cmp"
 	"container/list"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"slices"
@@ -355,3 +356,71 @@ func (g *Graph) ExportDot() (string, error) {
 
 	return writer.String(), nil
 }
+
+// ExportedGraph is the JSON representation of a resource graph produced by ExportJSON.
+type ExportedGraph struct {
+	Nodes []ExportedNode `json:"nodes"`
+}
+
+// ExportedNode is a resource graph node along with its state and dependencies.
+type ExportedNode struct {
+	Name                   string    `json:"name"`
+	API                    string    `json:"api"`
+	Remote                 string    `json:"remote,omitempty"`
+	Model                  string    `json:"model,omitempty"`
+	State                  string    `json:"state"`
+	LastUpdated            time.Time `json:"last_updated"`
+	Revision               string    `json:"revision,omitempty"`
+	Error                  string    `json:"error,omitempty"`
+	DependsOn              []string  `json:"depends_on"`
+	UnresolvedDependencies []string  `json:"unresolved_dependencies"`
+}
+
+// ExportJSON exports the resource graph, including remote resources, as JSON. Each node lists the nodes
+// it depends on and the dependencies that could not be resolved, so that a resource that is not starting
+// can be traced back to its missing or unhealthy dependencies. Like ExportDot, the same input resource
+// graph always produces the same output.
+func (g *Graph) ExportJSON() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	exported := ExportedGraph{Nodes: []ExportedNode{}}
+	for _, nameNode := range nodesSortedByName(g.nodes) {
+		name, node := nameNode.Name, nameNode.Node
+		status := node.Status()
+		node.mu.RLock()
+		model := node.currentModel
+		node.mu.RUnlock()
+
+		exportedNode := ExportedNode{
+			Name:                   name.String(),
+			API:                    name.API.String(),
+			Remote:                 name.Remote,
+			State:                  status.State.String(),
+			LastUpdated:            status.LastUpdated,
+			Revision:               status.Revision,
+			DependsOn:              []string{},
+			UnresolvedDependencies: node.UnresolvedDependencies(),
+		}
+		if model != (Model{}) {
+			exportedNode.Model = model.String()
+		}
+		if status.Error != nil {
+			exportedNode.Error = status.Error.Error()
+		}
+		if exportedNode.UnresolvedDependencies == nil {
+			exportedNode.UnresolvedDependencies = []string{}
+		}
+		for dep := range g.parents[name] {
+			exportedNode.DependsOn = append(exportedNode.DependsOn, dep.String())
+		}
+		slices.Sort(exportedNode.DependsOn)
+		exported.Nodes = append(exported.Nodes, exportedNode)
+	}
+
+	out, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
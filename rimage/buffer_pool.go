@@ -0,0 +1,73 @@
+package rimage
+
+import (
+	"image"
+	"math/bits"
+	"sync"
+)
+
+// Buffers are pooled in power of two size classes so that frames of slightly different
+// sizes, like encoded video frames, can share buffers.
+const (
+	minBufferClassBits = 10 // 1KiB
+	numBufferClasses   = 20 // up to 512MiB
+)
+
+var bufferPools [numBufferClasses]sync.Pool
+
+// bufferClass returns the size class that fits n bytes.
+func bufferClass(n int) int {
+	if n <= 1<<minBufferClassBits {
+		return 0
+	}
+	return bits.Len(uint(n-1)) - minBufferClassBits
+}
+
+// GetBuffer returns a byte slice of length n whose backing memory may be reused from a
+// previously released buffer. Its contents are undefined. Call PutBuffer once the buffer
+// is no longer referenced so that the next frame can reuse it.
+func GetBuffer(n int) []byte {
+	class := bufferClass(n)
+	if class >= numBufferClasses {
+		return make([]byte, n)
+	}
+	if b, ok := bufferPools[class].Get().(*[]byte); ok {
+		return (*b)[:n]
+	}
+	return make([]byte, n, 1<<(class+minBufferClassBits))
+}
+
+// PutBuffer releases a buffer returned by GetBuffer for reuse. The buffer must not be used
+// after it is released. Buffers that did not come from GetBuffer are ignored.
+func PutBuffer(b []byte) {
+	c := cap(b)
+	if c < 1<<minBufferClassBits || c&(c-1) != 0 {
+		return
+	}
+	class := bits.Len(uint(c)) - 1 - minBufferClassBits
+	if class >= numBufferClasses {
+		return
+	}
+	b = b[:0]
+	bufferPools[class].Put(&b)
+}
+
+// GetRGBA returns an RGBA image with the given bounds backed by a pooled buffer. Its pixels
+// are undefined. Call PutRGBA once the image is no longer referenced.
+func GetRGBA(r image.Rectangle) *image.RGBA {
+	return &image.RGBA{
+		Pix:    GetBuffer(4 * r.Dx() * r.Dy()),
+		Stride: 4 * r.Dx(),
+		Rect:   r,
+	}
+}
+
+// PutRGBA releases an image returned by GetRGBA for reuse. The image must not be used
+// after it is released.
+func PutRGBA(img *image.RGBA) {
+	if img == nil {
+		return
+	}
+	PutBuffer(img.Pix)
+	img.Pix = nil
+}
@@ -0,0 +1,35 @@
+package rimage
+
+import (
+	"image"
+	"testing"
+
+	"go.viam.com/test"
+)
+
+func TestBufferPool(t *testing.T) {
+	test.That(t, bufferClass(1), test.ShouldEqual, 0)
+	test.That(t, bufferClass(1024), test.ShouldEqual, 0)
+	test.That(t, bufferClass(1025), test.ShouldEqual, 1)
+	test.That(t, bufferClass(1920*1080*4), test.ShouldEqual, 13)
+
+	b := GetBuffer(1500)
+	test.That(t, b, test.ShouldHaveLength, 1500)
+	test.That(t, cap(b), test.ShouldEqual, 2048)
+
+	// buffers that did not come from the pool are ignored
+	PutBuffer(make([]byte, 1500))
+	PutBuffer(nil)
+
+	// huge buffers are not pooled
+	test.That(t, bufferClass(1<<30), test.ShouldBeGreaterThanOrEqualTo, numBufferClasses)
+
+	img := GetRGBA(image.Rect(0, 0, 64, 48))
+	test.That(t, img.Bounds(), test.ShouldResemble, image.Rect(0, 0, 64, 48))
+	test.That(t, img.Stride, test.ShouldEqual, 256)
+	test.That(t, img.Pix, test.ShouldHaveLength, 64*48*4)
+	img.Set(63, 47, image.White)
+	PutRGBA(img)
+	test.That(t, img.Pix, test.ShouldBeNil)
+	PutRGBA(nil)
+}
@@ -1,6 +1,7 @@
 package rimage
 
 import (
+	"fmt"
 	"image"
 	"math"
 
@@ -250,3 +251,90 @@ func pointsFromRayMarching(x, y, iterations int, directions []image.Point, iwd *
 	}
 	return rayMarchingPoints
 }
+
+// JointBilateralHoleFilling fills each missing pixel of the depth map with an average of the valid depths within
+// radius pixels of it, weighted both by distance and by how similar their color is to the color of the missing
+// pixel, so that depth is not carried across the edges of objects. Pixels with no valid depth within the radius
+// are left missing. Assumes rgb image and depth map are aligned.
+func JointBilateralHoleFilling(dm *DepthMap, img *Image, radius int, spatialSigma, colorSigma float64) (*DepthMap, error) {
+	if dm.Width() != img.Width() || dm.Height() != img.Height() {
+		return nil, fmt.Errorf("depth map (%dx%d) and color image (%dx%d) must be the same size",
+			dm.Width(), dm.Height(), img.Width(), img.Height())
+	}
+	if radius < 1 {
+		return nil, fmt.Errorf("radius must be at least 1, got %d", radius)
+	}
+	spatialGaus := gaussianFunction2D(spatialSigma)
+	colorGaus := gaussianFunction1D(colorSigma)
+	outDM := dm.Clone()
+	for y := 0; y < dm.Height(); y++ {
+		for x := 0; x < dm.Width(); x++ {
+			if dm.GetDepth(x, y) != 0 {
+				continue
+			}
+			centerColor := img.GetXY(x, y)
+			depthSum, weightSum := 0.0, 0.0
+			for dy := -radius; dy <= radius; dy++ {
+				for dx := -radius; dx <= radius; dx++ {
+					if !dm.Contains(x+dx, y+dy) {
+						continue
+					}
+					d := float64(dm.GetDepth(x+dx, y+dy))
+					if d == 0 {
+						continue
+					}
+					weight := spatialGaus(float64(dx), float64(dy)) * colorGaus(centerColor.Distance(img.GetXY(x+dx, y+dy)))
+					depthSum += d * weight
+					weightSum += weight
+				}
+			}
+			if weightSum > 0 {
+				outDM.Set(x, y, Depth(math.Round(depthSum/weightSum)))
+			}
+		}
+	}
+	return outDM, nil
+}
+
+// InpaintDepthMap fills missing pixels from the edges of each hole inwards. Every iteration fills the missing pixels
+// that border valid ones with the distance weighted average of their valid neighbors, so holes up to twice
+// maxIterations pixels across are filled completely. It needs no color image, making it a fast fallback when
+// none is aligned with the depth map.
+func InpaintDepthMap(dm *DepthMap, maxIterations int) *DepthMap {
+	outDM := dm.Clone()
+	for iter := 0; iter < maxIterations; iter++ {
+		// fills are computed from the previous iteration so that each one only grows the valid region by a pixel
+		prevDM := outDM.Clone()
+		filled := 0
+		for y := 0; y < prevDM.Height(); y++ {
+			for x := 0; x < prevDM.Width(); x++ {
+				if prevDM.GetDepth(x, y) != 0 {
+					continue
+				}
+				depthSum, weightSum := 0.0, 0.0
+				for dy := -1; dy <= 1; dy++ {
+					for dx := -1; dx <= 1; dx++ {
+						if (dx == 0 && dy == 0) || !prevDM.Contains(x+dx, y+dy) {
+							continue
+						}
+						d := float64(prevDM.GetDepth(x+dx, y+dy))
+						if d == 0 {
+							continue
+						}
+						weight := 1 / math.Hypot(float64(dx), float64(dy))
+						depthSum += d * weight
+						weightSum += weight
+					}
+				}
+				if weightSum > 0 {
+					outDM.Set(x, y, Depth(math.Round(depthSum/weightSum)))
+					filled++
+				}
+			}
+		}
+		if filled == 0 {
+			break
+		}
+	}
+	return outDM
+}
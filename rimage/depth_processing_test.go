@@ -71,3 +71,55 @@ func TestInterpolations(t *testing.T) {
 	d = BilinearInterpolationDepth(pt, dm)
 	test.That(t, d, test.ShouldBeNil)
 }
+
+func TestHoleFilling(t *testing.T) {
+	// left half is near, right half is far, with a hole straddling the edge between them
+	dm := NewEmptyDepthMap(10, 10)
+	img := NewImage(10, 10)
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			if x < 5 {
+				dm.Set(x, y, 1000)
+				img.SetXY(x, y, NewColor(255, 0, 0))
+			} else {
+				dm.Set(x, y, 3000)
+				img.SetXY(x, y, NewColor(0, 0, 255))
+			}
+		}
+	}
+	for y := 4; y < 6; y++ {
+		for x := 3; x < 7; x++ {
+			dm.Set(x, y, 0)
+		}
+	}
+
+	filled, err := JointBilateralHoleFilling(dm, img, 3, 2, 0.1)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, dm.GetDepth(4, 4), test.ShouldEqual, Depth(0)) // the input is not modified
+	for y := 4; y < 6; y++ {
+		for x := 3; x < 7; x++ {
+			// the color image keeps the depth of each side from bleeding into the other
+			if x < 5 {
+				test.That(t, float64(filled.GetDepth(x, y)), test.ShouldAlmostEqual, 1000, 1)
+			} else {
+				test.That(t, float64(filled.GetDepth(x, y)), test.ShouldAlmostEqual, 3000, 1)
+			}
+		}
+	}
+	_, err = JointBilateralHoleFilling(dm, NewImage(5, 5), 3, 2, 0.1)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	inpainted := InpaintDepthMap(dm, 10)
+	for y := 4; y < 6; y++ {
+		for x := 3; x < 7; x++ {
+			test.That(t, float64(inpainted.GetDepth(x, y)), test.ShouldBeBetweenOrEqual, 1000, 3000)
+		}
+	}
+	// one iteration only fills the edges of the hole
+	test.That(t, InpaintDepthMap(dm, 1).GetDepth(3, 4), test.ShouldNotEqual, Depth(0))
+	test.That(t, InpaintDepthMap(dm, 1).GetDepth(4, 4), test.ShouldNotEqual, Depth(0))
+	holey := NewEmptyDepthMap(5, 5)
+	holey.Set(0, 0, 500)
+	test.That(t, InpaintDepthMap(holey, 1).GetDepth(4, 4), test.ShouldEqual, Depth(0))
+	test.That(t, InpaintDepthMap(holey, 4).GetDepth(4, 4), test.ShouldEqual, Depth(500))
+}
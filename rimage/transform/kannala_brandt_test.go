@@ -0,0 +1,41 @@
+package transform
+
+import (
+	"math"
+	"testing"
+
+	"go.viam.com/test"
+)
+
+func TestKannalaBrandtCheckValid(t *testing.T) {
+	var nilKannalaBrandtPtr *KannalaBrandt
+	err := nilKannalaBrandtPtr.CheckValid()
+	test.That(t, err.Error(), test.ShouldContainSubstring, "KannalaBrandt shaped distortion_parameters not provided")
+	test.That(t, (&KannalaBrandt{}).CheckValid(), test.ShouldBeNil)
+}
+
+func TestKannalaBrandtTransform(t *testing.T) {
+	d, err := NewDistorter(KannalaBrandtDistortionType, []float64{0.1})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, d.ModelType(), test.ShouldEqual, KannalaBrandtDistortionType)
+	test.That(t, d.Parameters(), test.ShouldResemble, []float64{0.1, 0, 0, 0})
+
+	_, err = NewKannalaBrandt([]float64{1, 2, 3, 4, 5})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// the principal point is not moved
+	x, y := d.Transform(0, 0)
+	test.That(t, x, test.ShouldEqual, 0)
+	test.That(t, y, test.ShouldEqual, 0)
+
+	// a point at 45 degrees from the optical axis is pulled in to the distorted angle
+	x, y = d.Transform(0.6, 0.8)
+	theta := math.Pi / 4
+	thetaD := theta * (1 + 0.1*theta*theta)
+	test.That(t, math.Hypot(x, y), test.ShouldAlmostEqual, thetaD)
+	test.That(t, x/y, test.ShouldAlmostEqual, 0.75)
+
+	// without coefficients, the model is still an equidistant projection
+	x, _ = (&KannalaBrandt{}).Transform(1, 0)
+	test.That(t, x, test.ShouldAlmostEqual, theta)
+}
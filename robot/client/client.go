@@ -3,9 +3,11 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -20,6 +22,7 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	apppb "go.viam.com/api/app/v1"
 	commonpb "go.viam.com/api/common/v1"
 	pb "go.viam.com/api/robot/v1"
 	"go.viam.com/utils"
@@ -39,6 +42,7 @@ import (
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/pointcloud"
+	rcpb "go.viam.com/rdk/proto/rdk/resourcechanges/v1"
 	rprotoutils "go.viam.com/rdk/protoutils"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/resource"
@@ -63,6 +67,10 @@ var (
 	// defaultResourcesTimeout is the default timeout for getting resources.
 	defaultResourcesTimeout = 5 * time.Second
 
+	// lowBandwidthCheckInterval is the default interval to check and refresh low bandwidth
+	// connections at.
+	lowBandwidthCheckInterval = time.Minute
+
 	// DoNotWaitForRunning should be set only in tests to allow connecting to
 	// still-initializing machines. Note that robot clients in production (not in
 	// a testing environment) will already allow connecting to still-initializing
@@ -100,6 +108,18 @@ type RobotClient struct {
 	connected                atomic.Bool
 	rpcSubtypesUnimplemented bool
 
+	// low bandwidth links
+	lowBandwidth      bool
+	rpcAPIsMu         sync.Mutex
+	fetchedNames      []resource.Name
+	fetchedRPCAPIs    []resource.RPCAPI
+	rpcAPIsByService  map[string]resource.RPCAPI
+	heartbeatInterval time.Duration
+	// namesToken and deltaNames are the resource names last sent by the low bandwidth service
+	namesToken                string
+	deltaNames                map[string]struct{}
+	lowBandwidthUnimplemented atomic.Bool
+
 	activeBackgroundWorkers sync.WaitGroup
 	backgroundCtx           context.Context
 	backgroundCtxCancel     func()
@@ -125,6 +145,14 @@ type RobotClient struct {
 
 	pc         *webrtc.PeerConnection
 	sharedConn *grpc.SharedConn
+
+	clockOffset grpc.ClockOffsetEstimator
+
+	// methodPolicies are the default deadlines and retry policies advertised by the server.
+	methodPolicies grpc.MethodPolicyLearner
+
+	// offlineQueue, if set, holds commands called while disconnected until reconnecting.
+	offlineQueue *offlineQueue
 }
 
 // RemoteTypeName is the type name used for a remote. This is for internal use.
@@ -285,17 +313,28 @@ func New(ctx context.Context, address string, clientLogger logging.ZapCompatible
 		resourceClients:     make(map[resource.Name]resource.Resource),
 		remoteNameMap:       make(map[resource.Name]resource.Name),
 		sessionsDisabled:    rOpts.disableSessions,
+		lowBandwidth:        rOpts.lowBandwidth,
+		rpcAPIsByService:    make(map[string]resource.RPCAPI),
+		heartbeatInterval:   rOpts.heartbeatInterval,
 		heartbeatCtx:        heartbeatCtx,
 		heartbeatCtxCancel:  heartbeatCtxCancel,
 	}
+	if rOpts.offlineQueue != nil {
+		rc.offlineQueue = newOfflineQueue(*rOpts.offlineQueue)
+	}
 
 	// interceptors are applied in order from first to last
 	rc.dialOptions = append(
 		rc.dialOptions,
 		rpc.WithUnaryClientInterceptor(contextutils.ContextWithMetadataUnaryClientInterceptor),
+		// queueing commands while disconnected
+		rpc.WithUnaryClientInterceptor(rc.offlineQueueUnaryInterceptor),
 		// error handling
 		rpc.WithUnaryClientInterceptor(rc.handleUnaryDisconnect),
 		rpc.WithStreamClientInterceptor(rc.handleStreamDisconnect),
+		// default deadlines and retries advertised by the server, which grpc_retry carries out
+		rpc.WithUnaryClientInterceptor(rc.methodPolicies.UnaryClientInterceptor),
+		rpc.WithStreamClientInterceptor(rc.methodPolicies.StreamClientInterceptor),
 		// sessions
 		rpc.WithUnaryClientInterceptor(grpc_retry.UnaryClientInterceptor()),
 		rpc.WithStreamClientInterceptor(grpc_retry.StreamClientInterceptor()),
@@ -308,6 +347,8 @@ func New(ctx context.Context, address string, clientLogger logging.ZapCompatible
 		// sending version metadata
 		rpc.WithUnaryClientInterceptor(unaryClientInterceptor()),
 		rpc.WithStreamClientInterceptor(streamClientInterceptor()),
+		// clock synchronization
+		rpc.WithUnaryClientInterceptor(rc.clockOffset.UnaryClientInterceptor),
 	)
 
 	// If we're a client running as part of a module, we annotate our requests with our module
@@ -366,7 +407,7 @@ func New(ctx context.Context, address string, clientLogger logging.ZapCompatible
 				return nil, err
 			}
 
-			if mStatus.State == robot.StateRunning {
+			if mStatus.State == robot.StateRunning || mStatus.State == robot.StateShuttingDown {
 				break
 			}
 			time.Sleep(50 * time.Millisecond)
@@ -404,15 +445,19 @@ func New(ctx context.Context, address string, clientLogger logging.ZapCompatible
 		return nil, multierr.Combine(err, rc.conn.Close())
 	}
 
+	defaultCheckTime := 10 * time.Second
+	if rOpts.lowBandwidth {
+		defaultCheckTime = lowBandwidthCheckInterval
+	}
 	var refreshTime time.Duration
 	if rOpts.refreshEvery == nil {
-		refreshTime = 10 * time.Second
+		refreshTime = defaultCheckTime
 	} else {
 		refreshTime = *rOpts.refreshEvery
 	}
 	var checkConnectedTime time.Duration
 	if rOpts.checkConnectedEvery == nil {
-		checkConnectedTime = 10 * time.Second
+		checkConnectedTime = defaultCheckTime
 	} else {
 		checkConnectedTime = *rOpts.checkConnectedEvery
 	}
@@ -458,6 +503,13 @@ func (rc *RobotClient) Connected() bool {
 	return rc.connected.Load()
 }
 
+// ClockOffset returns how far the remote's clock is ahead of ours, as estimated from the times
+// it reports on responses, or false if it has not reported any yet. Add the offset to a local
+// time to get the remote's time, or use ToLocal to convert a time read from the remote.
+func (rc *RobotClient) ClockOffset() (grpc.ClockOffset, bool) {
+	return rc.clockOffset.Estimate()
+}
+
 // Changed watches for whether the remote has changed.
 func (rc *RobotClient) Changed() <-chan bool {
 	rc.mu.Lock()
@@ -479,6 +531,7 @@ func (rc *RobotClient) Connect(ctx context.Context) error {
 		rc.notifyParent()
 		rc.Logger().CDebugw(ctx, "successfully notified parent after (re)connection", "address", rc.address)
 	}
+	rc.sendQueuedCommands(rc.backgroundCtx)
 	return nil
 }
 
@@ -489,6 +542,7 @@ func (rc *RobotClient) connectWithLock(ctx context.Context) error {
 	if err := rc.conn.Close(); err != nil {
 		return err
 	}
+	rc.forgetRPCAPIs()
 
 	// Try forcing a webrtc connection.
 	dialOptionsWebRTCOnly := make([]rpc.DialOption, len(rc.dialOptions)+1)
@@ -761,30 +815,44 @@ func (rc *RobotClient) resources(ctx context.Context) ([]resource.Name, []resour
 		defer cancel()
 	}
 
-	resp, err := rc.client.ResourceNames(ctx, &pb.ResourceNamesRequest{})
+	resources, ok, err := rc.lowBandwidthResourceNames(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
+	if !ok {
+		resp, err := rc.client.ResourceNames(ctx, &pb.ResourceNamesRequest{})
+		if err != nil {
+			return nil, nil, err
+		}
+		resources = make([]resource.Name, 0, len(resp.Resources))
+		for _, name := range resp.Resources {
+			newName := rprotoutils.ResourceNameFromProto(name)
+			resources = append(resources, newName)
+		}
+	}
 
 	var resTypes []resource.RPCAPI
 
-	resources := make([]resource.Name, 0, len(resp.Resources))
-	for _, name := range resp.Resources {
-		newName := rprotoutils.ResourceNameFromProto(name)
-		resources = append(resources, newName)
-	}
-
 	// resource has previously returned an unimplemented response, skip rpc call
 	if rc.rpcSubtypesUnimplemented {
 		return resources, resTypes, nil
 	}
 
+	// on low bandwidth links, the APIs are only fetched again when the resources change
+	if apis, ok := rc.unchangedRPCAPIs(resources); ok {
+		return resources, apis, nil
+	}
+
 	typesResp, err := rc.client.ResourceRPCSubtypes(ctx, &pb.ResourceRPCSubtypesRequest{})
 	if err == nil {
 		reflSource := grpcurl.DescriptorSourceFromServer(ctx, rc.refClient)
 
 		resTypes = make([]resource.RPCAPI, 0, len(typesResp.ResourceRpcSubtypes))
 		for _, resAPI := range typesResp.ResourceRpcSubtypes {
+			if api, ok := rc.knownRPCAPI(resAPI.ProtoService); ok {
+				resTypes = append(resTypes, api)
+				continue
+			}
 			symDesc, err := reflSource.FindSymbol(resAPI.ProtoService)
 			if err != nil {
 				// Note: This happens right now if a client is talking to a main server
@@ -805,11 +873,14 @@ func (rc *RobotClient) resources(ctx context.Context) ([]resource.Name, []resour
 			if !ok {
 				return nil, nil, fmt.Errorf("expected descriptor to be service descriptor but got %T", symDesc)
 			}
-			resTypes = append(resTypes, resource.RPCAPI{
+			api := resource.RPCAPI{
 				API:  rprotoutils.ResourceNameFromProto(resAPI.Subtype).API,
 				Desc: svcDesc,
-			})
+			}
+			rc.rememberRPCAPI(resAPI.ProtoService, api)
+			resTypes = append(resTypes, api)
 		}
+		rc.rememberFetchedRPCAPIs(resources, resTypes)
 	} else {
 		if s, ok := status.FromError(err); !(ok && (s.Code() == codes.Unimplemented)) {
 			return nil, nil, err
@@ -1085,6 +1156,30 @@ func (rc *RobotClient) CurrentInputs(ctx context.Context) (referenceframe.FrameS
 	return input, nil
 }
 
+// GetWorldGeometries returns the geometries of every frame in the machine's frame system, keyed by frame name and
+// posed in the world frame. The frame system config and current inputs are fetched once and the geometries are
+// transformed locally, rather than with a TransformPose call per frame.
+//
+//	geometries, err := machine.GetWorldGeometries(ctx, nil)
+func (rc *RobotClient) GetWorldGeometries(
+	ctx context.Context,
+	supplementalTransforms []*referenceframe.LinkInFrame,
+) (map[string]*referenceframe.GeometriesInFrame, error) {
+	fsCfg, err := rc.FrameSystemConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fs, err := referenceframe.NewFrameSystem(framesystem.LocalFrameSystemName, fsCfg.Parts, supplementalTransforms)
+	if err != nil {
+		return nil, err
+	}
+	input, err := rc.CurrentInputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return framesystem.WorldGeometries(fs, input)
+}
+
 // StopAll cancels all current and outstanding operations for the machine and stops all actuators and movement.
 //
 //	err := machine.StopAll(ctx.Background())
@@ -1198,13 +1293,19 @@ func (rc *RobotClient) Shutdown(ctx context.Context) error {
 
 // MachineStatus returns the current status of the robot.
 func (rc *RobotClient) MachineStatus(ctx context.Context) (robot.MachineStatus, error) {
+	if mStatus, ok, err := rc.lowBandwidthMachineStatus(ctx); ok || err != nil {
+		return mStatus, err
+	}
 	mStatus := robot.MachineStatus{}
 
 	req := &pb.GetMachineStatusRequest{}
-	resp, err := rc.client.GetMachineStatus(ctx, req)
+	var hdr metadata.MD
+	resp, err := rc.client.GetMachineStatus(ctx, req, googlegrpc.Header(&hdr))
 	if err != nil {
 		return mStatus, err
 	}
+	generations := resourceGenerationsFromHeader(hdr)
+	crashes := resourceCrashesFromHeader(hdr)
 
 	if resp.Config != nil {
 		mStatus.Config = config.Revision{
@@ -1223,6 +1324,11 @@ func (rc *RobotClient) MachineStatus(ctx context.Context) (robot.MachineStatus,
 			},
 			CloudMetadata: rprotoutils.MetadataFromProto(pbResStatus.CloudMetadata),
 		}
+		resStatus.Generation = generations[resStatus.Name.String()]
+		if resCrashes, ok := crashes[resStatus.Name.String()]; ok {
+			resStatus.Configure.Builds.Crashes = resCrashes.BuildCrashes
+			resStatus.Configure.Reconfigures.Crashes = resCrashes.ReconfigureCrashes
+		}
 
 		switch pbResStatus.State {
 		case pb.ResourceStatus_STATE_UNSPECIFIED:
@@ -1254,11 +1360,36 @@ func (rc *RobotClient) MachineStatus(ctx context.Context) (robot.MachineStatus,
 		mStatus.State = robot.StateInitializing
 	case pb.GetMachineStatusResponse_STATE_RUNNING:
 		mStatus.State = robot.StateRunning
+		if slices.Contains(hdr.Get(operation.DrainingMetadataKey), "true") {
+			mStatus.State = robot.StateShuttingDown
+		}
 	}
 
 	return mStatus, nil
 }
 
+// resourceGenerationsFromHeader returns the generation of each resource, by name, sent in the
+// header of a GetMachineStatus response. Machines that do not send them report none.
+func resourceGenerationsFromHeader(hdr metadata.MD) map[string]uint64 {
+	values := grpc.DecodeMetadataValues[robot.ResourceGeneration](hdr, robot.ResourceGenerationsMetadataKey)
+	generations := make(map[string]uint64, len(values))
+	for _, v := range values {
+		generations[v.Name] = v.Generation
+	}
+	return generations
+}
+
+// resourceCrashesFromHeader returns how many times building and reconfiguring each resource
+// panicked, by name, sent in the header of a GetMachineStatus response.
+func resourceCrashesFromHeader(hdr metadata.MD) map[string]robot.ResourceCrashes {
+	values := grpc.DecodeMetadataValues[robot.ResourceCrashes](hdr, robot.ResourceCrashesMetadataKey)
+	crashes := make(map[string]robot.ResourceCrashes, len(values))
+	for _, v := range values {
+		crashes[v.Name] = v
+	}
+	return crashes
+}
+
 // Version returns version information about the machine.
 func (rc *RobotClient) Version(ctx context.Context) (robot.VersionResponse, error) {
 	mVersion := robot.VersionResponse{}
@@ -1275,6 +1406,62 @@ func (rc *RobotClient) Version(ctx context.Context) (robot.VersionResponse, erro
 	return mVersion, nil
 }
 
+// Introspect describes the machine, with its resources, their sanitized configs, properties
+// and capabilities, its frame system and its modules, as one document.
+func (rc *RobotClient) Introspect(ctx context.Context) (*robot.Introspection, error) {
+	md, err := grpc.GetIntrospection(ctx, &rc.conn)
+	if err != nil {
+		return nil, err
+	}
+	var intro robot.Introspection
+	if err := json.Unmarshal(md, &intro); err != nil {
+		return nil, err
+	}
+	return &intro, nil
+}
+
+// ApplyResourceChanges adds, removes and updates the given components and services of the machine
+// in a single reconfigure, returning why each added or updated resource that failed to build or
+// reconfigure did so.
+func (rc *RobotClient) ApplyResourceChanges(
+	ctx context.Context,
+	adds []resource.Config,
+	removes []resource.Name,
+	updates []resource.Config,
+) (map[resource.Name]error, error) {
+	toProto := func(confs []resource.Config) ([]*apppb.ComponentConfig, error) {
+		out := make([]*apppb.ComponentConfig, 0, len(confs))
+		for _, conf := range confs {
+			confProto, err := config.ComponentConfigToProto(&conf)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, confProto)
+		}
+		return out, nil
+	}
+	req := &rcpb.ApplyResourceChangesRequest{}
+	var err error
+	if req.Adds, err = toProto(adds); err != nil {
+		return nil, err
+	}
+	if req.Updates, err = toProto(updates); err != nil {
+		return nil, err
+	}
+	for _, name := range removes {
+		req.Removes = append(req.Removes, rprotoutils.ResourceNameToProto(name))
+	}
+	resp, err := rcpb.NewResourceChangesServiceClient(&rc.conn).ApplyResourceChanges(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resErrs := make(map[resource.Name]error, len(resp.GetErrors()))
+	for _, resErr := range resp.GetErrors() {
+		resErrs[rprotoutils.ResourceNameFromProto(resErr.GetName())] = errors.New(resErr.GetError())
+	}
+	return resErrs, nil
+}
+
 // Tunnel tunnels data to/from the read writer from/to the destination port on the server. This
 // function will close the connection passed in as part of cleanup.
 func (rc *RobotClient) Tunnel(ctx context.Context, conn io.ReadWriteCloser, dest int) error {
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/grpc"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+)
+
+// lowBandwidthResourceNames returns the resource names of the robot using the low bandwidth
+// service, which only sends the names that changed since the last call. It returns false if the
+// client is not on a low bandwidth link or the robot does not serve the service.
+func (rc *RobotClient) lowBandwidthResourceNames(ctx context.Context) ([]resource.Name, bool, error) {
+	if !rc.lowBandwidth || rc.lowBandwidthUnimplemented.Load() {
+		return nil, false, nil
+	}
+	rc.rpcAPIsMu.Lock()
+	since := rc.namesToken
+	rc.rpcAPIsMu.Unlock()
+
+	delta, err := grpc.GetResourceNamesDelta(ctx, &rc.conn, since)
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			rc.lowBandwidthUnimplemented.Store(true)
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	rc.rpcAPIsMu.Lock()
+	defer rc.rpcAPIsMu.Unlock()
+	// the names may have been forgotten on a reconnect while the delta was fetched
+	if rc.namesToken != since {
+		return nil, false, nil
+	}
+	if delta.Full {
+		rc.deltaNames = make(map[string]struct{}, len(delta.Added))
+	} else if rc.deltaNames == nil {
+		return nil, false, errors.New("low bandwidth service sent a delta against unknown resource names")
+	}
+	for _, name := range delta.Removed {
+		delete(rc.deltaNames, name)
+	}
+	for _, name := range delta.Added {
+		rc.deltaNames[name] = struct{}{}
+	}
+	rc.namesToken = delta.Token
+
+	names := make([]resource.Name, 0, len(rc.deltaNames))
+	for _, s := range slices.Sorted(maps.Keys(rc.deltaNames)) {
+		name, err := resource.NewFromString(s)
+		if err != nil {
+			return nil, false, err
+		}
+		names = append(names, name)
+	}
+	return names, true, nil
+}
+
+// lowBandwidthMachineStatus returns the status of the robot using the low bandwidth service,
+// which leaves out when each resource was last updated, its cloud metadata and its generation. It
+// returns false if the client is not on a low bandwidth link or the robot does not serve the
+// service.
+func (rc *RobotClient) lowBandwidthMachineStatus(ctx context.Context) (robot.MachineStatus, bool, error) {
+	if !rc.lowBandwidth || rc.lowBandwidthUnimplemented.Load() {
+		return robot.MachineStatus{}, false, nil
+	}
+	compact, err := grpc.GetCompactMachineStatus(ctx, &rc.conn)
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			rc.lowBandwidthUnimplemented.Store(true)
+			return robot.MachineStatus{}, false, nil
+		}
+		return robot.MachineStatus{}, false, err
+	}
+	mStatus := robot.MachineStatus{
+		Config:    config.Revision{Revision: compact.ConfigRevision},
+		State:     robot.MachineStateFromString(compact.State),
+		Resources: make([]resource.Status, 0, len(compact.Resources)),
+	}
+	for _, res := range compact.Resources {
+		name, err := resource.NewFromString(res.Name)
+		if err != nil {
+			return robot.MachineStatus{}, false, err
+		}
+		resStatus := resource.Status{NodeStatus: resource.NodeStatus{
+			Name:     name,
+			State:    resource.NodeStateFromString(res.State),
+			Revision: res.Revision,
+		}}
+		if res.Error != "" {
+			resStatus.Error = errors.New(res.Error)
+		}
+		mStatus.Resources = append(mStatus.Resources, resStatus)
+	}
+	return mStatus, true, nil
+}
+
+// unchangedRPCAPIs returns the APIs fetched along with the given resource names if the client is on a
+// low bandwidth link and the names have not changed since.
+func (rc *RobotClient) unchangedRPCAPIs(names []resource.Name) ([]resource.RPCAPI, bool) {
+	if !rc.lowBandwidth {
+		return nil, false
+	}
+	rc.rpcAPIsMu.Lock()
+	defer rc.rpcAPIsMu.Unlock()
+	if rc.fetchedRPCAPIs == nil || !sameResourceNames(rc.fetchedNames, names) {
+		return nil, false
+	}
+	return rc.fetchedRPCAPIs, true
+}
+
+// rememberFetchedRPCAPIs records the APIs fetched along with the given resource names.
+func (rc *RobotClient) rememberFetchedRPCAPIs(names []resource.Name, apis []resource.RPCAPI) {
+	if !rc.lowBandwidth {
+		return
+	}
+	rc.rpcAPIsMu.Lock()
+	defer rc.rpcAPIsMu.Unlock()
+	rc.fetchedNames = slices.Clone(names)
+	rc.fetchedRPCAPIs = slices.Clone(apis)
+}
+
+// knownRPCAPI returns the API already resolved through reflection for a proto service, so that low
+// bandwidth clients only reflect on APIs they have not seen before.
+func (rc *RobotClient) knownRPCAPI(protoService string) (resource.RPCAPI, bool) {
+	if !rc.lowBandwidth {
+		return resource.RPCAPI{}, false
+	}
+	rc.rpcAPIsMu.Lock()
+	defer rc.rpcAPIsMu.Unlock()
+	api, ok := rc.rpcAPIsByService[protoService]
+	return api, ok
+}
+
+func (rc *RobotClient) rememberRPCAPI(protoService string, api resource.RPCAPI) {
+	if !rc.lowBandwidth {
+		return
+	}
+	rc.rpcAPIsMu.Lock()
+	defer rc.rpcAPIsMu.Unlock()
+	rc.rpcAPIsByService[protoService] = api
+}
+
+// forgetRPCAPIs drops what was fetched over a previous connection, which may have been to a robot
+// that has since restarted with different APIs.
+func (rc *RobotClient) forgetRPCAPIs() {
+	rc.rpcAPIsMu.Lock()
+	defer rc.rpcAPIsMu.Unlock()
+	rc.fetchedNames = nil
+	rc.fetchedRPCAPIs = nil
+	clear(rc.rpcAPIsByService)
+	rc.namesToken = ""
+	rc.deltaNames = nil
+	rc.lowBandwidthUnimplemented.Store(false)
+}
+
+// sameResourceNames returns whether a and b hold the same names, in any order.
+func sameResourceNames(a, b []resource.Name) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[resource.Name]int, len(a))
+	for _, n := range a {
+		counts[n]++
+	}
+	for _, n := range b {
+		if counts[n] == 0 {
+			return false
+		}
+		counts[n]--
+	}
+	return true
+}
@@ -0,0 +1,242 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrCommandQueued is returned by calls that were queued to be sent once the client reconnects,
+// rather than sent, because the client is not connected to the machine.
+var ErrCommandQueued = errors.New("not connected to the machine, command queued to be sent on reconnect")
+
+// OfflineQueueConfig configures the queue of commands buffered while a client is disconnected.
+type OfflineQueueConfig struct {
+	// Methods are the full gRPC names of the methods to queue, such as
+	// "/viam.component.motor.v1.MotorService/SetPower". They must be idempotent, since a command
+	// that was sent just as the connection dropped may be applied twice.
+	Methods []string
+	// TTL is how long a queued command is sent for before it is dropped, unless the context of
+	// its call sets another with ContextWithCommandTTL.
+	TTL time.Duration
+	// MaxSize is how many commands can be queued at once. The oldest are dropped to make room.
+	MaxSize int
+}
+
+// ContextWithCommandTTL returns a context whose calls, if queued while the client is
+// disconnected, are dropped once the given time has passed rather than after the queue's TTL. A
+// TTL that is not positive keeps calls from being queued at all.
+func ContextWithCommandTTL(ctx context.Context, ttl time.Duration) context.Context {
+	return context.WithValue(ctx, commandTTLKey{}, ttl)
+}
+
+type (
+	commandTTLKey    struct{}
+	replayCommandKey struct{}
+)
+
+// queuedCommand is a unary call waiting to be sent.
+type queuedCommand struct {
+	method  string
+	req     proto.Message
+	reply   proto.Message
+	expires time.Time
+}
+
+// offlineQueue holds commands, in the order they were called, until the client reconnects.
+type offlineQueue struct {
+	methods map[string]bool
+	ttl     time.Duration
+	maxSize int
+
+	// replayMu is held while replaying, so that commands are sent in order.
+	replayMu sync.Mutex
+
+	mu       sync.Mutex
+	commands []queuedCommand
+}
+
+func newOfflineQueue(conf OfflineQueueConfig) *offlineQueue {
+	methods := make(map[string]bool, len(conf.Methods))
+	for _, method := range conf.Methods {
+		methods[method] = true
+	}
+	maxSize := conf.MaxSize
+	if maxSize <= 0 {
+		maxSize = 1
+	}
+	return &offlineQueue{methods: methods, ttl: conf.TTL, maxSize: maxSize}
+}
+
+// ttlFor returns how long a call of the method with the given context can stay queued, if it
+// can be queued at all.
+func (q *offlineQueue) ttlFor(ctx context.Context, method string) (time.Duration, bool) {
+	if q == nil || !q.methods[method] || ctx.Value(replayCommandKey{}) != nil {
+		return 0, false
+	}
+	ttl := q.ttl
+	if ctxTTL, ok := ctx.Value(commandTTLKey{}).(time.Duration); ok {
+		ttl = ctxTTL
+	}
+	return ttl, ttl > 0
+}
+
+// push queues a copy of a call, returning how many older commands were dropped to make room.
+func (q *offlineQueue) push(method string, req, reply interface{}, ttl time.Duration) (int, bool) {
+	reqMsg, ok := req.(proto.Message)
+	if !ok {
+		return 0, false
+	}
+	replyMsg, ok := reply.(proto.Message)
+	if !ok {
+		return 0, false
+	}
+	cmd := queuedCommand{
+		method:  method,
+		req:     proto.Clone(reqMsg),
+		reply:   replyMsg.ProtoReflect().New().Interface(),
+		expires: time.Now().Add(ttl),
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.commands = append(q.commands, cmd)
+	dropped := max(len(q.commands)-q.maxSize, 0)
+	q.commands = q.commands[dropped:]
+	return dropped, true
+}
+
+// pop returns the oldest command that has not expired, along with how many expired commands it
+// dropped to get to it.
+func (q *offlineQueue) pop() (queuedCommand, int, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := time.Now()
+	expired := 0
+	for len(q.commands) > 0 {
+		cmd := q.commands[0]
+		q.commands = q.commands[1:]
+		if now.Before(cmd.expires) {
+			return cmd, expired, true
+		}
+		expired++
+	}
+	return queuedCommand{}, expired, false
+}
+
+// unpop puts a command that could not be sent back at the front of the queue.
+func (q *offlineQueue) unpop(cmd queuedCommand) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.commands = append([]queuedCommand{cmd}, q.commands...)
+}
+
+func (q *offlineQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.commands)
+}
+
+// QueuedCommands returns how many commands are queued to be sent once the client reconnects.
+func (rc *RobotClient) QueuedCommands() int {
+	if rc.offlineQueue == nil {
+		return 0
+	}
+	return rc.offlineQueue.len()
+}
+
+// queueCommand queues a call made while disconnected, if it is of a method that can be queued.
+func (rc *RobotClient) queueCommand(ctx context.Context, method string, req, reply interface{}) bool {
+	ttl, ok := rc.offlineQueue.ttlFor(ctx, method)
+	if !ok {
+		return false
+	}
+	dropped, ok := rc.offlineQueue.push(method, req, reply, ttl)
+	if !ok {
+		return false
+	}
+	if dropped > 0 {
+		rc.Logger().CWarnw(ctx, "offline command queue is full, dropped oldest commands", "dropped", dropped)
+	}
+	rc.Logger().CDebugw(ctx, "not connected, queued command", "method", method, "ttl", ttl)
+	return true
+}
+
+// sendQueuedCommands sends the queued commands in order, until the queue is empty or the
+// connection drops again. It is called on reconnecting, and before sending any call that could
+// have been queued, so that no call overtakes the commands queued before it.
+func (rc *RobotClient) sendQueuedCommands(ctx context.Context) {
+	q := rc.offlineQueue
+	if q == nil {
+		return
+	}
+	// the queue is only empty once the command last popped has been sent, so it is checked under
+	// replayMu for calls not to overtake that command.
+	q.replayMu.Lock()
+	defer q.replayMu.Unlock()
+	if q.len() == 0 {
+		return
+	}
+	ctx = context.WithValue(ctx, replayCommandKey{}, true)
+	for {
+		cmd, expired, ok := q.pop()
+		if expired > 0 {
+			rc.Logger().CInfow(ctx, "dropped queued commands that expired before reconnecting", "expired", expired)
+		}
+		if !ok {
+			return
+		}
+		cmdCtx, cancel := context.WithDeadline(ctx, cmd.expires)
+		err := rc.conn.Invoke(cmdCtx, cmd.method, cmd.req, cmd.reply)
+		cancel()
+		if rc.isNotConnectedError(err) {
+			q.unpop(cmd)
+			return
+		}
+		if err != nil {
+			rc.Logger().CWarnw(ctx, "queued command failed", "method", cmd.method, "error", err)
+		}
+	}
+}
+
+// offlineQueueUnaryInterceptor queues calls made while disconnected, and keeps calls made once
+// reconnected from overtaking the ones queued before them.
+func (rc *RobotClient) offlineQueueUnaryInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *googlegrpc.ClientConn,
+	invoker googlegrpc.UnaryInvoker,
+	opts ...googlegrpc.CallOption,
+) error {
+	if _, ok := rc.offlineQueue.ttlFor(ctx, method); !ok {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	if rc.connected.Load() {
+		rc.sendQueuedCommands(rc.backgroundCtx)
+	}
+	if !rc.connected.Load() {
+		if rc.queueCommand(ctx, method, req, reply) {
+			return ErrCommandQueued
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	// the connection dropped before it was noticed, and the command may not have been sent
+	if rc.isNotConnectedError(err) && rc.queueCommand(ctx, method, req, reply) {
+		return ErrCommandQueued
+	}
+	return err
+}
+
+// isNotConnectedError returns whether err is the error calls fail with when the client is not
+// connected, see handleUnaryDisconnect.
+func (rc *RobotClient) isNotConnectedError(err error) bool {
+	s, ok := status.FromError(err)
+	return ok && s.Code() == codes.Unavailable && s.Message() == rc.notConnectedToRemoteError().Error()
+}
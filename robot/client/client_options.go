@@ -42,6 +42,16 @@ type robotClientOpts struct {
 	initialConnectionAttempts *int
 
 	modName string
+
+	// lowBandwidth tunes the client for constrained links.
+	lowBandwidth bool
+
+	// heartbeatInterval is how often to send session heartbeats. If unset, it is
+	// a fifth of the heartbeat window of the robot.
+	heartbeatInterval time.Duration
+
+	// offlineQueue, if set, configures queueing commands while disconnected.
+	offlineQueue *OfflineQueueConfig
 }
 
 // RobotClientOption configures how we set up the connection.
@@ -105,6 +115,36 @@ func WithReconnectEvery(reconnectEvery time.Duration) RobotClientOption {
 	})
 }
 
+// WithLowBandwidth returns a RobotClientOption that tunes the client for constrained links, such
+// as to a micro-RDK on LTE-M. The connection is checked and refreshed every minute unless
+// configured otherwise, and the APIs of the robot's resources are only fetched again, and only
+// for APIs not seen before, when its resources change. Robots that serve the low bandwidth
+// service only send the resource names that changed, and send machine status as CBOR.
+func WithLowBandwidth() RobotClientOption {
+	return newFuncRobotClientOption(func(o *robotClientOpts) {
+		o.lowBandwidth = true
+	})
+}
+
+// WithHeartbeatInterval returns a RobotClientOption for how often to send session heartbeats.
+// It is capped at half of the robot's heartbeat window so that sessions do not expire.
+func WithHeartbeatInterval(heartbeatInterval time.Duration) RobotClientOption {
+	return newFuncRobotClientOption(func(o *robotClientOpts) {
+		o.heartbeatInterval = heartbeatInterval
+	})
+}
+
+// WithOfflineQueue returns a RobotClientOption that queues calls of the configured methods made
+// while the client is disconnected, such as from a handheld controller on a flaky link, and sends
+// them in order once it reconnects. Queued calls return ErrCommandQueued, and are dropped if they
+// are not sent before their TTL passes. Calls of those methods made once reconnected wait for the
+// queue to be sent first.
+func WithOfflineQueue(conf OfflineQueueConfig) RobotClientOption {
+	return newFuncRobotClientOption(func(o *robotClientOpts) {
+		o.offlineQueue = &conf
+	})
+}
+
 // WithRemoteName returns a RobotClientOption setting the name of the remote robot.
 func WithRemoteName(remoteName string) RobotClientOption {
 	return newFuncRobotClientOption(func(o *robotClientOpts) {
@@ -125,6 +125,9 @@ func (rc *RobotClient) sessionMetadata(ctx context.Context, method string) (cont
 
 	heartbeatWindow := startResp.HeartbeatWindow.AsDuration()
 	sessionHeartbeatInterval := heartbeatWindow / 5
+	if rc.heartbeatInterval > 0 {
+		sessionHeartbeatInterval = min(rc.heartbeatInterval, heartbeatWindow/2)
+	}
 	if heartbeatWindow <= 0 || sessionHeartbeatInterval <= 0 {
 		rc.logger.CInfow(ctx, "session heartbeat window invalid; will not try again", "heartbeat_window", heartbeatWindow)
 		return ctx, nil
@@ -56,6 +56,7 @@ import (
 	rgrpc "go.viam.com/rdk/grpc"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/operation"
+	lbpb "go.viam.com/rdk/proto/rdk/lowbandwidth/v1"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/rimage"
@@ -2230,6 +2231,31 @@ func TestMachineStatus(t *testing.T) {
 			},
 			0,
 		},
+		{
+			"generations",
+			robot.MachineStatus{
+				Config: config.Revision{Revision: "rev1"},
+				Resources: []resource.Status{
+					{
+						NodeStatus: resource.NodeStatus{
+							Name:       arm.Named("arm1"),
+							State:      resource.NodeStateReady,
+							Revision:   "rev1",
+							Generation: 3,
+						},
+					},
+					{
+						NodeStatus: resource.NodeStatus{
+							Name:     arm.Named("arm2"),
+							State:    resource.NodeStateConfiguring,
+							Revision: "rev1",
+						},
+					},
+				},
+				State: robot.StateRunning,
+			},
+			0,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			logger, logs := logging.NewObservedTestLogger(t)
@@ -2351,3 +2377,220 @@ func TestListTunnels(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, ttes, test.ShouldResemble, expectedTTEs)
 }
+
+func TestLowBandwidthRefresh(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	listener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	gServer := grpc.NewServer()
+
+	var namesMu sync.Mutex
+	names := []resource.Name{arm.Named("arm1")}
+	var apiCalls atomic.Int32
+	injectRobot := &inject.Robot{
+		ResourceNamesFunc: func() []resource.Name {
+			namesMu.Lock()
+			defer namesMu.Unlock()
+			return names
+		},
+		ResourceRPCAPIsFunc: func() []resource.RPCAPI {
+			apiCalls.Add(1)
+			return nil
+		},
+		MachineStatusFunc: func(ctx context.Context) (robot.MachineStatus, error) {
+			return robot.MachineStatus{State: robot.StateRunning}, nil
+		},
+	}
+
+	pb.RegisterRobotServiceServer(gServer, server.New(injectRobot))
+
+	go gServer.Serve(listener)
+	defer gServer.Stop()
+
+	client, err := New(context.Background(), listener.Addr().String(), logger, WithLowBandwidth())
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, client.Close(context.Background()), test.ShouldBeNil)
+	}()
+	test.That(t, apiCalls.Load(), test.ShouldEqual, 1)
+
+	// APIs are not fetched again while the resources stay the same
+	test.That(t, client.Refresh(context.Background()), test.ShouldBeNil)
+	test.That(t, apiCalls.Load(), test.ShouldEqual, 1)
+
+	namesMu.Lock()
+	names = []resource.Name{arm.Named("arm1"), arm.Named("arm2")}
+	namesMu.Unlock()
+	test.That(t, client.Refresh(context.Background()), test.ShouldBeNil)
+	test.That(t, apiCalls.Load(), test.ShouldEqual, 2)
+	test.That(t, client.ResourceNames(), test.ShouldHaveLength, 2)
+}
+
+func TestClientOfflineQueue(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	listener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+
+	const setPower = "/viam.component.motor.v1.MotorService/SetPower"
+	const slowPower = 0.6
+	var powersMu sync.Mutex
+	var powers []float64
+	slowReceived, releaseSlow := make(chan struct{}), make(chan struct{})
+	gServer := grpc.NewServer(grpc.UnknownServiceHandler(func(srv interface{}, stream grpc.ServerStream) error {
+		// the client calls other services on its own while connected
+		if method, _ := grpc.MethodFromServerStream(stream); method != setPower {
+			return status.Errorf(codes.Unimplemented, "unknown method %s", method)
+		}
+		var req motorpb.SetPowerRequest
+		if err := stream.RecvMsg(&req); err != nil {
+			return err
+		}
+		if req.PowerPct == slowPower {
+			close(slowReceived)
+			<-releaseSlow
+		}
+		powersMu.Lock()
+		powers = append(powers, req.PowerPct)
+		powersMu.Unlock()
+		return stream.SendMsg(&motorpb.SetPowerResponse{})
+	}))
+
+	injectRobot := &inject.Robot{}
+	injectRobot.ResourceRPCAPIsFunc = func() []resource.RPCAPI { return nil }
+	injectRobot.ResourceNamesFunc = func() []resource.Name { return nil }
+	injectRobot.MachineStatusFunc = func(ctx context.Context) (robot.MachineStatus, error) {
+		return robot.MachineStatus{State: robot.StateRunning}, nil
+	}
+	pb.RegisterRobotServiceServer(gServer, server.New(injectRobot))
+
+	go gServer.Serve(listener)
+	defer gServer.Stop()
+
+	never := -1 * time.Second
+	client, err := New(
+		context.Background(),
+		listener.Addr().String(),
+		logger,
+		WithCheckConnectedEvery(never),
+		WithReconnectEvery(never),
+		WithOfflineQueue(OfflineQueueConfig{Methods: []string{setPower}, TTL: time.Minute, MaxSize: 3}),
+	)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, client.Close(context.Background()), test.ShouldBeNil)
+	}()
+
+	sendPower := func(ctx context.Context, power float64) error {
+		return client.conn.Invoke(ctx, setPower, &motorpb.SetPowerRequest{Name: "m", PowerPct: power}, &motorpb.SetPowerResponse{})
+	}
+
+	client.connected.Store(false)
+	test.That(t, sendPower(context.Background(), 0.1), test.ShouldEqual, ErrCommandQueued)
+	test.That(t, sendPower(ContextWithCommandTTL(context.Background(), time.Millisecond), 0.2), test.ShouldEqual, ErrCommandQueued)
+	test.That(t, sendPower(context.Background(), 0.3), test.ShouldEqual, ErrCommandQueued)
+	// the oldest command is dropped to make room
+	test.That(t, sendPower(context.Background(), 0.4), test.ShouldEqual, ErrCommandQueued)
+	test.That(t, client.QueuedCommands(), test.ShouldEqual, 3)
+
+	// calls that may not be queued fail as before
+	err = sendPower(ContextWithCommandTTL(context.Background(), 0), 0.9)
+	test.That(t, status.Code(err), test.ShouldEqual, codes.Unavailable)
+	test.That(t, client.QueuedCommands(), test.ShouldEqual, 3)
+
+	// the command with a short TTL expires before reconnecting
+	time.Sleep(10 * time.Millisecond)
+	client.connected.Store(true)
+	test.That(t, sendPower(context.Background(), 0.5), test.ShouldBeNil)
+	test.That(t, client.QueuedCommands(), test.ShouldEqual, 0)
+
+	powersMu.Lock()
+	test.That(t, powers, test.ShouldResemble, []float64{0.3, 0.4, 0.5})
+	powers = nil
+	powersMu.Unlock()
+
+	// calls wait for the queued command being sent, even once it has left the queue
+	client.connected.Store(false)
+	test.That(t, sendPower(context.Background(), slowPower), test.ShouldEqual, ErrCommandQueued)
+	client.connected.Store(true)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		test.That(t, sendPower(context.Background(), 0.7), test.ShouldBeNil)
+	}()
+	<-slowReceived
+	test.That(t, client.QueuedCommands(), test.ShouldEqual, 0)
+	go func() {
+		defer wg.Done()
+		test.That(t, sendPower(context.Background(), 0.8), test.ShouldBeNil)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(releaseSlow)
+	wg.Wait()
+
+	powersMu.Lock()
+	defer powersMu.Unlock()
+	test.That(t, powers, test.ShouldHaveLength, 3)
+	test.That(t, powers[0], test.ShouldEqual, slowPower)
+	test.That(t, powers[1:], test.ShouldContain, 0.8)
+}
+
+func TestLowBandwidthService(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	listener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	gServer := grpc.NewServer()
+
+	var namesMu sync.Mutex
+	names := []resource.Name{arm.Named("arm1")}
+	injectRobot := &inject.Robot{
+		ResourceNamesFunc: func() []resource.Name {
+			namesMu.Lock()
+			defer namesMu.Unlock()
+			return names
+		},
+		ResourceRPCAPIsFunc: func() []resource.RPCAPI { return nil },
+		MachineStatusFunc: func(ctx context.Context) (robot.MachineStatus, error) {
+			return robot.MachineStatus{
+				Config: config.Revision{Revision: "rev1"},
+				State:  robot.StateRunning,
+				Resources: []resource.Status{
+					{NodeStatus: resource.NodeStatus{Name: arm.Named("arm1"), State: resource.NodeStateReady, Revision: "rev1"}},
+				},
+			}, nil
+		},
+	}
+
+	robotServer := server.New(injectRobot)
+	pb.RegisterRobotServiceServer(gServer, robotServer)
+	lbpb.RegisterLowBandwidthServiceServer(gServer, robotServer.(lbpb.LowBandwidthServiceServer))
+
+	go gServer.Serve(listener)
+	defer gServer.Stop()
+
+	client, err := New(context.Background(), listener.Addr().String(), logger, WithLowBandwidth())
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, client.Close(context.Background()), test.ShouldBeNil)
+	}()
+	test.That(t, client.ResourceNames(), test.ShouldResemble, []resource.Name{arm.Named("arm1")})
+	test.That(t, client.namesToken, test.ShouldNotBeEmpty)
+
+	// only the added name is sent
+	namesMu.Lock()
+	names = []resource.Name{arm.Named("arm1"), arm.Named("arm2")}
+	namesMu.Unlock()
+	token := client.namesToken
+	test.That(t, client.Refresh(context.Background()), test.ShouldBeNil)
+	test.That(t, client.namesToken, test.ShouldNotEqual, token)
+	test.That(t, client.ResourceNames(), test.ShouldHaveLength, 2)
+	test.That(t, client.lowBandwidthUnimplemented.Load(), test.ShouldBeFalse)
+
+	mStatus, err := client.MachineStatus(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, mStatus.State, test.ShouldEqual, robot.StateRunning)
+	test.That(t, mStatus.Config.Revision, test.ShouldEqual, "rev1")
+	test.That(t, mStatus.Resources, test.ShouldHaveLength, 1)
+	test.That(t, mStatus.Resources[0].Name, test.ShouldResemble, arm.Named("arm1"))
+	test.That(t, mStatus.Resources[0].State, test.ShouldEqual, resource.NodeStateReady)
+}
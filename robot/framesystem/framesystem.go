@@ -79,6 +79,11 @@ type Service interface {
 //	myCurrentInputs, err := fsService.CurrentInputs(context.Background())
 //
 //	frameSystem, err := fsService.FrameSystem(context.Background(), nil)
+//
+// GetWorldGeometries example:
+//
+//	// Get the geometries of every frame, keyed by frame name, posed in the world frame.
+//	geometries, err := fsService.GetWorldGeometries(context.Background(), nil)
 type RobotFrameSystem interface {
 	// FrameSystemConfig returns the individual parts that make up a robot's frame system
 	FrameSystemConfig(ctx context.Context) (*Config, error)
@@ -109,6 +114,14 @@ type RobotFrameSystem interface {
 	// and a map of statuses indicating which of the machine's components may be actuated through input values.
 
 	CurrentInputs(ctx context.Context) (referenceframe.FrameSystemInputs, error)
+
+	// GetWorldGeometries returns the geometries of every frame in the frame system that has any, keyed by frame name
+	// and transformed into the world frame, using a single snapshot of the current inputs.
+	// The `supplemental_transforms` argument can be used to augment the machine's existing frame system with additional frames.
+	GetWorldGeometries(
+		ctx context.Context,
+		supplementalTransforms []*referenceframe.LinkInFrame,
+	) (map[string]*referenceframe.GeometriesInFrame, error)
 }
 
 // FromDependencies is a helper for getting the framesystem from a collection of dependencies.
@@ -324,7 +337,7 @@ func (svc *frameSystemService) CurrentInputs(ctx context.Context) (referencefram
 		if !ok {
 			return nil, DependencyNotFoundError(name)
 		}
-		inputEnabled, ok := component.(InputEnabled)
+		inputEnabled, ok := AsInputEnabled(ctx, component)
 		if !ok {
 			return nil, NotInputEnabledError(component)
 		}
@@ -340,6 +353,43 @@ func (svc *frameSystemService) CurrentInputs(ctx context.Context) (referencefram
 	return input, nil
 }
 
+// GetWorldGeometries returns the geometries of every frame in the robot's frame system posed in the world frame.
+func (svc *frameSystemService) GetWorldGeometries(
+	ctx context.Context,
+	supplementalTransforms []*referenceframe.LinkInFrame,
+) (map[string]*referenceframe.GeometriesInFrame, error) {
+	ctx, span := trace.StartSpan(ctx, "services::framesystem::GetWorldGeometries")
+	defer span.End()
+
+	svc.partsMu.RLock()
+	defer svc.partsMu.RUnlock()
+
+	fs, err := referenceframe.NewFrameSystem(LocalFrameSystemName, svc.parts, supplementalTransforms)
+	if err != nil {
+		return nil, err
+	}
+	input, err := svc.CurrentInputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return WorldGeometries(fs, input)
+}
+
+// WorldGeometries returns the geometries of every frame in fs that has any, posed in the world frame for the given inputs.
+// Inputs missing for supplemental frames are taken to be zero.
+func WorldGeometries(
+	fs *referenceframe.FrameSystem,
+	inputs referenceframe.FrameSystemInputs,
+) (map[string]*referenceframe.GeometriesInFrame, error) {
+	full := referenceframe.NewZeroInputs(fs)
+	for name, in := range inputs {
+		if _, ok := full[name]; ok {
+			full[name] = in
+		}
+	}
+	return referenceframe.FrameSystemGeometries(fs, full)
+}
+
 // NewFromService creates a referenceframe.FrameSystem from the given Service's FrameSystemConfig and returns it.
 // Supplemental transforms can be provided to augment the FrameSystemConfig.
 func NewFromService(
@@ -10,10 +10,12 @@ import (
 	"go.viam.com/test"
 
 	_ "go.viam.com/rdk/components/arm/fake"
+	"go.viam.com/rdk/components/gripper"
 	_ "go.viam.com/rdk/components/gripper/fake"
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
 	robotimpl "go.viam.com/rdk/robot/impl"
 	"go.viam.com/rdk/spatialmath"
 	rdkutils "go.viam.com/rdk/utils"
@@ -32,6 +34,73 @@ func TestEmptyConfigFrameService(t *testing.T) {
 	test.That(t, fs.FrameNames(), test.ShouldHaveLength, 0)
 }
 
+func TestGetWorldGeometries(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+	fakeModel := resource.DefaultModelFamily.WithModel("fake")
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{
+				Name:  "gripper1",
+				API:   gripper.API,
+				Model: fakeModel,
+				Frame: &referenceframe.LinkConfig{
+					Parent:      referenceframe.World,
+					Translation: r3.Vector{X: 100},
+					Geometry:    &spatialmath.GeometryConfig{Type: "box", X: 10, Y: 10, Z: 10, Label: "box1"},
+				},
+			},
+			{
+				Name:  "gripper2",
+				API:   gripper.API,
+				Model: fakeModel,
+				Frame: &referenceframe.LinkConfig{
+					Parent:      "gripper1",
+					Translation: r3.Vector{Z: 50},
+					Geometry:    &spatialmath.GeometryConfig{Type: "box", X: 10, Y: 10, Z: 10, Label: "box2"},
+				},
+			},
+		},
+	}
+	r, err := robotimpl.New(ctx, cfg, nil, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer r.Close(ctx)
+
+	sphere, err := spatialmath.NewSphere(spatialmath.NewZeroPose(), 5, "obstacle")
+	test.That(t, err, test.ShouldBeNil)
+	supplementalTransforms := []*referenceframe.LinkInFrame{
+		referenceframe.NewLinkInFrame("gripper2", spatialmath.NewPoseFromPoint(r3.Vector{Y: 10}), "obstacle", sphere),
+	}
+
+	geometries, err := r.GetWorldGeometries(ctx, supplementalTransforms)
+	test.That(t, err, test.ShouldBeNil)
+
+	points := map[string]r3.Vector{}
+	for _, gif := range geometries {
+		test.That(t, gif.Parent(), test.ShouldEqual, referenceframe.World)
+		for _, g := range gif.Geometries() {
+			points[g.Label()] = g.Pose().Point()
+		}
+	}
+	// geometries are labeled by the origin frame of the frame they belong to
+	expected := map[string]r3.Vector{
+		"gripper1_origin": {X: 100},
+		"gripper2_origin": {X: 100, Z: 50},
+		"obstacle_origin": {X: 100, Y: 10, Z: 50},
+	}
+	for label, want := range expected {
+		got, ok := points[label]
+		test.That(t, ok, test.ShouldBeTrue)
+		test.That(t, spatialmath.R3VectorAlmostEqual(got, want, 1e-6), test.ShouldBeTrue)
+	}
+
+	// the geometries agree with transforming each frame's origin on its own
+	pif, err := r.TransformPose(ctx, referenceframe.NewPoseInFrame("obstacle", spatialmath.NewZeroPose()),
+		referenceframe.World, supplementalTransforms)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatialmath.R3VectorAlmostEqual(pif.Pose().Point(), points["obstacle_origin"], 1e-6), test.ShouldBeTrue)
+}
+
 func TestNewFrameSystemFromConfig(t *testing.T) {
 	o1 := &spatialmath.R4AA{Theta: math.Pi / 2, RZ: 1}
 	o1Cfg, err := spatialmath.NewOrientationConfig(o1)
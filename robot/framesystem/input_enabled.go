@@ -0,0 +1,166 @@
+package framesystem
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	commonpb "go.viam.com/api/common/v1"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+)
+
+// CapabilityInputEnabled is the capability of generic components that answer the DoCommand commands below,
+// which a model opts into by declaring it in its registration.
+const CapabilityInputEnabled resource.Capability = "input_enabled"
+
+// These are the DoCommand keys that a generic component with CapabilityInputEnabled answers to be used as
+// InputEnabled, which is how modular hardware that has no matching API, such as a custom gantry or linear stage,
+// can be moved by the motion service.
+//
+// The KinematicsCommand response has the kinematics file in KinematicsDataKey and the name of its
+// commonpb.KinematicsFileFormat in KinematicsFormatKey. The CurrentInputsCommand response has the current
+// inputs as a list of numbers in InputsKey. The GoToInputsCommand value is a list of lists of inputs to move
+// through in order. InputEnabledDoCommand implements all three for an InputEnabled component.
+const (
+	KinematicsCommand    = "get_kinematics"
+	CurrentInputsCommand = "get_current_inputs"
+	GoToInputsCommand    = "go_to_inputs"
+
+	KinematicsDataKey   = "kinematics_data"
+	KinematicsFormatKey = "kinematics_format"
+	InputsKey           = "inputs"
+)
+
+// genericAPI is the generic component API, which cannot be imported from its package without a cycle.
+var genericAPI = resource.APINamespaceRDK.WithComponentType("generic")
+
+// AsInputEnabled returns the resource as InputEnabled if it implements it. Generic components, which cannot
+// implement InputEnabled over their API, are adapted to it through DoCommand if they have
+// CapabilityInputEnabled; see KinematicsCommand. Other generic components are never sent those commands.
+func AsInputEnabled(ctx context.Context, r resource.Resource) (InputEnabled, bool) {
+	if ie, ok := r.(InputEnabled); ok {
+		return ie, true
+	}
+	if r.Name().API != genericAPI {
+		return nil, false
+	}
+	if ok, err := resource.HasCapability(ctx, r, CapabilityInputEnabled); err != nil || !ok {
+		return nil, false
+	}
+	return &doCommandInputEnabled{r}, true
+}
+
+// doCommandInputEnabled is InputEnabled through a resource's DoCommand.
+type doCommandInputEnabled struct {
+	resource.Resource
+}
+
+func (ie *doCommandInputEnabled) Kinematics(ctx context.Context) (referenceframe.Model, error) {
+	resp, err := ie.DoCommand(ctx, map[string]interface{}{KinematicsCommand: true})
+	if err != nil {
+		return nil, err
+	}
+	data, ok := resp[KinematicsDataKey].(string)
+	if !ok || data == "" {
+		return nil, referenceframe.ErrNoModelInformation
+	}
+	formatName, _ := resp[KinematicsFormatKey].(string)
+	format, ok := commonpb.KinematicsFileFormat_value[formatName]
+	if !ok {
+		return nil, errors.Errorf("%v returned unknown kinematics format %q", ie.Name(), formatName)
+	}
+	return referenceframe.KinematicModelFromProtobuf(ie.Name().ShortName(), &commonpb.GetKinematicsResponse{
+		Format:         commonpb.KinematicsFileFormat(format),
+		KinematicsData: []byte(data),
+	})
+}
+
+func (ie *doCommandInputEnabled) CurrentInputs(ctx context.Context) ([]referenceframe.Input, error) {
+	resp, err := ie.DoCommand(ctx, map[string]interface{}{CurrentInputsCommand: true})
+	if err != nil {
+		return nil, err
+	}
+	values, ok := resp[InputsKey].([]interface{})
+	if !ok {
+		return nil, errors.Errorf("%v did not return its current inputs", ie.Name())
+	}
+	inputs := make([]referenceframe.Input, 0, len(values))
+	for _, v := range values {
+		f, ok := v.(float64)
+		if !ok {
+			return nil, errors.Errorf("%v returned a non-numeric input %v", ie.Name(), v)
+		}
+		inputs = append(inputs, referenceframe.Input{Value: f})
+	}
+	return inputs, nil
+}
+
+func (ie *doCommandInputEnabled) GoToInputs(ctx context.Context, inputSteps ...[]referenceframe.Input) error {
+	steps := make([]interface{}, 0, len(inputSteps))
+	for _, inputs := range inputSteps {
+		step := make([]interface{}, 0, len(inputs))
+		for _, input := range inputs {
+			step = append(step, input.Value)
+		}
+		steps = append(steps, step)
+	}
+	_, err := ie.DoCommand(ctx, map[string]interface{}{GoToInputsCommand: steps})
+	return err
+}
+
+// InputEnabledDoCommand handles the DoCommand commands that let a generic component be used as InputEnabled
+// by the motion service, by calling ie. It returns false if cmd is not one of them, so that a module can use it
+// at the top of its own DoCommand. The component's model must also declare CapabilityInputEnabled.
+func InputEnabledDoCommand(
+	ctx context.Context,
+	ie InputEnabled,
+	cmd map[string]interface{},
+) (map[string]interface{}, bool, error) {
+	if _, ok := cmd[KinematicsCommand]; ok {
+		model, err := ie.Kinematics(ctx)
+		if err != nil {
+			return nil, true, err
+		}
+		kinematics := referenceframe.KinematicModelToProtobuf(model)
+		return map[string]interface{}{
+			KinematicsDataKey:   string(kinematics.GetKinematicsData()),
+			KinematicsFormatKey: kinematics.GetFormat().String(),
+		}, true, nil
+	}
+	if _, ok := cmd[CurrentInputsCommand]; ok {
+		inputs, err := ie.CurrentInputs(ctx)
+		if err != nil {
+			return nil, true, err
+		}
+		values := make([]interface{}, 0, len(inputs))
+		for _, input := range inputs {
+			values = append(values, input.Value)
+		}
+		return map[string]interface{}{InputsKey: values}, true, nil
+	}
+	if stepsIface, ok := cmd[GoToInputsCommand]; ok {
+		steps, ok := stepsIface.([]interface{})
+		if !ok {
+			return nil, true, errors.Errorf("%s must be a list of lists of inputs", GoToInputsCommand)
+		}
+		inputSteps := make([][]referenceframe.Input, 0, len(steps))
+		for _, stepIface := range steps {
+			step, ok := stepIface.([]interface{})
+			if !ok {
+				return nil, true, errors.Errorf("%s must be a list of lists of inputs", GoToInputsCommand)
+			}
+			inputs := make([]referenceframe.Input, 0, len(step))
+			for _, v := range step {
+				f, ok := v.(float64)
+				if !ok {
+					return nil, true, errors.Errorf("%s has a non-numeric input %v", GoToInputsCommand, v)
+				}
+				inputs = append(inputs, referenceframe.Input{Value: f})
+			}
+			inputSteps = append(inputSteps, inputs)
+		}
+		return map[string]interface{}{}, true, ie.GoToInputs(ctx, inputSteps...)
+	}
+	return nil, false, nil
+}
@@ -0,0 +1,100 @@
+package framesystem_test
+
+import (
+	"context"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot/framesystem"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+// linearStage is the kind of InputEnabled hardware a module would put behind a generic component.
+type linearStage struct {
+	model  referenceframe.Model
+	inputs []referenceframe.Input
+	moves  int
+}
+
+func (s *linearStage) Kinematics(ctx context.Context) (referenceframe.Model, error) {
+	return s.model, nil
+}
+
+func (s *linearStage) CurrentInputs(ctx context.Context) ([]referenceframe.Input, error) {
+	return s.inputs, nil
+}
+
+func (s *linearStage) GoToInputs(ctx context.Context, inputSteps ...[]referenceframe.Input) error {
+	for _, inputs := range inputSteps {
+		s.inputs = inputs
+		s.moves++
+	}
+	return nil
+}
+
+func TestAsInputEnabled(t *testing.T) {
+	ctx := context.Background()
+	model, err := referenceframe.UnmarshalModelJSON([]byte(`{
+		"name": "stage",
+		"joints": [{"id": "slide", "type": "prismatic", "parent": "world", "axis": {"x": 1}, "min": 0, "max": 500}]
+	}`), "stage")
+	test.That(t, err, test.ShouldBeNil)
+	stage := &linearStage{model: model, inputs: []referenceframe.Input{{Value: 10}}}
+
+	component := inject.NewGenericComponent("stage")
+	var capable bool
+	component.DoFunc = func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+		if resource.IsGetCapabilitiesCommand(cmd) {
+			if !capable {
+				return resource.CapabilitiesResponse(nil), nil
+			}
+			return resource.CapabilitiesResponse([]resource.Capability{framesystem.CapabilityInputEnabled}), nil
+		}
+		test.That(t, capable, test.ShouldBeTrue)
+		resp, handled, err := framesystem.InputEnabledDoCommand(ctx, stage, cmd)
+		test.That(t, handled, test.ShouldBeTrue)
+		return resp, err
+	}
+
+	// generic components that have not opted in are not sent the commands
+	_, ok := framesystem.AsInputEnabled(ctx, component)
+	test.That(t, ok, test.ShouldBeFalse)
+
+	capable = true
+	ie, ok := framesystem.AsInputEnabled(ctx, component)
+	test.That(t, ok, test.ShouldBeTrue)
+
+	kinematics, err := ie.Kinematics(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, kinematics.Name(), test.ShouldEqual, "stage")
+	test.That(t, len(kinematics.DoF()), test.ShouldEqual, 1)
+
+	inputs, err := ie.CurrentInputs(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, inputs, test.ShouldResemble, []referenceframe.Input{{Value: 10}})
+
+	err = ie.GoToInputs(ctx, []referenceframe.Input{{Value: 20}}, []referenceframe.Input{{Value: 30}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, stage.moves, test.ShouldEqual, 2)
+	inputs, err = ie.CurrentInputs(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, inputs, test.ShouldResemble, []referenceframe.Input{{Value: 30}})
+
+	_, handled, err := framesystem.InputEnabledDoCommand(ctx, stage, map[string]interface{}{"other": true})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, handled, test.ShouldBeFalse)
+
+	// generic components that do not answer the commands have no kinematics
+	component.DoFunc = func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+		return map[string]interface{}{}, nil
+	}
+	_, err = ie.Kinematics(ctx)
+	test.That(t, err, test.ShouldBeError, referenceframe.ErrNoModelInformation)
+
+	// other resources have to implement InputEnabled themselves
+	_, ok = framesystem.AsInputEnabled(ctx, inject.NewGenericService("service"))
+	test.That(t, ok, test.ShouldBeFalse)
+}
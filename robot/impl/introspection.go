@@ -0,0 +1,155 @@
+package robotimpl
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+)
+
+// introspectPropertiesTimeout limits how long the Properties of each resource may take, so that
+// one slow or unreachable resource does not hold up the whole introspection.
+const introspectPropertiesTimeout = 5 * time.Second
+
+// Introspect describes the machine, with its resources, their sanitized configs, properties
+// and capabilities, its frame system and its modules, as one document.
+func (r *localRobot) Introspect(ctx context.Context) (*robot.Introspection, error) {
+	version, err := robot.Version()
+	if err != nil {
+		r.logger.CDebugw(ctx, "failed to read build info", "error", err)
+	}
+	cfg := r.Config()
+	r.configRevisionMu.RLock()
+	revision := r.configRevision.Revision
+	r.configRevisionMu.RUnlock()
+	intro := &robot.Introspection{
+		Platform:       version.Platform,
+		Version:        version.Version,
+		APIVersion:     version.APIVersion,
+		ConfigRevision: revision,
+		Time:           time.Now(),
+		Resources:      []robot.ResourceIntrospection{},
+		Modules:        []robot.ModuleIntrospection{},
+		FrameSystem:    []json.RawMessage{},
+	}
+
+	confs := map[resource.Name]resource.Config{}
+	for _, resConfs := range [][]resource.Config{cfg.Components, cfg.Services} {
+		for _, conf := range resConfs {
+			confs[conf.ResourceName()] = conf
+		}
+	}
+	models, err := r.GetModelsFromModules(ctx)
+	if err != nil {
+		intro.Errors = append(intro.Errors, errors.Wrap(err, "models of modules").Error())
+	}
+	moduleNames := map[resource.APIModel]string{}
+	for _, model := range models {
+		moduleNames[resource.APIModel{API: model.API, Model: model.Model}] = model.ModuleName
+	}
+
+	snapshot := r.manager.resources.Snapshot()
+	for _, name := range r.ResourceNames() {
+		node, ok := snapshot.Node(name)
+		if !ok {
+			continue
+		}
+		resIntro := robot.ResourceIntrospection{NodeSnapshot: node}
+		if conf, ok := confs[name]; ok {
+			resIntro.Attributes = config.SanitizedAttributes(conf)
+			resIntro.Module = moduleNames[resource.APIModel{API: conf.API, Model: conf.Model}]
+		}
+		if res, err := r.ResourceByName(name); err == nil {
+			resIntro.Capabilities, _ = resource.CapabilitiesOf(res)
+			resIntro.Properties, err = resourceProperties(ctx, res)
+			if err != nil {
+				resIntro.PropertiesError = err.Error()
+			}
+		}
+		intro.Resources = append(intro.Resources, resIntro)
+	}
+
+	packageVersions := map[string]string{}
+	for _, pkg := range cfg.Packages {
+		if pkg.Type == config.PackageTypeModule {
+			packageVersions[pkg.Name] = pkg.Version
+		}
+	}
+	for _, mod := range cfg.Modules {
+		modIntro := robot.ModuleIntrospection{
+			Name:     mod.Name,
+			Type:     mod.Type,
+			ModuleID: mod.ModuleID,
+			ExePath:  mod.ExePath,
+		}
+		if mod.Type == config.ModuleTypeRegistry {
+			modIntro.Version = packageVersions[mod.Name]
+		}
+		intro.Modules = append(intro.Modules, modIntro)
+	}
+
+	fsCfg, err := r.FrameSystemConfig(ctx)
+	if err != nil {
+		intro.Errors = append(intro.Errors, errors.Wrap(err, "frame system").Error())
+	} else {
+		for _, part := range fsCfg.Parts {
+			partJSON, err := framePartJSON(part)
+			if err != nil {
+				intro.Errors = append(intro.Errors, errors.Wrap(err, "frame system part").Error())
+				continue
+			}
+			intro.FrameSystem = append(intro.FrameSystem, partJSON)
+		}
+	}
+	return intro, nil
+}
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	extraType   = reflect.TypeOf(map[string]interface{}(nil))
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// resourceProperties returns, as JSON, what the Properties method of res returns. APIs name this
+// method the same but return their own types, and only some take extra, so it is found by
+// reflection. Resources without one have no properties.
+func resourceProperties(ctx context.Context, res resource.Resource) (json.RawMessage, error) {
+	method := reflect.ValueOf(res).MethodByName("Properties")
+	if !method.IsValid() {
+		return nil, nil
+	}
+	t := method.Type()
+	if t.NumIn() == 0 || t.NumIn() > 2 || t.In(0) != contextType || (t.NumIn() == 2 && t.In(1) != extraType) ||
+		t.NumOut() != 2 || t.Out(1) != errorType {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, introspectPropertiesTimeout)
+	defer cancel()
+	args := []reflect.Value{reflect.ValueOf(ctx)}
+	if t.NumIn() == 2 {
+		args = append(args, reflect.Zero(extraType))
+	}
+	out := method.Call(args)
+	if err, _ := out[1].Interface().(error); err != nil {
+		return nil, err
+	}
+	return json.Marshal(out[0].Interface())
+}
+
+// framePartJSON returns a frame system part as a FrameSystemConfig message in its protobuf JSON
+// form, which is how clients of the robot service already see it.
+func framePartJSON(part *referenceframe.FrameSystemPart) (json.RawMessage, error) {
+	partProto, err := part.ToProtobuf()
+	if err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(partProto)
+}
@@ -0,0 +1,111 @@
+package robotimpl
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/arm"
+	armfake "go.viam.com/rdk/components/arm/fake"
+	"go.viam.com/rdk/components/camera"
+	camerafake "go.viam.com/rdk/components/camera/fake"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/robot/client"
+	"go.viam.com/rdk/testutils/robottestutils"
+	"go.viam.com/rdk/utils"
+)
+
+func TestIntrospect(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	config.RegisterSensitiveAttributes(camera.API, fakeModel, "token")
+
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{
+				Name:                "arm1",
+				API:                 arm.API,
+				Model:               fakeModel,
+				Frame:               &referenceframe.LinkConfig{Parent: referenceframe.World},
+				ConvertedAttributes: &armfake.Config{ModelFilePath: "../../components/arm/fake/kinematics/fake.json"},
+			},
+			{
+				Name:                "cam1",
+				API:                 camera.API,
+				Model:               fakeModel,
+				Attributes:          utils.AttributeMap{"width": 100, "height": 50, "token": "secret-token"},
+				ConvertedAttributes: &camerafake.Config{Width: 100, Height: 50},
+			},
+		},
+	}
+	r := setupLocalRobot(t, ctx, cfg, logger)
+
+	check := func(t *testing.T, intro *robot.Introspection) {
+		t.Helper()
+		test.That(t, intro.Platform, test.ShouldEqual, "rdk")
+		test.That(t, intro.Version, test.ShouldNotBeEmpty)
+		test.That(t, intro.Errors, test.ShouldBeEmpty)
+		resources := map[string]robot.ResourceIntrospection{}
+		for _, res := range intro.Resources {
+			resources[res.Name] = res
+		}
+
+		cam, ok := resources[camera.Named("cam1").String()]
+		test.That(t, ok, test.ShouldBeTrue)
+		test.That(t, cam.State, test.ShouldEqual, resource.NodeStateReady.String())
+		test.That(t, cam.Model, test.ShouldEqual, fakeModel.String())
+		test.That(t, cam.Attributes["width"], test.ShouldEqual, 100.)
+		test.That(t, cam.Attributes["token"], test.ShouldEqual, "******")
+		test.That(t, cam.PropertiesError, test.ShouldBeEmpty)
+		var props map[string]interface{}
+		test.That(t, json.Unmarshal(cam.Properties, &props), test.ShouldBeNil)
+		test.That(t, props, test.ShouldNotBeEmpty)
+
+		_, ok = resources[arm.Named("arm1").String()]
+		test.That(t, ok, test.ShouldBeTrue)
+		test.That(t, intro.FrameSystem, test.ShouldHaveLength, 1)
+		test.That(t, string(intro.FrameSystem[0]), test.ShouldContainSubstring, "arm1")
+	}
+
+	t.Run("local", func(t *testing.T) {
+		intro, err := r.Introspect(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		// attributes are not yet JSON numbers before the introspection is sent
+		intro.Resources = roundTripResources(t, intro.Resources)
+		check(t, intro)
+		// the config of the robot is not masked
+		for _, conf := range r.Config().Components {
+			if conf.Name == "cam1" {
+				test.That(t, conf.Attributes["token"], test.ShouldEqual, "secret-token")
+			}
+		}
+	})
+
+	t.Run("client", func(t *testing.T) {
+		options, _, addr := robottestutils.CreateBaseOptionsAndListener(t)
+		test.That(t, r.StartWeb(ctx, options), test.ShouldBeNil)
+		defer r.StopWeb()
+		robotClient, err := client.New(ctx, addr, logger.Sublogger("client"))
+		test.That(t, err, test.ShouldBeNil)
+		defer robotClient.Close(ctx)
+
+		intro, err := robotClient.Introspect(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		check(t, intro)
+	})
+}
+
+func roundTripResources(t *testing.T, resources []robot.ResourceIntrospection) []robot.ResourceIntrospection {
+	t.Helper()
+	md, err := json.Marshal(resources)
+	test.That(t, err, test.ShouldBeNil)
+	var out []robot.ResourceIntrospection
+	test.That(t, json.Unmarshal(md, &out), test.ShouldBeNil)
+	return out
+}
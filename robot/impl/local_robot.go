@@ -6,7 +6,13 @@ package robotimpl
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"runtime/debug"
 	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -28,6 +34,7 @@ import (
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/ftdc"
 	"go.viam.com/rdk/ftdc/sys"
+	rgrpc "go.viam.com/rdk/grpc"
 	icloud "go.viam.com/rdk/internal/cloud"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/operation"
@@ -80,15 +87,39 @@ type localRobot struct {
 	// logical clock when updateWeakAndOptionalDependents was called.
 	lastWeakAndOptionalDependentsRound atomic.Int64
 
+	// passedOptionalDeps holds the weak and optional dependencies last passed to each resource,
+	// so that OptionalDependencyWatchers can be told how they changed.
+	passedOptionalDeps   map[resource.Name]resource.Dependencies
+	passedOptionalDepsMu sync.Mutex
+
 	// configRevision stores the revision of the latest config ingested during
-	// reconfigurations along with a timestamp.
-	configRevision   config.Revision
-	configRevisionMu sync.RWMutex
+	// reconfigurations along with a timestamp. configFetch stores when that config was
+	// fetched from the cloud. disabledResources are the resources of that config disabled
+	// by their enabled condition.
+	configRevision    config.Revision
+	configFetch       config.FetchStatus
+	appliedConfig     robot.AppliedConfigStatus
+	disabledResources []config.DisabledResource
+	configRevisionMu  sync.RWMutex
+
+	// cloudConn is the connection to the cloud managing this robot, if any.
+	cloudConn rpc.ClientConn
+
+	// configHistory stores recently applied configs for rollback, nil if disabled.
+	configHistory *config.History
+
+	// setRedactedValues, if set, is given the secrets resolved for each config before it is applied.
+	setRedactedValues func([]string)
 
 	// internal services that are in the graph but we also hold onto
 	webSvc   web.Service
 	frameSvc framesystem.Service
 
+	// resourceOverlays holds the resource changes applied through ApplyResourceChanges, keyed by
+	// the name of the module that applied them ("" for other clients). They are merged into every
+	// config given to Reconfigure so that they outlive it. Guarded by reconfigurationLock.
+	resourceOverlays map[string]*resourceOverlay
+
 	// map keyed by Module.Name. This is necessary to get the package manager to use a new folder
 	// when a local tarball is updated.
 	localModuleVersions map[string]semver.Version
@@ -111,6 +142,34 @@ func (r *localRobot) ExportResourcesAsDot(index int) (resource.GetSnapshotInfo,
 	return r.manager.ExportDot(index)
 }
 
+// ExportResourcesAsJSON exports the current resource graph, including each resource's state and
+// dependencies, as JSON.
+func (r *localRobot) ExportResourcesAsJSON() (string, error) {
+	return r.manager.resources.ExportJSON()
+}
+
+// ResourceGraphSnapshot returns a copy of the current resource graph that is safe to inspect and
+// serialize while the robot reconfigures.
+func (r *localRobot) ResourceGraphSnapshot() resource.GraphSnapshot {
+	return r.manager.resources.Snapshot()
+}
+
+// ResourceNamesByLabels returns the names of all resources whose config labels match the given
+// label selector.
+func (r *localRobot) ResourceNamesByLabels(selector string) ([]resource.Name, error) {
+	sel, err := resource.ParseLabelSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return r.manager.resourceNamesByLabels(sel), nil
+}
+
+// SubscribeResourceEvents streams an event every time a resource changes lifecycle state
+// until ctx is done.
+func (r *localRobot) SubscribeResourceEvents(ctx context.Context) <-chan resource.ResourceEvent {
+	return r.manager.resources.SubscribeEvents(ctx)
+}
+
 // RemoteByName returns a remote robot by name. If it does not exist
 // nil is returned.
 func (r *localRobot) RemoteByName(name string) (robot.Robot, bool) {
@@ -156,6 +215,11 @@ func (r *localRobot) PackageManager() packages.Manager {
 // Close attempts to cleanly close down all constituent parts of the robot. It does not wait on reconfigureWorkers,
 // as they may be running outside code and have unexpected behavior.
 func (r *localRobot) Close(ctx context.Context) error {
+	// a machine shutting down after draining its operations stops what is still moving before
+	// anything is torn down.
+	if r.manager != nil && r.operations.Draining() {
+		r.stopDependentsFirst(ctx)
+	}
 	// we will stop and close web ourselves since modules need it to be
 	// removed properly and in the right order, so grab it before its removed
 	// from the graph/closed automatically.
@@ -206,32 +270,122 @@ func (r *localRobot) Kill() {
 
 // StopAll cancels all current and outstanding operations for the robot and stops all actuators and movement.
 func (r *localRobot) StopAll(ctx context.Context, extra map[resource.Name]map[string]interface{}) error {
-	// Stop all operations
-	for _, op := range r.OperationManager().All() {
-		op.Cancel()
+	report, err := r.StopResources(ctx, robot.StopFilter{}, extra)
+	if err != nil {
+		return err
 	}
+	return report.Err()
+}
 
-	// Stop all stoppable resources
-	resourceErrs := make(map[string]error)
+// StopResources stops every actuator selected by filter concurrently and reports the outcome
+// for each of them. Operations are cancelled only when the filter is not scoped.
+func (r *localRobot) StopResources(
+	ctx context.Context,
+	filter robot.StopFilter,
+	extra map[resource.Name]map[string]interface{},
+) (*robot.StopReport, error) {
+	if !filter.IsScoped() {
+		for _, op := range r.OperationManager().All() {
+			op.Cancel()
+		}
+	}
+
+	report := &robot.StopReport{Failed: map[resource.Name]error{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
 	for _, name := range r.ResourceNames() {
+		if !filter.Matches(name) {
+			continue
+		}
 		res, err := r.ResourceByName(name)
 		if err != nil {
-			resourceErrs[name.Name] = err
+			report.Failed[name] = err
+			continue
+		}
+		actuator, ok := res.(resource.Actuator)
+		if !ok {
 			continue
 		}
 
-		if actuator, ok := res.(resource.Actuator); ok {
-			if err := actuator.Stop(ctx, extra[name]); err != nil {
-				resourceErrs[name.Name] = err
+		wg.Add(1)
+		goutils.PanicCapturingGo(func() {
+			defer wg.Done()
+			timedOut, err := stopActuator(ctx, actuator, filter.Timeout, extra[name])
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case timedOut:
+				report.TimedOut = append(report.TimedOut, name)
+			case err != nil:
+				report.Failed[name] = err
+			default:
+				report.Stopped = append(report.Stopped, name)
 			}
-		}
+		})
+	}
+	wg.Wait()
+
+	sortNames := func(names []resource.Name) {
+		sort.Slice(names, func(i, j int) bool { return names[i].String() < names[j].String() })
+	}
+	sortNames(report.Stopped)
+	sortNames(report.TimedOut)
+	return report, nil
+}
+
+// stopActuator stops the actuator, giving up after timeout if it is positive. An actuator
+// that does not stop in time is left to finish stopping in the background.
+func stopActuator(
+	ctx context.Context,
+	actuator resource.Actuator,
+	timeout time.Duration,
+	extra map[string]interface{},
+) (bool, error) {
+	if timeout <= 0 {
+		return false, actuator.Stop(ctx, extra)
 	}
+	stopCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	errCh := make(chan error, 1)
+	goutils.PanicCapturingGo(func() {
+		errCh <- actuator.Stop(stopCtx, extra)
+	})
+	select {
+	case err := <-errCh:
+		return false, err
+	case <-stopCtx.Done():
+		return errors.Is(stopCtx.Err(), context.DeadlineExceeded), stopCtx.Err()
+	}
+}
 
-	var errs error
-	for k, v := range resourceErrs {
-		errs = multierr.Combine(errs, errors.Errorf("failed to stop component named %s with error %v", k, v))
+// shutdownStopTimeout is how long each actuator is given to stop when the machine shuts down.
+const shutdownStopTimeout = 5 * time.Second
+
+// stopDependentsFirst stops every actuator, one level of the resource graph at a time, so that
+// resources are stopped before the resources they depend on, such as a base before its motors.
+// Actuators in the same level are stopped concurrently.
+func (r *localRobot) stopDependentsFirst(ctx context.Context) {
+	for _, level := range r.manager.resources.TopologicalSortInLevels() {
+		var wg sync.WaitGroup
+		for _, name := range level {
+			res, err := r.ResourceByName(name)
+			if err != nil {
+				continue
+			}
+			actuator, ok := res.(resource.Actuator)
+			if !ok {
+				continue
+			}
+			wg.Add(1)
+			goutils.PanicCapturingGo(func() {
+				defer wg.Done()
+				if _, err := stopActuator(ctx, actuator, shutdownStopTimeout, nil); err != nil {
+					r.logger.CWarnw(ctx, "failed to stop resource while shutting down", "resource", name, "error", err)
+				}
+			})
+		}
+		wg.Wait()
 	}
-	return errs
 }
 
 // Config returns a config representing the current state of the robot.
@@ -309,6 +463,9 @@ func (r *localRobot) updateRemotesAndRetryResourceConfigure() bool {
 	defer r.reconfigurationLock.Unlock()
 
 	anyChanges := r.manager.updateRemotesResourceNames(r.closeContext)
+	if r.manager.updateDependentsOfRecovered(r.closeContext) {
+		anyChanges = true
+	}
 	if r.manager.anyResourcesNotConfigured() {
 		anyChanges = true
 		r.manager.completeConfig(r.closeContext, r, false)
@@ -346,6 +503,25 @@ func (r *localRobot) completeConfigWorker() {
 	}
 }
 
+// maxResourceHealthCheckTimeout is the longest a resource is given to answer a health check.
+const maxResourceHealthCheckTimeout = 10 * time.Second
+
+// healthCheckWorker checks the health of the resources that can report it every interval,
+// so that resources that stop working after being built are reported as unhealthy.
+func (r *localRobot) healthCheckWorker(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	timeout := min(interval, maxResourceHealthCheckTimeout)
+	for {
+		select {
+		case <-r.closeContext.Done():
+			return
+		case <-ticker.C:
+		}
+		r.manager.resources.CheckHealth(r.closeContext, timeout)
+	}
+}
+
 func newWithResources(
 	ctx context.Context,
 	cfg *config.Config,
@@ -383,9 +559,12 @@ func newWithResources(
 		// - Guarantee that the `rpcServer` is initialized (enough) when the web service is
 		//   constructed to get a valid copy of its stats object (for the schema's sake). Even if
 		//   the web service has not been "started".
-		ftdcDir := ftdc.DefaultDirectory(utils.ViamDotDir, partID)
+		ftdcDir := ftdc.DefaultDirectory(utils.ViamDotDir, filepath.Join(rOpts.namespace, partID))
 		ftdcLogger := logger.Sublogger("ftdc")
 		ftdcWorker = ftdc.NewWithUploader(ftdcDir, conn, partID, ftdcLogger)
+		if rOpts.namespace != "" {
+			ftdcWorker.SetNamePrefix(rOpts.namespace + ".")
+		}
 		if statser, err := sys.NewSelfSysUsageStatser(); err == nil {
 			ftdcWorker.Add("proc.viam-server", statser)
 		}
@@ -417,9 +596,23 @@ func newWithResources(
 		configTicker:               nil,
 		revealSensitiveConfigDiffs: rOpts.revealSensitiveConfigDiffs,
 		cloudConnSvc:               icloud.NewCloudConnectionService(cfg.Cloud, conn, logger),
+		cloudConn:                  conn,
 		shutdownCallback:           rOpts.shutdownCallback,
 		localModuleVersions:        make(map[string]semver.Version),
+		resourceOverlays:           make(map[string]*resourceOverlay),
 		ftdc:                       ftdcWorker,
+		setRedactedValues:          rOpts.setRedactedValues,
+	}
+
+	if rOpts.configHistoryDir != "" {
+		historyDir := rOpts.configHistoryDir
+		if rOpts.namespace != "" {
+			historyDir = filepath.Join(historyDir, "namespaces", rOpts.namespace)
+		}
+		r.configHistory, err = config.NewHistory(historyDir, rOpts.configHistorySize)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot set up config history")
+		}
 	}
 
 	r.mostRecentCfg.Store(config.Config{})
@@ -511,6 +704,9 @@ func newWithResources(
 	if rOpts.viamHomeDir != "" {
 		homeDir = rOpts.viamHomeDir
 	}
+	if rOpts.namespace != "" {
+		homeDir = filepath.Join(homeDir, "namespaces", rOpts.namespace)
+	}
 	// Once web service is started, start module manager
 	if err := r.manager.startModuleManager(
 		closeCtx,
@@ -537,6 +733,13 @@ func newWithResources(
 		}, r.activeBackgroundWorkers.Done)
 	}
 
+	if interval := utils.GetResourceHealthCheckInterval(logger); interval > 0 {
+		r.activeBackgroundWorkers.Add(1)
+		goutils.ManagedGo(func() {
+			r.healthCheckWorker(interval)
+		}, r.activeBackgroundWorkers.Done)
+	}
+
 	// getResource is passed in to the jobmanager to have access to the resource graph.
 	getResource := func(res string) (resource.Resource, error) {
 		var found bool
@@ -658,8 +861,13 @@ func (r *localRobot) getDependencies(
 	rName resource.Name,
 	gNode *resource.GraphNode,
 ) (resource.Dependencies, error) {
-	if deps := gNode.UnresolvedDependencies(); len(deps) != 0 {
-		return nil, errors.Errorf("resource has unresolved dependencies not found in machine config or connected remotes: %v", deps)
+	if reasons := gNode.UnresolvedDependencyReasons(); len(reasons) != 0 {
+		explained := make([]string, 0, len(reasons))
+		for dep, reason := range reasons {
+			explained = append(explained, fmt.Sprintf("%s (%s)", dep, reason))
+		}
+		slices.Sort(explained)
+		return nil, errors.Errorf("resource has unresolved dependencies: %s", strings.Join(explained, ", "))
 	}
 	allDeps := make(resource.Dependencies)
 
@@ -674,22 +882,65 @@ func (r *localRobot) getDependencies(
 		allDeps[dep] = r
 	}
 	nodeConf := gNode.Config()
+	optionalDeps := make(resource.Dependencies)
 	for weakDepName, weakDepRes := range r.getWeakDependencies(rName, nodeConf.API, nodeConf.Model) {
 		if _, ok := allDeps[weakDepName]; ok {
 			continue
 		}
 		allDeps[weakDepName] = weakDepRes
+		optionalDeps[weakDepName] = weakDepRes
 	}
 	for optionalDepName, optionalDepRes := range r.getOptionalDependencies(nodeConf) {
 		if _, ok := allDeps[optionalDepName]; ok {
 			continue
 		}
 		allDeps[optionalDepName] = optionalDepRes
+		optionalDeps[optionalDepName] = optionalDepRes
 	}
+	r.setPassedOptionalDependencies(rName, optionalDeps)
 
 	return allDeps, nil
 }
 
+// setPassedOptionalDependencies records the weak and optional dependencies passed to a resource
+// and returns those previously recorded.
+func (r *localRobot) setPassedOptionalDependencies(rName resource.Name, deps resource.Dependencies) resource.Dependencies {
+	r.passedOptionalDepsMu.Lock()
+	defer r.passedOptionalDepsMu.Unlock()
+	if r.passedOptionalDeps == nil {
+		r.passedOptionalDeps = map[resource.Name]resource.Dependencies{}
+	}
+	prev := r.passedOptionalDeps[rName]
+	if deps == nil {
+		delete(r.passedOptionalDeps, rName)
+	} else {
+		r.passedOptionalDeps[rName] = deps
+	}
+	return prev
+}
+
+// prunePassedOptionalDependencies forgets the dependencies passed to resources that are no longer
+// configured.
+func (r *localRobot) prunePassedOptionalDependencies(confs []resource.Config) {
+	configured := make(map[resource.Name]struct{}, len(confs))
+	for _, conf := range confs {
+		configured[conf.ResourceName()] = struct{}{}
+	}
+	r.passedOptionalDepsMu.Lock()
+	defer r.passedOptionalDepsMu.Unlock()
+	for name := range r.passedOptionalDeps {
+		if _, ok := configured[name]; !ok {
+			delete(r.passedOptionalDeps, name)
+		}
+	}
+}
+
+func (r *localRobot) passedOptionalDependencies(rName resource.Name) resource.Dependencies {
+	r.passedOptionalDepsMu.Lock()
+	defer r.passedOptionalDepsMu.Unlock()
+	return r.passedOptionalDeps[rName]
+}
+
 func (r *localRobot) getWeakDependencyMatchers(api resource.API, model resource.Model) []resource.Matcher {
 	reg, ok := resource.LookupRegistration(api, model)
 	if !ok {
@@ -703,29 +954,28 @@ func (r *localRobot) getOptionalDependencies(conf resource.Config) resource.Depe
 
 	for _, optionalDepNameString := range conf.ImplicitOptionalDependsOn {
 		matchingResourceNames := r.manager.resources.FindNodesByShortName(optionalDepNameString)
-		switch len(matchingResourceNames) {
-		case 0:
+		if len(matchingResourceNames) == 0 {
 			r.logger.Infow(
 				"Optional dependency for resource does not exist; not passing to constructor or reconfigure yet",
 				"dependency", optionalDepNameString,
 				"resource", conf.ResourceName().String(),
 			)
 			continue
-		case 1:
-			if matchingResourceNames[0].String() == conf.ResourceName().String() {
-				r.logger.Errorw("Resource cannot optionally depend on itself", "resource", conf.ResourceName().String())
-				continue
-			}
-		default:
+		}
+		resolvedOptionalDepName, err := r.manager.resources.ResolveNameConflict(optionalDepNameString, matchingResourceNames)
+		if err != nil {
 			r.logger.Errorw(
 				"Cannot resolve optional dependency for resource due to multiple matching names",
 				"resource", conf.ResourceName().String(),
 				"conflicts", resource.NamesToStrings(matchingResourceNames),
+				"error", err,
 			)
 			continue
 		}
-
-		resolvedOptionalDepName := matchingResourceNames[0]
+		if resolvedOptionalDepName.String() == conf.ResourceName().String() {
+			r.logger.Errorw("Resource cannot optionally depend on itself", "resource", conf.ResourceName().String())
+			continue
+		}
 
 		optionalDep, err := r.ResourceByName(resolvedOptionalDepName)
 		if err != nil {
@@ -776,7 +1026,7 @@ func (r *localRobot) newResource(
 ) (res resource.Resource, err error) {
 	defer func() {
 		if r := recover(); r != nil {
-			err = errors.Wrap(errors.Errorf("%v", r), "panic creating resource")
+			err = errors.Wrap(resource.NewPanicError(r, debug.Stack()), "panic creating resource")
 		}
 	}()
 	resName := conf.ResourceName()
@@ -962,6 +1212,7 @@ func (r *localRobot) updateWeakAndOptionalDependents(ctx context.Context) {
 			return
 		}
 		r.Logger().CDebugw(ctx, "handling weak/optional update for resource", "resource", resName)
+		prevOptionalDeps := r.passedOptionalDependencies(resName)
 		deps, err := r.getDependencies(resName, resNode)
 		if err != nil {
 			r.Logger().CErrorw(
@@ -976,6 +1227,23 @@ func (r *localRobot) updateWeakAndOptionalDependents(ctx context.Context) {
 		// Use the module manager to reconfigure the resource if it's a modular resource. This
 		// would be a modular resource that has optional dependencies.
 		isModular := r.manager.moduleManager.Provides(conf)
+		if watcher, ok := res.(resource.OptionalDependencyWatcher); ok && !isModular {
+			added, removed := resource.DiffDependencies(prevOptionalDeps, r.passedOptionalDependencies(resName))
+			if len(added) == 0 && len(removed) == 0 {
+				return
+			}
+			if err := watcher.OptionalDependenciesChanged(ctx, added, removed); err != nil {
+				// tell the resource about the changes again next time
+				r.setPassedOptionalDependencies(resName, prevOptionalDeps)
+				r.Logger().CErrorw(
+					ctx,
+					"resource failed to take changed weak/optional dependencies",
+					"resource", resName,
+					"error", err,
+				)
+			}
+			return
+		}
 		if isModular {
 			var depStrings []string
 			for dep := range deps {
@@ -983,7 +1251,7 @@ func (r *localRobot) updateWeakAndOptionalDependents(ctx context.Context) {
 			}
 			err = r.manager.moduleManager.ReconfigureResource(ctx, conf, depStrings)
 		} else {
-			err = res.Reconfigure(ctx, deps, conf)
+			err = reconfigureRecoveringPanic(ctx, res, deps, conf)
 		}
 		if err != nil {
 			if resource.IsMustRebuildError(err) {
@@ -1004,6 +1272,7 @@ func (r *localRobot) updateWeakAndOptionalDependents(ctx context.Context) {
 	}
 
 	cfg := r.Config()
+	r.prunePassedOptionalDependencies(append(cfg.Components, cfg.Services...))
 	for _, conf := range append(cfg.Components, cfg.Services...) {
 		select {
 		case <-ctx.Done():
@@ -1098,6 +1367,18 @@ func (r *localRobot) getLocalFrameSystemParts(ctx context.Context) ([]*reference
 				continue
 			}
 		default:
+			// Any other component that reports and moves to inputs, such as a stabilized gimbal or modular
+			// hardware behind a generic component that declares framesystem.CapabilityInputEnabled, is
+			// included with its kinematics if it has any, so that the motion service can move it. Those
+			// that cannot provide kinematics are static frames.
+			model, err = r.extractModelFrameJSON(ctx, component.ResourceName())
+			if err != nil {
+				if !errors.Is(err, referenceframe.ErrNoModelInformation) && !resource.IsNotFoundError(err) &&
+					!resource.IsNotAvailableError(err) {
+					r.logger.CDebugw(ctx, "not using kinematics of component", "name", component.Name, "error", err)
+				}
+				model = nil
+			}
 		}
 		lif, err := cfgCopy.ParseConfig()
 		if err != nil {
@@ -1172,7 +1453,7 @@ func (r *localRobot) extractModelFrameJSON(ctx context.Context, name resource.Na
 	if err != nil {
 		return nil, err
 	}
-	if k, ok := part.(framesystem.InputEnabled); ok {
+	if k, ok := framesystem.AsInputEnabled(ctx, part); ok {
 		return k.Kinematics(ctx)
 	}
 	return nil, referenceframe.ErrNoModelInformation
@@ -1215,6 +1496,14 @@ func (r *localRobot) CurrentInputs(ctx context.Context) (referenceframe.FrameSys
 	return r.frameSvc.CurrentInputs(ctx)
 }
 
+// GetWorldGeometries returns the geometries of every frame in the robot's frame system posed in the world frame.
+func (r *localRobot) GetWorldGeometries(
+	ctx context.Context,
+	supplementalTransforms []*referenceframe.LinkInFrame,
+) (map[string]*referenceframe.GeometriesInFrame, error) {
+	return r.frameSvc.GetWorldGeometries(ctx, supplementalTransforms)
+}
+
 // RobotFromConfigPath is a helper to read and process a config given its path and then create a robot based on it.
 func RobotFromConfigPath(
 	ctx context.Context,
@@ -1275,6 +1564,15 @@ func dialRobotClient(
 	if config.ReconnectInterval != 0 {
 		rOpts = append(rOpts, client.WithReconnectEvery(config.ReconnectInterval))
 	}
+	if config.RefreshInterval != 0 {
+		rOpts = append(rOpts, client.WithRefreshEvery(config.RefreshInterval))
+	}
+	if config.HeartbeatInterval != 0 {
+		rOpts = append(rOpts, client.WithHeartbeatInterval(config.HeartbeatInterval))
+	}
+	if config.LowBandwidth {
+		rOpts = append(rOpts, client.WithLowBandwidth())
+	}
 
 	// only dial once per reconfiguration cycle, any failures will be retried on a ticker anyway
 	rOpts = append(rOpts, client.WithInitialDialAttempts(1))
@@ -1297,9 +1595,423 @@ func dialRobotClient(
 func (r *localRobot) Reconfigure(ctx context.Context, newConfig *config.Config) {
 	r.reconfigurationLock.Lock()
 	defer r.reconfigurationLock.Unlock()
+	r.applyResourceOverlays(newConfig)
 	r.reconfigure(ctx, newConfig, false)
 }
 
+// DryRunReconfigure computes what a reconfigure with the given config would do without
+// touching any running resources. The config is diffed against the current one, added and
+// modified resources are validated, and dependencies are resolved against a scratch
+// resource graph representing the result of the reconfigure.
+func (r *localRobot) DryRunReconfigure(ctx context.Context, newConfig *config.Config) (*robot.DryRunReport, error) {
+	r.reconfigurationLock.Lock()
+	defer r.reconfigurationLock.Unlock()
+
+	// work on a copy since adding default services and resource overlays modifies the config.
+	cfgCopy := *newConfig
+	cfgCopy.Services = slices.Clone(newConfig.Services)
+	newConfig = &cfgCopy
+	r.mergeResourceOverlays(newConfig)
+	defaultServicesErr := addDefaultServices(newConfig)
+
+	diff, err := config.DiffConfigs(*r.Config(), *newConfig, r.revealSensitiveConfigDiffs)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &robot.DryRunReport{
+		Diff:                   diff,
+		Errors:                 map[resource.Name]error{},
+		Warnings:               map[resource.Name][]resource.ConfigWarning{},
+		UnresolvedDependencies: map[resource.Name][]string{},
+	}
+
+	removed := make(map[resource.Name]struct{})
+	for _, conf := range slices.Concat(diff.Removed.Components, diff.Removed.Services) {
+		report.Removed = append(report.Removed, conf.ResourceName())
+		removed[conf.ResourceName()] = struct{}{}
+	}
+
+	// validate added and modified resources as reconfigure would and remember the
+	// implicit dependencies discovered along the way.
+	validated := make(map[resource.Name]resource.Config)
+	validate := func(conf resource.Config) {
+		name := conf.ResourceName()
+		requiredDeps, optionalDeps, err := conf.Validate("", name.API.Type.Name)
+		if err == nil && r.manager.moduleManager != nil && r.manager.moduleManager.Provides(conf) {
+			requiredDeps, optionalDeps, err = r.manager.moduleManager.ValidateConfig(ctx, conf)
+		}
+		if err != nil {
+			report.Errors[name] = err
+			return
+		}
+		if warnings := conf.Warnings(); len(warnings) != 0 {
+			report.Warnings[name] = warnings
+		}
+		conf.ImplicitDependsOn = requiredDeps
+		conf.ImplicitOptionalDependsOn = optionalDeps
+		validated[name] = conf
+	}
+	for _, conf := range slices.Concat(diff.Added.Components, diff.Added.Services) {
+		report.Added = append(report.Added, conf.ResourceName())
+		validate(conf)
+	}
+	for _, conf := range slices.Concat(diff.Modified.Components, diff.Modified.Services) {
+		report.Modified = append(report.Modified, conf.ResourceName())
+		validate(conf)
+	}
+
+	// build a scratch graph that represents the robot after reconfiguration. Existing
+	// nodes that are not configured through the new config (e.g. default services and
+	// remote resources) are carried over as-is.
+	scratch := resource.NewGraph(r.logger)
+	scratch.SetNameConflictResolution(newConfig.NameConflicts)
+	newConfs := make(map[resource.Name]resource.Config)
+	for _, conf := range slices.Concat(newConfig.Components, newConfig.Services) {
+		name := conf.ResourceName()
+		if _, hasErr := report.Errors[name]; hasErr {
+			continue
+		}
+		if validatedConf, ok := validated[name]; ok {
+			conf = validatedConf
+		}
+		newConfs[name] = conf
+	}
+	for _, name := range r.manager.resources.Names() {
+		if _, ok := removed[name]; ok {
+			continue
+		}
+		if _, ok := newConfs[name]; ok {
+			continue
+		}
+		if err := scratch.AddNode(name, resource.NewUninitializedNode()); err != nil {
+			return nil, err
+		}
+	}
+	for name, conf := range newConfs {
+		if err := scratch.AddNode(name, resource.NewUnconfiguredGraphNode(conf, conf.Dependencies())); err != nil {
+			return nil, err
+		}
+	}
+
+	// the resolver logs on its own, which would be misleading for a dry run.
+	report.DependencyError = multierr.Combine(
+		defaultServicesErr,
+		scratch.ResolveDependencies(logging.NewBlankLogger("dry_run")),
+	)
+	for _, name := range scratch.Names() {
+		node, ok := scratch.Node(name)
+		if !ok {
+			continue
+		}
+		if deps := node.UnresolvedDependencies(); len(deps) != 0 {
+			report.UnresolvedDependencies[name] = deps
+		}
+	}
+	return report, nil
+}
+
+// ConfigHistory returns the configs most recently applied to the robot, oldest first.
+func (r *localRobot) ConfigHistory() []config.HistoryEntry {
+	if r.configHistory == nil {
+		return nil
+	}
+	return r.configHistory.Entries()
+}
+
+// Rollback reapplies the config with the given revision from the config history. The
+// config goes through the same diff and reconfigure path as any other config, so only
+// resources that differ from the current config are touched. A later config from the
+// cloud will replace the rolled back config as usual.
+func (r *localRobot) Rollback(ctx context.Context, revision string) error {
+	if r.configHistory == nil {
+		return errors.New("config history is not enabled on this robot")
+	}
+	cfg, err := r.configHistory.Load(revision, r.logger)
+	if err != nil {
+		return err
+	}
+	r.logger.CInfow(ctx, "rolling back config", "revision", revision)
+	r.Reconfigure(ctx, cfg)
+	return nil
+}
+
+// ApplyResourceChanges adds, removes and updates the given components and services in one
+// reconfigure, so that the resource graph is sorted and the dependents of changed resources are
+// updated once for the whole batch. The changes are kept in an overlay for the module that made
+// them, which is merged into every later config the robot is given until the module is removed
+// from the config. Either every change is applied or, if any of them is invalid, none are. Once the
+// changes are applied, the errors of the added and updated resources that failed to build or
+// reconfigure are returned by name.
+func (r *localRobot) ApplyResourceChanges(
+	ctx context.Context,
+	adds []resource.Config,
+	removes []resource.Name,
+	updates []resource.Config,
+) (map[resource.Name]error, error) {
+	r.reconfigurationLock.Lock()
+	defer r.reconfigurationLock.Unlock()
+
+	// the configs are converted and validated in place, so the caller's are left as they are
+	adds, updates = slices.Clone(adds), slices.Clone(updates)
+	newConfig := r.mostRecentCfg.Load().(config.Config)
+	newConfig.Components = slices.Clone(newConfig.Components)
+	newConfig.Services = slices.Clone(newConfig.Services)
+	if err := applyResourceChanges(&newConfig, adds, removes, updates); err != nil {
+		return nil, err
+	}
+	modName := rgrpc.GetModuleName(ctx)
+	overlay, ok := r.resourceOverlays[modName]
+	if !ok {
+		overlay = &resourceOverlay{}
+		r.resourceOverlays[modName] = overlay
+	}
+	overlay.record(adds, removes, updates)
+	r.logger.CInfow(ctx, "applying resource changes", "module", modName,
+		"added", len(adds), "removed", len(removes), "updated", len(updates))
+	r.reconfigure(ctx, &newConfig, false)
+
+	resErrs := map[resource.Name]error{}
+	for _, conf := range slices.Concat(adds, updates) {
+		name := conf.ResourceName()
+		gNode, ok := r.manager.resources.Node(name)
+		if !ok {
+			resErrs[name] = resource.NewNotFoundError(name)
+			continue
+		}
+		if err := gNode.Status().Error; err != nil {
+			resErrs[name] = err
+		}
+	}
+	return resErrs, nil
+}
+
+// applyResourceChanges makes the given changes to the components and services of cfg, returning
+// an error without making any of them if one is invalid. The added and updated configs are
+// converted and validated in place.
+func applyResourceChanges(cfg *config.Config, adds []resource.Config, removes []resource.Name, updates []resource.Config) error {
+	find := func(name resource.Name) (*[]resource.Config, int) {
+		for _, confs := range []*[]resource.Config{&cfg.Components, &cfg.Services} {
+			if idx := slices.IndexFunc(*confs, func(conf resource.Config) bool {
+				return conf.ResourceName() == name
+			}); idx != -1 {
+				return confs, idx
+			}
+		}
+		return nil, -1
+	}
+	validate := func(conf *resource.Config) error {
+		name := conf.ResourceName()
+		if !(name.API.IsComponent() || name.API.IsService()) {
+			return errors.Errorf("resource %q is neither a component nor a service", name)
+		}
+		// configs sent by modules only have their attributes, which are converted here for builtin
+		// models as they are when reading a config. Modular models convert them in their module.
+		if conf.ConvertedAttributes == nil {
+			if reg, ok := resource.LookupRegistration(name.API, conf.Model); ok && reg.AttributeMapConverter != nil {
+				converted, err := reg.AttributeMapConverter(conf.Attributes)
+				if err != nil {
+					return errors.Wrapf(err, "error converting attributes for %q", name)
+				}
+				conf.ConvertedAttributes = converted
+			}
+		}
+		_, _, err := conf.Validate("", name.API.Type.Name)
+		return err
+	}
+
+	changed := map[resource.Name]bool{}
+	checkOnce := func(name resource.Name) error {
+		if changed[name] {
+			return errors.Errorf("resource %q is changed more than once", name)
+		}
+		changed[name] = true
+		return nil
+	}
+	for i := range adds {
+		conf := &adds[i]
+		if err := checkOnce(conf.ResourceName()); err != nil {
+			return err
+		}
+		if err := validate(conf); err != nil {
+			return err
+		}
+		if confs, _ := find(conf.ResourceName()); confs != nil {
+			return errors.Errorf("cannot add resource %q as it already exists", conf.ResourceName())
+		}
+	}
+	for _, name := range removes {
+		if err := checkOnce(name); err != nil {
+			return err
+		}
+		if confs, _ := find(name); confs == nil {
+			return resource.NewNotFoundError(name)
+		}
+	}
+	for i := range updates {
+		conf := &updates[i]
+		if err := checkOnce(conf.ResourceName()); err != nil {
+			return err
+		}
+		if err := validate(conf); err != nil {
+			return err
+		}
+		if confs, _ := find(conf.ResourceName()); confs == nil {
+			return resource.NewNotFoundError(conf.ResourceName())
+		}
+	}
+
+	for _, name := range removes {
+		confs, idx := find(name)
+		*confs = slices.Delete(*confs, idx, idx+1)
+	}
+	for _, conf := range updates {
+		confs, idx := find(conf.ResourceName())
+		(*confs)[idx] = conf
+	}
+	for _, conf := range adds {
+		if conf.ResourceName().API.IsComponent() {
+			cfg.Components = append(cfg.Components, conf)
+		} else {
+			cfg.Services = append(cfg.Services, conf)
+		}
+	}
+	return nil
+}
+
+// resourceOverlay is the net effect of the resource changes a module has applied, to be merged
+// into the configs the robot is given.
+type resourceOverlay struct {
+	// confs are the configs of the resources added or updated, in the order they were first
+	// changed. added records which of them were added rather than updated.
+	confs   []resource.Config
+	added   map[resource.Name]bool
+	removed map[resource.Name]bool
+}
+
+// record folds a batch of resource changes that has already been validated into the overlay.
+func (o *resourceOverlay) record(adds []resource.Config, removes []resource.Name, updates []resource.Config) {
+	if o.added == nil {
+		o.added = map[resource.Name]bool{}
+		o.removed = map[resource.Name]bool{}
+	}
+	set := func(conf resource.Config) {
+		name := conf.ResourceName()
+		delete(o.removed, name)
+		if idx := slices.IndexFunc(o.confs, func(c resource.Config) bool {
+			return c.ResourceName() == name
+		}); idx != -1 {
+			o.confs[idx] = conf
+			return
+		}
+		o.confs = append(o.confs, conf)
+	}
+	for _, name := range removes {
+		o.confs = slices.DeleteFunc(o.confs, func(c resource.Config) bool {
+			return c.ResourceName() == name
+		})
+		// removing a resource the module added itself leaves nothing to remove from later configs
+		if o.added[name] {
+			delete(o.added, name)
+			continue
+		}
+		o.removed[name] = true
+	}
+	for _, conf := range updates {
+		set(conf)
+	}
+	for _, conf := range adds {
+		// a resource the module removed from the config and adds back replaces the config's
+		o.added[conf.ResourceName()] = !o.removed[conf.ResourceName()]
+		set(conf)
+	}
+}
+
+// apply makes the overlay's changes to the components and services of cfg. Removed resources that
+// are not in cfg are ignored and added or updated resources replace any in cfg with the same name.
+func (o *resourceOverlay) apply(cfg *config.Config) {
+	for _, confs := range []*[]resource.Config{&cfg.Components, &cfg.Services} {
+		*confs = slices.DeleteFunc(*confs, func(conf resource.Config) bool {
+			name := conf.ResourceName()
+			return o.removed[name] || slices.ContainsFunc(o.confs, func(c resource.Config) bool {
+				return c.ResourceName() == name
+			})
+		})
+	}
+	for _, conf := range o.confs {
+		if conf.ResourceName().API.IsComponent() {
+			cfg.Components = append(cfg.Components, conf)
+		} else {
+			cfg.Services = append(cfg.Services, conf)
+		}
+	}
+}
+
+// applyResourceOverlays merges the resource changes applied through ApplyResourceChanges into
+// cfg, dropping the overlays of modules that are no longer in it. The reconfigurationLock must be
+// held.
+func (r *localRobot) applyResourceOverlays(cfg *config.Config) {
+	for modName := range r.resourceOverlays {
+		if !overlayInConfig(cfg, modName) {
+			delete(r.resourceOverlays, modName)
+		}
+	}
+	r.mergeResourceOverlays(cfg)
+}
+
+// mergeResourceOverlays merges the overlays of the modules in cfg into it without dropping those
+// of the other modules, as a dry run must leave the robot as it is. The reconfigurationLock must
+// be held.
+func (r *localRobot) mergeResourceOverlays(cfg *config.Config) {
+	if len(r.resourceOverlays) == 0 {
+		return
+	}
+	// the caller's slices may be shared, such as with the cached config of a config watcher
+	cfg.Components = slices.Clone(cfg.Components)
+	cfg.Services = slices.Clone(cfg.Services)
+	modNames := slices.Sorted(maps.Keys(r.resourceOverlays))
+	for _, modName := range modNames {
+		if overlayInConfig(cfg, modName) {
+			r.resourceOverlays[modName].apply(cfg)
+		}
+	}
+}
+
+// overlayInConfig returns whether the overlay of the named module applies to cfg, which is the
+// case while the module is in it. Changes that were not made by a module always apply.
+func overlayInConfig(cfg *config.Config, modName string) bool {
+	return modName == "" || slices.ContainsFunc(cfg.Modules, func(mod config.Module) bool {
+		return mod.Name == modName
+	})
+}
+
+// StartResource builds a resource with lazy_start set that has not been started yet, along with
+// any such resources it depends on, and waits for it to be built. It does nothing for resources
+// that have already been started or do not have lazy_start set.
+func (r *localRobot) StartResource(ctx context.Context, name resource.Name) error {
+	gNode, ok := r.manager.resources.Node(name)
+	if !ok {
+		return resource.NewNotFoundError(name)
+	}
+	if !gNode.Deferred() {
+		return nil
+	}
+
+	r.reconfigurationLock.Lock()
+	defer r.reconfigurationLock.Unlock()
+	// only the resource and the deferred resources it depends on are built, so that the first
+	// request for a resource does not wait on the rest of the graph.
+	if !r.manager.startResource(ctx, r, name) {
+		return nil
+	}
+	r.logger.CInfow(ctx, "started lazily started resource", "resource", name)
+	r.updateWeakAndOptionalDependents(ctx)
+	if _, err := gNode.Resource(); err != nil {
+		return resource.NewNotAvailableError(name, err)
+	}
+	return nil
+}
+
 // set Module.LocalVersion on Type=local modules. Call this before localPackages.Sync and in RestartModule.
 func (r *localRobot) applyLocalModuleVersions(cfg *config.Config) {
 	for i := range cfg.Modules {
@@ -1322,10 +2034,22 @@ func (r *localRobot) reconfigure(ctx context.Context, newConfig *config.Config,
 		r.initializing.Store(newConfig.Initial)
 	}()
 
+	// record where the config came from even if nothing about it changed, so that status
+	// reflects a cached config being replaced by the same config from the cloud.
+	if newConfig.Cloud != nil {
+		r.configRevisionMu.Lock()
+		r.configFetch = newConfig.FetchStatus()
+		r.configRevisionMu.Unlock()
+	}
+
 	if !r.reconfigureAllowed(ctx, newConfig, true) {
 		return
 	}
 
+	if r.setRedactedValues != nil {
+		r.setRedactedValues(newConfig.ResolvedSecrets())
+	}
+
 	// If reconfigure is allowed, assume we are reconfiguring until this function
 	// returns.
 	r.reconfiguring.Store(true)
@@ -1333,11 +2057,19 @@ func (r *localRobot) reconfigure(ctx context.Context, newConfig *config.Config,
 		r.reconfiguring.Store(false)
 	}()
 
+	// checksum the config before default services are added to it below
+	reconfigureStart := time.Now()
+	checksum, err := newConfig.Checksum()
+	if err != nil {
+		r.logger.CWarnw(ctx, "error computing config checksum", "error", err)
+	}
+
 	r.configRevisionMu.Lock()
 	r.configRevision = config.Revision{
 		Revision:    newConfig.Revision,
 		LastUpdated: time.Now(),
 	}
+	r.disabledResources = newConfig.DisabledResources
 	r.configRevisionMu.Unlock()
 
 	var allErrs error
@@ -1347,7 +2079,7 @@ func (r *localRobot) reconfigure(ctx context.Context, newConfig *config.Config,
 	// TODO(RSDK-1849): Make this non-blocking so other resources that do not require packages can run before package sync finishes.
 	// TODO(RSDK-2710) this should really use Reconfigure for the package and should allow itself to check
 	// if anything has changed.
-	err := r.packageManager.Sync(ctx, newConfig.Packages, newConfig.Modules)
+	err = r.packageManager.Sync(ctx, newConfig.Packages, newConfig.Modules)
 	if err != nil {
 		// The returned error is rich, detailing each individual packages error. The underlying
 		// `Sync` call is responsible for logging those errors in a readable way. We only need to
@@ -1391,70 +2123,14 @@ func (r *localRobot) reconfigure(ctx context.Context, newConfig *config.Config,
 		}
 	}
 
-	// Add default services and process their dependencies. Dependencies may
-	// already come from config validation so we check that here.
-	seen := make(map[resource.API][]int)
-	for idx, val := range newConfig.Services {
-		seen[val.API] = append(seen[val.API], idx)
-	}
-	for _, name := range resource.DefaultServices() {
-		existingConfIdxs, hasExistingConf := seen[name.API]
-		svcCfgs := []resource.Config{}
-
-		defaultSvcCfg := resource.Config{
-			Name:  name.Name,
-			Model: resource.DefaultServiceModel,
-			API:   name.API,
-		}
-
-		overwritesBuiltin := false
-		if hasExistingConf {
-			for _, existingConfIdx := range existingConfIdxs {
-				// Overwrite the builtin service if the configured service uses the same name.
-				// Otherwise, allow both to coexist.
-				if defaultSvcCfg.Name == newConfig.Services[existingConfIdx].Name {
-					overwritesBuiltin = true
-				}
-				svcCfgs = append(svcCfgs, newConfig.Services[existingConfIdx])
-			}
-		}
-		if !overwritesBuiltin {
-			svcCfgs = append(svcCfgs, defaultSvcCfg)
-		}
-
-		for i, svcCfg := range svcCfgs {
-			if svcCfg.ConvertedAttributes != nil || svcCfg.Attributes != nil {
-				// previously processed
-				continue
-			}
-
-			// we find dependencies through configs, so we must try to validate even a default config
-			if reg, ok := resource.LookupRegistration(svcCfg.API, svcCfg.Model); ok && reg.AttributeMapConverter != nil {
-				converted, err := reg.AttributeMapConverter(utils.AttributeMap{})
-				if err != nil {
-					allErrs = multierr.Combine(allErrs, errors.Wrapf(err, "error converting attributes for %s", svcCfg.API))
-					continue
-				}
-				svcCfg.ConvertedAttributes = converted
-				requiredDeps, optionalDeps, err := converted.Validate("")
-				if err != nil {
-					allErrs = multierr.Combine(allErrs, errors.Wrapf(err, "error getting default service dependencies for %s", svcCfg.API))
-					continue
-				}
-				svcCfg.ImplicitDependsOn = requiredDeps
-				svcCfg.ImplicitOptionalDependsOn = optionalDeps
-			}
-			// Update existing service configs, and the final config will be the default service, if not overridden
-			if i < len(existingConfIdxs) {
-				newConfig.Services[existingConfIdxs[i]] = svcCfg
-			} else {
-				newConfig.Services = append(newConfig.Services, svcCfg)
-			}
-		}
-	}
+	allErrs = multierr.Combine(allErrs, addDefaultServices(newConfig))
 
 	existingConfig := r.Config()
 	r.mostRecentCfg.Store(*newConfig)
+	r.manager.resources.SetNameConflictResolution(newConfig.NameConflicts)
+	// modules register their APIs as their resources are added, so their methods only get policies
+	// once the resources are updated.
+	defer r.webSvc.UpdateMethodPolicies(newConfig.Network.MethodPolicies)
 
 	// Now that we have the new config and all references are resolved, diff it
 	// with the current generated config to see what has changed
@@ -1464,6 +2140,37 @@ func (r *localRobot) reconfigure(ctx context.Context, newConfig *config.Config,
 		return
 	}
 
+	// record the config in the config history once it is applied without errors and all of its
+	// resources are built, so that only configs known to work can be rolled back to.
+	if r.configHistory != nil && !newConfig.Initial {
+		defer func() {
+			if allErrs != nil {
+				return
+			}
+			if failed := r.failedResources(newConfig); len(failed) != 0 {
+				r.logger.CInfow(ctx, "not recording the config in the config history as some of its resources failed to build",
+					"resources", resource.NamesToStrings(failed))
+				return
+			}
+			if err := r.configHistory.Record(newConfig); err != nil {
+				r.logger.CErrorw(ctx, "error recording the config in the config history", "error", err)
+			}
+		}()
+	}
+
+	// record the config as applied once reconfiguring with it finishes, even if no resources
+	// changed.
+	defer func() {
+		r.configRevisionMu.Lock()
+		r.appliedConfig = robot.AppliedConfigStatus{
+			Revision:            newConfig.Revision,
+			Checksum:            checksum,
+			ReconfiguredAt:      reconfigureStart,
+			ReconfigureDuration: time.Since(reconfigureStart),
+		}
+		r.configRevisionMu.Unlock()
+	}()
+
 	revision := diff.NewRevision()
 	for _, res := range diff.UnmodifiedResources {
 		r.manager.updateRevision(res.ResourceName(), revision)
@@ -1544,6 +2251,86 @@ func (r *localRobot) reconfigure(ctx context.Context, newConfig *config.Config,
 	}
 }
 
+// failedResources returns the components and services of cfg that failed to build or reconfigure.
+// Resources that have not been started as they are started lazily have not failed.
+func (r *localRobot) failedResources(cfg *config.Config) []resource.Name {
+	var failed []resource.Name
+	for _, conf := range slices.Concat(cfg.Components, cfg.Services) {
+		name := conf.ResourceName()
+		gNode, ok := r.manager.resources.Node(name)
+		if !ok || gNode.Status().Error != nil {
+			failed = append(failed, name)
+		}
+	}
+	return failed
+}
+
+// addDefaultServices adds default services to the given config and processes their
+// dependencies. Dependencies may already come from config validation so we check that here.
+func addDefaultServices(newConfig *config.Config) error {
+	var allErrs error
+	seen := make(map[resource.API][]int)
+	for idx, val := range newConfig.Services {
+		seen[val.API] = append(seen[val.API], idx)
+	}
+	for _, name := range resource.DefaultServices() {
+		existingConfIdxs, hasExistingConf := seen[name.API]
+		svcCfgs := []resource.Config{}
+
+		defaultSvcCfg := resource.Config{
+			Name:  name.Name,
+			Model: resource.DefaultServiceModel,
+			API:   name.API,
+		}
+
+		overwritesBuiltin := false
+		if hasExistingConf {
+			for _, existingConfIdx := range existingConfIdxs {
+				// Overwrite the builtin service if the configured service uses the same name.
+				// Otherwise, allow both to coexist.
+				if defaultSvcCfg.Name == newConfig.Services[existingConfIdx].Name {
+					overwritesBuiltin = true
+				}
+				svcCfgs = append(svcCfgs, newConfig.Services[existingConfIdx])
+			}
+		}
+		if !overwritesBuiltin {
+			svcCfgs = append(svcCfgs, defaultSvcCfg)
+		}
+
+		for i, svcCfg := range svcCfgs {
+			if svcCfg.ConvertedAttributes != nil || svcCfg.Attributes != nil {
+				// previously processed
+				continue
+			}
+
+			// we find dependencies through configs, so we must try to validate even a default config
+			if reg, ok := resource.LookupRegistration(svcCfg.API, svcCfg.Model); ok && reg.AttributeMapConverter != nil {
+				converted, err := reg.AttributeMapConverter(utils.AttributeMap{})
+				if err != nil {
+					allErrs = multierr.Combine(allErrs, errors.Wrapf(err, "error converting attributes for %s", svcCfg.API))
+					continue
+				}
+				svcCfg.ConvertedAttributes = converted
+				requiredDeps, optionalDeps, err := converted.Validate("")
+				if err != nil {
+					allErrs = multierr.Combine(allErrs, errors.Wrapf(err, "error getting default service dependencies for %s", svcCfg.API))
+					continue
+				}
+				svcCfg.ImplicitDependsOn = requiredDeps
+				svcCfg.ImplicitOptionalDependsOn = optionalDeps
+			}
+			// Update existing service configs, and the final config will be the default service, if not overridden
+			if i < len(existingConfIdxs) {
+				newConfig.Services[existingConfIdxs[i]] = svcCfg
+			} else {
+				newConfig.Services = append(newConfig.Services, svcCfg)
+			}
+		}
+	}
+	return allErrs
+}
+
 // checkMaxInstance checks to see if the local robot has reached the maximum number of a specific resource type that are local.
 func (r *localRobot) checkMaxInstance(api resource.API, max int) error {
 	maxInstance := 0
@@ -1677,17 +2464,36 @@ func (r *localRobot) MachineStatus(ctx context.Context) (robot.MachineStatus, er
 		// cloud metadata blank in that case.
 		result.Resources = append(result.Resources, resource.Status{NodeStatus: resourceStatus, CloudMetadata: cloud.Metadata{}})
 	}
+	// read the config statuses together so that they describe the same point in time
 	r.configRevisionMu.RLock()
 	result.Config = r.configRevision
+	result.ConfigFetch = r.configFetch
+	result.AppliedConfig = r.appliedConfig
+	result.DisabledResources = r.disabledResources
 	r.configRevisionMu.RUnlock()
+	result.CloudConnection = r.cloudConnectionState()
 
 	result.State = robot.StateRunning
 	if r.initializing.Load() {
 		result.State = robot.StateInitializing
 	}
+	if r.operations.Draining() {
+		result.State = robot.StateShuttingDown
+	}
 	return result, nil
 }
 
+// cloudConnectionState returns whether the robot is connected to the cloud managing it.
+func (r *localRobot) cloudConnectionState() robot.CloudConnectionState {
+	if r.cloudConn == nil {
+		return robot.CloudConnectionNotManaged
+	}
+	if conn, ok := r.cloudConn.(interface{ Connected() bool }); ok && !conn.Connected() {
+		return robot.CloudConnectionDisconnected
+	}
+	return robot.CloudConnectionConnected
+}
+
 // Version returns version information about the robot.
 func (r *localRobot) Version(ctx context.Context) (robot.VersionResponse, error) {
 	return robot.Version()
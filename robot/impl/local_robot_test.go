@@ -634,6 +634,101 @@ func TestStopAll(t *testing.T) {
 	test.That(t, stopAllErr, test.ShouldBeNil)
 }
 
+func TestStopResources(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	model := resource.DefaultModelFamily.WithModel(utils.RandomAlphaString(8))
+
+	var stopped sync.Map
+	newArm := func(stop func(ctx context.Context) error) *inject.Arm {
+		return &inject.Arm{StopFunc: func(ctx context.Context, extra map[string]interface{}) error {
+			return stop(ctx)
+		}}
+	}
+	arms := map[string]*inject.Arm{
+		"ok": newArm(func(ctx context.Context) error {
+			stopped.Store("ok", true)
+			return nil
+		}),
+		"fails": newArm(func(ctx context.Context) error {
+			return errors.New("stuck brake")
+		}),
+		"hangs": newArm(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+		"excluded": newArm(func(ctx context.Context) error {
+			stopped.Store("excluded", true)
+			return nil
+		}),
+	}
+	resource.RegisterComponent(
+		arm.API,
+		model,
+		resource.Registration[arm.Arm, resource.NoNativeConfig]{Constructor: func(
+			ctx context.Context,
+			deps resource.Dependencies,
+			conf resource.Config,
+			logger logging.Logger,
+		) (arm.Arm, error) {
+			return arms[conf.Name], nil
+		}})
+	defer func() {
+		resource.Deregister(arm.API, model)
+	}()
+	resource.RegisterComponent(
+		motor.API,
+		model,
+		resource.Registration[motor.Motor, resource.NoNativeConfig]{Constructor: func(
+			ctx context.Context,
+			deps resource.Dependencies,
+			conf resource.Config,
+			logger logging.Logger,
+		) (motor.Motor, error) {
+			return &inject.Motor{StopFunc: func(ctx context.Context, extra map[string]interface{}) error {
+				stopped.Store(conf.Name, true)
+				return nil
+			}}, nil
+		}})
+	defer func() {
+		resource.Deregister(motor.API, model)
+	}()
+
+	cfg := &config.Config{}
+	for name := range arms {
+		cfg.Components = append(cfg.Components, resource.Config{Name: name, API: arm.API, Model: model})
+	}
+	cfg.Components = append(cfg.Components, resource.Config{Name: "m", API: motor.API, Model: model})
+
+	ctx := context.Background()
+	r := setupLocalRobot(t, ctx, cfg, logger)
+
+	report, err := r.StopResources(ctx, robot.StopFilter{
+		APIs:    []resource.API{arm.API},
+		Exclude: []resource.Name{arm.Named("excluded")},
+		Timeout: 100 * time.Millisecond,
+	}, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, report.Stopped, test.ShouldResemble, []resource.Name{arm.Named("ok")})
+	test.That(t, report.TimedOut, test.ShouldResemble, []resource.Name{arm.Named("hangs")})
+	test.That(t, report.Failed, test.ShouldHaveLength, 1)
+	test.That(t, report.Failed[arm.Named("fails")].Error(), test.ShouldContainSubstring, "stuck brake")
+	test.That(t, report.Err(), test.ShouldNotBeNil)
+
+	_, ok := stopped.Load("excluded")
+	test.That(t, ok, test.ShouldBeFalse)
+	_, ok = stopped.Load("m")
+	test.That(t, ok, test.ShouldBeFalse)
+
+	report, err = r.StopResources(ctx, robot.StopFilter{Remotes: []string{"notaremote"}}, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, report.Stopped, test.ShouldBeEmpty)
+
+	report, err = r.StopResources(ctx, robot.StopFilter{LocalOnly: true, APIs: []resource.API{motor.API}}, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, report.Stopped, test.ShouldResemble, []resource.Name{motor.Named("m")})
+	test.That(t, report.Err(), test.ShouldBeNil)
+}
+
 func TestNewTeardown(t *testing.T) {
 	logger := logging.NewTestLogger(t)
 
@@ -841,7 +936,10 @@ func TestGetRemoteResourceAndGrandFather(t *testing.T) {
 	_, err = r.ResourceByName(arm.Named("remote:pieceArm"))
 	test.That(t, err, test.ShouldBeNil)
 	_, err = r.ResourceByName(arm.Named("pieceArm"))
-	test.That(t, err, test.ShouldBeError, "more than one remote resources with name \"pieceArm\" exists")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `more than one resource with name "pieceArm" exists`)
+	test.That(t, err.Error(), test.ShouldContainSubstring, arm.Named("remote:foo:pieceArm").String())
+	test.That(t, err.Error(), test.ShouldContainSubstring, arm.Named("remote:pieceArm").String())
 }
 
 type someConfig struct {
@@ -1619,6 +1717,141 @@ func TestDependentResources(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 }
 
+func TestDryRunReconfigure(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{
+				Name:  "b",
+				Model: fakeModel,
+				API:   base.API,
+			},
+			{
+				Name:                "m",
+				Model:               fakeModel,
+				API:                 motor.API,
+				DependsOn:           []string{"b"},
+				ConvertedAttributes: &fakemotor.Config{},
+			},
+		},
+	}
+	r := setupLocalRobot(t, ctx, cfg, logger)
+
+	// Remove base 'b', modify motor 'm' and add a motor 'm1' depending on a missing
+	// resource.
+	cfg2 := &config.Config{
+		Components: []resource.Config{
+			{
+				Name:                "m",
+				Model:               fakeModel,
+				API:                 motor.API,
+				ConvertedAttributes: &fakemotor.Config{MaxRPM: 10},
+			},
+			{
+				Name:                "m1",
+				Model:               fakeModel,
+				API:                 motor.API,
+				DependsOn:           []string{"missing"},
+				ConvertedAttributes: &fakemotor.Config{},
+			},
+		},
+	}
+	report, err := r.DryRunReconfigure(ctx, cfg2)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, report.Added, test.ShouldResemble, []resource.Name{motor.Named("m1")})
+	test.That(t, report.Modified, test.ShouldResemble, []resource.Name{motor.Named("m")})
+	test.That(t, report.Removed, test.ShouldResemble, []resource.Name{base.Named("b")})
+	test.That(t, report.Errors, test.ShouldBeEmpty)
+	test.That(t, report.DependencyError, test.ShouldBeNil)
+	test.That(t, report.UnresolvedDependencies, test.ShouldResemble, map[resource.Name][]string{
+		motor.Named("m1"): {"missing"},
+	})
+	test.That(t, report.HasErrors(), test.ShouldBeTrue)
+
+	// Nothing should have changed on the running robot.
+	_, err = r.ResourceByName(base.Named("b"))
+	test.That(t, err, test.ShouldBeNil)
+	_, err = r.ResourceByName(motor.Named("m1"))
+	test.That(t, err, test.ShouldBeError, resource.NewNotFoundError(motor.Named("m1")))
+
+	// A dry run of the current config reports nothing.
+	report, err = r.DryRunReconfigure(ctx, cfg)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, report.Diff.ResourcesEqual, test.ShouldBeTrue)
+	test.That(t, report.HasErrors(), test.ShouldBeFalse)
+}
+
+func TestConfigRollback(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	cfg1 := &config.Config{
+		Revision: "rev1",
+		Components: []resource.Config{
+			{
+				Name:  "b1",
+				Model: fakeModel,
+				API:   base.API,
+			},
+		},
+	}
+	r := setupLocalRobot(t, ctx, cfg1, logger, WithConfigHistory(t.TempDir(), config.DefaultHistorySize))
+
+	cfg2 := &config.Config{
+		Revision: "rev2",
+		Components: []resource.Config{
+			{
+				Name:  "b2",
+				Model: fakeModel,
+				API:   base.API,
+			},
+		},
+	}
+	r.Reconfigure(ctx, cfg2)
+	_, err := r.ResourceByName(base.Named("b2"))
+	test.That(t, err, test.ShouldBeNil)
+
+	test.That(t, r.Rollback(ctx, "rev3"), test.ShouldNotBeNil)
+	test.That(t, r.Rollback(ctx, "rev1"), test.ShouldBeNil)
+	_, err = r.ResourceByName(base.Named("b1"))
+	test.That(t, err, test.ShouldBeNil)
+	_, err = r.ResourceByName(base.Named("b2"))
+	test.That(t, err, test.ShouldBeError, resource.NewNotFoundError(base.Named("b2")))
+	mStatus, err := r.MachineStatus(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, mStatus.Config.Revision, test.ShouldEqual, "rev1")
+
+	var revisions []string
+	for _, entry := range r.ConfigHistory() {
+		revisions = append(revisions, entry.Revision)
+	}
+	test.That(t, revisions, test.ShouldResemble, []string{"rev1", "rev2", "rev1"})
+
+	// configs with resources that fail to build are not recorded
+	failingModel := resource.NewModel("rdk", "test", "failing")
+	resource.RegisterComponent(generic.API, failingModel, resource.Registration[resource.Resource, resource.NoNativeConfig]{
+		Constructor: func(
+			ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger,
+		) (resource.Resource, error) {
+			return nil, errors.New("cannot build")
+		},
+	})
+	defer func() {
+		resource.Deregister(generic.API, failingModel)
+	}()
+	r.Reconfigure(ctx, &config.Config{
+		Revision: "rev3",
+		Components: []resource.Config{
+			{Name: "b1", Model: fakeModel, API: base.API},
+			{Name: "broken", Model: failingModel, API: generic.API},
+		},
+	})
+	test.That(t, r.ConfigHistory(), test.ShouldHaveLength, 3)
+	test.That(t, r.ConfigHistory()[2].Revision, test.ShouldEqual, "rev1")
+}
+
 func TestSlowShutdownTicker(t *testing.T) {
 	ctx := context.Background()
 	logger, logs := logging.NewObservedTestLogger(t)
@@ -3101,6 +3334,43 @@ func TestMachineStatus(t *testing.T) {
 		rtestutils.VerifySameResourceStatuses(t, mStatus.Resources, expectedStatuses)
 	})
 
+	t.Run("applied config", func(t *testing.T) {
+		lr := setupLocalRobot(t, ctx, &config.Config{Revision: "rev1"}, logger)
+		mStatus, err := lr.MachineStatus(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, mStatus.AppliedConfig.Revision, test.ShouldEqual, "rev1")
+		test.That(t, mStatus.AppliedConfig.Checksum, test.ShouldNotBeEmpty)
+		test.That(t, mStatus.AppliedConfig.ReconfiguredAt.IsZero(), test.ShouldBeFalse)
+		test.That(t, mStatus.CloudConnection, test.ShouldEqual, robot.CloudConnectionNotManaged)
+		firstChecksum := mStatus.AppliedConfig.Checksum
+
+		lr.Reconfigure(ctx, &config.Config{
+			Revision:   "rev2",
+			Components: []resource.Config{newMockConfig("m", 0, false, "")},
+		})
+		mStatus, err = lr.MachineStatus(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, mStatus.AppliedConfig.Revision, test.ShouldEqual, "rev2")
+		test.That(t, mStatus.AppliedConfig.Checksum, test.ShouldNotEqual, firstChecksum)
+		secondChecksum := mStatus.AppliedConfig.Checksum
+		for _, status := range mStatus.Resources {
+			if status.Name.Name == "m" {
+				test.That(t, status.Configure.Builds.Attempts, test.ShouldEqual, 1)
+				test.That(t, status.Configure.Builds.Failures, test.ShouldEqual, 0)
+				test.That(t, status.Configure.Builds.TotalDuration, test.ShouldBeGreaterThan, 0)
+			}
+		}
+
+		// the same config content has the same checksum
+		lr.Reconfigure(ctx, &config.Config{
+			Revision:   "rev2",
+			Components: []resource.Config{newMockConfig("m", 0, false, "")},
+		})
+		mStatus, err = lr.MachineStatus(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, mStatus.AppliedConfig.Checksum, test.ShouldEqual, secondChecksum)
+	})
+
 	t.Run("default resources with cloud metadata", func(t *testing.T) {
 		rev1 := "rev1"
 		partID := "the-robot-part"
@@ -4693,3 +4963,532 @@ func TestListTunnels(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, ttes, test.ShouldResemble, trafficTunnelEndpoints)
 }
+
+func TestReconfigureDoesNotWaitForUnrelatedResources(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	model := resource.NewModel("rdk", "test", "blocking")
+	release := make(chan struct{})
+	dependentBuilt := make(chan struct{})
+	resource.RegisterComponent(generic.API, model, resource.Registration[resource.Resource,
+		resource.NoNativeConfig]{
+		Constructor: func(
+			ctx context.Context,
+			deps resource.Dependencies,
+			conf resource.Config,
+			logger logging.Logger,
+		) (resource.Resource, error) {
+			switch conf.Name {
+			case "slow":
+				<-release
+			case "dependent":
+				close(dependentBuilt)
+			}
+			return &fooComponent{
+				Named:  conf.ResourceName().AsNamed(),
+				logger: logger,
+			}, nil
+		},
+	})
+	defer func() {
+		resource.Deregister(generic.API, model)
+	}()
+
+	r := setupLocalRobot(t, ctx, &config.Config{}, logger)
+
+	// "dependent" is in the level after "slow", but only depends on "fast".
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{Name: "slow", API: generic.API, Model: model},
+			{Name: "fast", API: generic.API, Model: model},
+			{Name: "dependent", API: generic.API, Model: model, DependsOn: []string{"fast"}},
+		},
+	}
+	reconfigured := make(chan struct{})
+	go func() {
+		defer close(reconfigured)
+		r.Reconfigure(ctx, cfg)
+	}()
+
+	select {
+	case <-dependentBuilt:
+	case <-time.After(10 * time.Second):
+		close(release)
+		t.Fatal("resource waited for an unrelated resource in an earlier level")
+	}
+	close(release)
+	<-reconfigured
+
+	for _, name := range []string{"slow", "fast", "dependent"} {
+		_, err := r.ResourceByName(generic.Named(name))
+		test.That(t, err, test.ShouldBeNil)
+	}
+}
+
+func TestPerResourceConfigurationTimeout(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	model := resource.NewModel("rdk", "test", "hanging")
+	release := make(chan struct{})
+	defer close(release)
+	resource.RegisterComponent(generic.API, model, resource.Registration[resource.Resource,
+		resource.NoNativeConfig]{
+		Constructor: func(
+			ctx context.Context,
+			deps resource.Dependencies,
+			conf resource.Config,
+			logger logging.Logger,
+		) (resource.Resource, error) {
+			if conf.Name == "hanging" {
+				// ignores its context, like a misbehaving driver
+				<-release
+			}
+			return &fooComponent{
+				Named:  conf.ResourceName().AsNamed(),
+				logger: logger,
+			}, nil
+		},
+	})
+	defer func() {
+		resource.Deregister(generic.API, model)
+	}()
+
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{Name: "hanging", API: generic.API, Model: model, ConfigurationTimeout: 100 * time.Millisecond},
+			{Name: "healthy", API: generic.API, Model: model},
+		},
+	}
+	start := time.Now()
+	r := setupLocalRobot(t, ctx, cfg, logger)
+	// the machine wide timeout is a minute, so only the resource's own timeout lets
+	// construction finish this quickly.
+	test.That(t, time.Since(start), test.ShouldBeLessThan, 30*time.Second)
+
+	_, err := r.ResourceByName(generic.Named("healthy"))
+	test.That(t, err, test.ShouldBeNil)
+
+	mStatus, err := r.MachineStatus(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	var found bool
+	for _, status := range mStatus.Resources {
+		if status.Name != generic.Named("hanging") {
+			continue
+		}
+		found = true
+		test.That(t, status.State, test.ShouldEqual, resource.NodeStateUnhealthy)
+		test.That(t, status.Error, test.ShouldNotBeNil)
+		test.That(t, status.Error.Error(), test.ShouldContainSubstring, "timed out after 100ms")
+	}
+	test.That(t, found, test.ShouldBeTrue)
+}
+
+func TestRefreshDependentsOnRecovery(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	model := resource.NewModel("rdk", "test", "recovering")
+	var buildsMu sync.Mutex
+	builds := map[string]int{}
+	resource.RegisterComponent(generic.API, model, resource.Registration[resource.Resource,
+		resource.NoNativeConfig]{
+		Constructor: func(
+			ctx context.Context,
+			deps resource.Dependencies,
+			conf resource.Config,
+			logger logging.Logger,
+		) (resource.Resource, error) {
+			buildsMu.Lock()
+			builds[conf.Name]++
+			buildsMu.Unlock()
+			return &fooComponent{
+				Named:  conf.ResourceName().AsNamed(),
+				logger: logger,
+			}, nil
+		},
+	})
+	defer func() {
+		resource.Deregister(generic.API, model)
+	}()
+	buildsOf := func(name string) int {
+		buildsMu.Lock()
+		defer buildsMu.Unlock()
+		return builds[name]
+	}
+
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{Name: "refreshing", API: generic.API, Model: model, RefreshDependentsOnRecovery: true},
+			{Name: "plain", API: generic.API, Model: model},
+			{Name: "refreshing-dependent", API: generic.API, Model: model, DependsOn: []string{"refreshing"}},
+			{Name: "plain-dependent", API: generic.API, Model: model, DependsOn: []string{"plain"}},
+		},
+	}
+	r := setupLocalRobot(t, ctx, cfg, logger, withDisableCompleteConfigWorker())
+	lr := r.(*localRobot)
+
+	recoverResource := func(name string) {
+		gNode, ok := lr.manager.resources.Node(generic.Named(name))
+		test.That(t, ok, test.ShouldBeTrue)
+		res, err := gNode.UnsafeResource()
+		test.That(t, err, test.ShouldBeNil)
+		gNode.LogAndSetLastError(errors.New("lost connection"))
+		gNode.SwapResource(res, model, nil)
+	}
+	recoverResource("refreshing")
+	recoverResource("plain")
+	lr.updateRemotesAndRetryResourceConfigure()
+
+	test.That(t, buildsOf("refreshing-dependent"), test.ShouldEqual, 2)
+	test.That(t, buildsOf("plain-dependent"), test.ShouldEqual, 1)
+	test.That(t, buildsOf("refreshing"), test.ShouldEqual, 1)
+	_, err := r.ResourceByName(generic.Named("refreshing-dependent"))
+	test.That(t, err, test.ShouldBeNil)
+
+	// each recovery refreshes dependents once
+	lr.updateRemotesAndRetryResourceConfigure()
+	test.That(t, buildsOf("refreshing-dependent"), test.ShouldEqual, 2)
+}
+
+func TestLazyStart(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	model := resource.NewModel("rdk", "test", "lazy")
+	var buildsMu sync.Mutex
+	builds := map[string]int{}
+	resource.RegisterComponent(generic.API, model, resource.Registration[resource.Resource,
+		resource.NoNativeConfig]{
+		Constructor: func(
+			ctx context.Context,
+			deps resource.Dependencies,
+			conf resource.Config,
+			logger logging.Logger,
+		) (resource.Resource, error) {
+			buildsMu.Lock()
+			builds[conf.Name]++
+			buildsMu.Unlock()
+			if conf.Name == "failing" {
+				return nil, errors.New("failed to build")
+			}
+			return &fooComponent{
+				Named:  conf.ResourceName().AsNamed(),
+				logger: logger,
+			}, nil
+		},
+	})
+	defer func() {
+		resource.Deregister(generic.API, model)
+	}()
+	buildsOf := func(name string) int {
+		buildsMu.Lock()
+		defer buildsMu.Unlock()
+		return builds[name]
+	}
+
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{Name: "lazy", API: generic.API, Model: model, LazyStart: true, DependsOn: []string{"lazy-dep"}},
+			{Name: "lazy-dep", API: generic.API, Model: model, LazyStart: true},
+			{Name: "needed", API: generic.API, Model: model, LazyStart: true},
+			{Name: "dependent", API: generic.API, Model: model, DependsOn: []string{"needed"}},
+			{Name: "failing", API: generic.API, Model: model},
+		},
+	}
+	r := setupLocalRobot(t, ctx, cfg, logger, withDisableCompleteConfigWorker())
+	lr := r.(*localRobot)
+
+	// deferred resources are listed but not built
+	test.That(t, buildsOf("lazy"), test.ShouldEqual, 0)
+	test.That(t, r.ResourceNames(), test.ShouldContain, generic.Named("lazy"))
+	_, err := r.ResourceByName(generic.Named("lazy"))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "lazy_start")
+	// retrying the resources that failed to build does not start deferred resources
+	test.That(t, lr.updateRemotesAndRetryResourceConfigure(), test.ShouldBeTrue)
+	test.That(t, buildsOf("lazy"), test.ShouldEqual, 0)
+
+	// resources that depend on a deferred resource start it
+	test.That(t, buildsOf("needed"), test.ShouldEqual, 1)
+	_, err = r.ResourceByName(generic.Named("dependent"))
+	test.That(t, err, test.ShouldBeNil)
+
+	// starting a resource builds the deferred resources it depends on, but does not retry
+	// other resources that failed to build
+	failedBuilds := buildsOf("failing")
+	test.That(t, r.StartResource(ctx, generic.Named("lazy")), test.ShouldBeNil)
+	test.That(t, buildsOf("lazy"), test.ShouldEqual, 1)
+	test.That(t, buildsOf("lazy-dep"), test.ShouldEqual, 1)
+	test.That(t, buildsOf("failing"), test.ShouldEqual, failedBuilds)
+	_, err = r.ResourceByName(generic.Named("lazy"))
+	test.That(t, err, test.ShouldBeNil)
+	_, err = r.ResourceByName(generic.Named("lazy-dep"))
+	test.That(t, err, test.ShouldBeNil)
+
+	// starting a started resource does nothing
+	test.That(t, r.StartResource(ctx, generic.Named("lazy")), test.ShouldBeNil)
+	test.That(t, buildsOf("lazy"), test.ShouldEqual, 1)
+
+	err = r.StartResource(ctx, generic.Named("missing"))
+	test.That(t, resource.IsNotFoundError(err), test.ShouldBeTrue)
+}
+
+func TestResourceNamesByLabels(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{
+				Name:   "front-base",
+				API:    base.API,
+				Model:  fakeModel,
+				Labels: map[string]string{"zone": "front", "safety": "critical"},
+			},
+			{
+				Name:   "front-generic",
+				API:    generic.API,
+				Model:  fakeModel,
+				Labels: map[string]string{"zone": "front"},
+			},
+			{
+				Name:  "back-base",
+				API:   base.API,
+				Model: fakeModel,
+			},
+		},
+	}
+	r := setupLocalRobot(t, ctx, cfg, logger)
+
+	names, err := r.ResourceNamesByLabels("zone=front")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, names, test.ShouldHaveLength, 2)
+	test.That(t, names, test.ShouldContain, base.Named("front-base"))
+	test.That(t, names, test.ShouldContain, generic.Named("front-generic"))
+
+	names, err = r.ResourceNamesByLabels("zone=front,safety=critical")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, names, test.ShouldResemble, []resource.Name{base.Named("front-base")})
+
+	names, err = r.ResourceNamesByLabels("safety!=critical,zone")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, names, test.ShouldResemble, []resource.Name{generic.Named("front-generic")})
+
+	_, err = r.ResourceNamesByLabels("zone=front!")
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// labels follow reconfiguration
+	cfg.Components[2].Labels = map[string]string{"zone": "front"}
+	r.Reconfigure(ctx, cfg)
+	names, err = r.ResourceNamesByLabels("zone=front")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, names, test.ShouldHaveLength, 3)
+}
+
+func TestApplyResourceChanges(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	failingModel := resource.NewModel("rdk", "test", "failing")
+	resource.RegisterComponent(generic.API, failingModel, resource.Registration[resource.Resource, resource.NoNativeConfig]{
+		Constructor: func(
+			ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger,
+		) (resource.Resource, error) {
+			return nil, errors.New("cannot build")
+		},
+	})
+	defer func() {
+		resource.Deregister(generic.API, failingModel)
+	}()
+
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{Name: "removed", API: generic.API, Model: fakeModel},
+			{Name: "updated", API: generic.API, Model: fakeModel},
+		},
+	}
+	r := setupLocalRobot(t, ctx, cfg, logger)
+
+	// an invalid change leaves every resource as is
+	_, err := r.ApplyResourceChanges(ctx,
+		[]resource.Config{{Name: "added", API: base.API, Model: fakeModel}},
+		[]resource.Name{generic.Named("missing")},
+		nil)
+	test.That(t, resource.IsNotFoundError(err), test.ShouldBeTrue)
+	_, err = r.ResourceByName(base.Named("added"))
+	test.That(t, err, test.ShouldNotBeNil)
+
+	_, err = r.ApplyResourceChanges(ctx,
+		[]resource.Config{{Name: "added", API: base.API, Model: fakeModel}},
+		nil,
+		[]resource.Config{{Name: "added", API: base.API, Model: fakeModel}})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "more than once")
+
+	_, err = r.ApplyResourceChanges(ctx,
+		[]resource.Config{{Name: "updated", API: generic.API, Model: fakeModel}},
+		nil, nil)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "already exists")
+
+	resErrs, err := r.ApplyResourceChanges(ctx,
+		[]resource.Config{
+			{Name: "added", API: base.API, Model: fakeModel},
+			{Name: "broken", API: generic.API, Model: failingModel},
+		},
+		[]resource.Name{generic.Named("removed")},
+		[]resource.Config{{
+			Name: "updated", API: generic.API, Model: fakeModel,
+			Labels: map[string]string{"zone": "front"},
+		}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resErrs, test.ShouldHaveLength, 1)
+	test.That(t, resErrs[generic.Named("broken")], test.ShouldNotBeNil)
+	test.That(t, resErrs[generic.Named("broken")].Error(), test.ShouldContainSubstring, "cannot build")
+
+	_, err = r.ResourceByName(base.Named("added"))
+	test.That(t, err, test.ShouldBeNil)
+	_, err = r.ResourceByName(generic.Named("removed"))
+	test.That(t, err, test.ShouldNotBeNil)
+	names, err := r.ResourceNamesByLabels("zone=front")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, names, test.ShouldResemble, []resource.Name{generic.Named("updated")})
+
+	// the changes outlive the config being delivered again, as a config watcher does every poll
+	r.Reconfigure(ctx, &config.Config{
+		Components: []resource.Config{
+			{Name: "removed", API: generic.API, Model: fakeModel},
+			{Name: "updated", API: generic.API, Model: fakeModel},
+		},
+	})
+	_, err = r.ResourceByName(base.Named("added"))
+	test.That(t, err, test.ShouldBeNil)
+	_, err = r.ResourceByName(generic.Named("removed"))
+	test.That(t, err, test.ShouldNotBeNil)
+	names, err = r.ResourceNamesByLabels("zone=front")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, names, test.ShouldResemble, []resource.Name{generic.Named("updated")})
+
+	// a dry run of the same config accounts for the changes too, and does not drop them
+	report, err := r.DryRunReconfigure(ctx, &config.Config{
+		Components: []resource.Config{
+			{Name: "removed", API: generic.API, Model: fakeModel},
+			{Name: "updated", API: generic.API, Model: fakeModel},
+		},
+	})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, report.Removed, test.ShouldBeEmpty)
+	test.That(t, report.Added, test.ShouldBeEmpty)
+	test.That(t, report.Modified, test.ShouldBeEmpty)
+	_, err = r.ResourceByName(base.Named("added"))
+	test.That(t, err, test.ShouldBeNil)
+
+	t.Run("client", func(t *testing.T) {
+		options, _, addr := robottestutils.CreateBaseOptionsAndListener(t)
+		test.That(t, r.StartWeb(ctx, options), test.ShouldBeNil)
+		defer r.StopWeb()
+		robotClient, err := client.New(ctx, addr, logger.Sublogger("client"))
+		test.That(t, err, test.ShouldBeNil)
+		defer robotClient.Close(ctx)
+
+		// attributes of builtin models are converted by the robot
+		resErrs, err := robotClient.ApplyResourceChanges(ctx,
+			[]resource.Config{{
+				Name: "remote_added", API: base.API, Model: fakeModel,
+				Attributes: rutils.AttributeMap{},
+			}},
+			[]resource.Name{generic.Named("broken")},
+			nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resErrs, test.ShouldBeEmpty)
+		_, err = r.ResourceByName(base.Named("remote_added"))
+		test.That(t, err, test.ShouldBeNil)
+		_, err = r.ResourceByName(generic.Named("broken"))
+		test.That(t, err, test.ShouldNotBeNil)
+
+		_, err = robotClient.ApplyResourceChanges(ctx, nil, []resource.Name{generic.Named("missing")}, nil)
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
+
+func TestResourcePanicIsolation(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	model := resource.NewModel("rdk", "test", "crashy")
+	var buildsMu sync.Mutex
+	builds := map[string]int{}
+	resource.RegisterComponent(generic.API, model, resource.Registration[resource.Resource,
+		resource.NoNativeConfig]{
+		Constructor: func(
+			ctx context.Context,
+			deps resource.Dependencies,
+			conf resource.Config,
+			logger logging.Logger,
+		) (resource.Resource, error) {
+			buildsMu.Lock()
+			builds[conf.Name]++
+			buildsMu.Unlock()
+			if conf.Attributes.Bool("panic", false) {
+				panic("driver bug")
+			}
+			return &fooComponent{
+				Named:  conf.ResourceName().AsNamed(),
+				logger: logger,
+			}, nil
+		},
+	})
+	defer func() {
+		resource.Deregister(generic.API, model)
+	}()
+	buildsOf := func(name string) int {
+		buildsMu.Lock()
+		defer buildsMu.Unlock()
+		return builds[name]
+	}
+
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{Name: "crashy", API: generic.API, Model: model, Attributes: rutils.AttributeMap{"panic": true}},
+			{Name: "fine", API: generic.API, Model: model},
+		},
+	}
+	r := setupLocalRobot(t, ctx, cfg, logger, withDisableCompleteConfigWorker())
+	lr := r.(*localRobot)
+
+	// the rest of the machine keeps running
+	_, err := r.ResourceByName(generic.Named("fine"))
+	test.That(t, err, test.ShouldBeNil)
+	_, err = r.ResourceByName(generic.Named("crashy"))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "panic creating resource: driver bug")
+
+	mStatus, err := r.MachineStatus(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	for _, resStatus := range mStatus.Resources {
+		switch resStatus.Name {
+		case generic.Named("crashy"):
+			test.That(t, resStatus.State, test.ShouldEqual, resource.NodeStateUnhealthy)
+			test.That(t, resStatus.Configure.Builds.Crashes, test.ShouldEqual, 1)
+		case generic.Named("fine"):
+			test.That(t, resStatus.Configure.Crashes(), test.ShouldEqual, 0)
+		}
+	}
+
+	// a resource that crashed is not retried until its backoff passes
+	lr.updateRemotesAndRetryResourceConfigure()
+	test.That(t, buildsOf("crashy"), test.ShouldEqual, 1)
+
+	// but it is with a new config
+	r.Reconfigure(ctx, &config.Config{
+		Components: []resource.Config{
+			{Name: "crashy", API: generic.API, Model: model},
+			{Name: "fine", API: generic.API, Model: model},
+		},
+	})
+	test.That(t, buildsOf("crashy"), test.ShouldEqual, 2)
+	_, err = r.ResourceByName(generic.Named("crashy"))
+	test.That(t, err, test.ShouldBeNil)
+}
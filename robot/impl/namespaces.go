@@ -0,0 +1,199 @@
+package robotimpl
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"go.viam.com/utils/rpc"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/robot"
+)
+
+var namespaceRegexp = regexp.MustCompile(`^[\w-]+$`)
+
+// Namespaces hosts several independent robots in one process, for programs that embed the
+// RDK to serve many logical machines. Each namespace has its own resource graph, module
+// manager, and logger, named after the namespace, and keeps its FTDC data, module data and
+// config history in their own directories. The FTDC sections of each namespace are prefixed
+// with the namespace, as in "tenant-1.web". Namespaces still share the process-wide resource
+// registry.
+//
+// The loggers of all namespaces share one logging registry, so the secrets resolved for the
+// configs of every namespace are masked in the logs of all of them.
+//
+// Each namespace serves its own web service once started. Namespaces is also an http.Handler
+// that mounts the web service of each started namespace under "/<namespace>/", for gRPC-Web
+// and REST clients. Native gRPC clients cannot dial a path, so they must use the address of
+// the namespace's own web service.
+type Namespaces struct {
+	logger   logging.Logger
+	registry *logging.Registry
+	opts     []Option
+
+	mu sync.Mutex
+	// robots holds nil for namespaces that are still being created.
+	robots map[string]robot.LocalRobot
+	// secrets holds the secrets resolved for the current config of each namespace.
+	secrets map[string][]string
+}
+
+// NewNamespaces returns an empty Namespaces whose robots log to subloggers of logger and are
+// created with opts, in addition to any options given to Create. registry is the registry of
+// logger, and may be nil if resolved secrets should not be masked in logs.
+func NewNamespaces(logger logging.Logger, registry *logging.Registry, opts ...Option) *Namespaces {
+	return &Namespaces{
+		logger:   logger,
+		registry: registry,
+		opts:     opts,
+		robots:   map[string]robot.LocalRobot{},
+		secrets:  map[string][]string{},
+	}
+}
+
+// Create constructs a robot from cfg in a new namespace.
+func (ns *Namespaces) Create(
+	ctx context.Context,
+	name string,
+	cfg *config.Config,
+	conn rpc.ClientConn,
+	opts ...Option,
+) (robot.LocalRobot, error) {
+	if !namespaceRegexp.MatchString(name) {
+		return nil, errors.Errorf("namespace %q must only contain letters, numbers, underscores and hyphens", name)
+	}
+	ns.mu.Lock()
+	if _, ok := ns.robots[name]; ok {
+		ns.mu.Unlock()
+		return nil, errors.Errorf("namespace %q already exists", name)
+	}
+	// reserve the name so that the robot can be constructed without holding the lock
+	ns.robots[name] = nil
+	ns.mu.Unlock()
+
+	rOpts := slices.Clone(ns.opts)
+	rOpts = append(rOpts, opts...)
+	rOpts = append(rOpts, withNamespace(name), withRedactedValues(func(values []string) {
+		ns.setSecrets(name, values)
+	}))
+	r, err := New(ctx, cfg, conn, ns.logger.Sublogger(name), rOpts...)
+
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	if err != nil {
+		delete(ns.robots, name)
+		ns.updateRedactedValues(name, nil)
+		return nil, errors.Wrapf(err, "failed to create namespace %q", name)
+	}
+	ns.robots[name] = r
+	return r, nil
+}
+
+// Robot returns the robot in the given namespace.
+func (ns *Namespaces) Robot(name string) (robot.LocalRobot, bool) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	r, ok := ns.robots[name]
+	return r, ok && r != nil
+}
+
+// Names returns the names of all namespaces, in order.
+func (ns *Namespaces) Names() []string {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	names := make([]string, 0, len(ns.robots))
+	for name, r := range ns.robots {
+		if r != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ServeHTTP serves a request to "/<namespace>/<path>" with the web service of the namespace,
+// as a request to "/<path>". It responds with 404 if the namespace does not exist and with 503
+// if its web service is not started.
+func (ns *Namespaces) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	rbt, ok := ns.Robot(name)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	lr, ok := rbt.(*localRobot)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	handler := lr.webSvc.Handler()
+	if handler == nil {
+		http.Error(w, "web service of namespace "+name+" is not started", http.StatusServiceUnavailable)
+		return
+	}
+	http.StripPrefix("/"+name, handler).ServeHTTP(w, r)
+}
+
+// Destroy closes the robot in the given namespace and removes the namespace.
+func (ns *Namespaces) Destroy(ctx context.Context, name string) error {
+	ns.mu.Lock()
+	r, ok := ns.robots[name]
+	if !ok || r == nil {
+		ns.mu.Unlock()
+		return errors.Errorf("namespace %q does not exist", name)
+	}
+	delete(ns.robots, name)
+	ns.mu.Unlock()
+	err := r.Close(ctx)
+
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	ns.updateRedactedValues(name, nil)
+	return err
+}
+
+// Close closes the robots in all namespaces and removes them.
+func (ns *Namespaces) Close(ctx context.Context) error {
+	var err error
+	for _, name := range ns.Names() {
+		err = multierr.Combine(err, ns.Destroy(ctx, name))
+	}
+	return err
+}
+
+// setSecrets records the secrets resolved for the config that the named namespace is applying.
+func (ns *Namespaces) setSecrets(name string, values []string) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	if _, ok := ns.robots[name]; !ok {
+		// the namespace was destroyed while it was reconfiguring
+		return
+	}
+	ns.updateRedactedValues(name, values)
+}
+
+// updateRedactedValues replaces the secrets of the named namespace, removing them if values is
+// nil, and masks the secrets of all namespaces in logs. Must be called with mu held.
+func (ns *Namespaces) updateRedactedValues(name string, values []string) {
+	if values == nil {
+		delete(ns.secrets, name)
+	} else {
+		ns.secrets[name] = values
+	}
+	if ns.registry == nil {
+		return
+	}
+	var all []string
+	for _, secrets := range ns.secrets {
+		all = append(all, secrets...)
+	}
+	ns.registry.SetRedactedValues(all)
+}
@@ -0,0 +1,118 @@
+package robotimpl
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/arm/fake"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/testutils/robottestutils"
+)
+
+func TestNamespaces(t *testing.T) {
+	ctx := context.Background()
+	logger, logs, registry := logging.NewObservedTestLoggerWithRegistry(t, "namespaces")
+	ns := NewNamespaces(logger, registry, WithViamHomeDir(t.TempDir()))
+	defer func() {
+		test.That(t, ns.Close(ctx), test.ShouldBeNil)
+	}()
+
+	armConfig := func(name string) *config.Config {
+		return &config.Config{
+			Components: []resource.Config{{
+				Name:                name,
+				API:                 arm.API,
+				Model:               fakeModel,
+				ConvertedAttributes: &fake.Config{ModelFilePath: "../../components/arm/fake/kinematics/fake.json"},
+			}},
+		}
+	}
+	r1, err := ns.Create(ctx, "tenant-1", armConfig("arm1"), nil)
+	test.That(t, err, test.ShouldBeNil)
+	r2, err := ns.Create(ctx, "tenant-2", armConfig("arm2"), nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, ns.Names(), test.ShouldResemble, []string{"tenant-1", "tenant-2"})
+
+	// each namespace only sees its own resources
+	_, err = r1.ResourceByName(arm.Named("arm1"))
+	test.That(t, err, test.ShouldBeNil)
+	_, err = r1.ResourceByName(arm.Named("arm2"))
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = r2.ResourceByName(arm.Named("arm2"))
+	test.That(t, err, test.ShouldBeNil)
+	_, err = r2.ResourceByName(arm.Named("arm1"))
+	test.That(t, err, test.ShouldNotBeNil)
+
+	_, err = ns.Create(ctx, "tenant-1", armConfig("arm3"), nil)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "already exists")
+	_, err = ns.Create(ctx, "../tenant", armConfig("arm3"), nil)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// the secrets of every namespace are masked until the namespace is destroyed
+	ns.setSecrets("tenant-1", []string{"tenant-1-secret"})
+	ns.setSecrets("tenant-2", []string{"tenant-2-secret"})
+	logger.Info("tenant-1-secret tenant-2-secret")
+	test.That(t, logs.All()[logs.Len()-1].Message, test.ShouldEqual, "****** ******")
+
+	test.That(t, ns.Destroy(ctx, "tenant-1"), test.ShouldBeNil)
+	logger.Info("tenant-1-secret tenant-2-secret")
+	test.That(t, logs.All()[logs.Len()-1].Message, test.ShouldEqual, "tenant-1-secret ******")
+	_, ok := ns.Robot("tenant-1")
+	test.That(t, ok, test.ShouldBeFalse)
+	test.That(t, ns.Names(), test.ShouldResemble, []string{"tenant-2"})
+	test.That(t, ns.Destroy(ctx, "tenant-1"), test.ShouldNotBeNil)
+
+	got, ok := ns.Robot("tenant-2")
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, got, test.ShouldEqual, r2)
+}
+
+func TestNamespacesServeHTTP(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	ns := NewNamespaces(logger, nil, WithViamHomeDir(t.TempDir()))
+	defer func() {
+		test.That(t, ns.Close(ctx), test.ShouldBeNil)
+	}()
+
+	r1, err := ns.Create(ctx, "tenant-1", &config.Config{}, nil)
+	test.That(t, err, test.ShouldBeNil)
+	_, err = ns.Create(ctx, "tenant-2", &config.Config{}, nil)
+	test.That(t, err, test.ShouldBeNil)
+	options, _, _ := robottestutils.CreateBaseOptionsAndListener(t)
+	test.That(t, r1.StartWeb(ctx, options), test.ShouldBeNil)
+
+	server := httptest.NewServer(ns)
+	defer server.Close()
+	get := func(path string) (int, string) {
+		resp, err := http.Get(server.URL + path)
+		test.That(t, err, test.ShouldBeNil)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		test.That(t, err, test.ShouldBeNil)
+		return resp.StatusCode, string(body)
+	}
+
+	code, body := get("/tenant-1/")
+	test.That(t, code, test.ShouldEqual, http.StatusOK)
+	test.That(t, body, test.ShouldEqual, "healthy")
+
+	code, _ = get("/tenant-2/")
+	test.That(t, code, test.ShouldEqual, http.StatusServiceUnavailable)
+
+	code, _ = get("/tenant-3/")
+	test.That(t, code, test.ShouldEqual, http.StatusNotFound)
+
+	r1.StopWeb()
+	code, _ = get("/tenant-1/")
+	test.That(t, code, test.ShouldEqual, http.StatusServiceUnavailable)
+}
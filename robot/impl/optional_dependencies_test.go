@@ -1007,3 +1007,112 @@ func TestModularOptionalDependenciesCycles(t *testing.T) {
 		test.That(t, doCommandResp, test.ShouldResemble, map[string]any{"other_moc_state": "unset"})
 	}
 }
+
+// An optionalWatcher is an optionalChild that is told about changes to its optional dependency
+// instead of being reconfigured with them.
+type optionalWatcher struct {
+	optionalChild
+
+	added   []resource.Name
+	removed []resource.Name
+}
+
+func (ow *optionalWatcher) OptionalDependenciesChanged(
+	ctx context.Context,
+	added resource.Dependencies,
+	removed []resource.Name,
+) error {
+	for name, res := range added {
+		ow.added = append(ow.added, name)
+		if m, ok := res.(motor.Motor); ok {
+			ow.optionalMotor = m
+		}
+	}
+	for _, name := range removed {
+		ow.removed = append(ow.removed, name)
+		if ow.optionalMotor != nil && ow.optionalMotor.Name() == name {
+			ow.optionalMotor = nil
+		}
+	}
+	return nil
+}
+
+func TestOptionalDependencyWatcher(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+
+	lr := setupLocalRobot(t, ctx, &config.Config{}, logger)
+
+	watcherModel := resource.DefaultModelFamily.WithModel(utils.RandomAlphaString(5))
+	owName := generic.Named("ow")
+	resource.Register(
+		generic.API,
+		watcherModel,
+		resource.Registration[*optionalWatcher, *optionalChildConfig]{
+			Constructor: func(
+				ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger,
+			) (*optionalWatcher, error) {
+				oc, err := newOptionalChild(ctx, deps, conf, logger)
+				if err != nil {
+					return nil, err
+				}
+				return &optionalWatcher{optionalChild: *oc}, nil
+			},
+		})
+	defer resource.Deregister(generic.API, watcherModel)
+
+	owConf := resource.Config{
+		Name:  owName.Name,
+		API:   generic.API,
+		Model: watcherModel,
+		ConvertedAttributes: &optionalChildConfig{
+			RequiredMotor: "m",
+			OptionalMotor: "m1",
+		},
+	}
+	motorConf := func(name string) resource.Config {
+		return resource.Config{
+			Name:                name,
+			API:                 motor.API,
+			Model:               fake.Model,
+			ConvertedAttributes: &fake.Config{},
+		}
+	}
+	watcher := func() *optionalWatcher {
+		res, err := lr.ResourceByName(owName)
+		test.That(t, err, test.ShouldBeNil)
+		ow, err := resource.AsType[*optionalWatcher](res)
+		test.That(t, err, test.ShouldBeNil)
+		return ow
+	}
+
+	cfg := config.Config{Components: []resource.Config{owConf, motorConf("m")}}
+	test.That(t, cfg.Ensure(false, logger), test.ShouldBeNil)
+	lr.Reconfigure(ctx, &cfg)
+
+	// the missing optional dependency does not block construction, and nothing changed since
+	ow := watcher()
+	test.That(t, ow.reconfigCount, test.ShouldEqual, 1)
+	test.That(t, ow.optionalMotor, test.ShouldBeNil)
+	test.That(t, ow.added, test.ShouldBeEmpty)
+
+	// the optional dependency is passed to the callback when it appears, without reconfiguring
+	cfg = config.Config{Components: []resource.Config{owConf, motorConf("m"), motorConf("m1")}}
+	test.That(t, cfg.Ensure(false, logger), test.ShouldBeNil)
+	lr.Reconfigure(ctx, &cfg)
+
+	ow = watcher()
+	test.That(t, ow.reconfigCount, test.ShouldEqual, 1)
+	test.That(t, ow.added, test.ShouldResemble, []resource.Name{motor.Named("m1")})
+	test.That(t, ow.optionalMotor, test.ShouldNotBeNil)
+
+	// and its name when it goes away
+	cfg = config.Config{Components: []resource.Config{owConf, motorConf("m")}}
+	test.That(t, cfg.Ensure(false, logger), test.ShouldBeNil)
+	lr.Reconfigure(ctx, &cfg)
+
+	ow = watcher()
+	test.That(t, ow.reconfigCount, test.ShouldEqual, 1)
+	test.That(t, ow.removed, test.ShouldResemble, []resource.Name{motor.Named("m1")})
+	test.That(t, ow.optionalMotor, test.ShouldBeNil)
+}
@@ -7,6 +7,9 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"runtime/debug"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -75,6 +78,10 @@ type resourceManager struct {
 	opts           resourceManagerOptions
 	logger         logging.Logger
 
+	// buildsInFlight holds the names of resources that are being (re)configured, including
+	// those whose (re)configuration timed out but has not returned yet.
+	buildsInFlight sync.Map
+
 	viz resource.Visualizer
 }
 
@@ -373,7 +380,7 @@ func (manager *resourceManager) anyResourcesNotConfigured() bool {
 		if !ok {
 			continue
 		}
-		if res.NeedsReconfigure() {
+		if res.NeedsReconfigure() && !res.Deferred() {
 			return true
 		}
 	}
@@ -404,6 +411,18 @@ func (manager *resourceManager) ResourceNames() []resource.Name {
 	return names
 }
 
+// resourceNamesByLabels returns the names of the resources that ResourceNames would return whose
+// config labels match the given selector.
+func (manager *resourceManager) resourceNamesByLabels(selector resource.LabelSelector) []resource.Name {
+	names := []resource.Name{}
+	for _, k := range manager.resources.FindNodesByLabels(selector) {
+		if manager.resourceName(k) {
+			names = append(names, k)
+		}
+	}
+	return names
+}
+
 // reachableResourceNames returns the names of all resources in the manager, excluding the following types of resources:
 // - Resources that represent entire remote machines.
 // - Resources that are considered internal to viam-server that cannot be removed via configuration.
@@ -423,13 +442,14 @@ func (manager *resourceManager) reachableResourceNames() []resource.Name {
 // - The resource is not stored in the resource manager.
 // - The resource represents an entire remote machine.
 // - The resource is considered internal to viam-server, meaning it cannot be removed via configuration.
+// - The resource is not built, unless it is waiting on lazy_start so that clients can access it to start it.
 func (manager *resourceManager) resourceName(k resource.Name) bool {
 	if k.API == client.RemoteAPI ||
 		k.API.Type.Namespace == resource.APINamespaceRDKInternal {
 		return false
 	}
 	gNode, ok := manager.resources.Node(k)
-	if !ok || !gNode.HasResource() {
+	if !ok || !(gNode.HasResource() || gNode.Deferred()) {
 		return false
 	}
 	return true
@@ -659,194 +679,386 @@ func (manager *resourceManager) completeConfig(
 	}
 
 	// sort resources into topological "levels" based on their dependencies. resources in
-	// any given level only depend on resources in prior levels. this makes it safe to
-	// process resources within a level concurrently as long as levels are processed in
-	// order.
+	// any given level only depend on resources in prior levels.
 	levels := manager.resources.ReverseTopologicalSortInLevels()
 	timeout := rutils.GetResourceConfigurationTimeout(manager.logger)
-	for _, resourceNames := range levels {
-		// At the start of every reconfiguration level, check if
-		// updateWeakAndOptionalDependents should be run by checking if the logical clock is
-		// higher than the `lastWeakAndOptionalDependentsRound` value.
-		//
-		// This will make sure that weak and optional dependents are updated before they are
-		// passed into constructors or reconfigure methods.
-		//
-		// Resources that depend on weak or optional dependents should expect that the
-		// weak/optional dependents passed into the constructor or reconfigure method will
-		// only have been reconfigured with all resources constructed before their level.
-		for _, resName := range resourceNames {
+	manager.startDeferredDependencies(levels)
+
+	// every resource is (re)configured as soon as the resources it depends on are done, so
+	// that a slow resource only holds up the resources that depend on it rather than the
+	// whole next level. within a level, resources are split by reconfigure priority so that
+	// users can order resources that do not depend on each other: resources of a higher
+	// priority are done before any resource of a lower priority starts.
+	done := make(map[resource.Name]chan struct{})
+	for _, level := range levels {
+		for _, resName := range level {
+			done[resName] = make(chan struct{})
+		}
+	}
+	// workers limits how many resources are (re)configured at once. We've observed adding
+	// resources in batches instead of all at once to be more reliable when there are a
+	// large number of resources to add (e.g. hundreds).
+	workers := make(chan struct{}, rutils.GetResourceConfigurationConcurrency(manager.logger))
+	// weak and optional dependents are updated while no resource is being (re)configured.
+	var weakDepsUpdate sync.RWMutex
+	// resources with a maximum instance count are (re)configured one at a time.
+	var maxInstanceMu sync.Mutex
+	var wg sync.WaitGroup
+
+	configure := func(resName resource.Name, waitFor []resource.Name, updateWeakDeps, syncRes bool) {
+		defer close(done[resName])
+		for _, dep := range waitFor {
+			depDone, ok := done[dep]
+			if !ok {
+				continue
+			}
 			select {
+			case <-depDone:
 			case <-ctx.Done():
 				return
-			default:
-			}
-			gNode, ok := manager.resources.Node(resName)
-			if !ok || !gNode.NeedsReconfigure() {
-				continue
-			}
-			if !(resName.API.IsComponent() || resName.API.IsService()) {
-				continue
 			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		gNode, ok := manager.resources.Node(resName)
+		if !ok || !gNode.NeedsReconfigure() || gNode.Deferred() {
+			return
+		}
+		if !(resName.API.IsComponent() || resName.API.IsService()) {
+			return
+		}
+		if backoff := gNode.CrashBackoff(); backoff > 0 {
+			manager.logger.CDebugw(ctx, "waiting to retry resource that panicked while being (re)configured",
+				"resource", resName, "retry_in", backoff)
+			return
+		}
 
+		// Resources that depend on weak or optional dependents should expect that the
+		// weak/optional dependents passed into the constructor or reconfigure method will
+		// only have been reconfigured with all resources constructed before their level.
+		if updateWeakDeps {
+			weakDepsUpdate.Lock()
 			if lr.lastWeakAndOptionalDependentsRound.Load() < manager.resources.CurrLogicalClockValue() {
 				lr.updateWeakAndOptionalDependents(ctx)
 			}
+			weakDepsUpdate.Unlock()
 		}
-		// we use an errgroup here instead of a normal waitgroup to conveniently bubble
-		// up errors in resource processing goroutinues that warrant an early exit.
-		var levelErrG errgroup.Group
-		// Add resources in batches instead of all at once. We've observed this to be more
-		// reliable when there are a large number of resources to add (e.g. hundreds).
-		levelErrG.SetLimit(10)
-		for _, resName := range resourceNames {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-			}
-			// processResource is intended to be run concurrently for each resource
-			// within a topological sort level. if any processResource function returns a
-			// non-nil error then the entire `completeConfig` function will exit early.
-			//
-			// currently only a top-level context cancellation will result in an early
-			// exist - individual resource processing failures will not.
-			processResource := func() error {
-				resChan := make(chan struct{}, 1)
-				ctxWithTimeout, timeoutCancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
-				defer timeoutCancel()
-
-				stopSlowLogger := rutils.SlowLogger(
-					ctx, "Waiting for resource to complete (re)configuration", "resource", resName.String(), manager.logger)
 
+		select {
+		case workers <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		defer func() { <-workers }()
+		if syncRes {
+			maxInstanceMu.Lock()
+			defer maxInstanceMu.Unlock()
+		}
+		weakDepsUpdate.RLock()
+		defer weakDepsUpdate.RUnlock()
+		manager.configureResource(ctx, lr, resName, gNode, timeout)
+	}
+
+	var priorLevels []resource.Name
+	for _, level := range levels {
+		var higherPriority []resource.Name
+		for _, group := range manager.splitLevelsByPriority([][]resource.Name{level}) {
+			for _, resName := range group {
+				parents := manager.resources.GetAllParentsOf(resName)
+				waitFor := append(slices.Clone(parents), higherPriority...)
+				// the weak and optional dependencies of a resource are only known once every
+				// resource before its level is done, so such resources, and those that depend
+				// on them, wait for all prior levels.
+				updateWeakDeps := manager.hasWeakOrOptionalDependencies(lr, resName)
+				for _, parent := range parents {
+					updateWeakDeps = updateWeakDeps || manager.hasWeakOrOptionalDependencies(lr, parent)
+				}
+				if updateWeakDeps {
+					waitFor = append(slices.Clone(priorLevels), higherPriority...)
+				}
+				// modular resources get their dependencies through the web service, which
+				// only serves them once it is updated as a weak dependent.
+				if gNode, ok := manager.resources.Node(resName); ok && manager.moduleManager != nil &&
+					manager.moduleManager.Provides(gNode.Config()) {
+					updateWeakDeps = true
+				}
+
+				syncRes := forceSync
+				if !syncRes {
+					// TODO(RSDK-6925): support concurrent processing of resources of
+					// APIs with a maximum instance limit. Currently this limit is
+					// validated later in the resource creation flow and assumes that
+					// each resource is created synchronously to have an accurate
+					// creation count.
+					if c, ok := resource.LookupGenericAPIRegistration(resName.API); ok && c.MaxInstance != 0 {
+						syncRes = true
+					}
+				}
+
+				if forceSync {
+					// everything scheduled before this resource is already done.
+					configure(resName, waitFor, updateWeakDeps, syncRes)
+					continue
+				}
+				wg.Add(1)
 				lr.reconfigureWorkers.Add(1)
 				goutils.PanicCapturingGo(func() {
 					defer func() {
-						stopSlowLogger()
-						resChan <- struct{}{}
 						lr.reconfigureWorkers.Done()
+						wg.Done()
 					}()
-					gNode, ok := manager.resources.Node(resName)
-					if !ok || !gNode.NeedsReconfigure() {
-						return
-					}
-					if !(resName.API.IsComponent() || resName.API.IsService()) {
-						return
-					}
-
-					var prefix string
-					conf := gNode.Config()
-					if gNode.IsUninitialized() {
-						gNode.InitializeLogger(
-							manager.logger, resName.String(),
-						)
-					} else {
-						prefix = "re"
-					}
-					manager.logger.CInfow(ctx, fmt.Sprintf("Now %sconfiguring resource", prefix), "resource", resName, "model", conf.Model)
-
-					// The config was already validated, but we must check again before attempting
-					// to add.
-					if _, _, err := conf.Validate("", resName.API.Type.Name); err != nil {
-						gNode.LogAndSetLastError(
-							fmt.Errorf("resource config validation error: %w", err),
-							"resource", conf.ResourceName(),
-							"model", conf.Model)
-						return
-					}
-					if manager.moduleManager.Provides(conf) {
-						if _, _, err := manager.moduleManager.ValidateConfig(ctxWithTimeout, conf); err != nil {
-							gNode.LogAndSetLastError(
-								fmt.Errorf("modular resource config validation error: %w", err),
-								"resource", conf.ResourceName(),
-								"model", conf.Model)
-							return
-						}
-					}
-
-					switch {
-					case resName.API.IsComponent(), resName.API.IsService():
-
-						newRes, newlyBuilt, err := manager.processResource(ctxWithTimeout, conf, gNode, lr)
-						if newlyBuilt || err != nil {
-							if err := manager.markChildrenForUpdate(resName); err != nil {
-								manager.logger.CErrorw(ctx,
-									"failed to mark children of resource for update",
-									"resource", resName,
-									"reason", err)
-							}
-						}
-
-						if err != nil {
-							gNode.LogAndSetLastError(
-								fmt.Errorf("resource build error: %w", err),
-								"resource", conf.ResourceName(),
-								"model", conf.Model)
-							return
-						}
-
-						// if the ctxWithTimeout fails with DeadlineExceeded, then that means that
-						// resource generation is running async, and we don't currently have good
-						// validation around how this might affect the resource graph. So, we avoid
-						// updating the graph to be safe.
-						if errors.Is(ctxWithTimeout.Err(), context.DeadlineExceeded) {
-							manager.logger.CErrorw(
-								ctx, "error building resource", "resource", conf.ResourceName(), "model", conf.Model, "error", ctxWithTimeout.Err())
-						} else {
-							gNode.SwapResource(newRes, conf.Model, manager.opts.ftdc)
-							manager.logger.CInfow(ctx, fmt.Sprintf("Successfully %sconfigured resource", prefix), "resource", resName, "model", conf.Model)
-						}
-
-					default:
-						err := errors.New("config is not for a component or service")
-						gNode.LogAndSetLastError(err, "resource", resName)
-					}
+					configure(resName, waitFor, updateWeakDeps, syncRes)
 				})
+			}
+			higherPriority = append(higherPriority, group...)
+		}
+		priorLevels = append(priorLevels, level...)
+	}
+	wg.Wait()
+}
 
-				select {
-				case <-resChan:
-				case <-ctxWithTimeout.Done():
-					// this resource is taking too long to process, so we give up but
-					// continue processing other resources. we do not wait for this
-					// resource to finish processing since it may be running outside code
-					// and have unexpected behavior.
-					if errors.Is(ctxWithTimeout.Err(), context.DeadlineExceeded) {
-						lr.logger.CWarn(ctx, rutils.NewBuildTimeoutError(resName.String(), lr.logger))
-					}
-				case <-ctx.Done():
-					return ctx.Err()
+// startDeferredDependencies asks the deferred resources that a resource about to be
+// (re)configured depends on to start, since it cannot be built without them. levels must be in
+// dependency order, as ReverseTopologicalSortInLevels returns them, so that dependents are
+// visited before the deferred resources they start, and those in turn start their own.
+func (manager *resourceManager) startDeferredDependencies(levels [][]resource.Name) {
+	for i := len(levels) - 1; i >= 0; i-- {
+		for _, resName := range levels[i] {
+			gNode, ok := manager.resources.Node(resName)
+			if !ok || !gNode.NeedsReconfigure() || gNode.Deferred() {
+				continue
+			}
+			for _, parent := range manager.resources.GetAllParentsOf(resName) {
+				if parentNode, ok := manager.resources.Node(parent); ok && parentNode.RequestStart() {
+					manager.logger.Infow("starting lazily started resource needed by a dependent",
+						"resource", parent, "dependent", resName)
 				}
-				return nil
 			}
+		}
+	}
+}
 
-			syncRes := forceSync
-			if !syncRes {
-				// TODO(RSDK-6925): support concurrent processing of resources of
-				// APIs with a maximum instance limit. Currently this limit is
-				// validated later in the resource creation flow and assumes that
-				// each resource is created synchronously to have an accurate
-				// creation count.
-				if c, ok := resource.LookupGenericAPIRegistration(resName.API); ok && c.MaxInstance != 0 {
-					syncRes = true
-				}
+// startResource builds a deferred resource along with the deferred resources it depends on,
+// leaving the rest of the graph alone. It returns whether the resource was deferred.
+func (manager *resourceManager) startResource(ctx context.Context, lr *localRobot, name resource.Name) bool {
+	gNode, ok := manager.resources.Node(name)
+	if !ok || !gNode.RequestStart() {
+		return false
+	}
+	// build the deferred dependencies first, in dependency order.
+	var toStart []resource.Name
+	visited := map[resource.Name]bool{name: true}
+	var visit func(resName resource.Name)
+	visit = func(resName resource.Name) {
+		for _, parent := range manager.resources.GetAllParentsOf(resName) {
+			if visited[parent] {
+				continue
+			}
+			visited[parent] = true
+			if parentNode, ok := manager.resources.Node(parent); ok && parentNode.RequestStart() {
+				manager.logger.CInfow(ctx, "starting lazily started resource needed by a dependent",
+					"resource", parent, "dependent", resName)
+				visit(parent)
+				toStart = append(toStart, parent)
+			}
+		}
+	}
+	visit(name)
+	toStart = append(toStart, name)
+
+	timeout := rutils.GetResourceConfigurationTimeout(manager.logger)
+	for _, resName := range toStart {
+		if ctx.Err() != nil {
+			break
+		}
+		resNode, ok := manager.resources.Node(resName)
+		if !ok || !resNode.NeedsReconfigure() {
+			continue
+		}
+		manager.configureResource(ctx, lr, resName, resNode, timeout)
+	}
+	return true
+}
+
+// hasWeakOrOptionalDependencies returns whether the resource has weak or optional
+// dependencies, which are passed to it in addition to those in the resource graph.
+func (manager *resourceManager) hasWeakOrOptionalDependencies(lr *localRobot, resName resource.Name) bool {
+	gNode, ok := manager.resources.Node(resName)
+	if !ok {
+		return false
+	}
+	conf := gNode.Config()
+	return len(conf.ImplicitOptionalDependsOn) != 0 || len(lr.getWeakDependencyMatchers(conf.API, conf.Model)) != 0
+}
+
+// configureResource builds or reconfigures a resource that needs it, giving up on it
+// once the resource configuration timeout passes.
+func (manager *resourceManager) configureResource(
+	ctx context.Context,
+	lr *localRobot,
+	resName resource.Name,
+	gNode *resource.GraphNode,
+	timeout time.Duration,
+) {
+	if confTimeout := gNode.Config().ConfigurationTimeout; confTimeout > 0 {
+		timeout = confTimeout
+	}
+	// a driver that ignores its context can hang forever, so do not pile more builds of the
+	// same resource on top of one that is still running.
+	if _, running := manager.buildsInFlight.LoadOrStore(resName, struct{}{}); running {
+		manager.logger.CDebugw(ctx, "skipping (re)configuration of resource still running a timed out (re)configuration",
+			"resource", resName)
+		return
+	}
+
+	resChan := make(chan struct{}, 1)
+	ctxWithTimeout, timeoutCancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer timeoutCancel()
+
+	stopSlowLogger := rutils.SlowLogger(
+		ctx, "Waiting for resource to complete (re)configuration", "resource", resName.String(), manager.logger)
+
+	lr.reconfigureWorkers.Add(1)
+	goutils.PanicCapturingGo(func() {
+		defer func() {
+			stopSlowLogger()
+			manager.buildsInFlight.Delete(resName)
+			resChan <- struct{}{}
+			lr.reconfigureWorkers.Done()
+		}()
+
+		var prefix string
+		conf := gNode.Config()
+		if gNode.IsUninitialized() {
+			gNode.InitializeLogger(
+				manager.logger, resName.String(),
+			)
+		} else {
+			prefix = "re"
+		}
+		manager.logger.CInfow(ctx, fmt.Sprintf("Now %sconfiguring resource", prefix), "resource", resName, "model", conf.Model)
+
+		// The config was already validated, but we must check again before attempting
+		// to add.
+		if _, _, err := conf.Validate("", resName.API.Type.Name); err != nil {
+			gNode.LogAndSetLastError(
+				fmt.Errorf("resource config validation error: %w", err),
+				"resource", conf.ResourceName(),
+				"model", conf.Model)
+			return
+		}
+		logConfigWarnings(ctx, manager.logger, resName, conf)
+		if manager.moduleManager.Provides(conf) {
+			if _, _, err := manager.moduleManager.ValidateConfig(ctxWithTimeout, conf); err != nil {
+				gNode.LogAndSetLastError(
+					fmt.Errorf("modular resource config validation error: %w", err),
+					"resource", conf.ResourceName(),
+					"model", conf.Model)
+				return
 			}
+		}
 
-			if syncRes {
-				if err := processResource(); err != nil {
-					return
-				}
-			} else {
-				lr.reconfigureWorkers.Add(1)
-				levelErrG.Go(func() error {
-					defer lr.reconfigureWorkers.Done()
-					return processResource()
-				})
+		building := gNode.IsUninitialized()
+		start := time.Now()
+		newRes, newlyBuilt, err := manager.processResource(ctxWithTimeout, conf, gNode, lr)
+		attemptErr := err
+		if attemptErr == nil {
+			// an attempt that outlived its timeout is not applied, so it counts as failed
+			attemptErr = ctxWithTimeout.Err()
+		}
+		gNode.RecordConfigure(building || newlyBuilt, time.Since(start), attemptErr)
+		if resource.IsPanicError(err) {
+			manager.logger.CErrorw(ctx, "resource panicked while being (re)configured",
+				"resource", resName,
+				"model", conf.Model,
+				"crashes", gNode.ConfigureStats().Crashes(),
+				"stack", string(resource.PanicStack(err)))
+		}
+		if newlyBuilt || err != nil {
+			if err := manager.markChildrenForUpdate(resName); err != nil {
+				manager.logger.CErrorw(ctx,
+					"failed to mark children of resource for update",
+					"resource", resName,
+					"reason", err)
 			}
-		} // for-each resource name
-		if err := levelErrG.Wait(); err != nil {
+		}
+
+		if err != nil {
+			gNode.LogAndSetLastError(
+				fmt.Errorf("resource build error: %w", err),
+				"resource", conf.ResourceName(),
+				"model", conf.Model)
 			return
 		}
-	} // for-each level
+
+		// if the ctxWithTimeout fails with DeadlineExceeded, then that means that
+		// resource generation is running async, and we don't currently have good
+		// validation around how this might affect the resource graph. So, we avoid
+		// updating the graph to be safe.
+		if errors.Is(ctxWithTimeout.Err(), context.DeadlineExceeded) {
+			manager.logger.CErrorw(
+				ctx, "error building resource", "resource", conf.ResourceName(), "model", conf.Model, "error", ctxWithTimeout.Err())
+		} else {
+			gNode.SwapResource(newRes, conf.Model, manager.opts.ftdc)
+			manager.logger.CInfow(ctx, fmt.Sprintf("Successfully %sconfigured resource", prefix), "resource", resName, "model", conf.Model)
+		}
+	})
+
+	select {
+	case <-resChan:
+	case <-ctxWithTimeout.Done():
+		// this resource is taking too long to process, so we give up but
+		// continue processing other resources. we do not wait for this
+		// resource to finish processing since it may be running outside code
+		// and have unexpected behavior.
+		if errors.Is(ctxWithTimeout.Err(), context.DeadlineExceeded) {
+			gNode.LogAndSetLastError(
+				rutils.NewResourceBuildTimeoutError(resName.String(), timeout),
+				"resource", resName,
+				"model", gNode.Config().Model)
+		}
+	case <-ctx.Done():
+	}
+}
+
+// splitLevelsByPriority splits every level into consecutive levels of resources with the
+// same reconfigure priority, highest priority first. Since levels are processed in order,
+// this guarantees that a resource finishes (re)configuring before any resource of a lower
+// priority in the same level starts.
+func (manager *resourceManager) splitLevelsByPriority(levels [][]resource.Name) [][]resource.Name {
+	split := make([][]resource.Name, 0, len(levels))
+	for _, level := range levels {
+		byPriority := map[int][]resource.Name{}
+		for _, name := range level {
+			var priority int
+			if gNode, ok := manager.resources.Node(name); ok {
+				priority = gNode.Config().ReconfigurePriority
+			}
+			byPriority[priority] = append(byPriority[priority], name)
+		}
+		priorities := make([]int, 0, len(byPriority))
+		for priority := range byPriority {
+			priorities = append(priorities, priority)
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(priorities)))
+		for _, priority := range priorities {
+			split = append(split, byPriority[priority])
+		}
+	}
+	return split
+}
+
+// logConfigWarnings logs the non-fatal problems found validating a resource config.
+func logConfigWarnings(ctx context.Context, logger logging.Logger, name resource.Name, conf resource.Config) {
+	for _, w := range conf.Warnings() {
+		fields := []interface{}{"resource", name, "model", conf.Model, "field", w.Field, "message", w.Message}
+		if w.Severity == resource.ConfigWarningSeverityInfo {
+			logger.CInfow(ctx, "Resource config validation note", fields...)
+			continue
+		}
+		logger.CWarnw(ctx, "Resource config validation warning", fields...)
+	}
 }
 
 func (manager *resourceManager) completeConfigForRemotes(ctx context.Context, lr *localRobot) {
@@ -1052,6 +1264,30 @@ func (manager *resourceManager) markChildrenForUpdate(rName resource.Name) error
 	return nil
 }
 
+// updateDependentsOfRecovered marks the dependents of resources that have recovered from
+// being unhealthy for update, if those resources are configured to refresh their dependents
+// on recovery, so that dependents do not keep using state from before the failure. It
+// returns whether any dependents were marked.
+func (manager *resourceManager) updateDependentsOfRecovered(ctx context.Context) bool {
+	var anyMarked bool
+	for _, name := range manager.resources.TakeRecovered() {
+		gNode, ok := manager.resources.Node(name)
+		if !ok || !gNode.Config().RefreshDependentsOnRecovery {
+			continue
+		}
+		manager.logger.CInfow(ctx, "resource recovered, reconfiguring resources that depend on it", "resource", name)
+		if err := manager.markChildrenForUpdate(name); err != nil {
+			manager.logger.CErrorw(ctx,
+				"failed to mark children of resource for update",
+				"resource", name,
+				"reason", err)
+			continue
+		}
+		anyMarked = true
+	}
+	return anyMarked
+}
+
 func (manager *resourceManager) processResource(
 	ctx context.Context,
 	conf resource.Config,
@@ -1092,7 +1328,7 @@ func (manager *resourceManager) processResource(
 			return currentRes, false, nil
 		}
 
-		err = currentRes.Reconfigure(ctx, deps, conf)
+		err = reconfigureRecoveringPanic(ctx, currentRes, deps, conf)
 		if err == nil {
 			return currentRes, false, nil
 		}
@@ -1128,6 +1364,22 @@ func (manager *resourceManager) processResource(
 	return newRes, true, nil
 }
 
+// reconfigureRecoveringPanic reconfigures res, returning an error instead of taking down the
+// whole machine if it panics.
+func reconfigureRecoveringPanic(
+	ctx context.Context,
+	res resource.Resource,
+	deps resource.Dependencies,
+	conf resource.Config,
+) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic reconfiguring resource: %w", resource.NewPanicError(r, debug.Stack()))
+		}
+	}()
+	return res.Reconfigure(ctx, deps, conf)
+}
+
 // markResourceForUpdate marks the given resource in the graph to be updated. If it does not exist, a new node
 // is inserted. If it does exist, it's properly marked. Once this is done, all information needed to build/reconfigure
 // will be available when we call completeConfig.
@@ -1265,11 +1517,12 @@ func (manager *resourceManager) ResourceByName(name resource.Name) (resource.Res
 	// a string and not a resource name (e.g. expressions).
 	if !name.ContainsRemoteNames() {
 		keys := manager.resources.FindNodesByShortNameAndAPI(name)
-		if len(keys) > 1 {
-			return nil, rutils.NewRemoteResourceClashError(name.Name)
-		}
-		if len(keys) == 1 {
-			gNode, ok := manager.resources.Node(keys[0])
+		if len(keys) != 0 {
+			resolved, err := manager.resources.ResolveNameConflict(name.Name, keys)
+			if err != nil {
+				return nil, err
+			}
+			gNode, ok := manager.resources.Node(resolved)
 			if ok {
 				res, err := gNode.Resource()
 				if err != nil {
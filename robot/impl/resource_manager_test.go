@@ -476,6 +476,26 @@ func TestManagerAdd(t *testing.T) {
 	test.That(t, objectSegmentationService, test.ShouldEqual, injectVisionService)
 }
 
+func TestManagerSplitLevelsByPriority(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	manager := newResourceManager(resourceManagerOptions{}, logger)
+
+	addNode := func(name string, priority int) resource.Name {
+		cfg := resource.Config{API: motor.API, Name: name, ReconfigurePriority: priority}
+		rName := cfg.ResourceName()
+		manager.resources.AddNode(rName, resource.NewUnconfiguredGraphNode(cfg, nil))
+		return rName
+	}
+	pdb := addNode("pdb", 10)
+	m1 := addNode("m1", 0)
+	m2 := addNode("m2", 0)
+	lowest := addNode("lowest", -1)
+	last := addNode("last", 5)
+
+	levels := manager.splitLevelsByPriority([][]resource.Name{{m1, lowest, pdb, m2}, {last}})
+	test.That(t, levels, test.ShouldResemble, [][]resource.Name{{pdb}, {m1, m2}, {lowest}, {last}})
+}
+
 func TestManagerNewComponent(t *testing.T) {
 	fakeModel := resource.DefaultModelFamily.WithModel("fake")
 	cfg := &config.Config{
@@ -1897,6 +1917,13 @@ func (rr *dummyRobot) CurrentInputs(ctx context.Context) (referenceframe.FrameSy
 	panic("change to return nil")
 }
 
+func (rr *dummyRobot) GetWorldGeometries(
+	ctx context.Context,
+	supplementalTransforms []*referenceframe.LinkInFrame,
+) (map[string]*referenceframe.GeometriesInFrame, error) {
+	panic("change to return nil")
+}
+
 func (rr *dummyRobot) ProcessManager() pexec.ProcessManager {
 	panic("change to return nil")
 }
@@ -22,8 +22,20 @@ type options struct {
 	// whether or not to run FTDC
 	enableFTDC bool
 
+	// configHistoryDir and configHistorySize configure where and how many applied configs
+	// are kept for rollback. History is disabled when configHistoryDir is empty.
+	configHistoryDir  string
+	configHistorySize int
+
 	// disableCompleteConfigWorker starts the robot without the complete config worker - should only be used for tests.
 	disableCompleteConfigWorker bool
+
+	// namespace is the name of the robot within a Namespaces. It separates the robot's FTDC
+	// data, module data and config history from those of other robots in the same process.
+	namespace string
+
+	// setRedactedValues is given the secrets resolved for each config the robot applies.
+	setRedactedValues func([]string)
 }
 
 // Option configures how we set up the web service.
@@ -87,6 +99,30 @@ func WithShutdownCallback(shutdownFunc func()) Option {
 	})
 }
 
+// WithConfigHistory returns an Option which keeps the last size applied configs in dir
+// so that the robot can be rolled back to one of them.
+func WithConfigHistory(dir string, size int) Option {
+	return newFuncOption(func(o *options) {
+		o.configHistoryDir = dir
+		o.configHistorySize = size
+	})
+}
+
+// withNamespace returns an Option which names the robot within a Namespaces.
+func withNamespace(namespace string) Option {
+	return newFuncOption(func(o *options) {
+		o.namespace = namespace
+	})
+}
+
+// withRedactedValues returns an Option which passes the secrets resolved for each config the
+// robot applies to setRedactedValues, so that they can be masked in logs.
+func withRedactedValues(setRedactedValues func([]string)) Option {
+	return newFuncOption(func(o *options) {
+		o.setRedactedValues = setRedactedValues
+	})
+}
+
 // withDisableCompleteConfigWorker returns an Option which disables the complete config worker.
 func withDisableCompleteConfigWorker() Option {
 	return newFuncOption(func(o *options) {
@@ -128,7 +128,12 @@ func (jm *JobManager) createDescriptorSourceAndgRPCMethod(
 	}
 	var grpcService string
 	for _, srv := range services {
-		if strings.Split(srv, ".")[componentServiceIndex] == resourceType {
+		if strings.Split(srv, ".")[componentServiceIndex] != resourceType {
+			continue
+		}
+		// rdk-owned services, such as the streaming ones, have no descriptors to reflect on,
+		// so they cannot be invoked here.
+		if _, err := descSource.FindSymbol(srv); err == nil {
 			grpcService = srv
 			break
 		}
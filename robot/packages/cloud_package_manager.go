@@ -78,10 +78,15 @@ func NewCloudManager(
 		return nil, err
 	}
 
+	transport, err := cloudConfig.HTTPTransport()
+	if err != nil {
+		return nil, err
+	}
+
 	return &cloudManager{
 		Named:           InternalServiceName.AsNamed(),
 		client:          client,
-		httpClient:      http.Client{Timeout: time.Minute * 30},
+		httpClient:      http.Client{Timeout: time.Minute * 30, Transport: transport},
 		cloudConfig:     *cloudConfig,
 		packagesDir:     packagesDir,
 		packagesDataDir: packagesDataDir,
@@ -3,13 +3,18 @@ package robot
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"path"
 	"runtime/debug"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/jhump/protoreflect/desc"
 	"github.com/jhump/protoreflect/dynamic"
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 
 	"go.viam.com/rdk/cloud"
 	"go.viam.com/rdk/config"
@@ -21,6 +26,7 @@ import (
 	"go.viam.com/rdk/robot/packages"
 	weboptions "go.viam.com/rdk/robot/web/options"
 	"go.viam.com/rdk/session"
+	"go.viam.com/rdk/utils"
 )
 
 const (
@@ -147,6 +153,39 @@ type LocalRobot interface {
 	// on the given new config.
 	Reconfigure(ctx context.Context, newConfig *config.Config)
 
+	// DryRunReconfigure reports what Reconfigure would do with the given config
+	// without touching any running resources.
+	DryRunReconfigure(ctx context.Context, newConfig *config.Config) (*DryRunReport, error)
+
+	// StopResources stops the actuators selected by filter and reports which stopped,
+	// failed to stop, or did not stop in time. Operations are only cancelled when the
+	// filter selects every resource, as StopAll does.
+	StopResources(ctx context.Context, filter StopFilter, extra map[resource.Name]map[string]interface{}) (*StopReport, error)
+
+	// ConfigHistory returns the configs most recently applied to the robot, oldest first.
+	ConfigHistory() []config.HistoryEntry
+
+	// Rollback reapplies a config from the config history through the normal
+	// reconfigure path.
+	Rollback(ctx context.Context, revision string) error
+
+	// ApplyResourceChanges adds, removes and updates the given components and services in a
+	// single reconfigure, such as for resources a module manages dynamically. The changes are
+	// merged into every later config until the module that applied them is removed. Either every
+	// change is applied or, if any of them is invalid, none are and an error is returned. The
+	// returned map holds why each added or updated resource that failed to build or
+	// reconfigure did so.
+	ApplyResourceChanges(
+		ctx context.Context,
+		adds []resource.Config,
+		removes []resource.Name,
+		updates []resource.Config,
+	) (map[resource.Name]error, error)
+
+	// StartResource builds a resource with lazy_start set that has not been started yet and
+	// waits for it to be built. It does nothing for resources that have already been started.
+	StartResource(ctx context.Context, name resource.Name) error
+
 	// StartWeb starts the web server, will return an error if server is already up.
 	StartWeb(ctx context.Context, o weboptions.Options) error
 
@@ -164,6 +203,27 @@ type LocalRobot interface {
 	// DOT reference: https://graphviz.org/doc/info/lang.html
 	ExportResourcesAsDot(index int) (resource.GetSnapshotInfo, error)
 
+	// ExportResourcesAsJSON exports the current resource graph, including each resource's state and
+	// dependencies, as JSON.
+	ExportResourcesAsJSON() (string, error)
+
+	// ResourceGraphSnapshot returns a copy of the current resource graph that is safe to inspect
+	// and serialize while the robot reconfigures.
+	ResourceGraphSnapshot() resource.GraphSnapshot
+
+	// ResourceNamesByLabels returns the names of all resources whose config labels match the given
+	// label selector, like "zone=front,safety=critical". See resource.ParseLabelSelector.
+	ResourceNamesByLabels(selector string) ([]resource.Name, error)
+
+	// Introspect describes the machine, with its resources, their sanitized configs, properties
+	// and capabilities, its frame system and its modules, as one document.
+	Introspect(ctx context.Context) (*Introspection, error)
+
+	// SubscribeResourceEvents streams an event every time a resource changes lifecycle state
+	// (e.g. configuring, ready, unhealthy, or removed) until ctx is done, at which point the
+	// channel is closed. Events are dropped for subscribers that fall behind.
+	SubscribeResourceEvents(ctx context.Context) <-chan resource.ResourceEvent
+
 	// RestartAllowed returns whether the robot can safely be restarted.
 	RestartAllowed() bool
 
@@ -218,6 +278,22 @@ func AllResourcesByName(r Robot, name string) []resource.Resource {
 	return all
 }
 
+// ResourceNamesMatching returns the names of all resources whose short name, including any remote
+// prefixes, matches the given glob pattern, as understood by path.Match. For example, "camera-*" matches
+// local cameras named with that prefix, and "*:gripper" matches resources named gripper on any remote.
+func ResourceNamesMatching(r Robot, pattern string) ([]resource.Name, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, errors.Wrapf(err, "invalid resource name pattern %q", pattern)
+	}
+	names := []resource.Name{}
+	for _, n := range r.ResourceNames() {
+		if matched, _ := path.Match(pattern, n.ShortName()); matched {
+			names = append(names, n)
+		}
+	}
+	return names, nil
+}
+
 // NamesByAPI is a helper for getting all names from the given Robot given the API.
 func NamesByAPI(r Robot, api resource.API) []string {
 	names := []string{}
@@ -319,13 +395,227 @@ const (
 	// StateRunning denotes a running machine. The first reconfigure after
 	// initial creation has completed.
 	StateRunning
+	// StateShuttingDown denotes a machine that is shutting down, and is waiting for
+	// operations already running to finish without accepting new ones.
+	StateShuttingDown
 )
 
+var machineStateNames = map[MachineState]string{
+	StateUnknown:      "unknown",
+	StateInitializing: "initializing",
+	StateRunning:      "running",
+	StateShuttingDown: "shutting_down",
+}
+
+// String returns the name of the state, as sent by the low bandwidth service.
+func (s MachineState) String() string {
+	if name, ok := machineStateNames[s]; ok {
+		return name
+	}
+	return machineStateNames[StateUnknown]
+}
+
+// MachineStateFromString returns the state with the given name, or StateUnknown if there is none.
+func MachineStateFromString(name string) MachineState {
+	for s, n := range machineStateNames {
+		if n == name {
+			return s
+		}
+	}
+	return StateUnknown
+}
+
+// ResourceGenerationsMetadataKey is the key of the header in GetMachineStatus responses that
+// carries a ResourceGeneration for each resource, see grpc.EncodeMetadataValues.
+const ResourceGenerationsMetadataKey = "viam-resource-generations"
+
+// ResourceCrashesMetadataKey is the key of the header in GetMachineStatus responses that carries
+// ResourceCrashes for each resource that ever crashed, see grpc.EncodeMetadataValues.
+const ResourceCrashesMetadataKey = "viam-resource-crashes"
+
+// A ResourceGeneration is the generation of a resource, see resource.NodeStatus.Generation.
+type ResourceGeneration struct {
+	Name       string `json:"name"`
+	Generation uint64 `json:"generation"`
+}
+
+// ResourceCrashes are how many times building and reconfiguring a resource panicked, see
+// resource.ConfigureAttempts.Crashes.
+type ResourceCrashes struct {
+	Name               string `json:"name"`
+	BuildCrashes       int    `json:"build_crashes"`
+	ReconfigureCrashes int    `json:"reconfigure_crashes"`
+}
+
 // MachineStatus encapsulates the current status of the robot.
 type MachineStatus struct {
 	Resources []resource.Status
 	Config    config.Revision
 	State     MachineState
+	// ConfigFetch describes when the current cloud config was fetched and whether it
+	// was read from the local cache because the cloud could not be reached.
+	ConfigFetch config.FetchStatus
+	// AppliedConfig describes the last config the machine finished reconfiguring with.
+	// Unlike Config, which changes as soon as a reconfigure starts, it only changes once
+	// the reconfigure is done, so fleet tools can use it to check that a pushed config
+	// took effect.
+	AppliedConfig AppliedConfigStatus
+	// CloudConnection is whether the machine is connected to the cloud managing it.
+	CloudConnection CloudConnectionState
+	// DisabledResources are the resources in the config that are disabled by their enabled
+	// condition, and so are not on the machine.
+	DisabledResources []config.DisabledResource
+}
+
+// AppliedConfigStatus describes a config a machine finished reconfiguring with.
+type AppliedConfigStatus struct {
+	Revision string
+	// Checksum is a checksum of the config's content, see config.Config.Checksum.
+	Checksum string
+	// ReconfiguredAt is when the reconfigure started and ReconfigureDuration is how long it took.
+	ReconfiguredAt      time.Time
+	ReconfigureDuration time.Duration
+}
+
+// CloudConnectionState describes whether a machine is connected to the cloud managing it.
+type CloudConnectionState string
+
+// The supported CloudConnectionState values.
+const (
+	// CloudConnectionNotManaged is for machines that are not managed by the cloud.
+	CloudConnectionNotManaged CloudConnectionState = "not_managed"
+	// CloudConnectionConnected is for machines with an established connection to the cloud.
+	CloudConnectionConnected CloudConnectionState = "connected"
+	// CloudConnectionDisconnected is for machines that have not yet been able to connect to
+	// the cloud.
+	CloudConnectionDisconnected CloudConnectionState = "disconnected"
+)
+
+// DryRunReport describes the changes a reconfigure with a given config would make
+// to the robot along with any validation or dependency resolution errors that
+// would be encountered.
+type DryRunReport struct {
+	Diff *config.Diff
+
+	Added    []resource.Name
+	Modified []resource.Name
+	Removed  []resource.Name
+
+	// Errors contains validation errors for added or modified resources.
+	Errors map[resource.Name]error
+	// Warnings contains non-fatal validation problems for added or modified resources.
+	Warnings map[resource.Name][]resource.ConfigWarning
+	// UnresolvedDependencies contains the dependencies that could not be resolved for
+	// each resource in the resulting resource graph.
+	UnresolvedDependencies map[resource.Name][]string
+	// DependencyError contains any errors encountered resolving dependencies in the
+	// resulting resource graph, such as circular or conflicting dependencies.
+	DependencyError error
+}
+
+// HasErrors returns whether applying the config would result in any errors.
+func (r *DryRunReport) HasErrors() bool {
+	return len(r.Errors) != 0 || len(r.UnresolvedDependencies) != 0 || r.DependencyError != nil
+}
+
+// StopFilter selects the resources stopped by StopResources. The zero value selects
+// every resource.
+type StopFilter struct {
+	// LocalOnly selects only resources that are not on a remote.
+	LocalOnly bool
+	// Remotes, if set, selects only resources on these remotes. A resource on a remote
+	// of a remote is selected by its full remote path, for example "remote1:remote2".
+	Remotes []string
+	// APIs, if set, selects only resources of these APIs.
+	APIs []resource.API
+	// Exclude lists resources that are never stopped.
+	Exclude []resource.Name
+	// Timeout limits how long each resource may take to stop. Zero means no limit
+	// beyond the context passed to StopResources.
+	Timeout time.Duration
+}
+
+// IsScoped returns whether the filter selects only some resources.
+func (f StopFilter) IsScoped() bool {
+	return f.LocalOnly || len(f.Remotes) != 0 || len(f.APIs) != 0 || len(f.Exclude) != 0
+}
+
+// Matches returns whether the filter selects the named resource.
+func (f StopFilter) Matches(name resource.Name) bool {
+	if f.LocalOnly && name.ContainsRemoteNames() {
+		return false
+	}
+	if len(f.Remotes) != 0 && !slices.Contains(f.Remotes, name.Remote) {
+		return false
+	}
+	if len(f.APIs) != 0 && !slices.Contains(f.APIs, name.API) {
+		return false
+	}
+	return !slices.Contains(f.Exclude, name)
+}
+
+// StopReport describes the outcome of stopping each actuator selected by a StopFilter.
+// Selected resources that are not actuators do not appear in the report.
+type StopReport struct {
+	Stopped  []resource.Name
+	Failed   map[resource.Name]error
+	TimedOut []resource.Name
+}
+
+// Err combines every failure and timeout in the report into a single error.
+func (r *StopReport) Err() error {
+	var errs error
+	for name, err := range r.Failed {
+		errs = multierr.Combine(errs, errors.Errorf("failed to stop component named %s with error %v", name.Name, err))
+	}
+	for _, name := range r.TimedOut {
+		errs = multierr.Combine(errs, errors.Errorf("timed out stopping component named %s", name.Name))
+	}
+	return errs
+}
+
+// Introspection describes a machine as one JSON document, for fleet inventory tooling.
+type Introspection struct {
+	Platform   string `json:"platform"`
+	Version    string `json:"version"`
+	APIVersion string `json:"api_version"`
+	// ConfigRevision is the revision of the config the machine is running.
+	ConfigRevision string    `json:"config_revision,omitempty"`
+	Time           time.Time `json:"time"`
+
+	Resources []ResourceIntrospection `json:"resources"`
+	Modules   []ModuleIntrospection   `json:"modules"`
+	// FrameSystem holds the parts of the frame system, each a FrameSystemConfig message in its
+	// protobuf JSON form.
+	FrameSystem []json.RawMessage `json:"frame_system"`
+
+	// Errors are the parts of the machine that could not be introspected. Everything else is
+	// still described.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ResourceIntrospection describes a resource of a machine.
+type ResourceIntrospection struct {
+	resource.NodeSnapshot
+
+	// Attributes are the configured attributes of the resource, with sensitive ones masked.
+	Attributes utils.AttributeMap `json:"attributes,omitempty"`
+	// Module is the name of the module that provides the model of the resource, if any.
+	Module       string                `json:"module,omitempty"`
+	Capabilities []resource.Capability `json:"capabilities,omitempty"`
+	// Properties are what the Properties method of the resource returns, for APIs that have one.
+	Properties      json.RawMessage `json:"properties,omitempty"`
+	PropertiesError string          `json:"properties_error,omitempty"`
+}
+
+// ModuleIntrospection describes a module of a machine.
+type ModuleIntrospection struct {
+	Name     string            `json:"name"`
+	Type     config.ModuleType `json:"type"`
+	ModuleID string            `json:"module_id,omitempty"`
+	// Version is the version of the package of a registry module.
+	Version string `json:"version,omitempty"`
+	ExePath string `json:"executable_path"`
 }
 
 // VersionResponse encapsulates the version info of the robot.
@@ -68,6 +68,25 @@ func TestAllResourcesByName(t *testing.T) {
 	test.That(t, resources, test.ShouldBeEmpty)
 }
 
+func TestResourceNamesMatching(t *testing.T) {
+	r := setupInjectRobot()
+
+	names, err := robot.ResourceNamesMatching(r, "arm*")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, names, test.ShouldResemble, []resource.Name{arm.Named("arm1"), arm.Named("arm2"), button1})
+
+	names, err = robot.ResourceNamesMatching(r, "*:arm?")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, names, test.ShouldResemble, []resource.Name{arm.Named("remote:arm1")})
+
+	names, err = robot.ResourceNamesMatching(r, "blah*")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, names, test.ShouldBeEmpty)
+
+	_, err = robot.ResourceNamesMatching(r, "arm[")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
 func TestNamesFromRobot(t *testing.T) {
 	r := setupInjectRobot()
 
@@ -20,13 +20,17 @@ import (
 	"go.viam.com/utils"
 	vprotoutils "go.viam.com/utils/protoutils"
 	"go.viam.com/utils/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	rgrpc "go.viam.com/rdk/grpc"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/pointcloud"
+	lbpb "go.viam.com/rdk/proto/rdk/lowbandwidth/v1"
 	"go.viam.com/rdk/protoutils"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/resource"
@@ -49,7 +53,12 @@ var defaultTunnelConnectionTimeout = 10 * time.Second
 // a robot.Robot as a gRPC server.
 type Server struct {
 	pb.UnimplementedRobotServiceServer
+	lbpb.UnimplementedLowBandwidthServiceServer
 	robot robot.Robot
+
+	// namesSnapshots are the last sets of resource names sent by the low bandwidth service.
+	namesMu        sync.Mutex
+	namesSnapshots []resourceNamesSnapshot
 }
 
 // New constructs a gRPC service server for a Robot.
@@ -511,6 +520,8 @@ func (s *Server) GetMachineStatus(ctx context.Context, _ *pb.GetMachineStatusReq
 		LastUpdated: timestamppb.New(mStatus.Config.LastUpdated),
 	}
 	result.Resources = make([]*pb.ResourceStatus, 0, len(mStatus.Resources))
+	generations := make([]robot.ResourceGeneration, 0, len(mStatus.Resources))
+	var crashes []robot.ResourceCrashes
 	for _, resStatus := range mStatus.Resources {
 		pbResStatus := &pb.ResourceStatus{
 			Name:          protoutils.ResourceNameToProto(resStatus.Name),
@@ -539,6 +550,30 @@ func (s *Server) GetMachineStatus(ctx context.Context, _ *pb.GetMachineStatusReq
 		}
 
 		result.Resources = append(result.Resources, pbResStatus)
+		if resStatus.Generation > 0 {
+			generations = append(generations, robot.ResourceGeneration{Name: resStatus.Name.String(), Generation: resStatus.Generation})
+		}
+		if resStatus.Configure.Crashes() > 0 {
+			crashes = append(crashes, robot.ResourceCrashes{
+				Name:               resStatus.Name.String(),
+				BuildCrashes:       resStatus.Configure.Builds.Crashes,
+				ReconfigureCrashes: resStatus.Configure.Reconfigures.Crashes,
+			})
+		}
+	}
+	generationsMD, droppedGenerations := rgrpc.EncodeMetadataValues(robot.ResourceGenerationsMetadataKey, generations)
+	crashesMD, droppedCrashes := rgrpc.EncodeMetadataValues(robot.ResourceCrashesMetadataKey, crashes)
+	if droppedGenerations+droppedCrashes > 0 {
+		s.robot.Logger().CDebugw(ctx, "machine status headers are full, leaving out some resources",
+			"generations", droppedGenerations, "crashes", droppedCrashes)
+	}
+	// the API has no shutting down state, so it is sent in the header alongside running.
+	var drainingMD metadata.MD
+	if mStatus.State == robot.StateShuttingDown {
+		drainingMD = metadata.Pairs(operation.DrainingMetadataKey, "true")
+	}
+	if hdr := metadata.Join(generationsMD, crashesMD, drainingMD); hdr.Len() > 0 {
+		utils.UncheckedError(grpc.SetHeader(ctx, hdr))
 	}
 
 	switch mStatus.State {
@@ -549,6 +584,10 @@ func (s *Server) GetMachineStatus(ctx context.Context, _ *pb.GetMachineStatusReq
 		result.State = pb.GetMachineStatusResponse_STATE_INITIALIZING
 	case robot.StateRunning:
 		result.State = pb.GetMachineStatusResponse_STATE_RUNNING
+	case robot.StateShuttingDown:
+		// the machine is still serving while it drains; clients learn that it is shutting down
+		// from the header set above.
+		result.State = pb.GetMachineStatusResponse_STATE_RUNNING
 	}
 
 	return &result, nil
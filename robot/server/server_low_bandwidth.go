@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"encoding/hex"
+	"hash/fnv"
+	"slices"
+
+	"github.com/fxamacker/cbor/v2"
+
+	rgrpc "go.viam.com/rdk/grpc"
+	lbpb "go.viam.com/rdk/proto/rdk/lowbandwidth/v1"
+	"go.viam.com/rdk/resource"
+)
+
+// maxResourceNamesSnapshots is how many past sets of resource names are kept to send deltas
+// against. Clients whose token is older are sent every name.
+const maxResourceNamesSnapshots = 8
+
+// resourceNamesSnapshot is a set of resource names sent to low bandwidth clients, and its token.
+type resourceNamesSnapshot struct {
+	token string
+	names []string
+}
+
+// GetResourceNamesDelta returns how the resource names of the robot changed since the names with
+// the token the client sent.
+func (s *Server) GetResourceNamesDelta(
+	ctx context.Context, req *lbpb.GetResourceNamesDeltaRequest,
+) (*lbpb.GetResourceNamesDeltaResponse, error) {
+	names := resource.NamesToStrings(s.robot.ResourceNames())
+	slices.Sort(names)
+	token := resourceNamesToken(names)
+	since := req.GetSince()
+
+	delta := rgrpc.ResourceNamesDelta{Token: token}
+	if since != token {
+		prev, ok := s.resourceNamesSince(since, resourceNamesSnapshot{token: token, names: names})
+		if ok {
+			delta.Added, delta.Removed = diffSortedNames(prev, names)
+		} else {
+			delta.Full = true
+			delta.Added = names
+		}
+	}
+	data, err := cbor.Marshal(delta)
+	if err != nil {
+		return nil, err
+	}
+	return &lbpb.GetResourceNamesDeltaResponse{Delta: data}, nil
+}
+
+// resourceNamesSince records the current names and returns the names with the given token, if
+// they are still kept.
+func (s *Server) resourceNamesSince(since string, curr resourceNamesSnapshot) ([]string, bool) {
+	s.namesMu.Lock()
+	defer s.namesMu.Unlock()
+	if !slices.ContainsFunc(s.namesSnapshots, func(snap resourceNamesSnapshot) bool { return snap.token == curr.token }) {
+		s.namesSnapshots = append(s.namesSnapshots, curr)
+		if len(s.namesSnapshots) > maxResourceNamesSnapshots {
+			s.namesSnapshots = slices.Delete(s.namesSnapshots, 0, 1)
+		}
+	}
+	for _, snap := range s.namesSnapshots {
+		if snap.token == since {
+			return snap.names, true
+		}
+	}
+	return nil, false
+}
+
+// resourceNamesToken returns a token that identifies a sorted set of resource names.
+func resourceNamesToken(names []string) string {
+	h := fnv.New64a()
+	for _, name := range names {
+		//nolint:errcheck
+		h.Write([]byte(name))
+		//nolint:errcheck
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// diffSortedNames returns the names in curr that are not in prev, and those in prev that are not
+// in curr. Both must be sorted.
+func diffSortedNames(prev, curr []string) ([]string, []string) {
+	var added, removed []string
+	i, j := 0, 0
+	for i < len(prev) || j < len(curr) {
+		switch {
+		case j == len(curr) || (i < len(prev) && prev[i] < curr[j]):
+			removed = append(removed, prev[i])
+			i++
+		case i == len(prev) || curr[j] < prev[i]:
+			added = append(added, curr[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	return added, removed
+}
+
+// GetCompactMachineStatus returns the status of the robot as CBOR, for clients on constrained
+// links.
+func (s *Server) GetCompactMachineStatus(
+	ctx context.Context, _ *lbpb.GetCompactMachineStatusRequest,
+) (*lbpb.GetCompactMachineStatusResponse, error) {
+	mStatus, err := s.robot.MachineStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	compact := rgrpc.CompactMachineStatus{
+		ConfigRevision: mStatus.Config.Revision,
+		State:          mStatus.State.String(),
+		Resources:      make([]rgrpc.CompactResourceStatus, 0, len(mStatus.Resources)),
+	}
+	for _, resStatus := range mStatus.Resources {
+		res := rgrpc.CompactResourceStatus{
+			Name:     resStatus.Name.String(),
+			State:    resStatus.State.String(),
+			Revision: resStatus.Revision,
+		}
+		if resStatus.Error != nil {
+			res.Error = resStatus.Error.Error()
+		}
+		compact.Resources = append(compact.Resources, res)
+	}
+	data, err := cbor.Marshal(compact)
+	if err != nil {
+		return nil, err
+	}
+	return &lbpb.GetCompactMachineStatusResponse{Status: data}, nil
+}
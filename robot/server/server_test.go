@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fxamacker/cbor/v2"
 	"github.com/golang/geo/r3"
 	"github.com/google/uuid"
 	"github.com/jhump/protoreflect/grpcreflect"
@@ -23,8 +24,10 @@ import (
 	"go.viam.com/rdk/cloud"
 	"go.viam.com/rdk/components/arm"
 	"go.viam.com/rdk/config"
+	rgrpc "go.viam.com/rdk/grpc"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/operation"
+	lbpb "go.viam.com/rdk/proto/rdk/lowbandwidth/v1"
 	"go.viam.com/rdk/protoutils"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/resource"
@@ -70,6 +73,71 @@ func TestServer(t *testing.T) {
 		test.That(t, resourceResp.Resources, test.ShouldResemble, serverOneResourceResponse)
 	})
 
+	t.Run("LowBandwidth", func(t *testing.T) {
+		names := []resource.Name{arm.Named("arm1"), arm.Named("arm2")}
+		injectRobot := &inject.Robot{}
+		injectRobot.ResourceNamesFunc = func() []resource.Name { return names }
+		lbServer, ok := server.New(injectRobot).(lbpb.LowBandwidthServiceServer)
+		test.That(t, ok, test.ShouldBeTrue)
+
+		getDelta := func(since string) rgrpc.ResourceNamesDelta {
+			t.Helper()
+			resp, err := lbServer.GetResourceNamesDelta(context.Background(), &lbpb.GetResourceNamesDeltaRequest{Since: since})
+			test.That(t, err, test.ShouldBeNil)
+			var delta rgrpc.ResourceNamesDelta
+			test.That(t, cbor.Unmarshal(resp.GetDelta(), &delta), test.ShouldBeNil)
+			return delta
+		}
+
+		// a client without a token is sent every name
+		delta := getDelta("")
+		test.That(t, delta.Full, test.ShouldBeTrue)
+		test.That(t, delta.Added, test.ShouldResemble, resource.NamesToStrings(names))
+		token := delta.Token
+
+		// nothing is sent when nothing changed
+		delta = getDelta(token)
+		test.That(t, delta, test.ShouldResemble, rgrpc.ResourceNamesDelta{Token: token})
+
+		// only the changes are sent
+		names = []resource.Name{arm.Named("arm2"), arm.Named("arm3")}
+		delta = getDelta(token)
+		test.That(t, delta.Full, test.ShouldBeFalse)
+		test.That(t, delta.Token, test.ShouldNotEqual, token)
+		test.That(t, delta.Added, test.ShouldResemble, []string{arm.Named("arm3").String()})
+		test.That(t, delta.Removed, test.ShouldResemble, []string{arm.Named("arm1").String()})
+
+		// an unknown token is sent every name
+		delta = getDelta("unknown")
+		test.That(t, delta.Full, test.ShouldBeTrue)
+		test.That(t, delta.Added, test.ShouldHaveLength, 2)
+
+		injectRobot.MachineStatusFunc = func(ctx context.Context) (robot.MachineStatus, error) {
+			return robot.MachineStatus{
+				Config: config.Revision{Revision: "rev1"},
+				State:  robot.StateRunning,
+				Resources: []resource.Status{
+					{NodeStatus: resource.NodeStatus{Name: arm.Named("arm2"), State: resource.NodeStateReady, Revision: "rev1"}},
+					{NodeStatus: resource.NodeStatus{
+						Name: arm.Named("arm3"), State: resource.NodeStateUnhealthy, Error: errors.New("bad arm"),
+					}},
+				},
+			}, nil
+		}
+		resp, err := lbServer.GetCompactMachineStatus(context.Background(), &lbpb.GetCompactMachineStatusRequest{})
+		test.That(t, err, test.ShouldBeNil)
+		var status rgrpc.CompactMachineStatus
+		test.That(t, cbor.Unmarshal(resp.GetStatus(), &status), test.ShouldBeNil)
+		test.That(t, status, test.ShouldResemble, rgrpc.CompactMachineStatus{
+			ConfigRevision: "rev1",
+			State:          "running",
+			Resources: []rgrpc.CompactResourceStatus{
+				{Name: arm.Named("arm2").String(), State: "Ready", Revision: "rev1"},
+				{Name: arm.Named("arm3").String(), State: "Unhealthy", Error: "bad arm"},
+			},
+		})
+	})
+
 	t.Run("GetMachineStatus", func(t *testing.T) {
 		testCases := []struct {
 			name                     string
@@ -0,0 +1,31 @@
+package web
+
+import (
+	"context"
+	"time"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/operation"
+)
+
+// goAwayGracePeriod is how long HTTP/2 connections are given to wind down after being sent a
+// GOAWAY before they are closed.
+const goAwayGracePeriod = time.Second
+
+// drainOperations stops opManager from accepting new operations and waits up to timeout for
+// the motion operations already running to finish, so that the machine is not torn down in
+// the middle of a motion. Motion operations still running after timeout are cancelled.
+func drainOperations(opManager *operation.Manager, timeout time.Duration, logger logging.Logger) {
+	opManager.StartDraining()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if running := opManager.WaitForMotion(ctx); len(running) > 0 {
+		for _, op := range running {
+			logger.Warnw("cancelling motion operation that did not finish before shutdown",
+				"id", op.ID, "method", op.Method, "running_for", time.Since(op.Started).String())
+			op.Cancel()
+		}
+		return
+	}
+	logger.Debug("all motion operations finished before shutdown")
+}
@@ -19,6 +19,19 @@ func (svc *webService) handleVisualizeResourceGraph(w http.ResponseWriter, r *ht
 	if !isLocal {
 		return
 	}
+	layout := r.URL.Query().Get("layout")
+	if layout == "json" {
+		exported, err := localRobot.ExportResourcesAsJSON()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		//nolint:errcheck
+		_, _ = w.Write([]byte(exported))
+		return
+	}
+
 	const lookupParam = "history"
 	redirectToLatestSnapshot := func() {
 		url := *r.URL
@@ -62,7 +75,6 @@ func (svc *webService) handleVisualizeResourceGraph(w http.ResponseWriter, r *ht
 		_, _ = w.Write([]byte(s))
 	}
 
-	layout := r.URL.Query().Get("layout")
 	if layout == "text" {
 		write(snapshot.Snapshot.Dot)
 		return
@@ -2,9 +2,23 @@
 
 package web
 
-import "net/http"
+import (
+	"net/http"
 
-// stub for missing graphviz
+	"go.viam.com/rdk/robot"
+)
+
+// stub for missing graphviz. JSON exports do not need graphviz, so they are still served.
 func (svc *webService) handleVisualizeResourceGraph(w http.ResponseWriter, r *http.Request) {
+	if localRobot, isLocal := svc.r.(robot.LocalRobot); isLocal && r.URL.Query().Get("layout") == "json" {
+		exported, err := localRobot.ExportResourcesAsJSON()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(exported))
+		return
+	}
 	w.Write([]byte(`<html><body>Resource graph not supported on this build</body></html>`))
 }
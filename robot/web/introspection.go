@@ -0,0 +1,35 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"go.viam.com/rdk/robot"
+)
+
+// introspectionServer serves the introspection of the robot of a web service as JSON.
+type introspectionServer struct {
+	svc *webService
+}
+
+// GetIntrospection returns the introspection of the robot as a JSON document.
+func (s introspectionServer) GetIntrospection(ctx context.Context, req *emptypb.Empty) (*wrapperspb.StringValue, error) {
+	lr, ok := s.svc.r.(robot.LocalRobot)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "introspection is only available on local robots")
+	}
+	intro, err := lr.Introspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	md, err := json.Marshal(intro)
+	if err != nil {
+		return nil, err
+	}
+	return wrapperspb.String(string(md)), nil
+}
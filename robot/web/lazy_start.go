@@ -0,0 +1,73 @@
+package web
+
+import (
+	"context"
+
+	googlegrpc "google.golang.org/grpc"
+
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+)
+
+// lazyStartUnaryInterceptor starts the resource a request is for before handling it, if the
+// resource has lazy_start set and has not been started yet. The first request for such a
+// resource waits for it to be built.
+func (svc *webService) lazyStartUnaryInterceptor(
+	ctx context.Context, req any, info *googlegrpc.UnaryServerInfo, handler googlegrpc.UnaryHandler,
+) (any, error) {
+	if err := svc.lazyStart(ctx, extractViamAPI(info.FullMethod), req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// lazyStartStreamInterceptor is the streaming counterpart of lazyStartUnaryInterceptor. The
+// resource a stream is for is only known once the first request of the stream is received, so
+// it is started then.
+func (svc *webService) lazyStartStreamInterceptor(
+	srv any, ss googlegrpc.ServerStream, info *googlegrpc.StreamServerInfo, handler googlegrpc.StreamHandler,
+) error {
+	if _, ok := svc.r.(robot.LocalRobot); !ok {
+		return handler(srv, ss)
+	}
+	return handler(srv, &lazyStartStream{ServerStream: ss, svc: svc, apiMethod: extractViamAPI(info.FullMethod)})
+}
+
+// lazyStart starts the resource that req is for, if any.
+func (svc *webService) lazyStart(ctx context.Context, apiMethod apiMethod, req any) error {
+	lr, ok := svc.r.(robot.LocalRobot)
+	if !ok {
+		return nil
+	}
+	if name := apiMethod.getResourceName(req); name != "" {
+		if api, ok := svc.serviceAPIs.Load(apiMethod.service); ok {
+			// requests for resources that do not exist are left for the handler to reject.
+			if err := lr.StartResource(ctx, resource.NewName(api, name)); err != nil && !resource.IsNotFoundError(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type lazyStartStream struct {
+	googlegrpc.ServerStream
+	svc       *webService
+	apiMethod apiMethod
+
+	// received is whether the first request has been received. RecvMsg is not called
+	// concurrently, so it needs no lock.
+	received bool
+}
+
+// RecvMsg starts the resource that the first request of the stream is for.
+func (s *lazyStartStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.received {
+		return nil
+	}
+	s.received = true
+	return s.svc.lazyStart(s.Context(), s.apiMethod, m)
+}
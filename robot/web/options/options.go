@@ -2,18 +2,27 @@
 package weboptions
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
 	"fmt"
 	"net"
 
+	provisioningpb "go.viam.com/api/provisioning/v1"
 	"go.viam.com/utils/rpc"
 
 	"go.viam.com/rdk/config"
+	rprovisioningpb "go.viam.com/rdk/proto/rdk/provisioning/v1"
 	"go.viam.com/rdk/utils"
 )
 
+// A ProvisioningServer serves the provisioning API along with the calls it has no counterpart for.
+type ProvisioningServer interface {
+	provisioningpb.ProvisioningServiceServer
+	rprovisioningpb.ProvisioningModeServiceServer
+}
+
 // Options are used for configuring the web server.
 type Options struct {
 	// Pprof turns on the pprof profiler accessible at /debug
@@ -72,6 +81,15 @@ type Options struct {
 
 	// If true, starts an insecure http server without TLS certificates even if one exists
 	NoTLS bool
+
+	// Provisioning, if set, is served so that the machine's network can be changed, or it can be put
+	// back into provisioning mode, remotely.
+	Provisioning ProvisioningServer
+
+	// ReloadConfigSection, if set, is called when the machine itself requests a reload of a single
+	// section of the config through the `/reload_config/<section>` endpoint. It should only schedule
+	// the reload, since reloading the network section restarts the web server.
+	ReloadConfigSection func(ctx context.Context, section config.Section) error
 }
 
 // New returns a default set of options which will have the
@@ -156,6 +174,11 @@ func FromConfig(cfg *config.Config) (Options, error) {
 		if cfg.Cloud.SignalingInsecure {
 			signalingDialOpts = append(signalingDialOpts, rpc.WithInsecure())
 		}
+		networkOpts, err := cfg.Cloud.DialOptions()
+		if err != nil {
+			return options, err
+		}
+		signalingDialOpts = append(signalingDialOpts, networkOpts...)
 
 		options.BakedAuthEntity = options.FQDN
 		options.BakedAuthCreds = rpc.Credentials{
@@ -0,0 +1,69 @@
+package web
+
+import (
+	"context"
+
+	apppb "go.viam.com/api/app/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/rdk/config"
+	rcpb "go.viam.com/rdk/proto/rdk/resourcechanges/v1"
+	"go.viam.com/rdk/protoutils"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+)
+
+// resourceChangesServer applies batches of resource changes to the robot of a web service, for
+// modules and other clients.
+type resourceChangesServer struct {
+	rcpb.UnimplementedResourceChangesServiceServer
+	svc *webService
+}
+
+// ApplyResourceChanges applies the resource changes of the request and returns the added and
+// updated resources that could not be built or reconfigured.
+func (s resourceChangesServer) ApplyResourceChanges(
+	ctx context.Context, req *rcpb.ApplyResourceChangesRequest,
+) (*rcpb.ApplyResourceChangesResponse, error) {
+	lr, ok := s.svc.r.(robot.LocalRobot)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "resource changes can only be applied to local robots")
+	}
+	fromProto := func(confs []*apppb.ComponentConfig) ([]resource.Config, error) {
+		out := make([]resource.Config, 0, len(confs))
+		for _, confProto := range confs {
+			conf, err := config.ComponentConfigFromProto(confProto, s.svc.logger)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid resource config %q: %v", confProto.GetName(), err)
+			}
+			out = append(out, *conf)
+		}
+		return out, nil
+	}
+	adds, err := fromProto(req.GetAdds())
+	if err != nil {
+		return nil, err
+	}
+	updates, err := fromProto(req.GetUpdates())
+	if err != nil {
+		return nil, err
+	}
+	removes := make([]resource.Name, 0, len(req.GetRemoves()))
+	for _, n := range req.GetRemoves() {
+		removes = append(removes, protoutils.ResourceNameFromProto(n))
+	}
+
+	resErrs, err := lr.ApplyResourceChanges(ctx, adds, removes, updates)
+	if err != nil {
+		return nil, err
+	}
+	resp := &rcpb.ApplyResourceChangesResponse{}
+	for name, err := range resErrs {
+		resp.Errors = append(resp.Errors, &rcpb.ResourceError{
+			Name:  protoutils.ResourceNameToProto(name),
+			Error: err.Error(),
+		})
+	}
+	return resp, nil
+}
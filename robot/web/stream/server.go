@@ -15,6 +15,7 @@ import (
 	streampb "go.viam.com/api/stream/v1"
 	"go.viam.com/utils"
 	"go.viam.com/utils/rpc"
+	"google.golang.org/grpc/metadata"
 
 	"go.viam.com/rdk/components/audioinput"
 	"go.viam.com/rdk/components/camera"
@@ -57,6 +58,8 @@ type Server struct {
 	activePeerStreams       map[*webrtc.PeerConnection]map[string]*peerState
 	activeBackgroundWorkers sync.WaitGroup
 	isAlive                 bool
+	// variants holds the streams of cameras with a Transform that subscribers have asked for.
+	variants map[streamVariant]*state.StreamState
 
 	streamConfig gostream.StreamConfig
 	videoSources map[string]gostream.HotSwappableVideoSource
@@ -85,6 +88,7 @@ func NewServer(
 		nameToStreamState: map[string]*state.StreamState{},
 		activePeerStreams: map[*webrtc.PeerConnection]map[string]*peerState{},
 		isAlive:           true,
+		variants:          map[streamVariant]*state.StreamState{},
 		streamConfig:      streamConfig,
 		videoSources:      map[string]gostream.HotSwappableVideoSource{},
 		audioSources:      map[string]gostream.HotSwappableAudioSource{},
@@ -150,6 +154,11 @@ func (server *Server) AddStream(ctx context.Context, req *streampb.AddStreamRequ
 	if !ok {
 		return nil, errors.New("can only add a stream over a WebRTC based connection")
 	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	transform, err := TransformFromMetadata(md)
+	if err != nil {
+		return nil, err
+	}
 
 	server.mu.Lock()
 	defer server.mu.Unlock()
@@ -176,6 +185,15 @@ func (server *Server) AddStream(ctx context.Context, req *streampb.AddStreamRequ
 	if isCamErr != nil && isAudioErr != nil {
 		return nil, errors.Errorf("stream is neither a camera nor audioinput. streamName: %v", streamStateToAdd.Stream)
 	}
+	if !transform.isIdentity() {
+		if isCamErr != nil {
+			return nil, errors.Errorf("stream %q is not a camera, so cannot be transformed", req.Name)
+		}
+		if streamStateToAdd, err = server.variantStreamState(req.Name, transform); err != nil {
+			server.logger.Error(err.Error())
+			return nil, err
+		}
+	}
 
 	var nameToPeerState map[string]*peerState
 	// return error if the caller's peer connection is already being sent stream data
@@ -278,12 +296,13 @@ func (server *Server) RemoveStream(ctx context.Context, req *streampb.RemoveStre
 		return &streampb.RemoveStreamResponse{}, nil
 	}
 
-	if _, ok := server.activePeerStreams[pc][req.Name]; !ok {
+	ps, ok := server.activePeerStreams[pc][req.Name]
+	if !ok {
 		return &streampb.RemoveStreamResponse{}, nil
 	}
 
 	var errs error
-	for _, sender := range server.activePeerStreams[pc][req.Name].senders {
+	for _, sender := range ps.senders {
 		errs = multierr.Combine(errs, pc.RemoveTrack(sender))
 	}
 	if errs != nil {
@@ -291,7 +310,8 @@ func (server *Server) RemoveStream(ctx context.Context, req *streampb.RemoveStre
 		return nil, errs
 	}
 
-	if err := streamToRemove.Decrement(); err != nil {
+	// the peer may be subscribed to a transformed variant of the stream rather than the stream
+	if err := ps.streamState.Decrement(); err != nil {
 		server.logger.Error(err.Error())
 		return nil, err
 	}
@@ -536,6 +556,9 @@ func (server *Server) Close() error {
 	for _, streamState := range server.nameToStreamState {
 		errs = multierr.Combine(errs, streamState.Close())
 	}
+	for _, streamState := range server.variants {
+		errs = multierr.Combine(errs, streamState.Close())
+	}
 	if errs != nil {
 		server.logger.Errorf("Stream Server Close > StreamState.Close() errs: %s", errs)
 	}
@@ -620,13 +643,62 @@ func (server *Server) removeMissingStreams() {
 				server.logger.Warn(errs.Error())
 			}
 
-			if err := streamState.Decrement(); err != nil {
+			if err := peerState.streamState.Decrement(); err != nil {
 				server.logger.Warn(err.Error())
 			}
 			delete(server.activePeerStreams[pc], camName)
 		}
 		utils.UncheckedError(streamState.Close())
+		for variant, variantState := range server.variants {
+			if variant.name == camName {
+				utils.UncheckedError(variantState.Close())
+				delete(server.variants, variant)
+			}
+		}
+	}
+}
+
+// streamVariant identifies a stream of a camera with a Transform.
+type streamVariant struct {
+	name      string
+	transform Transform
+}
+
+// variantStreamState returns the state of the stream of the named camera with the given transform,
+// creating and starting the stream if no subscriber has asked for it before. The transform is
+// applied on top of the video source of the untransformed stream, so it follows the camera
+// through reconfigures and resizes of that stream.
+func (server *Server) variantStreamState(name string, transform Transform) (*state.StreamState, error) {
+	variant := streamVariant{name: name, transform: transform.normalized()}
+	if streamState, ok := server.variants[variant]; ok {
+		return streamState, nil
+	}
+	source, ok := server.videoSources[name]
+	if !ok {
+		return nil, fmt.Errorf("video source %q not found", name)
+	}
+	framerate, err := server.getFramerateFromCamera(name)
+	if err != nil {
+		server.logger.Debugf("error getting framerate from camera %q: %v", name, err)
+	}
+	// the variant keeps the name of the camera, as subscribers identify their tracks by it.
+	stream, err := gostream.NewStream(gostream.StreamConfig{
+		Name:                name,
+		VideoEncoderFactory: server.streamConfig.VideoEncoderFactory,
+		TargetFrameRate:     framerate,
+	}, server.logger)
+	if err != nil {
+		return nil, err
+	}
+	streamState := state.New(stream, server.robot, server.logger.Sublogger(name))
+	// RTP passthrough would skip the transform, so the variant is always encoded by gostream.
+	if err := streamState.Resize(); err != nil {
+		utils.UncheckedError(streamState.Close())
+		return nil, err
 	}
+	server.startVideoStream(server.closedCtx, transform.VideoSource(source), stream)
+	server.variants[variant] = streamState
+	return streamState, nil
 }
 
 // refreshVideoSources checks and initializes every possible video source that could be viewed from the robot.
@@ -0,0 +1,146 @@
+package webstream
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"strconv"
+
+	"github.com/disintegration/imaging"
+	"github.com/pion/mediadevices/pkg/prop"
+	"golang.org/x/image/draw"
+	"google.golang.org/grpc/metadata"
+
+	"go.viam.com/rdk/gostream"
+	"go.viam.com/rdk/rimage"
+)
+
+// The metadata keys an AddStream request can set to have its subscription to a camera transformed,
+// as the request itself has no fields for it. See ContextWithTransform.
+const (
+	RotationMetadataKey  = "viam-stream-rotation"
+	DownscaleMetadataKey = "viam-stream-downscale"
+)
+
+// A Transform is applied by the stream server to the video of a camera for a single subscriber,
+// such as a mobile client that needs a portrait or low resolution variant of the camera, without
+// a transform camera having to be configured. Subscribers with the same transform of a camera
+// share one stream of it.
+type Transform struct {
+	// Rotation is how many degrees to rotate frames clockwise by; one of 0, 90, 180 or 270.
+	Rotation int
+	// Downscale is what to divide the width and height of frames by; one of 1, 2, 4 or 8. Zero
+	// is the same as 1.
+	Downscale int
+}
+
+// Validate returns an error if the transform is not supported.
+func (t Transform) Validate() error {
+	switch t.Rotation {
+	case 0, 90, 180, 270:
+	default:
+		return fmt.Errorf("stream rotation must be 0, 90, 180 or 270 degrees, got %d", t.Rotation)
+	}
+	switch t.Downscale {
+	case 0, 1, 2, 4, 8:
+	default:
+		return fmt.Errorf("stream downscale must be 1, 2, 4 or 8, got %d", t.Downscale)
+	}
+	return nil
+}
+
+// normalized returns the transform with defaults made explicit, so that equal transforms compare
+// equal.
+func (t Transform) normalized() Transform {
+	if t.Downscale == 0 {
+		t.Downscale = 1
+	}
+	return t
+}
+
+func (t Transform) isIdentity() bool {
+	return t.normalized() == Transform{Downscale: 1}
+}
+
+// ContextWithTransform returns a context that requests the given transform from the stream server
+// when used to call AddStream.
+func ContextWithTransform(ctx context.Context, t Transform) context.Context {
+	t = t.normalized()
+	return metadata.AppendToOutgoingContext(ctx,
+		RotationMetadataKey, strconv.Itoa(t.Rotation),
+		DownscaleMetadataKey, strconv.Itoa(t.Downscale))
+}
+
+// TransformFromMetadata returns the transform requested by the given AddStream request metadata,
+// which has no rotation or downscaling if it does not request one.
+func TransformFromMetadata(md metadata.MD) (Transform, error) {
+	var t Transform
+	for key, field := range map[string]*int{RotationMetadataKey: &t.Rotation, DownscaleMetadataKey: &t.Downscale} {
+		values := md.Get(key)
+		if len(values) == 0 {
+			continue
+		}
+		v, err := strconv.Atoi(values[len(values)-1])
+		if err != nil {
+			return Transform{}, fmt.Errorf("invalid %s metadata %q: %w", key, values[len(values)-1], err)
+		}
+		*field = v
+	}
+	if err := t.Validate(); err != nil {
+		return Transform{}, err
+	}
+	return t.normalized(), nil
+}
+
+// VideoSource returns a source of the frames of src with the transform applied. Closing it does
+// not close src.
+func (t Transform) VideoSource(src gostream.VideoSource) gostream.VideoSource {
+	return gostream.NewVideoSource(&transformVideoSource{
+		stream:    gostream.NewEmbeddedVideoStream(src),
+		transform: t.normalized(),
+	}, prop.Video{})
+}
+
+type transformVideoSource struct {
+	stream    gostream.VideoStream
+	transform Transform
+}
+
+// Read returns the next frame of the source, downscaled and then rotated.
+func (tvs *transformVideoSource) Read(ctx context.Context) (image.Image, func(), error) {
+	img, release, err := tvs.stream.Next(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if release != nil {
+		defer release()
+	}
+
+	// frames are copied even when only rotating, as img is released once this returns.
+	// dimensions are kept even for the sake of encoders.
+	width := max((img.Bounds().Dx()/tvs.transform.Downscale)&^1, 2)
+	height := max((img.Bounds().Dy()/tvs.transform.Downscale)&^1, 2)
+	scaled := rimage.GetRGBA(image.Rect(0, 0, width, height))
+	draw.ApproxBiLinear.Scale(scaled, scaled.Bounds(), img, img.Bounds(), draw.Src, nil)
+
+	var rotated image.Image
+	switch tvs.transform.Rotation {
+	case 90:
+		// imaging rotates counter-clockwise
+		rotated = imaging.Rotate270(scaled)
+	case 180:
+		rotated = imaging.Rotate180(scaled)
+	case 270:
+		rotated = imaging.Rotate90(scaled)
+	default:
+		return scaled, func() { rimage.PutRGBA(scaled) }, nil
+	}
+	rimage.PutRGBA(scaled)
+	return rotated, func() {}, nil
+}
+
+// Close closes the stream of the underlying source, but not the source itself, which is shared
+// with the untransformed stream of the camera.
+func (tvs *transformVideoSource) Close(ctx context.Context) error {
+	return tvs.stream.Close(ctx)
+}
@@ -0,0 +1,68 @@
+//go:build !no_cgo
+
+package webstream_test
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/pion/mediadevices/pkg/prop"
+	"go.viam.com/test"
+	"google.golang.org/grpc/metadata"
+
+	"go.viam.com/rdk/gostream"
+	webstream "go.viam.com/rdk/robot/web/stream"
+)
+
+func TestTransformFromMetadata(t *testing.T) {
+	transform, err := webstream.TransformFromMetadata(nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, transform, test.ShouldResemble, webstream.Transform{Downscale: 1})
+
+	ctx := webstream.ContextWithTransform(context.Background(), webstream.Transform{Rotation: 90, Downscale: 4})
+	md, ok := metadata.FromOutgoingContext(ctx)
+	test.That(t, ok, test.ShouldBeTrue)
+	transform, err = webstream.TransformFromMetadata(md)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, transform, test.ShouldResemble, webstream.Transform{Rotation: 90, Downscale: 4})
+
+	_, err = webstream.TransformFromMetadata(metadata.Pairs(webstream.RotationMetadataKey, "45"))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "rotation")
+	_, err = webstream.TransformFromMetadata(metadata.Pairs(webstream.DownscaleMetadataKey, "3"))
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = webstream.TransformFromMetadata(metadata.Pairs(webstream.DownscaleMetadataKey, "half"))
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestTransformVideoSource(t *testing.T) {
+	// a 640x480 frame that is red in its top left corner
+	frame := image.NewRGBA(image.Rect(0, 0, 640, 480))
+	for x := 0; x < 320; x++ {
+		for y := 0; y < 240; y++ {
+			frame.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	src := gostream.NewVideoSource(gostream.VideoReaderFunc(func(ctx context.Context) (image.Image, func(), error) {
+		return frame, func() {}, nil
+	}), prop.Video{})
+
+	transformed := webstream.Transform{Rotation: 90, Downscale: 2}.VideoSource(src)
+	defer func() {
+		test.That(t, transformed.Close(context.Background()), test.ShouldBeNil)
+	}()
+	img, release, err := gostream.ReadImage(context.Background(), transformed)
+	test.That(t, err, test.ShouldBeNil)
+	defer release()
+
+	// downscaled to 320x240, then rotated clockwise into portrait
+	test.That(t, img.Bounds().Dx(), test.ShouldEqual, 240)
+	test.That(t, img.Bounds().Dy(), test.ShouldEqual, 320)
+	// the red top left corner is now the top right corner
+	r, _, _, _ := img.At(230, 10).RGBA()
+	test.That(t, r, test.ShouldBeGreaterThan, 0)
+	r, _, _, _ = img.At(10, 10).RGBA()
+	test.That(t, r, test.ShouldEqual, 0)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/rs/cors"
 	"go.opencensus.io/trace"
 	"go.uber.org/multierr"
+	provisioningpb "go.viam.com/api/provisioning/v1"
 	pb "go.viam.com/api/robot/v1"
 	"go.viam.com/utils"
 	echopb "go.viam.com/utils/proto/rpc/examples/echo/v1"
@@ -36,12 +37,16 @@ import (
 	"go.viam.com/rdk/grpc"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/module"
+	lbpb "go.viam.com/rdk/proto/rdk/lowbandwidth/v1"
+	rprovisioningpb "go.viam.com/rdk/proto/rdk/provisioning/v1"
+	rcpb "go.viam.com/rdk/proto/rdk/resourcechanges/v1"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/robot"
 	grpcserver "go.viam.com/rdk/robot/server"
 	weboptions "go.viam.com/rdk/robot/web/options"
 	webstream "go.viam.com/rdk/robot/web/stream"
 	rutils "go.viam.com/rdk/utils"
+	"go.viam.com/rdk/utils/ssync"
 )
 
 // SubtypeName is a constant that identifies the internal web resource subtype string.
@@ -76,11 +81,19 @@ type Service interface {
 	// Returns the unix socket path the module server listens on.
 	ModuleAddresses() config.ParentSockAddrs
 
+	// Handler returns the HTTP handler of the web server, or nil if it is not started. It can
+	// be mounted under a path by another server to serve gRPC-Web and REST requests.
+	Handler() http.Handler
+
 	Stats() any
 
 	RequestCounter() *RequestCounter
 
 	ModPeerConnTracker() *grpc.ModPeerConnTracker
+
+	// UpdateMethodPolicies replaces the default deadlines and retry policies of the methods of
+	// resource APIs.
+	UpdateMethodPolicies(policies []config.MethodPolicyConfig)
 }
 
 type webService struct {
@@ -98,6 +111,7 @@ type webService struct {
 	opts         options
 	addr         string
 	modAddrs     config.ParentSockAddrs
+	httpHandler  http.Handler
 	logger       logging.Logger
 	cancelCtx    context.Context
 	cancelFunc   func()
@@ -107,6 +121,11 @@ type webService struct {
 
 	requestCounter     RequestCounter
 	modPeerConnTracker *grpc.ModPeerConnTracker
+
+	// serviceAPIs maps the names of the gRPC services of resource APIs to those APIs.
+	serviceAPIs ssync.Map[string, resource.API]
+
+	methodPolicies grpc.MethodPolicies
 }
 
 // New returns a new web service for the given robot.
@@ -173,11 +192,14 @@ func RunWeb(ctx context.Context, r robot.LocalRobot, o weboptions.Options, logge
 		}
 	}()
 
-	if err := r.StartWeb(ctx, o); err != nil {
+	// the web service outlives ctx so that it can keep serving while operations drain.
+	if err := r.StartWeb(context.WithoutCancel(ctx), o); err != nil {
 		return err
 	}
 	<-ctx.Done()
 	logger.Info("Viam RDK shutting down")
+	drainOperations(r.OperationManager(), rutils.GetShutdownDrainTimeout(logger), logger)
+	r.StopWeb()
 	return ctx.Err()
 }
 
@@ -197,6 +219,16 @@ func (svc *webService) Address() string {
 	return svc.addr
 }
 
+// Handler returns the HTTP handler of the web server, or nil if it is not started.
+func (svc *webService) Handler() http.Handler {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	if !svc.isRunning {
+		return nil
+	}
+	return svc.httpHandler
+}
+
 // ModuleAddress returns the unix socket path the module server is listening on.
 func (svc *webService) ModuleAddresses() config.ParentSockAddrs {
 	svc.mu.Lock()
@@ -245,7 +277,10 @@ func (svc *webService) startProtocolModuleParentServer(ctx context.Context, tcpM
 		streamInterceptors []googlegrpc.StreamServerInterceptor
 	)
 
-	unaryInterceptors = append(unaryInterceptors, grpc.EnsureTimeoutUnaryServerInterceptor)
+	// default deadlines, from the method policies or else grpc.DefaultMethodTimeout
+	unaryInterceptors = append(unaryInterceptors, svc.methodPolicies.UnaryServerInterceptor)
+	streamInterceptors = append(streamInterceptors, svc.methodPolicies.StreamServerInterceptor)
+	unaryInterceptors = append(unaryInterceptors, grpc.ServerTimesUnaryServerInterceptor)
 
 	// Attach the module name (as defined by the robot config) to the handler context. Can be
 	// accessed via `grpc.GetModuleName`.
@@ -253,6 +288,8 @@ func (svc *webService) startProtocolModuleParentServer(ctx context.Context, tcpM
 
 	unaryInterceptors = append(unaryInterceptors, svc.requestCounter.UnaryInterceptor)
 	streamInterceptors = append(streamInterceptors, svc.requestCounter.StreamInterceptor)
+	unaryInterceptors = append(unaryInterceptors, svc.lazyStartUnaryInterceptor)
+	streamInterceptors = append(streamInterceptors, svc.lazyStartStreamInterceptor)
 
 	opManager := svc.r.OperationManager()
 	unaryInterceptors = append(unaryInterceptors,
@@ -277,6 +314,10 @@ func (svc *webService) startProtocolModuleParentServer(ctx context.Context, tcpM
 	if err := server.RegisterServiceServer(ctx, &pb.RobotService_ServiceDesc, grpcserver.New(svc.r)); err != nil {
 		return err
 	}
+	// modules that manage resources dynamically apply their changes through their parent
+	if err := server.RegisterServiceServer(ctx, &rcpb.ResourceChangesService_ServiceDesc, resourceChangesServer{svc: svc}); err != nil {
+		return err
+	}
 
 	if err := svc.initStreamServer(ctx, server); err != nil {
 		return err
@@ -401,6 +442,7 @@ func (svc *webService) stopWeb() {
 		svc.cancelFunc()
 	}
 	svc.isRunning = false
+	svc.httpHandler = nil
 	svc.webWorkers.Wait()
 }
 
@@ -475,15 +517,30 @@ func (svc *webService) runWeb(ctx context.Context, options weboptions.Options) (
 		options.SignalingAddress = svc.addr
 	}
 
+	robotServer := grpcserver.New(svc.r)
 	if err := svc.rpcServer.RegisterServiceServer(
 		ctx,
 		&pb.RobotService_ServiceDesc,
-		grpcserver.New(svc.r),
+		robotServer,
 		pb.RegisterRobotServiceHandlerFromEndpoint,
 	); err != nil {
 		return err
 	}
 
+	if err := svc.rpcServer.RegisterServiceServer(ctx, &lbpb.LowBandwidthService_ServiceDesc, robotServer); err != nil {
+		return err
+	}
+
+	if err := svc.rpcServer.RegisterServiceServer(ctx, &grpc.IntrospectionServiceDesc, introspectionServer{svc}); err != nil {
+		return err
+	}
+
+	if err := svc.rpcServer.RegisterServiceServer(
+		ctx, &rcpb.ResourceChangesService_ServiceDesc, resourceChangesServer{svc: svc},
+	); err != nil {
+		return err
+	}
+
 	if err := svc.initAPIResourceCollections(ctx, svc.rpcServer); err != nil {
 		return err
 	}
@@ -495,6 +552,24 @@ func (svc *webService) runWeb(ctx context.Context, options weboptions.Options) (
 		return err
 	}
 
+	if options.Provisioning != nil {
+		if err := svc.rpcServer.RegisterServiceServer(
+			ctx,
+			&provisioningpb.ProvisioningService_ServiceDesc,
+			options.Provisioning,
+			provisioningpb.RegisterProvisioningServiceHandlerFromEndpoint,
+		); err != nil {
+			return err
+		}
+		if err := svc.rpcServer.RegisterServiceServer(
+			ctx,
+			&rprovisioningpb.ProvisioningModeService_ServiceDesc,
+			options.Provisioning,
+		); err != nil {
+			return err
+		}
+	}
+
 	if options.Debug {
 		if err := svc.rpcServer.RegisterServiceServer(
 			ctx,
@@ -517,17 +592,21 @@ func (svc *webService) runWeb(ctx context.Context, options weboptions.Options) (
 	utils.PanicCapturingGo(func() {
 		defer svc.webWorkers.Done()
 		<-ctx.Done()
-		defer func() {
-			if err := httpServer.Shutdown(context.Background()); err != nil {
+		svc.closeStreamServer()
+		// shutting the HTTP server down sends HTTP/2 clients a GOAWAY, so that they stop
+		// sending requests before their connections are closed.
+		goAwayCtx, cancel := context.WithTimeout(context.Background(), goAwayGracePeriod)
+		shutdownErr := httpServer.Shutdown(goAwayCtx)
+		cancel()
+		if err := svc.rpcServer.Stop(); err != nil {
+			svc.logger.Errorw("error stopping rpc server", "error", err)
+		}
+		if shutdownErr != nil {
+			// some connections were still busy, such as with long lived streams
+			if err := httpServer.Close(); err != nil {
 				svc.logger.Errorw("error shutting down", "error", err)
 			}
-		}()
-		defer func() {
-			if err := svc.rpcServer.Stop(); err != nil {
-				svc.logger.Errorw("error stopping rpc server", "error", err)
-			}
-		}()
-		svc.closeStreamServer()
+		}
 	})
 	svc.webWorkers.Add(1)
 	utils.PanicCapturingGo(func() {
@@ -583,6 +662,62 @@ func (svc *webService) ModPeerConnTracker() *grpc.ModPeerConnTracker {
 	return svc.modPeerConnTracker
 }
 
+// UpdateMethodPolicies replaces the default deadlines and retry policies of the methods of
+// resource APIs. Policies for APIs that are not registered are ignored.
+func (svc *webService) UpdateMethodPolicies(policies []config.MethodPolicyConfig) {
+	apiRegs := resource.RegisteredAPIs()
+	methodPolicies := make(map[string]grpc.MethodPolicy, len(policies))
+	for _, policyConf := range policies {
+		api, err := resource.NewAPIFromString(policyConf.API)
+		if err != nil {
+			svc.logger.Warnw("ignoring method policy with invalid api", "api", policyConf.API, "error", err)
+			continue
+		}
+		reg, ok := apiRegs[api]
+		if !ok || reg.RPCServiceDesc == nil {
+			svc.logger.Warnw("ignoring method policy for api without a gRPC service", "api", policyConf.API)
+			continue
+		}
+		retryCodes, err := policyConf.ParseRetryCodes()
+		if err != nil {
+			svc.logger.Warnw("ignoring method policy with invalid retry codes", "api", policyConf.API, "error", err)
+			continue
+		}
+		policy := grpc.MethodPolicy{
+			Timeout:      policyConf.Timeout,
+			MaxAttempts:  policyConf.MaxAttempts,
+			RetryBackoff: policyConf.RetryBackoff,
+			RetryCodes:   retryCodes,
+		}
+		matched := false
+		for _, desc := range []*googlegrpc.ServiceDesc{reg.RPCServiceDesc, reg.StreamRPCServiceDesc} {
+			if desc != nil && (policyConf.Method == "" || serviceHasMethod(desc, policyConf.Method)) {
+				methodPolicies["/"+desc.ServiceName+"/"+policyConf.Method] = policy
+				matched = true
+			}
+		}
+		if !matched {
+			svc.logger.Warnw("ignoring method policy for unknown method", "api", policyConf.API, "method", policyConf.Method)
+		}
+	}
+	svc.methodPolicies.Set(methodPolicies)
+}
+
+// serviceHasMethod returns whether a gRPC service has a unary or streaming method of the given name.
+func serviceHasMethod(desc *googlegrpc.ServiceDesc, method string) bool {
+	for _, m := range desc.Methods {
+		if m.MethodName == method {
+			return true
+		}
+	}
+	for _, st := range desc.Streams {
+		if st.StreamName == method {
+			return true
+		}
+	}
+	return false
+}
+
 // Initialize RPC Server options.
 func (svc *webService) initRPCOptions(listenerTCPAddr *net.TCPAddr, options weboptions.Options) ([]rpc.ServerOption, error) {
 	hosts := options.GetHosts(listenerTCPAddr)
@@ -616,10 +751,16 @@ func (svc *webService) initRPCOptions(listenerTCPAddr *net.TCPAddr, options webo
 		unaryInterceptors  []googlegrpc.UnaryServerInterceptor
 		streamInterceptors []googlegrpc.StreamServerInterceptor
 	)
-	unaryInterceptors = append(unaryInterceptors, grpc.EnsureTimeoutUnaryServerInterceptor)
+	// default deadlines, from the method policies or else grpc.DefaultMethodTimeout
+	unaryInterceptors = append(unaryInterceptors, svc.methodPolicies.UnaryServerInterceptor)
+	streamInterceptors = append(streamInterceptors, svc.methodPolicies.StreamServerInterceptor)
+	// report when requests are handled so that clients can estimate our clock offset
+	unaryInterceptors = append(unaryInterceptors, grpc.ServerTimesUnaryServerInterceptor)
 
 	unaryInterceptors = append(unaryInterceptors, svc.requestCounter.UnaryInterceptor)
 	streamInterceptors = append(streamInterceptors, svc.requestCounter.StreamInterceptor)
+	unaryInterceptors = append(unaryInterceptors, svc.lazyStartUnaryInterceptor)
+	streamInterceptors = append(streamInterceptors, svc.lazyStartStreamInterceptor)
 
 	if options.Debug {
 		rpcOpts = append(rpcOpts, rpc.WithDebug())
@@ -767,6 +908,12 @@ func (svc *webService) initAPIResourceCollections(ctx context.Context, server rp
 		if err := rs.RegisterRPCService(ctx, server, apiResColl); err != nil {
 			return err
 		}
+		if rs.RPCServiceDesc != nil {
+			svc.serviceAPIs.Store(rs.RPCServiceDesc.ServiceName, s)
+		}
+		if rs.StreamRPCServiceDesc != nil {
+			svc.serviceAPIs.Store(rs.StreamRPCServiceDesc.ServiceName, s)
+		}
 	}
 	return nil
 }
@@ -774,6 +921,7 @@ func (svc *webService) initAPIResourceCollections(ctx context.Context, server rp
 // Initialize HTTP server.
 func (svc *webService) initHTTPServer(listenerTCPAddr *net.TCPAddr, options weboptions.Options) (*http.Server, error) {
 	mux := svc.initMux(options)
+	svc.httpHandler = mux
 
 	httpServer, err := utils.NewPossiblySecureHTTPServer(mux, utils.HTTPServerOptions{
 		Secure:         options.Secure,
@@ -815,6 +963,11 @@ func (svc *webService) initMux(options weboptions.Options) *goji.Mux {
 	// serve restart status
 	mux.HandleFunc(pat.New("/restart_status"), svc.handleRestartStatus)
 
+	// serve config section reloads, for platforms without the signals that also trigger them
+	if options.ReloadConfigSection != nil {
+		mux.HandleFunc(pat.Post("/reload_config/:section"), handleReloadConfigSection(options.ReloadConfigSection))
+	}
+
 	prefix := "/viam"
 	addPrefix := func(h http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -1061,3 +1214,25 @@ func (svc *webService) handleRestartStatus(w http.ResponseWriter, r *http.Reques
 	// happen.
 	utils.UncheckedError(json.NewEncoder(w).Encode(response))
 }
+
+// Handles the `/reload_config/<section>` endpoint. Like the signals that do the same, it can only
+// be used from the machine itself.
+func handleReloadConfigSection(reload func(context.Context, config.Section) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if ip := net.ParseIP(host); err != nil || ip == nil || !ip.IsLoopback() {
+			http.Error(w, "config sections can only be reloaded from the machine itself", http.StatusForbidden)
+			return
+		}
+		section, err := config.ParseSection(pat.Param(r, "section"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := reload(r.Context(), section); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"net/http"
 	"os"
 	"sync"
 	"testing"
@@ -21,6 +22,7 @@ import (
 	"github.com/jhump/protoreflect/grpcreflect"
 	"github.com/lestrrat-go/jwx/jwk"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	armpb "go.viam.com/api/component/arm/v1"
 	echopb "go.viam.com/api/component/testecho/v1"
 	robotpb "go.viam.com/api/robot/v1"
 	streampb "go.viam.com/api/stream/v1"
@@ -91,6 +93,78 @@ func TestWebStart(t *testing.T) {
 	test.That(t, conn.Close(), test.ShouldBeNil)
 }
 
+func TestWebReloadConfigSection(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ctx, injectRobot := setupRobotCtx(t)
+
+	svc := web.New(injectRobot, logger)
+
+	options, _, addr := robottestutils.CreateBaseOptionsAndListener(t)
+	var reloaded []config.Section
+	options.ReloadConfigSection = func(ctx context.Context, section config.Section) error {
+		reloaded = append(reloaded, section)
+		return nil
+	}
+	err := svc.Start(ctx, options)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, svc.Close(context.Background()), test.ShouldBeNil)
+	}()
+
+	post := func(section string) int {
+		resp, err := http.Post(fmt.Sprintf("http://%s/reload_config/%s", addr, section), "", nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp.Body.Close(), test.ShouldBeNil)
+		return resp.StatusCode
+	}
+	test.That(t, post("log"), test.ShouldEqual, http.StatusAccepted)
+	test.That(t, post("network"), test.ShouldEqual, http.StatusAccepted)
+	test.That(t, post("components"), test.ShouldEqual, http.StatusBadRequest)
+	test.That(t, reloaded, test.ShouldResemble, []config.Section{config.SectionLog, config.SectionNetwork})
+}
+
+func TestWebMethodPolicies(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	var remaining time.Duration
+	ctx, injectRobot := setupRobotCtx(t, withArmEndPosition(
+		func(ctx context.Context, extra map[string]interface{}) (spatialmath.Pose, error) {
+			if deadline, ok := ctx.Deadline(); ok {
+				remaining = time.Until(deadline)
+			}
+			return pos, nil
+		}))
+
+	svc := web.New(injectRobot, logger)
+	svc.UpdateMethodPolicies([]config.MethodPolicyConfig{
+		{API: arm.API.String(), Method: "GetEndPosition", Timeout: 2 * time.Second, MaxAttempts: 3},
+		{API: "acme:component:unregistered", Timeout: time.Second},
+	})
+
+	options, _, addr := robottestutils.CreateBaseOptionsAndListener(t)
+	test.That(t, svc.Start(ctx, options), test.ShouldBeNil)
+	conn, err := rgrpc.Dial(context.Background(), addr, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	var header metadata.MD
+	_, err = armpb.NewArmServiceClient(conn).GetEndPosition(context.Background(),
+		&armpb.GetEndPositionRequest{Name: arm1String}, grpc.Header(&header))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, remaining, test.ShouldBeBetweenOrEqual, time.Second, 2*time.Second)
+	test.That(t, header.Get(rgrpc.MethodPolicyMetadataKey), test.ShouldHaveLength, 1)
+
+	// removing the policy leaves the default timeout
+	svc.UpdateMethodPolicies(nil)
+	header = metadata.MD{}
+	_, err = armpb.NewArmServiceClient(conn).GetEndPosition(context.Background(),
+		&armpb.GetEndPositionRequest{Name: arm1String}, grpc.Header(&header))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, remaining, test.ShouldBeGreaterThan, time.Minute)
+	test.That(t, header.Get(rgrpc.MethodPolicyMetadataKey), test.ShouldBeEmpty)
+
+	test.That(t, svc.Close(context.Background()), test.ShouldBeNil)
+	test.That(t, conn.Close(), test.ShouldBeNil)
+}
+
 func TestModule(t *testing.T) {
 	logger := logging.NewTestLogger(t)
 	ctx, injectRobot := setupRobotCtx(t)
@@ -27,6 +27,7 @@ import (
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/services/datamanager"
 	"go.viam.com/rdk/services/datamanager/builtin/capture"
+	"go.viam.com/rdk/services/datamanager/builtin/notify"
 	"go.viam.com/rdk/services/datamanager/builtin/shared"
 	datasync "go.viam.com/rdk/services/datamanager/builtin/sync"
 	"go.viam.com/rdk/services/slam"
@@ -86,6 +87,7 @@ type builtIn struct {
 	capture           *capture.Capture
 	sync              *datasync.Sync
 	diskSummaryLogger *diskSummaryLogger
+	notifier          *notify.Notifier
 }
 
 // New returns a new builtin data manager service for the given robot.
@@ -99,8 +101,11 @@ func New(
 ) (datamanager.Service, error) {
 	logger.Info("New START")
 	defer logger.Info("New END")
+	// capture and sync report problems with the data pipeline through the notifier
+	notifier := notify.New(logger.Sublogger("notify"))
 	capture := capture.New(
 		clk,
+		notifier,
 		logger.Sublogger("capture"),
 	)
 	// sync needs to be able to flush collectors so that in memory data can be flushed to disk before a given sync interval
@@ -110,6 +115,7 @@ func New(
 		connToConnectivityStateEnabled,
 		capture.FlushCollectors,
 		clk,
+		notifier,
 		logger.Sublogger("sync"),
 	)
 	diskSummaryLogger := newDiskSummaryLogger(logger)
@@ -119,9 +125,11 @@ func New(
 		capture:           capture,
 		sync:              sync,
 		diskSummaryLogger: diskSummaryLogger,
+		notifier:          notifier,
 	}
 
 	if err := svc.Reconfigure(ctx, deps, conf); err != nil {
+		notifier.Close()
 		return nil, err
 	}
 	return svc, nil
@@ -136,6 +144,7 @@ func (b *builtIn) Close(ctx context.Context) error {
 	b.diskSummaryLogger.close()
 	b.capture.Close(ctx)
 	b.sync.Close()
+	b.notifier.Close()
 	return nil
 }
 
@@ -153,6 +162,25 @@ func (b *builtIn) Sync(ctx context.Context, extra map[string]interface{}) error
 	return b.sync.Sync(ctx, extra)
 }
 
+// DoCommand starts capture bursts, see datamanager.CaptureBurstCommand.
+func (b *builtIn) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	value, ok := cmd[datamanager.CaptureBurstCommand]
+	if !ok {
+		return nil, resource.ErrDoUnimplemented
+	}
+	burst, err := datamanager.CaptureBurstFromCommand(value)
+	if err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	started, err := b.capture.Burst(burst)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{datamanager.CaptureBurstCommand: started.ToCommand()}, nil
+}
+
 // Reconfigure updates the data manager service when the config has changed.
 // At time of writing Reconfigure only returns an error in one of the following unrecoverable error cases:
 //  1. There is some static (aka compile time) error which we currently are only able to detected at runtime:
@@ -196,6 +224,7 @@ func (b *builtIn) Reconfigure(ctx context.Context, deps resource.Dependencies, c
 		// If this error occurs it's a resource graph error
 		return err
 	}
+	captureConfig.VisionServices = autoLabelVisionServicesFromDeps(collectorConfigsByResource, deps, b.logger)
 	if err := os.MkdirAll(captureConfig.CaptureDir, 0o700); err != nil {
 		b.logger.Warnf("failed to create capture directory: %s", captureConfig.CaptureDir)
 	}
@@ -208,6 +237,7 @@ func (b *builtIn) Reconfigure(ctx context.Context, deps resource.Dependencies, c
 	// These Reconfigure calls are the only methods in builtin.Reconfigure which create / destroy resources.
 	// It is important that no errors happen for a given Reconfigure call after we being callin Reconfigure on capture & sync
 	// or we could leak goroutines, wasting resources and cauing bugs due to duplicate work.
+	b.notifier.Reconfigure(c.Notifications)
 	b.diskSummaryLogger.reconfigure(syncConfig.SyncPaths(), diskSummaryLogInterval)
 	b.capture.Reconfigure(ctx, collectorConfigsByResource, captureConfig)
 	b.sync.Reconfigure(ctx, syncConfig, cloudConnSvc)
@@ -229,6 +259,35 @@ func syncSensorFromDeps(name string, deps resource.Dependencies, logger logging.
 	return syncSensor, true
 }
 
+// autoLabelVisionServicesFromDeps returns the vision services that collectors are configured to
+// auto label images with, by name.
+func autoLabelVisionServicesFromDeps(
+	collectorConfigsByResource capture.CollectorConfigsByResource,
+	deps resource.Dependencies,
+	logger logging.Logger,
+) map[string]vision.Service {
+	visionServices := map[string]vision.Service{}
+	for _, collectorConfigs := range collectorConfigsByResource {
+		for _, collectorConfig := range collectorConfigs {
+			if collectorConfig.AutoLabel == nil {
+				continue
+			}
+			name := collectorConfig.AutoLabel.VisionService
+			if _, ok := visionServices[name]; ok {
+				continue
+			}
+			visionSvc, err := vision.FromDependencies(deps, name)
+			if err != nil {
+				// collectors that auto label with it are not created until it is found
+				logger.Warnw("unable to find vision service to auto label captured images with", "name", name, "error", err)
+				continue
+			}
+			visionServices[name] = visionSvc
+		}
+	}
+	return visionServices
+}
+
 // Lookup the collector configs associated with the data manager service.
 func lookupCollectorConfigsByResource(
 	deps resource.Dependencies,
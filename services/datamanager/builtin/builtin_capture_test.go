@@ -2,6 +2,7 @@ package builtin
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 	"sync"
 	"testing"
@@ -15,6 +16,7 @@ import (
 	"go.viam.com/rdk/data"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/services/datamanager"
 	datasync "go.viam.com/rdk/services/datamanager/builtin/sync"
 	"go.viam.com/rdk/spatialmath"
 	"go.viam.com/rdk/testutils/inject"
@@ -287,6 +289,83 @@ func TestReconfigureResource(t *testing.T) {
 	test.That(t, captureDataHasNonZeroReadings, test.ShouldBeTrue)
 }
 
+func TestCaptureBurst(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	captureDir := t.TempDir()
+
+	r := setupRobot(nil, map[resource.Name]resource.Resource{
+		arm.Named("arm1"): &inject.Arm{
+			EndPositionFunc: func(
+				ctx context.Context,
+				extra map[string]interface{},
+			) (spatialmath.Pose, error) {
+				return spatialmath.NewZeroPose(), nil
+			},
+		},
+	})
+	// the only collector is disabled, so the burst captures everything in the capture directory
+	config, deps := setupConfig(t, r, disabledTabularCollectorConfigPath)
+	c := config.ConvertedAttributes.(*Config)
+	c.CaptureDir = captureDir
+	c.ScheduledSyncDisabled = true
+	c.Tags = []string{"service"}
+	c.MaximumCaptureFileSizeBytes = 1
+
+	b, err := New(context.Background(), deps, config, datasync.NoOpCloudClientConstructor, connToConnectivityStateError, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() { test.That(t, b.Close(context.Background()), test.ShouldBeNil) }()
+	test.That(t, len(getAllFileInfos(captureDir)), test.ShouldEqual, 0)
+
+	_, err = datamanager.StartCaptureBurst(context.Background(), b, datamanager.CaptureBurst{
+		Resources: []string{"arm2"}, Duration: time.Second, FrequencyHz: 10,
+	})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "no capture methods configured")
+
+	_, err = datamanager.StartCaptureBurst(context.Background(), b, datamanager.CaptureBurst{
+		Resources: []string{"arm1"}, Duration: time.Hour, FrequencyHz: 10,
+	})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	burst := datamanager.CaptureBurst{
+		Resources: []string{"arm1"}, Duration: 200 * time.Millisecond, FrequencyHz: 100, Tags: []string{"diagnostic"},
+	}
+	start := time.Now()
+	started, err := datamanager.StartCaptureBurst(context.Background(), b, burst)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, started.Methods, test.ShouldResemble, []string{"rdk:component:arm/arm1/EndPosition"})
+	test.That(t, started.EndsAt, test.ShouldHappenOnOrAfter, start.Add(burst.Duration))
+
+	// the same method cannot be in two bursts at once
+	_, err = datamanager.StartCaptureBurst(context.Background(), b, burst)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "already being captured")
+
+	waitForCaptureFilesToExceedNFiles(captureDir, 0, logger)
+	sd, err := getSensorData(captureDir)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(sd), test.ShouldBeGreaterThan, 0)
+	for _, path := range getAllFilePaths(captureDir) {
+		if filepath.Ext(path) != data.CompletedCaptureFileExt {
+			continue
+		}
+		f, err := os.Open(path)
+		test.That(t, err, test.ShouldBeNil)
+		captureFile, err := data.ReadCaptureFile(f)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, captureFile.ReadMetadata().GetTags(), test.ShouldResemble, []string{"service", "diagnostic"})
+		test.That(t, f.Close(), test.ShouldBeNil)
+	}
+
+	// once the burst ends, capture stops and the method can be burst again
+	time.Sleep(time.Until(started.EndsAt) + 100*time.Millisecond)
+	numFiles := len(getAllFileInfos(captureDir))
+	time.Sleep(100 * time.Millisecond)
+	test.That(t, len(getAllFileInfos(captureDir)), test.ShouldEqual, numFiles)
+	_, err = datamanager.StartCaptureBurst(context.Background(), b, burst)
+	test.That(t, err, test.ShouldBeNil)
+}
+
 func getSensorData(dir string) ([]*v1.SensorData, error) {
 	var sd []*v1.SensorData
 	filePaths := getAllFilePaths(dir)
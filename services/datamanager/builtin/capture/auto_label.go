@@ -0,0 +1,211 @@
+package capture
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "go.viam.com/api/app/datasync/v1"
+	goutils "go.viam.com/utils"
+
+	"go.viam.com/rdk/data"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/services/datamanager"
+	"go.viam.com/rdk/services/vision"
+	"go.viam.com/rdk/utils"
+)
+
+const (
+	// AutoLabelSidecarExt is the extension of the sidecar files holding the labels of captured
+	// images.
+	AutoLabelSidecarExt = ".labels.json"
+	// autoLabelQueueSize is how many captured images can be waiting to be labeled before new ones
+	// are left unlabeled, so that a slow vision service never holds up capture.
+	autoLabelQueueSize = 10
+)
+
+// ImageLabels is the content of an auto label sidecar file. The image it labels is identified by
+// its SHA-256 hash and by when it was captured.
+type ImageLabels struct {
+	Component       string                `json:"component"`
+	Method          string                `json:"method"`
+	VisionService   string                `json:"vision_service"`
+	TimeRequested   time.Time             `json:"time_requested"`
+	TimeReceived    time.Time             `json:"time_received"`
+	MimeType        string                `json:"mime_type"`
+	ImageSHA256     string                `json:"image_sha256"`
+	BoundingBoxes   []LabelBoundingBox    `json:"bounding_boxes,omitempty"`
+	Classifications []LabelClassification `json:"classifications,omitempty"`
+}
+
+// LabelBoundingBox is a detection, with coordinates normalized to the size of the image.
+type LabelBoundingBox struct {
+	Label      string  `json:"label"`
+	Confidence float64 `json:"confidence"`
+	XMin       float64 `json:"x_min_normalized"`
+	YMin       float64 `json:"y_min_normalized"`
+	XMax       float64 `json:"x_max_normalized"`
+	YMax       float64 `json:"y_max_normalized"`
+}
+
+// LabelClassification is a classification of a whole image.
+type LabelClassification struct {
+	Label      string  `json:"label"`
+	Confidence float64 `json:"confidence"`
+}
+
+// autoLabeler writes captured images to the wrapped writer and labels them with a vision service
+// in the background.
+type autoLabeler struct {
+	data.CaptureBufferedWriter
+	config    datamanager.AutoLabelConfig
+	vision    vision.Service
+	component string
+	method    string
+	images    chan *v1.SensorData
+	workers   *goutils.StoppableWorkers
+	logger    logging.Logger
+}
+
+func newAutoLabeler(
+	target data.CaptureBufferedWriter,
+	collectorConfig datamanager.DataCaptureConfig,
+	visionSvc vision.Service,
+	logger logging.Logger,
+) *autoLabeler {
+	l := &autoLabeler{
+		CaptureBufferedWriter: target,
+		config:                *collectorConfig.AutoLabel,
+		vision:                visionSvc,
+		component:             collectorConfig.Name.String(),
+		method:                collectorConfig.Method,
+		images:                make(chan *v1.SensorData, autoLabelQueueSize),
+		logger:                logger,
+	}
+	l.workers = goutils.NewBackgroundStoppableWorkers(l.run)
+	return l
+}
+
+// WriteBinary writes the items and queues them to be labeled.
+func (l *autoLabeler) WriteBinary(items []*v1.SensorData) error {
+	if err := l.CaptureBufferedWriter.WriteBinary(items); err != nil {
+		return err
+	}
+	for _, item := range items {
+		select {
+		case l.images <- item:
+		default:
+			l.logger.Debugw("not labeling captured image as the vision service is falling behind",
+				"component", l.component, "method", l.method)
+		}
+	}
+	return nil
+}
+
+func (l *autoLabeler) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case item := <-l.images:
+			if err := l.label(ctx, item); err != nil && ctx.Err() == nil {
+				l.logger.Warnw("failed to label captured image", "component", l.component, "method", l.method, "error", err)
+			}
+		}
+	}
+}
+
+func (l *autoLabeler) label(ctx context.Context, item *v1.SensorData) error {
+	var mimeType string
+	switch data.MimeTypeFromProto(item.GetMetadata().GetMimeType()) {
+	case data.MimeTypeImageJpeg:
+		mimeType = utils.MimeTypeJPEG
+	case data.MimeTypeImagePng:
+		mimeType = utils.MimeTypePNG
+	case data.MimeTypeUnspecified, data.MimeTypeApplicationPcd:
+		fallthrough
+	default:
+		// only images can be labeled
+		return nil
+	}
+	payload := item.GetBinary()
+	img, err := rimage.DecodeImage(ctx, payload, mimeType)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode image")
+	}
+
+	hash := sha256.Sum256(payload)
+	labels := ImageLabels{
+		Component:     l.component,
+		Method:        l.method,
+		VisionService: l.config.VisionService,
+		TimeRequested: item.GetMetadata().GetTimeRequested().AsTime(),
+		TimeReceived:  item.GetMetadata().GetTimeReceived().AsTime(),
+		MimeType:      mimeType,
+		ImageSHA256:   hex.EncodeToString(hash[:]),
+	}
+	if l.config.Detections {
+		detections, err := l.vision.Detections(ctx, img, nil)
+		if err != nil {
+			return errors.Wrap(err, "failed to get detections")
+		}
+		for _, d := range detections {
+			if d.Score() < l.config.MinConfidence || d.BoundingBox() == nil {
+				continue
+			}
+			labels.BoundingBoxes = append(labels.BoundingBoxes,
+				normalizeBoundingBox(img.Bounds(), *d.BoundingBox(), d.Label(), d.Score()))
+		}
+	}
+	if l.config.Classifications > 0 {
+		classifications, err := l.vision.Classifications(ctx, img, l.config.Classifications, nil)
+		if err != nil {
+			return errors.Wrap(err, "failed to get classifications")
+		}
+		for _, c := range classifications {
+			if c.Score() < l.config.MinConfidence {
+				continue
+			}
+			labels.Classifications = append(labels.Classifications, LabelClassification{Label: c.Label(), Confidence: c.Score()})
+		}
+	}
+
+	md, err := json.Marshal(labels)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s_%s%s",
+		labels.TimeRequested.UTC().Format("2006-01-02T15-04-05.000000000Z"), labels.ImageSHA256[:12], AutoLabelSidecarExt)
+	return os.WriteFile(filepath.Join(l.Path(), name), md, 0o600)
+}
+
+func normalizeBoundingBox(bounds, box image.Rectangle, label string, score float64) LabelBoundingBox {
+	width, height := float64(bounds.Dx()), float64(bounds.Dy())
+	return LabelBoundingBox{
+		Label:      label,
+		Confidence: score,
+		XMin:       float64(box.Min.X-bounds.Min.X) / width,
+		YMin:       float64(box.Min.Y-bounds.Min.Y) / height,
+		XMax:       float64(box.Max.X-bounds.Min.X) / width,
+		YMax:       float64(box.Max.Y-bounds.Min.Y) / height,
+	}
+}
+
+// autoLabelingCollector stops labeling when its collector is closed.
+type autoLabelingCollector struct {
+	data.Collector
+	labeler *autoLabeler
+}
+
+func (c *autoLabelingCollector) Close() {
+	c.Collector.Close()
+	c.labeler.workers.Stop()
+}
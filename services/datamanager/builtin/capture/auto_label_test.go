@@ -0,0 +1,106 @@
+package capture
+
+import (
+	"context"
+	"encoding/json"
+	"image"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "go.viam.com/api/app/datasync/v1"
+	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/services/datamanager"
+	"go.viam.com/rdk/testutils/inject"
+	"go.viam.com/rdk/utils"
+	"go.viam.com/rdk/vision/classification"
+	"go.viam.com/rdk/vision/objectdetection"
+)
+
+type fakeTarget struct {
+	dir     string
+	written []*v1.SensorData
+}
+
+func (t *fakeTarget) WriteBinary(items []*v1.SensorData) error {
+	t.written = append(t.written, items...)
+	return nil
+}
+
+func (t *fakeTarget) WriteTabular(item *v1.SensorData) error { return nil }
+
+func (t *fakeTarget) Flush() error { return nil }
+
+func (t *fakeTarget) Path() string { return t.dir }
+
+func TestAutoLabeler(t *testing.T) {
+	ctx := context.Background()
+	img := image.NewRGBA(image.Rect(0, 0, 100, 50))
+	png, err := rimage.EncodeImage(ctx, img, utils.MimeTypePNG)
+	test.That(t, err, test.ShouldBeNil)
+
+	visionSvc := inject.NewVisionService("detector")
+	visionSvc.DetectionsFunc = func(ctx context.Context, img image.Image, extra map[string]interface{}) ([]objectdetection.Detection, error) {
+		return []objectdetection.Detection{
+			objectdetection.NewDetectionWithoutImgBounds(image.Rect(10, 10, 60, 40), 0.9, "robot"),
+			objectdetection.NewDetectionWithoutImgBounds(image.Rect(0, 0, 5, 5), 0.1, "noise"),
+		}, nil
+	}
+	visionSvc.ClassificationsFunc = func(ctx context.Context, img image.Image, n int,
+		extra map[string]interface{},
+	) (classification.Classifications, error) {
+		test.That(t, n, test.ShouldEqual, 1)
+		return classification.Classifications{classification.NewClassification(0.8, "workshop")}, nil
+	}
+
+	target := &fakeTarget{dir: t.TempDir()}
+	labeler := newAutoLabeler(target, datamanager.DataCaptureConfig{
+		Name:   camera.Named("cam"),
+		Method: "ReadImage",
+		AutoLabel: &datamanager.AutoLabelConfig{
+			VisionService:   "detector",
+			Detections:      true,
+			Classifications: 1,
+			MinConfidence:   0.5,
+		},
+	}, visionSvc, logging.NewTestLogger(t))
+	defer labeler.workers.Stop()
+
+	requested := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	item := &v1.SensorData{
+		Metadata: &v1.SensorMetadata{
+			TimeRequested: timestamppb.New(requested),
+			TimeReceived:  timestamppb.New(requested),
+			MimeType:      v1.MimeType_MIME_TYPE_IMAGE_PNG,
+		},
+		Data: &v1.SensorData_Binary{Binary: png},
+	}
+	test.That(t, labeler.WriteBinary([]*v1.SensorData{item}), test.ShouldBeNil)
+	test.That(t, target.written, test.ShouldHaveLength, 1)
+
+	var sidecars []string
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		sidecars, err = filepath.Glob(filepath.Join(target.dir, "*"+AutoLabelSidecarExt))
+		test.That(tb, err, test.ShouldBeNil)
+		test.That(tb, sidecars, test.ShouldHaveLength, 1)
+	})
+
+	md, err := os.ReadFile(sidecars[0])
+	test.That(t, err, test.ShouldBeNil)
+	var labels ImageLabels
+	test.That(t, json.Unmarshal(md, &labels), test.ShouldBeNil)
+	test.That(t, labels.Component, test.ShouldEqual, camera.Named("cam").String())
+	test.That(t, labels.TimeRequested.Equal(requested), test.ShouldBeTrue)
+	test.That(t, labels.ImageSHA256, test.ShouldHaveLength, 64)
+	test.That(t, labels.BoundingBoxes, test.ShouldResemble, []LabelBoundingBox{
+		{Label: "robot", Confidence: 0.9, XMin: 0.1, YMin: 0.2, XMax: 0.6, YMax: 0.8},
+	})
+	test.That(t, labels.Classifications, test.ShouldResemble, []LabelClassification{{Label: "workshop", Confidence: 0.8}})
+}
@@ -0,0 +1,173 @@
+package capture
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/pkg/errors"
+	goutils "go.viam.com/utils"
+
+	"go.viam.com/rdk/data"
+	"go.viam.com/rdk/protoutils"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/services/datamanager"
+)
+
+// burst is a set of collectors capturing for a bounded time outside of the configured schedules.
+type burst struct {
+	collectors []data.Collector
+	methods    []collectorMetadata
+	stop       chan struct{}
+	stopOnce   sync.Once
+}
+
+func (b *burst) cancel() {
+	b.stopOnce.Do(func() { close(b.stop) })
+}
+
+// Burst starts capturing every method configured for the given resources at the burst's
+// frequency until its duration has passed, returning once capture has started. A resource
+// method can only be in one burst at a time.
+func (c *Capture) Burst(req datamanager.CaptureBurst) (datamanager.CaptureBurstStarted, error) {
+	if err := req.Validate(); err != nil {
+		return datamanager.CaptureBurstStarted{}, err
+	}
+	if c.config.CaptureDisabled {
+		return datamanager.CaptureBurstStarted{}, errors.New("cannot start a capture burst while data capture is disabled")
+	}
+
+	type burstTarget struct {
+		res resource.Resource
+		cfg datamanager.DataCaptureConfig
+	}
+	var targets []burstTarget
+	for _, name := range req.Resources {
+		found := false
+		for res, cfgs := range c.collectorConfigsByResource {
+			for _, cfg := range cfgs {
+				if cfg.Name.ShortName() != name && cfg.Name.String() != name {
+					continue
+				}
+				found = true
+				listed := slices.ContainsFunc(targets, func(t burstTarget) bool {
+					return newCollectorMetadata(t.cfg) == newCollectorMetadata(cfg)
+				})
+				if !listed {
+					targets = append(targets, burstTarget{res, cfg})
+				}
+			}
+		}
+		if !found {
+			return datamanager.CaptureBurstStarted{}, errors.Errorf("resource %q has no capture methods configured", name)
+		}
+	}
+
+	c.burstsMu.Lock()
+	defer c.burstsMu.Unlock()
+	for _, t := range targets {
+		md := newCollectorMetadata(t.cfg)
+		for b := range c.bursts {
+			if slices.Contains(b.methods, md) {
+				return datamanager.CaptureBurstStarted{}, errors.Errorf("%s is already being captured by a burst", md)
+			}
+		}
+	}
+
+	b := &burst{stop: make(chan struct{})}
+	started := datamanager.CaptureBurstStarted{EndsAt: c.clk.Now().Add(req.Duration)}
+	for _, t := range targets {
+		md := newCollectorMetadata(t.cfg)
+		collector, err := c.newBurstCollector(t.res, t.cfg, req)
+		if err != nil {
+			for _, collector := range b.collectors {
+				collector.Close()
+			}
+			return datamanager.CaptureBurstStarted{}, errors.Wrapf(err, "failed to start capture burst of %s", md)
+		}
+		b.collectors = append(b.collectors, collector)
+		b.methods = append(b.methods, md)
+		started.Methods = append(started.Methods, fmt.Sprintf("%s/%s", t.cfg.Name, t.cfg.Method))
+	}
+	c.bursts[b] = struct{}{}
+	c.logger.Infow("capture burst started",
+		"methods", started.Methods, "duration", req.Duration, "frequency_hz", req.FrequencyHz, "tags", req.Tags)
+
+	timer := c.clk.Timer(req.Duration)
+	c.burstWorkers.Add(1)
+	goutils.ManagedGo(func() {
+		select {
+		case <-timer.C:
+		case <-b.stop:
+			timer.Stop()
+		}
+		for _, collector := range b.collectors {
+			collector.Close()
+		}
+		c.burstsMu.Lock()
+		delete(c.bursts, b)
+		c.burstsMu.Unlock()
+		c.logger.Infow("capture burst ended", "methods", started.Methods)
+	}, c.burstWorkers.Done)
+	return started, nil
+}
+
+// newBurstCollector returns a started collector capturing a configured method for a burst. It
+// writes next to the method's scheduled captures, without auto labeling or writing to mongo.
+func (c *Capture) newBurstCollector(
+	res resource.Resource,
+	cfg datamanager.DataCaptureConfig,
+	req datamanager.CaptureBurst,
+) (data.Collector, error) {
+	md := newCollectorMetadata(cfg)
+	collectorConstructor := data.CollectorLookup(md.MethodMetadata)
+	if collectorConstructor == nil {
+		return nil, errors.Errorf("failed to find collector constructor for %s", md.MethodMetadata)
+	}
+	methodParams, err := protoutils.ConvertMapToProtoAny(cfg.AdditionalParams)
+	if err != nil {
+		return nil, err
+	}
+	targetDir := targetDir(c.config.CaptureDir, cfg)
+	if err := os.MkdirAll(targetDir, 0o700); err != nil {
+		return nil, errors.Wrapf(err, "failed to create target directory %s with 700 file permissions", targetDir)
+	}
+	tags := append(slices.Clone(c.config.Tags), req.Tags...)
+	captureMetadata, dataType := data.BuildCaptureMetadata(
+		cfg.Name.API,
+		cfg.Name.ShortName(),
+		cfg.Method,
+		cfg.AdditionalParams,
+		methodParams,
+		tags,
+	)
+	collector, err := collectorConstructor(res, data.CollectorParams{
+		DataType:      dataType,
+		ComponentName: cfg.Name.ShortName(),
+		ComponentType: cfg.Name.API.String(),
+		MethodName:    cfg.Method,
+		Interval:      data.GetDurationFromHz(float32(req.FrequencyHz)),
+		MethodParams:  methodParams,
+		Target:        data.NewCaptureBuffer(targetDir, captureMetadata, c.config.MaximumCaptureFileSizeBytes),
+		QueueSize:     defaultIfZeroVal(cfg.CaptureQueueSize, defaultCaptureQueueSize),
+		BufferSize:    defaultIfZeroVal(cfg.CaptureBufferSize, defaultCaptureBufferSize),
+		Logger:        c.logger,
+		Clock:         c.clk,
+	})
+	if err != nil {
+		return nil, err
+	}
+	collector.Collect()
+	return collector, nil
+}
+
+// stopBursts stops every running burst and waits for their collectors to close.
+func (c *Capture) stopBursts() {
+	c.burstsMu.Lock()
+	for b := range c.bursts {
+		b.cancel()
+	}
+	c.burstsMu.Unlock()
+	c.burstWorkers.Wait()
+}
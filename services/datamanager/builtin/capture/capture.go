@@ -19,6 +19,8 @@ import (
 	"go.viam.com/rdk/protoutils"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/services/datamanager"
+	"go.viam.com/rdk/services/datamanager/builtin/notify"
+	"go.viam.com/rdk/services/vision"
 )
 
 // TODO: re-determine if queue size is optimal given we now support 10khz+ capture rates
@@ -53,8 +55,9 @@ var metadataToAdditionalParamFields = map[string]string{
 // - Reconfigure (any number of times)
 // - Close (any number of times).
 type Capture struct {
-	logger logging.Logger
-	clk    clock.Clock
+	logger   logging.Logger
+	clk      clock.Clock
+	notifier *notify.Notifier
 
 	collectorsMu sync.Mutex
 	collectors   collectors
@@ -64,6 +67,14 @@ type Capture struct {
 	maxCaptureFileSize int64
 	mongoMU            sync.Mutex
 	mongo              captureMongo
+
+	// config and collectorConfigsByResource are those of the last Reconfigure, which bursts
+	// capture with
+	config                     Config
+	collectorConfigsByResource CollectorConfigsByResource
+	burstsMu                   sync.Mutex
+	bursts                     map[*burst]struct{}
+	burstWorkers               sync.WaitGroup
 }
 
 type captureMongo struct {
@@ -86,6 +97,8 @@ type collectorAndConfig struct {
 	Resource  resource.Resource
 	Collector data.Collector
 	Config    datamanager.DataCaptureConfig
+	// VisionService labels the images the collector captures, if it is configured to.
+	VisionService vision.Service
 }
 
 // Identifier for a particular collector: component name, component model, component type,
@@ -93,12 +106,15 @@ type collectorAndConfig struct {
 // New creates a new capture manager.
 func New(
 	clock clock.Clock,
+	notifier *notify.Notifier,
 	logger logging.Logger,
 ) *Capture {
 	return &Capture{
 		clk:        clock,
+		notifier:   notifier,
 		logger:     logger,
 		collectors: collectors{},
+		bursts:     map[*burst]struct{}{},
 	}
 }
 
@@ -211,6 +227,8 @@ func (c *Capture) Reconfigure(
 ) {
 	c.logger.Debug("Reconfigure START")
 	defer c.logger.Debug("Reconfigure END")
+	c.config = config
+	c.collectorConfigsByResource = collectorConfigsByResource
 	// Service is disabled, so close all collectors and clear the map so we can instantiate new ones if we enable this service.
 	if config.CaptureDisabled {
 		c.logger.Info("Capture Disabled")
@@ -244,6 +262,7 @@ func (c *Capture) Reconfigure(
 
 // Close closes the capture manager.
 func (c *Capture) Close(ctx context.Context) {
+	c.stopBursts()
 	c.FlushCollectors()
 	c.closeCollectors()
 	c.mongoMU.Lock()
@@ -282,10 +301,22 @@ func (c *Capture) initializeOrUpdateCollector(
 		return nil, err
 	}
 
+	var visionSvc vision.Service
+	if collectorConfig.AutoLabel != nil {
+		if err := collectorConfig.AutoLabel.Validate(); err != nil {
+			return nil, err
+		}
+		var ok bool
+		if visionSvc, ok = config.VisionServices[collectorConfig.AutoLabel.VisionService]; !ok {
+			return nil, errors.Errorf("auto_label.vision_service %q not found", collectorConfig.AutoLabel.VisionService)
+		}
+	}
+
 	maxFileSizeChanged := c.maxCaptureFileSize != config.MaximumCaptureFileSizeBytes
 	if storedCollectorAndConfig, ok := c.collectors[md]; ok {
 		if storedCollectorAndConfig.Config.Equals(&collectorConfig) &&
 			res == storedCollectorAndConfig.Resource &&
+			visionSvc == storedCollectorAndConfig.VisionService &&
 			!maxFileSizeChanged {
 			// If the attributes have not changed, do nothing and leave the existing collector.
 			return c.collectors[md], nil
@@ -335,6 +366,12 @@ func (c *Capture) initializeOrUpdateCollector(
 	// Parameters to initialize collector.
 	queueSize := defaultIfZeroVal(collectorConfig.CaptureQueueSize, defaultCaptureQueueSize)
 	bufferSize := defaultIfZeroVal(collectorConfig.CaptureBufferSize, defaultCaptureBufferSize)
+	var target data.CaptureBufferedWriter = data.NewCaptureBuffer(targetDir, captureMetadata, config.MaximumCaptureFileSizeBytes)
+	var labeler *autoLabeler
+	if visionSvc != nil {
+		labeler = newAutoLabeler(target, collectorConfig, visionSvc, c.logger)
+		target = labeler
+	}
 	collector, err := collectorConstructor(res, data.CollectorParams{
 		MongoCollection: collection,
 		DataType:        dataType,
@@ -343,14 +380,34 @@ func (c *Capture) initializeOrUpdateCollector(
 		MethodName:      collectorConfig.Method,
 		Interval:        data.GetDurationFromHz(collectorConfig.CaptureFrequencyHz),
 		MethodParams:    methodParams,
-		Target:          data.NewCaptureBuffer(targetDir, captureMetadata, config.MaximumCaptureFileSizeBytes),
+		Target:          target,
 		// Set queue size to defaultCaptureQueueSize if it was not set in the config.
 		QueueSize:  queueSize,
 		BufferSize: bufferSize,
 		Logger:     c.logger,
 		Clock:      c.clk,
+		// report collectors that keep failing, once per streak of failures
+		OnCaptureFailure: func(consecutiveFailures int, err error) {
+			if consecutiveFailures != c.notifier.CaptureFailureThreshold() {
+				return
+			}
+			c.notifier.Notify(notify.Event{
+				Type: notify.EventCaptureFailing,
+				Message: fmt.Sprintf("capturing %s of %s has failed %d times in a row",
+					collectorConfig.Method, collectorConfig.Name, consecutiveFailures),
+				Details: map[string]interface{}{
+					"resource":             collectorConfig.Name.String(),
+					"method":               collectorConfig.Method,
+					"consecutive_failures": consecutiveFailures,
+					"error":                err.Error(),
+				},
+			})
+		},
 	})
 	if err != nil {
+		if labeler != nil {
+			labeler.workers.Stop()
+		}
 		return nil, errors.Wrapf(err, "constructor for collector %s failed with config: %s",
 			md, collectorConfigDescription(collectorConfig, targetDir, config.MaximumCaptureFileSizeBytes, queueSize, bufferSize))
 	}
@@ -358,8 +415,11 @@ func (c *Capture) initializeOrUpdateCollector(
 	c.logger.Infof("collector initialized; collector: %s, config: %s",
 		md, collectorConfigDescription(collectorConfig, targetDir, config.MaximumCaptureFileSizeBytes, queueSize, bufferSize))
 	collector.Collect()
+	if labeler != nil {
+		collector = &autoLabelingCollector{Collector: collector, labeler: labeler}
+	}
 
-	return &collectorAndConfig{res, collector, collectorConfig}, nil
+	return &collectorAndConfig{res, collector, collectorConfig, visionSvc}, nil
 }
 
 func collectorConfigDescription(
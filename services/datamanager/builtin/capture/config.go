@@ -1,5 +1,7 @@
 package capture
 
+import "go.viam.com/rdk/services/vision"
+
 // MongoConfig is the optional data capture mongo config.
 type MongoConfig struct {
 	URI        string `json:"uri"`
@@ -26,4 +28,6 @@ type Config struct {
 	MaximumCaptureFileSizeBytes int64
 
 	MongoConfig *MongoConfig
+	// VisionServices are the vision services that collectors can auto label images with, by name
+	VisionServices map[string]vision.Service
 }
@@ -11,6 +11,7 @@ import (
 	"go.viam.com/rdk/internal/cloud"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/services/datamanager/builtin/capture"
+	"go.viam.com/rdk/services/datamanager/builtin/notify"
 	"go.viam.com/rdk/services/datamanager/builtin/shared"
 	datasync "go.viam.com/rdk/services/datamanager/builtin/sync"
 	"go.viam.com/rdk/utils"
@@ -63,6 +64,8 @@ type Config struct {
 	ScheduledSyncDisabled  bool     `json:"sync_disabled"`
 	SelectiveSyncerName    string   `json:"selective_syncer_name"`
 	SyncIntervalMins       float64  `json:"sync_interval_mins"`
+	// Notifications is masked when configs are printed since it may hold credentials
+	Notifications *notify.Config `json:"notifications,omitempty" config:"secret"`
 }
 
 // Validate returns components which will be depended upon weakly due to the above matcher.
@@ -88,6 +91,11 @@ func (c *Config) Validate(path string) ([]string, []string, error) {
 	if c.CaptureDirDeletionThreshold < 0 {
 		return nil, nil, errors.New("capture_dir_deletion_threshold can't be negative")
 	}
+	if c.Notifications != nil {
+		if err := c.Notifications.Validate("notifications"); err != nil {
+			return nil, nil, err
+		}
+	}
 	return []string{cloud.InternalServiceName.String()}, nil, nil
 }
 
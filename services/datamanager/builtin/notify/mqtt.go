@@ -0,0 +1,118 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/url"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/logging"
+)
+
+const (
+	// mqttQoS is at least once delivery, so that notifications survive the connection dropping
+	// before the broker acknowledges them.
+	mqttQoS                  = 1
+	mqttKeepAlive            = 30 * time.Second
+	mqttMaxReconnectInterval = time.Minute
+	// mqttDisconnectQuiesceMs is how long in-flight publishes have to complete on disconnecting.
+	mqttDisconnectQuiesceMs = 250
+)
+
+// parseBroker returns the host:port of a broker address and whether to use TLS.
+func parseBroker(broker string) (string, bool, error) {
+	u, err := url.Parse(broker)
+	if err != nil {
+		return "", false, err
+	}
+	var useTLS bool
+	defaultPort := "1883"
+	switch u.Scheme {
+	case "tcp", "mqtt":
+	case "ssl", "tls", "mqtts":
+		useTLS = true
+		defaultPort = "8883"
+	default:
+		return "", false, errors.Errorf("unsupported scheme %q, must be one of tcp, mqtt, ssl, tls or mqtts", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return "", false, errors.New("missing host")
+	}
+	port := u.Port()
+	if port == "" {
+		port = defaultPort
+	}
+	return net.JoinHostPort(u.Hostname(), port), useTLS, nil
+}
+
+// An mqttPublisher publishes notifications to a broker over a connection that is kept open and
+// reopened whenever it drops. Publishes that are not acknowledged before the connection drops
+// are sent again once it is reopened.
+type mqttPublisher struct {
+	config MQTTConfig
+	client mqtt.Client
+	// connected is done once the first connection is open.
+	connected mqtt.Token
+}
+
+// newMQTTPublisher starts connecting to the broker.
+func newMQTTPublisher(config *MQTTConfig, logger logging.Logger) (*mqttPublisher, error) {
+	addr, useTLS, err := parseBroker(config.Broker)
+	if err != nil {
+		return nil, err
+	}
+	scheme := "tcp"
+	if useTLS {
+		scheme = "ssl"
+	}
+	opts := mqtt.NewClientOptions().
+		AddBroker(scheme + "://" + addr).
+		SetClientID(config.ClientID).
+		SetUsername(config.Username).
+		SetPassword(config.Password).
+		SetKeepAlive(mqttKeepAlive).
+		SetConnectTimeout(sendTimeout).
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetMaxReconnectInterval(mqttMaxReconnectInterval).
+		SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+			logger.Warnw("lost connection to mqtt broker, reconnecting", "broker", config.Broker, "error", err)
+		})
+	if useTLS {
+		opts.SetTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	client := mqtt.NewClient(opts)
+	// with connect retry set, connecting goes on in the background until it succeeds or the
+	// client is disconnected.
+	return &mqttPublisher{config: *config, client: client, connected: client.Connect()}, nil
+}
+
+// publish publishes the payload and waits for the broker to acknowledge it.
+func (p *mqttPublisher) publish(ctx context.Context, payload []byte) error {
+	// publishes queued while first connecting are dropped once connected, as the session is
+	// clean, whereas those queued while reconnecting are sent once reconnected.
+	select {
+	case <-p.connected.Done():
+		if err := p.connected.Error(); err != nil {
+			return err
+		}
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "not connected to broker")
+	}
+	token := p.client.Publish(p.config.Topic, mqttQoS, false, payload)
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// close disconnects from the broker, giving in-flight publishes a moment to complete.
+func (p *mqttPublisher) close() {
+	p.client.Disconnect(mqttDisconnectQuiesceMs)
+}
@@ -0,0 +1,258 @@
+// Package notify sends notifications about the builtin datamanager's data pipeline, like sync
+// completions, persistent capture failures and the disk filling up, to webhooks and MQTT brokers
+// so that operators learn about problems without scraping logs.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	goutils "go.viam.com/utils"
+
+	"go.viam.com/rdk/logging"
+)
+
+// EventType is the kind of an Event.
+type EventType string
+
+const (
+	// EventSyncCompleted is sent when all the files found by a sync have been uploaded or failed
+	// to upload.
+	EventSyncCompleted EventType = "sync_completed"
+	// EventCaptureFailing is sent when a collector has failed to capture data many times in a row.
+	EventCaptureFailing EventType = "capture_failing"
+	// EventDiskThresholdExceeded is sent when disk usage reaches the disk usage deletion threshold.
+	EventDiskThresholdExceeded EventType = "disk_threshold_exceeded"
+)
+
+var eventTypes = []EventType{EventSyncCompleted, EventCaptureFailing, EventDiskThresholdExceeded}
+
+const (
+	defaultCaptureFailureThreshold = 10
+	// queueSize is how many notifications can be waiting to be sent before new ones are dropped.
+	queueSize   = 100
+	sendTimeout = 10 * time.Second
+)
+
+// Config describes where notifications are sent. Notifications go to every configured
+// destination.
+type Config struct {
+	Webhook *WebhookConfig `json:"webhook,omitempty"`
+	MQTT    *MQTTConfig    `json:"mqtt,omitempty"`
+	// Events, if set, limits notifications to these events.
+	Events []EventType `json:"events,omitempty"`
+	// CaptureFailureThreshold is how many captures in a row a collector must fail before a
+	// capture_failing notification is sent. Defaults to 10.
+	CaptureFailureThreshold int `json:"capture_failure_threshold,omitempty"`
+}
+
+// WebhookConfig describes a webhook that notifications are POSTed to as JSON.
+type WebhookConfig struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// MQTTConfig describes an MQTT broker and topic that notifications are published to as JSON, at
+// QoS 1 so that the broker acknowledges each of them.
+type MQTTConfig struct {
+	// Broker is the address of the broker, like tcp://broker:1883 or ssl://broker:8883.
+	Broker   string `json:"broker"`
+	Topic    string `json:"topic"`
+	ClientID string `json:"client_id,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// Validate ensures all parts of the config are valid.
+func (c *Config) Validate(path string) error {
+	if c.Webhook == nil && c.MQTT == nil {
+		return errors.Errorf("%s: either webhook or mqtt must be set", path)
+	}
+	if c.Webhook != nil {
+		u, err := url.Parse(c.Webhook.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return errors.Errorf("%s.webhook.url must be an http or https URL", path)
+		}
+	}
+	if c.MQTT != nil {
+		if _, _, err := parseBroker(c.MQTT.Broker); err != nil {
+			return errors.Wrapf(err, "%s.mqtt.broker", path)
+		}
+		if c.MQTT.Topic == "" {
+			return errors.Errorf("%s.mqtt.topic is required", path)
+		}
+	}
+	for _, event := range c.Events {
+		if !slices.Contains(eventTypes, event) {
+			return errors.Errorf("%s.events: unknown event %q", path, event)
+		}
+	}
+	if c.CaptureFailureThreshold < 0 {
+		return errors.Errorf("%s.capture_failure_threshold can't be negative", path)
+	}
+	return nil
+}
+
+// An Event is a notification.
+type Event struct {
+	Type    EventType              `json:"type"`
+	Time    time.Time              `json:"time"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// A Notifier sends notifications in the background so that the data pipeline is never blocked
+// on a slow destination. A nil Notifier, or one without a config, drops every notification.
+type Notifier struct {
+	logger logging.Logger
+	client *http.Client
+	events chan Event
+	worker *goutils.StoppableWorkers
+
+	mu     sync.Mutex
+	config *Config
+	mqtt   *mqttPublisher
+}
+
+// New returns a Notifier that drops every notification until it is reconfigured.
+func New(logger logging.Logger) *Notifier {
+	n := &Notifier{
+		logger: logger,
+		client: &http.Client{Timeout: sendTimeout},
+		events: make(chan Event, queueSize),
+	}
+	n.worker = goutils.NewBackgroundStoppableWorkers(n.send)
+	return n
+}
+
+// Reconfigure changes where notifications are sent. A nil config disables notifications. The
+// connection to an MQTT broker is kept across reconfigurations that do not change it.
+func (n *Notifier) Reconfigure(config *Config) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.config = config
+	var mqttConfig *MQTTConfig
+	if config != nil {
+		mqttConfig = config.MQTT
+	}
+	if n.mqtt != nil && (mqttConfig == nil || *mqttConfig != n.mqtt.config) {
+		n.mqtt.close()
+		n.mqtt = nil
+	}
+	if mqttConfig != nil && n.mqtt == nil {
+		publisher, err := newMQTTPublisher(mqttConfig, n.logger)
+		if err != nil {
+			n.logger.Warnw("failed to set up mqtt notifications", "broker", mqttConfig.Broker, "error", err)
+			return
+		}
+		n.mqtt = publisher
+	}
+}
+
+// CaptureFailureThreshold returns how many captures in a row a collector must fail before it
+// is reported.
+func (n *Notifier) CaptureFailureThreshold() int {
+	if n == nil {
+		return defaultCaptureFailureThreshold
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.config == nil || n.config.CaptureFailureThreshold == 0 {
+		return defaultCaptureFailureThreshold
+	}
+	return n.config.CaptureFailureThreshold
+}
+
+// Notify queues an event to be sent, dropping it if notifications are disabled for it or too many
+// are already waiting to be sent.
+func (n *Notifier) Notify(event Event) {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	config := n.config
+	n.mu.Unlock()
+	if config == nil || (len(config.Events) != 0 && !slices.Contains(config.Events, event.Type)) {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	select {
+	case n.events <- event:
+	default:
+		n.logger.Warnw("dropping datamanager notification as too many are waiting to be sent", "type", event.Type)
+	}
+}
+
+// Close stops sending notifications.
+func (n *Notifier) Close() {
+	n.worker.Stop()
+	n.client.CloseIdleConnections()
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.mqtt != nil {
+		n.mqtt.close()
+		n.mqtt = nil
+	}
+}
+
+func (n *Notifier) send(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-n.events:
+			n.mu.Lock()
+			config, publisher := n.config, n.mqtt
+			n.mu.Unlock()
+			if config == nil {
+				continue
+			}
+			payload, err := json.Marshal(event)
+			if err != nil {
+				n.logger.Errorw("failed to encode datamanager notification", "error", err)
+				continue
+			}
+			if config.Webhook != nil {
+				if err := n.postWebhook(ctx, config.Webhook, payload); err != nil {
+					n.logger.Warnw("failed to send datamanager notification to webhook", "type", event.Type, "error", err)
+				}
+			}
+			if publisher != nil {
+				sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+				if err := publisher.publish(sendCtx, payload); err != nil {
+					n.logger.Warnw("failed to publish datamanager notification to mqtt", "type", event.Type, "error", err)
+				}
+				cancel()
+			}
+		}
+	}
+}
+
+func (n *Notifier) postWebhook(ctx context.Context, config *WebhookConfig, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range config.Headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer goutils.UncheckedErrorFunc(resp.Body.Close)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package notify
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/logging"
+)
+
+func TestWebhook(t *testing.T) {
+	received := make(chan Event, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		test.That(t, r.Header.Get("Authorization"), test.ShouldEqual, "Bearer token")
+		var event Event
+		test.That(t, json.NewDecoder(r.Body).Decode(&event), test.ShouldBeNil)
+		received <- event
+	}))
+	defer srv.Close()
+
+	n := New(logging.NewTestLogger(t))
+	defer n.Close()
+
+	// nothing is sent until notifications are configured
+	n.Notify(Event{Type: EventSyncCompleted})
+	n.Reconfigure(&Config{
+		Webhook: &WebhookConfig{URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer token"}},
+		Events:  []EventType{EventDiskThresholdExceeded},
+	})
+	// nor are events that are filtered out
+	n.Notify(Event{Type: EventSyncCompleted})
+	n.Notify(Event{Type: EventDiskThresholdExceeded, Message: "disk is full"})
+
+	select {
+	case event := <-received:
+		test.That(t, event.Type, test.ShouldEqual, EventDiskThresholdExceeded)
+		test.That(t, event.Message, test.ShouldEqual, "disk is full")
+		test.That(t, event.Time.IsZero(), test.ShouldBeFalse)
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not called")
+	}
+	select {
+	case event := <-received:
+		t.Fatalf("unexpected notification %v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestMQTT(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	defer listener.Close()
+
+	// the broker drops the first connection before acknowledging the publish on it, so the
+	// notification is only acknowledged once it is published again on the next connection
+	published := make(chan mqttPublish, 2)
+	go func() {
+		for conn := 0; ; conn++ {
+			c, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			serveMQTT(c, published, conn > 0)
+		}
+	}()
+
+	n := New(logging.NewTestLogger(t))
+	defer n.Close()
+	n.Reconfigure(&Config{MQTT: &MQTTConfig{
+		Broker:   "tcp://" + listener.Addr().String(),
+		Topic:    "machines/notifications",
+		ClientID: "machine",
+		Username: "user",
+		Password: "pass",
+	}})
+	n.Notify(Event{Type: EventSyncCompleted, Message: "synced"})
+
+	var dropped mqttPublish
+	select {
+	case dropped = <-published:
+		test.That(t, dropped.topic, test.ShouldEqual, "machines/notifications")
+		test.That(t, dropped.dup, test.ShouldBeFalse)
+	case <-time.After(10 * time.Second):
+		t.Fatal("nothing was published")
+	}
+	select {
+	case resent := <-published:
+		test.That(t, resent.dup, test.ShouldBeTrue)
+		test.That(t, resent.id, test.ShouldEqual, dropped.id)
+		var event Event
+		test.That(t, json.Unmarshal(resent.payload, &event), test.ShouldBeNil)
+		test.That(t, event.Type, test.ShouldEqual, EventSyncCompleted)
+		test.That(t, event.Message, test.ShouldEqual, "synced")
+	case <-time.After(10 * time.Second):
+		t.Fatal("notification was not published again after reconnecting")
+	}
+}
+
+// mqttPublish is a QoS 1 publish received by the fake broker.
+type mqttPublish struct {
+	topic   string
+	id      uint16
+	dup     bool
+	payload []byte
+}
+
+// serveMQTT is a fake MQTT 3.1.1 broker connection that accepts the connection and reports each
+// publish on it, closing the connection instead of acknowledging the first publish unless ack is set.
+func serveMQTT(conn net.Conn, published chan<- mqttPublish, ack bool) {
+	defer conn.Close()
+	for {
+		header, body, err := readMQTTPacket(conn)
+		if err != nil {
+			return
+		}
+		var reply []byte
+		switch header >> 4 {
+		case 1: // CONNECT
+			reply = []byte{0x20, 2, 0, 0}
+		case 3: // PUBLISH
+			topicLen := int(binary.BigEndian.Uint16(body))
+			id := binary.BigEndian.Uint16(body[2+topicLen:])
+			published <- mqttPublish{
+				topic:   string(body[2 : 2+topicLen]),
+				id:      id,
+				dup:     header&0x08 != 0,
+				payload: body[4+topicLen:],
+			}
+			if !ack {
+				return
+			}
+			reply = binary.BigEndian.AppendUint16([]byte{0x40, 2}, id)
+		case 12: // PINGREQ
+			reply = []byte{0xd0, 0}
+		case 14: // DISCONNECT
+			return
+		}
+		if _, err := conn.Write(reply); err != nil {
+			return
+		}
+	}
+}
+
+// readMQTTPacket reads a whole packet, returning its first header byte and its body.
+func readMQTTPacket(r io.Reader) (byte, []byte, error) {
+	var header [1]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	remaining, multiplier := 0, 1
+	for {
+		var b [1]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return 0, nil, err
+		}
+		remaining += int(b[0]&0x7f) * multiplier
+		multiplier *= 128
+		if b[0]&0x80 == 0 {
+			break
+		}
+	}
+	body := make([]byte, remaining)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return header[0], body, nil
+}
+
+func TestValidate(t *testing.T) {
+	test.That(t, (&Config{}).Validate("notifications"), test.ShouldNotBeNil)
+	test.That(t, (&Config{Webhook: &WebhookConfig{URL: "https://example.com/hook"}}).Validate("notifications"), test.ShouldBeNil)
+	test.That(t, (&Config{Webhook: &WebhookConfig{URL: "example.com"}}).Validate("notifications"), test.ShouldNotBeNil)
+	test.That(t, (&Config{MQTT: &MQTTConfig{Broker: "ssl://broker", Topic: "t"}}).Validate("notifications"), test.ShouldBeNil)
+	test.That(t, (&Config{MQTT: &MQTTConfig{Broker: "ws://broker", Topic: "t"}}).Validate("notifications"), test.ShouldNotBeNil)
+	test.That(t, (&Config{MQTT: &MQTTConfig{Broker: "tcp://broker"}}).Validate("notifications"), test.ShouldNotBeNil)
+	test.That(t, (&Config{
+		Webhook: &WebhookConfig{URL: "https://example.com/hook"},
+		Events:  []EventType{"sync_started"},
+	}).Validate("notifications"), test.ShouldNotBeNil)
+}
@@ -14,6 +14,7 @@ import (
 
 	"go.viam.com/rdk/data"
 	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/services/datamanager/builtin/notify"
 	"go.viam.com/rdk/utils/diskusage"
 )
 
@@ -27,6 +28,7 @@ func deleteExcessFilesOnSchedule(
 	diskUsageThreshold float64,
 	captureDirThreshold float64,
 	clock clock.Clock,
+	notifier *notify.Notifier,
 	logger logging.Logger,
 ) {
 	if runtime.GOOS == "android" {
@@ -35,6 +37,7 @@ func deleteExcessFilesOnSchedule(
 	}
 	t := clock.Ticker(CheckDeleteExcessFilesInterval)
 	defer t.Stop()
+	var diskThresholdExceeded bool
 	for {
 		if err := ctx.Err(); err != nil {
 			return
@@ -45,6 +48,9 @@ func deleteExcessFilesOnSchedule(
 			return
 		case <-t.C:
 			maybeDeleteExcessFiles(ctx, fileTracker, captureDir, deleteEveryNth, diskUsageThreshold, captureDirThreshold, clock, logger)
+			if usage, err := diskusage.Statfs(captureDir); err == nil {
+				diskThresholdExceeded = notifyDiskUsage(usage, diskUsageThreshold, diskThresholdExceeded, notifier)
+			}
 		}
 	}
 }
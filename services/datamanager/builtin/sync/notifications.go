@@ -0,0 +1,101 @@
+package sync
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.viam.com/rdk/data"
+	"go.viam.com/rdk/services/datamanager/builtin/notify"
+	"go.viam.com/rdk/utils/diskusage"
+)
+
+// syncBatch tracks the files found by one sync so that its completion can be reported once
+// every file has been uploaded or failed to upload.
+type syncBatch struct {
+	start    time.Time
+	notifier *notify.Notifier
+	// pending starts at one for the walk looking for files, which calls done when it finishes.
+	pending       atomic.Int64
+	uploadedFiles atomic.Int64
+	uploadedBytes atomic.Uint64
+	failedFiles   atomic.Int64
+}
+
+func newSyncBatch(notifier *notify.Notifier) *syncBatch {
+	b := &syncBatch{start: time.Now(), notifier: notifier}
+	b.pending.Add(1)
+	return b
+}
+
+func (b *syncBatch) add() {
+	b.pending.Add(1)
+}
+
+func (b *syncBatch) recordUpload(bytesUploaded uint64) {
+	if b == nil {
+		return
+	}
+	b.uploadedFiles.Add(1)
+	b.uploadedBytes.Add(bytesUploaded)
+}
+
+func (b *syncBatch) recordFailure() {
+	if b == nil {
+		return
+	}
+	b.failedFiles.Add(1)
+}
+
+// done marks a file, or the walk, as finished, notifying that the sync completed if it was the
+// last one. Syncs that did not upload or fail to upload anything are not reported.
+func (b *syncBatch) done() {
+	if b == nil || b.pending.Add(-1) != 0 {
+		return
+	}
+	uploaded, failed := b.uploadedFiles.Load(), b.failedFiles.Load()
+	if uploaded == 0 && failed == 0 {
+		return
+	}
+	b.notifier.Notify(notify.Event{
+		Type: notify.EventSyncCompleted,
+		Message: fmt.Sprintf("sync completed: uploaded %d files (%s), failed to upload %d files",
+			uploaded, data.FormatBytesU64(b.uploadedBytes.Load()), failed),
+		Details: map[string]interface{}{
+			"uploaded_files": uploaded,
+			"uploaded_bytes": b.uploadedBytes.Load(),
+			"failed_files":   failed,
+			"duration_sec":   time.Since(b.start).Seconds(),
+		},
+	})
+}
+
+// notifyDiskUsage notifies when disk usage reaches the threshold, and returns whether it is at
+// or above it. It only notifies when usage was previously below the threshold so that a full
+// disk is reported once rather than on every check.
+func notifyDiskUsage(
+	usage diskusage.DiskUsage,
+	diskUsageThreshold float64,
+	wasExceeded bool,
+	notifier *notify.Notifier,
+) bool {
+	if usage.SizeBytes == 0 {
+		return wasExceeded
+	}
+	usedSpace := 1.0 - usage.AvailablePercent()
+	exceeded := usedSpace >= diskUsageThreshold
+	if exceeded && !wasExceeded {
+		notifier.Notify(notify.Event{
+			Type: notify.EventDiskThresholdExceeded,
+			Message: fmt.Sprintf("disk usage of %.2f%% has reached the deletion threshold of %.2f%%",
+				usedSpace*100, diskUsageThreshold*100),
+			Details: map[string]interface{}{
+				"used_fraction":      usedSpace,
+				"threshold_fraction": diskUsageThreshold,
+				"size_bytes":         usage.SizeBytes,
+				"available_bytes":    usage.AvailableBytes,
+			},
+		})
+	}
+	return exceeded
+}
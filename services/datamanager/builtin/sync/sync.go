@@ -24,6 +24,7 @@ import (
 	"go.viam.com/rdk/internal/cloud"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/services/datamanager"
+	"go.viam.com/rdk/services/datamanager/builtin/notify"
 	"go.viam.com/rdk/utils"
 )
 
@@ -59,7 +60,8 @@ type Sync struct {
 	workersWg               sync.WaitGroup
 	flushCollectors         func()
 	fileTracker             *fileTracker
-	filesToSync             chan string
+	filesToSync             chan syncRequest
+	notifier                *notify.Notifier
 	clientConstructor       func(cc grpc.ClientConnInterface) v1.DataSyncServiceClient
 	clock                   clock.Clock
 	atomicUploadStats       *atomicUploadStats
@@ -87,6 +89,7 @@ func New(
 	connToConnectivityState func(conn rpc.ClientConn) ConnectivityState,
 	flushCollectors func(),
 	clock clock.Clock,
+	notifier *notify.Notifier,
 	logger logging.Logger,
 ) *Sync {
 	configCtx, configCancelFunc := context.WithCancel(context.Background())
@@ -100,7 +103,8 @@ func New(
 		clientConstructor:       clientConstructor,
 		logger:                  logger,
 		fileTracker:             newFileTracker(),
-		filesToSync:             make(chan string),
+		filesToSync:             make(chan syncRequest),
+		notifier:                notifier,
 		flushCollectors:         flushCollectors,
 		Scheduler:               goutils.NewBackgroundStoppableWorkers(),
 		cloudConn:               cloudConn{ready: make(chan struct{})},
@@ -183,6 +187,7 @@ func (s *Sync) Reconfigure(_ context.Context, config Config, cloudConnSvc cloud.
 				config.DiskUsageDeletionThreshold,
 				config.CaptureDirDeletionThreshold,
 				s.clock,
+				s.notifier,
 				s.logger,
 			)
 		})
@@ -309,6 +314,13 @@ func newCloudConn(
 // END connection management
 
 // BEGIN sync workers
+
+// syncRequest asks a worker to sync a file found by a sync.
+type syncRequest struct {
+	path  string
+	batch *syncBatch
+}
+
 // Assumed to be called after reconfigure is called.
 func (s *Sync) startWorkers(config Config) {
 	numThreads := config.MaximumNumSyncThreads
@@ -328,13 +340,14 @@ func (s *Sync) runWorker(config Config) {
 		select {
 		case <-s.configCtx.Done():
 			return
-		case path := <-s.filesToSync:
-			s.syncFile(config, path)
+		case req := <-s.filesToSync:
+			s.syncFile(config, req.path, req.batch)
+			req.batch.done()
 		}
 	}
 }
 
-func (s *Sync) syncFile(config Config, filePath string) {
+func (s *Sync) syncFile(config Config, filePath string, batch *syncBatch) {
 	// don't sync in progress files
 	if filepath.Ext(filePath) == data.InProgressCaptureFileExt {
 		s.logger.Warnf("ignoring request to sync in progress capture file: %s", filePath)
@@ -360,13 +373,13 @@ func (s *Sync) syncFile(config Config, filePath string) {
 	}
 
 	if data.IsDataCaptureFile(f) {
-		s.syncDataCaptureFile(f, config.CaptureDir, s.logger)
+		s.syncDataCaptureFile(f, config.CaptureDir, batch, s.logger)
 	} else {
-		s.syncArbitraryFile(f, config.Tags, config.FileLastModifiedMillis, s.logger)
+		s.syncArbitraryFile(f, config.Tags, config.FileLastModifiedMillis, batch, s.logger)
 	}
 }
 
-func (s *Sync) syncDataCaptureFile(f *os.File, captureDir string, logger logging.Logger) {
+func (s *Sync) syncDataCaptureFile(f *os.File, captureDir string, batch *syncBatch, logger logging.Logger) {
 	captureFile, err := data.ReadCaptureFile(f)
 	// if you can't read the capture file's metadata field, close & move it to the failed directory
 	if err != nil {
@@ -380,6 +393,7 @@ func (s *Sync) syncDataCaptureFile(f *os.File, captureDir string, logger logging
 			s.logger.Error(err)
 		}
 		s.atomicUploadStats.tabular.uploadFailedFileCount.Add(1)
+		batch.recordFailure()
 		return
 	}
 	isBinary := captureFile.ReadMetadata().GetType() == v1.DataType_DATA_TYPE_BINARY_SENSOR
@@ -418,6 +432,7 @@ func (s *Sync) syncDataCaptureFile(f *os.File, captureDir string, logger logging
 		} else {
 			s.atomicUploadStats.tabular.uploadFailedFileCount.Add(1)
 		}
+		batch.recordFailure()
 		return
 	}
 
@@ -432,9 +447,16 @@ func (s *Sync) syncDataCaptureFile(f *os.File, captureDir string, logger logging
 		s.atomicUploadStats.tabular.uploadedFileCount.Add(1)
 		s.atomicUploadStats.tabular.uploadedBytes.Add(bytesUploaded)
 	}
+	batch.recordUpload(bytesUploaded)
 }
 
-func (s *Sync) syncArbitraryFile(f *os.File, tags []string, fileLastModifiedMillis int, logger logging.Logger) {
+func (s *Sync) syncArbitraryFile(
+	f *os.File,
+	tags []string,
+	fileLastModifiedMillis int,
+	batch *syncBatch,
+	logger logging.Logger,
+) {
 	retry := newExponentialRetry(s.configCtx, s.clock, s.logger, f.Name(), func(ctx context.Context) (uint64, error) {
 		errMetadata := fmt.Sprintf("error uploading arbitrary file %s", f.Name())
 		bytesUploaded, err := uploadArbitraryFile(ctx, f, s.cloudConn, tags, fileLastModifiedMillis, s.clock, logger)
@@ -462,6 +484,7 @@ func (s *Sync) syncArbitraryFile(f *os.File, tags []string, fileLastModifiedMill
 			logger.Error(err.Error())
 		}
 		s.atomicUploadStats.arbitrary.uploadFailedFileCount.Add(1)
+		batch.recordFailure()
 		return
 	}
 
@@ -474,6 +497,7 @@ func (s *Sync) syncArbitraryFile(f *os.File, tags []string, fileLastModifiedMill
 	}
 	s.atomicUploadStats.arbitrary.uploadedFileCount.Add(1)
 	s.atomicUploadStats.arbitrary.uploadedBytes.Add(bytesUploaded)
+	batch.recordUpload(bytesUploaded)
 }
 
 // moveFailedData takes any data that could not be synced in the parentDir and
@@ -550,6 +574,8 @@ func (s *Sync) runScheduler(ctx context.Context, tkr *clock.Ticker, config Confi
 // while walkDirsAndSendFilesToSync.
 func (s *Sync) walkDirsAndSendFilesToSync(ctx context.Context, config Config) error {
 	s.flushCollectors()
+	batch := newSyncBatch(s.notifier)
+	defer batch.done()
 	var errs []error
 	for _, dir := range config.SyncPaths() {
 		s.logger.Debugf("syncing from: %s", dir)
@@ -590,7 +616,7 @@ func (s *Sync) walkDirsAndSendFilesToSync(ctx context.Context, config Config) er
 					loggedDirPaths[dirPath] = true
 					s.logger.Debugf("syncing from subdirectory: %s", dirPath)
 				}
-				s.sendToSync(ctx, path)
+				s.sendToSync(ctx, path, batch)
 			}
 			return nil
 		})
@@ -625,14 +651,14 @@ func isNonCaptureFileThatIsNotBeingWrittenTo(timeSinceMod time.Duration, path st
 		info.Size() > 0
 }
 
-func (s *Sync) sendToSync(ctx context.Context, path string) {
+func (s *Sync) sendToSync(ctx context.Context, path string, batch *syncBatch) {
+	batch.add()
 	select {
 	case <-ctx.Done():
-		return
+		batch.done()
 	case <-s.configCtx.Done():
-		return
-	case s.filesToSync <- path:
-		return
+		batch.done()
+	case s.filesToSync <- syncRequest{path: path, batch: batch}:
 	}
 }
 
@@ -0,0 +1,133 @@
+package datamanager
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/utils"
+)
+
+// CaptureBurstCommand is the DoCommand key that starts a capture burst on a data manager.
+const CaptureBurstCommand = "capture_burst"
+
+// MaxCaptureBurstDuration bounds how long a single capture burst can run.
+const MaxCaptureBurstDuration = 5 * time.Minute
+
+// A CaptureBurst captures data from resources at a high rate for a short time, outside of the
+// configured capture schedules, such as to grab diagnostic data on demand. Every capture method
+// configured for each of its resources is captured, whether or not it is disabled, into the
+// usual capture directory so that it is synced like any other data.
+type CaptureBurst struct {
+	// Resources are the names of the resources to capture from.
+	Resources []string
+	// Duration is how long the burst runs for, at most MaxCaptureBurstDuration.
+	Duration time.Duration
+	// FrequencyHz is how often each method is captured during the burst.
+	FrequencyHz float64
+	// Tags are added to the data captured by the burst, after the data manager's own tags.
+	Tags []string
+}
+
+// Validate ensures the burst is bounded and captures something.
+func (b CaptureBurst) Validate() error {
+	if len(b.Resources) == 0 {
+		return errors.New("capture burst must list at least one resource")
+	}
+	if b.Duration <= 0 || b.Duration > MaxCaptureBurstDuration {
+		return errors.Errorf("capture burst duration must be positive and at most %s, got %s", MaxCaptureBurstDuration, b.Duration)
+	}
+	if b.FrequencyHz <= 0 {
+		return errors.Errorf("capture burst frequency_hz must be positive, got %v", b.FrequencyHz)
+	}
+	return nil
+}
+
+// CaptureBurstStarted describes a capture burst that has started.
+type CaptureBurstStarted struct {
+	// Methods are the resource methods being captured, such as "rdk:component:arm/arm1/EndPosition".
+	Methods []string
+	// EndsAt is when the burst stops capturing.
+	EndsAt time.Time
+}
+
+// StartCaptureBurst starts a capture burst on a data manager through DoCommand, returning once
+// it has started rather than when it ends.
+func StartCaptureBurst(ctx context.Context, svc Service, burst CaptureBurst) (CaptureBurstStarted, error) {
+	if err := burst.Validate(); err != nil {
+		return CaptureBurstStarted{}, err
+	}
+	resp, err := svc.DoCommand(ctx, map[string]interface{}{CaptureBurstCommand: burst.toCommand()})
+	if err != nil {
+		return CaptureBurstStarted{}, err
+	}
+	fields, ok := resp[CaptureBurstCommand].(map[string]interface{})
+	if !ok {
+		return CaptureBurstStarted{}, errors.New("data manager does not support capture bursts")
+	}
+	return CaptureBurstStartedFromCommand(fields)
+}
+
+func (b CaptureBurst) toCommand() map[string]interface{} {
+	resources := make([]interface{}, 0, len(b.Resources))
+	for _, r := range b.Resources {
+		resources = append(resources, r)
+	}
+	tags := make([]interface{}, 0, len(b.Tags))
+	for _, t := range b.Tags {
+		tags = append(tags, t)
+	}
+	return map[string]interface{}{
+		"resources":    resources,
+		"duration_sec": b.Duration.Seconds(),
+		"frequency_hz": b.FrequencyHz,
+		"tags":         tags,
+	}
+}
+
+// CaptureBurstFromCommand reads the value of a CaptureBurstCommand.
+func CaptureBurstFromCommand(cmd interface{}) (CaptureBurst, error) {
+	fields, ok := cmd.(map[string]interface{})
+	if !ok {
+		return CaptureBurst{}, errors.Errorf("%s must be an object, got %T", CaptureBurstCommand, cmd)
+	}
+	r := utils.NewAttributeReader(fields)
+	burst := CaptureBurst{
+		Resources:   utils.Required[[]string](r, "resources"),
+		Duration:    time.Duration(utils.Required[float64](r, "duration_sec") * float64(time.Second)),
+		FrequencyHz: utils.Required[float64](r, "frequency_hz"),
+		Tags:        utils.Optional[[]string](r, "tags", nil),
+	}
+	if err := r.Err(); err != nil {
+		return CaptureBurst{}, errors.Wrapf(err, "invalid %s", CaptureBurstCommand)
+	}
+	return burst, burst.Validate()
+}
+
+// ToCommand returns the response to a CaptureBurstCommand.
+func (s CaptureBurstStarted) ToCommand() map[string]interface{} {
+	methods := make([]interface{}, 0, len(s.Methods))
+	for _, m := range s.Methods {
+		methods = append(methods, m)
+	}
+	return map[string]interface{}{
+		"methods": methods,
+		"ends_at": s.EndsAt.Format(time.RFC3339Nano),
+	}
+}
+
+// CaptureBurstStartedFromCommand reads the response to a CaptureBurstCommand.
+func CaptureBurstStartedFromCommand(fields map[string]interface{}) (CaptureBurstStarted, error) {
+	r := utils.NewAttributeReader(fields)
+	methods := utils.Optional[[]string](r, "methods", nil)
+	endsAt := utils.Required[string](r, "ends_at")
+	if err := r.Err(); err != nil {
+		return CaptureBurstStarted{}, errors.Wrapf(err, "invalid %s response", CaptureBurstCommand)
+	}
+	t, err := time.Parse(time.RFC3339Nano, endsAt)
+	if err != nil {
+		return CaptureBurstStarted{}, errors.Wrapf(err, "invalid %s response", CaptureBurstCommand)
+	}
+	return CaptureBurstStarted{Methods: methods, EndsAt: t}, nil
+}
@@ -0,0 +1,44 @@
+package datamanager
+
+import (
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+)
+
+func TestCaptureBurstCommand(t *testing.T) {
+	burst := CaptureBurst{
+		Resources:   []string{"arm1", "rdk:component:camera/cam"},
+		Duration:    1500 * time.Millisecond,
+		FrequencyHz: 50,
+		Tags:        []string{"diagnostic"},
+	}
+	read, err := CaptureBurstFromCommand(burst.toCommand())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, read, test.ShouldResemble, burst)
+
+	started := CaptureBurstStarted{
+		Methods: []string{"rdk:component:arm/arm1/EndPosition"},
+		EndsAt:  time.Date(2026, 3, 4, 5, 6, 7, 8, time.UTC),
+	}
+	readStarted, err := CaptureBurstStartedFromCommand(started.ToCommand())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, readStarted.Methods, test.ShouldResemble, started.Methods)
+	test.That(t, readStarted.EndsAt.Equal(started.EndsAt), test.ShouldBeTrue)
+
+	_, err = CaptureBurstFromCommand("arm1")
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = CaptureBurstFromCommand(map[string]interface{}{"resources": []interface{}{"arm1"}})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "duration_sec")
+
+	for _, bad := range []CaptureBurst{
+		{Duration: time.Second, FrequencyHz: 1},
+		{Resources: []string{"arm1"}, FrequencyHz: 1},
+		{Resources: []string{"arm1"}, Duration: MaxCaptureBurstDuration + time.Second, FrequencyHz: 1},
+		{Resources: []string{"arm1"}, Duration: time.Second},
+	} {
+		test.That(t, bad.Validate(), test.ShouldNotBeNil)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"reflect"
 	"slices"
 
+	"github.com/pkg/errors"
 	servicepb "go.viam.com/api/service/datamanager/v1"
 
 	"go.viam.com/rdk/resource"
@@ -151,6 +152,39 @@ type DataCaptureConfig struct {
 	Disabled           bool                   `json:"disabled"`
 	Tags               []string               `json:"tags,omitempty"`
 	CaptureDirectory   string                 `json:"capture_directory"`
+	AutoLabel          *AutoLabelConfig       `json:"auto_label,omitempty"`
+}
+
+// AutoLabelConfig describes how images captured by a collector are labeled with the outputs of a
+// vision service, to bootstrap training datasets from what a machine sees in production. The
+// labels of each image are written to a sidecar file next to its capture file, which is synced
+// along with it.
+type AutoLabelConfig struct {
+	// VisionService is the name of the vision service that labels the images.
+	VisionService string `json:"vision_service"`
+	// Detections, when true, labels images with the bounding boxes the vision service detects.
+	Detections bool `json:"detections,omitempty"`
+	// Classifications, when not zero, labels images with up to this many classifications.
+	Classifications int `json:"classifications,omitempty"`
+	// MinConfidence leaves out detections and classifications with lower confidence scores.
+	MinConfidence float64 `json:"min_confidence,omitempty"`
+}
+
+// Validate ensures all parts of the config are valid.
+func (c *AutoLabelConfig) Validate() error {
+	if c.VisionService == "" {
+		return errors.New("auto_label.vision_service is required")
+	}
+	if !c.Detections && c.Classifications == 0 {
+		return errors.New("auto_label must label images with detections, classifications or both")
+	}
+	if c.Classifications < 0 {
+		return errors.New("auto_label.classifications can't be negative")
+	}
+	if c.MinConfidence < 0 || c.MinConfidence > 1 {
+		return errors.New("auto_label.min_confidence must be between 0 and 1")
+	}
+	return nil
 }
 
 // Equals checks if one capture config is equal to another.
@@ -163,7 +197,8 @@ func (c *DataCaptureConfig) Equals(other *DataCaptureConfig) bool {
 		c.Disabled == other.Disabled &&
 		slices.Compare(c.Tags, other.Tags) == 0 &&
 		reflect.DeepEqual(c.AdditionalParams, other.AdditionalParams) &&
-		c.CaptureDirectory == other.CaptureDirectory
+		c.CaptureDirectory == other.CaptureDirectory &&
+		reflect.DeepEqual(c.AutoLabel, other.AutoLabel)
 }
 
 // ShouldSyncKey is a special key we use within a modular sensor to pass a boolean
@@ -0,0 +1,162 @@
+package motion
+
+import (
+	"context"
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/motionplan"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// defaultArticulationStep is the default spacing of waypoints along an articulated motion,
+// in degrees for hinges and mm for slides.
+const defaultArticulationStep = 5.
+
+// A Hinge describes a revolute articulation, such as a door or a lid, in the frame of an
+// ArticulatedMoveReq.
+type Hinge struct {
+	// Point is any point on the hinge axis, in mm.
+	Point r3.Vector
+	// Axis is the direction of the hinge axis. Positive angles open the articulation by the
+	// right hand rule about this axis.
+	Axis r3.Vector
+}
+
+// A Slide describes a prismatic articulation, such as a drawer, in the frame of an
+// ArticulatedMoveReq.
+type Slide struct {
+	// Direction is the direction the handle moves as the articulation opens.
+	Direction r3.Vector
+}
+
+// ArticulatedMoveReq describes a motion that follows an articulated object, like opening a
+// door about its hinge or pulling out a drawer, starting from a grasp on its handle.
+type ArticulatedMoveReq struct {
+	// ComponentName of the component grasping the handle
+	ComponentName resource.Name
+	// Frame that Grasp, Hinge and Slide are expressed in
+	Frame string
+	// Grasp is the pose of the component when grasping the handle, at the start of the motion.
+	Grasp spatialmath.Pose
+	// Exactly one of Hinge and Slide must be set.
+	Hinge *Hinge
+	Slide *Slide
+	// Amount is the angle to rotate about the hinge in degrees, or the distance to slide in mm.
+	// Negative amounts close the articulation.
+	Amount float64
+	// StepSize is the most degrees or mm between waypoints. Defaults to 5.
+	StepSize float64
+	// LineToleranceMm and OrientationToleranceDegs are how far the component may deviate from
+	// the straight line between waypoints, which lets it comply with the articulation.
+	LineToleranceMm          float64
+	OrientationToleranceDegs float64
+	// The external environment to be considered for the duration of the move
+	WorldState *referenceframe.WorldState
+	Extra      map[string]interface{}
+}
+
+// Waypoints returns the poses, in the request's frame, that the component passes through
+// during the articulated motion, starting with the grasp.
+func (req ArticulatedMoveReq) Waypoints() ([]spatialmath.Pose, error) {
+	if req.Grasp == nil {
+		return nil, errors.New("grasp pose is required")
+	}
+	if req.StepSize < 0 {
+		return nil, errors.New("step size cannot be negative")
+	}
+	step := req.StepSize
+	if step == 0 {
+		step = defaultArticulationStep
+	}
+	switch {
+	case req.Hinge != nil && req.Slide != nil:
+		return nil, errors.New("only one of hinge and slide can be set")
+	case req.Hinge != nil:
+		if req.Hinge.Axis.Norm() == 0 {
+			return nil, errors.New("hinge axis cannot be zero")
+		}
+		return ArcAboutHinge(req.Grasp, *req.Hinge, req.Amount, step), nil
+	case req.Slide != nil:
+		if req.Slide.Direction.Norm() == 0 {
+			return nil, errors.New("slide direction cannot be zero")
+		}
+		return LinearPull(req.Grasp, *req.Slide, req.Amount, step), nil
+	default:
+		return nil, errors.New("one of hinge or slide is required")
+	}
+}
+
+// ArcAboutHinge returns the poses of a grasp carried along an arc as the hinge rotates by
+// angleDegs, spaced at most stepDegs apart. The grasp keeps its orientation relative to the
+// articulated object. The first pose is the grasp itself.
+func ArcAboutHinge(grasp spatialmath.Pose, hinge Hinge, angleDegs, stepDegs float64) []spatialmath.Pose {
+	axis := hinge.Axis.Normalize()
+	toHinge := spatialmath.NewPoseFromPoint(hinge.Point.Mul(-1))
+	fromHinge := spatialmath.NewPoseFromPoint(hinge.Point)
+	return interpolateArticulation(angleDegs, stepDegs, func(amount float64) spatialmath.Pose {
+		rotation := spatialmath.NewPose(r3.Vector{}, &spatialmath.R4AA{
+			Theta: amount * math.Pi / 180,
+			RX:    axis.X,
+			RY:    axis.Y,
+			RZ:    axis.Z,
+		})
+		return spatialmath.Compose(fromHinge, spatialmath.Compose(rotation, spatialmath.Compose(toHinge, grasp)))
+	})
+}
+
+// LinearPull returns the poses of a grasp carried along a slide by distanceMm, spaced at most
+// stepMm apart. The first pose is the grasp itself.
+func LinearPull(grasp spatialmath.Pose, slide Slide, distanceMm, stepMm float64) []spatialmath.Pose {
+	direction := slide.Direction.Normalize()
+	return interpolateArticulation(distanceMm, stepMm, func(amount float64) spatialmath.Pose {
+		return spatialmath.Compose(spatialmath.NewPoseFromPoint(direction.Mul(amount)), grasp)
+	})
+}
+
+// interpolateArticulation returns the poses at evenly spaced amounts of the articulation,
+// from zero to total, that are at most step apart.
+func interpolateArticulation(total, step float64, at func(amount float64) spatialmath.Pose) []spatialmath.Pose {
+	steps := int(math.Ceil(math.Abs(total) / step))
+	poses := make([]spatialmath.Pose, 0, steps+1)
+	for i := 0; i <= steps; i++ {
+		amount := 0.
+		if steps > 0 {
+			amount = total * float64(i) / float64(steps)
+		}
+		poses = append(poses, at(amount))
+	}
+	return poses
+}
+
+// MoveArticulated moves the component along the articulated motion described by req, one
+// waypoint at a time, with each move constrained to the line between waypoints. The component
+// is expected to already be grasping the handle. It stops at the first move that fails.
+func MoveArticulated(ctx context.Context, ms Service, req ArticulatedMoveReq) error {
+	waypoints, err := req.Waypoints()
+	if err != nil {
+		return err
+	}
+	constraints := &motionplan.Constraints{
+		LinearConstraint: []motionplan.LinearConstraint{{
+			LineToleranceMm:          req.LineToleranceMm,
+			OrientationToleranceDegs: req.OrientationToleranceDegs,
+		}},
+	}
+	for i, waypoint := range waypoints[1:] {
+		if _, err := ms.Move(ctx, MoveReq{
+			ComponentName: req.ComponentName,
+			Destination:   referenceframe.NewPoseInFrame(req.Frame, waypoint),
+			WorldState:    req.WorldState,
+			Constraints:   constraints,
+			Extra:         req.Extra,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to move to articulation waypoint %d of %d", i+1, len(waypoints)-1)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package motion_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/services/motion"
+	"go.viam.com/rdk/spatialmath"
+	injectmotion "go.viam.com/rdk/testutils/inject/motion"
+)
+
+func TestArticulatedWaypoints(t *testing.T) {
+	grasp := spatialmath.NewPoseFromPoint(r3.Vector{X: 500})
+
+	t.Run("hinge", func(t *testing.T) {
+		// a door hinged about the Z axis at the origin, opened a quarter turn
+		waypoints := motion.ArcAboutHinge(grasp, motion.Hinge{Axis: r3.Vector{Z: 1}}, 90, 10)
+		test.That(t, waypoints, test.ShouldHaveLength, 10)
+		test.That(t, spatialmath.PoseAlmostEqual(waypoints[0], grasp), test.ShouldBeTrue)
+
+		expected := spatialmath.NewPose(r3.Vector{Y: 500}, &spatialmath.OrientationVectorDegrees{OZ: 1, Theta: 90})
+		test.That(t, spatialmath.PoseAlmostEqual(waypoints[9], expected), test.ShouldBeTrue)
+		for _, wp := range waypoints {
+			test.That(t, wp.Point().Norm(), test.ShouldAlmostEqual, 500)
+		}
+	})
+
+	t.Run("slide", func(t *testing.T) {
+		waypoints := motion.LinearPull(grasp, motion.Slide{Direction: r3.Vector{X: 2}}, 200, 30)
+		test.That(t, waypoints, test.ShouldHaveLength, 8)
+		test.That(t, spatialmath.PoseAlmostEqual(waypoints[7], spatialmath.NewPoseFromPoint(r3.Vector{X: 700})), test.ShouldBeTrue)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := motion.ArticulatedMoveReq{Grasp: grasp}.Waypoints()
+		test.That(t, err, test.ShouldNotBeNil)
+		_, err = motion.ArticulatedMoveReq{Grasp: grasp, Hinge: &motion.Hinge{}, Amount: 90}.Waypoints()
+		test.That(t, err, test.ShouldNotBeNil)
+		_, err = motion.ArticulatedMoveReq{
+			Grasp: grasp,
+			Hinge: &motion.Hinge{Axis: r3.Vector{Z: 1}},
+			Slide: &motion.Slide{Direction: r3.Vector{X: 1}},
+		}.Waypoints()
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
+
+func TestMoveArticulated(t *testing.T) {
+	ms := injectmotion.NewMotionService("my motion")
+	var reqs []motion.MoveReq
+	ms.MoveFunc = func(ctx context.Context, req motion.MoveReq) (bool, error) {
+		reqs = append(reqs, req)
+		if len(reqs) == 3 {
+			return false, errors.New("stuck")
+		}
+		return true, nil
+	}
+
+	req := motion.ArticulatedMoveReq{
+		ComponentName:   arm.Named("arm"),
+		Frame:           "world",
+		Grasp:           spatialmath.NewPoseFromPoint(r3.Vector{X: 500}),
+		Slide:           &motion.Slide{Direction: r3.Vector{X: 1}},
+		Amount:          10,
+		StepSize:        5,
+		LineToleranceMm: 2,
+	}
+	test.That(t, motion.MoveArticulated(context.Background(), ms, req), test.ShouldBeNil)
+	test.That(t, reqs, test.ShouldHaveLength, 2)
+	test.That(t, reqs[1].Destination.Parent(), test.ShouldEqual, "world")
+	test.That(t, reqs[1].Destination.Pose().Point().X, test.ShouldAlmostEqual, 510)
+	test.That(t, reqs[1].Constraints.LinearConstraint[0].LineToleranceMm, test.ShouldEqual, 2)
+
+	req.Amount = 20
+	err := motion.MoveArticulated(context.Background(), ms, req)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "stuck")
+	test.That(t, reqs, test.ShouldHaveLength, 3)
+}
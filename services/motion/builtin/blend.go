@@ -0,0 +1,249 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/golang/geo/r3"
+	goutils "go.viam.com/utils"
+
+	"go.viam.com/rdk/motionplan"
+	"go.viam.com/rdk/motionplan/armplanning"
+	"go.viam.com/rdk/referenceframe"
+)
+
+// blendRadiusKey is the Move extra that blends the corners of a move, both between the waypoints of one
+// move and between consecutive moves of the same component. Rather than coming to rest at a goal, the
+// component turns towards the next one once it is within blend_radius_mm of it, so the corner is cut by a
+// path planned from there.
+//
+// A blended Move returns once the component is within the radius of its last goal, while the rest of the
+// move is carried out in the background. If the next Move is of the same component, the rest of the
+// move is cancelled and the next move is planned from wherever the component got to. Otherwise, the next
+// Move waits for the rest of the move to be carried out first.
+const blendRadiusKey = "blend_radius_mm"
+
+// how finely a blend point is searched for between two steps of a trajectory, as a fraction of the step.
+const blendSearchResolution = 1e-3
+
+// A pendingBlend is the rest of a blended move, being carried out in the background.
+type pendingBlend struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// blendRadius returns the blend radius for a move, from its extras or else the service config. A zero
+// radius means the move is not blended.
+func (ms *builtIn) blendRadius(extra map[string]interface{}) (float64, error) {
+	v, ok := extra[blendRadiusKey]
+	if !ok {
+		return ms.conf.BlendRadiusMM, nil
+	}
+	var radius float64
+	switch v := v.(type) {
+	case float64:
+		radius = v
+	case int:
+		radius = float64(v)
+	default:
+		return 0, fmt.Errorf("%s must be a number, got %v", blendRadiusKey, v)
+	}
+	if radius < 0 || math.IsNaN(radius) {
+		return 0, fmt.Errorf("%s must not be negative, got %v", blendRadiusKey, radius)
+	}
+	return radius, nil
+}
+
+// settleBlends cancels the rest of the last blended move of component, so that its next move cuts the
+// corner, and waits for the rest of the blended moves of every other component to be carried out. An
+// empty component waits for every blended move.
+func (ms *builtIn) settleBlends(component string) {
+	ms.blendMu.Lock()
+	pending := ms.pendingBlends
+	ms.pendingBlends = nil
+	ms.blendMu.Unlock()
+	if p, ok := pending[component]; ok {
+		p.cancel()
+	}
+	for _, p := range pending {
+		<-p.done
+	}
+}
+
+// cancelBlends cancels the rest of every blended move, leaving components where they got to.
+func (ms *builtIn) cancelBlends() {
+	ms.blendMu.Lock()
+	pending := ms.pendingBlends
+	ms.pendingBlends = nil
+	ms.blendMu.Unlock()
+	for _, p := range pending {
+		p.cancel()
+		<-p.done
+	}
+}
+
+// executeBlended carries out a trajectory of component up to where it is within radius of its goal, and
+// then the rest of it in the background, until it is settled by the next move.
+func (ms *builtIn) executeBlended(
+	ctx context.Context,
+	fs *referenceframe.FrameSystem,
+	component string,
+	trajectory motionplan.Trajectory,
+	radius float64,
+) error {
+	head, tail, err := splitForBlend(fs, component, trajectory, radius)
+	if err != nil {
+		return err
+	}
+	if err := ms.execute(ctx, head, math.MaxFloat64); err != nil {
+		return err
+	}
+	if len(tail) < 2 {
+		return nil
+	}
+
+	// the rest of the move outlives the call, so it is only cancelled by the next move or the service
+	tailCtx, cancel := context.WithCancel(context.Background())
+	p := &pendingBlend{cancel: cancel, done: make(chan struct{})}
+	ms.blendMu.Lock()
+	if prior, ok := ms.pendingBlends[component]; ok {
+		// only possible if moves of the same component overlap, in which case the latest wins
+		prior.cancel()
+	}
+	if ms.pendingBlends == nil {
+		ms.pendingBlends = map[string]*pendingBlend{}
+	}
+	ms.pendingBlends[component] = p
+	ms.blendMu.Unlock()
+
+	goutils.PanicCapturingGo(func() {
+		defer close(p.done)
+		defer cancel()
+		if err := ms.execute(tailCtx, tail, math.MaxFloat64); err != nil && tailCtx.Err() == nil {
+			ms.logger.Warnw("failed to finish blended move", "component", component, "error", err)
+		}
+	})
+	return nil
+}
+
+// planBlended plans a move of component through each goal of the request in turn, leaving each goal but
+// the last once the component is within radius of it. It returns the trajectory of the whole move.
+func (ms *builtIn) planBlended(
+	ctx context.Context,
+	request *armplanning.PlanRequest,
+	component string,
+	radius float64,
+) (motionplan.Trajectory, error) {
+	trajectory := motionplan.Trajectory{}
+	start := request.StartState
+	for i, goal := range request.Goals {
+		segmentRequest := *request
+		segmentRequest.Goals = []*armplanning.PlanState{goal}
+		segmentRequest.StartState = start
+		plan, err := ms.planMotion(ctx, &segmentRequest, ms.logger)
+		if err != nil {
+			return nil, err
+		}
+		segment := plan.Trajectory()
+		if i < len(request.Goals)-1 {
+			if segment, _, err = splitForBlend(request.FrameSystem, component, segment, radius); err != nil {
+				return nil, err
+			}
+		}
+		if i > 0 && len(segment) > 0 {
+			// the first step of each segment is the last step of the prior one
+			segment = segment[1:]
+		}
+		trajectory = append(trajectory, segment...)
+
+		// the next segment starts where this one was left, with frames it does not move where they were
+		next := referenceframe.FrameSystemInputs{}
+		for name, inputs := range start.Configuration() {
+			next[name] = inputs
+		}
+		if len(trajectory) > 0 {
+			for name, inputs := range trajectory[len(trajectory)-1] {
+				next[name] = inputs
+			}
+		}
+		start = armplanning.NewPlanState(nil, next)
+	}
+	return trajectory, nil
+}
+
+// splitForBlend splits a trajectory where the named frame comes within radius mm of where it ends.
+// The head ends and the tail starts at the split, so the tail is empty if the frame is never further
+// than radius from its goal.
+func splitForBlend(
+	fs *referenceframe.FrameSystem,
+	frame string,
+	trajectory motionplan.Trajectory,
+	radius float64,
+) (motionplan.Trajectory, motionplan.Trajectory, error) {
+	if len(trajectory) < 2 || radius <= 0 {
+		return trajectory, nil, nil
+	}
+	position := func(inputs referenceframe.FrameSystemInputs) (r3.Vector, error) {
+		tf, err := fs.Transform(inputs, referenceframe.NewZeroPoseInFrame(frame), referenceframe.World)
+		if err != nil {
+			return r3.Vector{}, err
+		}
+		return tf.(*referenceframe.PoseInFrame).Pose().Point(), nil
+	}
+	goal, err := position(trajectory[len(trajectory)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	outside := func(inputs referenceframe.FrameSystemInputs) (bool, error) {
+		p, err := position(inputs)
+		if err != nil {
+			return false, err
+		}
+		return p.Distance(goal) > radius, nil
+	}
+
+	// find the last step that is further than radius from the goal
+	last := -1
+	for i := len(trajectory) - 2; i >= 0; i-- {
+		out, err := outside(trajectory[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		if out {
+			last = i
+			break
+		}
+	}
+	if last < 0 {
+		return trajectory, nil, nil
+	}
+
+	// and bisect the step after it for where the frame crosses into the radius
+	from, to := trajectory[last], trajectory[last+1]
+	lo, hi := 0., 1.
+	for hi-lo > blendSearchResolution {
+		mid := (lo + hi) / 2
+		inputs, err := referenceframe.InterpolateFS(fs, from, to, mid)
+		if err != nil {
+			return nil, nil, err
+		}
+		out, err := outside(inputs)
+		if err != nil {
+			return nil, nil, err
+		}
+		if out {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	split, err := referenceframe.InterpolateFS(fs, from, to, hi)
+	if err != nil {
+		return nil, nil, err
+	}
+	head := append(slices.Clone(trajectory[:last+1]), split)
+	tail := append(motionplan.Trajectory{split}, trajectory[last+1:]...)
+	return head, tail, nil
+}
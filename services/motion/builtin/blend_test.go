@@ -0,0 +1,216 @@
+package builtin
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/motionplan"
+	"go.viam.com/rdk/motionplan/armplanning"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+)
+
+func TestSplitForBlend(t *testing.T) {
+	fs := referenceframe.NewEmptyFrameSystem("test")
+	gantry, err := referenceframe.NewTranslationalFrame("gantry", r3.Vector{X: 1}, referenceframe.Limit{Min: -1000, Max: 1000})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(gantry, fs.World()), test.ShouldBeNil)
+
+	step := func(x float64) referenceframe.FrameSystemInputs {
+		return referenceframe.FrameSystemInputs{"gantry": referenceframe.FloatsToInputs([]float64{x})}
+	}
+	trajectory := motionplan.Trajectory{step(0), step(100), step(200)}
+
+	t.Run("splits where the frame comes within the radius", func(t *testing.T) {
+		head, tail, err := splitForBlend(fs, "gantry", trajectory, 50)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, head, test.ShouldHaveLength, 3)
+		test.That(t, tail, test.ShouldHaveLength, 2)
+		test.That(t, head[0], test.ShouldResemble, step(0))
+		test.That(t, head[2]["gantry"][0].Value, test.ShouldAlmostEqual, 150, 0.5)
+		test.That(t, tail[0], test.ShouldResemble, head[2])
+		test.That(t, tail[1], test.ShouldResemble, step(200))
+	})
+
+	t.Run("does not split within the radius", func(t *testing.T) {
+		head, tail, err := splitForBlend(fs, "gantry", trajectory, 500)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, head, test.ShouldResemble, trajectory)
+		test.That(t, tail, test.ShouldBeEmpty)
+	})
+
+	t.Run("does not split without a radius", func(t *testing.T) {
+		head, tail, err := splitForBlend(fs, "gantry", trajectory, 0)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, head, test.ShouldResemble, trajectory)
+		test.That(t, tail, test.ShouldBeEmpty)
+	})
+}
+
+func TestBlendRadius(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	ms, err := NewBuiltIn(ctx, nil, resource.Config{ConvertedAttributes: &Config{BlendRadiusMM: 10}}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, ms.Close(ctx), test.ShouldBeNil)
+	}()
+
+	radius, err := ms.(*builtIn).blendRadius(nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, radius, test.ShouldEqual, 10)
+
+	radius, err = ms.(*builtIn).blendRadius(map[string]interface{}{blendRadiusKey: 25})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, radius, test.ShouldEqual, 25)
+
+	_, err = ms.(*builtIn).blendRadius(map[string]interface{}{blendRadiusKey: -1.})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = ms.(*builtIn).blendRadius(map[string]interface{}{blendRadiusKey: "wide"})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	_, _, err = (&Config{BlendRadiusMM: -1}).Validate("")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestPlanBlended(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	fs := referenceframe.NewEmptyFrameSystem("test")
+	limit := referenceframe.Limit{Min: -1000, Max: 1000}
+	x, err := referenceframe.NewTranslationalFrame("x", r3.Vector{X: 1}, limit)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(x, fs.World()), test.ShouldBeNil)
+	y, err := referenceframe.NewTranslationalFrame("y", r3.Vector{Y: 1}, limit)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(y, x), test.ShouldBeNil)
+
+	config := func(x, y float64) referenceframe.FrameSystemInputs {
+		return referenceframe.FrameSystemInputs{
+			"x": referenceframe.FloatsToInputs([]float64{x}),
+			"y": referenceframe.FloatsToInputs([]float64{y}),
+		}
+	}
+	ms := &builtIn{conf: &Config{}, logger: logger}
+	trajectory, err := ms.planBlended(ctx, &armplanning.PlanRequest{
+		FrameSystem: fs,
+		Goals: []*armplanning.PlanState{
+			armplanning.NewPlanState(nil, config(100, 0)),
+			armplanning.NewPlanState(nil, config(100, 100)),
+		},
+		StartState:     armplanning.NewPlanState(nil, config(0, 0)),
+		PlannerOptions: armplanning.NewBasicPlannerOptions(),
+	}, "y", 20)
+	test.That(t, err, test.ShouldBeNil)
+
+	// the move ends at the last goal without passing through the corner
+	position := func(step referenceframe.FrameSystemInputs) r3.Vector {
+		return r3.Vector{X: step["x"][0].Value, Y: step["y"][0].Value}
+	}
+	test.That(t, position(trajectory[0]), test.ShouldResemble, r3.Vector{})
+	test.That(t, position(trajectory[len(trajectory)-1]), test.ShouldResemble, r3.Vector{X: 100, Y: 100})
+	for _, step := range trajectory {
+		test.That(t, position(step).Distance(r3.Vector{X: 100}), test.ShouldBeGreaterThan, 1)
+	}
+}
+
+// slowStage is an InputEnabled component whose moves beyond holdBeyond wait to be let through or cancelled.
+type slowStage struct {
+	resource.Named
+	resource.TriviallyReconfigurable
+	resource.TriviallyCloseable
+	holdBeyond float64
+	release    chan struct{}
+
+	mu       sync.Mutex
+	position float64
+}
+
+func (s *slowStage) Kinematics(ctx context.Context) (referenceframe.Model, error) {
+	return nil, referenceframe.ErrNoModelInformation
+}
+
+func (s *slowStage) CurrentInputs(ctx context.Context) ([]referenceframe.Input, error) {
+	return referenceframe.FloatsToInputs([]float64{s.at()}), nil
+}
+
+func (s *slowStage) GoToInputs(ctx context.Context, inputSteps ...[]referenceframe.Input) error {
+	for _, inputs := range inputSteps {
+		if inputs[0].Value > s.holdBeyond {
+			select {
+			case <-s.release:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		s.mu.Lock()
+		s.position = inputs[0].Value
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+func (s *slowStage) at() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.position
+}
+
+func TestExecuteBlended(t *testing.T) {
+	ctx := context.Background()
+	fs := referenceframe.NewEmptyFrameSystem("test")
+	gantry, err := referenceframe.NewTranslationalFrame("gantry", r3.Vector{X: 1}, referenceframe.Limit{Min: -1000, Max: 1000})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(gantry, fs.World()), test.ShouldBeNil)
+
+	step := func(x float64) referenceframe.FrameSystemInputs {
+		return referenceframe.FrameSystemInputs{"gantry": referenceframe.FloatsToInputs([]float64{x})}
+	}
+	trajectory := motionplan.Trajectory{step(0), step(100), step(200)}
+
+	// starts a blended move whose rest is held until released
+	blendedMove := func(t *testing.T) (*builtIn, *slowStage) {
+		stage := &slowStage{
+			Named:      resource.NewName(resource.APINamespaceRDK.WithComponentType("gantry"), "gantry").AsNamed(),
+			holdBeyond: 160,
+			release:    make(chan struct{}),
+		}
+		ms := &builtIn{
+			conf:       &Config{},
+			logger:     logging.NewTestLogger(t),
+			components: map[string]resource.Resource{"gantry": stage},
+		}
+		test.That(t, ms.executeBlended(ctx, fs, "gantry", trajectory, 50), test.ShouldBeNil)
+		// the move returns once the component is within the radius of its goal
+		test.That(t, stage.at(), test.ShouldAlmostEqual, 150, 0.5)
+		return ms, stage
+	}
+
+	t.Run("the next move of the component cuts the corner", func(t *testing.T) {
+		ms, stage := blendedMove(t)
+		ms.settleBlends("gantry")
+		test.That(t, stage.at(), test.ShouldAlmostEqual, 150, 0.5)
+		test.That(t, ms.pendingBlends, test.ShouldBeEmpty)
+	})
+
+	t.Run("moves of other components wait for the rest of the move", func(t *testing.T) {
+		ms, stage := blendedMove(t)
+		close(stage.release)
+		ms.settleBlends("other")
+		test.That(t, stage.at(), test.ShouldEqual, 200)
+		test.That(t, ms.pendingBlends, test.ShouldBeEmpty)
+	})
+
+	t.Run("closing leaves the component where it got to", func(t *testing.T) {
+		ms, stage := blendedMove(t)
+		test.That(t, ms.Close(ctx), test.ShouldBeNil)
+		test.That(t, stage.at(), test.ShouldAlmostEqual, 150, 0.5)
+	})
+}
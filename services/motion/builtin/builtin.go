@@ -100,6 +100,10 @@ type Config struct {
 	PlanFilePath           string `json:"plan_file_path"`
 	LogPlannerErrors       bool   `json:"log_planner_errors"`
 	LogSlowPlanThresholdMS int    `json:"log_slow_plan_threshold_ms"`
+
+	// BlendRadiusMM is the default for the blend_radius_mm Move extra, which blends the corners of a
+	// move through several waypoints and between consecutive moves of the same component.
+	BlendRadiusMM float64 `json:"blend_radius_mm,omitempty"`
 }
 
 func (c *Config) shouldWritePlan(start time.Time, err error) bool {
@@ -129,6 +133,10 @@ func (c *Config) Validate(path string) ([]string, []string, error) {
 		return nil, nil, fmt.Errorf("need a plan_file_path if you sent LogSlowPlanThresholdMS to %v", c.LogSlowPlanThresholdMS)
 	}
 
+	if c.BlendRadiusMM < 0 {
+		return nil, nil, errors.New("blend_radius_mm must not be negative")
+	}
+
 	return []string{framesystem.InternalServiceName.String()}, nil, nil
 }
 
@@ -144,6 +152,10 @@ type builtIn struct {
 	logger                  logging.Logger
 	state                   *state.State
 	configuredDefaultExtras map[string]any
+
+	// pendingBlends holds the rest of the last blended move of each component, by name.
+	blendMu       sync.Mutex
+	pendingBlends map[string]*pendingBlend
 }
 
 // NewBuiltIn returns a new move and grab service for the given robot.
@@ -170,6 +182,8 @@ func (ms *builtIn) Reconfigure(
 ) error {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
+	// blended moves use the components being replaced
+	ms.cancelBlends()
 
 	config, err := resource.NativeConfig[*Config](conf)
 	if err != nil {
@@ -222,6 +236,7 @@ func (ms *builtIn) Reconfigure(
 func (ms *builtIn) Close(ctx context.Context) error {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
+	ms.cancelBlends()
 	if ms.state != nil {
 		ms.state.Stop()
 	}
@@ -234,14 +249,66 @@ func (ms *builtIn) Move(ctx context.Context, req motion.MoveReq) (bool, error) {
 	operation.CancelOtherWithLabel(ctx, builtinOpLabel)
 
 	ms.applyDefaultExtras(req.Extra)
-	plan, err := ms.plan(ctx, req, ms.logger)
+	blendRadius, err := ms.blendRadius(req.Extra)
+	if err != nil {
+		return false, err
+	}
+	// plan from where the last blended moves leave the components
+	ms.settleBlends(req.ComponentName.ShortName())
+	planRequest, err := ms.newPlanRequest(ctx, req, ms.logger)
 	if err != nil {
 		return false, err
 	}
-	err = ms.execute(ctx, plan.Trajectory(), math.MaxFloat64)
+	if planRequest.PlannerOptions.AnytimeTimeout > 0 {
+		err = ms.moveAnytime(ctx, planRequest)
+		return err == nil, err
+	}
+	var trajectory motionplan.Trajectory
+	if blendRadius > 0 && len(planRequest.Goals) > 1 {
+		trajectory, err = ms.planBlended(ctx, planRequest, req.ComponentName.ShortName(), blendRadius)
+	} else {
+		var plan motionplan.Plan
+		plan, err = ms.planMotion(ctx, planRequest, ms.logger)
+		if plan != nil {
+			trajectory = plan.Trajectory()
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+	if blendRadius > 0 {
+		err = ms.executeBlended(ctx, planRequest.FrameSystem, req.ComponentName.ShortName(), trajectory, blendRadius)
+	} else {
+		err = ms.execute(ctx, trajectory, math.MaxFloat64)
+	}
 	return err == nil, err
 }
 
+// moveAnytime executes the plan to each goal in turn, using the cheapest plan found by the time each
+// one is reached, while the planner keeps improving the segments that have not been reached yet.
+func (ms *builtIn) moveAnytime(ctx context.Context, planRequest *armplanning.PlanRequest) error {
+	start := time.Now()
+	timeout := time.Duration(planRequest.PlannerOptions.AnytimeTimeout * float64(time.Second))
+	planner, err := armplanning.PlanMotionAnytime(ctx, ms.logger, planRequest, start.Add(timeout))
+	if ms.conf.shouldWritePlan(start, err) {
+		if err := ms.writePlanRequest(planRequest); err != nil {
+			ms.logger.Warnf("couldn't write plan: %v", err)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	defer planner.Close()
+
+	for i := range planRequest.Goals {
+		plan := planner.Commit(i + 1)
+		if err := ms.execute(ctx, plan.Segments[i].Trajectory(), math.MaxFloat64); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (ms *builtIn) MoveOnMap(ctx context.Context, req motion.MoveOnMapReq) (motion.ExecutionID, error) {
 	if err := ctx.Err(); err != nil {
 		return uuid.Nil, err
@@ -467,6 +534,7 @@ func (ms *builtIn) DoCommand(ctx context.Context, cmd map[string]interface{}) (m
 
 			resp[DoExecuteCheckStart] = "resource at starting location"
 		}
+		ms.settleBlends("")
 		if err := ms.execute(ctx, trajectory, epsilon); err != nil {
 			return nil, err
 		}
@@ -476,6 +544,15 @@ func (ms *builtIn) DoCommand(ctx context.Context, cmd map[string]interface{}) (m
 }
 
 func (ms *builtIn) plan(ctx context.Context, req motion.MoveReq, logger logging.Logger) (motionplan.Plan, error) {
+	planRequest, err := ms.newPlanRequest(ctx, req, logger)
+	if err != nil {
+		return nil, err
+	}
+	return ms.planMotion(ctx, planRequest, logger)
+}
+
+// newPlanRequest builds the request to plan the motion asked for by req.
+func (ms *builtIn) newPlanRequest(ctx context.Context, req motion.MoveReq, logger logging.Logger) (*armplanning.PlanRequest, error) {
 	frameSys, err := framesystem.NewFromService(ctx, ms.fsService, req.WorldState.Transforms())
 	if err != nil {
 		return nil, err
@@ -566,15 +643,21 @@ func (ms *builtIn) plan(ctx context.Context, req motion.MoveReq, logger logging.
 
 	// the goal is to move the component to goalPose which is specified in coordinates of goalFrameName
 
-	planRequest := &armplanning.PlanRequest{
+	return &armplanning.PlanRequest{
 		FrameSystem:    frameSys,
 		Goals:          worldWaypoints,
 		StartState:     startState,
 		WorldState:     req.WorldState,
 		Constraints:    req.Constraints,
 		PlannerOptions: planOpts,
-	}
+	}, nil
+}
 
+func (ms *builtIn) planMotion(
+	ctx context.Context,
+	planRequest *armplanning.PlanRequest,
+	logger logging.Logger,
+) (motionplan.Plan, error) {
 	start := time.Now()
 	plan, err := armplanning.PlanMotion(ctx, logger, planRequest)
 	if ms.conf.shouldWritePlan(start, err) {
@@ -601,9 +684,9 @@ func (ms *builtIn) execute(ctx context.Context, trajectory motionplan.Trajectory
 				if !ok {
 					return fmt.Errorf("plan had step for resource %s but the motion service is not aware of a component of that name", name)
 				}
-				ie, err := utils.AssertType[framesystem.InputEnabled](r)
-				if err != nil {
-					return err
+				ie, ok := framesystem.AsInputEnabled(ctx, r)
+				if !ok {
+					return framesystem.NotInputEnabledError(r)
 				}
 				curr, err := ie.CurrentInputs(ctx)
 				if err != nil {
@@ -670,9 +753,9 @@ func (ms *builtIn) execute(ctx context.Context, trajectory motionplan.Trajectory
 			if !ok {
 				return fmt.Errorf("plan had step for resource %s but it was not found in the motion", name)
 			}
-			ie, err := utils.AssertType[framesystem.InputEnabled](r)
-			if err != nil {
-				return err
+			ie, ok := framesystem.AsInputEnabled(ctx, r)
+			if !ok {
+				return framesystem.NotInputEnabledError(r)
 			}
 			if err := ie.GoToInputs(ctx, inputs...); err != nil {
 				// If there is an error on GoToInputs, stop the component if possible before returning the error
@@ -104,6 +104,7 @@ type Config struct {
 
 	Obstacles                  []*spatialmath.GeoGeometryConfig `json:"obstacles,omitempty"`
 	BoundingRegions            []*spatialmath.GeoGeometryConfig `json:"bounding_regions,omitempty"`
+	SpeedZones                 []*SpeedZoneConfig               `json:"speed_zones,omitempty"`
 	PositionPollingFrequencyHz float64                          `json:"position_polling_frequency_hz,omitempty"`
 	ObstaclePollingFrequencyHz float64                          `json:"obstacle_polling_frequency_hz,omitempty"`
 	PlanDeviationM             float64                          `json:"plan_deviation_m,omitempty"`
@@ -200,6 +201,13 @@ func (conf *Config) Validate(path string) ([]string, []string, error) {
 		}
 	}
 
+	// Ensure speed zones are valid
+	for _, zone := range conf.SpeedZones {
+		if err := zone.validate(); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// add framesystem service as dependency to be used by builtin and explore motion service
 	deps = append(deps, framesystem.InternalServiceName.String())
 
@@ -238,6 +246,7 @@ type builtIn struct {
 	motionService        motion.Service
 	obstacles            []*spatialmath.GeoGeometry
 	boundingRegions      []*spatialmath.GeoGeometry
+	speedZones           []speedZone
 
 	motionCfg        *motion.MotionConfiguration
 	replanCostFactor float64
@@ -387,12 +396,19 @@ func (svc *builtIn) Reconfigure(ctx context.Context, deps resource.Dependencies,
 		return errors.Wrap(errBoundingRegionsGeomParse, err.Error())
 	}
 
+	// Parse speed zones from the configuration
+	newSpeedZones, err := newSpeedZones(svcConfig.SpeedZones)
+	if err != nil {
+		return err
+	}
+
 	svc.mode = navigation.ModeManual
 	svc.base = baseComponent
 	svc.mapType = mapType
 	svc.motionService = motionSvc
 	svc.obstacles = newObstacles
 	svc.boundingRegions = newBoundingRegions
+	svc.speedZones = newSpeedZones
 	svc.replanCostFactor = replanCostFactor
 	svc.visionServicesByName = visionServicesByName
 	svc.motionCfg = &motion.MotionConfiguration{
@@ -542,14 +558,37 @@ func (svc *builtIn) Close(ctx context.Context) error {
 	return svc.store.Close(ctx)
 }
 
+// moveToWaypoint moves the base to the waypoint, restarting the execution with new speeds
+// whenever the base moves into or out of a speed zone.
 func (svc *builtIn) moveToWaypoint(ctx context.Context, wp navigation.Waypoint, extra map[string]interface{}) error {
+	for {
+		limits, err := svc.currentSpeedLimits(ctx)
+		if err != nil {
+			return err
+		}
+		zoneCtx, zoneCancel := context.WithCancelCause(ctx)
+		stopEnforcing := svc.enforceSpeedZones(zoneCtx, limits, zoneCancel)
+		err = svc.moveToWaypointWithLimits(zoneCtx, wp, extra, limits)
+		stopEnforcing()
+		zoneCancel(nil)
+		if err != nil && ctx.Err() == nil && errors.Is(context.Cause(zoneCtx), errSpeedZoneChanged) {
+			svc.logger.CInfof(ctx, "restarting navigation to waypoint %+v with new speed limits", wp)
+			continue
+		}
+		return err
+	}
+}
+
+func (svc *builtIn) moveToWaypointWithLimits(
+	ctx context.Context, wp navigation.Waypoint, extra map[string]interface{}, limits speedLimits,
+) error {
 	req := motion.MoveOnGlobeReq{
 		ComponentName:      svc.base.Name(),
 		Destination:        wp.ToPoint(),
 		Heading:            math.NaN(),
 		MovementSensorName: svc.movementSensor.Name(),
 		Obstacles:          svc.obstacles,
-		MotionCfg:          svc.motionCfg,
+		MotionCfg:          limits.apply(svc.motionCfg),
 		BoundingRegions:    svc.boundingRegions,
 		Extra:              extra,
 	}
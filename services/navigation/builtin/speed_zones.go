@@ -0,0 +1,195 @@
+package builtin
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/golang/geo/r3"
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/services/motion"
+	"go.viam.com/rdk/spatialmath"
+)
+
+var (
+	errSpeedZoneMissingRegion       = errors.New("speed zone must have a region")
+	errSpeedZoneMissingLimit        = errors.New("speed zone must set max_meters_per_sec or max_degs_per_sec")
+	errSpeedZoneNegativeLimit       = errors.New("speed zone max_meters_per_sec and max_degs_per_sec must be non-negative if set")
+	errSpeedZoneGeomWithTranslation = errors.New("speed zone " + geomWithTranslation)
+	errSpeedZoneGeomParse           = errors.New("speed zone unable to be converted from geometry config")
+
+	// errSpeedZoneChanged is the cause of a waypoint's execution being cancelled because the base
+	// moved into or out of a speed zone.
+	errSpeedZoneChanged = errors.New("speed limit changed")
+)
+
+// SpeedZoneConfig describes a region of the map, such as a slow zone near people or a ramp,
+// in which the base's speed is limited no matter what speeds the motion service plans for.
+type SpeedZoneConfig struct {
+	Name            string                         `json:"name,omitempty"`
+	Region          *spatialmath.GeoGeometryConfig `json:"region"`
+	MaxMetersPerSec float64                        `json:"max_meters_per_sec,omitempty"`
+	MaxDegsPerSec   float64                        `json:"max_degs_per_sec,omitempty"`
+}
+
+func (cfg *SpeedZoneConfig) validate() error {
+	if cfg.Region == nil || cfg.Region.Location == nil || len(cfg.Region.Geometries) == 0 {
+		return errSpeedZoneMissingRegion
+	}
+	if cfg.MaxMetersPerSec < 0 || cfg.MaxDegsPerSec < 0 {
+		return errSpeedZoneNegativeLimit
+	}
+	if cfg.MaxMetersPerSec == 0 && cfg.MaxDegsPerSec == 0 {
+		return errSpeedZoneMissingLimit
+	}
+	for _, geom := range cfg.Region.Geometries {
+		if !geom.TranslationOffset.ApproxEqual(r3.Vector{}) {
+			return errSpeedZoneGeomWithTranslation
+		}
+	}
+	return nil
+}
+
+type speedZone struct {
+	region          []*spatialmath.GeoGeometry
+	maxMetersPerSec float64
+	maxDegsPerSec   float64
+}
+
+func newSpeedZones(configs []*SpeedZoneConfig) ([]speedZone, error) {
+	zones := make([]speedZone, 0, len(configs))
+	for _, cfg := range configs {
+		region, err := spatialmath.GeoGeometriesFromConfig(cfg.Region)
+		if err != nil {
+			return nil, errors.Wrap(errSpeedZoneGeomParse, err.Error())
+		}
+		zones = append(zones, speedZone{
+			region:          region,
+			maxMetersPerSec: cfg.MaxMetersPerSec,
+			maxDegsPerSec:   cfg.MaxDegsPerSec,
+		})
+	}
+	return zones, nil
+}
+
+// contains returns whether the point is within the zone's region.
+func (z speedZone) contains(pt *geo.Point) (bool, error) {
+	// express the region relative to the point, so that the point is at the origin
+	origin := spatialmath.NewPoint(r3.Vector{}, "")
+	for _, geom := range spatialmath.GeoGeometriesToGeometries(z.region, pt) {
+		inside, err := origin.CollidesWith(geom, 0)
+		if err != nil {
+			return false, err
+		}
+		if inside {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// speedLimits are the most restrictive limits of the speed zones at a point. A zero limit
+// means that speed is not limited.
+type speedLimits struct {
+	metersPerSec float64
+	degsPerSec   float64
+}
+
+func speedLimitsAt(zones []speedZone, pt *geo.Point) (speedLimits, error) {
+	var limits speedLimits
+	for _, z := range zones {
+		inside, err := z.contains(pt)
+		if err != nil {
+			return speedLimits{}, err
+		}
+		if !inside {
+			continue
+		}
+		limits.metersPerSec = minLimit(limits.metersPerSec, z.maxMetersPerSec)
+		limits.degsPerSec = minLimit(limits.degsPerSec, z.maxDegsPerSec)
+	}
+	return limits, nil
+}
+
+// minLimit returns the smaller of two limits, where zero means no limit.
+func minLimit(a, b float64) float64 {
+	switch {
+	case a == 0:
+		return b
+	case b == 0:
+		return a
+	default:
+		return math.Min(a, b)
+	}
+}
+
+// apply returns a copy of the motion configuration with its speeds clamped to the limits.
+func (l speedLimits) apply(cfg *motion.MotionConfiguration) *motion.MotionConfiguration {
+	limited := *cfg
+	if l.metersPerSec > 0 && limited.LinearMPerSec > l.metersPerSec {
+		limited.LinearMPerSec = l.metersPerSec
+	}
+	if l.degsPerSec > 0 && limited.AngularDegsPerSec > l.degsPerSec {
+		limited.AngularDegsPerSec = l.degsPerSec
+	}
+	return &limited
+}
+
+// currentSpeedLimits returns the speed limits at the base's current position.
+func (svc *builtIn) currentSpeedLimits(ctx context.Context) (speedLimits, error) {
+	if len(svc.speedZones) == 0 {
+		return speedLimits{}, nil
+	}
+	pt, _, err := svc.movementSensor.Position(ctx, nil)
+	if err != nil {
+		return speedLimits{}, err
+	}
+	return speedLimitsAt(svc.speedZones, pt)
+}
+
+// enforceSpeedZones polls the base's position while a waypoint is being executed with the given
+// limits, and cancels the execution with errSpeedZoneChanged once the limits where the base is
+// differ from them, so that the execution can be restarted with the new limits. It returns a
+// function that stops polling and waits for it to finish.
+func (svc *builtIn) enforceSpeedZones(ctx context.Context, limits speedLimits, cancel context.CancelCauseFunc) func() {
+	if len(svc.speedZones) == 0 {
+		return func() {}
+	}
+	pollHz := defaultPositionPollingHz
+	if svc.motionCfg.PositionPollingFreqHz != nil && *svc.motionCfg.PositionPollingFreqHz > 0 {
+		pollHz = *svc.motionCfg.PositionPollingFreqHz
+	}
+	pollCtx, stop := context.WithCancel(ctx)
+	done := make(chan struct{})
+	utils.PanicCapturingGo(func() {
+		defer close(done)
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / pollHz))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-pollCtx.Done():
+				return
+			case <-ticker.C:
+			}
+			current, err := svc.currentSpeedLimits(pollCtx)
+			if err != nil {
+				if pollCtx.Err() == nil {
+					svc.logger.CWarnw(pollCtx, "failed to check speed zones", "error", err)
+				}
+				continue
+			}
+			if current != limits {
+				svc.logger.CInfof(pollCtx, "speed limit changed from %+v to %+v", limits, current)
+				cancel(errSpeedZoneChanged)
+				return
+			}
+		}
+	})
+	return func() {
+		stop()
+		<-done
+	}
+}
@@ -0,0 +1,83 @@
+package builtin
+
+import (
+	"testing"
+
+	"github.com/golang/geo/r3"
+	geo "github.com/kellydunn/golang-geo"
+	commonpb "go.viam.com/api/common/v1"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/services/motion"
+	"go.viam.com/rdk/spatialmath"
+)
+
+func TestSpeedZones(t *testing.T) {
+	// a 100m sphere and a 20m box sharing a center
+	center := geo.NewPoint(40.7, -74)
+	zoneCfgs := []*SpeedZoneConfig{
+		{
+			Name: "campus",
+			Region: &spatialmath.GeoGeometryConfig{
+				Location:   &commonpb.GeoPoint{Latitude: center.Lat(), Longitude: center.Lng()},
+				Geometries: []*spatialmath.GeometryConfig{{Type: spatialmath.SphereType, R: 100e3}},
+			},
+			MaxMetersPerSec: 1,
+		},
+		{
+			Name: "crosswalk",
+			Region: &spatialmath.GeoGeometryConfig{
+				Location:   &commonpb.GeoPoint{Latitude: center.Lat(), Longitude: center.Lng()},
+				Geometries: []*spatialmath.GeometryConfig{{Type: spatialmath.BoxType, X: 20e3, Y: 20e3, Z: 10e3}},
+			},
+			MaxMetersPerSec: 0.2,
+			MaxDegsPerSec:   10,
+		},
+	}
+	for _, cfg := range zoneCfgs {
+		test.That(t, cfg.validate(), test.ShouldBeNil)
+	}
+	zones, err := newSpeedZones(zoneCfgs)
+	test.That(t, err, test.ShouldBeNil)
+
+	t.Run("limits", func(t *testing.T) {
+		limits, err := speedLimitsAt(zones, center)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, limits, test.ShouldResemble, speedLimits{metersPerSec: 0.2, degsPerSec: 10})
+
+		limits, err = speedLimitsAt(zones, center.PointAtDistanceAndBearing(0.05, 90))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, limits, test.ShouldResemble, speedLimits{metersPerSec: 1})
+
+		limits, err = speedLimitsAt(zones, center.PointAtDistanceAndBearing(1, 90))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, limits, test.ShouldResemble, speedLimits{})
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		cfg := &motion.MotionConfiguration{LinearMPerSec: 0.5, AngularDegsPerSec: 5}
+		limited := speedLimits{metersPerSec: 0.2, degsPerSec: 10}.apply(cfg)
+		test.That(t, limited.LinearMPerSec, test.ShouldEqual, 0.2)
+		test.That(t, limited.AngularDegsPerSec, test.ShouldEqual, 5)
+		test.That(t, cfg.LinearMPerSec, test.ShouldEqual, 0.5)
+
+		test.That(t, speedLimits{}.apply(cfg), test.ShouldResemble, cfg)
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		test.That(t, (&SpeedZoneConfig{MaxMetersPerSec: 1}).validate(), test.ShouldBeError, errSpeedZoneMissingRegion)
+
+		cfg := *zoneCfgs[0]
+		cfg.MaxMetersPerSec = 0
+		test.That(t, cfg.validate(), test.ShouldBeError, errSpeedZoneMissingLimit)
+		cfg.MaxMetersPerSec = -1
+		test.That(t, cfg.validate(), test.ShouldBeError, errSpeedZoneNegativeLimit)
+
+		cfg.MaxMetersPerSec = 1
+		cfg.Region = &spatialmath.GeoGeometryConfig{
+			Location:   zoneCfgs[0].Region.Location,
+			Geometries: []*spatialmath.GeometryConfig{{Type: spatialmath.SphereType, R: 10, TranslationOffset: r3.Vector{X: 1}}},
+		}
+		test.That(t, cfg.validate(), test.ShouldBeError, errSpeedZoneGeomWithTranslation)
+	})
+}
@@ -15,6 +15,8 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.uber.org/multierr"
 	mongoutils "go.viam.com/utils/mongo"
+
+	"go.viam.com/rdk/utils"
 )
 
 var errNoMoreWaypoints = errors.New("no more waypoints")
@@ -210,9 +212,10 @@ func NewMongoDBNavigationStore(ctx context.Context, config map[string]interface{
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	uri, ok := config["uri"].(string)
-	if !ok {
-		uri = defaultMongoDBURI
+	r := utils.NewAttributeReader(config)
+	uri := utils.Optional(r, "uri", defaultMongoDBURI)
+	if err := r.Err(); err != nil {
+		return nil, err
 	}
 
 	mongoClient, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
@@ -17,6 +17,7 @@ import (
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/services/shell"
+	rutils "go.viam.com/rdk/utils"
 )
 
 func init() {
@@ -75,14 +76,11 @@ func (svc *builtIn) Shell(ctx context.Context, extra map[string]interface{}) (
 		}
 		switch data["message"] {
 		case "window-change":
-			cols, ok := data["cols"].(float64)
-			if !ok {
-				svc.logger.CErrorw(ctx, "invalid window-change message; expected cols to be float64", "value", data["cols"])
-				return
-			}
-			rows, ok := data["rows"].(float64)
-			if !ok {
-				svc.logger.CErrorw(ctx, "invalid window-change message; expected rows to be float64", "value", data["rows"])
+			r := rutils.NewAttributeReader(data)
+			cols := rutils.Required[uint16](r, "cols")
+			rows := rutils.Required[uint16](r, "rows")
+			if err := r.Err(); err != nil {
+				svc.logger.CErrorw(ctx, "invalid window-change message", "error", err)
 				return
 			}
 			sizeLock.Lock()
@@ -93,8 +91,8 @@ func (svc *builtIn) Shell(ctx context.Context, extra map[string]interface{}) (
 			}
 			lastSet = time.Now()
 			if err := pty.Setsize(f, &pty.Winsize{
-				Rows: uint16(rows),
-				Cols: uint16(cols),
+				Rows: rows,
+				Cols: cols,
 			}); err != nil {
 				svc.logger.CErrorw(ctx, "error setting pty window size", "error", err)
 			}
@@ -0,0 +1,193 @@
+// Package barcode is a vision service model that reads 1D barcodes and QR codes, such as tote
+// labels and printed markers, detecting each as a box labeled with its payload.
+package barcode
+
+import (
+	"context"
+	"image"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/services/vision"
+	"go.viam.com/rdk/spatialmath"
+	visbarcode "go.viam.com/rdk/vision/barcode"
+	"go.viam.com/rdk/vision/objectdetection"
+)
+
+// Model is the model of the barcode reading vision service.
+var Model = resource.DefaultModelFamily.WithModel("barcode")
+
+// ReadCommand is the DoCommand key that reads the barcodes in the next image from a camera, the
+// value being the camera's name or empty for the default camera. Each barcode read is returned
+// with its format, payload and corners, and with its pose in the camera's frame if it is a QR
+// code, marker_size_mm is configured and the camera has intrinsics.
+const ReadCommand = "read_barcodes"
+
+func init() {
+	resource.RegisterService(vision.API, Model, resource.Registration[vision.Service, *Config]{
+		Constructor: func(
+			ctx context.Context, deps resource.Dependencies, c resource.Config, logger logging.Logger,
+		) (vision.Service, error) {
+			conf, err := resource.NativeConfig[*Config](c)
+			if err != nil {
+				return nil, err
+			}
+			return newReader(ctx, c.ResourceName(), conf, deps, logger)
+		},
+	})
+}
+
+// Config configures a barcode reader.
+type Config struct {
+	DefaultCamera string `json:"camera_name"`
+	// the formats to read, out of qr_code, ean_13, ean_8, upc_a and code_128; all of them if empty
+	Formats []string `json:"formats,omitempty"`
+	// the printed width of the QR codes read, for finding their poses
+	MarkerSizeMM float64 `json:"marker_size_mm,omitempty"`
+}
+
+// Validate checks the formats and marker size, and depends on the default camera if there is one.
+func (conf *Config) Validate(path string) ([]string, []string, error) {
+	for _, f := range conf.Formats {
+		if _, err := visbarcode.ParseFormat(f); err != nil {
+			return nil, nil, resource.NewConfigValidationError(path, err)
+		}
+	}
+	if conf.MarkerSizeMM < 0 {
+		return nil, nil, resource.NewConfigValidationError(path, errors.New("marker_size_mm cannot be negative"))
+	}
+	if conf.DefaultCamera == "" {
+		return nil, nil, nil
+	}
+	return []string{conf.DefaultCamera}, nil, nil
+}
+
+// reader adds reading barcodes with their corners and poses through DoCommand to the vision
+// service built around its detector.
+type reader struct {
+	vision.Service
+	deps          resource.Dependencies
+	defaultCamera string
+	formats       []visbarcode.Format
+	markerSizeMM  float64
+}
+
+func newReader(
+	ctx context.Context,
+	name resource.Name,
+	conf *Config,
+	deps resource.Dependencies,
+	logger logging.Logger,
+) (vision.Service, error) {
+	_, span := trace.StartSpan(ctx, "service::vision::barcode::newReader")
+	defer span.End()
+
+	r := &reader{deps: deps, defaultCamera: conf.DefaultCamera, markerSizeMM: conf.MarkerSizeMM}
+	for _, f := range conf.Formats {
+		format, err := visbarcode.ParseFormat(f)
+		if err != nil {
+			return nil, err
+		}
+		r.formats = append(r.formats, format)
+	}
+	if conf.DefaultCamera != "" {
+		if _, err := camera.FromDependencies(deps, conf.DefaultCamera); err != nil {
+			return nil, errors.Errorf("could not find camera %q", conf.DefaultCamera)
+		}
+	}
+	svc, err := vision.NewService(name, deps, logger, nil, nil, r.detect, nil, conf.DefaultCamera)
+	if err != nil {
+		return nil, err
+	}
+	r.Service = svc
+	return r, nil
+}
+
+// detect returns a detection for each barcode in img, labeled with its payload.
+func (r *reader) detect(ctx context.Context, img image.Image) ([]objectdetection.Detection, error) {
+	_, span := trace.StartSpan(ctx, "service::vision::barcode::detect")
+	defer span.End()
+
+	var detections []objectdetection.Detection
+	for _, b := range visbarcode.Decode(img, r.formats...) {
+		box := b.BoundingBox().Intersect(img.Bounds())
+		detections = append(detections, objectdetection.NewDetection(img.Bounds(), box, 1, b.Payload))
+	}
+	return detections, nil
+}
+
+// DoCommand reads the barcodes in the next image from a camera, with their corners and poses.
+func (r *reader) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	value, ok := cmd[ReadCommand]
+	if !ok {
+		return r.Service.DoCommand(ctx, cmd)
+	}
+	cameraName, ok := value.(string)
+	if !ok {
+		return nil, errors.Errorf("%s must be a camera name, got %v", ReadCommand, value)
+	}
+	if cameraName == "" {
+		cameraName = r.defaultCamera
+	}
+	if cameraName == "" {
+		return nil, errors.New("no camera name provided and no default camera found")
+	}
+
+	cam, err := camera.FromDependencies(r.deps, cameraName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not find camera named %s", cameraName)
+	}
+	img, err := camera.DecodeImageFromCamera(ctx, "", nil, cam)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get image from %s", cameraName)
+	}
+	var intrinsics *transform.PinholeCameraIntrinsics
+	if r.markerSizeMM > 0 {
+		props, err := cam.Properties(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get properties of %s", cameraName)
+		}
+		intrinsics = props.IntrinsicParams
+	}
+
+	barcodes := []interface{}{}
+	for _, b := range visbarcode.Decode(img, r.formats...) {
+		corners := make([]interface{}, 0, len(b.Corners))
+		for _, c := range b.Corners {
+			corners = append(corners, []interface{}{c.X, c.Y})
+		}
+		read := map[string]interface{}{
+			"format":  string(b.Format),
+			"payload": b.Payload,
+			"corners": corners,
+		}
+		if b.Format == visbarcode.QRCode && intrinsics != nil {
+			pose, err := b.Pose(intrinsics, r.markerSizeMM)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not find pose of barcode %q", b.Payload)
+			}
+			read["pose"] = poseToMap(pose)
+		}
+		barcodes = append(barcodes, read)
+	}
+	return map[string]interface{}{ReadCommand: barcodes}, nil
+}
+
+// poseToMap returns a pose with the same fields as its JSON protobuf form.
+func poseToMap(pose spatialmath.Pose) map[string]interface{} {
+	p := spatialmath.PoseToProtobuf(pose)
+	return map[string]interface{}{
+		"x":     p.X,
+		"y":     p.Y,
+		"z":     p.Z,
+		"o_x":   p.OX,
+		"o_y":   p.OY,
+		"o_z":   p.OZ,
+		"theta": p.Theta,
+	}
+}
@@ -0,0 +1,116 @@
+package barcode
+
+import (
+	"context"
+	"image"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/services/vision"
+	"go.viam.com/rdk/testutils/inject"
+	"go.viam.com/rdk/utils"
+)
+
+// toteImage returns the 320x240 image of a version 1 QR code of "TOTE-0042" shared by the barcode
+// tests, drawn at 4 pixels per module with its top left corner at (100, 60).
+func toteImage(t *testing.T) image.Image {
+	t.Helper()
+	img, err := rimage.ReadImageFromFile(utils.ResolveFile("vision/barcode/data/tote_qr.png"))
+	test.That(t, err, test.ShouldBeNil)
+	return img
+}
+
+func newTestCamera(t *testing.T) *inject.Camera {
+	t.Helper()
+	cam := inject.NewCamera("cam")
+	cam.ImageFunc = func(ctx context.Context, mimeType string, extra map[string]interface{}) ([]byte, camera.ImageMetadata, error) {
+		imgBytes, err := rimage.EncodeImage(ctx, toteImage(t), utils.MimeTypePNG)
+		test.That(t, err, test.ShouldBeNil)
+		return imgBytes, camera.ImageMetadata{MimeType: utils.MimeTypePNG}, nil
+	}
+	cam.PropertiesFunc = func(ctx context.Context) (camera.Properties, error) {
+		return camera.Properties{
+			IntrinsicParams: &transform.PinholeCameraIntrinsics{
+				Width: 320, Height: 240, Fx: 300, Fy: 300, Ppx: 160, Ppy: 120,
+			},
+		}, nil
+	}
+	return cam
+}
+
+func TestConfigValidate(t *testing.T) {
+	deps, _, err := (&Config{DefaultCamera: "cam", Formats: []string{"qr_code", "ean_13"}}).Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, deps, test.ShouldResemble, []string{"cam"})
+
+	deps, _, err = (&Config{}).Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, deps, test.ShouldBeEmpty)
+
+	_, _, err = (&Config{Formats: []string{"aztec"}}).Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "aztec")
+
+	_, _, err = (&Config{MarkerSizeMM: -1}).Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "marker_size_mm")
+}
+
+func TestReader(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	cam := newTestCamera(t)
+	deps := resource.Dependencies{camera.Named("cam"): cam}
+
+	_, err := newReader(ctx, vision.Named("reader"), &Config{DefaultCamera: "missing"}, deps, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	svc, err := newReader(ctx, vision.Named("reader"), &Config{DefaultCamera: "cam", MarkerSizeMM: 84}, deps, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	t.Run("detections", func(t *testing.T) {
+		detections, err := svc.DetectionsFromCamera(ctx, "", nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, detections, test.ShouldHaveLength, 1)
+		test.That(t, detections[0].Label(), test.ShouldEqual, "TOTE-0042")
+		box := detections[0].BoundingBox()
+		test.That(t, box.Min.X, test.ShouldAlmostEqual, 100, 2)
+		test.That(t, box.Min.Y, test.ShouldAlmostEqual, 60, 2)
+		test.That(t, box.Max.X, test.ShouldAlmostEqual, 184, 2)
+		test.That(t, box.Max.Y, test.ShouldAlmostEqual, 144, 2)
+	})
+
+	t.Run("read with pose", func(t *testing.T) {
+		resp, err := svc.DoCommand(ctx, map[string]interface{}{ReadCommand: ""})
+		test.That(t, err, test.ShouldBeNil)
+		reads, ok := resp[ReadCommand].([]interface{})
+		test.That(t, ok, test.ShouldBeTrue)
+		test.That(t, reads, test.ShouldHaveLength, 1)
+		read := reads[0].(map[string]interface{})
+		test.That(t, read["format"], test.ShouldEqual, "qr_code")
+		test.That(t, read["payload"], test.ShouldEqual, "TOTE-0042")
+		test.That(t, read["corners"], test.ShouldHaveLength, 4)
+
+		// 84mm across 84 pixels at a focal length of 300 pixels is 300mm away, left of and
+		// above the principal point
+		pose, ok := read["pose"].(map[string]interface{})
+		test.That(t, ok, test.ShouldBeTrue)
+		test.That(t, pose["z"], test.ShouldAlmostEqual, 300, 10)
+		test.That(t, pose["x"], test.ShouldAlmostEqual, -18, 3)
+		test.That(t, pose["y"], test.ShouldAlmostEqual, -18, 3)
+	})
+
+	t.Run("read errors", func(t *testing.T) {
+		_, err := svc.DoCommand(ctx, map[string]interface{}{ReadCommand: 5})
+		test.That(t, err, test.ShouldNotBeNil)
+		_, err = svc.DoCommand(ctx, map[string]interface{}{ReadCommand: "missing"})
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "missing")
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"go.viam.com/rdk/ml"
 	"go.viam.com/rdk/rimage"
 	"go.viam.com/rdk/services/mlmodel"
+	"go.viam.com/rdk/services/vision"
 	"go.viam.com/rdk/vision/classification"
 )
 
@@ -104,7 +105,9 @@ func attemptToBuildClassifier(mlm mlmodel.Service,
 			return nil, err
 		}
 
-		if postprocessor != nil {
+		// a request that overrides the confidences may lower them below the configured ones, so the
+		// vision service filters on the overridden confidences instead
+		if overrides, _ := vision.RequestOverridesFromContext(ctx); postprocessor != nil && !overrides.OverridesConfidence() {
 			classifications = postprocessor(classifications)
 		}
 		return classifications, nil
@@ -13,6 +13,7 @@ import (
 	"go.viam.com/rdk/ml"
 	"go.viam.com/rdk/rimage"
 	"go.viam.com/rdk/services/mlmodel"
+	"go.viam.com/rdk/services/vision"
 	"go.viam.com/rdk/vision/objectdetection"
 )
 
@@ -115,7 +116,9 @@ func attemptToBuildDetector(mlm mlmodel.Service,
 			return nil, err
 		}
 		detections := convertBoundingBoxesToDetections(boundingBoxes, origW, origH)
-		if postprocessor != nil {
+		// a request that overrides the confidences may lower them below the configured ones, so the
+		// vision service filters on the overridden confidences instead
+		if overrides, _ := vision.RequestOverridesFromContext(ctx); postprocessor != nil && !overrides.OverridesConfidence() {
 			detections = postprocessor(detections)
 		}
 		return detections, nil
@@ -0,0 +1,193 @@
+package vision
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/vision/classification"
+	"go.viam.com/rdk/vision/objectdetection"
+)
+
+// The keys of extra that override the parameters of a vision model for a single detections,
+// classifications or capture all request, so that thresholds can be tried out without
+// reconfiguring the service.
+const (
+	// ExtraConfidenceThreshold is the lowest confidence, from 0 to 1, of the results returned. It is
+	// either a number for every label, or a map from labels to numbers, in which case results with
+	// other labels are not returned.
+	ExtraConfidenceThreshold = "confidence_threshold"
+	// ExtraMaxResults is how many of the most confident results are returned.
+	ExtraMaxResults = "max_results"
+	// ExtraClassFilter is a list of the labels of the results returned.
+	ExtraClassFilter = "class_filter"
+)
+
+// RequestOverrides are the model parameters overridden by the extra of a request.
+type RequestOverrides struct {
+	// ConfidenceThreshold is the lowest confidence returned for every label, if it was overridden.
+	ConfidenceThreshold *float64
+	// LabelConfidences are the lowest confidences returned for each label, keyed by lower case label.
+	LabelConfidences map[string]float64
+	// MaxResults is how many results are returned, or 0 for all of them.
+	MaxResults int
+	// ClassFilter are the lower case labels of the results returned, or all of them if empty.
+	ClassFilter map[string]interface{}
+}
+
+// OverridesConfidence returns whether the request overrides the confidences of the model, in which
+// case models that filter their results by their configured confidences should not.
+func (o RequestOverrides) OverridesConfidence() bool {
+	return o.ConfidenceThreshold != nil || len(o.LabelConfidences) != 0
+}
+
+func (o RequestOverrides) isZero() bool {
+	return !o.OverridesConfidence() && o.MaxResults == 0 && len(o.ClassFilter) == 0
+}
+
+// ParseRequestOverrides returns the model parameters overridden by extra.
+func ParseRequestOverrides(extra map[string]interface{}) (RequestOverrides, error) {
+	var o RequestOverrides
+	if value, ok := extra[ExtraConfidenceThreshold]; ok {
+		if conf, ok := number(value); ok {
+			if conf < 0 || conf > 1 {
+				return RequestOverrides{}, errors.Errorf("%s must be between 0 and 1, got %v", ExtraConfidenceThreshold, conf)
+			}
+			o.ConfidenceThreshold = &conf
+		} else if confs, ok := value.(map[string]interface{}); ok {
+			o.LabelConfidences = make(map[string]float64, len(confs))
+			for label, conf := range confs {
+				c, ok := number(conf)
+				if !ok || c < 0 || c > 1 {
+					return RequestOverrides{}, errors.Errorf(
+						"%s of label %q must be a number between 0 and 1, got %v", ExtraConfidenceThreshold, label, conf)
+				}
+				o.LabelConfidences[strings.ToLower(label)] = c
+			}
+		} else {
+			return RequestOverrides{}, errors.Errorf(
+				"%s must be a number or a map of labels to numbers, got %v", ExtraConfidenceThreshold, value)
+		}
+	}
+	if value, ok := extra[ExtraMaxResults]; ok {
+		n, ok := number(value)
+		if !ok || n < 0 || n != math.Trunc(n) {
+			return RequestOverrides{}, errors.Errorf("%s must be a whole number that is not negative, got %v", ExtraMaxResults, value)
+		}
+		o.MaxResults = int(n)
+	}
+	if value, ok := extra[ExtraClassFilter]; ok {
+		var labels []interface{}
+		switch v := value.(type) {
+		case []interface{}:
+			labels = v
+		case []string:
+			for _, label := range v {
+				labels = append(labels, label)
+			}
+		default:
+			return RequestOverrides{}, errors.Errorf("%s must be a list of labels, got %v", ExtraClassFilter, value)
+		}
+		o.ClassFilter = make(map[string]interface{}, len(labels))
+		for _, label := range labels {
+			l, ok := label.(string)
+			if !ok {
+				return RequestOverrides{}, errors.Errorf("%s must be a list of labels, got %v", ExtraClassFilter, label)
+			}
+			o.ClassFilter[strings.ToLower(l)] = struct{}{}
+		}
+	}
+	return o, nil
+}
+
+// number returns value as a float64 if it is a number. Extras sent over the network always hold
+// float64s, but those passed in by Go callers may hold ints.
+func number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// keep returns whether a result with the given label and score is returned.
+func (o RequestOverrides) keep(label string, score float64) bool {
+	label = strings.ToLower(label)
+	if len(o.ClassFilter) != 0 {
+		if _, ok := o.ClassFilter[label]; !ok {
+			return false
+		}
+	}
+	if o.ConfidenceThreshold != nil && score < *o.ConfidenceThreshold {
+		return false
+	}
+	if len(o.LabelConfidences) != 0 {
+		conf, ok := o.LabelConfidences[label]
+		if !ok || score < conf {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterDetections returns the detections that the overrides keep, the most confident first if
+// the number of results is limited.
+func (o RequestOverrides) FilterDetections(dets []objectdetection.Detection) []objectdetection.Detection {
+	if o.isZero() {
+		return dets
+	}
+	out := make([]objectdetection.Detection, 0, len(dets))
+	for _, d := range dets {
+		if o.keep(d.Label(), d.Score()) {
+			out = append(out, d)
+		}
+	}
+	if o.MaxResults > 0 && len(out) > o.MaxResults {
+		sort.SliceStable(out, func(i, j int) bool { return out[i].Score() > out[j].Score() })
+		out = out[:o.MaxResults]
+	}
+	return out
+}
+
+// FilterClassifications returns the classifications that the overrides keep, the most confident
+// first if the number of results is limited.
+func (o RequestOverrides) FilterClassifications(cls classification.Classifications) classification.Classifications {
+	if o.isZero() {
+		return cls
+	}
+	out := make(classification.Classifications, 0, len(cls))
+	for _, c := range cls {
+		if o.keep(c.Label(), c.Score()) {
+			out = append(out, c)
+		}
+	}
+	if o.MaxResults > 0 && len(out) > o.MaxResults {
+		sort.SliceStable(out, func(i, j int) bool { return out[i].Score() > out[j].Score() })
+		out = out[:o.MaxResults]
+	}
+	return out
+}
+
+type requestOverridesKey struct{}
+
+// ContextWithRequestOverrides returns a context carrying the overrides of a request to the model
+// handling it.
+func ContextWithRequestOverrides(ctx context.Context, o RequestOverrides) context.Context {
+	return context.WithValue(ctx, requestOverridesKey{}, o)
+}
+
+// RequestOverridesFromContext returns the overrides of the request being handled, if there are any.
+func RequestOverridesFromContext(ctx context.Context) (RequestOverrides, bool) {
+	o, ok := ctx.Value(requestOverridesKey{}).(RequestOverrides)
+	return o, ok
+}
@@ -4,6 +4,7 @@ package register
 import (
 	// for vision models.
 	_ "go.viam.com/rdk/services/vision"
+	_ "go.viam.com/rdk/services/vision/barcode"
 	_ "go.viam.com/rdk/services/vision/colordetector"
 	_ "go.viam.com/rdk/services/vision/fake"
 	_ "go.viam.com/rdk/services/vision/mlvision"
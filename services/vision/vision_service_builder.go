@@ -134,7 +134,7 @@ func (vm *vizModel) Detections(
 	if vm.detectorFunc == nil {
 		return nil, errors.Errorf("vision model %q does not implement a Detector", vm.Named.Name())
 	}
-	return vm.detectorFunc(ctx, img)
+	return vm.detect(ctx, img, extra)
 }
 
 // DetectionsFromCamera returns the detections of the next image from the given camera.
@@ -163,7 +163,7 @@ func (vm *vizModel) DetectionsFromCamera(
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not get image from %s", cameraName)
 	}
-	return vm.detectorFunc(ctx, img)
+	return vm.detect(ctx, img, extra)
 }
 
 // Classifications returns the classifications of given image if the model implements classifications.Classifier.
@@ -179,7 +179,7 @@ func (vm *vizModel) Classifications(
 	if vm.classifierFunc == nil {
 		return nil, errors.Errorf("vision model %q does not implement a Classifier", vm.Named.Name())
 	}
-	fullClassifications, err := vm.classifierFunc(ctx, img)
+	fullClassifications, err := vm.classify(ctx, img, extra)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get classifications from image")
 	}
@@ -214,13 +214,41 @@ func (vm *vizModel) ClassificationsFromCamera(
 		return nil, errors.Wrapf(err, "could not get image from %s", cameraName)
 	}
 
-	fullClassifications, err := vm.classifierFunc(ctx, img)
+	fullClassifications, err := vm.classify(ctx, img, extra)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get classifications from image")
 	}
 	return fullClassifications.TopN(n)
 }
 
+// detect runs the detector on img, passing it the overrides in extra and then applying them to
+// its detections.
+func (vm *vizModel) detect(ctx context.Context, img image.Image, extra map[string]interface{}) ([]objectdetection.Detection, error) {
+	overrides, err := ParseRequestOverrides(extra)
+	if err != nil {
+		return nil, err
+	}
+	detections, err := vm.detectorFunc(ContextWithRequestOverrides(ctx, overrides), img)
+	if err != nil {
+		return nil, err
+	}
+	return overrides.FilterDetections(detections), nil
+}
+
+// classify runs the classifier on img, passing it the overrides in extra and then applying them
+// to its classifications.
+func (vm *vizModel) classify(ctx context.Context, img image.Image, extra map[string]interface{}) (classification.Classifications, error) {
+	overrides, err := ParseRequestOverrides(extra)
+	if err != nil {
+		return nil, err
+	}
+	classifications, err := vm.classifierFunc(ContextWithRequestOverrides(ctx, overrides), img)
+	if err != nil {
+		return nil, err
+	}
+	return overrides.FilterClassifications(classifications), nil
+}
+
 // GetObjectPointClouds returns all the found objects in a 3D image if the model implements Segmenter3D.
 func (vm *vizModel) GetObjectPointClouds(
 	ctx context.Context,
@@ -141,3 +141,87 @@ func TestDefaultCameraSettings(t *testing.T) {
 	_, err = svc.CaptureAllFromCamera(context.Background(), secondCameraName, viscapture.CaptureOptions{}, nil)
 	test.That(t, err, test.ShouldBeNil)
 }
+
+func TestRequestOverrides(t *testing.T) {
+	ctx := context.Background()
+	var r inject.Robot
+	r.LoggerFunc = func() logging.Logger { return nil }
+
+	var seen vision.RequestOverrides
+	detector := func(ctx context.Context, img image.Image) ([]objectdetection.Detection, error) {
+		seen, _ = vision.RequestOverridesFromContext(ctx)
+		return []objectdetection.Detection{
+			objectdetection.NewDetection(image.Rect(0, 0, 50, 50), image.Rect(0, 0, 10, 10), 0.3, "cat"),
+			objectdetection.NewDetection(image.Rect(0, 0, 50, 50), image.Rect(0, 0, 10, 10), 0.9, "Dog"),
+			objectdetection.NewDetection(image.Rect(0, 0, 50, 50), image.Rect(0, 0, 10, 10), 0.6, "dog"),
+		}, nil
+	}
+	classifier := func(ctx context.Context, img image.Image) (classification.Classifications, error) {
+		return classification.Classifications{
+			classification.NewClassification(0.2, "cat"),
+			classification.NewClassification(0.7, "dog"),
+			classification.NewClassification(0.5, "bird"),
+		}, nil
+	}
+	svc, err := vision.DeprecatedNewService(vision.Named("testService"), &r, nil, classifier, detector, nil, "")
+	test.That(t, err, test.ShouldBeNil)
+
+	labels := func(dets []objectdetection.Detection) []string {
+		out := []string{}
+		for _, d := range dets {
+			out = append(out, d.Label())
+		}
+		return out
+	}
+
+	// without overrides everything the model returns is returned
+	dets, err := svc.Detections(ctx, nil, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, dets, test.ShouldHaveLength, 3)
+	test.That(t, seen.OverridesConfidence(), test.ShouldBeFalse)
+
+	dets, err = svc.Detections(ctx, nil, map[string]interface{}{vision.ExtraConfidenceThreshold: 0.5})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, labels(dets), test.ShouldResemble, []string{"Dog", "dog"})
+	// the model is told about the overrides
+	test.That(t, seen.OverridesConfidence(), test.ShouldBeTrue)
+	test.That(t, *seen.ConfidenceThreshold, test.ShouldEqual, 0.5)
+
+	dets, err = svc.Detections(ctx, nil, map[string]interface{}{vision.ExtraMaxResults: 1.})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, labels(dets), test.ShouldResemble, []string{"Dog"})
+
+	dets, err = svc.Detections(ctx, nil, map[string]interface{}{vision.ExtraClassFilter: []interface{}{"CAT"}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, labels(dets), test.ShouldResemble, []string{"cat"})
+
+	dets, err = svc.Detections(ctx, nil, map[string]interface{}{
+		vision.ExtraConfidenceThreshold: map[string]interface{}{"cat": 0.1, "dog": 0.7},
+	})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, labels(dets), test.ShouldResemble, []string{"cat", "Dog"})
+
+	cls, err := svc.Classifications(ctx, nil, 0, map[string]interface{}{
+		vision.ExtraConfidenceThreshold: 0.4,
+		vision.ExtraMaxResults:          1.,
+	})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cls, test.ShouldHaveLength, 1)
+	test.That(t, cls[0].Label(), test.ShouldEqual, "dog")
+
+	cls, err = svc.Classifications(ctx, nil, 1, map[string]interface{}{vision.ExtraClassFilter: []interface{}{"cat", "bird"}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cls, test.ShouldHaveLength, 1)
+	test.That(t, cls[0].Label(), test.ShouldEqual, "bird")
+
+	for errText, extra := range map[string]map[string]interface{}{
+		vision.ExtraConfidenceThreshold: {vision.ExtraConfidenceThreshold: 1.5},
+		"label":                         {vision.ExtraConfidenceThreshold: map[string]interface{}{"cat": "high"}},
+		vision.ExtraMaxResults:          {vision.ExtraMaxResults: 1.5},
+		vision.ExtraClassFilter:         {vision.ExtraClassFilter: "cat"},
+	} {
+		_, err := svc.Detections(ctx, nil, extra)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, errText)
+	}
+}
@@ -32,8 +32,12 @@ type FrameSystemService struct {
 		srcpc pointcloud.PointCloud,
 		srcName, dstName string,
 	) (pointcloud.PointCloud, error)
-	CurrentInputsFunc func(ctx context.Context) (referenceframe.FrameSystemInputs, map[string]framesystem.InputEnabled, error)
-	FrameSystemFunc   func(
+	CurrentInputsFunc      func(ctx context.Context) (referenceframe.FrameSystemInputs, map[string]framesystem.InputEnabled, error)
+	GetWorldGeometriesFunc func(
+		ctx context.Context,
+		supplementalTransforms []*referenceframe.LinkInFrame,
+	) (map[string]*referenceframe.GeometriesInFrame, error)
+	FrameSystemFunc func(
 		ctx context.Context,
 		additionalTransforms []*referenceframe.LinkInFrame,
 	) (*referenceframe.FrameSystem, error)
@@ -96,6 +100,17 @@ func (fs *FrameSystemService) TransformPointCloud(
 	return fs.TransformPointCloudFunc(ctx, srcpc, srcName, dstName)
 }
 
+// GetWorldGeometries calls the injected method or the real variant.
+func (fs *FrameSystemService) GetWorldGeometries(
+	ctx context.Context,
+	supplementalTransforms []*referenceframe.LinkInFrame,
+) (map[string]*referenceframe.GeometriesInFrame, error) {
+	if fs.GetWorldGeometriesFunc == nil {
+		return fs.Service.GetWorldGeometries(ctx, supplementalTransforms)
+	}
+	return fs.GetWorldGeometriesFunc(ctx, supplementalTransforms)
+}
+
 // DoCommand calls the injected DoCommand or the real variant.
 func (fs *FrameSystemService) DoCommand(ctx context.Context,
 	cmd map[string]interface{},
@@ -0,0 +1,145 @@
+package inject
+
+import (
+	"context"
+
+	"go.viam.com/rdk/components/gimbal"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+)
+
+// Gimbal is an injected gimbal.
+type Gimbal struct {
+	gimbal.Gimbal
+	name                 resource.Name
+	DoFunc               func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error)
+	AnglesFunc           func(ctx context.Context, extra map[string]interface{}) (gimbal.Angles, error)
+	SetTargetFunc        func(ctx context.Context, target gimbal.Angles, extra map[string]interface{}) error
+	TargetFunc           func(ctx context.Context, extra map[string]interface{}) (gimbal.Angles, error)
+	SetStabilizationFunc func(ctx context.Context, enabled bool, extra map[string]interface{}) error
+	StabilizingFunc      func(ctx context.Context, extra map[string]interface{}) (bool, error)
+	TrackDetectionFunc   func(ctx context.Context, label string, extra map[string]interface{}) error
+	StopFunc             func(ctx context.Context, extra map[string]interface{}) error
+	IsMovingFunc         func(context.Context) (bool, error)
+	CloseFunc            func(ctx context.Context) error
+	KinematicsFunc       func(ctx context.Context) (referenceframe.Model, error)
+	CurrentInputsFunc    func(ctx context.Context) ([]referenceframe.Input, error)
+	GoToInputsFunc       func(ctx context.Context, inputSteps ...[]referenceframe.Input) error
+}
+
+// NewGimbal returns a new injected gimbal.
+func NewGimbal(name string) *Gimbal {
+	return &Gimbal{name: gimbal.Named(name)}
+}
+
+// Name returns the name of the resource.
+func (g *Gimbal) Name() resource.Name {
+	return g.name
+}
+
+// Angles calls the injected Angles or the real version.
+func (g *Gimbal) Angles(ctx context.Context, extra map[string]interface{}) (gimbal.Angles, error) {
+	if g.AnglesFunc == nil {
+		return g.Gimbal.Angles(ctx, extra)
+	}
+	return g.AnglesFunc(ctx, extra)
+}
+
+// SetTarget calls the injected SetTarget or the real version.
+func (g *Gimbal) SetTarget(ctx context.Context, target gimbal.Angles, extra map[string]interface{}) error {
+	if g.SetTargetFunc == nil {
+		return g.Gimbal.SetTarget(ctx, target, extra)
+	}
+	return g.SetTargetFunc(ctx, target, extra)
+}
+
+// Target calls the injected Target or the real version.
+func (g *Gimbal) Target(ctx context.Context, extra map[string]interface{}) (gimbal.Angles, error) {
+	if g.TargetFunc == nil {
+		return g.Gimbal.Target(ctx, extra)
+	}
+	return g.TargetFunc(ctx, extra)
+}
+
+// SetStabilization calls the injected SetStabilization or the real version.
+func (g *Gimbal) SetStabilization(ctx context.Context, enabled bool, extra map[string]interface{}) error {
+	if g.SetStabilizationFunc == nil {
+		return g.Gimbal.SetStabilization(ctx, enabled, extra)
+	}
+	return g.SetStabilizationFunc(ctx, enabled, extra)
+}
+
+// Stabilizing calls the injected Stabilizing or the real version.
+func (g *Gimbal) Stabilizing(ctx context.Context, extra map[string]interface{}) (bool, error) {
+	if g.StabilizingFunc == nil {
+		return g.Gimbal.Stabilizing(ctx, extra)
+	}
+	return g.StabilizingFunc(ctx, extra)
+}
+
+// TrackDetection calls the injected TrackDetection or the real version.
+func (g *Gimbal) TrackDetection(ctx context.Context, label string, extra map[string]interface{}) error {
+	if g.TrackDetectionFunc == nil {
+		return g.Gimbal.TrackDetection(ctx, label, extra)
+	}
+	return g.TrackDetectionFunc(ctx, label, extra)
+}
+
+// Stop calls the injected Stop or the real version.
+func (g *Gimbal) Stop(ctx context.Context, extra map[string]interface{}) error {
+	if g.StopFunc == nil {
+		return g.Gimbal.Stop(ctx, extra)
+	}
+	return g.StopFunc(ctx, extra)
+}
+
+// IsMoving calls the injected IsMoving or the real version.
+func (g *Gimbal) IsMoving(ctx context.Context) (bool, error) {
+	if g.IsMovingFunc == nil {
+		return g.Gimbal.IsMoving(ctx)
+	}
+	return g.IsMovingFunc(ctx)
+}
+
+// Close calls the injected Close or the real version.
+func (g *Gimbal) Close(ctx context.Context) error {
+	if g.CloseFunc == nil {
+		if g.Gimbal == nil {
+			return nil
+		}
+		return g.Gimbal.Close(ctx)
+	}
+	return g.CloseFunc(ctx)
+}
+
+// DoCommand calls the injected DoCommand or the real version.
+func (g *Gimbal) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	if g.DoFunc == nil {
+		return g.Gimbal.DoCommand(ctx, cmd)
+	}
+	return g.DoFunc(ctx, cmd)
+}
+
+// Kinematics calls the injected Kinematics or the real version.
+func (g *Gimbal) Kinematics(ctx context.Context) (referenceframe.Model, error) {
+	if g.KinematicsFunc == nil {
+		return g.Gimbal.Kinematics(ctx)
+	}
+	return g.KinematicsFunc(ctx)
+}
+
+// CurrentInputs calls the injected CurrentInputs or the real version.
+func (g *Gimbal) CurrentInputs(ctx context.Context) ([]referenceframe.Input, error) {
+	if g.CurrentInputsFunc == nil {
+		return g.Gimbal.CurrentInputs(ctx)
+	}
+	return g.CurrentInputsFunc(ctx)
+}
+
+// GoToInputs calls the injected GoToInputs or the real version.
+func (g *Gimbal) GoToInputs(ctx context.Context, inputSteps ...[]referenceframe.Input) error {
+	if g.GoToInputsFunc == nil {
+		return g.Gimbal.GoToInputs(ctx, inputSteps...)
+	}
+	return g.GoToInputsFunc(ctx, inputSteps...)
+}
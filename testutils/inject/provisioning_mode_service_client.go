@@ -0,0 +1,26 @@
+package inject
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	rprovisioningpb "go.viam.com/rdk/proto/rdk/provisioning/v1"
+)
+
+// ProvisioningModeServiceClient represents a fake instance of a provisioning mode client.
+type ProvisioningModeServiceClient struct {
+	rprovisioningpb.ProvisioningModeServiceClient
+	EnterProvisioningFunc func(ctx context.Context, in *rprovisioningpb.EnterProvisioningRequest,
+		opts ...grpc.CallOption) (*rprovisioningpb.EnterProvisioningResponse, error)
+}
+
+// EnterProvisioning calls the injected EnterProvisioningFunc or the real version.
+func (pmsc *ProvisioningModeServiceClient) EnterProvisioning(ctx context.Context, in *rprovisioningpb.EnterProvisioningRequest,
+	opts ...grpc.CallOption,
+) (*rprovisioningpb.EnterProvisioningResponse, error) {
+	if pmsc.EnterProvisioningFunc == nil {
+		return pmsc.ProvisioningModeServiceClient.EnterProvisioning(ctx, in, opts...)
+	}
+	return pmsc.EnterProvisioningFunc(ctx, in, opts...)
+}
@@ -18,6 +18,8 @@ type ProvisioningServiceClient struct {
 		opts ...grpc.CallOption) (*provisioningPb.SetSmartMachineCredentialsResponse, error)
 	GetNetworkListFunc func(ctx context.Context, in *provisioningPb.GetNetworkListRequest,
 		opts ...grpc.CallOption) (*provisioningPb.GetNetworkListResponse, error)
+	ExitProvisioningFunc func(ctx context.Context, in *provisioningPb.ExitProvisioningRequest,
+		opts ...grpc.CallOption) (*provisioningPb.ExitProvisioningResponse, error)
 }
 
 // GetSmartMachineStatus calls the injected GetSmartMachineStatusFunc or the real version.
@@ -59,3 +61,13 @@ func (psc *ProvisioningServiceClient) GetNetworkList(ctx context.Context, in *pr
 	}
 	return psc.GetNetworkListFunc(ctx, in, opts...)
 }
+
+// ExitProvisioning calls the injected ExitProvisioningFunc or the real version.
+func (psc *ProvisioningServiceClient) ExitProvisioning(ctx context.Context, in *provisioningPb.ExitProvisioningRequest,
+	opts ...grpc.CallOption,
+) (*provisioningPb.ExitProvisioningResponse, error) {
+	if psc.ExitProvisioningFunc == nil {
+		return psc.ProvisioningServiceClient.ExitProvisioning(ctx, in, opts...)
+	}
+	return psc.ExitProvisioningFunc(ctx, in, opts...)
+}
@@ -47,11 +47,16 @@ type Robot struct {
 	) (*referenceframe.PoseInFrame, error)
 	TransformPointCloudFunc func(ctx context.Context, srcpc pointcloud.PointCloud, srcName, dstName string) (pointcloud.PointCloud, error)
 	CurrentInputsFunc       func(ctx context.Context) (referenceframe.FrameSystemInputs, error)
-	ModuleAddressesFunc     func() (config.ParentSockAddrs, error)
-	CloudMetadataFunc       func(ctx context.Context) (cloud.Metadata, error)
-	MachineStatusFunc       func(ctx context.Context) (robot.MachineStatus, error)
-	ShutdownFunc            func(ctx context.Context) error
-	ListTunnelsFunc         func(ctx context.Context) ([]config.TrafficTunnelEndpoint, error)
+	GetWorldGeometriesFunc  func(
+		ctx context.Context,
+		supplementalTransforms []*referenceframe.LinkInFrame,
+	) (map[string]*referenceframe.GeometriesInFrame, error)
+	ModuleAddressesFunc func() (config.ParentSockAddrs, error)
+	CloudMetadataFunc   func(ctx context.Context) (cloud.Metadata, error)
+	MachineStatusFunc   func(ctx context.Context) (robot.MachineStatus, error)
+	ShutdownFunc        func(ctx context.Context) error
+	ListTunnelsFunc     func(ctx context.Context) ([]config.TrafficTunnelEndpoint, error)
+	StartResourceFunc   func(ctx context.Context, name resource.Name) error
 
 	ops        *operation.Manager
 	SessMgr    session.Manager
@@ -268,6 +273,19 @@ func (r *Robot) CurrentInputs(ctx context.Context) (referenceframe.FrameSystemIn
 	return r.CurrentInputs(ctx)
 }
 
+// GetWorldGeometries calls the injected GetWorldGeometries or the real version.
+func (r *Robot) GetWorldGeometries(
+	ctx context.Context,
+	supplementalTransforms []*referenceframe.LinkInFrame,
+) (map[string]*referenceframe.GeometriesInFrame, error) {
+	r.Mu.RLock()
+	defer r.Mu.RUnlock()
+	if r.GetWorldGeometriesFunc == nil {
+		return r.LocalRobot.GetWorldGeometries(ctx, supplementalTransforms)
+	}
+	return r.GetWorldGeometriesFunc(ctx, supplementalTransforms)
+}
+
 // ModuleAddresses calls the injected ModuleAddresses or the real one.
 func (r *Robot) ModuleAddresses() (config.ParentSockAddrs, error) {
 	r.Mu.RLock()
@@ -318,6 +336,20 @@ func (r *Robot) ListTunnels(ctx context.Context) ([]config.TrafficTunnelEndpoint
 	return r.ListTunnelsFunc(ctx)
 }
 
+// StartResource calls the injected StartResource or the real one. Without either, there are
+// no lazily started resources, so there is nothing to start.
+func (r *Robot) StartResource(ctx context.Context, name resource.Name) error {
+	r.Mu.RLock()
+	defer r.Mu.RUnlock()
+	if r.StartResourceFunc == nil {
+		if r.LocalRobot == nil {
+			return nil
+		}
+		return r.LocalRobot.StartResource(ctx, name)
+	}
+	return r.StartResourceFunc(ctx, name)
+}
+
 type noopSessionManager struct{}
 
 func (m noopSessionManager) Start(ctx context.Context, ownerID string) (*session.Session, error) {
@@ -100,11 +100,16 @@ func VerifySameResourceStatuses(tb testing.TB, actual, expected []resource.Statu
 	sortedActual := newSortedResourceStatuses(actual)
 	sortedExpected := newSortedResourceStatuses(expected)
 
+	// when, how long and how many times resources took to configure varies from run to run
 	for i := range sortedActual {
 		sortedActual[i].LastUpdated = time.Time{}
+		sortedActual[i].Configure = resource.ConfigureStats{}
+		sortedActual[i].Generation = 0
 	}
 	for i := range sortedExpected {
 		sortedExpected[i].LastUpdated = time.Time{}
+		sortedExpected[i].Configure = resource.ConfigureStats{}
+		sortedExpected[i].Generation = 0
 	}
 
 	// This deferred function provides more concise output for debugging on failure
@@ -15,7 +15,7 @@ import (
 	_ "golang.org/x/tools/cmd/stringer" // generates `String` methods for enums
 	_ "gotest.tools/gotestsum"
 
-	// only needed for proto building in examples/customresources/apis/proto
+	// only needed for proto building in proto and examples/customresources/apis/proto
 	_ "github.com/bufbuild/buf/cmd/buf"
 	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-grpc-gateway"
 	_ "google.golang.org/grpc/cmd/protoc-gen-go-grpc"
@@ -0,0 +1,203 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// An AttributeError is a problem with a single attribute.
+type AttributeError struct {
+	Name string
+	Err  error
+}
+
+func (e *AttributeError) Error() string {
+	return fmt.Sprintf("attribute %q: %v", e.Name, e.Err)
+}
+
+func (e *AttributeError) Unwrap() error {
+	return e.Err
+}
+
+// An AttributeReader reads typed values out of an AttributeMap. Unlike the accessors on
+// AttributeMap, it never panics: it records every problem it finds so that they can all
+// be reported at once by Err.
+//
+//	r := utils.NewAttributeReader(conf.Attributes)
+//	port := utils.Required[int](r, "port")
+//	rate := utils.Optional(r, "rate_hz", 10.0)
+//	if err := r.Err(); err != nil {
+//		return nil, err
+//	}
+type AttributeReader struct {
+	am  AttributeMap
+	err error
+}
+
+// NewAttributeReader returns a reader for the given attributes.
+func NewAttributeReader(am AttributeMap) *AttributeReader {
+	return &AttributeReader{am: am}
+}
+
+// Err returns every problem found reading attributes so far, combined, or nil if there
+// were none.
+func (r *AttributeReader) Err() error {
+	return r.err
+}
+
+// Required reads the named attribute as a T. If it is missing, null, or cannot be converted
+// to a T, an error is recorded and the zero T is returned.
+func Required[T any](r *AttributeReader, name string) T {
+	v, ok, err := GetAttribute[T](r.am, name)
+	switch {
+	case err != nil:
+		r.err = multierr.Combine(r.err, err)
+	case !ok:
+		r.err = multierr.Combine(r.err, &AttributeError{Name: name, Err: errors.New("is required")})
+	}
+	return v
+}
+
+// Optional reads the named attribute as a T, or returns def if it is missing or null. If it
+// cannot be converted to a T, an error is recorded and def is returned.
+func Optional[T any](r *AttributeReader, name string, def T) T {
+	v, ok, err := GetAttribute[T](r.am, name)
+	if err != nil {
+		r.err = multierr.Combine(r.err, err)
+		return def
+	}
+	if !ok {
+		return def
+	}
+	return v
+}
+
+// GetAttribute returns the named attribute as a T, and whether it was present and not null.
+// Numbers convert between integer and floating point types as long as no precision is lost,
+// strings convert to time.Durations, slices convert element by element, and objects decode
+// into structs and maps using their json tags.
+func GetAttribute[T any](am AttributeMap, name string) (T, bool, error) {
+	var out T
+	raw, ok := am[name]
+	if !ok || raw == nil {
+		return out, false, nil
+	}
+	v, err := convertAttribute(raw, reflect.TypeOf(&out).Elem())
+	if err != nil {
+		return out, true, &AttributeError{Name: name, Err: err}
+	}
+	out, _ = v.Interface().(T)
+	return out, true, nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func convertAttribute(raw interface{}, to reflect.Type) (reflect.Value, error) {
+	if raw == nil {
+		return reflect.Zero(to), nil
+	}
+	rawV := reflect.ValueOf(raw)
+	if rawV.Type().AssignableTo(to) {
+		v := reflect.New(to).Elem()
+		v.Set(rawV)
+		return v, nil
+	}
+	if to == durationType {
+		s, ok := raw.(string)
+		if !ok {
+			return reflect.Value{}, errors.Errorf("wanted a duration string but got (%v) %T", raw, raw)
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(d), nil
+	}
+
+	switch to.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f, ok := attributeNumber(rawV)
+		if !ok || f != math.Trunc(f) {
+			return reflect.Value{}, errors.Errorf("wanted an integer but got (%v) %T", raw, raw)
+		}
+		v := reflect.New(to).Elem()
+		if f >= math.MaxInt64 || f < math.MinInt64 || v.OverflowInt(int64(f)) {
+			return reflect.Value{}, errors.Errorf("%v does not fit in %s", raw, to)
+		}
+		v.SetInt(int64(f))
+		return v, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f, ok := attributeNumber(rawV)
+		if !ok || f != math.Trunc(f) || f < 0 {
+			return reflect.Value{}, errors.Errorf("wanted a non-negative integer but got (%v) %T", raw, raw)
+		}
+		v := reflect.New(to).Elem()
+		if f >= math.MaxUint64 || v.OverflowUint(uint64(f)) {
+			return reflect.Value{}, errors.Errorf("%v does not fit in %s", raw, to)
+		}
+		v.SetUint(uint64(f))
+		return v, nil
+	case reflect.Float32, reflect.Float64:
+		f, ok := attributeNumber(rawV)
+		if !ok {
+			return reflect.Value{}, errors.Errorf("wanted a number but got (%v) %T", raw, raw)
+		}
+		v := reflect.New(to).Elem()
+		v.SetFloat(f)
+		return v, nil
+	case reflect.Slice:
+		if rawV.Kind() != reflect.Slice {
+			return reflect.Value{}, errors.Errorf("wanted a list but got (%v) %T", raw, raw)
+		}
+		v := reflect.MakeSlice(to, rawV.Len(), rawV.Len())
+		var err error
+		for i := 0; i < rawV.Len(); i++ {
+			elem, elemErr := convertAttribute(rawV.Index(i).Interface(), to.Elem())
+			if elemErr != nil {
+				err = multierr.Combine(err, errors.Wrapf(elemErr, "index %d", i))
+				continue
+			}
+			v.Index(i).Set(elem)
+		}
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return v, nil
+	case reflect.Struct, reflect.Map, reflect.Ptr:
+		out := reflect.New(to)
+		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			TagName:    "json",
+			Result:     out.Interface(),
+			DecodeHook: mapstructure.StringToTimeDurationHookFunc(),
+		})
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if err := decoder.Decode(raw); err != nil {
+			return reflect.Value{}, err
+		}
+		return out.Elem(), nil
+	default:
+		return reflect.Value{}, errors.Errorf("wanted a %s but got (%v) %T", to, raw, raw)
+	}
+}
+
+// attributeNumber returns the value of a number of any type as a float64.
+func attributeNumber(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+)
+
+func TestAttributeReader(t *testing.T) {
+	type limits struct {
+		MaxSpeed float64       `json:"max_speed"`
+		Timeout  time.Duration `json:"timeout"`
+	}
+	attrs := AttributeMap{
+		"port":     float64(8080),
+		"rate_hz":  10,
+		"name":     "arm",
+		"enabled":  true,
+		"pins":     []interface{}{float64(1), float64(2), float64(3)},
+		"interval": "250ms",
+		"limits":   map[string]interface{}{"max_speed": 1.5, "timeout": "2s"},
+		"nothing":  nil,
+	}
+
+	r := NewAttributeReader(attrs)
+	test.That(t, Required[int](r, "port"), test.ShouldEqual, 8080)
+	test.That(t, Required[float64](r, "rate_hz"), test.ShouldEqual, 10.)
+	test.That(t, Required[string](r, "name"), test.ShouldEqual, "arm")
+	test.That(t, Optional(r, "enabled", false), test.ShouldBeTrue)
+	test.That(t, Required[[]uint8](r, "pins"), test.ShouldResemble, []uint8{1, 2, 3})
+	test.That(t, Required[time.Duration](r, "interval"), test.ShouldEqual, 250*time.Millisecond)
+	test.That(t, Required[limits](r, "limits"), test.ShouldResemble, limits{MaxSpeed: 1.5, Timeout: 2 * time.Second})
+	test.That(t, Optional(r, "nothing", 7), test.ShouldEqual, 7)
+	test.That(t, Optional(r, "missing", "default"), test.ShouldEqual, "default")
+	test.That(t, r.Err(), test.ShouldBeNil)
+
+	// every problem is reported, not just the first
+	r = NewAttributeReader(attrs)
+	test.That(t, Required[int](r, "missing"), test.ShouldEqual, 0)
+	test.That(t, Required[bool](r, "name"), test.ShouldBeFalse)
+	test.That(t, Optional(r, "port", int8(5)), test.ShouldEqual, 5)
+	test.That(t, Required[[]string](r, "pins"), test.ShouldBeNil)
+	err := r.Err()
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `attribute "missing": is required`)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `attribute "name"`)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `attribute "port": 8080 does not fit in int8`)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `attribute "pins": index 0`)
+
+	_, ok, err := GetAttribute[int](AttributeMap{"half": 0.5}, "half")
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -31,6 +31,32 @@ const (
 	// that modules are allowed to startup.
 	ModuleStartupTimeoutEnvVar = "VIAM_MODULE_STARTUP_TIMEOUT"
 
+	// DefaultResourceConfigurationConcurrency is the default number of resources that
+	// are (re)configured at once.
+	DefaultResourceConfigurationConcurrency = 10
+
+	// ResourceConfigurationConcurrencyEnvVar is the environment variable that can be set
+	// to override DefaultResourceConfigurationConcurrency as the number of resources that
+	// are (re)configured at once.
+	ResourceConfigurationConcurrencyEnvVar = "VIAM_RESOURCE_CONFIGURATION_CONCURRENCY"
+
+	// DefaultResourceHealthCheckInterval is the default interval at which resources that
+	// can report their health are checked.
+	DefaultResourceHealthCheckInterval = 30 * time.Second
+
+	// ResourceHealthCheckIntervalEnvVar is the environment variable that can be set to
+	// override DefaultResourceHealthCheckInterval. A zero or negative interval disables
+	// health checks.
+	ResourceHealthCheckIntervalEnvVar = "VIAM_RESOURCE_HEALTH_CHECK_INTERVAL"
+
+	// DefaultShutdownDrainTimeout is the default time that motion operations already running
+	// at shutdown are given to finish before they are cancelled.
+	DefaultShutdownDrainTimeout = 10 * time.Second
+
+	// ShutdownDrainTimeoutEnvVar is the environment variable that can be set to override
+	// DefaultShutdownDrainTimeout.
+	ShutdownDrainTimeoutEnvVar = "VIAM_SHUTDOWN_DRAIN_TIMEOUT"
+
 	// AndroidFilesDir is hardcoded because golang inits before our android code can override HOME var.
 	AndroidFilesDir = "/data/user/0/com.viam.rdk.fgservice/cache"
 
@@ -86,6 +112,34 @@ func GetResourceConfigurationTimeout(logger logging.Logger) time.Duration {
 	return timeoutHelper(DefaultResourceConfigurationTimeout, ResourceConfigurationTimeoutEnvVar, logger)
 }
 
+// GetResourceConfigurationConcurrency calculates the number of resources that are
+// (re)configured at once (env variable value if set, DefaultResourceConfigurationConcurrency
+// otherwise).
+func GetResourceConfigurationConcurrency(logger logging.Logger) int {
+	if concurrencyVal := os.Getenv(ResourceConfigurationConcurrencyEnvVar); concurrencyVal != "" {
+		concurrency, err := strconv.Atoi(concurrencyVal)
+		if err != nil || concurrency < 1 {
+			logger.Warnf("Failed to parse %s env var, falling back to default concurrency of %d",
+				ResourceConfigurationConcurrencyEnvVar, DefaultResourceConfigurationConcurrency)
+			return DefaultResourceConfigurationConcurrency
+		}
+		return concurrency
+	}
+	return DefaultResourceConfigurationConcurrency
+}
+
+// GetResourceHealthCheckInterval calculates the interval at which resources are health
+// checked (env variable value if set, DefaultResourceHealthCheckInterval otherwise).
+func GetResourceHealthCheckInterval(logger logging.Logger) time.Duration {
+	return timeoutHelper(DefaultResourceHealthCheckInterval, ResourceHealthCheckIntervalEnvVar, logger)
+}
+
+// GetShutdownDrainTimeout calculates the time motion operations are given to finish at
+// shutdown (env variable value if set, DefaultShutdownDrainTimeout otherwise).
+func GetShutdownDrainTimeout(logger logging.Logger) time.Duration {
+	return timeoutHelper(DefaultShutdownDrainTimeout, ShutdownDrainTimeoutEnvVar, logger)
+}
+
 // GetModuleStartupTimeout calculates the module startup timeout
 // (env variable value if set, DefaultModuleStartupTimeout otherwise).
 func GetModuleStartupTimeout(logger logging.Logger) time.Duration {
@@ -38,7 +38,12 @@ func NewWeakOrOptionalDependenciesUpdateTimeoutError(name string, logger logging
 
 // NewBuildTimeoutError is used when a resource times out during construction or reconfiguration.
 func NewBuildTimeoutError(name string, logger logging.Logger) error {
-	timeout := GetResourceConfigurationTimeout(logger)
+	return NewResourceBuildTimeoutError(name, GetResourceConfigurationTimeout(logger))
+}
+
+// NewResourceBuildTimeoutError is used when a resource times out during construction or
+// reconfiguration with its own timeout.
+func NewResourceBuildTimeoutError(name string, timeout time.Duration) error {
 	id := fmt.Sprintf("resource %s", name)
 	timeoutMsg := "reconfigure"
 	return timeoutErrorHelper(id, timeout, timeoutMsg)
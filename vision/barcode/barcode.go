@@ -0,0 +1,113 @@
+// Package barcode finds and decodes 1D barcodes and QR codes in images, such as labels on totes
+// or printed markers a robot localizes itself against.
+package barcode
+
+import (
+	"image"
+	"sort"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/pkg/errors"
+)
+
+// A Format is a kind of barcode.
+type Format string
+
+// The barcode formats that can be decoded.
+const (
+	QRCode  Format = "qr_code"
+	EAN13   Format = "ean_13"
+	EAN8    Format = "ean_8"
+	UPCA    Format = "upc_a"
+	Code128 Format = "code_128"
+)
+
+// Formats are all the formats that can be decoded.
+var Formats = []Format{QRCode, EAN13, EAN8, UPCA, Code128}
+
+// zxingFormats are the formats as gozxing names them.
+var zxingFormats = map[Format]gozxing.BarcodeFormat{
+	QRCode:  gozxing.BarcodeFormat_QR_CODE,
+	EAN13:   gozxing.BarcodeFormat_EAN_13,
+	EAN8:    gozxing.BarcodeFormat_EAN_8,
+	UPCA:    gozxing.BarcodeFormat_UPC_A,
+	Code128: gozxing.BarcodeFormat_CODE_128,
+}
+
+// ParseFormat returns the format with the given name.
+func ParseFormat(name string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == name {
+			return f, nil
+		}
+	}
+	return "", errors.Errorf("unknown barcode format %q, expected one of %v", name, Formats)
+}
+
+// A Barcode is a barcode found in an image.
+type Barcode struct {
+	Format  Format
+	Payload string
+	// Corners are the corners of the barcode in the image, clockwise from its top left as it
+	// reads, so their order gives its orientation. The corners of a 1D barcode are the middles
+	// of its start and stop patterns on the row it was read along, so its top and bottom
+	// corners are the same.
+	Corners [4]image.Point
+}
+
+// BoundingBox returns the smallest rectangle holding the corners of the barcode.
+func (b Barcode) BoundingBox() image.Rectangle {
+	var box image.Rectangle
+	for _, c := range b.Corners {
+		box = box.Union(image.Rectangle{Min: c, Max: c.Add(image.Pt(1, 1))})
+	}
+	return box
+}
+
+// Decode returns the barcodes of the given formats found in img, or of every format if none are
+// given.
+func Decode(img image.Image, formats ...Format) []Barcode {
+	want := func(f Format) bool {
+		if len(formats) == 0 {
+			return true
+		}
+		for _, wanted := range formats {
+			if wanted == f {
+				return true
+			}
+		}
+		return false
+	}
+
+	bitmap, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		return nil
+	}
+	var found []Barcode
+	if want(QRCode) {
+		found = append(found, decodeQRCodes(bitmap)...)
+	}
+	var linear []gozxing.BarcodeFormat
+	for _, f := range []Format{EAN13, EAN8, UPCA, Code128} {
+		if want(f) {
+			linear = append(linear, zxingFormats[f])
+		}
+	}
+	if len(linear) > 0 {
+		found = append(found, decodeLinear(bitmap, linear)...)
+	}
+	// the bitmap starts at the origin, wherever the image does
+	for i := range found {
+		for j := range found[i].Corners {
+			found[i].Corners[j] = found[i].Corners[j].Add(img.Bounds().Min)
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		a, b := found[i].BoundingBox().Min, found[j].BoundingBox().Min
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		return a.X < b.X
+	})
+	return found
+}
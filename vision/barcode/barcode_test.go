@@ -0,0 +1,310 @@
+package barcode
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/oned"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/rimage/transform"
+)
+
+// version 1 QR code of "TOTE-0042"
+var toteQR = []string{
+	"#######..####.#######",
+	"#.....#.#.#...#.....#",
+	"#.###.#..#..#.#.###.#",
+	"#.###.#..#.#..#.###.#",
+	"#.###.#.#...#.#.###.#",
+	"#.....#.....#.#.....#",
+	"#######.#.#.#.#######",
+	"..........###........",
+	"#.#.#.#..#.#....#..#.",
+	"#.####..#.#...##.##..",
+	".##.####.##.#...#...#",
+	"#......#..#...##....#",
+	"#...#.###...#.#.#.#.#",
+	"........#..#.#.#..#..",
+	"#######...##.#####.#.",
+	"#.....#..#####.#..##.",
+	"#.###.#.####.##.#.###",
+	"#.###.#..#....###.##.",
+	"#.###.#.#.#.#...###.#",
+	"#.....#...#...##...##",
+	"#######.#.#.#.#.#.#.#",
+}
+
+// version 2 QR code of "https://example.com/dock/7"
+var dockQR = []string{
+	"#######..###.#..#.#######",
+	"#.....#...##.####.#.....#",
+	"#.###.#.#####.#...#.###.#",
+	"#.###.#.#.##.###..#.###.#",
+	"#.###.#.###..#..#.#.###.#",
+	"#.....#.##....##..#.....#",
+	"#######.#.#.#.#.#.#######",
+	"........#.#.#.#.#........",
+	"#.#####....###....#####..",
+	"..#..#....##.#...#.#...#.",
+	"..##.###..#######..#.#.##",
+	"#..##.....###.###.##....#",
+	"#.#...##...#####.##.#.###",
+	"#.##...#.#..#...#..#.#.#.",
+	"#...###..##...##..####.##",
+	"#.##...###.#...######...#",
+	"#..#..##.##..##.#####.#..",
+	"........######.##...##...",
+	"#######......##.#.#.#.###",
+	"#.....#.#.##..#.#...##...",
+	"#.###.#.##.##########.#..",
+	"#.###.#.#..##..#.##.#####",
+	"#.###.#.#.#..#.#.....##.#",
+	"#.....#..####.#.##.###..#",
+	"#######.#######..########",
+}
+
+// newWhite returns a white image of the given size.
+func newWhite(w, h int) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for i := range img.Pix {
+		img.Pix[i] = 255
+	}
+	return img
+}
+
+// drawModules draws a QR code with its top left at (x0, y0), scale pixels to a module, rotated
+// clockwise by the given number of quarter turns about its center.
+func drawModules(img *image.Gray, modules []string, x0, y0, scale, quarterTurns int) {
+	size := len(modules)
+	for y, row := range modules {
+		for x, m := range row {
+			if m != '#' {
+				continue
+			}
+			u, v := x, y
+			for i := 0; i < quarterTurns; i++ {
+				u, v = size-1-v, u
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetGray(x0+u*scale+dx, y0+v*scale+dy, color.Gray{})
+				}
+			}
+		}
+	}
+}
+
+// drawBars draws bars of the given widths in modules, alternately dark and light starting with
+// dark, from (x0, y0) down to y1.
+func drawBars(img *image.Gray, widths []int, x0, y0, y1, scale int) {
+	x := x0
+	for i, w := range widths {
+		if i%2 == 0 {
+			for dx := 0; dx < w*scale; dx++ {
+				for y := y0; y < y1; y++ {
+					img.SetGray(x+dx, y, color.Gray{})
+				}
+			}
+		}
+		x += w * scale
+	}
+}
+
+// Bar and space widths, in modules, of the EAN digits in their L encoding. Their R encoding has
+// the same widths starting with a bar, and their G encoding the widths reversed.
+var eanDigitWidths = [10][4]int{
+	{3, 2, 1, 1},
+	{2, 2, 2, 1},
+	{2, 1, 2, 2},
+	{1, 4, 1, 1},
+	{1, 1, 3, 2},
+	{1, 2, 3, 1},
+	{1, 1, 1, 4},
+	{1, 3, 1, 2},
+	{1, 2, 1, 3},
+	{3, 1, 1, 2},
+}
+
+// The first digit of an EAN-13 is encoded in which of the left digits use the G encoding, with a
+// bit set for each, from the leftmost digit in the highest bit.
+var eanFirstDigitParities = [10]int{0x00, 0x0B, 0x0D, 0x0E, 0x13, 0x19, 0x1C, 0x15, 0x16, 0x1A}
+
+// ean13Widths returns the bar widths of an EAN-13, which need not have a valid check digit.
+func ean13Widths(digits string) []int {
+	widths := []int{1, 1, 1}
+	parity := eanFirstDigitParities[digits[0]-'0']
+	for i := 1; i <= 6; i++ {
+		w := eanDigitWidths[digits[i]-'0']
+		if parity&(1<<(6-i)) != 0 {
+			w = [4]int{w[3], w[2], w[1], w[0]}
+		}
+		widths = append(widths, w[:]...)
+	}
+	widths = append(widths, 1, 1, 1, 1, 1)
+	for i := 7; i <= 12; i++ {
+		w := eanDigitWidths[digits[i]-'0']
+		widths = append(widths, w[:]...)
+	}
+	return append(widths, 1, 1, 1)
+}
+
+// code128Widths returns the bar widths of a Code 128 of text.
+func code128Widths(t *testing.T, text string) []int {
+	t.Helper()
+	code, err := oned.NewCode128Writer().Encode(text, gozxing.BarcodeFormat_CODE_128, 0, 1, nil)
+	test.That(t, err, test.ShouldBeNil)
+	var widths []int
+	for x := 0; x < code.GetWidth(); x++ {
+		dark := code.Get(x, 0)
+		switch {
+		case len(widths) == 0 && !dark:
+			// the margin before the first bar
+		case len(widths)%2 == 1 == dark:
+			widths[len(widths)-1]++
+		default:
+			widths = append(widths, 1)
+		}
+	}
+	return widths
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range Formats {
+		parsed, err := ParseFormat(string(f))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, parsed, test.ShouldEqual, f)
+	}
+	_, err := ParseFormat("aztec")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "aztec")
+}
+
+func TestDecodeQRCode(t *testing.T) {
+	const scale, x0, y0 = 4, 40, 30
+	for turns := 0; turns < 4; turns++ {
+		img := newWhite(200, 200)
+		drawModules(img, toteQR, x0, y0, scale, turns)
+
+		found := Decode(img)
+		test.That(t, found, test.ShouldHaveLength, 1)
+		test.That(t, found[0].Format, test.ShouldEqual, QRCode)
+		test.That(t, found[0].Payload, test.ShouldEqual, "TOTE-0042")
+
+		// the corners start from the code's own top left, wherever it was turned to
+		square := [4]image.Point{
+			image.Pt(x0, y0),
+			image.Pt(x0+21*scale, y0),
+			image.Pt(x0+21*scale, y0+21*scale),
+			image.Pt(x0, y0+21*scale),
+		}
+		for i, c := range found[0].Corners {
+			want := square[(i+turns)%4]
+			test.That(t, c.X, test.ShouldAlmostEqual, want.X, 2)
+			test.That(t, c.Y, test.ShouldAlmostEqual, want.Y, 2)
+		}
+		test.That(t, found[0].BoundingBox().Min.X, test.ShouldAlmostEqual, x0, 2)
+	}
+}
+
+func TestDecodeSeveral(t *testing.T) {
+	img := newWhite(400, 300)
+	drawModules(img, dockQR, 220, 20, 5, 0)
+	drawModules(img, toteQR, 20, 30, 4, 1)
+	drawBars(img, ean13Widths("4006381333931"), 30, 200, 260, 2)
+
+	found := Decode(img)
+	test.That(t, found, test.ShouldHaveLength, 3)
+	// in reading order, top to bottom
+	test.That(t, found[0].Payload, test.ShouldEqual, "https://example.com/dock/7")
+	test.That(t, found[1].Payload, test.ShouldEqual, "TOTE-0042")
+	test.That(t, found[2].Format, test.ShouldEqual, EAN13)
+	test.That(t, found[2].Payload, test.ShouldEqual, "4006381333931")
+
+	found = Decode(img, EAN13, UPCA)
+	test.That(t, found, test.ShouldHaveLength, 1)
+	test.That(t, found[0].Payload, test.ShouldEqual, "4006381333931")
+}
+
+func TestDecodeLinear(t *testing.T) {
+	t.Run("ean 13", func(t *testing.T) {
+		img := newWhite(300, 100)
+		drawBars(img, ean13Widths("5901234123457"), 30, 20, 80, 2)
+		found := Decode(img)
+		test.That(t, found, test.ShouldHaveLength, 1)
+		test.That(t, found[0].Format, test.ShouldEqual, EAN13)
+		test.That(t, found[0].Payload, test.ShouldEqual, "5901234123457")
+		// the corners are at the middles of the guards, which are 3 modules wide
+		test.That(t, found[0].Corners[0].X, test.ShouldAlmostEqual, 30+3, 1)
+		test.That(t, found[0].Corners[2].X, test.ShouldAlmostEqual, 30+(95-1.5)*2, 1)
+		test.That(t, found[0].Corners[0].Y, test.ShouldEqual, found[0].Corners[3].Y)
+	})
+
+	t.Run("upc a", func(t *testing.T) {
+		img := newWhite(300, 100)
+		drawBars(img, ean13Widths("0036000291452"), 30, 20, 80, 2)
+		found := Decode(img)
+		test.That(t, found, test.ShouldHaveLength, 1)
+		test.That(t, found[0].Format, test.ShouldEqual, UPCA)
+		test.That(t, found[0].Payload, test.ShouldEqual, "036000291452")
+	})
+
+	t.Run("bad check digit", func(t *testing.T) {
+		img := newWhite(300, 100)
+		drawBars(img, ean13Widths("5901234123458"), 30, 20, 80, 2)
+		test.That(t, Decode(img), test.ShouldBeEmpty)
+	})
+
+	t.Run("code 128 upside down", func(t *testing.T) {
+		img := newWhite(300, 100)
+		drawBars(img, code128Widths(t, "Tote 17"), 30, 20, 80, 2)
+		upsideDown := newWhite(300, 100)
+		for y := 0; y < 100; y++ {
+			for x := 0; x < 300; x++ {
+				upsideDown.SetGray(299-x, 99-y, img.GrayAt(x, y))
+			}
+		}
+		found := Decode(upsideDown)
+		test.That(t, found, test.ShouldHaveLength, 1)
+		test.That(t, found[0].Format, test.ShouldEqual, Code128)
+		test.That(t, found[0].Payload, test.ShouldEqual, "Tote 17")
+		// read right to left, so it starts at the right
+		test.That(t, found[0].Corners[0].X, test.ShouldBeGreaterThan, found[0].Corners[1].X)
+	})
+}
+
+func TestSquarePose(t *testing.T) {
+	intrinsics := &transform.PinholeCameraIntrinsics{Width: 640, Height: 480, Fx: 600, Fy: 600, Ppx: 320, Ppy: 240}
+	// turned 30 degrees about y, then -20 degrees about x
+	a, b := 30*math.Pi/180, -20*math.Pi/180
+	rotation := [9]float64{
+		math.Cos(a), 0, math.Sin(a),
+		math.Sin(b) * math.Sin(a), math.Cos(b), -math.Sin(b) * math.Cos(a),
+		-math.Cos(b) * math.Sin(a), math.Sin(b), math.Cos(b) * math.Cos(a),
+	}
+	position := [3]float64{40, -25, 500}
+	var corners [4][2]float64
+	for i, p := range [4][2]float64{{-40, -40}, {40, -40}, {40, 40}, {-40, 40}} {
+		x := rotation[0]*p[0] + rotation[1]*p[1] + position[0]
+		y := rotation[3]*p[0] + rotation[4]*p[1] + position[1]
+		z := rotation[6]*p[0] + rotation[7]*p[1] + position[2]
+		corners[i] = [2]float64{intrinsics.Fx*x/z + intrinsics.Ppx, intrinsics.Fy*y/z + intrinsics.Ppy}
+	}
+
+	point, got, err := squarePose(corners, intrinsics, 80)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, point.X, test.ShouldAlmostEqual, position[0], 1e-6)
+	test.That(t, point.Y, test.ShouldAlmostEqual, position[1], 1e-6)
+	test.That(t, point.Z, test.ShouldAlmostEqual, position[2], 1e-6)
+	for i := range rotation {
+		test.That(t, got[i], test.ShouldAlmostEqual, rotation[i], 1e-6)
+	}
+
+	_, err = Barcode{Format: EAN13}.Pose(intrinsics, 80)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = Barcode{Format: QRCode}.Pose(nil, 80)
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -0,0 +1,102 @@
+package barcode
+
+import (
+	"image"
+	"math"
+	"slices"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/oned"
+)
+
+const (
+	// how many times the parts of an image around a 1D barcode are searched again for more
+	maxLinearSearchDepth = 4
+	// the smallest width or height in pixels of a part of an image that is searched again
+	minLinearSearchSide = 100
+)
+
+// decodeLinear returns the 1D barcodes of the given formats in bitmap. A reader only finds one
+// barcode, so once it has, the parts of the image to each side of it are searched for more, as
+// zxing's GenericMultipleBarcodeReader does.
+func decodeLinear(bitmap *gozxing.BinaryBitmap, formats []gozxing.BarcodeFormat) []Barcode {
+	hints := map[gozxing.DecodeHintType]interface{}{
+		gozxing.DecodeHintType_TRY_HARDER:       true,
+		gozxing.DecodeHintType_POSSIBLE_FORMATS: formats,
+	}
+	var readers []gozxing.Reader
+	if slices.ContainsFunc(formats, func(f gozxing.BarcodeFormat) bool { return f != gozxing.BarcodeFormat_CODE_128 }) {
+		// reads UPC-A as EAN-13 with a leading zero, and reports it as UPC-A if that was asked for
+		readers = append(readers, oned.NewMultiFormatUPCEANReader(hints))
+	}
+	if slices.Contains(formats, gozxing.BarcodeFormat_CODE_128) {
+		readers = append(readers, oned.NewCode128Reader())
+	}
+	var found []Barcode
+	searchLinear(bitmap, readers, hints, image.Point{}, 0, &found)
+	return found
+}
+
+// searchLinear adds the 1D barcodes found in bitmap, offset by the given point, to found.
+func searchLinear(
+	bitmap *gozxing.BinaryBitmap,
+	readers []gozxing.Reader,
+	hints map[gozxing.DecodeHintType]interface{},
+	offset image.Point,
+	depth int,
+	found *[]Barcode,
+) {
+	if depth > maxLinearSearchDepth {
+		return
+	}
+	var result *gozxing.Result
+	for _, r := range readers {
+		if read, err := r.Decode(bitmap, hints); err == nil {
+			result = read
+			break
+		}
+	}
+	if result == nil || len(result.GetResultPoints()) < 2 {
+		return
+	}
+
+	points := result.GetResultPoints()
+	start := image.Pt(int(math.Round(points[0].GetX())), int(math.Round(points[0].GetY()))).Add(offset)
+	end := image.Pt(int(math.Round(points[1].GetX())), int(math.Round(points[1].GetY()))).Add(offset)
+	b := Barcode{Payload: result.GetText(), Corners: [4]image.Point{start, end, end, start}}
+	for f, zf := range zxingFormats {
+		if zf == result.GetBarcodeFormat() {
+			b.Format = f
+		}
+	}
+	// the bars of a barcode span many rows, so the parts of the image above and below a barcode
+	// read along one row can hold it again
+	if !slices.ContainsFunc(*found, func(other Barcode) bool {
+		return other.Format == b.Format && other.Payload == b.Payload
+	}) {
+		*found = append(*found, b)
+	}
+
+	minX, minY := bitmap.GetWidth(), bitmap.GetHeight()
+	maxX, maxY := 0, 0
+	for _, p := range points {
+		x, y := int(p.GetX()), int(p.GetY())
+		minX, minY = min(minX, x), min(minY, y)
+		maxX, maxY = max(maxX, x), max(maxY, y)
+	}
+	w, h := bitmap.GetWidth(), bitmap.GetHeight()
+	search := func(region image.Rectangle) {
+		if region.Dx() < minLinearSearchSide || region.Dy() < minLinearSearchSide {
+			return
+		}
+		cropped, err := bitmap.Crop(region.Min.X, region.Min.Y, region.Dx(), region.Dy())
+		if err != nil {
+			return
+		}
+		searchLinear(cropped, readers, hints, offset.Add(region.Min), depth+1, found)
+	}
+	search(image.Rect(0, 0, minX, h))
+	search(image.Rect(0, 0, w, minY))
+	search(image.Rect(maxX, 0, w, h))
+	search(image.Rect(0, maxY, w, h))
+}
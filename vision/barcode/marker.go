@@ -0,0 +1,594 @@
+package barcode
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/golang/geo/r2"
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// ArucoOriginal is the family of the 1024 markers of the original ArUco library, whose 5 by 5
+// data cells hold 2 bits of the marker's ID in each row.
+const ArucoOriginal = "aruco_original"
+
+// MarkerFamilies are the names of the built in marker families. Families whose codes cannot be
+// derived, such as the AprilTag tag36h11 family and OpenCV's DICT_4X4_50 and DICT_6X6_250
+// dictionaries, are not built in, and are made with NewMarkerFamily from their published codes.
+var MarkerFamilies = []string{ArucoOriginal}
+
+const (
+	// the smallest width and height in pixels of a marker that is looked for
+	minMarkerSide = 12
+	// the least fraction of the convex hull of a dark region that its quadrilateral must cover
+	// for the region to be a marker's border
+	minQuadFill = 0.9
+)
+
+// A MarkerFamily is a dictionary of square fiducial markers, such as ArUco markers or AprilTags,
+// each a grid of black and white data cells inside a black border one cell wide, on a white
+// background.
+type MarkerFamily struct {
+	Name string
+	// Size is the number of data cells along each side of a marker.
+	Size int
+	// Codes are the data cells of the markers by ID, read row by row from the top left with the
+	// first cell the most significant bit and a white cell a set bit.
+	Codes []uint64
+	// MaxCorrection is how many data cells of a marker may be misread for it to still be decoded
+	// as the marker with the closest code.
+	MaxCorrection int
+
+	ids map[uint64]int
+}
+
+// NewMarkerFamily returns a family of markers of the given size with the given codes by ID, such
+// as an AprilTag family.
+func NewMarkerFamily(name string, size int, codes []uint64, maxCorrection int) (*MarkerFamily, error) {
+	if size < 1 || size > 8 {
+		return nil, errors.Errorf("marker family size must be from 1 to 8 cells, got %d", size)
+	}
+	if len(codes) == 0 {
+		return nil, errors.New("marker family must have at least one code")
+	}
+	if maxCorrection < 0 {
+		return nil, errors.Errorf("marker family max correction cannot be negative, got %d", maxCorrection)
+	}
+	for id, code := range codes {
+		if size < 8 && code>>(size*size) != 0 {
+			return nil, errors.Errorf("code %#x of marker %d has more than %d bits", code, id, size*size)
+		}
+	}
+	f := newMarkerFamily(name, size, codes, maxCorrection)
+	if len(f.ids) != len(codes) {
+		return nil, errors.Errorf("marker family %q has the same code for more than one marker", name)
+	}
+	return f, nil
+}
+
+func newMarkerFamily(name string, size int, codes []uint64, maxCorrection int) *MarkerFamily {
+	f := &MarkerFamily{Name: name, Size: size, Codes: codes, MaxCorrection: maxCorrection, ids: make(map[uint64]int, len(codes))}
+	for id, code := range codes {
+		if _, ok := f.ids[code]; !ok {
+			f.ids[code] = id
+		}
+	}
+	return f
+}
+
+// the rows of an original ArUco marker holding each pair of ID bits, with a set bit for a white cell
+var arucoOriginalRows = [4]uint64{0b10000, 0b10111, 0b01001, 0b01110}
+
+var builtinMarkerFamilies = map[string]*MarkerFamily{
+	ArucoOriginal: func() *MarkerFamily {
+		codes := make([]uint64, 1024)
+		for id := range codes {
+			for y := 0; y < 5; y++ {
+				codes[id] = codes[id]<<5 | arucoOriginalRows[(id>>(2*(4-y)))&3]
+			}
+		}
+		return newMarkerFamily(ArucoOriginal, 5, codes, 0)
+	}(),
+}
+
+// ParseMarkerFamily returns the built in marker family with the given name.
+func ParseMarkerFamily(name string) (*MarkerFamily, error) {
+	if f, ok := builtinMarkerFamilies[name]; ok {
+		return f, nil
+	}
+	return nil, errors.Errorf("unknown marker family %q, expected one of %v", name, MarkerFamilies)
+}
+
+// closest returns the ID of the marker whose code is closest to code, and how many cells they
+// differ by.
+func (f *MarkerFamily) closest(code uint64) (int, int) {
+	if id, ok := f.ids[code]; ok {
+		return id, 0
+	}
+	best, bestDistance := -1, math.MaxInt
+	if f.MaxCorrection == 0 {
+		return best, bestDistance
+	}
+	for id, c := range f.Codes {
+		if d := bits.OnesCount64(code ^ c); d < bestDistance {
+			best, bestDistance = id, d
+		}
+	}
+	return best, bestDistance
+}
+
+// A Marker is a fiducial marker found in an image.
+type Marker struct {
+	Family string
+	ID     int
+	// Corners are the outer corners of the marker's black border in the image, clockwise from its
+	// top left as it was made, so their order gives its orientation.
+	Corners [4]r2.Point
+}
+
+// BoundingBox returns the smallest rectangle holding the corners of the marker.
+func (m Marker) BoundingBox() image.Rectangle {
+	box := image.Rectangle{Min: image.Pt(math.MaxInt, math.MaxInt), Max: image.Pt(math.MinInt, math.MinInt)}
+	for _, c := range m.Corners {
+		box.Min.X = min(box.Min.X, int(math.Floor(c.X)))
+		box.Min.Y = min(box.Min.Y, int(math.Floor(c.Y)))
+		box.Max.X = max(box.Max.X, int(math.Ceil(c.X)))
+		box.Max.Y = max(box.Max.Y, int(math.Ceil(c.Y)))
+	}
+	return box
+}
+
+// Center returns where the diagonals of the marker cross in the image.
+func (m Marker) Center() r2.Point {
+	if p, ok := intersectLines(m.Corners[0], m.Corners[2].Sub(m.Corners[0]), m.Corners[1], m.Corners[3].Sub(m.Corners[1])); ok {
+		return p
+	}
+	return m.Corners[0].Add(m.Corners[2]).Mul(0.5)
+}
+
+// Pose returns the pose of the marker in the frame of the camera that took the image it was found
+// in, given the camera's intrinsics and the printed width of the marker's black border in mm. The
+// pose is of the center of the marker, with its x axis to the right and its y axis down the
+// marker as it was made, so its z axis points into the surface it is printed on.
+func (m Marker) Pose(intrinsics *transform.PinholeCameraIntrinsics, sizeMM float64) (spatialmath.Pose, error) {
+	var corners [4][2]float64
+	for i, c := range m.Corners {
+		corners[i] = [2]float64{c.X, c.Y}
+	}
+	return cornersPose(corners, intrinsics, sizeMM)
+}
+
+// DecodeMarkers returns the markers of the given families found in img, or of the original ArUco
+// family if none are given.
+func DecodeMarkers(img image.Image, families ...*MarkerFamily) []Marker {
+	if len(families) == 0 {
+		families = []*MarkerFamily{builtinMarkerFamilies[ArucoOriginal]}
+	}
+	bits := binarize(img)
+	var found []Marker
+	for _, quad := range findQuads(bits) {
+		for _, f := range families {
+			if m, ok := decodeMarker(bits, quad, f); ok {
+				found = append(found, m)
+				break
+			}
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		a, b := found[i].BoundingBox().Min, found[j].BoundingBox().Min
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		return a.X < b.X
+	})
+	return found
+}
+
+// findQuads returns the corners, clockwise, of the dark regions of bits whose outlines are
+// quadrilaterals, such as the borders of markers. Regions touching the edge of the image are
+// skipped, since they may be cut off.
+func findQuads(bits *bitMatrix) [][4]r2.Point {
+	w := bits.width
+	seen := make([]bool, len(bits.bits))
+	var quads [][4]r2.Point
+	var region []int
+	for start, black := range bits.bits {
+		if !black || seen[start] {
+			continue
+		}
+		seen[start] = true
+		region = append(region[:0], start)
+		onEdge := false
+		for i := 0; i < len(region); i++ {
+			x, y := region[i]%w, region[i]/w
+			if x == 0 || y == 0 || x == w-1 || y == bits.height-1 {
+				onEdge = true
+			}
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					if !bits.get(x+dx, y+dy) {
+						continue
+					}
+					if n := (y+dy)*w + x + dx; !seen[n] {
+						seen[n] = true
+						region = append(region, n)
+					}
+				}
+			}
+		}
+		if onEdge {
+			continue
+		}
+		if quad, ok := regionQuad(region, w); ok {
+			quads = append(quads, quad)
+		}
+	}
+	return quads
+}
+
+// regionQuad returns the corners, clockwise, of the quadrilateral outlining a region of pixels
+// given by their indexes in an image of width w, or false if the region is not outlined by one.
+func regionQuad(region []int, w int) ([4]r2.Point, bool) {
+	minX, minY, maxX, maxY := math.MaxInt, math.MaxInt, -1, -1
+	for _, i := range region {
+		x, y := i%w, i/w
+		minX, minY, maxX, maxY = min(minX, x), min(minY, y), max(maxX, x), max(maxY, y)
+	}
+	width, height := maxX-minX+1, maxY-minY+1
+	if width < minMarkerSide || height < minMarkerSide {
+		return [4]r2.Point{}, false
+	}
+
+	// the outline is the first and last pixel of the region in each row and column
+	rowFirst, rowLast := make([]int, height), make([]int, height)
+	colFirst, colLast := make([]int, width), make([]int, width)
+	for i := range rowFirst {
+		rowFirst[i], rowLast[i] = math.MaxInt, -1
+	}
+	for i := range colFirst {
+		colFirst[i], colLast[i] = math.MaxInt, -1
+	}
+	for _, i := range region {
+		x, y := i%w, i/w
+		rowFirst[y-minY], rowLast[y-minY] = min(rowFirst[y-minY], x), max(rowLast[y-minY], x)
+		colFirst[x-minX], colLast[x-minX] = min(colFirst[x-minX], y), max(colLast[x-minX], y)
+	}
+	outline := make([]r2.Point, 0, 2*(width+height))
+	for i := range rowFirst {
+		if rowLast[i] >= 0 {
+			y := float64(minY+i) + 0.5
+			outline = append(outline, r2.Point{X: float64(rowFirst[i]) + 0.5, Y: y}, r2.Point{X: float64(rowLast[i]) + 0.5, Y: y})
+		}
+	}
+	for i := range colFirst {
+		if colLast[i] >= 0 {
+			x := float64(minX+i) + 0.5
+			outline = append(outline, r2.Point{X: x, Y: float64(colFirst[i]) + 0.5}, r2.Point{X: x, Y: float64(colLast[i]) + 0.5})
+		}
+	}
+
+	hull := convexHull(outline)
+	corners, ok := hullQuad(hull)
+	if !ok || polygonArea(corners[:]) < minQuadFill*polygonArea(hull) {
+		return [4]r2.Point{}, false
+	}
+	for i := range corners {
+		if corners[i].Sub(corners[(i+1)%4]).Norm() < minMarkerSide/2 {
+			return [4]r2.Point{}, false
+		}
+	}
+	if polygonArea(corners[:]) < 0 {
+		corners[1], corners[3] = corners[3], corners[1]
+	}
+	return refineQuad(corners, outline), true
+}
+
+// convexHull returns the convex hull of points, clockwise in image coordinates.
+func convexHull(points []r2.Point) []r2.Point {
+	sorted := append([]r2.Point(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+	hull := make([]r2.Point, 0, len(sorted)+1)
+	for pass := 0; pass < 2; pass++ {
+		start := len(hull)
+		for _, p := range sorted {
+			for len(hull) >= start+2 && hull[len(hull)-1].Sub(hull[len(hull)-2]).Cross(p.Sub(hull[len(hull)-2])) <= 0 {
+				hull = hull[:len(hull)-1]
+			}
+			hull = append(hull, p)
+		}
+		// the last point of each half is the first of the other
+		hull = hull[:len(hull)-1]
+		for i, j := 0, len(sorted)-1; i < j; i, j = i+1, j-1 {
+			sorted[i], sorted[j] = sorted[j], sorted[i]
+		}
+	}
+	return hull
+}
+
+// polygonArea returns the area of a polygon, positive if its corners are clockwise in image
+// coordinates.
+func polygonArea(corners []r2.Point) float64 {
+	var area float64
+	for i, c := range corners {
+		area += c.Cross(corners[(i+1)%len(corners)])
+	}
+	return area / 2
+}
+
+// hullQuad returns the four points of a convex hull that best span it, in the hull's order: the
+// point furthest from the hull's center, the point furthest from that, and the points furthest
+// to either side of the line between them.
+func hullQuad(hull []r2.Point) ([4]r2.Point, bool) {
+	if len(hull) < 4 {
+		return [4]r2.Point{}, false
+	}
+	var center r2.Point
+	for _, p := range hull {
+		center = center.Add(p)
+	}
+	center = center.Mul(1 / float64(len(hull)))
+	furthest := func(from r2.Point) int {
+		best := 0
+		for i, p := range hull {
+			if p.Sub(from).Norm() > hull[best].Sub(from).Norm() {
+				best = i
+			}
+		}
+		return best
+	}
+	a := furthest(center)
+	c := furthest(hull[a])
+	b, d := -1, -1
+	var bSide, dSide float64
+	for i, p := range hull {
+		side := hull[c].Sub(hull[a]).Cross(p.Sub(hull[a]))
+		if side > bSide {
+			b, bSide = i, side
+		}
+		if side < dSide {
+			d, dSide = i, side
+		}
+	}
+	if b < 0 || d < 0 {
+		return [4]r2.Point{}, false
+	}
+	indexes := []int{a, b, c, d}
+	sort.Ints(indexes)
+	var quad [4]r2.Point
+	for i, index := range indexes {
+		quad[i] = hull[index]
+	}
+	return quad, true
+}
+
+// refineQuad moves the corners of a quadrilateral found from pixel centers to where the lines
+// best fitting the outline of each of its sides cross, half a pixel out to the edges of the
+// pixels. It returns the corners as they are if any side cannot be fit.
+func refineQuad(corners [4]r2.Point, outline []r2.Point) [4]r2.Point {
+	center := corners[0].Add(corners[1]).Add(corners[2]).Add(corners[3]).Mul(0.25)
+	var points, directions [4]r2.Point
+	for i := range corners {
+		p, q := corners[i], corners[(i+1)%4]
+		side := q.Sub(p)
+		length := side.Norm()
+		unit := side.Mul(1 / length)
+		tolerance := math.Max(1.5, 0.05*length)
+		var near []r2.Point
+		for _, o := range outline {
+			along := o.Sub(p).Dot(unit) / length
+			if along > 0.1 && along < 0.9 && math.Abs(unit.Cross(o.Sub(p))) <= tolerance {
+				near = append(near, o)
+			}
+		}
+		if len(near) < 3 {
+			return corners
+		}
+		var mean r2.Point
+		for _, o := range near {
+			mean = mean.Add(o)
+		}
+		mean = mean.Mul(1 / float64(len(near)))
+		var sxx, sxy, syy float64
+		for _, o := range near {
+			d := o.Sub(mean)
+			sxx, sxy, syy = sxx+d.X*d.X, sxy+d.X*d.Y, syy+d.Y*d.Y
+		}
+		angle := math.Atan2(2*sxy, sxx-syy) / 2
+		direction := r2.Point{X: math.Cos(angle), Y: math.Sin(angle)}
+		outward := direction.Ortho()
+		if outward.Dot(mean.Sub(center)) < 0 {
+			outward = outward.Mul(-1)
+		}
+		points[i], directions[i] = mean.Add(outward.Mul(0.5)), direction
+	}
+	var refined [4]r2.Point
+	for i := range corners {
+		prev := (i + 3) % 4
+		p, ok := intersectLines(points[prev], directions[prev], points[i], directions[i])
+		if !ok || p.Sub(corners[i]).Norm() > 3 {
+			return corners
+		}
+		refined[i] = p
+	}
+	return refined
+}
+
+// intersectLines returns where the line through p in direction d crosses the line through q in
+// direction e, or false if they are parallel.
+func intersectLines(p, d, q, e r2.Point) (r2.Point, bool) {
+	denominator := d.Cross(e)
+	if math.Abs(denominator) < 1e-9 {
+		return r2.Point{}, false
+	}
+	return p.Add(d.Mul(q.Sub(p).Cross(e) / denominator)), true
+}
+
+// decodeMarker reads the cells of a marker of family f inside quad, and returns the marker if
+// they are one, trying each way the marker could be turned.
+func decodeMarker(bits *bitMatrix, quad [4]r2.Point, f *MarkerFamily) (Marker, bool) {
+	n := float64(f.Size + 2)
+	var dst [4][2]float64
+	for i, c := range quad {
+		dst[i] = [2]float64{c.X, c.Y}
+	}
+	h, ok := newHomography([4][2]float64{{0, 0}, {n, 0}, {n, n}, {0, n}}, dst)
+	if !ok {
+		return Marker{}, false
+	}
+
+	// each cell is white if most of the points sampled around its center are
+	cells := f.Size + 2
+	white := make([]bool, cells*cells)
+	offsets := [][2]float64{{0.5, 0.5}, {0.25, 0.25}, {0.75, 0.25}, {0.25, 0.75}, {0.75, 0.75}}
+	for y := 0; y < cells; y++ {
+		for x := 0; x < cells; x++ {
+			whites := 0
+			for _, o := range offsets {
+				px, py := h.apply(float64(x)+o[0], float64(y)+o[1])
+				if !bits.get(int(math.Floor(px)), int(math.Floor(py))) {
+					whites++
+				}
+			}
+			white[y*cells+x] = whites > len(offsets)/2
+			onBorder := x == 0 || y == 0 || x == cells-1 || y == cells-1
+			if onBorder && white[y*cells+x] {
+				return Marker{}, false
+			}
+		}
+	}
+
+	bestID, bestDistance, bestTurns := -1, math.MaxInt, 0
+	for turns := 0; turns < 4; turns++ {
+		var code uint64
+		for y := 0; y < f.Size; y++ {
+			for x := 0; x < f.Size; x++ {
+				// the cell at (x, y) of the marker turned so that the corner it was found with at
+				// index turns is its top left
+				u, v := x, y
+				for i := 0; i < turns; i++ {
+					u, v = f.Size-1-v, u
+				}
+				code <<= 1
+				if white[(v+1)*cells+u+1] {
+					code |= 1
+				}
+			}
+		}
+		if id, distance := f.closest(code); distance < bestDistance {
+			bestID, bestDistance, bestTurns = id, distance, turns
+		}
+	}
+	if bestID < 0 || bestDistance > f.MaxCorrection {
+		return Marker{}, false
+	}
+	m := Marker{Family: f.Name, ID: bestID}
+	for i := range m.Corners {
+		m.Corners[i] = quad[(i+bestTurns)%4]
+	}
+	return m, true
+}
+
+// bitMatrix is a black and white image, with true for black.
+type bitMatrix struct {
+	width, height int
+	bits          []bool
+}
+
+func newBitMatrix(width, height int) *bitMatrix {
+	return &bitMatrix{width: width, height: height, bits: make([]bool, width*height)}
+}
+
+func (m *bitMatrix) get(x, y int) bool {
+	if x < 0 || y < 0 || x >= m.width || y >= m.height {
+		return false
+	}
+	return m.bits[y*m.width+x]
+}
+
+func (m *bitMatrix) set(x, y int, black bool) {
+	m.bits[y*m.width+x] = black
+}
+
+// binarize thresholds img at the average of its Otsu threshold and the mean of each pixel's
+// neighborhood, which copes with uneven lighting without breaking up large dark areas.
+func binarize(img image.Image) *bitMatrix {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	gray := make([]uint8, w*h)
+	var histogram [256]int
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := color.GrayModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray).Y
+			gray[y*w+x] = v
+			histogram[v]++
+		}
+	}
+	global := otsu(histogram, w*h)
+
+	// integral image of the gray levels, with an extra leading row and column of zeros
+	integral := make([]int, (w+1)*(h+1))
+	for y := 0; y < h; y++ {
+		row := 0
+		for x := 0; x < w; x++ {
+			row += int(gray[y*w+x])
+			integral[(y+1)*(w+1)+x+1] = integral[y*(w+1)+x+1] + row
+		}
+	}
+	radius := max(w, h) / 16
+	if radius < 8 {
+		radius = 8
+	}
+
+	m := newBitMatrix(w, h)
+	for y := 0; y < h; y++ {
+		y0, y1 := max(0, y-radius), min(h, y+radius+1)
+		for x := 0; x < w; x++ {
+			x0, x1 := max(0, x-radius), min(w, x+radius+1)
+			sum := integral[y1*(w+1)+x1] - integral[y0*(w+1)+x1] - integral[y1*(w+1)+x0] + integral[y0*(w+1)+x0]
+			local := sum / ((x1 - x0) * (y1 - y0))
+			m.set(x, y, int(gray[y*w+x]) < (local+global)/2)
+		}
+	}
+	return m
+}
+
+// otsu returns the gray level that best separates the histogram into two classes.
+func otsu(histogram [256]int, total int) int {
+	var sum float64
+	for i, n := range histogram {
+		sum += float64(i * n)
+	}
+	var sumBackground, best float64
+	var background int
+	threshold := 128
+	for i, n := range histogram {
+		background += n
+		if background == 0 {
+			continue
+		}
+		foreground := total - background
+		if foreground == 0 {
+			break
+		}
+		sumBackground += float64(i * n)
+		meanBackground := sumBackground / float64(background)
+		meanForeground := (sum - sumBackground) / float64(foreground)
+		between := float64(background) * float64(foreground) * (meanBackground - meanForeground) * (meanBackground - meanForeground)
+		if between > best {
+			best = between
+			threshold = i + 1
+		}
+	}
+	return threshold
+}
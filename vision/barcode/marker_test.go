@@ -0,0 +1,188 @@
+package barcode
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// markerModules returns the cells of the marker of family f with the given ID, with its border,
+// as drawModules draws them.
+func markerModules(f *MarkerFamily, id int) []string {
+	n := f.Size + 2
+	rows := make([]string, n)
+	for y := 0; y < n; y++ {
+		row := make([]byte, n)
+		for x := 0; x < n; x++ {
+			row[x] = '#'
+			if x > 0 && y > 0 && x < n-1 && y < n-1 {
+				bit := f.Size*f.Size - 1 - ((y-1)*f.Size + x - 1)
+				if f.Codes[id]>>bit&1 == 1 {
+					row[x] = '.'
+				}
+			}
+		}
+		rows[y] = string(row)
+	}
+	return rows
+}
+
+func TestArucoOriginal(t *testing.T) {
+	f, err := ParseMarkerFamily(ArucoOriginal)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, f.Codes, test.ShouldHaveLength, 1024)
+	// each row of the data holds two bits of the ID, the first row the most significant
+	test.That(t, markerModules(f, 0b1110010011), test.ShouldResemble, []string{
+		"#######",
+		"##...##",
+		"##.##.#",
+		"#.#...#",
+		"#.#####",
+		"##...##",
+		"#######",
+	})
+
+	_, err = ParseMarkerFamily("tag99h99")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestDecodeMarkers(t *testing.T) {
+	f, err := ParseMarkerFamily(ArucoOriginal)
+	test.That(t, err, test.ShouldBeNil)
+	const scale, x0, y0 = 8, 40, 30
+	for turns := 0; turns < 4; turns++ {
+		img := newWhite(200, 200)
+		drawModules(img, markerModules(f, 915), x0, y0, scale, turns)
+
+		found := DecodeMarkers(img)
+		test.That(t, found, test.ShouldHaveLength, 1)
+		test.That(t, found[0].Family, test.ShouldEqual, ArucoOriginal)
+		test.That(t, found[0].ID, test.ShouldEqual, 915)
+
+		// the corners start from the marker's own top left, wherever it was turned to
+		square := [4]image.Point{
+			image.Pt(x0, y0),
+			image.Pt(x0+7*scale, y0),
+			image.Pt(x0+7*scale, y0+7*scale),
+			image.Pt(x0, y0+7*scale),
+		}
+		for i, c := range found[0].Corners {
+			want := square[(i+turns)%4]
+			test.That(t, c.X, test.ShouldAlmostEqual, want.X, 0.5)
+			test.That(t, c.Y, test.ShouldAlmostEqual, want.Y, 0.5)
+		}
+		test.That(t, found[0].BoundingBox().Min.X, test.ShouldAlmostEqual, x0, 1)
+		center := found[0].Center()
+		test.That(t, center.X, test.ShouldAlmostEqual, x0+3.5*scale, 0.5)
+		test.That(t, center.Y, test.ShouldAlmostEqual, y0+3.5*scale, 0.5)
+	}
+}
+
+func TestDecodeSeveralMarkers(t *testing.T) {
+	f, err := ParseMarkerFamily(ArucoOriginal)
+	test.That(t, err, test.ShouldBeNil)
+	img := newWhite(400, 300)
+	drawModules(img, markerModules(f, 7), 250, 20, 10, 0)
+	drawModules(img, markerModules(f, 1000), 30, 40, 6, 3)
+	// a plain black square and a QR code are not markers
+	drawModules(img, []string{"#######", "#######", "#######", "#######", "#######", "#######", "#######"}, 150, 180, 8, 0)
+	drawModules(img, toteQR, 260, 160, 4, 0)
+
+	found := DecodeMarkers(img)
+	test.That(t, found, test.ShouldHaveLength, 2)
+	// in reading order, top to bottom
+	test.That(t, found[0].ID, test.ShouldEqual, 7)
+	test.That(t, found[1].ID, test.ShouldEqual, 1000)
+}
+
+func TestMarkerFamily(t *testing.T) {
+	// a family of 3 by 3 markers far enough apart to correct a misread cell
+	f, err := NewMarkerFamily("test", 3, []uint64{0b101010101, 0b010111010, 0b111000111}, 1)
+	test.That(t, err, test.ShouldBeNil)
+
+	modules := markerModules(f, 2)
+	// misread the middle cell
+	modules[2] = "##.##"
+	img := newWhite(200, 200)
+	drawModules(img, modules, 50, 50, 12, 1)
+	found := DecodeMarkers(img, f)
+	test.That(t, found, test.ShouldHaveLength, 1)
+	test.That(t, found[0].Family, test.ShouldEqual, "test")
+	test.That(t, found[0].ID, test.ShouldEqual, 2)
+	// a marker of another family is not decoded
+	test.That(t, DecodeMarkers(img), test.ShouldBeEmpty)
+
+	for _, bad := range []func() error{
+		func() error { _, err := NewMarkerFamily("test", 9, []uint64{1}, 0); return err },
+		func() error { _, err := NewMarkerFamily("test", 3, nil, 0); return err },
+		func() error { _, err := NewMarkerFamily("test", 3, []uint64{1 << 9}, 0); return err },
+		func() error { _, err := NewMarkerFamily("test", 3, []uint64{1, 1}, 0); return err },
+		func() error { _, err := NewMarkerFamily("test", 3, []uint64{1}, -1); return err },
+	} {
+		test.That(t, bad(), test.ShouldNotBeNil)
+	}
+}
+
+func TestMarkerPose(t *testing.T) {
+	f, err := ParseMarkerFamily(ArucoOriginal)
+	test.That(t, err, test.ShouldBeNil)
+	intrinsics := &transform.PinholeCameraIntrinsics{Width: 640, Height: 480, Fx: 600, Fy: 600, Ppx: 320, Ppy: 240}
+	// turned 30 degrees about y, then -20 degrees about x
+	a, b := 30*math.Pi/180, -20*math.Pi/180
+	rotation := [9]float64{
+		math.Cos(a), 0, math.Sin(a),
+		math.Sin(b) * math.Sin(a), math.Cos(b), -math.Sin(b) * math.Cos(a),
+		-math.Cos(b) * math.Sin(a), math.Sin(b), math.Cos(b) * math.Cos(a),
+	}
+	position := [3]float64{40, -25, 500}
+	square := [4][2]float64{{-40, -40}, {40, -40}, {40, 40}, {-40, 40}}
+	var corners [4][2]float64
+	for i, p := range square {
+		x := rotation[0]*p[0] + rotation[1]*p[1] + position[0]
+		y := rotation[3]*p[0] + rotation[4]*p[1] + position[1]
+		z := rotation[6]*p[0] + rotation[7]*p[1] + position[2]
+		corners[i] = [2]float64{intrinsics.Fx*x/z + intrinsics.Ppx, intrinsics.Fy*y/z + intrinsics.Ppy}
+	}
+
+	// draw the marker as the camera would see it, looking up the cell of each pixel
+	toMarker, ok := newHomography(corners, square)
+	test.That(t, ok, test.ShouldBeTrue)
+	modules := markerModules(f, 42)
+	img := newWhite(640, 480)
+	for y := 0; y < 480; y++ {
+		for x := 0; x < 640; x++ {
+			u, v := toMarker.apply(float64(x)+0.5, float64(y)+0.5)
+			cellX, cellY := int(math.Floor((u+40)/80*7)), int(math.Floor((v+40)/80*7))
+			if cellX >= 0 && cellY >= 0 && cellX < 7 && cellY < 7 && modules[cellY][cellX] == '#' {
+				img.Pix[y*img.Stride+x] = 0
+			}
+		}
+	}
+
+	found := DecodeMarkers(img)
+	test.That(t, found, test.ShouldHaveLength, 1)
+	test.That(t, found[0].ID, test.ShouldEqual, 42)
+	for i, c := range found[0].Corners {
+		test.That(t, c.X, test.ShouldAlmostEqual, corners[i][0], 1)
+		test.That(t, c.Y, test.ShouldAlmostEqual, corners[i][1], 1)
+	}
+
+	pose, err := found[0].Pose(intrinsics, 80)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, pose.Point().X, test.ShouldAlmostEqual, position[0], 5)
+	test.That(t, pose.Point().Y, test.ShouldAlmostEqual, position[1], 5)
+	test.That(t, pose.Point().Z, test.ShouldAlmostEqual, position[2], 10)
+	want, err := spatialmath.NewRotationMatrix(rotation[:])
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatialmath.OrientationAlmostEqualEps(pose.Orientation(), want, 0.01), test.ShouldBeTrue)
+
+	_, err = found[0].Pose(nil, 80)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = found[0].Pose(intrinsics, 0)
+	test.That(t, err, test.ShouldNotBeNil)
+}
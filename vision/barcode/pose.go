@@ -0,0 +1,148 @@
+package barcode
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// Pose returns the pose of a QR code in the frame of the camera that took the image it was found
+// in, given the camera's intrinsics and the printed width of the code in mm. The pose is of the
+// center of the code, with its x axis to the right and its y axis down the code as it reads, so
+// its z axis points into the surface it is printed on.
+func (b Barcode) Pose(intrinsics *transform.PinholeCameraIntrinsics, sizeMM float64) (spatialmath.Pose, error) {
+	if b.Format != QRCode {
+		return nil, errors.Errorf("cannot find the pose of a %s barcode, only of a %s", b.Format, QRCode)
+	}
+	var corners [4][2]float64
+	for i, c := range b.Corners {
+		corners[i] = [2]float64{float64(c.X), float64(c.Y)}
+	}
+	return cornersPose(corners, intrinsics, sizeMM)
+}
+
+// cornersPose returns the pose of a square of the given size in mm whose corners, clockwise from
+// its top left, are seen at the given pixels.
+func cornersPose(corners [4][2]float64, intrinsics *transform.PinholeCameraIntrinsics, sizeMM float64) (spatialmath.Pose, error) {
+	if err := intrinsics.CheckValid(); err != nil {
+		return nil, err
+	}
+	if sizeMM <= 0 {
+		return nil, errors.Errorf("barcode size must be positive, got %v", sizeMM)
+	}
+	point, rotation, err := squarePose(corners, intrinsics, sizeMM)
+	if err != nil {
+		return nil, err
+	}
+	orientation, err := spatialmath.NewRotationMatrix(rotation[:])
+	if err != nil {
+		return nil, err
+	}
+	return spatialmath.NewPose(point, orientation), nil
+}
+
+// squarePose returns the position and row major rotation matrix of a square of the given size
+// whose corners, clockwise from its top left, are seen at the given pixels. It decomposes the
+// homography from the plane of the square to normalized image coordinates.
+func squarePose(
+	corners [4][2]float64,
+	intrinsics *transform.PinholeCameraIntrinsics,
+	size float64,
+) (r3.Vector, [9]float64, error) {
+	half := size / 2
+	square := [4][2]float64{{-half, -half}, {half, -half}, {half, half}, {-half, half}}
+	var normalized [4][2]float64
+	for i, c := range corners {
+		normalized[i] = [2]float64{(c[0] - intrinsics.Ppx) / intrinsics.Fx, (c[1] - intrinsics.Ppy) / intrinsics.Fy}
+	}
+	h, ok := newHomography(square, normalized)
+	if !ok {
+		return r3.Vector{}, [9]float64{}, errors.New("barcode corners are degenerate")
+	}
+
+	// the columns of the homography are the first two axes of the square and its position, up
+	// to a common scale
+	r1 := r3.Vector{X: h[0], Y: h[3], Z: h[6]}
+	r2 := r3.Vector{X: h[1], Y: h[4], Z: h[7]}
+	t := r3.Vector{X: h[2], Y: h[5], Z: h[8]}
+	scale := 2 / (r1.Norm() + r2.Norm())
+	if t.Z < 0 {
+		// the square is in front of the camera
+		scale = -scale
+	}
+	r1, r2, t = r1.Mul(scale), r2.Mul(scale), t.Mul(scale)
+
+	// noise leaves the axes slightly skewed, so make them orthonormal
+	r1 = r1.Normalize()
+	r2 = r2.Sub(r1.Mul(r1.Dot(r2))).Normalize()
+	r3v := r1.Cross(r2)
+	rotation := [9]float64{
+		r1.X, r2.X, r3v.X,
+		r1.Y, r2.Y, r3v.Y,
+		r1.Z, r2.Z, r3v.Z,
+	}
+	return t, rotation, nil
+}
+
+type homography [9]float64
+
+// newHomography returns the homography taking each of src to the point of dst at the same index.
+func newHomography(src, dst [4][2]float64) (homography, bool) {
+	var a [8][9]float64
+	for i := range src {
+		u, v := src[i][0], src[i][1]
+		x, y := dst[i][0], dst[i][1]
+		a[2*i] = [9]float64{u, v, 1, 0, 0, 0, -u * x, -v * x, x}
+		a[2*i+1] = [9]float64{0, 0, 0, u, v, 1, -u * y, -v * y, y}
+	}
+	solution, ok := solveLinear(a)
+	if !ok {
+		return homography{}, false
+	}
+	var h homography
+	copy(h[:8], solution[:])
+	h[8] = 1
+	return h, true
+}
+
+func (h homography) apply(u, v float64) (float64, float64) {
+	w := h[6]*u + h[7]*v + h[8]
+	return (h[0]*u + h[1]*v + h[2]) / w, (h[3]*u + h[4]*v + h[5]) / w
+}
+
+// solveLinear solves the 8 linear equations in 8 unknowns of an augmented matrix by Gaussian
+// elimination with partial pivoting.
+func solveLinear(a [8][9]float64) ([8]float64, bool) {
+	const n = 8
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return [8]float64{}, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		for row := col + 1; row < n; row++ {
+			f := a[row][col] / a[col][col]
+			for k := col; k <= n; k++ {
+				a[row][k] -= f * a[col][k]
+			}
+		}
+	}
+	var x [8]float64
+	for row := n - 1; row >= 0; row-- {
+		sum := a[row][n]
+		for k := row + 1; k < n; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x, true
+}
@@ -0,0 +1,70 @@
+package barcode
+
+import (
+	"image"
+	"math"
+
+	"github.com/makiuchi-d/gozxing"
+	multidetector "github.com/makiuchi-d/gozxing/multi/qrcode/detector"
+	"github.com/makiuchi-d/gozxing/qrcode/decoder"
+)
+
+// decodeQRCodes returns the QR codes in bitmap.
+func decodeQRCodes(bitmap *gozxing.BinaryBitmap) []Barcode {
+	matrix, err := bitmap.GetBlackMatrix()
+	if err != nil {
+		return nil
+	}
+	hints := map[gozxing.DecodeHintType]interface{}{gozxing.DecodeHintType_TRY_HARDER: true}
+	detected, err := multidetector.NewMultiDetector(matrix).DetectMulti(hints)
+	if err != nil {
+		return nil
+	}
+	var found []Barcode
+	for _, d := range detected {
+		decoded, err := decoder.NewDecoder().Decode(d.GetBits(), hints)
+		if err != nil {
+			continue
+		}
+		points := d.GetPoints()
+		if metadata, ok := decoded.GetOther().(*decoder.QRCodeDecoderMetaData); ok {
+			metadata.ApplyMirroredCorrection(points)
+		}
+		corners, ok := qrCorners(points, d.GetBits().GetWidth())
+		if !ok {
+			continue
+		}
+		found = append(found, Barcode{Format: QRCode, Payload: decoded.GetText(), Corners: corners})
+	}
+	return found
+}
+
+// qrCorners returns the outer corners of a QR code the given number of modules wide, clockwise
+// from its top left, from the centers of its bottom left, top left and top right finder patterns
+// and, if it has one, of its bottom right alignment pattern.
+func qrCorners(points []gozxing.ResultPoint, dimension int) ([4]image.Point, bool) {
+	d := float64(dimension)
+	// the centers of the patterns in modules from the code's top left
+	modules := [4][2]float64{{3.5, d - 3.5}, {3.5, 3.5}, {d - 3.5, 3.5}, {d - 6.5, d - 6.5}}
+	var pixels [4][2]float64
+	for i := 0; i < 3; i++ {
+		pixels[i] = [2]float64{points[i].GetX(), points[i].GetY()}
+	}
+	if len(points) > 3 {
+		pixels[3] = [2]float64{points[3].GetX(), points[3].GetY()}
+	} else {
+		// without an alignment pattern, take the code to be a parallelogram
+		modules[3] = [2]float64{d - 3.5, d - 3.5}
+		pixels[3] = [2]float64{pixels[0][0] + pixels[2][0] - pixels[1][0], pixels[0][1] + pixels[2][1] - pixels[1][1]}
+	}
+	h, ok := newHomography(modules, pixels)
+	if !ok {
+		return [4]image.Point{}, false
+	}
+	var corners [4]image.Point
+	for i, c := range [4][2]float64{{0, 0}, {d, 0}, {d, d}, {0, d}} {
+		x, y := h.apply(c[0], c[1])
+		corners[i] = image.Pt(int(math.Round(x)), int(math.Round(y)))
+	}
+	return corners, true
+}
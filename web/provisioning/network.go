@@ -0,0 +1,169 @@
+package provisioning
+
+import (
+	"context"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	pb "go.viam.com/api/provisioning/v1"
+)
+
+// hotspotConnectionName is the NetworkManager connection that the provisioning hotspot is made as.
+const hotspotConnectionName = "viam-hotspot"
+
+// NetworkManager is the part of the machine's networking that provisioning needs.
+type NetworkManager interface {
+	// Networks scans for the WiFi networks in range, strongest first.
+	Networks(ctx context.Context) ([]*pb.NetworkInfo, error)
+	// Connect joins a WiFi network, saving its credentials so that it is rejoined on boot.
+	Connect(ctx context.Context, ssid, psk string) error
+	// StartHotspot makes the machine a WiFi access point.
+	StartHotspot(ctx context.Context, ssid, psk string) error
+	// StopHotspot stops the access point made by StartHotspot.
+	StopHotspot(ctx context.Context) error
+	// Online returns whether the machine can reach the internet.
+	Online(ctx context.Context) bool
+}
+
+// NewNMCLINetworkManager returns a NetworkManager that uses NetworkManager's nmcli on the given WiFi
+// interface, or on the one NetworkManager picks if it is empty.
+func NewNMCLINetworkManager(iface string) NetworkManager {
+	return &nmcliNetworkManager{iface: iface}
+}
+
+type nmcliNetworkManager struct {
+	iface string
+}
+
+func (nm *nmcliNetworkManager) run(ctx context.Context, args ...string) (string, error) {
+	out, err := exec.CommandContext(ctx, "nmcli", args...).CombinedOutput()
+	if err != nil {
+		// the arguments are left out since they can have a password in them
+		return "", errors.Wrapf(err, "nmcli %s failed: %s", args[0], strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+func (nm *nmcliNetworkManager) withInterface(args ...string) []string {
+	if nm.iface == "" {
+		return args
+	}
+	return append(args, "ifname", nm.iface)
+}
+
+func (nm *nmcliNetworkManager) Networks(ctx context.Context) ([]*pb.NetworkInfo, error) {
+	out, err := nm.run(ctx, nm.withInterface("-t", "-f", "IN-USE,SSID,SECURITY,SIGNAL", "device", "wifi", "list", "--rescan", "yes")...)
+	if err != nil {
+		return nil, err
+	}
+	return parseNetworkList(out), nil
+}
+
+// parseNetworkList parses the terse output of nmcli device wifi list, keeping the strongest
+// access point of each network.
+func parseNetworkList(out string) []*pb.NetworkInfo {
+	bySSID := map[string]*pb.NetworkInfo{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := splitTerseLine(line)
+		if len(fields) != 4 || fields[1] == "" {
+			continue
+		}
+		signal, err := strconv.Atoi(fields[3])
+		if err != nil {
+			continue
+		}
+		network := &pb.NetworkInfo{
+			Type:      wifiNetworkType,
+			Ssid:      fields[1],
+			Security:  fields[2],
+			Signal:    int32(signal),
+			Connected: fields[0] == "*",
+		}
+		if existing, ok := bySSID[network.Ssid]; ok {
+			existing.Connected = existing.Connected || network.Connected
+			if existing.Signal >= network.Signal {
+				continue
+			}
+			network.Connected = existing.Connected
+		}
+		bySSID[network.Ssid] = network
+	}
+	networks := make([]*pb.NetworkInfo, 0, len(bySSID))
+	for _, network := range bySSID {
+		networks = append(networks, network)
+	}
+	slices.SortFunc(networks, func(a, b *pb.NetworkInfo) int {
+		if a.Signal != b.Signal {
+			return int(b.Signal - a.Signal)
+		}
+		return strings.Compare(a.Ssid, b.Ssid)
+	})
+	return networks
+}
+
+// splitTerseLine splits a line of nmcli's terse output, in which colons in values are escaped.
+func splitTerseLine(line string) []string {
+	var fields []string
+	var field strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line):
+			i++
+			field.WriteByte(line[i])
+		case line[i] == ':':
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteByte(line[i])
+		}
+	}
+	return append(fields, field.String())
+}
+
+// validateSSID returns an error if ssid is not a WiFi network name that can be passed to nmcli, which takes
+// the SSID to join as a positional argument and so would read one starting with a dash as an option.
+func validateSSID(ssid string) error {
+	switch {
+	case ssid == "":
+		return errors.New("ssid is required")
+	case len(ssid) > 32:
+		return errors.Errorf("ssid %q is longer than 32 bytes", ssid)
+	case strings.HasPrefix(ssid, "-"):
+		return errors.Errorf("ssid %q cannot start with a dash", ssid)
+	case strings.ContainsFunc(ssid, unicode.IsControl):
+		return errors.Errorf("ssid %q cannot contain control characters", ssid)
+	}
+	return nil
+}
+
+func (nm *nmcliNetworkManager) Connect(ctx context.Context, ssid, psk string) error {
+	if err := validateSSID(ssid); err != nil {
+		return err
+	}
+	args := []string{"device", "wifi", "connect", ssid}
+	if psk != "" {
+		args = append(args, "password", psk)
+	}
+	_, err := nm.run(ctx, nm.withInterface(args...)...)
+	return err
+}
+
+func (nm *nmcliNetworkManager) StartHotspot(ctx context.Context, ssid, psk string) error {
+	_, err := nm.run(ctx, nm.withInterface(
+		"device", "wifi", "hotspot", "con-name", hotspotConnectionName, "ssid", ssid, "password", psk)...)
+	return err
+}
+
+func (nm *nmcliNetworkManager) StopHotspot(ctx context.Context) error {
+	_, err := nm.run(ctx, "connection", "delete", hotspotConnectionName)
+	return err
+}
+
+func (nm *nmcliNetworkManager) Online(ctx context.Context) bool {
+	out, err := nm.run(ctx, "networking", "connectivity", "check")
+	return err == nil && strings.TrimSpace(out) == "full"
+}
@@ -0,0 +1,127 @@
+package provisioning
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strings"
+
+	pb "go.viam.com/api/provisioning/v1"
+)
+
+var portalTemplate = template.Must(template.New("portal").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Set up {{.Manufacturer}} {{.Model}}</title>
+<style>
+body { font-family: sans-serif; max-width: 32em; margin: 1em auto; padding: 0 1em; }
+label, input, select, textarea, button { display: block; width: 100%; box-sizing: border-box; margin-bottom: 0.5em; }
+textarea { height: 8em; font-family: monospace; }
+.error { color: #b00020; }
+</style>
+</head>
+<body>
+<h1>Set up {{.Manufacturer}} {{.Model}}</h1>
+{{if .Saved}}<p>Saved.</p>{{end}}
+{{range .Errors}}<p class="error">{{.}}</p>{{end}}
+<form method="post" action="/save">
+{{if .NeedsWiFi}}
+<label for="ssid">WiFi network</label>
+<select id="ssid" name="ssid">
+{{range .Networks}}<option value="{{.Ssid}}">{{.Ssid}} ({{.Signal}}%{{if .Security}}, {{.Security}}{{end}})</option>{{end}}
+</select>
+<label for="psk">WiFi password</label>
+<input id="psk" name="psk" type="password">
+{{end}}
+{{if .NeedsCloud}}
+<label for="viam_config">Machine cloud credentials (the contents of viam.json)</label>
+<textarea id="viam_config" name="viam_config"></textarea>
+{{end}}
+<button type="submit">Save</button>
+</form>
+</body>
+</html>
+`))
+
+// portalPage is what the captive portal's page is rendered from.
+type portalPage struct {
+	Manufacturer string
+	Model        string
+	Networks     []*pb.NetworkInfo
+	NeedsWiFi    bool
+	NeedsCloud   bool
+	Saved        bool
+	Errors       []string
+}
+
+// portalHandler serves the captive portal. Every path other than /save shows the setup page, so that the captive
+// portal checks of phones and laptops, which fetch well known URLs, open it.
+func (p *Provisioner) portalHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/save", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/", http.StatusFound)
+			return
+		}
+		errs := p.savePortalForm(r)
+		p.renderPortal(w, len(errs) == 0, errs)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.Redirect(w, r, "/", http.StatusFound)
+			return
+		}
+		p.renderPortal(w, false, nil)
+	})
+	return mux
+}
+
+func (p *Provisioner) savePortalForm(r *http.Request) []string {
+	if err := r.ParseForm(); err != nil {
+		return []string{err.Error()}
+	}
+	var cloud *pb.CloudConfig
+	if viamConfig := strings.TrimSpace(r.PostForm.Get("viam_config")); viamConfig != "" {
+		var cfg viamConfigFile
+		err := json.Unmarshal([]byte(viamConfig), &cfg)
+		if err != nil || cfg.Cloud == nil || cfg.Cloud.ID == "" || cfg.Cloud.Secret == "" {
+			return []string{`the machine cloud credentials must be JSON like {"cloud": {"id": "...", "secret": "..."}}`}
+		}
+		cloud = &pb.CloudConfig{Id: cfg.Cloud.ID, Secret: cfg.Cloud.Secret, AppAddress: cfg.Cloud.AppAddress}
+	}
+	// the cloud credentials are set first so that setting the network does not leave provisioning mode without them
+	if cloud != nil && !p.setCloud(cloud) {
+		return []string{"this machine is no longer in setup mode"}
+	}
+	if ssid := r.PostForm.Get("ssid"); ssid != "" {
+		if err := validateSSID(ssid); err != nil {
+			return []string{err.Error()}
+		}
+		if !p.setWiFi(&wifiCredentials{ssid: ssid, psk: r.PostForm.Get("psk")}) {
+			return []string{"this machine is no longer in setup mode"}
+		}
+	}
+	return nil
+}
+
+func (p *Provisioner) renderPortal(w http.ResponseWriter, saved bool, errs []string) {
+	p.mu.Lock()
+	page := portalPage{
+		Manufacturer: p.cfg.Manufacturer,
+		Model:        p.cfg.Model,
+		Networks:     p.networks,
+		NeedsWiFi:    !p.wasOnline,
+		NeedsCloud:   p.cloud == nil && !p.hasCloudCredentials(),
+		Saved:        saved,
+		Errors:       append(errs, p.errors...),
+	}
+	p.mu.Unlock()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	// captive portal pages must not be cached, or devices keep showing them after setup
+	w.Header().Set("Cache-Control", "no-store")
+	if err := portalTemplate.Execute(w, page); err != nil {
+		p.logger.Warnw("could not render captive portal", "error", err)
+	}
+}
@@ -0,0 +1,409 @@
+// Package provisioning sets up a machine's WiFi and cloud credentials on first boot. Until the machine has both,
+// it runs a WiFi hotspot with a captive portal for a browser and the provisioning gRPC API for the Viam mobile app.
+package provisioning
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	pb "go.viam.com/api/provisioning/v1"
+	goutils "go.viam.com/utils"
+	googlegrpc "google.golang.org/grpc"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+	rpb "go.viam.com/rdk/proto/rdk/provisioning/v1"
+)
+
+const (
+	// DefaultConfigPath is where the provisioning config is read from, which a manufacturer puts on the machine's image.
+	DefaultConfigPath = "/etc/viam-provisioning.json"
+
+	wifiNetworkType = "wifi"
+
+	defaultAppAddress = "https://app.viam.com:443"
+
+	// defaultOnlineTimeout is how long a network that was joined has to reach the internet.
+	defaultOnlineTimeout = 30 * time.Second
+)
+
+// Config is the provisioning config. Everything in it is optional.
+type Config struct {
+	// Manufacturer, Model and FragmentID are reported to the app setting the machine up.
+	Manufacturer string `json:"manufacturer"`
+	Model        string `json:"model"`
+	FragmentID   string `json:"fragment_id"`
+
+	// HotspotPrefix is the start of the hotspot's SSID, which ends with the machine's hostname.
+	HotspotPrefix    string `json:"hotspot_prefix"`
+	HotspotPassword  string `json:"hotspot_password"`
+	HotspotInterface string `json:"hotspot_interface"`
+
+	// PortalAddress and GRPCAddress are where the captive portal and the provisioning API listen.
+	PortalAddress string `json:"portal_address"`
+	GRPCAddress   string `json:"grpc_address"`
+}
+
+// ReadConfig reads the provisioning config at path, filling in defaults. A missing file is the default config.
+func ReadConfig(path string) (Config, error) {
+	cfg := Config{
+		Manufacturer:    "viam",
+		Model:           "custom",
+		HotspotPrefix:   "viam-setup",
+		HotspotPassword: "viamsetup",
+		PortalAddress:   ":80",
+		GRPCAddress:     ":4772",
+	}
+	data, err := os.ReadFile(path) //nolint:gosec
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return Config{}, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, errors.Wrapf(err, "invalid provisioning config %q", path)
+	}
+	if len(cfg.HotspotPassword) < 8 {
+		return Config{}, errors.Errorf("hotspot_password in %q must be at least 8 characters", path)
+	}
+	return cfg, nil
+}
+
+// wifiCredentials are the credentials of a WiFi network to join.
+type wifiCredentials struct {
+	ssid string
+	psk  string
+}
+
+// A Provisioner puts the machine into provisioning mode when it needs to be set up, and serves the provisioning API.
+type Provisioner struct {
+	pb.UnimplementedProvisioningServiceServer
+	rpb.UnimplementedProvisioningModeServiceServer
+
+	cfg            Config
+	viamConfigPath string
+	network        NetworkManager
+	logger         logging.Logger
+	onlineTimeout  time.Duration
+	enter          chan struct{}
+
+	mu            sync.Mutex
+	active        bool
+	wasOnline     bool
+	exit          chan struct{}
+	exitRequested bool
+	networks      []*pb.NetworkInfo
+	lastAttempt   *pb.NetworkInfo
+	errors        []string
+	wifi          *wifiCredentials
+	cloud         *pb.CloudConfig
+}
+
+// NewProvisioner returns a Provisioner that writes the cloud credentials it is given to viamConfigPath,
+// the machine's config file.
+func NewProvisioner(cfg Config, viamConfigPath string, network NetworkManager, logger logging.Logger) *Provisioner {
+	return &Provisioner{
+		cfg:            cfg,
+		viamConfigPath: viamConfigPath,
+		network:        network,
+		logger:         logger,
+		onlineTimeout:  defaultOnlineTimeout,
+		enter:          make(chan struct{}, 1),
+	}
+}
+
+// WaitUntilProvisioned returns once the machine has cloud credentials and is online, running provisioning mode until it does.
+func (p *Provisioner) WaitUntilProvisioned(ctx context.Context) error {
+	if p.hasCloudCredentials() && p.network.Online(ctx) {
+		return nil
+	}
+	_, err := p.provision(ctx)
+	return err
+}
+
+// Run puts the machine back into provisioning mode whenever EnterProvisioning is called, until ctx is done.
+// It returns true if the machine was given new cloud credentials, which it has to restart to use.
+func (p *Provisioner) Run(ctx context.Context) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-p.enter:
+		}
+		changed, err := p.provision(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				p.logger.Errorw("error in provisioning mode", "error", err)
+			}
+			continue
+		}
+		if changed {
+			return true
+		}
+	}
+}
+
+// provision runs provisioning mode until the machine is set up, or it is exited through the API. It returns whether
+// the machine was given new cloud credentials.
+func (p *Provisioner) provision(ctx context.Context) (bool, error) {
+	var changed bool
+	for {
+		if err := p.runHotspot(ctx); err != nil {
+			return changed, err
+		}
+
+		p.mu.Lock()
+		wifi, cloud, exitRequested := p.wifi, p.cloud, p.exitRequested
+		p.mu.Unlock()
+
+		if cloud != nil {
+			if err := p.writeCloudCredentials(cloud); err != nil {
+				p.addError(err)
+				continue
+			}
+			changed = true
+		}
+		if wifi != nil {
+			if err := p.connect(ctx, wifi); err != nil {
+				p.logger.Warnw("could not join network, returning to provisioning mode", "ssid", wifi.ssid, "error", err)
+				continue
+			}
+		}
+		if exitRequested || (p.hasCloudCredentials() && p.network.Online(ctx)) {
+			p.logger.Info("exited provisioning mode")
+			return changed, nil
+		}
+		p.logger.Info("machine is not set up yet, returning to provisioning mode")
+	}
+}
+
+// runHotspot runs the hotspot, captive portal and provisioning API until they have been given what the machine needs.
+func (p *Provisioner) runHotspot(ctx context.Context) error {
+	online := p.network.Online(ctx)
+	// the radio cannot scan while it is a hotspot, so the networks to offer are found first
+	networks, err := p.network.Networks(ctx)
+	if err != nil {
+		p.logger.Warnw("could not scan for networks", "error", err)
+	}
+
+	exit := make(chan struct{})
+	p.mu.Lock()
+	p.active = true
+	p.wasOnline = online
+	p.exit = exit
+	p.exitRequested = false
+	p.networks = networks
+	p.wifi = nil
+	p.cloud = nil
+	p.mu.Unlock()
+	defer func() {
+		p.mu.Lock()
+		p.active = false
+		p.mu.Unlock()
+	}()
+
+	ssid := p.hotspotSSID()
+	if err := p.network.StartHotspot(ctx, ssid, p.cfg.HotspotPassword); err != nil {
+		return err
+	}
+	defer func() {
+		// the hotspot is stopped even if ctx is done, so that it is not left up
+		stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := p.network.StopHotspot(stopCtx); err != nil {
+			p.logger.Warnw("could not stop hotspot", "error", err)
+		}
+	}()
+	p.logger.Infow("entered provisioning mode", "hotspot", ssid, "portal", p.cfg.PortalAddress, "grpc", p.cfg.GRPCAddress)
+
+	stopServers, err := p.startServers()
+	if err != nil {
+		return err
+	}
+	defer stopServers()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-exit:
+		return nil
+	}
+}
+
+func (p *Provisioner) startServers() (func(), error) {
+	portalListener, err := net.Listen("tcp", p.cfg.PortalAddress)
+	if err != nil {
+		return nil, err
+	}
+	grpcListener, err := net.Listen("tcp", p.cfg.GRPCAddress)
+	if err != nil {
+		goutils.UncheckedError(portalListener.Close())
+		return nil, err
+	}
+
+	portalServer := &http.Server{Handler: p.portalHandler(), ReadHeaderTimeout: 10 * time.Second}
+	grpcServer := googlegrpc.NewServer()
+	pb.RegisterProvisioningServiceServer(grpcServer, p)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	goutils.PanicCapturingGo(func() {
+		defer wg.Done()
+		if err := portalServer.Serve(portalListener); !errors.Is(err, http.ErrServerClosed) {
+			p.logger.Errorw("captive portal stopped", "error", err)
+		}
+	})
+	goutils.PanicCapturingGo(func() {
+		defer wg.Done()
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			p.logger.Errorw("provisioning API stopped", "error", err)
+		}
+	})
+	return func() {
+		goutils.UncheckedError(portalServer.Close())
+		grpcServer.Stop()
+		wg.Wait()
+	}, nil
+}
+
+func (p *Provisioner) hotspotSSID() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return p.cfg.HotspotPrefix
+	}
+	// SSIDs can be at most 32 bytes
+	ssid := p.cfg.HotspotPrefix + "-" + strings.ToLower(hostname)
+	return ssid[:min(len(ssid), 32)]
+}
+
+// connect joins the network and waits for it to reach the internet, recording the attempt for GetSmartMachineStatus.
+func (p *Provisioner) connect(ctx context.Context, wifi *wifiCredentials) error {
+	attempt := &pb.NetworkInfo{Type: wifiNetworkType, Ssid: wifi.ssid}
+	err := p.network.Connect(ctx, wifi.ssid, wifi.psk)
+	if err == nil && !p.waitOnline(ctx) {
+		err = errors.Errorf("joined %q but could not reach the internet", wifi.ssid)
+	}
+	if err != nil {
+		attempt.LastError = err.Error()
+		p.addError(err)
+	} else {
+		attempt.Connected = true
+	}
+	p.mu.Lock()
+	p.lastAttempt = attempt
+	p.mu.Unlock()
+	return err
+}
+
+func (p *Provisioner) waitOnline(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, p.onlineTimeout)
+	defer cancel()
+	for {
+		if p.network.Online(ctx) {
+			return true
+		}
+		if !goutils.SelectContextOrWait(ctx, time.Second) {
+			return false
+		}
+	}
+}
+
+func (p *Provisioner) addError(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.errors = append(p.errors, err.Error())
+}
+
+// setWiFi and setCloud store what provisioning mode was given, and exit it once the machine can be set up.
+// They return false if the machine is not in provisioning mode.
+func (p *Provisioner) setWiFi(wifi *wifiCredentials) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.active {
+		return false
+	}
+	p.wifi = wifi
+	p.exitIfReadyLocked()
+	return true
+}
+
+func (p *Provisioner) setCloud(cloud *pb.CloudConfig) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.active {
+		return false
+	}
+	p.cloud = cloud
+	p.exitIfReadyLocked()
+	return true
+}
+
+func (p *Provisioner) exitIfReadyLocked() {
+	hasCloud := p.cloud != nil || p.hasCloudCredentials()
+	hasNetwork := p.wifi != nil || p.wasOnline
+	if hasCloud && hasNetwork {
+		p.exitLocked()
+	}
+}
+
+func (p *Provisioner) exitLocked() {
+	select {
+	case <-p.exit:
+	default:
+		close(p.exit)
+	}
+}
+
+// viamConfigFile is the part of the machine's config file that provisioning reads and writes.
+type viamConfigFile struct {
+	Cloud *config.Cloud `json:"cloud"`
+}
+
+func (p *Provisioner) hasCloudCredentials() bool {
+	//nolint:gosec
+	data, err := os.ReadFile(p.viamConfigPath)
+	if err != nil {
+		return false
+	}
+	var cfg viamConfigFile
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return false
+	}
+	return cfg.Cloud != nil && cfg.Cloud.ID != "" && cfg.Cloud.Secret != ""
+}
+
+// writeCloudCredentials replaces the machine's config file with one for the cloud credentials.
+func (p *Provisioner) writeCloudCredentials(cloud *pb.CloudConfig) error {
+	appAddress := cloud.GetAppAddress()
+	if appAddress == "" {
+		appAddress = defaultAppAddress
+	}
+	data, err := json.MarshalIndent(map[string]interface{}{
+		"cloud": map[string]string{
+			"id":          cloud.GetId(),
+			"secret":      cloud.GetSecret(),
+			"app_address": appAddress,
+		},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p.viamConfigPath), 0o750); err != nil {
+		return err
+	}
+	// written to a temporary file first so that a crash cannot leave a partial config
+	tmpPath := p.viamConfigPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, p.viamConfigPath)
+}
@@ -0,0 +1,253 @@
+package provisioning
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pb "go.viam.com/api/provisioning/v1"
+	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
+
+	"go.viam.com/rdk/logging"
+	rpb "go.viam.com/rdk/proto/rdk/provisioning/v1"
+)
+
+type fakeNetworkManager struct {
+	mu        sync.Mutex
+	online    bool
+	hotspot   string
+	connected string
+	networks  []*pb.NetworkInfo
+}
+
+func (nm *fakeNetworkManager) Networks(ctx context.Context) ([]*pb.NetworkInfo, error) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	return nm.networks, nil
+}
+
+func (nm *fakeNetworkManager) Connect(ctx context.Context, ssid, psk string) error {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	nm.connected = ssid
+	nm.online = psk == "right password"
+	return nil
+}
+
+func (nm *fakeNetworkManager) StartHotspot(ctx context.Context, ssid, psk string) error {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	nm.hotspot = ssid
+	nm.online = false
+	return nil
+}
+
+func (nm *fakeNetworkManager) StopHotspot(ctx context.Context) error {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	nm.hotspot = ""
+	return nil
+}
+
+func (nm *fakeNetworkManager) Online(ctx context.Context) bool {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	return nm.online
+}
+
+func (nm *fakeNetworkManager) hotspotUp() bool {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	return nm.hotspot != ""
+}
+
+func newTestProvisioner(t *testing.T, nm NetworkManager) (*Provisioner, string) {
+	t.Helper()
+	cfg, err := ReadConfig(filepath.Join(t.TempDir(), "missing.json"))
+	test.That(t, err, test.ShouldBeNil)
+	cfg.PortalAddress = "localhost:0"
+	cfg.GRPCAddress = "localhost:0"
+	viamConfigPath := filepath.Join(t.TempDir(), "viam.json")
+	p := NewProvisioner(cfg, viamConfigPath, nm, logging.NewTestLogger(t))
+	p.onlineTimeout = time.Millisecond
+	return p, viamConfigPath
+}
+
+func TestParseNetworkList(t *testing.T) {
+	networks := parseNetworkList(strings.Join([]string{
+		`:home:WPA2:40`,
+		`*:home:WPA2:70`,
+		`:cafe\:guest::55`,
+		`::WPA2:90`,
+		`:bad:WPA2:strong`,
+	}, "\n"))
+	test.That(t, networks, test.ShouldHaveLength, 2)
+	test.That(t, networks[0].Ssid, test.ShouldEqual, "home")
+	test.That(t, networks[0].Signal, test.ShouldEqual, 70)
+	test.That(t, networks[0].Connected, test.ShouldBeTrue)
+	test.That(t, networks[1].Ssid, test.ShouldEqual, "cafe:guest")
+	test.That(t, networks[1].Security, test.ShouldBeEmpty)
+}
+
+func TestValidateSSID(t *testing.T) {
+	test.That(t, validateSSID("factory-wifi"), test.ShouldBeNil)
+	test.That(t, validateSSID("café wifi"), test.ShouldBeNil)
+	for _, ssid := range []string{"", "--help", "-x", "wifi\nname", strings.Repeat("a", 33)} {
+		test.That(t, validateSSID(ssid), test.ShouldNotBeNil)
+	}
+
+	p, _ := newTestProvisioner(t, &fakeNetworkManager{online: true})
+	_, err := p.SetNetworkCredentials(context.Background(), &pb.SetNetworkCredentialsRequest{Ssid: "--ask"})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "dash")
+}
+
+func TestFirstBootProvisioning(t *testing.T) {
+	ctx := context.Background()
+	nm := &fakeNetworkManager{networks: []*pb.NetworkInfo{{Type: wifiNetworkType, Ssid: "factory-wifi", Signal: 80}}}
+	p, viamConfigPath := newTestProvisioner(t, nm)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- p.WaitUntilProvisioned(ctx)
+	}()
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, nm.hotspotUp(), test.ShouldBeTrue)
+	})
+
+	// the captive portal lists the networks found before the hotspot started
+	rec := httptest.NewRecorder()
+	p.portalHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/generate_204", nil))
+	test.That(t, rec.Code, test.ShouldEqual, http.StatusFound)
+	rec = httptest.NewRecorder()
+	p.portalHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	test.That(t, rec.Body.String(), test.ShouldContainSubstring, "factory-wifi")
+	test.That(t, rec.Body.String(), test.ShouldContainSubstring, "viam_config")
+
+	form := url.Values{"viam_config": {`{"cloud": {"id": "part-id", "secret": "part-secret"}}`}}
+	req := httptest.NewRequest(http.MethodPost, "/save", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	p.portalHandler().ServeHTTP(rec, req)
+	test.That(t, rec.Body.String(), test.ShouldContainSubstring, "Saved.")
+
+	status, err := p.GetSmartMachineStatus(ctx, &pb.GetSmartMachineStatusRequest{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, status.HasSmartMachineCredentials, test.ShouldBeTrue)
+	test.That(t, status.IsOnline, test.ShouldBeFalse)
+
+	// a network that cannot reach the internet sends the machine back into provisioning mode
+	_, err = p.SetNetworkCredentials(ctx, &pb.SetNetworkCredentialsRequest{Type: "wifi", Ssid: "factory-wifi", Psk: "wrong password"})
+	test.That(t, err, test.ShouldBeNil)
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		status, err := p.GetSmartMachineStatus(ctx, &pb.GetSmartMachineStatusRequest{})
+		test.That(tb, err, test.ShouldBeNil)
+		test.That(tb, status.GetLatestConnectionAttempt(), test.ShouldNotBeNil)
+		test.That(tb, status.GetLatestConnectionAttempt().GetLastError(), test.ShouldNotBeEmpty)
+		test.That(tb, nm.hotspotUp(), test.ShouldBeTrue)
+	})
+
+	networks, err := p.GetNetworkList(ctx, &pb.GetNetworkListRequest{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, networks.Networks, test.ShouldHaveLength, 1)
+
+	_, err = p.SetNetworkCredentials(ctx, &pb.SetNetworkCredentialsRequest{Type: "wifi", Ssid: "factory-wifi", Psk: "right password"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, <-done, test.ShouldBeNil)
+	test.That(t, nm.hotspotUp(), test.ShouldBeFalse)
+	test.That(t, nm.Online(ctx), test.ShouldBeTrue)
+
+	viamConfig, err := os.ReadFile(viamConfigPath)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, string(viamConfig), test.ShouldContainSubstring, `"part-secret"`)
+	test.That(t, string(viamConfig), test.ShouldContainSubstring, defaultAppAddress)
+	test.That(t, p.hasCloudCredentials(), test.ShouldBeTrue)
+
+	// a provisioned machine does not enter provisioning mode on boot
+	test.That(t, p.WaitUntilProvisioned(ctx), test.ShouldBeNil)
+	test.That(t, nm.hotspotUp(), test.ShouldBeFalse)
+}
+
+func TestReenterProvisioning(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	nm := &fakeNetworkManager{online: true}
+	p, viamConfigPath := newTestProvisioner(t, nm)
+	test.That(t, p.writeCloudCredentials(&pb.CloudConfig{Id: "part-id", Secret: "part-secret"}), test.ShouldBeNil)
+
+	_, err := p.SetSmartMachineCredentials(ctx, &pb.SetSmartMachineCredentialsRequest{
+		Cloud: &pb.CloudConfig{Id: "other-id", Secret: "other-secret"},
+	})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "provisioning mode")
+	_, err = p.ExitProvisioning(ctx, &pb.ExitProvisioningRequest{})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	changed := make(chan bool, 1)
+	go func() {
+		changed <- p.Run(ctx)
+	}()
+
+	_, err = p.EnterProvisioning(ctx, &rpb.EnterProvisioningRequest{})
+	test.That(t, err, test.ShouldBeNil)
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, nm.hotspotUp(), test.ShouldBeTrue)
+	})
+
+	// exiting without changes leaves the machine as it was
+	_, err = p.ExitProvisioning(ctx, &pb.ExitProvisioningRequest{})
+	test.That(t, err, test.ShouldBeNil)
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, nm.hotspotUp(), test.ShouldBeFalse)
+	})
+
+	// new cloud credentials make Run return so that the machine restarts with them
+	_, err = p.EnterProvisioning(ctx, &rpb.EnterProvisioningRequest{})
+	test.That(t, err, test.ShouldBeNil)
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, nm.hotspotUp(), test.ShouldBeTrue)
+	})
+	_, err = p.SetSmartMachineCredentials(ctx, &pb.SetSmartMachineCredentialsRequest{
+		Cloud: &pb.CloudConfig{Id: "other-id", Secret: "other-secret", AppAddress: "https://app.example.com:443"},
+	})
+	test.That(t, err, test.ShouldBeNil)
+	_, err = p.SetNetworkCredentials(ctx, &pb.SetNetworkCredentialsRequest{Ssid: "new-wifi", Psk: "right password"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, <-changed, test.ShouldBeTrue)
+	nm.mu.Lock()
+	test.That(t, nm.connected, test.ShouldEqual, "new-wifi")
+	nm.mu.Unlock()
+
+	viamConfig, err := os.ReadFile(viamConfigPath)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, string(viamConfig), test.ShouldContainSubstring, `"other-secret"`)
+	test.That(t, string(viamConfig), test.ShouldContainSubstring, "https://app.example.com:443")
+}
+
+func TestReadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "viam-provisioning.json")
+	test.That(t, os.WriteFile(path, []byte(`{"manufacturer": "acme", "hotspot_prefix": "acme-setup"}`), 0o600), test.ShouldBeNil)
+	cfg, err := ReadConfig(path)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cfg.Manufacturer, test.ShouldEqual, "acme")
+	test.That(t, cfg.HotspotPrefix, test.ShouldEqual, "acme-setup")
+	test.That(t, cfg.HotspotPassword, test.ShouldEqual, "viamsetup")
+
+	test.That(t, os.WriteFile(path, []byte(`{"hotspot_password": "short"}`), 0o600), test.ShouldBeNil)
+	_, err = ReadConfig(path)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "hotspot_password")
+}
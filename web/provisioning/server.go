@@ -0,0 +1,105 @@
+package provisioning
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+	pb "go.viam.com/api/provisioning/v1"
+
+	rpb "go.viam.com/rdk/proto/rdk/provisioning/v1"
+)
+
+// GetSmartMachineStatus returns what the machine has been set up with so far.
+func (p *Provisioner) GetSmartMachineStatus(
+	ctx context.Context, req *pb.GetSmartMachineStatusRequest,
+) (*pb.GetSmartMachineStatusResponse, error) {
+	online := p.network.Online(ctx)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return &pb.GetSmartMachineStatusResponse{
+		ProvisioningInfo: &pb.ProvisioningInfo{
+			FragmentId:   p.cfg.FragmentID,
+			Model:        p.cfg.Model,
+			Manufacturer: p.cfg.Manufacturer,
+		},
+		HasSmartMachineCredentials: p.cloud != nil || p.hasCloudCredentials(),
+		IsOnline:                   online,
+		LatestConnectionAttempt:    p.lastAttempt,
+		Errors:                     slices.Clone(p.errors),
+	}, nil
+}
+
+// SetNetworkCredentials sets the WiFi network for the machine to join. In provisioning mode it is joined
+// on leaving it, otherwise it is joined right away.
+func (p *Provisioner) SetNetworkCredentials(
+	ctx context.Context, req *pb.SetNetworkCredentialsRequest,
+) (*pb.SetNetworkCredentialsResponse, error) {
+	if req.GetType() != wifiNetworkType && req.GetType() != "" {
+		return nil, errors.Errorf("unsupported network type %q", req.GetType())
+	}
+	if err := validateSSID(req.GetSsid()); err != nil {
+		return nil, err
+	}
+	wifi := &wifiCredentials{ssid: req.GetSsid(), psk: req.GetPsk()}
+	if p.setWiFi(wifi) {
+		return &pb.SetNetworkCredentialsResponse{}, nil
+	}
+	if err := p.connect(ctx, wifi); err != nil {
+		return nil, err
+	}
+	return &pb.SetNetworkCredentialsResponse{}, nil
+}
+
+// SetSmartMachineCredentials sets the machine's cloud credentials, which can only be done in provisioning mode.
+func (p *Provisioner) SetSmartMachineCredentials(
+	ctx context.Context, req *pb.SetSmartMachineCredentialsRequest,
+) (*pb.SetSmartMachineCredentialsResponse, error) {
+	cloud := req.GetCloud()
+	if cloud.GetId() == "" || cloud.GetSecret() == "" {
+		return nil, errors.New("cloud credentials must have an id and secret")
+	}
+	if !p.setCloud(cloud) {
+		return nil, errors.New("cloud credentials can only be set in provisioning mode")
+	}
+	return &pb.SetSmartMachineCredentialsResponse{}, nil
+}
+
+// GetNetworkList returns the WiFi networks in range. In provisioning mode they are the ones found on entering it,
+// since the machine cannot scan while it is a hotspot.
+func (p *Provisioner) GetNetworkList(ctx context.Context, req *pb.GetNetworkListRequest) (*pb.GetNetworkListResponse, error) {
+	p.mu.Lock()
+	active, networks := p.active, p.networks
+	p.mu.Unlock()
+	if !active {
+		var err error
+		if networks, err = p.network.Networks(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.GetNetworkListResponse{Networks: networks}, nil
+}
+
+// ExitProvisioning leaves provisioning mode with whatever it has been given.
+func (p *Provisioner) ExitProvisioning(ctx context.Context, req *pb.ExitProvisioningRequest) (*pb.ExitProvisioningResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.active {
+		return nil, errors.New("machine is not in provisioning mode")
+	}
+	p.exitRequested = true
+	p.exitLocked()
+	return &pb.ExitProvisioningResponse{}, nil
+}
+
+// EnterProvisioning asks Run to put the machine into provisioning mode. It returns before the hotspot is up.
+func (p *Provisioner) EnterProvisioning(
+	ctx context.Context, req *rpb.EnterProvisioningRequest,
+) (*rpb.EnterProvisioningResponse, error) {
+	p.logger.Info("entering provisioning mode by request")
+	select {
+	case p.enter <- struct{}{}:
+	default:
+	}
+	return &rpb.EnterProvisioningResponse{}, nil
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"os/signal"
 	"path"
 	"path/filepath"
 	"runtime"
@@ -34,6 +35,7 @@ import (
 	weboptions "go.viam.com/rdk/robot/web/options"
 	rutils "go.viam.com/rdk/utils"
 	nc "go.viam.com/rdk/web/networkcheck"
+	"go.viam.com/rdk/web/provisioning"
 )
 
 var viamDotDir = filepath.Join(rutils.PlatformHomeDir(), ".viam")
@@ -57,13 +59,19 @@ type Arguments struct {
 	OutputLogFile              string `flag:"log-file,usage=write logs to a file with log rotation"`
 	NoTLS                      bool   `flag:"no-tls,usage=starts an insecure http server without TLS certificates even if one exists"`
 	NetworkCheckOnly           bool   `flag:"network-check,usage=only runs normal network checks, logs results, and exits"`
+	Provisioning               bool   `flag:"provisioning,usage=set up wifi and cloud credentials through a hotspot when the machine has none"`
 }
 
 type robotServer struct {
-	args     Arguments
-	logger   logging.Logger
-	registry *logging.Registry
-	conn     rpc.ClientConn
+	args        Arguments
+	logger      logging.Logger
+	registry    *logging.Registry
+	conn        rpc.ClientConn
+	provisioner *provisioning.Provisioner
+
+	// sectionReloads carries the config sections requested to be reloaded through the web
+	// server, to the config watcher.
+	sectionReloads chan config.Section
 }
 
 func logViamEnvVariables(logger logging.Logger) {
@@ -181,6 +189,33 @@ func RunServer(ctx context.Context, args []string, _ logging.Logger) (err error)
 		defer pprof.StopCPUProfile()
 	}
 
+	// provisioning comes before reading the config, since it is what writes the config on first boot
+	var provisioner *provisioning.Provisioner
+	if argsParsed.Provisioning {
+		provisioningCfg, err := provisioning.ReadConfig(provisioning.DefaultConfigPath)
+		if err != nil {
+			return err
+		}
+		provisioner = provisioning.NewProvisioner(
+			provisioningCfg,
+			argsParsed.ConfigFile,
+			provisioning.NewNMCLINetworkManager(provisioningCfg.HotspotInterface),
+			logger.Sublogger("provisioning"),
+		)
+		if err := provisioner.WaitUntilProvisioned(ctx); err != nil {
+			return err
+		}
+		var cancelServer func()
+		ctx, cancelServer = context.WithCancel(ctx)
+		defer cancelServer()
+		utils.PanicCapturingGo(func() {
+			if provisioner.Run(ctx) {
+				logger.Info("machine was given new cloud credentials, exiting to be restarted with them")
+				cancelServer()
+			}
+		})
+	}
+
 	var appConn rpc.ClientConn
 
 	// Read the config from disk and use it to initialize the remote logger.
@@ -204,8 +239,17 @@ func RunServer(ctx context.Context, args []string, _ logging.Logger) (err error)
 	// serialized manner
 	if cfgFromDisk.Cloud != nil {
 		cloud := cfgFromDisk.Cloud
+		// the connection to app, which signaling shares, goes through the cloud config's proxy
+		appCtx, err := cloud.ContextWithProxy(ctx)
+		if err != nil {
+			return err
+		}
+		networkOpts, err := cloud.DialOptions()
+		if err != nil {
+			return err
+		}
 		appConnLogger := logger.Sublogger("networking").Sublogger("app_connection")
-		appConn, err = grpc.NewAppConn(ctx, cloud.AppAddress, cloud.Secret, cloud.ID, appConnLogger)
+		appConn, err = grpc.NewAppConn(appCtx, cloud.AppAddress, cloud.Secret, cloud.ID, appConnLogger, networkOpts...)
 		if err != nil {
 			return err
 		}
@@ -240,10 +284,13 @@ func RunServer(ctx context.Context, args []string, _ logging.Logger) (err error)
 	go nc.RunNetworkChecks(ctx, logger, true /* continueRunningTestDNS */)
 
 	server := robotServer{
-		logger:   logger,
-		args:     argsParsed,
-		registry: registry,
-		conn:     appConn,
+		logger:      logger,
+		args:        argsParsed,
+		registry:    registry,
+		conn:        appConn,
+		provisioner: provisioner,
+
+		sectionReloads: make(chan config.Section, 1),
 	}
 
 	// Run the server with remote logging enabled.
@@ -286,10 +333,14 @@ func (s *robotServer) createWebOptions(cfg *config.Config) (weboptions.Options,
 	options.PreferWebRTC = s.args.WebRTC
 	options.DisableMulticastDNS = s.args.DisableMulticastDNS
 	options.NoTLS = s.args.NoTLS || cfg.Network.NoTLS
+	options.ReloadConfigSection = s.requestSectionReload
 	if cfg.Cloud != nil && s.args.AllowInsecureCreds {
 		options.SignalingDialOpts = append(options.SignalingDialOpts, rpc.WithAllowInsecureWithCredentialsDowngrade())
 	}
 	options.SignalingDialOpts = append(options.SignalingDialOpts, rpc.WithSignalingConn(s.conn))
+	if s.provisioner != nil {
+		options.Provisioning = s.provisioner
+	}
 
 	if len(options.Auth.Handlers) == 0 {
 		host, _, err := net.SplitHostPort(cfg.Network.BindAddress)
@@ -336,6 +387,24 @@ func (s *robotServer) configWatcher(ctx context.Context, currCfg *config.Config,
 	startTime := time.Now()
 	r.Reconfigure(ctx, currCfg)
 	s.logger.CInfow(ctx, "Robot reconfigured with full config", "time_to_reconfigure", time.Since(startTime).String())
+
+	// Operators can signal the server, or make a request to its web server, to reload a single
+	// section of the config, which is applied without reconfiguring any resources.
+	reloadSignals := reloadSectionSignals()
+	reloadCh := make(chan os.Signal, 1)
+	for sig := range reloadSignals {
+		signal.Notify(reloadCh, sig)
+	}
+	defer signal.Stop(reloadCh)
+	reloadSection := func(section config.Section) {
+		nextCfg, err := s.reloadConfigSection(ctx, currCfg, r, section)
+		if err != nil {
+			s.logger.Errorw("config section reload aborted", "section", section, "error", err)
+			return
+		}
+		currCfg = nextCfg
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -345,6 +414,13 @@ func (s *robotServer) configWatcher(ctx context.Context, currCfg *config.Config,
 		select {
 		case <-ctx.Done():
 			return
+		case sig := <-reloadCh:
+			section := reloadSignals[sig]
+			s.logger.Infow("reloading config section", "section", section, "signal", sig.String())
+			reloadSection(section)
+		case section := <-s.sectionReloads:
+			s.logger.Infow("reloading config section", "section", section, "trigger", "web request")
+			reloadSection(section)
 		case cfg := <-watcher.Config():
 			processedConfig, err := s.processConfig(cfg)
 			if err != nil {
@@ -414,6 +490,62 @@ func (s *robotServer) configWatcher(ctx context.Context, currCfg *config.Config,
 	}
 }
 
+// requestSectionReload schedules the config watcher to reload a section of the config. It does
+// not wait for the reload, which may restart the web server that the request came through.
+func (s *robotServer) requestSectionReload(ctx context.Context, section config.Section) error {
+	select {
+	case s.sectionReloads <- section:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reloadConfigSection re-reads the config and applies only the given section of it to the
+// running server, leaving resources untouched. It returns the current config with that section
+// replaced, which later config changes are compared against.
+func (s *robotServer) reloadConfigSection(
+	ctx context.Context, currCfg *config.Config, r robot.LocalRobot, section config.Section,
+) (*config.Config, error) {
+	readCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+	cfg, err := config.Read(readCtx, s.args.ConfigFile, s.logger, s.conn)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading config")
+	}
+	processedConfig, err := s.processConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "error processing config")
+	}
+	nextCfg, err := config.ApplySection(currCfg, processedConfig, section)
+	if err != nil {
+		return nil, err
+	}
+
+	switch section {
+	case config.SectionNetwork:
+		// Keep a non-default BindAddress rather than replacing it with the default one, as the
+		// config watcher does.
+		if nextCfg.Network.BindAddressDefaultSet && !currCfg.Network.BindAddressDefaultSet {
+			nextCfg.Network.BindAddress = currCfg.Network.BindAddress
+			nextCfg.Network.BindAddressDefaultSet = false
+		}
+		options, err := s.createWebOptions(nextCfg)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating weboptions")
+		}
+		r.StopWeb()
+		if err := r.StartWeb(ctx, options); err != nil {
+			// the web service is stopped either way, so the new network config is in effect
+			s.logger.Errorw("error starting web service while reloading network config", "error", err)
+		}
+		s.logger.Info("web service restart finished")
+	case config.SectionLog:
+		config.UpdateLoggerRegistryFromConfig(s.registry, nextCfg, s.logger)
+	}
+	return nextCfg, nil
+}
+
 func (s *robotServer) serveWeb(ctx context.Context, cfg *config.Config) (err error) {
 	ctx, cancel := context.WithCancel(ctx)
 
@@ -551,6 +683,13 @@ func (s *robotServer) serveWeb(ctx context.Context, cfg *config.Config) (err err
 		robotOptions = append(robotOptions, robotimpl.WithFTDC())
 	}
 
+	historyID := "local-config"
+	if fullProcessedConfig.Cloud != nil {
+		historyID = fullProcessedConfig.Cloud.ID
+	}
+	robotOptions = append(robotOptions,
+		robotimpl.WithConfigHistory(config.DefaultHistoryDirectory(historyID), config.DefaultHistorySize))
+
 	// Create `minimalProcessedConfig`, a copy of `fullProcessedConfig`. Remove
 	// all components, services, remotes, modules, processes, packages, and jobs from
 	// `minimalProcessedConfig`. Create new robot with `minimalProcessedConfig`
@@ -0,0 +1,16 @@
+//go:build !unix
+
+package server
+
+import (
+	"os"
+
+	"go.viam.com/rdk/config"
+)
+
+// reloadSectionSignals returns the signals that reload a single section of the config. There
+// are none on platforms without user defined signals, where sections can only be reloaded with
+// the web server's `/reload_config/<section>` endpoint.
+func reloadSectionSignals() map[os.Signal]config.Section {
+	return nil
+}
@@ -0,0 +1,18 @@
+//go:build unix
+
+package server
+
+import (
+	"os"
+	"syscall"
+
+	"go.viam.com/rdk/config"
+)
+
+// reloadSectionSignals returns the signals that reload a single section of the config.
+func reloadSectionSignals() map[os.Signal]config.Section {
+	return map[os.Signal]config.Section{
+		syscall.SIGUSR1: config.SectionNetwork,
+		syscall.SIGUSR2: config.SectionLog,
+	}
+}